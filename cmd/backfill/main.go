@@ -0,0 +1,104 @@
+// Package main provides the RAG backfill tool.
+//
+// backfill embeds the distinct job descriptions and study case briefs of
+// past evaluation tasks into the RAG collections, bootstrapping retrieval
+// for deployments that ran before the collections were seeded:
+//
+//	go run ./cmd/backfill -since 2025-06-01
+//
+// Tasks are read from the jobs table with the environment's DB_URL, so every
+// job still within DATA_RETENTION_DAYS is found. Reruns overwrite the points they
+// wrote before.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/freemodels"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/app"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/ragbackfill"
+)
+
+func main() {
+	since := flag.String("since", "", "only read tasks recorded on or after this date (YYYY-MM-DD)")
+	tenant := flag.String("tenant", "", "only read tasks of this tenant")
+	jdCollection := flag.String("jd-collection", "job_description", "collection receiving job descriptions")
+	studyCaseCollection := flag.String("study-case-collection", "scoring_rubric", "collection receiving study case briefs")
+	minChars := flag.Int("min-chars", 40, "skip texts shorter than this")
+	batch := flag.Int("batch", 64, "tasks read and texts embedded per step")
+	dryRun := flag.Bool("dry-run", false, "only report what would be upserted")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("config load failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	slog.SetDefault(observability.SetupLogger(cfg))
+	if cfg.QdrantURL == "" {
+		slog.Error("QDRANT_URL is required")
+		os.Exit(1)
+	}
+	b := &ragbackfill.Backfiller{
+		JobDescriptionCollection: *jdCollection,
+		StudyCaseCollection:      *studyCaseCollection,
+		TenantID:                 *tenant,
+		MinChars:                 *minChars,
+		BatchSize:                *batch,
+		DryRun:                   *dryRun,
+	}
+	if *since != "" {
+		b.Since, err = time.Parse(time.DateOnly, *since)
+		if err != nil {
+			slog.Error("invalid -since", slog.String("since", *since), slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg, b); err != nil {
+		slog.Error("backfill failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg config.Config, b *ragbackfill.Backfiller) error {
+	pool, err := postgres.NewPoolWithConfig(ctx, cfg.DBURL, cfg.GetDBPoolConfig())
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	qc := cfg.GetQdrantCollectionConfig()
+	store := qdrantcli.New(cfg.QdrantURL, cfg.QdrantAPIKey).WithUpsertOptions(qdrantcli.UpsertOptions{
+		BatchSize:  cfg.QdrantUpsertBatchSize,
+		MaxRetries: cfg.QdrantUpsertMaxRetries,
+	}).WithVectorName(qc.VectorName).WithNamespace(qc.Namespace)
+	b.Source = postgres.NewJobRepo(pool)
+	b.Store = store
+	b.AI = freemodels.NewFreeModelWrapper(cfg)
+	if !b.DryRun {
+		// Create the default collections without seeding them.
+		app.EnsureCollections(ctx, store, nil, qc)
+	}
+
+	slog.Info("backfill starting", slog.Time("since", b.Since), slog.String("tenant", b.TenantID), slog.Bool("dry_run", b.DryRun))
+	res, err := b.Run(ctx)
+	if err != nil {
+		return err
+	}
+	slog.Info("backfill done", slog.Int("tasks", res.Tasks), slog.Int("duplicates", res.Duplicates), slog.Int("skipped", res.Skipped),
+		slog.Any("upserted", res.Upserted))
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package main provides the data export tool.
+//
+// export dumps the uploads, jobs, results and RAG corpus of an environment
+// to a portable archive that cmd/import restores elsewhere, e.g. to refresh
+// staging from production or to rehearse a disaster recovery:
+//
+//	go run ./cmd/export -out prod-20251201.tar.gz
+//
+// Rows are read with the environment's DB_URL and points, with their
+// vectors, from QDRANT_URL. The archive contains candidate documents, so
+// store and transfer it like a database backup.
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/portable"
+)
+
+func main() {
+	out := flag.String("out", "", "archive file to write (- for stdout)")
+	tables := flag.String("tables", strings.Join(postgres.PortableTables, ","), "comma-separated tables to export")
+	collections := flag.String("collections", "job_description,scoring_rubric", "comma-separated Qdrant collections to export (empty for none)")
+	batch := flag.Int("batch", 256, "points per scroll")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("config load failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	slog.SetDefault(observability.SetupLogger(cfg))
+	if *out == "" {
+		slog.Error("-out is required")
+		os.Exit(1)
+	}
+	exp := &portable.Exporter{TableNames: splitList(*tables), Collections: splitList(*collections), BatchSize: *batch}
+	if len(exp.Collections) > 0 && cfg.QdrantURL == "" {
+		slog.Error("QDRANT_URL is required to export collections")
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg, exp, *out); err != nil {
+		slog.Error("export failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg config.Config, exp *portable.Exporter, out string) (err error) {
+	pool, err := postgres.NewPoolWithConfig(ctx, cfg.DBURL, cfg.GetDBPoolConfig())
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	exp.Tables = postgres.NewPortableRepo(pool)
+	if cfg.QdrantURL != "" {
+		qc := cfg.GetQdrantCollectionConfig()
+		exp.Vectors = qdrantcli.New(cfg.QdrantURL, cfg.QdrantAPIKey).WithVectorName(qc.VectorName).WithNamespace(qc.Namespace)
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "-" {
+		f, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				_ = os.Remove(out)
+			}
+		}()
+		w = f
+	}
+	m, err := exp.Export(ctx, w)
+	if err != nil {
+		return err
+	}
+	slog.Info("export done", slog.String("out", out), slog.Any("tables", m.Tables), slog.Any("collections", m.Collections))
+	return nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+// Package main provides the Kubernetes manifest generator.
+//
+// genmanifests renders the ConfigMap, an example Secret, the server and
+// worker Deployments, the server Service and the autoscalers from the
+// configuration struct, so every variable read by config.Load appears in the
+// output:
+//
+//	go run ./cmd/genmanifests -out deploy/k8s -tag v1.4.0 -set CORS_ALLOW_ORIGINS=https://example.com
+//
+// Secrets (API keys, passwords, DB_URL, webhook URLs) are never written to the
+// ConfigMap; they are listed in secret.example.yaml instead.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/manifests"
+)
+
+// setFlags collects repeated -set NAME=VALUE flags.
+type setFlags map[string]string
+
+func (s setFlags) String() string { return fmt.Sprint(map[string]string(s)) }
+
+func (s setFlags) Set(v string) error {
+	name, value, ok := strings.Cut(v, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected NAME=VALUE, got %q", v)
+	}
+	s[name] = value
+	return nil
+}
+
+func main() {
+	opts := manifests.DefaultOptions()
+	out := flag.String("out", "deploy/k8s", "directory to write the manifests to")
+	flag.StringVar(&opts.Namespace, "namespace", opts.Namespace, "Kubernetes namespace")
+	flag.StringVar(&opts.Registry, "registry", opts.Registry, "image registry")
+	flag.StringVar(&opts.Tag, "tag", opts.Tag, "image tag")
+	flag.IntVar(&opts.ServerReplicas, "server-replicas", opts.ServerReplicas, "minimum server replicas")
+	flag.IntVar(&opts.ServerMaxReplicas, "server-max-replicas", opts.ServerMaxReplicas, "maximum server replicas")
+	flag.IntVar(&opts.WorkerReplicas, "worker-replicas", opts.WorkerReplicas, "minimum worker replicas")
+	flag.IntVar(&opts.WorkerMaxReplicas, "worker-max-replicas", opts.WorkerMaxReplicas, "maximum worker replicas")
+	flag.IntVar(&opts.TargetCPU, "target-cpu", opts.TargetCPU, "autoscaler CPU utilisation target in percent")
+	flag.Var(setFlags(opts.Env), "set", "override a ConfigMap value as NAME=VALUE (repeatable)")
+	flag.Parse()
+
+	files, err := manifests.Render(opts)
+	if err != nil {
+		slog.Error("render failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(*out, 0o750); err != nil {
+		slog.Error("create output directory failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	for _, f := range files {
+		path := filepath.Join(*out, f.Name)
+		if err := os.WriteFile(path, f.Body, 0o600); err != nil {
+			slog.Error("write manifest failed", slog.String("path", path), slog.Any("error", err))
+			os.Exit(1)
+		}
+		slog.Info("wrote manifest", slog.String("path", path))
+	}
+}
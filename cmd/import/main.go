@@ -0,0 +1,96 @@
+// Package main provides the data import tool.
+//
+// import restores an archive written by cmd/export into the environment
+// configured by DB_URL and QDRANT_URL:
+//
+//	go run ./cmd/import -in prod-20251201.tar.gz
+//
+// Run the database migrations first. Rows that already exist are kept as
+// they are and points are upserted by id, so an interrupted import can be
+// rerun with the same archive.
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/portable"
+)
+
+func main() {
+	in := flag.String("in", "", "archive file to read (- for stdin)")
+	skipVectors := flag.Bool("skip-vectors", false, "restore the database tables only")
+	batch := flag.Int("batch", 256, "points per upsert")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("config load failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	slog.SetDefault(observability.SetupLogger(cfg))
+	if *in == "" {
+		slog.Error("-in is required")
+		os.Exit(1)
+	}
+	if !*skipVectors && cfg.QdrantURL == "" {
+		slog.Error("QDRANT_URL is required unless -skip-vectors is set")
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg, *in, *skipVectors, *batch); err != nil {
+		slog.Error("import failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg config.Config, in string, skipVectors bool, batch int) error {
+	pool, err := postgres.NewPoolWithConfig(ctx, cfg.DBURL, cfg.GetDBPoolConfig())
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	qc := cfg.GetQdrantCollectionConfig()
+	imp := &portable.Importer{
+		Tables:         postgres.NewPortableRepo(pool),
+		VectorName:     qc.VectorName,
+		PayloadIndexes: qc.PayloadIndexes,
+		BatchSize:      batch,
+		SkipVectors:    skipVectors,
+	}
+	if !skipVectors {
+		imp.Vectors = qdrantcli.New(cfg.QdrantURL, cfg.QdrantAPIKey).WithUpsertOptions(qdrantcli.UpsertOptions{
+			BatchSize:  cfg.QdrantUpsertBatchSize,
+			MaxRetries: cfg.QdrantUpsertMaxRetries,
+		}).WithVectorName(qc.VectorName).WithNamespace(qc.Namespace)
+	}
+
+	var r io.Reader = os.Stdin
+	if in != "-" {
+		f, err := os.Open(in)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+	m, res, err := imp.Import(ctx, r)
+	if err != nil {
+		return err
+	}
+	slog.Info("import done", slog.String("in", in), slog.Time("archive_created_at", m.CreatedAt),
+		slog.Any("rows", res.Rows), slog.Any("skipped_rows", res.SkippedRows), slog.Any("points", res.Points))
+	return nil
+}
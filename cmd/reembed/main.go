@@ -0,0 +1,100 @@
+// Package main provides the embedding model migration tool.
+//
+// reembed copies every point of the RAG collections into a parallel
+// collection embedded with the configured (new) EMBEDDINGS_MODEL and then
+// switches the collection name to it through a Qdrant alias:
+//
+//	EMBEDDINGS_MODEL=text-embedding-3-large go run ./cmd/reembed
+//
+// Run it before rolling out services configured with the new model so that
+// query vectors and stored vectors always come from the same model.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/freemodels"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/reembed"
+)
+
+func main() {
+	collections := flag.String("collections", "job_description,scoring_rubric", "comma-separated logical collections to migrate")
+	model := flag.String("model", "", "embeddings model to migrate to (defaults to EMBEDDINGS_MODEL)")
+	suffix := flag.String("suffix", "", "suffix for the new collections (defaults to <model>_<timestamp>)")
+	batch := flag.Int("batch", 64, "points per scroll/embed/upsert step")
+	dropSource := flag.Bool("drop-source", false, "replace a concrete collection with an alias (brief search outage)")
+	deleteOld := flag.Bool("delete-old", false, "delete the previous collection after switching the alias")
+	dryRun := flag.Bool("dry-run", false, "only report what would be migrated")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("config load failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	slog.SetDefault(observability.SetupLogger(cfg))
+	if *model != "" {
+		cfg.EmbeddingsModel = *model
+	}
+	if cfg.QdrantURL == "" {
+		slog.Error("QDRANT_URL is required")
+		os.Exit(1)
+	}
+	if *suffix == "" {
+		*suffix = reembed.Suffix(cfg.EmbeddingsModel, time.Now())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	qc := cfg.GetQdrantCollectionConfig()
+	store := qdrantcli.New(cfg.QdrantURL, cfg.QdrantAPIKey).WithUpsertOptions(qdrantcli.UpsertOptions{
+		BatchSize:  cfg.QdrantUpsertBatchSize,
+		MaxRetries: cfg.QdrantUpsertMaxRetries,
+	}).WithVectorName(qc.VectorName).WithNamespace(qc.Namespace)
+	m := &reembed.Migrator{
+		Store:          store,
+		AI:             freemodels.NewFreeModelWrapper(cfg),
+		Suffix:         *suffix,
+		VectorName:     qc.VectorName,
+		PayloadIndexes: qc.PayloadIndexes,
+		BatchSize:      *batch,
+		DropSource:     *dropSource,
+		DeleteOld:      *deleteOld,
+		DryRun:         *dryRun,
+	}
+
+	slog.Info("reembed starting", slog.String("model", cfg.EmbeddingsModel), slog.String("suffix", *suffix), slog.Bool("dry_run", *dryRun))
+	failed := false
+	for _, name := range strings.Split(*collections, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		res, err := m.Migrate(ctx, name)
+		if err != nil {
+			failed = true
+			if errors.Is(err, reembed.ErrSourceNotAlias) {
+				slog.Error("reembed requires -drop-source for the first migration", slog.String("collection", name))
+			}
+			slog.Error("reembed failed", slog.String("collection", name), slog.String("target", res.Target), slog.Any("error", err))
+			continue
+		}
+		slog.Info("reembed done", slog.String("collection", name), slog.String("source", res.Source), slog.String("target", res.Target),
+			slog.Int("migrated", res.Migrated), slog.Int("skipped", res.Skipped), slog.Bool("switched", res.Switched))
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -9,22 +9,29 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
 
-	ai "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	aiadapter "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/freemodels"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/promptguard"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/safety"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/archive"
 	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/queue/redpanda"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/s3"
 	tikaext "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/textextractor/tika"
 	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/app"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
 )
 
@@ -32,6 +39,28 @@ import (
 type poolAdapter struct{ *pgxpool.Pool }
 type txAdapter struct{ pgx.Tx }
 
+// newArchiveSink builds the cold-storage sink selected by ARCHIVE_SINK.
+func newArchiveSink(c config.ArchiveConfig) (app.SnapshotSink, error) {
+	switch c.Sink {
+	case config.ArchiveSinkFile:
+		return archive.NewFileSink(c.Dir), nil
+	case config.ArchiveSinkS3:
+		if c.S3Bucket == "" {
+			return nil, fmt.Errorf("ARCHIVE_S3_BUCKET is required for the s3 archive sink")
+		}
+		return archive.NewS3Sink(archive.S3Config{
+			Endpoint:        c.S3Endpoint,
+			Region:          c.S3Region,
+			Bucket:          c.S3Bucket,
+			Prefix:          c.S3Prefix,
+			AccessKeyID:     c.S3AccessKeyID,
+			SecretAccessKey: c.S3SecretAccessKey,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown ARCHIVE_SINK %q", c.Sink)
+	}
+}
+
 func (p poolAdapter) Begin(ctx context.Context) (postgres.Tx, error) {
 	tx, err := p.Pool.Begin(ctx)
 	if err != nil {
@@ -52,7 +81,7 @@ func main() {
 		panic(err)
 	}
 
-	logger := observability.SetupLogger(cfg)
+	logger := obsctx.WithRequestIDs(observability.SetupLogger(cfg))
 	slog.SetDefault(logger)
 
 	// Configure observability with the current environment so that
@@ -68,70 +97,178 @@ func main() {
 	if err != nil {
 		slog.Error("failed to setup tracing", slog.Any("error", err))
 	}
-	defer func() {
-		if shutdownTracer != nil {
-			_ = shutdownTracer(context.Background())
-		}
-	}()
 
-	// Infra: DB pool
-	ctx := context.Background()
-	pool, err := postgres.NewPool(ctx, cfg.DBURL)
+	// Infra: DB pool. ctx is cancelled once HTTP requests are drained to stop
+	// background loops.
+	ctx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	pool, err := postgres.NewPoolWithConfig(ctx, cfg.DBURL, cfg.GetDBPoolConfig())
 	if err != nil {
 		slog.Error("db connect failed", slog.Any("error", err))
 		os.Exit(1)
 	}
+	prometheus.MustRegister(postgres.NewPoolCollector(pool, "server"))
 
 	// Repositories
 	upRepo := postgres.NewUploadRepo(pool)
 	jobRepo := postgres.NewJobRepo(pool)
 	resRepo := postgres.NewResultRepo(pool)
 
+	// Partition maintenance: make sure upcoming monthly partitions exist
+	// before any writes and keep creating them ahead, and optionally archive
+	// expired ones on cleanup.
+	archiveCfg := cfg.GetArchiveConfig()
+	partitions := postgres.NewPartitionManager(pool, nil, nil, archiveCfg.PremakeMonths)
+	if archiveCfg.Enabled {
+		sink, err := newArchiveSink(archiveCfg)
+		if err != nil {
+			slog.Error("archive sink init failed", slog.Any("error", err))
+			os.Exit(1)
+		}
+		partitions.Copier = postgres.PoolCopier{Pool: pool}
+		partitions.Sink = sink
+	}
+	if err := partitions.EnsurePartitions(ctx, time.Now()); err != nil {
+		slog.Warn("partition maintenance failed", slog.Any("error", err))
+	}
+	go partitions.RunPeriodic(ctx, archiveCfg.MaintenanceInterval)
+
 	// Start cleanup service for data retention
 	if cfg.DataRetentionDays > 0 {
 		cleanupSvc := postgres.NewCleanupService(poolAdapter{pool}, cfg.DataRetentionDays)
+		cleanupSvc.Partitions = partitions
+		cleanupSvc.AccessLogRetentionDays = cfg.AccessLogRetentionDays
 		go cleanupSvc.RunPeriodic(ctx, cfg.CleanupInterval)
 		slog.Info("cleanup service started", slog.Int("retention_days", cfg.DataRetentionDays), slog.Duration("interval", cfg.CleanupInterval))
 	}
 
+	// Keep the evaluation topic's settings in line with the configuration
+	app.ReconcileTopics(ctx, cfg)
+
 	// Queue client (Redpanda producer)
+	payloadCipher, err := app.BuildPayloadCipher(cfg)
+	if err != nil {
+		slog.Error("invalid queue encryption config", slog.Any("error", err))
+		os.Exit(1)
+	}
 	qClient, err := redpanda.NewProducer(cfg.KafkaBrokers)
 	if err != nil {
 		slog.Error("redpanda producer connect failed", slog.Any("error", err))
 		os.Exit(1)
 	}
-	defer func() {
-		if err := qClient.Close(); err != nil {
-			slog.Error("failed to close queue client", slog.Any("error", err))
-		}
-	}()
+	qClient.WithPayloadCipher(payloadCipher)
 
 	// AI client: always use free models for cost-effective operation.
 	// Global Redis/Postgres rate limiting has been removed; the AI client now
 	// relies solely on provider headers and its in-process rate limit cache for
 	// cooldown behavior.
-	freeModelWrapper := freemodels.NewFreeModelWrapper(cfg)
+	keySecrets, err := app.BuildProviderKeySecrets(cfg)
+	if err != nil {
+		slog.Error("invalid provider key encryption config", slog.Any("error", err))
+		os.Exit(1)
+	}
+	providerKeys := postgres.NewProviderKeyRepo(pool, keySecrets)
+	// Seal secrets stored in plaintext or under a retired key.
+	if n, err := providerKeys.Reseal(ctx); err != nil {
+		slog.Error("provider key reseal failed", slog.Any("error", err))
+	} else if n > 0 {
+		slog.Info("provider key secrets resealed", slog.Int("keys", n))
+	}
+	keyRing := app.BuildKeyRing(ctx, cfg, providerKeys, postgres.NewKeyUsageRepo(pool))
+	modelLimits := app.BuildModelLimits(ctx, cfg, postgres.NewModelLimitRepo(pool))
+	// Failed provider calls are fingerprinted and stored for the admin error report.
+	providerErrors := aiadapter.NewProviderErrorLog(postgres.NewProviderErrorRepo(pool))
+	freeModelWrapper := freemodels.NewFreeModelWrapper(cfg).WithKeyRing(keyRing).WithModelLimits(modelLimits).WithProviderErrors(providerErrors)
 	slog.Info("AI client initialized with free models support")
 
 	// AI client is ready for use
 	slog.Info("AI client initialized successfully")
 	// Embedding cache wrapper (safe for accuracy; caches embeddings only)
-	aicl := ai.NewEmbedCache(freeModelWrapper, cfg.EmbedCacheSize)
+	aicl, closeEmbedCache := app.BuildEmbedCache(context.Background(), cfg, app.BuildAISimulation(cfg, freeModelWrapper))
+	defer closeEmbedCache()
 	// Qdrant client (shared)
 	var qcli *qdrantcli.Client
 	if cfg.QdrantURL != "" {
-		qcli = qdrantcli.New(cfg.QdrantURL, cfg.QdrantAPIKey)
+		qc := cfg.GetQdrantCollectionConfig()
+		qcli = qdrantcli.New(cfg.QdrantURL, cfg.QdrantAPIKey).WithUpsertOptions(qdrantcli.UpsertOptions{
+			BatchSize:  cfg.QdrantUpsertBatchSize,
+			MaxRetries: cfg.QdrantUpsertMaxRetries,
+		}).WithVectorName(qc.VectorName).WithNamespace(qc.Namespace)
+	}
+	switch {
+	case cfg.RunsWorker():
+		slog.Info("all-in-one mode - worker runs in the server process")
+	case strings.EqualFold(strings.TrimSpace(cfg.RunMode), config.RunModeServer):
+		slog.Info("server-only mode - worker runs in separate container")
+	default:
+		slog.Error("unknown RUN_MODE", slog.String("run_mode", cfg.RunMode))
+		os.Exit(1)
 	}
-	// Note: Worker is now running in a separate container
-	slog.Info("server-only mode - worker runs in separate container")
 
 	// Usecases
 	uploadSvc := usecase.NewUploadService(upRepo)
+	uploadSvc.QualityGate = cfg.UploadQualityGate
+	uploadSvc.MinChars = cfg.UploadMinChars
 	evalSvc := usecase.NewEvaluateServiceWithHealthChecks(jobRepo, qClient, upRepo, aicl, qcli)
+	evalSvc.JobTTL = cfg.JobTTL
+	evalSvc.SLA = cfg.EvaluationSLA
+	// The outbox relay publishes released deferred evaluations and tasks left
+	// behind by crashed servers. With OUTBOX_ENABLED, new jobs are created
+	// together with their task in the outbox as well.
+	outbox := usecase.NewOutboxService(postgres.NewOutboxRepo(pool), qClient, cfg.OutboxBatchSize, cfg.OutboxRetention)
+	outbox.MaxAttempts = cfg.OutboxMaxAttempts
+	go outbox.Run(ctx, cfg.OutboxPollInterval)
+	if cfg.OutboxEnabled {
+		evalSvc.Outbox = outbox
+	}
+	// Maintenance mode rejects or defers new evaluations during worker upgrades.
+	maintenanceRepo := postgres.NewMaintenanceRepo(pool)
+	maintenance := usecase.NewMaintenanceService(maintenanceRepo, outbox, cfg.MaintenanceRetryAfter)
+	if err := maintenance.Sync(ctx); err != nil {
+		slog.Warn("maintenance state load failed; assuming off", slog.Any("error", err))
+	}
+	go maintenance.Run(ctx, cfg.MaintenanceSyncPeriod)
+	evalSvc.Maintenance = maintenance
+	// Backpressure rejects or defers new evaluations while the backlog is full;
+	// deferred ones are released by the maintenance loop as it drains.
+	backpressure := usecase.NewBackpressureService(jobRepo, maintenanceRepo, cfg.BackpressureMaxPending, cfg.BackpressureMode, cfg.BackpressureRetryAfter)
+	evalSvc.Backpressure = backpressure
+	maintenance.Backpressure = backpressure
+	// Evaluations fail fast while the workers report every AI model blocked.
+	if cfg.AISaturationFailFast {
+		evalSvc.Saturation = usecase.NewAISaturationService(postgres.NewAISaturationRepo(pool))
+	}
+	// Per-tenant overrides keyed by the X-API-Key of evaluation requests.
+	tenants := usecase.NewTenantService(postgres.NewTenantSettingsRepo(pool))
+	evalSvc.Tenants = tenants
+	evalSvc.Quota = usecase.NewQuotaService(postgres.NewTenantQuotaRepo(pool), cfg.TenantQuotaWarnRatio)
 	resultSvc := usecase.NewResultService(jobRepo, resRepo)
+	resultSvc.StaleAfter = cfg.EvaluationSLA
+	// Deferred jobs and jobs behind a paused topic wait on purpose and are
+	// not reported stale.
+	consumerPauses := app.BuildConsumerPauses(ctx, cfg, pool)
+	resultSvc.Maintenance = maintenance
+	resultSvc.Pauses = consumerPauses
+	resultSvc.QueueTopic = redpanda.TopicEvaluate
+	resultSvc.Versions = resRepo
+	resultSvc.CacheMaxAge = cfg.ResultCacheMaxAge
+	// Queued jobs report their queue position; admins bump urgent ones.
+	jobQueue := usecase.NewJobQueueService(postgres.NewJobQueueRepo(pool), jobRepo, evalSvc.Outbox, cfg.QueueThroughputWindow)
+	resultSvc.Queue = jobQueue
 
 	// Bootstrap Qdrant collections (idempotent) and optional seeding
-	app.EnsureDefaultCollections(ctx, qcli, aicl)
+	app.EnsureCollections(ctx, qcli, aicl, cfg.GetQdrantCollectionConfig())
+
+	// All-in-one mode: consume evaluations in this process with the shared
+	// DB pool and AI client.
+	stopWorker := func() {}
+	if cfg.RunsWorker() {
+		stopWorker, err = app.StartWorker(ctx, cfg, app.WorkerDeps{Pool: pool, AI: aicl, Qdrant: qcli, KeyRing: keyRing, Saturation: freeModelWrapper})
+		if err != nil {
+			slog.Error("worker start failed", slog.Any("error", err))
+			os.Exit(1)
+		}
+	}
 
 	// Readiness checks (removed Redis check - using Redpanda now)
 	dbCheck, qdrantCheck, tikaCheck := app.BuildReadinessChecks(cfg, pool)
@@ -141,6 +278,75 @@ func main() {
 
 	// HTTP server
 	srv := httpserver.NewServer(cfg, uploadSvc, evalSvc, resultSvc, ext, dbCheck, qdrantCheck, tikaCheck)
+	srv.ProviderKeys = keyRing
+	srv.Maintenance = maintenance
+	srv.Tenants = tenants
+	srv.Experiments = usecase.NewPromptExperimentService(postgres.NewPromptExperimentRepo(pool), redpanda.HasPromptVersion)
+	srv.LegalHolds = usecase.NewLegalHoldService(postgres.NewLegalHoldRepo(pool))
+	srv.ConsumerPauses = consumerPauses
+	srv.JobQueue = jobQueue
+	srv.JobBulk = usecase.NewJobBulkService(postgres.NewJobBulkRepo(pool), jobRepo, evalSvc.Outbox, cfg.BulkJobMaxJobs)
+	srv.ProviderErrors = usecase.NewProviderErrorService(postgres.NewProviderErrorRepo(pool))
+	srv.JobUsage = usecase.NewJobUsageService(postgres.NewJobUsageRepo(pool))
+	// Past jobs are replayed from the task kept on the job, which only jobs
+	// created through the outbox or deferred record.
+	if evalSvc.Outbox != nil {
+		sandbox := redpanda.NewSandbox(aicl, qcli, promptguard.New(cfg.PromptInjectionMode), safety.New(cfg.OutputSafetyFilter), cfg.EvaluationSLA).
+			WithJDLanguage(cfg.JDLanguageMode)
+		srv.Replays = usecase.NewJobReplayService(jobRepo, upRepo, resRepo, sandbox)
+	}
+	if cfg.AccessLogEnabled {
+		srv.AccessLog = usecase.NewAccessLogService(postgres.NewAccessLogRepo(pool))
+	}
+	srv.Drainer = httpserver.NewDrainer()
+	srv.BiasAudit = usecase.NewBiasAuditService(postgres.NewBiasAuditRepo(pool), cfg.BiasAuditWindow, cfg.BiasAuditMinSegment)
+	srv.Summaries = usecase.NewCandidateSummaryService(jobRepo, resRepo, postgres.NewCandidateSummaryRepo(pool), aicl)
+	srv.Diffs = usecase.NewResultDiffService(jobRepo, resRepo)
+	// Qdrant snapshots of the RAG collections, on demand and on a schedule.
+	if qcli != nil {
+		snapCfg := cfg.GetQdrantSnapshotConfig()
+		var sink app.SnapshotSink
+		if snapCfg.Upload {
+			if sink, err = newArchiveSink(archiveCfg); err != nil {
+				slog.Error("snapshot sink init failed", slog.Any("error", err))
+				os.Exit(1)
+			}
+		}
+		if snapshots := app.NewQdrantSnapshotService(qcli, sink, snapCfg); snapshots != nil {
+			srv.Snapshots = snapshots
+			go snapshots.Run(ctx)
+		}
+	}
+	if cfg.StatsEnabled {
+		srv.UsageStats = usecase.NewUsageStatsService(postgres.NewReportRepo(pool), cfg.StatsWindow, cfg.StatsDeploymentID)
+	}
+
+	// S3 ingestion turns documents dropped into a bucket into evaluations.
+	if cfg.IngestS3Enabled {
+		store := s3.New(s3.Config{
+			Endpoint:    cfg.IngestS3Endpoint,
+			Region:      cfg.IngestS3Region,
+			Bucket:      cfg.IngestS3Bucket,
+			Credentials: s3.Credentials{AccessKeyID: cfg.IngestS3AccessKeyID, SecretAccessKey: cfg.IngestS3SecretAccessKey},
+		})
+		ingest := app.NewS3IngestScheduler(store, srv, evalSvc, jobRepo, app.S3IngestOptions{
+			Prefix:          cfg.IngestS3Prefix,
+			Interval:        cfg.IngestS3PollInterval,
+			PairWait:        cfg.IngestS3PairWait,
+			DeleteProcessed: cfg.IngestS3DeleteProcessed,
+			MaxBytes:        cfg.MaxUploadMB * 1024 * 1024,
+		})
+		go ingest.Run(ctx)
+	}
+
+	if cfg.InboundEmailEnabled {
+		mailer, err := app.NewMailer(cfg.GetMailConfig())
+		if err != nil {
+			slog.Error("invalid mail configuration", slog.Any("error", err))
+			os.Exit(1)
+		}
+		srv.InboundMailer = mailer
+	}
 
 	// Build router with API endpoints and admin authentication
 	handler := app.BuildRouter(cfg, srv)
@@ -173,7 +379,38 @@ func main() {
 		}
 	}
 
+	// Fail readiness, stop accepting requests and wait for in-flight uploads
+	// and evaluations to finish enqueueing before closing the producer.
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ServerShutdownTimeout)
 	defer cancel()
-	_ = srvHTTP.Shutdown(shutdownCtx)
+	rep := srv.Drainer.Drain(shutdownCtx, cfg.ServerDrainDelay, srvHTTP.Shutdown)
+	for _, kind := range []string{httpserver.RequestKindUpload, httpserver.RequestKindEvaluate, httpserver.RequestKindOther} {
+		if rep.InFlight[kind] == 0 {
+			continue
+		}
+		slog.Info("http requests drained",
+			slog.String("kind", kind),
+			slog.Int("drained", rep.Drained(kind)),
+			slog.Int("abandoned", rep.Abandoned[kind]))
+	}
+	if rep.Err != nil {
+		slog.Warn("http shutdown incomplete", slog.Duration("duration", rep.Duration), slog.Any("error", rep.Err))
+	} else {
+		slog.Info("http server drained", slog.Duration("duration", rep.Duration))
+	}
+	stopWorker()
+	stopBackground()
+
+	// Flushing gets its own budget in case draining used up the timeout.
+	flushCtx, cancelFlush := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelFlush()
+	if err := qClient.CloseContext(flushCtx); err != nil {
+		slog.Error("failed to close queue client", slog.Any("error", err))
+	}
+	if shutdownTracer != nil {
+		if err := shutdownTracer(flushCtx); err != nil {
+			slog.Warn("trace flush failed", slog.Any("error", err))
+		}
+	}
+	slog.Info("server stopped")
 }
@@ -2,18 +2,34 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/client"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// Container labels that opt a container into HTTP service discovery.
+const (
+	labelScrape = "prometheus.io/scrape"
+	labelPort   = "prometheus.io/port"
+	labelPath   = "prometheus.io/path"
+	labelJob    = "prometheus.io/job"
+)
+
+// healthStatuses are the Docker health states reported by
+// container_meta_health_status; "none" means no health check is configured.
+var healthStatuses = []string{"healthy", "unhealthy", "starting", "none"}
+
 var (
 	// Metric definition
 	containerMeta = prometheus.NewGaugeVec(
@@ -23,14 +39,112 @@ var (
 		},
 		[]string{"id", "name", "image", "com_docker_compose_service", "state", "full_id"},
 	)
+	containerHealth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "container_meta_health_status",
+			Help: "Container health check status (1 for the current status, 0 otherwise)",
+		},
+		[]string{"id", "name", "com_docker_compose_service", "status"},
+	)
+	containerRestarts = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "container_meta_restart_count",
+			Help: "Number of times Docker restarted the container",
+		},
+		[]string{"id", "name", "com_docker_compose_service"},
+	)
+	containerMemoryLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "container_meta_memory_limit_bytes",
+			Help: "Container memory limit in bytes (0 when unlimited)",
+		},
+		[]string{"id", "name", "com_docker_compose_service"},
+	)
+	containerCPULimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "container_meta_cpu_limit_cores",
+			Help: "Container CPU limit in cores (0 when unlimited)",
+		},
+		[]string{"id", "name", "com_docker_compose_service"},
+	)
+)
+
+// targetGroup is one entry of the Prometheus HTTP SD response.
+type targetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+var (
+	targetsMu sync.RWMutex
+	targets   = []targetGroup{}
 )
 
 func init() {
 	// Register metric with Prometheus
-	prometheus.MustRegister(containerMeta)
+	prometheus.MustRegister(containerMeta, containerHealth, containerRestarts, containerMemoryLimit, containerCPULimit)
+}
+
+// discoverTarget returns the scrape target of a running container that opted
+// in through the prometheus.io/* labels. The container name is used as host
+// since it resolves on the compose network.
+func discoverTarget(name, service, state string, labels map[string]string) (targetGroup, bool) {
+	if state != "running" || labels[labelScrape] != "true" || labels[labelPort] == "" {
+		return targetGroup{}, false
+	}
+	tg := targetGroup{
+		Targets: []string{name + ":" + labels[labelPort]},
+		Labels:  map[string]string{"container": name, "com_docker_compose_service": service},
+	}
+	if p := labels[labelPath]; p != "" {
+		tg.Labels["__metrics_path__"] = p
+	}
+	if j := labels[labelJob]; j != "" {
+		tg.Labels["job"] = j
+	}
+	return tg, true
+}
+
+// cpuLimit converts the CPU limit of hc to cores.
+func cpuLimit(hc *container.HostConfig) float64 {
+	switch {
+	case hc == nil:
+		return 0
+	case hc.NanoCPUs > 0:
+		return float64(hc.NanoCPUs) / 1e9
+	case hc.CPUQuota > 0 && hc.CPUPeriod > 0:
+		return float64(hc.CPUQuota) / float64(hc.CPUPeriod)
+	}
+	return 0
+}
+
+// recordInspect sets the health, restart and limit metrics of one container.
+func recordInspect(info types.ContainerJSON, shortID, name, service string) {
+	if info.ContainerJSONBase == nil {
+		return
+	}
+	status := "none"
+	if info.State != nil && info.State.Health != nil && info.State.Health.Status != "" {
+		status = info.State.Health.Status
+	}
+	for _, s := range healthStatuses {
+		v := 0.0
+		if s == status {
+			v = 1
+		}
+		containerHealth.WithLabelValues(shortID, name, service, s).Set(v)
+	}
+	containerRestarts.WithLabelValues(shortID, name, service).Set(float64(info.RestartCount))
+	if info.HostConfig != nil {
+		containerMemoryLimit.WithLabelValues(shortID, name, service).Set(float64(info.HostConfig.Memory))
+	}
+	containerCPULimit.WithLabelValues(shortID, name, service).Set(cpuLimit(info.HostConfig))
 }
 
 func collectMetrics() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
 	// Initialize Docker client
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
@@ -40,15 +154,20 @@ func collectMetrics() {
 	defer cli.Close()
 
 	// Get list of all containers
-	containers, err := cli.ContainerList(context.Background(), container.ListOptions{All: true})
+	containers, err := cli.ContainerList(ctx, container.ListOptions{All: true})
 	if err != nil {
 		log.Printf("Error listing containers: %v", err)
 		return
 	}
 
-	// Reset metric to clear old data
+	// Reset metrics to clear old data
 	containerMeta.Reset()
+	containerHealth.Reset()
+	containerRestarts.Reset()
+	containerMemoryLimit.Reset()
+	containerCPULimit.Reset()
 
+	discovered := []targetGroup{}
 	for _, container := range containers {
 		// Extract IDs
 		fullID := container.ID
@@ -80,6 +199,34 @@ func collectMetrics() {
 			container.State,
 			fullID,
 		).Set(1)
+
+		if tg, ok := discoverTarget(name, service, container.State, container.Labels); ok {
+			discovered = append(discovered, tg)
+		}
+
+		// Health, restarts and limits are only available from inspect.
+		info, err := cli.ContainerInspect(ctx, fullID)
+		if err != nil {
+			log.Printf("Error inspecting container %s: %v", name, err)
+			continue
+		}
+		recordInspect(info, shortID, name, service)
+	}
+
+	sort.Slice(discovered, func(i, j int) bool { return discovered[i].Targets[0] < discovered[j].Targets[0] })
+	targetsMu.Lock()
+	targets = discovered
+	targetsMu.Unlock()
+}
+
+// targetsHandler serves the discovered targets in the Prometheus HTTP SD
+// format.
+func targetsHandler(w http.ResponseWriter, _ *http.Request) {
+	targetsMu.RLock()
+	defer targetsMu.RUnlock()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(targets); err != nil {
+		log.Printf("Error encoding targets: %v", err)
 	}
 }
 
@@ -92,8 +239,9 @@ func main() {
 		}
 	}()
 
-	// Expose metrics via HTTP
+	// Expose metrics and scrape targets via HTTP
 	http.Handle("/metrics", promhttp.Handler())
+	http.HandleFunc("/targets", targetsHandler)
 	fmt.Println("Starting Docker Meta Exporter on :8000")
 	log.Fatal(http.ListenAndServe(":8000", nil))
 }
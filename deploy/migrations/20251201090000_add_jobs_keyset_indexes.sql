@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keyset pagination for admin job listings orders by (created_at, id); these
+-- indexes let the row-value cursor predicate seek instead of scanning.
+CREATE INDEX IF NOT EXISTS idx_jobs_created_at_id ON jobs(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_status_created_at_id ON jobs(status, created_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_status_created_at_id;
+DROP INDEX IF EXISTS idx_jobs_created_at_id;
+-- +goose StatementEnd
@@ -0,0 +1,118 @@
+-- +goose Up
+-- Convert jobs and results into monthly range partitions on created_at so that
+-- retention can archive and drop whole partitions instead of running large
+-- DELETEs. Partitions are named <table>_pYYYYMM; the application creates
+-- upcoming partitions ahead of time (see postgres.PartitionArchiver) and the
+-- DEFAULT partition only catches rows outside the pre-created range.
+--
+-- Partitioned tables require the partition key in every unique constraint, so
+-- jobs' primary key becomes (id, created_at) and results no longer carry a
+-- unique job_id or a foreign key to jobs. Result upserts are performed with an
+-- UPDATE-then-INSERT statement and job/result rows are removed together by
+-- CleanupService.
+-- +goose StatementBegin
+ALTER TABLE results RENAME TO results_unpartitioned;
+ALTER TABLE jobs RENAME TO jobs_unpartitioned;
+ALTER INDEX IF EXISTS idx_jobs_status RENAME TO idx_jobs_unpartitioned_status;
+ALTER INDEX IF EXISTS idx_jobs_created_at_id RENAME TO idx_jobs_unpartitioned_created_at_id;
+ALTER INDEX IF EXISTS idx_jobs_status_created_at_id RENAME TO idx_jobs_unpartitioned_status_created_at_id;
+
+CREATE TABLE jobs (
+  id TEXT NOT NULL,
+  status TEXT NOT NULL CHECK (status IN ('queued','processing','completed','failed')),
+  error TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  cv_id TEXT NOT NULL REFERENCES uploads(id) ON DELETE RESTRICT,
+  project_id TEXT NOT NULL REFERENCES uploads(id) ON DELETE RESTRICT,
+  idempotency_key TEXT,
+  PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status);
+CREATE INDEX IF NOT EXISTS idx_jobs_created_at_id ON jobs(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_status_created_at_id ON jobs(status, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_idempotency_key ON jobs(idempotency_key) WHERE idempotency_key IS NOT NULL;
+
+CREATE TABLE results (
+  job_id TEXT NOT NULL,
+  cv_match_rate DOUBLE PRECISION NOT NULL,
+  cv_feedback TEXT NOT NULL,
+  project_score DOUBLE PRECISION NOT NULL,
+  project_feedback TEXT NOT NULL,
+  overall_summary TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+) PARTITION BY RANGE (created_at);
+
+CREATE INDEX IF NOT EXISTS idx_results_job_id ON results(job_id);
+
+CREATE TABLE IF NOT EXISTS jobs_default PARTITION OF jobs DEFAULT;
+CREATE TABLE IF NOT EXISTS results_default PARTITION OF results DEFAULT;
+
+-- Create one partition per month from the oldest existing row up to three
+-- months ahead so migrated rows land in archivable partitions.
+DO $$
+DECLARE
+  m DATE;
+  last_month DATE := date_trunc('month', now() AT TIME ZONE 'UTC')::date + INTERVAL '3 months';
+BEGIN
+  SELECT date_trunc('month', LEAST(
+           COALESCE((SELECT min(created_at) FROM jobs_unpartitioned), now()),
+           COALESCE((SELECT min(created_at) FROM results_unpartitioned), now())
+         ) AT TIME ZONE 'UTC')::date
+    INTO m;
+  WHILE m <= last_month LOOP
+    EXECUTE format(
+      'CREATE TABLE IF NOT EXISTS %I PARTITION OF jobs FOR VALUES FROM (%L) TO (%L)',
+      'jobs_p' || to_char(m, 'YYYYMM'), m::timestamp AT TIME ZONE 'UTC', (m + INTERVAL '1 month')::timestamp AT TIME ZONE 'UTC');
+    EXECUTE format(
+      'CREATE TABLE IF NOT EXISTS %I PARTITION OF results FOR VALUES FROM (%L) TO (%L)',
+      'results_p' || to_char(m, 'YYYYMM'), m::timestamp AT TIME ZONE 'UTC', (m + INTERVAL '1 month')::timestamp AT TIME ZONE 'UTC');
+    m := (m + INTERVAL '1 month')::date;
+  END LOOP;
+END $$;
+
+INSERT INTO jobs SELECT id, status, error, created_at, updated_at, cv_id, project_id, idempotency_key FROM jobs_unpartitioned;
+INSERT INTO results SELECT job_id, cv_match_rate, cv_feedback, project_score, project_feedback, overall_summary, created_at FROM results_unpartitioned;
+
+DROP TABLE results_unpartitioned;
+DROP TABLE jobs_unpartitioned;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TABLE jobs_unpartitioned (
+  id TEXT PRIMARY KEY,
+  status TEXT NOT NULL CHECK (status IN ('queued','processing','completed','failed')),
+  error TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  cv_id TEXT NOT NULL REFERENCES uploads(id) ON DELETE RESTRICT,
+  project_id TEXT NOT NULL REFERENCES uploads(id) ON DELETE RESTRICT,
+  idempotency_key TEXT
+);
+INSERT INTO jobs_unpartitioned SELECT id, status, error, created_at, updated_at, cv_id, project_id, idempotency_key FROM jobs;
+
+CREATE TABLE results_unpartitioned (
+  job_id TEXT PRIMARY KEY REFERENCES jobs_unpartitioned(id) ON DELETE CASCADE,
+  cv_match_rate DOUBLE PRECISION NOT NULL,
+  cv_feedback TEXT NOT NULL,
+  project_score DOUBLE PRECISION NOT NULL,
+  project_feedback TEXT NOT NULL,
+  overall_summary TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+INSERT INTO results_unpartitioned
+  SELECT DISTINCT ON (r.job_id) r.job_id, r.cv_match_rate, r.cv_feedback, r.project_score, r.project_feedback, r.overall_summary, r.created_at
+  FROM results r JOIN jobs_unpartitioned j ON j.id = r.job_id
+  ORDER BY r.job_id, r.created_at DESC;
+
+DROP TABLE results;
+DROP TABLE jobs;
+ALTER TABLE results_unpartitioned RENAME TO results;
+ALTER TABLE jobs_unpartitioned RENAME TO jobs;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status);
+CREATE INDEX IF NOT EXISTS idx_jobs_created_at_id ON jobs(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_status_created_at_id ON jobs(status, created_at DESC, id DESC);
+-- +goose StatementEnd
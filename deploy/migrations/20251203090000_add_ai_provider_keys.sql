@@ -0,0 +1,21 @@
+-- +goose Up
+-- Runtime-managed AI provider API keys. Rows either add a key (secret set) or
+-- override the weight/state of a key configured through the environment
+-- (secret empty, id matching the configured key's id). Access to this table
+-- must be restricted like any other credential store.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ai_provider_keys (
+  id TEXT PRIMARY KEY,
+  provider TEXT NOT NULL,
+  secret TEXT NOT NULL DEFAULT '',
+  weight INTEGER NOT NULL DEFAULT 1 CHECK (weight > 0),
+  state TEXT NOT NULL CHECK (state IN ('active','draining','disabled')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ai_provider_keys;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Daily request and token counts per AI provider key. Rows are incremented by
+-- every process after each provider call and read back to enforce per-key
+-- daily budgets across the server and workers.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ai_key_usage (
+  key_id TEXT NOT NULL,
+  day DATE NOT NULL,
+  requests BIGINT NOT NULL DEFAULT 0,
+  tokens BIGINT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (key_id, day)
+);
+CREATE INDEX IF NOT EXISTS idx_ai_key_usage_day ON ai_key_usage(day);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ai_key_usage;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Rate limits of AI provider models as advertised by provider response
+-- headers, so new models and limit changes are picked up without a deploy.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ai_model_limits (
+  provider TEXT NOT NULL,
+  model TEXT NOT NULL,
+  requests_per_day BIGINT NOT NULL DEFAULT 0,
+  tokens_per_minute BIGINT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (provider, model)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ai_model_limits;
+-- +goose StatementEnd
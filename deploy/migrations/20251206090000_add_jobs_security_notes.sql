@@ -0,0 +1,11 @@
+-- +goose Up
+-- Security findings about a job's inputs (e.g. prompt-injection attempts in
+-- uploaded documents), surfaced with the job's result.
+-- +goose StatementBegin
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS security_notes TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs DROP COLUMN IF EXISTS security_notes;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Periodic bias/drift audits of evaluation scores segmented by document
+-- language and length. Reports are stored whole as JSON.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS bias_reports (
+  id TEXT PRIMARY KEY,
+  generated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  window_start TIMESTAMPTZ NOT NULL,
+  window_end TIMESTAMPTZ NOT NULL,
+  report JSONB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_bias_reports_generated_at ON bias_reports(generated_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS bias_reports;
+-- +goose StatementEnd
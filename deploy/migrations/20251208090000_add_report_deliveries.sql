@@ -0,0 +1,16 @@
+-- +goose Up
+-- Scheduled activity report deliveries. A row is claimed before a report is
+-- emailed so that only one worker sends each scheduled report.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS report_deliveries (
+  recipient TEXT NOT NULL,
+  scheduled_for TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (recipient, scheduled_for)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS report_deliveries;
+-- +goose StatementEnd
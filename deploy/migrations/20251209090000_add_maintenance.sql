@@ -0,0 +1,24 @@
+-- +goose Up
+-- Maintenance mode shared by all server processes (a single row) and the
+-- evaluations accepted while it defers new work.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS maintenance_state (
+  id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+  mode TEXT NOT NULL CHECK (mode IN ('off','reject','defer')),
+  retry_after_seconds INTEGER NOT NULL DEFAULT 0,
+  message TEXT NOT NULL DEFAULT '',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE TABLE IF NOT EXISTS deferred_evaluations (
+  job_id TEXT PRIMARY KEY,
+  payload JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_deferred_evaluations_created_at ON deferred_evaluations(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS deferred_evaluations;
+DROP TABLE IF EXISTS maintenance_state;
+-- +goose StatementEnd
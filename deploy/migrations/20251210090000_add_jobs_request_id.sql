@@ -0,0 +1,13 @@
+-- +goose Up
+-- X-Request-Id of the HTTP request that created the job, so support can find
+-- a job from the identifier a client reports.
+-- +goose StatementBegin
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS request_id TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_jobs_request_id ON jobs(request_id) WHERE request_id <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_request_id;
+ALTER TABLE jobs DROP COLUMN IF EXISTS request_id;
+-- +goose StatementEnd
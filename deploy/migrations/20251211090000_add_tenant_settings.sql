@@ -0,0 +1,19 @@
+-- +goose Up
+-- Admin-managed evaluation settings per tenant. Tenants are identified by the
+-- SHA-256 of the API key their requests carry in X-API-Key.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS tenant_settings (
+  tenant_id TEXT PRIMARY KEY,
+  api_key_hash TEXT NOT NULL UNIQUE,
+  preferred_models TEXT[] NOT NULL DEFAULT '{}',
+  rubric_template TEXT NOT NULL DEFAULT '',
+  max_tokens INTEGER NOT NULL DEFAULT 0 CHECK (max_tokens >= 0),
+  anonymize BOOLEAN NOT NULL DEFAULT FALSE,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tenant_settings;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- CV-only evaluations have no project upload and no project score.
+-- +goose StatementBegin
+ALTER TABLE jobs ALTER COLUMN project_id DROP NOT NULL;
+ALTER TABLE results ALTER COLUMN project_score DROP NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM results WHERE project_score IS NULL;
+DELETE FROM jobs WHERE project_id IS NULL;
+ALTER TABLE results ALTER COLUMN project_score SET NOT NULL;
+ALTER TABLE jobs ALTER COLUMN project_id SET NOT NULL;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Project-only evaluations have no CV upload and no CV match rate.
+-- +goose StatementBegin
+ALTER TABLE jobs ALTER COLUMN cv_id DROP NOT NULL;
+ALTER TABLE results ALTER COLUMN cv_match_rate DROP NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM results WHERE cv_match_rate IS NULL;
+DELETE FROM jobs WHERE cv_id IS NULL;
+ALTER TABLE results ALTER COLUMN cv_match_rate SET NOT NULL;
+ALTER TABLE jobs ALTER COLUMN cv_id SET NOT NULL;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Recruiter-facing summaries generated on demand from evaluation results.
+-- result_version is a hash of the result content; a summary whose version no
+-- longer matches the result is regenerated.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS candidate_summaries (
+  job_id TEXT PRIMARY KEY,
+  result_version TEXT NOT NULL,
+  summary TEXT NOT NULL,
+  strengths TEXT[] NOT NULL DEFAULT '{}',
+  risks TEXT[] NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_candidate_summaries_created_at ON candidate_summaries(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS candidate_summaries;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Rubric weights a result was scored with when the request overrode the
+-- defaults; NULL means the default rubric weights applied.
+-- +goose StatementBegin
+ALTER TABLE results ADD COLUMN IF NOT EXISTS scoring_weights JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE results DROP COLUMN IF EXISTS scoring_weights;
+-- +goose StatementEnd
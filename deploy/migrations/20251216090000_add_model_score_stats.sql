@@ -0,0 +1,23 @@
+-- +goose Up
+-- Running score distributions per AI model, used to normalize results so they
+-- are comparable regardless of the model that served the job. buckets is an
+-- equal-width histogram over the metric's range.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS model_score_stats (
+  model TEXT NOT NULL,
+  metric TEXT NOT NULL,
+  n BIGINT NOT NULL DEFAULT 0,
+  sum DOUBLE PRECISION NOT NULL DEFAULT 0,
+  sum_sq DOUBLE PRECISION NOT NULL DEFAULT 0,
+  buckets BIGINT[] NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (model, metric)
+);
+ALTER TABLE results ADD COLUMN IF NOT EXISTS score_normalization JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE results DROP COLUMN IF EXISTS score_normalization;
+DROP TABLE IF EXISTS model_score_stats;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Evaluation tasks written in the same transaction as their job and
+-- published to the queue by the outbox relay, so a crash between the two
+-- steps can neither lose a job nor publish a task without one.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS evaluate_outbox (
+  id BIGSERIAL PRIMARY KEY,
+  job_id TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  locked_until TIMESTAMPTZ NOT NULL DEFAULT '-infinity',
+  sent_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_evaluate_outbox_pending ON evaluate_outbox(created_at) WHERE sent_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_evaluate_outbox_sent_at ON evaluate_outbox(sent_at) WHERE sent_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS evaluate_outbox;
+-- +goose StatementEnd
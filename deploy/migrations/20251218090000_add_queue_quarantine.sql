@@ -0,0 +1,25 @@
+-- +goose Up
+-- Queue records the worker could not decode, captured with their headers
+-- and decode error; records are skipped after QUEUE_POISON_MAX_ATTEMPTS
+-- deliveries so they cannot wedge a partition.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS queue_quarantine (
+  topic TEXT NOT NULL,
+  record_partition INTEGER NOT NULL,
+  record_offset BIGINT NOT NULL,
+  record_key BYTEA,
+  payload BYTEA NOT NULL,
+  headers JSONB NOT NULL DEFAULT '{}'::jsonb,
+  error TEXT NOT NULL DEFAULT '',
+  attempts INTEGER NOT NULL DEFAULT 1,
+  first_seen_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_seen_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (topic, record_partition, record_offset)
+);
+CREATE INDEX IF NOT EXISTS idx_queue_quarantine_last_seen_at ON queue_quarantine(last_seen_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS queue_quarantine;
+-- +goose StatementEnd
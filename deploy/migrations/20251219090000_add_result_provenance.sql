@@ -0,0 +1,12 @@
+-- +goose Up
+-- Provider, model and prompt template version behind each evaluation step of
+-- a result, so score anomalies can be attributed to the models that served
+-- them.
+-- +goose StatementBegin
+ALTER TABLE results ADD COLUMN IF NOT EXISTS provenance JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE results DROP COLUMN IF EXISTS provenance;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Outputs of completed evaluation chain steps, so a retried job resumes after
+-- the last completed step. Rows are deleted when the job completes.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS evaluation_checkpoints (
+  job_id TEXT NOT NULL,
+  step TEXT NOT NULL,
+  output TEXT NOT NULL,
+  provenance JSONB NOT NULL DEFAULT '[]'::jsonb,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (job_id, step)
+);
+CREATE INDEX IF NOT EXISTS idx_evaluation_checkpoints_created_at ON evaluation_checkpoints(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS evaluation_checkpoints;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Expiring leases on jobs held by the consumer evaluating them. token is a
+-- fencing token incremented on every acquisition, so a holder whose lease
+-- expired and was taken over can tell it must not store a result.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS job_locks (
+  job_id TEXT PRIMARY KEY,
+  owner TEXT NOT NULL,
+  token BIGINT NOT NULL DEFAULT 1,
+  acquired_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_job_locks_expires_at ON job_locks(expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS job_locks;
+-- +goose StatementEnd
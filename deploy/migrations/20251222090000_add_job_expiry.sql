@@ -0,0 +1,19 @@
+-- +goose Up
+-- Per-job expiry: queued jobs past expires_at are marked expired instead of
+-- being processed and the results of completed jobs past it are no longer
+-- served. NULL never expires.
+-- +goose StatementBegin
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_jobs_expires_at ON jobs(expires_at) WHERE expires_at IS NOT NULL;
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check CHECK (status IN ('queued','processing','completed','failed','expired'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE jobs SET status = 'failed', error = 'expired' WHERE status = 'expired';
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_status_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_status_check CHECK (status IN ('queued','processing','completed','failed'));
+DROP INDEX IF EXISTS idx_jobs_expires_at;
+ALTER TABLE jobs DROP COLUMN IF EXISTS expires_at;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Daily per-tenant quotas on submitted evaluations and used AI tokens, and
+-- the usage counted against them per UTC day. A limit of 0 is unlimited.
+-- +goose StatementBegin
+ALTER TABLE tenant_settings ADD COLUMN IF NOT EXISTS daily_evaluations INTEGER NOT NULL DEFAULT 0 CHECK (daily_evaluations >= 0);
+ALTER TABLE tenant_settings ADD COLUMN IF NOT EXISTS daily_tokens BIGINT NOT NULL DEFAULT 0 CHECK (daily_tokens >= 0);
+CREATE TABLE IF NOT EXISTS tenant_quota_usage (
+  tenant_id TEXT NOT NULL,
+  day DATE NOT NULL,
+  evaluations INTEGER NOT NULL DEFAULT 0,
+  tokens BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY (tenant_id, day)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tenant_quota_usage;
+ALTER TABLE tenant_settings DROP COLUMN IF EXISTS daily_tokens;
+ALTER TABLE tenant_settings DROP COLUMN IF EXISTS daily_evaluations;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Earlier project submissions found highly similar to a result's project
+-- report, flagged for reviewer attention.
+-- +goose StatementBegin
+ALTER TABLE results ADD COLUMN IF NOT EXISTS similarity JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE results DROP COLUMN IF EXISTS similarity;
+-- +goose StatementEnd
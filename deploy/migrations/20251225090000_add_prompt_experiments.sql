@@ -0,0 +1,33 @@
+-- +goose Up
+-- Prompt A/B experiments: two template versions of one step's prompt share
+-- the evaluation traffic, and each job records the variant it used together
+-- with how its evaluation went, for the admin comparison report.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS prompt_experiments (
+  id TEXT PRIMARY KEY,
+  name TEXT NOT NULL,
+  step TEXT NOT NULL,
+  version_a TEXT NOT NULL,
+  version_b TEXT NOT NULL,
+  traffic_b INTEGER NOT NULL CHECK (traffic_b BETWEEN 0 AND 100),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  ended_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_prompt_experiments_active_step ON prompt_experiments(step) WHERE ended_at IS NULL;
+CREATE TABLE IF NOT EXISTS job_prompt_variants (
+  job_id TEXT NOT NULL,
+  experiment_id TEXT NOT NULL REFERENCES prompt_experiments(id) ON DELETE CASCADE,
+  variant TEXT NOT NULL CHECK (variant IN ('a', 'b')),
+  parse_failures INTEGER,
+  latency_ms BIGINT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (job_id, experiment_id)
+);
+CREATE INDEX IF NOT EXISTS idx_job_prompt_variants_experiment ON job_prompt_variants(experiment_id, variant);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS job_prompt_variants;
+DROP TABLE IF EXISTS prompt_experiments;
+-- +goose StatementEnd
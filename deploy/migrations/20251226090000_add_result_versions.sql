@@ -0,0 +1,18 @@
+-- +goose Up
+-- Every stored result of a job, re-evaluations included, is kept as a
+-- numbered version so reviewers can compare the effect of re-runs.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS result_versions (
+  job_id TEXT NOT NULL,
+  version INTEGER NOT NULL,
+  result JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (job_id, version)
+);
+CREATE INDEX IF NOT EXISTS idx_result_versions_created_at ON result_versions(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS result_versions;
+-- +goose StatementEnd
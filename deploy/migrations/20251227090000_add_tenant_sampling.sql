@@ -0,0 +1,15 @@
+-- +goose Up
+-- Per-tenant default sampling parameters of evaluation AI calls. NULL keeps
+-- the deployment default.
+-- +goose StatementBegin
+ALTER TABLE tenant_settings ADD COLUMN IF NOT EXISTS temperature DOUBLE PRECISION CHECK (temperature >= 0 AND temperature <= 1);
+ALTER TABLE tenant_settings ADD COLUMN IF NOT EXISTS top_p DOUBLE PRECISION CHECK (top_p > 0 AND top_p <= 1);
+ALTER TABLE tenant_settings ADD COLUMN IF NOT EXISTS frequency_penalty DOUBLE PRECISION CHECK (frequency_penalty >= 0 AND frequency_penalty <= 1);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE tenant_settings DROP COLUMN IF EXISTS frequency_penalty;
+ALTER TABLE tenant_settings DROP COLUMN IF EXISTS top_p;
+ALTER TABLE tenant_settings DROP COLUMN IF EXISTS temperature;
+-- +goose StatementEnd
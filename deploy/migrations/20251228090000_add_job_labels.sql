@@ -0,0 +1,17 @@
+-- +goose Up
+-- Client-supplied metadata and tags of a job, echoed with its result and
+-- filterable in admin listings.
+-- +goose StatementBegin
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS metadata JSONB;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS idx_jobs_metadata ON jobs USING GIN (metadata jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_jobs_tags ON jobs USING GIN (tags);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_jobs_tags;
+DROP INDEX IF EXISTS idx_jobs_metadata;
+ALTER TABLE jobs DROP COLUMN IF EXISTS tags;
+ALTER TABLE jobs DROP COLUMN IF EXISTS metadata;
+-- +goose StatementEnd
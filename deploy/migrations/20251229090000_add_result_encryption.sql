@@ -0,0 +1,14 @@
+-- +goose Up
+-- Tenant public keys for result encryption and, per result, the wrapped data
+-- key of encrypted feedback. An empty key and a NULL encryption mean plain
+-- text feedback.
+-- +goose StatementBegin
+ALTER TABLE tenant_settings ADD COLUMN IF NOT EXISTS result_public_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE results ADD COLUMN IF NOT EXISTS encryption JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE results DROP COLUMN IF EXISTS encryption;
+ALTER TABLE tenant_settings DROP COLUMN IF EXISTS result_public_key;
+-- +goose StatementEnd
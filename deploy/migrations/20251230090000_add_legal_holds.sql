@@ -0,0 +1,28 @@
+-- +goose Up
+-- Legal holds keep uploads and jobs, with everything derived from them, out of
+-- data retention. Every change is recorded in legal_hold_events.
+-- +goose StatementBegin
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS legal_hold BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS legal_hold BOOLEAN NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS idx_uploads_legal_hold ON uploads (id) WHERE legal_hold;
+CREATE INDEX IF NOT EXISTS idx_jobs_legal_hold ON jobs (id) WHERE legal_hold;
+CREATE TABLE IF NOT EXISTS legal_hold_events (
+    id BIGSERIAL PRIMARY KEY,
+    target_type TEXT NOT NULL CHECK (target_type IN ('upload', 'job')),
+    target_id TEXT NOT NULL,
+    held BOOLEAN NOT NULL,
+    actor TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_legal_hold_events_target ON legal_hold_events (target_type, target_id, created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS legal_hold_events;
+DROP INDEX IF EXISTS idx_jobs_legal_hold;
+DROP INDEX IF EXISTS idx_uploads_legal_hold;
+ALTER TABLE jobs DROP COLUMN IF EXISTS legal_hold;
+ALTER TABLE uploads DROP COLUMN IF EXISTS legal_hold;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- access_log records every read of a result. It has no foreign key to jobs so
+-- the record of who read a candidate's data outlives the data itself.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS access_log (
+    id BIGSERIAL PRIMARY KEY,
+    job_id TEXT NOT NULL,
+    accessor_type TEXT NOT NULL CHECK (accessor_type IN ('api_key', 'admin', 'anonymous')),
+    accessor TEXT NOT NULL DEFAULT '',
+    ip TEXT NOT NULL DEFAULT '',
+    endpoint TEXT NOT NULL,
+    accessed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_access_log_job ON access_log (job_id, accessed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_access_log_accessor ON access_log (accessor, accessed_at DESC);
+CREATE INDEX IF NOT EXISTS idx_access_log_accessed_at ON access_log (accessed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS access_log;
+-- +goose StatementEnd
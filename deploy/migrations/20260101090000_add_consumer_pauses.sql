@@ -0,0 +1,16 @@
+-- +goose Up
+-- Queue topics whose consumption is paused by an operator; workers stop
+-- polling a topic while it has a row here.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS consumer_pauses (
+  topic TEXT PRIMARY KEY,
+  reason TEXT NOT NULL DEFAULT '',
+  paused_by TEXT NOT NULL DEFAULT '',
+  paused_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS consumer_pauses;
+-- +goose StatementEnd
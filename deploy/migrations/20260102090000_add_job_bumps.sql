@@ -0,0 +1,16 @@
+-- +goose Up
+-- Queued jobs an admin moved ahead of the backlog; their tasks are
+-- republished to the priority topic.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS job_bumps (
+  job_id TEXT PRIMARY KEY,
+  bumped_by TEXT NOT NULL DEFAULT '',
+  reason TEXT NOT NULL DEFAULT '',
+  bumped_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS job_bumps;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- Admin actions (requeue, cancel, fail) applied in the background to every
+-- job matching a filter, with their progress.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS job_bulk_operations (
+  id TEXT PRIMARY KEY,
+  action TEXT NOT NULL CHECK (action IN ('requeue','cancel','fail')),
+  filter_status TEXT NOT NULL,
+  filter_older_than_seconds BIGINT NOT NULL DEFAULT 0,
+  filter_failure_reason TEXT NOT NULL DEFAULT '',
+  reason TEXT NOT NULL DEFAULT '',
+  started_by TEXT NOT NULL DEFAULT '',
+  status TEXT NOT NULL CHECK (status IN ('running','completed')),
+  matched INTEGER NOT NULL DEFAULT 0,
+  truncated BOOLEAN NOT NULL DEFAULT false,
+  processed INTEGER NOT NULL DEFAULT 0,
+  succeeded INTEGER NOT NULL DEFAULT 0,
+  skipped INTEGER NOT NULL DEFAULT 0,
+  failed INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  finished_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_job_bulk_operations_created_at ON job_bulk_operations(created_at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS job_bulk_operations;
+-- +goose StatementEnd
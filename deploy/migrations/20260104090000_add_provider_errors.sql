@@ -0,0 +1,24 @@
+-- +goose Up
+-- Failed AI provider calls classified by the error body, counted by the admin
+-- API to surface recurring failures.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS provider_errors (
+  id TEXT PRIMARY KEY,
+  provider TEXT NOT NULL,
+  op TEXT NOT NULL DEFAULT '',
+  model TEXT NOT NULL DEFAULT '',
+  status INTEGER NOT NULL DEFAULT 0,
+  fingerprint TEXT NOT NULL,
+  message TEXT NOT NULL DEFAULT '',
+  job_id TEXT NOT NULL DEFAULT '',
+  step TEXT NOT NULL DEFAULT '',
+  at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_provider_errors_at ON provider_errors(at);
+CREATE INDEX IF NOT EXISTS idx_provider_errors_fingerprint_at ON provider_errors(fingerprint, provider, at DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS provider_errors;
+-- +goose StatementEnd
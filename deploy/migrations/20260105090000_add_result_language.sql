@@ -0,0 +1,10 @@
+-- +goose Up
+-- Languages detected in a job's job description and CV when they differ.
+-- +goose StatementBegin
+ALTER TABLE results ADD COLUMN IF NOT EXISTS language JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE results DROP COLUMN IF EXISTS language;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Wall-clock time completed jobs spent in each phase of their processing,
+-- summarised by the admin API for capacity planning.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS job_usage (
+  job_id TEXT PRIMARY KEY,
+  queue_wait_ms BIGINT NOT NULL DEFAULT 0,
+  extraction_ms BIGINT NOT NULL DEFAULT 0,
+  rag_ms BIGINT NOT NULL DEFAULT 0,
+  ai_ms BIGINT NOT NULL DEFAULT 0,
+  ai_calls INTEGER NOT NULL DEFAULT 0,
+  post_processing_ms BIGINT NOT NULL DEFAULT 0,
+  total_ms BIGINT NOT NULL DEFAULT 0,
+  completed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_job_usage_completed_at ON job_usage(completed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS job_usage;
+-- +goose StatementEnd
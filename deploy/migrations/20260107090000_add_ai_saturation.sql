@@ -0,0 +1,16 @@
+-- +goose Up
+-- Each worker's latest report of whether all of its AI chat models are
+-- blocked after rate limits, read by the API to fail evaluations fast.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS ai_saturation (
+  instance TEXT PRIMARY KEY,
+  blocked_until TIMESTAMPTZ,
+  reported_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_ai_saturation_reported_at ON ai_saturation(reported_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS ai_saturation;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- How an upload's text was submitted: extracted from files or sent as text.
+-- +goose StatementBegin
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'file';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE uploads DROP COLUMN IF EXISTS source;
+-- +goose StatementEnd
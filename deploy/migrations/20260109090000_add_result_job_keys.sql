@@ -0,0 +1,30 @@
+-- +goose Up
+-- results is range partitioned on created_at, so a unique key on job_id alone
+-- is not allowed. result_job_keys records the created_at of each job's single
+-- result row: upserts read it to route to the right partition and conflict on
+-- the (job_id, created_at) unique index, and the job_id primary key
+-- serializes concurrent writers of the same job.
+-- +goose StatementBegin
+DELETE FROM results r
+  WHERE EXISTS (SELECT 1 FROM results n WHERE n.job_id = r.job_id AND n.created_at > r.created_at);
+DELETE FROM results r
+  USING results n
+  WHERE n.job_id = r.job_id AND n.created_at = r.created_at AND n.ctid > r.ctid;
+
+CREATE TABLE IF NOT EXISTS result_job_keys (
+  job_id TEXT PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_result_job_keys_created_at ON result_job_keys(created_at);
+INSERT INTO result_job_keys (job_id, created_at) SELECT job_id, created_at FROM results;
+
+DROP INDEX IF EXISTS idx_results_job_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_results_job_id_created_at ON results(job_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_results_job_id_created_at;
+CREATE INDEX IF NOT EXISTS idx_results_job_id ON results(job_id);
+DROP TABLE IF EXISTS result_job_keys;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Entries whose publish keeps failing are parked after OUTBOX_MAX_ATTEMPTS:
+-- the relay stops claiming them and their job is marked failed.
+-- +goose StatementBegin
+ALTER TABLE evaluate_outbox ADD COLUMN IF NOT EXISTS parked_at TIMESTAMPTZ;
+ALTER TABLE evaluate_outbox ADD COLUMN IF NOT EXISTS last_error TEXT NOT NULL DEFAULT '';
+DROP INDEX IF EXISTS idx_evaluate_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_evaluate_outbox_pending ON evaluate_outbox(created_at) WHERE sent_at IS NULL AND parked_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_evaluate_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_evaluate_outbox_pending ON evaluate_outbox(created_at) WHERE sent_at IS NULL;
+ALTER TABLE evaluate_outbox DROP COLUMN IF EXISTS last_error;
+ALTER TABLE evaluate_outbox DROP COLUMN IF EXISTS parked_at;
+-- +goose StatementEnd
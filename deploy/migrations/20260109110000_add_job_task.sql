@@ -0,0 +1,14 @@
+-- +goose Up
+-- The evaluation task a job was last queued with, kept on the job row so
+-- retries and replays can read it after the outbox entry is purged.
+-- +goose StatementBegin
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS task JSONB;
+UPDATE jobs j SET task = o.payload
+  FROM (SELECT DISTINCT ON (job_id) job_id, payload FROM evaluate_outbox ORDER BY job_id, created_at DESC, id DESC) o
+  WHERE o.job_id = j.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE jobs DROP COLUMN IF EXISTS task;
+-- +goose StatementEnd
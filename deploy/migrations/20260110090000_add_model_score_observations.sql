@@ -0,0 +1,18 @@
+-- +goose Up
+-- The jobs whose scores are counted in model_score_stats, one row per job and
+-- metric, so a retried or redelivered job is not counted again.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS model_score_observations (
+  job_id TEXT NOT NULL,
+  metric TEXT NOT NULL,
+  model TEXT NOT NULL,
+  observed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (job_id, metric)
+);
+CREATE INDEX IF NOT EXISTS idx_model_score_observations_observed_at ON model_score_observations (observed_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS model_score_observations;
+-- +goose StatementEnd
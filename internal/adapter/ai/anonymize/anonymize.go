@@ -0,0 +1,36 @@
+// Package anonymize redacts contact details from candidate documents before
+// they are inserted into prompts, for tenants that must not send personal
+// data to AI providers.
+package anonymize
+
+import "regexp"
+
+// Placeholders replacing redacted values.
+const (
+	EmailPlaceholder   = "[email]"
+	PhonePlaceholder   = "[phone]"
+	ProfilePlaceholder = "[profile]"
+)
+
+var (
+	emailRe = regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}\b`)
+	// Profile links identify a person as directly as an email address.
+	profileRe = regexp.MustCompile(`(?i)\b(?:https?://)?(?:www\.)?(?:linkedin\.com/in|github\.com|gitlab\.com|twitter\.com|x\.com|facebook\.com|instagram\.com)/[A-Za-z0-9_.-]+/?`)
+	// Phone numbers need at least nine digits so that years, version numbers
+	// and date ranges survive.
+	phoneRe = regexp.MustCompile(`(?:\+\d{1,3}[\s.-]?)?(?:\(\d{1,4}\)[\s.-]?)?\d{2,4}(?:[\s.-]?\d{2,4}){2,4}`)
+	digitRe = regexp.MustCompile(`\d`)
+)
+
+// Text returns text with email addresses, phone numbers and social profile
+// links replaced by placeholders.
+func Text(text string) string {
+	text = emailRe.ReplaceAllString(text, EmailPlaceholder)
+	text = profileRe.ReplaceAllString(text, ProfilePlaceholder)
+	return phoneRe.ReplaceAllStringFunc(text, func(m string) string {
+		if len(digitRe.FindAllString(m, -1)) < 9 {
+			return m
+		}
+		return PhonePlaceholder
+	})
+}
@@ -0,0 +1,17 @@
+package anonymize
+
+import "testing"
+
+func TestText(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"Contact: jane.doe+cv@example.co.id", "Contact: [email]"},
+		{"Phone +62 812-3456-7890 or (021) 555 1234 56", "Phone [phone] or [phone]"},
+		{"See linkedin.com/in/jane-doe and https://github.com/janedoe/", "See [profile] and [profile]"},
+		{"Go 1.22, worked 2019-2023, 5 years, v3.4.1", "Go 1.22, worked 2019-2023, 5 years, v3.4.1"},
+	}
+	for _, c := range cases {
+		if got := Text(c.in); got != c.want {
+			t.Errorf("Text(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
@@ -2,61 +2,153 @@
 package ai
 
 import (
+	"container/list"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"log/slog"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
 )
 
+// EmbedStore is an optional second-level cache for embeddings that outlives
+// the process, e.g. Redis. Implementations must treat keys as opaque.
+type EmbedStore interface {
+	// GetMany returns the vectors found for keys; missing keys are omitted.
+	GetMany(ctx context.Context, keys []string) (map[string][]float32, error)
+	// SetMany stores vectors with the given TTL (0 means no expiry).
+	SetMany(ctx context.Context, entries map[string][]float32, ttl time.Duration) error
+}
+
+// EmbedCacheOptions configures NewEmbedCacheWithOptions.
+type EmbedCacheOptions struct {
+	// Capacity is the maximum number of in-memory entries.
+	Capacity int
+	// MaxBytes bounds the estimated in-memory size of cached vectors; 0 disables the bound.
+	MaxBytes int64
+	// TTL expires entries in memory and in Store; 0 keeps entries until evicted.
+	TTL time.Duration
+	// Store, when set, persists embeddings beyond the process lifetime.
+	Store EmbedStore
+}
+
 // embedCacheClient wraps an AIClient and caches embedding vectors by text hash.
 // It is safe for concurrent use.
 // Only the Embed method is cached; ChatJSON is passed through.
-// Cache is a simple LRU-ish with FIFO eviction for simplicity.
-
+// The in-memory tier is an LRU bounded by entry count and estimated bytes;
+// an optional EmbedStore backs it so entries survive restarts.
 type embedCacheClient struct {
 	base     domain.AIClient
 	capacity int
-	mu       sync.RWMutex
-	m        map[string][]float32
-	ord      []string
+	maxBytes int64
+	ttl      time.Duration
+	store    EmbedStore
+	now      func() time.Time
+
+	mu    sync.Mutex
+	m     map[string]*list.Element
+	lru   *list.List // front = most recently used
+	bytes int64
+}
+
+type embedCacheEntry struct {
+	key     string
+	vec     []float32
+	size    int64
+	expires time.Time
 }
 
+// embedEntryOverhead approximates per-entry bookkeeping (map slot, list
+// element, hex key) on top of the vector payload.
+const embedEntryOverhead = 160
+
 // NewEmbedCache wraps base with an embedding cache of given capacity (number of entries).
 // If capacity <= 0, base is returned unmodified.
 func NewEmbedCache(base domain.AIClient, capacity int) domain.AIClient {
-	if capacity <= 0 || base == nil {
+	return NewEmbedCacheWithOptions(base, EmbedCacheOptions{Capacity: capacity})
+}
+
+// NewEmbedCacheWithOptions wraps base with an embedding cache configured by opts.
+// If opts.Capacity <= 0, base is returned unmodified.
+func NewEmbedCacheWithOptions(base domain.AIClient, opts EmbedCacheOptions) domain.AIClient {
+	if opts.Capacity <= 0 || base == nil {
 		return base
 	}
-	return &embedCacheClient{base: base, capacity: capacity, m: make(map[string][]float32), ord: make([]string, 0, capacity)}
+	return &embedCacheClient{
+		base:     base,
+		capacity: opts.Capacity,
+		maxBytes: opts.MaxBytes,
+		ttl:      opts.TTL,
+		store:    opts.Store,
+		now:      time.Now,
+		m:        make(map[string]*list.Element),
+		lru:      list.New(),
+	}
 }
 
 func (c *embedCacheClient) Embed(ctx domain.Context, texts []string) ([][]float32, error) {
 	res := make([][]float32, len(texts))
 	missIdx := make([]int, 0)
-	missTexts := make([]string, 0)
-	// Lookup cache
+	missKeys := make([]string, 0)
+	// Lookup in-memory cache
 	for i, t := range texts {
 		k := keyFor(t)
-		c.mu.RLock()
-		v, ok := c.m[k]
-		c.mu.RUnlock()
-		if ok {
+		if v, ok := c.get(k); ok {
 			res[i] = v
+			observability.RecordEmbedCacheHit("memory")
 			continue
 		}
 		missIdx = append(missIdx, i)
-		missTexts = append(missTexts, t)
+		missKeys = append(missKeys, k)
 	}
+
+	// Lookup persistent store; failures degrade to a plain miss.
+	if len(missIdx) > 0 && c.store != nil {
+		found, err := c.store.GetMany(ctx, uniqueKeys(missKeys))
+		if err != nil {
+			slog.Warn("embed cache store lookup failed", slog.Any("error", err))
+		}
+		if len(found) > 0 {
+			remainingIdx := missIdx[:0:0]
+			remainingKeys := missKeys[:0:0]
+			for j, idx := range missIdx {
+				if v, ok := found[missKeys[j]]; ok {
+					res[idx] = v
+					c.put(missKeys[j], v)
+					observability.RecordEmbedCacheHit("store")
+					continue
+				}
+				remainingIdx = append(remainingIdx, idx)
+				remainingKeys = append(remainingKeys, missKeys[j])
+			}
+			missIdx, missKeys = remainingIdx, remainingKeys
+		}
+	}
+
 	if len(missIdx) > 0 {
+		observability.RecordEmbedCacheMiss(len(missIdx))
+		missTexts := make([]string, len(missIdx))
+		for j, idx := range missIdx {
+			missTexts[j] = texts[idx]
+		}
 		vecs, err := c.base.Embed(ctx, missTexts)
 		if err != nil {
 			return nil, err
 		}
+		fresh := make(map[string][]float32, len(missIdx))
 		for j, idx := range missIdx {
 			res[idx] = vecs[j]
-			c.put(missTexts[j], vecs[j])
+			c.put(missKeys[j], vecs[j])
+			fresh[missKeys[j]] = vecs[j]
+		}
+		if c.store != nil {
+			if err := c.store.SetMany(ctx, fresh, c.ttl); err != nil {
+				slog.Warn("embed cache store write failed", slog.Any("error", err))
+			}
 		}
 	}
 	return res, nil
@@ -74,21 +166,74 @@ func (c *embedCacheClient) CleanCoTResponse(ctx domain.Context, response string)
 	return c.base.CleanCoTResponse(ctx, response)
 }
 
-func (c *embedCacheClient) put(text string, vec []float32) {
-	k := keyFor(text)
+// get returns a live entry and marks it most recently used. Expired entries
+// are dropped on access.
+func (c *embedCacheClient) get(k string) ([]float32, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.m[k]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*embedCacheEntry)
+	if !e.expires.IsZero() && !c.now().Before(e.expires) {
+		c.removeLocked(el, "ttl")
+		c.reportSizeLocked()
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return e.vec, true
+}
+
+func (c *embedCacheClient) put(k string, vec []float32) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if _, exists := c.m[k]; exists {
-		c.m[k] = vec
-		return
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+	size := int64(len(vec))*4 + embedEntryOverhead
+	if el, exists := c.m[k]; exists {
+		e := el.Value.(*embedCacheEntry)
+		c.bytes += size - e.size
+		e.vec, e.size, e.expires = vec, size, expires
+		c.lru.MoveToFront(el)
+	} else {
+		c.m[k] = c.lru.PushFront(&embedCacheEntry{key: k, vec: vec, size: size, expires: expires})
+		c.bytes += size
+	}
+	for c.lru.Len() > c.capacity {
+		c.removeLocked(c.lru.Back(), "capacity")
 	}
-	if len(c.ord) >= c.capacity {
-		old := c.ord[0]
-		c.ord = c.ord[1:]
-		delete(c.m, old)
+	// Keep at least the newest entry even if it alone exceeds the budget.
+	for c.maxBytes > 0 && c.bytes > c.maxBytes && c.lru.Len() > 1 {
+		c.removeLocked(c.lru.Back(), "memory")
+	}
+	c.reportSizeLocked()
+}
+
+func (c *embedCacheClient) removeLocked(el *list.Element, reason string) {
+	e := c.lru.Remove(el).(*embedCacheEntry)
+	delete(c.m, e.key)
+	c.bytes -= e.size
+	observability.RecordEmbedCacheEviction(reason)
+}
+
+func (c *embedCacheClient) reportSizeLocked() {
+	observability.SetEmbedCacheSize(c.lru.Len(), c.bytes)
+}
+
+func uniqueKeys(keys []string) []string {
+	seen := make(map[string]struct{}, len(keys))
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, k)
 	}
-	c.m[k] = vec
-	c.ord = append(c.ord, k)
+	return out
 }
 
 func keyFor(text string) string {
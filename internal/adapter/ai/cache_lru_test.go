@@ -0,0 +1,134 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type memStore struct {
+	m       map[string][]float32
+	ttl     time.Duration
+	getErr  error
+	setErr  error
+	setCall int
+}
+
+func (s *memStore) GetMany(_ context.Context, keys []string) (map[string][]float32, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	out := map[string][]float32{}
+	for _, k := range keys {
+		if v, ok := s.m[k]; ok {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+func (s *memStore) SetMany(_ context.Context, entries map[string][]float32, ttl time.Duration) error {
+	s.setCall++
+	s.ttl = ttl
+	if s.setErr != nil {
+		return s.setErr
+	}
+	for k, v := range entries {
+		s.m[k] = v
+	}
+	return nil
+}
+
+func Test_EmbedCache_LRUKeepsRecentlyUsed(t *testing.T) {
+	base := &fakeAI{}
+	c := NewEmbedCacheWithOptions(base, EmbedCacheOptions{Capacity: 2})
+	ctx := context.Background()
+	_, _ = c.Embed(ctx, []string{"a"})
+	_, _ = c.Embed(ctx, []string{"b"})
+	_, _ = c.Embed(ctx, []string{"a"}) // touch a; b becomes LRU
+	_, _ = c.Embed(ctx, []string{"c"}) // evicts b
+	calls := base.embedCalls
+	_, _ = c.Embed(ctx, []string{"a"})
+	if base.embedCalls != calls {
+		t.Fatalf("expected a to stay cached")
+	}
+	_, _ = c.Embed(ctx, []string{"b"})
+	if base.embedCalls != calls+1 {
+		t.Fatalf("expected b to be evicted")
+	}
+}
+
+func Test_EmbedCache_MaxBytesEviction(t *testing.T) {
+	base := &fakeAI{}
+	entry := int64(3*4 + embedEntryOverhead)
+	c := NewEmbedCacheWithOptions(base, EmbedCacheOptions{Capacity: 100, MaxBytes: 2 * entry}).(*embedCacheClient)
+	_, _ = c.Embed(context.Background(), []string{"a", "b", "c"})
+	if c.lru.Len() != 2 || c.bytes != 2*entry {
+		t.Fatalf("expected 2 entries within budget, got %d entries / %d bytes", c.lru.Len(), c.bytes)
+	}
+	if _, ok := c.m[keyFor("a")]; ok {
+		t.Fatalf("expected oldest entry to be evicted")
+	}
+}
+
+func Test_EmbedCache_TTLExpiry(t *testing.T) {
+	base := &fakeAI{}
+	c := NewEmbedCacheWithOptions(base, EmbedCacheOptions{Capacity: 10, TTL: time.Minute}).(*embedCacheClient)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	_, _ = c.Embed(context.Background(), []string{"a"})
+	_, _ = c.Embed(context.Background(), []string{"a"})
+	if base.embedCalls != 1 {
+		t.Fatalf("expected cache hit before ttl, calls=%d", base.embedCalls)
+	}
+	now = now.Add(2 * time.Minute)
+	_, _ = c.Embed(context.Background(), []string{"a"})
+	if base.embedCalls != 2 {
+		t.Fatalf("expected refetch after ttl, calls=%d", base.embedCalls)
+	}
+	if c.lru.Len() != 1 {
+		t.Fatalf("expected refreshed entry only, got %d", c.lru.Len())
+	}
+}
+
+func Test_EmbedCache_StoreTier(t *testing.T) {
+	ctx := context.Background()
+	store := &memStore{m: map[string][]float32{keyFor("warm"): {9, 9}}}
+	base := &fakeAI{}
+	c := NewEmbedCacheWithOptions(base, EmbedCacheOptions{Capacity: 10, TTL: time.Hour, Store: store})
+
+	out, err := c.Embed(ctx, []string{"warm", "cold"})
+	if err != nil {
+		t.Fatalf("embed: %v", err)
+	}
+	if out[0][0] != 9 || out[1][0] != 1 {
+		t.Fatalf("unexpected vectors: %v", out)
+	}
+	if base.embedCalls != 1 {
+		t.Fatalf("expected only cold text embedded, calls=%d", base.embedCalls)
+	}
+	if _, ok := store.m[keyFor("cold")]; !ok || store.ttl != time.Hour {
+		t.Fatalf("expected cold vector persisted with ttl, ttl=%v", store.ttl)
+	}
+
+	// A fresh process reuses persisted vectors without calling the provider.
+	base2 := &fakeAI{}
+	c2 := NewEmbedCacheWithOptions(base2, EmbedCacheOptions{Capacity: 10, Store: store})
+	if _, err := c2.Embed(ctx, []string{"warm", "cold"}); err != nil {
+		t.Fatalf("embed: %v", err)
+	}
+	if base2.embedCalls != 0 {
+		t.Fatalf("expected store hits, calls=%d", base2.embedCalls)
+	}
+}
+
+func Test_EmbedCache_StoreErrorsDegradeToMiss(t *testing.T) {
+	store := &memStore{m: map[string][]float32{}, getErr: errors.New("down"), setErr: errors.New("down")}
+	base := &fakeAI{}
+	c := NewEmbedCacheWithOptions(base, EmbedCacheOptions{Capacity: 10, Store: store})
+	out, err := c.Embed(context.Background(), []string{"x"})
+	if err != nil || len(out) != 1 || base.embedCalls != 1 || store.setCall != 1 {
+		t.Fatalf("expected provider fallback, err=%v calls=%d", err, base.embedCalls)
+	}
+}
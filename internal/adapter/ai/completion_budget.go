@@ -0,0 +1,128 @@
+package ai
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+)
+
+const (
+	// completionBudgetWindow is how many recent completions are kept per
+	// step and model.
+	completionBudgetWindow = 200
+	// completionBudgetFloor is the smallest max_tokens ever requested.
+	completionBudgetFloor = 128
+	// truncationGrowth scales the limit a truncated completion ran into; the
+	// real length is unknown, so the sample errs on the long side.
+	truncationGrowth = 1.5
+)
+
+// CompletionBudget picks max_tokens for AI calls from the completion lengths
+// of earlier calls of the same evaluation step and model.
+//
+// Once a step/model pair has enough samples, its calls request the 95th
+// percentile completion length plus a margin, clamped to a floor and a
+// ceiling. Completions that ran into their limit count as longer than the
+// limit, so a budget that truncates grows on the following calls. Until then,
+// and for calls made outside an evaluation step, the caller's max_tokens is
+// used. A nil CompletionBudget always returns the caller's value.
+// CompletionBudget is safe for concurrent use.
+type CompletionBudget struct {
+	margin     float64
+	minSamples int
+	ceiling    int
+
+	mu      sync.Mutex
+	samples map[completionKey]*completionSamples
+}
+
+type completionKey struct{ step, model string }
+
+// completionSamples is a ring buffer of completion lengths.
+type completionSamples struct {
+	vals []int
+	next int
+}
+
+// NewCompletionBudget creates a budget requesting the P95 completion length
+// plus margin (a fraction) once minSamples completions were seen, at most
+// ceiling tokens (0 = no ceiling).
+func NewCompletionBudget(margin float64, minSamples, ceiling int) *CompletionBudget {
+	if margin < 0 {
+		margin = 0
+	}
+	if minSamples < 1 {
+		minSamples = 1
+	}
+	return &CompletionBudget{
+		margin:     margin,
+		minSamples: minSamples,
+		ceiling:    ceiling,
+		samples:    map[completionKey]*completionSamples{},
+	}
+}
+
+// NewCompletionBudgetFromConfig creates the budget configured by the
+// ADAPTIVE_MAX_TOKENS settings, or nil when adaptive max_tokens is off.
+func NewCompletionBudgetFromConfig(cfg config.Config) *CompletionBudget {
+	if !cfg.AdaptiveMaxTokens {
+		return nil
+	}
+	return NewCompletionBudget(cfg.AdaptiveMaxTokensMargin, cfg.AdaptiveMaxTokensMinSamples, cfg.AdaptiveMaxTokensCeiling)
+}
+
+// MaxTokens returns the max_tokens for a call of step to model, or fallback
+// while the pair has too few samples.
+func (b *CompletionBudget) MaxTokens(step, model string, fallback int) int {
+	if b == nil || step == "" {
+		return fallback
+	}
+	b.mu.Lock()
+	s := b.samples[completionKey{step, model}]
+	if s == nil || len(s.vals) < b.minSamples {
+		b.mu.Unlock()
+		return fallback
+	}
+	vals := append([]int(nil), s.vals...)
+	b.mu.Unlock()
+
+	sort.Ints(vals)
+	p95 := vals[int(math.Ceil(0.95*float64(len(vals))))-1]
+	n := int(math.Ceil(float64(p95) * (1 + b.margin)))
+	if n < completionBudgetFloor {
+		n = completionBudgetFloor
+	}
+	if b.ceiling > 0 && n > b.ceiling {
+		n = b.ceiling
+	}
+	return n
+}
+
+// Observe records that a call of step to model requested limit tokens and
+// produced completion tokens. Calls outside an evaluation step are ignored.
+func (b *CompletionBudget) Observe(step, model string, completion, limit int) {
+	if b == nil || step == "" || completion <= 0 {
+		return
+	}
+	// Token counts are estimated locally, so a completion within 5% of its
+	// limit is taken as cut off.
+	if limit > 0 && completion*100 >= limit*95 {
+		completion = int(math.Ceil(float64(limit) * truncationGrowth))
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	key := completionKey{step, model}
+	s := b.samples[key]
+	if s == nil {
+		s = &completionSamples{}
+		b.samples[key] = s
+	}
+	if len(s.vals) < completionBudgetWindow {
+		s.vals = append(s.vals, completion)
+		return
+	}
+	s.vals[s.next] = completion
+	s.next = (s.next + 1) % completionBudgetWindow
+}
@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+)
+
+func TestCompletionBudget_MaxTokens(t *testing.T) {
+	b := NewCompletionBudget(0.25, 20, 4096)
+
+	// Too few samples: the caller's value is used.
+	for i := 0; i < 19; i++ {
+		b.Observe("cv_match", "m1", 400, 1024)
+	}
+	assert.Equal(t, 1024, b.MaxTokens("cv_match", "m1", 1024))
+
+	// 19 completions of 400 and one of 800: P95 is 400, plus 25%.
+	b.Observe("cv_match", "m1", 800, 1024)
+	assert.Equal(t, 500, b.MaxTokens("cv_match", "m1", 1024))
+
+	// Other steps, other models and unlabeled calls keep the fallback.
+	assert.Equal(t, 1024, b.MaxTokens("refine", "m1", 1024))
+	assert.Equal(t, 1024, b.MaxTokens("cv_match", "m2", 1024))
+	b.Observe("", "m1", 10, 1024)
+	assert.Equal(t, 700, b.MaxTokens("", "m1", 700))
+
+	// Tiny completions never shrink the budget below the floor.
+	s := NewCompletionBudget(0, 1, 0)
+	s.Observe("validate", "m1", 20, 512)
+	assert.Equal(t, completionBudgetFloor, s.MaxTokens("validate", "m1", 512))
+
+	var off *CompletionBudget
+	off.Observe("cv_match", "m1", 400, 1024)
+	assert.Equal(t, 1024, off.MaxTokens("cv_match", "m1", 1024))
+}
+
+func TestCompletionBudget_TruncationGrowsBudget(t *testing.T) {
+	b := NewCompletionBudget(0, 1, 1000)
+	// A completion at its limit was probably cut off and counts as longer.
+	b.Observe("refine", "m1", 500, 500)
+	assert.Equal(t, 750, b.MaxTokens("refine", "m1", 500))
+	b.Observe("refine", "m1", 750, 750)
+	assert.Equal(t, 1000, b.MaxTokens("refine", "m1", 500), "capped at the ceiling")
+}
+
+func TestCompletionBudget_WindowKeepsRecentSamples(t *testing.T) {
+	b := NewCompletionBudget(0, 1, 0)
+	for i := 0; i < completionBudgetWindow; i++ {
+		b.Observe("cv_match", "m1", 2000, 4096)
+	}
+	assert.Equal(t, 2000, b.MaxTokens("cv_match", "m1", 512))
+	for i := 0; i < completionBudgetWindow; i++ {
+		b.Observe("cv_match", "m1", 300, 4096)
+	}
+	assert.Equal(t, 300, b.MaxTokens("cv_match", "m1", 512))
+}
+
+func TestNewCompletionBudgetFromConfig(t *testing.T) {
+	assert.Nil(t, NewCompletionBudgetFromConfig(config.Config{}))
+	b := NewCompletionBudgetFromConfig(config.Config{AdaptiveMaxTokens: true, AdaptiveMaxTokensMinSamples: 5, AdaptiveMaxTokensCeiling: 2048})
+	if assert.NotNil(t, b) {
+		assert.Equal(t, 5, b.minSamples)
+		assert.Equal(t, 2048, b.ceiling)
+	}
+}
@@ -0,0 +1,119 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Kinds of AI calls with separate concurrency limits.
+const (
+	CallKindEmbed    = "embed"
+	CallKindChat     = "chat"
+	CallKindCoTClean = "cot_clean"
+)
+
+// ConcurrencyLimits bounds how many AI calls of each kind run at a time; 0
+// leaves a kind unlimited.
+type ConcurrencyLimits struct {
+	Embed    int
+	Chat     int
+	CoTClean int
+}
+
+// concurrencyLimitedClient wraps an AIClient and runs at most a fixed number
+// of calls of each kind at a time. The kinds have separate slots, so that a
+// burst of embeddings does not hold up chat calls and vice versa.
+// ChatJSON and ChatJSONWithRetry share the chat slots.
+type concurrencyLimitedClient struct {
+	base     domain.AIClient
+	embed    chan struct{}
+	chat     chan struct{}
+	cotClean chan struct{}
+}
+
+// NewConcurrencyLimitedClient wraps base with the concurrency limits of
+// limits. Calls wait for a slot of their kind until their context is done.
+// If no kind is limited, base is returned unmodified.
+func NewConcurrencyLimitedClient(base domain.AIClient, limits ConcurrencyLimits) domain.AIClient {
+	if base == nil || (limits.Embed <= 0 && limits.Chat <= 0 && limits.CoTClean <= 0) {
+		return base
+	}
+	return &concurrencyLimitedClient{
+		base:     base,
+		embed:    callSlots(limits.Embed),
+		chat:     callSlots(limits.Chat),
+		cotClean: callSlots(limits.CoTClean),
+	}
+}
+
+// callSlots returns a semaphore of n slots; nil for n <= 0, which never
+// blocks.
+func callSlots(n int) chan struct{} {
+	if n <= 0 {
+		return nil
+	}
+	return make(chan struct{}, n)
+}
+
+// acquireSlot takes a slot of kind from slots and returns the function that
+// releases it. It fails with ctx's error when ctx is done first.
+func acquireSlot(ctx context.Context, kind string, slots chan struct{}) (func(), error) {
+	if slots == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for %s call slot: %w", kind, ctx.Err())
+	}
+	observability.RecordAICallSlot(kind, time.Since(start))
+	return func() {
+		<-slots
+		observability.ReleaseAICallSlot(kind)
+	}, nil
+}
+
+// Embed implements domain.AIClient.
+func (c *concurrencyLimitedClient) Embed(ctx domain.Context, texts []string) ([][]float32, error) {
+	release, err := acquireSlot(ctx, CallKindEmbed, c.embed)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.base.Embed(ctx, texts)
+}
+
+// ChatJSON implements domain.AIClient.
+func (c *concurrencyLimitedClient) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	release, err := acquireSlot(ctx, CallKindChat, c.chat)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return c.base.ChatJSON(ctx, systemPrompt, userPrompt, maxTokens)
+}
+
+// ChatJSONWithRetry implements domain.AIClient.
+func (c *concurrencyLimitedClient) ChatJSONWithRetry(ctx domain.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	release, err := acquireSlot(ctx, CallKindChat, c.chat)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return c.base.ChatJSONWithRetry(ctx, systemPrompt, userPrompt, maxTokens)
+}
+
+// CleanCoTResponse implements domain.AIClient.
+func (c *concurrencyLimitedClient) CleanCoTResponse(ctx domain.Context, response string) (string, error) {
+	release, err := acquireSlot(ctx, CallKindCoTClean, c.cotClean)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return c.base.CleanCoTResponse(ctx, response)
+}
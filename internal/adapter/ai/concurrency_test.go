@@ -0,0 +1,67 @@
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// blockingAI blocks embeddings until unblock is closed.
+type blockingAI struct {
+	embedding chan struct{}
+	unblock   chan struct{}
+}
+
+func (b *blockingAI) Embed(domain.Context, []string) ([][]float32, error) {
+	b.embedding <- struct{}{}
+	<-b.unblock
+	return [][]float32{{1}}, nil
+}
+
+func (b *blockingAI) ChatJSON(domain.Context, string, string, int) (string, error) {
+	return `{}`, nil
+}
+
+func (b *blockingAI) ChatJSONWithRetry(domain.Context, string, string, int) (string, error) {
+	return `{}`, nil
+}
+
+func (b *blockingAI) CleanCoTResponse(_ domain.Context, s string) (string, error) { return s, nil }
+
+func TestConcurrencyLimitedClient(t *testing.T) {
+	base := &blockingAI{embedding: make(chan struct{}, 1), unblock: make(chan struct{})}
+	assert.Same(t, domain.AIClient(base), NewConcurrencyLimitedClient(base, ConcurrencyLimits{}))
+	c := NewConcurrencyLimitedClient(base, ConcurrencyLimits{Embed: 1, Chat: 1, CoTClean: 1})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.Embed(context.Background(), []string{"a"})
+		done <- err
+	}()
+	<-base.embedding
+
+	// The embedding slot is taken, but chat and cleaning calls have their own.
+	_, err := c.ChatJSON(context.Background(), "s", "u", 10)
+	require.NoError(t, err)
+	_, err = c.ChatJSONWithRetry(context.Background(), "s", "u", 10)
+	require.NoError(t, err)
+	_, err = c.CleanCoTResponse(context.Background(), "x")
+	require.NoError(t, err)
+
+	// Another embedding waits for the slot until its context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = c.Embed(ctx, []string{"b"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(base.unblock)
+	require.NoError(t, <-done)
+	go func() { <-base.embedding }()
+	_, err = c.Embed(context.Background(), []string{"c"})
+	require.NoError(t, err)
+}
@@ -0,0 +1,93 @@
+package ai
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisEmbedStore persists embeddings in Redis as little-endian float32
+// blobs so that cached vectors survive restarts and are shared between the
+// server and worker processes.
+type RedisEmbedStore struct {
+	rdb    *redis.Client
+	prefix string
+}
+
+// NewRedisEmbedStore creates a store that namespaces keys with prefix.
+// It returns nil when rdb is nil.
+func NewRedisEmbedStore(rdb *redis.Client, prefix string) *RedisEmbedStore {
+	if rdb == nil {
+		return nil
+	}
+	return &RedisEmbedStore{rdb: rdb, prefix: prefix}
+}
+
+// GetMany fetches vectors with a single MGET. Malformed values are skipped.
+func (s *RedisEmbedStore) GetMany(ctx context.Context, keys []string) (map[string][]float32, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	full := make([]string, len(keys))
+	for i, k := range keys {
+		full[i] = s.prefix + k
+	}
+	vals, err := s.rdb.MGet(ctx, full...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("op=embedstore.get: %w", err)
+	}
+	out := make(map[string][]float32, len(vals))
+	for i, v := range vals {
+		str, ok := v.(string)
+		if !ok {
+			continue
+		}
+		vec, err := decodeVector([]byte(str))
+		if err != nil {
+			continue
+		}
+		out[keys[i]] = vec
+	}
+	return out, nil
+}
+
+// SetMany writes vectors in one pipeline. A ttl of 0 stores without expiry.
+func (s *RedisEmbedStore) SetMany(ctx context.Context, entries map[string][]float32, ttl time.Duration) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	pipe := s.rdb.Pipeline()
+	for k, vec := range entries {
+		pipe.Set(ctx, s.prefix+k, encodeVector(vec), ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("op=embedstore.set: %w", err)
+	}
+	return nil
+}
+
+var errBadVector = errors.New("malformed vector")
+
+func encodeVector(vec []float32) []byte {
+	buf := make([]byte, len(vec)*4)
+	for i, f := range vec {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(f))
+	}
+	return buf
+}
+
+func decodeVector(b []byte) ([]float32, error) {
+	if len(b) == 0 || len(b)%4 != 0 {
+		return nil, errBadVector
+	}
+	vec := make([]float32, len(b)/4)
+	for i := range vec {
+		vec[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[i*4:]))
+	}
+	return vec, nil
+}
@@ -0,0 +1,59 @@
+package ai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisEmbedStore_RoundTrip(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+	s := NewRedisEmbedStore(rdb, "emb:m:")
+	ctx := context.Background()
+
+	if err := s.SetMany(ctx, map[string][]float32{"k1": {0.5, -1.25, 3}}, time.Minute); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if !mr.Exists("emb:m:k1") || mr.TTL("emb:m:k1") != time.Minute {
+		t.Fatalf("expected prefixed key with ttl, ttl=%v", mr.TTL("emb:m:k1"))
+	}
+	mr.Set("emb:m:bad", "xyz")
+
+	got, err := s.GetMany(ctx, []string{"k1", "missing", "bad"})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected only k1, got %v", got)
+	}
+	v := got["k1"]
+	if len(v) != 3 || v[0] != 0.5 || v[1] != -1.25 || v[2] != 3 {
+		t.Fatalf("unexpected vector %v", v)
+	}
+}
+
+func TestRedisEmbedStore_Errors(t *testing.T) {
+	if NewRedisEmbedStore(nil, "x") != nil {
+		t.Fatalf("expected nil store for nil client")
+	}
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer func() { _ = rdb.Close() }()
+	s := NewRedisEmbedStore(rdb, "p:")
+	mr.Close()
+	ctx := context.Background()
+	if _, err := s.GetMany(ctx, []string{"a"}); err == nil {
+		t.Fatalf("expected get error")
+	}
+	if err := s.SetMany(ctx, map[string][]float32{"a": {1}}, 0); err == nil {
+		t.Fatalf("expected set error")
+	}
+	if got, err := s.GetMany(ctx, nil); err != nil || got != nil {
+		t.Fatalf("expected no-op for empty keys")
+	}
+}
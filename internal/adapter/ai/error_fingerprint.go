@@ -0,0 +1,153 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// providerErrorMessageMax bounds the provider message kept with a failed call.
+const providerErrorMessageMax = 300
+
+// providerErrorRules map phrases of provider error codes, types and messages
+// to fingerprints. The first rule with a matching phrase wins, so the more
+// specific causes come before quota and rate limits, which providers mention
+// in passing.
+var providerErrorRules = []struct {
+	fingerprint domain.ProviderErrorFingerprint
+	phrases     []string
+}{
+	{domain.ProviderErrorContextLength, []string{"context_length", "context length", "context window", "maximum context", "prompt is too long", "reduce the length"}},
+	{domain.ProviderErrorContentFilter, []string{"content_filter", "content filter", "content policy", "content management policy", "moderation", "flagged", "safety system"}},
+	{domain.ProviderErrorModelNotFound, []string{"model_not_found", "model not found", "no endpoints found", "not a valid model", "invalid model", "model_decommissioned", "has been decommissioned", "does not exist"}},
+	{domain.ProviderErrorQuota, []string{"insufficient_quota", "quota", "credits", "billing", "payment required", "per-day", "per day"}},
+	{domain.ProviderErrorRateLimited, []string{"rate_limit", "rate limit", "too many requests"}},
+	{domain.ProviderErrorAuth, []string{"invalid_api_key", "invalid api key", "incorrect api key", "unauthorized", "authentication", "no auth credentials"}},
+}
+
+// FingerprintProviderError classifies the response of a failed provider call
+// from its status and error body, and returns the provider's error message.
+// OpenAI-style {"error":{"message","type","code"}} bodies, OpenRouter's
+// upstream error in error.metadata.raw and plain-text bodies are understood;
+// the status decides when the body names no known cause.
+func FingerprintProviderError(status int, body string) (domain.ProviderErrorFingerprint, string) {
+	msg, text := parseProviderErrorBody(body)
+	text = strings.ToLower(text)
+	for _, rule := range providerErrorRules {
+		for _, p := range rule.phrases {
+			if strings.Contains(text, p) {
+				return rule.fingerprint, msg
+			}
+		}
+	}
+	switch {
+	case status == http.StatusPaymentRequired:
+		return domain.ProviderErrorQuota, msg
+	case status == http.StatusTooManyRequests:
+		return domain.ProviderErrorRateLimited, msg
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return domain.ProviderErrorAuth, msg
+	case status == http.StatusNotFound:
+		return domain.ProviderErrorModelNotFound, msg
+	case status >= 500:
+		return domain.ProviderErrorUnavailable, msg
+	case status >= 400:
+		return domain.ProviderErrorInvalidRequest, msg
+	}
+	return domain.ProviderErrorUnknown, msg
+}
+
+// parseProviderErrorBody returns the error message of body and the text to
+// classify, which adds the error code, type and upstream error to it.
+func parseProviderErrorBody(body string) (msg, text string) {
+	body = strings.TrimSpace(body)
+	var env struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(body), &env); err != nil {
+		return truncateProviderMessage(body), body
+	}
+	var detail struct {
+		Message  string `json:"message"`
+		Type     string `json:"type"`
+		Code     any    `json:"code"`
+		Metadata struct {
+			Raw string `json:"raw"`
+		} `json:"metadata"`
+	}
+	var plain string
+	switch {
+	case json.Unmarshal(env.Error, &plain) == nil:
+		msg = plain
+	case json.Unmarshal(env.Error, &detail) == nil:
+		msg = detail.Message
+		text = fmt.Sprint(detail.Type, " ", detail.Code, " ", detail.Metadata.Raw)
+	}
+	if msg == "" {
+		msg = env.Message
+	}
+	if msg == "" && text == "" {
+		msg = body
+	}
+	return truncateProviderMessage(msg), msg + " " + text
+}
+
+func truncateProviderMessage(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if len(msg) > providerErrorMessageMax {
+		msg = msg[:providerErrorMessageMax]
+	}
+	return msg
+}
+
+// ProviderErrorLog fingerprints failed provider calls, counts them in
+// metrics and, when a store is attached, persists them so the most frequent
+// failures of every process can be listed. A nil ProviderErrorLog only
+// counts them.
+type ProviderErrorLog struct {
+	store domain.ProviderErrorRepository
+	now   func() time.Time
+}
+
+// NewProviderErrorLog creates a log persisting failed calls to store.
+func NewProviderErrorLog(store domain.ProviderErrorRepository) *ProviderErrorLog {
+	return &ProviderErrorLog{store: store, now: time.Now}
+}
+
+// Record fingerprints a failed call of op to model answered with status and
+// body, stores it with the evaluation job and step of ctx, and returns the
+// fingerprint.
+func (l *ProviderErrorLog) Record(ctx context.Context, provider, op, model string, status int, body string) domain.ProviderErrorFingerprint {
+	fp, msg := FingerprintProviderError(status, body)
+	observability.RecordAIProviderError(provider, string(fp))
+	if l == nil || l.store == nil {
+		return fp
+	}
+	e := domain.ProviderError{
+		Provider:    provider,
+		Op:          op,
+		Model:       model,
+		Status:      status,
+		Fingerprint: fp,
+		Message:     msg,
+		JobID:       domain.EvaluationJobFrom(ctx),
+		Step:        domain.EvaluationStepFrom(ctx),
+		At:          l.now().UTC(),
+	}
+	// The call may have failed because its context ran out; the failure is
+	// still recorded.
+	storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Second)
+	defer cancel()
+	if err := l.store.Record(storeCtx, e); err != nil {
+		slog.Warn("failed to persist provider error", slog.String("provider", provider), slog.String("fingerprint", string(fp)), slog.Any("error", err))
+	}
+	return fp
+}
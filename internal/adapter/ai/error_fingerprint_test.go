@@ -0,0 +1,92 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestFingerprintProviderError(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		want   domain.ProviderErrorFingerprint
+		msg    string
+	}{
+		{400, `{"error":{"message":"This model's maximum context length is 8192 tokens.","type":"invalid_request_error","code":"context_length_exceeded"}}`,
+			domain.ProviderErrorContextLength, "This model's maximum context length is 8192 tokens."},
+		{404, `{"error":{"message":"No endpoints found for meta-llama/llama-3-8b:free.","code":404}}`,
+			domain.ProviderErrorModelNotFound, "No endpoints found for meta-llama/llama-3-8b:free."},
+		{400, `{"error":{"message":"The model ` + "`llama3-70b-8192`" + ` has been decommissioned","type":"invalid_request_error","code":"model_decommissioned"}}`,
+			domain.ProviderErrorModelNotFound, "The model `llama3-70b-8192` has been decommissioned"},
+		{403, `{"error":{"message":"Provider returned error","code":403,"metadata":{"raw":"{\"error\":\"flagged by moderation\"}"}}}`,
+			domain.ProviderErrorContentFilter, "Provider returned error"},
+		{429, `{"error":{"message":"Rate limit exceeded: free-models-per-day.","code":429}}`,
+			domain.ProviderErrorQuota, "Rate limit exceeded: free-models-per-day."},
+		{429, `{"error":{"message":"You exceeded your current quota","type":"insufficient_quota"}}`,
+			domain.ProviderErrorQuota, "You exceeded your current quota"},
+		{429, ``, domain.ProviderErrorRateLimited, ""},
+		{401, `{"error":"No auth credentials found"}`, domain.ProviderErrorAuth, "No auth credentials found"},
+		{422, `{"message":"temperature out of range"}`, domain.ProviderErrorInvalidRequest, "temperature out of range"},
+		{502, `<html>Bad Gateway</html>`, domain.ProviderErrorUnavailable, "<html>Bad Gateway</html>"},
+		{302, ``, domain.ProviderErrorUnknown, ""},
+	}
+	for _, tc := range cases {
+		fp, msg := FingerprintProviderError(tc.status, tc.body)
+		assert.Equal(t, tc.want, fp, tc.body)
+		assert.Equal(t, tc.msg, msg, tc.body)
+	}
+
+	_, msg := FingerprintProviderError(400, `{"error":{"message":"`+strings.Repeat("x", 1000)+`"}}`)
+	assert.Len(t, msg, providerErrorMessageMax)
+}
+
+type stubProviderErrors struct {
+	recorded []domain.ProviderError
+	err      error
+}
+
+func (s *stubProviderErrors) Record(_ domain.Context, e domain.ProviderError) error {
+	s.recorded = append(s.recorded, e)
+	return s.err
+}
+
+func (s *stubProviderErrors) Top(domain.Context, time.Time, int) ([]domain.ProviderErrorCount, error) {
+	return nil, nil
+}
+
+func (s *stubProviderErrors) Buckets(domain.Context, time.Time, time.Duration) ([]domain.ProviderErrorBucket, error) {
+	return nil, nil
+}
+
+func TestProviderErrorLog_Record(t *testing.T) {
+	at := time.Date(2026, 1, 4, 9, 0, 0, 0, time.UTC)
+	store := &stubProviderErrors{}
+	l := NewProviderErrorLog(store)
+	l.now = func() time.Time { return at }
+
+	// A call failing after its evaluation ran out of time is still stored.
+	ctx, cancel := context.WithCancel(domain.WithEvaluationStep(domain.WithEvaluationJob(context.Background(), "job-1"), "cv_match", "v2"))
+	cancel()
+	fp := l.Record(ctx, ProviderGroq, "chat", "llama-3.1-8b-instant", 404, `{"error":{"message":"model not found","code":"model_not_found"}}`)
+	assert.Equal(t, domain.ProviderErrorModelNotFound, fp)
+	require.Len(t, store.recorded, 1)
+	assert.Equal(t, domain.ProviderError{
+		Provider: ProviderGroq, Op: "chat", Model: "llama-3.1-8b-instant", Status: 404,
+		Fingerprint: domain.ProviderErrorModelNotFound, Message: "model not found",
+		JobID: "job-1", Step: "cv_match", At: at,
+	}, store.recorded[0])
+
+	// Store failures and a nil log only cost the stored record.
+	store.err = errors.New("db down")
+	assert.Equal(t, domain.ProviderErrorUnavailable, l.Record(context.Background(), ProviderGroq, "chat", "m", 503, ""))
+	var none *ProviderErrorLog
+	assert.Equal(t, domain.ProviderErrorRateLimited, none.Record(context.Background(), ProviderGroq, "chat", "m", 429, ""))
+}
@@ -4,7 +4,9 @@ package freemodels
 import (
 	"context"
 	"log/slog"
+	"time"
 
+	aiadapter "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/real"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
@@ -29,13 +31,9 @@ func NewFreeModelWrapperWithLimiter(cfg config.Config, lim ratelimiter.Limiter)
 	// Create the underlying real client
 	realClient := real.NewWithLimiter(cfg, lim)
 
-	// Create free models service with configurable refresh interval.
-	// Prefer primary OpenRouter key but fall back to OPENROUTER_API_KEY_2 when
-	// only the secondary key is configured.
-	openRouterKey := cfg.OpenRouterAPIKey
-	if openRouterKey == "" {
-		openRouterKey = cfg.OpenRouterAPIKey2
-	}
+	// Create free models service with configurable refresh interval. Model
+	// discovery uses the first configured, non-disabled OpenRouter key.
+	openRouterKey := cfg.PrimaryProviderKey("openrouter")
 	freeModelsSvc := freemodels.NewService(openRouterKey, cfg.OpenRouterBaseURL, cfg.FreeModelsRefresh)
 
 	return &FreeModelWrapper{
@@ -45,6 +43,42 @@ func NewFreeModelWrapperWithLimiter(cfg config.Config, lim ratelimiter.Limiter)
 	}
 }
 
+// WithKeyRing makes the underlying client rotate across the keys of r
+// instead of the ones read from configuration.
+func (w *FreeModelWrapper) WithKeyRing(r *aiadapter.KeyRing) *FreeModelWrapper {
+	if rc, ok := w.client.(*real.Client); ok {
+		rc.WithKeyRing(r)
+	}
+	return w
+}
+
+// WithModelLimits makes the underlying client order and fall back across
+// Groq models using l instead of limits read from configuration only.
+func (w *FreeModelWrapper) WithModelLimits(l *aiadapter.ModelLimits) *FreeModelWrapper {
+	if rc, ok := w.client.(*real.Client); ok {
+		rc.WithModelLimits(l)
+	}
+	return w
+}
+
+// WithProviderErrors makes the underlying client store the fingerprints of
+// failed provider calls in l.
+func (w *FreeModelWrapper) WithProviderErrors(l *aiadapter.ProviderErrorLog) *FreeModelWrapper {
+	if rc, ok := w.client.(*real.Client); ok {
+		rc.WithProviderErrors(l)
+	}
+	return w
+}
+
+// Saturation reports whether every chat model of the underlying client is
+// blocked after rate limits and how long until the first is usable again.
+func (w *FreeModelWrapper) Saturation(ctx context.Context) (time.Duration, bool) {
+	if rc, ok := w.client.(*real.Client); ok {
+		return rc.Saturation(ctx)
+	}
+	return 0, false
+}
+
 // ChatJSON implements domain.AIClient using free models with automatic fallback.
 func (w *FreeModelWrapper) ChatJSON(ctx context.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
 	// The real client now handles free model selection dynamically
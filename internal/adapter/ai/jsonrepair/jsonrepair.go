@@ -0,0 +1,173 @@
+// Package jsonrepair fixes common defects in JSON produced by language
+// models (trailing commas, raw line breaks in strings, single-quoted
+// strings, truncated documents) without another model round-trip.
+package jsonrepair
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Repair stages, applied cumulatively in this order.
+const (
+	// StageNone means the extracted document was already valid.
+	StageNone = "none"
+	// StageTrailingCommas removes commas directly before '}' or ']'.
+	StageTrailingCommas = "trailing_commas"
+	// StageControlChars escapes line breaks and other control characters
+	// written raw inside strings, as models do in multi-line Markdown.
+	StageControlChars = "control_chars"
+	// StageSingleQuotes converts single-quoted strings to double-quoted ones.
+	StageSingleQuotes = "single_quotes"
+	// StageBalance closes open strings and brackets of a truncated document.
+	StageBalance = "balance"
+)
+
+// Repair extracts the JSON document embedded in s (dropping surrounding
+// prose or code fences) and applies the repair stages until it parses. It
+// returns the repaired document and the stage that made it valid, or false
+// when no stage succeeds.
+func Repair(s string) (string, string, bool) {
+	start := strings.IndexAny(s, "{[")
+	if start < 0 {
+		return "", "", false
+	}
+	body := s[start:]
+	candidate := body
+	if end := strings.LastIndexAny(body, "}]"); end >= 0 {
+		candidate = body[:end+1]
+	}
+	if json.Valid([]byte(candidate)) {
+		return candidate, StageNone, true
+	}
+	if out := RemoveTrailingCommas(candidate); json.Valid([]byte(out)) {
+		return out, StageTrailingCommas, true
+	}
+	if out := EscapeControlChars(RemoveTrailingCommas(candidate)); json.Valid([]byte(out)) {
+		return out, StageControlChars, true
+	}
+	if out := EscapeControlChars(RemoveTrailingCommas(ConvertSingleQuotes(candidate))); json.Valid([]byte(out)) {
+		return out, StageSingleQuotes, true
+	}
+	if out, ok := CloseTruncated(EscapeControlChars(RemoveTrailingCommas(ConvertSingleQuotes(body)))); ok {
+		return out, StageBalance, true
+	}
+	return "", "", false
+}
+
+// RemoveTrailingCommas drops commas that are followed only by whitespace and
+// a closing '}' or ']'. Commas inside strings are kept.
+func RemoveTrailingCommas(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			}
+			b.WriteByte(ch)
+			continue
+		}
+		if ch == '"' {
+			inString = true
+		}
+		if ch == ',' {
+			j := i + 1
+			for j < len(s) && strings.IndexByte(" \t\r\n", s[j]) >= 0 {
+				j++
+			}
+			if j < len(s) && (s[j] == '}' || s[j] == ']') {
+				continue
+			}
+		}
+		b.WriteByte(ch)
+	}
+	return b.String()
+}
+
+// EscapeControlChars escapes the control characters inside double-quoted
+// strings, which JSON does not allow raw. Whitespace between tokens is kept.
+func EscapeControlChars(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	inString, escaped := false, false
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if !inString {
+			inString = ch == '"'
+			b.WriteByte(ch)
+			continue
+		}
+		switch {
+		case escaped:
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '"':
+			inString = false
+		case ch == '\n':
+			b.WriteString(`\n`)
+			continue
+		case ch == '\r':
+			b.WriteString(`\r`)
+			continue
+		case ch == '\t':
+			b.WriteString(`\t`)
+			continue
+		case ch < 0x20:
+			fmt.Fprintf(&b, `\u%04x`, ch)
+			continue
+		}
+		b.WriteByte(ch)
+	}
+	return b.String()
+}
+
+// ConvertSingleQuotes rewrites single-quoted strings as double-quoted JSON
+// strings, escaping embedded double quotes. Apostrophes inside
+// double-quoted strings are left alone.
+func ConvertSingleQuotes(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	var quote byte // 0 outside strings, otherwise the opening quote
+	escaped := false
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case quote == 0:
+			if ch == '"' || ch == '\'' {
+				quote = ch
+				ch = '"'
+			}
+			b.WriteByte(ch)
+		case escaped:
+			escaped = false
+			b.WriteByte(ch)
+		case ch == '\\':
+			if quote == '\'' && i+1 < len(s) && s[i+1] == '\'' {
+				// \' is not a valid JSON escape.
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			escaped = true
+			b.WriteByte(ch)
+		case ch == quote:
+			quote = 0
+			b.WriteByte('"')
+		case quote == '\'' && ch == '"':
+			b.WriteString(`\"`)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepair(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		want  string
+		stage string
+	}{
+		{"valid with prose", "Result: {\"a\":1} done", `{"a":1}`, StageNone},
+		{"trailing commas", "```json\n{\"a\":[1,2,],\"b\":\"x,}\",}\n```", `{"a":[1,2],"b":"x,}"}`, StageTrailingCommas},
+		{"raw line breaks", "{\"a\":\"### CV\n- good\r\n\t- fine\",\n\"b\":1,}", `{"a":"### CV\n- good\r\n\t- fine","b":1}`, StageControlChars},
+		{"single quotes", `{'a':'it\'s','b':'say "hi"','c':"don't",}`, `{"a":"it's","b":"say \"hi\"","c":"don't"}`, StageSingleQuotes},
+		{"truncated", `{"a":{"b":[1,2`, `{"a":{"b":[1,2]}}`, StageBalance},
+		{"truncated single quotes", `{'a':'x','b':'unterminated`, `{"a":"x","b":"unterminated"}`, StageBalance},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, stage, ok := Repair(tt.in)
+			require.True(t, ok)
+			assert.Equal(t, tt.stage, stage)
+			assert.JSONEq(t, tt.want, got)
+			assert.True(t, json.Valid([]byte(got)))
+		})
+	}
+
+	_, _, ok := Repair("no json here")
+	assert.False(t, ok)
+	_, _, ok = Repair(`{"a" "b"}`)
+	assert.False(t, ok)
+}
+
+func TestRemoveTrailingCommas(t *testing.T) {
+	assert.Equal(t, "{\"a\":[1 ],\"b\":\", ]\"\n}", RemoveTrailingCommas("{\"a\":[1, ],\"b\":\", ]\",\n}"))
+	assert.Equal(t, `{"a":"\",}"}`, RemoveTrailingCommas(`{"a":"\",}"}`))
+}
+
+func TestEscapeControlChars(t *testing.T) {
+	assert.Equal(t, "{\n\"a\":\"x\\ny\\u0001\",\n\"b\":\"\\\"\\n\"}", EscapeControlChars("{\n\"a\":\"x\ny\x01\",\n\"b\":\"\\\"\\n\"}"))
+}
+
+func TestConvertSingleQuotes(t *testing.T) {
+	assert.Equal(t, `{"a":"b"}`, ConvertSingleQuotes(`{'a':'b'}`))
+	assert.Equal(t, `{"a":"it's \"x\""}`, ConvertSingleQuotes(`{"a":"it's \"x\""}`))
+	assert.Equal(t, `["a\\","b"]`, ConvertSingleQuotes(`['a\\','b']`))
+}
@@ -0,0 +1,96 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// CloseTruncated turns the beginning of a JSON document, as left behind by
+// an interrupted generation, into valid JSON. Text before the first '{' or
+// '[' is dropped. An open string is closed and open containers are closed in
+// order; if that does not parse, the document is cut back to the last
+// element separator and closed there. It reports false when nothing valid
+// can be recovered.
+func CloseTruncated(s string) (string, bool) {
+	start := strings.IndexAny(s, "{[")
+	if start < 0 {
+		return "", false
+	}
+	body := s[start:]
+
+	type cut struct {
+		pos     int
+		closers string
+	}
+	var (
+		stack    []byte
+		cuts     []cut
+		inString bool
+		escaped  bool
+	)
+	closers := func() string {
+		b := make([]byte, len(stack))
+		for i := range stack {
+			b[i] = stack[len(stack)-1-i]
+		}
+		return string(b)
+	}
+	for i := 0; i < len(body); i++ {
+		ch := body[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == '"':
+				inString = false
+			}
+			continue
+		}
+		switch ch {
+		case '"':
+			inString = true
+		case '{':
+			stack = append(stack, '}')
+		case '[':
+			stack = append(stack, ']')
+		case '}', ']':
+			if len(stack) == 0 || stack[len(stack)-1] != ch {
+				return "", false
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				doc := body[:i+1]
+				return doc, json.Valid([]byte(doc))
+			}
+		case ',':
+			cuts = append(cuts, cut{pos: i, closers: closers()})
+		}
+	}
+
+	// Close the document where it stopped.
+	tail := body
+	if inString {
+		if escaped {
+			tail = tail[:len(tail)-1]
+		}
+		tail += `"`
+	}
+	tail = strings.TrimRight(tail, " \t\r\n")
+	tail = strings.TrimSuffix(tail, ",")
+	if strings.HasSuffix(tail, ":") {
+		tail += "null"
+	}
+	if doc := tail + closers(); json.Valid([]byte(doc)) {
+		return doc, true
+	}
+
+	// Drop the incomplete trailing element(s).
+	for i := len(cuts) - 1; i >= 0; i-- {
+		if doc := body[:cuts[i].pos] + cuts[i].closers; json.Valid([]byte(doc)) {
+			return doc, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,40 @@
+package jsonrepair
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloseTruncated(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+		ok   bool
+	}{
+		{"complete", `{"a":1} trailing`, `{"a":1}`, true},
+		{"open_string_value", `{"a":1,"b":"hel`, `{"a":1,"b":"hel"}`, true},
+		{"nested_open", "```json\n{\"a\":{\"b\":[1,2", `{"a":{"b":[1,2]}}`, true},
+		{"dangling_key", `{"a":1,"sco`, `{"a":1}`, true},
+		{"dangling_colon", `{"a":1,"b":`, `{"a":1,"b":null}`, true},
+		{"dangling_comma", `{"a":[1,2],`, `{"a":[1,2]}`, true},
+		{"partial_literal", `{"a":1,"b":tru`, `{"a":1}`, true},
+		{"escaped_quote", `{"a":"x\"y`, `{"a":"x\"y"}`, true},
+		{"pending_escape", `{"a":"x\`, `{"a":"x"}`, true},
+		{"no_json", `I cannot help with that`, "", false},
+		{"mismatched", `{"a":[1}`, "", false},
+		{"nothing_recoverable", `{"a`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CloseTruncated(tt.in)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
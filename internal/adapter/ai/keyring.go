@@ -0,0 +1,681 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Provider names used for key rotation.
+const (
+	ProviderOpenRouter = "openrouter"
+	ProviderGroq       = "groq"
+)
+
+// KeyID returns the stable, non-secret identifier of an API key.
+func KeyID(secret string) string {
+	h := sha256.Sum256([]byte(strings.TrimSpace(secret)))
+	return "k_" + hex.EncodeToString(h[:6])
+}
+
+// KeyRing rotates requests across the API keys of each provider.
+//
+// Active keys share traffic by weight using smooth weighted round robin;
+// draining keys are only handed out when no active key is usable, which
+// gives a retiring key an overlap window; disabled keys are never used.
+// Keys blocked after a 429 are skipped until the block expires.
+//
+// Keys come from configuration and, when a store is attached, from the
+// ai_provider_keys table, whose rows add keys or override the weight and
+// state of configured ones. Sync reloads the table so changes made through
+// the admin API reach every process without a restart.
+//
+// With budgets configured, each key's requests and tokens are counted per
+// UTC day (shared through the usage store) and a key that reaches its
+// provider's budget is skipped until the next day. With balancing enabled,
+// keys and providers are also weighted by the share of their daily budget
+// that is left, so accounts run down together instead of one after another.
+// KeyRing is safe for concurrent use.
+type KeyRing struct {
+	store      domain.ProviderKeyRepository
+	usageStore domain.KeyUsageRepository
+	budgets    map[string]config.KeyBudget
+	balance    bool
+	now        func() time.Time
+
+	mu      sync.Mutex
+	base    []domain.ProviderKey
+	keys    []*ringKey
+	byID    map[string]*ringKey
+	blocked map[string]time.Time // by key ID, kept across syncs
+	day     time.Time            // UTC day of usage
+	usage   map[string]domain.KeyUsage
+	// providerCurrent is the smooth weighted round robin accumulator of
+	// ProviderOrder, by provider.
+	providerCurrent map[string]float64
+}
+
+type ringKey struct {
+	domain.ProviderKey
+	current int // smooth weighted round robin accumulator
+}
+
+// NewKeyRing builds a ring from the given keys. IDs are derived from the
+// secrets when empty; keys without a secret are ignored.
+func NewKeyRing(keys []domain.ProviderKey) *KeyRing {
+	r := &KeyRing{now: time.Now, blocked: map[string]time.Time{}, usage: map[string]domain.KeyUsage{}, providerCurrent: map[string]float64{}}
+	for _, k := range keys {
+		if k = normalizeKey(k); k.Secret != "" {
+			r.base = append(r.base, k)
+		}
+	}
+	r.rebuildLocked(nil)
+	return r
+}
+
+// NewKeyRingFromConfig builds a ring from the configured key pools.
+func NewKeyRingFromConfig(cfg config.Config) *KeyRing {
+	specs := cfg.GetProviderKeys()
+	keys := make([]domain.ProviderKey, 0, len(specs))
+	for _, s := range specs {
+		keys = append(keys, domain.ProviderKey{
+			Provider: s.Provider,
+			Secret:   s.Secret,
+			Weight:   s.Weight,
+			State:    domain.ProviderKeyState(s.State),
+		})
+	}
+	return NewKeyRing(keys).WithUsage(nil, cfg.GetProviderKeyBudgets()).WithBalancing(cfg.AIProviderBalancing)
+}
+
+// WithStore attaches a repository used to persist admin changes and to load
+// them in Sync.
+func (r *KeyRing) WithStore(store domain.ProviderKeyRepository) *KeyRing {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.store = store
+	return r
+}
+
+// WithUsage attaches a repository for daily usage counters and the per-key
+// budgets of each provider. A nil store counts usage in this process only.
+func (r *KeyRing) WithUsage(store domain.KeyUsageRepository, budgets map[string]config.KeyBudget) *KeyRing {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if store != nil {
+		r.usageStore = store
+	}
+	if budgets != nil {
+		r.budgets = budgets
+	}
+	return r
+}
+
+// WithBalancing turns on weighting keys and providers by their remaining
+// daily budget. Without it keys rotate by configured weight only and
+// ProviderOrder keeps the caller's order.
+func (r *KeyRing) WithBalancing(on bool) *KeyRing {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.balance = on
+	return r
+}
+
+// Sync reloads stored keys and merges them over the configured ones, then
+// reloads today's usage so budgets account for calls made by other processes.
+func (r *KeyRing) Sync(ctx context.Context) error {
+	r.mu.Lock()
+	store, usageStore := r.store, r.usageStore
+	r.mu.Unlock()
+	if store != nil {
+		rows, err := store.List(ctx)
+		if err != nil {
+			return fmt.Errorf("op=keyring.sync: %w", err)
+		}
+		r.mu.Lock()
+		r.rebuildLocked(rows)
+		r.mu.Unlock()
+	}
+	if usageStore != nil {
+		day := utcDay(r.now())
+		rows, err := usageStore.ListDay(ctx, day)
+		if err != nil {
+			return fmt.Errorf("op=keyring.sync_usage: %w", err)
+		}
+		r.mu.Lock()
+		r.rollDayLocked(day)
+		for _, u := range rows {
+			cur := r.usage[u.KeyID]
+			u.Requests = max(u.Requests, cur.Requests)
+			u.Tokens = max(u.Tokens, cur.Tokens)
+			r.usage[u.KeyID] = u
+		}
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+// RecordUsage counts one completed provider call and its tokens against the
+// key with the given secret. When the usage store is unavailable the call is
+// still counted locally.
+func (r *KeyRing) RecordUsage(ctx context.Context, secret string, tokens int64) {
+	id := KeyID(secret)
+	now := r.now()
+	day := utcDay(now)
+	r.mu.Lock()
+	k, ok := r.byID[id]
+	usageStore := r.usageStore
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+	var stored *domain.KeyUsage
+	if usageStore != nil {
+		u, err := usageStore.Add(ctx, id, day, 1, tokens)
+		if err != nil {
+			slog.Warn("provider key usage not persisted", slog.String("key_id", id), slog.Any("error", err))
+		} else {
+			stored = &u
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rollDayLocked(day)
+	wasExhausted := r.exhaustedLocked(k)
+	u := r.usage[id]
+	u.KeyID, u.Day = id, day
+	u.Requests++
+	u.Tokens += tokens
+	if stored != nil {
+		u.Requests = max(u.Requests, stored.Requests)
+		u.Tokens = max(u.Tokens, stored.Tokens)
+	}
+	r.usage[id] = u
+	observability.SetAIKeyDailyUsage(k.Provider, id, u.Requests, u.Tokens)
+	if !wasExhausted && r.exhaustedLocked(k) {
+		observability.RecordAIKeyBudgetExhausted(k.Provider)
+		slog.Warn("provider key reached its daily budget; skipping it until tomorrow",
+			slog.String("provider", k.Provider),
+			slog.String("key_id", id),
+			slog.Int64("requests", u.Requests),
+			slog.Int64("tokens", u.Tokens))
+	}
+}
+
+// Exhausted reports whether provider has keys and every non-disabled one has
+// reached its daily budget.
+func (r *KeyRing) Exhausted(provider string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rollDayLocked(utcDay(r.now()))
+	found := false
+	for _, k := range r.keys {
+		if k.Provider != provider || k.State == domain.ProviderKeyDisabled {
+			continue
+		}
+		if !r.exhaustedLocked(k) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// Usage returns today's usage of the key with the given ID.
+func (r *KeyRing) Usage(id string) domain.KeyUsage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rollDayLocked(utcDay(r.now()))
+	return r.usage[id]
+}
+
+// RunSync calls Sync every interval until ctx is done. Failures are logged
+// and the previous key set stays in use.
+func (r *KeyRing) RunSync(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := r.Sync(ctx); err != nil {
+				slog.Warn("provider key sync failed", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// Candidates returns the secrets of provider's usable keys in the order they
+// should be tried: active keys starting with the weighted round robin pick,
+// then draining keys. Blocked, disabled and over-budget keys are omitted.
+// Candidates does not advance the rotation; callers report the key they send
+// a request with to Use.
+func (r *KeyRing) Candidates(provider string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	active, draining, _ := r.usableLocked(provider)
+	out := make([]string, 0, len(active)+len(draining))
+	if len(active) > 0 {
+		var pick *ringKey
+		for _, k := range active {
+			if pick == nil || k.current+r.effectiveWeightLocked(k) > pick.current+r.effectiveWeightLocked(pick) {
+				pick = k
+			}
+		}
+		out = append(out, pick.Secret)
+		for _, k := range active {
+			if k != pick {
+				out = append(out, k.Secret)
+			}
+		}
+	}
+	for _, k := range draining {
+		out = append(out, k.Secret)
+	}
+	return out
+}
+
+// Use advances the weighted round robin of the key's provider because a
+// request is being sent with the key with the given secret. Draining and
+// unknown keys do not take part in the rotation and are ignored.
+func (r *KeyRing) Use(secret string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	chosen, ok := r.byID[KeyID(secret)]
+	if !ok || chosen.State != domain.ProviderKeyActive {
+		return
+	}
+	active, _, total := r.usableLocked(chosen.Provider)
+	for _, k := range active {
+		k.current += r.effectiveWeightLocked(k)
+	}
+	chosen.current -= total
+}
+
+// usableLocked returns provider's active and draining keys that are neither
+// blocked nor over budget, and the total effective weight of the active ones.
+func (r *KeyRing) usableLocked(provider string) (active, draining []*ringKey, total int) {
+	now := r.now()
+	r.rollDayLocked(utcDay(now))
+	for _, k := range r.keys {
+		if k.Provider != provider || r.isBlockedLocked(k.ID, now) || r.exhaustedLocked(k) {
+			continue
+		}
+		switch k.State {
+		case domain.ProviderKeyActive:
+			active = append(active, k)
+			total += r.effectiveWeightLocked(k)
+		case domain.ProviderKeyDraining:
+			draining = append(draining, k)
+		}
+	}
+	return active, draining, total
+}
+
+// ProviderOrder returns providers in the order they should be tried. With
+// balancing enabled, providers that have a usable key are ordered by smooth
+// weighted round robin over their capacity: the sum of their active and
+// draining keys' weights, each scaled by the share of its daily budget that
+// is left. Providers without usable keys (all blocked, disabled or over
+// budget) follow in the given order. Without balancing the given order is
+// returned unchanged.
+func (r *KeyRing) ProviderOrder(providers ...string) []string {
+	out := append([]string(nil), providers...)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.balance || len(providers) < 2 {
+		return out
+	}
+	now := r.now()
+	r.rollDayLocked(utcDay(now))
+	capacity := make(map[string]float64, len(providers))
+	for _, k := range r.keys {
+		if k.State == domain.ProviderKeyDisabled || r.isBlockedLocked(k.ID, now) {
+			continue
+		}
+		capacity[k.Provider] += float64(k.Weight) * r.remainingLocked(k)
+	}
+	var usable, idle []string
+	total := 0.0
+	for _, p := range providers {
+		if capacity[p] > 0 {
+			usable = append(usable, p)
+			total += capacity[p]
+		} else {
+			idle = append(idle, p)
+		}
+	}
+	if len(usable) == 0 {
+		return out
+	}
+	pick := ""
+	for _, p := range usable {
+		r.providerCurrent[p] += capacity[p]
+		if pick == "" || r.providerCurrent[p] > r.providerCurrent[pick] {
+			pick = p
+		}
+	}
+	r.providerCurrent[pick] -= total
+	out = append(out[:0], pick)
+	rest := make([]string, 0, len(usable)-1)
+	for _, p := range usable {
+		if p != pick {
+			rest = append(rest, p)
+		}
+	}
+	sort.SliceStable(rest, func(i, j int) bool { return capacity[rest[i]] > capacity[rest[j]] })
+	out = append(out, rest...)
+	return append(out, idle...)
+}
+
+// Next returns the key to try first for a single request: the first candidate, or
+// when every key is blocked the first non-disabled key within budget so the
+// caller can surface the provider's own rate-limit error. It returns "" when
+// provider has no usable key.
+func (r *KeyRing) Next(provider string) string {
+	if c := r.Candidates(provider); len(c) > 0 {
+		return c[0]
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, k := range r.keys {
+		if k.Provider == provider && k.State != domain.ProviderKeyDisabled && !r.exhaustedLocked(k) {
+			return k.Secret
+		}
+	}
+	return ""
+}
+
+// Configured reports whether provider has at least one non-disabled key.
+func (r *KeyRing) Configured(provider string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, k := range r.keys {
+		if k.Provider == provider && k.State != domain.ProviderKeyDisabled {
+			return true
+		}
+	}
+	return false
+}
+
+// Block excludes the key with the given secret from Candidates for d.
+// Unknown secrets are ignored.
+func (r *KeyRing) Block(secret string, d time.Duration) {
+	id := KeyID(secret)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byID[id]; ok {
+		r.blocked[id] = r.now().Add(d)
+	}
+}
+
+// IsBlocked reports whether the key with the given secret is blocked.
+func (r *KeyRing) IsBlocked(secret string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.isBlockedLocked(KeyID(secret), r.now())
+}
+
+// BlockedFor returns how long until one of provider's keys that is neither
+// disabled nor over budget can be used again: 0 when one is usable now. ok
+// is false when provider has no such key.
+func (r *KeyRing) BlockedFor(provider string) (d time.Duration, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	r.rollDayLocked(utcDay(now))
+	for _, k := range r.keys {
+		if k.Provider != provider || k.State == domain.ProviderKeyDisabled || r.exhaustedLocked(k) {
+			continue
+		}
+		if !r.isBlockedLocked(k.ID, now) {
+			return 0, true
+		}
+		if wait := r.blocked[k.ID].Sub(now); !ok || wait < d {
+			d = wait
+		}
+		ok = true
+	}
+	return d, ok
+}
+
+// List returns a copy of all keys in rotation order.
+func (r *KeyRing) List() []domain.ProviderKey {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]domain.ProviderKey, 0, len(r.keys))
+	for _, k := range r.keys {
+		out = append(out, k.ProviderKey)
+	}
+	return out
+}
+
+// Provider returns the provider of the key with the given ID, or "" when
+// the key is unknown.
+func (r *KeyRing) Provider(id string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if k, ok := r.byID[id]; ok {
+		return k.Provider
+	}
+	return ""
+}
+
+// Add registers a new key, persisting it first when a store is attached.
+// Adding an existing secret returns domain.ErrConflict.
+func (r *KeyRing) Add(ctx context.Context, k domain.ProviderKey) (domain.ProviderKey, error) {
+	k = normalizeKey(k)
+	if err := validateKey(k); err != nil {
+		return domain.ProviderKey{}, err
+	}
+	if k.Secret == "" {
+		return domain.ProviderKey{}, fmt.Errorf("%w: secret is required", domain.ErrInvalidArgument)
+	}
+	r.mu.Lock()
+	_, exists := r.byID[k.ID]
+	store := r.store
+	r.mu.Unlock()
+	if exists {
+		return domain.ProviderKey{}, fmt.Errorf("%w: key %s already exists", domain.ErrConflict, k.ID)
+	}
+	now := r.now().UTC()
+	k.CreatedAt, k.UpdatedAt = now, now
+	if store != nil {
+		if err := store.Upsert(ctx, k); err != nil {
+			return domain.ProviderKey{}, fmt.Errorf("op=keyring.add: %w", err)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byID[k.ID]; !ok {
+		rk := &ringKey{ProviderKey: k}
+		r.keys = append(r.keys, rk)
+		r.byID[k.ID] = rk
+	}
+	return r.byID[k.ID].ProviderKey, nil
+}
+
+// Update changes the weight and/or state of the key with the given ID. Nil
+// arguments leave the field unchanged. The secret is never written for
+// configured keys; the stored row only carries the override.
+func (r *KeyRing) Update(ctx context.Context, id string, weight *int, state *domain.ProviderKeyState) (domain.ProviderKey, error) {
+	r.mu.Lock()
+	rk, ok := r.byID[id]
+	var k domain.ProviderKey
+	if ok {
+		k = rk.ProviderKey
+	}
+	store := r.store
+	r.mu.Unlock()
+	if !ok {
+		return domain.ProviderKey{}, fmt.Errorf("%w: key %s", domain.ErrNotFound, id)
+	}
+	if weight != nil {
+		k.Weight = *weight
+	}
+	if state != nil {
+		k.State = *state
+	}
+	if err := validateKey(k); err != nil {
+		return domain.ProviderKey{}, err
+	}
+	k.UpdatedAt = r.now().UTC()
+	if store != nil {
+		row := k
+		row.Secret = ""
+		if err := store.Upsert(ctx, row); err != nil {
+			return domain.ProviderKey{}, fmt.Errorf("op=keyring.update: %w", err)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rk, ok := r.byID[id]; ok {
+		rk.Weight, rk.State, rk.UpdatedAt = k.Weight, k.State, k.UpdatedAt
+		rk.current = 0
+	}
+	return k, nil
+}
+
+// rebuildLocked recomputes the key set from the configured keys and rows,
+// preserving round robin progress of keys that keep their ID.
+func (r *KeyRing) rebuildLocked(rows []domain.ProviderKey) {
+	prev := r.byID
+	r.keys = nil
+	r.byID = map[string]*ringKey{}
+	put := func(k domain.ProviderKey) {
+		if rk, ok := r.byID[k.ID]; ok {
+			if k.Secret == "" {
+				k.Secret = rk.Secret
+			}
+			if k.CreatedAt.IsZero() {
+				k.CreatedAt = rk.CreatedAt
+			}
+			rk.ProviderKey = k
+			return
+		}
+		if k.Secret == "" {
+			return // override for a key that is no longer configured
+		}
+		rk := &ringKey{ProviderKey: k}
+		if old, ok := prev[k.ID]; ok {
+			rk.current = old.current
+		}
+		r.keys = append(r.keys, rk)
+		r.byID[k.ID] = rk
+	}
+	for _, k := range r.base {
+		put(k)
+	}
+	for _, k := range rows {
+		k.Provider = strings.ToLower(strings.TrimSpace(k.Provider))
+		if k.Weight < 1 || !k.State.Valid() {
+			continue
+		}
+		put(k)
+	}
+	for id := range r.blocked {
+		if _, ok := r.byID[id]; !ok {
+			delete(r.blocked, id)
+		}
+	}
+	sort.SliceStable(r.keys, func(i, j int) bool { return r.keys[i].Provider < r.keys[j].Provider })
+}
+
+// exhaustedLocked reports whether k reached its provider's daily budget.
+func (r *KeyRing) exhaustedLocked(k *ringKey) bool {
+	b, ok := r.budgets[k.Provider]
+	if !ok {
+		return false
+	}
+	u := r.usage[k.ID]
+	return (b.Requests > 0 && u.Requests >= b.Requests) || (b.Tokens > 0 && u.Tokens >= b.Tokens)
+}
+
+// remainingLocked returns the share of k's daily budget that is left, from
+// 0 to 1, taking the tighter of the request and token budgets. Keys of
+// providers without a budget always have 1.
+func (r *KeyRing) remainingLocked(k *ringKey) float64 {
+	b, ok := r.budgets[k.Provider]
+	if !ok {
+		return 1
+	}
+	u := r.usage[k.ID]
+	left := 1.0
+	if b.Requests > 0 {
+		left = min(left, 1-float64(u.Requests)/float64(b.Requests))
+	}
+	if b.Tokens > 0 {
+		left = min(left, 1-float64(u.Tokens)/float64(b.Tokens))
+	}
+	return max(left, 0)
+}
+
+// effectiveWeightLocked returns k's round robin weight: the configured
+// weight, or with balancing that weight in thousandths scaled by the share
+// of the budget left. Keys within budget keep a weight of at least 1.
+func (r *KeyRing) effectiveWeightLocked(k *ringKey) int {
+	if !r.balance {
+		return k.Weight
+	}
+	return max(int(float64(k.Weight*1000)*r.remainingLocked(k)), 1)
+}
+
+// rollDayLocked resets usage counters when the UTC day changes.
+func (r *KeyRing) rollDayLocked(day time.Time) {
+	if !r.day.Equal(day) {
+		r.day = day
+		r.usage = map[string]domain.KeyUsage{}
+	}
+}
+
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (r *KeyRing) isBlockedLocked(id string, now time.Time) bool {
+	until, ok := r.blocked[id]
+	return ok && now.Before(until)
+}
+
+func normalizeKey(k domain.ProviderKey) domain.ProviderKey {
+	k.Secret = strings.TrimSpace(k.Secret)
+	k.Provider = strings.ToLower(strings.TrimSpace(k.Provider))
+	if k.ID == "" && k.Secret != "" {
+		k.ID = KeyID(k.Secret)
+	}
+	if k.Weight == 0 {
+		k.Weight = 1
+	}
+	if k.State == "" {
+		k.State = domain.ProviderKeyActive
+	}
+	return k
+}
+
+func validateKey(k domain.ProviderKey) error {
+	if k.Provider != ProviderOpenRouter && k.Provider != ProviderGroq {
+		return fmt.Errorf("%w: unknown provider %q", domain.ErrInvalidArgument, k.Provider)
+	}
+	if k.Weight < 1 {
+		return fmt.Errorf("%w: weight must be >= 1", domain.ErrInvalidArgument)
+	}
+	if !k.State.Valid() {
+		return fmt.Errorf("%w: unknown state %q", domain.ErrInvalidArgument, k.State)
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+)
+
+func TestKeyRing_WeightedRotation(t *testing.T) {
+	r := NewKeyRing([]domain.ProviderKey{
+		{Provider: ProviderGroq, Secret: "a", Weight: 3},
+		{Provider: ProviderGroq, Secret: "b", Weight: 1},
+		{Provider: ProviderOpenRouter, Secret: "o"},
+	})
+	counts := map[string]int{}
+	for i := 0; i < 8; i++ {
+		c := r.Candidates(ProviderGroq)
+		require.Len(t, c, 2)
+		// Asking again without sending a request keeps the same pick.
+		assert.Equal(t, c, r.Candidates(ProviderGroq))
+		r.Use(c[0])
+		counts[c[0]]++
+	}
+	assert.Equal(t, 6, counts["a"])
+	assert.Equal(t, 2, counts["b"])
+	assert.Equal(t, []string{"o"}, r.Candidates(ProviderOpenRouter))
+	assert.Equal(t, ProviderGroq, r.Provider(KeyID("b")))
+	assert.Equal(t, "", r.Provider("k_missing"))
+}
+
+func TestKeyRing_UseAdvancesTheChosenKey(t *testing.T) {
+	r := NewKeyRing([]domain.ProviderKey{
+		{Provider: ProviderGroq, Secret: "a"},
+		{Provider: ProviderGroq, Secret: "b"},
+		{Provider: ProviderGroq, Secret: "old", State: domain.ProviderKeyDraining},
+	})
+	assert.Equal(t, []string{"a", "b", "old"}, r.Candidates(ProviderGroq))
+	// The first pick failed over to b: b is debited, so a stays first.
+	r.Use("b")
+	assert.Equal(t, []string{"a", "b", "old"}, r.Candidates(ProviderGroq))
+	r.Use("a")
+	r.Use("old")
+	r.Use("unknown")
+	assert.Equal(t, []string{"a", "b", "old"}, r.Candidates(ProviderGroq))
+	r.Use("a")
+	assert.Equal(t, []string{"b", "a", "old"}, r.Candidates(ProviderGroq))
+}
+
+func TestKeyRing_StatesAndBlocks(t *testing.T) {
+	r := NewKeyRing([]domain.ProviderKey{
+		{Provider: ProviderGroq, Secret: "old", State: domain.ProviderKeyDraining},
+		{Provider: ProviderGroq, Secret: "new"},
+		{Provider: ProviderGroq, Secret: "off", State: domain.ProviderKeyDisabled},
+	})
+	assert.Equal(t, []string{"new", "old"}, r.Candidates(ProviderGroq))
+
+	r.Block("new", time.Minute)
+	assert.True(t, r.IsBlocked("new"))
+	assert.Equal(t, []string{"old"}, r.Candidates(ProviderGroq))
+
+	r.Block("old", time.Minute)
+	assert.Empty(t, r.Candidates(ProviderGroq))
+	assert.True(t, r.Configured(ProviderGroq))
+	assert.Equal(t, "old", r.Next(ProviderGroq), "falls back to first non-disabled key when all are blocked")
+
+	r.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	assert.False(t, r.IsBlocked("new"))
+	assert.False(t, r.Configured(ProviderOpenRouter))
+	assert.Equal(t, "", r.Next(ProviderOpenRouter))
+}
+
+func TestKeyRing_BlockedFor(t *testing.T) {
+	now := time.Date(2026, 1, 7, 9, 0, 0, 0, time.UTC)
+	r := NewKeyRing([]domain.ProviderKey{
+		{Provider: ProviderGroq, Secret: "a"},
+		{Provider: ProviderGroq, Secret: "b"},
+		{Provider: ProviderGroq, Secret: "off", State: domain.ProviderKeyDisabled},
+	})
+	r.now = func() time.Time { return now }
+
+	d, ok := r.BlockedFor(ProviderGroq)
+	assert.True(t, ok)
+	assert.Zero(t, d)
+	r.Block("a", 3*time.Minute)
+	d, _ = r.BlockedFor(ProviderGroq)
+	assert.Zero(t, d)
+	r.Block("b", time.Minute)
+	d, ok = r.BlockedFor(ProviderGroq)
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, d)
+
+	_, ok = r.BlockedFor(ProviderOpenRouter)
+	assert.False(t, ok)
+}
+
+func TestKeyRing_AddUpdatePersist(t *testing.T) {
+	store := mocks.NewMockProviderKeyRepository(t)
+	r := NewKeyRing([]domain.ProviderKey{{Provider: ProviderOpenRouter, Secret: "cfg"}}).WithStore(store)
+	ctx := context.Background()
+
+	store.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(k domain.ProviderKey) bool {
+		return k.Secret == "added" && k.Weight == 2 && k.ID == KeyID("added")
+	})).Return(nil).Once()
+	added, err := r.Add(ctx, domain.ProviderKey{Provider: ProviderOpenRouter, Secret: "added", Weight: 2})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProviderKeyActive, added.State)
+
+	_, err = r.Add(ctx, domain.ProviderKey{Provider: ProviderOpenRouter, Secret: "added"})
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	_, err = r.Add(ctx, domain.ProviderKey{Provider: "acme", Secret: "x"})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	disabled := domain.ProviderKeyDisabled
+	store.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(k domain.ProviderKey) bool {
+		return k.ID == KeyID("cfg") && k.Secret == "" && k.State == domain.ProviderKeyDisabled
+	})).Return(nil).Once()
+	_, err = r.Update(ctx, KeyID("cfg"), nil, &disabled)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"added"}, r.Candidates(ProviderOpenRouter))
+
+	_, err = r.Update(ctx, "k_missing", nil, &disabled)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	store.EXPECT().Upsert(mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+	_, err = r.Update(ctx, KeyID("added"), nil, &disabled)
+	require.Error(t, err)
+	assert.Equal(t, []string{"added"}, r.Candidates(ProviderOpenRouter), "failed persistence leaves the key unchanged")
+}
+
+func TestKeyRing_Sync(t *testing.T) {
+	store := mocks.NewMockProviderKeyRepository(t)
+	r := NewKeyRing([]domain.ProviderKey{{Provider: ProviderGroq, Secret: "cfg"}}).WithStore(store)
+
+	store.EXPECT().List(mock.Anything).Return([]domain.ProviderKey{
+		{ID: KeyID("cfg"), Provider: ProviderGroq, Weight: 1, State: domain.ProviderKeyDraining},
+		{ID: KeyID("db"), Provider: ProviderGroq, Secret: "db", Weight: 1, State: domain.ProviderKeyActive},
+		{ID: "k_gone", Provider: ProviderGroq, Weight: 1, State: domain.ProviderKeyDisabled},
+	}, nil).Once()
+	require.NoError(t, r.Sync(context.Background()))
+	assert.Equal(t, []string{"db", "cfg"}, r.Candidates(ProviderGroq))
+	assert.Len(t, r.List(), 2)
+
+	store.EXPECT().List(mock.Anything).Return(nil, errors.New("db down")).Once()
+	require.Error(t, r.Sync(context.Background()))
+	assert.Len(t, r.List(), 2, "failed sync keeps previous keys")
+
+	store.EXPECT().List(mock.Anything).Return(nil, nil).Once()
+	require.NoError(t, r.Sync(context.Background()))
+	assert.Equal(t, []string{"cfg"}, r.Candidates(ProviderGroq), "removed rows revert to configuration")
+}
+
+func TestKeyRing_DailyBudget(t *testing.T) {
+	store := mocks.NewMockKeyUsageRepository(t)
+	r := NewKeyRing([]domain.ProviderKey{
+		{Provider: ProviderGroq, Secret: "a"},
+		{Provider: ProviderGroq, Secret: "b"},
+	}).WithUsage(store, map[string]config.KeyBudget{ProviderGroq: {Requests: 2, Tokens: 1000}})
+	day := time.Date(2025, 12, 4, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return day.Add(10 * time.Hour) }
+	ctx := context.Background()
+
+	// Another process already used key a once; the store returns shared totals.
+	store.EXPECT().Add(mock.Anything, KeyID("a"), day, int64(1), int64(100)).
+		Return(domain.KeyUsage{KeyID: KeyID("a"), Day: day, Requests: 2, Tokens: 300}, nil).Once()
+	r.RecordUsage(ctx, "a", 100)
+	assert.Equal(t, []string{"b"}, r.Candidates(ProviderGroq))
+	assert.False(t, r.Exhausted(ProviderGroq))
+	assert.Equal(t, int64(2), r.Usage(KeyID("a")).Requests)
+
+	// Store failures still count locally.
+	store.EXPECT().Add(mock.Anything, KeyID("b"), day, int64(1), int64(2000)).
+		Return(domain.KeyUsage{}, errors.New("db down")).Once()
+	r.RecordUsage(ctx, "b", 2000)
+	assert.Empty(t, r.Candidates(ProviderGroq))
+	assert.True(t, r.Exhausted(ProviderGroq))
+	assert.Equal(t, "", r.Next(ProviderGroq))
+
+	// Budgets reset on the next UTC day.
+	r.now = func() time.Time { return day.Add(25 * time.Hour) }
+	assert.False(t, r.Exhausted(ProviderGroq))
+	assert.Len(t, r.Candidates(ProviderGroq), 2)
+}
+
+func TestKeyRing_SyncUsage(t *testing.T) {
+	store := mocks.NewMockKeyUsageRepository(t)
+	r := NewKeyRing([]domain.ProviderKey{{Provider: ProviderOpenRouter, Secret: "o"}}).
+		WithUsage(store, map[string]config.KeyBudget{ProviderOpenRouter: {Tokens: 500}})
+	day := time.Date(2025, 12, 4, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return day.Add(time.Hour) }
+
+	store.EXPECT().ListDay(mock.Anything, day).
+		Return([]domain.KeyUsage{{KeyID: KeyID("o"), Day: day, Requests: 9, Tokens: 800}}, nil).Once()
+	require.NoError(t, r.Sync(context.Background()))
+	assert.True(t, r.Exhausted(ProviderOpenRouter))
+	assert.False(t, r.Exhausted(ProviderGroq), "provider without keys is not exhausted")
+}
+
+func TestKeyRing_ProviderOrderBalancing(t *testing.T) {
+	keys := []domain.ProviderKey{
+		{Provider: ProviderGroq, Secret: "g"},
+		{Provider: ProviderOpenRouter, Secret: "o1"},
+		{Provider: ProviderOpenRouter, Secret: "o2"},
+	}
+	assert.Equal(t, []string{ProviderGroq, ProviderOpenRouter},
+		NewKeyRing(keys).ProviderOrder(ProviderGroq, ProviderOpenRouter), "fixed order without balancing")
+
+	r := NewKeyRing(keys).WithUsage(nil, map[string]config.KeyBudget{ProviderGroq: {Requests: 4}}).WithBalancing(true)
+	day := time.Date(2025, 12, 4, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return day.Add(time.Hour) }
+	counts := map[string]int{}
+	for i := 0; i < 6; i++ {
+		order := r.ProviderOrder(ProviderGroq, ProviderOpenRouter)
+		require.Len(t, order, 2)
+		counts[order[0]]++
+	}
+	assert.Equal(t, 2, counts[ProviderGroq], "groq has one key, openrouter two")
+	assert.Equal(t, 4, counts[ProviderOpenRouter])
+
+	// Groq's only key has used its whole budget; it goes last.
+	for i := 0; i < 4; i++ {
+		r.RecordUsage(context.Background(), "g", 10)
+	}
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, []string{ProviderOpenRouter, ProviderGroq}, r.ProviderOrder(ProviderGroq, ProviderOpenRouter))
+	}
+
+	// Blocked keys count as no capacity.
+	r.Block("o1", time.Minute)
+	r.Block("o2", time.Minute)
+	assert.Equal(t, []string{ProviderGroq, ProviderOpenRouter}, r.ProviderOrder(ProviderGroq, ProviderOpenRouter))
+}
+
+func TestKeyRing_BalancingWeightsKeysByRemainingBudget(t *testing.T) {
+	r := NewKeyRing([]domain.ProviderKey{
+		{Provider: ProviderGroq, Secret: "a"},
+		{Provider: ProviderGroq, Secret: "b"},
+	}).WithUsage(nil, map[string]config.KeyBudget{ProviderGroq: {Requests: 100}}).WithBalancing(true)
+	day := time.Date(2025, 12, 4, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return day.Add(time.Hour) }
+	// Key a has used 75% of its budget, b none: b is picked three times as often.
+	for i := 0; i < 75; i++ {
+		r.RecordUsage(context.Background(), "a", 1)
+	}
+	counts := map[string]int{}
+	for i := 0; i < 8; i++ {
+		k := r.Candidates(ProviderGroq)[0]
+		r.Use(k)
+		counts[k]++
+	}
+	assert.Equal(t, 2, counts["a"])
+	assert.Equal(t, 6, counts["b"])
+}
@@ -0,0 +1,205 @@
+package ai
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+)
+
+// MinModelHealthScore is the score below which a model is only tried after
+// every healthier model.
+const MinModelHealthScore = 20
+
+// Weights of the health signals in the score. They add up to 1.
+const (
+	healthWeightErrors   = 0.35
+	healthWeightRefusals = 0.20
+	healthWeightParse    = 0.20
+	healthWeightLatency  = 0.25
+)
+
+const (
+	// healthAlpha is the weight of the newest sample in the moving averages.
+	healthAlpha = 0.2
+	// healthHalfLife is how fast the penalty of a model that is no longer
+	// called fades, so demoted models are eventually tried again.
+	healthHalfLife = 10 * time.Minute
+	// Latencies up to healthLatencyGood score fully, latencies from
+	// healthLatencyBad on score nothing.
+	healthLatencyGood = 10 * time.Second
+	healthLatencyBad  = 60 * time.Second
+)
+
+// modelHealthStats are the moving averages of one model's signals.
+type modelHealthStats struct {
+	errorRate   float64
+	refusalRate float64
+	parseFail   float64
+	latency     float64 // seconds; 0 until the first sample
+	updated     time.Time
+}
+
+// ModelHealth scores AI models from 0 (unusable) to 100 (healthy) by
+// combining exponentially weighted averages of their error and timeout rate,
+// refusal rate, JSON parse failure rate and latency. Model selection weighs
+// models by their score, and every change is exported as the
+// ai_model_health_score gauge. Models without samples score 100. ModelHealth
+// is safe for concurrent use; a nil ModelHealth scores every model 100.
+type ModelHealth struct {
+	mu     sync.Mutex
+	models map[string]*modelHealthStats
+	now    func() time.Time
+	rand   func() float64
+}
+
+// NewModelHealth creates an empty tracker.
+func NewModelHealth() *ModelHealth {
+	return &ModelHealth{models: map[string]*modelHealthStats{}, now: time.Now, rand: rand.Float64}
+}
+
+// DefaultModelHealth is the tracker shared by the AI client, which records
+// calls and refusals, and the evaluation handler, which records parse
+// outcomes.
+var DefaultModelHealth = NewModelHealth()
+
+func ewma(avg, sample float64) float64 {
+	return avg + healthAlpha*(sample-avg)
+}
+
+func boolSample(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// record applies f to model's stats and publishes the new score.
+func (h *ModelHealth) record(model string, f func(s *modelHealthStats)) {
+	if h == nil || model == "" {
+		return
+	}
+	h.mu.Lock()
+	s, ok := h.models[model]
+	if !ok {
+		s = &modelHealthStats{}
+		h.models[model] = s
+	}
+	f(s)
+	s.updated = h.now()
+	score := h.scoreLocked(s)
+	h.mu.Unlock()
+	observability.SetAIModelHealthScore(model, score)
+}
+
+// RecordSuccess records a call of model answered after latency.
+func (h *ModelHealth) RecordSuccess(model string, latency time.Duration) {
+	h.record(model, func(s *modelHealthStats) {
+		s.errorRate = ewma(s.errorRate, 0)
+		s.observeLatency(latency)
+	})
+}
+
+// RecordFailure records a failed or timed-out call of model. latency is the
+// time the call took, or 0 when unknown.
+func (h *ModelHealth) RecordFailure(model string, latency time.Duration) {
+	h.record(model, func(s *modelHealthStats) {
+		s.errorRate = ewma(s.errorRate, 1)
+		if latency > 0 {
+			s.observeLatency(latency)
+		}
+	})
+}
+
+// RecordRefusal records whether a response of model was a refusal.
+func (h *ModelHealth) RecordRefusal(model string, refused bool) {
+	h.record(model, func(s *modelHealthStats) { s.refusalRate = ewma(s.refusalRate, boolSample(refused)) })
+}
+
+// RecordParse records whether a response of model parsed as the expected
+// JSON without repairs.
+func (h *ModelHealth) RecordParse(model string, ok bool) {
+	h.record(model, func(s *modelHealthStats) { s.parseFail = ewma(s.parseFail, boolSample(!ok)) })
+}
+
+func (s *modelHealthStats) observeLatency(d time.Duration) {
+	if s.latency == 0 {
+		s.latency = d.Seconds()
+		return
+	}
+	s.latency = ewma(s.latency, d.Seconds())
+}
+
+// scoreLocked computes the score of s, fading the penalty with the time
+// since its last sample.
+func (h *ModelHealth) scoreLocked(s *modelHealthStats) float64 {
+	latencyScore := 1.0
+	if s.latency > healthLatencyGood.Seconds() {
+		latencyScore = max(0, 1-(s.latency-healthLatencyGood.Seconds())/(healthLatencyBad-healthLatencyGood).Seconds())
+	}
+	raw := healthWeightErrors*(1-s.errorRate) +
+		healthWeightRefusals*(1-s.refusalRate) +
+		healthWeightParse*(1-s.parseFail) +
+		healthWeightLatency*latencyScore
+	fade := math.Pow(0.5, float64(h.now().Sub(s.updated))/float64(healthHalfLife))
+	return 100 - (100-100*raw)*fade
+}
+
+// Score returns the health score of model.
+func (h *ModelHealth) Score(model string) float64 {
+	if h == nil {
+		return 100
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	s, ok := h.models[model]
+	if !ok {
+		return 100
+	}
+	return h.scoreLocked(s)
+}
+
+// Order returns models in the order to try them: a random draw weighted by
+// score, so healthier models are tried first more often without starving the
+// others, followed by the models scoring below MinModelHealthScore, worst
+// last.
+func (h *ModelHealth) Order(models []string) []string {
+	if h == nil {
+		return append([]string(nil), models...)
+	}
+	type ranked struct {
+		id    string
+		score float64
+		key   float64
+	}
+	rs := make([]ranked, len(models))
+	h.mu.Lock()
+	for i, m := range models {
+		score := 100.0
+		if s, ok := h.models[m]; ok {
+			score = h.scoreLocked(s)
+		}
+		// Weighted sampling without replacement: sorting by u^(1/w)
+		// descending draws each model with probability proportional to w.
+		rs[i] = ranked{id: m, score: score, key: math.Pow(h.rand(), 1/max(score, 1))}
+	}
+	h.mu.Unlock()
+	sort.SliceStable(rs, func(i, j int) bool {
+		hi, hj := rs[i].score >= MinModelHealthScore, rs[j].score >= MinModelHealthScore
+		if hi != hj {
+			return hi
+		}
+		if !hi {
+			return rs[i].score > rs[j].score
+		}
+		return rs[i].key > rs[j].key
+	})
+	out := make([]string, len(rs))
+	for i, r := range rs {
+		out[i] = r.id
+	}
+	return out
+}
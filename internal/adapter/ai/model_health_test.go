@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestModelHealth(now *time.Time) *ModelHealth {
+	h := NewModelHealth()
+	h.now = func() time.Time { return *now }
+	return h
+}
+
+func TestModelHealth_Score(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := newTestModelHealth(&now)
+
+	assert.Equal(t, 100.0, h.Score("unknown"))
+
+	h.RecordSuccess("fast", 2*time.Second)
+	h.RecordParse("fast", true)
+	assert.Equal(t, 100.0, h.Score("fast"))
+
+	// Latency alone: 35s is halfway between the good and bad bounds.
+	h.RecordSuccess("slow", 35*time.Second)
+	assert.InDelta(t, 87.5, h.Score("slow"), 0.001)
+
+	for range 5 {
+		h.RecordFailure("flaky", 0)
+		h.RecordRefusal("flaky", true)
+		h.RecordParse("flaky", false)
+	}
+	assert.Less(t, h.Score("flaky"), 60.0)
+	assert.Greater(t, h.Score("flaky"), 0.0)
+
+	// A success moves the score back up.
+	before := h.Score("flaky")
+	h.RecordSuccess("flaky", time.Second)
+	assert.Greater(t, h.Score("flaky"), before)
+}
+
+func TestModelHealth_PenaltyFades(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := newTestModelHealth(&now)
+	for range 20 {
+		h.RecordFailure("m", 0)
+	}
+	penalty := 100 - h.Score("m")
+	now = now.Add(healthHalfLife)
+	assert.InDelta(t, penalty/2, 100-h.Score("m"), 0.001)
+}
+
+func TestModelHealth_Order(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := newTestModelHealth(&now)
+	for range 30 {
+		h.RecordFailure("bad", time.Minute)
+		h.RecordRefusal("bad", true)
+		h.RecordParse("bad", false)
+		h.RecordFailure("worse", 2*time.Minute)
+		h.RecordRefusal("worse", true)
+		h.RecordParse("worse", false)
+	}
+	h.RecordFailure("bad", 0)
+	h.RecordSuccess("bad", time.Minute)
+	assert.Less(t, h.Score("worse"), h.Score("bad"))
+	assert.Less(t, h.Score("bad"), float64(MinModelHealthScore))
+
+	for range 3 {
+		h.RecordFailure("meh", 0)
+	}
+	first := map[string]int{}
+	for range 2000 {
+		order := h.Order([]string{"worse", "meh", "good", "bad"})
+		assert.Equal(t, []string{"bad", "worse"}, order[2:], "unhealthy models go last, worst last")
+		first[order[0]]++
+	}
+	// "good" scores 100 and "meh" about 80, so good is drawn first about
+	// 100/180 of the time.
+	assert.InDelta(t, 2000*100/(100+h.Score("meh")), first["good"], 120)
+}
+
+func TestModelHealth_Nil(t *testing.T) {
+	var h *ModelHealth
+	h.RecordSuccess("m", time.Second)
+	h.RecordFailure("m", 0)
+	assert.Equal(t, 100.0, h.Score("m"))
+	assert.Equal(t, []string{"a", "b"}, h.Order([]string{"a", "b"}))
+}
@@ -0,0 +1,189 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ModelLimits tracks the rate limits of one provider's models.
+//
+// Limits are learned from the x-ratelimit-limit-requests (per day) and
+// x-ratelimit-limit-tokens (per minute) headers of provider responses and,
+// when a store is attached, persisted so every process and restart starts
+// from the latest known values. Configured overrides take precedence field
+// by field. ModelLimits is safe for concurrent use.
+type ModelLimits struct {
+	provider  string
+	overrides map[string]config.ModelLimitOverride
+	now       func() time.Time
+
+	mu         sync.RWMutex
+	store      domain.ModelLimitRepository
+	discovered map[string]domain.ModelLimit
+}
+
+// NewModelLimits creates an empty registry for provider with the given overrides.
+func NewModelLimits(provider string, overrides map[string]config.ModelLimitOverride) *ModelLimits {
+	if overrides == nil {
+		overrides = map[string]config.ModelLimitOverride{}
+	}
+	return &ModelLimits{
+		provider:   provider,
+		overrides:  overrides,
+		now:        time.Now,
+		discovered: map[string]domain.ModelLimit{},
+	}
+}
+
+// NewGroqModelLimitsFromConfig creates the Groq registry with the GROQ_MODEL_LIMITS overrides.
+func NewGroqModelLimitsFromConfig(cfg config.Config) *ModelLimits {
+	return NewModelLimits(ProviderGroq, cfg.GetGroqModelLimitOverrides())
+}
+
+// WithStore attaches a repository used to persist discovered limits and to load them in Load.
+func (m *ModelLimits) WithStore(store domain.ModelLimitRepository) *ModelLimits {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+	return m
+}
+
+// Load replaces the discovered limits with the stored ones.
+func (m *ModelLimits) Load(ctx context.Context) error {
+	m.mu.RLock()
+	store := m.store
+	m.mu.RUnlock()
+	if store == nil {
+		return nil
+	}
+	rows, err := store.List(ctx, m.provider)
+	if err != nil {
+		return fmt.Errorf("op=model_limits.load: %w", err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, l := range rows {
+		m.discovered[l.Model] = l
+		observability.SetAIModelLimit(m.provider, l.Model, l.RequestsPerDay, l.TokensPerMinute)
+	}
+	return nil
+}
+
+// Observe records the limits advertised in the response headers of a call to
+// model and persists them when they changed. Responses without limit headers
+// are ignored.
+func (m *ModelLimits) Observe(ctx context.Context, model string, h http.Header) {
+	model = strings.TrimSpace(model)
+	rpd, okR := parseLimitHeader(h.Get("x-ratelimit-limit-requests"))
+	tpm, okT := parseLimitHeader(h.Get("x-ratelimit-limit-tokens"))
+	if model == "" || (!okR && !okT) {
+		return
+	}
+	m.mu.Lock()
+	prev := m.discovered[model]
+	l := prev
+	if okR {
+		l.RequestsPerDay = rpd
+	}
+	if okT {
+		l.TokensPerMinute = tpm
+	}
+	if l.Model != "" && l.RequestsPerDay == prev.RequestsPerDay && l.TokensPerMinute == prev.TokensPerMinute {
+		m.mu.Unlock()
+		return
+	}
+	l.Provider, l.Model, l.UpdatedAt = m.provider, model, m.now().UTC()
+	m.discovered[model] = l
+	store := m.store
+	m.mu.Unlock()
+
+	slog.Info("discovered model rate limits",
+		slog.String("provider", m.provider),
+		slog.String("model", model),
+		slog.Int64("requests_per_day", l.RequestsPerDay),
+		slog.Int64("tokens_per_minute", l.TokensPerMinute))
+	observability.SetAIModelLimit(m.provider, model, l.RequestsPerDay, l.TokensPerMinute)
+	if store != nil {
+		if err := store.Upsert(ctx, l); err != nil {
+			slog.Warn("failed to persist model limits", slog.String("model", model), slog.Any("error", err))
+		}
+	}
+}
+
+// Limit returns the effective limit of model and whether anything is known about it.
+func (m *ModelLimits) Limit(model string) (domain.ModelLimit, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.limitLocked(model)
+}
+
+// Models returns every model with a known limit, highest capacity first.
+func (m *ModelLimits) Models() []string {
+	m.mu.RLock()
+	ids := make([]string, 0, len(m.discovered)+len(m.overrides))
+	for id := range m.discovered {
+		ids = append(ids, id)
+	}
+	for id := range m.overrides {
+		if _, ok := m.discovered[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	m.mu.RUnlock()
+	sort.Strings(ids)
+	m.Sort(ids)
+	return ids
+}
+
+// Sort orders models by capacity: tokens per minute, then requests per day,
+// both descending. Models without a known limit keep their relative order
+// after the known ones.
+func (m *ModelLimits) Sort(models []string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	sort.SliceStable(models, func(i, j int) bool {
+		li, oki := m.limitLocked(models[i])
+		lj, okj := m.limitLocked(models[j])
+		if oki != okj {
+			return oki
+		}
+		if li.TokensPerMinute != lj.TokensPerMinute {
+			return li.TokensPerMinute > lj.TokensPerMinute
+		}
+		return li.RequestsPerDay > lj.RequestsPerDay
+	})
+}
+
+func (m *ModelLimits) limitLocked(model string) (domain.ModelLimit, bool) {
+	l, ok := m.discovered[model]
+	if o, has := m.overrides[model]; has {
+		l.Provider, l.Model = m.provider, model
+		if o.RequestsPerDay > 0 {
+			l.RequestsPerDay = o.RequestsPerDay
+		}
+		if o.TokensPerMinute > 0 {
+			l.TokensPerMinute = o.TokensPerMinute
+		}
+		ok = true
+	}
+	return l, ok
+}
+
+func parseLimitHeader(v string) (int64, bool) {
+	n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
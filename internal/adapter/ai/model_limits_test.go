@@ -0,0 +1,76 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+)
+
+func limitHeaders(rpd, tpm string) http.Header {
+	h := http.Header{}
+	h.Set("x-ratelimit-limit-requests", rpd)
+	h.Set("x-ratelimit-limit-tokens", tpm)
+	return h
+}
+
+func TestModelLimits_ObservePersistsChanges(t *testing.T) {
+	store := mocks.NewMockModelLimitRepository(t)
+	m := NewModelLimits(ProviderGroq, nil).WithStore(store)
+	now := time.Date(2025, 12, 5, 9, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	store.EXPECT().Upsert(mock.Anything, domain.ModelLimit{
+		Provider: ProviderGroq, Model: "new-model", RequestsPerDay: 1000, TokensPerMinute: 12000, UpdatedAt: now,
+	}).Return(nil).Once()
+	m.Observe(ctx, "new-model", limitHeaders("1000", "12000"))
+	m.Observe(ctx, "new-model", limitHeaders("1000", "12000")) // unchanged: not persisted again
+	m.Observe(ctx, "new-model", http.Header{})                 // no headers: ignored
+
+	store.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(l domain.ModelLimit) bool {
+		return l.Model == "new-model" && l.RequestsPerDay == 1000 && l.TokensPerMinute == 6000
+	})).Return(errors.New("db down")).Once()
+	m.Observe(ctx, "new-model", limitHeaders("", "6000"))
+
+	l, ok := m.Limit("new-model")
+	require.True(t, ok)
+	assert.Equal(t, int64(6000), l.TokensPerMinute, "failed persistence still updates memory")
+	_, ok = m.Limit("other")
+	assert.False(t, ok)
+}
+
+func TestModelLimits_OverridesAndOrdering(t *testing.T) {
+	store := mocks.NewMockModelLimitRepository(t)
+	m := NewModelLimits(ProviderGroq, map[string]config.ModelLimitOverride{
+		"small":  {TokensPerMinute: 30000},
+		"pinned": {RequestsPerDay: 500, TokensPerMinute: 8000},
+	}).WithStore(store)
+
+	store.EXPECT().List(mock.Anything, ProviderGroq).Return([]domain.ModelLimit{
+		{Provider: ProviderGroq, Model: "small", RequestsPerDay: 14400, TokensPerMinute: 6000},
+		{Provider: ProviderGroq, Model: "big", RequestsPerDay: 1000, TokensPerMinute: 12000},
+	}, nil).Once()
+	require.NoError(t, m.Load(context.Background()))
+
+	l, _ := m.Limit("small")
+	assert.Equal(t, domain.ModelLimit{Provider: ProviderGroq, Model: "small", RequestsPerDay: 14400, TokensPerMinute: 30000}, l)
+	assert.Equal(t, []string{"small", "big", "pinned"}, m.Models())
+
+	models := []string{"unknown-a", "pinned", "unknown-b", "big"}
+	m.Sort(models)
+	assert.Equal(t, []string{"big", "pinned", "unknown-a", "unknown-b"}, models)
+
+	store.EXPECT().List(mock.Anything, ProviderGroq).Return(nil, errors.New("db down")).Once()
+	require.Error(t, m.Load(context.Background()))
+	assert.Len(t, m.Models(), 3, "failed load keeps known limits")
+}
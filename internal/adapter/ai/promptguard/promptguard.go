@@ -0,0 +1,144 @@
+// Package promptguard detects prompt-injection attempts in user-supplied
+// documents before their text is inserted into model prompts.
+package promptguard
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Mode selects what a Guard does with detected injection attempts.
+type Mode string
+
+// Guard modes.
+const (
+	// ModeOff disables scanning.
+	ModeOff Mode = "off"
+	// ModeFlag reports findings but leaves the text unchanged.
+	ModeFlag Mode = "flag"
+	// ModeSanitize reports findings and replaces the matched text.
+	ModeSanitize Mode = "sanitize"
+)
+
+// Replacement is the text that stands in for a sanitized match.
+const Replacement = "[removed: possible prompt injection]"
+
+// Finding describes one detected injection attempt.
+type Finding struct {
+	// Rule names the detection rule, e.g. "instruction_override".
+	Rule string
+	// Match is the offending text as it appeared in the document.
+	Match string
+}
+
+type rule struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// rules are ordered from most to least specific; the first rule that matches
+// a span claims it.
+var rules = []rule{
+	{"instruction_override", regexp.MustCompile(`(?i)\b(?:ignore|disregard|forget|override|bypass)\s+(?:all\s+|any\s+|every\s+)?(?:of\s+)?(?:the\s+|your\s+)?(?:previous|prior|above|earlier|preceding|original|system)\s+(?:instructions?|prompts?|rules?|directions?|guidelines?|context)`)},
+	{"prompt_extraction", regexp.MustCompile(`(?i)\b(?:reveal|print|show|repeat|output|display|leak|tell\s+me)\s+(?:me\s+)?(?:your|the)\s+(?:full\s+|original\s+|hidden\s+|initial\s+)?(?:system\s+prompt|system\s+message|instructions|prompt)`)},
+	{"role_hijack", regexp.MustCompile(`(?i)\b(?:you\s+are\s+now|from\s+now\s+on,?\s+you\s+(?:are|will|must)|new\s+instructions?\s*:|pretend\s+(?:to\s+be|you\s+are))`)},
+	{"score_manipulation", regexp.MustCompile(`(?i)\b(?:give|assign|award|rate|score)\s+(?:this\s+|the\s+)?(?:candidate|cv|resume|project|submission|me)\s+(?:a\s+|the\s+)?(?:perfect|maximum|max|full|highest|top)\b`)},
+	{"role_delimiter", regexp.MustCompile(`(?im)(?:<\|im_start\|>|<\|im_end\|>|<\|system\|>|\[/?INST\]|</?system>|^\s*(?:system|assistant)\s*:)`)},
+}
+
+// Guard scans and optionally sanitizes document text. A nil Guard is
+// disabled.
+type Guard struct {
+	mode Mode
+}
+
+// New returns a Guard for mode ("off", "flag" or "sanitize"). It returns nil
+// for "off"; unknown modes fall back to sanitize.
+func New(mode string) *Guard {
+	switch Mode(strings.ToLower(strings.TrimSpace(mode))) {
+	case ModeOff:
+		return nil
+	case ModeFlag:
+		return &Guard{mode: ModeFlag}
+	default:
+		return &Guard{mode: ModeSanitize}
+	}
+}
+
+// Mode returns the guard's mode.
+func (g *Guard) Mode() Mode {
+	if g == nil {
+		return ModeOff
+	}
+	return g.mode
+}
+
+// Scan returns the injection attempts found in text, in document order.
+func (g *Guard) Scan(text string) []Finding {
+	if g == nil || text == "" {
+		return nil
+	}
+	spans := find(text)
+	out := make([]Finding, len(spans))
+	for i, s := range spans {
+		out[i] = Finding{Rule: s.rule, Match: text[s.start:s.end]}
+	}
+	return out
+}
+
+// Apply scans text and, in sanitize mode, replaces every finding with
+// Replacement. It returns the text to use in prompts and the findings.
+func (g *Guard) Apply(text string) (string, []Finding) {
+	if g == nil || text == "" {
+		return text, nil
+	}
+	spans := find(text)
+	if len(spans) == 0 {
+		return text, nil
+	}
+	out := make([]Finding, len(spans))
+	for i, s := range spans {
+		out[i] = Finding{Rule: s.rule, Match: text[s.start:s.end]}
+	}
+	if g.mode != ModeSanitize {
+		return text, out
+	}
+	var b strings.Builder
+	last := 0
+	for _, s := range spans {
+		b.WriteString(text[last:s.start])
+		b.WriteString(Replacement)
+		last = s.end
+	}
+	b.WriteString(text[last:])
+	return b.String(), out
+}
+
+type span struct {
+	rule       string
+	start, end int
+}
+
+// find returns the non-overlapping matches of all rules sorted by position.
+func find(text string) []span {
+	var spans []span
+	for _, r := range rules {
+		for _, loc := range r.re.FindAllStringIndex(text, -1) {
+			if !overlaps(spans, loc[0], loc[1]) {
+				spans = append(spans, span{rule: r.name, start: loc[0], end: loc[1]})
+			}
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	return spans
+}
+
+func overlaps(spans []span, start, end int) bool {
+	for _, s := range spans {
+		if start < s.end && s.start < end {
+			return true
+		}
+	}
+	return false
+}
@@ -285,6 +285,25 @@ func (rlc *RateLimitCache) RemainingBlockDuration(modelID string) time.Duration
 	return entry.GetTimeUntilUnblocked()
 }
 
+// BlockedFor returns how long until one of modelIDs becomes unblocked: 0
+// when one of them is not blocked or modelIDs is empty.
+func (rlc *RateLimitCache) BlockedFor(modelIDs []string) time.Duration {
+	rlc.mu.RLock()
+	defer rlc.mu.RUnlock()
+
+	var shortest time.Duration
+	for i, id := range modelIDs {
+		entry, exists := rlc.blockedModels[id]
+		if !exists || !entry.IsBlocked() {
+			return 0
+		}
+		if wait := entry.GetTimeUntilUnblocked(); i == 0 || wait < shortest {
+			shortest = wait
+		}
+	}
+	return shortest
+}
+
 // Stop stops the cleanup routine
 func (rlc *RateLimitCache) Stop() {
 	close(rlc.stopCleanup)
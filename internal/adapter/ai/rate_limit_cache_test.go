@@ -213,6 +213,19 @@ func TestRateLimitCache_BlockModel(t *testing.T) {
 	assert.True(t, cache.IsModelBlocked("test-model"))
 }
 
+func TestRateLimitCache_BlockedFor(t *testing.T) {
+	cache := NewRateLimitCache()
+	defer cache.Stop()
+
+	assert.Zero(t, cache.BlockedFor(nil))
+	cache.BlockModel("a", time.Hour)
+	assert.Zero(t, cache.BlockedFor([]string{"a", "b"}))
+	cache.BlockModel("b", time.Minute)
+	wait := cache.BlockedFor([]string{"a", "b"})
+	assert.Greater(t, wait, 50*time.Second)
+	assert.LessOrEqual(t, wait, time.Minute)
+}
+
 func TestRateLimitCache_RecordFailure(t *testing.T) {
 	cache := NewRateLimitCache()
 	defer cache.Stop()
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,7 +21,6 @@ import (
 	"time"
 
 	backoff "github.com/cenkalti/backoff/v4"
-	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -28,6 +28,7 @@ import (
 	"log/slog"
 
 	aiadapter "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/jsonrepair"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/tokencount"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
@@ -39,25 +40,33 @@ import (
 
 // Client implements domain.AIClient using OpenRouter (chat) and OpenAI (embeddings).
 type Client struct {
-	cfg                  config.Config
-	chatHC               *http.Client
-	embedHC              *http.Client
-	freeModelsSvc        *freemodels.Service
-	modelCounter         int64                     // Counter for round-robin model selection
-	providerCounter      int64                     //nolint:unused // Counter to balance load between Groq and OpenRouter when both are available
-	rlc                  *aiadapter.RateLimitCache // Client-side rate-limit model cache
-	limiter              ratelimiter.Limiter
-	lastORCall           atomic.Int64 // unix nano timestamp of last OpenRouter call (client-level throttle)
-	lastGroqCall         atomic.Int64 // unix nano timestamp of last Groq call (client-level throttle)
-	groq1Blocked         atomic.Int64 // unix nano timestamp until which Groq primary key is blocked due to 429
-	groq2Blocked         atomic.Int64 // unix nano timestamp until which Groq secondary key is blocked due to 429
-	openRouterBlocked    atomic.Int64 // unix nano timestamp until which OpenRouter is blocked (legacy provider-level block)
-	openRouterKeyCounter int64
-	openRouter1Blocked   atomic.Int64 // unix nano timestamp until which OpenRouter primary key is blocked due to 429
-	openRouter2Blocked   atomic.Int64 // unix nano timestamp until which OpenRouter secondary key is blocked due to 429
-	groqModels           []string     // Cached Groq chat-capable models ordered by capacity
-	groqModelsLastFetch  time.Time    // Last time the Groq models cache was refreshed
-	groqModelsMu         sync.RWMutex // Protects access to groqModels and groqModelsLastFetch
+	cfg                 config.Config
+	chatHC              *http.Client // OpenRouter chat and CoT cleaning
+	groqHC              *http.Client
+	embedHC             *http.Client
+	freeModelsSvc       *freemodels.Service
+	providerCounter     int64                     //nolint:unused // Counter to balance load between Groq and OpenRouter when both are available
+	rlc                 *aiadapter.RateLimitCache // Client-side rate-limit model cache
+	health              *aiadapter.ModelHealth    // Model health scores weighting model selection
+	limiter             ratelimiter.Limiter
+	lastORCall          atomic.Int64                          // unix nano timestamp of last OpenRouter call (client-level throttle)
+	lastGroqCall        atomic.Int64                          // unix nano timestamp of last Groq call (client-level throttle)
+	openRouterBlocked   atomic.Int64                          // unix nano timestamp until which OpenRouter is blocked (legacy provider-level block)
+	keys                atomic.Pointer[aiadapter.KeyRing]     // Provider API keys with weights, states and per-key 429 blocks
+	limits              atomic.Pointer[aiadapter.ModelLimits] // Groq model limits discovered from response headers
+	groqModels          []string                              // Cached Groq chat-capable models ordered by capacity
+	groqModelsLastFetch time.Time                             // Last time the Groq models cache was refreshed
+	groqModelsMu        sync.RWMutex                          // Protects access to groqModels and groqModelsLastFetch
+
+	// Prompt races of the enhanced switching in flight by job and prompt
+	racesMu sync.Mutex
+	races   map[string]*promptRace
+
+	// Adaptive max_tokens per evaluation step and model (nil = off)
+	budget *aiadapter.CompletionBudget
+
+	// Fingerprints and stores failed provider calls (nil = metrics only)
+	providerErrors *aiadapter.ProviderErrorLog
 
 	// Integrated observability for external AI calls
 	obsOpenRouterChat *intobs.IntegratedObservableClient
@@ -93,14 +102,48 @@ func (l *limitedReader) Read(p []byte) (int, error) {
 	return n, err
 }
 
+// errStreamIdle marks a streaming response aborted by an idle or token timeout.
+var errStreamIdle = errors.New("stream idle")
+
+// readChatStream reads a streaming chat response with the configured
+// timeouts. When SSE_SALVAGE_PARTIAL is enabled and the stream times out,
+// the partial content is closed into valid JSON where possible and returned
+// as the result so the usual cleaning and validation can decide whether it
+// is usable, instead of discarding a long generation.
+func (c *Client) readChatStream(r io.Reader, provider, model string) (chatMessage, error) {
+	msg, err := readSSEChatStream(r, provider, model, c.cfg.SSEIdleTimeout, c.cfg.SSETokenTimeout)
+	if err == nil || !errors.Is(err, errStreamIdle) || !c.cfg.SSESalvagePartial {
+		return msg, err
+	}
+	answer, _ := splitThinkTags(msg.Content)
+	repaired, ok := jsonrepair.CloseTruncated(answer)
+	if !ok {
+		observability.RecordAIStreamSalvage(provider, "failed")
+		return chatMessage{}, err
+	}
+	slog.Warn("salvaged partial streaming response",
+		slog.String("provider", provider),
+		slog.String("model", model),
+		slog.Int("partial_length", len(answer)),
+		slog.Any("error", err))
+	observability.RecordAIStreamSalvage(provider, "salvaged")
+	msg.Content = repaired
+	return msg, nil
+}
+
 // readSSEChatStream parses a text/event-stream response from OpenAI-compatible
 // chat completions and accumulates the content from each chunk. It supports
 // both OpenAI-style {"choices":[{"delta":{"content":"..."}}]} and
-// fallback to {"choices":[{"message":{"content":"..."}}]} payloads.
+// fallback to {"choices":[{"message":{"content":"..."}}]} payloads. Reasoning
+// deltas of reasoning models are accumulated apart from the content.
 //
 // It also enforces a sliding idle timeout: if no new SSE line is received
 // within idleTimeout, the stream is considered idle and an error is returned.
-func readSSEChatStream(r io.Reader, provider, model string, idleTimeout time.Duration) (string, error) {
+// A positive tokenTimeout additionally bounds the gap between content tokens,
+// so keep-alive comments cannot hold a stalled generation open. On either
+// timeout the content accumulated so far is returned with an error wrapping
+// errStreamIdle so callers may salvage it.
+func readSSEChatStream(r io.Reader, provider, model string, idleTimeout, tokenTimeout time.Duration) (chatMessage, error) {
 	if idleTimeout <= 0 {
 		idleTimeout = 20 * time.Second
 	}
@@ -130,19 +173,32 @@ func readSSEChatStream(r io.Reader, provider, model string, idleTimeout time.Dur
 		}
 	}()
 
-	var sb strings.Builder
+	var sb, rb strings.Builder
+	soFar := func() chatMessage { return chatMessage{Content: sb.String(), Reasoning: rb.String()} }
 	timer := time.NewTimer(idleTimeout)
 	defer timer.Stop()
+	var tokenTimer *time.Timer
+	var tokenC <-chan time.Time
+	if tokenTimeout > 0 {
+		tokenTimer = time.NewTimer(tokenTimeout)
+		defer tokenTimer.Stop()
+		tokenC = tokenTimer.C
+	}
+	abort := func() {
+		if closer, ok := r.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}
 
 	for {
 		select {
 		case msg, ok := <-lines:
 			if !ok {
 				// Stream ended normally
-				return sb.String(), nil
+				return soFar(), nil
 			}
 			if msg.err != nil {
-				return "", msg.err
+				return chatMessage{}, msg.err
 			}
 
 			// Got activity: reset idle timer and parse the line
@@ -170,17 +226,13 @@ func readSSEChatStream(r io.Reader, provider, model string, idleTimeout time.Dur
 				continue
 			}
 			if data == "[DONE]" {
-				return sb.String(), nil
+				return soFar(), nil
 			}
 
 			var chunk struct {
 				Choices []struct {
-					Delta struct {
-						Content string `json:"content"`
-					} `json:"delta"`
-					Message struct {
-						Content string `json:"content"`
-					} `json:"message"`
+					Delta   chatMessage `json:"delta"`
+					Message chatMessage `json:"message"`
 				} `json:"choices"`
 			}
 
@@ -200,16 +252,34 @@ func readSSEChatStream(r io.Reader, provider, model string, idleTimeout time.Dur
 			if piece == "" {
 				piece = c.Message.Content
 			}
-			if piece != "" {
-				_, _ = sb.WriteString(piece)
+			thought := c.Delta.Reasoning + c.Delta.ReasoningContent
+			if thought == "" {
+				thought = c.Message.Reasoning + c.Message.ReasoningContent
+			}
+			_, _ = sb.WriteString(piece)
+			_, _ = rb.WriteString(thought)
+			// Reasoning tokens count as progress: reasoning models think
+			// before the first content token.
+			if piece != "" || thought != "" {
+				if tokenTimer != nil {
+					if !tokenTimer.Stop() {
+						select {
+						case <-tokenTimer.C:
+						default:
+						}
+					}
+					_ = tokenTimer.Reset(tokenTimeout)
+				}
 			}
 
 		case <-timer.C:
 			// No activity within idleTimeout: treat as idle and abort the stream.
-			if closer, ok := r.(io.Closer); ok {
-				_ = closer.Close()
-			}
-			return "", fmt.Errorf("stream idle for %s", idleTimeout)
+			abort()
+			return soFar(), fmt.Errorf("%w for %s", errStreamIdle, idleTimeout)
+
+		case <-tokenC:
+			abort()
+			return soFar(), fmt.Errorf("%w: no content token for %s", errStreamIdle, tokenTimeout)
 		}
 	}
 }
@@ -234,14 +304,16 @@ func NewWithLimiter(cfg config.Config, lim ratelimiter.Limiter) *Client {
 		embedTimeout = 60 * time.Second
 	}
 
-	// Initialize free models service. Prefer primary OpenRouter key but
-	// transparently fall back to OPENROUTER_API_KEY_2 when only the
-	// secondary key is configured.
-	openRouterKey := cfg.OpenRouterAPIKey
-	if openRouterKey == "" {
-		openRouterKey = cfg.OpenRouterAPIKey2
+	// Initialize free models service. Use the first configured, non-disabled
+	// OpenRouter key for model discovery.
+	openRouterKey := cfg.PrimaryProviderKey("openrouter")
+	openRouterTransport := providerRoundTripper(cfg, "openrouter")
+	freeModelsSvc := freemodels.NewService(openRouterKey, cfg.OpenRouterBaseURL, cfg.FreeModelsRefresh).
+		WithTransport(openRouterTransport).
+		WithAgeObserver(observability.SetFreeModelsCatalogAge)
+	if store := freemodels.NewFileCatalogStore(cfg.FreeModelsCatalogPath); store != nil {
+		freeModelsSvc.WithStore(store)
 	}
-	freeModelsSvc := freemodels.NewService(openRouterKey, cfg.OpenRouterBaseURL, cfg.FreeModelsRefresh)
 
 	// Build integrated observable clients for AI providers
 	openRouterObs := intobs.NewIntegratedObservableClient(
@@ -281,25 +353,23 @@ func NewWithLimiter(cfg config.Config, lim ratelimiter.Limiter) *Client {
 		2*chatTimeout,
 	)
 
-	// Create HTTP clients with OpenTelemetry tracing for external AI calls
-	chatTransport := otelhttp.NewTransport(http.DefaultTransport,
-		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
-			return fmt.Sprintf("AI %s %s", r.Method, r.URL.Host)
-		}),
-	)
-	embedTransport := otelhttp.NewTransport(http.DefaultTransport,
-		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
-			return fmt.Sprintf("AI Embed %s %s", r.Method, r.URL.Host)
-		}),
-	)
+	// Create HTTP clients with OpenTelemetry tracing for external AI calls,
+	// one transport per provider.
+	// Requests carry the originating request_id as X-Request-Id.
+	chatTransport := tracedTransport(openRouterTransport, "AI")
+	groqTransport := tracedTransport(providerRoundTripper(cfg, "groq"), "AI")
+	embedTransport := tracedTransport(providerRoundTripper(cfg, "openai"), "AI Embed")
 
 	return &Client{
 		cfg:               cfg,
 		chatHC:            &http.Client{Timeout: chatTimeout, Transport: chatTransport},
+		groqHC:            &http.Client{Timeout: chatTimeout, Transport: groqTransport},
 		embedHC:           &http.Client{Timeout: embedTimeout, Transport: embedTransport},
 		freeModelsSvc:     freeModelsSvc,
 		rlc:               aiadapter.NewRateLimitCache(),
+		health:            aiadapter.DefaultModelHealth,
 		limiter:           lim,
+		budget:            aiadapter.NewCompletionBudgetFromConfig(cfg),
 		obsOpenRouterChat: openRouterObs,
 		obsGroqChat:       groqObs,
 		obsOpenAIEmbed:    embedObs,
@@ -307,37 +377,64 @@ func NewWithLimiter(cfg config.Config, lim ratelimiter.Limiter) *Client {
 	}
 }
 
+// WithKeyRing replaces the key ring built from configuration, e.g. with one
+// backed by the database so admin changes apply at runtime.
+func (c *Client) WithKeyRing(r *aiadapter.KeyRing) *Client {
+	if r != nil {
+		c.keys.Store(r)
+	}
+	return c
+}
+
+// keyRing returns the client's key ring, building it from configuration on
+// first use.
+func (c *Client) keyRing() *aiadapter.KeyRing {
+	if r := c.keys.Load(); r != nil {
+		return r
+	}
+	c.keys.CompareAndSwap(nil, aiadapter.NewKeyRingFromConfig(c.cfg))
+	return c.keys.Load()
+}
+
+// WithModelLimits replaces the Groq model limits built from configuration,
+// e.g. with a registry backed by the database.
+func (c *Client) WithModelLimits(l *aiadapter.ModelLimits) *Client {
+	if l != nil {
+		c.limits.Store(l)
+	}
+	return c
+}
+
+// WithProviderErrors makes the client store the fingerprints of failed
+// provider calls in l.
+func (c *Client) WithProviderErrors(l *aiadapter.ProviderErrorLog) *Client {
+	if l != nil {
+		c.providerErrors = l
+	}
+	return c
+}
+
+// modelLimits returns the client's Groq model limits, building them from
+// configuration on first use.
+func (c *Client) modelLimits() *aiadapter.ModelLimits {
+	if l := c.limits.Load(); l != nil {
+		return l
+	}
+	c.limits.CompareAndSwap(nil, aiadapter.NewGroqModelLimitsFromConfig(c.cfg))
+	return c.limits.Load()
+}
+
 // getOpenRouterAPIKey returns an OpenRouter API key to use for this request.
-// If both OPENROUTER_API_KEY and OPENROUTER_API_KEY_2 are configured, it
-// distributes calls between them in a simple round-robin. Whitespace is
-// trimmed and empty keys are ignored.
+// Active keys are rotated by weight; draining keys are used only when no
+// active key is usable. It returns "" when no OpenRouter key is configured.
 func (c *Client) getOpenRouterAPIKey() string {
-	k1 := strings.TrimSpace(c.cfg.OpenRouterAPIKey)
-	k2 := strings.TrimSpace(c.cfg.OpenRouterAPIKey2)
-	// Only primary configured
-	if k1 != "" && k2 == "" {
-		return k1
-	}
-	// Only secondary configured
-	if k2 != "" && k1 == "" {
-		return k2
-	}
-	// Both configured: simple round-robin across the two accounts to spread
-	// free-tier usage while still respecting global provider-level throttling.
-	if k1 != "" && k2 != "" {
-		if atomic.AddInt64(&c.openRouterKeyCounter, 1)%2 == 0 {
-			return k1
-		}
-		return k2
-	}
-	// Neither configured
-	return ""
+	return c.keyRing().Next(aiadapter.ProviderOpenRouter)
 }
 
-// getOpenRouterKeys returns the configured OpenRouter API keys (primary, secondary),
-// both trimmed. Either value may be empty when not configured.
-func (c *Client) getOpenRouterKeys() (string, string) {
-	return strings.TrimSpace(c.cfg.OpenRouterAPIKey), strings.TrimSpace(c.cfg.OpenRouterAPIKey2)
+// getOpenRouterKeys returns the OpenRouter keys to try for one request, in
+// rotation order, excluding blocked and disabled keys.
+func (c *Client) getOpenRouterKeys() []string {
+	return c.keyRing().Candidates(aiadapter.ProviderOpenRouter)
 }
 
 // getBackoffConfig returns a configured ExponentialBackOff based on the current environment.
@@ -357,7 +454,15 @@ func (c *Client) getBackoffConfig() *backoff.ExponentialBackOff {
 // This method implements retry logic with model fallback for better reliability.
 // nolint:gocyclo // Function is intentionally complex due to robust retry, logging, and fallback logic.
 func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
-	groqKey := strings.TrimSpace(c.cfg.GroqAPIKey)
+	maxTokens = capMaxTokens(ctx, maxTokens)
+	if ov := domain.EvaluationOverridesFrom(ctx); ov.Pinned() {
+		res, err := c.chatPinned(ctx, ov, systemPrompt, userPrompt, maxTokens)
+		if err == nil || !ov.Challenger {
+			return res, err
+		}
+		c.challengerFellBack(ctx, ov, err)
+	}
+	groqKey := c.keyRing().Next(aiadapter.ProviderGroq)
 	hasGroq := groqKey != ""
 	openRouterKey := c.getOpenRouterAPIKey()
 	hasOpenRouter := openRouterKey != ""
@@ -386,6 +491,13 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 	}
 
 	// 2) Secondary: OpenRouter free models
+	if !hasOpenRouter && (c.keyRing().Exhausted(aiadapter.ProviderOpenRouter) || (!hasGroq && c.keyRing().Exhausted(aiadapter.ProviderGroq))) {
+		lg.Warn("no provider key left within its daily budget")
+		if groqErr != nil {
+			return "", fmt.Errorf("%w: daily budget reached for all provider keys; groq: %v", domain.ErrQuotaExceeded, groqErr)
+		}
+		return "", fmt.Errorf("%w: daily budget reached for all provider keys", domain.ErrQuotaExceeded)
+	}
 	if !hasOpenRouter {
 		lg.Error("OpenRouter API key missing", slog.String("provider", "openrouter"))
 		if hasGroq {
@@ -450,24 +562,20 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 	fallbackModels := make([]string, 0, 3)
 
 	if len(available) > 0 {
-		// Round-robin only among available
-		counter := atomic.AddInt64(&c.modelCounter, 1)
-		idx := int(counter % int64(len(available)))
-		selectedModel = available[idx]
-		// Fill fallbacks: remaining available (cyclic) then shortest-wait blocked
-		for i := 0; i < len(available) && len(fallbackModels) < 3; i++ {
-			pos := (idx + 1 + i) % len(available)
-			cand := available[pos]
-			if cand.ID != selectedModel.ID {
-				fallbackModels = append(fallbackModels, cand.ID)
-			}
+		// Health-weighted draw among available
+		available = c.orderByHealth(available)
+		selectedModel = available[0]
+		// Fill fallbacks: remaining available in drawn order then shortest-wait blocked
+		for i := 1; i < len(available) && len(fallbackModels) < 3; i++ {
+			fallbackModels = append(fallbackModels, available[i].ID)
 		}
 		for i := 0; i < len(blocked) && len(fallbackModels) < 3; i++ {
 			fallbackModels = append(fallbackModels, blocked[i].ID)
 		}
 	} else {
-		// All blocked: try cheap paid models first, then fall back to shortest-wait free models
-		paidModels, err := c.freeModelsSvc.GetCheapestPaidModels(ctx, 2)
+		// All blocked: try cheap paid models first (subject to the paid
+		// fallback policy), then fall back to shortest-wait free models
+		paidModels, outcome, err := c.paidFallbackModels(ctx)
 		if err == nil && len(paidModels) > 0 {
 			// Prefer the cheapest paid as primary, then remaining paid, then shortest-wait free
 			selectedModel = paidModels[0]
@@ -489,6 +597,7 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 		} else {
 			// No paid models available or error: use shortest-wait free models
 			lg.Warn("paid model fallback unavailable, using shortest-wait free models",
+				slog.String("reason", outcome),
 				slog.Any("error", err),
 				slog.Int("free_models_count", len(freeModels)))
 			all := append([]freemodels.Model{}, freeModels...)
@@ -503,6 +612,7 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 	}
 
 	model := selectedModel.ID
+	maxTokens = c.adaptMaxTokens(ctx, model, maxTokens)
 
 	// Log available models and selection details
 	modelIDs := make([]string, len(freeModels))
@@ -517,24 +627,28 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 		slog.String("provider", "openrouter"),
 		slog.Int("max_tokens", maxTokens),
 		slog.Int("total_free_models", len(freeModels)),
+		slog.Float64("health_score", c.health.Score(model)),
 	}
 	if c.rlc != nil {
 		selectionLog = append(selectionLog,
 			slog.Int("available_models", len(available)),
 			slog.Int("blocked_models", len(blocked)))
 	}
-	lg.Info("using free model (rate-limit-aware round-robin)", selectionLog...)
+	lg.Info("using free model (rate-limit and health aware)", selectionLog...)
 
 	lg.Info("calling OpenRouter API", slog.String("provider", "openrouter"), slog.String("model", model), slog.Int("max_tokens", maxTokens))
 	body := map[string]any{
-		"model":       model,
-		"temperature": 0.2,
-		"max_tokens":  maxTokens,
+		"model":      model,
+		"max_tokens": maxTokens,
 		"messages": []map[string]string{
 			{"role": "system", "content": systemPrompt},
 			{"role": "user", "content": userPrompt},
 		},
 	}
+	c.setSampling(ctx, body)
+	if p := c.openRouterProvider(ctx); p != nil {
+		body["provider"] = p
+	}
 	// For non-test environments, request streaming responses so we can detect
 	// inactivity and fail fast if the provider stops sending chunks.
 	if !c.cfg.IsTest() {
@@ -547,13 +661,11 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 		lg.Debug("added fallback models", slog.String("fallback_models", fmt.Sprintf("%v", fallbackModels)))
 	}
 	b, _ := json.Marshal(body)
-	slog.Debug("OpenRouter API request body", slog.String("body", string(b)))
+	slog.DebugContext(ctx, "OpenRouter API request body", slog.String("body", c.logBody(b)))
 	var out struct {
 		Model   string `json:"model"`
 		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
+			Message chatMessage `json:"message"`
 		} `json:"choices"`
 	}
 	openRouterKey = c.getOpenRouterAPIKey()
@@ -572,16 +684,17 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 		}
 		bo := backoff.WithContext(expo, callCtx)
 
-		slog.Info("starting OpenRouter API retry logic", slog.String("provider", "openrouter"), slog.Duration("max_elapsed", expo.MaxElapsedTime))
+		slog.InfoContext(ctx, "starting OpenRouter API retry logic", slog.String("provider", "openrouter"), slog.Duration("max_elapsed", expo.MaxElapsedTime))
 
+		c.keyRing().Use(openRouterKey)
 		op := func() error {
 			// Global limiter gate for OpenRouter account across workers
 			if c.limiter != nil {
 				allowed, retryAfter, err := c.limiter.Allow(callCtx, openRouterBucketKey(openRouterKey), 1)
 				if err != nil {
-					slog.Error("global rate limiter error for OpenRouter", slog.Any("error", err))
+					slog.ErrorContext(ctx, "global rate limiter error for OpenRouter", slog.Any("error", err))
 				} else if !allowed {
-					slog.Warn("global rate limiter denied OpenRouter call",
+					slog.WarnContext(ctx, "global rate limiter denied OpenRouter call",
 						slog.String("provider", "openrouter"),
 						slog.Duration("retry_after", retryAfter))
 					c.blockOpenRouterAccount(openRouterKey, retryAfter)
@@ -591,7 +704,9 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 			connectionStart := time.Now()
 			// Recreate request each attempt to avoid reusing consumed bodies
 			// Client-level minimal spacing between OpenRouter calls to reduce 429s
-			c.waitOpenRouterMinInterval()
+			if err := c.waitOpenRouterMinInterval(callCtx); err != nil {
+				return backoff.Permanent(err)
+			}
 
 			r, _ := http.NewRequestWithContext(callCtx, http.MethodPost, c.cfg.OpenRouterBaseURL+"/chat/completions", bytes.NewReader(b))
 			r.Header.Set("Authorization", "Bearer "+openRouterKey)
@@ -604,7 +719,7 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 			}
 
 			// Log connection start
-			slog.Debug("starting OpenRouter API connection",
+			slog.DebugContext(ctx, "starting OpenRouter API connection",
 				slog.String("model", model),
 				slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"),
 				slog.Time("connection_start", connectionStart))
@@ -615,14 +730,15 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 
 			if err != nil {
 				// Log without touching resp
-				slog.Info("OpenRouter API connection attempt failed",
+				slog.InfoContext(ctx, "OpenRouter API connection attempt failed",
 					slog.String("model", model),
 					slog.Duration("connection_duration", connectionDuration))
+				c.health.RecordFailure(model, connectionDuration)
 				return err
 			}
 
 			// Log connection duration
-			slog.Info("OpenRouter API connection completed",
+			slog.InfoContext(ctx, "OpenRouter API connection completed",
 				slog.String("model", model),
 				slog.Duration("connection_duration", connectionDuration),
 				slog.Int("status_code", resp.StatusCode),
@@ -631,10 +747,10 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode == 429 {
-				// Retryable: let backoff handle retries. We don't need the body content
-				// here, but we keep the branch structure consistent with other status
-				// handlers for logging and rate-limit bookkeeping.
-				slog.Warn("ai provider rate limited", slog.String("provider", "openrouter"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("x_request_id", resp.Header.Get("X-Request-Id")))
+				// Retryable: let backoff handle retries. The body only tells a
+				// daily quota apart from a short rate limit.
+				fp := c.providerErrors.Record(ctx, "openrouter", "chat", model, resp.StatusCode, readSnippet(resp.Body, 512))
+				slog.WarnContext(ctx, "ai provider rate limited", slog.String("provider", "openrouter"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("fingerprint", string(fp)))
 				retryAfter := parseRetryAfterHeader(resp.Header.Get("Retry-After"))
 				if c.rlc != nil {
 					c.rlc.RecordRateLimit(model, retryAfter)
@@ -650,11 +766,10 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 				if len(bodySnippet) > 512 {
 					bodySnippet = bodySnippet[:512]
 				}
-				slog.Warn("ai provider 4xx", slog.String("provider", "openrouter"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("body", bodySnippet))
-				slog.Error("OpenRouter API 4xx error details", slog.String("response_body", bodySnippet), slog.String("request_body", string(b)))
-				if c.rlc != nil {
-					c.rlc.RecordFailure(model)
-				}
+				fp := c.providerErrors.Record(ctx, "openrouter", "chat", model, resp.StatusCode, bodySnippet)
+				slog.WarnContext(ctx, "ai provider 4xx", slog.String("provider", "openrouter"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("fingerprint", string(fp)), slog.String("body", bodySnippet))
+				slog.ErrorContext(ctx, "OpenRouter API 4xx error details", slog.String("response_body", bodySnippet), slog.String("request_body", c.logBody(b)))
+				c.health.RecordFailure(model, time.Since(connectionStart))
 				return backoff.Permanent(fmt.Errorf("chat status %d", resp.StatusCode))
 			}
 			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
@@ -664,10 +779,9 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 				if len(bodySnippet) > 512 {
 					bodySnippet = bodySnippet[:512]
 				}
-				slog.Error("ai provider non-2xx", slog.String("provider", "openrouter"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("body", bodySnippet))
-				if c.rlc != nil {
-					c.rlc.RecordFailure(model)
-				}
+				fp := c.providerErrors.Record(ctx, "openrouter", "chat", model, resp.StatusCode, bodySnippet)
+				slog.ErrorContext(ctx, "ai provider non-2xx", slog.String("provider", "openrouter"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("fingerprint", string(fp)), slog.String("body", bodySnippet))
+				c.health.RecordFailure(model, time.Since(connectionStart))
 				return fmt.Errorf("chat status %d", resp.StatusCode)
 			}
 			// At this point we have a successful 2xx status code. Prefer SSE
@@ -675,75 +789,61 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 			contentType := strings.ToLower(resp.Header.Get("Content-Type"))
 			isStream := strings.Contains(contentType, "text/event-stream") && !c.cfg.IsTest()
 			if isStream {
-				content, err := readSSEChatStream(resp.Body, "openrouter", model, 20*time.Second)
+				msg, err := c.readChatStream(resp.Body, "openrouter", model)
 				if err != nil {
-					slog.Error("failed to read OpenRouter streaming response", slog.String("provider", "openrouter"), slog.String("model", model), slog.Any("error", err))
-					if c.rlc != nil {
-						c.rlc.RecordFailure(model)
-					}
+					slog.ErrorContext(ctx, "failed to read OpenRouter streaming response", slog.String("provider", "openrouter"), slog.String("model", model), slog.Any("error", err))
+					c.health.RecordFailure(model, time.Since(connectionStart))
 					return err
 				}
-				if content == "" {
-					slog.Error("OpenRouter streaming response produced empty content", slog.String("provider", "openrouter"), slog.String("model", model))
-					if c.rlc != nil {
-						c.rlc.RecordFailure(model)
-					}
+				if msg.Content == "" {
+					slog.ErrorContext(ctx, "OpenRouter streaming response produced empty content", slog.String("provider", "openrouter"), slog.String("model", model))
+					c.health.RecordFailure(model, time.Since(connectionStart))
 					return errors.New("empty content from OpenRouter streaming response")
 				}
 				// Populate out so downstream logic (model substitution, success
 				// recording) can remain unchanged.
 				out.Model = model
 				out.Choices = []struct {
-					Message struct {
-						Content string `json:"content"`
-					} `json:"message"`
-				}{
-					{Message: struct {
-						Content string `json:"content"`
-					}{Content: content}},
-				}
+					Message chatMessage `json:"message"`
+				}{{Message: msg}}
+				c.health.RecordSuccess(model, time.Since(connectionStart))
 				return nil
 			}
 
 			// Non-streaming JSON response
 			bodyBytes, err := io.ReadAll(resp.Body)
 			if err != nil {
-				slog.Error("failed to read response body", slog.String("provider", "openrouter"), slog.Any("error", err))
+				slog.ErrorContext(ctx, "failed to read response body", slog.String("provider", "openrouter"), slog.Any("error", err))
 				return err
 			}
 			if err := json.Unmarshal(bodyBytes, &out); err != nil {
-				slog.Error("ai provider decode error", slog.String("provider", "openrouter"), slog.String("op", "chat"), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.Any("error", err))
-				if c.rlc != nil {
-					c.rlc.RecordFailure(model)
-				}
+				slog.ErrorContext(ctx, "ai provider decode error", slog.String("provider", "openrouter"), slog.String("op", "chat"), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.Any("error", err))
+				c.health.RecordFailure(model, time.Since(connectionStart))
 				return err
 			}
+			c.health.RecordSuccess(model, time.Since(connectionStart))
 			return nil
 		}
 
 		if err := backoff.Retry(op, bo); err != nil {
-			slog.Error("OpenRouter API failed after retries", slog.String("provider", "openrouter"), slog.Any("error", err))
+			slog.ErrorContext(ctx, "OpenRouter API failed after retries", slog.String("provider", "openrouter"), slog.Any("error", err))
 			return fmt.Errorf("openrouter api failed: %w", err)
 		}
 
 		if len(out.Choices) == 0 {
-			slog.Error("OpenRouter API returned empty choices", slog.String("provider", "openrouter"))
+			slog.ErrorContext(ctx, "OpenRouter API returned empty choices", slog.String("provider", "openrouter"))
 			return errors.New("empty choices from OpenRouter API")
 		}
 
+		// Verify the model that served the call against the requested ones
+		if err := c.checkServedModel(ctx, model, out.Model, fallbackModels...); err != nil {
+			return err
+		}
+
 		// Log successful API call with model verification
 		actualModel := "unknown"
 		if len(out.Choices) > 0 && out.Choices[0].Message.Content != "" {
-			// Check if the actual model used was different from requested
-			if out.Model != "" && out.Model != model {
-				slog.Warn("model substitution detected",
-					slog.String("requested_model", model),
-					slog.String("actual_model", out.Model),
-					slog.String("provider", "openrouter"))
-				actualModel = out.Model
-			} else {
-				actualModel = model
-			}
+			actualModel = servedModel(model, out.Model)
 		}
 
 		// Record success for actual model used
@@ -751,100 +851,128 @@ func (c *Client) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, m
 			c.rlc.RecordSuccess(actualModel)
 		}
 
-		slog.Info("OpenRouter API call successful",
+		slog.InfoContext(ctx, "OpenRouter API call successful",
 			slog.String("provider", "openrouter"),
 			slog.Int("choices_count", len(out.Choices)),
 			slog.String("requested_model", model),
 			slog.String("actual_model", actualModel))
 
-		result = out.Choices[0].Message.Content
+		result = c.finalAnswer(ctx, "openrouter", model, out.Choices[0].Message)
 		return nil
 	})
 	if err != nil {
 		return "", err
 	}
 
-	// Record token usage for metrics
-	recordTokenUsage("openrouter", model, systemPrompt, userPrompt, result)
+	// Record token usage for metrics and the key's daily budget
+	tokens, completionTokens := recordTokenUsage(ctx, "openrouter", model, systemPrompt, userPrompt, result)
+	c.budget.Observe(domain.EvaluationStepFrom(ctx), model, completionTokens, maxTokens)
+	c.keyRing().RecordUsage(ctx, openRouterKey, int64(tokens))
+	recordOpenRouterServed(ctx, model, out.Model, fallbackModels...)
 
 	return result, nil
 }
 
-// ChatJSONWithRetry performs chat with enhanced retry and model switching, preferring Groq
-// when configured and falling back to OpenRouter free models when available.
+// paidFallbackModels returns up to two paid models that the paid fallback
+// policy allows for this request, together with the recorded outcome: used,
+// disabled, no_opt_in or unavailable.
+func (c *Client) paidFallbackModels(ctx domain.Context) ([]freemodels.Model, string, error) {
+	policy := c.cfg.GetPaidFallbackPolicy()
+	outcome := "unavailable"
+	var models []freemodels.Model
+	var err error
+	switch {
+	case !policy.Enabled:
+		outcome = "disabled"
+	case policy.RequireOptIn && !domain.PaidFallbackOptedIn(ctx):
+		outcome = "no_opt_in"
+	default:
+		models, err = c.freeModelsSvc.GetCheapestPaidModelsFiltered(ctx, 2, freemodels.PaidModelFilter{
+			MaxPricePer1K: policy.MaxPricePer1K,
+			Allowed:       policy.AllowedModels,
+		})
+	}
+	if err == nil && len(models) > 0 {
+		outcome = "used"
+		observability.RecordAIPaidFallback(outcome, models[0].ID)
+		return models, outcome, nil
+	}
+	observability.RecordAIPaidFallback(outcome, "")
+	return nil, outcome, err
+}
+
+// ChatJSONWithRetry performs chat with enhanced retry and model switching across Groq
+// and OpenRouter free models, in the order chosen by the key ring's provider balancing.
 //
 //nolint:gocyclo // Function is intentionally complex due to robust retry, logging, and fallback logic.
 func (c *Client) ChatJSONWithRetry(ctx domain.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	maxTokens = capMaxTokens(ctx, maxTokens)
+	if ov := domain.EvaluationOverridesFrom(ctx); ov.Pinned() {
+		res, err := c.chatPinned(ctx, ov, systemPrompt, userPrompt, maxTokens)
+		if err == nil || !ov.Challenger {
+			return res, err
+		}
+		c.challengerFellBack(ctx, ov, err)
+	}
 	lg := intobs.LoggerFromContext(ctx)
 
-	groqPrimary := strings.TrimSpace(c.cfg.GroqAPIKey)
-	groqSecondary := strings.TrimSpace(c.cfg.GroqAPIKey2)
-	hasGroq1 := groqPrimary != ""
-	hasGroq2 := groqSecondary != ""
-	hasAnyGroq := hasGroq1 || hasGroq2
-	orPrimary, orSecondary := c.getOpenRouterKeys()
-	hasOR1 := orPrimary != ""
-	hasOR2 := orSecondary != ""
+	hasAnyGroq := c.keyRing().Configured(aiadapter.ProviderGroq)
+	hasOR := c.keyRing().Configured(aiadapter.ProviderOpenRouter)
 
 	var groqErr error
 	var orErr error
 	var freeModels []freemodels.Model
 
-	// 1) Primary/secondary: Groq accounts, when configured and not individually blocked
-	hasUnblockedGroq1 := hasGroq1 && !c.isGroqAccountBlocked(groqPrimary)
-	hasUnblockedGroq2 := hasGroq2 && !c.isGroqAccountBlocked(groqSecondary)
-	if hasUnblockedGroq1 || hasUnblockedGroq2 {
-		// Groq account 1
-		if hasUnblockedGroq1 {
-			res, err := c.callGroqChat(ctx, groqPrimary, systemPrompt, userPrompt, maxTokens)
-			if err == nil {
-				return res, nil
-			}
-			groqErr = err
-			lg.Warn("Groq ChatJSONWithRetry primary account attempt failed",
-				slog.String("provider", "groq"),
-				slog.Any("error", err))
-		}
-
-		// Groq account 2
-		if hasUnblockedGroq2 {
-			res, err := c.callGroqChat(ctx, groqSecondary, systemPrompt, userPrompt, maxTokens)
-			if err == nil {
-				return res, nil
-			}
-			if groqErr == nil {
-				groqErr = err
-			} else {
-				groqErr = fmt.Errorf("groq secondary account failed: %v; %w", err, groqErr)
+	// Groq accounts in rotation order, skipping blocked keys
+	tryGroq := func() (string, bool) {
+		if groqKeys := c.keyRing().Candidates(aiadapter.ProviderGroq); len(groqKeys) > 0 {
+			for i, key := range groqKeys {
+				res, err := c.callGroqChat(ctx, key, systemPrompt, userPrompt, maxTokens)
+				if err == nil {
+					return res, true
+				}
+				if groqErr == nil {
+					groqErr = err
+				} else {
+					groqErr = fmt.Errorf("groq account %d failed: %v; %w", i+1, err, groqErr)
+				}
+				lg.Warn("Groq ChatJSONWithRetry account attempt failed",
+					slog.String("provider", "groq"),
+					slog.Int("account", i+1),
+					slog.Any("error", err))
 			}
-			lg.Warn("Groq ChatJSONWithRetry secondary account attempt failed",
-				slog.String("provider", "groq"),
-				slog.Any("error", err))
+		} else if c.keyRing().Exhausted(aiadapter.ProviderGroq) {
+			lg.Warn("skipping Groq: all accounts reached their daily budget", slog.String("provider", "groq"))
+			groqErr = fmt.Errorf("%w: daily budget reached for all groq keys", domain.ErrQuotaExceeded)
+		} else if hasAnyGroq {
+			lg.Info("skipping Groq due to active rate limit block on all accounts", slog.String("provider", "groq"))
+			groqErr = errors.New("groq rate limited and all accounts blocked")
 		}
-	} else if hasAnyGroq {
-		lg.Info("skipping Groq due to active rate limit block on all accounts", slog.String("provider", "groq"))
-		groqErr = errors.New("groq rate limited and all accounts blocked")
+		return "", false
 	}
 
-	// 2) Secondary: OpenRouter free models via primary account, then secondary account
-	if hasOR1 || hasOR2 {
+	// OpenRouter free models, trying each account in rotation order
+	tryOpenRouter := func() (string, bool) {
+		if !hasOR {
+			return "", false
+		}
 		// Get free models from the service with retry logic (shared across accounts)
-		slog.Debug("calling free models service to get available models")
+		slog.DebugContext(ctx, "calling free models service to get available models")
 		models, err := c.freeModelsSvc.GetFreeModels(ctx)
 		if err != nil {
-			slog.Error("failed to get free models from service",
+			slog.ErrorContext(ctx, "failed to get free models from service",
 				slog.Any("error", err),
 				slog.String("service", "freemodels"))
 
 			// Try to refresh models and retry once
-			slog.Info("attempting to refresh free models")
+			slog.InfoContext(ctx, "attempting to refresh free models")
 			if refreshErr := c.freeModelsSvc.Refresh(ctx); refreshErr != nil {
-				slog.Error("failed to refresh free models", slog.Any("error", refreshErr))
+				slog.ErrorContext(ctx, "failed to refresh free models", slog.Any("error", refreshErr))
 				orErr = fmt.Errorf("openrouter free models unavailable: %w", err)
 			} else {
 				models, err = c.freeModelsSvc.GetFreeModels(ctx)
 				if err != nil {
-					slog.Error("failed to get free models after refresh", slog.Any("error", err))
+					slog.ErrorContext(ctx, "failed to get free models after refresh", slog.Any("error", err))
 					orErr = fmt.Errorf("openrouter free models unavailable after refresh: %w", err)
 				} else {
 					freeModels = models
@@ -855,56 +983,68 @@ func (c *Client) ChatJSONWithRetry(ctx domain.Context, systemPrompt, userPrompt
 		}
 
 		if len(freeModels) == 0 && orErr == nil {
-			slog.Error("no free models available", slog.String("provider", "openrouter"))
+			slog.ErrorContext(ctx, "no free models available", slog.String("provider", "openrouter"))
 			orErr = fmt.Errorf("no free models available from OpenRouter API")
 		}
 
-		// Try OpenRouter primary account with enhanced switching
-		if hasOR1 && len(freeModels) > 0 && !c.isOpenRouterAccountBlocked(orPrimary) {
-			result, err := c.chatJSONWithEnhancedModelSwitchingForKey(ctx, orPrimary, systemPrompt, userPrompt, maxTokens, freeModels)
-			if err == nil {
-				return result, nil
-			}
-			if orErr == nil {
-				orErr = err
-			} else {
-				orErr = fmt.Errorf("openrouter primary account failed: %v; %w", err, orErr)
+		// Try each OpenRouter account with enhanced switching. The accounts
+		// share the prompt's race, so that the attempts of an account still
+		// running are waited for, not repeated, by the next.
+		if len(freeModels) > 0 {
+			raceCtx, _, leave := c.joinPromptRace(ctx, systemPrompt, userPrompt, maxTokens)
+			defer leave()
+			for i, key := range c.getOpenRouterKeys() {
+				if c.isOpenRouterAccountBlocked(key) {
+					continue
+				}
+				result, err := c.chatJSONWithEnhancedModelSwitchingForKey(raceCtx, key, systemPrompt, userPrompt, maxTokens, freeModels)
+				if err == nil {
+					return result, true
+				}
+				if orErr == nil {
+					orErr = err
+				} else {
+					orErr = fmt.Errorf("openrouter account %d failed: %v; %w", i+1, err, orErr)
+				}
 			}
 		}
 
-		// Try OpenRouter secondary account with enhanced switching
-		if hasOR2 && len(freeModels) > 0 && !c.isOpenRouterAccountBlocked(orSecondary) {
-			result, err := c.chatJSONWithEnhancedModelSwitchingForKey(ctx, orSecondary, systemPrompt, userPrompt, maxTokens, freeModels)
-			if err == nil {
-				return result, nil
-			}
-			if orErr == nil {
-				orErr = err
-			} else {
-				orErr = fmt.Errorf("openrouter secondary account failed: %v; %w", err, orErr)
-			}
+		// If no account produced an error, every key is over budget or blocked
+		if orErr == nil && c.keyRing().Exhausted(aiadapter.ProviderOpenRouter) {
+			orErr = fmt.Errorf("%w: daily budget reached for all openrouter keys", domain.ErrQuotaExceeded)
 		}
-
-		// If neither account produced an error but both are blocked, surface a generic error
-		if orErr == nil && (hasOR1 || hasOR2) {
+		if orErr == nil {
 			orErr = fmt.Errorf("openrouter chat failed: all configured accounts are rate limited or blocked")
 		}
+		return "", false
+	}
+
+	// Groq before OpenRouter, unless balancing orders the providers by the
+	// share of their daily budget left
+	for _, provider := range c.keyRing().ProviderOrder(aiadapter.ProviderGroq, aiadapter.ProviderOpenRouter) {
+		try := tryGroq
+		if provider == aiadapter.ProviderOpenRouter {
+			try = tryOpenRouter
+		}
+		if res, ok := try(); ok {
+			return res, nil
+		}
 	}
 
 	// Aggregate final error based on which providers were configured
-	if hasAnyGroq && (hasOR1 || hasOR2) {
+	if hasAnyGroq && hasOR {
 		return "", fmt.Errorf("groq chat failed: %v; openrouter chat failed: %w", groqErr, orErr)
 	}
 	if hasAnyGroq {
 		// Groq was configured but failed and OpenRouter is not available
 		return "", groqErr
 	}
-	if hasOR1 || hasOR2 {
+	if hasOR {
 		return "", orErr
 	}
 
 	// Neither provider is configured
-	slog.Error("no AI providers configured (Groq/OpenRouter)")
+	slog.ErrorContext(ctx, "no AI providers configured (Groq/OpenRouter)")
 	return "", fmt.Errorf("%w: no AI providers configured", domain.ErrInvalidArgument)
 }
 
@@ -928,14 +1068,18 @@ func (c *Client) chatJSONWithEnhancedModelSwitchingForKey(ctx domain.Context, ap
 	if strings.EqualFold(c.cfg.AppEnv, "dev") {
 		modelTimeout = 120 * time.Second
 	}
-	circuitBreakerThreshold := 3 // Switch after 3 consecutive failures
-
+	if c.cfg.AIModelAttemptTimeout > 0 {
+		modelTimeout = c.cfg.AIModelAttemptTimeout
+	}
+	// A timed-out attempt may still deliver its result for lateGrace while
+	// the following attempts run.
+	lateGrace := max(c.cfg.AILateResultGrace, 0)
 	// Track model performance for intelligent selection
 	modelFailures := make(map[string]int)
 	modelSuccesses := make(map[string]int)
 	modelsTried := 0
 
-	// Build rate-limit-aware order: unblocked first (RR offset), then blocked by shortest wait
+	// Build rate-limit-aware order: unblocked first (health-weighted), then blocked by shortest wait
 	ordered := make([]freemodels.Model, 0, len(freeModels))
 	blocked := make([]freemodels.Model, 0)
 	if c.rlc != nil {
@@ -956,17 +1100,16 @@ func (c *Client) chatJSONWithEnhancedModelSwitchingForKey(ctx domain.Context, ap
 	// Track the boundary between unblocked and blocked models
 	unblockedCount := len(ordered)
 
-	// Apply round-robin offset within unblocked bucket
-	if len(ordered) > 1 {
-		offset := int(atomic.AddInt64(&c.modelCounter, 1) % int64(len(ordered)))
-		ordered = append(ordered[offset:], ordered[:offset]...)
-	}
+	// Weigh the unblocked bucket by model health; unhealthy models go last
+	ordered = c.orderByHealth(ordered)
 	ordered = append(ordered, blocked...)
+	// Tenant-preferred models go first; blocked ones are still skipped below.
+	ordered = preferModels(ctx, ordered, modelID)
 
 	// If all models are blocked, we'll still try them - don't skip entirely
 	allBlocked := unblockedCount == 0
 	if allBlocked {
-		slog.Warn("all OpenRouter models are blocked, will try blocked models with shortest wait",
+		slog.WarnContext(ctx, "all OpenRouter models are blocked, will try blocked models with shortest wait",
 			slog.Int("blocked_count", len(blocked)))
 	}
 
@@ -975,10 +1118,46 @@ func (c *Client) chatJSONWithEnhancedModelSwitchingForKey(ctx domain.Context, ap
 		maxModelsToTry = len(ordered)
 	}
 
+	// Attempts run in the prompt's race, shared with the other accounts of
+	// the fallback and the identical calls of the job in flight.
+	ctx, race, leave := c.joinPromptRace(ctx, systemPrompt, userPrompt, maxTokens)
+	defer leave()
+	// rejected holds the attempts whose result was a refusal.
+	rejected := map[int]bool{}
+	// usable reports whether the successful result r of another, timed-out
+	// attempt is no refusal.
+	usable := func(r modelAttemptResult) bool {
+		if rejected[r.id] {
+			return false
+		}
+		if refused, reason := c.checkModelResult(ctx, r.model, r.result); refused {
+			rejected[r.id] = true
+			observability.RecordAILateResult("discarded")
+			slog.WarnContext(ctx, "late result of timed-out model attempt is a refusal, discarding",
+				slog.String("model", r.model),
+				slog.String("refusal_reason", reason))
+			return false
+		}
+		return true
+	}
+	// harvest returns the late result r of an earlier attempt.
+	harvest := func(r modelAttemptResult) (string, error) {
+		race.win(r.id)
+		observability.RecordAILateResult("harvested")
+		modelSuccesses[r.model]++
+		if c.rlc != nil {
+			c.rlc.RecordSuccess(r.model)
+		}
+		slog.InfoContext(ctx, "using late result of timed-out model attempt",
+			slog.String("model", r.model),
+			slog.Int("response_length", len(r.result)))
+		return r.result, nil
+	}
+
 	// Try each model with enhanced timeout and circuit breaker logic
 	for modelIndex, model := range ordered {
 		if modelIndex >= maxModelsToTry {
-			slog.Warn("max model attempts reached in enhanced switching",
+			slog.WarnContext(ctx, "max model attempts reached in enhanced switching",
 				slog.Int("max_models_to_try", maxModelsToTry),
 				slog.Int("models_tried", modelsTried),
 				slog.Int("total_models", len(freeModels)))
@@ -986,7 +1165,7 @@ func (c *Client) chatJSONWithEnhancedModelSwitchingForKey(ctx domain.Context, ap
 		}
 
 		if c.isOpenRouterAccountBlocked(apiKey) {
-			slog.Warn("OpenRouter account blocked due to rate limiting, aborting remaining model attempts",
+			slog.WarnContext(ctx, "OpenRouter account blocked due to rate limiting, aborting remaining model attempts",
 				slog.Int("models_tried", modelsTried),
 				slog.Int("total_models", len(freeModels)))
 			break
@@ -998,82 +1177,85 @@ func (c *Client) chatJSONWithEnhancedModelSwitchingForKey(ctx domain.Context, ap
 		// Skip models that are currently blocked by rate-limit cache,
 		// UNLESS all models are blocked (then we try anyway)
 		if c.rlc != nil && c.rlc.IsModelBlocked(modelID) && !allBlocked {
-			slog.Warn("skipping model due to active rate-limit block",
+			slog.WarnContext(ctx, "skipping model due to active rate-limit block",
 				slog.String("model", modelID),
 				slog.String("model_name", modelName))
 			continue
 		}
 
-		// Skip models that have failed too many times (circuit breaker)
-		if modelFailures[modelID] >= circuitBreakerThreshold {
-			slog.Warn("model circuit breaker triggered, skipping model",
-				slog.String("model", modelID),
-				slog.String("model_name", modelName),
-				slog.Int("failures", modelFailures[modelID]),
-				slog.Int("threshold", circuitBreakerThreshold))
-			continue
-		}
-
 		modelsTried++
 
-		slog.Info("trying model with enhanced switching",
+		slog.InfoContext(ctx, "trying model with enhanced switching",
 			slog.String("model", modelID),
 			slog.String("model_name", modelName),
 			slog.Int("model_index", modelIndex),
 			slog.Int("total_models", len(freeModels)),
+			slog.Float64("health_score", c.health.Score(modelID)),
 			slog.Int("previous_failures", modelFailures[modelID]),
 			slog.Int("previous_successes", modelSuccesses[modelID]))
 
 		// Try this model with retry logic and timeout handling
 		for attempt := 1; attempt <= maxRetriesPerModel; attempt++ {
-			slog.Info("model attempt with timeout",
+			slog.InfoContext(ctx, "model attempt with timeout",
 				slog.String("model", modelID),
 				slog.Int("attempt", attempt),
 				slog.Int("max_retries", maxRetriesPerModel),
 				slog.Duration("timeout", modelTimeout))
 
-			// Create a timeout context for this specific model attempt
-			modelCtx, cancel := context.WithTimeout(ctx, modelTimeout)
-
-			// Use a channel to detect if the call completes or times out
-			resultChan := make(chan struct {
-				result string
-				err    error
-			}, 1)
-
-			// Make the AI call in a goroutine to handle timeouts properly
-			go func() {
-				result, err := c.callOpenRouterWithModelForKey(modelCtx, apiKey, modelID, systemPrompt, userPrompt, maxTokens)
-				resultChan <- struct {
-					result string
-					err    error
-				}{result, err}
-			}()
+			// The attempt's request is canceled when it times out, or lateGrace
+			// after that so that a late result can still be harvested. While
+			// it runs, the model is not sent the prompt again, by this or
+			// another account: the retry waits for it once more instead.
+			a, joined := race.start(modelID, modelTimeout+lateGrace, func(attemptCtx context.Context) (string, error) {
+				return c.callOpenRouterWithModelForKey(attemptCtx, apiKey, modelID, systemPrompt, userPrompt, maxTokens)
+			})
+			if joined {
+				slog.InfoContext(ctx, "model attempt still running, waiting for it instead of resending the prompt",
+					slog.String("model", modelID),
+					slog.Int("attempt", attempt))
+			}
 
 			// Wait for either completion or timeout
-			select {
-			case result := <-resultChan:
-				cancel() // Clean up the timeout context
-
+			timer := time.NewTimer(modelTimeout)
+			result, state := race.await(ctx, a, timer.C, usable)
+			timer.Stop()
+			switch state {
+			case attemptHarvested:
+				return harvest(result)
+			case attemptCanceled:
+				return "", fmt.Errorf("op=ai.model_switching: %w", ctx.Err())
+			case attemptExpired:
+				if lateGrace <= 0 {
+					race.abandon(a)
+				}
+				modelFailures[modelID]++
+				slog.WarnContext(ctx, "model timeout exceeded, switching to next model",
+					slog.String("model", modelID),
+					slog.String("model_name", modelName),
+					slog.Duration("timeout", modelTimeout),
+					slog.Duration("late_result_grace", lateGrace),
+					slog.Int("failures", modelFailures[modelID]))
+			case attemptFinished:
 				if result.err == nil {
 					// Enhanced refusal detection with comprehensive validation
-					refusalDetected, refusalReason := c.detectRefusalWithValidation(ctx, result.result)
-					if refusalDetected {
-						slog.Warn("model returned refusal response, switching to next model",
+					if refused, refusalReason := c.checkModelResult(ctx, modelID, result.result); refused {
+						rejected[result.id] = true
+						slog.WarnContext(ctx, "model returned refusal response, switching to next model",
 							slog.String("model", modelID),
 							slog.String("model_name", modelName),
 							slog.String("refusal_reason", refusalReason),
 							slog.String("response_preview", truncateString(result.result, 100)))
 						modelFailures[modelID]++
-						break // Skip to next model immediately
+						break
 					}
 
-					// Success! Update success counter and return
+					// Success! Cancel the sibling attempts, update success counter and return
+					race.win(result.id)
 					modelSuccesses[modelID]++
 					if c.rlc != nil {
 						c.rlc.RecordSuccess(modelID)
 					}
-					slog.Info("model succeeded with enhanced switching",
+					slog.InfoContext(ctx, "model succeeded with enhanced switching",
 						slog.String("model", modelID),
 						slog.String("model_name", modelName),
 						slog.Int("attempt", attempt),
@@ -1083,55 +1265,33 @@ func (c *Client) chatJSONWithEnhancedModelSwitchingForKey(ctx domain.Context, ap
 				}
 
 				// Handle different types of errors
-				slog.Warn("model attempt failed with enhanced switching",
+				slog.WarnContext(ctx, "model attempt failed with enhanced switching",
 					slog.String("model", modelID),
 					slog.String("model_name", modelName),
 					slog.Int("attempt", attempt),
 					slog.Any("error", result.err))
-
-				// Check if it's a timeout error
-				if modelCtx.Err() == context.DeadlineExceeded {
-					slog.Warn("model timeout exceeded",
-						slog.String("model", modelID),
-						slog.String("model_name", modelName),
-						slog.Duration("timeout", modelTimeout))
-					modelFailures[modelID]++
-					if c.rlc != nil {
-						c.rlc.RecordFailure(modelID)
-					}
-				} else {
-					// Other types of errors
-					modelFailures[modelID]++
-					if c.rlc != nil {
-						c.rlc.RecordFailure(modelID)
-					}
-				}
-
-			case <-modelCtx.Done():
-				// Timeout occurred
-				cancel()
 				modelFailures[modelID]++
-				if c.rlc != nil {
-					c.rlc.RecordFailure(modelID)
-				}
-				slog.Warn("model timeout exceeded, switching to next model",
-					slog.String("model", modelID),
-					slog.String("model_name", modelName),
-					slog.Duration("timeout", modelTimeout),
-					slog.Int("failures", modelFailures[modelID]))
 			}
 
 			// If this is not the last attempt for this model, wait before retrying
 			if attempt < maxRetriesPerModel {
 				backoffDuration := time.Duration(attempt) * 2 * time.Second
-				slog.Info("waiting before model retry",
+				slog.InfoContext(ctx, "waiting before model retry",
 					slog.String("model", modelID),
 					slog.Duration("backoff", backoffDuration))
-				time.Sleep(backoffDuration)
+				timer := time.NewTimer(backoffDuration)
+				late, state := race.await(ctx, nil, timer.C, usable)
+				timer.Stop()
+				switch state {
+				case attemptHarvested:
+					return harvest(late)
+				case attemptCanceled:
+					return "", fmt.Errorf("op=ai.model_switching: %w", ctx.Err())
+				}
 			}
 		}
 
-		slog.Warn("model failed after all retries, trying next model",
+		slog.WarnContext(ctx, "model failed after all retries, trying next model",
 			slog.String("model", modelID),
 			slog.String("model_name", modelName),
 			slog.Int("model_index", modelIndex),
@@ -1141,7 +1301,7 @@ func (c *Client) chatJSONWithEnhancedModelSwitchingForKey(ctx domain.Context, ap
 	}
 
 	// Log final statistics
-	slog.Error("all models failed with enhanced switching",
+	slog.ErrorContext(ctx, "all models failed with enhanced switching",
 		slog.Int("total_models_tried", modelsTried),
 		slog.Int("total_models_available", len(freeModels)),
 		slog.Any("model_failures", modelFailures),
@@ -1150,6 +1310,55 @@ func (c *Client) chatJSONWithEnhancedModelSwitchingForKey(ctx domain.Context, ap
 	return "", fmt.Errorf("all models failed after enhanced switching (tried %d models)", modelsTried)
 }
 
+// modelAttemptResult is the outcome of attempt id of model switching.
+type modelAttemptResult struct {
+	id     int
+	model  string
+	result string
+	err    error
+}
+
+// attemptWait tells how waiting for a model attempt ended.
+type attemptWait int
+
+const (
+	// attemptFinished: the awaited attempt returned.
+	attemptFinished attemptWait = iota
+	// attemptHarvested: an earlier, timed-out attempt succeeded first.
+	attemptHarvested
+	// attemptExpired: the wait timed out.
+	attemptExpired
+	// attemptCanceled: the caller's context is done.
+	attemptCanceled
+)
+
+// checkModelResult runs refusal detection on the response result of model
+// and records the outcome with the model's health.
+func (c *Client) checkModelResult(ctx domain.Context, model, result string) (bool, string) {
+	refused, reason := c.detectRefusalWithValidation(ctx, result)
+	c.health.RecordRefusal(model, refused)
+	return refused, reason
+}
+
+// orderByHealth orders models by a draw weighted by their health scores,
+// with models below aiadapter.MinModelHealthScore last.
+func (c *Client) orderByHealth(models []freemodels.Model) []freemodels.Model {
+	if len(models) < 2 {
+		return models
+	}
+	byID := make(map[string]freemodels.Model, len(models))
+	ids := make([]string, len(models))
+	for i, m := range models {
+		byID[m.ID] = m
+		ids[i] = m.ID
+	}
+	out := make([]freemodels.Model, 0, len(models))
+	for _, id := range c.health.Order(ids) {
+		out = append(out, byID[id])
+	}
+	return out
+}
+
 // callOpenRouterWithModel makes a single call to OpenRouter with a specific model.
 // callOpenRouterWithModel calls OpenRouter using whichever key is returned by getOpenRouterAPIKey.
 // It preserves the legacy behaviour of distributing calls across accounts when both are configured.
@@ -1163,6 +1372,7 @@ func (c *Client) callOpenRouterWithModel(ctx domain.Context, model, systemPrompt
 //
 //nolint:gocyclo // Function is accidentally complex due to retry logic and instrumentation.
 func (c *Client) callOpenRouterWithModelForKey(ctx domain.Context, apiKey, model, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	maxTokens = c.adaptMaxTokens(ctx, model, maxTokens)
 	tracer := otel.Tracer("ai-cv-evaluator")
 	ctx, span := tracer.Start(ctx, "ai.real.callOpenRouterWithModelForKey",
 		trace.WithAttributes(
@@ -1172,30 +1382,31 @@ func (c *Client) callOpenRouterWithModelForKey(ctx domain.Context, apiKey, model
 		))
 	defer span.End()
 	body := map[string]any{
-		"model":       model,
-		"temperature": 0.2,
-		"max_tokens":  maxTokens,
+		"model":      model,
+		"max_tokens": maxTokens,
 		"messages": []map[string]string{
 			{"role": "system", "content": systemPrompt},
 			{"role": "user", "content": userPrompt},
 		},
 	}
+	c.setSampling(ctx, body)
+	if p := c.openRouterProvider(ctx); p != nil {
+		body["provider"] = p
+	}
 
 	b, _ := json.Marshal(body)
-	slog.Debug("OpenRouter API request body", slog.String("body", string(b)))
+	slog.DebugContext(ctx, "OpenRouter API request body", slog.String("body", c.logBody(b)))
 
 	var out struct {
 		Model   string `json:"model"`
 		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
+			Message chatMessage `json:"message"`
 		} `json:"choices"`
 	}
 
 	openRouterKey := strings.TrimSpace(apiKey)
 	if openRouterKey == "" {
-		slog.Error("OpenRouter API key missing for model switching", slog.String("provider", "openrouter"))
+		slog.ErrorContext(ctx, "OpenRouter API key missing for model switching", slog.String("provider", "openrouter"))
 		return "", fmt.Errorf("%w: OPENROUTER_API_KEY missing", domain.ErrInvalidArgument)
 	}
 
@@ -1209,14 +1420,15 @@ func (c *Client) callOpenRouterWithModelForKey(ctx domain.Context, apiKey, model
 		}
 		bo := backoff.WithContext(expo, callCtx)
 
+		c.keyRing().Use(openRouterKey)
 		op := func() error {
 			// Global limiter gate for OpenRouter account across workers
 			if c.limiter != nil {
 				allowed, retryAfter, err := c.limiter.Allow(callCtx, openRouterBucketKey(openRouterKey), 1)
 				if err != nil {
-					slog.Error("global rate limiter error for OpenRouter (model switching)", slog.Any("error", err))
+					slog.ErrorContext(ctx, "global rate limiter error for OpenRouter (model switching)", slog.Any("error", err))
 				} else if !allowed {
-					slog.Warn("global rate limiter denied OpenRouter call (model switching)",
+					slog.WarnContext(ctx, "global rate limiter denied OpenRouter call (model switching)",
 						slog.String("provider", "openrouter"),
 						slog.Duration("retry_after", retryAfter))
 					c.blockOpenRouterAccount(openRouterKey, retryAfter)
@@ -1225,7 +1437,9 @@ func (c *Client) callOpenRouterWithModelForKey(ctx domain.Context, apiKey, model
 			}
 			connectionStart := time.Now()
 			// Client-level minimal spacing between OpenRouter calls to reduce 429s
-			c.waitOpenRouterMinInterval()
+			if err := c.waitOpenRouterMinInterval(callCtx); err != nil {
+				return backoff.Permanent(err)
+			}
 
 			r, _ := http.NewRequestWithContext(callCtx, http.MethodPost, c.cfg.OpenRouterBaseURL+"/chat/completions", bytes.NewReader(b))
 			r.Header.Set("Authorization", "Bearer "+openRouterKey)
@@ -1238,7 +1452,7 @@ func (c *Client) callOpenRouterWithModelForKey(ctx domain.Context, apiKey, model
 			}
 
 			// Log connection start for model switching
-			slog.Debug("starting OpenRouter API connection (model switching)",
+			slog.DebugContext(ctx, "starting OpenRouter API connection (model switching)",
 				slog.String("model", model),
 				slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"),
 				slog.Time("connection_start", connectionStart))
@@ -1249,14 +1463,15 @@ func (c *Client) callOpenRouterWithModelForKey(ctx domain.Context, apiKey, model
 
 			if err != nil {
 				// Log without touching resp
-				slog.Info("OpenRouter API connection attempt failed (model switching)",
+				slog.InfoContext(ctx, "OpenRouter API connection attempt failed (model switching)",
 					slog.String("model", model),
 					slog.Duration("connection_duration", connectionDuration))
+				c.health.RecordFailure(model, connectionDuration)
 				return err
 			}
 
 			// Log connection duration for model switching
-			slog.Info("OpenRouter API connection completed (model switching)",
+			slog.InfoContext(ctx, "OpenRouter API connection completed (model switching)",
 				slog.String("model", model),
 				slog.Duration("connection_duration", connectionDuration),
 				slog.Int("status_code", resp.StatusCode),
@@ -1265,8 +1480,9 @@ func (c *Client) callOpenRouterWithModelForKey(ctx domain.Context, apiKey, model
 			defer func() { _ = resp.Body.Close() }()
 
 			if resp.StatusCode == 429 {
-				// Rate limit: log and record without needing the body content.
-				slog.Warn("ai provider rate limited", slog.String("provider", "openrouter"), slog.String("op", "chat_retry"), slog.Int("status", resp.StatusCode))
+				// Rate limit: the body only tells a daily quota apart.
+				fp := c.providerErrors.Record(ctx, "openrouter", "chat_retry", model, resp.StatusCode, readSnippet(resp.Body, 512))
+				slog.WarnContext(ctx, "ai provider rate limited", slog.String("provider", "openrouter"), slog.String("op", "chat_retry"), slog.Int("status", resp.StatusCode), slog.String("fingerprint", string(fp)))
 				retryAfter := parseRetryAfterHeader(resp.Header.Get("Retry-After"))
 				if c.rlc != nil {
 					c.rlc.RecordRateLimit(model, retryAfter)
@@ -1281,10 +1497,9 @@ func (c *Client) callOpenRouterWithModelForKey(ctx domain.Context, apiKey, model
 				if len(bodySnippet) > 512 {
 					bodySnippet = bodySnippet[:512]
 				}
-				slog.Warn("ai provider 4xx", slog.String("provider", "openrouter"), slog.String("op", "chat_retry"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("body", bodySnippet))
-				if c.rlc != nil {
-					c.rlc.RecordFailure(model)
-				}
+				fp := c.providerErrors.Record(ctx, "openrouter", "chat_retry", model, resp.StatusCode, bodySnippet)
+				slog.WarnContext(ctx, "ai provider 4xx", slog.String("provider", "openrouter"), slog.String("op", "chat_retry"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("fingerprint", string(fp)), slog.String("body", bodySnippet))
+				c.health.RecordFailure(model, time.Since(connectionStart))
 				return backoff.Permanent(fmt.Errorf("chat status %d", resp.StatusCode))
 			}
 			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
@@ -1293,77 +1508,72 @@ func (c *Client) callOpenRouterWithModelForKey(ctx domain.Context, apiKey, model
 				if len(bodySnippet) > 512 {
 					bodySnippet = bodySnippet[:512]
 				}
-				slog.Error("ai provider non-2xx", slog.String("provider", "openrouter"), slog.String("op", "chat_retry"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("body", bodySnippet))
-				if c.rlc != nil {
-					c.rlc.RecordFailure(model)
-				}
+				fp := c.providerErrors.Record(ctx, "openrouter", "chat_retry", model, resp.StatusCode, bodySnippet)
+				slog.ErrorContext(ctx, "ai provider non-2xx", slog.String("provider", "openrouter"), slog.String("op", "chat_retry"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("fingerprint", string(fp)), slog.String("body", bodySnippet))
+				c.health.RecordFailure(model, time.Since(connectionStart))
 				return fmt.Errorf("chat status %d", resp.StatusCode)
 			}
 			contentType := strings.ToLower(resp.Header.Get("Content-Type"))
 			isStream := strings.Contains(contentType, "text/event-stream") && !c.cfg.IsTest()
 			if isStream {
-				content, err := readSSEChatStream(resp.Body, "openrouter", model, 20*time.Second)
+				msg, err := c.readChatStream(resp.Body, "openrouter", model)
 				if err != nil {
-					slog.Error("failed to read OpenRouter streaming response (model switching)", slog.String("provider", "openrouter"), slog.String("model", model), slog.Any("error", err))
-					if c.rlc != nil {
-						c.rlc.RecordFailure(model)
-					}
+					slog.ErrorContext(ctx, "failed to read OpenRouter streaming response (model switching)", slog.String("provider", "openrouter"), slog.String("model", model), slog.Any("error", err))
+					c.health.RecordFailure(model, time.Since(connectionStart))
 					return err
 				}
-				if content == "" {
-					slog.Error("OpenRouter streaming response produced empty content (model switching)", slog.String("provider", "openrouter"), slog.String("model", model))
-					if c.rlc != nil {
-						c.rlc.RecordFailure(model)
-					}
+				if msg.Content == "" {
+					slog.ErrorContext(ctx, "OpenRouter streaming response produced empty content (model switching)", slog.String("provider", "openrouter"), slog.String("model", model))
+					c.health.RecordFailure(model, time.Since(connectionStart))
 					return fmt.Errorf("openrouter streaming response empty for model %s", model)
 				}
 				out.Model = model
 				out.Choices = []struct {
-					Message struct {
-						Content string `json:"content"`
-					} `json:"message"`
-				}{
-					{Message: struct {
-						Content string `json:"content"`
-					}{Content: content}},
-				}
+					Message chatMessage `json:"message"`
+				}{{Message: msg}}
+				c.health.RecordSuccess(model, time.Since(connectionStart))
 				return nil
 			}
 
 			bodyBytes, err := io.ReadAll(resp.Body)
 			if err != nil {
-				slog.Error("failed to read response body", slog.String("provider", "openrouter"), slog.Any("error", err))
+				slog.ErrorContext(ctx, "failed to read response body", slog.String("provider", "openrouter"), slog.Any("error", err))
 				return err
 			}
 			if err := json.Unmarshal(bodyBytes, &out); err != nil {
-				slog.Error("ai provider decode error", slog.String("provider", "openrouter"), slog.String("op", "chat_retry"), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.Any("error", err))
-				if c.rlc != nil {
-					c.rlc.RecordFailure(model)
-				}
+				slog.ErrorContext(ctx, "ai provider decode error", slog.String("provider", "openrouter"), slog.String("op", "chat_retry"), slog.String("model", model), slog.String("endpoint", c.cfg.OpenRouterBaseURL+"/chat/completions"), slog.Any("error", err))
+				c.health.RecordFailure(model, time.Since(connectionStart))
 				return err
 			}
+			c.health.RecordSuccess(model, time.Since(connectionStart))
 			return nil
 		}
 
 		if err := backoff.Retry(op, bo); err != nil {
-			slog.Error("OpenRouter API failed after retries", slog.String("provider", "openrouter"), slog.String("model", model), slog.Any("error", err))
+			slog.ErrorContext(ctx, "OpenRouter API failed after retries", slog.String("provider", "openrouter"), slog.String("model", model), slog.Any("error", err))
 			return fmt.Errorf("openrouter api failed for model %s: %w", model, err)
 		}
 
 		if len(out.Choices) == 0 {
-			slog.Error("OpenRouter API returned empty choices", slog.String("provider", "openrouter"), slog.String("model", model))
+			slog.ErrorContext(ctx, "OpenRouter API returned empty choices", slog.String("provider", "openrouter"), slog.String("model", model))
 			return fmt.Errorf("openrouter api returned empty choices for model %s", model)
 		}
+		if err := c.checkServedModel(ctx, model, out.Model); err != nil {
+			return err
+		}
 
-		result = out.Choices[0].Message.Content
+		result = c.finalAnswer(ctx, "openrouter", model, out.Choices[0].Message)
 		return nil
 	})
 	if err != nil {
 		return "", err
 	}
 
-	// Record token usage for metrics
-	recordTokenUsage("openrouter", model, systemPrompt, userPrompt, result)
+	// Record token usage for metrics and the key's daily budget
+	tokens, completionTokens := recordTokenUsage(ctx, "openrouter", model, systemPrompt, userPrompt, result)
+	c.budget.Observe(domain.EvaluationStepFrom(ctx), model, completionTokens, maxTokens)
+	c.keyRing().RecordUsage(ctx, openRouterKey, int64(tokens))
+	recordOpenRouterServed(ctx, model, out.Model)
 
 	return result, nil
 }
@@ -1391,8 +1601,11 @@ func (c *Client) callGroqChat(ctx domain.Context, apiKey, systemPrompt, userProm
 			"llama-3.3-70b-versatile",
 		}
 	} else {
-		models = c.getGroqModels(ctx, trimmedKey)
-		if len(models) == 0 {
+		var err error
+		if models, err = c.getGroqModels(ctx, trimmedKey); err != nil {
+			lg.Warn("Groq models unavailable; trying the default models",
+				slog.String("provider", "groq"),
+				slog.Any("error", err))
 			models = []string{
 				"llama-3.1-8b-instant",
 				"llama-3.3-70b-versatile",
@@ -1400,6 +1613,8 @@ func (c *Client) callGroqChat(ctx domain.Context, apiKey, systemPrompt, userProm
 		}
 	}
 
+	models = preferModels(ctx, models, sameID)
+
 	var lastErr error
 	for _, model := range models {
 		res, err := c.callGroqChatWithModel(ctx, trimmedKey, model, systemPrompt, userPrompt, maxTokens)
@@ -1433,6 +1648,7 @@ func (c *Client) callGroqChat(ctx domain.Context, apiKey, systemPrompt, userProm
 // callGroqChatWithModel performs the actual Groq API call for a specific model with
 // existing backoff and rate-limit handling.
 func (c *Client) callGroqChatWithModel(ctx domain.Context, apiKey, model, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	maxTokens = c.adaptMaxTokens(ctx, model, maxTokens)
 	tracer := otel.Tracer("ai-cv-evaluator")
 	ctx, span := tracer.Start(ctx, "ai.real.callGroqChatWithModel",
 		trace.WithAttributes(
@@ -1453,23 +1669,21 @@ func (c *Client) callGroqChatWithModel(ctx domain.Context, apiKey, model, system
 	}
 
 	body := map[string]any{
-		"model":       model,
-		"temperature": 0.2,
-		"max_tokens":  maxTokens,
+		"model":      model,
+		"max_tokens": maxTokens,
 		"messages": []map[string]string{
 			{"role": "system", "content": systemPrompt},
 			{"role": "user", "content": userPrompt},
 		},
 	}
+	c.setSampling(ctx, body)
 
 	b, _ := json.Marshal(body)
-	lg.Debug("Groq API request body", slog.String("body", string(b)))
+	lg.Debug("Groq API request body", slog.String("body", c.logBody(b)))
 
 	var out struct {
 		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
+			Message chatMessage `json:"message"`
 		} `json:"choices"`
 	}
 
@@ -1483,6 +1697,7 @@ func (c *Client) callGroqChatWithModel(ctx domain.Context, apiKey, model, system
 		}
 		bo := backoff.WithContext(expo, callCtx)
 
+		c.keyRing().Use(apiKey)
 		op := func() error {
 			endpoint := strings.TrimRight(baseURL, "/") + "/chat/completions"
 			// Global limiter gate for Groq account across workers
@@ -1503,7 +1718,7 @@ func (c *Client) callGroqChatWithModel(ctx domain.Context, apiKey, model, system
 			r.Header.Set("Content-Type", "application/json")
 
 			connectionStart := time.Now()
-			resp, err := c.chatHC.Do(r)
+			resp, err := c.groqHC.Do(r)
 			c.markGroqCall() // Mark the call timestamp for rate limiting
 			connectionDuration := time.Since(connectionStart)
 			if err != nil {
@@ -1521,9 +1736,11 @@ func (c *Client) callGroqChatWithModel(ctx domain.Context, apiKey, model, system
 			defer func() { _ = resp.Body.Close() }()
 			// Update global limiter configuration from Groq rate-limit headers when present
 			c.updateGroqLimiterFromHeaders(apiKey, resp.Header)
+			c.modelLimits().Observe(ctx, model, resp.Header)
 
 			if resp.StatusCode == http.StatusTooManyRequests {
-				lg.Warn("ai provider rate limited", slog.String("provider", "groq"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode))
+				fp := c.providerErrors.Record(ctx, "groq", "chat", model, resp.StatusCode, readSnippet(resp.Body, 512))
+				lg.Warn("ai provider rate limited", slog.String("provider", "groq"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("fingerprint", string(fp)))
 				// Block Groq for 60 seconds (or Retry-After if provided) and fail fast
 				retryAfter := parseRetryAfterHeader(resp.Header.Get("Retry-After"))
 				c.blockGroqAccount(apiKey, retryAfter)
@@ -1536,7 +1753,8 @@ func (c *Client) callGroqChatWithModel(ctx domain.Context, apiKey, model, system
 				if len(bodySnippet) > 512 {
 					bodySnippet = bodySnippet[:512]
 				}
-				lg.Warn("ai provider 4xx", slog.String("provider", "groq"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", endpoint), slog.String("body", bodySnippet))
+				fp := c.providerErrors.Record(ctx, "groq", "chat", model, resp.StatusCode, bodySnippet)
+				lg.Warn("ai provider 4xx", slog.String("provider", "groq"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", endpoint), slog.String("fingerprint", string(fp)), slog.String("body", bodySnippet))
 				return backoff.Permanent(fmt.Errorf("chat status %d", resp.StatusCode))
 			}
 			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
@@ -1545,30 +1763,25 @@ func (c *Client) callGroqChatWithModel(ctx domain.Context, apiKey, model, system
 				if len(bodySnippet) > 512 {
 					bodySnippet = bodySnippet[:512]
 				}
-				lg.Error("ai provider non-2xx", slog.String("provider", "groq"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", endpoint), slog.String("body", bodySnippet))
+				fp := c.providerErrors.Record(ctx, "groq", "chat", model, resp.StatusCode, bodySnippet)
+				lg.Error("ai provider non-2xx", slog.String("provider", "groq"), slog.String("op", "chat"), slog.Int("status", resp.StatusCode), slog.String("model", model), slog.String("endpoint", endpoint), slog.String("fingerprint", string(fp)), slog.String("body", bodySnippet))
 				return fmt.Errorf("chat status %d", resp.StatusCode)
 			}
 			contentType := strings.ToLower(resp.Header.Get("Content-Type"))
 			isStream := strings.Contains(contentType, "text/event-stream") && !c.cfg.IsTest()
 			if isStream {
-				content, err := readSSEChatStream(resp.Body, "groq", model, 20*time.Second)
+				msg, err := c.readChatStream(resp.Body, "groq", model)
 				if err != nil {
 					lg.Error("failed to read Groq streaming response", slog.String("provider", "groq"), slog.String("model", model), slog.Any("error", err))
 					return err
 				}
-				if content == "" {
+				if msg.Content == "" {
 					lg.Error("Groq streaming response produced empty content", slog.String("provider", "groq"), slog.String("model", model))
 					return errors.New("empty content from Groq streaming response")
 				}
 				out.Choices = []struct {
-					Message struct {
-						Content string `json:"content"`
-					} `json:"message"`
-				}{
-					{Message: struct {
-						Content string `json:"content"`
-					}{Content: content}},
-				}
+					Message chatMessage `json:"message"`
+				}{{Message: msg}}
 				return nil
 			}
 
@@ -1595,34 +1808,51 @@ func (c *Client) callGroqChatWithModel(ctx domain.Context, apiKey, model, system
 			return errors.New("empty choices from Groq API")
 		}
 
-		result = out.Choices[0].Message.Content
+		result = c.finalAnswer(ctx, "groq", model, out.Choices[0].Message)
 		return nil
 	})
 	if err != nil {
 		return "", err
 	}
 
-	// Record token usage for metrics
-	recordTokenUsage("groq", model, systemPrompt, userPrompt, result)
+	// Record token usage for metrics and the key's daily budget
+	tokens, completionTokens := recordTokenUsage(ctx, "groq", model, systemPrompt, userPrompt, result)
+	c.budget.Observe(domain.EvaluationStepFrom(ctx), model, completionTokens, maxTokens)
+	c.keyRing().RecordUsage(ctx, apiKey, int64(tokens))
+	domain.RecordServedModel(ctx, "groq", "groq/"+model)
 
 	return result, nil
 }
 
-// recordTokenUsage calculates and records token usage metrics for an AI call.
-func recordTokenUsage(provider, model, systemPrompt, userPrompt, completion string) {
+// servedModel returns the model OpenRouter reports as having served a call,
+// falling back to the requested model.
+func servedModel(requested, reported string) string {
+	if reported != "" {
+		return reported
+	}
+	return requested
+}
+
+// recordTokenUsage calculates and records token usage metrics for an AI call,
+// including the per-step distribution and the job's running totals in ctx.
+// It returns the total and completion token counts.
+func recordTokenUsage(ctx domain.Context, provider, model, systemPrompt, userPrompt, completion string) (total, completionTokens int) {
 	usage, err := tokencount.CalculateUsageDefault(systemPrompt, userPrompt, completion, model, provider)
 	if err != nil {
 		slog.Warn("failed to calculate token usage",
 			slog.String("provider", provider),
 			slog.String("model", model),
 			slog.Any("error", err))
-		return
+		// Rough estimate (~4 chars per token) so key budgets still advance
+		return (len(systemPrompt) + len(userPrompt) + len(completion)) / 4, len(completion) / 4
 	}
 
 	// Record prompt tokens
 	observability.RecordAITokenUsage(provider, "prompt", model, usage.PromptTokens)
 	// Record completion tokens
 	observability.RecordAITokenUsage(provider, "completion", model, usage.CompletionTokens)
+	observability.ObserveAICallTokens(domain.EvaluationStepFrom(ctx), model, usage.PromptTokens, usage.CompletionTokens)
+	domain.RecordTokenUsage(ctx, usage.PromptTokens, usage.CompletionTokens)
 
 	slog.Debug("recorded token usage",
 		slog.String("provider", provider),
@@ -1630,12 +1860,14 @@ func recordTokenUsage(provider, model, systemPrompt, userPrompt, completion stri
 		slog.Int("prompt_tokens", usage.PromptTokens),
 		slog.Int("completion_tokens", usage.CompletionTokens),
 		slog.Int("total_tokens", usage.TotalTokens))
+	return usage.TotalTokens, usage.CompletionTokens
 }
 
 // waitOpenRouterMinInterval enforces a minimal spacing between OpenRouter calls across this client instance.
-func (c *Client) waitOpenRouterMinInterval() {
+// It returns ctx's error when ctx is done before the call may be made.
+func (c *Client) waitOpenRouterMinInterval(ctx context.Context) error {
 	tracer := otel.Tracer("ai-cv-evaluator")
-	_, span := tracer.Start(context.Background(), "ai.real.waitOpenRouterMinInterval")
+	_, span := tracer.Start(ctx, "ai.real.waitOpenRouterMinInterval")
 	defer span.End()
 
 	minGap := c.cfg.OpenRouterMinInterval
@@ -1648,7 +1880,7 @@ func (c *Client) waitOpenRouterMinInterval() {
 	}
 	minGap = time.Duration(int64(minGap) * int64(workerFactor))
 	if minGap <= 0 {
-		return
+		return nil
 	}
 	for {
 		prev := c.lastORCall.Load()
@@ -1656,7 +1888,7 @@ func (c *Client) waitOpenRouterMinInterval() {
 		if prev == 0 {
 			// First call wins the slot
 			if c.lastORCall.CompareAndSwap(0, now.UnixNano()) {
-				return
+				return nil
 			}
 			continue
 		}
@@ -1665,11 +1897,17 @@ func (c *Client) waitOpenRouterMinInterval() {
 		if delta >= minGap {
 			// Try to claim the next slot; if we lose CAS, retry
 			if c.lastORCall.CompareAndSwap(prev, now.UnixNano()) {
-				return
+				return nil
 			}
 			continue
 		}
-		time.Sleep(minGap - delta)
+		timer := time.NewTimer(minGap - delta)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
 	}
 }
 
@@ -1696,27 +1934,19 @@ func (c *Client) blockOpenRouter(d time.Duration) {
 }
 
 // isOpenRouterAccountBlocked returns true if the given OpenRouter API key is currently
-// blocked due to a recent 429 response. This enables per-account fallback between
-// primary and secondary OpenRouter keys.
+// blocked due to a recent 429 response. This enables per-account fallback across
+// the configured OpenRouter keys.
 func (c *Client) isOpenRouterAccountBlocked(apiKey string) bool {
 	key := strings.TrimSpace(apiKey)
 	if key == "" {
 		return false
 	}
-	if key == strings.TrimSpace(c.cfg.OpenRouterAPIKey) {
-		blockedUntil := c.openRouter1Blocked.Load()
-		return blockedUntil != 0 && time.Now().UnixNano() < blockedUntil
-	}
-	if key == strings.TrimSpace(c.cfg.OpenRouterAPIKey2) {
-		blockedUntil := c.openRouter2Blocked.Load()
-		return blockedUntil != 0 && time.Now().UnixNano() < blockedUntil
-	}
-	return false
+	return c.keyRing().IsBlocked(key)
 }
 
 // blockOpenRouterAccount blocks a specific OpenRouter API key for the given duration
-// after a 429 response, without affecting the other account. This allows sequential
-// fallback from primary to secondary account when rate limits are hit.
+// after a 429 response, without affecting the other accounts. This allows sequential
+// fallback across accounts when rate limits are hit.
 func (c *Client) blockOpenRouterAccount(apiKey string, d time.Duration) {
 	key := strings.TrimSpace(apiKey)
 	if key == "" {
@@ -1725,19 +1955,10 @@ func (c *Client) blockOpenRouterAccount(apiKey string, d time.Duration) {
 	if d <= 0 {
 		d = 60 * time.Second
 	}
-	blockedUntil := time.Now().Add(d).UnixNano()
-	if key == strings.TrimSpace(c.cfg.OpenRouterAPIKey) {
-		c.openRouter1Blocked.Store(blockedUntil)
-		slog.Warn("blocking OpenRouter primary account due to rate limit",
-			slog.Duration("block_duration", d))
-		return
-	}
-	if key == strings.TrimSpace(c.cfg.OpenRouterAPIKey2) {
-		c.openRouter2Blocked.Store(blockedUntil)
-		slog.Warn("blocking OpenRouter secondary account due to rate limit",
-			slog.Duration("block_duration", d))
-		return
-	}
+	c.keyRing().Block(key, d)
+	slog.Warn("blocking OpenRouter account due to rate limit",
+		slog.String("key_id", aiadapter.KeyID(key)),
+		slog.Duration("block_duration", d))
 }
 
 // waitGroqMinInterval enforces a minimal spacing between Groq calls to avoid rate limiting.
@@ -1790,15 +2011,7 @@ func (c *Client) isGroqAccountBlocked(apiKey string) bool {
 	if key == "" {
 		return false
 	}
-	if key == strings.TrimSpace(c.cfg.GroqAPIKey) {
-		blockedUntil := c.groq1Blocked.Load()
-		return blockedUntil != 0 && time.Now().UnixNano() < blockedUntil
-	}
-	if key == strings.TrimSpace(c.cfg.GroqAPIKey2) {
-		blockedUntil := c.groq2Blocked.Load()
-		return blockedUntil != 0 && time.Now().UnixNano() < blockedUntil
-	}
-	return false
+	return c.keyRing().IsBlocked(key)
 }
 
 // blockGroqAccount blocks a specific Groq API key for the given duration after a 429 response.
@@ -1810,19 +2023,10 @@ func (c *Client) blockGroqAccount(apiKey string, d time.Duration) {
 	if d <= 0 {
 		d = 60 * time.Second // Default 60 second cooldown
 	}
-	blockedUntil := time.Now().Add(d).UnixNano()
-	if key == strings.TrimSpace(c.cfg.GroqAPIKey) {
-		c.groq1Blocked.Store(blockedUntil)
-		slog.Warn("blocking Groq primary account due to rate limit",
-			slog.Duration("block_duration", d))
-		return
-	}
-	if key == strings.TrimSpace(c.cfg.GroqAPIKey2) {
-		c.groq2Blocked.Store(blockedUntil)
-		slog.Warn("blocking Groq secondary account due to rate limit",
-			slog.Duration("block_duration", d))
-		return
-	}
+	c.keyRing().Block(key, d)
+	slog.Warn("blocking Groq account due to rate limit",
+		slog.String("key_id", aiadapter.KeyID(key)),
+		slog.Duration("block_duration", d))
 }
 
 // parseRetryAfterHeader parses Retry-After header into duration (delta-seconds or HTTP-date).
@@ -1905,67 +2109,63 @@ func (c *Client) updateOpenRouterLimiterFromRetryAfter(apiKey string, d time.Dur
 	lua.SetBucketConfig(openRouterBucketKey(apiKey), cfg)
 }
 
-type groqModelLimit struct {
-	RPM int
-	TPM int
-}
+// groqNonChatMarkers identify Groq models that do not serve chat completions
+// (speech, text-to-speech and safety classifiers).
+var groqNonChatMarkers = []string{"whisper", "tts", "guard", "orpheus"}
 
-var groqModelLimits = map[string]groqModelLimit{
-	"llama-3.1-8b-instant":                          {RPM: 30, TPM: 6000},
-	"llama-3.3-70b-versatile":                       {RPM: 30, TPM: 12000},
-	"openai/gpt-oss-20b":                            {RPM: 30, TPM: 8000},
-	"openai/gpt-oss-120b":                           {RPM: 30, TPM: 8000},
-	"qwen/qwen3-32b":                                {RPM: 60, TPM: 6000},
-	"meta-llama/llama-4-maverick-17b-128e-instruct": {RPM: 30, TPM: 6000},
-	"meta-llama/llama-4-scout-17b-16e-instruct":     {RPM: 30, TPM: 30000},
+func isGroqChatModel(id string) bool {
+	id = strings.ToLower(id)
+	for _, m := range groqNonChatMarkers {
+		if strings.Contains(id, m) {
+			return false
+		}
+	}
+	return true
 }
 
-func (c *Client) getGroqModels(ctx domain.Context, apiKey string) []string {
+// getGroqModels returns Groq's chat models ordered by capacity, cached for
+// FREE_MODELS_REFRESH. When /models fails or lists no chat model, the last
+// list it returned is kept; without one, every model whose limits were
+// discovered or configured is used and /models is asked again on the next
+// call. It returns an error when there is no model to fall back to.
+func (c *Client) getGroqModels(ctx domain.Context, apiKey string) ([]string, error) {
 	c.groqModelsMu.RLock()
 	if len(c.groqModels) > 0 && !c.groqModelsLastFetch.IsZero() && time.Since(c.groqModelsLastFetch) < c.cfg.FreeModelsRefresh {
-		models := make([]string, len(c.groqModels))
-		copy(models, c.groqModels)
+		models := slices.Clone(c.groqModels)
 		c.groqModelsMu.RUnlock()
-		return models
+		return models, nil
 	}
 	c.groqModelsMu.RUnlock()
 
 	c.groqModelsMu.Lock()
 	defer c.groqModelsMu.Unlock()
 	if len(c.groqModels) > 0 && !c.groqModelsLastFetch.IsZero() && time.Since(c.groqModelsLastFetch) < c.cfg.FreeModelsRefresh {
-		models := make([]string, len(c.groqModels))
-		copy(models, c.groqModels)
-		return models
+		return slices.Clone(c.groqModels), nil
 	}
 
 	models, err := c.fetchGroqModelsFromAPI(ctx, apiKey)
-	if err != nil || len(models) == 0 {
-		fallback := make([]string, 0, len(groqModelLimits))
-		for id := range groqModelLimits {
-			fallback = append(fallback, id)
-		}
-		if len(fallback) > 1 {
-			sort.SliceStable(fallback, func(i, j int) bool {
-				li := groqModelLimits[fallback[i]]
-				lj := groqModelLimits[fallback[j]]
-				if li.TPM != lj.TPM {
-					return li.TPM > lj.TPM
-				}
-				return li.RPM > lj.RPM
-			})
+	if err == nil && len(models) == 0 {
+		err = errors.New("groq models response lists no chat model")
+	}
+	if err != nil {
+		if len(c.groqModels) > 0 {
+			// Keep the last good list until the next refresh.
+			slog.WarnContext(ctx, "groq models refresh failed; keeping the previous list",
+				slog.String("provider", "groq"), slog.Int("models", len(c.groqModels)), slog.Any("error", err))
+			c.groqModelsLastFetch = time.Now()
+			return slices.Clone(c.groqModels), nil
 		}
-		c.groqModels = fallback
-		c.groqModelsLastFetch = time.Now()
-		models = make([]string, len(fallback))
-		copy(models, fallback)
-		return models
+		if fallback := c.modelLimits().Models(); len(fallback) > 0 {
+			slog.WarnContext(ctx, "groq models fetch failed; using models with known limits",
+				slog.String("provider", "groq"), slog.Int("models", len(fallback)), slog.Any("error", err))
+			return fallback, nil
+		}
+		return nil, fmt.Errorf("op=groq.models: %w", err)
 	}
 
 	c.groqModels = models
 	c.groqModelsLastFetch = time.Now()
-	out := make([]string, len(models))
-	copy(out, models)
-	return out
+	return slices.Clone(models), nil
 }
 
 func (c *Client) fetchGroqModelsFromAPI(ctx domain.Context, apiKey string) ([]string, error) {
@@ -1991,7 +2191,7 @@ func (c *Client) fetchGroqModelsFromAPI(ctx domain.Context, apiKey string) ([]st
 		req.Header.Set("Authorization", "Bearer "+trimmedKey)
 	}
 
-	resp, err := c.chatHC.Do(req)
+	resp, err := c.groqHC.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("groq models request failed: %w", err)
 	}
@@ -2004,7 +2204,8 @@ func (c *Client) fetchGroqModelsFromAPI(ctx domain.Context, apiKey string) ([]st
 
 	var out struct {
 		Data []struct {
-			ID string `json:"id"`
+			ID     string `json:"id"`
+			Active *bool  `json:"active"`
 		} `json:"data"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
@@ -2014,24 +2215,12 @@ func (c *Client) fetchGroqModelsFromAPI(ctx domain.Context, apiKey string) ([]st
 	models := make([]string, 0, len(out.Data))
 	for _, m := range out.Data {
 		id := strings.TrimSpace(m.ID)
-		if id == "" {
+		if id == "" || (m.Active != nil && !*m.Active) || !isGroqChatModel(id) {
 			continue
 		}
-		if _, ok := groqModelLimits[id]; ok {
-			models = append(models, id)
-		}
-	}
-
-	if len(models) > 1 {
-		sort.SliceStable(models, func(i, j int) bool {
-			li := groqModelLimits[models[i]]
-			lj := groqModelLimits[models[j]]
-			if li.TPM != lj.TPM {
-				return li.TPM > lj.TPM
-			}
-			return li.RPM > lj.RPM
-		})
+		models = append(models, id)
 	}
+	c.modelLimits().Sort(models)
 
 	return models, nil
 }
@@ -2052,10 +2241,10 @@ func (c *Client) Embed(ctx domain.Context, texts []string) ([][]float32, error)
 	}
 	if c.cfg.OpenAIAPIKey == "" || c.cfg.EmbeddingsModel == "" {
 		// Do not log secrets; only indicate presence
-		slog.Error("OpenAI API key or model missing", slog.String("provider", "openai"), slog.Bool("has_api_key", c.cfg.OpenAIAPIKey != ""), slog.String("model", c.cfg.EmbeddingsModel))
+		slog.ErrorContext(ctx, "OpenAI API key or model missing", slog.String("provider", "openai"), slog.Bool("has_api_key", c.cfg.OpenAIAPIKey != ""), slog.String("model", c.cfg.EmbeddingsModel))
 		return nil, fmt.Errorf("%w: OPENAI_API_KEY or EMBEDDINGS_MODEL missing", domain.ErrInvalidArgument)
 	}
-	slog.Info("calling OpenAI API for embeddings", slog.String("provider", "openai"), slog.String("model", c.cfg.EmbeddingsModel), slog.Int("text_count", len(texts)))
+	slog.InfoContext(ctx, "calling OpenAI API for embeddings", slog.String("provider", "openai"), slog.String("model", c.cfg.EmbeddingsModel), slog.Int("text_count", len(texts)))
 	body := map[string]any{
 		"model": c.cfg.EmbeddingsModel,
 		"input": texts,
@@ -2074,7 +2263,7 @@ func (c *Client) Embed(ctx domain.Context, texts []string) ([][]float32, error)
 		r.Header.Set("Content-Type", "application/json")
 
 		// Log connection start for embeddings
-		slog.Debug("starting OpenAI API connection (embeddings)",
+		slog.DebugContext(ctx, "starting OpenAI API connection (embeddings)",
 			slog.String("model", c.cfg.EmbeddingsModel),
 			slog.String("endpoint", c.cfg.OpenAIBaseURL+"/embeddings"),
 			slog.Time("connection_start", connectionStart))
@@ -2084,14 +2273,14 @@ func (c *Client) Embed(ctx domain.Context, texts []string) ([][]float32, error)
 
 		// If the connection failed, do not access resp
 		if err != nil {
-			slog.Info("OpenAI API connection attempt failed (embeddings)",
+			slog.InfoContext(ctx, "OpenAI API connection attempt failed (embeddings)",
 				slog.String("model", c.cfg.EmbeddingsModel),
 				slog.Duration("connection_duration", connectionDuration))
 			return err
 		}
 
 		// Log connection duration for embeddings with response details
-		slog.Info("OpenAI API connection completed (embeddings)",
+		slog.InfoContext(ctx, "OpenAI API connection completed (embeddings)",
 			slog.String("model", c.cfg.EmbeddingsModel),
 			slog.Duration("connection_duration", connectionDuration),
 			slog.Int("status_code", resp.StatusCode),
@@ -2100,23 +2289,26 @@ func (c *Client) Embed(ctx domain.Context, texts []string) ([][]float32, error)
 		defer func() { _ = resp.Body.Close() }()
 		if resp.StatusCode == 429 {
 			// Retryable: let backoff handle retries
-			slog.Warn("ai provider rate limited", slog.String("provider", "openai"), slog.String("op", "embed"), slog.Int("status", resp.StatusCode), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("openai_request_id", resp.Header.Get("Openai-Request-Id")))
+			fp := c.providerErrors.Record(ctx, "openai", "embed", c.cfg.EmbeddingsModel, resp.StatusCode, readSnippet(resp.Body, 512))
+			slog.WarnContext(ctx, "ai provider rate limited", slog.String("provider", "openai"), slog.String("op", "embed"), slog.Int("status", resp.StatusCode), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("openai_request_id", resp.Header.Get("Openai-Request-Id")), slog.String("fingerprint", string(fp)))
 			return fmt.Errorf("rate limited: 429")
 		}
 		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
 			// Client error: non-retryable
 			bodySnippet := readSnippet(resp.Body, 512)
-			slog.Warn("ai provider 4xx", slog.String("provider", "openai"), slog.String("op", "embed"), slog.Int("status", resp.StatusCode), slog.String("model", c.cfg.EmbeddingsModel), slog.String("endpoint", c.cfg.OpenAIBaseURL+"/embeddings"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("openai_request_id", resp.Header.Get("Openai-Request-Id")), slog.String("body", bodySnippet))
+			fp := c.providerErrors.Record(ctx, "openai", "embed", c.cfg.EmbeddingsModel, resp.StatusCode, bodySnippet)
+			slog.WarnContext(ctx, "ai provider 4xx", slog.String("provider", "openai"), slog.String("op", "embed"), slog.Int("status", resp.StatusCode), slog.String("model", c.cfg.EmbeddingsModel), slog.String("endpoint", c.cfg.OpenAIBaseURL+"/embeddings"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("openai_request_id", resp.Header.Get("Openai-Request-Id")), slog.String("fingerprint", string(fp)), slog.String("body", bodySnippet))
 			return backoff.Permanent(fmt.Errorf("embed status %d", resp.StatusCode))
 		}
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 			// 5xx and others: retryable
 			bodySnippet := readSnippet(resp.Body, 512)
-			slog.Error("ai provider non-2xx", slog.String("provider", "openai"), slog.String("op", "embed"), slog.Int("status", resp.StatusCode), slog.String("model", c.cfg.EmbeddingsModel), slog.String("endpoint", c.cfg.OpenAIBaseURL+"/embeddings"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("openai_request_id", resp.Header.Get("Openai-Request-Id")), slog.String("body", bodySnippet))
+			fp := c.providerErrors.Record(ctx, "openai", "embed", c.cfg.EmbeddingsModel, resp.StatusCode, bodySnippet)
+			slog.ErrorContext(ctx, "ai provider non-2xx", slog.String("provider", "openai"), slog.String("op", "embed"), slog.Int("status", resp.StatusCode), slog.String("model", c.cfg.EmbeddingsModel), slog.String("endpoint", c.cfg.OpenAIBaseURL+"/embeddings"), slog.String("x_request_id", resp.Header.Get("X-Request-Id")), slog.String("openai_request_id", resp.Header.Get("Openai-Request-Id")), slog.String("fingerprint", string(fp)), slog.String("body", bodySnippet))
 			return fmt.Errorf("embed status %d", resp.StatusCode)
 		}
 		if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-			slog.Error("ai provider decode error", slog.String("provider", "openai"), slog.String("op", "embed"), slog.String("model", c.cfg.EmbeddingsModel), slog.String("endpoint", c.cfg.OpenAIBaseURL+"/embeddings"), slog.Any("error", err))
+			slog.ErrorContext(ctx, "ai provider decode error", slog.String("provider", "openai"), slog.String("op", "embed"), slog.String("model", c.cfg.EmbeddingsModel), slog.String("endpoint", c.cfg.OpenAIBaseURL+"/embeddings"), slog.Any("error", err))
 			return err
 		}
 		return nil
@@ -2126,9 +2318,9 @@ func (c *Client) Embed(ctx domain.Context, texts []string) ([][]float32, error)
 		expo := c.getBackoffConfig()
 		bo := backoff.WithContext(expo, callCtx)
 
-		slog.Info("starting OpenAI API retry logic", slog.String("provider", "openai"), slog.Duration("max_elapsed", expo.MaxElapsedTime))
+		slog.InfoContext(ctx, "starting OpenAI API retry logic", slog.String("provider", "openai"), slog.Duration("max_elapsed", expo.MaxElapsedTime))
 		if err := backoff.Retry(func() error { return op(callCtx) }, bo); err != nil {
-			slog.Error("OpenAI API failed after retries", slog.String("provider", "openai"), slog.Any("error", err))
+			slog.ErrorContext(ctx, "OpenAI API failed after retries", slog.String("provider", "openai"), slog.Any("error", err))
 			return fmt.Errorf("openai api failed: %w", err)
 		}
 		return nil
@@ -2138,11 +2330,11 @@ func (c *Client) Embed(ctx domain.Context, texts []string) ([][]float32, error)
 	}
 
 	if len(out.Data) == 0 {
-		slog.Error("OpenAI API returned empty data", slog.String("provider", "openai"))
+		slog.ErrorContext(ctx, "OpenAI API returned empty data", slog.String("provider", "openai"))
 		return nil, errors.New("empty data from OpenAI API")
 	}
 
-	slog.Info("OpenAI API call successful", slog.String("provider", "openai"), slog.Int("data_count", len(out.Data)))
+	slog.InfoContext(ctx, "OpenAI API call successful", slog.String("provider", "openai"), slog.Int("data_count", len(out.Data)))
 	res := make([][]float32, len(out.Data))
 	for i := range out.Data {
 		v := make([]float32, len(out.Data[i].Embedding))
@@ -2163,7 +2355,7 @@ func (c *Client) Embed(ctx domain.Context, texts []string) ([][]float32, error)
 	}
 	if totalInputTokens > 0 {
 		observability.RecordAITokenUsage("openai", "embed", c.cfg.EmbeddingsModel, totalInputTokens)
-		slog.Debug("recorded embedding token usage",
+		slog.DebugContext(ctx, "recorded embedding token usage",
 			slog.String("provider", "openai"),
 			slog.String("model", c.cfg.EmbeddingsModel),
 			slog.Int("tokens", totalInputTokens))
@@ -2174,7 +2366,7 @@ func (c *Client) Embed(ctx domain.Context, texts []string) ([][]float32, error)
 
 // CleanCoTResponse sends a response with CoT leakage back to OpenRouter for cleaning
 func (c *Client) CleanCoTResponse(ctx domain.Context, originalResponse string) (string, error) {
-	slog.Info("cleaning CoT leakage from response", slog.Int("original_length", len(originalResponse)))
+	slog.InfoContext(ctx, "cleaning CoT leakage from response", slog.Int("original_length", len(originalResponse)))
 
 	// Create a cleaning prompt
 	cleaningPrompt := `You are a response cleaner. Remove all chain-of-thought reasoning, step-by-step analysis, and explanatory text from the following response. Return ONLY the clean JSON data without any reasoning, explanations, or step-by-step analysis.
@@ -2188,32 +2380,28 @@ Original response to clean:
 	// Get free models and select a different one for cleaning
 	freeModels, err := c.freeModelsSvc.GetFreeModels(ctx)
 	if err != nil {
-		slog.Error("failed to get free models for cleaning", slog.Any("error", err))
+		slog.ErrorContext(ctx, "failed to get free models for cleaning", slog.Any("error", err))
 		return "", fmt.Errorf("failed to get free models for cleaning: %w", err)
 	}
 
 	if len(freeModels) == 0 {
-		slog.Error("no free models available for cleaning")
+		slog.ErrorContext(ctx, "no free models available for cleaning")
 		return "", fmt.Errorf("no free models available for cleaning")
 	}
 
-	// Select a different model for cleaning (use a different index)
-	cleaningModelIndex := (atomic.AddInt64(&c.modelCounter, 1) + 1) % int64(len(freeModels))
-	cleaningModel := freeModels[cleaningModelIndex]
+	// Select the cleaning model by a health-weighted draw
+	ordered := c.orderByHealth(freeModels)
+	cleaningModel := ordered[0]
 
-	slog.Info("using cleaning model",
+	slog.InfoContext(ctx, "using cleaning model",
 		slog.String("model", cleaningModel.ID),
 		slog.String("model_name", cleaningModel.Name),
-		slog.Int64("model_index", cleaningModelIndex))
+		slog.Float64("health_score", c.health.Score(cleaningModel.ID)))
 
 	// Prepare fallback models for cleaning
 	fallbackModels := make([]string, 0, 3)
-	fallbackCount := 0
-	for i := 0; i < len(freeModels) && fallbackCount < 3; i++ {
-		if int64(i) != cleaningModelIndex {
-			fallbackModels = append(fallbackModels, freeModels[i].ID)
-			fallbackCount++
-		}
+	for i := 1; i < len(ordered) && len(fallbackModels) < 3; i++ {
+		fallbackModels = append(fallbackModels, ordered[i].ID)
 	}
 
 	// Call OpenRouter API for cleaning
@@ -2226,6 +2414,9 @@ Original response to clean:
 			{"role": "user", "content": "Clean this response and return only the JSON data:"},
 		},
 	}
+	if p := c.openRouterProvider(ctx); p != nil {
+		body["provider"] = p
+	}
 
 	// Add fallback models if available
 	if len(fallbackModels) > 0 {
@@ -2233,21 +2424,22 @@ Original response to clean:
 	}
 
 	b, _ := json.Marshal(body)
-	slog.Debug("CoT cleaning request body", slog.String("body", string(b)))
+	slog.DebugContext(ctx, "CoT cleaning request body", slog.String("body", c.logBody(b)))
 
 	var out struct {
 		Model   string `json:"model"`
 		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
+			Message chatMessage `json:"message"`
 		} `json:"choices"`
 	}
 	openRouterKey := c.getOpenRouterAPIKey()
+	c.keyRing().Use(openRouterKey)
 
 	op := func(callCtx context.Context) error {
 		// Respect global OpenRouter client-level throttling to avoid 429s during cleaning
-		c.waitOpenRouterMinInterval()
+		if err := c.waitOpenRouterMinInterval(callCtx); err != nil {
+			return backoff.Permanent(err)
+		}
 		r, _ := http.NewRequestWithContext(callCtx, http.MethodPost, c.cfg.OpenRouterBaseURL+"/chat/completions", bytes.NewReader(b))
 		r.Header.Set("Authorization", "Bearer "+openRouterKey)
 		r.Header.Set("Content-Type", "application/json")
@@ -2259,7 +2451,8 @@ Original response to clean:
 		defer func() { _ = resp.Body.Close() }()
 
 		if resp.StatusCode == 429 {
-			slog.Warn("ai provider rate limited during CoT cleaning", slog.String("provider", "openrouter"), slog.String("op", "cot_cleaning"), slog.Int("status", resp.StatusCode))
+			fp := c.providerErrors.Record(ctx, "openrouter", "cot_cleaning", cleaningModel.ID, resp.StatusCode, readSnippet(resp.Body, 512))
+			slog.WarnContext(ctx, "ai provider rate limited during CoT cleaning", slog.String("provider", "openrouter"), slog.String("op", "cot_cleaning"), slog.Int("status", resp.StatusCode), slog.String("fingerprint", string(fp)))
 			// Block the cleaning model briefly as well
 			retryAfter := parseRetryAfterHeader(resp.Header.Get("Retry-After"))
 			if c.rlc != nil {
@@ -2271,16 +2464,18 @@ Original response to clean:
 		}
 		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
 			bodySnippet := readSnippet(resp.Body, 512)
-			slog.Warn("ai provider 4xx during CoT cleaning", slog.String("provider", "openrouter"), slog.String("op", "cot_cleaning"), slog.Int("status", resp.StatusCode), slog.String("model", cleaningModel.ID), slog.String("body", bodySnippet))
+			fp := c.providerErrors.Record(ctx, "openrouter", "cot_cleaning", cleaningModel.ID, resp.StatusCode, bodySnippet)
+			slog.WarnContext(ctx, "ai provider 4xx during CoT cleaning", slog.String("provider", "openrouter"), slog.String("op", "cot_cleaning"), slog.Int("status", resp.StatusCode), slog.String("model", cleaningModel.ID), slog.String("fingerprint", string(fp)), slog.String("body", bodySnippet))
 			return backoff.Permanent(fmt.Errorf("cot cleaning status %d", resp.StatusCode))
 		}
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 			bodySnippet := readSnippet(resp.Body, 512)
-			slog.Error("ai provider non-2xx during CoT cleaning", slog.String("provider", "openrouter"), slog.String("op", "cot_cleaning"), slog.Int("status", resp.StatusCode), slog.String("model", cleaningModel.ID), slog.String("body", bodySnippet))
+			fp := c.providerErrors.Record(ctx, "openrouter", "cot_cleaning", cleaningModel.ID, resp.StatusCode, bodySnippet)
+			slog.ErrorContext(ctx, "ai provider non-2xx during CoT cleaning", slog.String("provider", "openrouter"), slog.String("op", "cot_cleaning"), slog.Int("status", resp.StatusCode), slog.String("model", cleaningModel.ID), slog.String("fingerprint", string(fp)), slog.String("body", bodySnippet))
 			return fmt.Errorf("cot cleaning status %d", resp.StatusCode)
 		}
 		if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-			slog.Error("ai provider decode error during CoT cleaning", slog.String("provider", "openrouter"), slog.String("op", "cot_cleaning"), slog.String("model", cleaningModel.ID), slog.Any("error", err))
+			slog.ErrorContext(ctx, "ai provider decode error during CoT cleaning", slog.String("provider", "openrouter"), slog.String("op", "cot_cleaning"), slog.String("model", cleaningModel.ID), slog.Any("error", err))
 			return err
 		}
 		return nil
@@ -2290,9 +2485,9 @@ Original response to clean:
 		expo := c.getBackoffConfig()
 		bo := backoff.WithContext(expo, callCtx)
 
-		slog.Info("starting CoT cleaning retry logic", slog.String("provider", "openrouter"), slog.Duration("max_elapsed", expo.MaxElapsedTime))
+		slog.InfoContext(ctx, "starting CoT cleaning retry logic", slog.String("provider", "openrouter"), slog.Duration("max_elapsed", expo.MaxElapsedTime))
 		if err := backoff.Retry(func() error { return op(callCtx) }, bo); err != nil {
-			slog.Error("CoT cleaning failed after retries", slog.String("provider", "openrouter"), slog.Any("error", err))
+			slog.ErrorContext(ctx, "CoT cleaning failed after retries", slog.String("provider", "openrouter"), slog.Any("error", err))
 			return fmt.Errorf("cot cleaning failed: %w", err)
 		}
 		return nil
@@ -2302,12 +2497,12 @@ Original response to clean:
 	}
 
 	if len(out.Choices) == 0 {
-		slog.Error("CoT cleaning returned empty choices", slog.String("provider", "openrouter"))
+		slog.ErrorContext(ctx, "CoT cleaning returned empty choices", slog.String("provider", "openrouter"))
 		return "", errors.New("empty choices from CoT cleaning")
 	}
 
-	cleanedResponse := out.Choices[0].Message.Content
-	slog.Info("CoT cleaning successful",
+	cleanedResponse := c.finalAnswer(ctx, "openrouter", cleaningModel.ID, out.Choices[0].Message)
+	slog.InfoContext(ctx, "CoT cleaning successful",
 		slog.String("provider", "openrouter"),
 		slog.Int("original_length", len(originalResponse)),
 		slog.Int("cleaned_length", len(cleanedResponse)),
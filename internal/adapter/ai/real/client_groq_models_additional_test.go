@@ -5,16 +5,18 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	aiadapter "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
 )
 
-func TestFetchGroqModelsFromAPI_SuccessFiltersKnownModels(t *testing.T) {
+func TestFetchGroqModelsFromAPI_SuccessFiltersNonChatModels(t *testing.T) {
 	t.Parallel()
 
 	// Local HTTP server returning a mix of known and unknown Groq model IDs.
@@ -24,8 +26,11 @@ func TestFetchGroqModelsFromAPI_SuccessFiltersKnownModels(t *testing.T) {
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(map[string]any{
 			"data": []map[string]any{
-				{"id": "llama-3.1-8b-instant"},        // present in groqModelLimits
-				{"id": "some-unknown-model-not-used"}, // filtered out
+				{"id": "brand-new-model"},                // no known limits yet: ordered last
+				{"id": "llama-3.1-8b-instant"},           // limits configured
+				{"id": "whisper-large-v3"},               // speech model: filtered out
+				{"id": "retired-model", "active": false}, // inactive: filtered out
+				{"id": "meta-llama/llama-guard-4-12b"},   // safety classifier: filtered out
 			},
 		})
 	}))
@@ -33,15 +38,16 @@ func TestFetchGroqModelsFromAPI_SuccessFiltersKnownModels(t *testing.T) {
 
 	c := &Client{
 		cfg: config.Config{
-			GroqBaseURL: ts.URL,
+			GroqBaseURL:     ts.URL,
+			GroqModelLimits: "llama-3.1-8b-instant=14400:6000",
 		},
-		chatHC: ts.Client(),
+		groqHC: ts.Client(),
 	}
 
 	ctx := context.Background()
 	models, err := c.fetchGroqModelsFromAPI(ctx, "  test-key  ")
 	require.NoError(t, err)
-	require.Equal(t, []string{"llama-3.1-8b-instant"}, models)
+	require.Equal(t, []string{"llama-3.1-8b-instant", "brand-new-model"}, models)
 }
 
 func TestFetchGroqModelsFromAPI_Non200Status(t *testing.T) {
@@ -55,7 +61,7 @@ func TestFetchGroqModelsFromAPI_Non200Status(t *testing.T) {
 
 	c := &Client{
 		cfg:    config.Config{GroqBaseURL: ts.URL},
-		chatHC: ts.Client(),
+		groqHC: ts.Client(),
 	}
 
 	ctx := context.Background()
@@ -84,16 +90,106 @@ func TestGetGroqModels_CachesResults(t *testing.T) {
 			GroqBaseURL:       ts.URL,
 			FreeModelsRefresh: time.Hour,
 		},
-		chatHC: ts.Client(),
+		groqHC: ts.Client(),
 	}
 
 	ctx := context.Background()
 
-	first := c.getGroqModels(ctx, "g-key")
+	first, err := c.getGroqModels(ctx, "g-key")
+	require.NoError(t, err)
 	require.NotEmpty(t, first)
 	require.Equal(t, int32(1), atomic.LoadInt32(&requestCount), "expected exactly one HTTP request on first call")
 
-	second := c.getGroqModels(ctx, "g-key")
+	second, err := c.getGroqModels(ctx, "g-key")
+	require.NoError(t, err)
 	require.Equal(t, first, second, "expected cached models to be returned on second call")
 	require.Equal(t, int32(1), atomic.LoadInt32(&requestCount), "expected no additional HTTP requests due to caching")
 }
+
+func TestGetGroqModels_FallsBackToKnownLimits(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	limits := aiadapter.NewModelLimits(aiadapter.ProviderGroq, nil)
+	h := http.Header{}
+	h.Set("x-ratelimit-limit-tokens", "30000")
+	limits.Observe(context.Background(), "discovered-model", h)
+
+	c := (&Client{
+		cfg:    config.Config{GroqBaseURL: ts.URL, FreeModelsRefresh: time.Hour},
+		groqHC: ts.Client(),
+	}).WithModelLimits(limits)
+
+	models, err := c.getGroqModels(context.Background(), "g-key")
+	require.NoError(t, err)
+	require.Equal(t, []string{"discovered-model"}, models)
+}
+
+func TestGetGroqModels_KeepsLastGoodList(t *testing.T) {
+	t.Parallel()
+
+	var failing atomic.Bool
+	var requestCount int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": []map[string]any{{"id": "llama-3.1-8b-instant"}}})
+	}))
+	defer ts.Close()
+
+	// A zero refresh period asks /models on every call.
+	c := &Client{cfg: config.Config{GroqBaseURL: ts.URL}, groqHC: ts.Client()}
+	ctx := context.Background()
+	models, err := c.getGroqModels(ctx, "g-key")
+	require.NoError(t, err)
+	require.Equal(t, []string{"llama-3.1-8b-instant"}, models)
+
+	failing.Store(true)
+	models, err = c.getGroqModels(ctx, "g-key")
+	require.NoError(t, err)
+	require.Equal(t, []string{"llama-3.1-8b-instant"}, models)
+	require.Equal(t, int32(2), atomic.LoadInt32(&requestCount))
+}
+
+func TestGetGroqModels_ErrorWithoutFallback(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	c := (&Client{
+		cfg:    config.Config{GroqBaseURL: ts.URL, FreeModelsRefresh: time.Hour},
+		groqHC: ts.Client(),
+	}).WithModelLimits(aiadapter.NewModelLimits(aiadapter.ProviderGroq, nil))
+
+	models, err := c.getGroqModels(context.Background(), "g-key")
+	require.ErrorContains(t, err, "groq models status 502")
+	require.Empty(t, models)
+}
+
+func TestWithModelLimits_ConcurrentWithReaders(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{}
+	limits := aiadapter.NewModelLimits(aiadapter.ProviderGroq, nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NotNil(t, c.modelLimits())
+		}()
+	}
+	c.WithModelLimits(limits)
+	wg.Wait()
+	require.Same(t, limits, c.modelLimits())
+}
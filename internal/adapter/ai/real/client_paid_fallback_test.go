@@ -0,0 +1,68 @@
+package real
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/freemodels"
+)
+
+func TestPaidFallbackModels_Policy(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(freemodels.OpenRouterResponse{
+			Data: []freemodels.Model{
+				{ID: "cheap/a", Pricing: freemodels.Pricing{Prompt: "0.0000001", Completion: "0.0000001"}},
+				{ID: "pricey/b", Pricing: freemodels.Pricing{Prompt: "0.00001", Completion: "0.00003"}},
+			},
+		})
+	}))
+	defer ts.Close()
+
+	newClient := func(cfg config.Config) *Client {
+		return &Client{cfg: cfg, freeModelsSvc: freemodels.NewService("k", ts.URL, time.Hour)}
+	}
+	ctx := context.Background()
+
+	models, outcome, err := newClient(config.Config{PaidFallbackEnabled: true}).paidFallbackModels(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "used", outcome)
+	require.Len(t, models, 2)
+	assert.Equal(t, "cheap/a", models[0].ID)
+
+	models, outcome, _ = newClient(config.Config{PaidFallbackEnabled: false}).paidFallbackModels(ctx)
+	assert.Equal(t, "disabled", outcome)
+	assert.Empty(t, models)
+
+	optIn := newClient(config.Config{PaidFallbackEnabled: true, PaidFallbackRequireOptIn: true})
+	before := calls.Load()
+	_, outcome, _ = optIn.paidFallbackModels(ctx)
+	assert.Equal(t, "no_opt_in", outcome)
+	assert.Equal(t, before, calls.Load(), "catalog is not fetched without opt-in")
+	models, outcome, _ = optIn.paidFallbackModels(domain.WithPaidFallbackOptIn(ctx, true))
+	assert.Equal(t, "used", outcome)
+	assert.Len(t, models, 2)
+
+	models, outcome, _ = newClient(config.Config{PaidFallbackEnabled: true, PaidFallbackMaxPricePer1K: 0.001}).paidFallbackModels(ctx)
+	assert.Equal(t, "used", outcome)
+	require.Len(t, models, 1)
+	assert.Equal(t, "cheap/a", models[0].ID)
+
+	models, outcome, _ = newClient(config.Config{PaidFallbackEnabled: true, PaidFallbackModels: "other/x"}).paidFallbackModels(ctx)
+	assert.Equal(t, "unavailable", outcome)
+	assert.Empty(t, models)
+}
@@ -3,6 +3,7 @@ package real
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -492,12 +493,12 @@ func TestReadSSEChatStream_AccumulatedContent(t *testing.T) {
 		"",
 	}, "\n")
 
-	out, err := readSSEChatStream(strings.NewReader(stream), "test-provider", "test-model", 5*time.Second)
+	out, err := readSSEChatStream(strings.NewReader(stream), "test-provider", "test-model", 5*time.Second, 0)
 	if err != nil {
 		t.Fatalf("unexpected error from readSSEChatStream: %v", err)
 	}
-	if out != "Hello world" {
-		t.Fatalf("unexpected accumulated content: %q", out)
+	if out.Content != "Hello world" {
+		t.Fatalf("unexpected accumulated content: %q", out.Content)
 	}
 }
 
@@ -542,15 +543,65 @@ func TestReadSSEChatStream_IdleTimeout(t *testing.T) {
 	})
 
 	start := time.Now()
-	_, err := readSSEChatStream(stream, "test-provider", "test-model", 50*time.Millisecond)
+	partial, err := readSSEChatStream(stream, "test-provider", "test-model", 50*time.Millisecond, 0)
 	if err == nil || !strings.Contains(err.Error(), "stream idle") {
 		t.Fatalf("expected idle timeout error, got: %v", err)
 	}
+	if partial.Content != "Hi" {
+		t.Fatalf("expected partial content to be returned, got: %q", partial.Content)
+	}
 	if time.Since(start) < 40*time.Millisecond {
 		t.Fatalf("idle timeout triggered too early: %v", time.Since(start))
 	}
 }
 
+func TestReadSSEChatStream_TokenTimeoutIgnoresKeepAlive(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = io.WriteString(pw, "data: {\"choices\":[{\"delta\":{\"content\":\"{\\\"a\\\":1,\"}}]}\n")
+		for i := 0; i < 20; i++ {
+			if _, err := io.WriteString(pw, ":keep-alive\n"); err != nil {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		_ = pw.Close()
+	}()
+
+	partial, err := readSSEChatStream(pr, "test-provider", "test-model", time.Second, 50*time.Millisecond)
+	if !errors.Is(err, errStreamIdle) {
+		t.Fatalf("expected token timeout error, got: %v", err)
+	}
+	if partial.Content != `{"a":1,` {
+		t.Fatalf("unexpected partial content: %q", partial.Content)
+	}
+}
+
+func TestReadChatStream_SalvagesPartialJSON(t *testing.T) {
+	lines := []string{
+		"data: {\"choices\":[{\"delta\":{\"content\":\"{\\\"score\\\":4,\\\"feedback\\\":\\\"Solid\"}}]}",
+	}
+
+	c := &Client{cfg: config.Config{SSEIdleTimeout: 50 * time.Millisecond}}
+	if _, err := c.readChatStream(newIdleTestStream(lines), "groq", "m"); !errors.Is(err, errStreamIdle) {
+		t.Fatalf("expected idle error without salvage, got: %v", err)
+	}
+
+	c.cfg.SSESalvagePartial = true
+	out, err := c.readChatStream(newIdleTestStream(lines), "groq", "m")
+	if err != nil {
+		t.Fatalf("expected salvage to succeed, got: %v", err)
+	}
+	if out.Content != `{"score":4,"feedback":"Solid"}` {
+		t.Fatalf("unexpected salvaged content: %q", out.Content)
+	}
+
+	unrecoverable := []string{"data: {\"choices\":[{\"delta\":{\"content\":\"Thinking about\"}}]}"}
+	if _, err := c.readChatStream(newIdleTestStream(unrecoverable), "groq", "m"); !errors.Is(err, errStreamIdle) {
+		t.Fatalf("expected idle error when nothing is recoverable, got: %v", err)
+	}
+}
+
 func TestTestClient_GetBackoffConfig(t *testing.T) {
 	cfg := config.Config{
 		OpenRouterAPIKey: "test-key",
@@ -0,0 +1,151 @@
+package real
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/freemodels"
+)
+
+// lateResultContent is a response long enough to pass refusal detection.
+const lateResultContent = `{"model": "%s", "summary": "The candidate matches most of the listed backend requirements."}`
+
+// slowChatServer answers chat completions after delay, or not before the
+// request is canceled when delay is 0; canceled receives the models of
+// canceled requests.
+func slowChatServer(t *testing.T, delay time.Duration, canceled chan<- string) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var cr chatReq
+		_ = json.NewDecoder(r.Body).Decode(&cr)
+		var wait <-chan time.Time
+		if delay > 0 {
+			wait = time.After(delay)
+		}
+		select {
+		case <-wait:
+		case <-r.Context().Done():
+			canceled <- cr.Model
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": fmt.Sprintf(lateResultContent, cr.Model)}}},
+		})
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestEnhancedModelSwitching_CancelsTimedOutAttempt(t *testing.T) {
+	canceled := make(chan string, 4)
+	ts := slowChatServer(t, 0, canceled)
+	c := NewTestClient(config.Config{
+		OpenRouterAPIKey:      "x",
+		OpenRouterBaseURL:     ts.URL,
+		AIModelAttemptTimeout: 50 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := c.chatJSONWithEnhancedModelSwitchingForKey(ctx, "x", "sys", "user", 64, []freemodels.Model{{ID: "a:free"}})
+		errs <- err
+	}()
+
+	// The request of the timed-out attempt is canceled at the timeout.
+	select {
+	case model := <-canceled:
+		assert.Equal(t, "a:free", model)
+	case <-time.After(time.Second):
+		t.Fatal("timed-out attempt not canceled")
+	}
+	// Canceling the caller ends the backoff before the next attempt.
+	cancel()
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("model switching did not stop with its context")
+	}
+}
+
+func TestEnhancedModelSwitching_HarvestsLateResult(t *testing.T) {
+	canceled := make(chan string, 4)
+	ts := slowChatServer(t, 150*time.Millisecond, canceled)
+	c := NewTestClient(config.Config{
+		OpenRouterAPIKey:      "x",
+		OpenRouterBaseURL:     ts.URL,
+		AIModelAttemptTimeout: 50 * time.Millisecond,
+		AILateResultGrace:     time.Second,
+	})
+
+	start := time.Now()
+	out, err := c.chatJSONWithEnhancedModelSwitchingForKey(context.Background(), "x", "sys", "user", 64, []freemodels.Model{{ID: "a:free"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(lateResultContent, "a:free"), out)
+	// The result arrived during the backoff before the retry.
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Empty(t, canceled)
+}
+
+func TestChatJSONWithRetry_WaitsForSlowFirstAccount(t *testing.T) {
+	var mu sync.Mutex
+	var sent []string // accounts the prompt was sent with
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/models" {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": []map[string]any{{"id": "a:free", "pricing": map[string]string{"prompt": "0", "completion": "0"}}},
+			})
+			return
+		}
+		mu.Lock()
+		sent = append(sent, r.Header.Get("Authorization"))
+		mu.Unlock()
+		// The first account answers after its attempt timed out, its retry
+		// backoff passed and the fallback moved on to the second account.
+		select {
+		case <-time.After(2300 * time.Millisecond):
+		case <-r.Context().Done():
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": fmt.Sprintf(lateResultContent, "a:free")}}},
+		})
+	}))
+	t.Cleanup(ts.Close)
+	c := NewTestClient(config.Config{
+		OpenRouterAPIKey:      "first",
+		OpenRouterAPIKey2:     "second",
+		OpenRouterBaseURL:     ts.URL,
+		AIModelAttemptTimeout: 50 * time.Millisecond,
+		AILateResultGrace:     5 * time.Second,
+	})
+
+	ctx := domain.WithEvaluationJob(context.Background(), "job-1")
+	start := time.Now()
+	out, err := c.ChatJSONWithRetry(ctx, "sys", "user", 64)
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(lateResultContent, "a:free"), out)
+	assert.Less(t, time.Since(start), 4*time.Second)
+	// Neither the retry nor the second account resent the prompt while the
+	// first account's request was in flight.
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, sent, 1)
+	c.racesMu.Lock()
+	defer c.racesMu.Unlock()
+	assert.Empty(t, c.races)
+}
@@ -0,0 +1,144 @@
+package real
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	aiadapter "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	intobs "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/freemodels"
+)
+
+// capMaxTokens lowers maxTokens to the tenant's token budget carried by ctx.
+func capMaxTokens(ctx domain.Context, maxTokens int) int {
+	if limit := domain.EvaluationOverridesFrom(ctx).MaxTokens; limit > 0 && (maxTokens <= 0 || maxTokens > limit) {
+		return limit
+	}
+	return maxTokens
+}
+
+// adaptMaxTokens returns the max_tokens for a call to model: the completion
+// budget learned for the evaluation step carried by ctx, or maxTokens until
+// one is learned, within the tenant's token budget.
+func (c *Client) adaptMaxTokens(ctx domain.Context, model string, maxTokens int) int {
+	return capMaxTokens(ctx, c.budget.MaxTokens(domain.EvaluationStepFrom(ctx), model, maxTokens))
+}
+
+// preferModels moves the tenant's preferred models carried by ctx to the
+// front of models, in preference order, keeping the relative order of the
+// rest. Preferred models the provider does not offer are ignored.
+func preferModels[T any](ctx domain.Context, models []T, id func(T) string) []T {
+	preferred := domain.EvaluationOverridesFrom(ctx).PreferredModels
+	if len(preferred) == 0 || len(models) < 2 {
+		return models
+	}
+	out := make([]T, 0, len(models))
+	for _, p := range preferred {
+		for _, m := range models {
+			if id(m) == p {
+				out = append(out, m)
+			}
+		}
+	}
+	for _, m := range models {
+		if !slices.Contains(preferred, id(m)) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// chatPinned calls the model pinned by the overrides carried by ctx, trying
+// the provider's usable keys in rotation order. No other model or provider is
+// tried, so a failure is reported as is.
+func (c *Client) chatPinned(ctx domain.Context, ov domain.EvaluationOverrides, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	var call func(key string) (string, error)
+	switch ov.Provider {
+	case aiadapter.ProviderGroq:
+		model := strings.TrimPrefix(ov.Model, "groq/")
+		call = func(key string) (string, error) {
+			return c.callGroqChatWithModel(ctx, key, model, systemPrompt, userPrompt, maxTokens)
+		}
+	case aiadapter.ProviderOpenRouter:
+		call = func(key string) (string, error) {
+			return c.callOpenRouterWithModelForKey(ctx, key, ov.Model, systemPrompt, userPrompt, maxTokens)
+		}
+	default:
+		return "", fmt.Errorf("%w: unknown pinned provider %q", domain.ErrInvalidArgument, ov.Provider)
+	}
+	keys := c.keyRing().Candidates(ov.Provider)
+	if len(keys) == 0 {
+		return "", fmt.Errorf("%w: no usable %s key for pinned model %s", domain.ErrUpstreamRateLimit, ov.Provider, ov.Model)
+	}
+	var lastErr error
+	for _, key := range keys {
+		res, err := call(key)
+		if err == nil {
+			return res, nil
+		}
+		lastErr = err
+	}
+	return "", fmt.Errorf("pinned %s model %s failed: %w", ov.Provider, ov.Model, lastErr)
+}
+
+// openRouterProvider returns the "provider" object of an OpenRouter chat
+// request: the deployment's routing options with the overrides carried by
+// ctx applied, or nil when neither sets any.
+func (c *Client) openRouterProvider(ctx domain.Context) *domain.OpenRouterProviderPrefs {
+	r := c.cfg.GetOpenRouterProviderRouting()
+	p := domain.OpenRouterProviderPrefs{Order: r.Order, Ignore: r.Ignore}
+	if !r.AllowFallbacks {
+		p.AllowFallbacks = &r.AllowFallbacks
+	}
+	if r.DenyDataCollection {
+		p.DataCollection = "deny"
+	}
+	if r.RequireParameters {
+		p.RequireParameters = &r.RequireParameters
+	}
+	if ov := domain.EvaluationOverridesFrom(ctx).OpenRouterProvider; ov != nil {
+		p = p.Merge(*ov)
+	}
+	if p.IsZero() {
+		return nil
+	}
+	return &p
+}
+
+// setSampling sets the sampling parameters of a chat request body: the
+// deployment's temperature, replaced by the parameters the overrides carried
+// by ctx set.
+func (c *Client) setSampling(ctx domain.Context, body map[string]any) {
+	body["temperature"] = c.cfg.AITemperature
+	p := domain.EvaluationOverridesFrom(ctx).Sampling
+	if p == nil {
+		return
+	}
+	if p.Temperature != nil {
+		body["temperature"] = *p.Temperature
+	}
+	if p.TopP != nil {
+		body["top_p"] = *p.TopP
+	}
+	if p.FrequencyPenalty != nil {
+		body["frequency_penalty"] = *p.FrequencyPenalty
+	}
+}
+
+// challengerFellBack logs and counts a failed call to the challenger model,
+// after which the call is served by the default pool.
+func (c *Client) challengerFellBack(ctx domain.Context, ov domain.EvaluationOverrides, err error) {
+	intobs.LoggerFromContext(ctx).Warn("challenger model failed; falling back to the default pool",
+		slog.String("provider", ov.Provider),
+		slog.String("model", ov.Model),
+		slog.Any("error", err))
+	observability.RecordModelChallengerFallback(ov.Model)
+}
+
+func modelID(m freemodels.Model) string { return m.ID }
+
+func sameID(id string) string { return id }
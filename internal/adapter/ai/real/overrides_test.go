@@ -0,0 +1,211 @@
+package real
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	aiadapter "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/freemodels"
+)
+
+func TestCapMaxTokens(t *testing.T) {
+	ctx := context.Background()
+	if got := capMaxTokens(ctx, 2000); got != 2000 {
+		t.Fatalf("no override: got %d", got)
+	}
+	ctx = domain.WithEvaluationOverrides(ctx, domain.EvaluationOverrides{MaxTokens: 800})
+	for in, want := range map[int]int{2000: 800, 500: 500, 0: 800} {
+		if got := capMaxTokens(ctx, in); got != want {
+			t.Errorf("capMaxTokens(%d) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestAdaptMaxTokens(t *testing.T) {
+	c := &Client{budget: aiadapter.NewCompletionBudget(0, 1, 0)}
+	ctx := domain.WithEvaluationStep(context.Background(), "cv_match", "1")
+	if got := c.adaptMaxTokens(ctx, "m", 1024); got != 1024 {
+		t.Fatalf("no history: got %d", got)
+	}
+	c.budget.Observe("cv_match", "m", 300, 1024)
+	if got := c.adaptMaxTokens(ctx, "m", 1024); got != 300 {
+		t.Fatalf("learned: got %d", got)
+	}
+	// The tenant's token budget still applies to learned values.
+	ctx = domain.WithEvaluationOverrides(ctx, domain.EvaluationOverrides{MaxTokens: 200})
+	if got := c.adaptMaxTokens(ctx, "m", 1024); got != 200 {
+		t.Fatalf("capped: got %d", got)
+	}
+	if got := (&Client{}).adaptMaxTokens(context.Background(), "m", 1024); got != 1024 {
+		t.Fatalf("disabled: got %d", got)
+	}
+}
+
+func TestPreferModels(t *testing.T) {
+	ids := []string{"a", "b", "c", "d"}
+	if got := preferModels(context.Background(), ids, sameID); !reflect.DeepEqual(got, ids) {
+		t.Fatalf("no override: got %v", got)
+	}
+	ctx := domain.WithEvaluationOverrides(context.Background(), domain.EvaluationOverrides{PreferredModels: []string{"c", "x", "a"}})
+	if got := preferModels(ctx, ids, sameID); !reflect.DeepEqual(got, []string{"c", "a", "b", "d"}) {
+		t.Fatalf("got %v", got)
+	}
+	models := []freemodels.Model{{ID: "a"}, {ID: "b"}}
+	ctx = domain.WithEvaluationOverrides(context.Background(), domain.EvaluationOverrides{PreferredModels: []string{"b"}})
+	if got := preferModels(ctx, models, modelID); got[0].ID != "b" || got[1].ID != "a" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestChatJSONWithRetry_PinnedModel(t *testing.T) {
+	var tried []string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		tried = append(tried, body["model"].(string))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": `{"ok":true}`}}},
+		})
+	}))
+	defer server.Close()
+	client := NewTestClient(config.Config{GroqAPIKey: "test-groq-key", GroqBaseURL: server.URL})
+	ctx := domain.WithEvaluationOverrides(context.Background(), domain.EvaluationOverrides{Provider: "groq", Model: "groq/llama-3.3-70b-versatile"})
+
+	out, err := client.ChatJSONWithRetry(ctx, "system", "user", 100)
+	if err != nil || out != `{"ok":true}` {
+		t.Fatalf("pinned call: out=%q err=%v", out, err)
+	}
+	if !reflect.DeepEqual(tried, []string{"llama-3.3-70b-versatile"}) {
+		t.Fatalf("tried %v", tried)
+	}
+
+	// A failing pinned model is not replaced by another one.
+	tried, status = nil, http.StatusBadRequest
+	if _, err := client.ChatJSON(ctx, "system", "user", 100); err == nil {
+		t.Fatal("expected the pinned model's error")
+	}
+	if !reflect.DeepEqual(tried, []string{"llama-3.3-70b-versatile"}) {
+		t.Fatalf("tried %v", tried)
+	}
+
+	ctx = domain.WithEvaluationOverrides(context.Background(), domain.EvaluationOverrides{Provider: "anthropic", Model: "m"})
+	if _, err := client.ChatJSON(ctx, "system", "user", 100); !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("unknown provider: err=%v", err)
+	}
+}
+
+func TestChatJSON_ChallengerFallsBack(t *testing.T) {
+	var tried []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		model := body["model"].(string)
+		tried = append(tried, model)
+		w.Header().Set("Content-Type", "application/json")
+		if model == "challenger-model" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": `{"ok":true}`}}},
+		})
+	}))
+	defer server.Close()
+	client := NewTestClient(config.Config{GroqAPIKey: "test-groq-key", GroqBaseURL: server.URL})
+	ctx := domain.WithEvaluationOverrides(context.Background(), domain.EvaluationOverrides{Provider: "groq", Model: "challenger-model", Challenger: true})
+
+	// A failing challenger is followed by the default pool.
+	out, err := client.ChatJSON(ctx, "system", "user", 100)
+	if err != nil || out != `{"ok":true}` {
+		t.Fatalf("challenger fallback: out=%q err=%v", out, err)
+	}
+	if !reflect.DeepEqual(tried, []string{"challenger-model", "llama-3.1-8b-instant"}) {
+		t.Fatalf("tried %v", tried)
+	}
+}
+
+func TestOpenRouterProvider(t *testing.T) {
+	c := &Client{cfg: config.Config{OpenRouterProviderAllowFallbacks: true}}
+	if p := c.openRouterProvider(context.Background()); p != nil {
+		t.Fatalf("defaults: got %+v", p)
+	}
+
+	c.cfg = config.Config{OpenRouterProviderIgnore: "DeepInfra", OpenRouterProviderDataCollection: "deny"}
+	no, yes := false, true
+	want := domain.OpenRouterProviderPrefs{Ignore: []string{"DeepInfra"}, AllowFallbacks: &no, DataCollection: "deny"}
+	if p := c.openRouterProvider(context.Background()); p == nil || !reflect.DeepEqual(*p, want) {
+		t.Fatalf("config: got %+v", p)
+	}
+
+	// The request's options replace the deployment's ones they set.
+	ctx := domain.WithEvaluationOverrides(context.Background(), domain.EvaluationOverrides{
+		OpenRouterProvider: &domain.OpenRouterProviderPrefs{Order: []string{"Groq"}, AllowFallbacks: &yes},
+	})
+	want = domain.OpenRouterProviderPrefs{Order: []string{"Groq"}, Ignore: []string{"DeepInfra"}, AllowFallbacks: &yes, DataCollection: "deny"}
+	if p := c.openRouterProvider(ctx); p == nil || !reflect.DeepEqual(*p, want) {
+		t.Fatalf("override: got %+v", p)
+	}
+}
+
+func TestChatJSON_SendsOpenRouterProvider(t *testing.T) {
+	var provider map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		provider, _ = body["provider"].(map[string]any)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]any{"content": `{"ok":true}`}}},
+		})
+	}))
+	defer server.Close()
+	client := NewTestClient(config.Config{
+		OpenRouterAPIKey:                 "test-openrouter-key",
+		OpenRouterBaseURL:                server.URL,
+		OpenRouterProviderAllowFallbacks: true,
+		OpenRouterProviderIgnore:         "DeepInfra",
+	})
+	ctx := domain.WithEvaluationOverrides(context.Background(), domain.EvaluationOverrides{Provider: "openrouter", Model: "vendor/model:free"})
+
+	if _, err := client.ChatJSON(ctx, "system", "user", 100); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if !reflect.DeepEqual(provider, map[string]any{"ignore": []any{"DeepInfra"}}) {
+		t.Fatalf("provider %v", provider)
+	}
+}
+
+func TestSetSampling(t *testing.T) {
+	c := &Client{cfg: config.Config{AITemperature: 0.2}}
+	body := map[string]any{}
+	c.setSampling(context.Background(), body)
+	if !reflect.DeepEqual(body, map[string]any{"temperature": 0.2}) {
+		t.Fatalf("defaults: got %v", body)
+	}
+
+	topP, penalty := 0.9, 0.5
+	ctx := domain.WithEvaluationOverrides(context.Background(), domain.EvaluationOverrides{
+		Sampling: &domain.SamplingParams{TopP: &topP, FrequencyPenalty: &penalty},
+	})
+	body = map[string]any{}
+	c.setSampling(ctx, body)
+	if !reflect.DeepEqual(body, map[string]any{"temperature": 0.2, "top_p": 0.9, "frequency_penalty": 0.5}) {
+		t.Fatalf("override: got %v", body)
+	}
+}
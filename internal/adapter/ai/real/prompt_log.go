@@ -0,0 +1,43 @@
+package real
+
+import (
+	"encoding/json"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+)
+
+// logBody returns the request body b as it is written to the log. With
+// LOG_PROMPT_BODIES redaction the prompts are replaced by redactPromptBody.
+func (c *Client) logBody(b []byte) string {
+	if !c.cfg.RedactPromptLogs() {
+		return string(b)
+	}
+	return redactPromptBody(b)
+}
+
+// redactPromptBody replaces the content of every message of a chat request
+// body with its digest and length, keeping the other fields (model, sampling,
+// provider routing) readable. A body that is not a JSON object is redacted as
+// a whole.
+func redactPromptBody(b []byte) string {
+	var body map[string]any
+	if err := json.Unmarshal(b, &body); err != nil {
+		return observability.RedactedText(string(b))
+	}
+	if msgs, ok := body["messages"].([]any); ok {
+		for _, m := range msgs {
+			msg, ok := m.(map[string]any)
+			if !ok {
+				continue
+			}
+			if content, ok := msg["content"].(string); ok {
+				msg["content"] = observability.RedactedText(content)
+			}
+		}
+	}
+	out, err := json.Marshal(body)
+	if err != nil {
+		return observability.RedactedText(string(b))
+	}
+	return string(out)
+}
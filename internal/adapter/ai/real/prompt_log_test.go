@@ -0,0 +1,34 @@
+package real
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+)
+
+func TestLogBody_RedactsPrompts(t *testing.T) {
+	body := []byte(`{"model":"m","messages":[{"role":"system","content":"You grade CVs"},{"role":"user","content":"Jane Doe, jane@example.com"}],"temperature":0.2}`)
+
+	full := &Client{cfg: config.Config{AppEnv: "dev"}}
+	if got := full.logBody(body); got != string(body) {
+		t.Fatalf("dev logs the full body, got %s", got)
+	}
+
+	for _, cfg := range []config.Config{{AppEnv: "prod"}, {AppEnv: "dev", LogPromptBodies: "redact"}} {
+		got := (&Client{cfg: cfg}).logBody(body)
+		if strings.Contains(got, "Jane") || strings.Contains(got, "You grade CVs") {
+			t.Fatalf("prompt leaked: %s", got)
+		}
+		if !strings.Contains(got, `"model":"m"`) || !strings.Contains(got, "len:26]") {
+			t.Fatalf("want model and content length kept, got %s", got)
+		}
+	}
+
+	if got := (&Client{cfg: config.Config{AppEnv: "prod", LogPromptBodies: "full"}}).logBody(body); got != string(body) {
+		t.Fatalf("LOG_PROMPT_BODIES=full logs the full body, got %s", got)
+	}
+	if got := redactPromptBody([]byte("not json")); !strings.HasPrefix(got, "[redacted sha256:") {
+		t.Fatalf("want the whole body redacted, got %s", got)
+	}
+}
@@ -0,0 +1,193 @@
+package real
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	aiadapter "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// promptRace holds the OpenRouter model attempts made for one prompt across
+// the account and model fallback of enhanced switching. A model is sent the
+// prompt at most once at a time: a retry, or the fallback to the next
+// account, joins the model's attempt that timed out but still runs within
+// the late-result grace instead of sending the prompt again. Attempts
+// outlive the account that started them, so that a slow first account can
+// still answer while the next is tried, and the first success cancels the
+// sibling attempts still running.
+//
+// The race of a prompt of an evaluation job is shared by the identical
+// calls of the job in flight; it ends, canceling what still runs, when the
+// last of them returns.
+type promptRace struct {
+	ctx    context.Context // parent of the attempts, canceled when the race ends
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	attempts []*raceAttempt
+	inflight map[string]*raceAttempt // running attempt by model
+	changed  chan struct{}           // closed and replaced when an attempt finishes
+	refs     int
+}
+
+// raceAttempt is a model attempt of a promptRace.
+type raceAttempt struct {
+	modelAttemptResult
+	cancel   context.CancelFunc
+	finished bool
+}
+
+type promptRaceKey struct{}
+
+func newPromptRace(ctx context.Context) *promptRace {
+	raceCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	return &promptRace{
+		ctx:      raceCtx,
+		cancel:   cancel,
+		inflight: map[string]*raceAttempt{},
+		changed:  make(chan struct{}),
+	}
+}
+
+// promptRaceKeyOf returns the key of the prompt within job.
+func promptRaceKeyOf(job, systemPrompt, userPrompt string, maxTokens int) string {
+	h := sha256.New()
+	for _, p := range []string{systemPrompt, userPrompt, strconv.Itoa(maxTokens)} {
+		h.Write([]byte(strconv.Itoa(len(p))))
+		h.Write([]byte{0})
+		h.Write([]byte(p))
+	}
+	return job + "/" + hex.EncodeToString(h.Sum(nil))
+}
+
+// joinPromptRace returns ctx carrying the race of the prompt and a func
+// that leaves it. A ctx already carrying a race keeps it. Prompts of
+// contexts without an evaluation job get a race of their own.
+func (c *Client) joinPromptRace(ctx domain.Context, systemPrompt, userPrompt string, maxTokens int) (domain.Context, *promptRace, func()) {
+	if r, ok := ctx.Value(promptRaceKey{}).(*promptRace); ok {
+		return ctx, r, func() {}
+	}
+	job := domain.EvaluationJobFrom(ctx)
+	if job == "" {
+		r := newPromptRace(ctx)
+		return context.WithValue(ctx, promptRaceKey{}, r), r, r.cancel
+	}
+
+	key := promptRaceKeyOf(job, systemPrompt, userPrompt, maxTokens)
+	c.racesMu.Lock()
+	if c.races == nil {
+		c.races = map[string]*promptRace{}
+	}
+	r := c.races[key]
+	if r == nil {
+		r = newPromptRace(ctx)
+		c.races[key] = r
+	}
+	r.refs++
+	c.racesMu.Unlock()
+
+	leave := func() {
+		c.racesMu.Lock()
+		defer c.racesMu.Unlock()
+		r.refs--
+		if r.refs == 0 {
+			delete(c.races, key)
+			r.cancel()
+		}
+	}
+	return context.WithValue(ctx, promptRaceKey{}, r), r, leave
+}
+
+// start runs call as an attempt of model bounded by timeout, or returns the
+// model's attempt still running and true.
+func (r *promptRace) start(model string, timeout time.Duration, call func(context.Context) (string, error)) (*raceAttempt, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a := r.inflight[model]; a != nil {
+		observability.RecordAIDuplicateCall(aiadapter.CallKindChat)
+		return a, true
+	}
+	ctx, cancel := context.WithTimeout(r.ctx, timeout)
+	a := &raceAttempt{modelAttemptResult: modelAttemptResult{id: len(r.attempts) + 1, model: model}, cancel: cancel}
+	r.attempts = append(r.attempts, a)
+	r.inflight[model] = a
+	go func() {
+		result, err := call(ctx)
+		cancel()
+		r.mu.Lock()
+		a.result, a.err, a.finished = result, err, true
+		if r.inflight[model] == a {
+			delete(r.inflight, model)
+		}
+		close(r.changed)
+		r.changed = make(chan struct{})
+		r.mu.Unlock()
+	}()
+	return a, false
+}
+
+// abandon cancels attempt a, so that the next attempt of its model sends
+// the prompt again.
+func (r *promptRace) abandon(a *raceAttempt) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a.cancel()
+	if r.inflight[a.model] == a {
+		delete(r.inflight, a.model)
+	}
+}
+
+// win cancels the attempts other than the one with id.
+func (r *promptRace) win(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, o := range r.attempts {
+		if o.id != id && !o.finished {
+			o.cancel()
+		}
+	}
+}
+
+// await waits for attempt a, or a nil a, until expired fires or ctx is
+// done. A successful result of another attempt for which usable holds is
+// harvested instead.
+func (r *promptRace) await(ctx context.Context, a *raceAttempt, expired <-chan time.Time, usable func(modelAttemptResult) bool) (modelAttemptResult, attemptWait) {
+	for {
+		r.mu.Lock()
+		changed := r.changed
+		var late []modelAttemptResult
+		for _, o := range r.attempts {
+			if o != a && o.finished && o.err == nil {
+				late = append(late, o.modelAttemptResult)
+			}
+		}
+		var own modelAttemptResult
+		finished := a != nil && a.finished
+		if finished {
+			own = a.modelAttemptResult
+		}
+		r.mu.Unlock()
+
+		for _, l := range late {
+			if usable(l) {
+				return l, attemptHarvested
+			}
+		}
+		if finished {
+			return own, attemptFinished
+		}
+		select {
+		case <-changed:
+		case <-expired:
+			return modelAttemptResult{}, attemptExpired
+		case <-ctx.Done():
+			return modelAttemptResult{}, attemptCanceled
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package real
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	intobs "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+)
+
+// chatMessage is the message of a chat completion choice or the delta of a
+// streamed chunk. Reasoning models return their reasoning in Reasoning
+// (OpenRouter, Groq) or ReasoningContent (DeepSeek-style APIs), or inline in
+// Content between <think> tags.
+type chatMessage struct {
+	Content          string `json:"content"`
+	Reasoning        string `json:"reasoning,omitempty"`
+	ReasoningContent string `json:"reasoning_content,omitempty"`
+}
+
+// thinkTags are the tags reasoning models wrap inline reasoning in.
+var thinkTags = []string{"think", "thinking"}
+
+// splitThinkTags removes the <think> blocks from s and returns what is left
+// and the blocks' text. A closing tag without an opening one ends reasoning
+// that started the content, as some models omit the opening tag; an
+// unterminated block is reasoning cut off by max_tokens.
+func splitThinkTags(s string) (answer, reasoning string) {
+	var thoughts []string
+	for _, tag := range thinkTags {
+		open, end := "<"+tag+">", "</"+tag+">"
+		for {
+			stop := strings.Index(s, end)
+			if stop < 0 {
+				if start := strings.Index(s, open); start >= 0 {
+					thoughts = append(thoughts, s[start+len(open):])
+					s = s[:start]
+				}
+				break
+			}
+			start, from := strings.LastIndex(s[:stop], open), 0
+			if start >= 0 {
+				from = start + len(open)
+			} else {
+				start = 0
+			}
+			thoughts = append(thoughts, s[from:stop])
+			s = s[:start] + s[stop+len(end):]
+		}
+	}
+	if len(thoughts) == 0 {
+		return s, ""
+	}
+	return strings.TrimSpace(s), joinNonEmpty(thoughts...)
+}
+
+func joinNonEmpty(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, "\n\n")
+}
+
+// finalAnswer returns the answer of a chat response without the model's
+// reasoning, which is counted and, with AI_REASONING_LOG, logged.
+func (c *Client) finalAnswer(ctx domain.Context, provider, model string, m chatMessage) string {
+	answer, inline := splitThinkTags(m.Content)
+	reasoning := joinNonEmpty(m.Reasoning, m.ReasoningContent, inline)
+	if reasoning == "" {
+		return answer
+	}
+	source := "field"
+	if inline != "" {
+		source = "tags"
+	}
+	observability.RecordAIReasoningSeparated(provider, source)
+	if c.cfg.AIReasoningLog {
+		truncated := false
+		if limit := c.cfg.AIReasoningLogMaxChars; limit > 0 && len(reasoning) > limit {
+			reasoning, truncated = reasoning[:limit], true
+		}
+		intobs.LoggerFromContext(ctx).Info("model reasoning",
+			slog.String("provider", provider),
+			slog.String("model", model),
+			slog.String("step", domain.EvaluationStepFrom(ctx)),
+			slog.String("reasoning", reasoning),
+			slog.Bool("truncated", truncated))
+	}
+	return answer
+}
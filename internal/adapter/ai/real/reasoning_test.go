@@ -0,0 +1,58 @@
+package real
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+)
+
+func TestSplitThinkTags(t *testing.T) {
+	cases := []struct {
+		name, in, answer, reasoning string
+	}{
+		{"none", `{"a":1}`, `{"a":1}`, ""},
+		{"leading block", "<think>weigh the CV</think>\n{\"a\":1}", `{"a":1}`, "weigh the CV"},
+		{"thinking tag", "<thinking>x</thinking>{\"a\":1}<thinking>y</thinking>", `{"a":1}`, "x\n\ny"},
+		{"missing opening tag", "weigh the CV</think>{\"a\":1}", `{"a":1}`, "weigh the CV"},
+		{"unterminated", "<think>cut off by max_tokens", "", "cut off by max_tokens"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			answer, reasoning := splitThinkTags(tc.in)
+			if answer != tc.answer || reasoning != tc.reasoning {
+				t.Fatalf("got answer=%q reasoning=%q", answer, reasoning)
+			}
+		})
+	}
+}
+
+func TestFinalAnswer(t *testing.T) {
+	c := &Client{cfg: config.Config{AIReasoningLog: true, AIReasoningLogMaxChars: 5}}
+	msg := chatMessage{Content: "<think>inline</think>{\"a\":1}", Reasoning: "separate reasoning"}
+	if got := c.finalAnswer(context.Background(), "openrouter", "m", msg); got != `{"a":1}` {
+		t.Fatalf("got %q", got)
+	}
+	if got := c.finalAnswer(context.Background(), "groq", "m", chatMessage{Content: `{"a":1}`}); got != `{"a":1}` {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestReadSSEChatStream_SeparatesReasoning(t *testing.T) {
+	stream := strings.Join([]string{
+		`data: {"choices":[{"delta":{"content":"","reasoning":"Compare "}}]}`,
+		`data: {"choices":[{"delta":{"reasoning_content":"skills."}}]}`,
+		`data: {"choices":[{"delta":{"content":"{\"a\":1}"}}]}`,
+		"data: [DONE]",
+		"",
+	}, "\n")
+	msg, err := readSSEChatStream(strings.NewReader(stream), "openrouter", "m", 5*time.Second, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Content != `{"a":1}` || msg.Reasoning != "Compare skills." {
+		t.Fatalf("got %+v", msg)
+	}
+}
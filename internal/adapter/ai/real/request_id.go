@@ -0,0 +1,19 @@
+package real
+
+import (
+	"net/http"
+
+	intobs "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+)
+
+// requestIDTransport forwards the originating request_id to AI providers as
+// X-Request-Id so provider-side logs can be matched to our jobs.
+type requestIDTransport struct{ base http.RoundTripper }
+
+func (t requestIDTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if rid := intobs.RequestIDFromContext(r.Context()); rid != "" && r.Header.Get("X-Request-Id") == "" {
+		r = r.Clone(r.Context())
+		r.Header.Set("X-Request-Id", rid)
+	}
+	return t.base.RoundTrip(r)
+}
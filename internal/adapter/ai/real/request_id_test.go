@@ -0,0 +1,41 @@
+package real
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	intobs "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+)
+
+func TestRequestIDTransport(t *testing.T) {
+	var got []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-Request-Id"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	hc := &http.Client{Transport: requestIDTransport{base: http.DefaultTransport}}
+
+	for _, ctx := range []context.Context{
+		intobs.ContextWithRequestID(context.Background(), "req-42"),
+		context.Background(),
+	} {
+		r, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := hc.Do(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if r.Header.Get("X-Request-Id") != "" {
+			t.Fatalf("caller's request was mutated")
+		}
+	}
+	if len(got) != 2 || got[0] != "req-42" || got[1] != "" {
+		t.Fatalf("unexpected forwarded ids %q", got)
+	}
+}
@@ -0,0 +1,45 @@
+package real
+
+import (
+	"slices"
+	"time"
+
+	aiadapter "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Saturation reports whether every chat model this client can use is
+// blocked after rate limits and, when it is, how long until the first of
+// them can be used again. Groq is usable while one of its keys is not
+// blocked; OpenRouter while one of its keys and one free model are not.
+// Providers without usable keys (none configured, disabled or over budget)
+// and paid fallback models are not considered.
+func (c *Client) Saturation(ctx domain.Context) (time.Duration, bool) {
+	var waits []time.Duration
+	if d, ok := c.keyRing().BlockedFor(aiadapter.ProviderGroq); ok {
+		if d <= 0 {
+			return 0, false
+		}
+		waits = append(waits, d)
+	}
+	if d, ok := c.keyRing().BlockedFor(aiadapter.ProviderOpenRouter); ok {
+		if until := c.openRouterBlocked.Load(); until > 0 {
+			d = max(d, time.Until(time.Unix(0, until)))
+		}
+		if models, err := c.freeModelsSvc.GetFreeModels(ctx); err == nil && len(models) > 0 && c.rlc != nil {
+			ids := make([]string, len(models))
+			for i, m := range models {
+				ids[i] = m.ID
+			}
+			d = max(d, c.rlc.BlockedFor(ids))
+		}
+		if d <= 0 {
+			return 0, false
+		}
+		waits = append(waits, d)
+	}
+	if len(waits) == 0 {
+		return 0, false
+	}
+	return slices.Min(waits), true
+}
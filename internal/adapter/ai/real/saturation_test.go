@@ -0,0 +1,60 @@
+package real
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	aiadapter "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/freemodels"
+)
+
+func TestClient_Saturation(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(freemodels.OpenRouterResponse{
+			Data: []freemodels.Model{
+				{ID: "free/a:free", Pricing: freemodels.Pricing{Prompt: "0", Completion: "0"}},
+				{ID: "free/b:free", Pricing: freemodels.Pricing{Prompt: "0", Completion: "0"}},
+			},
+		})
+	}))
+	defer ts.Close()
+
+	rlc := aiadapter.NewRateLimitCache()
+	defer rlc.Stop()
+	c := (&Client{freeModelsSvc: freemodels.NewService("k", ts.URL, time.Hour), rlc: rlc}).
+		WithKeyRing(aiadapter.NewKeyRing([]domain.ProviderKey{
+			{Provider: aiadapter.ProviderGroq, Secret: "g"},
+			{Provider: aiadapter.ProviderOpenRouter, Secret: "o"},
+		}))
+	ctx := context.Background()
+
+	_, saturated := c.Saturation(ctx)
+	assert.False(t, saturated)
+
+	// Groq is blocked but OpenRouter still has free models.
+	c.keys.Load().Block("g", 5*time.Minute)
+	_, saturated = c.Saturation(ctx)
+	assert.False(t, saturated)
+
+	rlc.BlockModel("free/a:free", 10*time.Minute)
+	rlc.BlockModel("free/b:free", 2*time.Minute)
+	wait, saturated := c.Saturation(ctx)
+	assert.True(t, saturated)
+	assert.InDelta(t, (2 * time.Minute).Seconds(), wait.Seconds(), 2)
+
+	// The OpenRouter account block outlasts its models'.
+	c.keys.Load().Block("o", 20*time.Minute)
+	wait, saturated = c.Saturation(ctx)
+	assert.True(t, saturated)
+	assert.InDelta(t, (5 * time.Minute).Seconds(), wait.Seconds(), 2)
+}
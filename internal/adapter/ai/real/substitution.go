@@ -0,0 +1,116 @@
+package real
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Model substitution policies: what to do when OpenRouter serves a call
+// with a model outside the requested model's family.
+const (
+	// SubstitutionPolicyWarn accepts every substitute and records it.
+	SubstitutionPolicyWarn = "warn"
+	// SubstitutionPolicyReject fails calls served by a substitute that is
+	// not a free model.
+	SubstitutionPolicyReject = "reject"
+)
+
+// Kinds of substitute models.
+const (
+	substituteFree          = "free"
+	substitutePaidOrUnknown = "paid_or_unknown"
+)
+
+// errModelSubstituted fails a call whose substitute model the substitution
+// policy rejects.
+var errModelSubstituted = errors.New("model substituted")
+
+// modelFamily returns model's ID without its variant suffix, e.g. ":free".
+func modelFamily(model string) string {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if i := strings.LastIndexByte(model, ':'); i > 0 {
+		model = model[:i]
+	}
+	return model
+}
+
+// sameModelFamily reports whether served belongs to the family of the
+// requested model or of one of the alternates sent with it: the same model,
+// another variant of it or a dated snapshot such as
+// "openai/gpt-4o-mini-2024-07-18" of "openai/gpt-4o-mini".
+func sameModelFamily(served, requested string, alternates ...string) bool {
+	s := modelFamily(served)
+	for _, m := range append([]string{requested}, alternates...) {
+		r := modelFamily(m)
+		if r == s {
+			return true
+		}
+		if rest, ok := strings.CutPrefix(s, r+"-"); ok && rest != "" && rest[0] >= '0' && rest[0] <= '9' {
+			return true
+		}
+	}
+	return false
+}
+
+// substituteKind returns whether the substitute model is free: a ":free"
+// variant or of the family of a model of the free models catalog, which
+// OpenRouter echoes without the variant suffix.
+func (c *Client) substituteKind(ctx domain.Context, model string) string {
+	if strings.HasSuffix(strings.ToLower(model), ":free") {
+		return substituteFree
+	}
+	if c.freeModelsSvc != nil {
+		if models, err := c.freeModelsSvc.GetFreeModels(ctx); err == nil {
+			for _, m := range models {
+				if modelFamily(m.ID) == modelFamily(model) {
+					return substituteFree
+				}
+			}
+		}
+	}
+	return substitutePaidOrUnknown
+}
+
+// checkServedModel compares the model OpenRouter reported to have served a
+// call with the requested one and the fallback alternates sent with it. A
+// substitute outside their families is logged and counted, and rejected with
+// errModelSubstituted when it is a paid or unknown model under
+// SubstitutionPolicyReject.
+func (c *Client) checkServedModel(ctx domain.Context, requested, reported string, alternates ...string) error {
+	if reported == "" || sameModelFamily(reported, requested, alternates...) {
+		return nil
+	}
+	kind := c.substituteKind(ctx, reported)
+	action := "accepted"
+	if c.cfg.AIModelSubstitutionPolicy == SubstitutionPolicyReject && kind != substituteFree {
+		action = "rejected"
+	}
+	observability.RecordAIModelSubstitution("openrouter", kind, action)
+	slog.WarnContext(ctx, "model substitution detected",
+		slog.String("requested_model", requested),
+		slog.String("actual_model", reported),
+		slog.String("kind", kind),
+		slog.String("action", action),
+		slog.String("provider", "openrouter"))
+	if action == "rejected" {
+		return fmt.Errorf("%w: %s served %s instead of %s", errModelSubstituted, kind, reported, requested)
+	}
+	return nil
+}
+
+// recordOpenRouterServed records the model that served an OpenRouter call
+// for requested and alternates, noting substitutes outside their families in
+// the provenance.
+func recordOpenRouterServed(ctx domain.Context, requested, reported string, alternates ...string) {
+	served := servedModel(requested, reported)
+	if sameModelFamily(served, requested, alternates...) {
+		domain.RecordServedModel(ctx, "openrouter", served)
+		return
+	}
+	domain.RecordModelSubstitution(ctx, "openrouter", requested, served)
+}
@@ -0,0 +1,84 @@
+package real
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestSameModelFamily(t *testing.T) {
+	assert.True(t, sameModelFamily("meta-llama/llama-3.1-8b-instruct", "meta-llama/llama-3.1-8b-instruct:free"))
+	assert.True(t, sameModelFamily("openai/gpt-4o-mini-2024-07-18", "openai/gpt-4o-mini"))
+	assert.True(t, sameModelFamily("mistralai/mistral-7b-instruct:free", "meta-llama/llama-3.1-8b-instruct:free", "mistralai/mistral-7b-instruct:free"))
+	assert.False(t, sameModelFamily("openai/gpt-4o", "meta-llama/llama-3.1-8b-instruct:free"))
+	assert.False(t, sameModelFamily("openai/gpt-4o-mini", "openai/gpt-4o"))
+}
+
+// substitutingServer lists free/listed:free as its only free model and
+// answers every chat completion as served by model.
+func substitutingServer(t *testing.T, model string) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/models" {
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": []map[string]any{
+					{"id": "free/listed:free", "pricing": map[string]string{"prompt": "0", "completion": "0"}},
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"model":   model,
+			"choices": []map[string]any{{"message": map[string]any{"content": `{"ok":true}`}}},
+		})
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestCallOpenRouter_ModelSubstitution(t *testing.T) {
+	const requested = "meta-llama/llama-3.1-8b-instruct:free"
+	cases := []struct {
+		name, policy, served string
+		rejected             bool
+	}{
+		{"same family", SubstitutionPolicyReject, "meta-llama/llama-3.1-8b-instruct", false},
+		{"free substitute", SubstitutionPolicyReject, "free/listed", false},
+		{"paid substitute warned", SubstitutionPolicyWarn, "openai/gpt-4o", false},
+		{"paid substitute rejected", SubstitutionPolicyReject, "openai/gpt-4o", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := substitutingServer(t, tc.served)
+			c := NewTestClient(config.Config{
+				OpenRouterAPIKey:          "x",
+				OpenRouterBaseURL:         ts.URL,
+				AIModelSubstitutionPolicy: tc.policy,
+			})
+			ctx, trace := domain.WithModelTrace(context.Background())
+			ctx = domain.WithEvaluationStep(ctx, "cv_match", "1")
+
+			_, err := c.callOpenRouterWithModelForKey(ctx, "x", requested, "sys", "user", 64)
+			if tc.rejected {
+				require.ErrorIs(t, err, errModelSubstituted)
+				assert.Empty(t, trace.Provenance())
+				return
+			}
+			require.NoError(t, err)
+			want := domain.StepProvenance{Step: "cv_match", Provider: "openrouter", Model: tc.served, PromptVersion: "1"}
+			if !sameModelFamily(tc.served, requested) {
+				want.RequestedModel = requested
+			}
+			assert.Equal(t, []domain.StepProvenance{want}, trace.Provenance())
+		})
+	}
+}
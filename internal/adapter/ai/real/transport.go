@@ -0,0 +1,150 @@
+package real
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+)
+
+// providerRoundTripper builds the tuned transport of provider, with
+// dead-connection eviction.
+func providerRoundTripper(cfg config.Config, provider string) http.RoundTripper {
+	tc := cfg.GetHTTPTransportConfig(provider)
+	return withConnEviction(provider, newProviderTransport(provider, tc), tc)
+}
+
+// newProviderTransport builds the HTTP transport of an AI provider from tc.
+// An invalid proxy URL is logged and the proxy environment variables apply
+// instead.
+func newProviderTransport(provider string, tc config.HTTPTransportConfig) *http.Transport {
+	proxy := http.ProxyFromEnvironment
+	if tc.Proxy != "" {
+		if u, err := url.Parse(tc.Proxy); err == nil && u.Host != "" {
+			proxy = http.ProxyURL(u)
+		} else {
+			slog.Warn("invalid AI provider proxy URL; using the proxy environment", slog.String("provider", provider))
+		}
+	}
+	dialer := &net.Dialer{Timeout: tc.DialTimeout, KeepAlive: 30 * time.Second}
+	t := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     tc.HTTP2,
+		MaxIdleConns:          tc.MaxIdleConns,
+		MaxIdleConnsPerHost:   tc.MaxIdleConns,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   tc.TLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+	if !tc.HTTP2 {
+		// A non-nil empty map keeps TLS connections on HTTP/1.1.
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+	return t
+}
+
+// tracedTransport wraps base with OpenTelemetry tracing and forwards the
+// originating request_id as X-Request-Id.
+func tracedTransport(base http.RoundTripper, spanPrefix string) http.RoundTripper {
+	return otelhttp.NewTransport(requestIDTransport{base: base},
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return fmt.Sprintf("%s %s %s", spanPrefix, r.Method, r.URL.Host)
+		}),
+	)
+}
+
+// evictingTransport closes the idle connections of a provider transport once
+// requests to a host fail EvictFailures times within EvictWindow. After a
+// provider incident the keep-alive pool may hold connections to load
+// balancer nodes that are gone; without eviction each of them fails one
+// request before the pool recovers.
+type evictingTransport struct {
+	provider string
+	base     *http.Transport
+	failures int
+	window   time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*hostFailures
+}
+
+type hostFailures struct {
+	count int
+	since time.Time
+}
+
+// withConnEviction wraps base with dead-connection eviction; it returns base
+// when tc disables eviction.
+func withConnEviction(provider string, base *http.Transport, tc config.HTTPTransportConfig) http.RoundTripper {
+	if tc.EvictFailures <= 0 || tc.EvictWindow <= 0 {
+		return base
+	}
+	return &evictingTransport{
+		provider: provider,
+		base:     base,
+		failures: tc.EvictFailures,
+		window:   tc.EvictWindow,
+		now:      time.Now,
+		hosts:    map[string]*hostFailures{},
+	}
+}
+
+func (t *evictingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(r)
+	switch {
+	case err != nil && r.Context().Err() == nil:
+		t.recordFailure(r.URL.Host)
+	case err == nil && isGatewayFailure(resp.StatusCode):
+		t.recordFailure(r.URL.Host)
+	case err == nil:
+		t.recordSuccess(r.URL.Host)
+	}
+	return resp, err
+}
+
+// isGatewayFailure reports statuses a load balancer returns for a backend it
+// cannot reach.
+func isGatewayFailure(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+func (t *evictingTransport) recordSuccess(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.hosts, host)
+}
+
+func (t *evictingTransport) recordFailure(host string) {
+	t.mu.Lock()
+	now := t.now()
+	f := t.hosts[host]
+	if f == nil || now.Sub(f.since) > t.window {
+		f = &hostFailures{since: now}
+		t.hosts[host] = f
+	}
+	f.count++
+	evict := f.count >= t.failures
+	if evict {
+		delete(t.hosts, host)
+	}
+	t.mu.Unlock()
+
+	if evict {
+		t.base.CloseIdleConnections()
+		observability.RecordAIConnectionEviction(t.provider)
+		slog.Warn("evicted idle AI provider connections after repeated failures",
+			slog.String("provider", t.provider), slog.String("host", host),
+			slog.Int("failures", t.failures), slog.Duration("window", t.window))
+	}
+}
@@ -0,0 +1,87 @@
+package real
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+)
+
+func TestNewProviderTransport(t *testing.T) {
+	tr := newProviderTransport("groq", config.HTTPTransportConfig{MaxIdleConns: 50, TLSHandshakeTimeout: 5 * time.Second, Proxy: "http://proxy.internal:3128"})
+	if tr.MaxIdleConnsPerHost != 50 || tr.TLSHandshakeTimeout != 5*time.Second {
+		t.Fatalf("unexpected transport: %+v", tr)
+	}
+	if tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+		t.Fatal("want HTTP/2 disabled")
+	}
+	req, _ := http.NewRequest(http.MethodPost, "https://api.groq.com/openai/v1/chat/completions", nil)
+	u, err := tr.Proxy(req)
+	if err != nil || u == nil || u.Host != "proxy.internal:3128" {
+		t.Fatalf("want the configured proxy, got %v, %v", u, err)
+	}
+
+	tr = newProviderTransport("openai", config.HTTPTransportConfig{HTTP2: true, Proxy: "::bad"})
+	if !tr.ForceAttemptHTTP2 || tr.TLSNextProto != nil {
+		t.Fatal("want HTTP/2 enabled")
+	}
+	if _, err := tr.Proxy(req); err != nil {
+		t.Fatalf("an invalid proxy falls back to the environment, got %v", err)
+	}
+}
+
+func TestEvictingTransport_ClosesIdleConnectionsAfterFailures(t *testing.T) {
+	var conns, status atomic.Int32
+	status.Store(http.StatusOK)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	ts.Config.ConnState = func(_ net.Conn, s http.ConnState) {
+		if s == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	tc := config.HTTPTransportConfig{MaxIdleConns: 4, EvictFailures: 2, EvictWindow: time.Minute}
+	hc := &http.Client{Transport: withConnEviction("evict-test", newProviderTransport("evict-test", tc), tc)}
+	get := func() {
+		resp, err := hc.Get(ts.URL)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		_ = resp.Body.Close()
+	}
+	evictions := func() float64 {
+		return testutil.ToFloat64(observability.AIConnectionEvictions.WithLabelValues("evict-test"))
+	}
+
+	get()
+	get()
+	if conns.Load() != 1 {
+		t.Fatalf("want the keep-alive connection reused, got %d connections", conns.Load())
+	}
+	status.Store(http.StatusBadGateway)
+	get()
+	if evictions() != 0 {
+		t.Fatal("one failure must not evict")
+	}
+	get()
+	if evictions() != 1 {
+		t.Fatalf("want one eviction after two failures, got %v", evictions())
+	}
+	status.Store(http.StatusOK)
+	get()
+	get()
+	if conns.Load() != 2 {
+		t.Fatalf("want a fresh connection after the eviction, got %d connections", conns.Load())
+	}
+}
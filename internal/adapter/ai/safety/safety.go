@@ -0,0 +1,102 @@
+// Package safety removes discriminatory commentary from generated evaluation
+// feedback so that stored results can be used in hiring decisions.
+package safety
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Withheld replaces feedback whose every sentence was removed.
+const Withheld = "Feedback withheld because it referenced protected personal attributes."
+
+// Violation describes one removed sentence.
+type Violation struct {
+	// Category is the protected attribute referenced, e.g. "age".
+	Category string
+	// Sentence is the removed text.
+	Sentence string
+}
+
+type category struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// categories lists protected attributes and the phrases that indicate
+// commentary about them. Patterns favour precision: technical phrases such as
+// "race condition" or "legacy code" must not match.
+var categories = []category{
+	{"age", regexp.MustCompile(`(?i)\b(?:\d{2}\s*(?:years?|yrs?)[\s-]*old|(?:too|quite|very|fairly|rather)\s+(?:young|old)|(?:his|her|their|the\s+candidate'?s?)\s+age|age[ds]?\s+(?:\d|over|under|above)|(?:older|younger)\s+(?:candidate|applicant|person|worker|developer|engineer)s?|millennial|boomer|gen[\s-]?z|near(?:ing)?\s+retirement|elderly)\b`)},
+	{"gender", regexp.MustCompile(`(?i)\b(?:gender|male|female|wom[ae]n|as\s+a\s+(?:man|woman|girl|guy)|pregnan\w*|maternity|paternity)\b`)},
+	{"nationality", regexp.MustCompile(`(?i)\b(?:nationality|national\s+origin|ethnicity|ethnic(?:ally)?|racial(?:ly)?|(?:his|her|their|the\s+candidate'?s?)\s+race|foreigner|foreign[\s-]born|immigrant|citizenship|skin\s+colou?r|accent)\b`)},
+	{"religion", regexp.MustCompile(`(?i)\b(?:religion|religious|christian|muslim|jewish|hindu|buddhist|atheist)\b`)},
+	{"family_status", regexp.MustCompile(`(?i)\b(?:marital|married|unmarried|divorced|single\s+(?:mother|father|parent)|has\s+(?:kids|children)|having\s+(?:kids|children))\b`)},
+	{"disability", regexp.MustCompile(`(?i)\b(?:disabled|disability|handicap\w*)\b`)},
+}
+
+var sentenceEnd = regexp.MustCompile(`[.!?]+(?:\s+|$)|\n+`)
+
+// Filter removes sentences that comment on protected attributes. A nil
+// Filter is disabled.
+type Filter struct{}
+
+// New returns an enabled Filter, or nil when enabled is false.
+func New(enabled bool) *Filter {
+	if !enabled {
+		return nil
+	}
+	return &Filter{}
+}
+
+// Clean returns text without the sentences that reference a protected
+// attribute, and the removed sentences. When nothing is left, Withheld is
+// returned instead.
+func (f *Filter) Clean(text string) (string, []Violation) {
+	if f == nil || strings.TrimSpace(text) == "" {
+		return text, nil
+	}
+	var (
+		kept       strings.Builder
+		violations []Violation
+	)
+	for _, s := range splitSentences(text) {
+		if cat := classify(s); cat != "" {
+			violations = append(violations, Violation{Category: cat, Sentence: strings.TrimSpace(s)})
+			continue
+		}
+		kept.WriteString(s)
+	}
+	if len(violations) == 0 {
+		return text, nil
+	}
+	out := strings.TrimSpace(kept.String())
+	if out == "" {
+		out = Withheld
+	}
+	return out, violations
+}
+
+func classify(sentence string) string {
+	for _, c := range categories {
+		if c.re.MatchString(sentence) {
+			return c.name
+		}
+	}
+	return ""
+}
+
+// splitSentences splits text after sentence terminators and line breaks,
+// keeping the separators so that the kept sentences can be joined verbatim.
+func splitSentences(text string) []string {
+	var out []string
+	last := 0
+	for _, loc := range sentenceEnd.FindAllStringIndex(text, -1) {
+		out = append(out, text[last:loc[1]])
+		last = loc[1]
+	}
+	if last < len(text) {
+		out = append(out, text[last:])
+	}
+	return out
+}
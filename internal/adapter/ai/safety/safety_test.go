@@ -0,0 +1,37 @@
+package safety
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Clean(t *testing.T) {
+	f := New(true)
+
+	out, v := f.Clean("Strong Go skills. At 52 years old he may struggle to adapt. Good testing discipline.")
+	require.Len(t, v, 1)
+	assert.Equal(t, "age", v[0].Category)
+	assert.Equal(t, "At 52 years old he may struggle to adapt.", v[0].Sentence)
+	assert.Equal(t, "Strong Go skills. Good testing discipline.", out)
+
+	out, v = f.Clean("As a woman she might leave for maternity.\nHer nationality is a concern!")
+	require.Len(t, v, 2)
+	assert.Equal(t, "gender", v[0].Category)
+	assert.Equal(t, "nationality", v[1].Category)
+	assert.Equal(t, Withheld, out)
+
+	clean := "Fixed a race condition in legacy code; manages an old monolith. Senior engineer with 10 years of experience."
+	out, v = f.Clean(clean)
+	assert.Empty(t, v)
+	assert.Equal(t, clean, out)
+}
+
+func TestFilter_Disabled(t *testing.T) {
+	f := New(false)
+	assert.Nil(t, f)
+	out, v := f.Clean("The candidate is too old.")
+	assert.Empty(t, v)
+	assert.Equal(t, "The candidate is too old.", out)
+}
@@ -0,0 +1,216 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Simulation modes of the AI client.
+const (
+	// SimulationOff calls the providers directly (default).
+	SimulationOff = "off"
+	// SimulationRecord calls the providers and stores every successful
+	// response under the hash of its request, replacing older recordings.
+	SimulationRecord = "record"
+	// SimulationReplay serves stored responses and never calls a provider.
+	SimulationReplay = "replay"
+)
+
+// ErrNotRecorded is returned in replay mode for a request without a stored
+// response.
+var ErrNotRecorded = errors.New("ai simulation: no recorded response")
+
+// Recording is one stored provider response.
+type Recording struct {
+	Method   string    `json:"method"`
+	Response string    `json:"response,omitempty"`
+	Vector   []float32 `json:"vector,omitempty"`
+}
+
+// RecordingStore persists recordings by request hash.
+type RecordingStore interface {
+	// Get returns the recording stored under key and whether one exists.
+	Get(key string) (Recording, bool, error)
+	// Put stores rec under key, replacing any previous recording.
+	Put(key string, rec Recording) error
+}
+
+// FileRecordingStore keeps one JSON file per recording in a directory, so
+// recordings can be committed next to the tests that replay them.
+type FileRecordingStore struct {
+	dir string
+}
+
+// NewFileRecordingStore returns a store rooted at dir. The directory is
+// created on the first Put.
+func NewFileRecordingStore(dir string) *FileRecordingStore {
+	return &FileRecordingStore{dir: dir}
+}
+
+// Get implements RecordingStore.
+func (s *FileRecordingStore) Get(key string) (Recording, bool, error) {
+	b, err := os.ReadFile(filepath.Join(s.dir, key+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return Recording{}, false, nil
+	}
+	if err != nil {
+		return Recording{}, false, fmt.Errorf("op=recording.get: %w", err)
+	}
+	var rec Recording
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return Recording{}, false, fmt.Errorf("op=recording.get key=%s: %w", key, err)
+	}
+	return rec, true, nil
+}
+
+// Put implements RecordingStore. The file is written atomically.
+func (s *FileRecordingStore) Put(key string, rec Recording) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return fmt.Errorf("op=recording.put: %w", err)
+	}
+	b, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("op=recording.put: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("op=recording.put: %w", err)
+	}
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("op=recording.put: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("op=recording.put: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, key+".json")); err != nil {
+		return fmt.Errorf("op=recording.put: %w", err)
+	}
+	return nil
+}
+
+// simulationClient records provider responses or replays them. Requests are
+// keyed by a hash of the method and all of its arguments; embeddings are
+// keyed per text so batches may be split differently on replay. Failed calls
+// are never recorded.
+type simulationClient struct {
+	base   domain.AIClient
+	replay bool
+	store  RecordingStore
+}
+
+// NewSimulationClient wraps base for the given simulation mode. In record
+// mode base is called and its successful responses are stored; in replay
+// mode base is never called and may be nil. Other modes return base
+// unmodified.
+func NewSimulationClient(base domain.AIClient, mode string, store RecordingStore) domain.AIClient {
+	if store == nil || (mode != SimulationRecord && mode != SimulationReplay) {
+		return base
+	}
+	return &simulationClient{base: base, replay: mode == SimulationReplay, store: store}
+}
+
+// RecordingKey returns the hash a request is stored under.
+func RecordingKey(method string, args ...string) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	for _, a := range args {
+		h.Write([]byte{0})
+		h.Write([]byte(a))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *simulationClient) Embed(ctx domain.Context, texts []string) ([][]float32, error) {
+	if c.replay {
+		out := make([][]float32, len(texts))
+		for i, t := range texts {
+			rec, ok, err := c.store.Get(RecordingKey("embed", t))
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, fmt.Errorf("%w: embed text %d of %d", ErrNotRecorded, i+1, len(texts))
+			}
+			out[i] = rec.Vector
+		}
+		return out, nil
+	}
+	vecs, err := c.base.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range vecs {
+		if i < len(texts) {
+			c.record(RecordingKey("embed", texts[i]), Recording{Method: "embed", Vector: v})
+		}
+	}
+	return vecs, nil
+}
+
+func (c *simulationClient) ChatJSON(ctx domain.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	key := RecordingKey("chat_json", systemPrompt, userPrompt, strconv.Itoa(maxTokens))
+	return c.replayOrCall(key, "chat_json", func() (string, error) {
+		return c.base.ChatJSON(ctx, systemPrompt, userPrompt, maxTokens)
+	})
+}
+
+func (c *simulationClient) ChatJSONWithRetry(ctx domain.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	key := RecordingKey("chat_json_with_retry", systemPrompt, userPrompt, strconv.Itoa(maxTokens))
+	return c.replayOrCall(key, "chat_json_with_retry", func() (string, error) {
+		return c.base.ChatJSONWithRetry(ctx, systemPrompt, userPrompt, maxTokens)
+	})
+}
+
+func (c *simulationClient) CleanCoTResponse(ctx domain.Context, response string) (string, error) {
+	key := RecordingKey("clean_cot", response)
+	return c.replayOrCall(key, "clean_cot", func() (string, error) {
+		return c.base.CleanCoTResponse(ctx, response)
+	})
+}
+
+// replayOrCall returns the recording under key in replay mode, and
+// otherwise calls the provider and stores a successful response.
+func (c *simulationClient) replayOrCall(key, method string, call func() (string, error)) (string, error) {
+	if c.replay {
+		rec, ok, err := c.store.Get(key)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", fmt.Errorf("%w: %s %s", ErrNotRecorded, method, shortKey(key))
+		}
+		return rec.Response, nil
+	}
+	res, err := call()
+	if err != nil {
+		return "", err
+	}
+	c.record(key, Recording{Method: method, Response: res})
+	return res, nil
+}
+
+// record stores rec. Failures are logged so recording never breaks live calls.
+func (c *simulationClient) record(key string, rec Recording) {
+	if err := c.store.Put(key, rec); err != nil {
+		slog.Warn("ai simulation recording not stored", slog.String("key", shortKey(key)), slog.String("method", rec.Method), slog.Any("error", err))
+	}
+}
+
+func shortKey(key string) string {
+	if len(key) > 12 {
+		return key[:12]
+	}
+	return key
+}
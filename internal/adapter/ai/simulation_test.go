@@ -0,0 +1,60 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+)
+
+func TestSimulationClient_RecordThenReplay(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileRecordingStore(t.TempDir())
+
+	base := mocks.NewMockAIClient(t)
+	base.EXPECT().ChatJSONWithRetry(mock.Anything, "sys", "user", 100).Return(`{"ok":true}`, nil).Once()
+	base.EXPECT().ChatJSON(mock.Anything, "sys", "fails", 100).Return("", errors.New("rate limited")).Once()
+	base.EXPECT().Embed(mock.Anything, []string{"a", "b"}).Return([][]float32{{1}, {2}}, nil).Once()
+	base.EXPECT().CleanCoTResponse(mock.Anything, "raw").Return("clean", nil).Once()
+
+	rec := NewSimulationClient(base, SimulationRecord, store)
+	res, err := rec.ChatJSONWithRetry(ctx, "sys", "user", 100)
+	require.NoError(t, err)
+	assert.Equal(t, `{"ok":true}`, res)
+	_, err = rec.ChatJSON(ctx, "sys", "fails", 100)
+	require.Error(t, err)
+	_, err = rec.Embed(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	_, err = rec.CleanCoTResponse(ctx, "raw")
+	require.NoError(t, err)
+
+	// Replay never touches a provider.
+	replay := NewSimulationClient(nil, SimulationReplay, store)
+	res, err = replay.ChatJSONWithRetry(ctx, "sys", "user", 100)
+	require.NoError(t, err)
+	assert.Equal(t, `{"ok":true}`, res)
+	vecs, err := replay.Embed(ctx, []string{"b", "a"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{2}, {1}}, vecs)
+	res, err = replay.CleanCoTResponse(ctx, "raw")
+	require.NoError(t, err)
+	assert.Equal(t, "clean", res)
+
+	_, err = replay.ChatJSON(ctx, "sys", "fails", 100)
+	require.ErrorIs(t, err, ErrNotRecorded, "failures are not recorded")
+	_, err = replay.ChatJSONWithRetry(ctx, "sys", "user", 200)
+	require.ErrorIs(t, err, ErrNotRecorded, "max tokens are part of the key")
+	_, err = replay.Embed(ctx, []string{"a", "c"})
+	require.ErrorIs(t, err, ErrNotRecorded)
+}
+
+func TestNewSimulationClient_OffReturnsBase(t *testing.T) {
+	base := mocks.NewMockAIClient(t)
+	assert.Same(t, base, NewSimulationClient(base, SimulationOff, NewFileRecordingStore(t.TempDir())))
+	assert.Same(t, base, NewSimulationClient(base, SimulationReplay, nil))
+}
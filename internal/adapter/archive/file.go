@@ -0,0 +1,87 @@
+// Package archive provides cold-storage sinks for exported database data.
+//
+// Sinks receive a stream (for example a CSV export of an expired table
+// partition) under a slash-separated key and persist it outside the
+// primary database, either on a local/mounted filesystem or in S3.
+package archive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileSink stores archives as files below a base directory.
+type FileSink struct {
+	Dir string
+}
+
+// NewFileSink constructs a FileSink rooted at dir.
+func NewFileSink(dir string) *FileSink { return &FileSink{Dir: dir} }
+
+// Store writes r to Dir/key. The file is written under a temporary name and
+// renamed once complete so a partially written archive is never mistaken for
+// a finished one.
+func (s *FileSink) Store(ctx context.Context, key string, r io.Reader) error {
+	if s.Dir == "" {
+		return fmt.Errorf("op=archive.file_store: empty directory")
+	}
+	clean := filepath.Clean("/" + key)
+	if strings.Contains(key, "..") || clean == "/" {
+		return fmt.Errorf("op=archive.file_store: invalid key %q", key)
+	}
+	dst := filepath.Join(s.Dir, filepath.FromSlash(clean))
+	if err := os.MkdirAll(filepath.Dir(dst), 0o750); err != nil {
+		return fmt.Errorf("op=archive.file_mkdir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".archive-*")
+	if err != nil {
+		return fmt.Errorf("op=archive.file_create: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := io.Copy(tmp, contextReader{ctx: ctx, r: r}); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("op=archive.file_write: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("op=archive.file_sync: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("op=archive.file_close: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return fmt.Errorf("op=archive.file_rename: %w", err)
+	}
+	return nil
+}
+
+// Open returns the archive stored under key.
+func (s *FileSink) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	clean := filepath.Clean("/" + key)
+	if s.Dir == "" || strings.Contains(key, "..") || clean == "/" {
+		return nil, fmt.Errorf("op=archive.file_open: invalid key %q", key)
+	}
+	f, err := os.Open(filepath.Join(s.Dir, filepath.FromSlash(clean)))
+	if err != nil {
+		return nil, fmt.Errorf("op=archive.file_open: %w", err)
+	}
+	return f, nil
+}
+
+// contextReader stops a copy as soon as ctx is canceled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,52 @@
+package archive
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink_Store(t *testing.T) {
+	dir := t.TempDir()
+	s := NewFileSink(dir)
+	require.NoError(t, s.Store(context.Background(), "jobs/jobs_p202501.csv", strings.NewReader("id\n1\n")))
+
+	b, err := os.ReadFile(filepath.Join(dir, "jobs", "jobs_p202501.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "id\n1\n", string(b))
+
+	entries, err := os.ReadDir(filepath.Join(dir, "jobs"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary file must be renamed, not left behind")
+
+	rc, err := s.Open(context.Background(), "jobs/jobs_p202501.csv")
+	require.NoError(t, err)
+	b, _ = io.ReadAll(rc)
+	_ = rc.Close()
+	assert.Equal(t, "id\n1\n", string(b))
+	_, err = s.Open(context.Background(), "../escape.csv")
+	assert.Error(t, err)
+}
+
+func TestFileSink_Store_RejectsInvalidKeys(t *testing.T) {
+	s := NewFileSink(t.TempDir())
+	for _, key := range []string{"", "../escape.csv", "a/../../b.csv"} {
+		assert.Error(t, s.Store(context.Background(), key, strings.NewReader("x")), key)
+	}
+	assert.Error(t, NewFileSink("").Store(context.Background(), "a.csv", strings.NewReader("x")))
+}
+
+func TestFileSink_Store_CanceledContext(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, NewFileSink(dir).Store(ctx, "a.csv", strings.NewReader("x")))
+	_, err := os.Stat(filepath.Join(dir, "a.csv"))
+	assert.True(t, os.IsNotExist(err))
+}
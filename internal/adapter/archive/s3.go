@@ -0,0 +1,129 @@
+package archive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/s3"
+)
+
+// S3Config configures an S3 (or S3-compatible) archive bucket.
+type S3Config struct {
+	// Endpoint overrides the AWS endpoint, e.g. http://minio:9000. When set,
+	// path-style addressing is used.
+	Endpoint string
+	// Region is the bucket region used for request signing.
+	Region string
+	// Bucket is the destination bucket.
+	Bucket string
+	// Prefix is prepended to every object key.
+	Prefix string
+	// AccessKeyID and SecretAccessKey are static credentials.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3Sink uploads archives to S3 with a single SigV4-signed PUT per object.
+type S3Sink struct {
+	cfg        S3Config
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3Sink constructs an S3Sink.
+func NewS3Sink(cfg S3Config) *S3Sink {
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	return &S3Sink{cfg: cfg, httpClient: &http.Client{Timeout: 10 * time.Minute}, now: time.Now}
+}
+
+// Store uploads r as Prefix/key. The stream is spooled to a temporary file
+// first because S3 requires the content length and payload hash up front.
+func (s *S3Sink) Store(ctx context.Context, key string, r io.Reader) error {
+	if s.cfg.Bucket == "" {
+		return fmt.Errorf("op=archive.s3_store: empty bucket")
+	}
+	tmp, err := os.CreateTemp("", "archive-s3-*")
+	if err != nil {
+		return fmt.Errorf("op=archive.s3_spool: %w", err)
+	}
+	defer func() { _ = tmp.Close(); _ = os.Remove(tmp.Name()) }()
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, h), contextReader{ctx: ctx, r: r})
+	if err != nil {
+		return fmt.Errorf("op=archive.s3_spool: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("op=archive.s3_spool: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key), tmp)
+	if err != nil {
+		return fmt.Errorf("op=archive.s3_request: %w", err)
+	}
+	req.ContentLength = size
+	contentType := "application/octet-stream"
+	if strings.HasSuffix(key, ".csv") {
+		contentType = "text/csv"
+	}
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, hex.EncodeToString(h.Sum(nil)))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("op=archive.s3_put: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("op=archive.s3_put: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// Open downloads the object stored as Prefix/key.
+func (s *S3Sink) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if s.cfg.Bucket == "" {
+		return nil, fmt.Errorf("op=archive.s3_open: empty bucket")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("op=archive.s3_request: %w", err)
+	}
+	s.sign(req, s3.EmptyPayloadHash)
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("op=archive.s3_get: %w", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("op=archive.s3_get: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp.Body, nil
+}
+
+// objectURL returns the path-style (custom endpoint) or virtual-hosted-style
+// (AWS) URL for key.
+func (s *S3Sink) objectURL(key string) string {
+	objectKey := strings.TrimPrefix(path.Join(s.cfg.Prefix, key), "/")
+	if s.cfg.Endpoint != "" {
+		return strings.TrimRight(s.cfg.Endpoint, "/") + "/" + s.cfg.Bucket + "/" + s3.EscapePath(objectKey)
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.cfg.Bucket, s.cfg.Region, s3.EscapePath(objectKey))
+}
+
+// sign adds AWS Signature Version 4 headers for the S3 service.
+func (s *S3Sink) sign(req *http.Request, payloadHash string) {
+	s3.Sign(req, s3.Credentials{AccessKeyID: s.cfg.AccessKeyID, SecretAccessKey: s.cfg.SecretAccessKey}, s.cfg.Region, payloadHash, s.now())
+}
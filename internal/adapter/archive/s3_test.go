@@ -0,0 +1,82 @@
+package archive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3Sink_Store_SignsAndUploads(t *testing.T) {
+	var gotPath, gotAuth, gotHash, gotBody string
+	var gotLen int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		gotHash = r.Header.Get("X-Amz-Content-Sha256")
+		gotLen = r.ContentLength
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	s := NewS3Sink(S3Config{Endpoint: srv.URL, Region: "eu-west-1", Bucket: "cold", Prefix: "evaluator", AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	s.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	require.NoError(t, s.Store(context.Background(), "jobs/jobs_p202501.csv", strings.NewReader("id\n1\n")))
+	assert.Equal(t, "/cold/evaluator/jobs/jobs_p202501.csv", gotPath)
+	assert.Equal(t, "id\n1\n", gotBody)
+	assert.Equal(t, int64(5), gotLen)
+	// sha256("id\n1\n")
+	assert.Equal(t, "7cde7fb64fd82bd152710cf238e017b9ab46c0592483edc067ba4f6c75fac108", gotHash)
+	assert.True(t, strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKID/20250102/eu-west-1/s3/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature="))
+}
+
+func TestS3Sink_Open(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		if r.URL.Path != "/cold/evaluator/qdrant/jd/1.snapshot" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.NotEmpty(t, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte("snapshot"))
+	}))
+	defer srv.Close()
+
+	s := NewS3Sink(S3Config{Endpoint: srv.URL, Bucket: "cold", Prefix: "evaluator", AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	rc, err := s.Open(context.Background(), "qdrant/jd/1.snapshot")
+	require.NoError(t, err)
+	b, _ := io.ReadAll(rc)
+	_ = rc.Close()
+	assert.Equal(t, "snapshot", string(b))
+
+	_, err = s.Open(context.Background(), "qdrant/jd/2.snapshot")
+	assert.ErrorContains(t, err, "status 404")
+}
+
+func TestS3Sink_Store_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("<Error>AccessDenied</Error>"))
+	}))
+	defer srv.Close()
+
+	s := NewS3Sink(S3Config{Endpoint: srv.URL, Bucket: "cold"})
+	err := s.Store(context.Background(), "a.csv", strings.NewReader("x"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 403")
+	assert.Error(t, NewS3Sink(S3Config{}).Store(context.Background(), "a.csv", strings.NewReader("x")))
+}
+
+func TestS3Sink_ObjectURL_VirtualHosted(t *testing.T) {
+	s := NewS3Sink(S3Config{Region: "us-west-2", Bucket: "cold", Prefix: "/p"})
+	assert.Equal(t, "https://cold.s3.us-west-2.amazonaws.com/p/jobs/a%20b.csv", s.objectURL("jobs/a b.csv"))
+}
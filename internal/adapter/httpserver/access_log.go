@@ -0,0 +1,126 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// AccessLogger records reads of results and queries them. It is implemented
+// by usecase.AccessLogService.
+type AccessLogger interface {
+	Record(ctx context.Context, entries []domain.ResultAccess) error
+	List(ctx context.Context, f domain.AccessLogFilter) ([]domain.ResultAccess, error)
+}
+
+// Endpoints recorded in the access log.
+const (
+	accessResult         = "GET /v1/result/{id}"
+	accessResults        = "/v1/results"
+	accessResultDiff     = "GET /v1/result/{id}/diff"
+	accessSummary        = "POST /v1/results/{id}/summary"
+	accessBadge          = "GET /v1/evaluate/{id}/badge.svg"
+	accessAdminJobDetail = "GET /admin/api/jobs/{id}"
+)
+
+// clientIP returns the address the request came from: the first
+// X-Forwarded-For entry when trusted, otherwise the peer address.
+func clientIP(r *http.Request, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			first, _, _ := strings.Cut(xff, ",")
+			if ip := strings.TrimSpace(first); ip != "" {
+				return ip
+			}
+		}
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// recordResultAccess logs that the results of jobIDs were served by
+// endpoint. The reader is the admin authenticated on the request, else the
+// holder of the X-API-Key, else anonymous. A failure to record is logged but
+// does not fail the read.
+func (s *Server) recordResultAccess(r *http.Request, endpoint string, jobIDs ...string) {
+	if s.AccessLog == nil || len(jobIDs) == 0 {
+		return
+	}
+	accessorType, accessor := domain.AccessorAnonymous, ""
+	if u := adminUser(r); u != "" {
+		accessorType, accessor = domain.AccessorAdmin, u
+	} else if key := r.Header.Get("X-API-Key"); key != "" {
+		accessorType, accessor = domain.AccessorAPIKey, usecase.HashAPIKey(key)
+	}
+	ip := clientIP(r, s.Cfg.AccessLogTrustForwardedFor)
+	entries := make([]domain.ResultAccess, 0, len(jobIDs))
+	for _, id := range jobIDs {
+		entries = append(entries, domain.ResultAccess{JobID: id, AccessorType: accessorType, Accessor: accessor, IP: ip, Endpoint: endpoint})
+	}
+	if err := s.AccessLog.Record(r.Context(), entries); err != nil {
+		LoggerFrom(r).Error("failed to record result access", "endpoint", endpoint, "jobs", len(jobIDs), "error", err)
+	}
+}
+
+type accessLogView struct {
+	JobID        string    `json:"job_id"`
+	AccessorType string    `json:"accessor_type"`
+	Accessor     string    `json:"accessor,omitempty"`
+	IP           string    `json:"ip,omitempty"`
+	Endpoint     string    `json:"endpoint"`
+	AccessedAt   time.Time `json:"accessed_at"`
+}
+
+// AdminAccessLogHandler lists reads of results, newest first. The job_id and
+// accessor query parameters filter by job and reader, since and until
+// (RFC 3339) by time, and limit caps the number of entries.
+func (a *AdminServer) AdminAccessLogHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminAccessLogHandler")
+		defer span.End()
+		q := r.URL.Query()
+		f := domain.AccessLogFilter{JobID: q.Get("job_id"), Accessor: q.Get("accessor")}
+		for name, dst := range map[string]*time.Time{"since": &f.Since, "until": &f.Until} {
+			raw := q.Get(name)
+			if raw == "" {
+				continue
+			}
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				writeError(w, r, fmt.Errorf("%w: %s must be an RFC 3339 time", domain.ErrInvalidArgument, name), map[string]string{name: "datetime"})
+				return
+			}
+			*dst = t
+		}
+		if raw := q.Get("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				writeError(w, r, fmt.Errorf("%w: limit must be a number", domain.ErrInvalidArgument), map[string]string{"limit": "number"})
+				return
+			}
+			f.Limit = n
+		}
+		entries, err := a.server.AccessLog.List(ctx, f)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		out := make([]accessLogView, 0, len(entries))
+		for _, e := range entries {
+			out = append(out, accessLogView{JobID: e.JobID, AccessorType: e.AccessorType, Accessor: e.Accessor, IP: e.IP, Endpoint: e.Endpoint, AccessedAt: e.AccessedAt})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"entries": out})
+	}
+}
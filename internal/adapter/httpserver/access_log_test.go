@@ -0,0 +1,118 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestResultHandler_RecordsAccess(t *testing.T) {
+	srv := newResultServer(t, domain.Job{ID: "job1", Status: domain.JobCompleted}, domain.Result{JobID: "job1", CVMatchRate: 0.9, CVFeedback: "good.", ProjectScore: 9, ProjectFeedback: "nice.", OverallSummary: "great overall."})
+	srv.Cfg.AccessLogTrustForwardedFor = true
+	repo := mocks.NewMockAccessLogRepository(t)
+	srv.AccessLog = usecase.NewAccessLogService(repo)
+	router := chi.NewRouter()
+	router.Get("/v1/result/{id}", srv.ResultHandler())
+
+	repo.EXPECT().Record(mock.Anything, mock.MatchedBy(func(entries []domain.ResultAccess) bool {
+		e := entries[0]
+		return len(entries) == 1 && e.JobID == "job1" && e.AccessorType == domain.AccessorAPIKey &&
+			e.Accessor == usecase.HashAPIKey("k1") && e.IP == "203.0.113.7" && e.Endpoint == "GET /v1/result/{id}"
+	})).Return(nil).Once()
+	r := httptest.NewRequest(http.MethodGet, "/v1/result/job1", nil)
+	r.Header.Set("X-API-Key", "k1")
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+
+	// A revalidated read serves no result and is not recorded; a failure to
+	// record does not fail the read.
+	r = httptest.NewRequest(http.MethodGet, "/v1/result/job1", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusNotModified, w.Code)
+
+	repo.EXPECT().Record(mock.Anything, mock.MatchedBy(func(entries []domain.ResultAccess) bool {
+		return entries[0].AccessorType == domain.AccessorAnonymous && entries[0].IP == "192.0.2.1"
+	})).Return(assert.AnError).Once()
+	r = httptest.NewRequest(http.MethodGet, "/v1/result/job1", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestResultHandler_PendingNotRecorded(t *testing.T) {
+	srv := newResultServer(t, domain.Job{ID: "job1", Status: domain.JobProcessing, UpdatedAt: time.Now()}, domain.Result{})
+	srv.AccessLog = usecase.NewAccessLogService(mocks.NewMockAccessLogRepository(t))
+	router := chi.NewRouter()
+	router.Get("/v1/result/{id}", srv.ResultHandler())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/result/job1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestStatusBadgeHandler_RecordsAccess(t *testing.T) {
+	srv := newResultServer(t, domain.Job{ID: "job1", Status: domain.JobCompleted}, domain.Result{JobID: "job1", CVMatchRate: 0.82, ProjectScore: 8.4})
+	repo := mocks.NewMockAccessLogRepository(t)
+	srv.AccessLog = usecase.NewAccessLogService(repo)
+	repo.EXPECT().Record(mock.Anything, mock.MatchedBy(func(entries []domain.ResultAccess) bool {
+		e := entries[0]
+		return len(entries) == 1 && e.JobID == "job1" && e.AccessorType == domain.AccessorAnonymous && e.Endpoint == "GET /v1/evaluate/{id}/badge.svg"
+	})).Return(nil).Once()
+	code, svg := getBadge(t, srv, "job1")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, svg, "cv 82%")
+
+	// A badge without scores is not recorded.
+	srv = newResultServer(t, domain.Job{ID: "job2", Status: domain.JobProcessing, CreatedAt: time.Now(), UpdatedAt: time.Now()}, domain.Result{})
+	srv.AccessLog = usecase.NewAccessLogService(mocks.NewMockAccessLogRepository(t))
+	_, svg = getBadge(t, srv, "job2")
+	require.Contains(t, svg, ">processing</text>")
+}
+
+func Test_Admin_AccessLog(t *testing.T) {
+	repo := mocks.NewMockAccessLogRepository(t)
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.EvaluateService{}, usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.AccessLog = usecase.NewAccessLogService(repo)
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/access-log", admin.AdminBearerRequired(admin.AdminAccessLogHandler()))
+	token := loginAndGetToken(t, r)
+
+	for _, q := range []string{"since=yesterday", "limit=ten", "limit=-1"} {
+		if rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/access-log?"+q, ""); rw.Code != http.StatusBadRequest {
+			t.Fatalf("%s status = %d", q, rw.Code)
+		}
+	}
+
+	since := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC)
+	repo.EXPECT().List(mock.Anything, domain.AccessLogFilter{JobID: "j1", Since: since, Limit: 20}).
+		Return([]domain.ResultAccess{{JobID: "j1", AccessorType: "admin", Accessor: "alice", IP: "10.0.0.2", Endpoint: "GET /admin/api/jobs/{id}", AccessedAt: at}}, nil).Once()
+	rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/access-log?job_id=j1&since=2025-12-01T00:00:00Z&limit=20", "")
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+	assert.True(t, strings.Contains(rw.Body.String(), `"accessor":"alice"`), rw.Body.String())
+}
@@ -0,0 +1,89 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// BiasAuditor reads and produces bias audit reports.
+// It is implemented by usecase.BiasAuditService.
+type BiasAuditor interface {
+	Latest(ctx context.Context) (domain.BiasReport, error)
+	Generate(ctx context.Context) (domain.BiasReport, error)
+}
+
+type biasSegmentView struct {
+	Dimension         string   `json:"dimension"`
+	Value             string   `json:"value"`
+	Count             int      `json:"count"`
+	AvgCVMatchRate    float64  `json:"avg_cv_match_rate"`
+	AvgProjectScore   float64  `json:"avg_project_score"`
+	CVMatchRateDelta  float64  `json:"cv_match_rate_delta"`
+	ProjectScoreDelta float64  `json:"project_score_delta"`
+	CVMatchRateDrift  *float64 `json:"cv_match_rate_drift,omitempty"`
+	ProjectScoreDrift *float64 `json:"project_score_drift,omitempty"`
+	Flagged           bool     `json:"flagged"`
+}
+
+type biasReportView struct {
+	ID              string            `json:"id"`
+	GeneratedAt     time.Time         `json:"generated_at"`
+	WindowStart     time.Time         `json:"window_start"`
+	WindowEnd       time.Time         `json:"window_end"`
+	SampleCount     int               `json:"sample_count"`
+	AvgCVMatchRate  float64           `json:"avg_cv_match_rate"`
+	AvgProjectScore float64           `json:"avg_project_score"`
+	Segments        []biasSegmentView `json:"segments"`
+}
+
+func toBiasReportView(r domain.BiasReport) biasReportView {
+	v := biasReportView{
+		ID:              r.ID,
+		GeneratedAt:     r.GeneratedAt,
+		WindowStart:     r.WindowStart,
+		WindowEnd:       r.WindowEnd,
+		SampleCount:     r.SampleCount,
+		AvgCVMatchRate:  r.AvgCVMatchRate,
+		AvgProjectScore: r.AvgProjectScore,
+		Segments:        make([]biasSegmentView, 0, len(r.Segments)),
+	}
+	for _, s := range r.Segments {
+		v.Segments = append(v.Segments, biasSegmentView(s))
+	}
+	return v
+}
+
+// AdminBiasReportHandler returns the latest bias audit report.
+func (a *AdminServer) AdminBiasReportHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminBiasReportHandler")
+		defer span.End()
+		rep, err := a.server.BiasAudit.Latest(ctx)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, toBiasReportView(rep))
+	}
+}
+
+// AdminGenerateBiasReportHandler generates a bias audit report now.
+func (a *AdminServer) AdminGenerateBiasReportHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminGenerateBiasReportHandler")
+		defer span.End()
+		rep, err := a.server.BiasAudit.Generate(ctx)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusCreated, toBiasReportView(rep))
+	}
+}
@@ -0,0 +1,84 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type stubBiasAuditor struct {
+	latest    domain.BiasReport
+	latestErr error
+	generated int
+}
+
+func (s *stubBiasAuditor) Latest(context.Context) (domain.BiasReport, error) {
+	return s.latest, s.latestErr
+}
+
+func (s *stubBiasAuditor) Generate(context.Context) (domain.BiasReport, error) {
+	s.generated++
+	return s.latest, nil
+}
+
+func Test_Admin_BiasReport(t *testing.T) {
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	drift := -0.05
+	auditor := &stubBiasAuditor{latestErr: domain.ErrNotFound}
+	srv.BiasAudit = auditor
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/analytics/bias", admin.AdminBearerRequired(admin.AdminBiasReportHandler()))
+	r.Post("/admin/api/analytics/bias", admin.AdminBearerRequired(admin.AdminGenerateBiasReportHandler()))
+	token := loginAndGetToken(t, r)
+
+	if rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/analytics/bias", ""); rw.Code != http.StatusNotFound {
+		t.Fatalf("missing report status = %d", rw.Code)
+	}
+
+	auditor.latest = domain.BiasReport{ID: "r1", GeneratedAt: time.Now().UTC(), SampleCount: 40, Segments: []domain.BiasSegment{
+		{Dimension: "language", Value: "id", Count: 25, CVMatchRateDelta: -0.12, CVMatchRateDrift: &drift, Flagged: true},
+	}}
+	auditor.latestErr = nil
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/analytics/bias", "")
+	if rw.Code != http.StatusCreated || auditor.generated != 1 {
+		t.Fatalf("generate status = %d, calls = %d", rw.Code, auditor.generated)
+	}
+
+	rw = doAdminJSON(r, token, http.MethodGet, "/admin/api/analytics/bias", "")
+	if rw.Code != http.StatusOK {
+		t.Fatalf("latest status = %d", rw.Code)
+	}
+	var body struct {
+		SampleCount int `json:"sample_count"`
+		Segments    []struct {
+			Value            string   `json:"value"`
+			CVMatchRateDrift *float64 `json:"cv_match_rate_drift"`
+			Flagged          bool     `json:"flagged"`
+		} `json:"segments"`
+	}
+	if err := json.Unmarshal(rw.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.SampleCount != 40 || len(body.Segments) != 1 || !body.Segments[0].Flagged || body.Segments[0].CVMatchRateDrift == nil {
+		t.Fatalf("unexpected body: %s", rw.Body.String())
+	}
+}
@@ -0,0 +1,276 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Limits of a bulk submission.
+const (
+	maxBulkCSVBytes    = 5 << 20
+	bulkFetchTimeout   = 30 * time.Second
+	defaultBulkMaxRows = 500
+)
+
+// bulkColumns are the CSV columns of a bulk submission; candidate_name and cv
+// are required.
+var bulkColumns = []string{"candidate_name", "cv", "project", "posting_id"}
+
+// bulkRowResult is one line of the streamed bulk submission response.
+type bulkRowResult struct {
+	Row           int       `json:"row"`
+	CandidateName string    `json:"candidate_name,omitempty"`
+	PostingID     string    `json:"posting_id,omitempty"`
+	ID            string    `json:"id,omitempty"`
+	Status        string    `json:"status,omitempty"`
+	Error         *apiError `json:"error,omitempty"`
+}
+
+type bulkSummary struct {
+	Rows   int `json:"rows"`
+	Queued int `json:"queued"`
+	Failed int `json:"failed"`
+}
+
+// AdminBulkEvaluateHandler creates one evaluation per row of a CSV body with
+// the columns candidate_name, cv, project and posting_id. cv and project are
+// upload ids or http(s) URLs of documents, which are fetched and ingested;
+// an empty project requests a CV-only evaluation. The response is streamed as
+// newline-delimited JSON: one line per row with the job id or the row's
+// validation error, then a summary line. Re-submitting a row queues no
+// duplicate job.
+func (a *AdminServer) AdminBulkEvaluateHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminBulkEvaluateHandler")
+		defer span.End()
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBulkCSVBytes)
+		cr := csv.NewReader(r.Body)
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+		header, err := cr.Read()
+		if err != nil {
+			writeError(w, r, fmt.Errorf("%w: csv header: %v", domain.ErrInvalidArgument, err), nil)
+			return
+		}
+		cols := map[string]int{}
+		for i, name := range header {
+			cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+		}
+		for _, required := range []string{"candidate_name", "cv"} {
+			if _, ok := cols[required]; !ok {
+				writeError(w, r, fmt.Errorf("%w: csv header misses column %s", domain.ErrInvalidArgument, required), map[string]any{"columns": bulkColumns})
+				return
+			}
+		}
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			ctx = domain.WithTenantAPIKey(ctx, key)
+		}
+		maxRows := a.cfg.BulkSubmitMaxRows
+		if maxRows <= 0 {
+			maxRows = defaultBulkMaxRows
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		enc := json.NewEncoder(w)
+		flusher, _ := w.(http.Flusher)
+		emit := func(v any) {
+			_ = enc.Encode(v)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		var sum bulkSummary
+		lg := LoggerFrom(r)
+		// Row 1 is the header, so data rows are numbered like spreadsheet rows.
+		for row := 2; ; row++ {
+			rec, err := cr.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			res := bulkRowResult{Row: row}
+			var parseErr *csv.ParseError
+			switch {
+			case err != nil && !errors.As(err, &parseErr):
+				// The body is unreadable or too large; later rows are lost.
+				res.Error = bulkRowError(fmt.Errorf("%w: csv: %v", domain.ErrInvalidArgument, err), "")
+				sum.Rows++
+				sum.Failed++
+				emit(res)
+				emit(map[string]bulkSummary{"summary": sum})
+				return
+			case err != nil:
+				res.Error = bulkRowError(fmt.Errorf("%w: csv: %v", domain.ErrInvalidArgument, err), "")
+			case sum.Rows >= maxRows:
+				res.Error = bulkRowError(fmt.Errorf("%w: at most %d rows per submission", domain.ErrInvalidArgument, maxRows), "")
+				sum.Rows++
+				sum.Failed++
+				emit(res)
+				emit(map[string]bulkSummary{"summary": sum})
+				return
+			default:
+				field := func(name string) string {
+					if i, ok := cols[name]; ok && i < len(rec) {
+						return strings.TrimSpace(rec[i])
+					}
+					return ""
+				}
+				res.CandidateName, res.PostingID = field("candidate_name"), field("posting_id")
+				id, errField, err := a.server.submitBulkRow(ctx, res.CandidateName, field("cv"), field("project"), res.PostingID)
+				if err != nil {
+					res.Error = bulkRowError(err, errField)
+				} else {
+					res.ID, res.Status = id, string(domain.JobQueued)
+				}
+			}
+			sum.Rows++
+			if res.Error != nil {
+				sum.Failed++
+			} else {
+				sum.Queued++
+			}
+			emit(res)
+		}
+		lg.Info("bulk submission processed", slog.Int("rows", sum.Rows), slog.Int("queued", sum.Queued), slog.Int("failed", sum.Failed))
+		emit(map[string]bulkSummary{"summary": sum})
+	}
+}
+
+func bulkRowError(err error, field string) *apiError {
+	_, code := errorStatus(err)
+	e := &apiError{Code: code, Message: err.Error()}
+	if field != "" {
+		e.Details = map[string]string{"field": field}
+	}
+	return e
+}
+
+// submitBulkRow resolves the documents of one bulk row and enqueues its
+// evaluation. On failure it also returns the column at fault, if any.
+func (s *Server) submitBulkRow(ctx context.Context, name, cvRef, projectRef, postingID string) (string, string, error) {
+	if name == "" {
+		return "", "candidate_name", fmt.Errorf("%w: candidate_name required", domain.ErrInvalidArgument)
+	}
+	if cvRef == "" {
+		return "", "cv", fmt.Errorf("%w: cv required", domain.ErrInvalidArgument)
+	}
+	// The key covers the row's references, so re-submitting the same CSV
+	// returns the jobs queued the first time without fetching the documents
+	// again.
+	sum := sha256.Sum256([]byte(strings.Join([]string{postingID, name, cvRef, projectRef}, "\x00")))
+	idemKey := "bulk-" + hex.EncodeToString(sum[:16])
+	if s.Evaluate.Jobs != nil {
+		if j, err := s.Evaluate.Jobs.FindByIdempotencyKey(ctx, idemKey); err == nil && j.ID != "" {
+			return j.ID, "", nil
+		}
+	}
+	cvID, err := s.resolveBulkDocument(ctx, domain.UploadTypeCV, cvRef)
+	if err != nil {
+		return "", "cv", err
+	}
+	var projectID string
+	if projectRef != "" {
+		if projectID, err = s.resolveBulkDocument(ctx, domain.UploadTypeProject, projectRef); err != nil {
+			return "", "project", err
+		}
+	}
+	jobDescription, studyCase := getDefaultJobDescription(), ""
+	if projectID != "" {
+		studyCase = getDefaultStudyCaseBrief()
+	}
+	jobID, err := s.Evaluate.Enqueue(ctx, cvID, projectID, jobDescription, studyCase, "", idemKey)
+	if err != nil {
+		return "", "", fmt.Errorf("enqueue: %w", err)
+	}
+	return jobID, "", nil
+}
+
+// resolveBulkDocument returns the upload id of ref: an existing upload of
+// uploadType, or the document at an http(s) URL, which is fetched, extracted
+// and ingested.
+func (s *Server) resolveBulkDocument(ctx context.Context, uploadType, ref string) (string, error) {
+	if u, err := url.Parse(ref); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		name, data, err := s.fetchBulkDocument(ctx, u)
+		if err != nil {
+			return "", err
+		}
+		return s.IngestDocument(ctx, uploadType, name, data)
+	}
+	up, err := s.Uploads.Repo.Get(ctx, ref)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", fmt.Errorf("%w: %s upload %s not found", domain.ErrNotFound, uploadType, ref)
+		}
+		return "", err
+	}
+	if up.Type != uploadType {
+		return "", fmt.Errorf("%w: upload %s is a %s, not a %s", domain.ErrInvalidArgument, ref, up.Type, uploadType)
+	}
+	return up.ID, nil
+}
+
+// IngestDocument stores the file name with content data as an upload of
+// uploadType after the same type checks and text extraction as /v1/upload,
+// and returns the upload id.
+func (s *Server) IngestDocument(ctx context.Context, uploadType, name string, data []byte) (string, error) {
+	allowed := allowedExt
+	if uploadType == domain.UploadTypeCV {
+		allowed = allowedCVExt
+	}
+	if !allowed(name) || !allowedMIMEFor(mimetype.Detect(data).String(), name) {
+		return "", fmt.Errorf("%w: unsupported media type for %s", domain.ErrInvalidArgument, uploadType)
+	}
+	text, err := extractUploadedText(ctx, s.Extractor, &multipart.FileHeader{Filename: name}, data)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s extract: %v", domain.ErrInvalidArgument, uploadType, err)
+	}
+	return s.Uploads.IngestOne(ctx, uploadType, text, name)
+}
+
+// fetchBulkDocument downloads the document at u, capped at MAX_UPLOAD_MB. The
+// file name is taken from the URL path.
+func (s *Server) fetchBulkDocument(ctx context.Context, u *url.URL) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, bulkFetchTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", domain.ErrInvalidArgument, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: fetch %s: %v", domain.ErrInvalidArgument, u.Redacted(), err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return "", nil, fmt.Errorf("%w: fetch %s: status %d", domain.ErrInvalidArgument, u.Redacted(), resp.StatusCode)
+	}
+	maxBytes := s.Cfg.MaxUploadMB * 1024 * 1024
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: fetch %s: %v", domain.ErrInvalidArgument, u.Redacted(), err)
+	}
+	if int64(len(data)) > maxBytes {
+		return "", nil, fmt.Errorf("%w: %s exceeds %d MB", domain.ErrInvalidArgument, u.Redacted(), s.Cfg.MaxUploadMB)
+	}
+	return path.Base(u.Path), data, nil
+}
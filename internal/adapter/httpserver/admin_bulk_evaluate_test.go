@@ -0,0 +1,124 @@
+package httpserver_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func newBulkRouter(t *testing.T, jobs domain.JobRepository, q domain.Queue, uploads domain.UploadRepository) (*chi.Mux, string) {
+	t.Helper()
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+		MaxUploadMB:        1,
+	}, usecase.NewUploadService(uploads), usecase.NewEvaluateService(jobs, q, uploads), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Post("/admin/api/v1/evaluations/bulk", admin.AdminBearerRequired(admin.AdminBulkEvaluateHandler()))
+	return r, loginAndGetToken(t, r)
+}
+
+func readBulkLines(t *testing.T, body string) []map[string]any {
+	t.Helper()
+	var out []map[string]any
+	sc := bufio.NewScanner(strings.NewReader(body))
+	for sc.Scan() {
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &m))
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestAdminBulkEvaluate_StreamsRowResults(t *testing.T) {
+	docs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alice.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("Alice: Go engineer with five years of backend experience."))
+	}))
+	defer docs.Close()
+
+	jobs := mocks.NewMockJobRepository(t)
+	q := mocks.NewMockQueue(t)
+	uploads := mocks.NewMockUploadRepository(t)
+	jobs.EXPECT().FindByIdempotencyKey(mock.Anything, mock.Anything).Return(domain.Job{}, domain.ErrNotFound)
+	uploads.EXPECT().Get(mock.Anything, "cv-1").Return(domain.Upload{ID: "cv-1", Type: domain.UploadTypeCV}, nil)
+	uploads.EXPECT().Get(mock.Anything, "pr-1").Return(domain.Upload{ID: "pr-1", Type: domain.UploadTypeProject}, nil)
+	uploads.EXPECT().Get(mock.Anything, "missing").Return(domain.Upload{}, domain.ErrNotFound)
+	uploads.EXPECT().Create(mock.Anything, mock.MatchedBy(func(u domain.Upload) bool {
+		return u.Type == domain.UploadTypeCV && u.Filename == "alice.txt" && strings.Contains(u.Text, "Go engineer")
+	})).Return("cv-2", nil).Once()
+	jobs.EXPECT().Create(mock.Anything, mock.MatchedBy(func(j domain.Job) bool {
+		return j.CVID == "cv-1" && j.ProjectID == "pr-1" && j.IdemKey != nil && strings.HasPrefix(*j.IdemKey, "bulk-")
+	})).Return("job-1", nil).Once()
+	jobs.EXPECT().Create(mock.Anything, mock.MatchedBy(func(j domain.Job) bool {
+		return j.CVID == "cv-2" && j.ProjectID == ""
+	})).Return("job-2", nil).Once()
+	q.EXPECT().EnqueueEvaluate(mock.Anything, mock.Anything).Return("task", nil).Times(2)
+
+	r, token := newBulkRouter(t, jobs, q, uploads)
+	csvBody := "\ufeffCandidate_Name,cv,project,posting_id\n" +
+		"Bob,cv-1,pr-1,be-42\n" +
+		"Alice," + docs.URL + "/alice.txt,,be-42\n" +
+		",cv-1,,be-42\n" +
+		"Carol,missing,,be-42\n" +
+		"Dave,pr-1,,be-42\n"
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/evaluations/bulk", csvBody)
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Equal(t, "application/x-ndjson", rw.Header().Get("Content-Type"))
+
+	lines := readBulkLines(t, rw.Body.String())
+	require.Len(t, lines, 6)
+	require.Equal(t, "job-1", lines[0]["id"])
+	require.Equal(t, float64(2), lines[0]["row"])
+	require.Equal(t, "be-42", lines[0]["posting_id"])
+	require.Equal(t, "job-2", lines[1]["id"])
+	for i, field := range []string{"candidate_name", "cv", "cv"} {
+		e, ok := lines[2+i]["error"].(map[string]any)
+		require.True(t, ok, "row %d should fail", i+4)
+		require.Equal(t, field, e["details"].(map[string]any)["field"])
+	}
+	require.Equal(t, "NOT_FOUND", lines[3]["error"].(map[string]any)["code"])
+	require.Equal(t, map[string]any{"rows": float64(5), "queued": float64(2), "failed": float64(3)}, lines[5]["summary"])
+}
+
+func TestAdminBulkEvaluate_ResubmitReturnsExistingJob(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	jobs.EXPECT().FindByIdempotencyKey(mock.Anything, mock.MatchedBy(func(k string) bool {
+		return strings.HasPrefix(k, "bulk-")
+	})).Return(domain.Job{ID: "job-1"}, nil).Once()
+
+	r, token := newBulkRouter(t, jobs, mocks.NewMockQueue(t), mocks.NewMockUploadRepository(t))
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/evaluations/bulk", "candidate_name,cv\nBob,https://cv.example/bob.pdf\n")
+	require.Equal(t, http.StatusOK, rw.Code)
+	lines := readBulkLines(t, rw.Body.String())
+	require.Len(t, lines, 2)
+	require.Equal(t, "job-1", lines[0]["id"])
+}
+
+func TestAdminBulkEvaluate_RejectsMissingColumns(t *testing.T) {
+	r, token := newBulkRouter(t, nil, nil, nil)
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/evaluations/bulk", "name,resume\nBob,cv-1\n")
+	require.Equal(t, http.StatusBadRequest, rw.Code)
+	require.Contains(t, rw.Body.String(), "candidate_name")
+}
@@ -0,0 +1,102 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// ConsumerPauseController pauses and resumes the consumption of queue topics.
+// It is implemented by usecase.ConsumerPauseService.
+type ConsumerPauseController interface {
+	States() []usecase.ConsumerTopicState
+	Pause(ctx context.Context, topic, actor, reason string) (domain.ConsumerPause, error)
+	Resume(ctx context.Context, topic, actor string) (bool, error)
+}
+
+type consumerTopicView struct {
+	Topic    string     `json:"topic"`
+	Paused   bool       `json:"paused"`
+	Reason   string     `json:"reason,omitempty"`
+	PausedBy string     `json:"paused_by,omitempty"`
+	PausedAt *time.Time `json:"paused_at,omitempty"`
+}
+
+func toConsumerTopicView(topic string, p *domain.ConsumerPause) consumerTopicView {
+	v := consumerTopicView{Topic: topic}
+	if p != nil {
+		v.Paused, v.Reason, v.PausedBy = true, p.Reason, p.PausedBy
+		at := p.PausedAt
+		v.PausedAt = &at
+	}
+	return v
+}
+
+// AdminConsumersHandler lists the queue topics and whether their consumption
+// is paused.
+func (a *AdminServer) AdminConsumersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		_, span := tracer.Start(r.Context(), "AdminServer.AdminConsumersHandler")
+		defer span.End()
+		states := a.server.ConsumerPauses.States()
+		out := make([]consumerTopicView, 0, len(states))
+		for _, st := range states {
+			out = append(out, toConsumerTopicView(st.Topic, st.Pause))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"topics": out})
+	}
+}
+
+// AdminPauseConsumerHandler stops the workers from polling a topic. In-flight
+// jobs are finished; the optional reason is recorded with the admin's name.
+func (a *AdminServer) AdminPauseConsumerHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminPauseConsumerHandler")
+		defer span.End()
+		topic := chi.URLParam(r, "topic")
+		span.SetAttributes(attribute.String("messaging.destination", topic))
+		var req struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		p, err := a.server.ConsumerPauses.Pause(ctx, topic, adminUser(r), req.Reason)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, toConsumerTopicView(topic, &p))
+	}
+}
+
+// AdminResumeConsumerHandler lets the workers poll a paused topic again.
+func (a *AdminServer) AdminResumeConsumerHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminResumeConsumerHandler")
+		defer span.End()
+		topic := chi.URLParam(r, "topic")
+		span.SetAttributes(attribute.String("messaging.destination", topic))
+		resumed, err := a.server.ConsumerPauses.Resume(ctx, topic, adminUser(r))
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"topic": topic, "paused": false, "resumed": resumed})
+	}
+}
@@ -0,0 +1,90 @@
+package httpserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// memPauseRepo is an in-memory domain.ConsumerPauseRepository.
+type memPauseRepo map[string]domain.ConsumerPause
+
+func (m memPauseRepo) List(domain.Context) ([]domain.ConsumerPause, error) {
+	out := make([]domain.ConsumerPause, 0, len(m))
+	for _, p := range m {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (m memPauseRepo) Pause(_ domain.Context, p domain.ConsumerPause) error {
+	m[p.Topic] = p
+	return nil
+}
+
+func (m memPauseRepo) Resume(_ domain.Context, topic string) (bool, error) {
+	_, ok := m[topic]
+	delete(m, topic)
+	return ok, nil
+}
+
+func Test_Admin_ConsumerPauses(t *testing.T) {
+	repo := memPauseRepo{}
+	pauses := usecase.NewConsumerPauseService(repo, "evaluate-jobs", "dlq-jobs")
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.EvaluateService{}, usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.ConsumerPauses = pauses
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/consumers", admin.AdminBearerRequired(admin.AdminConsumersHandler()))
+	r.Post("/admin/api/consumers/{topic}/pause", admin.AdminBearerRequired(admin.AdminPauseConsumerHandler()))
+	r.Post("/admin/api/consumers/{topic}/resume", admin.AdminBearerRequired(admin.AdminResumeConsumerHandler()))
+	token := loginAndGetToken(t, r)
+
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/consumers/unknown/pause", ""); rw.Code != http.StatusBadRequest {
+		t.Fatalf("unknown topic status = %d", rw.Code)
+	}
+
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/consumers/evaluate-jobs/pause", `{"reason":"provider outage"}`)
+	if rw.Code != http.StatusOK {
+		t.Fatalf("pause status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	if !pauses.Paused("evaluate-jobs") || repo["evaluate-jobs"].PausedBy != "admin" {
+		t.Fatalf("pause not stored: %+v", repo)
+	}
+
+	rw = doAdminJSON(r, token, http.MethodGet, "/admin/api/consumers", "")
+	var body struct {
+		Topics []struct {
+			Topic  string `json:"topic"`
+			Paused bool   `json:"paused"`
+			Reason string `json:"reason"`
+		} `json:"topics"`
+	}
+	if err := json.Unmarshal(rw.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Topics) != 2 || !body.Topics[0].Paused || body.Topics[0].Reason != "provider outage" || body.Topics[1].Paused {
+		t.Fatalf("unexpected topics: %s", rw.Body.String())
+	}
+
+	rw = doAdminJSON(r, token, http.MethodPost, "/admin/api/consumers/evaluate-jobs/resume", "")
+	if rw.Code != http.StatusOK || pauses.Paused("evaluate-jobs") {
+		t.Fatalf("resume status = %d body=%s", rw.Code, rw.Body.String())
+	}
+}
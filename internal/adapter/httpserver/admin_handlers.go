@@ -2,6 +2,7 @@
 package httpserver
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -154,7 +155,9 @@ func (a *AdminServer) AdminStatsHandler() http.HandlerFunc {
 	}
 }
 
-// AdminJobsHandler returns paginated job list
+// AdminJobsHandler returns paginated job list. Requests carrying a "page"
+// parameter use the legacy OFFSET pagination; all others use keyset
+// pagination driven by the opaque "cursor" returned as next_cursor.
 func (a *AdminServer) AdminJobsHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		tracer := otel.Tracer("http.admin")
@@ -175,6 +178,33 @@ func (a *AdminServer) AdminJobsHandler() http.HandlerFunc {
 			}
 		}
 
+		if r.URL.Query().Get("page") == "" || r.URL.Query().Get("cursor") != "" {
+			params, validation := parseJobListParams(r.URL.Query())
+			if !validation.Valid {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				if err := json.NewEncoder(w).Encode(map[string]any{
+					"error": map[string]any{
+						"code":    "VALIDATION_ERROR",
+						"message": "Invalid job listing parameters",
+						"details": validation.Errors,
+					},
+				}); err != nil {
+					http.Error(w, "Failed to encode error response", http.StatusInternalServerError)
+				}
+				return
+			}
+
+			jobs := a.server.getJobsByCursor(ctx, params)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			if err := json.NewEncoder(w).Encode(jobs); err != nil {
+				http.Error(w, "Failed to encode jobs", http.StatusInternalServerError)
+			}
+			return
+		}
+
 		// Parse and validate query parameters
 		page := SanitizeString(r.URL.Query().Get("page"))
 		limit := SanitizeString(r.URL.Query().Get("limit"))
@@ -250,7 +280,8 @@ func (a *AdminServer) AdminJobDetailsHandler() http.HandlerFunc {
 		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminJobDetailsHandler")
 		defer span.End()
 		// Prefer SSO header injected by reverse proxy (e.g. oauth2-proxy)
-		if getSSOUsernameFromHeaders(r) == "" {
+		user := getSSOUsernameFromHeaders(r)
+		if user == "" {
 			// Fallback to Bearer JWT
 			authz := strings.TrimSpace(r.Header.Get("Authorization"))
 			if !strings.HasPrefix(strings.ToLower(authz), "bearer ") {
@@ -258,11 +289,14 @@ func (a *AdminServer) AdminJobDetailsHandler() http.HandlerFunc {
 				return
 			}
 			token := strings.TrimSpace(authz[len("Bearer "):])
-			if _, err := a.sessionManager.ValidateJWT(token); err != nil {
+			sub, err := a.sessionManager.ValidateJWT(token)
+			if err != nil {
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
+			user = sub
 		}
+		r = r.WithContext(context.WithValue(r.Context(), adminUserKey{}, user))
 
 		// Get and validate job ID from URL path
 		jobID := SanitizeJobID(chi.URLParam(r, "id"))
@@ -286,6 +320,9 @@ func (a *AdminServer) AdminJobDetailsHandler() http.HandlerFunc {
 
 		// Get job details from the main server
 		jobDetails := a.server.getJobDetails(ctx, jobID)
+		if _, ok := jobDetails["result"]; ok {
+			a.server.recordResultAccess(r, accessAdminJobDetail, jobID)
+		}
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -168,7 +168,7 @@ func TestAdminJobsHandler_Pagination(t *testing.T) {
 	}{
 		{"1", "5", 5},
 		{"2", "10", 10},
-		{"", "", 10}, // default values
+		{"", "", 10}, // default values use keyset pagination
 	}
 
 	for _, tc := range testCases {
@@ -189,7 +189,11 @@ func TestAdminJobsHandler_Pagination(t *testing.T) {
 
 		pagination, ok := response["pagination"].(map[string]interface{})
 		require.True(t, ok)
-		require.Contains(t, pagination, "page")
+		if tc.page != "" {
+			require.Contains(t, pagination, "page")
+		} else {
+			require.Contains(t, pagination, "has_more")
+		}
 		require.Contains(t, pagination, "limit")
 	}
 }
@@ -214,6 +218,8 @@ func createMockJobRepoForAdmin(t *testing.T) *mocks.MockJobRepository {
 	mockRepo.EXPECT().CountByStatus(mock.Anything, mock.Anything).Return(int64(0), nil).Maybe()
 	mockRepo.EXPECT().ListWithFilters(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]domain.Job{}, nil).Maybe()
 	mockRepo.EXPECT().CountWithFilters(mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil).Maybe()
+	mockRepo.EXPECT().ListByCursor(mock.Anything, mock.Anything).Return([]domain.Job{}, nil).Maybe()
+	mockRepo.EXPECT().CountByFilter(mock.Anything, mock.Anything).Return(int64(0), nil).Maybe()
 	mockRepo.EXPECT().GetAverageProcessingTime(mock.Anything).Return(float64(0), nil).Maybe()
 	return mockRepo
 }
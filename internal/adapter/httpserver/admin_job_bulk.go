@@ -0,0 +1,154 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// JobBulkOperator applies admin actions to every job matching a filter in
+// the background. It is implemented by usecase.JobBulkService.
+type JobBulkOperator interface {
+	Start(ctx context.Context, action domain.JobBulkAction, f domain.JobBulkFilter, actor, reason string, dryRun bool) (domain.JobBulkOperation, error)
+	Get(ctx context.Context, id string) (domain.JobBulkOperation, error)
+	List(ctx context.Context, limit int) ([]domain.JobBulkOperation, error)
+}
+
+type jobBulkFilterView struct {
+	Status        string `json:"status"`
+	OlderThan     string `json:"older_than,omitempty"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+type jobBulkOperationView struct {
+	ID         string            `json:"id,omitempty"`
+	Action     string            `json:"action"`
+	Filter     jobBulkFilterView `json:"filter"`
+	Reason     string            `json:"reason,omitempty"`
+	StartedBy  string            `json:"started_by,omitempty"`
+	Status     string            `json:"status"`
+	Matched    int               `json:"matched"`
+	Truncated  bool              `json:"truncated"`
+	Processed  int               `json:"processed"`
+	Succeeded  int               `json:"succeeded"`
+	Skipped    int               `json:"skipped"`
+	Failed     int               `json:"failed"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"`
+}
+
+func toJobBulkOperationView(op domain.JobBulkOperation) jobBulkOperationView {
+	f := jobBulkFilterView{Status: string(op.Filter.Status), FailureReason: op.Filter.FailureReason}
+	if op.Filter.OlderThan > 0 {
+		f.OlderThan = op.Filter.OlderThan.String()
+	}
+	return jobBulkOperationView{
+		ID: op.ID, Action: string(op.Action), Filter: f, Reason: op.Reason, StartedBy: op.StartedBy,
+		Status: op.Status, Matched: op.Matched, Truncated: op.Truncated,
+		Processed: op.Processed, Succeeded: op.Succeeded, Skipped: op.Skipped, Failed: op.Failed,
+		CreatedAt: op.CreatedAt, UpdatedAt: op.UpdatedAt, FinishedAt: op.FinishedAt,
+	}
+}
+
+// AdminStartJobBulkHandler applies requeue, cancel or fail to every job
+// matching the filter of the body, e.g. requeueing the jobs that failed on a
+// provider rate limit or failing jobs stuck in processing, and answers with
+// the operation to poll. With "dry_run": true it only reports how many jobs
+// match.
+func (a *AdminServer) AdminStartJobBulkHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminStartJobBulkHandler")
+		defer span.End()
+		var req struct {
+			Action string            `json:"action"`
+			Filter jobBulkFilterView `json:"filter"`
+			Reason string            `json:"reason"`
+			DryRun bool              `json:"dry_run"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		f := domain.JobBulkFilter{Status: domain.JobStatus(req.Filter.Status), FailureReason: req.Filter.FailureReason}
+		if req.Filter.OlderThan != "" {
+			d, err := time.ParseDuration(req.Filter.OlderThan)
+			if err != nil {
+				writeError(w, r, fmt.Errorf("%w: older_than must be a duration such as 30m", domain.ErrInvalidArgument), map[string]string{"older_than": "duration"})
+				return
+			}
+			f.OlderThan = d
+		}
+		span.SetAttributes(
+			attribute.String("job_bulk.action", req.Action),
+			attribute.String("job_bulk.status", req.Filter.Status),
+			attribute.Bool("job_bulk.dry_run", req.DryRun),
+		)
+		op, err := a.server.JobBulk.Start(ctx, domain.JobBulkAction(req.Action), f, adminUser(r), req.Reason, req.DryRun)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		status := http.StatusAccepted
+		if req.DryRun {
+			status = http.StatusOK
+		}
+		writeJSON(w, status, toJobBulkOperationView(op))
+	}
+}
+
+// AdminJobBulkOperationsHandler lists the latest bulk operations, newest
+// first; limit caps their number.
+func (a *AdminServer) AdminJobBulkOperationsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminJobBulkOperationsHandler")
+		defer span.End()
+		limit := 0
+		if raw := r.URL.Query().Get("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				writeError(w, r, fmt.Errorf("%w: limit must be a number", domain.ErrInvalidArgument), map[string]string{"limit": "number"})
+				return
+			}
+			limit = n
+		}
+		ops, err := a.server.JobBulk.List(ctx, limit)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		out := make([]jobBulkOperationView, 0, len(ops))
+		for _, op := range ops {
+			out = append(out, toJobBulkOperationView(op))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"operations": out})
+	}
+}
+
+// AdminJobBulkOperationHandler reports the progress of one bulk operation.
+func (a *AdminServer) AdminJobBulkOperationHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminJobBulkOperationHandler")
+		defer span.End()
+		id := chi.URLParam(r, "id")
+		span.SetAttributes(attribute.String("job_bulk.id", id))
+		op, err := a.server.JobBulk.Get(ctx, id)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, toJobBulkOperationView(op))
+	}
+}
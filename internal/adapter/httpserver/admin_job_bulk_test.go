@@ -0,0 +1,104 @@
+package httpserver_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// stubJobBulkOperator records started operations; only processing jobs can
+// be failed.
+type stubJobBulkOperator struct {
+	action domain.JobBulkAction
+	filter domain.JobBulkFilter
+	actor  string
+}
+
+func (s *stubJobBulkOperator) Start(_ context.Context, action domain.JobBulkAction, f domain.JobBulkFilter, actor, reason string, dryRun bool) (domain.JobBulkOperation, error) {
+	if f.Status != domain.JobProcessing {
+		return domain.JobBulkOperation{}, fmt.Errorf("%w: fail applies to queued or processing jobs", domain.ErrInvalidArgument)
+	}
+	s.action, s.filter, s.actor = action, f, actor
+	op := domain.JobBulkOperation{Action: action, Filter: f, Reason: reason, StartedBy: actor, Status: domain.JobBulkRunning, Matched: 4}
+	if !dryRun {
+		op.ID = "op-1"
+	}
+	return op, nil
+}
+
+func (s *stubJobBulkOperator) Get(_ context.Context, id string) (domain.JobBulkOperation, error) {
+	if id != "op-1" {
+		return domain.JobBulkOperation{}, domain.ErrNotFound
+	}
+	done := time.Now()
+	return domain.JobBulkOperation{ID: id, Action: s.action, Filter: s.filter, Status: domain.JobBulkCompleted, Matched: 4, Processed: 4, Succeeded: 3, Skipped: 1, FinishedAt: &done}, nil
+}
+
+func (s *stubJobBulkOperator) List(context.Context, int) ([]domain.JobBulkOperation, error) {
+	return []domain.JobBulkOperation{{ID: "op-1", Action: s.action, Filter: s.filter, Status: domain.JobBulkRunning}}, nil
+}
+
+func Test_Admin_JobBulk(t *testing.T) {
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	bulk := &stubJobBulkOperator{}
+	srv.JobBulk = bulk
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Post("/admin/api/v1/jobs/bulk", admin.AdminBearerRequired(admin.AdminStartJobBulkHandler()))
+	r.Get("/admin/api/v1/jobs/bulk", admin.AdminBearerRequired(admin.AdminJobBulkOperationsHandler()))
+	r.Get("/admin/api/v1/jobs/bulk/{id}", admin.AdminBearerRequired(admin.AdminJobBulkOperationHandler()))
+	token := loginAndGetToken(t, r)
+
+	body := `{"action":"fail","filter":{"status":"processing","older_than":"30m"},"reason":"stuck workers"}`
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/bulk", body)
+	if rw.Code != http.StatusAccepted || !strings.Contains(rw.Body.String(), `"id":"op-1"`) || !strings.Contains(rw.Body.String(), `"older_than":"30m0s"`) {
+		t.Fatalf("start status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	if bulk.action != domain.JobBulkFail || bulk.filter.OlderThan != 30*time.Minute || bulk.actor != "admin" {
+		t.Fatalf("started %+v by %q", bulk, bulk.actor)
+	}
+	dry := `{"action":"fail","filter":{"status":"processing"},"dry_run":true}`
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/bulk", dry); rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"matched":4`) {
+		t.Fatalf("dry run status = %d body=%s", rw.Code, rw.Body.String())
+	}
+
+	rw = doAdminJSON(r, token, http.MethodGet, "/admin/api/v1/jobs/bulk/op-1", "")
+	if rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"status":"completed"`) || !strings.Contains(rw.Body.String(), `"succeeded":3`) {
+		t.Fatalf("progress status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	if rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/v1/jobs/bulk", ""); rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"operations":[`) {
+		t.Fatalf("list status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	if rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/v1/jobs/bulk/op-2", ""); rw.Code != http.StatusNotFound {
+		t.Fatalf("unknown operation status = %d", rw.Code)
+	}
+
+	for _, bad := range []string{
+		`{"action":"fail","filter":{"status":"completed"}}`,
+		`{"action":"fail","filter":{"status":"processing","older_than":"half an hour"}}`,
+		`{`,
+	} {
+		if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/bulk", bad); rw.Code != http.StatusBadRequest {
+			t.Fatalf("%s status = %d", bad, rw.Code)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// JobBumper moves queued jobs ahead of the backlog. It is implemented by
+// usecase.JobQueueService.
+type JobBumper interface {
+	Bump(ctx context.Context, jobID, actor, reason string) (domain.JobBump, error)
+}
+
+// AdminBumpJobHandler moves a queued job ahead of every job that was not
+// bumped earlier, e.g. for an urgent support request. The optional reason is
+// recorded with the admin's name.
+func (a *AdminServer) AdminBumpJobHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminBumpJobHandler")
+		defer span.End()
+		id := chi.URLParam(r, "id")
+		span.SetAttributes(attribute.String("job.id", id))
+		var req struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		b, err := a.server.JobQueue.Bump(ctx, id, adminUser(r), req.Reason)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusAccepted, map[string]any{
+			"id":        id,
+			"status":    string(domain.JobQueued),
+			"bumped_by": b.BumpedBy,
+			"reason":    b.Reason,
+			"bumped_at": b.BumpedAt,
+		})
+	}
+}
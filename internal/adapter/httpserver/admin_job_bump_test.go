@@ -0,0 +1,62 @@
+package httpserver_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// stubBumper bumps job-1 only; other jobs are not queued.
+type stubBumper struct{ got domain.JobBump }
+
+func (s *stubBumper) Bump(_ context.Context, jobID, actor, reason string) (domain.JobBump, error) {
+	if jobID != "job-1" {
+		return domain.JobBump{}, fmt.Errorf("%w: only queued jobs can be bumped", domain.ErrConflict)
+	}
+	s.got = domain.JobBump{JobID: jobID, BumpedBy: actor, Reason: reason, BumpedAt: time.Now()}
+	return s.got, nil
+}
+
+func Test_Admin_BumpJob(t *testing.T) {
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	bumper := &stubBumper{}
+	srv.JobQueue = bumper
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Post("/admin/api/v1/jobs/{id}/bump", admin.AdminBearerRequired(admin.AdminBumpJobHandler()))
+	token := loginAndGetToken(t, r)
+
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/job-1/bump", `{"reason":"customer escalation"}`)
+	if rw.Code != http.StatusAccepted || !strings.Contains(rw.Body.String(), `"bumped_by":"admin"`) {
+		t.Fatalf("bump status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	if bumper.got.Reason != "customer escalation" {
+		t.Fatalf("reason = %q", bumper.got.Reason)
+	}
+
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/job-2/bump", ""); rw.Code != http.StatusConflict {
+		t.Fatalf("bump of a running job status = %d", rw.Code)
+	}
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/job-1/bump", "{"); rw.Code != http.StatusBadRequest {
+		t.Fatalf("invalid body status = %d", rw.Code)
+	}
+}
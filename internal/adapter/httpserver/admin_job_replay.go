@@ -0,0 +1,100 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// JobReplayer re-runs past jobs through the current evaluation pipeline
+// without storing the outcome. It is implemented by usecase.JobReplayService.
+type JobReplayer interface {
+	Replay(ctx context.Context, jobID string) (domain.JobReplay, error)
+}
+
+type replayResultView struct {
+	CVMatchRate     *float64                 `json:"cv_match_rate,omitempty"`
+	CVFeedback      string                   `json:"cv_feedback,omitempty"`
+	ProjectScore    *float64                 `json:"project_score,omitempty"`
+	ProjectFeedback string                   `json:"project_feedback,omitempty"`
+	OverallSummary  string                   `json:"overall_summary"`
+	Encryption      *domain.ResultEncryption `json:"encryption,omitempty"`
+	Provenance      []domain.StepProvenance  `json:"provenance,omitempty"`
+	CreatedAt       time.Time                `json:"created_at"`
+}
+
+type jobReplayView struct {
+	ID         string            `json:"id"`
+	DurationMS int64             `json:"duration_ms"`
+	Result     replayResultView  `json:"result"`
+	Stored     *replayResultView `json:"stored,omitempty"`
+	// The changes from the stored result are set when the job has one.
+	Scores            map[string]scoreChangeView      `json:"scores,omitempty"`
+	Feedback          map[string][]sentenceChangeView `json:"feedback,omitempty"`
+	FeedbackEncrypted bool                            `json:"feedback_encrypted,omitempty"`
+}
+
+// AdminReplayJobHandler re-runs a past job on its stored uploads and task
+// through the current pipeline in sandbox mode and returns the would-be
+// result next to the stored one. Nothing is stored and the job is unchanged.
+func (a *AdminServer) AdminReplayJobHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminReplayJobHandler")
+		defer span.End()
+		id := chi.URLParam(r, "id")
+		span.SetAttributes(attribute.String("job.id", id))
+		// A replay takes as long as an evaluation; the request timeout, not
+		// the server's write timeout, bounds it.
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+		rep, err := a.server.Replays.Replay(ctx, id)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		v := jobReplayView{ID: id, DurationMS: rep.Duration.Milliseconds(), Result: newReplayResultView(rep.Result)}
+		if rep.Stored != nil {
+			stored := newReplayResultView(*rep.Stored)
+			v.Stored = &stored
+		}
+		if d := rep.Diff; d != nil {
+			v.Scores = map[string]scoreChangeView{
+				"cv_match_rate": newScoreChangeView(d.CVMatchRate),
+				"project_score": newScoreChangeView(d.ProjectScore),
+			}
+			v.Feedback = map[string][]sentenceChangeView{
+				"cv_feedback":      newSentenceChangeViews(d.CVFeedback),
+				"project_feedback": newSentenceChangeViews(d.ProjectFeedback),
+				"overall_summary":  newSentenceChangeViews(d.OverallSummary),
+			}
+			v.FeedbackEncrypted = d.FeedbackEncrypted
+		}
+		writeJSON(w, http.StatusOK, v)
+	}
+}
+
+func newReplayResultView(res domain.Result) replayResultView {
+	v := replayResultView{
+		CVFeedback:      res.CVFeedback,
+		ProjectFeedback: res.ProjectFeedback,
+		OverallSummary:  res.OverallSummary,
+		Encryption:      res.Encryption,
+		Provenance:      res.Provenance,
+		CreatedAt:       res.CreatedAt,
+	}
+	if !res.ProjectOnly {
+		rate := res.CVMatchRate
+		v.CVMatchRate = &rate
+	}
+	if !res.CVOnly {
+		score := res.ProjectScore
+		v.ProjectScore = &score
+	}
+	return v
+}
@@ -0,0 +1,72 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type stubJobReplayer struct{}
+
+func (stubJobReplayer) Replay(_ context.Context, id string) (domain.JobReplay, error) {
+	if id != "job-1" {
+		return domain.JobReplay{}, fmt.Errorf("%w: job is still queued", domain.ErrConflict)
+	}
+	from, to := 0.5, 0.8
+	return domain.JobReplay{
+		JobID:    id,
+		Result:   domain.Result{JobID: id, CVMatchRate: to, CVFeedback: "Strong Go skills.", CVOnly: true, OverallSummary: "Hire."},
+		Stored:   &domain.Result{JobID: id, CVMatchRate: from, CVFeedback: "Weak Go skills.", CVOnly: true, OverallSummary: "Hire."},
+		Diff:     &domain.ResultDiff{JobID: id, CVMatchRate: domain.ScoreChange{From: &from, To: &to, Delta: 0.3}},
+		Duration: 1500 * time.Millisecond,
+	}, nil
+}
+
+func Test_Admin_ReplayJob(t *testing.T) {
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.Replays = stubJobReplayer{}
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Post("/admin/api/v1/jobs/{id}/replay", admin.AdminBearerRequired(admin.AdminReplayJobHandler()))
+
+	assert.Equal(t, http.StatusUnauthorized, doAdminJSON(r, "", http.MethodPost, "/admin/api/v1/jobs/job-1/replay", "").Code)
+
+	token := loginAndGetToken(t, r)
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/job-1/replay", "")
+	require.Equal(t, http.StatusOK, rw.Code)
+	var body struct {
+		ID         string                    `json:"id"`
+		DurationMS int64                     `json:"duration_ms"`
+		Result     map[string]any            `json:"result"`
+		Stored     map[string]any            `json:"stored"`
+		Scores     map[string]map[string]any `json:"scores"`
+	}
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(&body))
+	assert.Equal(t, "job-1", body.ID)
+	assert.Equal(t, int64(1500), body.DurationMS)
+	assert.Equal(t, 0.8, body.Result["cv_match_rate"])
+	assert.NotContains(t, body.Result, "project_score")
+	assert.Equal(t, "Weak Go skills.", body.Stored["cv_feedback"])
+	assert.Equal(t, 0.3, body.Scores["cv_match_rate"]["delta"])
+
+	assert.Equal(t, http.StatusConflict, doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/job-2/replay", "").Code)
+}
@@ -0,0 +1,47 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// AdminRetryJobHandler requeues a failed job with every AI call pinned to the
+// provider and model of the request body, to debug model-specific failures.
+func (a *AdminServer) AdminRetryJobHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminRetryJobHandler")
+		defer span.End()
+		id := chi.URLParam(r, "id")
+		var req struct {
+			Provider string `json:"provider"`
+			Model    string `json:"model"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		span.SetAttributes(
+			attribute.String("job.id", id),
+			attribute.String("ai.provider", req.Provider),
+			attribute.String("ai.model", req.Model),
+		)
+		if err := a.server.Evaluate.RetryPinned(ctx, id, req.Provider, req.Model); err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusAccepted, map[string]string{
+			"id":       id,
+			"status":   string(domain.JobQueued),
+			"provider": req.Provider,
+			"model":    req.Model,
+		})
+	}
+}
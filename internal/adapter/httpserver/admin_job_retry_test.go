@@ -0,0 +1,56 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func Test_Admin_RetryJob(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	outbox := mocks.NewMockOutboxRepository(t)
+	eval := usecase.NewEvaluateService(jobs, nil, nil)
+	eval.Outbox = usecase.NewOutboxService(outbox, nil, 0, 0)
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), eval, usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Post("/admin/api/v1/jobs/{id}/retry", admin.AdminBearerRequired(admin.AdminRetryJobHandler()))
+	token := loginAndGetToken(t, r)
+
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/job-1/retry", `{"provider":"nope","model":"m"}`); rw.Code != http.StatusBadRequest {
+		t.Fatalf("invalid provider status = %d", rw.Code)
+	}
+
+	jobs.EXPECT().Get(mock.Anything, "job-1").Return(domain.Job{ID: "job-1", Status: domain.JobFailed}, nil).Once()
+	jobs.EXPECT().Task(mock.Anything, "job-1").Return(domain.EvaluateTaskPayload{JobID: "job-1"}, nil).Once()
+	outbox.EXPECT().Requeue(mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.Overrides.Provider == "openrouter" && p.Overrides.Model == "qwen/qwen3-8b:free"
+	})).Return(nil).Once()
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/job-1/retry", `{"provider":"openrouter","model":"qwen/qwen3-8b:free"}`)
+	if rw.Code != http.StatusAccepted || !strings.Contains(rw.Body.String(), `"status":"queued"`) {
+		t.Fatalf("retry status = %d body=%s", rw.Code, rw.Body.String())
+	}
+
+	jobs.EXPECT().Get(mock.Anything, "job-2").Return(domain.Job{ID: "job-2", Status: domain.JobProcessing}, nil).Once()
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/v1/jobs/job-2/retry", `{"provider":"groq","model":"m"}`); rw.Code != http.StatusConflict {
+		t.Fatalf("retry of a running job status = %d", rw.Code)
+	}
+}
@@ -0,0 +1,62 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// JobUsageReporter reports how long completed jobs spent in each phase. It
+// is implemented by usecase.JobUsageService.
+type JobUsageReporter interface {
+	Report(ctx context.Context, window time.Duration) (domain.JobUsageReport, error)
+}
+
+type jobUsagePhaseView struct {
+	Phase string  `json:"phase"`
+	AvgMS float64 `json:"avg_ms"`
+	P50MS float64 `json:"p50_ms"`
+	P90MS float64 `json:"p90_ms"`
+	P99MS float64 `json:"p99_ms"`
+}
+
+// AdminJobUsageHandler reports the average and percentile wall-clock time
+// the jobs completed in the last window spent queued, extracting text,
+// retrieving RAG context, waiting on AI providers, post-processing and in
+// total. window is a duration such as 24h.
+func (a *AdminServer) AdminJobUsageHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminJobUsageHandler")
+		defer span.End()
+		var window time.Duration
+		if raw := r.URL.Query().Get("window"); raw != "" {
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				writeError(w, r, fmt.Errorf("%w: window must be a duration such as 24h", domain.ErrInvalidArgument), map[string]string{"window": "duration"})
+				return
+			}
+			window = d
+		}
+		rep, err := a.server.JobUsage.Report(ctx, window)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		phases := make([]jobUsagePhaseView, 0, len(rep.Phases))
+		for _, p := range rep.Phases {
+			phases = append(phases, jobUsagePhaseView{Phase: p.Phase, AvgMS: p.AvgMS, P50MS: p.P50MS, P90MS: p.P90MS, P99MS: p.P99MS})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"since":  rep.Since,
+			"until":  rep.Until,
+			"jobs":   rep.Jobs,
+			"phases": phases,
+		})
+	}
+}
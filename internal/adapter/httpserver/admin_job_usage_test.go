@@ -0,0 +1,66 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type stubJobUsageReporter struct{ window time.Duration }
+
+func (s *stubJobUsageReporter) Report(_ context.Context, window time.Duration) (domain.JobUsageReport, error) {
+	s.window = window
+	until := time.Date(2026, 1, 6, 9, 0, 0, 0, time.UTC)
+	return domain.JobUsageReport{Since: until.Add(-window), Until: until, Jobs: 40, Phases: []domain.JobUsagePercentiles{
+		{Phase: domain.UsagePhaseAI, AvgMS: 8200, P50MS: 7000, P90MS: 15000, P99MS: 31000},
+		{Phase: domain.UsagePhaseLocal, AvgMS: 450, P50MS: 400, P90MS: 700, P99MS: 1200},
+	}}, nil
+}
+
+func Test_Admin_JobUsage(t *testing.T) {
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	rep := &stubJobUsageReporter{}
+	srv.JobUsage = rep
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/analytics/job-usage", admin.AdminBearerRequired(admin.AdminJobUsageHandler()))
+
+	assert.Equal(t, http.StatusUnauthorized, doAdminJSON(r, "", http.MethodGet, "/admin/api/analytics/job-usage", "").Code)
+
+	token := loginAndGetToken(t, r)
+	rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/analytics/job-usage?window=6h", "")
+	require.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, 6*time.Hour, rep.window)
+	var body struct {
+		Jobs   int64 `json:"jobs"`
+		Phases []struct {
+			Phase string  `json:"phase"`
+			P90MS float64 `json:"p90_ms"`
+		} `json:"phases"`
+	}
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(&body))
+	assert.Equal(t, int64(40), body.Jobs)
+	require.Len(t, body.Phases, 2)
+	assert.Equal(t, "ai", body.Phases[0].Phase)
+	assert.Equal(t, 15000.0, body.Phases[0].P90MS)
+
+	assert.Equal(t, http.StatusBadRequest, doAdminJSON(r, token, http.MethodGet, "/admin/api/analytics/job-usage?window=today", "").Code)
+}
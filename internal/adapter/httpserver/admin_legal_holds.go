@@ -0,0 +1,104 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// LegalHoldManager places and releases legal holds and reports their audit
+// trail. It is implemented by usecase.LegalHoldService.
+type LegalHoldManager interface {
+	Set(ctx context.Context, targetType, targetID string, held bool, actor, reason string) (domain.LegalHold, error)
+	History(ctx context.Context, targetType, targetID string) ([]domain.LegalHold, error)
+	ListHeld(ctx context.Context) ([]domain.LegalHold, error)
+}
+
+type legalHoldView struct {
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	Held       bool      `json:"held"`
+	Actor      string    `json:"actor"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func toLegalHoldViews(holds []domain.LegalHold) []legalHoldView {
+	out := make([]legalHoldView, 0, len(holds))
+	for _, h := range holds {
+		out = append(out, legalHoldView(h))
+	}
+	return out
+}
+
+// AdminLegalHoldsHandler lists the legal holds in place with who placed them,
+// when and why.
+func (a *AdminServer) AdminLegalHoldsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminLegalHoldsHandler")
+		defer span.End()
+		holds, err := a.server.LegalHolds.ListHeld(ctx)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"legal_holds": toLegalHoldViews(holds)})
+	}
+}
+
+// AdminLegalHoldHistoryHandler returns every change of the hold on an upload
+// or job, newest first.
+func (a *AdminServer) AdminLegalHoldHistoryHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminLegalHoldHistoryHandler")
+		defer span.End()
+		typ, id := chi.URLParam(r, "type"), chi.URLParam(r, "id")
+		span.SetAttributes(attribute.String("legal_hold.target_type", typ), attribute.String("legal_hold.target_id", id))
+		events, err := a.server.LegalHolds.History(ctx, typ, id)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"events": toLegalHoldViews(events)})
+	}
+}
+
+// AdminSetLegalHoldHandler places ("held": true) or releases the hold on an
+// upload or job. The reason is required and recorded with the admin's name.
+func (a *AdminServer) AdminSetLegalHoldHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminSetLegalHoldHandler")
+		defer span.End()
+		typ, id := chi.URLParam(r, "type"), chi.URLParam(r, "id")
+		span.SetAttributes(attribute.String("legal_hold.target_type", typ), attribute.String("legal_hold.target_id", id))
+		var req struct {
+			Held   *bool  `json:"held"`
+			Reason string `json:"reason"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 8192)).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		if req.Held == nil {
+			writeError(w, r, fmt.Errorf("%w: held is required", domain.ErrInvalidArgument), map[string]string{"held": "required"})
+			return
+		}
+		h, err := a.server.LegalHolds.Set(ctx, typ, id, *req.Held, adminUser(r), req.Reason)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, legalHoldView(h))
+	}
+}
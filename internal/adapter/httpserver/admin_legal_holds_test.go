@@ -0,0 +1,74 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func Test_Admin_LegalHolds(t *testing.T) {
+	repo := mocks.NewMockLegalHoldRepository(t)
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.EvaluateService{}, usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.LegalHolds = usecase.NewLegalHoldService(repo)
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/legal-holds", admin.AdminBearerRequired(admin.AdminLegalHoldsHandler()))
+	r.Get("/admin/api/legal-holds/{type}/{id}", admin.AdminBearerRequired(admin.AdminLegalHoldHistoryHandler()))
+	r.Put("/admin/api/legal-holds/{type}/{id}", admin.AdminBearerRequired(admin.AdminSetLegalHoldHandler()))
+	token := loginAndGetToken(t, r)
+
+	if rw := doAdminJSON(r, token, http.MethodPut, "/admin/api/legal-holds/job/j1", `{"reason":"audit"}`); rw.Code != http.StatusBadRequest {
+		t.Fatalf("missing held status = %d", rw.Code)
+	}
+	if rw := doAdminJSON(r, token, http.MethodPut, "/admin/api/legal-holds/job/j1", `{"held":true}`); rw.Code != http.StatusBadRequest {
+		t.Fatalf("missing reason status = %d", rw.Code)
+	}
+
+	repo.EXPECT().Set(mock.Anything, mock.MatchedBy(func(h domain.LegalHold) bool {
+		return h.TargetType == "upload" && h.TargetID == "u1" && h.Held && h.Actor == "admin" && h.Reason == "litigation 42"
+	})).Return(nil).Once()
+	rw := doAdminJSON(r, token, http.MethodPut, "/admin/api/legal-holds/upload/u1", `{"held":true,"reason":"litigation 42"}`)
+	if rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"actor":"admin"`) {
+		t.Fatalf("set status = %d body=%s", rw.Code, rw.Body.String())
+	}
+
+	repo.EXPECT().Set(mock.Anything, mock.Anything).Return(domain.ErrNotFound).Once()
+	if rw := doAdminJSON(r, token, http.MethodPut, "/admin/api/legal-holds/job/gone", `{"held":false,"reason":"closed"}`); rw.Code != http.StatusNotFound {
+		t.Fatalf("missing target status = %d", rw.Code)
+	}
+
+	at := time.Date(2025, 12, 30, 9, 0, 0, 0, time.UTC)
+	repo.EXPECT().ListHeld(mock.Anything).Return([]domain.LegalHold{{TargetType: "upload", TargetID: "u1", Held: true, Actor: "admin", Reason: "litigation 42", CreatedAt: at}}, nil).Once()
+	rw = doAdminJSON(r, token, http.MethodGet, "/admin/api/legal-holds", "")
+	if rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"target_id":"u1"`) || !strings.Contains(rw.Body.String(), `"reason":"litigation 42"`) {
+		t.Fatalf("list status = %d body=%s", rw.Code, rw.Body.String())
+	}
+
+	repo.EXPECT().History(mock.Anything, "upload", "u1").Return(nil, nil).Once()
+	rw = doAdminJSON(r, token, http.MethodGet, "/admin/api/legal-holds/upload/u1", "")
+	if rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"events":[]`) {
+		t.Fatalf("history status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	if rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/legal-holds/result/r1", ""); rw.Code != http.StatusBadRequest {
+		t.Fatalf("bad type status = %d", rw.Code)
+	}
+}
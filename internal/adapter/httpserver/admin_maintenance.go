@@ -0,0 +1,81 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// MaintenanceController reads and changes the maintenance mode.
+// It is implemented by usecase.MaintenanceService.
+type MaintenanceController interface {
+	State() domain.MaintenanceState
+	Set(ctx context.Context, s domain.MaintenanceState) (domain.MaintenanceState, int, error)
+}
+
+type maintenanceView struct {
+	Mode              string    `json:"mode"`
+	RetryAfterSeconds int       `json:"retry_after_seconds"`
+	Message           string    `json:"message"`
+	UpdatedAt         time.Time `json:"updated_at,omitempty"`
+	// Released is the number of deferred evaluations queued by this change.
+	Released *int `json:"released,omitempty"`
+}
+
+func toMaintenanceView(s domain.MaintenanceState) maintenanceView {
+	return maintenanceView{
+		Mode:              string(s.Mode),
+		RetryAfterSeconds: int(s.RetryAfter / time.Second),
+		Message:           s.Message,
+		UpdatedAt:         s.UpdatedAt,
+	}
+}
+
+// AdminMaintenanceHandler returns the current maintenance mode.
+func (a *AdminServer) AdminMaintenanceHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		_, span := tracer.Start(r.Context(), "AdminServer.AdminMaintenanceHandler")
+		defer span.End()
+		writeJSON(w, http.StatusOK, toMaintenanceView(a.server.Maintenance.State()))
+	}
+}
+
+// AdminSetMaintenanceHandler switches maintenance mode. Turning it off
+// queues the evaluations deferred in the meantime.
+func (a *AdminServer) AdminSetMaintenanceHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminSetMaintenanceHandler")
+		defer span.End()
+		var req struct {
+			Mode              string `json:"mode"`
+			RetryAfterSeconds int    `json:"retry_after_seconds"`
+			Message           string `json:"message"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		span.SetAttributes(attribute.String("maintenance.mode", req.Mode))
+		st, released, err := a.server.Maintenance.Set(ctx, domain.MaintenanceState{
+			Mode:       domain.MaintenanceMode(req.Mode),
+			RetryAfter: time.Duration(req.RetryAfterSeconds) * time.Second,
+			Message:    req.Message,
+		})
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		v := toMaintenanceView(st)
+		v.Released = &released
+		writeJSON(w, http.StatusOK, v)
+	}
+}
@@ -0,0 +1,85 @@
+package httpserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func Test_Admin_Maintenance_RejectsEvaluations(t *testing.T) {
+	repo := mocks.NewMockMaintenanceRepository(t)
+	maintenance := usecase.NewMaintenanceService(repo, nil, 2*time.Minute)
+	eval := usecase.NewEvaluateService(&mocks.MockJobRepository{}, &mocks.MockQueue{}, nil)
+	eval.Maintenance = maintenance
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), eval, usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.Maintenance = maintenance
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/maintenance", admin.AdminBearerRequired(admin.AdminMaintenanceHandler()))
+	r.Post("/admin/api/maintenance", admin.AdminBearerRequired(admin.AdminSetMaintenanceHandler()))
+	r.Post("/v1/evaluate", srv.EvaluateHandler())
+	token := loginAndGetToken(t, r)
+
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/maintenance", `{"mode":"paused"}`); rw.Code != http.StatusBadRequest {
+		t.Fatalf("invalid mode status = %d", rw.Code)
+	}
+
+	repo.EXPECT().Set(mock.Anything, mock.Anything).Return(nil).Once()
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/maintenance", `{"mode":"reject","retry_after_seconds":90,"message":"upgrading workers"}`)
+	if rw.Code != http.StatusOK {
+		t.Fatalf("set status = %d body=%s", rw.Code, rw.Body.String())
+	}
+
+	rw = doAdminJSON(r, token, http.MethodGet, "/admin/api/maintenance", "")
+	var body struct {
+		Mode              string `json:"mode"`
+		RetryAfterSeconds int    `json:"retry_after_seconds"`
+	}
+	if err := json.Unmarshal(rw.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Mode != "reject" || body.RetryAfterSeconds != 90 {
+		t.Fatalf("unexpected body: %s", rw.Body.String())
+	}
+
+	rw = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/evaluate", strings.NewReader(`{"cv_id":"cv-1","project_id":"pr-1"}`))
+	r.ServeHTTP(rw, req)
+	if rw.Code != http.StatusServiceUnavailable || rw.Header().Get("Retry-After") != "90" {
+		t.Fatalf("evaluate status = %d retry-after=%q", rw.Code, rw.Header().Get("Retry-After"))
+	}
+	if !strings.Contains(rw.Body.String(), `"MAINTENANCE"`) || !strings.Contains(rw.Body.String(), "upgrading workers") {
+		t.Fatalf("unexpected error body: %s", rw.Body.String())
+	}
+
+	repo.EXPECT().Set(mock.Anything, mock.Anything).Return(nil).Once()
+	repo.EXPECT().ReleaseDeferred(mock.Anything, mock.Anything).Return(0, nil).Once()
+	rw = doAdminJSON(r, token, http.MethodPost, "/admin/api/maintenance", `{"mode":"off"}`)
+	if rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"released":0`) {
+		t.Fatalf("off status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	if maintenance.State().Mode != domain.MaintenanceOff {
+		t.Fatalf("maintenance still on")
+	}
+}
@@ -0,0 +1,161 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// PromptExperimenter manages prompt A/B experiments and their reports.
+// It is implemented by usecase.PromptExperimentService.
+type PromptExperimenter interface {
+	Create(ctx context.Context, e domain.PromptExperiment) (domain.PromptExperiment, error)
+	List(ctx context.Context) ([]domain.PromptExperiment, error)
+	End(ctx context.Context, id string) error
+	Report(ctx context.Context, id string) (domain.PromptExperimentReport, error)
+}
+
+type promptExperimentView struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Step      string     `json:"step"`
+	VersionA  string     `json:"version_a"`
+	VersionB  string     `json:"version_b"`
+	TrafficB  int        `json:"traffic_b"`
+	Active    bool       `json:"active"`
+	CreatedAt time.Time  `json:"created_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+func toPromptExperimentView(e domain.PromptExperiment) promptExperimentView {
+	return promptExperimentView{
+		ID:        e.ID,
+		Name:      e.Name,
+		Step:      e.Step,
+		VersionA:  e.VersionA,
+		VersionB:  e.VersionB,
+		TrafficB:  e.TrafficB,
+		Active:    e.Active(),
+		CreatedAt: e.CreatedAt,
+		EndedAt:   e.EndedAt,
+	}
+}
+
+type promptVariantView struct {
+	Variant          string   `json:"variant"`
+	Version          string   `json:"version"`
+	Jobs             int      `json:"jobs"`
+	Completed        int      `json:"completed"`
+	Failed           int      `json:"failed"`
+	ParseFailureRate float64  `json:"parse_failure_rate"`
+	ParseFailures    int      `json:"parse_failures"`
+	AvgCVMatchRate   *float64 `json:"avg_cv_match_rate"`
+	StdCVMatchRate   *float64 `json:"stddev_cv_match_rate"`
+	AvgProjectScore  *float64 `json:"avg_project_score"`
+	StdProjectScore  *float64 `json:"stddev_project_score"`
+	AvgLatencyMS     *float64 `json:"avg_latency_ms"`
+	P50LatencyMS     *float64 `json:"p50_latency_ms"`
+	P95LatencyMS     *float64 `json:"p95_latency_ms"`
+}
+
+// AdminPromptExperimentsHandler lists the prompt experiments.
+func (a *AdminServer) AdminPromptExperimentsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminPromptExperimentsHandler")
+		defer span.End()
+		exps, err := a.server.Experiments.List(ctx)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		views := make([]promptExperimentView, 0, len(exps))
+		for _, e := range exps {
+			views = append(views, toPromptExperimentView(e))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"experiments": views})
+	}
+}
+
+// AdminCreatePromptExperimentHandler starts a prompt experiment that sends
+// traffic_b percent of jobs to version_b of the step's prompt.
+func (a *AdminServer) AdminCreatePromptExperimentHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminCreatePromptExperimentHandler")
+		defer span.End()
+		var req struct {
+			Name     string `json:"name"`
+			Step     string `json:"step"`
+			VersionA string `json:"version_a"`
+			VersionB string `json:"version_b"`
+			TrafficB int    `json:"traffic_b"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16<<10)).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		e, err := a.server.Experiments.Create(ctx, domain.PromptExperiment{
+			Name:     req.Name,
+			Step:     req.Step,
+			VersionA: req.VersionA,
+			VersionB: req.VersionB,
+			TrafficB: req.TrafficB,
+		})
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusCreated, toPromptExperimentView(e))
+	}
+}
+
+// AdminEndPromptExperimentHandler ends a prompt experiment; later jobs use
+// the default prompts.
+func (a *AdminServer) AdminEndPromptExperimentHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminEndPromptExperimentHandler")
+		defer span.End()
+		id := chi.URLParam(r, "id")
+		span.SetAttributes(attribute.String("experiment.id", id))
+		if err := a.server.Experiments.End(ctx, id); err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// AdminPromptExperimentReportHandler compares the variants of a prompt
+// experiment.
+func (a *AdminServer) AdminPromptExperimentReportHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminPromptExperimentReportHandler")
+		defer span.End()
+		id := chi.URLParam(r, "id")
+		span.SetAttributes(attribute.String("experiment.id", id))
+		rep, err := a.server.Experiments.Report(ctx, id)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		variants := make([]promptVariantView, 0, len(rep.Variants))
+		for _, v := range rep.Variants {
+			variants = append(variants, promptVariantView(v))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"experiment": toPromptExperimentView(rep.Experiment),
+			"variants":   variants,
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func Test_Admin_PromptExperiments(t *testing.T) {
+	repo := mocks.NewMockPromptExperimentRepository(t)
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.Experiments = usecase.NewPromptExperimentService(repo, func(step, version string) bool {
+		return step == "cv_match" && (version == "1" || version == "2")
+	})
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/experiments", admin.AdminBearerRequired(admin.AdminPromptExperimentsHandler()))
+	r.Post("/admin/api/experiments", admin.AdminBearerRequired(admin.AdminCreatePromptExperimentHandler()))
+	r.Post("/admin/api/experiments/{id}/end", admin.AdminBearerRequired(admin.AdminEndPromptExperimentHandler()))
+	r.Get("/admin/api/experiments/{id}/report", admin.AdminBearerRequired(admin.AdminPromptExperimentReportHandler()))
+	token := loginAndGetToken(t, r)
+
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/experiments", `{"name":"x","step":"cv_match","version_a":"1","version_b":"9"}`); rw.Code != http.StatusBadRequest {
+		t.Fatalf("unknown version status = %d", rw.Code)
+	}
+
+	repo.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ domain.Context, e domain.PromptExperiment) (domain.PromptExperiment, error) {
+		e.ID = "e1"
+		return e, nil
+	}).Once()
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/experiments", `{"name":"evidence","step":"cv_match","version_a":"1","version_b":"2","traffic_b":20}`)
+	if rw.Code != http.StatusCreated || !strings.Contains(rw.Body.String(), `"id":"e1"`) || !strings.Contains(rw.Body.String(), `"active":true`) {
+		t.Fatalf("create status = %d body=%s", rw.Code, rw.Body.String())
+	}
+
+	repo.EXPECT().Create(mock.Anything, mock.Anything).Return(domain.PromptExperiment{}, domain.ErrConflict).Once()
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/experiments", `{"name":"again","step":"cv_match","version_a":"1","version_b":"2"}`); rw.Code != http.StatusConflict {
+		t.Fatalf("duplicate status = %d", rw.Code)
+	}
+
+	repo.EXPECT().List(mock.Anything).Return([]domain.PromptExperiment{{ID: "e1", Name: "evidence"}}, nil).Once()
+	if rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/experiments", ""); rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"name":"evidence"`) {
+		t.Fatalf("list status = %d body=%s", rw.Code, rw.Body.String())
+	}
+
+	score := 0.8
+	repo.EXPECT().Get(mock.Anything, "e1").Return(domain.PromptExperiment{ID: "e1", Step: "cv_match", VersionA: "1", VersionB: "2"}, nil).Once()
+	repo.EXPECT().Stats(mock.Anything, "e1").Return([]domain.PromptVariantStats{{Variant: "a", Jobs: 2, AvgCVMatchRate: &score}}, nil).Once()
+	rw = doAdminJSON(r, token, http.MethodGet, "/admin/api/experiments/e1/report", "")
+	if rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"avg_cv_match_rate":0.8`) || !strings.Contains(rw.Body.String(), `"version":"2"`) {
+		t.Fatalf("report status = %d body=%s", rw.Code, rw.Body.String())
+	}
+
+	repo.EXPECT().End(mock.Anything, "e1", mock.Anything).Return(nil).Once()
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/experiments/e1/end", ""); rw.Code != http.StatusNoContent {
+		t.Fatalf("end status = %d", rw.Code)
+	}
+	repo.EXPECT().End(mock.Anything, "e1", mock.Anything).Return(domain.ErrNotFound).Once()
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/experiments/e1/end", ""); rw.Code != http.StatusNotFound {
+		t.Fatalf("end again status = %d", rw.Code)
+	}
+}
@@ -0,0 +1,83 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ProviderErrorReporter reports the most frequent fingerprints of failed AI
+// provider calls. It is implemented by usecase.ProviderErrorService.
+type ProviderErrorReporter interface {
+	Report(ctx context.Context, window, bucket time.Duration, limit int) (domain.ProviderErrorReport, error)
+}
+
+type providerErrorTrendView struct {
+	Fingerprint string    `json:"fingerprint"`
+	Provider    string    `json:"provider"`
+	Count       int64     `json:"count"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+	LastModel   string    `json:"last_model,omitempty"`
+	LastMessage string    `json:"last_message,omitempty"`
+	Series      []int64   `json:"series"`
+}
+
+// AdminProviderErrorsHandler lists the provider error fingerprints (model
+// not found, content filter, context length exceeded, quota, ...) that
+// occurred most often in the last window, each with its occurrences per
+// bucket. window and bucket are durations such as 24h and 1h; limit caps the
+// fingerprints listed.
+func (a *AdminServer) AdminProviderErrorsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminProviderErrorsHandler")
+		defer span.End()
+		q := r.URL.Query()
+		var window, bucket time.Duration
+		for name, dst := range map[string]*time.Duration{"window": &window, "bucket": &bucket} {
+			if raw := q.Get(name); raw != "" {
+				d, err := time.ParseDuration(raw)
+				if err != nil {
+					writeError(w, r, fmt.Errorf("%w: %s must be a duration such as 1h", domain.ErrInvalidArgument, name), map[string]string{name: "duration"})
+					return
+				}
+				*dst = d
+			}
+		}
+		limit := 0
+		if raw := q.Get("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				writeError(w, r, fmt.Errorf("%w: limit must be a number", domain.ErrInvalidArgument), map[string]string{"limit": "number"})
+				return
+			}
+			limit = n
+		}
+		rep, err := a.server.ProviderErrors.Report(ctx, window, bucket, limit)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		top := make([]providerErrorTrendView, 0, len(rep.Top))
+		for _, t := range rep.Top {
+			top = append(top, providerErrorTrendView{
+				Fingerprint: string(t.Fingerprint), Provider: t.Provider, Count: t.Count,
+				FirstSeen: t.FirstSeen, LastSeen: t.LastSeen, LastModel: t.Model, LastMessage: t.Message,
+				Series: t.Series,
+			})
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"since":  rep.Since,
+			"until":  rep.Until,
+			"bucket": rep.Bucket.String(),
+			"top":    top,
+		})
+	}
+}
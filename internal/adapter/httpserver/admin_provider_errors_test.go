@@ -0,0 +1,77 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type stubProviderErrorReporter struct {
+	window, bucket time.Duration
+	limit          int
+}
+
+func (s *stubProviderErrorReporter) Report(_ context.Context, window, bucket time.Duration, limit int) (domain.ProviderErrorReport, error) {
+	s.window, s.bucket, s.limit = window, bucket, limit
+	since := time.Date(2026, 1, 4, 6, 0, 0, 0, time.UTC)
+	return domain.ProviderErrorReport{Since: since, Until: since.Add(2 * time.Hour), Bucket: time.Hour, Top: []domain.ProviderErrorTrend{{
+		ProviderErrorCount: domain.ProviderErrorCount{
+			Fingerprint: domain.ProviderErrorContextLength, Provider: "openrouter", Count: 3,
+			FirstSeen: since, LastSeen: since.Add(90 * time.Minute), Model: "qwen/qwen3-8b:free", Message: "maximum context length is 8192 tokens",
+		},
+		Series: []int64{1, 2},
+	}}}, nil
+}
+
+func Test_Admin_ProviderErrors(t *testing.T) {
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	rep := &stubProviderErrorReporter{}
+	srv.ProviderErrors = rep
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/ai/errors", admin.AdminBearerRequired(admin.AdminProviderErrorsHandler()))
+
+	assert.Equal(t, http.StatusUnauthorized, doAdminJSON(r, "", http.MethodGet, "/admin/api/ai/errors", "").Code)
+
+	token := loginAndGetToken(t, r)
+	rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/ai/errors?window=2h&bucket=1h&limit=5", "")
+	require.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, 2*time.Hour, rep.window)
+	assert.Equal(t, time.Hour, rep.bucket)
+	assert.Equal(t, 5, rep.limit)
+	var body struct {
+		Bucket string `json:"bucket"`
+		Top    []struct {
+			Fingerprint string  `json:"fingerprint"`
+			Count       int64   `json:"count"`
+			LastModel   string  `json:"last_model"`
+			Series      []int64 `json:"series"`
+		} `json:"top"`
+	}
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(&body))
+	assert.Equal(t, "1h0m0s", body.Bucket)
+	require.Len(t, body.Top, 1)
+	assert.Equal(t, "context_length_exceeded", body.Top[0].Fingerprint)
+	assert.Equal(t, "qwen/qwen3-8b:free", body.Top[0].LastModel)
+	assert.Equal(t, []int64{1, 2}, body.Top[0].Series)
+
+	assert.Equal(t, http.StatusBadRequest, doAdminJSON(r, token, http.MethodGet, "/admin/api/ai/errors?window=yesterday", "").Code)
+}
@@ -0,0 +1,132 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ProviderKeyManager lists and changes AI provider keys at runtime.
+// It is implemented by ai.KeyRing.
+type ProviderKeyManager interface {
+	List() []domain.ProviderKey
+	Usage(id string) domain.KeyUsage
+	Add(ctx context.Context, k domain.ProviderKey) (domain.ProviderKey, error)
+	Update(ctx context.Context, id string, weight *int, state *domain.ProviderKeyState) (domain.ProviderKey, error)
+}
+
+// providerKeyView is the admin API representation of a key; the secret is
+// never returned, only its last four characters.
+type providerKeyView struct {
+	ID        string    `json:"id"`
+	Provider  string    `json:"provider"`
+	Hint      string    `json:"hint"`
+	Weight    int       `json:"weight"`
+	State     string    `json:"state"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Usage on the current UTC day, counted against the daily budget
+	RequestsToday int64 `json:"requests_today"`
+	TokensToday   int64 `json:"tokens_today"`
+}
+
+func toProviderKeyView(k domain.ProviderKey, u domain.KeyUsage) providerKeyView {
+	hint := "****"
+	if len(k.Secret) > 8 {
+		hint = "****" + k.Secret[len(k.Secret)-4:]
+	}
+	return providerKeyView{
+		ID:            k.ID,
+		Provider:      k.Provider,
+		Hint:          hint,
+		Weight:        k.Weight,
+		State:         string(k.State),
+		UpdatedAt:     k.UpdatedAt,
+		RequestsToday: u.Requests,
+		TokensToday:   u.Tokens,
+	}
+}
+
+// AdminProviderKeysHandler lists AI provider keys with masked secrets.
+func (a *AdminServer) AdminProviderKeysHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		_, span := tracer.Start(r.Context(), "AdminServer.AdminProviderKeysHandler")
+		defer span.End()
+		keys := a.server.ProviderKeys.List()
+		out := make([]providerKeyView, 0, len(keys))
+		for _, k := range keys {
+			out = append(out, toProviderKeyView(k, a.server.ProviderKeys.Usage(k.ID)))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"keys": out})
+	}
+}
+
+// AdminAddProviderKeyHandler adds an AI provider key to rotation.
+func (a *AdminServer) AdminAddProviderKeyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminAddProviderKeyHandler")
+		defer span.End()
+		var req struct {
+			Provider string `json:"provider"`
+			Secret   string `json:"secret"`
+			Weight   int    `json:"weight"`
+			State    string `json:"state"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		span.SetAttributes(attribute.String("ai.provider", req.Provider))
+		k, err := a.server.ProviderKeys.Add(ctx, domain.ProviderKey{
+			Provider: req.Provider,
+			Secret:   req.Secret,
+			Weight:   req.Weight,
+			State:    domain.ProviderKeyState(req.State),
+		})
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusCreated, toProviderKeyView(k, domain.KeyUsage{}))
+	}
+}
+
+// AdminUpdateProviderKeyHandler changes the weight and/or state of a key,
+// e.g. to drain or disable it during rotation.
+func (a *AdminServer) AdminUpdateProviderKeyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminUpdateProviderKeyHandler")
+		defer span.End()
+		id := chi.URLParam(r, "id")
+		span.SetAttributes(attribute.String("ai.key_id", id))
+		var req struct {
+			Weight *int    `json:"weight"`
+			State  *string `json:"state"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		var state *domain.ProviderKeyState
+		if req.State != nil {
+			s := domain.ProviderKeyState(*req.State)
+			state = &s
+		}
+		k, err := a.server.ProviderKeys.Update(ctx, id, req.Weight, state)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, toProviderKeyView(k, a.server.ProviderKeys.Usage(k.ID)))
+	}
+}
@@ -0,0 +1,98 @@
+package httpserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func newProviderKeysRouter(t *testing.T) (*chi.Mux, string) {
+	t.Helper()
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.ProviderKeys = ai.NewKeyRing([]domain.ProviderKey{{Provider: ai.ProviderGroq, Secret: "gsk_primary_1234"}})
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/ai/keys", admin.AdminBearerRequired(admin.AdminProviderKeysHandler()))
+	r.Post("/admin/api/ai/keys", admin.AdminBearerRequired(admin.AdminAddProviderKeyHandler()))
+	r.Patch("/admin/api/ai/keys/{id}", admin.AdminBearerRequired(admin.AdminUpdateProviderKeyHandler()))
+	return r, loginAndGetToken(t, r)
+}
+
+func doAdminJSON(r *chi.Mux, token, method, path, body string) *httptest.ResponseRecorder {
+	rw := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(rw, req)
+	return rw
+}
+
+func Test_Admin_ProviderKeys_Lifecycle(t *testing.T) {
+	r, token := newProviderKeysRouter(t)
+
+	rw := doAdminJSON(r, "bad", http.MethodGet, "/admin/api/ai/keys", "")
+	if rw.Code != http.StatusUnauthorized {
+		t.Fatalf("unauthenticated list status = %d", rw.Code)
+	}
+
+	rw = doAdminJSON(r, token, http.MethodGet, "/admin/api/ai/keys", "")
+	if rw.Code != http.StatusOK {
+		t.Fatalf("list status = %d", rw.Code)
+	}
+	if strings.Contains(rw.Body.String(), "gsk_primary") || !strings.Contains(rw.Body.String(), "****1234") {
+		t.Fatalf("secret not masked: %s", rw.Body.String())
+	}
+
+	rw = doAdminJSON(r, token, http.MethodPost, "/admin/api/ai/keys", `{"provider":"groq","secret":"gsk_secondary_5678","weight":2}`)
+	if rw.Code != http.StatusCreated {
+		t.Fatalf("add status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	var added struct {
+		ID     string `json:"id"`
+		Weight int    `json:"weight"`
+		State  string `json:"state"`
+	}
+	if err := json.Unmarshal(rw.Body.Bytes(), &added); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if added.ID != ai.KeyID("gsk_secondary_5678") || added.Weight != 2 || added.State != "active" {
+		t.Fatalf("unexpected added key: %+v", added)
+	}
+
+	rw = doAdminJSON(r, token, http.MethodPost, "/admin/api/ai/keys", `{"provider":"groq","secret":"gsk_secondary_5678"}`)
+	if rw.Code != http.StatusConflict {
+		t.Fatalf("duplicate add status = %d", rw.Code)
+	}
+
+	rw = doAdminJSON(r, token, http.MethodPatch, "/admin/api/ai/keys/"+ai.KeyID("gsk_primary_1234"), `{"state":"draining"}`)
+	if rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"state":"draining"`) {
+		t.Fatalf("patch status = %d body=%s", rw.Code, rw.Body.String())
+	}
+
+	rw = doAdminJSON(r, token, http.MethodPatch, "/admin/api/ai/keys/"+added.ID, `{"state":"paused"}`)
+	if rw.Code != http.StatusBadRequest {
+		t.Fatalf("invalid state status = %d", rw.Code)
+	}
+	rw = doAdminJSON(r, token, http.MethodPatch, "/admin/api/ai/keys/k_unknown", `{"weight":3}`)
+	if rw.Code != http.StatusNotFound {
+		t.Fatalf("unknown key status = %d", rw.Code)
+	}
+}
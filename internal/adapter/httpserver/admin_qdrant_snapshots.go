@@ -0,0 +1,113 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// SnapshotManager snapshots and restores the RAG collections. It is
+// implemented by app.QdrantSnapshotService.
+type SnapshotManager interface {
+	Create(ctx context.Context, collections []string) ([]domain.VectorSnapshot, error)
+	List(ctx context.Context, collections []string) ([]domain.VectorSnapshot, error)
+	Restore(ctx context.Context, collection, name string, fromStorage bool) error
+}
+
+type snapshotView struct {
+	Collection string    `json:"collection"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+	Size       int64     `json:"size"`
+	StorageKey string    `json:"storage_key,omitempty"`
+}
+
+func toSnapshotViews(snaps []domain.VectorSnapshot) []snapshotView {
+	out := make([]snapshotView, 0, len(snaps))
+	for _, s := range snaps {
+		out = append(out, snapshotView{Collection: s.Collection, Name: s.Name, CreatedAt: s.CreatedAt, Size: s.Size, StorageKey: s.StorageKey})
+	}
+	return out
+}
+
+// AdminSnapshotsHandler lists the Qdrant snapshots of the RAG collections,
+// optionally of the one named by the "collection" query parameter.
+func (a *AdminServer) AdminSnapshotsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminSnapshotsHandler")
+		defer span.End()
+		var collections []string
+		if c := r.URL.Query().Get("collection"); c != "" {
+			collections = []string{c}
+		}
+		snaps, err := a.server.Snapshots.List(ctx, collections)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"snapshots": toSnapshotViews(snaps)})
+	}
+}
+
+// AdminCreateSnapshotsHandler snapshots the collections listed in the body,
+// or every snapshotted collection when the list is empty.
+func (a *AdminServer) AdminCreateSnapshotsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminCreateSnapshotsHandler")
+		defer span.End()
+		var req struct {
+			Collections []string `json:"collections"`
+		}
+		if r.ContentLength != 0 {
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+				writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+				return
+			}
+		}
+		snaps, err := a.server.Snapshots.Create(ctx, req.Collections)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusCreated, map[string]any{"snapshots": toSnapshotViews(snaps)})
+	}
+}
+
+// AdminRestoreSnapshotHandler replaces a collection with one of its
+// snapshots, read from Qdrant or, with "source": "storage", from object
+// storage.
+func (a *AdminServer) AdminRestoreSnapshotHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminRestoreSnapshotHandler")
+		defer span.End()
+		var req struct {
+			Collection string `json:"collection"`
+			Snapshot   string `json:"snapshot"`
+			Source     string `json:"source"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		if req.Source != "" && req.Source != "qdrant" && req.Source != "storage" {
+			writeError(w, r, fmt.Errorf("%w: source must be qdrant or storage", domain.ErrInvalidArgument), map[string]string{"source": "oneof"})
+			return
+		}
+		span.SetAttributes(attribute.String("qdrant.collection", req.Collection), attribute.String("qdrant.snapshot", req.Snapshot))
+		if err := a.server.Snapshots.Restore(ctx, req.Collection, req.Snapshot, req.Source == "storage"); err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"collection": req.Collection, "snapshot": req.Snapshot, "status": "restored"})
+	}
+}
@@ -0,0 +1,106 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type fakeSnapshots struct {
+	restored []string
+}
+
+func (f *fakeSnapshots) Create(_ context.Context, collections []string) ([]domain.VectorSnapshot, error) {
+	if len(collections) == 0 {
+		collections = []string{"job_description"}
+	}
+	var out []domain.VectorSnapshot
+	for _, c := range collections {
+		if c != "job_description" {
+			return nil, fmt.Errorf("%w: collection %q", domain.ErrInvalidArgument, c)
+		}
+		out = append(out, domain.VectorSnapshot{Collection: c, Name: c + "-1.snapshot", CreatedAt: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), StorageKey: "qdrant/" + c + "/" + c + "-1.snapshot"})
+	}
+	return out, nil
+}
+
+func (f *fakeSnapshots) List(ctx context.Context, collections []string) ([]domain.VectorSnapshot, error) {
+	return f.Create(ctx, collections)
+}
+
+func (f *fakeSnapshots) Restore(_ context.Context, collection, name string, fromStorage bool) error {
+	if name == "missing" {
+		return fmt.Errorf("%w: snapshot %s", domain.ErrNotFound, name)
+	}
+	f.restored = append(f.restored, fmt.Sprintf("%s/%s/%t", collection, name, fromStorage))
+	return nil
+}
+
+func Test_Admin_QdrantSnapshots(t *testing.T) {
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.EvaluateService{}, usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	snaps := &fakeSnapshots{}
+	srv.Snapshots = snaps
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/qdrant/snapshots", admin.AdminBearerRequired(admin.AdminSnapshotsHandler()))
+	r.Post("/admin/api/qdrant/snapshots", admin.AdminBearerRequired(admin.AdminCreateSnapshotsHandler()))
+	r.Post("/admin/api/qdrant/snapshots/restore", admin.AdminBearerRequired(admin.AdminRestoreSnapshotHandler()))
+	token := loginAndGetToken(t, r)
+
+	rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/qdrant/snapshots", "")
+	if rw.Code != http.StatusCreated {
+		t.Fatalf("create status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	var body struct {
+		Snapshots []struct {
+			Collection string `json:"collection"`
+			Name       string `json:"name"`
+			StorageKey string `json:"storage_key"`
+		} `json:"snapshots"`
+	}
+	if err := json.Unmarshal(rw.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(body.Snapshots) != 1 || body.Snapshots[0].Name != "job_description-1.snapshot" || body.Snapshots[0].StorageKey == "" {
+		t.Fatalf("unexpected body: %s", rw.Body.String())
+	}
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/qdrant/snapshots", `{"collections":["users"]}`); rw.Code != http.StatusBadRequest {
+		t.Fatalf("unknown collection status = %d", rw.Code)
+	}
+	if rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/qdrant/snapshots?collection=job_description", ""); rw.Code != http.StatusOK {
+		t.Fatalf("list status = %d", rw.Code)
+	}
+
+	rw = doAdminJSON(r, token, http.MethodPost, "/admin/api/qdrant/snapshots/restore", `{"collection":"job_description","snapshot":"job_description-1.snapshot","source":"storage"}`)
+	if rw.Code != http.StatusOK {
+		t.Fatalf("restore status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	if len(snaps.restored) != 1 || snaps.restored[0] != "job_description/job_description-1.snapshot/true" {
+		t.Fatalf("restored = %v", snaps.restored)
+	}
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/qdrant/snapshots/restore", `{"collection":"job_description","snapshot":"x","source":"s3"}`); rw.Code != http.StatusBadRequest {
+		t.Fatalf("invalid source status = %d", rw.Code)
+	}
+	if rw := doAdminJSON(r, token, http.MethodPost, "/admin/api/qdrant/snapshots/restore", `{"collection":"job_description","snapshot":"missing"}`); rw.Code != http.StatusNotFound {
+		t.Fatalf("missing snapshot status = %d", rw.Code)
+	}
+}
@@ -0,0 +1,147 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/envelope"
+)
+
+// TenantManager lists and changes per-tenant evaluation settings.
+// It is implemented by usecase.TenantService.
+type TenantManager interface {
+	List(ctx context.Context) ([]domain.TenantSettings, error)
+	Set(ctx context.Context, s domain.TenantSettings, apiKey string) (domain.TenantSettings, error)
+	Delete(ctx context.Context, tenantID string) error
+}
+
+// tenantView never exposes the API key or its hash.
+type tenantView struct {
+	TenantID         string                 `json:"tenant_id"`
+	PreferredModels  []string               `json:"preferred_models"`
+	MaxTokens        int                    `json:"max_tokens"`
+	Anonymize        bool                   `json:"anonymize"`
+	Sampling         *domain.SamplingParams `json:"sampling,omitempty"`
+	RubricTemplate   string                 `json:"rubric_template"`
+	DailyEvaluations int                    `json:"daily_evaluations"`
+	DailyTokens      int64                  `json:"daily_tokens"`
+	ResultPublicKey  string                 `json:"result_public_key,omitempty"`
+	ResultKeyID      string                 `json:"result_key_id,omitempty"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
+
+func toTenantView(s domain.TenantSettings) tenantView {
+	models := s.Overrides.PreferredModels
+	if models == nil {
+		models = []string{}
+	}
+	v := tenantView{
+		TenantID:         s.TenantID,
+		PreferredModels:  models,
+		MaxTokens:        s.Overrides.MaxTokens,
+		Anonymize:        s.Overrides.Anonymize,
+		Sampling:         s.Overrides.Sampling,
+		RubricTemplate:   s.RubricTemplate,
+		DailyEvaluations: s.DailyEvaluations,
+		DailyTokens:      s.DailyTokens,
+		ResultPublicKey:  s.ResultPublicKey,
+		UpdatedAt:        s.UpdatedAt,
+	}
+	if s.ResultPublicKey != "" {
+		if _, id, err := envelope.ParsePublicKey(s.ResultPublicKey); err == nil {
+			v.ResultKeyID = id
+		}
+	}
+	return v
+}
+
+// AdminTenantsHandler lists the tenants and their evaluation settings.
+func (a *AdminServer) AdminTenantsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminTenantsHandler")
+		defer span.End()
+		tenants, err := a.server.Tenants.List(ctx)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		views := make([]tenantView, 0, len(tenants))
+		for _, t := range tenants {
+			views = append(views, toTenantView(t))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"tenants": views})
+	}
+}
+
+// AdminSetTenantHandler creates or replaces the settings of a tenant. The
+// api_key is required when creating a tenant and rotates it when given for
+// an existing one. A result_public_key encrypts the tenant's future result
+// feedback; results stored earlier keep their encryption.
+func (a *AdminServer) AdminSetTenantHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminSetTenantHandler")
+		defer span.End()
+		id := chi.URLParam(r, "id")
+		span.SetAttributes(attribute.String("tenant.id", id))
+		var req struct {
+			APIKey           string                 `json:"api_key"`
+			PreferredModels  []string               `json:"preferred_models"`
+			MaxTokens        int                    `json:"max_tokens"`
+			Anonymize        bool                   `json:"anonymize"`
+			Sampling         *domain.SamplingParams `json:"sampling"`
+			RubricTemplate   string                 `json:"rubric_template"`
+			DailyEvaluations int                    `json:"daily_evaluations"`
+			DailyTokens      int64                  `json:"daily_tokens"`
+			ResultPublicKey  string                 `json:"result_public_key"`
+		}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64<<10)).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: invalid JSON body", domain.ErrInvalidArgument), nil)
+			return
+		}
+		s, err := a.server.Tenants.Set(ctx, domain.TenantSettings{
+			TenantID: id,
+			Overrides: domain.EvaluationOverrides{
+				PreferredModels: req.PreferredModels,
+				MaxTokens:       req.MaxTokens,
+				Anonymize:       req.Anonymize,
+				Sampling:        req.Sampling,
+			},
+			RubricTemplate:   req.RubricTemplate,
+			DailyEvaluations: req.DailyEvaluations,
+			DailyTokens:      req.DailyTokens,
+			ResultPublicKey:  req.ResultPublicKey,
+		}, req.APIKey)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, toTenantView(s))
+	}
+}
+
+// AdminDeleteTenantHandler removes a tenant; its requests fall back to the
+// defaults.
+func (a *AdminServer) AdminDeleteTenantHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminDeleteTenantHandler")
+		defer span.End()
+		id := chi.URLParam(r, "id")
+		span.SetAttributes(attribute.String("tenant.id", id))
+		if err := a.server.Tenants.Delete(ctx, id); err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,69 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func Test_Admin_Tenants(t *testing.T) {
+	repo := mocks.NewMockTenantSettingsRepository(t)
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.Tenants = usecase.NewTenantService(repo)
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	if err != nil {
+		t.Fatalf("new admin: %v", err)
+	}
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/tenants", admin.AdminBearerRequired(admin.AdminTenantsHandler()))
+	r.Put("/admin/api/tenants/{id}", admin.AdminBearerRequired(admin.AdminSetTenantHandler()))
+	r.Delete("/admin/api/tenants/{id}", admin.AdminBearerRequired(admin.AdminDeleteTenantHandler()))
+	token := loginAndGetToken(t, r)
+
+	if rw := doAdminJSON(r, token, http.MethodPut, "/admin/api/tenants/acme", `{"max_tokens":-5,"api_key":"k1"}`); rw.Code != http.StatusBadRequest {
+		t.Fatalf("invalid settings status = %d", rw.Code)
+	}
+	if rw := doAdminJSON(r, token, http.MethodPut, "/admin/api/tenants/acme", `{"api_key":"k1","result_public_key":"not a key"}`); rw.Code != http.StatusBadRequest {
+		t.Fatalf("invalid result key status = %d", rw.Code)
+	}
+
+	repo.EXPECT().GetByAPIKeyHash(mock.Anything, usecase.HashAPIKey("k1")).Return(domain.TenantSettings{}, domain.ErrNotFound).Once()
+	repo.EXPECT().Upsert(mock.Anything, mock.Anything).Return(nil).Once()
+	rw := doAdminJSON(r, token, http.MethodPut, "/admin/api/tenants/acme", `{"api_key":"k1","preferred_models":["m1"],"max_tokens":800,"anonymize":true,"daily_evaluations":50}`)
+	if rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"max_tokens":800`) || !strings.Contains(rw.Body.String(), `"daily_evaluations":50`) {
+		t.Fatalf("set status = %d body=%s", rw.Code, rw.Body.String())
+	}
+	if strings.Contains(rw.Body.String(), "k1") || strings.Contains(rw.Body.String(), usecase.HashAPIKey("k1")) {
+		t.Fatalf("response leaks the api key: %s", rw.Body.String())
+	}
+
+	repo.EXPECT().List(mock.Anything).Return([]domain.TenantSettings{{TenantID: "acme", APIKeyHash: "h"}}, nil).Once()
+	rw = doAdminJSON(r, token, http.MethodGet, "/admin/api/tenants", "")
+	if rw.Code != http.StatusOK || !strings.Contains(rw.Body.String(), `"tenant_id":"acme"`) || strings.Contains(rw.Body.String(), `"h"`) {
+		t.Fatalf("list status = %d body=%s", rw.Code, rw.Body.String())
+	}
+
+	repo.EXPECT().Delete(mock.Anything, "acme").Return(nil).Once()
+	if rw := doAdminJSON(r, token, http.MethodDelete, "/admin/api/tenants/acme", ""); rw.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d", rw.Code)
+	}
+	repo.EXPECT().Delete(mock.Anything, "gone").Return(domain.ErrNotFound).Once()
+	if rw := doAdminJSON(r, token, http.MethodDelete, "/admin/api/tenants/gone", ""); rw.Code != http.StatusNotFound {
+		t.Fatalf("delete missing status = %d", rw.Code)
+	}
+}
@@ -0,0 +1,32 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// UsageStatsCompiler compiles anonymous usage stats.
+// It is implemented by usecase.UsageStatsService.
+type UsageStatsCompiler interface {
+	Compile(ctx context.Context) (domain.UsageStats, error)
+}
+
+// AdminUsageStatsHandler returns the anonymous usage stats of the deployment:
+// jobs per day, mean latency, failure ratio and model mix.
+func (a *AdminServer) AdminUsageStatsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.admin")
+		ctx, span := tracer.Start(r.Context(), "AdminServer.AdminUsageStatsHandler")
+		defer span.End()
+		st, err := a.server.UsageStats.Compile(ctx)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, st)
+	}
+}
@@ -0,0 +1,51 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type stubUsageStats struct {
+	stats domain.UsageStats
+	err   error
+}
+
+func (s stubUsageStats) Compile(context.Context) (domain.UsageStats, error) { return s.stats, s.err }
+
+func Test_Admin_UsageStats(t *testing.T) {
+	srv := httpserver.NewServer(config.Config{
+		AppEnv:             "dev",
+		AdminUsername:      "admin",
+		AdminPassword:      "secret",
+		AdminSessionSecret: "abcd",
+	}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.UsageStats = stubUsageStats{stats: domain.UsageStats{Jobs: 14, JobsPerDay: 2, Completed: 12, Failed: 2, FailureRatio: 0.14, ModelMix: map[string]int64{"groq/llama-3.1-8b-instant": 30}}}
+	admin, err := httpserver.NewAdminServer(srv.Cfg, srv)
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Post("/admin/token", admin.AdminTokenHandler())
+	r.Get("/admin/api/v1/stats", admin.AdminBearerRequired(admin.AdminUsageStatsHandler()))
+
+	assert.Equal(t, http.StatusUnauthorized, doAdminJSON(r, "", http.MethodGet, "/admin/api/v1/stats", "").Code)
+
+	token := loginAndGetToken(t, r)
+	rw := doAdminJSON(r, token, http.MethodGet, "/admin/api/v1/stats", "")
+	require.Equal(t, http.StatusOK, rw.Code)
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(&body))
+	assert.Equal(t, 14.0, body["jobs"])
+	assert.Equal(t, 0.14, body["failure_ratio"])
+	assert.Equal(t, map[string]any{"groq/llama-3.1-8b-instant": 30.0}, body["model_mix"])
+	assert.NotContains(t, body, "deployment_id")
+}
@@ -0,0 +1,47 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type staticAISaturation []domain.AISaturation
+
+func (s staticAISaturation) Report(domain.Context, domain.AISaturation) error { return nil }
+
+func (s staticAISaturation) List(domain.Context, time.Time) ([]domain.AISaturation, error) {
+	return s, nil
+}
+
+func Test_Evaluate_AISaturatedReturns503WithRetryAfter(t *testing.T) {
+	until := time.Now().Add(90 * time.Second)
+	eval := usecase.NewEvaluateService(&mocks.MockJobRepository{}, &mocks.MockQueue{}, nil)
+	eval.Saturation = usecase.NewAISaturationService(staticAISaturation{{Instance: "worker-1", BlockedUntil: &until, ReportedAt: time.Now()}})
+	srv := httpserver.NewServer(config.Config{Port: 8080}, usecase.NewUploadService(nil), eval, usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	r := chi.NewRouter()
+	r.Post("/v1/evaluate", srv.EvaluateHandler())
+
+	rw := httptest.NewRecorder()
+	r.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "/v1/evaluate", strings.NewReader(`{"cv_id":"cv-1","project_id":"pr-1"}`)))
+	if rw.Code != http.StatusServiceUnavailable {
+		t.Fatalf("evaluate status = %d, want 503", rw.Code)
+	}
+	if secs, err := strconv.Atoi(rw.Header().Get("Retry-After")); err != nil || secs < 89 || secs > 90 {
+		t.Fatalf("retry-after = %q, want about 90", rw.Header().Get("Retry-After"))
+	}
+	if !strings.Contains(rw.Body.String(), `"AI_SATURATED"`) {
+		t.Fatalf("unexpected error body: %s", rw.Body.String())
+	}
+}
@@ -5,6 +5,7 @@
 package httpserver
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
@@ -341,20 +342,29 @@ func getSSOUsernameFromHeaders(r *http.Request) string {
 	return ""
 }
 
+type adminUserKey struct{}
+
+// adminUser returns the admin authenticated by AdminBearerRequired, or an
+// empty string outside of it.
+func adminUser(r *http.Request) string {
+	u, _ := r.Context().Value(adminUserKey{}).(string)
+	return u
+}
+
 // AdminBearerRequired enforces Bearer JWT auth and injects subject into context.
 func (a *AdminServer) AdminBearerRequired(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Prefer SSO via trusted reverse proxy headers
 		if ssoUser := getSSOUsernameFromHeaders(r); ssoUser != "" {
-			next(w, r)
+			next(w, r.WithContext(context.WithValue(r.Context(), adminUserKey{}, ssoUser)))
 			return
 		}
 		// Fallback to Bearer JWT
 		authz := strings.TrimSpace(r.Header.Get("Authorization"))
 		if strings.HasPrefix(strings.ToLower(authz), "bearer ") {
 			token := strings.TrimSpace(authz[len("Bearer "):])
-			if _, err := a.sessionManager.ValidateJWT(token); err == nil {
-				next(w, r)
+			if sub, err := a.sessionManager.ValidateJWT(token); err == nil {
+				next(w, r.WithContext(context.WithValue(r.Context(), adminUserKey{}, sub)))
 				return
 			}
 		}
@@ -0,0 +1,38 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func Test_Evaluate_BacklogFullReturns429(t *testing.T) {
+	jobs := &mocks.MockJobRepository{}
+	jobs.On("CountByStatus", mock.Anything, domain.JobQueued).Return(int64(40), nil).Once()
+	jobs.On("CountByStatus", mock.Anything, domain.JobProcessing).Return(int64(10), nil).Once()
+	eval := usecase.NewEvaluateService(jobs, &mocks.MockQueue{}, nil)
+	eval.Backpressure = usecase.NewBackpressureService(jobs, nil, 50, usecase.BackpressureReject, 45*time.Second)
+	srv := httpserver.NewServer(config.Config{Port: 8080}, usecase.NewUploadService(nil), eval, usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	r := chi.NewRouter()
+	r.Post("/v1/evaluate", srv.EvaluateHandler())
+
+	rw := httptest.NewRecorder()
+	r.ServeHTTP(rw, httptest.NewRequest(http.MethodPost, "/v1/evaluate", strings.NewReader(`{"cv_id":"cv-1","project_id":"pr-1"}`)))
+	if rw.Code != http.StatusTooManyRequests || rw.Header().Get("Retry-After") != "45" {
+		t.Fatalf("evaluate status = %d retry-after=%q", rw.Code, rw.Header().Get("Retry-After"))
+	}
+	if !strings.Contains(rw.Body.String(), `"BACKLOG_FULL"`) {
+		t.Fatalf("unexpected error body: %s", rw.Body.String())
+	}
+}
@@ -0,0 +1,92 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Badge colors by job status.
+var badgeColors = map[string]string{
+	string(domain.JobQueued):     "#9f9f9f",
+	string(domain.JobProcessing): "#007ec6",
+	string(domain.JobCompleted):  "#4c1",
+	string(domain.JobFailed):     "#e05d44",
+	string(domain.JobExpired):    "#9f9f9f",
+}
+
+// StatusBadgeHandler renders a job's status, and the scores of a completed
+// job, as an SVG badge for embedding in wikis and pull requests. Unknown jobs
+// get a "not found" badge with status 404.
+func (s *Server) StatusBadgeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			writeError(w, r, fmt.Errorf("%w: id missing", domain.ErrInvalidArgument), nil)
+			return
+		}
+		status, res, _, err := s.Results.Fetch(r.Context(), id, "")
+		if err != nil {
+			status, _ = errorStatus(err)
+			msg := "unavailable"
+			if errors.Is(err, domain.ErrNotFound) {
+				msg = "not found"
+			}
+			writeBadge(w, status, msg, "#9f9f9f")
+			return
+		}
+		jobStatus, _ := res["status"].(string)
+		if _, ok := res["result"]; ok && jobStatus == string(domain.JobCompleted) {
+			// The badge shows the scores.
+			s.recordResultAccess(r, accessBadge, id)
+		}
+		writeBadge(w, status, badgeMessage(jobStatus, res), badgeColors[jobStatus])
+	}
+}
+
+// badgeMessage is the status of a job or, once completed, its scores.
+func badgeMessage(status string, res map[string]any) string {
+	result, _ := res["result"].(map[string]any)
+	if status != string(domain.JobCompleted) || result == nil {
+		return status
+	}
+	var parts []string
+	if v, ok := result["cv_match_rate"].(float64); ok {
+		parts = append(parts, fmt.Sprintf("cv %.0f%%", v*100))
+	}
+	if v, ok := result["project_score"].(float64); ok {
+		parts = append(parts, fmt.Sprintf("project %.1f/10", v))
+	}
+	if len(parts) == 0 {
+		return status
+	}
+	return strings.Join(parts, " | ")
+}
+
+// writeBadge writes a flat "evaluation | message" badge. Widths are
+// estimated from the text length, which is close enough for the short
+// messages used here.
+func writeBadge(w http.ResponseWriter, status int, message, color string) {
+	if color == "" {
+		color = "#9f9f9f"
+	}
+	const label = "evaluation"
+	lw, mw := 10+7*len(label), 10+7*len(message)
+	svg := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[2]s: %[3]s">`+
+		`<title>%[2]s: %[3]s</title>`+
+		`<rect width="%[4]d" height="20" fill="#555"/><rect x="%[4]d" width="%[5]d" height="20" fill="%[6]s"/>`+
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`+
+		`<text x="%[7]d" y="14">%[2]s</text><text x="%[8]d" y="14">%[3]s</text></g></svg>`,
+		lw+mw, label, html.EscapeString(message), lw, mw, html.EscapeString(color), lw/2, lw+mw/2)
+	w.Header().Set("Content-Type", "image/svg+xml")
+	// Badges are embedded by pages that would otherwise cache a stale status.
+	w.Header().Set("Cache-Control", "no-cache, max-age=0")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(svg))
+}
@@ -0,0 +1,53 @@
+package httpserver_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	domainmocks "github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func getBadge(t *testing.T, srv *httpserver.Server, id string) (int, string) {
+	t.Helper()
+	router := chi.NewRouter()
+	router.Get("/v1/evaluate/{id}/badge.svg", srv.StatusBadgeHandler())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/evaluate/"+id+"/badge.svg", nil))
+	resp := w.Result()
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, "image/svg+xml", resp.Header.Get("Content-Type"))
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func TestStatusBadgeHandler(t *testing.T) {
+	srv := newResultServer(t, domain.Job{ID: "job1", Status: domain.JobCompleted}, domain.Result{JobID: "job1", CVMatchRate: 0.82, ProjectScore: 8.4})
+	code, svg := getBadge(t, srv, "job1")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, svg, "cv 82% | project 8.4/10")
+	require.Contains(t, svg, "#4c1")
+
+	srv = newResultServer(t, domain.Job{ID: "job2", Status: domain.JobProcessing, CreatedAt: time.Now(), UpdatedAt: time.Now()}, domain.Result{})
+	_, svg = getBadge(t, srv, "job2")
+	require.Contains(t, svg, ">processing</text>")
+}
+
+func TestStatusBadgeHandler_NotFound(t *testing.T) {
+	jobRepo := domainmocks.NewMockJobRepository(t)
+	jobRepo.EXPECT().Get(mock.Anything, "missing").Return(domain.Job{}, domain.ErrNotFound)
+	srv := httpserver.NewServer(config.Config{Port: 8080}, usecase.NewUploadService(nil), usecase.NewEvaluateService(jobRepo, nil, nil), usecase.NewResultService(jobRepo, nil), nil, nil, nil, nil)
+	code, svg := getBadge(t, srv, "missing")
+	require.Equal(t, http.StatusNotFound, code)
+	require.Contains(t, svg, "not found")
+}
@@ -0,0 +1,62 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// CandidateSummarizer generates recruiter-facing summaries of evaluation
+// results. It is implemented by usecase.CandidateSummaryService.
+type CandidateSummarizer interface {
+	Summarize(ctx context.Context, id string) (domain.CandidateSummary, bool, error)
+}
+
+type candidateSummaryView struct {
+	ID            string    `json:"id"`
+	ResultVersion string    `json:"result_version"`
+	Summary       string    `json:"summary"`
+	Strengths     []string  `json:"strengths"`
+	Risks         []string  `json:"risks"`
+	GeneratedAt   time.Time `json:"generated_at"`
+	Cached        bool      `json:"cached"`
+}
+
+// CandidateSummaryHandler generates, or returns the cached, candidate summary
+// of a completed evaluation.
+func (s *Server) CandidateSummaryHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			writeError(w, r, fmt.Errorf("%w: id missing", domain.ErrInvalidArgument), nil)
+			return
+		}
+		sum, cached, err := s.Summaries.Summarize(r.Context(), id)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		s.recordResultAccess(r, accessSummary, id)
+		v := candidateSummaryView{
+			ID:            id,
+			ResultVersion: sum.ResultVersion,
+			Summary:       sum.Summary,
+			Strengths:     sum.Strengths,
+			Risks:         sum.Risks,
+			GeneratedAt:   sum.CreatedAt,
+			Cached:        cached,
+		}
+		if v.Strengths == nil {
+			v.Strengths = []string{}
+		}
+		if v.Risks == nil {
+			v.Risks = []string{}
+		}
+		writeJSON(w, http.StatusOK, v)
+	}
+}
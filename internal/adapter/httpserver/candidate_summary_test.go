@@ -0,0 +1,62 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type stubSummarizer struct {
+	sum    domain.CandidateSummary
+	cached bool
+	err    error
+}
+
+func (s stubSummarizer) Summarize(_ context.Context, _ string) (domain.CandidateSummary, bool, error) {
+	return s.sum, s.cached, s.err
+}
+
+func serveSummary(t *testing.T, sm httpserver.CandidateSummarizer) *httptest.ResponseRecorder {
+	t.Helper()
+	srv := httpserver.NewServer(config.Config{Port: 8080}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.Summaries = sm
+	router := chi.NewRouter()
+	router.Post("/v1/results/{id}/summary", srv.CandidateSummaryHandler())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/results/job-1/summary", nil))
+	return w
+}
+
+func TestCandidateSummaryHandler(t *testing.T) {
+	at := time.Date(2025, 12, 14, 9, 0, 0, 0, time.UTC)
+	w := serveSummary(t, stubSummarizer{sum: domain.CandidateSummary{JobID: "job-1", ResultVersion: "v1", Summary: "Strong fit.", Strengths: []string{"Go"}, CreatedAt: at}, cached: true})
+	require.Equal(t, http.StatusOK, w.Code)
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, "job-1", body["id"])
+	assert.Equal(t, "v1", body["result_version"])
+	assert.Equal(t, "Strong fit.", body["summary"])
+	assert.Equal(t, []any{"Go"}, body["strengths"])
+	assert.Equal(t, []any{}, body["risks"])
+	assert.Equal(t, true, body["cached"])
+	assert.Equal(t, "2025-12-14T09:00:00Z", body["generated_at"])
+}
+
+func TestCandidateSummaryHandler_Errors(t *testing.T) {
+	assert.Equal(t, http.StatusNotFound, serveSummary(t, stubSummarizer{err: fmt.Errorf("%w: job not found", domain.ErrNotFound)}).Code)
+	assert.Equal(t, http.StatusConflict, serveSummary(t, stubSummarizer{err: fmt.Errorf("%w: not completed", domain.ErrConflict)}).Code)
+	assert.Equal(t, http.StatusServiceUnavailable, serveSummary(t, stubSummarizer{err: domain.ErrSchemaInvalid}).Code)
+}
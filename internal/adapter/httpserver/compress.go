@@ -0,0 +1,300 @@
+package httpserver
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/klauspost/compress/zstd"
+
+	adapterobs "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+)
+
+// Content codings negotiated by Compress, in order of preference when the
+// client weighs them equally.
+const (
+	encodingZstd    = "zstd"
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+var supportedEncodings = []string{encodingZstd, encodingGzip, encodingDeflate}
+
+// defaultCompressMinBytes is the smallest response compressed when
+// CompressionOptions.MinBytes is 0; smaller bodies gain little and cost a
+// frame header.
+const defaultCompressMinBytes = 1024
+
+// CompressionOptions configures Compress.
+type CompressionOptions struct {
+	// MinBytes is the smallest response body compressed; 0 means 1024.
+	MinBytes int
+	// Routes are the chi route patterns whose responses are compressed, e.g.
+	// "/v1/result/{id}". A pattern ending in "/*" matches every route below
+	// it and "*" matches all routes. Empty compresses nothing.
+	Routes []string
+}
+
+// compressesRoute reports whether responses of the route pattern are
+// compressed.
+func (o CompressionOptions) compressesRoute(route string) bool {
+	for _, p := range o.Routes {
+		p = strings.TrimSpace(p)
+		switch {
+		case p == "*" || p == route:
+			return true
+		case strings.HasSuffix(p, "/*") && strings.HasPrefix(route, strings.TrimSuffix(p, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// Compress negotiates a gzip, deflate or zstd Content-Encoding from
+// Accept-Encoding for JSON responses of the configured routes. Bodies are
+// buffered up to MinBytes to decide, so short error envelopes are sent
+// as-is. The compression ratio of every compressed response is recorded.
+func Compress(opts CompressionOptions) func(http.Handler) http.Handler {
+	if opts.MinBytes <= 0 {
+		opts.MinBytes = defaultCompressMinBytes
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressWriter{ResponseWriter: w, r: r, opts: opts, encoding: encoding, status: http.StatusOK}
+			defer cw.close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding returns the supported coding the Accept-Encoding header
+// weighs highest, or "" when the client accepts none of them.
+func negotiateEncoding(header string) string {
+	if header == "" {
+		return ""
+	}
+	weights := map[string]float64{}
+	wildcard := -1.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		for _, p := range strings.Split(params, ";") {
+			k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+			if ok && strings.EqualFold(k, "q") {
+				if f, err := strconv.ParseFloat(v, 64); err == nil {
+					q = f
+				}
+			}
+		}
+		if name == "*" {
+			wildcard = q
+			continue
+		}
+		weights[name] = q
+	}
+	best, bestQ := "", 0.0
+	for _, enc := range supportedEncodings {
+		q, ok := weights[enc]
+		if !ok {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = enc, q
+		}
+	}
+	return best
+}
+
+// encoder is a pooled compressor of one content coding.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+var encoderPools = map[string]*sync.Pool{
+	encodingGzip:    {New: func() any { return gzip.NewWriter(io.Discard) }},
+	encodingDeflate: {New: func() any { return zlib.NewWriter(io.Discard) }},
+	encodingZstd: {New: func() any {
+		// Concurrency 1 keeps the encoder synchronous; responses are small.
+		enc, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderConcurrency(1))
+		return enc
+	}},
+}
+
+// compressWriter buffers the start of a response until it can tell whether
+// to compress it, then streams through the encoder or unchanged.
+type compressWriter struct {
+	http.ResponseWriter
+	r        *http.Request
+	opts     CompressionOptions
+	encoding string
+
+	status      int
+	wroteHeader bool
+	decided     bool
+	buf         []byte
+	enc         encoder
+	counter     *countingWriter
+	raw         int
+}
+
+// countingWriter counts the bytes written to the client.
+type countingWriter struct {
+	w io.Writer
+	n int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += n
+	return n, err
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = code
+	// Bodiless and informational responses are passed through at once.
+	if code < http.StatusOK || code == http.StatusNoContent || code == http.StatusNotModified {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	cw.wroteHeader = true
+	if !cw.decided {
+		cw.buf = append(cw.buf, p...)
+		if len(cw.buf) < cw.opts.MinBytes {
+			return len(p), nil
+		}
+		buffered := cw.buf
+		cw.buf = nil
+		if err := cw.start(buffered, true); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if cw.enc != nil {
+		cw.raw += len(p)
+		return cw.enc.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// Flush sends what was written so far; a body still shorter than MinBytes
+// is sent uncompressed.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		buffered := cw.buf
+		cw.buf = nil
+		_ = cw.start(buffered, len(buffered) >= cw.opts.MinBytes)
+	}
+	if cw.enc != nil {
+		_ = cw.enc.Flush()
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (cw *compressWriter) Unwrap() http.ResponseWriter { return cw.ResponseWriter }
+
+// decide sends the headers, compressing the body when allowed and eligible.
+func (cw *compressWriter) decide(allowed bool) {
+	if cw.decided {
+		return
+	}
+	cw.decided = true
+	h := cw.Header()
+	if allowed && cw.eligible() {
+		enc := encoderPools[cw.encoding].Get().(encoder)
+		cw.counter = &countingWriter{w: cw.ResponseWriter}
+		enc.Reset(cw.counter)
+		cw.enc = enc
+		h.Set("Content-Encoding", cw.encoding)
+		h.Del("Content-Length")
+	}
+	if cw.jsonResponse() && cw.opts.compressesRoute(cw.route()) {
+		h.Add("Vary", "Accept-Encoding")
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+}
+
+// start decides and writes the buffered start of the body.
+func (cw *compressWriter) start(buffered []byte, allowed bool) error {
+	cw.decide(allowed)
+	if len(buffered) == 0 {
+		return nil
+	}
+	var err error
+	if cw.enc != nil {
+		cw.raw += len(buffered)
+		_, err = cw.enc.Write(buffered)
+	} else {
+		_, err = cw.ResponseWriter.Write(buffered)
+	}
+	return err
+}
+
+// eligible reports whether the response may be compressed: an uncoded JSON
+// body of a configured route.
+func (cw *compressWriter) eligible() bool {
+	if cw.Header().Get("Content-Encoding") != "" || !cw.jsonResponse() {
+		return false
+	}
+	return cw.opts.compressesRoute(cw.route())
+}
+
+// jsonResponse reports whether the response is JSON, including +json types
+// such as application/problem+json.
+func (cw *compressWriter) jsonResponse() bool {
+	mt, _, err := mime.ParseMediaType(cw.Header().Get("Content-Type"))
+	return err == nil && (mt == "application/json" || strings.HasSuffix(mt, "+json"))
+}
+
+// route returns the matched chi route pattern, or the path outside chi.
+func (cw *compressWriter) route() string {
+	if rc := chi.RouteContext(cw.r.Context()); rc != nil {
+		if p := rc.RoutePattern(); p != "" {
+			return p
+		}
+	}
+	return cw.r.URL.Path
+}
+
+// close finishes the response after the handler returned.
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		if !cw.wroteHeader {
+			// The handler wrote nothing; let the server send its default.
+			return
+		}
+		buffered := cw.buf
+		cw.buf = nil
+		_ = cw.start(buffered, false)
+	}
+	if cw.enc == nil {
+		return
+	}
+	_ = cw.enc.Close()
+	cw.enc.Reset(io.Discard)
+	encoderPools[cw.encoding].Put(cw.enc)
+	adapterobs.RecordHTTPCompression(cw.route(), cw.encoding, cw.raw, cw.counter.n)
+	cw.enc = nil
+}
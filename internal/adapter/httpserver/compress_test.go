@@ -0,0 +1,92 @@
+package httpserver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := map[string]string{
+		"":                          "",
+		"identity":                  "",
+		"gzip":                      encodingGzip,
+		"deflate, gzip":             encodingGzip,
+		"gzip, deflate, br, zstd":   encodingZstd,
+		"zstd;q=0.5, gzip;q=0.8":    encodingGzip,
+		"gzip;q=0, deflate":         encodingDeflate,
+		"*":                         encodingZstd,
+		"*;q=0.1, gzip;q=0":         encodingZstd,
+		"GZIP; Q=0.9, zstd;q=0.001": encodingGzip,
+	}
+	for header, want := range cases {
+		assert.Equal(t, want, negotiateEncoding(header), header)
+	}
+}
+
+func TestCompress(t *testing.T) {
+	long := `{"feedback":"` + strings.Repeat("clear and well structured. ", 100) + `"}`
+	r := chi.NewRouter()
+	r.Use(Compress(CompressionOptions{MinBytes: 256, Routes: []string{"/v1/result/{id}", "/admin/api/*"}}))
+	writeBody := func(contentType, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			_, _ = io.WriteString(w, body)
+		}
+	}
+	r.Get("/v1/result/{id}", writeBody("application/json", long))
+	r.Get("/admin/api/jobs", writeBody("application/json", long))
+	r.Get("/v1/result/{id}/diff", writeBody("application/json", long))
+	r.Get("/v1/small", writeBody("application/json", `{"ok":true}`))
+	r.Get("/openapi.yaml", writeBody("application/yaml", long))
+	r.Get("/admin/api/missing", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNotModified) })
+
+	get := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	decoders := map[string]func(io.Reader) (io.Reader, error){
+		encodingGzip:    func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		encodingDeflate: func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) },
+		encodingZstd:    func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+	}
+	for enc, decode := range decoders {
+		for _, path := range []string{"/v1/result/job-1", "/admin/api/jobs"} {
+			w := get(path, enc)
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, enc, w.Header().Get("Content-Encoding"), path)
+			assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+			assert.Less(t, w.Body.Len(), len(long))
+			dec, err := decode(bytes.NewReader(w.Body.Bytes()))
+			require.NoError(t, err)
+			got, err := io.ReadAll(dec)
+			require.NoError(t, err)
+			assert.Equal(t, long, string(got))
+		}
+	}
+
+	// Other routes, small bodies, non-JSON and bodiless responses are sent
+	// as-is.
+	for _, path := range []string{"/v1/result/job-1/diff", "/v1/small", "/openapi.yaml", "/admin/api/missing"} {
+		w := get(path, "gzip")
+		assert.Empty(t, w.Header().Get("Content-Encoding"), path)
+	}
+	assert.Equal(t, `{"ok":true}`, get("/v1/small", "gzip").Body.String())
+	assert.Equal(t, http.StatusNotModified, get("/admin/api/missing", "gzip").Code)
+	w := get("/v1/result/job-1", "identity")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, long, w.Body.String())
+}
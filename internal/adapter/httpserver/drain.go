@@ -0,0 +1,120 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	adapterobs "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+)
+
+// Request kinds tracked while draining.
+const (
+	RequestKindUpload   = "upload"
+	RequestKindEvaluate = "evaluate"
+	RequestKindOther    = "other"
+)
+
+// Drainer tracks in-flight requests so that shutdown can fail readiness,
+// wait for uploads and evaluations to finish enqueueing and report what was
+// drained. It is safe for concurrent use.
+type Drainer struct {
+	draining atomic.Bool
+
+	mu       sync.Mutex
+	inFlight map[string]int
+}
+
+// DrainReport summarizes a shutdown by request kind.
+type DrainReport struct {
+	// InFlight is the number of requests being served when the server
+	// stopped accepting new ones.
+	InFlight map[string]int
+	// Abandoned is the number still being served when shutdown gave up.
+	Abandoned map[string]int
+	// Duration is how long draining took, including the delay.
+	Duration time.Duration
+	// Err is the error of the server shutdown, e.g. its deadline.
+	Err error
+}
+
+// Drained returns the number of requests of kind that completed while draining.
+func (r DrainReport) Drained(kind string) int { return r.InFlight[kind] - r.Abandoned[kind] }
+
+// NewDrainer creates a Drainer.
+func NewDrainer() *Drainer {
+	return &Drainer{inFlight: map[string]int{}}
+}
+
+// requestKind classifies r for in-flight accounting.
+func requestKind(r *http.Request) string {
+	switch r.URL.Path {
+	case "/v1/upload":
+		return RequestKindUpload
+	case "/v1/evaluate":
+		return RequestKindEvaluate
+	default:
+		return RequestKindOther
+	}
+}
+
+// Middleware counts the requests being served.
+func (d *Drainer) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kind := requestKind(r)
+		d.add(kind, 1)
+		defer d.add(kind, -1)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (d *Drainer) add(kind string, delta int) {
+	d.mu.Lock()
+	d.inFlight[kind] += delta
+	n := d.inFlight[kind]
+	d.mu.Unlock()
+	adapterobs.SetHTTPRequestsInFlight(kind, n)
+}
+
+func (d *Drainer) snapshot() map[string]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make(map[string]int, len(d.inFlight))
+	for k, n := range d.inFlight {
+		if n > 0 {
+			out[k] = n
+		}
+	}
+	return out
+}
+
+// Draining reports whether shutdown has begun. A nil Drainer never drains.
+func (d *Drainer) Draining() bool { return d != nil && d.draining.Load() }
+
+// Drain marks the server as draining, keeps serving for delay so that load
+// balancers observe the failing readiness probe, then calls shutdown, which
+// must stop accepting requests and wait for in-flight ones until ctx is done
+// (e.g. http.Server.Shutdown).
+func (d *Drainer) Drain(ctx context.Context, delay time.Duration, shutdown func(context.Context) error) DrainReport {
+	start := time.Now()
+	d.draining.Store(true)
+	if delay > 0 {
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+		}
+	}
+	rep := DrainReport{InFlight: d.snapshot()}
+	rep.Err = shutdown(ctx)
+	rep.Abandoned = d.snapshot()
+	rep.Duration = time.Since(start)
+	for kind := range rep.InFlight {
+		adapterobs.RecordShutdownRequests(kind, "drained", max(rep.Drained(kind), 0))
+		adapterobs.RecordShutdownRequests(kind, "abandoned", rep.Abandoned[kind])
+	}
+	return rep
+}
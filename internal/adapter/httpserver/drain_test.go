@@ -0,0 +1,91 @@
+package httpserver_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestDrainer_DrainReportsInFlightRequests(t *testing.T) {
+	d := httpserver.NewDrainer()
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	h := d.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		if r.URL.Path == "/v1/evaluate" {
+			<-release
+		} else {
+			<-r.Context().Done()
+		}
+	}))
+
+	var wg sync.WaitGroup
+	stuckCtx, cancelStuck := context.WithCancel(context.Background())
+	defer cancelStuck()
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/v1/evaluate", nil),
+		httptest.NewRequest(http.MethodPost, "/v1/upload", nil).WithContext(stuckCtx),
+	} {
+		wg.Add(1)
+		go func(req *http.Request) {
+			defer wg.Done()
+			h.ServeHTTP(httptest.NewRecorder(), req)
+		}(req)
+	}
+	<-started
+	<-started
+
+	if d.Draining() {
+		t.Fatalf("draining before Drain")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	rep := d.Drain(ctx, 0, func(ctx context.Context) error {
+		if !d.Draining() {
+			t.Errorf("not draining during shutdown")
+		}
+		// The evaluation finishes; the upload outlives the deadline.
+		close(release)
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Millisecond):
+			}
+		}
+	})
+	if rep.InFlight[httpserver.RequestKindEvaluate] != 1 || rep.InFlight[httpserver.RequestKindUpload] != 1 {
+		t.Fatalf("unexpected in-flight counts %+v", rep.InFlight)
+	}
+	if rep.Drained(httpserver.RequestKindEvaluate) != 1 || rep.Abandoned[httpserver.RequestKindUpload] != 1 {
+		t.Fatalf("unexpected report %+v", rep)
+	}
+	if rep.Err == nil {
+		t.Fatalf("expected the shutdown deadline error")
+	}
+	cancelStuck()
+	wg.Wait()
+}
+
+func TestReadyz_FailsWhileDraining(t *testing.T) {
+	srv := httpserver.NewServer(config.Config{}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.Drainer = httpserver.NewDrainer()
+	rw := httptest.NewRecorder()
+	srv.ReadyzHandler()(rw, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("readyz before drain = %d", rw.Code)
+	}
+	srv.Drainer.Drain(context.Background(), 0, func(context.Context) error { return nil })
+	rw = httptest.NewRecorder()
+	srv.ReadyzHandler()(rw, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rw.Code != http.StatusServiceUnavailable {
+		t.Fatalf("readyz while draining = %d", rw.Code)
+	}
+}
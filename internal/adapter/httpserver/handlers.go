@@ -10,8 +10,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -28,6 +30,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 
+	adapterobs "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/textextractor/jsonresume"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
@@ -46,6 +50,45 @@ type Server struct {
 	QdrantCheck func(ctx context.Context) error
 	TikaCheck   func(ctx context.Context) error
 
+	// ProviderKeys manages AI provider API keys at runtime (optional)
+	ProviderKeys ProviderKeyManager
+	// BiasAudit serves bias audit reports (optional)
+	BiasAudit BiasAuditor
+	// Maintenance toggles maintenance mode (optional)
+	Maintenance MaintenanceController
+	// Tenants manages per-tenant evaluation settings (optional)
+	Tenants TenantManager
+	// Experiments manages prompt A/B experiments (optional)
+	Experiments PromptExperimenter
+	// Snapshots snapshots and restores the RAG collections (optional)
+	Snapshots SnapshotManager
+	// LegalHolds places and releases legal holds on uploads and jobs (optional)
+	LegalHolds LegalHoldManager
+	// ConsumerPauses pauses and resumes the workers' queue consumption (optional)
+	ConsumerPauses ConsumerPauseController
+	// JobQueue bumps queued jobs ahead of the backlog (optional)
+	JobQueue JobBumper
+	// JobBulk applies admin actions to every job matching a filter (optional)
+	JobBulk JobBulkOperator
+	// Replays re-runs past jobs in sandbox mode (optional)
+	Replays JobReplayer
+	// AccessLog records reads of results (optional)
+	AccessLog AccessLogger
+	// Summaries generates candidate summaries of results (optional)
+	Summaries CandidateSummarizer
+	// Diffs compares stored result versions (optional)
+	Diffs ResultDiffer
+	// UsageStats compiles anonymous usage stats (optional)
+	UsageStats UsageStatsCompiler
+	// ProviderErrors reports the most frequent AI provider error fingerprints (optional)
+	ProviderErrors ProviderErrorReporter
+	// JobUsage reports percentiles of the time jobs spend in each phase (optional)
+	JobUsage JobUsageReporter
+	// InboundMailer replies to email-in submissions (optional)
+	InboundMailer domain.Mailer
+	// Drainer tracks in-flight requests for graceful shutdown (optional)
+	Drainer *Drainer
+
 	// Observability components
 	healthObservableClient *observability.IntegratedObservableClient
 }
@@ -56,7 +99,7 @@ func allowedMIME(m string) bool { return allowedMIMEFor(m, "dummy.txt") }
 
 // extractUploadedText performs text extraction based on the uploaded content and filename.
 // - For .pdf/.docx: requires an external extractor (Apache Tika) and streams via a temp file.
-// - For .txt: returns sanitized text directly.
+// - For .txt: returns sanitized text with page numbers and running headers stripped.
 func extractUploadedText(ctx context.Context, extractor domain.TextExtractor, h *multipart.FileHeader, data []byte) (string, error) {
 	ext := strings.ToLower(filepath.Ext(h.Filename))
 
@@ -67,6 +110,10 @@ func extractUploadedText(ctx context.Context, extractor domain.TextExtractor, h
 		attribute.String("upload.filename", h.Filename),
 		attribute.String("upload.ext", ext),
 	)
+	// Structured resumes are rendered from their fields without Tika.
+	if ext == ".json" {
+		return jsonresume.Text(data)
+	}
 	if ext == ".pdf" || ext == ".docx" {
 		if extractor == nil {
 			return "", fmt.Errorf("%w: %s requires extractor", domain.ErrInvalidArgument, strings.TrimPrefix(ext, "."))
@@ -85,7 +132,7 @@ func extractUploadedText(ctx context.Context, extractor domain.TextExtractor, h
 		return extractor.ExtractPath(ctx, h.Filename, tmp.Name())
 	}
 	// Treat as plain text with sanitization
-	return textx.SanitizeText(string(data)), nil
+	return textx.Normalize(textx.SanitizeText(string(data))), nil
 }
 
 // Admin cookie helpers removed; AdminServer with SessionManager handles authentication.
@@ -96,8 +143,35 @@ func allowedExt(name string) bool {
 	return strings.HasSuffix(n, ".txt") || strings.HasSuffix(n, ".pdf") || strings.HasSuffix(n, ".docx")
 }
 
+// maxProjectFiles bounds the files of one multi-file project upload.
+const maxProjectFiles = 20
+
+// allowedProjectExt additionally accepts .md project files such as README.md.
+func allowedProjectExt(name string) bool {
+	return allowedExt(name) || strings.HasSuffix(strings.ToLower(name), ".md")
+}
+
+// readFileHeader reads an uploaded multipart file into memory.
+func readFileHeader(h *multipart.FileHeader) ([]byte, error) {
+	f, err := h.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	return io.ReadAll(f)
+}
+
+// allowedCVExt additionally accepts .json CVs: JSON Resume documents and
+// LinkedIn profile exports.
+func allowedCVExt(name string) bool {
+	return allowedExt(name) || strings.HasSuffix(strings.ToLower(name), ".json")
+}
+
 func allowedMIMEFor(m string, filename string) bool {
 	m = strings.ToLower(m)
+	if strings.HasSuffix(strings.ToLower(filename), ".json") {
+		return strings.HasPrefix(m, "application/json")
+	}
 	// For .txt files, accept any text/* including text/html as some detectors misclassify rich text
 	if strings.HasSuffix(strings.ToLower(filename), ".txt") {
 		if strings.HasPrefix(m, "text/") {
@@ -156,8 +230,12 @@ func (s *Server) UploadHandler() http.HandlerFunc {
 			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "INVALID_ARGUMENT", "message": "not acceptable", "details": map[string]any{"accept": a}}})
 			return
 		}
+		if strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+			s.uploadText(w, r)
+			return
+		}
 		if !strings.Contains(r.Header.Get("Content-Type"), "multipart/form-data") {
-			writeError(w, r, fmt.Errorf("%w: content-type must be multipart/form-data", domain.ErrInvalidArgument), nil)
+			writeError(w, r, fmt.Errorf("%w: content-type must be multipart/form-data or application/json", domain.ErrInvalidArgument), nil)
 			return
 		}
 		// Limit total multipart size
@@ -180,12 +258,15 @@ func (s *Server) UploadHandler() http.HandlerFunc {
 			return
 		}
 		defer func() { _ = cvFile.Close() }()
-		projFile, projHeader, err := r.FormFile("project")
-		if err != nil {
+		projHeaders := r.MultipartForm.File["project"]
+		if len(projHeaders) == 0 {
 			writeError(w, r, fmt.Errorf("%w: project file required", domain.ErrInvalidArgument), map[string]string{"field": "project"})
 			return
 		}
-		defer func() { _ = projFile.Close() }()
+		if len(projHeaders) > maxProjectFiles {
+			writeError(w, r, fmt.Errorf("%w: at most %d project files", domain.ErrInvalidArgument, maxProjectFiles), map[string]any{"field": "project", "max_files": maxProjectFiles})
+			return
+		}
 
 		// Read files into memory (body already capped by MaxBytesReader/ParseMultipartForm)
 		cvBytes, err := io.ReadAll(cvFile)
@@ -193,25 +274,14 @@ func (s *Server) UploadHandler() http.HandlerFunc {
 			writeError(w, r, fmt.Errorf("%w: cv read: %v", domain.ErrInvalidArgument, err), nil)
 			return
 		}
-		prBytes, err := io.ReadAll(projFile)
-		if err != nil {
-			writeError(w, r, fmt.Errorf("%w: project read: %v", domain.ErrInvalidArgument, err), nil)
-			return
-		}
 
 		// Extension allowlist first
-		if !allowedExt(cvHeader.Filename) {
+		if !allowedCVExt(cvHeader.Filename) {
 			w.Header().Set("Content-Type", "application/json; charset=utf-8")
 			w.WriteHeader(http.StatusUnsupportedMediaType)
 			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "INVALID_ARGUMENT", "message": "unsupported media type for cv (extension)", "details": map[string]any{"filename": cvHeader.Filename}}})
 			return
 		}
-		if !allowedExt(projHeader.Filename) {
-			w.Header().Set("Content-Type", "application/json; charset=utf-8")
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "INVALID_ARGUMENT", "message": "unsupported media type for project (extension)", "details": map[string]any{"filename": projHeader.Filename}}})
-			return
-		}
 
 		// Content sniffing with mimetype; enforce allowlist
 		cvMime := mimetype.Detect(cvBytes)
@@ -221,13 +291,6 @@ func (s *Server) UploadHandler() http.HandlerFunc {
 			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "INVALID_ARGUMENT", "message": "unsupported media type for cv (content)", "details": map[string]any{"mime": cvMime.String(), "filename": cvHeader.Filename}}})
 			return
 		}
-		prMime := mimetype.Detect(prBytes)
-		if !allowedMIMEFor(prMime.String(), projHeader.Filename) {
-			w.Header().Set("Content-Type", "application/json; charset=utf-8")
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "INVALID_ARGUMENT", "message": "unsupported media type for project (content)", "details": map[string]any{"mime": prMime.String(), "filename": projHeader.Filename}}})
-			return
-		}
 
 		// Extract text
 		cvText, err := extractUploadedText(r.Context(), s.Extractor, cvHeader, cvBytes)
@@ -235,20 +298,116 @@ func (s *Server) UploadHandler() http.HandlerFunc {
 			writeError(w, r, fmt.Errorf("%w: cv extract: %v", domain.ErrInvalidArgument, err), nil)
 			return
 		}
-		projText, err := extractUploadedText(r.Context(), s.Extractor, projHeader, prBytes)
-		if err != nil {
-			writeError(w, r, fmt.Errorf("%w: project extract: %v", domain.ErrInvalidArgument, err), nil)
+		// A project may be several files, e.g. a README and source files;
+		// they are assembled into one document with the README first.
+		projDocs := make([]textx.ProjectDocument, 0, len(projHeaders))
+		projNames := make([]string, 0, len(projHeaders))
+		for _, ph := range projHeaders {
+			if !allowedProjectExt(ph.Filename) {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "INVALID_ARGUMENT", "message": "unsupported media type for project (extension)", "details": map[string]any{"filename": ph.Filename}}})
+				return
+			}
+			prBytes, err := readFileHeader(ph)
+			if err != nil {
+				writeError(w, r, fmt.Errorf("%w: project read: %v", domain.ErrInvalidArgument, err), nil)
+				return
+			}
+			prMime := mimetype.Detect(prBytes)
+			if !allowedMIMEFor(prMime.String(), ph.Filename) {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "INVALID_ARGUMENT", "message": "unsupported media type for project (content)", "details": map[string]any{"mime": prMime.String(), "filename": ph.Filename}}})
+				return
+			}
+			text, err := extractUploadedText(r.Context(), s.Extractor, ph, prBytes)
+			if err != nil {
+				writeError(w, r, fmt.Errorf("%w: project extract: %v", domain.ErrInvalidArgument, err), map[string]string{"filename": ph.Filename})
+				return
+			}
+			projDocs = append(projDocs, textx.ProjectDocument{Name: ph.Filename, Text: text})
+			projNames = append(projNames, ph.Filename)
+		}
+		projText := textx.AssembleProject(projDocs)
+
+		ctx, warnings := usecase.WithUploadWarnings(r.Context())
+		cvID, projID, err := s.Uploads.Ingest(ctx, cvText, projText, cvHeader.Filename, strings.Join(projNames, ", "))
+		writeUploadResult(w, r, cvID, projID, *warnings, err)
+	}
+}
+
+// uploadText handles the JSON variant of /v1/upload: a CV and a project
+// whose text was already extracted, stored without file checks or Tika.
+func (s *Server) uploadText(w http.ResponseWriter, r *http.Request) {
+	// Each text may be as large as an uploaded file.
+	maxBytes := s.Cfg.MaxUploadMB * 1024 * 1024
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes*2)
+	var req struct {
+		CVText      string `json:"cv_text"`
+		ProjectText string `json:"project_text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			writeTextTooLarge(w, "", s.Cfg.MaxUploadMB)
+			return
+		}
+		writeError(w, r, fmt.Errorf("%w: invalid json", domain.ErrInvalidArgument), nil)
+		return
+	}
+	for _, f := range []struct{ name, text string }{{"cv_text", req.CVText}, {"project_text", req.ProjectText}} {
+		if strings.TrimSpace(f.text) == "" {
+			writeError(w, r, fmt.Errorf("%w: %s required", domain.ErrInvalidArgument, f.name), map[string]string{"field": f.name})
+			return
+		}
+		if int64(len(f.text)) > maxBytes {
+			writeTextTooLarge(w, f.name, s.Cfg.MaxUploadMB)
 			return
 		}
+	}
+	cvText := textx.Normalize(textx.SanitizeText(req.CVText))
+	projText := textx.Normalize(textx.SanitizeText(req.ProjectText))
 
-		ctx := r.Context()
-		cvID, projID, err := s.Uploads.Ingest(ctx, cvText, projText, cvHeader.Filename, projHeader.Filename)
-		if err != nil {
-			writeError(w, r, fmt.Errorf("upload ingest: %w", err), nil)
+	ctx, warnings := usecase.WithUploadWarnings(r.Context())
+	cvID, projID, err := s.Uploads.IngestText(ctx, cvText, projText)
+	writeUploadResult(w, r, cvID, projID, *warnings, err)
+}
+
+// writeTextTooLarge answers 413 for a text upload above the size limit.
+func writeTextTooLarge(w http.ResponseWriter, field string, maxMB int64) {
+	details := map[string]any{"max_mb": maxMB}
+	if field != "" {
+		details["field"] = field
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusRequestEntityTooLarge)
+	_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "INVALID_ARGUMENT", "message": "payload too large", "details": details}})
+}
+
+// writeUploadResult answers an upload with the ids of the stored documents
+// and their quality warnings, or with the ingest error.
+func writeUploadResult(w http.ResponseWriter, r *http.Request, cvID, projID string, warnings []usecase.UploadWarning, err error) {
+	if err != nil {
+		var qerr *usecase.UploadQualityError
+		if errors.As(err, &qerr) {
+			for _, i := range qerr.Issues {
+				adapterobs.RecordUploadQualityIssue(qerr.Field, i.Code, i.Reject)
+			}
+			writeError(w, r, fmt.Errorf("upload ingest: %w", err), map[string]any{"field": qerr.Field, "reasons": qerr.Issues})
 			return
 		}
+		writeError(w, r, fmt.Errorf("upload ingest: %w", err), nil)
+		return
+	}
+	if len(warnings) == 0 {
 		writeJSON(w, http.StatusOK, map[string]string{"cv_id": cvID, "project_id": projID})
+		return
+	}
+	for _, wn := range warnings {
+		adapterobs.RecordUploadQualityIssue(wn.Field, wn.Code, false)
 	}
+	writeJSON(w, http.StatusOK, map[string]any{"cv_id": cvID, "project_id": projID, "warnings": warnings})
 }
 
 // EvaluateHandler enqueues evaluation job.
@@ -264,11 +423,32 @@ func (s *Server) EvaluateHandler() http.HandlerFunc {
 		// Cap body size to prevent abuse
 		r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1MB
 		var req struct {
-			CVID           string `json:"cv_id" validate:"required"`
-			ProjectID      string `json:"project_id" validate:"required"`
+			CVID           string `json:"cv_id" validate:"required_without=ProjectID"` // empty requests a project-only evaluation
+			ProjectID      string `json:"project_id"`                                  // empty requests a CV-only evaluation
 			JobDescription string `json:"job_description" validate:"omitempty,max=5000"`
 			StudyCaseBrief string `json:"study_case_brief" validate:"omitempty,max=5000"`
 			ScoringRubric  string `json:"scoring_rubric" validate:"omitempty,max=10000"`
+			// AllowPaidFallback opts this job into paid models when all free models are rate limited
+			AllowPaidFallback bool `json:"allow_paid_fallback"`
+			// ScoringWeights overrides rubric weights in percent, keyed by rubric section
+			ScoringWeights map[string]float64 `json:"scoring_weights"`
+			// TTLSeconds overrides how long after submission the job expires (max 30 days)
+			TTLSeconds int `json:"ttl_seconds" validate:"omitempty,min=1,max=2592000"`
+			// OpenRouterProvider adjusts OpenRouter's upstream provider routing for this job
+			OpenRouterProvider *domain.OpenRouterProviderPrefs `json:"openrouter_provider"`
+			// Temperature, TopP and FrequencyPenalty tune the sampling of this job's AI calls
+			Temperature      *float64 `json:"temperature"`
+			TopP             *float64 `json:"top_p"`
+			FrequencyPenalty *float64 `json:"frequency_penalty"`
+			// Metadata and Tags are stored on the job and echoed with its result
+			Metadata map[string]string `json:"metadata"`
+			Tags     []string          `json:"tags"`
+			// Priority interactive asks for the faster single-prompt evaluation
+			Priority string `json:"priority" validate:"omitempty,oneof=batch interactive"`
+			// Format markdown asks for feedback structured with headings and bullet lists
+			Format string `json:"format" validate:"omitempty,oneof=text markdown"`
+			// FeedbackLanguage is the ISO 639-1 code of the language to write the feedback in
+			FeedbackLanguage string `json:"feedback_language"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			writeError(w, r, fmt.Errorf("%w: invalid json", domain.ErrInvalidArgument), nil)
@@ -284,25 +464,83 @@ func (s *Server) EvaluateHandler() http.HandlerFunc {
 			writeError(w, r, fmt.Errorf("%w: validation failed", domain.ErrInvalidArgument), verrs)
 			return
 		}
+		if p := req.OpenRouterProvider; p != nil && p.DataCollection != "" && p.DataCollection != "allow" && p.DataCollection != "deny" {
+			writeError(w, r, fmt.Errorf("%w: validation failed", domain.ErrInvalidArgument), map[string]string{"data_collection": "oneof"})
+			return
+		}
+		sampling := domain.SamplingParams{Temperature: req.Temperature, TopP: req.TopP, FrequencyPenalty: req.FrequencyPenalty}
+		if err := usecase.ValidateSamplingParams(sampling); err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		labels, err := usecase.ValidateJobLabels(domain.JobLabels{Metadata: req.Metadata, Tags: req.Tags})
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
 		ctx := r.Context()
 
 		// Use default values if not provided
 		jobDescription := req.JobDescription
 		studyCaseBrief := req.StudyCaseBrief
-		scoringRubric := req.ScoringRubric
 
-		if jobDescription == "" {
+		if jobDescription == "" && req.CVID != "" {
 			jobDescription = getDefaultJobDescription()
 		}
-		if studyCaseBrief == "" {
+		if studyCaseBrief == "" && req.ProjectID != "" {
 			studyCaseBrief = getDefaultStudyCaseBrief()
 		}
-		if scoringRubric == "" {
-			scoringRubric = getDefaultScoringRubric()
-		}
+		// An empty scoring rubric is resolved by the usecase from the tenant's
+		// template or the default rubric.
 
-		jobID, err := s.Evaluate.Enqueue(ctx, req.CVID, req.ProjectID, jobDescription, studyCaseBrief, scoringRubric, r.Header.Get("Idempotency-Key"))
+		if req.AllowPaidFallback {
+			ctx = domain.WithPaidFallbackOptIn(ctx, true)
+		}
+		if len(req.ScoringWeights) > 0 {
+			ctx = domain.WithScoringWeights(ctx, req.ScoringWeights)
+		}
+		if req.TTLSeconds > 0 {
+			ctx = domain.WithJobTTL(ctx, time.Duration(req.TTLSeconds)*time.Second)
+		}
+		if req.OpenRouterProvider != nil {
+			ctx = domain.WithOpenRouterProviderPrefs(ctx, *req.OpenRouterProvider)
+		}
+		if !sampling.IsZero() {
+			ctx = domain.WithSamplingParams(ctx, sampling)
+		}
+		if len(labels.Metadata) > 0 || len(labels.Tags) > 0 {
+			ctx = domain.WithJobLabels(ctx, labels)
+		}
+		if req.Priority != "" {
+			ctx = domain.WithJobPriority(ctx, req.Priority)
+		}
+		if req.Format != "" {
+			ctx = domain.WithFeedbackFormat(ctx, req.Format)
+		}
+		if req.FeedbackLanguage != "" {
+			ctx = domain.WithFeedbackLanguage(ctx, strings.ToLower(req.FeedbackLanguage))
+		}
+		if key := r.Header.Get("X-API-Key"); key != "" {
+			ctx = domain.WithTenantAPIKey(ctx, key)
+		}
+		ctx, quota := usecase.WithQuotaStatus(ctx)
+		jobID, err := s.Evaluate.Enqueue(ctx, req.CVID, req.ProjectID, jobDescription, studyCaseBrief, req.ScoringRubric, r.Header.Get("Idempotency-Key"))
+		setQuotaHeaders(w, *quota)
 		if err != nil {
+			if errors.Is(err, domain.ErrRateLimited) && quota.Warn {
+				w.Header().Set("Retry-After", w.Header().Get("X-Quota-Reset"))
+			}
+			if errors.Is(err, domain.ErrMaintenance) {
+				retryAfter := s.Evaluate.Maintenance.State().RetryAfter
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			}
+			if errors.Is(err, domain.ErrBacklogFull) && s.Evaluate.Backpressure != nil {
+				retryAfter := s.Evaluate.Backpressure.RetryAfter
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			}
+			if retryAfter, ok := domain.RetryAfter(err); ok {
+				w.Header().Set("Retry-After", strconv.Itoa(max(int(math.Ceil(retryAfter.Seconds())), 1)))
+			}
 			writeError(w, r, fmt.Errorf("enqueue: %w", err), nil)
 			return
 		}
@@ -310,7 +548,25 @@ func (s *Server) EvaluateHandler() http.HandlerFunc {
 	}
 }
 
-// ResultHandler returns job status and result when completed.
+// setQuotaHeaders warns a tenant that is close to a daily quota:
+// X-Quota-Remaining and X-Quota-Remaining-Tokens carry what is left of the
+// evaluation and token quotas and X-Quota-Reset the seconds until they reset.
+func setQuotaHeaders(w http.ResponseWriter, st usecase.QuotaStatus) {
+	if !st.Warn {
+		return
+	}
+	if st.Remaining >= 0 {
+		w.Header().Set("X-Quota-Remaining", strconv.Itoa(st.Remaining))
+	}
+	if st.RemainingTokens >= 0 {
+		w.Header().Set("X-Quota-Remaining-Tokens", strconv.FormatInt(st.RemainingTokens, 10))
+	}
+	w.Header().Set("X-Quota-Reset", strconv.Itoa(int(math.Ceil(time.Until(st.Reset).Seconds()))))
+}
+
+// ResultHandler returns job status and result when completed. Responses
+// carry a strong ETag and, for completed jobs, Last-Modified and a private
+// Cache-Control max-age; conditional requests are answered with 304.
 func (s *Server) ResultHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Accept negotiation: only JSON responses supported
@@ -326,20 +582,112 @@ func (s *Server) ResultHandler() http.HandlerFunc {
 			return
 		}
 		ctx := r.Context()
-		status, res, etag, err := s.Results.Fetch(ctx, id, r.Header.Get("If-None-Match"))
+		cond := usecase.ResultConditions{IfNoneMatch: r.Header.Get("If-None-Match")}
+		if t, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil {
+			cond.IfModifiedSince = t
+		}
+		resp, err := s.Results.FetchConditional(ctx, id, cond)
 		if err != nil {
 			writeError(w, r, err, nil)
 			return
 		}
-		w.Header().Set("ETag", etag)
-		if status != http.StatusNotModified {
-			writeJSON(w, status, res)
+		w.Header().Set("ETag", resp.ETag)
+		if !resp.LastModified.IsZero() {
+			w.Header().Set("Last-Modified", resp.LastModified.Format(http.TimeFormat))
+		}
+		if resp.MaxAge > 0 {
+			w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(resp.MaxAge/time.Second)))
 		} else {
-			w.WriteHeader(status)
+			w.Header().Set("Cache-Control", "no-cache")
+		}
+		if resp.Status != http.StatusNotModified {
+			if _, ok := resp.Body["result"]; ok {
+				s.recordResultAccess(r, accessResult, id)
+			}
+			writeJSON(w, resp.Status, resp.Body)
+		} else {
+			w.WriteHeader(resp.Status)
 		}
 	}
 }
 
+// maxBatchResultIDs caps how many job ids a single batch status request may ask for.
+const maxBatchResultIDs = 100
+
+// BatchResultsHandler returns statuses and results for many jobs in one call.
+// Ids come from the comma-separated "ids" query parameter (GET) or a JSON body
+// {"ids": [...]} (POST). The response maps each known id to the same envelope
+// served by ResultHandler and lists unknown ids under "not_found".
+func (s *Server) BatchResultsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Accept negotiation: only JSON responses supported
+		if a := r.Header.Get("Accept"); a != "" && a != "*/*" && !strings.Contains(a, "application/json") {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusNotAcceptable)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": "INVALID_ARGUMENT", "message": "not acceptable", "details": map[string]any{"accept": a}}})
+			return
+		}
+		var ids []string
+		if r.Method == http.MethodPost {
+			r.Body = http.MaxBytesReader(w, r.Body, 1<<20) // 1MB
+			var req struct {
+				IDs []string `json:"ids"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, r, fmt.Errorf("%w: invalid json", domain.ErrInvalidArgument), nil)
+				return
+			}
+			ids = req.IDs
+		} else {
+			for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+				ids = append(ids, strings.TrimSpace(id))
+			}
+		}
+
+		unique := make([]string, 0, len(ids))
+		seen := make(map[string]struct{}, len(ids))
+		for _, id := range ids {
+			if id == "" {
+				continue
+			}
+			if validation := ValidateJobID(id); !validation.Valid {
+				writeError(w, r, fmt.Errorf("%w: invalid job id", domain.ErrInvalidArgument), map[string]any{"id": id, "errors": validation.Errors})
+				return
+			}
+			if _, dup := seen[id]; dup {
+				continue
+			}
+			seen[id] = struct{}{}
+			unique = append(unique, id)
+		}
+		if len(unique) == 0 {
+			writeError(w, r, fmt.Errorf("%w: ids required", domain.ErrInvalidArgument), map[string]string{"field": "ids"})
+			return
+		}
+		if len(unique) > maxBatchResultIDs {
+			writeError(w, r, fmt.Errorf("%w: too many ids", domain.ErrInvalidArgument), map[string]any{"field": "ids", "max": maxBatchResultIDs})
+			return
+		}
+
+		results, missing, err := s.Results.FetchMany(r.Context(), unique)
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		if missing == nil {
+			missing = []string{}
+		}
+		read := make([]string, 0, len(results))
+		for _, id := range unique {
+			if _, ok := results[id]["result"]; ok {
+				read = append(read, id)
+			}
+		}
+		s.recordResultAccess(r, r.Method+" "+accessResults, read...)
+		writeJSON(w, http.StatusOK, map[string]any{"results": results, "not_found": missing})
+	}
+}
+
 // HealthzHandler returns a comprehensive health check handler that probes all services.
 func (s *Server) HealthzHandler() http.HandlerFunc {
 	type check struct {
@@ -459,6 +807,13 @@ func (s *Server) ReadyzHandler() http.HandlerFunc {
 		Details string `json:"details"`
 	}
 	return func(w http.ResponseWriter, r *http.Request) {
+		// Fail readiness while draining so load balancers stop routing here
+		if s.Drainer.Draining() {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]any{
+				"checks": []check{{Name: "server", OK: false, Details: "shutting down"}},
+			})
+			return
+		}
 		// Use observable client for readiness checks
 		var checks []check
 
@@ -755,24 +1110,7 @@ func (s *Server) getJobs(ctx context.Context, page, limit, search, status string
 	// Convert domain jobs to response format
 	jobList := make([]map[string]any, len(jobs))
 	for i, job := range jobs {
-		jobItem := map[string]any{
-			"id":         job.ID,
-			"status":     string(job.Status),
-			"created_at": job.CreatedAt.Format(time.RFC3339),
-			"updated_at": job.UpdatedAt.Format(time.RFC3339),
-			"cv_id":      job.CVID,
-			"project_id": job.ProjectID,
-		}
-
-		// Add error information if job failed
-		if job.Status == domain.JobFailed && job.Error != "" {
-			jobItem["error"] = map[string]any{
-				"code":    "JOB_FAILED",
-				"message": job.Error,
-			}
-		}
-
-		jobList[i] = jobItem
+		jobList[i] = jobListItem(job)
 	}
 
 	return map[string]any{
@@ -785,6 +1123,101 @@ func (s *Server) getJobs(ctx context.Context, page, limit, search, status string
 	}
 }
 
+// getJobsByCursor returns a keyset-paginated job list. It fetches one extra
+// row to learn whether another page exists without counting the table; the
+// total is only computed when explicitly requested.
+func (s *Server) getJobsByCursor(ctx context.Context, p jobListParams) map[string]any {
+	tracer := otel.Tracer("http.admin")
+	ctx, span := tracer.Start(ctx, "Server.getJobsByCursor")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("jobs.search", p.Filter.Search),
+		attribute.String("jobs.status", p.Filter.Status),
+		attribute.String("jobs.sort", string(p.Filter.Sort)),
+	)
+
+	f := p.Filter
+	limit := f.Limit
+	f.Limit = limit + 1
+	jobs, err := s.Evaluate.Jobs.ListByCursor(ctx, f)
+	if err != nil {
+		return map[string]any{
+			"error": map[string]any{
+				"code":    "DATABASE_ERROR",
+				"message": "Failed to retrieve jobs",
+				"details": map[string]any{
+					"error": err.Error(),
+				},
+			},
+			"jobs": []map[string]any{},
+			"pagination": map[string]any{
+				"limit":    limit,
+				"sort":     string(p.Filter.Sort),
+				"has_more": false,
+			},
+		}
+	}
+
+	hasMore := len(jobs) > limit
+	if hasMore {
+		jobs = jobs[:limit]
+	}
+	jobList := make([]map[string]any, len(jobs))
+	for i, job := range jobs {
+		jobList[i] = jobListItem(job)
+	}
+
+	pagination := map[string]any{
+		"limit":    limit,
+		"sort":     string(p.Filter.Sort),
+		"has_more": hasMore,
+	}
+	if hasMore {
+		last := jobs[len(jobs)-1]
+		pagination["next_cursor"] = encodeJobCursor(domain.JobCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	if p.IncludeTotal {
+		if total, err := s.Evaluate.Jobs.CountByFilter(ctx, p.Filter); err == nil {
+			pagination["total"] = total
+		}
+	}
+
+	return map[string]any{
+		"jobs":       jobList,
+		"pagination": pagination,
+	}
+}
+
+// jobListItem converts a job into the admin listing response shape.
+func jobListItem(job domain.Job) map[string]any {
+	jobItem := map[string]any{
+		"id":         job.ID,
+		"status":     string(job.Status),
+		"created_at": job.CreatedAt.Format(time.RFC3339),
+		"updated_at": job.UpdatedAt.Format(time.RFC3339),
+		"cv_id":      job.CVID,
+		"project_id": job.ProjectID,
+	}
+	if job.RequestID != "" {
+		jobItem["request_id"] = job.RequestID
+	}
+	if len(job.Metadata) > 0 {
+		jobItem["metadata"] = job.Metadata
+	}
+	if len(job.Tags) > 0 {
+		jobItem["tags"] = job.Tags
+	}
+
+	// Add error information if job failed
+	if job.Status == domain.JobFailed && job.Error != "" {
+		jobItem["error"] = map[string]any{
+			"code":    "JOB_FAILED",
+			"message": job.Error,
+		}
+	}
+	return jobItem
+}
+
 // getJobDetails returns detailed information about a specific job
 func (s *Server) getJobDetails(ctx context.Context, jobID string) map[string]any {
 	tracer := otel.Tracer("http.admin")
@@ -816,6 +1249,15 @@ func (s *Server) getJobDetails(ctx context.Context, jobID string) map[string]any
 		"cv_id":      job.CVID,
 		"project_id": job.ProjectID,
 	}
+	if job.RequestID != "" {
+		jobDetails["request_id"] = job.RequestID
+	}
+	if len(job.Metadata) > 0 {
+		jobDetails["metadata"] = job.Metadata
+	}
+	if len(job.Tags) > 0 {
+		jobDetails["tags"] = job.Tags
+	}
 
 	// Add error information if job failed
 	if job.Status == domain.JobFailed && job.Error != "" {
@@ -849,8 +1291,3 @@ func getDefaultJobDescription() string {
 func getDefaultStudyCaseBrief() string {
 	return config.GetDefaultStudyCaseBrief()
 }
-
-// getDefaultScoringRubric returns the default scoring rubric from config
-func getDefaultScoringRubric() string {
-	return config.GetDefaultScoringRubric()
-}
@@ -0,0 +1,110 @@
+package httpserver_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	domainmocks "github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func newBatchResultServer(t *testing.T, jobs []domain.Job, results []domain.Result) (*httpserver.Server, *domainmocks.MockJobRepository) {
+	t.Helper()
+	jobRepo := domainmocks.NewMockJobRepository(t)
+	jobRepo.EXPECT().GetMany(mock.Anything, mock.Anything).Return(jobs, nil).Maybe()
+	resultRepo := domainmocks.NewMockResultRepository(t)
+	resultRepo.EXPECT().GetByJobIDs(mock.Anything, mock.Anything).Return(results, nil).Maybe()
+	cfg := config.Config{Port: 8080, AppEnv: "dev"}
+	upSvc := usecase.NewUploadService(nil)
+	evSvc := usecase.NewEvaluateService(jobRepo, nil, nil)
+	resSvc := usecase.NewResultService(jobRepo, resultRepo)
+	return httpserver.NewServer(cfg, upSvc, evSvc, resSvc, nil, nil, nil, nil), jobRepo
+}
+
+func batchRouter(srv *httpserver.Server) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/v1/results", srv.BatchResultsHandler())
+	r.Post("/v1/results", srv.BatchResultsHandler())
+	return r
+}
+
+func TestBatchResultsHandler_GET_ReturnsResultsAndNotFound(t *testing.T) {
+	now := time.Now().UTC()
+	srv, _ := newBatchResultServer(t,
+		[]domain.Job{
+			{ID: "job1", Status: domain.JobCompleted, CreatedAt: now, UpdatedAt: now},
+			{ID: "job2", Status: domain.JobQueued, CreatedAt: now, UpdatedAt: now},
+		},
+		[]domain.Result{{JobID: "job1", CVMatchRate: 0.9, CVFeedback: "good.", ProjectScore: 9, ProjectFeedback: "nice.", OverallSummary: "great."}},
+	)
+	r := httptest.NewRequest(http.MethodGet, "/v1/results?ids=job1,%20job2,job3,job1", nil)
+	r.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	batchRouter(srv).ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Results  map[string]map[string]any `json:"results"`
+		NotFound []string                  `json:"not_found"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Equal(t, "completed", body.Results["job1"]["status"])
+	require.NotNil(t, body.Results["job1"]["result"])
+	require.Equal(t, "queued", body.Results["job2"]["status"])
+	require.Equal(t, []string{"job3"}, body.NotFound)
+}
+
+func TestBatchResultsHandler_POST_JSONBody(t *testing.T) {
+	now := time.Now().UTC()
+	srv, jobRepo := newBatchResultServer(t, []domain.Job{{ID: "job1", Status: domain.JobProcessing, CreatedAt: now, UpdatedAt: now}}, nil)
+	r := httptest.NewRequest(http.MethodPost, "/v1/results", strings.NewReader(`{"ids":["job1"]}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	batchRouter(srv).ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"not_found":[]`)
+	jobRepo.AssertCalled(t, "GetMany", mock.Anything, []string{"job1"})
+}
+
+func TestBatchResultsHandler_Validation(t *testing.T) {
+	srv, _ := newBatchResultServer(t, nil, nil)
+	tooMany := make([]string, 101)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("job%d", i)
+	}
+	cases := map[string]*http.Request{
+		"missing ids":   httptest.NewRequest(http.MethodGet, "/v1/results", nil),
+		"invalid id":    httptest.NewRequest(http.MethodGet, "/v1/results?ids=ok,bad%20id", nil),
+		"too many ids":  httptest.NewRequest(http.MethodGet, "/v1/results?ids="+strings.Join(tooMany, ","), nil),
+		"invalid json":  httptest.NewRequest(http.MethodPost, "/v1/results", strings.NewReader(`{`)),
+		"empty id list": httptest.NewRequest(http.MethodPost, "/v1/results", strings.NewReader(`{"ids":[]}`)),
+	}
+	for name, r := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			batchRouter(srv).ServeHTTP(w, r)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestBatchResultsHandler_NotAcceptable(t *testing.T) {
+	srv, _ := newBatchResultServer(t, nil, nil)
+	r := httptest.NewRequest(http.MethodGet, "/v1/results?ids=job1", nil)
+	r.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+	batchRouter(srv).ServeHTTP(w, r)
+	require.Equal(t, http.StatusNotAcceptable, w.Code)
+}
@@ -100,8 +100,8 @@ func TestEvaluateHandler_400_ValidationFailed(t *testing.T) {
 	evSvc := usecase.NewEvaluateService(jobRepo, queue, upRepo)
 	resSvc := usecase.NewResultService(jobRepo, nil)
 	srv := httpserver.NewServer(cfg, upSvc, evSvc, resSvc, nil, nil, nil, nil)
-	// Missing required fields
-	payload := map[string]any{"cv_id": "cv1"}
+	// Missing both cv_id and project_id
+	payload := map[string]any{"job_description": "jd"}
 	b, _ := json.Marshal(payload)
 	r := httptest.NewRequest(http.MethodPost, "/v1/evaluate", bytes.NewReader(b))
 	r.Header.Set("Content-Type", "application/json")
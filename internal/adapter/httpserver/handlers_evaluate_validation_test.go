@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -48,7 +49,7 @@ func createMockQueueValidation(t *testing.T) *domainmocks.MockQueue {
 func TestEvaluateHandler_ValidationDetails(t *testing.T) {
 	cfg := config.Config{Port: 8080}
 	s := httpserver.NewServer(cfg, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
-	payload := map[string]any{"cv_id": "cv1"} // missing project_id (job_description and study_case_brief are now optional)
+	payload := map[string]any{"job_description": "jd"} // missing both cv_id and project_id
 	b, _ := json.Marshal(payload)
 	r := httptest.NewRequest(http.MethodPost, "/v1/evaluate", bytes.NewReader(b))
 	r.Header.Set("Content-Type", "application/json")
@@ -99,3 +100,137 @@ func TestEvaluateHandler_OptionalFields(t *testing.T) {
 		t.Fatalf("unexpected validation error when optional fields are omitted")
 	}
 }
+
+func TestEvaluateHandler_TTLSeconds(t *testing.T) {
+	cfg := config.Config{Port: 8080}
+	upRepo := createMockUploadRepoValidation(t)
+	jobRepo := domainmocks.NewMockJobRepository(t)
+	jobRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(j domain.Job) bool {
+		return j.ExpiresAt != nil && j.ExpiresAt.Sub(j.CreatedAt) == time.Hour
+	})).Return("job-1", nil).Once()
+	queue := createMockQueueValidation(t)
+	s := httpserver.NewServer(cfg, usecase.NewUploadService(upRepo), usecase.NewEvaluateService(jobRepo, queue, upRepo), usecase.NewResultService(jobRepo, nil), nil, nil, nil, nil)
+
+	post := func(ttl int) int {
+		b, _ := json.Marshal(map[string]any{"cv_id": "cv1", "project_id": "proj1", "ttl_seconds": ttl})
+		r := httptest.NewRequest(http.MethodPost, "/v1/evaluate", bytes.NewReader(b))
+		r.Header.Set("Content-Type", "application/json")
+		rw := httptest.NewRecorder()
+		s.EvaluateHandler()(rw, r)
+		return rw.Result().StatusCode
+	}
+	if code := post(3600); code != http.StatusOK {
+		t.Fatalf("want 200, got %d", code)
+	}
+	if code := post(31 * 24 * 3600); code != http.StatusBadRequest {
+		t.Fatalf("want 400 for a ttl over 30 days, got %d", code)
+	}
+}
+
+func TestEvaluateHandler_OpenRouterProvider(t *testing.T) {
+	cfg := config.Config{Port: 8080}
+	upRepo := createMockUploadRepoValidation(t)
+	jobRepo := createMockJobRepoValidation(t)
+	queue := domainmocks.NewMockQueue(t)
+	queue.EXPECT().EnqueueEvaluate(mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		prefs := p.Overrides.OpenRouterProvider
+		return prefs != nil && prefs.DataCollection == "deny" && len(prefs.Ignore) == 1 && prefs.Ignore[0] == "DeepInfra"
+	})).Return("t-1", nil).Once()
+	s := httpserver.NewServer(cfg, usecase.NewUploadService(upRepo), usecase.NewEvaluateService(jobRepo, queue, upRepo), usecase.NewResultService(jobRepo, nil), nil, nil, nil, nil)
+
+	post := func(dataCollection string) int {
+		b, _ := json.Marshal(map[string]any{"cv_id": "cv1", "project_id": "proj1", "openrouter_provider": map[string]any{
+			"ignore": []string{"DeepInfra"}, "data_collection": dataCollection,
+		}})
+		r := httptest.NewRequest(http.MethodPost, "/v1/evaluate", bytes.NewReader(b))
+		r.Header.Set("Content-Type", "application/json")
+		rw := httptest.NewRecorder()
+		s.EvaluateHandler()(rw, r)
+		return rw.Result().StatusCode
+	}
+	if code := post("deny"); code != http.StatusOK {
+		t.Fatalf("want 200, got %d", code)
+	}
+	if code := post("never"); code != http.StatusBadRequest {
+		t.Fatalf("want 400 for an unknown data_collection, got %d", code)
+	}
+}
+
+func TestEvaluateHandler_SamplingParams(t *testing.T) {
+	cfg := config.Config{Port: 8080}
+	upRepo := createMockUploadRepoValidation(t)
+	jobRepo := createMockJobRepoValidation(t)
+	queue := domainmocks.NewMockQueue(t)
+	queue.EXPECT().EnqueueEvaluate(mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		sp := p.Overrides.Sampling
+		return sp != nil && *sp.Temperature == 0.5 && *sp.TopP == 0.8 && sp.FrequencyPenalty == nil
+	})).Return("t-1", nil).Once()
+	s := httpserver.NewServer(cfg, usecase.NewUploadService(upRepo), usecase.NewEvaluateService(jobRepo, queue, upRepo), usecase.NewResultService(jobRepo, nil), nil, nil, nil, nil)
+
+	post := func(temperature float64) int {
+		b, _ := json.Marshal(map[string]any{"cv_id": "cv1", "project_id": "proj1", "temperature": temperature, "top_p": 0.8})
+		r := httptest.NewRequest(http.MethodPost, "/v1/evaluate", bytes.NewReader(b))
+		r.Header.Set("Content-Type", "application/json")
+		rw := httptest.NewRecorder()
+		s.EvaluateHandler()(rw, r)
+		return rw.Result().StatusCode
+	}
+	if code := post(0.5); code != http.StatusOK {
+		t.Fatalf("want 200, got %d", code)
+	}
+	if code := post(1.5); code != http.StatusBadRequest {
+		t.Fatalf("want 400 for a temperature above 1, got %d", code)
+	}
+}
+
+func TestEvaluateHandler_Priority(t *testing.T) {
+	cfg := config.Config{Port: 8080}
+	upRepo := createMockUploadRepoValidation(t)
+	jobRepo := createMockJobRepoValidation(t)
+	queue := domainmocks.NewMockQueue(t)
+	queue.EXPECT().EnqueueEvaluate(mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.Priority == domain.PriorityInteractive
+	})).Return("t-1", nil).Once()
+	s := httpserver.NewServer(cfg, usecase.NewUploadService(upRepo), usecase.NewEvaluateService(jobRepo, queue, upRepo), usecase.NewResultService(jobRepo, nil), nil, nil, nil, nil)
+
+	post := func(priority string) int {
+		b, _ := json.Marshal(map[string]any{"cv_id": "cv1", "project_id": "proj1", "priority": priority})
+		r := httptest.NewRequest(http.MethodPost, "/v1/evaluate", bytes.NewReader(b))
+		r.Header.Set("Content-Type", "application/json")
+		rw := httptest.NewRecorder()
+		s.EvaluateHandler()(rw, r)
+		return rw.Result().StatusCode
+	}
+	if code := post("interactive"); code != http.StatusOK {
+		t.Fatalf("want 200, got %d", code)
+	}
+	if code := post("urgent"); code != http.StatusBadRequest {
+		t.Fatalf("want 400 for an unknown priority, got %d", code)
+	}
+}
+
+func TestEvaluateHandler_JobLabels(t *testing.T) {
+	cfg := config.Config{Port: 8080}
+	upRepo := createMockUploadRepoValidation(t)
+	jobRepo := domainmocks.NewMockJobRepository(t)
+	jobRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(j domain.Job) bool {
+		return j.Metadata["team"] == "platform" && len(j.Tags) == 1 && j.Tags[0] == "campaign-q4"
+	})).Return("job-1", nil).Once()
+	queue := createMockQueueValidation(t)
+	s := httpserver.NewServer(cfg, usecase.NewUploadService(upRepo), usecase.NewEvaluateService(jobRepo, queue, upRepo), usecase.NewResultService(jobRepo, nil), nil, nil, nil, nil)
+
+	post := func(tag string) int {
+		b, _ := json.Marshal(map[string]any{"cv_id": "cv1", "project_id": "proj1", "metadata": map[string]string{"team": "platform"}, "tags": []string{tag}})
+		r := httptest.NewRequest(http.MethodPost, "/v1/evaluate", bytes.NewReader(b))
+		r.Header.Set("Content-Type", "application/json")
+		rw := httptest.NewRecorder()
+		s.EvaluateHandler()(rw, r)
+		return rw.Result().StatusCode
+	}
+	if code := post("campaign-q4"); code != http.StatusOK {
+		t.Fatalf("want 200, got %d", code)
+	}
+	if code := post("two words"); code != http.StatusBadRequest {
+		t.Fatalf("want 400 for an invalid tag, got %d", code)
+	}
+}
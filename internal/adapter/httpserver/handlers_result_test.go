@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -71,6 +73,36 @@ func TestResultHandler_Completed_ETagCaching(t *testing.T) {
 	_ = resp2.Body.Close()
 }
 
+func TestResultHandler_Completed_CacheHeaders(t *testing.T) {
+	updated := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	srv := newResultServer(t, domain.Job{ID: "job1", Status: domain.JobCompleted, UpdatedAt: updated}, domain.Result{JobID: "job1", CVMatchRate: 0.9, CVFeedback: "good.", ProjectScore: 9, ProjectFeedback: "nice.", OverallSummary: "great overall."})
+	srv.Results.CacheMaxAge = time.Minute
+	router := chi.NewRouter()
+	router.Get("/v1/result/{id}", srv.ResultHandler())
+	r := httptest.NewRequest(http.MethodGet, "/v1/result/job1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "private, max-age=60", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "Fri, 02 Jan 2026 09:00:00 GMT", w.Header().Get("Last-Modified"))
+
+	r2 := httptest.NewRequest(http.MethodGet, "/v1/result/job1", nil)
+	r2.Header.Set("If-Modified-Since", w.Header().Get("Last-Modified"))
+	w2 := httptest.NewRecorder()
+	router.ServeHTTP(w2, r2)
+	assert.Equal(t, http.StatusNotModified, w2.Code)
+
+	// Pending jobs are revalidated on every poll.
+	pending := newResultServer(t, domain.Job{ID: "job2", Status: domain.JobQueued, CreatedAt: time.Now()}, domain.Result{})
+	router = chi.NewRouter()
+	router.Get("/v1/result/{id}", pending.ResultHandler())
+	w3 := httptest.NewRecorder()
+	router.ServeHTTP(w3, httptest.NewRequest(http.MethodGet, "/v1/result/job2", nil))
+	require.Equal(t, http.StatusOK, w3.Code)
+	assert.Equal(t, "no-cache", w3.Header().Get("Cache-Control"))
+	assert.Empty(t, w3.Header().Get("Last-Modified"))
+}
+
 func TestResultHandler_FailedShape_IncludesError(t *testing.T) {
 	srv := newResultServer(t, domain.Job{ID: "job2", Status: domain.JobFailed, Error: "schema invalid: field"}, domain.Result{})
 	router := chi.NewRouter()
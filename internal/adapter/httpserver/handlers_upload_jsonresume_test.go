@@ -0,0 +1,72 @@
+package httpserver_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	domainmocks "github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func postUpload(t *testing.T, srv *httpserver.Server, files, names map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	fields := map[string][]byte{}
+	for k, v := range files {
+		fields[k] = []byte(v)
+	}
+	body, ctype := buildMultipartWithNames2(t, fields, names)
+	r := httptest.NewRequest(http.MethodPost, "/v1/upload", bytes.NewReader(body.Bytes()))
+	r.Header.Set("Content-Type", ctype)
+	w := httptest.NewRecorder()
+	srv.UploadHandler()(w, r)
+	return w
+}
+
+func TestUploadHandler_JSONResumeSkipsExtractor(t *testing.T) {
+	repo := domainmocks.NewMockUploadRepository(t)
+	var stored []domain.Upload
+	repo.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ domain.Context, u domain.Upload) (string, error) {
+		stored = append(stored, u)
+		return "id", nil
+	}).Times(2)
+	// The extractor has no expectations: calling it fails the test.
+	srv := httpserver.NewServer(config.Config{MaxUploadMB: 5}, usecase.NewUploadService(repo), usecase.EvaluateService{}, usecase.ResultService{},
+		domainmocks.NewMockTextExtractor(t), nil, nil, nil)
+
+	w := postUpload(t, srv,
+		map[string]string{"cv": `{"basics": {"name": "Dewi Lestari"}, "skills": [{"keywords": ["Go"]}]}`, "project": "project report"},
+		map[string]string{"cv": "resume.json", "project": "report.txt"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, stored, 2)
+	assert.Equal(t, "Candidate:\nName: Dewi Lestari\n\nSkills:\n- Go", stored[0].Text)
+	assert.Equal(t, "application/json", stored[0].MIME)
+}
+
+func TestUploadHandler_JSONRejected(t *testing.T) {
+	srv := newSrvWithExt(t, createMockTextExtractor(t))
+
+	// Only CVs may be structured documents.
+	w := postUpload(t, srv,
+		map[string]string{"cv": "cv text", "project": `{"basics": {"name": "x"}}`},
+		map[string]string{"cv": "cv.txt", "project": "report.json"})
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+
+	w = postUpload(t, srv,
+		map[string]string{"cv": `{"title": "not a resume"}`, "project": "project report"},
+		map[string]string{"cv": "resume.json", "project": "report.txt"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = postUpload(t, srv,
+		map[string]string{"cv": "plain text", "project": "project report"},
+		map[string]string{"cv": "resume.json", "project": "report.txt"})
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
@@ -105,7 +105,7 @@ func TestUploadHandler_406_NotAcceptable(t *testing.T) {
 func TestUploadHandler_InvalidContentType(t *testing.T) {
 	srv := newSrvWithExt(t, createMockTextExtractor(t))
 	r := httptest.NewRequest(http.MethodPost, "/v1/upload", bytes.NewReader([]byte("not multipart")))
-	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Content-Type", "text/plain")
 	r.Header.Set("Accept", "application/json")
 	w := httptest.NewRecorder()
 	srv.UploadHandler()(w, r)
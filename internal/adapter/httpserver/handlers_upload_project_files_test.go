@@ -0,0 +1,56 @@
+package httpserver_test
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	domainmocks "github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+	"github.com/fairyhunter13/ai-cv-evaluator/pkg/textx"
+)
+
+func TestUploadHandler_MultipleProjectFiles(t *testing.T) {
+	repo := domainmocks.NewMockUploadRepository(t)
+	var stored []domain.Upload
+	repo.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ domain.Context, u domain.Upload) (string, error) {
+		stored = append(stored, u)
+		return "id", nil
+	}).Times(2)
+	srv := httpserver.NewServer(config.Config{MaxUploadMB: 5}, usecase.NewUploadService(repo), usecase.EvaluateService{}, usecase.ResultService{},
+		domainmocks.NewMockTextExtractor(t), nil, nil, nil)
+
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	for _, f := range []struct{ field, name, text string }{
+		{"cv", "cv.txt", "Backend engineer"},
+		{"project", "worker.txt", "package worker\nfunc Run() error { return nil }"},
+		{"project", "README.md", "Evaluation service\nRun make up to start it."},
+	} {
+		fw, err := mw.CreateFormFile(f.field, f.name)
+		require.NoError(t, err)
+		_, _ = fw.Write([]byte(f.text))
+	}
+	require.NoError(t, mw.Close())
+	r := httptest.NewRequest(http.MethodPost, "/v1/upload", body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	srv.UploadHandler()(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, stored, 2)
+	project := stored[1]
+	assert.True(t, textx.HasProjectReadme(project.Text), project.Text)
+	assert.Less(t, strings.Index(project.Text, "Run make up"), strings.Index(project.Text, "func Run()"))
+	assert.Equal(t, "worker.txt, README.md", project.Filename)
+}
@@ -0,0 +1,73 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	domainmocks "github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func postUploadJSON(srv *httpserver.Server, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/v1/upload", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	srv.UploadHandler()(w, r)
+	return w
+}
+
+func TestUploadHandler_Text(t *testing.T) {
+	repo := domainmocks.NewMockUploadRepository(t)
+	var stored []domain.Upload
+	repo.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(func(_ domain.Context, u domain.Upload) (string, error) {
+		stored = append(stored, u)
+		return u.Type + "-1", nil
+	}).Times(2)
+	// The extractor has no expectations: calling it fails the test.
+	srv := httpserver.NewServer(config.Config{MaxUploadMB: 1}, usecase.NewUploadService(repo), usecase.EvaluateService{}, usecase.ResultService{},
+		domainmocks.NewMockTextExtractor(t), nil, nil, nil)
+
+	w := postUploadJSON(srv, `{"cv_text": "  Backend engineer\u0000 with Go  ", "project_text": "Evaluation service report"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"cv_id": "cv-1", "project_id": "project-1"}`, w.Body.String())
+	require.Len(t, stored, 2)
+	assert.Equal(t, "Backend engineer with Go", stored[0].Text)
+	assert.Equal(t, domain.UploadSourceText, stored[0].Source)
+	assert.Equal(t, "Evaluation service report", stored[1].Text)
+	assert.Equal(t, domain.UploadSourceText, stored[1].Source)
+}
+
+func TestUploadHandler_TextInvalid(t *testing.T) {
+	srv := httpserver.NewServer(config.Config{MaxUploadMB: 1}, usecase.NewUploadService(domainmocks.NewMockUploadRepository(t)), usecase.EvaluateService{}, usecase.ResultService{},
+		nil, nil, nil, nil)
+	large := strings.Repeat("a", 1<<20+1)
+	cases := []struct {
+		name, body string
+		status     int
+		field      string
+	}{
+		{"invalid json", `{"cv_text":`, http.StatusBadRequest, ""},
+		{"missing cv", `{"project_text": "report"}`, http.StatusBadRequest, "cv_text"},
+		{"blank project", `{"cv_text": "cv", "project_text": " \n"}`, http.StatusBadRequest, "project_text"},
+		{"cv too large", `{"cv_text": "` + large + `", "project_text": "report"}`, http.StatusRequestEntityTooLarge, "cv_text"},
+		{"body too large", `{"cv_text": "` + large + large + `"}`, http.StatusRequestEntityTooLarge, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := postUploadJSON(srv, tc.body)
+			require.Equal(t, tc.status, w.Code, w.Body.String())
+			if tc.field != "" {
+				assert.Contains(t, w.Body.String(), `"field":"`+tc.field+`"`)
+			}
+		})
+	}
+}
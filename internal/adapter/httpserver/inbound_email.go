@@ -0,0 +1,261 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// InboundEmailHandler accepts the inbound parse webhook of SendGrid or
+// Mailgun. The CV and the optional project report attached to the message
+// are evaluated and the sender gets a reply with the result link, or with the
+// reason the message was rejected. Rejections are answered with 200 so the
+// provider does not redeliver them; messages from senders outside
+// INBOUND_EMAIL_ALLOWED_SENDERS are dropped without a reply.
+func (s *Server) InboundEmailHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracer := otel.Tracer("http.inbound_email")
+		ctx, span := tracer.Start(r.Context(), "Server.InboundEmailHandler")
+		defer span.End()
+
+		secret := chi.URLParam(r, "secret")
+		if s.Cfg.InboundEmailSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(s.Cfg.InboundEmailSecret)) != 1 {
+			writeError(w, r, fmt.Errorf("%w: inbound email endpoint", domain.ErrNotFound), nil)
+			return
+		}
+		// Both documents plus the message body and headers.
+		maxBytes := 2*s.Cfg.MaxUploadMB*1024*1024 + 1<<20
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		if err := r.ParseMultipartForm(maxBytes); err != nil {
+			writeError(w, r, fmt.Errorf("%w: inbound email form: %v", domain.ErrInvalidArgument, err), nil)
+			return
+		}
+		lg := LoggerFrom(r)
+		from := firstFormValue(r, "from", "sender")
+		addr, err := mail.ParseAddress(from)
+		if err != nil {
+			lg.Warn("inbound email without a valid sender", slog.String("from", from))
+			writeJSON(w, http.StatusOK, map[string]string{"status": "ignored"})
+			return
+		}
+		sender := strings.ToLower(addr.Address)
+		if !inboundSenderAllowed(s.Cfg.InboundEmailAllowedSenders, sender) ||
+			!inboundRecipientMatches(s.Cfg.InboundEmailAddress, firstFormValue(r, "to", "recipient")) {
+			lg.Warn("inbound email dropped", slog.String("sender", sender))
+			writeJSON(w, http.StatusOK, map[string]string{"status": "ignored"})
+			return
+		}
+		subject := firstFormValue(r, "subject")
+
+		jobID, err := s.submitInboundEmail(ctx, sender, subject, r.MultipartForm)
+		if err != nil {
+			if !errors.Is(err, domain.ErrInvalidArgument) {
+				// Let the provider redeliver the message.
+				writeError(w, r, err, nil)
+				return
+			}
+			lg.Info("inbound email rejected", slog.String("sender", sender), slog.Any("error", err))
+			s.replyInboundEmail(ctx, sender, subject, "Your submission could not be evaluated: "+err.Error()+
+				"\n\nAttach the CV and, optionally, the project report as .pdf, .docx or .txt files and send the message again.")
+			writeJSON(w, http.StatusOK, map[string]string{"status": "rejected", "error": err.Error()})
+			return
+		}
+		lg.Info("inbound email queued", slog.String("sender", sender), slog.String("job_id", jobID))
+		s.replyInboundEmail(ctx, sender, subject, "Your submission was queued for evaluation.\n\n"+
+			"Job: "+jobID+"\nResult: "+s.resultLink(r, jobID)+"\n\nThe result is available at the link once the evaluation completes.")
+		writeJSON(w, http.StatusOK, map[string]string{"id": jobID, "status": string(domain.JobQueued)})
+	}
+}
+
+// submitInboundEmail ingests the attachments of a message and enqueues their
+// evaluation. Redeliveries of the same message return the job queued first.
+func (s *Server) submitInboundEmail(ctx context.Context, sender, subject string, form *multipart.Form) (string, error) {
+	cvFile, projectFile := classifyInboundAttachments(form)
+	if cvFile == nil {
+		return "", fmt.Errorf("%w: no CV attachment found", domain.ErrInvalidArgument)
+	}
+	h := sha256.New()
+	_, _ = io.WriteString(h, sender+"\x00"+subject)
+	docs := map[string][]byte{}
+	for uploadType, fh := range map[string]*multipart.FileHeader{domain.UploadTypeCV: cvFile, domain.UploadTypeProject: projectFile} {
+		if fh == nil {
+			continue
+		}
+		data, err := readFormFile(fh)
+		if err != nil {
+			return "", err
+		}
+		docs[uploadType] = data
+	}
+	for _, uploadType := range []string{domain.UploadTypeCV, domain.UploadTypeProject} {
+		sum := sha256.Sum256(docs[uploadType])
+		_, _ = h.Write(sum[:])
+	}
+	idemKey := "email-" + hex.EncodeToString(h.Sum(nil)[:16])
+	if s.Evaluate.Jobs != nil {
+		if j, err := s.Evaluate.Jobs.FindByIdempotencyKey(ctx, idemKey); err == nil && j.ID != "" {
+			return j.ID, nil
+		}
+	}
+	ids := map[string]string{}
+	for uploadType, fh := range map[string]*multipart.FileHeader{domain.UploadTypeCV: cvFile, domain.UploadTypeProject: projectFile} {
+		if fh == nil {
+			continue
+		}
+		id, err := s.IngestDocument(ctx, uploadType, fh.Filename, docs[uploadType])
+		if err != nil {
+			return "", err
+		}
+		ids[uploadType] = id
+	}
+	jobDescription, studyCase := getDefaultJobDescription(), ""
+	if ids[domain.UploadTypeProject] != "" {
+		studyCase = getDefaultStudyCaseBrief()
+	}
+	jobID, err := s.Evaluate.Enqueue(ctx, ids[domain.UploadTypeCV], ids[domain.UploadTypeProject], jobDescription, studyCase, "", idemKey)
+	if err != nil {
+		return "", fmt.Errorf("enqueue: %w", err)
+	}
+	return jobID, nil
+}
+
+func readFormFile(fh *multipart.FileHeader) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: attachment %s: %v", domain.ErrInvalidArgument, fh.Filename, err)
+	}
+	defer func() { _ = f.Close() }()
+	return io.ReadAll(f)
+}
+
+// classifyInboundAttachments picks the CV and project report among the
+// attached documents. A name mentioning the project or report marks the
+// project and one mentioning a CV or resume the CV; otherwise the first
+// document is the CV and the second the project. Other files, such as
+// signature images, are ignored.
+func classifyInboundAttachments(form *multipart.Form) (cv, project *multipart.FileHeader) {
+	var fields []string
+	for field := range form.File {
+		fields = append(fields, field)
+	}
+	// attachment1, attachment2, ... in the order they were attached.
+	sort.Slice(fields, func(i, j int) bool {
+		if len(fields[i]) != len(fields[j]) {
+			return len(fields[i]) < len(fields[j])
+		}
+		return fields[i] < fields[j]
+	})
+	var unnamed []*multipart.FileHeader
+	for _, field := range fields {
+		for _, fh := range form.File[field] {
+			if !allowedCVExt(fh.Filename) {
+				continue
+			}
+			name := strings.ToLower(fh.Filename)
+			switch {
+			case project == nil && (strings.Contains(name, "project") || strings.Contains(name, "report")):
+				project = fh
+			case cv == nil && (strings.Contains(name, "cv") || strings.Contains(name, "resume")):
+				cv = fh
+			default:
+				unnamed = append(unnamed, fh)
+			}
+		}
+	}
+	for _, fh := range unnamed {
+		switch {
+		case cv == nil:
+			cv = fh
+		case project == nil:
+			project = fh
+		}
+	}
+	return cv, project
+}
+
+// inboundSenderAllowed reports whether sender matches an address or @domain
+// of the comma-separated allowlist; an empty list allows everyone.
+func inboundSenderAllowed(allowlist, sender string) bool {
+	if strings.TrimSpace(allowlist) == "" {
+		return true
+	}
+	for _, entry := range strings.Split(allowlist, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if strings.HasPrefix(entry, "@") && strings.HasSuffix(sender, entry) || entry == sender {
+			return true
+		}
+	}
+	return false
+}
+
+// inboundRecipientMatches reports whether the To header names address; an
+// empty address accepts any recipient.
+func inboundRecipientMatches(address, to string) bool {
+	if address == "" {
+		return true
+	}
+	list, err := mail.ParseAddressList(to)
+	if err != nil {
+		return strings.Contains(strings.ToLower(to), strings.ToLower(address))
+	}
+	for _, a := range list {
+		if strings.EqualFold(a.Address, address) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstFormValue(r *http.Request, names ...string) string {
+	for _, n := range names {
+		if v := strings.TrimSpace(r.FormValue(n)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// resultLink is the URL of the job's result, rooted at PUBLIC_BASE_URL or,
+// when unset, at the host the webhook was delivered to.
+func (s *Server) resultLink(r *http.Request, jobID string) string {
+	base := strings.TrimRight(s.Cfg.PublicBaseURL, "/")
+	if base == "" {
+		scheme := "https"
+		if r.TLS == nil && r.Header.Get("X-Forwarded-Proto") != "https" {
+			scheme = "http"
+		}
+		base = scheme + "://" + r.Host
+	}
+	return base + "/v1/result/" + jobID
+}
+
+// replyInboundEmail answers the sender when a mailer is configured.
+func (s *Server) replyInboundEmail(ctx context.Context, to, subject, body string) {
+	if s.InboundMailer == nil {
+		return
+	}
+	if !strings.HasPrefix(strings.ToLower(subject), "re:") {
+		subject = strings.TrimSpace("Re: " + subject)
+	}
+	if err := s.InboundMailer.Send(ctx, domain.Email{To: []string{to}, Subject: subject, Body: body}); err != nil {
+		slog.Warn("inbound email reply failed", slog.String("to", to), slog.Any("error", err))
+	}
+}
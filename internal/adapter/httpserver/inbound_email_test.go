@@ -0,0 +1,129 @@
+package httpserver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type capturingMailer struct{ sent []domain.Email }
+
+func (m *capturingMailer) Send(_ context.Context, e domain.Email) error {
+	m.sent = append(m.sent, e)
+	return nil
+}
+
+func newInboundEmailRouter(jobs domain.JobRepository, q domain.Queue, uploads domain.UploadRepository, mailer domain.Mailer) *chi.Mux {
+	srv := httpserver.NewServer(config.Config{
+		MaxUploadMB:                1,
+		InboundEmailSecret:         "s3cret",
+		InboundEmailAddress:        "cv@hire.example",
+		InboundEmailAllowedSenders: "@agency.example,boss@other.example",
+		PublicBaseURL:              "https://cv.example/",
+	}, usecase.NewUploadService(uploads), usecase.NewEvaluateService(jobs, q, uploads), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.InboundMailer = mailer
+	r := chi.NewRouter()
+	r.Post("/v1/inbound/email/{secret}", srv.InboundEmailHandler())
+	return r
+}
+
+func postInboundEmail(t *testing.T, r *chi.Mux, secret string, fields map[string]string, files map[string][2]string) *httptest.ResponseRecorder {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	for k, v := range fields {
+		require.NoError(t, w.WriteField(k, v))
+	}
+	for field, f := range files {
+		fw, err := w.CreateFormFile(field, f[0])
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(f[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	req := httptest.NewRequest(http.MethodPost, "/v1/inbound/email/"+secret, buf)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rw := httptest.NewRecorder()
+	r.ServeHTTP(rw, req)
+	return rw
+}
+
+func TestInboundEmail_QueuesAttachmentsAndReplies(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	q := mocks.NewMockQueue(t)
+	uploads := mocks.NewMockUploadRepository(t)
+	mailer := &capturingMailer{}
+	jobs.EXPECT().FindByIdempotencyKey(mock.Anything, mock.Anything).Return(domain.Job{}, domain.ErrNotFound).Times(2)
+	uploads.EXPECT().Create(mock.Anything, mock.MatchedBy(func(u domain.Upload) bool {
+		return u.Type == domain.UploadTypeCV && u.Filename == "jane.txt"
+	})).Return("cv-1", nil).Once()
+	uploads.EXPECT().Create(mock.Anything, mock.MatchedBy(func(u domain.Upload) bool {
+		return u.Type == domain.UploadTypeProject && u.Filename == "Project Report.txt"
+	})).Return("pr-1", nil).Once()
+	jobs.EXPECT().Create(mock.Anything, mock.MatchedBy(func(j domain.Job) bool {
+		return j.CVID == "cv-1" && j.ProjectID == "pr-1"
+	})).Return("job-1", nil).Once()
+	q.EXPECT().EnqueueEvaluate(mock.Anything, mock.Anything).Return("task", nil).Once()
+
+	r := newInboundEmailRouter(jobs, q, uploads, mailer)
+	rw := postInboundEmail(t, r, "s3cret",
+		map[string]string{"from": "Recruiter <Ann@Agency.example>", "to": "Hiring <cv@hire.example>", "subject": "Jane Doe"},
+		map[string][2]string{
+			"attachment1": {"Project Report.txt", "A queue-backed evaluation service."},
+			"attachment2": {"logo.png", "\x89PNG"},
+			"attachment3": {"jane.txt", "Jane Doe, Go engineer."},
+		})
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body))
+	require.Equal(t, "job-1", body["id"])
+
+	require.Len(t, mailer.sent, 1)
+	require.Equal(t, []string{"ann@agency.example"}, mailer.sent[0].To)
+	require.Equal(t, "Re: Jane Doe", mailer.sent[0].Subject)
+	require.Contains(t, mailer.sent[0].Body, "https://cv.example/v1/result/job-1")
+}
+
+func TestInboundEmail_RejectsWithoutDocuments(t *testing.T) {
+	mailer := &capturingMailer{}
+	r := newInboundEmailRouter(nil, nil, nil, mailer)
+	rw := postInboundEmail(t, r, "s3cret",
+		map[string]string{"sender": "boss@other.example", "recipient": "cv@hire.example", "subject": "Re: candidate"},
+		map[string][2]string{"attachment-1": {"photo.png", "\x89PNG"}})
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), "rejected")
+	require.Len(t, mailer.sent, 1)
+	require.Equal(t, "Re: candidate", mailer.sent[0].Subject)
+	require.Contains(t, mailer.sent[0].Body, "no CV attachment")
+}
+
+func TestInboundEmail_DropsUnknownSendersAndSecrets(t *testing.T) {
+	mailer := &capturingMailer{}
+	r := newInboundEmailRouter(nil, nil, nil, mailer)
+	files := map[string][2]string{"attachment1": {"cv.txt", "cv"}}
+
+	rw := postInboundEmail(t, r, "wrong", map[string]string{"from": "ann@agency.example", "to": "cv@hire.example"}, files)
+	require.Equal(t, http.StatusNotFound, rw.Code)
+
+	rw = postInboundEmail(t, r, "s3cret", map[string]string{"from": "eve@agency.example.evil", "to": "cv@hire.example"}, files)
+	require.Equal(t, http.StatusOK, rw.Code)
+	require.Contains(t, rw.Body.String(), "ignored")
+
+	rw = postInboundEmail(t, r, "s3cret", map[string]string{"from": "ann@agency.example", "to": "other@hire.example"}, files)
+	require.Contains(t, rw.Body.String(), "ignored")
+	require.Empty(t, mailer.sent)
+}
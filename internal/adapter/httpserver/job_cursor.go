@@ -0,0 +1,168 @@
+package httpserver
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// dateOnlyLayout is accepted for from/to filters in addition to RFC3339.
+const dateOnlyLayout = "2006-01-02"
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// jobListParams carries the validated query parameters of a keyset job listing.
+type jobListParams struct {
+	Filter       domain.JobListFilter
+	IncludeTotal bool
+}
+
+// encodeJobCursor serializes a cursor into an opaque URL-safe token.
+func encodeJobCursor(c domain.JobCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeJobCursor parses a token produced by encodeJobCursor.
+func decodeJobCursor(token string) (domain.JobCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return domain.JobCursor{}, errInvalidCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return domain.JobCursor{}, errInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return domain.JobCursor{}, errInvalidCursor
+	}
+	if v := ValidateJobID(id); !v.Valid {
+		return domain.JobCursor{}, errInvalidCursor
+	}
+	return domain.JobCursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// parseDateParam accepts RFC3339 timestamps or YYYY-MM-DD dates. A date-only
+// upper bound is moved to the start of the following day so "to=2025-01-31"
+// includes the whole of January 31st.
+func parseDateParam(v string, upper bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(dateOnlyLayout, v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if upper {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// parseJobListParams validates the keyset listing parameters (limit, search,
+// status, request_id, tag, metadata, sort, from, to, cursor, include_total)
+// from the query string. tag and metadata may repeat; metadata filters are
+// written key:value.
+func parseJobListParams(q url.Values) (jobListParams, ValidationResult) {
+	var errs []ValidationError
+	p := jobListParams{Filter: domain.JobListFilter{
+		Search:    SanitizeString(q.Get("search")),
+		Status:    SanitizeString(q.Get("status")),
+		RequestID: q.Get("request_id"),
+		Sort:      domain.JobSortCreatedDesc,
+		Limit:     10,
+	}}
+
+	if v := ValidatePagination("", q.Get("limit")); !v.Valid {
+		errs = append(errs, v.Errors...)
+	} else if l, err := strconv.Atoi(q.Get("limit")); err == nil {
+		p.Filter.Limit = l
+	}
+	if v := ValidateSearchQuery(p.Filter.Search); !v.Valid {
+		errs = append(errs, v.Errors...)
+	}
+	if v := ValidateStatus(p.Filter.Status); !v.Valid {
+		errs = append(errs, v.Errors...)
+	}
+	if p.Filter.RequestID != "" && !validRequestID(p.Filter.RequestID) {
+		errs = append(errs, ValidationError{Field: "request_id", Code: "INVALID_FORMAT", Message: "Request ID must be at most 128 letters, digits or -_.: characters"})
+	}
+	if tags, err := usecase.ValidateJobLabels(domain.JobLabels{Tags: q["tag"]}); err != nil {
+		errs = append(errs, ValidationError{Field: "tag", Code: "INVALID_FORMAT", Message: labelErrorMessage(err)})
+	} else {
+		p.Filter.Tags = tags.Tags
+	}
+	metadata := map[string]string{}
+	for _, pair := range q["metadata"] {
+		k, v, ok := strings.Cut(pair, ":")
+		if !ok {
+			errs = append(errs, ValidationError{Field: "metadata", Code: "INVALID_FORMAT", Message: "Metadata filters must be key:value"})
+			break
+		}
+		metadata[k] = v
+	}
+	if md, err := usecase.ValidateJobLabels(domain.JobLabels{Metadata: metadata}); err != nil {
+		errs = append(errs, ValidationError{Field: "metadata", Code: "INVALID_FORMAT", Message: labelErrorMessage(err)})
+	} else {
+		p.Filter.Metadata = md.Metadata
+	}
+
+	switch sort := domain.JobSortOrder(q.Get("sort")); sort {
+	case "":
+	case domain.JobSortCreatedDesc, domain.JobSortCreatedAsc:
+		p.Filter.Sort = sort
+	default:
+		errs = append(errs, ValidationError{
+			Field:   "sort",
+			Code:    "INVALID_VALUE",
+			Message: "Sort must be one of: created_at_desc, created_at_asc",
+		})
+	}
+
+	if v := q.Get("from"); v != "" {
+		t, err := parseDateParam(v, false)
+		if err != nil {
+			errs = append(errs, ValidationError{Field: "from", Code: "INVALID_FORMAT", Message: "From must be RFC3339 or YYYY-MM-DD"})
+		}
+		p.Filter.CreatedFrom = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := parseDateParam(v, true)
+		if err != nil {
+			errs = append(errs, ValidationError{Field: "to", Code: "INVALID_FORMAT", Message: "To must be RFC3339 or YYYY-MM-DD"})
+		}
+		p.Filter.CreatedTo = t
+	}
+	if !p.Filter.CreatedFrom.IsZero() && !p.Filter.CreatedTo.IsZero() && !p.Filter.CreatedFrom.Before(p.Filter.CreatedTo) {
+		errs = append(errs, ValidationError{Field: "to", Code: "INVALID_RANGE", Message: "To must be after from"})
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		c, err := decodeJobCursor(v)
+		if err != nil {
+			errs = append(errs, ValidationError{Field: "cursor", Code: "INVALID_FORMAT", Message: "Cursor is malformed"})
+		} else {
+			p.Filter.After = &c
+		}
+	}
+
+	p.IncludeTotal = q.Get("include_total") == "true"
+
+	if len(errs) > 0 {
+		return p, ValidationResult{Valid: false, Errors: errs}
+	}
+	return p, ValidationResult{Valid: true}
+}
+
+// labelErrorMessage is the message of a job label validation error without
+// its error kind prefix.
+func labelErrorMessage(err error) string {
+	return strings.TrimPrefix(err.Error(), domain.ErrInvalidArgument.Error()+": ")
+}
@@ -0,0 +1,113 @@
+package httpserver
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestJobCursor_RoundTrip(t *testing.T) {
+	in := domain.JobCursor{CreatedAt: time.Date(2025, 3, 1, 10, 0, 0, 123456789, time.UTC), ID: "job-42"}
+	out, err := decodeJobCursor(encodeJobCursor(in))
+	require.NoError(t, err)
+	assert.True(t, in.CreatedAt.Equal(out.CreatedAt))
+	assert.Equal(t, in.ID, out.ID)
+
+	for _, bad := range []string{"%%%", "bm9waXBl", encodeJobCursor(domain.JobCursor{CreatedAt: time.Now(), ID: "bad id"})} {
+		_, err := decodeJobCursor(bad)
+		assert.ErrorIs(t, err, errInvalidCursor, bad)
+	}
+}
+
+func TestParseJobListParams(t *testing.T) {
+	cursor := encodeJobCursor(domain.JobCursor{CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), ID: "job-1"})
+	q := url.Values{
+		"limit":         {"25"},
+		"status":        {"failed"},
+		"sort":          {"created_at_asc"},
+		"from":          {"2025-01-01"},
+		"to":            {"2025-01-31"},
+		"cursor":        {cursor},
+		"include_total": {"true"},
+		"tag":           {"campaign-q4", "urgent"},
+		"metadata":      {"team:platform", "req:R-1"},
+	}
+	p, v := parseJobListParams(q)
+	require.True(t, v.Valid, v.Errors)
+	assert.Equal(t, 25, p.Filter.Limit)
+	assert.Equal(t, "failed", p.Filter.Status)
+	assert.Equal(t, domain.JobSortCreatedAsc, p.Filter.Sort)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), p.Filter.CreatedFrom)
+	assert.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), p.Filter.CreatedTo)
+	require.NotNil(t, p.Filter.After)
+	assert.Equal(t, "job-1", p.Filter.After.ID)
+	assert.True(t, p.IncludeTotal)
+	assert.Equal(t, []string{"campaign-q4", "urgent"}, p.Filter.Tags)
+	assert.Equal(t, map[string]string{"team": "platform", "req": "R-1"}, p.Filter.Metadata)
+
+	p, v = parseJobListParams(url.Values{})
+	require.True(t, v.Valid)
+	assert.Equal(t, 10, p.Filter.Limit)
+	assert.Equal(t, domain.JobSortCreatedDesc, p.Filter.Sort)
+
+	_, v = parseJobListParams(url.Values{
+		"sort":     {"id"},
+		"from":     {"yesterday"},
+		"cursor":   {"%%%"},
+		"limit":    {"1000"},
+		"tag":      {"bad tag"},
+		"metadata": {"team"},
+	})
+	require.False(t, v.Valid)
+	fields := map[string]bool{}
+	for _, e := range v.Errors {
+		fields[e.Field] = true
+	}
+	assert.True(t, fields["sort"])
+	assert.True(t, fields["from"])
+	assert.True(t, fields["cursor"])
+	assert.True(t, fields["limit"])
+	assert.True(t, fields["tag"])
+	assert.True(t, fields["metadata"])
+
+	_, v = parseJobListParams(url.Values{"from": {"2025-02-01"}, "to": {"2025-01-01"}})
+	require.False(t, v.Valid)
+	assert.Equal(t, "INVALID_RANGE", v.Errors[0].Code)
+}
+
+func TestServer_getJobsByCursor_HasMoreAndNextCursor(t *testing.T) {
+	now := time.Now().UTC()
+	jobRepo := mocks.NewMockJobRepository(t)
+	jobRepo.EXPECT().ListByCursor(mock.Anything, mock.MatchedBy(func(f domain.JobListFilter) bool {
+		return f.Limit == 3
+	})).Return([]domain.Job{
+		{ID: "job-3", Status: domain.JobQueued, CreatedAt: now},
+		{ID: "job-2", Status: domain.JobQueued, CreatedAt: now.Add(-time.Second)},
+		{ID: "job-1", Status: domain.JobQueued, CreatedAt: now.Add(-2 * time.Second)},
+	}, nil)
+	jobRepo.EXPECT().CountByFilter(mock.Anything, mock.Anything).Return(int64(3), nil)
+	server := &Server{Evaluate: usecase.NewEvaluateService(jobRepo, nil, nil)}
+
+	res := server.getJobsByCursor(context.Background(), jobListParams{
+		Filter:       domain.JobListFilter{Sort: domain.JobSortCreatedDesc, Limit: 2},
+		IncludeTotal: true,
+	})
+	jobs := res["jobs"].([]map[string]any)
+	require.Len(t, jobs, 2)
+	pagination := res["pagination"].(map[string]any)
+	assert.Equal(t, true, pagination["has_more"])
+	assert.Equal(t, int64(3), pagination["total"])
+
+	next, err := decodeJobCursor(pagination["next_cursor"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "job-2", next.ID)
+}
@@ -36,12 +36,36 @@ func Recoverer() func(http.Handler) http.Handler {
 	}
 }
 
-// RequestID injects a request id and correlates with tracing ids.
+// maxRequestIDLen bounds client-supplied request ids, which end up in logs,
+// job rows, queue headers and AI provider requests.
+const maxRequestIDLen = 128
+
+// validRequestID reports whether a client-supplied X-Request-Id is safe to
+// propagate: non-empty, bounded and limited to URL/header friendly characters.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RequestID accepts a valid X-Request-Id from the client or generates one,
+// echoes it in the response and stores it in the request context, where it
+// is carried to the job row, the queue and the AI provider calls. It also
+// correlates the request logger with tracing ids.
 func RequestID() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			reqID := r.Header.Get("X-Request-Id")
-			if reqID == "" {
+			if !validRequestID(reqID) {
 				reqID = newReqID()
 				r.Header.Set("X-Request-Id", reqID)
 			}
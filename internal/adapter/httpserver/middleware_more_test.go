@@ -3,8 +3,11 @@ package httpserver
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
 )
 
 func Test_SecurityHeaders(t *testing.T) {
@@ -32,6 +35,33 @@ func Test_RequestID_SetsHeader(t *testing.T) {
 	}
 }
 
+func Test_RequestID_AcceptsValidClientID(t *testing.T) {
+	cases := map[string]bool{
+		"support-case-42":        true,
+		"01J9ZK4V6T3Q.2:abc_D":   true,
+		"bad id":                 false,
+		"evil\r\nX-Injected: 1":  false,
+		strings.Repeat("a", 129): false,
+	}
+	for in, keep := range cases {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/x", nil)
+		r.Header.Set("X-Request-Id", in)
+		var ctxID string
+		RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctxID = obsctx.RequestIDFromContext(r.Context())
+			w.WriteHeader(204)
+		})).ServeHTTP(rec, r)
+		got := rec.Result().Header.Get("X-Request-Id")
+		if got == "" || got != ctxID {
+			t.Fatalf("%q: response id %q, context id %q", in, got, ctxID)
+		}
+		if (got == in) != keep {
+			t.Fatalf("%q: kept=%v, want %v", in, got == in, keep)
+		}
+	}
+}
+
 func Test_Recoverer_HandlesPanic(t *testing.T) {
 	rec := httptest.NewRecorder()
 	r := httptest.NewRequest(http.MethodGet, "/x", nil)
@@ -0,0 +1,76 @@
+package httpserver_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func newQuotaRouter(t *testing.T, jobs *mocks.MockJobRepository, queue *mocks.MockQueue, quota domain.TenantQuotaRepository) http.Handler {
+	t.Helper()
+	tenants := mocks.NewMockTenantSettingsRepository(t)
+	tenants.EXPECT().GetByAPIKeyHash(mock.Anything, mock.Anything).Return(domain.TenantSettings{TenantID: "acme", DailyEvaluations: 10}, nil)
+	eval := usecase.NewEvaluateService(jobs, queue, nil)
+	eval.Tenants = usecase.NewTenantService(tenants)
+	eval.Quota = usecase.NewQuotaService(quota, 0.8)
+	srv := httpserver.NewServer(config.Config{Port: 8080}, usecase.NewUploadService(nil), eval, usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	r := chi.NewRouter()
+	r.Post("/v1/evaluate", srv.EvaluateHandler())
+	return r
+}
+
+func evaluateWithKey() *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/v1/evaluate", strings.NewReader(`{"cv_id":"cv-1","project_id":"pr-1"}`))
+	req.Header.Set("X-API-Key", "k1")
+	return req
+}
+
+func Test_Evaluate_QuotaWarningHeaders(t *testing.T) {
+	jobs := &mocks.MockJobRepository{}
+	queue := &mocks.MockQueue{}
+	quota := mocks.NewMockTenantQuotaRepository(t)
+	jobs.On("Create", mock.Anything, mock.Anything).Return("job-1", nil)
+	queue.On("EnqueueEvaluate", mock.Anything, mock.Anything).Return("t1", nil)
+	r := newQuotaRouter(t, jobs, queue, quota)
+
+	// Below the warning ratio no headers are set.
+	quota.EXPECT().Consume(mock.Anything, "acme", mock.Anything, 10, int64(0)).Return(domain.TenantQuotaUsage{Evaluations: 8}, nil).Once()
+	rw := httptest.NewRecorder()
+	r.ServeHTTP(rw, evaluateWithKey())
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Empty(t, rw.Header().Get("X-Quota-Remaining"))
+
+	quota.EXPECT().Consume(mock.Anything, "acme", mock.Anything, 10, int64(0)).Return(domain.TenantQuotaUsage{Evaluations: 9}, nil).Once()
+	rw = httptest.NewRecorder()
+	r.ServeHTTP(rw, evaluateWithKey())
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "1", rw.Header().Get("X-Quota-Remaining"))
+	assert.NotEmpty(t, rw.Header().Get("X-Quota-Reset"))
+	assert.Empty(t, rw.Header().Get("X-Quota-Remaining-Tokens"))
+}
+
+func Test_Evaluate_QuotaExhaustedReturns429(t *testing.T) {
+	quota := mocks.NewMockTenantQuotaRepository(t)
+	quota.EXPECT().Consume(mock.Anything, "acme", mock.Anything, 10, int64(0)).
+		Return(domain.TenantQuotaUsage{}, fmt.Errorf("op=tenant_quota.consume: %w", domain.ErrRateLimited)).Once()
+	r := newQuotaRouter(t, &mocks.MockJobRepository{}, &mocks.MockQueue{}, quota)
+
+	rw := httptest.NewRecorder()
+	r.ServeHTTP(rw, evaluateWithKey())
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "0", rw.Header().Get("X-Quota-Remaining"))
+	assert.NotEmpty(t, rw.Header().Get("Retry-After"))
+	assert.Equal(t, rw.Header().Get("X-Quota-Reset"), rw.Header().Get("Retry-After"))
+}
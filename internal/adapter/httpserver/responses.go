@@ -31,6 +31,12 @@ func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 }
 
 func writeError(w http.ResponseWriter, _ *http.Request, err error, details interface{}) {
+	code, codeStr := errorStatus(err)
+	writeJSON(w, code, errorEnvelope{Error: apiError{Code: codeStr, Message: err.Error(), Details: details}})
+}
+
+// errorStatus maps err to its HTTP status and API error code.
+func errorStatus(err error) (int, string) {
 	code := http.StatusInternalServerError
 	codeStr := "INTERNAL"
 	switch {
@@ -46,15 +52,27 @@ func writeError(w http.ResponseWriter, _ *http.Request, err error, details inter
 	case errors.Is(err, domain.ErrRateLimited):
 		code = http.StatusTooManyRequests
 		codeStr = "RATE_LIMITED"
+	case errors.Is(err, domain.ErrBacklogFull):
+		code = http.StatusTooManyRequests
+		codeStr = "BACKLOG_FULL"
 	case errors.Is(err, domain.ErrUpstreamTimeout):
 		code = http.StatusServiceUnavailable
 		codeStr = "UPSTREAM_TIMEOUT"
 	case errors.Is(err, domain.ErrUpstreamRateLimit):
 		code = http.StatusServiceUnavailable
 		codeStr = "UPSTREAM_RATE_LIMIT"
+	case errors.Is(err, domain.ErrQuotaExceeded):
+		code = http.StatusServiceUnavailable
+		codeStr = "QUOTA_EXCEEDED"
+	case errors.Is(err, domain.ErrMaintenance):
+		code = http.StatusServiceUnavailable
+		codeStr = "MAINTENANCE"
+	case errors.Is(err, domain.ErrAISaturated):
+		code = http.StatusServiceUnavailable
+		codeStr = "AI_SATURATED"
 	case errors.Is(err, domain.ErrSchemaInvalid):
 		code = http.StatusServiceUnavailable
 		codeStr = "SCHEMA_INVALID"
 	}
-	writeJSON(w, code, errorEnvelope{Error: apiError{Code: codeStr, Message: err.Error(), Details: details}})
+	return code, codeStr
 }
@@ -0,0 +1,112 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ResultDiffer compares stored versions of evaluation results. It is
+// implemented by usecase.ResultDiffService.
+type ResultDiffer interface {
+	Diff(ctx context.Context, id string, from, to int) (domain.ResultDiff, error)
+}
+
+type resultVersionView struct {
+	Version    int                     `json:"version"`
+	CreatedAt  time.Time               `json:"created_at"`
+	Provenance []domain.StepProvenance `json:"provenance,omitempty"`
+}
+
+type scoreChangeView struct {
+	From  *float64 `json:"from"`
+	To    *float64 `json:"to"`
+	Delta *float64 `json:"delta"`
+}
+
+type sentenceChangeView struct {
+	Change   string `json:"change"`
+	Text     string `json:"text"`
+	Previous string `json:"previous,omitempty"`
+}
+
+type resultDiffView struct {
+	ID       string                          `json:"id"`
+	From     resultVersionView               `json:"from"`
+	To       resultVersionView               `json:"to"`
+	Scores   map[string]scoreChangeView      `json:"scores"`
+	Feedback map[string][]sentenceChangeView `json:"feedback"`
+	// FeedbackEncrypted is set when the feedback is encrypted for the tenant
+	// and therefore not diffed.
+	FeedbackEncrypted bool `json:"feedback_encrypted,omitempty"`
+}
+
+// ResultDiffHandler compares two versions of a job's result. The optional
+// "from" and "to" query parameters select the versions; by default the newest
+// version is compared with the one before it.
+func (s *Server) ResultDiffHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if id == "" {
+			writeError(w, r, fmt.Errorf("%w: id missing", domain.ErrInvalidArgument), nil)
+			return
+		}
+		versions := map[string]int{}
+		for _, name := range []string{"from", "to"} {
+			raw := r.URL.Query().Get(name)
+			if raw == "" {
+				continue
+			}
+			v, err := strconv.Atoi(raw)
+			if err != nil || v < 1 {
+				writeError(w, r, fmt.Errorf("%w: %s must be a positive version number", domain.ErrInvalidArgument, name), map[string]string{name: "min"})
+				return
+			}
+			versions[name] = v
+		}
+		d, err := s.Diffs.Diff(r.Context(), id, versions["from"], versions["to"])
+		if err != nil {
+			writeError(w, r, err, nil)
+			return
+		}
+		s.recordResultAccess(r, accessResultDiff, id)
+		writeJSON(w, http.StatusOK, resultDiffView{
+			ID:   id,
+			From: resultVersionView{Version: d.From, CreatedAt: d.FromCreatedAt, Provenance: d.FromProvenance},
+			To:   resultVersionView{Version: d.To, CreatedAt: d.ToCreatedAt, Provenance: d.ToProvenance},
+			Scores: map[string]scoreChangeView{
+				"cv_match_rate": newScoreChangeView(d.CVMatchRate),
+				"project_score": newScoreChangeView(d.ProjectScore),
+			},
+			Feedback: map[string][]sentenceChangeView{
+				"cv_feedback":      newSentenceChangeViews(d.CVFeedback),
+				"project_feedback": newSentenceChangeViews(d.ProjectFeedback),
+				"overall_summary":  newSentenceChangeViews(d.OverallSummary),
+			},
+			FeedbackEncrypted: d.FeedbackEncrypted,
+		})
+	}
+}
+
+func newScoreChangeView(c domain.ScoreChange) scoreChangeView {
+	v := scoreChangeView{From: c.From, To: c.To}
+	if c.From != nil && c.To != nil {
+		delta := c.Delta
+		v.Delta = &delta
+	}
+	return v
+}
+
+func newSentenceChangeViews(changes []domain.SentenceChange) []sentenceChangeView {
+	out := make([]sentenceChangeView, 0, len(changes))
+	for _, c := range changes {
+		out = append(out, sentenceChangeView{Change: c.Kind, Text: c.Text, Previous: c.Previous})
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpserver "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/httpserver"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type stubDiffer struct {
+	diff     domain.ResultDiff
+	err      error
+	from, to int
+}
+
+func (s *stubDiffer) Diff(_ context.Context, _ string, from, to int) (domain.ResultDiff, error) {
+	s.from, s.to = from, to
+	return s.diff, s.err
+}
+
+func serveDiff(t *testing.T, d httpserver.ResultDiffer, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	srv := httpserver.NewServer(config.Config{Port: 8080}, usecase.NewUploadService(nil), usecase.NewEvaluateService(nil, nil, nil), usecase.NewResultService(nil, nil), nil, nil, nil, nil)
+	srv.Diffs = d
+	router := chi.NewRouter()
+	router.Get("/v1/result/{id}/diff", srv.ResultDiffHandler())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+func TestResultDiffHandler(t *testing.T) {
+	from, to := 0.6, 0.7
+	d := &stubDiffer{diff: domain.ResultDiff{
+		JobID: "job-1", From: 1, To: 3,
+		CVMatchRate: domain.ScoreChange{From: &from, To: &to, Delta: 0.1},
+		CVFeedback:  []domain.SentenceChange{{Kind: domain.SentenceChanged, Text: "Limited AWS experience.", Previous: "Limited cloud experience."}},
+	}}
+	w := serveDiff(t, d, "/v1/result/job-1/diff?from=1&to=3")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []int{1, 3}, []int{d.from, d.to})
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, "job-1", body["id"])
+	assert.Equal(t, 3.0, body["to"].(map[string]any)["version"])
+	scores := body["scores"].(map[string]any)
+	assert.Equal(t, map[string]any{"from": 0.6, "to": 0.7, "delta": 0.1}, scores["cv_match_rate"])
+	assert.Equal(t, map[string]any{"from": nil, "to": nil, "delta": nil}, scores["project_score"])
+	feedback := body["feedback"].(map[string]any)
+	assert.Equal(t, []any{map[string]any{"change": "changed", "text": "Limited AWS experience.", "previous": "Limited cloud experience."}}, feedback["cv_feedback"])
+	assert.Equal(t, []any{}, feedback["overall_summary"])
+}
+
+func TestResultDiffHandler_Errors(t *testing.T) {
+	assert.Equal(t, http.StatusBadRequest, serveDiff(t, &stubDiffer{}, "/v1/result/job-1/diff?from=abc").Code)
+	assert.Equal(t, http.StatusBadRequest, serveDiff(t, &stubDiffer{}, "/v1/result/job-1/diff?to=0").Code)
+	assert.Equal(t, http.StatusNotFound, serveDiff(t, &stubDiffer{err: fmt.Errorf("%w: no result stored for job", domain.ErrNotFound)}, "/v1/result/job-1/diff").Code)
+}
@@ -155,7 +155,7 @@ func ValidateStatus(status string) ValidationResult {
 		return ValidationResult{Valid: true}
 	}
 
-	validStatuses := []string{"queued", "processing", "completed", "failed"}
+	validStatuses := []string{"queued", "processing", "completed", "failed", "expired"}
 	for _, validStatus := range validStatuses {
 		if status == validStatus {
 			return ValidationResult{Valid: true}
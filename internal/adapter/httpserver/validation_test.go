@@ -56,7 +56,7 @@ func TestValidateStatus(t *testing.T) {
 	if !ValidateStatus("").Valid {
 		t.Fatalf("empty status should be valid")
 	}
-	for _, s := range []string{"queued", "processing", "completed", "failed"} {
+	for _, s := range []string{"queued", "processing", "completed", "failed", "expired"} {
 		if !ValidateStatus(s).Valid {
 			t.Fatalf("status %q should be valid", s)
 		}
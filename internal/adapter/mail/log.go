@@ -0,0 +1,23 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// LogMailer writes email to a logger instead of sending it; useful in development.
+type LogMailer struct {
+	logf func(subject string, to []string, body string)
+}
+
+// NewLogMailer constructs a LogMailer that reports through logf.
+func NewLogMailer(logf func(subject string, to []string, body string)) *LogMailer {
+	return &LogMailer{logf: logf}
+}
+
+// Send logs e.
+func (m *LogMailer) Send(_ context.Context, e domain.Email) error {
+	m.logf(e.Subject, e.To, e.Body)
+	return nil
+}
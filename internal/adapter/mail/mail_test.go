@@ -0,0 +1,92 @@
+package mail
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestSMTPMailer_Send(t *testing.T) {
+	m := NewSMTPMailer(SMTPConfig{Host: "smtp.example.com", Username: "u", Password: "p", From: "reports@example.com"})
+	m.now = func() time.Time { return time.Date(2025, 12, 8, 8, 0, 0, 0, time.UTC) }
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+	var gotAuth smtp.Auth
+	m.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotAuth, gotFrom, gotTo, gotMsg = addr, a, from, to, msg
+		return nil
+	}
+
+	err := m.Send(context.Background(), domain.Email{To: []string{"ops@example.com", "lead@example.com"}, Subject: "Daily report", Body: "line 1\nline 2"})
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.NotNil(t, gotAuth)
+	assert.Equal(t, "reports@example.com", gotFrom)
+	assert.Equal(t, []string{"ops@example.com", "lead@example.com"}, gotTo)
+	msg := string(gotMsg)
+	assert.Contains(t, msg, "To: ops@example.com, lead@example.com\r\n")
+	assert.Contains(t, msg, "Subject: Daily report\r\n")
+	assert.Contains(t, msg, "Date: Mon, 08 Dec 2025 08:00:00 +0000\r\n")
+	assert.True(t, strings.HasSuffix(msg, "\r\n\r\nline 1\r\nline 2"))
+
+	assert.ErrorIs(t, m.Send(context.Background(), domain.Email{}), domain.ErrInvalidArgument)
+	m.send = func(string, smtp.Auth, string, []string, []byte) error { return assert.AnError }
+	assert.ErrorContains(t, m.Send(context.Background(), domain.Email{To: []string{"a@b"}}), "op=mail.smtp_send")
+}
+
+func TestSESMailer_Send_SignsAndPosts(t *testing.T) {
+	var gotPath, gotAuth string
+	var got sesSendEmail
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &got)
+		_, _ = w.Write([]byte(`{"MessageId":"m1"}`))
+	}))
+	defer srv.Close()
+
+	m := NewSESMailer(SESConfig{Endpoint: srv.URL, Region: "eu-west-1", AccessKeyID: "AKID", SecretAccessKey: "secret", From: "reports@example.com"})
+	m.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	require.NoError(t, m.Send(context.Background(), domain.Email{To: []string{"ops@example.com"}, Subject: "Weekly report", Body: "hello"}))
+	assert.Equal(t, "/v2/email/outbound-emails", gotPath)
+	assert.True(t, strings.HasPrefix(gotAuth, "AWS4-HMAC-SHA256 Credential=AKID/20250102/eu-west-1/ses/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature="))
+	assert.Equal(t, "reports@example.com", got.FromEmailAddress)
+	assert.Equal(t, []string{"ops@example.com"}, got.Destination.ToAddresses)
+	assert.Equal(t, "Weekly report", got.Content.Simple.Subject.Data)
+	assert.Equal(t, "hello", got.Content.Simple.Body.Text.Data)
+}
+
+func TestSESMailer_Send_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"Email address is not verified."}`))
+	}))
+	defer srv.Close()
+
+	m := NewSESMailer(SESConfig{Endpoint: srv.URL})
+	err := m.Send(context.Background(), domain.Email{To: []string{"ops@example.com"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+	assert.Equal(t, "https://email.us-east-1.amazonaws.com", NewSESMailer(SESConfig{}).endpoint())
+}
+
+func TestLogMailer_Send(t *testing.T) {
+	var subject string
+	m := NewLogMailer(func(s string, _ []string, _ string) { subject = s })
+	require.NoError(t, m.Send(context.Background(), domain.Email{Subject: "hi"}))
+	assert.Equal(t, "hi", subject)
+}
@@ -0,0 +1,112 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/sigv4"
+)
+
+// SESConfig configures Amazon SES delivery.
+type SESConfig struct {
+	// Endpoint overrides the regional AWS endpoint, e.g. for a local emulator.
+	Endpoint string
+	// Region is the SES region used for the endpoint and request signing.
+	Region string
+	// AccessKeyID and SecretAccessKey are static credentials.
+	AccessKeyID     string
+	SecretAccessKey string
+	// From is the verified sender address.
+	From string
+}
+
+// SESMailer sends email with the SES v2 SendEmail API using SigV4-signed requests.
+type SESMailer struct {
+	cfg        SESConfig
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewSESMailer constructs an SESMailer. An empty region defaults to us-east-1.
+func NewSESMailer(cfg SESConfig) *SESMailer {
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	return &SESMailer{cfg: cfg, httpClient: &http.Client{Timeout: 30 * time.Second}, now: time.Now}
+}
+
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesSendEmail struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    struct {
+				Text sesContent `json:"Text"`
+			} `json:"Body"`
+		} `json:"Simple"`
+	} `json:"Content"`
+}
+
+// Send delivers e.
+func (m *SESMailer) Send(ctx domain.Context, e domain.Email) error {
+	if len(e.To) == 0 {
+		return fmt.Errorf("op=mail.ses_send: %w", domain.ErrInvalidArgument)
+	}
+	var payload sesSendEmail
+	payload.FromEmailAddress = m.cfg.From
+	payload.Destination.ToAddresses = e.To
+	payload.Content.Simple.Subject = sesContent{Data: e.Subject, Charset: "UTF-8"}
+	payload.Content.Simple.Body.Text = sesContent{Data: e.Body, Charset: "UTF-8"}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("op=mail.ses_marshal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.endpoint()+"/v2/email/outbound-emails", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("op=mail.ses_request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	h := sha256.Sum256(body)
+	m.sign(req, hex.EncodeToString(h[:]))
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("op=mail.ses_send: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("op=mail.ses_send: status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func (m *SESMailer) endpoint() string {
+	if m.cfg.Endpoint != "" {
+		return strings.TrimRight(m.cfg.Endpoint, "/")
+	}
+	return fmt.Sprintf("https://email.%s.amazonaws.com", m.cfg.Region)
+}
+
+// sign adds AWS Signature Version 4 headers for the SES service.
+func (m *SESMailer) sign(req *http.Request, payloadHash string) {
+	creds := sigv4.Credentials{AccessKeyID: m.cfg.AccessKeyID, SecretAccessKey: m.cfg.SecretAccessKey}
+	sigv4.Sign(req, creds, m.cfg.Region, "ses", payloadHash, m.now())
+}
@@ -0,0 +1,78 @@
+// Package mail provides Mailer implementations for outgoing email: SMTP,
+// Amazon SES and a log-only mailer for development.
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// SMTPConfig configures an SMTP relay.
+type SMTPConfig struct {
+	Host string
+	Port int
+	// Username and Password enable PLAIN authentication when Username is set.
+	// net/smtp only sends credentials over TLS or to localhost.
+	Username string
+	Password string
+	// From is the sender address.
+	From string
+}
+
+// SMTPMailer sends plain-text email through an SMTP relay, upgrading to TLS
+// with STARTTLS when the server offers it.
+type SMTPMailer struct {
+	cfg  SMTPConfig
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+	now  func() time.Time
+}
+
+// NewSMTPMailer constructs an SMTPMailer. A zero port defaults to 587.
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	return &SMTPMailer{cfg: cfg, send: smtp.SendMail, now: time.Now}
+}
+
+// Send delivers e. net/smtp has no context support, so ctx is only checked
+// before connecting.
+func (m *SMTPMailer) Send(ctx domain.Context, e domain.Email) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("op=mail.smtp_send: %w", err)
+	}
+	if len(e.To) == 0 {
+		return fmt.Errorf("op=mail.smtp_send: %w", domain.ErrInvalidArgument)
+	}
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	if err := m.send(addr, auth, m.cfg.From, e.To, buildMessage(m.cfg.From, e, m.now())); err != nil {
+		return fmt.Errorf("op=mail.smtp_send: %w", err)
+	}
+	return nil
+}
+
+// buildMessage renders e as an RFC 5322 message with a UTF-8 text body.
+func buildMessage(from string, e domain.Email, at time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", e.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", at.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(e.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes()
+}
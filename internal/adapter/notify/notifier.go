@@ -0,0 +1,150 @@
+// Package notify posts operational events to Slack and Microsoft Teams
+// incoming webhooks with templated messages and per-event rate limiting.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// DefaultTemplates are the message templates used when no override is configured.
+var DefaultTemplates = map[string]string{
+	domain.NotifyJobFailureSpike:     "Job failure spike: {{.Count}} jobs failed in the last {{.Window}} ({{.Completed}} completed).",
+	domain.NotifyDLQGrowth:           "DLQ growth: {{.Count}} jobs moved to the dead letter queue in the last {{.Window}}.",
+	domain.NotifyProviderCircuitOpen: "Provider circuit open: {{.Endpoint}} ({{.Connection}}) opened its circuit after repeated failures.",
+	domain.NotifySweeperAction:       "Stuck job sweeper failed {{.Count}} jobs processing for longer than {{.MaxAge}}.",
+}
+
+// sendTimeout bounds one delivery to all sinks.
+const sendTimeout = 15 * time.Second
+
+// Notifier renders notifications and posts them to every sink in the
+// background. Each event and subject is posted at most once per minimum
+// interval; notifications dropped in between are counted and mentioned in
+// the next message. Notifier is safe for concurrent use.
+type Notifier struct {
+	sinks       []Sink
+	events      map[string]bool
+	templates   map[string]*template.Template
+	minInterval time.Duration
+	now         func() time.Time
+
+	mu         sync.Mutex
+	last       map[string]time.Time
+	suppressed map[string]int
+	wg         sync.WaitGroup
+}
+
+// New creates a notifier from configuration. It returns nil when no webhook
+// is configured and an error when a template override does not parse.
+func New(cfg config.NotifyConfig) (*Notifier, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	var sinks []Sink
+	if cfg.SlackWebhookURL != "" {
+		sinks = append(sinks, NewSlackSink(cfg.SlackWebhookURL))
+	}
+	if cfg.TeamsWebhookURL != "" {
+		sinks = append(sinks, NewTeamsSink(cfg.TeamsWebhookURL))
+	}
+	return NewWithSinks(sinks, cfg.Events, cfg.Templates, cfg.MinInterval)
+}
+
+// NewWithSinks creates a notifier posting enabled events to sinks. Templates
+// override DefaultTemplates by event name.
+func NewWithSinks(sinks []Sink, events map[string]bool, templates map[string]string, minInterval time.Duration) (*Notifier, error) {
+	n := &Notifier{
+		sinks:       sinks,
+		events:      events,
+		templates:   map[string]*template.Template{},
+		minInterval: minInterval,
+		now:         time.Now,
+		last:        map[string]time.Time{},
+		suppressed:  map[string]int{},
+	}
+	for name, text := range DefaultTemplates {
+		if o, ok := templates[name]; ok {
+			text = o
+		}
+		t, err := template.New(name).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("op=notify.template %s: %w", name, err)
+		}
+		n.templates[name] = t
+	}
+	return n, nil
+}
+
+// Notify renders and posts n unless its event is disabled or rate limited.
+// Delivery happens in the background; Wait blocks until it finishes.
+func (n *Notifier) Notify(_ domain.Context, ev domain.Notification) {
+	if n == nil || !n.events[ev.Event] {
+		return
+	}
+	tmpl, ok := n.templates[ev.Event]
+	if !ok {
+		slog.Warn("notification for unknown event dropped", slog.String("event", ev.Event))
+		return
+	}
+
+	key := ev.Event + "\x00" + ev.Subject
+	n.mu.Lock()
+	now := n.now()
+	if last, seen := n.last[key]; seen && now.Sub(last) < n.minInterval {
+		n.suppressed[key]++
+		n.mu.Unlock()
+		observability.RecordNotification(ev.Event, "suppressed")
+		return
+	}
+	n.last[key] = now
+	dropped := n.suppressed[key]
+	delete(n.suppressed, key)
+	n.mu.Unlock()
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, ev.Data); err != nil {
+		slog.Error("notification template failed", slog.String("event", ev.Event), slog.Any("error", err))
+		observability.RecordNotification(ev.Event, "failed")
+		return
+	}
+	if dropped > 0 {
+		fmt.Fprintf(&b, " (%d similar notifications suppressed)", dropped)
+	}
+	text := b.String()
+
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		// Delivery outlives the caller's request or loop iteration.
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+		for _, s := range n.sinks {
+			if err := s.Post(ctx, text); err != nil {
+				slog.Warn("notification delivery failed",
+					slog.String("event", ev.Event),
+					slog.String("sink", s.Name()),
+					slog.Any("error", err))
+				observability.RecordNotification(ev.Event, "failed")
+				continue
+			}
+			observability.RecordNotification(ev.Event, "sent")
+		}
+	}()
+}
+
+// Wait blocks until all pending deliveries finish.
+func (n *Notifier) Wait() {
+	if n != nil {
+		n.wg.Wait()
+	}
+}
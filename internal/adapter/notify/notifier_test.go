@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+type recordingSink struct {
+	mu    sync.Mutex
+	texts []string
+	err   error
+}
+
+func (s *recordingSink) Name() string { return "test" }
+
+func (s *recordingSink) Post(_ context.Context, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.texts = append(s.texts, text)
+	return s.err
+}
+
+func TestNotifier_TemplatesAndRateLimit(t *testing.T) {
+	sink := &recordingSink{}
+	n, err := NewWithSinks([]Sink{sink},
+		map[string]bool{domain.NotifyDLQGrowth: true, domain.NotifyProviderCircuitOpen: true},
+		map[string]string{domain.NotifyDLQGrowth: "DLQ {{.Count}}/{{.Window}}"},
+		time.Minute)
+	require.NoError(t, err)
+	now := time.Date(2025, 12, 8, 10, 0, 0, 0, time.UTC)
+	n.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	n.Notify(ctx, domain.Notification{Event: domain.NotifyDLQGrowth, Data: map[string]any{"Count": 21, "Window": "10m0s"}})
+	n.Notify(ctx, domain.Notification{Event: domain.NotifyDLQGrowth, Data: map[string]any{"Count": 22}})
+	n.Notify(ctx, domain.Notification{Event: domain.NotifyDLQGrowth, Data: map[string]any{"Count": 23}})
+	n.Notify(ctx, domain.Notification{Event: domain.NotifySweeperAction, Data: map[string]any{"Count": 1}})
+	n.Notify(ctx, domain.Notification{Event: domain.NotifyProviderCircuitOpen, Subject: "groq", Data: map[string]any{"Endpoint": "groq", "Connection": "ai"}})
+	n.Notify(ctx, domain.Notification{Event: domain.NotifyProviderCircuitOpen, Subject: "openrouter", Data: map[string]any{"Endpoint": "openrouter", "Connection": "ai"}})
+	n.Wait()
+	assert.ElementsMatch(t, []string{
+		"DLQ 21/10m0s",
+		"Provider circuit open: groq (ai) opened its circuit after repeated failures.",
+		"Provider circuit open: openrouter (ai) opened its circuit after repeated failures.",
+	}, sink.texts, "disabled events are dropped and subjects are limited separately")
+
+	now = now.Add(time.Minute)
+	n.Notify(ctx, domain.Notification{Event: domain.NotifyDLQGrowth, Data: map[string]any{"Count": 30, "Window": "10m0s"}})
+	n.Wait()
+	assert.Equal(t, "DLQ 30/10m0s (2 similar notifications suppressed)", sink.texts[len(sink.texts)-1])
+}
+
+func TestNotifier_DisabledAndInvalid(t *testing.T) {
+	n, err := New(config.NotifyConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, n)
+	n.Notify(context.Background(), domain.Notification{Event: domain.NotifyDLQGrowth})
+	n.Wait()
+
+	_, err = NewWithSinks(nil, nil, map[string]string{domain.NotifySweeperAction: "{{.Count"}, time.Minute)
+	assert.ErrorContains(t, err, "op=notify.template sweeper_action")
+}
+
+func TestSinks_Payloads(t *testing.T) {
+	var bodies []map[string]string
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&m)
+		mu.Lock()
+		bodies = append(bodies, m)
+		mu.Unlock()
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	require.NoError(t, NewSlackSink(srv.URL).Post(ctx, "hello"))
+	require.NoError(t, NewTeamsSink(srv.URL).Post(ctx, "line one\nline two"))
+	assert.ErrorContains(t, NewSlackSink(srv.URL+"/fail").Post(ctx, "x"), "status 403")
+
+	require.Len(t, bodies, 3)
+	assert.Equal(t, map[string]string{"text": "hello"}, bodies[0])
+	assert.Equal(t, "MessageCard", bodies[1]["@type"])
+	assert.Equal(t, "line one", bodies[1]["summary"])
+	assert.Equal(t, "line one\nline two", bodies[1]["text"])
+}
+
+func TestWindowCounter(t *testing.T) {
+	c := NewWindowCounter(time.Minute)
+	t0 := time.Date(2025, 12, 8, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, 1, c.Add(t0))
+	assert.Equal(t, 2, c.Add(t0.Add(30*time.Second)))
+	assert.Equal(t, 2, c.Add(t0.Add(61*time.Second)), "first event left the window")
+	assert.Equal(t, 1, c.Add(t0.Add(5*time.Minute)))
+}
+
+func TestStatsPusher_Push(t *testing.T) {
+	var got domain.UsageStats
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	st := domain.UsageStats{DeploymentID: "eu-1", Jobs: 10, FailureRatio: 0.1, ModelMix: map[string]int64{"groq/llama": 4}}
+	require.NoError(t, NewStatsPusher(srv.URL).Push(context.Background(), st))
+	assert.Equal(t, st.DeploymentID, got.DeploymentID)
+	assert.Equal(t, st.ModelMix, got.ModelMix)
+	assert.ErrorContains(t, NewStatsPusher(srv.URL+"/fail").Push(context.Background(), st), "status 503")
+}
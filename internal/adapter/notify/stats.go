@@ -0,0 +1,26 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// StatsPusher posts anonymous usage stats to a collector endpoint that
+// monitors a fleet of deployments.
+type StatsPusher struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewStatsPusher constructs a StatsPusher for the endpoint URL.
+func NewStatsPusher(url string) *StatsPusher {
+	return &StatsPusher{url: url, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Push posts s as JSON, in the shape served by /admin/api/v1/stats.
+func (p *StatsPusher) Push(ctx context.Context, s domain.UsageStats) error {
+	return postJSON(ctx, p.httpClient, p.url, s)
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Sink posts a rendered message to one chat channel.
+type Sink interface {
+	// Name identifies the sink in logs and metrics.
+	Name() string
+	// Post delivers text.
+	Post(ctx context.Context, text string) error
+}
+
+// SlackSink posts to a Slack incoming webhook.
+type SlackSink struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewSlackSink constructs a SlackSink for the webhook URL.
+func NewSlackSink(url string) *SlackSink {
+	return &SlackSink{url: url, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Name returns "slack".
+func (s *SlackSink) Name() string { return "slack" }
+
+// Post sends text as a plain Slack message.
+func (s *SlackSink) Post(ctx context.Context, text string) error {
+	return postJSON(ctx, s.httpClient, s.url, map[string]string{"text": text})
+}
+
+// TeamsSink posts to a Microsoft Teams incoming webhook.
+type TeamsSink struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewTeamsSink constructs a TeamsSink for the webhook URL.
+func NewTeamsSink(url string) *TeamsSink {
+	return &TeamsSink{url: url, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Name returns "teams".
+func (s *TeamsSink) Name() string { return "teams" }
+
+// Post sends text as a MessageCard.
+func (s *TeamsSink) Post(ctx context.Context, text string) error {
+	summary := text
+	if i := strings.IndexByte(summary, '\n'); i >= 0 {
+		summary = summary[:i]
+	}
+	return postJSON(ctx, s.httpClient, s.url, map[string]string{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    summary,
+		"themeColor": "D70000",
+		"text":       text,
+	})
+}
+
+func postJSON(ctx context.Context, c *http.Client, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("op=notify.marshal: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("op=notify.request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("op=notify.post: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("op=notify.post: status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
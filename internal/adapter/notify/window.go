@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"sync"
+	"time"
+)
+
+// WindowCounter counts events within a sliding time window. It is safe for
+// concurrent use.
+type WindowCounter struct {
+	window time.Duration
+
+	mu    sync.Mutex
+	times []time.Time
+}
+
+// NewWindowCounter creates a counter over window.
+func NewWindowCounter(window time.Duration) *WindowCounter {
+	return &WindowCounter{window: window}
+}
+
+// Add records an event at now and returns the number of events in the window ending at now.
+func (c *WindowCounter) Add(now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cutoff := now.Add(-c.window)
+	i := 0
+	for i < len(c.times) && !c.times[i].After(cutoff) {
+		i++
+	}
+	c.times = append(c.times[i:], now)
+	return len(c.times)
+}
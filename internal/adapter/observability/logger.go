@@ -2,6 +2,9 @@
 package observability
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"log/slog"
 	"os"
 
@@ -22,3 +25,10 @@ func SetupLogger(cfg config.Config) *slog.Logger {
 	)
 	return logger
 }
+
+// RedactedText stands in for s in logs: equal texts get equal placeholders,
+// so repeated texts can still be correlated.
+func RedactedText(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return fmt.Sprintf("[redacted sha256:%s len:%d]", hex.EncodeToString(sum[:6]), len(s))
+}
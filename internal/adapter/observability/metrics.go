@@ -125,6 +125,26 @@ var (
 		},
 		[]string{"provider", "type", "model"},
 	)
+	// AICallTokens is the distribution of prompt and completion tokens per AI
+	// call, by evaluation step and model.
+	AICallTokens = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ai_call_tokens",
+			Help:    "Prompt and completion tokens per AI call by evaluation step and model",
+			Buckets: prometheus.ExponentialBuckets(64, 2, 11),
+		},
+		[]string{"step", "model", "type"},
+	)
+	// EvaluationJobTokens is the distribution of prompt and completion tokens
+	// used by a whole evaluation job, including retried calls.
+	EvaluationJobTokens = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "evaluation_job_tokens",
+			Help:    "Prompt and completion tokens used per evaluation job",
+			Buckets: prometheus.ExponentialBuckets(256, 2, 12),
+		},
+		[]string{"type"},
+	)
 	// RAGEffectiveness tracks RAG retrieval effectiveness scores.
 	RAGEffectiveness = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -158,6 +178,386 @@ var (
 		},
 		[]string{"collection", "error_type"},
 	)
+	// EmbedCacheHits counts embedding cache hits by tier (memory, store).
+	EmbedCacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "embed_cache_hits_total",
+			Help: "Total embedding cache hits by tier",
+		},
+		[]string{"tier"},
+	)
+	// EmbedCacheMisses counts texts that had to be embedded by the provider.
+	EmbedCacheMisses = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "embed_cache_misses_total",
+			Help: "Total embedding cache misses",
+		},
+	)
+	// EmbedCacheEvictions counts in-memory evictions by reason (capacity, memory, ttl).
+	EmbedCacheEvictions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "embed_cache_evictions_total",
+			Help: "Total embedding cache evictions by reason",
+		},
+		[]string{"reason"},
+	)
+	// EmbedCacheEntries tracks the number of in-memory embedding cache entries.
+	EmbedCacheEntries = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "embed_cache_entries",
+			Help: "Current number of in-memory embedding cache entries",
+		},
+	)
+	// EmbedCacheBytes tracks the estimated in-memory size of the embedding cache.
+	EmbedCacheBytes = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "embed_cache_bytes",
+			Help: "Estimated in-memory size of the embedding cache in bytes",
+		},
+	)
+	// AIKeyDailyUsage tracks today's usage of each provider key by kind (requests, tokens).
+	AIKeyDailyUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ai_key_daily_usage",
+			Help: "Usage of each AI provider key on the current UTC day",
+		},
+		[]string{"provider", "key_id", "kind"},
+	)
+	// AIKeyBudgetExhausted counts provider keys taken out of rotation for the day.
+	AIKeyBudgetExhausted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_key_budget_exhausted_total",
+			Help: "Total provider keys that reached their daily budget",
+		},
+		[]string{"provider"},
+	)
+	// AIPaidFallbackTotal counts paid-model fallback decisions by outcome
+	// (used, disabled, no_opt_in, unavailable) and selected model.
+	AIPaidFallbackTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_paid_fallback_total",
+			Help: "Total paid-model fallback decisions made when all free models were blocked",
+		},
+		[]string{"outcome", "model"},
+	)
+	// AIModelLimit exposes discovered provider model limits by kind
+	// (requests_per_day, tokens_per_minute).
+	AIModelLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ai_model_limit",
+			Help: "Rate limits of AI provider models discovered from response headers",
+		},
+		[]string{"provider", "model", "kind"},
+	)
+	// AIModelHealthScore exposes the 0-100 health score of each AI model.
+	AIModelHealthScore = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ai_model_health_score",
+			Help: "Health score (0-100) of AI models from their recent errors, refusals, parse failures and latency",
+		},
+		[]string{"model"},
+	)
+	// AIStreamSalvageTotal counts timed-out streaming responses by salvage outcome.
+	AIStreamSalvageTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_stream_salvage_total",
+			Help: "Total timed-out streaming chat responses by salvage outcome (salvaged, failed)",
+		},
+		[]string{"provider", "outcome"},
+	)
+	// AIProviderErrorsTotal counts failed AI provider calls by error fingerprint.
+	AIProviderErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_provider_errors_total",
+			Help: "Total failed AI provider calls by provider and error fingerprint (model_not_found, content_filter, context_length_exceeded, quota_exceeded, ...)",
+		},
+		[]string{"provider", "fingerprint"},
+	)
+	// JSONRepromptTotal counts corrective re-prompts after unparsable JSON.
+	JSONRepromptTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_json_reprompt_total",
+			Help: "Total corrective re-prompts sent to the model after its JSON could not be parsed or repaired, by outcome (fixed, failed)",
+		},
+		[]string{"outcome"},
+	)
+	// AIReasoningSeparated counts chat responses whose reasoning was separated
+	// from the final answer, by where the reasoning was found.
+	AIReasoningSeparated = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_reasoning_separated_total",
+			Help: "Total AI chat responses whose reasoning was separated from the answer by source (field, tags)",
+		},
+		[]string{"provider", "source"},
+	)
+	// JSONRepairTotal counts local repairs of malformed model JSON by stage.
+	JSONRepairTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_json_repair_total",
+			Help: "Total malformed AI JSON responses by the local repair stage that fixed them (trailing_commas, control_chars, single_quotes, balance, failed)",
+		},
+		[]string{"stage"},
+	)
+	// EvaluationCheckpointResumesTotal counts evaluation steps resumed from a checkpoint.
+	EvaluationCheckpointResumesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "evaluation_checkpoint_resumes_total",
+			Help: "Total evaluation chain steps taken from a checkpoint instead of calling the AI again, by step",
+		},
+		[]string{"step"},
+	)
+	// UploadQualityIssues counts extraction quality issues of uploaded documents.
+	UploadQualityIssues = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "upload_quality_issues_total",
+			Help: "Total extraction quality issues of uploaded documents by upload type, issue code and action (rejected or warned)",
+		},
+		[]string{"type", "code", "action"},
+	)
+	// PromptInjectionDetected counts prompt-injection findings in uploaded documents.
+	PromptInjectionDetected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "prompt_injection_detected_total",
+			Help: "Total prompt-injection findings in uploaded documents by source and rule",
+		},
+		[]string{"source", "rule"},
+	)
+	// FeedbackSafetyViolations counts sentences removed from generated feedback.
+	FeedbackSafetyViolations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "feedback_safety_violations_total",
+			Help: "Total feedback sentences removed for referencing protected attributes by field and category",
+		},
+		[]string{"field", "category"},
+	)
+	// ReportDeliveries counts scheduled activity report deliveries.
+	ReportDeliveries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "report_deliveries_total",
+			Help: "Total scheduled activity report deliveries by period and outcome (sent, failed, skipped)",
+		},
+		[]string{"period", "outcome"},
+	)
+	// Notifications counts operational notifications by event and outcome.
+	Notifications = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "notifications_total",
+			Help: "Total operational notifications by event and outcome (sent, failed, suppressed)",
+		},
+		[]string{"event", "outcome"},
+	)
+	// HTTPRequestsInFlight tracks requests being served by kind (upload, evaluate, other).
+	HTTPRequestsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "http_requests_in_flight",
+			Help: "HTTP requests currently being served by kind",
+		},
+		[]string{"kind"},
+	)
+	// HTTPShutdownRequestsTotal counts requests in flight at shutdown by kind
+	// and whether they completed (drained) or were cut off (abandoned).
+	HTTPShutdownRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_shutdown_requests_total",
+			Help: "Requests in flight at shutdown by kind and outcome (drained, abandoned)",
+		},
+		[]string{"kind", "outcome"},
+	)
+	// QueuePoisonMessages counts queue records that could not be decoded.
+	QueuePoisonMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "queue_poison_messages_total",
+			Help: "Total undecodable queue records by topic and outcome (quarantined, skipped, capture_failed)",
+		},
+		[]string{"topic", "outcome"},
+	)
+	// QueueConsumerPaused is 1 while a worker's consumption of a topic is
+	// paused through the admin API.
+	QueueConsumerPaused = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "queue_consumer_paused",
+			Help: "Whether this worker's consumption of a queue topic is paused (1) or running (0)",
+		},
+		[]string{"topic"},
+	)
+	// EvaluationJobLocks counts job lease outcomes in the consumer.
+	EvaluationJobLocks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "evaluation_job_locks_total",
+			Help: "Total job lease outcomes by outcome (acquired, conflict, lost, error)",
+		},
+		[]string{"outcome"},
+	)
+	// SimilarityChecks counts project similarity checks by outcome.
+	SimilarityChecks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "similarity_checks_total",
+			Help: "Total project similarity checks by outcome (clear, flagged, error)",
+		},
+		[]string{"outcome"},
+	)
+	// JDLanguageChecks counts job description/CV language comparisons by outcome.
+	JDLanguageChecks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jd_language_checks_total",
+			Help: "Total job description/CV language comparisons by outcome (match, unknown, mismatch, translated, translate_failed)",
+		},
+		[]string{"outcome"},
+	)
+	// ModelABJobs counts model A/B jobs by arm and outcome.
+	ModelABJobs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "model_ab_jobs_total",
+			Help: "Total evaluation jobs routed by model A/B arm (control, challenger) and outcome (completed, failed)",
+		},
+		[]string{"arm", "outcome"},
+	)
+	// ModelABCVMatchRate is the distribution of cv_match_rate by model A/B arm.
+	ModelABCVMatchRate = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "model_ab_cv_match_rate",
+			Help:    "Distribution of cv_match_rate by model A/B arm",
+			Buckets: []float64{0, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0},
+		},
+		[]string{"arm"},
+	)
+	// ModelABProjectScore is the distribution of project_score by model A/B arm.
+	ModelABProjectScore = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "model_ab_project_score",
+			Help:    "Distribution of project_score by model A/B arm",
+			Buckets: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		[]string{"arm"},
+	)
+	// ModelABJobDuration is the evaluation duration of completed jobs by model A/B arm.
+	ModelABJobDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "model_ab_job_duration_seconds",
+			Help:    "Evaluation duration of completed jobs by model A/B arm",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+		},
+		[]string{"arm"},
+	)
+	// ModelChallengerFallbacks counts challenger calls that fell back to the default pool.
+	ModelChallengerFallbacks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "model_challenger_fallbacks_total",
+			Help: "Total AI calls of challenger-arm jobs that fell back to the default model pool, by challenger model",
+		},
+		[]string{"model"},
+	)
+	// S3Ingestions counts candidate folders processed by the S3 ingestion worker.
+	S3Ingestions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "s3_ingestions_total",
+			Help: "Total candidate folders ingested from S3 by outcome (queued, duplicate, rejected, failed)",
+		},
+		[]string{"outcome"},
+	)
+	// EvaluationFastPath counts evaluations run with the single-prompt fast path.
+	EvaluationFastPath = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "evaluation_fast_path_total",
+			Help: "Total evaluations run with the single-prompt fast path by reason (fallback, interactive, queue_lag, provider_latency)",
+		},
+		[]string{"reason"},
+	)
+	// RAGCacheLookups counts lookups of the per-posting RAG context cache.
+	RAGCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rag_cache_lookups_total",
+			Help: "Total lookups of the per-posting RAG context cache by result (hit, miss)",
+		},
+		[]string{"result"},
+	)
+	// AIConnectionEvictions counts evictions of idle AI provider connections.
+	AIConnectionEvictions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_http_connection_evictions_total",
+			Help: "Total evictions of idle AI provider connections after repeated failed requests by provider",
+		},
+		[]string{"provider"},
+	)
+	// QdrantSnapshots counts Qdrant collection snapshots and restores.
+	QdrantSnapshots = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "qdrant_snapshots_total",
+			Help: "Total Qdrant collection snapshots and restores by collection, operation (create, upload, restore) and result",
+		},
+		[]string{"collection", "operation", "result"},
+	)
+	// HTTPCompressionRatio records the uncompressed to compressed size ratio
+	// of compressed responses by route and encoding.
+	HTTPCompressionRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_response_compression_ratio",
+			Help:    "Uncompressed to compressed size ratio of compressed HTTP responses by route and encoding",
+			Buckets: []float64{1, 1.5, 2, 3, 4, 6, 8, 12, 16},
+		},
+		[]string{"route", "encoding"},
+	)
+	// AICallSlotWait records how long AI calls waited for a concurrency slot
+	// of their kind (embed, chat, cot_clean).
+	AICallSlotWait = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ai_call_slot_wait_seconds",
+			Help:    "Time AI calls waited for a concurrency slot of their kind (embed, chat, cot_clean)",
+			Buckets: []float64{0.001, 0.01, 0.1, 0.5, 1, 2, 5, 10, 30, 60},
+		},
+		[]string{"kind"},
+	)
+	// AICallsInFlight tracks the AI calls holding a concurrency slot by kind.
+	AICallsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ai_calls_in_flight",
+			Help: "AI calls currently holding a concurrency slot by kind (embed, chat, cot_clean)",
+		},
+		[]string{"kind"},
+	)
+	// AIDuplicateCalls counts the model attempts that waited for an
+	// identical attempt of the same job in flight instead of resending it.
+	AIDuplicateCalls = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_duplicate_calls_total",
+			Help: "AI model attempts that waited for an identical in-flight attempt of the same job instead of resending the prompt by kind (chat)",
+		},
+		[]string{"kind"},
+	)
+	// AILateResults counts the results of model attempts that arrived after
+	// the attempt timed out, by outcome (harvested, discarded).
+	AILateResults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_late_results_total",
+			Help: "Results of timed-out model attempts by outcome (harvested, discarded)",
+		},
+		[]string{"outcome"},
+	)
+	// AIModelSubstitutions counts the calls a provider served with a model
+	// outside the requested model's family.
+	AIModelSubstitutions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_model_substitutions_total",
+			Help: "AI calls served with a model outside the requested family by provider, substitute kind (free, paid_or_unknown) and action (accepted, rejected)",
+		},
+		[]string{"provider", "kind", "action"},
+	)
+	// HTTPCompressionBytes counts the bytes of compressed responses before
+	// and after compression by route and encoding.
+	HTTPCompressionBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_response_compression_bytes_total",
+			Help: "Bytes of compressed HTTP responses by route, encoding and stage (uncompressed, compressed)",
+		},
+		[]string{"route", "encoding", "stage"},
+	)
+	// FreeModelsCatalogAge tracks the age of the free models catalog being served.
+	FreeModelsCatalogAge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "ai_free_models_catalog_age_seconds",
+			Help: "Age of the OpenRouter free models catalog currently served",
+		},
+	)
 )
 
 // appEnv holds the current application environment (dev, prod, test).
@@ -186,10 +586,58 @@ func InitMetrics() {
 	prometheus.MustRegister(CVMatchRateHistogram)
 	prometheus.MustRegister(ProjectScoreHistogram)
 	prometheus.MustRegister(AITokenUsage)
+	prometheus.MustRegister(AICallTokens)
+	prometheus.MustRegister(EvaluationJobTokens)
 	prometheus.MustRegister(RAGEffectiveness)
 	prometheus.MustRegister(ScoreDriftDetector)
 	prometheus.MustRegister(CircuitBreakerStatus)
 	prometheus.MustRegister(RAGRetrievalErrors)
+	prometheus.MustRegister(EmbedCacheHits)
+	prometheus.MustRegister(EmbedCacheMisses)
+	prometheus.MustRegister(EmbedCacheEvictions)
+	prometheus.MustRegister(EmbedCacheEntries)
+	prometheus.MustRegister(EmbedCacheBytes)
+	prometheus.MustRegister(AIKeyDailyUsage)
+	prometheus.MustRegister(AIKeyBudgetExhausted)
+	prometheus.MustRegister(AIPaidFallbackTotal)
+	prometheus.MustRegister(FreeModelsCatalogAge)
+	prometheus.MustRegister(AIModelLimit)
+	prometheus.MustRegister(AIModelHealthScore)
+	prometheus.MustRegister(AIStreamSalvageTotal)
+	prometheus.MustRegister(AIProviderErrorsTotal)
+	prometheus.MustRegister(AIReasoningSeparated)
+	prometheus.MustRegister(JSONRepairTotal)
+	prometheus.MustRegister(JSONRepromptTotal)
+	prometheus.MustRegister(EvaluationCheckpointResumesTotal)
+	prometheus.MustRegister(UploadQualityIssues)
+	prometheus.MustRegister(PromptInjectionDetected)
+	prometheus.MustRegister(FeedbackSafetyViolations)
+	prometheus.MustRegister(ReportDeliveries)
+	prometheus.MustRegister(Notifications)
+	prometheus.MustRegister(HTTPRequestsInFlight)
+	prometheus.MustRegister(HTTPShutdownRequestsTotal)
+	prometheus.MustRegister(QueuePoisonMessages)
+	prometheus.MustRegister(QueueConsumerPaused)
+	prometheus.MustRegister(EvaluationJobLocks)
+	prometheus.MustRegister(SimilarityChecks)
+	prometheus.MustRegister(JDLanguageChecks)
+	prometheus.MustRegister(ModelABJobs)
+	prometheus.MustRegister(ModelABCVMatchRate)
+	prometheus.MustRegister(ModelABProjectScore)
+	prometheus.MustRegister(ModelABJobDuration)
+	prometheus.MustRegister(ModelChallengerFallbacks)
+	prometheus.MustRegister(S3Ingestions)
+	prometheus.MustRegister(EvaluationFastPath)
+	prometheus.MustRegister(RAGCacheLookups)
+	prometheus.MustRegister(AIConnectionEvictions)
+	prometheus.MustRegister(QdrantSnapshots)
+	prometheus.MustRegister(HTTPCompressionRatio)
+	prometheus.MustRegister(HTTPCompressionBytes)
+	prometheus.MustRegister(AICallSlotWait)
+	prometheus.MustRegister(AICallsInFlight)
+	prometheus.MustRegister(AIDuplicateCalls)
+	prometheus.MustRegister(AILateResults)
+	prometheus.MustRegister(AIModelSubstitutions)
 	if isDevEnv() {
 		prometheus.MustRegister(HTTPRequestsByID)
 	}
@@ -271,6 +719,22 @@ func RecordAITokenUsage(provider, tokenType, model string, tokens int) {
 	AITokenUsage.WithLabelValues(provider, tokenType, model).Add(float64(tokens))
 }
 
+// ObserveAICallTokens records the prompt and completion tokens of one AI
+// call made for step ("" for calls outside an evaluation step).
+func ObserveAICallTokens(step, model string, prompt, completion int) {
+	if step == "" {
+		step = "none"
+	}
+	AICallTokens.WithLabelValues(step, model, "prompt").Observe(float64(prompt))
+	AICallTokens.WithLabelValues(step, model, "completion").Observe(float64(completion))
+}
+
+// ObserveEvaluationJobTokens records the tokens an evaluation job used.
+func ObserveEvaluationJobTokens(prompt, completion int) {
+	EvaluationJobTokens.WithLabelValues("prompt").Observe(float64(prompt))
+	EvaluationJobTokens.WithLabelValues("completion").Observe(float64(completion))
+}
+
 // RecordRAGEffectiveness records RAG retrieval effectiveness.
 func RecordRAGEffectiveness(collection, queryType string, effectiveness float64) {
 	RAGEffectiveness.WithLabelValues(collection, queryType).Observe(effectiveness)
@@ -290,3 +754,264 @@ func RecordCircuitBreakerStatus(service, operation string, status int) {
 func RecordRAGRetrievalError(collection, errorType string) {
 	RAGRetrievalErrors.WithLabelValues(collection, errorType).Inc()
 }
+
+// RecordEmbedCacheHit records an embedding cache hit in the given tier.
+func RecordEmbedCacheHit(tier string) {
+	EmbedCacheHits.WithLabelValues(tier).Inc()
+}
+
+// RecordEmbedCacheMiss records n embedding cache misses.
+func RecordEmbedCacheMiss(n int) {
+	EmbedCacheMisses.Add(float64(n))
+}
+
+// RecordEmbedCacheEviction records an in-memory embedding cache eviction.
+func RecordEmbedCacheEviction(reason string) {
+	EmbedCacheEvictions.WithLabelValues(reason).Inc()
+}
+
+// SetEmbedCacheSize records the current in-memory embedding cache size.
+func SetEmbedCacheSize(entries int, bytes int64) {
+	EmbedCacheEntries.Set(float64(entries))
+	EmbedCacheBytes.Set(float64(bytes))
+}
+
+// SetAIKeyDailyUsage records today's request and token counts of a provider key.
+func SetAIKeyDailyUsage(provider, keyID string, requests, tokens int64) {
+	AIKeyDailyUsage.WithLabelValues(provider, keyID, "requests").Set(float64(requests))
+	AIKeyDailyUsage.WithLabelValues(provider, keyID, "tokens").Set(float64(tokens))
+}
+
+// RecordAIKeyBudgetExhausted records a provider key reaching its daily budget.
+func RecordAIKeyBudgetExhausted(provider string) {
+	AIKeyBudgetExhausted.WithLabelValues(provider).Inc()
+}
+
+// RecordAIPaidFallback records a paid-model fallback decision. model is empty
+// unless a paid model was used.
+func RecordAIPaidFallback(outcome, model string) {
+	AIPaidFallbackTotal.WithLabelValues(outcome, model).Inc()
+}
+
+// SetFreeModelsCatalogAge records the age of the served free models catalog.
+func SetFreeModelsCatalogAge(age time.Duration) {
+	FreeModelsCatalogAge.Set(age.Seconds())
+}
+
+// SetAIModelHealthScore records the health score of model.
+func SetAIModelHealthScore(model string, score float64) {
+	AIModelHealthScore.WithLabelValues(model).Set(score)
+}
+
+// SetAIModelLimit records the known limits of a provider model.
+func SetAIModelLimit(provider, model string, requestsPerDay, tokensPerMinute int64) {
+	AIModelLimit.WithLabelValues(provider, model, "requests_per_day").Set(float64(requestsPerDay))
+	AIModelLimit.WithLabelValues(provider, model, "tokens_per_minute").Set(float64(tokensPerMinute))
+}
+
+// RecordAIStreamSalvage records an attempt to salvage a timed-out stream.
+func RecordAIStreamSalvage(provider, outcome string) {
+	AIStreamSalvageTotal.WithLabelValues(provider, outcome).Inc()
+}
+
+// RecordAIProviderError records a failed provider call with its error
+// fingerprint.
+func RecordAIProviderError(provider, fingerprint string) {
+	AIProviderErrorsTotal.WithLabelValues(provider, fingerprint).Inc()
+}
+
+// RecordAIReasoningSeparated records a chat response whose reasoning was
+// separated from the answer.
+func RecordAIReasoningSeparated(provider, source string) {
+	AIReasoningSeparated.WithLabelValues(provider, source).Inc()
+}
+
+// RecordJSONRepair records the outcome of a local JSON repair attempt.
+func RecordJSONRepair(stage string) {
+	JSONRepairTotal.WithLabelValues(stage).Inc()
+}
+
+// RecordJSONReprompt records the outcome of a corrective re-prompt.
+func RecordJSONReprompt(outcome string) {
+	JSONRepromptTotal.WithLabelValues(outcome).Inc()
+}
+
+// RecordCheckpointResume records an evaluation step taken from a checkpoint
+// instead of being run again.
+func RecordCheckpointResume(step string) {
+	EvaluationCheckpointResumesTotal.WithLabelValues(step).Inc()
+}
+
+// RecordUploadQualityIssue records an extraction quality issue of an
+// uploaded document that rejected it or was returned as a warning.
+func RecordUploadQualityIssue(uploadType, code string, rejected bool) {
+	action := "warned"
+	if rejected {
+		action = "rejected"
+	}
+	UploadQualityIssues.WithLabelValues(uploadType, code, action).Inc()
+}
+
+// RecordPromptInjection records a prompt-injection finding in an uploaded document.
+func RecordPromptInjection(source, rule string) {
+	PromptInjectionDetected.WithLabelValues(source, rule).Inc()
+}
+
+// RecordFeedbackSafetyViolation records a feedback sentence removed by the safety filter.
+func RecordFeedbackSafetyViolation(field, category string) {
+	FeedbackSafetyViolations.WithLabelValues(field, category).Inc()
+}
+
+// RecordReportDelivery records the outcome of a scheduled report delivery.
+func RecordReportDelivery(period, outcome string) {
+	ReportDeliveries.WithLabelValues(period, outcome).Inc()
+}
+
+// RecordNotification records the outcome of an operational notification.
+func RecordNotification(event, outcome string) {
+	Notifications.WithLabelValues(event, outcome).Inc()
+}
+
+// SetHTTPRequestsInFlight sets the number of requests of kind being served.
+func SetHTTPRequestsInFlight(kind string, n int) {
+	HTTPRequestsInFlight.WithLabelValues(kind).Set(float64(n))
+}
+
+// RecordShutdownRequests adds n requests of kind that were in flight at
+// shutdown with outcome drained or abandoned.
+func RecordShutdownRequests(kind, outcome string, n int) {
+	HTTPShutdownRequestsTotal.WithLabelValues(kind, outcome).Add(float64(n))
+}
+
+// RecordPoisonMessage records an undecodable queue record and what was done
+// with it.
+func RecordPoisonMessage(topic, outcome string) {
+	QueuePoisonMessages.WithLabelValues(topic, outcome).Inc()
+}
+
+// SetQueueConsumerPaused records whether consumption of topic is paused.
+func SetQueueConsumerPaused(topic string, paused bool) {
+	v := 0.0
+	if paused {
+		v = 1
+	}
+	QueueConsumerPaused.WithLabelValues(topic).Set(v)
+}
+
+// RecordJobLock records the outcome of acquiring or keeping a job lease.
+func RecordJobLock(outcome string) {
+	EvaluationJobLocks.WithLabelValues(outcome).Inc()
+}
+
+// RecordSimilarityCheck records the outcome of comparing a project report
+// with earlier submissions.
+func RecordSimilarityCheck(outcome string) {
+	SimilarityChecks.WithLabelValues(outcome).Inc()
+}
+
+// RecordJDLanguageCheck records the outcome of comparing the languages of a
+// job's job description and CV.
+func RecordJDLanguageCheck(outcome string) {
+	JDLanguageChecks.WithLabelValues(outcome).Inc()
+}
+
+// RecordModelABJob records the outcome of a job routed by model A/B arm.
+// Completed jobs also record their scores and evaluation duration.
+func RecordModelABJob(arm string, completed bool, cvMatchRate, projectScore float64, d time.Duration) {
+	if !completed {
+		ModelABJobs.WithLabelValues(arm, "failed").Inc()
+		return
+	}
+	ModelABJobs.WithLabelValues(arm, "completed").Inc()
+	if cvMatchRate >= 0 && cvMatchRate <= 1 {
+		ModelABCVMatchRate.WithLabelValues(arm).Observe(cvMatchRate)
+	}
+	if projectScore >= 1 && projectScore <= 10 {
+		ModelABProjectScore.WithLabelValues(arm).Observe(projectScore)
+	}
+	ModelABJobDuration.WithLabelValues(arm).Observe(d.Seconds())
+}
+
+// RecordModelChallengerFallback records a challenger call falling back to
+// the default model pool.
+func RecordModelChallengerFallback(model string) {
+	ModelChallengerFallbacks.WithLabelValues(model).Inc()
+}
+
+// RecordS3Ingestion records the outcome of ingesting a candidate folder from S3.
+func RecordS3Ingestion(outcome string) {
+	S3Ingestions.WithLabelValues(outcome).Inc()
+}
+
+// RecordEvaluationFastPath records an evaluation run with the fast path for
+// reason.
+func RecordEvaluationFastPath(reason string) {
+	EvaluationFastPath.WithLabelValues(reason).Inc()
+}
+
+// RecordRAGCacheLookup records a hit or miss of the RAG context cache.
+func RecordRAGCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	RAGCacheLookups.WithLabelValues(result).Inc()
+}
+
+// RecordAIConnectionEviction records the eviction of a provider's idle
+// connections.
+func RecordAIConnectionEviction(provider string) {
+	AIConnectionEvictions.WithLabelValues(provider).Inc()
+}
+
+// RecordQdrantSnapshot records a snapshot operation on collection; err
+// selects the "error" result.
+func RecordQdrantSnapshot(collection, operation string, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	QdrantSnapshots.WithLabelValues(collection, operation, result).Inc()
+}
+
+// RecordHTTPCompression records a response of route compressed with encoding
+// from uncompressed to compressed bytes.
+func RecordHTTPCompression(route, encoding string, uncompressed, compressed int) {
+	if compressed > 0 {
+		HTTPCompressionRatio.WithLabelValues(route, encoding).Observe(float64(uncompressed) / float64(compressed))
+	}
+	HTTPCompressionBytes.WithLabelValues(route, encoding, "uncompressed").Add(float64(uncompressed))
+	HTTPCompressionBytes.WithLabelValues(route, encoding, "compressed").Add(float64(compressed))
+}
+
+// RecordAICallSlot records that an AI call of kind waited wait for its
+// concurrency slot and now holds it.
+func RecordAICallSlot(kind string, wait time.Duration) {
+	AICallSlotWait.WithLabelValues(kind).Observe(wait.Seconds())
+	AICallsInFlight.WithLabelValues(kind).Inc()
+}
+
+// ReleaseAICallSlot records that an AI call of kind released its
+// concurrency slot.
+func ReleaseAICallSlot(kind string) {
+	AICallsInFlight.WithLabelValues(kind).Dec()
+}
+
+// RecordAIDuplicateCall records that a model attempt of an AI call of kind
+// waited for an identical attempt already in flight.
+func RecordAIDuplicateCall(kind string) {
+	AIDuplicateCalls.WithLabelValues(kind).Inc()
+}
+
+// RecordAILateResult records the outcome of a result that arrived after its
+// model attempt timed out: "harvested" when it was used, "discarded"
+// otherwise.
+func RecordAILateResult(outcome string) {
+	AILateResults.WithLabelValues(outcome).Inc()
+}
+
+// RecordAIModelSubstitution records that provider served a call with a
+// substitute model of kind, and whether the substitute was accepted.
+func RecordAIModelSubstitution(provider, kind, action string) {
+	AIModelSubstitutions.WithLabelValues(provider, kind, action).Inc()
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestHTTPMetricsMiddleware_Basic(t *testing.T) {
@@ -26,3 +28,15 @@ func TestJobMetricsHelpers(t *testing.T) {
 	FailJob("eval")
 	ObserveEvaluation(0.5, 7)
 }
+
+func TestTokenHistograms(t *testing.T) {
+	ObserveAICallTokens("", "token-test-model", 1200, 300)
+	ObserveAICallTokens("cv_match", "token-test-model", 800, 200)
+	if n := testutil.CollectAndCount(AICallTokens); n < 4 {
+		t.Fatalf("want prompt and completion series per step, got %d", n)
+	}
+	ObserveEvaluationJobTokens(5000, 900)
+	if n := testutil.CollectAndCount(EvaluationJobTokens); n != 2 {
+		t.Fatalf("want prompt and completion job series, got %d", n)
+	}
+}
@@ -0,0 +1,56 @@
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// WithCheckpoints saves the output of completed chain steps to repo so that
+// a retried job resumes after them instead of repeating their AI calls. A
+// nil repo disables checkpointing.
+func (h *IntegratedEvaluationHandler) WithCheckpoints(repo domain.CheckpointRepository) *IntegratedEvaluationHandler {
+	h.checkpoints = repo
+	return h
+}
+
+// resumeStep returns the checkpointed output of step for jobID and restores
+// the provenance of the models that produced it.
+func (h *IntegratedEvaluationHandler) resumeStep(ctx context.Context, jobID, step string) (string, bool) {
+	if h.checkpoints == nil || jobID == "" {
+		return "", false
+	}
+	c, err := h.checkpoints.Get(ctx, jobID, step)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			slog.Warn("failed to load evaluation checkpoint", slog.String("job_id", jobID), slog.String("step", step), slog.Any("error", err))
+		}
+		return "", false
+	}
+	domain.RestoreProvenance(ctx, c.Provenance)
+	observability.RecordCheckpointResume(step)
+	slog.Info("resuming evaluation step from checkpoint", slog.String("job_id", jobID), slog.String("step", step),
+		slog.Time("checkpointed_at", c.CreatedAt))
+	return c.Output, true
+}
+
+// checkpointStep saves output as the result of step for jobID, together with
+// the provenance recorded after the first mark entries. Failures are logged;
+// the job then repeats the step if it is retried.
+func (h *IntegratedEvaluationHandler) checkpointStep(ctx context.Context, jobID, step, output string, mark int) {
+	if h.checkpoints == nil || jobID == "" {
+		return
+	}
+	var steps []domain.StepProvenance
+	if p := domain.ProvenanceFrom(ctx); len(p) > mark {
+		steps = p[mark:]
+	}
+	c := domain.EvaluationCheckpoint{JobID: jobID, Step: step, Output: output, Provenance: steps, CreatedAt: time.Now().UTC()}
+	if err := h.checkpoints.Save(ctx, c); err != nil {
+		slog.Warn("failed to save evaluation checkpoint", slog.String("job_id", jobID), slog.String("step", step), slog.Any("error", err))
+	}
+}
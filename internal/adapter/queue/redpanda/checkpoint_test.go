@@ -0,0 +1,93 @@
+package redpanda
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+)
+
+// memCheckpoints is an in-memory CheckpointRepository.
+type memCheckpoints struct {
+	mu sync.Mutex
+	m  map[string]domain.EvaluationCheckpoint
+}
+
+func (r *memCheckpoints) Get(_ domain.Context, jobID, step string) (domain.EvaluationCheckpoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.m[jobID+"/"+step]
+	if !ok {
+		return domain.EvaluationCheckpoint{}, domain.ErrNotFound
+	}
+	return c, nil
+}
+
+func (r *memCheckpoints) Save(_ domain.Context, c domain.EvaluationCheckpoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.m == nil {
+		r.m = map[string]domain.EvaluationCheckpoint{}
+	}
+	r.m[c.JobID+"/"+c.Step] = c
+	return nil
+}
+
+func (r *memCheckpoints) Delete(_ domain.Context, jobID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, c := range r.m {
+		if c.JobID == jobID {
+			delete(r.m, k)
+		}
+	}
+	return nil
+}
+
+func TestIntegratedEvaluation_ResumesFromCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	repo := &memCheckpoints{}
+	first := &provenanceAI{}
+	ctx, _ := domain.WithModelTrace(context.Background())
+	_, err := NewIntegratedEvaluationHandler(first, nil).WithCheckpoints(repo).
+		PerformIntegratedEvaluation(ctx, "cv", "project", "jd", "case", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cv_evaluate", "project_evaluate", "refine"}, first.calls)
+
+	// A retry of the same job only repeats the steps after the checkpoints,
+	// and the result still credits the models of the resumed steps.
+	retry := &provenanceAI{}
+	ctx, trace := domain.WithModelTrace(context.Background())
+	_, err = NewIntegratedEvaluationHandler(retry, nil).WithCheckpoints(repo).
+		PerformIntegratedEvaluation(ctx, "cv", "project", "jd", "case", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"refine"}, retry.calls)
+	assert.Equal(t, []string{stepCVMatch, stepProjectEvaluate, stepRefine}, provenanceSteps(t, trace.Provenance()))
+
+	// Other jobs and the CV-only flow of another job start from scratch.
+	other := &provenanceAI{}
+	_, err = NewIntegratedEvaluationHandler(other, nil).WithCheckpoints(repo).
+		PerformCVOnlyEvaluation(context.Background(), "cv", "jd", "rubric", "job-2")
+	require.NoError(t, err)
+	assert.Equal(t, "cv_evaluate", other.calls[0])
+}
+
+func TestIntegratedEvaluation_CheckpointFailuresDoNotFailSteps(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewMockCheckpointRepository(t)
+	repo.EXPECT().Get(mock.Anything, "job-1", mock.Anything).Return(domain.EvaluationCheckpoint{}, assert.AnError).Twice()
+	repo.EXPECT().Save(mock.Anything, mock.Anything).Return(assert.AnError).Twice()
+	ai := &provenanceAI{}
+	_, err := NewIntegratedEvaluationHandler(ai, nil).WithCheckpoints(repo).
+		PerformIntegratedEvaluation(context.Background(), "cv", "project", "jd", "case", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cv_evaluate", "project_evaluate", "refine"}, ai.calls)
+}
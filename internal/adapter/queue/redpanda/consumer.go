@@ -17,6 +17,8 @@ import (
 	"github.com/twmb/franz-go/pkg/kgo"
 	"github.com/twmb/franz-go/plugin/kotel"
 
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/promptguard"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/safety"
 	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
@@ -33,11 +35,33 @@ type Consumer struct {
 	q       *qdrantcli.Client
 
 	retryManager *RetryManager
+	evalOpts     EvaluateOptions
+	// Jobs are split between the default pool and a challenger model here
+	// (see WithModelChallenger).
+	challenger ModelChallenger
+
+	// Undecodable records are captured here and skipped after
+	// poisonMaxAttempts failed decodes (see WithQuarantine).
+	quarantine        domain.QuarantineRepository
+	poisonMaxAttempts int
+
+	// Jobs are leased here before they are evaluated (see WithJobLocks).
+	jobLocks     domain.JobLockRepository
+	jobLockTTL   time.Duration
+	jobLockOwner string
+
+	// Polling stops while the topic is paused (see WithPauses).
+	pause *topicPause
+	// cipher decrypts encrypted payloads (see WithPayloadCipher).
+	cipher *PayloadCipher
 
 	// Observability components
 	observableClient *observability.IntegratedObservableClient
 	groupID          string
 	topic            string
+	// priorityTopic carries bumped tasks; its records are handed to the
+	// workers before those of topic fetched in the same poll.
+	priorityTopic string
 	// Dynamic worker pool configuration
 	maxWorkers    int
 	minWorkers    int
@@ -122,6 +146,12 @@ func NewConsumerWithTopic(brokers []string, groupID string, transactionalID stri
 			// Don't fail if topic creation fails - it might already exist
 		}
 	}
+	priority := topic + priorityTopicSuffix
+	if err := createTopicIfNotExists(ctx, tempClient, priority, 1, 1); err != nil {
+		slog.Warn("failed to create priority topic, it may already exist",
+			slog.String("topic", priority),
+			slog.Any("error", err))
+	}
 
 	// Create transactional session for EOS semantics
 	slog.Info("creating redpanda transactional session",
@@ -144,7 +174,7 @@ func NewConsumerWithTopic(brokers []string, groupID string, transactionalID stri
 		kgo.TransactionalID(transactionalID),
 		kgo.FetchIsolationLevel(kgo.ReadCommitted()),
 		kgo.ConsumerGroup(groupID),
-		kgo.ConsumeTopics(topic),
+		kgo.ConsumeTopics(topic, priority),
 		kgo.RequireStableFetchOffsets(),
 
 		// Add OpenTelemetry hooks for distributed tracing
@@ -196,6 +226,7 @@ func NewConsumerWithTopic(brokers []string, groupID string, transactionalID stri
 		q:                qcli,
 		groupID:          groupID,
 		topic:            topic,
+		priorityTopic:    priority,
 		minWorkers:       minWorkers,
 		maxWorkers:       maxWorkers,
 		workerPool:       make(chan struct{}, maxWorkers),
@@ -328,6 +359,9 @@ func (c *Consumer) messageFetcher(ctx context.Context) {
 			slog.Info("messageFetcher shutting down due to shutdown signal")
 			return
 		default:
+			if c.pause.hold(ctx, c.session.Client()) {
+				continue
+			}
 			pollCount++
 
 			// Phase 1 Algorithm: Use adaptive polling interval
@@ -443,8 +477,8 @@ func (c *Consumer) messageFetcher(ctx context.Context) {
 			// Phase 1 Algorithm: Record successful poll (messages found)
 			c.adaptivePoller.RecordSuccess()
 
-			// Queue all records for processing
-			fetches.EachRecord(func(record *kgo.Record) {
+			// Queue all records for processing, bumped jobs first
+			for _, record := range priorityFirst(fetches.Records(), c.priorityTopic) {
 				jobID := string(record.Key)
 				for _, h := range record.Headers {
 					if h.Key == "job_id" {
@@ -470,7 +504,7 @@ func (c *Consumer) messageFetcher(ctx context.Context) {
 						slog.Int("partition", int(record.Partition)))
 					go func(rec *kgo.Record, _ string) { _ = c.processRecord(ctx, rec) }(record, jobID)
 				}
-			})
+			}
 
 			slog.Info("queued messages for processing",
 				slog.Int("count", fetches.NumRecords()),
@@ -604,6 +638,18 @@ func (c *Consumer) processRecord(ctx context.Context, record *kgo.Record) error
 		slog.String("key", string(record.Key)),
 		slog.Int("value_size", len(record.Value)))
 
+	if err := c.cipher.Open(record); err != nil {
+		slog.Error("failed to decrypt payload",
+			slog.String("topic", record.Topic),
+			slog.Int64("offset", record.Offset),
+			slog.String("key_id", recordHeader(record, headerEncKeyID)),
+			slog.Any("error", err))
+		if c.quarantineRecord(ctx, record, err) {
+			return nil
+		}
+		return fmt.Errorf("decrypt payload: %w", err)
+	}
+
 	var payload domain.EvaluateTaskPayload
 	slog.Info("attempting to unmarshal payload", slog.Int("value_size", len(record.Value)))
 	if err := json.Unmarshal(record.Value, &payload); err != nil {
@@ -611,14 +657,28 @@ func (c *Consumer) processRecord(ctx context.Context, record *kgo.Record) error
 			slog.Any("error", err),
 			slog.String("value_preview", string(record.Value[:minInt(100, len(record.Value))])),
 			slog.Int("value_length", len(record.Value)))
+		if c.quarantineRecord(ctx, record, err) {
+			return nil
+		}
 		return fmt.Errorf("unmarshal payload: %w", err)
 	}
 
 	// Attach request-scoped metadata to the worker context so that all
 	// downstream logs (including AI client logs) are correlated by request_id.
+	// Records published by other tools may only carry it as a header.
+	if payload.RequestID == "" {
+		payload.RequestID = recordHeader(record, "request_id")
+	}
 	if payload.RequestID != "" {
 		ctx = observability.ContextWithRequestID(ctx, payload.RequestID)
 	}
+	if payload.AllowPaidFallback {
+		ctx = domain.WithPaidFallbackOptIn(ctx, true)
+	}
+	// Tenant overrides steer model selection and token budgets of every AI
+	// call made for this job; challenger-arm jobs try the challenger first.
+	ctx, payload = c.challenger.route(ctx, payload)
+	ctx = domain.WithEvaluationOverrides(ctx, payload.Overrides)
 	lg := observability.LoggerFromContext(ctx).With(
 		slog.String("job_id", payload.JobID),
 		slog.String("cv_id", payload.CVID),
@@ -627,14 +687,29 @@ func (c *Consumer) processRecord(ctx context.Context, record *kgo.Record) error
 	if payload.RequestID != "" {
 		lg = lg.With(slog.String("request_id", payload.RequestID))
 	}
+	if payload.TenantID != "" {
+		lg = lg.With(slog.String("tenant_id", payload.TenantID))
+	}
 	ctx = observability.ContextWithLogger(ctx, lg)
 
 	lg.Info("payload unmarshaled successfully")
+
+	// Lease the job so a consumer in another group does not evaluate it too.
+	ctx, lease, skip := c.acquireJobLease(ctx, payload.JobID)
+	if skip {
+		return nil
+	}
+	defer lease.Release()
+
 	lg.Info("processing evaluate task")
 
 	// Call the local evaluation handler (defaults: two-pass + chaining enabled)
 	lg.Info("calling HandleEvaluate")
-	err := HandleEvaluate(ctx, c.jobs, c.uploads, c.results, c.ai, c.q, payload)
+	err := HandleEvaluate(ctx, c.jobs, c.uploads, c.results, c.ai, c.q, payload, c.evalOpts)
+	if err != nil && lease.Lost() {
+		lg.Warn("evaluate task abandoned after its lease was taken over", slog.Any("error", err))
+		return nil
+	}
 	if err != nil {
 		lg.Error("evaluate task failed", slog.Any("error", err))
 
@@ -746,7 +821,7 @@ func (c *Consumer) reconnectToRedpanda() error {
 		kgo.TransactionalID(c.transactionalID),
 		kgo.FetchIsolationLevel(kgo.ReadCommitted()),
 		kgo.ConsumerGroup(c.groupID),
-		kgo.ConsumeTopics(c.topic),
+		kgo.ConsumeTopics(c.topic, c.priorityTopic),
 		kgo.RequireStableFetchOffsets(),
 
 		// Optimized timeouts for better connectivity
@@ -793,3 +868,121 @@ func (c *Consumer) WithRetryManager(rm *RetryManager) *Consumer {
 	c.retryManager = rm
 	return c
 }
+
+// WithPromptGuard screens uploaded documents for prompt injection before
+// evaluation. A nil guard disables screening.
+func (c *Consumer) WithPromptGuard(g *promptguard.Guard) *Consumer {
+	c.evalOpts.PromptGuard = g
+	return c
+}
+
+// WithSafetyFilter removes protected-attribute commentary from generated
+// feedback before results are stored. A nil filter disables it.
+func (c *Consumer) WithSafetyFilter(f *safety.Filter) *Consumer {
+	c.evalOpts.SafetyFilter = f
+	return c
+}
+
+// WithScoreNormalizer normalizes scores against the distribution of the
+// model that produced them before results are stored. A nil normalizer keeps
+// raw scores.
+func (c *Consumer) WithScoreNormalizer(n ScoreNormalizer) *Consumer {
+	c.evalOpts.Normalizer = n
+	return c
+}
+
+// WithCheckpoints saves the output of completed evaluation chain steps so a
+// retried job resumes after them. A nil repository disables checkpointing.
+func (c *Consumer) WithCheckpoints(repo domain.CheckpointRepository) *Consumer {
+	c.evalOpts.Checkpoints = repo
+	return c
+}
+
+// WithTenantQuota counts the tokens used by tenants' jobs against their daily
+// token budgets. A nil repository disables the counting.
+func (c *Consumer) WithTenantQuota(repo domain.TenantQuotaRepository) *Consumer {
+	c.evalOpts.Quota = repo
+	return c
+}
+
+// WithSimilarity flags project reports that are highly similar to earlier
+// submissions of the same tenant. A nil index disables detection.
+func (c *Consumer) WithSimilarity(idx *SimilarityIndex) *Consumer {
+	c.evalOpts.Similarity = idx
+	return c
+}
+
+// WithPromptExperiments runs jobs with the prompt variants of active
+// experiments and records their outcome. A nil repository disables them.
+func (c *Consumer) WithPromptExperiments(repo domain.PromptExperimentRepository) *Consumer {
+	c.evalOpts.Experiments = repo
+	return c
+}
+
+// WithEvaluationSLA sets the SLA of jobs enqueued without one. Zero keeps
+// the default of 5 minutes.
+func (c *Consumer) WithEvaluationSLA(d time.Duration) *Consumer {
+	c.evalOpts.SLA = d
+	return c
+}
+
+// WithRAGCache caches the RAG context retrieved for a posting so the
+// posting's later jobs skip the embedding call and the Qdrant searches.
+func (c *Consumer) WithRAGCache(cache *RAGCache) *Consumer {
+	c.evalOpts.RAGCache = cache
+	return c
+}
+
+// WithFastPath sets the policy that selects the single-prompt fast path over
+// the multi-step chain. The zero policy only selects it for interactive jobs.
+func (c *Consumer) WithFastPath(p FastPathPolicy) *Consumer {
+	c.evalOpts.FastPath = p
+	return c
+}
+
+// WithJDLanguage sets what the worker does when a job's job description and
+// CV are in different languages: JDLanguageWarn, JDLanguageTranslate or
+// JDLanguageOff.
+func (c *Consumer) WithJDLanguage(mode string) *Consumer {
+	c.evalOpts.JDLanguage = strings.ToLower(strings.TrimSpace(mode))
+	return c
+}
+
+// WithJobUsage stores the time completed jobs spent in each phase in repo.
+// A nil repository disables it.
+func (c *Consumer) WithJobUsage(repo domain.JobUsageRepository) *Consumer {
+	c.evalOpts.Usage = repo
+	return c
+}
+
+// WithModelChallenger routes m.Traffic percent of jobs to the challenger
+// model m. A zero m disables routing.
+func (c *Consumer) WithModelChallenger(m ModelChallenger) *Consumer {
+	c.challenger = m
+	return c
+}
+
+// WithPauses stops polling the consumer's topic and its priority topic while
+// checker reports the topic paused. Jobs already fetched are finished.
+func (c *Consumer) WithPauses(checker PauseChecker) *Consumer {
+	c.pause = &topicPause{checker: checker, topic: c.topic, also: []string{c.priorityTopic}}
+	return c
+}
+
+// WithPayloadCipher decrypts the payloads of encrypted records, which are
+// quarantined like undecodable ones when their key is unknown. A nil cipher
+// accepts plaintext records only.
+func (c *Consumer) WithPayloadCipher(pc *PayloadCipher) *Consumer {
+	c.cipher = pc
+	return c
+}
+
+// recordHeader returns the value of the first header named key, or "".
+func recordHeader(record *kgo.Record, key string) string {
+	for _, h := range record.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
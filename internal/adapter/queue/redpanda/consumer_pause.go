@@ -0,0 +1,60 @@
+package redpanda
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	adapterobs "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+)
+
+// consumerPauseCheck is how often a paused consumer checks for a resume.
+const consumerPauseCheck = time.Second
+
+// PauseChecker reports whether consumption of a topic is paused. It is
+// implemented by usecase.ConsumerPauseService.
+type PauseChecker interface {
+	Paused(topic string) bool
+}
+
+// topicPause applies the pause state of one consumer's topic. The group
+// membership stays alive while paused, so resuming needs no rebalance.
+type topicPause struct {
+	checker PauseChecker
+	topic   string
+	// also are paused and resumed together with topic.
+	also   []string
+	paused bool
+}
+
+// hold reports whether the topic is paused. It pauses or resumes fetching on
+// client when the state changes and, while paused, waits consumerPauseCheck
+// so the caller can skip polling. Workers keep processing the records they
+// already received.
+func (p *topicPause) hold(ctx context.Context, client *kgo.Client) bool {
+	if p == nil || p.checker == nil {
+		return false
+	}
+	paused := p.checker.Paused(p.topic)
+	if paused != p.paused {
+		p.paused = paused
+		if paused {
+			client.PauseFetchTopics(append([]string{p.topic}, p.also...)...)
+			slog.Warn("queue consumption paused; finishing in-flight jobs", slog.String("topic", p.topic))
+		} else {
+			client.ResumeFetchTopics(append([]string{p.topic}, p.also...)...)
+			slog.Info("queue consumption resumed", slog.String("topic", p.topic))
+		}
+		adapterobs.SetQueueConsumerPaused(p.topic, paused)
+	}
+	if !paused {
+		return false
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(consumerPauseCheck):
+	}
+	return true
+}
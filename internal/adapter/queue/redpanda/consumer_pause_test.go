@@ -0,0 +1,34 @@
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+type pauseFlag bool
+
+func (p *pauseFlag) Paused(string) bool { return bool(*p) }
+
+func TestTopicPause_Hold(t *testing.T) {
+	client, err := kgo.NewClient(kgo.SeedBrokers("127.0.0.1:1"), kgo.ConsumeTopics(TopicEvaluate, TopicEvaluatePriority))
+	require.NoError(t, err)
+	defer client.Close()
+
+	var none *topicPause
+	assert.False(t, none.hold(context.Background(), client))
+
+	flag := pauseFlag(true)
+	p := &topicPause{checker: &flag, topic: TopicEvaluate, also: []string{TopicEvaluatePriority}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.True(t, p.hold(ctx, client))
+	assert.ElementsMatch(t, []string{TopicEvaluate, TopicEvaluatePriority}, client.PauseFetchTopics())
+
+	flag = false
+	assert.False(t, p.hold(context.Background(), client))
+	assert.Empty(t, client.PauseFetchTopics())
+}
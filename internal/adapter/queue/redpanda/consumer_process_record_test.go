@@ -62,3 +62,12 @@ func TestConsumer_ProcessRecord_Success(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, domain.JobCompleted, job.Status)
 }
+
+func TestRecordHeader(t *testing.T) {
+	rec := &kgo.Record{Headers: []kgo.RecordHeader{
+		{Key: "job_id", Value: []byte("job-1")},
+		{Key: "request_id", Value: []byte("req-42")},
+	}}
+	require.Equal(t, "req-42", recordHeader(rec, "request_id"))
+	require.Equal(t, "", recordHeader(rec, "cv_id"))
+}
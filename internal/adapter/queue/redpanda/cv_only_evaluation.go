@@ -0,0 +1,188 @@
+package redpanda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// PerformCVOnlyEvaluation evaluates a CV against the job description without
+// a project. It runs the CV step of the multi-step chain and a refinement that
+// only asks for cv_match_rate, cv_feedback and overall_summary, falling back
+// to a single CV-only prompt when a step fails.
+func (h *IntegratedEvaluationHandler) PerformCVOnlyEvaluation(
+	ctx context.Context,
+	cvContent, jobDesc, scoringRubric string,
+	jobID string,
+) (domain.Result, error) {
+	tracer := otel.Tracer("integrated.evaluation")
+	ctx, span := tracer.Start(ctx, "PerformCVOnlyEvaluation")
+	defer span.End()
+
+	if reason := domain.FastPathReasonFrom(ctx); reason != "" {
+		slog.Info("CV-only fast path selected", slog.String("job_id", jobID), slog.String("reason", reason))
+		return h.performCVOnlyFastPath(ctx, cvContent, jobDesc, scoringRubric, jobID)
+	}
+	slog.Info("performing CV-only evaluation", slog.String("job_id", jobID))
+
+	step1Ctx, step1Span := tracer.Start(ctx, "PerformCVOnlyEvaluation.evaluateCVMatch")
+	cvEvaluation, err := h.evaluateCVMatch(step1Ctx, cvContent, jobDesc, scoringRubric, jobID)
+	step1Span.End()
+	if err != nil {
+		slog.Error("step 1: evaluateCVMatch failed; falling back to CV-only fast path",
+			slog.String("job_id", jobID),
+			slog.Any("error", err))
+		return h.performCVOnlyFastPath(ctx, cvContent, jobDesc, scoringRubric, jobID)
+	}
+
+	step2Ctx, step2Span := tracer.Start(ctx, "PerformCVOnlyEvaluation.refineEvaluation")
+	refined, err := h.refineCVOnlyEvaluation(step2Ctx, cvEvaluation, jobID)
+	step2Span.End()
+	if err != nil {
+		slog.Error("step 2: refineCVOnlyEvaluation failed; falling back to CV-only fast path",
+			slog.String("job_id", jobID),
+			slog.Any("error", err))
+		return h.performCVOnlyFastPath(ctx, cvContent, jobDesc, scoringRubric, jobID)
+	}
+
+	result, err := h.parseCVOnlyResponse(ctx, refined, jobID)
+	if err != nil {
+		slog.Error("parseCVOnlyResponse failed; falling back to CV-only fast path",
+			slog.String("job_id", jobID),
+			slog.Any("error", err))
+		return h.performCVOnlyFastPath(ctx, cvContent, jobDesc, scoringRubric, jobID)
+	}
+
+	slog.Info("CV-only evaluation completed successfully", slog.String("job_id", jobID),
+		slog.Float64("cv_match_rate", result.CVMatchRate))
+	observability.ObserveEvaluation(result.CVMatchRate, 0)
+	return result, nil
+}
+
+// refineCVOnlyEvaluation turns the CV analysis into the final CV-only JSON.
+func (h *IntegratedEvaluationHandler) refineCVOnlyEvaluation(ctx context.Context, cvEvaluation, jobID string) (string, error) {
+	ctx = withStep(ctx, stepRefineCVOnly)
+	prompt := `You are a technical reviewer. Refine the CV evaluation results into a final score and feedback. No project was submitted; do not mention or score one.
+
+CV Evaluation Results:
+%s
+
+Please provide the final evaluation in JSON format (no explanations in the output):
+{
+  "cv_match_rate": 0.85,
+  "cv_feedback": "Professional CV feedback",
+  "overall_summary": "Candidate summary with recommendations based on the CV"
+}
+
+Guidelines:
+- cv_match_rate: 0.0 to 1.0 (0=no match, 1=perfect match)
+- Provide professional, constructive feedback
+- Return only the JSON object, no additional text
+
+`
+	response, err := h.performStableEvaluation(ctx, fmt.Sprintf(prompt, cvEvaluation)+h.parameterScoresPrompt(domain.RubricGroupCV)+h.feedbackFormatPrompt()+h.feedbackLanguagePrompt(), jobID)
+	if err != nil {
+		return "", fmt.Errorf("AI CV-only refinement failed: %w", err)
+	}
+	return response, nil
+}
+
+// performCVOnlyFastPath evaluates the CV with a single prompt.
+func (h *IntegratedEvaluationHandler) performCVOnlyFastPath(ctx context.Context, cvContent, jobDesc, scoringRubric, jobID string) (domain.Result, error) {
+	// The fast path alone produces the result; drop the calls of an
+	// abandoned multi-step chain from the provenance.
+	domain.ResetModelTrace(ctx)
+	ctx = withStep(fastPathContext(ctx), stepFastPathCV)
+	tracer := otel.Tracer("integrated.evaluation")
+	ctx, span := tracer.Start(ctx, "PerformCVOnlyEvaluation.fastPath")
+	defer span.End()
+
+	prompt := fmt.Sprintf(`You are a senior technical recruiter evaluating a candidate's CV. No project was submitted.
+
+CV Content:
+%s
+
+Job Description:
+%s
+
+Scoring Rubric (apply only the CV parameters):
+%s
+
+Using the information above, produce a single JSON object with the following fields:
+{
+  "cv_match_rate": 0.85,
+  "cv_feedback": "Professional CV feedback",
+  "overall_summary": "Candidate summary with recommendations based on the CV"
+}
+
+Guidelines:
+- cv_match_rate: 0.0 to 1.0 (0=no match, 1=perfect match)
+- Provide professional, constructive feedback in the feedback fields.
+- Return only the JSON object, with no extra commentary, prose, or code fences.
+%s`, cvContent, jobDesc, scoringRubric, h.parameterScoresPrompt(domain.RubricGroupCV)+h.feedbackFormatPrompt()+h.feedbackLanguagePrompt())
+
+	response, err := h.performStableEvaluation(ctx, prompt, jobID)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("fast CV-only evaluation failed: %w", err)
+	}
+	result, err := h.parseCVOnlyResponse(ctx, response, jobID)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("result validation failed: %w", err)
+	}
+	slog.Info("CV-only evaluation completed successfully with fast path", slog.String("job_id", jobID),
+		slog.Float64("cv_match_rate", result.CVMatchRate))
+	observability.ObserveEvaluation(result.CVMatchRate, 0)
+	return result, nil
+}
+
+// parseCVOnlyResponse parses and validates a CV-only evaluation. Any project
+// fields the model adds are dropped.
+func (h *IntegratedEvaluationHandler) parseCVOnlyResponse(ctx context.Context, response, jobID string) (domain.Result, error) {
+	cleaned, err := h.cleanJSONResponseWithCoTFallback(ctx, response, jobID)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("clean JSON response: %w", err)
+	}
+	var data struct {
+		CVMatchRate    *float64 `json:"cv_match_rate"`
+		CVFeedback     string   `json:"cv_feedback"`
+		OverallSummary string   `json:"overall_summary"`
+		// Per-parameter 1-5 scores, requested only with custom weights.
+		CVParameterScores map[string]float64 `json:"cv_parameter_scores"`
+	}
+	if err := json.Unmarshal([]byte(cleaned), &data); err != nil {
+		return domain.Result{}, fmt.Errorf("parse evaluation JSON: %w", err)
+	}
+	if data.CVMatchRate == nil {
+		return domain.Result{}, fmt.Errorf("missing cv_match_rate")
+	}
+	rate := min(max(*data.CVMatchRate, 0), 1)
+	if rate != *data.CVMatchRate {
+		slog.Warn("invalid CV match rate, clamping to valid range",
+			slog.String("job_id", jobID),
+			slog.Float64("cv_match_rate", *data.CVMatchRate))
+	}
+	result := domain.Result{
+		JobID:          jobID,
+		CVMatchRate:    rate,
+		CVFeedback:     data.CVFeedback,
+		OverallSummary: data.OverallSummary,
+		CVOnly:         true,
+		CreatedAt:      time.Now(),
+	}
+	h.applyScoringWeights(&result, data.CVParameterScores, nil)
+	h.formatFeedback(&result)
+	if result.CVFeedback == "" {
+		result.CVFeedback = "No feedback provided"
+	}
+	if result.OverallSummary == "" {
+		result.OverallSummary = "No summary provided"
+	}
+	return result, nil
+}
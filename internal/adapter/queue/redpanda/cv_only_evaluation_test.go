@@ -0,0 +1,65 @@
+package redpanda
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// cvOnlyTestAI answers every prompt with refine, and records the prompts.
+type cvOnlyTestAI struct {
+	prompts []string
+	refine  string
+}
+
+func (a *cvOnlyTestAI) Embed(_ domain.Context, _ []string) ([][]float32, error) { return nil, nil }
+
+func (a *cvOnlyTestAI) ChatJSON(_ domain.Context, _ string, _ string, _ int) (string, error) {
+	return "", nil
+}
+
+func (a *cvOnlyTestAI) ChatJSONWithRetry(_ domain.Context, prompt, _ string, _ int) (string, error) {
+	a.prompts = append(a.prompts, prompt)
+	if strings.Contains(prompt, "senior technical recruiter") {
+		return `{"cv_match_rate":0.6,"cv_feedback":"fast","overall_summary":"fast"}`, nil
+	}
+	return a.refine, nil
+}
+
+func (a *cvOnlyTestAI) CleanCoTResponse(_ domain.Context, response string) (string, error) {
+	return response, nil
+}
+
+func TestPerformCVOnlyEvaluation(t *testing.T) {
+	ai := &cvOnlyTestAI{refine: `{"cv_match_rate":1.4,"cv_feedback":"strong Go","project_score":7,"project_feedback":"invented","overall_summary":"hire"}`}
+	h := NewIntegratedEvaluationHandler(ai, nil)
+
+	res, err := h.PerformCVOnlyEvaluation(context.Background(), "cv", "jd", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.True(t, res.CVOnly)
+	assert.Equal(t, 1.0, res.CVMatchRate, "clamped")
+	assert.Equal(t, "strong Go", res.CVFeedback)
+	assert.Zero(t, res.ProjectScore)
+	assert.Empty(t, res.ProjectFeedback)
+	require.Len(t, ai.prompts, 2)
+	for _, p := range ai.prompts {
+		assert.NotContains(t, p, "Project Content")
+	}
+	assert.Contains(t, ai.prompts[1], "No project was submitted")
+}
+
+func TestPerformCVOnlyEvaluation_FallsBackToFastPath(t *testing.T) {
+	ai := &cvOnlyTestAI{refine: `{"cv_feedback":"no score"}`}
+	h := NewIntegratedEvaluationHandler(ai, nil)
+
+	res, err := h.PerformCVOnlyEvaluation(context.Background(), "cv", "jd", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.True(t, res.CVOnly)
+	assert.Equal(t, 0.6, res.CVMatchRate)
+	assert.Equal(t, "fast", res.CVFeedback)
+}
@@ -20,6 +20,8 @@ type DLQConsumer struct {
 	groupID      string
 	topic        string
 	shutdown     chan struct{}
+	pause        *topicPause
+	cipher       *PayloadCipher
 }
 
 // NewDLQConsumer creates a new DLQ consumer
@@ -40,7 +42,7 @@ func NewDLQConsumer(brokers []string, groupID string, retryManager *RetryManager
 	opts := []kgo.Opt{
 		kgo.SeedBrokers(brokers...),
 		kgo.ConsumerGroup(groupID),
-		kgo.ConsumeTopics("dlq-jobs"),
+		kgo.ConsumeTopics(TopicDLQ),
 		kgo.FetchIsolationLevel(kgo.ReadCommitted()),
 		kgo.RequireStableFetchOffsets(),
 		// DLQ-specific settings
@@ -67,7 +69,7 @@ func NewDLQConsumer(brokers []string, groupID string, retryManager *RetryManager
 		retryManager: retryManager,
 		jobs:         jobs,
 		groupID:      groupID,
-		topic:        "dlq-jobs",
+		topic:        TopicDLQ,
 		shutdown:     make(chan struct{}),
 	}, nil
 }
@@ -82,6 +84,19 @@ func (dc *DLQConsumer) Start(ctx context.Context) error {
 	return nil
 }
 
+// WithPauses stops polling the DLQ while checker reports it paused.
+func (dc *DLQConsumer) WithPauses(checker PauseChecker) *DLQConsumer {
+	dc.pause = &topicPause{checker: checker, topic: dc.topic}
+	return dc
+}
+
+// WithPayloadCipher decrypts encrypted DLQ messages. A nil cipher accepts
+// plaintext messages only.
+func (dc *DLQConsumer) WithPayloadCipher(pc *PayloadCipher) *DLQConsumer {
+	dc.cipher = pc
+	return dc
+}
+
 // Stop stops the DLQ consumer
 func (dc *DLQConsumer) Stop() {
 	slog.Info("stopping DLQ consumer")
@@ -103,6 +118,9 @@ func (dc *DLQConsumer) dlqMessageProcessor(ctx context.Context) {
 			slog.Info("DLQ message processor shutting down due to shutdown signal")
 			return
 		default:
+			if dc.pause.hold(ctx, dc.client) {
+				continue
+			}
 			// Poll for DLQ messages
 			fetchCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
 			fetches := dc.client.PollFetches(fetchCtx)
@@ -152,6 +170,15 @@ func (dc *DLQConsumer) processDLQRecord(ctx context.Context, record *kgo.Record)
 		slog.Int64("offset", record.Offset),
 		slog.String("key", string(record.Key)))
 
+	if err := dc.cipher.Open(record); err != nil {
+		slog.Error("failed to decrypt DLQ message",
+			slog.String("topic", record.Topic),
+			slog.Int("partition", int(record.Partition)),
+			slog.Int64("offset", record.Offset),
+			slog.Any("error", err))
+		return
+	}
+
 	// Parse DLQ message
 	var dlqMessage map[string]interface{}
 	if err := json.Unmarshal(record.Value, &dlqMessage); err != nil {
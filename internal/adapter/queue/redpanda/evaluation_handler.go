@@ -9,20 +9,78 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"go.opentelemetry.io/otel"
 
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/anonymize"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/promptguard"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/safety"
 	adapterobs "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
 	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
 	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/envelope"
 )
 
+// EvaluateOptions holds the optional screening steps of HandleEvaluate.
+type EvaluateOptions struct {
+	// PromptGuard screens uploaded documents before they reach any prompt; nil disables it.
+	PromptGuard *promptguard.Guard
+	// SafetyFilter removes protected-attribute commentary from feedback before it is stored; nil disables it.
+	SafetyFilter *safety.Filter
+	// Normalizer adjusts scores for the model that produced them; nil keeps raw scores.
+	Normalizer ScoreNormalizer
+	// Checkpoints stores completed chain steps so retries resume after them; nil disables it.
+	Checkpoints domain.CheckpointRepository
+	// Quota counts the tokens of tenants' jobs against their daily budgets; nil disables it.
+	Quota domain.TenantQuotaRepository
+	// Similarity compares project reports with the tenant's earlier submissions; nil disables it.
+	Similarity *SimilarityIndex
+	// Experiments assigns jobs to the variants of active prompt experiments; nil disables them.
+	Experiments domain.PromptExperimentRepository
+	// SLA bounds the evaluation of jobs whose payload carries none; 0 means defaultEvaluationSLA.
+	SLA time.Duration
+	// RAGCache shares the context retrieved for a posting between its jobs;
+	// nil disables it.
+	RAGCache *RAGCache
+	// FastPath selects the single-prompt fast path for interactive jobs and
+	// when the worker is behind its latency budget.
+	FastPath FastPathPolicy
+	// JDLanguage is the JDLanguage* mode applied when the job description
+	// and the CV are in different languages; empty disables the check.
+	JDLanguage string
+	// Usage stores the phase timings of completed jobs; nil disables it.
+	Usage domain.JobUsageRepository
+}
+
+// defaultEvaluationSLA bounds evaluations when neither the job nor the
+// worker sets an SLA.
+const defaultEvaluationSLA = 5 * time.Minute
+
+// evaluationSLA returns the time the evaluation of payload may take.
+func evaluationSLA(payload domain.EvaluateTaskPayload, opts EvaluateOptions) time.Duration {
+	switch {
+	case payload.SLA > 0:
+		return payload.SLA
+	case opts.SLA > 0:
+		return opts.SLA
+	default:
+		return defaultEvaluationSLA
+	}
+}
+
+// ScoreNormalizer makes scores comparable across the models that serve
+// evaluations. It is implemented by usecase.ScoreNormalizer.
+type ScoreNormalizer interface {
+	Normalize(ctx context.Context, model string, res domain.Result) domain.Result
+}
+
 // HandleEvaluate processes an evaluation task with the given dependencies.
 // This is the evaluation logic that uses the enhanced AI evaluation system by default.
+// opts controls screening of the uploaded documents and of the generated feedback.
 //
 //nolint:gocyclo // Function orchestrates complex evaluation process.
 func HandleEvaluate(
@@ -33,6 +91,7 @@ func HandleEvaluate(
 	ai domain.AIClient,
 	q *qdrantcli.Client,
 	payload domain.EvaluateTaskPayload,
+	opts EvaluateOptions,
 ) error {
 	tracer := otel.Tracer("queue.handler")
 	ctx, span := tracer.Start(ctx, "HandleEvaluate")
@@ -55,28 +114,38 @@ func HandleEvaluate(
 	if ai == nil {
 		return fmt.Errorf("AI client is nil")
 	}
+	ai = timeAICalls(ai)
 
-	// FIXED: Add timeout handling for stuck processing jobs
-	// Create a timeout context for the entire evaluation process
-	timeoutDuration := 5 * time.Minute // 5 minutes timeout for AI processing
-	if v := os.Getenv("E2E_AI_TIMEOUT"); v != "" {
-		if d, err := time.ParseDuration(v); err == nil {
-			timeoutDuration = d
-		}
-	}
+	// The job's SLA is the deadline of every step and AI call below, so the
+	// evaluation stops at the same boundary wherever it is.
+	timeoutDuration := evaluationSLA(payload, opts)
 	evalCtx, cancel := context.WithTimeout(ctx, timeoutDuration)
 	defer cancel()
+	// Record which models serve the AI calls; the last one produced the scores
+	// and the labeled ones make up the result's provenance.
+	evalCtx, models := domain.WithModelTrace(evalCtx)
+	evalCtx = domain.WithEvaluationJob(evalCtx, payload.JobID)
+	// Time spent in AI calls and RAG lookups is set apart in the job's usage.
+	evalCtx, timer := domain.WithJobTimer(evalCtx)
 
 	// If the job is already in a terminal state, skip processing entirely. This
 	// prevents re-delivered messages for completed/failed jobs from being
 	// counted as additional failed evaluations in Prometheus metrics.
 	job, err := jobs.Get(ctx, payload.JobID)
-	if err == nil && (job.Status == domain.JobCompleted || job.Status == domain.JobFailed) {
+	if err == nil && (job.Status == domain.JobCompleted || job.Status == domain.JobFailed || job.Status == domain.JobExpired) {
 		slog.Info("job already in terminal state; skipping evaluation",
 			slog.String("job_id", payload.JobID),
 			slog.String("status", string(job.Status)))
 		return nil
 	}
+	// A job that waited in the queue past its expiry is not processed.
+	if err == nil && job.Status == domain.JobQueued && job.Expired(time.Now()) {
+		slog.Info("job expired before processing; skipping evaluation", slog.String("job_id", payload.JobID))
+		if err := jobs.UpdateStatus(ctx, payload.JobID, domain.JobExpired, nil); err != nil {
+			return fmt.Errorf("expire job: %w", err)
+		}
+		return nil
+	}
 
 	// Track job processing lifecycle for Prometheus job-queue metrics. These
 	// metrics live in the worker process and are scraped via the worker's
@@ -84,7 +153,26 @@ func HandleEvaluate(
 	// already in a terminal state so that re-deliveries do not skew success
 	// rates.
 	adapterobs.StartProcessingJob("evaluate")
+	// Observe the tokens the job used across all attempts, successful or not,
+	// and charge them to the tenant.
+	defer func() {
+		prompt, completion := models.Tokens()
+		if prompt+completion == 0 {
+			return
+		}
+		adapterobs.ObserveEvaluationJobTokens(prompt, completion)
+		if opts.Quota != nil && payload.TenantID != "" {
+			if err := opts.Quota.AddTokens(context.Background(), payload.TenantID, time.Now(), int64(prompt+completion)); err != nil {
+				lg.Warn("failed to count tokens against tenant quota", slog.String("tenant_id", payload.TenantID), slog.Any("error", err))
+			}
+		}
+	}()
+	// Jobs run the prompt variants of active experiments, whose report
+	// compares their outcome.
+	evalCtx, finishExperiments := startPromptExperiments(evalCtx, opts.Experiments, payload.JobID)
+	defer func() { finishExperiments(time.Since(start)) }()
 	success := false
+	lease := jobLeaseFrom(ctx)
 	defer func() {
 		if success {
 			adapterobs.CompleteJob("evaluate")
@@ -92,8 +180,12 @@ func HandleEvaluate(
 		}
 
 		adapterobs.FailJob("evaluate")
+		if arm := domain.ModelArmFrom(ctx); arm != "" {
+			adapterobs.RecordModelABJob(arm, false, 0, 0, 0)
+		}
 
-		if jobs == nil {
+		// The consumer that took the job over decides its outcome.
+		if jobs == nil || lease.Lost() {
 			return
 		}
 
@@ -113,7 +205,12 @@ func HandleEvaluate(
 		}
 	}()
 
-	// Update job status to processing
+	// Update job status to processing, unless another consumer took the job
+	// over and owns its status
+	if lease.Lost() {
+		lg.Warn("job lease lost before processing", slog.String("job_id", payload.JobID))
+		return fmt.Errorf("update job status: %w: job lease lost", domain.ErrConflict)
+	}
 	if err := jobs.UpdateStatus(evalCtx, payload.JobID, domain.JobProcessing, nil); err != nil {
 		lg.Error("failed to update job status to processing", slog.String("job_id", payload.JobID), slog.Any("error", err))
 		return fmt.Errorf("update job status: %w", err)
@@ -129,6 +226,10 @@ func HandleEvaluate(
 		if evalCtx.Err() != context.DeadlineExceeded {
 			return
 		}
+		// The consumer that took the job over decides its outcome.
+		if lease.Lost() {
+			return
+		}
 
 		lg.Warn("job processing timeout exceeded, marking as failed",
 			slog.String("job_id", payload.JobID),
@@ -149,57 +250,70 @@ func HandleEvaluate(
 			slog.String("error_code", code))
 	}()
 
-	// Get CV content
-	cvUpload, err := uploads.Get(evalCtx, payload.CVID)
-	if err != nil {
-		lg.Error("failed to get CV content", slog.String("job_id", payload.JobID), slog.String("cv_id", payload.CVID), slog.Any("error", err))
-		msg := "failed to get CV content"
-		_ = jobs.UpdateStatus(ctx, payload.JobID, domain.JobFailed, ptr(msg))
-		adapterobs.RecordJobFailureByCode("evaluate", classifyFailureCode(msg))
-		return fmt.Errorf("get CV content: %w", err)
+	extractionStart := time.Now()
+	// Get CV content; project-only evaluations have none.
+	var cvUpload domain.Upload
+	if !payload.ProjectOnly {
+		cvUpload, err = uploads.Get(evalCtx, payload.CVID)
+		if err != nil {
+			lg.Error("failed to get CV content", slog.String("job_id", payload.JobID), slog.String("cv_id", payload.CVID), slog.Any("error", err))
+			msg := "failed to get CV content"
+			_ = jobs.UpdateStatus(ctx, payload.JobID, domain.JobFailed, ptr(msg))
+			adapterobs.RecordJobFailureByCode("evaluate", classifyFailureCode(msg))
+			return fmt.Errorf("get CV content: %w", err)
+		}
 	}
 
-	// Get project content
-	projectUpload, err := uploads.Get(evalCtx, payload.ProjectID)
-	if err != nil {
-		lg.Error("failed to get project content", slog.String("job_id", payload.JobID), slog.String("project_id", payload.ProjectID), slog.Any("error", err))
-		msg := "failed to get project content"
-		_ = jobs.UpdateStatus(ctx, payload.JobID, domain.JobFailed, ptr(msg))
-		adapterobs.RecordJobFailureByCode("evaluate", classifyFailureCode(msg))
-		return fmt.Errorf("get project content: %w", err)
+	// Get project content; CV-only evaluations have none.
+	var projectUpload domain.Upload
+	if !payload.CVOnly {
+		projectUpload, err = uploads.Get(evalCtx, payload.ProjectID)
+		if err != nil {
+			lg.Error("failed to get project content", slog.String("job_id", payload.JobID), slog.String("project_id", payload.ProjectID), slog.Any("error", err))
+			msg := "failed to get project content"
+			_ = jobs.UpdateStatus(ctx, payload.JobID, domain.JobFailed, ptr(msg))
+			adapterobs.RecordJobFailureByCode("evaluate", classifyFailureCode(msg))
+			return fmt.Errorf("get project content: %w", err)
+		}
+	}
+
+	// Screen user-controlled documents for prompt injection before they are
+	// inserted into prompts.
+	cvText := screenDocument(ctx, jobs, opts.PromptGuard, job.SecurityNotes, payload.JobID, "cv", cvUpload.Text)
+	projectText := screenDocument(ctx, jobs, opts.PromptGuard, job.SecurityNotes, payload.JobID, "project", projectUpload.Text)
+	if payload.Overrides.Anonymize {
+		cvText = anonymize.Text(cvText)
+		projectText = anonymize.Text(projectText)
+	}
+	extraction := time.Since(extractionStart)
+
+	// Prompt with a job description in the CV's language, or at least record
+	// that they differ. The payload itself is left untouched for retries.
+	evalPayload := payload
+	var language *domain.LanguageMismatch
+	if !payload.ProjectOnly {
+		evalPayload.JobDescription, language = checkJDLanguage(evalCtx, ai, opts.JDLanguage, payload.JobID, payload.JobDescription, cvText)
+	}
+
+	// Interactive jobs and jobs of a worker behind its latency budget take
+	// the single-prompt fast path.
+	if reason := opts.FastPath.Select(payload, job, time.Now()); reason != "" {
+		evalCtx = domain.WithFastPathReason(evalCtx, reason)
 	}
 
 	// Perform enhanced AI evaluation with retry logic and model fallback
 	lg.Info("performing enhanced AI evaluation with retry logic", slog.String("job_id", payload.JobID))
-	handler := NewIntegratedEvaluationHandler(ai, q)
+	handler := NewIntegratedEvaluationHandler(trackLatency(ai, opts.FastPath.Latency), q).
+		WithScoringWeights(payload.ScoringWeights).
+		WithRubric(payload.Rubric).
+		WithFeedbackFormat(payload.FeedbackFormat).
+		WithFeedbackLanguage(payload.FeedbackLanguage).
+		WithCheckpoints(opts.Checkpoints).
+		WithRAGCache(opts.RAGCache, payload.ScoringRubric)
 
 	// Retry evaluation with exponential backoff
-	maxRetries := 3
-	var result domain.Result
-	var lastErr error
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		slog.Info("evaluation attempt", slog.String("job_id", payload.JobID), slog.Int("attempt", attempt), slog.Int("max_retries", maxRetries))
-
-		result, lastErr = handler.PerformIntegratedEvaluation(evalCtx, cvUpload.Text, projectUpload.Text, payload.JobDescription, payload.StudyCaseBrief, payload.ScoringRubric, payload.JobID)
-		if lastErr == nil {
-			lg.Info("evaluation succeeded", slog.String("job_id", payload.JobID), slog.Int("attempt", attempt))
-			break
-		}
-
-		lg.Warn("evaluation attempt failed",
-			slog.String("job_id", payload.JobID),
-			slog.Int("attempt", attempt),
-			slog.Int("max_retries", maxRetries),
-			slog.Any("error", lastErr))
-
-		// If this is not the last attempt, wait before retrying
-		if attempt < maxRetries {
-			backoffDuration := time.Duration(attempt) * 2 * time.Second
-			lg.Info("waiting before retry", slog.String("job_id", payload.JobID), slog.Duration("backoff", backoffDuration))
-			time.Sleep(backoffDuration)
-		}
-	}
+	maxRetries := maxEvaluationAttempts
+	result, lastErr := evaluateWithRetries(evalCtx, handler, evalPayload, cvText, projectText, models)
 
 	if lastErr != nil {
 		lg.Error("enhanced evaluation failed after all retries",
@@ -253,9 +367,42 @@ func HandleEvaluate(
 		return fmt.Errorf("enhanced evaluation failed after %d attempts: %w", maxRetries, lastErr)
 	}
 
-	// Store the result FIRST
+	postProcessingStart := time.Now()
+	// Record the custom weights the scores were aggregated with.
+	result.ScoringWeights = payload.ScoringWeights
+	result.Provenance = models.Provenance()
+	result.Language = language
+	if opts.Normalizer != nil {
+		result = opts.Normalizer.Normalize(ctx, models.Last(), result)
+	}
+
+	// Remove protected-attribute commentary before anything is persisted.
+	result = filterFeedback(result, opts.SafetyFilter, payload.JobID)
+	result.Similarity = checkSimilarity(ctx, opts.Similarity, payload, projectUpload.Text)
+
+	// Tenants with a public key get their feedback encrypted; a result that
+	// cannot be encrypted is never stored in plain text.
+	if payload.ResultPublicKey != "" {
+		encrypted, err := envelope.Encrypt(result, payload.ResultPublicKey)
+		if err != nil {
+			lg.Error("failed to encrypt result", slog.String("job_id", payload.JobID), slog.Any("error", err))
+			failMsg := "failed to encrypt evaluation result"
+			if jobs != nil {
+				_ = jobs.UpdateStatus(ctx, payload.JobID, domain.JobFailed, &failMsg)
+			}
+			adapterobs.RecordJobFailureByCode("evaluate", classifyFailureCode(failMsg))
+			return fmt.Errorf("encrypt result: %w", err)
+		}
+		result = encrypted
+	}
+
+	// Store the result FIRST; only the current lease holder may store it.
 	lg.Info("storing evaluation result", slog.String("job_id", payload.JobID))
-	if err := results.Upsert(ctx, result); err != nil {
+	if err := lease.StoreResult(ctx, results, result); err != nil {
+		if lease.Lost() {
+			lg.Warn("job lease lost before storing result", slog.String("job_id", payload.JobID), slog.Any("error", err))
+			return fmt.Errorf("store result: %w", err)
+		}
 		lg.Error("failed to store result", slog.String("job_id", payload.JobID), slog.Any("error", err))
 		failMsg := "failed to store evaluation result"
 		if jobs != nil {
@@ -283,10 +430,151 @@ func HandleEvaluate(
 	}
 	lg.Info("job status updated to completed successfully", slog.String("job_id", payload.JobID))
 	success = true
+	completedAt := time.Now()
+	aiTime, aiCalls := timer.AI()
+	usage := domain.JobUsage{
+		JobID:          payload.JobID,
+		Extraction:     extraction,
+		RAG:            timer.RAG(),
+		AI:             aiTime,
+		AICalls:        aiCalls,
+		PostProcessing: completedAt.Sub(postProcessingStart),
+		Total:          completedAt.Sub(start),
+		CompletedAt:    completedAt,
+	}
+	// Jobs whose record could not be loaded are timed from their start.
+	if !job.CreatedAt.IsZero() && job.CreatedAt.Before(start) {
+		usage.QueueWait = start.Sub(job.CreatedAt)
+		usage.Total = completedAt.Sub(job.CreatedAt)
+	}
+	recordJobUsage(ctx, opts.Usage, usage)
+	if arm := domain.ModelArmFrom(ctx); arm != "" {
+		adapterobs.RecordModelABJob(arm, true, result.CVMatchRate, result.ProjectScore, time.Since(start))
+	}
+	// The checkpoints are not needed once the result is stored.
+	if opts.Checkpoints != nil {
+		if err := opts.Checkpoints.Delete(ctx, payload.JobID); err != nil {
+			lg.Warn("failed to delete evaluation checkpoints", slog.String("job_id", payload.JobID), slog.Any("error", err))
+		}
+	}
 	lg.Info("job completed",
 		slog.String("job_id", payload.JobID),
 		slog.Duration("processing_duration", time.Since(start)))
 	return nil
 }
 
+// maxEvaluationAttempts is how often an evaluation is attempted before the
+// job fails.
+const maxEvaluationAttempts = 3
+
+// evaluateWithRetries evaluates payload on the screened texts with handler,
+// retrying failed attempts with a linear backoff up to maxEvaluationAttempts
+// times; no attempt starts once ctx is done. models only keeps the calls of
+// the last attempt.
+func evaluateWithRetries(ctx context.Context, handler *IntegratedEvaluationHandler, payload domain.EvaluateTaskPayload, cvText, projectText string, models *domain.ModelTrace) (domain.Result, error) {
+	lg := obsctx.LoggerFromContext(ctx)
+	var result domain.Result
+	var lastErr error
+	for attempt := 1; attempt <= maxEvaluationAttempts; attempt++ {
+		lg.Info("evaluation attempt", slog.String("job_id", payload.JobID), slog.Int("attempt", attempt), slog.Int("max_retries", maxEvaluationAttempts))
+		// Only the calls of the attempt that produces the result count.
+		models.Reset()
+
+		switch {
+		case payload.CVOnly:
+			result, lastErr = handler.PerformCVOnlyEvaluation(ctx, cvText, payload.JobDescription, payload.ScoringRubric, payload.JobID)
+		case payload.ProjectOnly:
+			result, lastErr = handler.PerformProjectOnlyEvaluation(ctx, projectText, payload.StudyCaseBrief, payload.ScoringRubric, payload.JobID)
+		default:
+			result, lastErr = handler.PerformIntegratedEvaluation(ctx, cvText, projectText, payload.JobDescription, payload.StudyCaseBrief, payload.ScoringRubric, payload.JobID)
+		}
+		if lastErr == nil {
+			lg.Info("evaluation succeeded", slog.String("job_id", payload.JobID), slog.Int("attempt", attempt))
+			return result, nil
+		}
+
+		lg.Warn("evaluation attempt failed",
+			slog.String("job_id", payload.JobID),
+			slog.Int("attempt", attempt),
+			slog.Int("max_retries", maxEvaluationAttempts),
+			slog.Any("error", lastErr))
+
+		// If this is not the last attempt, wait before retrying; no attempt
+		// starts past the SLA.
+		if attempt < maxEvaluationAttempts {
+			backoffDuration := time.Duration(attempt) * 2 * time.Second
+			lg.Info("waiting before retry", slog.String("job_id", payload.JobID), slog.Duration("backoff", backoffDuration))
+			select {
+			case <-time.After(backoffDuration):
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+	}
+	return result, lastErr
+}
+
+// screenDocument runs guard over an uploaded document and returns the text to
+// use in prompts. Each matched rule is recorded once as a security note on the
+// job; notes already present (from an earlier delivery) are not repeated.
+func screenDocument(ctx context.Context, jobs domain.JobRepository, guard *promptguard.Guard, existing []string, jobID, source, text string) string {
+	out, findings := guard.Apply(text)
+	if len(findings) == 0 {
+		return out
+	}
+	action := "flagged"
+	if guard.Mode() == promptguard.ModeSanitize {
+		action = "sanitized"
+	}
+	seen := make(map[string]bool, len(findings))
+	for _, f := range findings {
+		adapterobs.RecordPromptInjection(source, f.Rule)
+		if seen[f.Rule] {
+			continue
+		}
+		seen[f.Rule] = true
+		note := fmt.Sprintf("prompt injection in %s (%s, %s): %q", source, f.Rule, action, truncateString(f.Match, 80))
+		slog.Warn("prompt injection detected in uploaded document",
+			slog.String("job_id", jobID),
+			slog.String("source", source),
+			slog.String("rule", f.Rule),
+			slog.String("action", action))
+		if slices.Contains(existing, note) {
+			continue
+		}
+		if err := jobs.AddSecurityNote(ctx, jobID, note); err != nil {
+			slog.Error("failed to record security note", slog.String("job_id", jobID), slog.Any("error", err))
+		}
+	}
+	return out
+}
+
+// filterFeedback runs filter over the feedback fields of res. Removed
+// sentences are logged and counted per field and category.
+func filterFeedback(res domain.Result, filter *safety.Filter, jobID string) domain.Result {
+	fields := []struct {
+		name string
+		text *string
+	}{
+		{"cv_feedback", &res.CVFeedback},
+		{"project_feedback", &res.ProjectFeedback},
+		{"overall_summary", &res.OverallSummary},
+	}
+	for _, f := range fields {
+		cleaned, violations := filter.Clean(*f.text)
+		for _, v := range violations {
+			adapterobs.RecordFeedbackSafetyViolation(f.name, v.Category)
+			slog.Warn("removed protected-attribute commentary from feedback",
+				slog.String("job_id", jobID),
+				slog.String("field", f.name),
+				slog.String("category", v.Category),
+				slog.String("sentence", adapterobs.RedactedText(v.Sentence)))
+		}
+		*f.text = cleaned
+	}
+	return res
+}
+
 // ptr returns a pointer to the given string.
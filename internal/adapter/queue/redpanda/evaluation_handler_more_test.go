@@ -13,7 +13,7 @@ func TestHandleEvaluate_NilRepositories_Error(t *testing.T) {
 	ctx := context.Background()
 	payload := domain.EvaluateTaskPayload{JobID: "job-1", CVID: "cv-1", ProjectID: "project-1"}
 
-	err := HandleEvaluate(ctx, nil, nil, nil, nil, nil, payload)
+	err := HandleEvaluate(ctx, nil, nil, nil, nil, nil, payload, EvaluateOptions{})
 	require.Error(t, err)
 }
 
@@ -38,7 +38,7 @@ func TestHandleEvaluate_UploadsError_UpdatesJobFailed(t *testing.T) {
 		ScoringRubric:  "rubric",
 	}
 
-	err := HandleEvaluate(ctx, jobs, uploads, results, ai, nil, payload)
+	err := HandleEvaluate(ctx, jobs, uploads, results, ai, nil, payload, EvaluateOptions{})
 	require.Error(t, err)
 
 	job, err := jobs.Get(ctx, "job-1")
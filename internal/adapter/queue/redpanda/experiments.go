@@ -0,0 +1,71 @@
+package redpanda
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// assignVariant picks the variant of e that a job runs. The choice hashes
+// the job and experiment IDs, so redeliveries of a job keep their variant
+// and TrafficB percent of jobs run variant B.
+func assignVariant(jobID string, e domain.PromptExperiment) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(e.ID + "/" + jobID))
+	if int(h.Sum32()%100) < e.TrafficB {
+		return domain.PromptVariantB
+	}
+	return domain.PromptVariantA
+}
+
+type parseFailuresKey struct{}
+
+// countParseFailure records a model response of the job evaluated with ctx
+// that was not valid JSON.
+func countParseFailure(ctx context.Context) {
+	if n, ok := ctx.Value(parseFailuresKey{}).(*atomic.Int64); ok {
+		n.Add(1)
+	}
+}
+
+// startPromptExperiments assigns the job to a variant of every active prompt
+// experiment and returns a ctx whose steps use the assigned prompt versions.
+// The returned func records the job's parse failures and latency for the
+// experiment report. Experiments never fail a job: when they cannot be loaded
+// or the assignment is not stored, the job runs the default prompts.
+func startPromptExperiments(ctx context.Context, repo domain.PromptExperimentRepository, jobID string) (context.Context, func(latency time.Duration)) {
+	noop := func(time.Duration) {}
+	if repo == nil {
+		return ctx, noop
+	}
+	active, err := repo.Active(ctx)
+	if err != nil {
+		slog.Warn("failed to load prompt experiments", slog.String("job_id", jobID), slog.Any("error", err))
+		return ctx, noop
+	}
+	if len(active) == 0 {
+		return ctx, noop
+	}
+	variants := make(map[string]string, len(active))
+	versions := make(map[string]string, len(active))
+	for _, e := range active {
+		v := assignVariant(jobID, e)
+		variants[e.ID] = v
+		versions[e.Step] = e.Version(v)
+	}
+	if err := repo.Assign(ctx, jobID, variants); err != nil {
+		slog.Warn("failed to record prompt experiment variants", slog.String("job_id", jobID), slog.Any("error", err))
+		return ctx, noop
+	}
+	failures := new(atomic.Int64)
+	ctx = context.WithValue(withPromptVersions(ctx, versions), parseFailuresKey{}, failures)
+	return ctx, func(latency time.Duration) {
+		if err := repo.Finish(context.WithoutCancel(ctx), jobID, int(failures.Load()), latency); err != nil {
+			slog.Warn("failed to record prompt experiment outcome", slog.String("job_id", jobID), slog.Any("error", err))
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+)
+
+func TestAssignVariant(t *testing.T) {
+	e := domain.PromptExperiment{ID: "e1", TrafficB: 30}
+	b := 0
+	for i := 0; i < 1000; i++ {
+		jobID := fmt.Sprintf("job-%d", i)
+		v := assignVariant(jobID, e)
+		assert.Equal(t, v, assignVariant(jobID, e), "assignment is stable")
+		if v == domain.PromptVariantB {
+			b++
+		}
+	}
+	assert.InDelta(t, 300, b, 60)
+
+	e.TrafficB = 0
+	assert.Equal(t, domain.PromptVariantA, assignVariant("job-1", e))
+	e.TrafficB = 100
+	assert.Equal(t, domain.PromptVariantB, assignVariant("job-1", e))
+}
+
+func TestStartPromptExperiments(t *testing.T) {
+	repo := mocks.NewMockPromptExperimentRepository(t)
+	exp := domain.PromptExperiment{ID: "e1", Step: stepCVMatch, VersionA: "1", VersionB: "2", TrafficB: 100}
+	repo.EXPECT().Active(mock.Anything).Return([]domain.PromptExperiment{exp}, nil).Once()
+	repo.EXPECT().Assign(mock.Anything, "job-1", map[string]string{"e1": "b"}).Return(nil).Once()
+	repo.EXPECT().Finish(mock.Anything, "job-1", 2, 3*time.Second).Return(nil).Once()
+
+	ctx, finish := startPromptExperiments(context.Background(), repo, "job-1")
+	assert.Equal(t, "2", promptVersion(ctx, stepCVMatch))
+	assert.Equal(t, cvMatchPromptV2, promptTemplate(ctx, stepCVMatch))
+	assert.Equal(t, "1", promptVersion(ctx, stepRefine), "other steps keep their version")
+	countParseFailure(ctx)
+	countParseFailure(ctx)
+	finish(3 * time.Second)
+
+	// Jobs run the default prompts when experiments cannot be loaded.
+	repo.EXPECT().Active(mock.Anything).Return(nil, assert.AnError).Once()
+	ctx, finish = startPromptExperiments(context.Background(), repo, "job-2")
+	assert.Equal(t, cvMatchPromptV1, promptTemplate(ctx, stepCVMatch))
+	finish(time.Second)
+
+	ctx, _ = startPromptExperiments(context.Background(), nil, "job-3")
+	countParseFailure(ctx)
+	assert.Equal(t, "1", promptVersion(ctx, stepCVMatch))
+}
+
+func TestHasPromptVersion(t *testing.T) {
+	require.True(t, HasPromptVersion(stepCVMatch, "1"))
+	require.True(t, HasPromptVersion(stepCVMatch, "2"))
+	assert.False(t, HasPromptVersion(stepCVMatch, "3"))
+	assert.False(t, HasPromptVersion(stepRefine, "1"))
+	// An assigned version the worker does not know falls back to the default.
+	ctx := withPromptVersions(context.Background(), map[string]string{stepCVMatch: "9"})
+	assert.Equal(t, "1", promptVersion(ctx, stepCVMatch))
+}
@@ -0,0 +1,115 @@
+package redpanda
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// FastPathPolicy selects the single-prompt fast path instead of the
+// multi-step chain when the worker is behind its latency budget. Requests
+// with priority interactive always take the fast path.
+type FastPathPolicy struct {
+	// QueueLag selects the fast path for jobs that waited in the queue
+	// longer than this; 0 disables the trigger.
+	QueueLag time.Duration
+	// ProviderLatency selects the fast path while the average latency of
+	// recent AI calls exceeds this; 0 disables the trigger.
+	ProviderLatency time.Duration
+	// Latency tracks the latency of AI calls; nil disables the provider
+	// latency trigger.
+	Latency *LatencyTracker
+}
+
+// Select returns why job should take the fast path, one of the
+// domain.FastPath* reasons, or "" for the multi-step chain.
+func (p FastPathPolicy) Select(payload domain.EvaluateTaskPayload, job domain.Job, now time.Time) string {
+	switch {
+	case payload.Priority == domain.PriorityInteractive:
+		return domain.FastPathInteractive
+	case p.QueueLag > 0 && !job.CreatedAt.IsZero() && now.Sub(job.CreatedAt) > p.QueueLag:
+		return domain.FastPathQueueLag
+	case p.ProviderLatency > 0 && p.Latency.Average() > p.ProviderLatency:
+		return domain.FastPathProviderLatency
+	}
+	return ""
+}
+
+// fastPathContext labels the calls of a fast path run with why it runs: the
+// reason the worker selected it, or a fallback from the multi-step chain.
+func fastPathContext(ctx context.Context) context.Context {
+	reason := domain.FastPathReasonFrom(ctx)
+	if reason == "" {
+		reason = domain.FastPathFallback
+		ctx = domain.WithFastPathReason(ctx, reason)
+	}
+	observability.RecordEvaluationFastPath(reason)
+	return ctx
+}
+
+// latencyAlpha is the weight of the newest sample in LatencyTracker's
+// moving average.
+const latencyAlpha = 0.2
+
+// LatencyTracker keeps an exponentially weighted moving average of AI call
+// latency. It is safe for concurrent use; the nil tracker reports 0.
+type LatencyTracker struct {
+	mu  sync.Mutex
+	avg time.Duration
+}
+
+// NewLatencyTracker creates an empty tracker.
+func NewLatencyTracker() *LatencyTracker { return &LatencyTracker{} }
+
+// Observe adds the latency of one call.
+func (t *LatencyTracker) Observe(d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.avg == 0 {
+		t.avg = d
+		return
+	}
+	t.avg = time.Duration(latencyAlpha*float64(d) + (1-latencyAlpha)*float64(t.avg))
+}
+
+// Average returns the moving average, or 0 before the first call.
+func (t *LatencyTracker) Average() time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.avg
+}
+
+// latencyTrackingAI records the latency of the chat calls of an AI client.
+type latencyTrackingAI struct {
+	domain.AIClient
+	latency *LatencyTracker
+}
+
+// trackLatency wraps ai so that its chat calls feed t; a nil t returns ai.
+func trackLatency(ai domain.AIClient, t *LatencyTracker) domain.AIClient {
+	if t == nil {
+		return ai
+	}
+	return latencyTrackingAI{AIClient: ai, latency: t}
+}
+
+func (a latencyTrackingAI) ChatJSON(ctx context.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	defer a.observe(time.Now())
+	return a.AIClient.ChatJSON(ctx, systemPrompt, userPrompt, maxTokens)
+}
+
+func (a latencyTrackingAI) ChatJSONWithRetry(ctx context.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	defer a.observe(time.Now())
+	return a.AIClient.ChatJSONWithRetry(ctx, systemPrompt, userPrompt, maxTokens)
+}
+
+func (a latencyTrackingAI) observe(start time.Time) { a.latency.Observe(time.Since(start)) }
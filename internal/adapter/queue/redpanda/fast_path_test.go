@@ -0,0 +1,53 @@
+package redpanda
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestFastPathPolicy_Select(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	slow := NewLatencyTracker()
+	slow.Observe(time.Minute)
+	cases := []struct {
+		name    string
+		policy  FastPathPolicy
+		payload domain.EvaluateTaskPayload
+		job     domain.Job
+		want    string
+	}{
+		{name: "zero policy", job: domain.Job{CreatedAt: now.Add(-time.Hour)}},
+		{name: "interactive", payload: domain.EvaluateTaskPayload{Priority: domain.PriorityInteractive}, want: domain.FastPathInteractive},
+		{name: "batch", payload: domain.EvaluateTaskPayload{Priority: domain.PriorityBatch}, policy: FastPathPolicy{QueueLag: time.Minute}, job: domain.Job{CreatedAt: now}},
+		{name: "queue lag", policy: FastPathPolicy{QueueLag: time.Minute}, job: domain.Job{CreatedAt: now.Add(-2 * time.Minute)}, want: domain.FastPathQueueLag},
+		{name: "unknown creation time", policy: FastPathPolicy{QueueLag: time.Minute}},
+		{name: "provider latency", policy: FastPathPolicy{ProviderLatency: 30 * time.Second, Latency: slow}, job: domain.Job{CreatedAt: now}, want: domain.FastPathProviderLatency},
+		{name: "provider fast enough", policy: FastPathPolicy{ProviderLatency: 2 * time.Minute, Latency: slow}, job: domain.Job{CreatedAt: now}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.policy.Select(tc.payload, tc.job, now))
+		})
+	}
+}
+
+func TestLatencyTracker_Average(t *testing.T) {
+	t.Parallel()
+
+	var none *LatencyTracker
+	none.Observe(time.Second)
+	assert.Zero(t, none.Average())
+
+	lt := NewLatencyTracker()
+	assert.Zero(t, lt.Average())
+	lt.Observe(10 * time.Second)
+	assert.Equal(t, 10*time.Second, lt.Average())
+	lt.Observe(20 * time.Second)
+	assert.Equal(t, 12*time.Second, lt.Average())
+}
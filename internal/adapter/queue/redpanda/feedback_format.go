@@ -0,0 +1,53 @@
+package redpanda
+
+import (
+	"strings"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// WithFeedbackFormat makes the handler ask for, and tidy, feedback in the
+// given format. Empty keeps plain text.
+func (h *IntegratedEvaluationHandler) WithFeedbackFormat(format string) *IntegratedEvaluationHandler {
+	h.feedbackFormat = format
+	return h
+}
+
+// feedbackFormatPrompt asks JSON-producing steps for Markdown feedback that
+// still fits in JSON strings. It is empty for plain text.
+func (h *IntegratedEvaluationHandler) feedbackFormatPrompt() string {
+	if h.feedbackFormat != domain.FeedbackFormatMarkdown {
+		return ""
+	}
+	return `
+Format every feedback and summary field as Markdown: group points under "### " section headings and list them as "- " bullets.
+Each field is still a single JSON string: write line breaks as \n, escape double quotes, and do not use code fences, tables or HTML.
+`
+}
+
+// formatFeedback tidies Markdown feedback the model produced despite the
+// prompt: line breaks it escaped twice and fences it wrapped the text in.
+func (h *IntegratedEvaluationHandler) formatFeedback(res *domain.Result) {
+	if h.feedbackFormat != domain.FeedbackFormatMarkdown {
+		return
+	}
+	for _, f := range []*string{&res.CVFeedback, &res.ProjectFeedback, &res.OverallSummary} {
+		*f = tidyMarkdown(*f)
+	}
+}
+
+// tidyMarkdown returns s without a surrounding code fence and with literal
+// "\n" sequences turned into line breaks when s has no real ones.
+func tidyMarkdown(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "\n") && strings.Contains(s, `\n`) {
+		s = strings.ReplaceAll(s, `\n`, "\n")
+	}
+	if strings.HasPrefix(s, "```") && strings.HasSuffix(s, "```") && len(s) >= 6 {
+		body := strings.TrimSuffix(s, "```")
+		if nl := strings.IndexByte(body, '\n'); nl >= 0 {
+			s = strings.TrimSpace(body[nl+1:])
+		}
+	}
+	return s
+}
@@ -0,0 +1,36 @@
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestFeedbackFormat_TextPromptUnchanged(t *testing.T) {
+	assert.Empty(t, NewIntegratedEvaluationHandler(nil, nil).feedbackFormatPrompt())
+	assert.Empty(t, NewIntegratedEvaluationHandler(nil, nil).WithFeedbackFormat(domain.FeedbackFormatText).feedbackFormatPrompt())
+}
+
+func TestFeedbackFormat_MarkdownWithRawLineBreaks(t *testing.T) {
+	// The model writes the Markdown line breaks raw, which is invalid JSON.
+	ai := &projectOnlyTestAI{refine: "{\"project_score\":8,\"project_feedback\":\"### Strengths\n- Clean \\\"retry\\\" logic\n- Tests\",\"overall_summary\":\"```markdown\n### Summary\n- Hire\n```\"}"}
+	h := NewIntegratedEvaluationHandler(ai, nil).WithFeedbackFormat(domain.FeedbackFormatMarkdown)
+
+	res, err := h.PerformProjectOnlyEvaluation(context.Background(), "project", "brief", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, "### Strengths\n- Clean \"retry\" logic\n- Tests", res.ProjectFeedback)
+	assert.Equal(t, "### Summary\n- Hire", res.OverallSummary)
+	require.Len(t, ai.prompts, 2)
+	assert.Contains(t, ai.prompts[1], `"### " section headings`)
+}
+
+func TestTidyMarkdown(t *testing.T) {
+	assert.Equal(t, "### CV\n- good", tidyMarkdown(`  ### CV\n- good `))
+	assert.Equal(t, "a\n`\\n` stays", tidyMarkdown("a\n`\\n` stays"))
+	assert.Equal(t, "- x", tidyMarkdown("```\n- x\n```"))
+	assert.Equal(t, "```", tidyMarkdown("```"))
+}
@@ -0,0 +1,28 @@
+package redpanda
+
+import (
+	"fmt"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// WithFeedbackLanguage makes the handler ask for feedback in the language
+// with the given domain.FeedbackLanguages code. Empty keeps English.
+func (h *IntegratedEvaluationHandler) WithFeedbackLanguage(lang string) *IntegratedEvaluationHandler {
+	h.feedbackLanguage = lang
+	return h
+}
+
+// feedbackLanguagePrompt asks JSON-producing steps to write the feedback and
+// summary in the requested language while keeping the JSON keys and scores
+// as they are. It is empty for English or an unknown code.
+func (h *IntegratedEvaluationHandler) feedbackLanguagePrompt() string {
+	name, ok := domain.FeedbackLanguages[h.feedbackLanguage]
+	if !ok || h.feedbackLanguage == "en" {
+		return ""
+	}
+	return fmt.Sprintf(`
+Write every feedback and summary field in %s, even though the CV and documents may be in another language.
+Keep the JSON keys, rubric parameter names and numbers exactly as specified; only the feedback text is translated and the scoring is unchanged.
+`, name)
+}
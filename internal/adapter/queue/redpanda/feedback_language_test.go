@@ -0,0 +1,28 @@
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedbackLanguage_EnglishPromptUnchanged(t *testing.T) {
+	assert.Empty(t, NewIntegratedEvaluationHandler(nil, nil).feedbackLanguagePrompt())
+	assert.Empty(t, NewIntegratedEvaluationHandler(nil, nil).WithFeedbackLanguage("en").feedbackLanguagePrompt())
+	assert.Empty(t, NewIntegratedEvaluationHandler(nil, nil).WithFeedbackLanguage("xx").feedbackLanguagePrompt())
+}
+
+func TestFeedbackLanguage_RefinePromptAsksForLanguage(t *testing.T) {
+	ai := &projectOnlyTestAI{refine: `{"project_score":8,"project_feedback":"Buen manejo de errores","overall_summary":"Recomendado"}`}
+	h := NewIntegratedEvaluationHandler(ai, nil).WithFeedbackLanguage("es")
+
+	res, err := h.PerformProjectOnlyEvaluation(context.Background(), "project", "brief", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, 8.0, res.ProjectScore)
+	assert.Equal(t, "Buen manejo de errores", res.ProjectFeedback)
+	require.Len(t, ai.prompts, 2)
+	assert.Contains(t, ai.prompts[1], "summary field in Spanish")
+	assert.NotContains(t, ai.prompts[0], "Spanish")
+}
@@ -12,8 +12,11 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	aiadapter "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/jsonrepair"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
 	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
@@ -24,6 +27,22 @@ import (
 type IntegratedEvaluationHandler struct {
 	ai domain.AIClient
 	q  *qdrantcli.Client
+	// weights overrides the default rubric weights when non-nil.
+	weights domain.ScoringWeights
+	// rubric lists the sections weights apply to; nil means the default rubric.
+	rubric []domain.RubricSection
+	// checkpoints stores completed chain steps for resumption; nil disables it.
+	checkpoints domain.CheckpointRepository
+	// ragCache shares retrieved context between jobs of a posting; nil disables it.
+	ragCache      *RAGCache
+	rubricVersion string
+	// feedbackFormat is domain.FeedbackFormatMarkdown or empty for plain text.
+	feedbackFormat string
+	// feedbackLanguage is a domain.FeedbackLanguages code or empty for English.
+	feedbackLanguage string
+	// prompts holds the last prompt sent per step, for corrective re-prompts.
+	promptsMu sync.Mutex
+	prompts   map[string]string
 }
 
 // NewIntegratedEvaluationHandler creates a new integrated evaluation handler.
@@ -44,6 +63,10 @@ func (h *IntegratedEvaluationHandler) PerformIntegratedEvaluation(
 	ctx, span := tracer.Start(ctx, "PerformIntegratedEvaluation")
 	defer span.End()
 
+	if reason := domain.FastPathReasonFrom(ctx); reason != "" {
+		slog.Info("fast path selected", slog.String("job_id", jobID), slog.String("reason", reason))
+		return h.performFastPathEvaluation(ctx, cvContent, projectContent, jobDesc, studyCase, scoringRubric, jobID)
+	}
 	slog.Info("performing multi-step integrated evaluation", slog.String("job_id", jobID))
 
 	// Step 1: evaluate CV match directly against job requirements using the
@@ -100,12 +123,17 @@ func (h *IntegratedEvaluationHandler) PerformIntegratedEvaluation(
 	return result, nil
 }
 
-// performFastPathEvaluation runs the previous single-prompt evaluation as a fallback.
+// performFastPathEvaluation runs the previous single-prompt evaluation, as a
+// fallback or when the worker selected the fast path.
 func (h *IntegratedEvaluationHandler) performFastPathEvaluation(
 	ctx context.Context,
 	cvContent, projectContent, jobDesc, studyCase, scoringRubric string,
 	jobID string,
 ) (domain.Result, error) {
+	// The fast path alone produces the result; drop the calls of an
+	// abandoned multi-step chain from the provenance.
+	domain.ResetModelTrace(ctx)
+	ctx = withStep(fastPathContext(ctx), stepFastPath)
 	tracer := otel.Tracer("integrated.evaluation")
 	ctx, span := tracer.Start(ctx, "PerformIntegratedEvaluation.fastPath")
 	defer span.End()
@@ -164,7 +192,7 @@ Guidelines:
 - project_score: 1.0 to 10.0 (1=poor, 10=excellent)
 - Provide professional, constructive feedback in the feedback fields.
 - Return only the JSON object, with no extra commentary, prose, or code fences.
-`, cvContent, projectContent, jobDesc, studyCase, scoringRubric, extraContext)
+%s`, cvContent, projectContent, jobDesc, studyCase, scoringRubric, extraContext, projectReadmePrompt(projectContent)+h.parameterScoresPrompt(domain.RubricGroupCV, domain.RubricGroupProject)+h.feedbackFormatPrompt()+h.feedbackLanguagePrompt())
 
 	response, err := h.performStableEvaluation(ctx, prompt, jobID)
 	if err != nil {
@@ -187,6 +215,7 @@ Guidelines:
 
 // extractStructuredCVInfo extracts structured information from CV with enhanced prompts.
 func (h *IntegratedEvaluationHandler) extractStructuredCVInfo(ctx context.Context, cvContent, jobID string) (string, error) {
+	ctx = withStep(ctx, stepCVExtraction)
 	slog.Info("step 1: extracting structured CV information", slog.String("job_id", jobID))
 
 	prompt := `You are a CV analyst. Extract structured information from the CV.
@@ -246,6 +275,7 @@ Return only valid JSON with no additional text, explanations, or reasoning.
 
 // compareWithJobRequirements compares CV data with job requirements using RAG.
 func (h *IntegratedEvaluationHandler) compareWithJobRequirements(ctx context.Context, extractedCV, jobDesc, jobID string) (string, error) {
+	ctx = withStep(ctx, stepJobComparison)
 	slog.Info("step 2: comparing CV data with job requirements", slog.String("job_id", jobID))
 
 	// Retrieve RAG context for job requirements
@@ -366,6 +396,11 @@ Return only the JSON object, no additional text or explanations.
 // description using the standardized scoring rubric. The output is an
 // analytical narrative that is later refined into final scores.
 func (h *IntegratedEvaluationHandler) evaluateCVMatch(ctx context.Context, cvContent, jobDesc, scoringRubric, jobID string) (string, error) {
+	ctx = withStep(ctx, stepCVMatch)
+	if out, ok := h.resumeStep(ctx, jobID, stepCVMatch); ok {
+		return out, nil
+	}
+	mark := len(domain.ProvenanceFrom(ctx))
 	slog.Info("evaluating CV match and generating feedback", slog.String("job_id", jobID))
 
 	// Retrieve RAG context for job requirements (best-effort; must not fail the
@@ -385,26 +420,8 @@ func (h *IntegratedEvaluationHandler) evaluateCVMatch(ctx context.Context, cvCon
 		jobInput = fmt.Sprintf("%s\n\nAdditional Job Context:\n%s", jobDesc, ragContext)
 	}
 
-	promptTemplate := `You are an HR specialist and recruitment expert. Evaluate the candidate's CV against the job requirements using the standardized scoring rubric.
-
-CV Content:
-%s
-
-Job Description and Context:
-%s
-
-Scoring Rubric:
-%s
-
-Provide a concise analytical assessment focusing on:
-- Technical skills alignment with the backend + AI/LLM role
-- Experience level and impact of previous work
-- Relevant achievements and measurable outcomes
-- Cultural and collaboration fit (communication, learning mindset, teamwork)
-
-Return a short analysis (bullet list or structured paragraphs). Do NOT return JSON.`
-
-	fullPrompt := fmt.Sprintf(promptTemplate, cvContent, jobInput, scoringRubric)
+	fullPrompt := fmt.Sprintf(promptTemplate(ctx, stepCVMatch), cvContent, jobInput, scoringRubric)
+	fullPrompt += h.customWeightsPrompt(domain.RubricGroupCV)
 
 	response, err := h.performStableEvaluation(ctx, fullPrompt, jobID)
 	if err != nil {
@@ -412,24 +429,23 @@ Return a short analysis (bullet list or structured paragraphs). Do NOT return JS
 	}
 
 	slog.Info("CV evaluation completed", slog.String("job_id", jobID), slog.Int("response_length", len(response)))
+	h.checkpointStep(ctx, jobID, stepCVMatch, response, mark)
 	return response, nil
 }
 
 // evaluateProjectDeliverables evaluates project deliverables with RAG context.
 func (h *IntegratedEvaluationHandler) evaluateProjectDeliverables(ctx context.Context, projectContent, studyCase, scoringRubric, jobID string) (string, error) {
+	ctx = withStep(ctx, stepProjectEvaluate)
+	if out, ok := h.resumeStep(ctx, jobID, stepProjectEvaluate); ok {
+		return out, nil
+	}
+	mark := len(domain.ProvenanceFrom(ctx))
 	slog.Info("evaluating project deliverables", slog.String("job_id", jobID))
 
-	// Create a timeout context for the entire project evaluation process. This
-	// remains generous but we now perform a single scoring call (no separate
-	// summarization step) to reduce overall latency.
-	timeoutDuration := 5 * time.Minute // 5 minutes timeout for AI processing
-	evalCtx, cancel := context.WithTimeout(ctx, timeoutDuration)
-	defer cancel()
-
 	// Retrieve RAG context for project evaluation (best-effort).
 	var ragContext string
 	if h.q != nil {
-		context, err := h.retrieveEnhancedRAGContext(evalCtx, projectContent, studyCase, "scoring_rubric")
+		context, err := h.retrieveEnhancedRAGContext(ctx, projectContent, studyCase, "scoring_rubric")
 		if err != nil {
 			slog.Warn("RAG context retrieval failed for project evaluation", slog.String("job_id", jobID), slog.Any("error", err))
 		} else {
@@ -448,17 +464,19 @@ func (h *IntegratedEvaluationHandler) evaluateProjectDeliverables(ctx context.Co
 	// keep the chain leaner while still providing rich context to the model.
 	fullPrompt := h.generateProjectEvaluationPrompt(projectContent, studyInput, scoringRubric)
 
-	response, err := h.performStableEvaluation(evalCtx, fullPrompt, jobID)
+	response, err := h.performStableEvaluation(ctx, fullPrompt, jobID)
 	if err != nil {
 		return "", fmt.Errorf("AI project evaluation failed: %w", err)
 	}
 
 	slog.Info("project evaluation completed", slog.String("job_id", jobID), slog.Int("response_length", len(response)))
+	h.checkpointStep(ctx, jobID, stepProjectEvaluate, response, mark)
 	return response, nil
 }
 
 // refineEvaluation refines evaluation with stability controls.
 func (h *IntegratedEvaluationHandler) refineEvaluation(ctx context.Context, cvEvaluation, projectEvaluation, jobID string) (string, error) {
+	ctx = withStep(ctx, stepRefine)
 	slog.Info("refining evaluation with stability controls", slog.String("job_id", jobID))
 
 	prompt := `You are a technical reviewer. Refine the evaluation results into final scores and feedback.
@@ -486,7 +504,8 @@ Guidelines:
 
 `
 
-	response, err := h.performStableEvaluation(ctx, fmt.Sprintf(prompt, cvEvaluation, projectEvaluation), jobID)
+	fullPrompt := fmt.Sprintf(prompt, cvEvaluation, projectEvaluation) + h.parameterScoresPrompt(domain.RubricGroupCV, domain.RubricGroupProject) + h.feedbackFormatPrompt() + h.feedbackLanguagePrompt()
+	response, err := h.performStableEvaluation(ctx, fullPrompt, jobID)
 	if err != nil {
 		return "", fmt.Errorf("AI refinement failed: %w", err)
 	}
@@ -497,6 +516,7 @@ Guidelines:
 
 // validateAndFinalizeResults validates and finalizes the evaluation results.
 func (h *IntegratedEvaluationHandler) validateAndFinalizeResults(ctx context.Context, refinedResponse, jobID string) (domain.Result, error) {
+	ctx = withStep(ctx, stepValidate)
 	slog.Info("validating and finalizing results", slog.String("job_id", jobID))
 
 	// Parse and validate the refined response
@@ -514,6 +534,7 @@ func (h *IntegratedEvaluationHandler) validateAndFinalizeResults(ctx context.Con
 		return domain.Result{}, fmt.Errorf("invalid project score: %.2f (must be 1.0-10.0)", result.ProjectScore)
 	}
 
+	h.formatFeedback(&result)
 	// Validate text fields are not empty
 	if result.CVFeedback == "" {
 		result.CVFeedback = "No feedback provided"
@@ -544,6 +565,7 @@ func (h *IntegratedEvaluationHandler) validateAndFinalizeResults(ctx context.Con
 // This is intentionally a simpler prompt than the full scoring rubric to improve
 // reliability with free models.
 func (h *IntegratedEvaluationHandler) summarizeProjectContent(ctx context.Context, projectContent, jobID string) (string, error) {
+	ctx = withStep(ctx, stepProjectSummary)
 	slog.Info("summarizing project content before scoring", slog.String("job_id", jobID), slog.Int("project_length", len(projectContent)))
 
 	prompt := `You are summarizing a backend and AI-enabled project implementation.
@@ -572,34 +594,62 @@ Return only a short markdown bullet list (no JSON, no code blocks, no additional
 
 // performStableEvaluation performs a stable AI evaluation with retry logic.
 func (h *IntegratedEvaluationHandler) performStableEvaluation(ctx context.Context, prompt, _ string) (string, error) {
-	// Choose a reasonable maxTokens based on prompt length to avoid provider defaults
-	// that may result in very long or stalled generations.
-	maxTokens := 512
-	plen := len(prompt)
-	switch {
-	case plen > 16000:
-		maxTokens = 2048
-	case plen > 8000:
-		maxTokens = 1536
-	case plen > 4000:
-		maxTokens = 1024
-	}
-
-	// Use the enhanced retry method with model fallback
-	response, err := h.ai.ChatJSONWithRetry(ctx, prompt, "user", maxTokens)
+	h.rememberPrompt(ctx, prompt)
+	// Use the enhanced retry method with model fallback. The AI client
+	// replaces the default max_tokens with one learned from the step's
+	// completion lengths once enough calls were seen.
+	response, err := h.ai.ChatJSONWithRetry(ctx, prompt, "user", defaultMaxTokens(prompt))
 	if err != nil {
 		return "", fmt.Errorf("AI evaluation failed: %w", err)
 	}
 	return response, nil
 }
 
+// defaultMaxTokens picks max_tokens from the prompt length, to avoid provider
+// defaults that may result in very long or stalled generations. It is used
+// until the step has a learned completion budget.
+func defaultMaxTokens(prompt string) int {
+	switch plen := len(prompt); {
+	case plen > 16000:
+		return 2048
+	case plen > 8000:
+		return 1536
+	case plen > 4000:
+		return 1024
+	default:
+		return 512
+	}
+}
+
 // cleanJSONResponseWithCoTFallback first attempts to clean JSON directly and, on failure,
-// uses the AI client's CoT-cleaning endpoint as a fallback before re-attempting cleaning.
+// repairs it locally, then asks the model that produced it for a corrected
+// response, and finally uses the AI client's CoT-cleaning endpoint as a
+// fallback before re-attempting cleaning.
 func (h *IntegratedEvaluationHandler) cleanJSONResponseWithCoTFallback(ctx context.Context, response string, jobID string) (string, error) {
 	cleaned, err := h.cleanJSONResponse(response)
+	aiadapter.DefaultModelHealth.RecordParse(domain.LastServedModel(ctx), err == nil)
 	if err == nil {
 		return cleaned, nil
 	}
+	countParseFailure(ctx)
+
+	// Cheap local repairs first; only fall back to another model call when they fail.
+	if repaired, stage, ok := jsonrepair.Repair(response); ok {
+		if cleanedRepaired, repErr := h.cleanJSONResponse(repaired); repErr == nil {
+			observability.RecordJSONRepair(stage)
+			slog.Info("repaired malformed JSON response locally",
+				slog.String("job_id", jobID),
+				slog.String("stage", stage))
+			return cleanedRepaired, nil
+		}
+	}
+	observability.RecordJSONRepair("failed")
+
+	// One corrective round-trip to the same model fixes most failures more
+	// cheaply than switching models.
+	if corrected, ok := h.reprompt(ctx, response, err, jobID); ok {
+		return corrected, nil
+	}
 
 	slog.Warn("primary JSON cleaning failed, attempting CoT cleaning",
 		slog.String("job_id", jobID),
@@ -938,8 +988,30 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
-// retrieveEnhancedRAGContext retrieves enhanced RAG context.
+// retrieveEnhancedRAGContext retrieves enhanced RAG context. With a RAG
+// cache the context is retrieved for the posting alone, without the
+// candidate's query, so that it can be shared by the posting's jobs.
 func (h *IntegratedEvaluationHandler) retrieveEnhancedRAGContext(ctx context.Context, query, jobDesc, studyCase string) (string, error) {
+	defer func(start time.Time) { domain.AddRAGTime(ctx, time.Since(start)) }(time.Now())
+	if h.ragCache == nil {
+		return h.retrieveRAGContext(ctx, query, jobDesc, studyCase)
+	}
+	key := ragCacheKey(jobDesc, studyCase, h.rubricVersion)
+	if cached, ok := h.ragCache.Get(key); ok {
+		observability.RecordRAGCacheLookup(true)
+		return cached, nil
+	}
+	observability.RecordRAGCacheLookup(false)
+	out, err := h.retrieveRAGContext(ctx, "", jobDesc, studyCase)
+	if err == nil && out != "" {
+		h.ragCache.Put(key, out)
+	}
+	return out, err
+}
+
+// retrieveRAGContext embeds the search query and looks up the job
+// description and scoring rubric collections.
+func (h *IntegratedEvaluationHandler) retrieveRAGContext(ctx context.Context, query, jobDesc, studyCase string) (string, error) {
 	slog.Info("retrieving enhanced RAG context", slog.String("query", query), slog.Int("job_desc_length", len(jobDesc)), slog.Int("study_case_length", len(studyCase)))
 
 	// Create search query combining job description and study case
@@ -1028,51 +1100,51 @@ func (h *IntegratedEvaluationHandler) generateScoringPrompt(cvContent, projectCo
 		"Project Content:\n" + projectContent + "\n\n" +
 		"## CV Match Evaluation (Weighted Scoring)\n\n" +
 		"Evaluate the CV against these parameters (1-5 scale each):\n\n" +
-		"**1. Technical Skills Match (40%% weight):**\n" +
+		"**1. Technical Skills Match (" + h.weightOf("technical_skills_match") + "% weight):**\n" +
 		"- Backend languages & frameworks alignment (Node.js, Django, Rails)\n" +
 		"- Database experience (MySQL, PostgreSQL, MongoDB)\n" +
 		"- API development experience\n" +
 		"- Cloud technologies (AWS, Google Cloud, Azure)\n" +
 		"- AI/LLM exposure and experience\n" +
 		"Scoring: 1=Irrelevant → 5=Excellent + AI/LLM experience\n\n" +
-		"**2. Experience Level (25%% weight):**\n" +
+		"**2. Experience Level (" + h.weightOf("experience_level") + "% weight):**\n" +
 		"- Years of experience assessment\n" +
 		"- Project complexity indicators\n" +
 		"- Leadership and mentoring experience\n" +
 		"Scoring: 1=<1yr → 5=5+ yrs high-impact\n\n" +
-		"**3. Relevant Achievements (20%% weight):**\n" +
+		"**3. Relevant Achievements (" + h.weightOf("relevant_achievements") + "% weight):**\n" +
 		"- Measurable impact of past work\n" +
 		"- Scale and scope of projects\n" +
 		"- Innovation and problem-solving examples\n" +
 		"Scoring: 1=None → 5=Major measurable impact\n\n" +
-		"**4. Cultural/Collaboration Fit (15%% weight):**\n" +
+		"**4. Cultural/Collaboration Fit (" + h.weightOf("cultural_collaboration_fit") + "% weight):**\n" +
 		"- Communication skills indicators\n" +
 		"- Learning mindset and adaptability\n" +
 		"- Teamwork and collaboration evidence\n" +
 		"Scoring: 1=Not shown → 5=Excellent\n\n" +
 		"## Project Deliverable Evaluation (Weighted Scoring)\n\n" +
 		"Evaluate the project against these parameters (1-5 scale each):\n\n" +
-		"**1. Correctness (30%% weight):**\n" +
+		"**1. Correctness (" + h.weightOf("correctness") + "% weight):**\n" +
 		"- Implements prompt design and LLM chaining\n" +
 		"- RAG (retrieval, embeddings, vector DB) implementation\n" +
 		"- Meets all specified requirements\n" +
 		"Scoring: 1=Not implemented → 5=Fully correct\n\n" +
-		"**2. Code Quality & Structure (25%% weight):**\n" +
+		"**2. Code Quality & Structure (" + h.weightOf("code_quality_structure") + "% weight):**\n" +
 		"- Clean, modular, reusable code\n" +
 		"- Testable architecture\n" +
 		"- Strong test coverage\n" +
 		"Scoring: 1=Poor → 5=Excellent + strong tests\n\n" +
-		"**3. Resilience & Error Handling (20%% weight):**\n" +
+		"**3. Resilience & Error Handling (" + h.weightOf("resilience_error_handling") + "% weight):**\n" +
 		"- Handles jobs, retries, randomness\n" +
 		"- API failures and timeouts\n" +
 		"- Graceful error recovery\n" +
 		"Scoring: 1=Missing → 5=Robust\n\n" +
-		"**4. Documentation & Explanation (15%% weight):**\n" +
+		"**4. Documentation & Explanation (" + h.weightOf("documentation_explanation") + "% weight):**\n" +
 		"- README clarity and setup instructions\n" +
 		"- Explanation of trade-offs\n" +
 		"- Design decisions documented\n" +
 		"Scoring: 1=Missing → 5=Excellent\n\n" +
-		"**5. Creativity/Bonus (10%% weight):**\n" +
+		"**5. Creativity/Bonus (" + h.weightOf("creativity_bonus") + "% weight):**\n" +
 		"- Extra features beyond requirements\n" +
 		"- Innovative solutions\n" +
 		"- Outstanding creativity\n" +
@@ -1095,7 +1167,8 @@ func (h *IntegratedEvaluationHandler) generateScoringPrompt(cvContent, projectCo
 		"- project_score: 1.0 to 10.0 (1=poor, 10=excellent)\n" +
 		"- Provide specific, actionable feedback with examples\n" +
 		"- Focus on technical skills, experience, and project quality\n" +
-		"- Return only the JSON object, no additional text"
+		"- Return only the JSON object, no additional text" +
+		h.customWeightsPrompt(domain.RubricGroupCV, domain.RubricGroupProject)
 
 	return prompt
 }
@@ -1111,38 +1184,38 @@ func (h *IntegratedEvaluationHandler) generateProjectEvaluationPrompt(projectCon
 	prompt := "You are a technical reviewer evaluating a candidate's project deliverables using a standardized scoring rubric.\n\n" +
 		"Study Case:\n" + studyCase + "\n\n" +
 		"Additional Scoring Rubric:\n" + scoringRubric + "\n\n" +
-		"Project Content:\n" + projectContent + "\n\n" +
+		"Project Content:\n" + projectContent + "\n" + projectReadmePrompt(projectContent) + "\n" +
 		"## Project Deliverable Evaluation (Weighted Scoring)\n\n" +
 		"Evaluate the project against these parameters (1-5 scale each):\n\n" +
-		"**1. Correctness (30% weight):**\n" +
+		"**1. Correctness (" + h.weightOf("correctness") + "% weight):**\n" +
 		"- Implements prompt design and LLM chaining\n" +
 		"- RAG (retrieval, embeddings, vector DB) implementation\n" +
 		"- Meets all specified requirements\n" +
 		"- API endpoints work correctly\n" +
 		"- Async job processing implemented\n" +
 		"Scoring: 1=Not implemented → 5=Fully correct\n\n" +
-		"**2. Code Quality & Structure (25% weight):**\n" +
+		"**2. Code Quality & Structure (" + h.weightOf("code_quality_structure") + "% weight):**\n" +
 		"- Clean, modular, reusable code\n" +
 		"- Testable architecture\n" +
 		"- Strong test coverage\n" +
 		"- Proper error handling\n" +
 		"- Code organization and separation of concerns\n" +
 		"Scoring: 1=Poor → 5=Excellent + strong tests\n\n" +
-		"**3. Resilience & Error Handling (20% weight):**\n" +
+		"**3. Resilience & Error Handling (" + h.weightOf("resilience_error_handling") + "% weight):**\n" +
 		"- Handles jobs, retries, randomness\n" +
 		"- API failures and timeouts\n" +
 		"- Graceful error recovery\n" +
 		"- Backoff strategies\n" +
 		"- Fallback mechanisms\n" +
 		"Scoring: 1=Missing → 5=Robust\n\n" +
-		"**4. Documentation & Explanation (15% weight):**\n" +
+		"**4. Documentation & Explanation (" + h.weightOf("documentation_explanation") + "% weight):**\n" +
 		"- README clarity and setup instructions\n" +
 		"- Explanation of trade-offs\n" +
 		"- Design decisions documented\n" +
 		"- API documentation\n" +
 		"- Architecture explanations\n" +
 		"Scoring: 1=Missing → 5=Excellent\n\n" +
-		"**5. Creativity/Bonus (10% weight):**\n" +
+		"**5. Creativity/Bonus (" + h.weightOf("creativity_bonus") + "% weight):**\n" +
 		"- Extra features beyond requirements\n" +
 		"- Innovative solutions\n" +
 		"- Outstanding creativity\n" +
@@ -1159,38 +1232,39 @@ func (h *IntegratedEvaluationHandler) generateProjectEvaluationPrompt(projectCon
 		"Respond with detailed JSON analysis including:\n" +
 		"{\n" +
 		"  \"correctness\": {\n" +
-		"    \"weight\": 30,\n" +
+		"    \"weight\": " + h.weightOf("correctness") + ",\n" +
 		"    \"score\": 4,\n" +
 		"    \"analysis\": \"Detailed technical analysis with specific examples\",\n" +
 		"    \"implementation\": \"Specific implementation details assessed\"\n" +
 		"  },\n" +
 		"  \"code_quality\": {\n" +
-		"    \"weight\": 25,\n" +
+		"    \"weight\": " + h.weightOf("code_quality_structure") + ",\n" +
 		"    \"score\": 4,\n" +
 		"    \"analysis\": \"Code quality assessment with examples\",\n" +
 		"    \"structure\": \"Architecture and organization analysis\"\n" +
 		"  },\n" +
 		"  \"resilience\": {\n" +
-		"    \"weight\": 20,\n" +
+		"    \"weight\": " + h.weightOf("resilience_error_handling") + ",\n" +
 		"    \"score\": 3,\n" +
 		"    \"analysis\": \"Error handling and resilience assessment\",\n" +
 		"    \"robustness\": \"Failure handling and recovery mechanisms\"\n" +
 		"  },\n" +
 		"  \"documentation\": {\n" +
-		"    \"weight\": 15,\n" +
+		"    \"weight\": " + h.weightOf("documentation_explanation") + ",\n" +
 		"    \"score\": 4,\n" +
 		"    \"analysis\": \"Documentation quality assessment\",\n" +
 		"    \"clarity\": \"Setup instructions and explanations\"\n" +
 		"  },\n" +
 		"  \"creativity\": {\n" +
-		"    \"weight\": 10,\n" +
+		"    \"weight\": " + h.weightOf("creativity_bonus") + ",\n" +
 		"    \"score\": 3,\n" +
 		"    \"analysis\": \"Creativity and bonus features assessment\",\n" +
 		"    \"innovation\": \"Innovative solutions and extra features\"\n" +
 		"  },\n" +
 		"  \"overall_assessment\": \"Comprehensive project summary with specific strengths and areas for improvement\"\n" +
 		"}\n\n" +
-		"Provide detailed analysis for each parameter with specific examples from the project."
+		"Provide detailed analysis for each parameter with specific examples from the project." +
+		h.customWeightsPrompt(domain.RubricGroupProject)
 
 	return prompt
 }
@@ -1213,6 +1287,9 @@ func (h *IntegratedEvaluationHandler) parseRefinedEvaluationResponse(ctx context
 		ProjectScore    float64 `json:"project_score"`
 		ProjectFeedback string  `json:"project_feedback"`
 		OverallSummary  string  `json:"overall_summary"`
+		// Per-parameter 1-5 scores, requested only with custom weights.
+		CVParameterScores      map[string]float64 `json:"cv_parameter_scores"`
+		ProjectParameterScores map[string]float64 `json:"project_parameter_scores"`
 	}
 
 	if err := json.Unmarshal([]byte(cleanedResponse), &evaluationData); err != nil {
@@ -1253,6 +1330,7 @@ func (h *IntegratedEvaluationHandler) parseRefinedEvaluationResponse(ctx context
 		OverallSummary:  evaluationData.OverallSummary,
 		CreatedAt:       time.Now(),
 	}
+	h.applyScoringWeights(&result, evaluationData.CVParameterScores, evaluationData.ProjectParameterScores)
 
 	slog.Info("successfully parsed evaluation response",
 		slog.String("job_id", jobID),
@@ -15,7 +15,8 @@ import (
 // multi-step chain is executed end-to-end. It records which kind of prompt was
 // seen and always returns a stable JSON payload.
 type chainTestAI struct {
-	calls []string
+	calls   []string
+	prompts []string
 }
 
 func (a *chainTestAI) Embed(_ domain.Context, _ []string) ([][]float32, error) {
@@ -42,6 +43,7 @@ func (a *chainTestAI) ChatJSONWithRetry(_ domain.Context, systemPrompt, _ string
 		label = "fast"
 	}
 	a.calls = append(a.calls, label)
+	a.prompts = append(a.prompts, systemPrompt)
 
 	// Always return a stable final JSON payload that matches parseRefinedEvaluationResponse expectations.
 	return `{"cv_match_rate":0.7,"cv_feedback":"ok","project_score":8.2,"project_feedback":"ok","overall_summary":"ok"}`, nil
@@ -119,6 +121,43 @@ func TestIntegratedEvaluationHandler_PerformIntegratedEvaluation_MultiStepChainP
 	}
 }
 
+// TestIntegratedEvaluationHandler_PerformIntegratedEvaluation_CustomRubricWeights
+// verifies that no chain step renders a default parameter with a zero weight
+// when the job's custom rubric lacks it, and that the steps state the custom
+// weights instead.
+func TestIntegratedEvaluationHandler_PerformIntegratedEvaluation_CustomRubricWeights(t *testing.T) {
+	t.Parallel()
+
+	ai := &chainTestAI{}
+	h := NewIntegratedEvaluationHandler(ai, nil).
+		WithScoringWeights(domain.ScoringWeights{"leadership": 75, "domain_knowledge": 25, "architecture": 70, "testing": 30}).
+		WithRubric([]domain.RubricSection{
+			{Name: "leadership", Group: domain.RubricGroupCV, Label: "Leadership", Weight: 60},
+			{Name: "domain_knowledge", Group: domain.RubricGroupCV, Label: "Domain Knowledge", Weight: 40},
+			{Name: "architecture", Group: domain.RubricGroupProject, Label: "Architecture", Weight: 50},
+			{Name: "testing", Group: domain.RubricGroupProject, Label: "Testing", Weight: 50},
+		})
+
+	_, err := h.PerformIntegratedEvaluation(context.Background(), "cv", "project", "jd", "study case", "custom rubric", "job-123")
+	require.NoError(t, err)
+	require.Contains(t, ai.calls, "cv_evaluate")
+	require.Contains(t, ai.calls, "project_evaluate")
+	for i, prompt := range ai.prompts {
+		assert.NotContains(t, prompt, "(0% weight)", ai.calls[i])
+		assert.NotContains(t, prompt, `"weight": 0,`, ai.calls[i])
+		switch ai.calls[i] {
+		case "cv_evaluate":
+			assert.Contains(t, prompt, "- Leadership (leadership): 75%")
+		case "project_evaluate":
+			assert.Contains(t, prompt, "- Architecture (architecture): 70%")
+			assert.Contains(t, prompt, "- Testing (testing): 30%")
+		}
+	}
+	scoring := h.generateScoringPrompt("cv", "project", "jd", "study", "rubric")
+	assert.NotContains(t, scoring, "(0% weight)")
+	assert.Contains(t, scoring, "- Domain Knowledge (domain_knowledge): 25%")
+}
+
 // TestIntegratedEvaluationHandler_CleanJSONResponseWithCoTFallback verifies
 // that when the primary JSON cleaning fails, the handler calls
 // AIClient.CleanCoTResponse and successfully parses the cleaned output.
@@ -168,3 +207,31 @@ func TestIntegratedEvaluationHandler_ParseRefinedEvaluationResponse_UsesCoTFallb
 	assert.Equal(t, "clean", res.ProjectFeedback)
 	assert.Equal(t, "clean", res.OverallSummary)
 }
+
+// TestIntegratedEvaluationHandler_CleanJSONResponseWithCoTFallback_LocalRepair
+// verifies that malformed JSON that can be repaired locally never reaches
+// CleanCoTResponse.
+func TestIntegratedEvaluationHandler_CleanJSONResponseWithCoTFallback_LocalRepair(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"trailing comma": `{"cv_match_rate":0.8,"project_score":7,}`,
+		"single quotes":  `{'cv_match_rate':0.8,'cv_feedback':'say "hi"','project_score':7}`,
+		"truncated":      "```json\n{\"cv_match_rate\":0.8,\"project_score\":7,\"cv_feedback\":\"str",
+	}
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ai := &cotFallbackAI{}
+			h := NewIntegratedEvaluationHandler(ai, nil)
+
+			cleaned, err := h.cleanJSONResponseWithCoTFallback(context.Background(), raw, "job-repair")
+			require.NoError(t, err)
+			assert.Equal(t, 0, ai.cleanCalls, "local repair must avoid a CoT cleaning call")
+
+			var payload map[string]any
+			require.NoError(t, json.Unmarshal([]byte(cleaned), &payload))
+			assert.InDelta(t, 0.8, payload["cv_match_rate"], 0.0001)
+		})
+	}
+}
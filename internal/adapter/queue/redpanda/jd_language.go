@@ -0,0 +1,102 @@
+package redpanda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/jsonrepair"
+	adapterobs "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/langdetect"
+)
+
+// Job description language modes: what the worker does when the job
+// description and the CV are written in different languages.
+const (
+	// JDLanguageOff skips the comparison.
+	JDLanguageOff = "off"
+	// JDLanguageWarn records the mismatch with the result.
+	JDLanguageWarn = "warn"
+	// JDLanguageTranslate also translates the job description into the CV's
+	// language before it is used in prompts.
+	JDLanguageTranslate = "translate"
+)
+
+const translateJobDescriptionPrompt = `You translate job descriptions for a CV screening system.
+Translate the job description given by the user into %s. Keep the meaning, requirements, skill names, technologies and numbers exactly; do not add, drop or summarise anything.
+Respond with JSON only: {"translation": "<the translated job description>"}`
+
+// checkJDLanguage compares the languages of the job description jd and the
+// CV text. When they differ it returns the mismatch to record with the
+// result and, in translate mode, jd translated into the CV's language. In
+// every other case jd is returned unchanged. A failed translation falls back
+// to the original job description.
+func checkJDLanguage(ctx context.Context, ai domain.AIClient, mode, jobID, jd, cvText string) (string, *domain.LanguageMismatch) {
+	if (mode != JDLanguageWarn && mode != JDLanguageTranslate) || strings.TrimSpace(jd) == "" || strings.TrimSpace(cvText) == "" {
+		return jd, nil
+	}
+	jdLang, cvLang := langdetect.Detect(jd), langdetect.Detect(cvText)
+	switch {
+	case jdLang == langdetect.Unknown || cvLang == langdetect.Unknown:
+		adapterobs.RecordJDLanguageCheck("unknown")
+		return jd, nil
+	case jdLang == cvLang:
+		adapterobs.RecordJDLanguageCheck("match")
+		return jd, nil
+	}
+	mismatch := &domain.LanguageMismatch{JobDescription: jdLang, CV: cvLang}
+	slog.Warn("job description and CV languages differ",
+		slog.String("job_id", jobID),
+		slog.String("job_description", jdLang),
+		slog.String("cv", cvLang))
+	if mode != JDLanguageTranslate || ai == nil {
+		adapterobs.RecordJDLanguageCheck("mismatch")
+		return jd, mismatch
+	}
+	translated, err := translateJobDescription(ctx, ai, jd, cvLang)
+	if err != nil {
+		adapterobs.RecordJDLanguageCheck("translate_failed")
+		slog.Warn("job description translation failed; prompting with the original",
+			slog.String("job_id", jobID),
+			slog.Any("error", err))
+		return jd, mismatch
+	}
+	adapterobs.RecordJDLanguageCheck("translated")
+	mismatch.Translated = true
+	return translated, mismatch
+}
+
+// translateJobDescription asks the model to translate jd into the language
+// with the ISO 639-1 code lang.
+func translateJobDescription(ctx context.Context, ai domain.AIClient, jd, lang string) (string, error) {
+	name, ok := domain.FeedbackLanguages[lang]
+	if !ok {
+		return "", fmt.Errorf("op=jd_language.translate: no language name for %q", lang)
+	}
+	// Translations are about as long as their source; leave room for scripts
+	// that take more tokens per character.
+	maxTokens := min(len([]rune(jd))/2+256, 4096)
+	response, err := ai.ChatJSONWithRetry(withStep(ctx, stepJDTranslation), fmt.Sprintf(translateJobDescriptionPrompt, name), jd, maxTokens)
+	if err != nil {
+		return "", fmt.Errorf("op=jd_language.translate: %w", err)
+	}
+	var out struct {
+		Translation string `json:"translation"`
+	}
+	if err := json.Unmarshal([]byte(response), &out); err != nil {
+		repaired, _, ok := jsonrepair.Repair(response)
+		if !ok {
+			return "", fmt.Errorf("op=jd_language.translate_parse: %w", err)
+		}
+		if err := json.Unmarshal([]byte(repaired), &out); err != nil {
+			return "", fmt.Errorf("op=jd_language.translate_parse: %w", err)
+		}
+	}
+	if strings.TrimSpace(out.Translation) == "" {
+		return "", fmt.Errorf("op=jd_language.translate: empty translation")
+	}
+	return strings.TrimSpace(out.Translation), nil
+}
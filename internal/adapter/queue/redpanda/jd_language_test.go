@@ -0,0 +1,63 @@
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// translatingAI answers every chat call with response, or err when set.
+type translatingAI struct {
+	response string
+	err      error
+	system   string
+}
+
+func (a *translatingAI) Embed(domain.Context, []string) ([][]float32, error) { return nil, nil }
+
+func (a *translatingAI) ChatJSON(ctx domain.Context, system, user string, maxTokens int) (string, error) {
+	return a.ChatJSONWithRetry(ctx, system, user, maxTokens)
+}
+
+func (a *translatingAI) ChatJSONWithRetry(_ domain.Context, system, _ string, _ int) (string, error) {
+	a.system = system
+	return a.response, a.err
+}
+
+func (a *translatingAI) CleanCoTResponse(_ domain.Context, s string) (string, error) { return s, nil }
+
+func TestCheckJDLanguage(t *testing.T) {
+	ctx := context.Background()
+	jd := "We are looking for a backend engineer with experience in Go and the design of distributed systems."
+	cvID := "Saya adalah pengembang backend dengan pengalaman lima tahun dalam membangun layanan yang andal."
+	cvEN := "I have built the payment services of an online shop and have led a team of four engineers."
+
+	// Matching or undetectable languages are not reported.
+	got, mismatch := checkJDLanguage(ctx, nil, JDLanguageWarn, "job-1", jd, cvEN)
+	assert.Equal(t, jd, got)
+	assert.Nil(t, mismatch)
+	_, mismatch = checkJDLanguage(ctx, nil, JDLanguageWarn, "job-1", jd, "Go, Kubernetes, PostgreSQL")
+	assert.Nil(t, mismatch)
+	_, mismatch = checkJDLanguage(ctx, nil, JDLanguageOff, "job-1", jd, cvID)
+	assert.Nil(t, mismatch)
+
+	got, mismatch = checkJDLanguage(ctx, nil, JDLanguageWarn, "job-1", jd, cvID)
+	assert.Equal(t, jd, got)
+	assert.Equal(t, &domain.LanguageMismatch{JobDescription: "en", CV: "id"}, mismatch)
+
+	ai := &translatingAI{response: "```json\n{\"translation\": \"Kami mencari insinyur backend.\"}\n```"}
+	got, mismatch = checkJDLanguage(ctx, ai, JDLanguageTranslate, "job-1", jd, cvID)
+	assert.Equal(t, "Kami mencari insinyur backend.", got)
+	assert.Equal(t, &domain.LanguageMismatch{JobDescription: "en", CV: "id", Translated: true}, mismatch)
+	require.Contains(t, ai.system, "Indonesian")
+
+	// A failed translation keeps the original job description.
+	got, mismatch = checkJDLanguage(ctx, &translatingAI{err: errors.New("rate limited")}, JDLanguageTranslate, "job-1", jd, cvID)
+	assert.Equal(t, jd, got)
+	assert.Equal(t, &domain.LanguageMismatch{JobDescription: "en", CV: "id"}, mismatch)
+}
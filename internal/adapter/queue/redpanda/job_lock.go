@@ -0,0 +1,167 @@
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	adapterobs "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+)
+
+// WithJobLocks makes the consumer lease each job in repo for ttl before
+// evaluating it, so consumers in different groups that receive the same job
+// do not evaluate it twice. The lease is renewed while the job runs and its
+// fencing token is checked by the statement that stores the result. A nil
+// repo or a non-positive ttl disables the locks.
+func (c *Consumer) WithJobLocks(repo domain.JobLockRepository, ttl time.Duration) *Consumer {
+	if repo == nil || ttl <= 0 {
+		c.jobLocks = nil
+		return c
+	}
+	host, _ := os.Hostname()
+	c.jobLocks = repo
+	c.jobLockTTL = ttl
+	c.jobLockOwner = fmt.Sprintf("%s/%d/%s", host, os.Getpid(), c.groupID)
+	return c
+}
+
+// jobLease is a consumer's lease on one job. It is renewed in the background
+// every third of its ttl; once another consumer takes the job over the lease
+// is marked lost and the job's context is cancelled.
+type jobLease struct {
+	repo   domain.JobLockRepository
+	jobID  string
+	token  int64
+	ttl    time.Duration
+	lost   atomic.Bool
+	cancel context.CancelFunc
+	stop   chan struct{}
+	done   sync.WaitGroup
+}
+
+// acquireJobLease leases jobID for the record being processed. It returns a
+// nil lease when locks are disabled or the lock store is unavailable (the job
+// is then processed unfenced), and skip when another consumer holds the job.
+// The returned context is cancelled when the lease is lost.
+func (c *Consumer) acquireJobLease(ctx context.Context, jobID string) (context.Context, *jobLease, bool) {
+	if c.jobLocks == nil || jobID == "" {
+		return ctx, nil, false
+	}
+	lg := obsctx.LoggerFromContext(ctx)
+	token, err := c.jobLocks.Acquire(ctx, jobID, c.jobLockOwner, c.jobLockTTL)
+	if errors.Is(err, domain.ErrConflict) {
+		adapterobs.RecordJobLock("conflict")
+		lg.Info("job is leased by another consumer; skipping", slog.String("job_id", jobID))
+		return ctx, nil, true
+	}
+	if err != nil {
+		adapterobs.RecordJobLock("error")
+		lg.Warn("failed to lease job; processing without a lease", slog.String("job_id", jobID), slog.Any("error", err))
+		return ctx, nil, false
+	}
+	adapterobs.RecordJobLock("acquired")
+	ctx, cancel := context.WithCancel(ctx)
+	l := &jobLease{repo: c.jobLocks, jobID: jobID, token: token, ttl: c.jobLockTTL, cancel: cancel, stop: make(chan struct{})}
+	l.done.Add(1)
+	go l.keepAlive(ctx)
+	return withJobLease(ctx, l), l, false
+}
+
+// keepAlive renews the lease until it is released or lost. Transient renewal
+// errors are retried on the next tick.
+func (l *jobLease) keepAlive(ctx context.Context) {
+	defer l.done.Done()
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.renew(ctx); err != nil && !l.Lost() {
+				obsctx.LoggerFromContext(ctx).Warn("failed to renew job lease", slog.String("job_id", l.jobID), slog.Any("error", err))
+			}
+			if l.Lost() {
+				return
+			}
+		}
+	}
+}
+
+// renew extends the lease and marks it lost when it was taken over.
+func (l *jobLease) renew(ctx context.Context) error {
+	err := l.repo.Renew(ctx, l.jobID, l.token, l.ttl)
+	if errors.Is(err, domain.ErrConflict) {
+		l.markLost(ctx)
+	}
+	return err
+}
+
+// markLost marks the lease lost and cancels the job's context, once.
+func (l *jobLease) markLost(ctx context.Context) {
+	if l.lost.CompareAndSwap(false, true) {
+		adapterobs.RecordJobLock("lost")
+		obsctx.LoggerFromContext(ctx).Warn("job lease taken over by another consumer", slog.String("job_id", l.jobID))
+		l.cancel()
+	}
+}
+
+// Lost reports whether another consumer took the job over. A nil lease is
+// never lost.
+func (l *jobLease) Lost() bool {
+	return l != nil && l.lost.Load()
+}
+
+// StoreResult stores r only while the lease holds, checking its fencing token
+// in the same statement, and marks the lease lost when it was taken over. A
+// nil lease stores r unfenced.
+func (l *jobLease) StoreResult(ctx context.Context, results domain.ResultRepository, r domain.Result) error {
+	if l == nil {
+		return results.Upsert(ctx, r)
+	}
+	err := results.UpsertFenced(ctx, r, l.token)
+	if errors.Is(err, domain.ErrConflict) {
+		l.markLost(ctx)
+	}
+	return err
+}
+
+// Release stops renewing the lease and deletes it unless it was lost.
+func (l *jobLease) Release() {
+	if l == nil {
+		return
+	}
+	close(l.stop)
+	l.done.Wait()
+	l.cancel()
+	if l.Lost() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := l.repo.Release(ctx, l.jobID, l.token); err != nil {
+		slog.Warn("failed to release job lease", slog.String("job_id", l.jobID), slog.Any("error", err))
+	}
+}
+
+type jobLeaseKey struct{}
+
+// withJobLease attaches l to ctx for HandleEvaluate.
+func withJobLease(ctx context.Context, l *jobLease) context.Context {
+	return context.WithValue(ctx, jobLeaseKey{}, l)
+}
+
+// jobLeaseFrom returns the lease attached to ctx, or nil.
+func jobLeaseFrom(ctx context.Context) *jobLease {
+	l, _ := ctx.Value(jobLeaseKey{}).(*jobLease)
+	return l
+}
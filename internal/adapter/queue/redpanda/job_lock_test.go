@@ -0,0 +1,114 @@
+package redpanda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+)
+
+// newLockedConsumer returns a consumer leasing jobs in locks with a fresh
+// queued job-1 and the record that delivers it.
+func newLockedConsumer(t *testing.T, locks domain.JobLockRepository) (*Consumer, *fakeJobRepo, *fakeResultRepo, *kgo.Record) {
+	t.Helper()
+	jobs := &fakeJobRepo{jobs: map[string]domain.Job{
+		"job-1": {ID: "job-1", Status: domain.JobQueued},
+	}}
+	results := &fakeResultRepo{token: 5}
+	c := (&Consumer{
+		jobs: jobs,
+		uploads: &fakeUploadRepo{uploads: map[string]domain.Upload{
+			"cv-1":      {ID: "cv-1", Type: domain.UploadTypeCV, Text: "cv text"},
+			"project-1": {ID: "project-1", Type: domain.UploadTypeProject, Text: "project text"},
+		}},
+		results: results,
+		ai:      &stubAIForHandle{},
+	}).WithJobLocks(locks, 10*time.Minute)
+	value, err := json.Marshal(domain.EvaluateTaskPayload{
+		JobID: "job-1", CVID: "cv-1", ProjectID: "project-1",
+		JobDescription: "desc", StudyCaseBrief: "study", ScoringRubric: "rubric",
+	})
+	require.NoError(t, err)
+	return c, jobs, results, &kgo.Record{Topic: "evaluate-jobs", Key: []byte("job-1"), Value: value}
+}
+
+func TestConsumer_ProcessRecord_LeasesJob(t *testing.T) {
+	locks := mocks.NewMockJobLockRepository(t)
+	c, jobs, results, rec := newLockedConsumer(t, locks)
+	locks.EXPECT().Acquire(mock.Anything, "job-1", c.jobLockOwner, 10*time.Minute).Return(5, nil).Once()
+	// The result is stored fenced by the lease, which is released after.
+	locks.EXPECT().Release(mock.Anything, "job-1", int64(5)).Return(nil).Once()
+
+	require.NoError(t, c.processRecord(context.Background(), rec))
+	assert.Contains(t, results.stored, "job-1")
+	assert.Equal(t, domain.JobCompleted, jobs.jobs["job-1"].Status)
+}
+
+func TestConsumer_ProcessRecord_SkipsJobLeasedElsewhere(t *testing.T) {
+	locks := mocks.NewMockJobLockRepository(t)
+	c, jobs, results, rec := newLockedConsumer(t, locks)
+	locks.EXPECT().Acquire(mock.Anything, "job-1", mock.Anything, mock.Anything).
+		Return(0, fmt.Errorf("op=job_lock.acquire: %w", domain.ErrConflict)).Once()
+
+	require.NoError(t, c.processRecord(context.Background(), rec))
+	assert.Empty(t, results.stored)
+	assert.Equal(t, domain.JobQueued, jobs.jobs["job-1"].Status)
+}
+
+func TestConsumer_ProcessRecord_LostLeaseDoesNotStoreResult(t *testing.T) {
+	locks := mocks.NewMockJobLockRepository(t)
+	c, jobs, results, rec := newLockedConsumer(t, locks)
+	locks.EXPECT().Acquire(mock.Anything, "job-1", mock.Anything, mock.Anything).Return(5, nil).Once()
+	// Another consumer took the job over with the next token before the
+	// result was written.
+	results.token = 6
+
+	// The consumer that took the job over stores the result and settles the
+	// job status; the lease is not released on its behalf.
+	require.NoError(t, c.processRecord(context.Background(), rec))
+	assert.Empty(t, results.stored)
+	assert.Equal(t, domain.JobProcessing, jobs.jobs["job-1"].Status)
+}
+
+func TestHandleEvaluate_LostLeaseKeepsJobStatus(t *testing.T) {
+	locks := mocks.NewMockJobLockRepository(t)
+	c, jobs, results, rec := newLockedConsumer(t, locks)
+	var payload domain.EvaluateTaskPayload
+	require.NoError(t, json.Unmarshal(rec.Value, &payload))
+	// The job was taken over before it was marked processing.
+	lease := &jobLease{jobID: "job-1", cancel: func() {}}
+	lease.lost.Store(true)
+
+	err := HandleEvaluate(withJobLease(context.Background(), lease), c.jobs, c.uploads, c.results, c.ai, nil, payload, c.evalOpts)
+	require.ErrorIs(t, err, domain.ErrConflict)
+	assert.Empty(t, jobs.updated)
+	assert.Empty(t, results.stored)
+	assert.Equal(t, domain.JobQueued, jobs.jobs["job-1"].Status)
+}
+
+func TestConsumer_ProcessRecord_LockStoreUnavailable(t *testing.T) {
+	locks := mocks.NewMockJobLockRepository(t)
+	c, _, results, rec := newLockedConsumer(t, locks)
+	locks.EXPECT().Acquire(mock.Anything, "job-1", mock.Anything, mock.Anything).Return(0, assert.AnError).Once()
+
+	// Without a lease the job is still processed.
+	require.NoError(t, c.processRecord(context.Background(), rec))
+	assert.Contains(t, results.stored, "job-1")
+}
+
+func TestConsumer_WithJobLocks_Disabled(t *testing.T) {
+	c := (&Consumer{}).WithJobLocks(mocks.NewMockJobLockRepository(t), 0)
+	assert.Nil(t, c.jobLocks)
+	_, lease, skip := c.acquireJobLease(context.Background(), "job-1")
+	assert.Nil(t, lease)
+	assert.False(t, skip)
+}
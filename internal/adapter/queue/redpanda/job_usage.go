@@ -0,0 +1,45 @@
+package redpanda
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// aiTimingAI adds the duration of the chat calls of an AI client to the
+// JobTimer of their context. Embeddings are part of the RAG lookups that
+// make them and are timed there.
+type aiTimingAI struct{ domain.AIClient }
+
+// timeAICalls wraps ai so that its chat calls are added to the job's usage.
+func timeAICalls(ai domain.AIClient) domain.AIClient { return aiTimingAI{AIClient: ai} }
+
+func (a aiTimingAI) ChatJSON(ctx context.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	defer observeAITime(ctx, time.Now())
+	return a.AIClient.ChatJSON(ctx, systemPrompt, userPrompt, maxTokens)
+}
+
+func (a aiTimingAI) ChatJSONWithRetry(ctx context.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	defer observeAITime(ctx, time.Now())
+	return a.AIClient.ChatJSONWithRetry(ctx, systemPrompt, userPrompt, maxTokens)
+}
+
+func (a aiTimingAI) CleanCoTResponse(ctx context.Context, response string) (string, error) {
+	defer observeAITime(ctx, time.Now())
+	return a.AIClient.CleanCoTResponse(ctx, response)
+}
+
+func observeAITime(ctx context.Context, start time.Time) { domain.AddAITime(ctx, time.Since(start)) }
+
+// recordJobUsage stores the phase timings of a completed job. Failures are
+// logged; they do not affect the job.
+func recordJobUsage(ctx context.Context, repo domain.JobUsageRepository, u domain.JobUsage) {
+	if repo == nil {
+		return
+	}
+	if err := repo.Record(ctx, u); err != nil {
+		slog.Warn("failed to record job usage", slog.String("job_id", u.JobID), slog.Any("error", err))
+	}
+}
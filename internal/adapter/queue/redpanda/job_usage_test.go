@@ -0,0 +1,67 @@
+package redpanda
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+type memJobUsage struct{ recorded []domain.JobUsage }
+
+func (m *memJobUsage) Record(_ domain.Context, u domain.JobUsage) error {
+	m.recorded = append(m.recorded, u)
+	return nil
+}
+
+func (m *memJobUsage) Percentiles(domain.Context, time.Time) (domain.JobUsageReport, error) {
+	return domain.JobUsageReport{}, nil
+}
+
+func TestTimeAICalls_CountsChatCalls(t *testing.T) {
+	ctx, timer := domain.WithJobTimer(context.Background())
+	ai := timeAICalls(&stubAIForHandle{})
+
+	_, err := ai.ChatJSON(ctx, "s", "u", 10)
+	require.NoError(t, err)
+	_, err = ai.ChatJSONWithRetry(ctx, "s", "u", 10)
+	require.NoError(t, err)
+	_, err = ai.CleanCoTResponse(ctx, "{}")
+	require.NoError(t, err)
+	// Embeddings are timed with the RAG lookups that make them.
+	_, err = ai.Embed(ctx, []string{"q"})
+	require.NoError(t, err)
+
+	_, calls := timer.AI()
+	assert.Equal(t, 3, calls)
+}
+
+func TestHandleEvaluate_RecordsJobUsage(t *testing.T) {
+	ctx := context.Background()
+	created := time.Now().Add(-2 * time.Second)
+	jobs := &fakeJobRepo{jobs: map[string]domain.Job{
+		"job-1": {ID: "job-1", Status: domain.JobQueued, CreatedAt: created},
+	}}
+	uploads := &fakeUploadRepo{uploads: map[string]domain.Upload{
+		"cv-1":      {ID: "cv-1", Type: domain.UploadTypeCV, Text: "cv text"},
+		"project-1": {ID: "project-1", Type: domain.UploadTypeProject, Text: "project text"},
+	}}
+	payload := domain.EvaluateTaskPayload{
+		JobID: "job-1", CVID: "cv-1", ProjectID: "project-1",
+		JobDescription: "job desc", StudyCaseBrief: "study", ScoringRubric: "rubric",
+	}
+	usage := &memJobUsage{}
+
+	require.NoError(t, HandleEvaluate(ctx, jobs, uploads, &fakeResultRepo{}, &stubAIForHandle{}, nil, payload, EvaluateOptions{Usage: usage}))
+	require.Len(t, usage.recorded, 1)
+	u := usage.recorded[0]
+	assert.Equal(t, "job-1", u.JobID)
+	assert.GreaterOrEqual(t, u.QueueWait, 2*time.Second)
+	assert.Positive(t, u.AICalls)
+	assert.GreaterOrEqual(t, u.Total, u.QueueWait+u.AI+u.RAG)
+	assert.Equal(t, u.CompletedAt.Sub(created), u.Total)
+}
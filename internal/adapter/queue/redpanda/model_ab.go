@@ -0,0 +1,49 @@
+package redpanda
+
+import (
+	"context"
+	"hash/fnv"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ModelChallenger routes a share of jobs to a challenger model. Jobs of the
+// challenger arm try the model first on every AI call and fall back to the
+// default pool when it fails; the other jobs make up the control arm.
+type ModelChallenger struct {
+	Provider string
+	Model    string
+	// Traffic is the percentage of jobs routed to the challenger.
+	Traffic int
+}
+
+// enabled reports whether m routes any jobs.
+func (m ModelChallenger) enabled() bool {
+	return m.Model != "" && m.Traffic > 0
+}
+
+// arm returns the arm of jobID. The choice hashes the job ID, so
+// redeliveries of a job stay in their arm.
+func (m ModelChallenger) arm(jobID string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte("model_ab/" + jobID))
+	if int(h.Sum32()%100) < m.Traffic {
+		return domain.ModelArmChallenger
+	}
+	return domain.ModelArmControl
+}
+
+// route assigns payload's job to an arm, recording it in ctx, and points the
+// overrides of challenger-arm jobs at the challenger. Jobs pinned to a model
+// by an admin retry are not routed.
+func (m ModelChallenger) route(ctx context.Context, payload domain.EvaluateTaskPayload) (context.Context, domain.EvaluateTaskPayload) {
+	if !m.enabled() || payload.Overrides.Pinned() {
+		return ctx, payload
+	}
+	arm := m.arm(payload.JobID)
+	if arm == domain.ModelArmChallenger {
+		payload.Overrides.Provider, payload.Overrides.Model = m.Provider, m.Model
+		payload.Overrides.Challenger = true
+	}
+	return domain.WithModelArm(ctx, arm), payload
+}
@@ -0,0 +1,39 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestModelChallenger_Route(t *testing.T) {
+	m := ModelChallenger{Provider: "groq", Model: "challenger-model", Traffic: 25}
+	challengers := 0
+	for i := 0; i < 1000; i++ {
+		payload := domain.EvaluateTaskPayload{JobID: fmt.Sprintf("job-%d", i), Overrides: domain.EvaluationOverrides{MaxTokens: 800}}
+		ctx, routed := m.route(context.Background(), payload)
+		arm := domain.ModelArmFrom(ctx)
+		assert.Equal(t, arm, m.arm(payload.JobID), "assignment is stable")
+		assert.Equal(t, 800, routed.Overrides.MaxTokens, "tenant overrides are kept")
+		if arm == domain.ModelArmChallenger {
+			challengers++
+			assert.Equal(t, domain.EvaluationOverrides{MaxTokens: 800, Provider: "groq", Model: "challenger-model", Challenger: true}, routed.Overrides)
+		} else {
+			assert.Equal(t, domain.ModelArmControl, arm)
+			assert.Equal(t, payload, routed)
+		}
+	}
+	assert.InDelta(t, 250, challengers, 50)
+
+	// Admin retries keep their pinned model, and disabled routing records no arm.
+	pinned := domain.EvaluateTaskPayload{JobID: "job-1", Overrides: domain.EvaluationOverrides{Provider: "openrouter", Model: "m"}}
+	ctx, routed := ModelChallenger{Provider: "groq", Model: "challenger-model", Traffic: 100}.route(context.Background(), pinned)
+	assert.Equal(t, pinned, routed)
+	assert.Empty(t, domain.ModelArmFrom(ctx))
+	ctx, _ = ModelChallenger{Model: "challenger-model"}.route(context.Background(), domain.EvaluateTaskPayload{JobID: "job-1"})
+	assert.Empty(t, domain.ModelArmFrom(ctx))
+}
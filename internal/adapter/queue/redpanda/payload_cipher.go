@@ -0,0 +1,122 @@
+package redpanda
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Headers of encrypted records.
+const (
+	// headerEncKeyID names the key a record's value is encrypted with.
+	headerEncKeyID = "enc-key-id"
+	// headerEncAlg names the algorithm a record's value is encrypted with.
+	headerEncAlg = "enc-alg"
+	// encAlgA256GCM is AES-256-GCM with the nonce prepended to the value.
+	encAlgA256GCM = "A256GCM"
+)
+
+// errUnknownPayloadKey fails records encrypted with a key, or an algorithm,
+// the consumer does not have.
+var errUnknownPayloadKey = errors.New("unknown payload encryption key")
+
+// PayloadCipher encrypts the values of produced records with AES-256-GCM and
+// decrypts consumed ones. The key id travels in the enc-key-id header so keys
+// can be rotated: records are sealed with the active key and opened with any
+// known key. A nil PayloadCipher leaves values in plaintext.
+type PayloadCipher struct {
+	active string
+	keys   map[string]cipher.AEAD
+}
+
+// NewPayloadCipher returns a PayloadCipher that seals with the key named
+// activeKeyID, or none when it is empty, and opens with all keys. It returns
+// nil when keys is empty.
+func NewPayloadCipher(activeKeyID string, keys map[string][]byte) (*PayloadCipher, error) {
+	if len(keys) == 0 {
+		if activeKeyID != "" {
+			return nil, fmt.Errorf("op=redpanda.payload_cipher: active key %q: %w", activeKeyID, errUnknownPayloadKey)
+		}
+		return nil, nil
+	}
+	pc := &PayloadCipher{active: activeKeyID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("op=redpanda.payload_cipher: key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("op=redpanda.payload_cipher: key %q: %w", id, err)
+		}
+		pc.keys[id] = aead
+	}
+	if _, ok := pc.keys[activeKeyID]; activeKeyID != "" && !ok {
+		return nil, fmt.Errorf("op=redpanda.payload_cipher: active key %q: %w", activeKeyID, errUnknownPayloadKey)
+	}
+	return pc, nil
+}
+
+// Seal encrypts record's value with the active key, bound to the record's
+// key, and sets the encryption headers. It leaves the record unchanged
+// without an active key.
+func (pc *PayloadCipher) Seal(record *kgo.Record) error {
+	if pc == nil || pc.active == "" {
+		return nil
+	}
+	aead := pc.keys[pc.active]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(record.Value)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("op=redpanda.seal: nonce: %w", err)
+	}
+	record.Value = aead.Seal(nonce, nonce, record.Value, record.Key)
+	record.Headers = append(record.Headers,
+		kgo.RecordHeader{Key: headerEncKeyID, Value: []byte(pc.active)},
+		kgo.RecordHeader{Key: headerEncAlg, Value: []byte(encAlgA256GCM)},
+	)
+	return nil
+}
+
+// Open decrypts the value of a record sealed by Seal in place and drops its
+// encryption headers, so opening a record twice is harmless. Records
+// without the enc-key-id header are plaintext and left unchanged, so that
+// encryption can be enabled while plaintext records are still queued.
+// Records sealed with a key the cipher does not know fail with
+// errUnknownPayloadKey.
+func (pc *PayloadCipher) Open(record *kgo.Record) error {
+	keyID := recordHeader(record, headerEncKeyID)
+	if keyID == "" {
+		return nil
+	}
+	if alg := recordHeader(record, headerEncAlg); alg != encAlgA256GCM {
+		return fmt.Errorf("op=redpanda.open: algorithm %q: %w", alg, errUnknownPayloadKey)
+	}
+	var aead cipher.AEAD
+	if pc != nil {
+		aead = pc.keys[keyID]
+	}
+	if aead == nil {
+		return fmt.Errorf("op=redpanda.open: key %q: %w", keyID, errUnknownPayloadKey)
+	}
+	if len(record.Value) < aead.NonceSize() {
+		return fmt.Errorf("op=redpanda.open: value shorter than nonce")
+	}
+	nonce, sealed := record.Value[:aead.NonceSize()], record.Value[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, record.Key)
+	if err != nil {
+		return fmt.Errorf("op=redpanda.open: key %q: %w", keyID, err)
+	}
+	record.Value = plain
+	headers := record.Headers[:0]
+	for _, h := range record.Headers {
+		if h.Key != headerEncKeyID && h.Key != headerEncAlg {
+			headers = append(headers, h)
+		}
+	}
+	record.Headers = headers
+	return nil
+}
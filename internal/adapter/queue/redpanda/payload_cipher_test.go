@@ -0,0 +1,101 @@
+package redpanda
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+)
+
+func testPayloadKeys() map[string][]byte {
+	return map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, 32),
+		"k2": bytes.Repeat([]byte{2}, 32),
+	}
+}
+
+func TestPayloadCipher_SealOpen(t *testing.T) {
+	producer, err := NewPayloadCipher("k2", testPayloadKeys())
+	require.NoError(t, err)
+	rec := &kgo.Record{
+		Key:     []byte("job-1"),
+		Value:   []byte(`{"cv_text":"secret"}`),
+		Headers: []kgo.RecordHeader{{Key: "job_id", Value: []byte("job-1")}},
+	}
+	require.NoError(t, producer.Seal(rec))
+	assert.NotContains(t, string(rec.Value), "secret")
+	assert.Equal(t, "k2", recordHeader(rec, headerEncKeyID))
+	assert.Equal(t, encAlgA256GCM, recordHeader(rec, headerEncAlg))
+
+	// A consumer that no longer seals with k2 still opens its records.
+	consumer, err := NewPayloadCipher("k1", testPayloadKeys())
+	require.NoError(t, err)
+	require.NoError(t, consumer.Open(rec))
+	assert.JSONEq(t, `{"cv_text":"secret"}`, string(rec.Value))
+	assert.Equal(t, []kgo.RecordHeader{{Key: "job_id", Value: []byte("job-1")}}, rec.Headers)
+	require.NoError(t, consumer.Open(rec))
+	assert.JSONEq(t, `{"cv_text":"secret"}`, string(rec.Value))
+}
+
+func TestPayloadCipher_Open_Rejects(t *testing.T) {
+	pc, err := NewPayloadCipher("k1", testPayloadKeys())
+	require.NoError(t, err)
+	sealed := func(key []byte) *kgo.Record {
+		rec := &kgo.Record{Key: key, Value: []byte(`{}`)}
+		require.NoError(t, pc.Seal(rec))
+		return rec
+	}
+
+	unknown := sealed([]byte("job-1"))
+	unknown.Headers[0].Value = []byte("k9")
+	assert.ErrorIs(t, pc.Open(unknown), errUnknownPayloadKey)
+	assert.ErrorIs(t, (*PayloadCipher)(nil).Open(sealed([]byte("job-1"))), errUnknownPayloadKey)
+
+	// The value is bound to the record key.
+	moved := sealed([]byte("job-1"))
+	moved.Key = []byte("job-2")
+	assert.Error(t, pc.Open(moved))
+
+	plain := &kgo.Record{Value: []byte(`{}`)}
+	require.NoError(t, pc.Open(plain))
+	assert.Equal(t, `{}`, string(plain.Value))
+}
+
+func TestNewPayloadCipher(t *testing.T) {
+	pc, err := NewPayloadCipher("", nil)
+	require.NoError(t, err)
+	assert.Nil(t, pc)
+	rec := &kgo.Record{Value: []byte(`{}`)}
+	require.NoError(t, pc.Seal(rec))
+	assert.Empty(t, rec.Headers)
+
+	_, err = NewPayloadCipher("k9", testPayloadKeys())
+	assert.ErrorIs(t, err, errUnknownPayloadKey)
+	_, err = NewPayloadCipher("k1", map[string][]byte{"k1": []byte("short")})
+	assert.Error(t, err)
+}
+
+func TestConsumer_ProcessRecord_QuarantinesUnknownPayloadKey(t *testing.T) {
+	sealer, err := NewPayloadCipher("k9", map[string][]byte{"k9": bytes.Repeat([]byte{9}, 32)})
+	require.NoError(t, err)
+	rec := &kgo.Record{Topic: "evaluate-jobs", Key: []byte("job-1"), Value: []byte(`{"job_id":"job-1"}`)}
+	require.NoError(t, sealer.Seal(rec))
+
+	repo := mocks.NewMockQuarantineRepository(t)
+	pc, err := NewPayloadCipher("", testPayloadKeys())
+	require.NoError(t, err)
+	c := (&Consumer{}).WithQuarantine(repo, 1).WithPayloadCipher(pc)
+	captured := mock.MatchedBy(func(m domain.QuarantinedMessage) bool {
+		return m.Headers[headerEncKeyID] == "k9" && !bytes.Contains(m.Value, []byte("job-1"))
+	})
+	repo.EXPECT().Record(mock.Anything, captured).Return(1, nil).Once()
+
+	require.NoError(t, c.processRecord(context.Background(), rec))
+}
@@ -0,0 +1,28 @@
+package redpanda
+
+import (
+	"slices"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// priorityTopicSuffix names the priority topic of an evaluation topic.
+const priorityTopicSuffix = "-priority"
+
+// priorityFirst orders records so those of the priority topic come first,
+// keeping the fetch order otherwise. Kafka has no message priorities, so a
+// bumped job is published to a separate topic that is fetched on the next
+// poll instead of waiting behind the unfetched backlog; ordering the poll
+// hands it to the workers ahead of the records fetched with it.
+func priorityFirst(records []*kgo.Record, priority string) []*kgo.Record {
+	slices.SortStableFunc(records, func(a, b *kgo.Record) int {
+		switch {
+		case a.Topic == priority && b.Topic != priority:
+			return -1
+		case a.Topic != priority && b.Topic == priority:
+			return 1
+		}
+		return 0
+	})
+	return records
+}
@@ -0,0 +1,23 @@
+package redpanda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestPriorityFirst(t *testing.T) {
+	records := []*kgo.Record{
+		{Topic: TopicEvaluate, Offset: 1},
+		{Topic: TopicEvaluatePriority, Offset: 7},
+		{Topic: TopicEvaluate, Offset: 2},
+		{Topic: TopicEvaluatePriority, Offset: 8},
+	}
+	got := priorityFirst(records, TopicEvaluatePriority)
+	var order []int64
+	for _, r := range got {
+		order = append(order, r.Offset)
+	}
+	assert.Equal(t, []int64{7, 8, 1, 2}, order)
+}
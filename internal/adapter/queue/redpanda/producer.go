@@ -26,6 +26,11 @@ import (
 const (
 	// TopicEvaluate is the Kafka topic for evaluation jobs
 	TopicEvaluate = "evaluate-jobs"
+	// TopicEvaluatePriority is the Kafka topic for evaluation jobs bumped
+	// ahead of the backlog; consumers of TopicEvaluate also consume it
+	TopicEvaluatePriority = TopicEvaluate + priorityTopicSuffix
+	// TopicDLQ is the Kafka topic for jobs that exhausted their retries
+	TopicDLQ = "dlq-jobs"
 )
 
 // Producer wraps a Kafka producer and implements domain.Queue.
@@ -33,6 +38,8 @@ type Producer struct {
 	client *kgo.Client
 	// Channel-based approach for concurrent processing
 	transactionChan chan struct{}
+	// cipher encrypts record values (see WithPayloadCipher).
+	cipher *PayloadCipher
 }
 
 // NewProducer constructs a Producer with exactly-once semantics.
@@ -93,6 +100,11 @@ func NewProducerWithTransactionalID(brokers []string, transactionalID string) (*
 			// Don't fail if topic creation fails - it might already exist
 		}
 	}
+	if err := createTopicIfNotExists(ctx, client, TopicEvaluatePriority, 1, 1); err != nil {
+		slog.Warn("failed to create priority topic, it may already exist",
+			slog.String("topic", TopicEvaluatePriority),
+			slog.Any("error", err))
+	}
 
 	slog.Info("redpanda producer created successfully")
 	return &Producer{
@@ -101,6 +113,13 @@ func NewProducerWithTransactionalID(brokers []string, transactionalID string) (*
 	}, nil
 }
 
+// WithPayloadCipher encrypts the values of produced records with the
+// cipher's active key. A nil cipher produces plaintext records.
+func (p *Producer) WithPayloadCipher(pc *PayloadCipher) *Producer {
+	p.cipher = pc
+	return p
+}
+
 // EnqueueDLQ enqueues a job to the Dead Letter Queue
 func (p *Producer) EnqueueDLQ(ctx domain.Context, jobID string, dlqData []byte) error {
 	tracer := otel.Tracer("queue.producer")
@@ -112,7 +131,7 @@ func (p *Producer) EnqueueDLQ(ctx domain.Context, jobID string, dlqData []byte)
 	span.SetAttributes(
 		attribute.String("messaging.system", "redpanda"),
 		attribute.String("messaging.operation", "publish"),
-		attribute.String("messaging.destination", "dlq-jobs"),
+		attribute.String("messaging.destination", TopicDLQ),
 		attribute.String("messaging.kafka.transactional_id", "ai-cv-evaluator-producer"),
 		attribute.String("messaging.job_id", jobID),
 	)
@@ -137,7 +156,13 @@ func (p *Producer) EnqueueDLQ(ctx domain.Context, jobID string, dlqData []byte)
 	record := &kgo.Record{
 		Key:   []byte(jobID),
 		Value: messageBytes,
-		Topic: "dlq-jobs",
+		Topic: TopicDLQ,
+	}
+	if err := p.cipher.Seal(record); err != nil {
+		lg.Error("failed to encrypt DLQ message", slog.String("job_id", jobID), slog.Any("error", err))
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(attribute.String("error.type", "encrypt"))
+		return fmt.Errorf("encrypt DLQ message: %w", err)
 	}
 
 	// Use transactional producer for exactly-once semantics
@@ -183,7 +208,11 @@ func (p *Producer) EnqueueDLQ(ctx domain.Context, jobID string, dlqData []byte)
 }
 
 // EnqueueEvaluate enqueues an evaluation task with exactly-once semantics.
+// Bumped tasks go to TopicEvaluatePriority.
 func (p *Producer) EnqueueEvaluate(ctx domain.Context, payload domain.EvaluateTaskPayload) (string, error) {
+	if payload.Bumped {
+		return p.EnqueueEvaluateToTopic(ctx, payload, TopicEvaluatePriority)
+	}
 	return p.EnqueueEvaluateToTopic(ctx, payload, TopicEvaluate)
 }
 
@@ -275,8 +304,24 @@ func (p *Producer) EnqueueEvaluateToTopic(ctx domain.Context, payload domain.Eva
 			{Key: "job_id", Value: []byte(payload.JobID)},
 			{Key: "cv_id", Value: []byte(payload.CVID)},
 			{Key: "project_id", Value: []byte(payload.ProjectID)},
+			{Key: "request_id", Value: []byte(payload.RequestID)},
 		},
 	}
+	if err := p.cipher.Seal(record); err != nil {
+		lg.Error("failed to encrypt payload",
+			slog.String("job_id", payload.JobID),
+			slog.Any("error", err))
+		if abortErr := p.client.EndTransaction(ctx, kgo.TryAbort); abortErr != nil {
+			slog.Error("failed to abort transaction", slog.Any("error", abortErr))
+		}
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(
+			attribute.String("error.type", "encrypt"),
+			attribute.String("messaging.status_code", "INTERNAL"),
+			attribute.String("messaging.status_message", "Failed to encrypt payload"),
+		)
+		return "", fmt.Errorf("encrypt payload: %w", err)
+	}
 
 	// Use AbortingFirstErrPromise for proper error handling
 	lg.Info("producing message to topic",
@@ -325,13 +370,28 @@ func (p *Producer) EnqueueEvaluateToTopic(ctx domain.Context, payload domain.Eva
 	lg.Info("transaction committed successfully", slog.String("job_id", payload.JobID))
 
 	observability.EnqueueJob("evaluate")
-	lg.Info("redpanda enqueue successful", slog.String("topic", TopicEvaluate), slog.String("job_id", payload.JobID))
+	lg.Info("redpanda enqueue successful", slog.String("topic", topic), slog.String("job_id", payload.JobID))
 	span.SetStatus(codes.Ok, "evaluate job enqueued")
 
 	// Return job ID as task ID
 	return payload.JobID, nil
 }
 
+// CloseContext waits until no transaction is in progress, or ctx is done,
+// and closes the producer. Transactions that start afterwards fail.
+func (p *Producer) CloseContext(ctx context.Context) error {
+	if p.transactionChan != nil {
+		select {
+		case p.transactionChan <- struct{}{}:
+			// Holding the lock keeps new transactions from starting; Close
+			// takes it back.
+		case <-ctx.Done():
+			slog.Warn("closing producer with a transaction in progress", slog.Any("error", ctx.Err()))
+		}
+	}
+	return p.Close()
+}
+
 // Close closes the producer.
 func (p *Producer) Close() error {
 	if p.client != nil {
@@ -0,0 +1,190 @@
+package redpanda
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// PerformProjectOnlyEvaluation grades a project deliverable against the study
+// case without a CV. It runs the project step of the multi-step chain and a
+// refinement that only asks for project_score, project_feedback and
+// overall_summary, falling back to a single project-only prompt when a step
+// fails.
+func (h *IntegratedEvaluationHandler) PerformProjectOnlyEvaluation(
+	ctx context.Context,
+	projectContent, studyCase, scoringRubric string,
+	jobID string,
+) (domain.Result, error) {
+	tracer := otel.Tracer("integrated.evaluation")
+	ctx, span := tracer.Start(ctx, "PerformProjectOnlyEvaluation")
+	defer span.End()
+
+	if reason := domain.FastPathReasonFrom(ctx); reason != "" {
+		slog.Info("project-only fast path selected", slog.String("job_id", jobID), slog.String("reason", reason))
+		return h.performProjectOnlyFastPath(ctx, projectContent, studyCase, scoringRubric, jobID)
+	}
+	slog.Info("performing project-only evaluation", slog.String("job_id", jobID))
+
+	step1Ctx, step1Span := tracer.Start(ctx, "PerformProjectOnlyEvaluation.evaluateProjectDeliverables")
+	projectEvaluation, err := h.evaluateProjectDeliverables(step1Ctx, projectContent, studyCase, scoringRubric, jobID)
+	step1Span.End()
+	if err != nil {
+		slog.Error("step 1: evaluateProjectDeliverables failed; falling back to project-only fast path",
+			slog.String("job_id", jobID),
+			slog.Any("error", err))
+		return h.performProjectOnlyFastPath(ctx, projectContent, studyCase, scoringRubric, jobID)
+	}
+
+	step2Ctx, step2Span := tracer.Start(ctx, "PerformProjectOnlyEvaluation.refineEvaluation")
+	refined, err := h.refineProjectOnlyEvaluation(step2Ctx, projectEvaluation, jobID)
+	step2Span.End()
+	if err != nil {
+		slog.Error("step 2: refineProjectOnlyEvaluation failed; falling back to project-only fast path",
+			slog.String("job_id", jobID),
+			slog.Any("error", err))
+		return h.performProjectOnlyFastPath(ctx, projectContent, studyCase, scoringRubric, jobID)
+	}
+
+	result, err := h.parseProjectOnlyResponse(ctx, refined, jobID)
+	if err != nil {
+		slog.Error("parseProjectOnlyResponse failed; falling back to project-only fast path",
+			slog.String("job_id", jobID),
+			slog.Any("error", err))
+		return h.performProjectOnlyFastPath(ctx, projectContent, studyCase, scoringRubric, jobID)
+	}
+
+	slog.Info("project-only evaluation completed successfully", slog.String("job_id", jobID),
+		slog.Float64("project_score", result.ProjectScore))
+	observability.ObserveEvaluation(-1, result.ProjectScore)
+	return result, nil
+}
+
+// refineProjectOnlyEvaluation turns the project analysis into the final
+// project-only JSON.
+func (h *IntegratedEvaluationHandler) refineProjectOnlyEvaluation(ctx context.Context, projectEvaluation, jobID string) (string, error) {
+	ctx = withStep(ctx, stepRefineProject)
+	prompt := `You are a technical reviewer. Refine the project evaluation results into a final score and feedback. No CV was submitted; do not mention or score one.
+
+Project Evaluation Results:
+%s
+
+Please provide the final evaluation in JSON format (no explanations in the output):
+{
+  "project_score": 8.5,
+  "project_feedback": "Technical project feedback",
+  "overall_summary": "Summary of the deliverable with recommendations"
+}
+
+Guidelines:
+- project_score: 1.0 to 10.0 (1=poor, 10=excellent)
+- Provide professional, constructive feedback
+- Return only the JSON object, no additional text
+
+`
+	response, err := h.performStableEvaluation(ctx, fmt.Sprintf(prompt, projectEvaluation)+h.parameterScoresPrompt(domain.RubricGroupProject)+h.feedbackFormatPrompt()+h.feedbackLanguagePrompt(), jobID)
+	if err != nil {
+		return "", fmt.Errorf("AI project-only refinement failed: %w", err)
+	}
+	return response, nil
+}
+
+// performProjectOnlyFastPath grades the project with a single prompt.
+func (h *IntegratedEvaluationHandler) performProjectOnlyFastPath(ctx context.Context, projectContent, studyCase, scoringRubric, jobID string) (domain.Result, error) {
+	// The fast path alone produces the result; drop the calls of an
+	// abandoned multi-step chain from the provenance.
+	domain.ResetModelTrace(ctx)
+	ctx = withStep(fastPathContext(ctx), stepFastPathProject)
+	tracer := otel.Tracer("integrated.evaluation")
+	ctx, span := tracer.Start(ctx, "PerformProjectOnlyEvaluation.fastPath")
+	defer span.End()
+
+	prompt := fmt.Sprintf(`You are a senior engineer grading a coding-challenge deliverable. No CV was submitted.
+
+Project Content:
+%s
+
+Study Case Brief:
+%s
+
+Scoring Rubric (apply only the project parameters):
+%s
+
+Using the information above, produce a single JSON object with the following fields:
+{
+  "project_score": 8.5,
+  "project_feedback": "Technical project feedback",
+  "overall_summary": "Summary of the deliverable with recommendations"
+}
+
+Guidelines:
+- project_score: 1.0 to 10.0 (1=poor, 10=excellent)
+- Provide professional, constructive feedback in the feedback fields.
+- Return only the JSON object, with no extra commentary, prose, or code fences.
+%s`, projectContent, studyCase, scoringRubric, projectReadmePrompt(projectContent)+h.parameterScoresPrompt(domain.RubricGroupProject)+h.feedbackFormatPrompt()+h.feedbackLanguagePrompt())
+
+	response, err := h.performStableEvaluation(ctx, prompt, jobID)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("fast project-only evaluation failed: %w", err)
+	}
+	result, err := h.parseProjectOnlyResponse(ctx, response, jobID)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("result validation failed: %w", err)
+	}
+	slog.Info("project-only evaluation completed successfully with fast path", slog.String("job_id", jobID),
+		slog.Float64("project_score", result.ProjectScore))
+	observability.ObserveEvaluation(-1, result.ProjectScore)
+	return result, nil
+}
+
+// parseProjectOnlyResponse parses and validates a project-only evaluation.
+// Any CV fields the model adds are dropped.
+func (h *IntegratedEvaluationHandler) parseProjectOnlyResponse(ctx context.Context, response, jobID string) (domain.Result, error) {
+	cleaned, err := h.cleanJSONResponseWithCoTFallback(ctx, response, jobID)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("clean JSON response: %w", err)
+	}
+	var data struct {
+		ProjectScore    *float64 `json:"project_score"`
+		ProjectFeedback string   `json:"project_feedback"`
+		OverallSummary  string   `json:"overall_summary"`
+		// Per-parameter 1-5 scores, requested only with custom weights.
+		ProjectParameterScores map[string]float64 `json:"project_parameter_scores"`
+	}
+	if err := json.Unmarshal([]byte(cleaned), &data); err != nil {
+		return domain.Result{}, fmt.Errorf("parse evaluation JSON: %w", err)
+	}
+	if data.ProjectScore == nil {
+		return domain.Result{}, fmt.Errorf("missing project_score")
+	}
+	score := min(max(*data.ProjectScore, 1), 10)
+	if score != *data.ProjectScore {
+		slog.Warn("invalid project score, clamping to valid range",
+			slog.String("job_id", jobID),
+			slog.Float64("project_score", *data.ProjectScore))
+	}
+	result := domain.Result{
+		JobID:           jobID,
+		ProjectScore:    score,
+		ProjectFeedback: data.ProjectFeedback,
+		OverallSummary:  data.OverallSummary,
+		ProjectOnly:     true,
+		CreatedAt:       time.Now(),
+	}
+	h.applyScoringWeights(&result, nil, data.ProjectParameterScores)
+	h.formatFeedback(&result)
+	if result.ProjectFeedback == "" {
+		result.ProjectFeedback = "No feedback provided"
+	}
+	if result.OverallSummary == "" {
+		result.OverallSummary = "No summary provided"
+	}
+	return result, nil
+}
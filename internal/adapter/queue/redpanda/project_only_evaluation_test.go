@@ -0,0 +1,67 @@
+package redpanda
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// projectOnlyTestAI answers every prompt with refine, and records the prompts.
+type projectOnlyTestAI struct {
+	prompts []string
+	refine  string
+}
+
+func (a *projectOnlyTestAI) Embed(_ domain.Context, _ []string) ([][]float32, error) {
+	return nil, nil
+}
+
+func (a *projectOnlyTestAI) ChatJSON(_ domain.Context, _ string, _ string, _ int) (string, error) {
+	return "", nil
+}
+
+func (a *projectOnlyTestAI) ChatJSONWithRetry(_ domain.Context, prompt, _ string, _ int) (string, error) {
+	a.prompts = append(a.prompts, prompt)
+	if strings.Contains(prompt, "grading a coding-challenge deliverable") {
+		return `{"project_score":6,"project_feedback":"fast","overall_summary":"fast"}`, nil
+	}
+	return a.refine, nil
+}
+
+func (a *projectOnlyTestAI) CleanCoTResponse(_ domain.Context, response string) (string, error) {
+	return response, nil
+}
+
+func TestPerformProjectOnlyEvaluation(t *testing.T) {
+	ai := &projectOnlyTestAI{refine: `{"cv_match_rate":0.9,"cv_feedback":"invented","project_score":12,"project_feedback":"clean code","overall_summary":"pass"}`}
+	h := NewIntegratedEvaluationHandler(ai, nil)
+
+	res, err := h.PerformProjectOnlyEvaluation(context.Background(), "project", "brief", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.True(t, res.ProjectOnly)
+	assert.Equal(t, 10.0, res.ProjectScore, "clamped")
+	assert.Equal(t, "clean code", res.ProjectFeedback)
+	assert.Zero(t, res.CVMatchRate)
+	assert.Empty(t, res.CVFeedback)
+	require.Len(t, ai.prompts, 2)
+	for _, p := range ai.prompts {
+		assert.NotContains(t, p, "CV Content")
+	}
+	assert.Contains(t, ai.prompts[1], "No CV was submitted")
+}
+
+func TestPerformProjectOnlyEvaluation_FallsBackToFastPath(t *testing.T) {
+	ai := &projectOnlyTestAI{refine: `{"project_feedback":"no score"}`}
+	h := NewIntegratedEvaluationHandler(ai, nil)
+
+	res, err := h.PerformProjectOnlyEvaluation(context.Background(), "project", "brief", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.True(t, res.ProjectOnly)
+	assert.Equal(t, 6.0, res.ProjectScore)
+	assert.Equal(t, "fast", res.ProjectFeedback)
+}
@@ -0,0 +1,16 @@
+package redpanda
+
+import "github.com/fairyhunter13/ai-cv-evaluator/pkg/textx"
+
+// projectReadmePrompt points project scoring at the README/report section of
+// a multi-file submission, which is included in full while source files are
+// only summarized. It is empty for single-document projects.
+func projectReadmePrompt(projectContent string) string {
+	if !textx.HasProjectReadme(projectContent) {
+		return ""
+	}
+	return `
+The project content starts with the submission's README/report, included in full; the source files after it are summarized to their leading lines and declarations.
+Score documentation and explanation mainly from the README/report, and do not penalize code quality for lines omitted from the summaries.
+`
+}
@@ -0,0 +1,91 @@
+package redpanda
+
+import (
+	"context"
+)
+
+// promptTemplates holds the versions of the step prompts that prompt
+// experiments can choose between. Steps not listed use their inline prompt
+// at the version in promptVersions.
+var promptTemplates = map[string]map[string]string{
+	stepCVMatch: {
+		"1": cvMatchPromptV1,
+		"2": cvMatchPromptV2,
+	},
+}
+
+// cvMatchPromptV1 takes the CV content, the job description with its
+// context and the scoring rubric.
+const cvMatchPromptV1 = `You are an HR specialist and recruitment expert. Evaluate the candidate's CV against the job requirements using the standardized scoring rubric.
+
+CV Content:
+%s
+
+Job Description and Context:
+%s
+
+Scoring Rubric:
+%s
+
+Provide a concise analytical assessment focusing on:
+- Technical skills alignment with the backend + AI/LLM role
+- Experience level and impact of previous work
+- Relevant achievements and measurable outcomes
+- Cultural and collaboration fit (communication, learning mindset, teamwork)
+
+Return a short analysis (bullet list or structured paragraphs). Do NOT return JSON.`
+
+// cvMatchPromptV2 asks for every judgement to be anchored in quoted CV
+// evidence, to test whether it reduces unsupported scores.
+const cvMatchPromptV2 = `You are an HR specialist and recruitment expert. Evaluate the candidate's CV against the job requirements using the standardized scoring rubric.
+
+CV Content:
+%s
+
+Job Description and Context:
+%s
+
+Scoring Rubric:
+%s
+
+For each rubric parameter:
+1. Quote the CV lines that are evidence for it (or state that there are none).
+2. Judge how well that evidence meets the job requirements.
+3. Note the gaps a hiring manager would ask about.
+
+Cover technical skills alignment with the backend + AI/LLM role, experience level and impact, measurable achievements, and collaboration fit. Do not credit skills the CV does not show.
+
+Return a short analysis (bullet list or structured paragraphs). Do NOT return JSON.`
+
+// HasPromptVersion reports whether step has a prompt template at version,
+// which prompt experiments require of both their variants.
+func HasPromptVersion(step, version string) bool {
+	_, ok := promptTemplates[step][version]
+	return ok
+}
+
+type promptVersionsKey struct{}
+
+// withPromptVersions makes the steps in versions use the given prompt
+// versions for the AI calls made with ctx.
+func withPromptVersions(ctx context.Context, versions map[string]string) context.Context {
+	if len(versions) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, promptVersionsKey{}, versions)
+}
+
+// promptVersion returns the prompt version step uses with ctx.
+func promptVersion(ctx context.Context, step string) string {
+	if versions, ok := ctx.Value(promptVersionsKey{}).(map[string]string); ok {
+		if v, ok := versions[step]; ok && HasPromptVersion(step, v) {
+			return v
+		}
+	}
+	return promptVersions[step]
+}
+
+// promptTemplate returns the template of step at the version it uses with ctx.
+func promptTemplate(ctx context.Context, step string) string {
+	return promptTemplates[step][promptVersion(ctx, step)]
+}
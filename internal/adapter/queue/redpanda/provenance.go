@@ -0,0 +1,49 @@
+package redpanda
+
+import (
+	"context"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Evaluation steps recorded in result provenance.
+const (
+	stepCVExtraction    = "cv_extraction"
+	stepJobComparison   = "job_comparison"
+	stepCVMatch         = "cv_match"
+	stepProjectSummary  = "project_summary"
+	stepProjectEvaluate = "project_evaluation"
+	stepRefine          = "refine"
+	stepRefineCVOnly    = "refine_cv_only"
+	stepRefineProject   = "refine_project_only"
+	stepValidate        = "validate"
+	stepFastPath        = "fast_path"
+	stepFastPathCV      = "fast_path_cv_only"
+	stepFastPathProject = "fast_path_project_only"
+	stepJDTranslation   = "jd_translation"
+)
+
+// promptVersions are the template versions of the step prompts. Bump a
+// step's version whenever its prompt changes, so that score shifts can be
+// traced back to the prompt that produced them. Prompt experiments can run
+// another version of the steps in promptTemplates.
+var promptVersions = map[string]string{
+	stepCVExtraction:    "1",
+	stepJobComparison:   "1",
+	stepCVMatch:         "1",
+	stepProjectSummary:  "1",
+	stepProjectEvaluate: "1",
+	stepRefine:          "1",
+	stepRefineCVOnly:    "1",
+	stepRefineProject:   "1",
+	stepValidate:        "1",
+	stepFastPath:        "1",
+	stepFastPathCV:      "1",
+	stepFastPathProject: "1",
+	stepJDTranslation:   "1",
+}
+
+// withStep labels the AI calls made with ctx as part of step.
+func withStep(ctx context.Context, step string) context.Context {
+	return domain.WithEvaluationStep(ctx, step, promptVersion(ctx, step))
+}
@@ -0,0 +1,75 @@
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// provenanceAI records a served model for every chat call, like the real
+// client, and can fail the refinement step to force the fast path.
+type provenanceAI struct {
+	chainTestAI
+	failRefine bool
+}
+
+func (a *provenanceAI) ChatJSONWithRetry(ctx domain.Context, systemPrompt, userPrompt string, maxTokens int) (string, error) {
+	out, _ := a.chainTestAI.ChatJSONWithRetry(ctx, systemPrompt, userPrompt, maxTokens)
+	if a.failRefine && a.calls[len(a.calls)-1] == "refine" {
+		return "", errors.New("refine failed")
+	}
+	domain.RecordServedModel(ctx, "groq", "groq/llama-3.1-8b-instant")
+	return out, nil
+}
+
+func provenanceSteps(t *testing.T, p []domain.StepProvenance) []string {
+	t.Helper()
+	steps := make([]string, 0, len(p))
+	for _, s := range p {
+		assert.Equal(t, "groq", s.Provider)
+		assert.Equal(t, "groq/llama-3.1-8b-instant", s.Model)
+		assert.Equal(t, promptVersions[s.Step], s.PromptVersion)
+		steps = append(steps, s.Step)
+	}
+	return steps
+}
+
+func TestIntegratedEvaluation_RecordsStepProvenance(t *testing.T) {
+	t.Parallel()
+
+	ctx, trace := domain.WithModelTrace(context.Background())
+	h := NewIntegratedEvaluationHandler(&provenanceAI{}, nil)
+	_, err := h.PerformIntegratedEvaluation(ctx, "cv", "project", "jd", "case", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{stepCVMatch, stepProjectEvaluate, stepRefine}, provenanceSteps(t, trace.Provenance()))
+}
+
+func TestIntegratedEvaluation_FastPathReplacesChainProvenance(t *testing.T) {
+	t.Parallel()
+
+	ctx, trace := domain.WithModelTrace(context.Background())
+	h := NewIntegratedEvaluationHandler(&provenanceAI{failRefine: true}, nil)
+	_, err := h.PerformIntegratedEvaluation(ctx, "cv", "project", "jd", "case", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{stepFastPath}, provenanceSteps(t, trace.Provenance()))
+	assert.Equal(t, domain.FastPathFallback, trace.Provenance()[0].FastPath)
+}
+
+func TestIntegratedEvaluation_SelectedFastPathSkipsChain(t *testing.T) {
+	t.Parallel()
+
+	ctx, trace := domain.WithModelTrace(context.Background())
+	ctx = domain.WithFastPathReason(ctx, domain.FastPathInteractive)
+	ai := &provenanceAI{}
+	h := NewIntegratedEvaluationHandler(ai, nil)
+	_, err := h.PerformIntegratedEvaluation(ctx, "cv", "project", "jd", "case", "rubric", "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{stepFastPath}, provenanceSteps(t, trace.Provenance()))
+	assert.Equal(t, domain.FastPathInteractive, trace.Provenance()[0].FastPath)
+	assert.NotContains(t, ai.calls, "refine")
+}
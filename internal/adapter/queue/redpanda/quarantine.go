@@ -0,0 +1,70 @@
+package redpanda
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	adapterobs "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// defaultPoisonMaxAttempts is the number of failed decodes after which a
+// record is skipped when WithQuarantine is given no limit.
+const defaultPoisonMaxAttempts = 3
+
+// WithQuarantine captures records whose payload cannot be decoded in repo,
+// with their headers and decode error, and skips a record once it failed to
+// decode maxAttempts times (non-positive: 3) so it cannot wedge its
+// partition. A nil repo disables the quarantine.
+func (c *Consumer) WithQuarantine(repo domain.QuarantineRepository, maxAttempts int) *Consumer {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultPoisonMaxAttempts
+	}
+	c.quarantine = repo
+	c.poisonMaxAttempts = maxAttempts
+	return c
+}
+
+// quarantineRecord captures record, whose payload failed to decode with
+// decodeErr, and reports whether it should be skipped. Records that cannot be
+// captured are never skipped.
+func (c *Consumer) quarantineRecord(ctx context.Context, record *kgo.Record, decodeErr error) bool {
+	if c.quarantine == nil {
+		return false
+	}
+	headers := make(map[string]string, len(record.Headers))
+	for _, h := range record.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+	attempts, err := c.quarantine.Record(ctx, domain.QuarantinedMessage{
+		Topic:     record.Topic,
+		Partition: record.Partition,
+		Offset:    record.Offset,
+		Key:       record.Key,
+		Value:     record.Value,
+		Headers:   headers,
+		Error:     decodeErr.Error(),
+	})
+	if err != nil {
+		adapterobs.RecordPoisonMessage(record.Topic, "capture_failed")
+		slog.Error("failed to quarantine undecodable record",
+			slog.String("topic", record.Topic),
+			slog.Int("partition", int(record.Partition)),
+			slog.Int64("offset", record.Offset),
+			slog.Any("error", err))
+		return false
+	}
+	if attempts >= c.poisonMaxAttempts {
+		adapterobs.RecordPoisonMessage(record.Topic, "skipped")
+		slog.Warn("skipping poison record",
+			slog.String("topic", record.Topic),
+			slog.Int("partition", int(record.Partition)),
+			slog.Int64("offset", record.Offset),
+			slog.Int("attempts", attempts))
+		return true
+	}
+	adapterobs.RecordPoisonMessage(record.Topic, "quarantined")
+	return false
+}
@@ -0,0 +1,44 @@
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+)
+
+func TestConsumer_ProcessRecord_QuarantinesPoisonRecord(t *testing.T) {
+	repo := mocks.NewMockQuarantineRepository(t)
+	c := (&Consumer{}).WithQuarantine(repo, 0)
+	require.Equal(t, 3, c.poisonMaxAttempts)
+	rec := &kgo.Record{
+		Topic:     "evaluate-jobs",
+		Partition: 2,
+		Offset:    7,
+		Key:       []byte("job-1"),
+		Value:     []byte("{not json"),
+		Headers:   []kgo.RecordHeader{{Key: "job_id", Value: []byte("job-1")}},
+	}
+	captured := mock.MatchedBy(func(m domain.QuarantinedMessage) bool {
+		return m.Topic == "evaluate-jobs" && m.Partition == 2 && m.Offset == 7 &&
+			string(m.Value) == "{not json" && m.Headers["job_id"] == "job-1" && m.Error != ""
+	})
+
+	// Below the limit the failure is reported so the record is redelivered.
+	repo.EXPECT().Record(mock.Anything, captured).Return(2, nil).Once()
+	assert.ErrorContains(t, c.processRecord(context.Background(), rec), "unmarshal payload")
+
+	// At the limit the record is skipped.
+	repo.EXPECT().Record(mock.Anything, captured).Return(3, nil).Once()
+	assert.NoError(t, c.processRecord(context.Background(), rec))
+
+	// A record that cannot be captured is never skipped.
+	repo.EXPECT().Record(mock.Anything, mock.Anything).Return(0, assert.AnError).Once()
+	assert.Error(t, c.processRecord(context.Background(), rec))
+}
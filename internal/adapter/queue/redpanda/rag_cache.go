@@ -0,0 +1,99 @@
+package redpanda
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// RAGCache keeps the context retrieved for a posting, so that evaluations
+// against the same job description and rubric skip the embedding call and
+// the Qdrant searches until the entry expires. A nil cache disables caching.
+type RAGCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]ragCacheEntry
+}
+
+type ragCacheEntry struct {
+	context string
+	expires time.Time
+}
+
+// NewRAGCache creates a cache whose entries live for ttl. It returns nil when
+// ttl is not positive.
+func NewRAGCache(ttl time.Duration) *RAGCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &RAGCache{ttl: ttl, now: time.Now, entries: map[string]ragCacheEntry{}}
+}
+
+// Get returns the context cached under key.
+func (c *RAGCache) Get(key string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return "", false
+	}
+	return e.context, true
+}
+
+// Put caches context under key and drops expired entries.
+func (c *RAGCache) Put(key, context string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = ragCacheEntry{context: context, expires: now.Add(c.ttl)}
+}
+
+// Invalidate drops every entry. Call it after the RAG corpus changes.
+func (c *RAGCache) Invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string]ragCacheEntry{}
+}
+
+// WithRAGCache shares the context retrieved for a posting through c; rubric
+// is the job's scoring rubric, whose version is part of the cache key.
+func (h *IntegratedEvaluationHandler) WithRAGCache(c *RAGCache, rubric string) *IntegratedEvaluationHandler {
+	h.ragCache = c
+	h.rubricVersion = rubricVersion(rubric)
+	return h
+}
+
+// ragCacheKey identifies the context retrieved for a posting, given by its
+// job description and retrieval focus, under a rubric version.
+func ragCacheKey(jobDesc, focus, rubricVersion string) string {
+	sum := sha256.Sum256([]byte(jobDesc + "\x00" + focus + "\x00" + rubricVersion))
+	return hex.EncodeToString(sum[:])
+}
+
+// rubricVersion is the digest of a scoring rubric, so that editing the rubric
+// of a posting misses the context cached for the previous rubric.
+func rubricVersion(rubric string) string {
+	sum := sha256.Sum256([]byte(rubric))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,70 @@
+package redpanda
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+)
+
+func TestRAGCache_Expiry(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, NewRAGCache(0))
+	var none *RAGCache
+	none.Put("k", "v")
+	_, ok := none.Get("k")
+	assert.False(t, ok)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewRAGCache(time.Minute)
+	c.now = func() time.Time { return now }
+	c.Put("k", "context")
+	got, ok := c.Get("k")
+	require.True(t, ok)
+	assert.Equal(t, "context", got)
+
+	now = now.Add(time.Minute)
+	_, ok = c.Get("k")
+	assert.False(t, ok, "entries expire after the TTL")
+
+	c.Put("k", "context")
+	c.Invalidate()
+	_, ok = c.Get("k")
+	assert.False(t, ok)
+}
+
+func TestIntegratedEvaluationHandler_RAGCacheSkipsSearches(t *testing.T) {
+	var searches atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		searches.Add(1)
+		_, _ = w.Write([]byte(`{"result":[{"payload":{"text":"snippet"}}]}`))
+	}))
+	defer ts.Close()
+
+	cache := NewRAGCache(time.Minute)
+	newHandler := func(rubric string) *IntegratedEvaluationHandler {
+		return NewIntegratedEvaluationHandler(ragTestAI{}, qdrantcli.New(ts.URL, "")).WithRAGCache(cache, rubric)
+	}
+	ctx := context.Background()
+
+	first, err := newHandler("rubric v1").retrieveEnhancedRAGContext(ctx, "candidate one", "jd", "case")
+	require.NoError(t, err)
+	require.Equal(t, int32(2), searches.Load())
+
+	second, err := newHandler("rubric v1").retrieveEnhancedRAGContext(ctx, "candidate two", "jd", "case")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, int32(2), searches.Load(), "a posting's later jobs reuse its context")
+
+	_, err = newHandler("rubric v2").retrieveEnhancedRAGContext(ctx, "candidate two", "jd", "case")
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), searches.Load(), "a new rubric version misses the cache")
+}
@@ -90,10 +90,18 @@ func (m *threadSafeJobMock) CountWithFilters(ctx domain.Context, field, value st
 	return 1, nil
 }
 
+func (m *threadSafeJobMock) CountByFilter(ctx domain.Context, f domain.JobListFilter) (int64, error) {
+	return 1, nil
+}
+
 func (m *threadSafeJobMock) Create(ctx domain.Context, job domain.Job) (string, error) {
 	return "test-job-id", nil
 }
 
+func (m *threadSafeJobMock) AddSecurityNote(ctx domain.Context, id string, note string) error {
+	return nil
+}
+
 func (m *threadSafeJobMock) FindByIdempotencyKey(ctx domain.Context, key string) (domain.Job, error) {
 	return domain.Job{
 		ID:     "test-job-id",
@@ -112,6 +120,18 @@ func (m *threadSafeJobMock) GetAverageProcessingTime(ctx domain.Context) (float6
 	return 1.5, nil
 }
 
+func (m *threadSafeJobMock) Task(ctx domain.Context, id string) (domain.EvaluateTaskPayload, error) {
+	return domain.EvaluateTaskPayload{}, domain.ErrNotFound
+}
+
+func (m *threadSafeJobMock) GetMany(ctx domain.Context, ids []string) ([]domain.Job, error) {
+	jobs := make([]domain.Job, 0, len(ids))
+	for _, id := range ids {
+		jobs = append(jobs, domain.Job{ID: id, Status: domain.JobCompleted})
+	}
+	return jobs, nil
+}
+
 func (m *threadSafeJobMock) List(ctx domain.Context, offset, limit int) ([]domain.Job, error) {
 	return []domain.Job{
 		{
@@ -138,12 +158,20 @@ func (m *threadSafeJobMock) ListWithFilters(ctx domain.Context, offset, limit in
 	}, nil
 }
 
+func (m *threadSafeJobMock) ListByCursor(ctx domain.Context, f domain.JobListFilter) ([]domain.Job, error) {
+	return m.ListWithFilters(ctx, 0, f.Limit, f.Search, f.Status)
+}
+
 // threadSafeResultMock is a simple mock for ResultRepository that is thread-safe
 type threadSafeResultMock struct {
 	sync.Mutex
 	resultCh chan struct{}
 }
 
+func (m *threadSafeResultMock) UpsertFenced(ctx domain.Context, result domain.Result, _ int64) error {
+	return m.Upsert(ctx, result)
+}
+
 func (m *threadSafeResultMock) Upsert(ctx domain.Context, result domain.Result) error {
 	// Signal for each result processed
 	select {
@@ -166,6 +194,15 @@ func (m *threadSafeResultMock) GetByJobID(ctx domain.Context, jobID string) (dom
 	}, nil
 }
 
+func (m *threadSafeResultMock) GetByJobIDs(ctx domain.Context, jobIDs []string) ([]domain.Result, error) {
+	results := make([]domain.Result, 0, len(jobIDs))
+	for _, id := range jobIDs {
+		res, _ := m.GetByJobID(ctx, id)
+		results = append(results, res)
+	}
+	return results, nil
+}
+
 // --- Test helpers --------------------------------------------------------------
 
 // generateUniqueTransactionalID generates a unique transactional ID for testing
@@ -0,0 +1,84 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// maxRepromptOutput caps how much of an unparsable response is quoted back
+// to the model.
+const maxRepromptOutput = 8000
+
+const repromptMessage = `Your previous response could not be parsed as JSON: %v
+
+Previous response:
+%s
+
+Return only valid JSON matching the schema in the instructions above, with no prose, reasoning or code fences.`
+
+// rememberPrompt records prompt as the last one sent for the step of ctx, so
+// a response to it that does not parse can be corrected.
+func (h *IntegratedEvaluationHandler) rememberPrompt(ctx context.Context, prompt string) {
+	h.promptsMu.Lock()
+	defer h.promptsMu.Unlock()
+	if h.prompts == nil {
+		h.prompts = make(map[string]string)
+	}
+	h.prompts[domain.EvaluationStepFrom(ctx)] = prompt
+}
+
+func (h *IntegratedEvaluationHandler) lastPrompt(ctx context.Context) string {
+	h.promptsMu.Lock()
+	defer h.promptsMu.Unlock()
+	return h.prompts[domain.EvaluationStepFrom(ctx)]
+}
+
+// reprompt sends one corrective request to the model that produced response,
+// quoting it and parseErr, and returns the cleaned corrected JSON. It reports
+// false when the step's prompt is unknown or the correction does not parse
+// either, leaving the caller's other fallbacks to run.
+func (h *IntegratedEvaluationHandler) reprompt(ctx context.Context, response string, parseErr error, jobID string) (string, bool) {
+	prompt := h.lastPrompt(ctx)
+	if h.ai == nil || prompt == "" {
+		return "", false
+	}
+	if len(response) > maxRepromptOutput {
+		response = response[:maxRepromptOutput]
+	}
+	corrected, err := h.ai.ChatJSON(withServingModel(ctx), prompt, fmt.Sprintf(repromptMessage, parseErr, response), defaultMaxTokens(prompt))
+	if err != nil {
+		observability.RecordJSONReprompt("failed")
+		slog.Warn("corrective re-prompt failed", slog.String("job_id", jobID), slog.Any("error", err))
+		return "", false
+	}
+	cleaned, err := h.cleanJSONResponse(corrected)
+	if err != nil {
+		observability.RecordJSONReprompt("failed")
+		slog.Warn("corrective re-prompt returned invalid JSON", slog.String("job_id", jobID), slog.Any("error", err))
+		return "", false
+	}
+	observability.RecordJSONReprompt("fixed")
+	slog.Info("fixed malformed JSON response with a corrective re-prompt", slog.String("job_id", jobID))
+	return cleaned, true
+}
+
+// withServingModel pins the AI calls made with the returned ctx to the model
+// that last served the step of ctx. ctx is returned as is when that model is
+// not known.
+func withServingModel(ctx context.Context) context.Context {
+	step := domain.EvaluationStepFrom(ctx)
+	prov := domain.ProvenanceFrom(ctx)
+	for i := len(prov) - 1; i >= 0; i-- {
+		if prov[i].Step != step {
+			continue
+		}
+		ov := domain.EvaluationOverridesFrom(ctx)
+		ov.Provider, ov.Model, ov.Challenger = prov[i].Provider, prov[i].Model, false
+		return domain.WithEvaluationOverrides(ctx, ov)
+	}
+	return ctx
+}
@@ -0,0 +1,62 @@
+package redpanda
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// repromptAI answers the evaluation with invalid JSON and records the
+// corrective request.
+type repromptAI struct {
+	fakeAI
+	correction  string
+	overrides   domain.EvaluationOverrides
+	system, msg string
+	cotCalls    int
+}
+
+func (f *repromptAI) ChatJSON(ctx domain.Context, system, user string, _ int) (string, error) {
+	f.overrides, f.system, f.msg = domain.EvaluationOverridesFrom(ctx), system, user
+	return f.correction, nil
+}
+
+func (f *repromptAI) CleanCoTResponse(_ domain.Context, _ string) (string, error) {
+	f.cotCalls++
+	return `{"from":"cot"}`, nil
+}
+
+func TestCleanJSONResponse_RepromptsServingModel(t *testing.T) {
+	ai := &repromptAI{fakeAI: fakeAI{chatRetryResp: "I think the score is high"}, correction: "```json\n{\"score\":4}\n```"}
+	h := NewIntegratedEvaluationHandler(ai, nil)
+	ctx, _ := domain.WithModelTrace(context.Background())
+	ctx = withStep(ctx, stepCVMatch)
+	domain.RecordServedModel(ctx, "groq", "llama-3.3-70b-versatile")
+
+	resp, err := h.performStableEvaluation(ctx, "Return JSON {\"score\": number}", "job-1")
+	if err != nil {
+		t.Fatalf("evaluation: %v", err)
+	}
+	got, err := h.cleanJSONResponseWithCoTFallback(ctx, resp, "job-1")
+	if err != nil || got != `{"score":4}` {
+		t.Fatalf("got %q err=%v", got, err)
+	}
+	if ai.overrides.Provider != "groq" || ai.overrides.Model != "llama-3.3-70b-versatile" {
+		t.Fatalf("corrective request not pinned to the serving model: %+v", ai.overrides)
+	}
+	if ai.system != "Return JSON {\"score\": number}" || !strings.Contains(ai.msg, "I think the score is high") {
+		t.Fatalf("corrective request system=%q user=%q", ai.system, ai.msg)
+	}
+	if ai.cotCalls != 0 {
+		t.Fatalf("CoT cleaning ran %d times after a successful re-prompt", ai.cotCalls)
+	}
+
+	// A correction that does not parse either falls through to CoT cleaning.
+	ai.correction = "still not JSON"
+	got, err = h.cleanJSONResponseWithCoTFallback(ctx, resp, "job-1")
+	if err != nil || got != `{"from":"cot"}` || ai.cotCalls != 1 {
+		t.Fatalf("got %q err=%v cot=%d", got, err, ai.cotCalls)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/notify"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
 )
 
@@ -24,6 +25,11 @@ type RetryManager struct {
 	dlqProducer retryProducer
 	jobs        domain.JobRepository
 	config      domain.RetryConfig
+
+	notifier     domain.Notifier
+	dlqThreshold int
+	dlqWindow    time.Duration
+	dlqMoves     *notify.WindowCounter
 }
 
 // NewRetryManager creates a new retry manager
@@ -36,6 +42,18 @@ func NewRetryManager(producer, dlqProducer retryProducer, jobs domain.JobReposit
 	}
 }
 
+// WithDLQAlert raises a dlq_growth notification on n whenever this process
+// has moved at least threshold jobs to the DLQ within window. A nil notifier
+// or non-positive threshold or window leaves alerts disabled.
+func (rm *RetryManager) WithDLQAlert(n domain.Notifier, threshold int, window time.Duration) *RetryManager {
+	if n == nil || threshold <= 0 || window <= 0 {
+		return rm
+	}
+	rm.notifier, rm.dlqThreshold, rm.dlqWindow = n, threshold, window
+	rm.dlqMoves = notify.NewWindowCounter(window)
+	return rm
+}
+
 // RetryJob attempts to retry a failed job
 func (rm *RetryManager) RetryJob(ctx context.Context, jobID string, retryInfo *domain.RetryInfo, payload domain.EvaluateTaskPayload) error {
 	// For upstream rate-limit and timeout failures, bypass immediate inline
@@ -184,6 +202,15 @@ func (rm *RetryManager) moveToDLQ(ctx context.Context, jobID string, payload dom
 		slog.Int("attempt_count", retryInfo.AttemptCount),
 		slog.String("retry_status", string(retryInfo.RetryStatus)))
 
+	if rm.dlqMoves != nil {
+		if n := rm.dlqMoves.Add(time.Now()); n >= rm.dlqThreshold {
+			rm.notifier.Notify(ctx, domain.Notification{
+				Event: domain.NotifyDLQGrowth,
+				Data:  map[string]any{"Count": n, "Window": rm.dlqWindow.String()},
+			})
+		}
+	}
+
 	return nil
 }
 
@@ -60,6 +60,11 @@ func (r *fakeJobRepo) Get(_ domain.Context, id string) (domain.Job, error) {
 	}
 	return domain.Job{ID: id}, nil
 }
+func (*fakeJobRepo) AddSecurityNote(domain.Context, string, string) error   { return nil }
+func (*fakeJobRepo) GetMany(domain.Context, []string) ([]domain.Job, error) { return nil, nil }
+func (*fakeJobRepo) Task(domain.Context, string) (domain.EvaluateTaskPayload, error) {
+	return domain.EvaluateTaskPayload{}, domain.ErrNotFound
+}
 func (*fakeJobRepo) FindByIdempotencyKey(domain.Context, string) (domain.Job, error) {
 	return domain.Job{}, nil
 }
@@ -74,6 +79,12 @@ func (*fakeJobRepo) ListWithFilters(domain.Context, int, int, string, string) ([
 func (*fakeJobRepo) CountWithFilters(domain.Context, string, string) (int64, error) {
 	return 0, nil
 }
+func (*fakeJobRepo) ListByCursor(domain.Context, domain.JobListFilter) ([]domain.Job, error) {
+	return nil, nil
+}
+func (*fakeJobRepo) CountByFilter(domain.Context, domain.JobListFilter) (int64, error) {
+	return 0, nil
+}
 func (*fakeJobRepo) GetAverageProcessingTime(domain.Context) (float64, error) { return 0, nil }
 
 func TestRetryManager_MoveToDLQ_SetsStatusAndEnqueues(t *testing.T) {
@@ -106,6 +117,30 @@ func TestRetryManager_MoveToDLQ_SetsStatusAndEnqueues(t *testing.T) {
 	}
 }
 
+type fakeNotifier struct{ got []domain.Notification }
+
+func (n *fakeNotifier) Notify(_ domain.Context, ev domain.Notification) { n.got = append(n.got, ev) }
+
+func TestRetryManager_MoveToDLQ_AlertsOnGrowth(t *testing.T) {
+	ctx := context.Background()
+	prod := &fakeRetryProducer{}
+	jobs := &fakeJobRepo{jobs: make(map[string]domain.Job)}
+	n := &fakeNotifier{}
+	rm := NewRetryManager(prod, prod, jobs, domain.DefaultRetryConfig()).WithDLQAlert(n, 2, time.Minute)
+
+	for _, id := range []string{"job-1", "job-2", "job-3"} {
+		if err := rm.moveToDLQ(ctx, id, domain.EvaluateTaskPayload{JobID: id}, &domain.RetryInfo{}, "reason"); err != nil {
+			t.Fatalf("moveToDLQ returned error: %v", err)
+		}
+	}
+	if len(n.got) != 2 || n.got[0].Event != domain.NotifyDLQGrowth || n.got[0].Data["Count"] != 2 || n.got[1].Data["Count"] != 3 {
+		t.Fatalf("unexpected notifications: %+v", n.got)
+	}
+	if NewRetryManager(prod, prod, jobs, domain.DefaultRetryConfig()).WithDLQAlert(nil, 2, time.Minute).dlqMoves != nil {
+		t.Fatalf("alerts must stay disabled without a notifier")
+	}
+}
+
 func TestRetryManager_RequeueFromDLQ_UpdatesStatusAndEnqueues(t *testing.T) {
 	ctx := context.Background()
 	prod := &fakeRetryProducer{}
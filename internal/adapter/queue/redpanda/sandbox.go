@@ -0,0 +1,100 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/anonymize"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/promptguard"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/safety"
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/envelope"
+)
+
+// Sandbox runs job tasks through the evaluation chain of HandleEvaluate
+// without its side effects: no job status changes, no stored result,
+// checkpoint, security note, score statistic or similarity entry, and no
+// tokens charged to the tenant. Admins use it to replay past jobs.
+type Sandbox struct {
+	ai   domain.AIClient
+	q    *qdrantcli.Client
+	opts EvaluateOptions
+}
+
+// NewSandbox creates a Sandbox that screens documents with guard, filters
+// feedback with filter and bounds evaluations of tasks without an SLA by
+// sla; nil guard or filter disables that step and 0 means
+// defaultEvaluationSLA.
+func NewSandbox(ai domain.AIClient, q *qdrantcli.Client, guard *promptguard.Guard, filter *safety.Filter, sla time.Duration) *Sandbox {
+	return &Sandbox{ai: ai, q: q, opts: EvaluateOptions{PromptGuard: guard, SafetyFilter: filter, SLA: sla}}
+}
+
+// WithJDLanguage applies the worker's JDLanguage* mode to replays.
+func (s *Sandbox) WithJDLanguage(mode string) *Sandbox {
+	s.opts.JDLanguage = strings.ToLower(strings.TrimSpace(mode))
+	return s
+}
+
+// Evaluate evaluates p on the uploaded texts cvText and projectText the way
+// the worker would and returns the result. Results of tenants with a public
+// key are encrypted for it, as stored results are.
+func (s *Sandbox) Evaluate(ctx context.Context, p domain.EvaluateTaskPayload, cvText, projectText string) (domain.Result, error) {
+	tracer := otel.Tracer("queue.handler")
+	ctx, span := tracer.Start(ctx, "Sandbox.Evaluate")
+	defer span.End()
+
+	if s.ai == nil {
+		return domain.Result{}, fmt.Errorf("AI client is nil")
+	}
+	ctx, cancel := context.WithTimeout(ctx, evaluationSLA(p, s.opts))
+	defer cancel()
+	ctx, models := domain.WithModelTrace(ctx)
+	ctx = domain.WithEvaluationJob(ctx, p.JobID)
+	if p.AllowPaidFallback {
+		ctx = domain.WithPaidFallbackOptIn(ctx, true)
+	}
+	ctx = domain.WithEvaluationOverrides(ctx, p.Overrides)
+	if p.Priority == domain.PriorityInteractive {
+		ctx = domain.WithFastPathReason(ctx, domain.FastPathInteractive)
+	}
+
+	// Findings are not recorded as security notes; the job has them from
+	// its original run.
+	cvText, _ = s.opts.PromptGuard.Apply(cvText)
+	projectText, _ = s.opts.PromptGuard.Apply(projectText)
+	if p.Overrides.Anonymize {
+		cvText = anonymize.Text(cvText)
+		projectText = anonymize.Text(projectText)
+	}
+	var language *domain.LanguageMismatch
+	if !p.ProjectOnly {
+		p.JobDescription, language = checkJDLanguage(ctx, s.ai, s.opts.JDLanguage, p.JobID, p.JobDescription, cvText)
+	}
+
+	handler := NewIntegratedEvaluationHandler(s.ai, s.q).
+		WithScoringWeights(p.ScoringWeights).
+		WithRubric(p.Rubric).
+		WithFeedbackFormat(p.FeedbackFormat).
+		WithFeedbackLanguage(p.FeedbackLanguage)
+	result, err := evaluateWithRetries(ctx, handler, p, cvText, projectText, models)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("evaluation failed after %d attempts: %w", maxEvaluationAttempts, err)
+	}
+	result.ScoringWeights = p.ScoringWeights
+	result.Provenance = models.Provenance()
+	result.Language = language
+	result = filterFeedback(result, s.opts.SafetyFilter, p.JobID)
+	if p.ResultPublicKey != "" {
+		encrypted, err := envelope.Encrypt(result, p.ResultPublicKey)
+		if err != nil {
+			return domain.Result{}, fmt.Errorf("encrypt result: %w", err)
+		}
+		result = encrypted
+	}
+	return result, nil
+}
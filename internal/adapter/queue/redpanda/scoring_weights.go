@@ -0,0 +1,124 @@
+package redpanda
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// WithScoringWeights makes the handler generate prompts with, and aggregate
+// the final scores from, the given rubric weights. Nil keeps the defaults.
+func (h *IntegratedEvaluationHandler) WithScoringWeights(w domain.ScoringWeights) *IntegratedEvaluationHandler {
+	h.weights = w
+	return h
+}
+
+// WithRubric sets the rubric sections the scoring weights apply to, as
+// validated at submission. Nil keeps the default rubric.
+func (h *IntegratedEvaluationHandler) WithRubric(sections []domain.RubricSection) *IntegratedEvaluationHandler {
+	h.rubric = sections
+	return h
+}
+
+// sections returns the rubric sections with the scoring weights applied.
+func (h *IntegratedEvaluationHandler) sections() []domain.RubricSection {
+	if len(h.rubric) == 0 {
+		return h.weights.Rubric()
+	}
+	return h.weights.Apply(h.rubric)
+}
+
+// weightOf returns the weight in percent of a rubric section. A default
+// section the active rubric lacks, as named by the built-in prompts, keeps
+// its default weight; customWeightsPrompt states the weights that apply.
+func (h *IntegratedEvaluationHandler) weightOf(section string) string {
+	for _, sections := range [][]domain.RubricSection{h.sections(), domain.DefaultRubric} {
+		for _, s := range sections {
+			if s.Name == section {
+				return strconv.FormatFloat(s.Weight, 'f', -1, 64)
+			}
+		}
+	}
+	return "0"
+}
+
+// customWeightsPrompt lists the custom weights of the given rubric groups.
+// It is empty when the defaults apply.
+func (h *IntegratedEvaluationHandler) customWeightsPrompt(groups ...string) string {
+	if h.weights == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\nCustom scoring weights (these replace any weights stated elsewhere):\n")
+	for _, g := range groups {
+		for _, s := range h.sections() {
+			if s.Group == g {
+				fmt.Fprintf(&b, "- %s (%s): %s%%\n", s.Label, s.Name, h.weightOf(s.Name))
+			}
+		}
+	}
+	return b.String()
+}
+
+// parameterScoresPrompt extends customWeightsPrompt for JSON-producing steps
+// by asking for per-parameter scores, so the final scores can be aggregated
+// with the custom weights instead of trusting the model's arithmetic.
+func (h *IntegratedEvaluationHandler) parameterScoresPrompt(groups ...string) string {
+	if h.weights == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(h.customWeightsPrompt(groups...))
+	for _, g := range groups {
+		fmt.Fprintf(&b, "Also include \"%s_parameter_scores\": an object mapping each %s parameter key above to its 1-5 score.\n", g, g)
+	}
+	return b.String()
+}
+
+// aggregateWeighted returns the weighted average (1-5) of the group's
+// parameter scores. It reports false when a weighted parameter is missing.
+func (h *IntegratedEvaluationHandler) aggregateWeighted(group string, scores map[string]float64) (float64, bool) {
+	var sum, total float64
+	for _, s := range h.sections() {
+		if s.Group != group || s.Weight == 0 {
+			continue
+		}
+		v, ok := scores[s.Name]
+		if !ok {
+			return 0, false
+		}
+		sum += s.Weight * min(max(v, 1), 5)
+		total += s.Weight
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return sum / total, true
+}
+
+// applyScoringWeights recomputes the CV match rate and project score from
+// per-parameter scores when custom weights are set, following the rubric:
+// the CV average is divided by 5 and the project average doubled. Scores the
+// model computed itself are kept when parameter scores are missing.
+func (h *IntegratedEvaluationHandler) applyScoringWeights(res *domain.Result, cvScores, projectScores map[string]float64) {
+	if h.weights == nil {
+		return
+	}
+	if cvScores != nil {
+		if avg, ok := h.aggregateWeighted(domain.RubricGroupCV, cvScores); ok {
+			res.CVMatchRate = avg / 5
+		} else {
+			slog.Warn("incomplete CV parameter scores; keeping model CV match rate", slog.String("job_id", res.JobID))
+		}
+	}
+	if projectScores != nil {
+		if avg, ok := h.aggregateWeighted(domain.RubricGroupProject, projectScores); ok {
+			res.ProjectScore = min(max(avg*2, 1), 10)
+		} else {
+			slog.Warn("incomplete project parameter scores; keeping model project score", slog.String("job_id", res.JobID))
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package redpanda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestScoringWeights_DefaultPromptsUnchanged(t *testing.T) {
+	h := NewIntegratedEvaluationHandler(nil, nil)
+	prompt := h.generateProjectEvaluationPrompt("project", "study", "rubric")
+	assert.Contains(t, prompt, "**1. Correctness (30% weight):**")
+	assert.Contains(t, prompt, `"weight": 10,`)
+	assert.Contains(t, h.generateScoringPrompt("cv", "project", "jd", "study", "rubric"), "**1. Technical Skills Match (40% weight):**")
+	assert.Empty(t, h.parameterScoresPrompt(domain.RubricGroupCV))
+}
+
+func TestScoringWeights_CustomPromptAndAggregation(t *testing.T) {
+	weights := domain.ScoringWeights{
+		"technical_skills_match": 40, "experience_level": 25, "relevant_achievements": 20, "cultural_collaboration_fit": 15,
+		"correctness": 60, "code_quality_structure": 10, "resilience_error_handling": 10, "documentation_explanation": 10, "creativity_bonus": 10,
+	}
+	ai := &projectOnlyTestAI{refine: `{"project_score":3,"project_feedback":"ok","overall_summary":"ok",` +
+		`"project_parameter_scores":{"correctness":5,"code_quality_structure":3,"resilience_error_handling":3,"documentation_explanation":3,"creativity_bonus":3}}`}
+	h := NewIntegratedEvaluationHandler(ai, nil).WithScoringWeights(weights)
+
+	res, err := h.PerformProjectOnlyEvaluation(context.Background(), "project", "brief", "rubric", "job-1")
+	require.NoError(t, err)
+	// (60*5 + 40*3) / 100 = 4.2, doubled to the 1-10 scale.
+	assert.InDelta(t, 8.4, res.ProjectScore, 1e-9)
+	require.Len(t, ai.prompts, 2)
+	assert.Contains(t, ai.prompts[0], "**1. Correctness (60% weight):**")
+	assert.Contains(t, ai.prompts[1], "- Correctness (correctness): 60%")
+	assert.Contains(t, ai.prompts[1], `"project_parameter_scores"`)
+}
+
+func TestScoringWeights_IncompleteParameterScoresKeepModelScore(t *testing.T) {
+	h := NewIntegratedEvaluationHandler(nil, nil).WithScoringWeights(domain.ScoringWeights{
+		"technical_skills_match": 100, "experience_level": 0, "relevant_achievements": 0, "cultural_collaboration_fit": 0,
+	})
+	res := domain.Result{CVMatchRate: 0.5}
+	h.applyScoringWeights(&res, map[string]float64{"experience_level": 5}, nil)
+	assert.Equal(t, 0.5, res.CVMatchRate)
+
+	h.applyScoringWeights(&res, map[string]float64{"technical_skills_match": 4}, nil)
+	assert.InDelta(t, 0.8, res.CVMatchRate, 1e-9, "zero-weight parameters are not required")
+}
+
+func TestScoringWeights_CustomRubric(t *testing.T) {
+	rubric := []domain.RubricSection{
+		{Name: "leadership", Group: domain.RubricGroupCV, Label: "Leadership", Weight: 60},
+		{Name: "domain_knowledge", Group: domain.RubricGroupCV, Label: "Domain Knowledge", Weight: 40},
+	}
+	h := NewIntegratedEvaluationHandler(nil, nil).
+		WithScoringWeights(domain.ScoringWeights{"leadership": 75, "domain_knowledge": 25}).
+		WithRubric(rubric)
+
+	prompt := h.parameterScoresPrompt(domain.RubricGroupCV)
+	assert.Contains(t, prompt, "- Leadership (leadership): 75%")
+	assert.Contains(t, prompt, "- Domain Knowledge (domain_knowledge): 25%")
+	assert.NotContains(t, prompt, "technical_skills_match")
+
+	res := domain.Result{CVMatchRate: 0.1}
+	h.applyScoringWeights(&res, map[string]float64{"leadership": 5, "domain_knowledge": 1}, nil)
+	// (75*5 + 25*1) / 100 = 4, divided by 5.
+	assert.InDelta(t, 0.8, res.CVMatchRate, 1e-9)
+}
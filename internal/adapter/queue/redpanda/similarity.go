@@ -0,0 +1,116 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	adapterobs "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// SubmissionsCollection is the Qdrant collection holding one embedding per
+// evaluated project report.
+const SubmissionsCollection = "project_submissions"
+
+// similarityMaxChars bounds the project text that is embedded so long reports
+// stay within the embeddings model's input limit.
+const similarityMaxChars = 24000
+
+// SubmissionStore is the subset of the Qdrant client used by SimilarityIndex.
+type SubmissionStore interface {
+	SearchFiltered(ctx context.Context, collection string, vector []float32, topK int, filter map[string]any) ([]map[string]any, error)
+	UpsertPoints(ctx context.Context, collection string, vectors [][]float32, payloads []map[string]any, ids []any) error
+}
+
+// SimilarityIndex compares project reports with the earlier submissions of
+// the same tenant and records every report it checks.
+type SimilarityIndex struct {
+	ai        domain.AIClient
+	store     SubmissionStore
+	threshold float64
+	topK      int
+}
+
+// NewSimilarityIndex returns an index that flags reports whose cosine
+// similarity to an earlier submission is at least threshold, referencing up
+// to topK of them. It returns nil, disabling detection, when store is nil.
+func NewSimilarityIndex(ai domain.AIClient, store SubmissionStore, threshold float64, topK int) *SimilarityIndex {
+	if store == nil {
+		return nil
+	}
+	if topK <= 0 {
+		topK = 3
+	}
+	return &SimilarityIndex{ai: ai, store: store, threshold: threshold, topK: topK}
+}
+
+// Check embeds the project text of jobID, searches the tenant's earlier
+// submissions for similar ones and then adds the text to the index. The
+// point ID is derived from the job ID, so a redelivered job replaces its own
+// entry and never matches itself.
+func (s *SimilarityIndex) Check(ctx context.Context, jobID, tenantID, text string) (*domain.SimilarityReport, error) {
+	if len(text) > similarityMaxChars {
+		text = text[:similarityMaxChars]
+	}
+	vectors, err := s.ai.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, fmt.Errorf("op=similarity.embed: %w", err)
+	}
+	if len(vectors) == 0 || len(vectors[0]) == 0 {
+		return nil, fmt.Errorf("op=similarity.embed: empty embedding")
+	}
+	pointID := uuid.NewSHA1(uuid.NameSpaceOID, []byte(jobID)).String()
+	filter := map[string]any{
+		"must":     []any{map[string]any{"key": "tenant_id", "match": map[string]any{"value": tenantID}}},
+		"must_not": []any{map[string]any{"has_id": []any{pointID}}},
+	}
+	hits, err := s.store.SearchFiltered(ctx, SubmissionsCollection, vectors[0], s.topK, filter)
+	if err != nil {
+		return nil, fmt.Errorf("op=similarity.search: %w", err)
+	}
+	report := &domain.SimilarityReport{Matches: []domain.SimilarMatch{}}
+	for _, h := range hits {
+		score, _ := h["score"].(float64)
+		payload, _ := h["payload"].(map[string]any)
+		matchID, _ := payload["job_id"].(string)
+		if score < s.threshold || matchID == "" {
+			continue
+		}
+		report.Matches = append(report.Matches, domain.SimilarMatch{JobID: matchID, Score: score})
+	}
+	report.Flagged = len(report.Matches) > 0
+
+	payload := map[string]any{"job_id": jobID, "tenant_id": tenantID}
+	if err := s.store.UpsertPoints(ctx, SubmissionsCollection, vectors, []map[string]any{payload}, []any{pointID}); err != nil {
+		return nil, fmt.Errorf("op=similarity.upsert: %w", err)
+	}
+	return report, nil
+}
+
+// checkSimilarity runs idx over the project text of a job. Failures are
+// logged and leave the result without a similarity report; detection never
+// fails an evaluation.
+func checkSimilarity(ctx context.Context, idx *SimilarityIndex, payload domain.EvaluateTaskPayload, text string) *domain.SimilarityReport {
+	if idx == nil || payload.CVOnly || text == "" {
+		return nil
+	}
+	report, err := idx.Check(ctx, payload.JobID, payload.TenantID, text)
+	if err != nil {
+		adapterobs.RecordSimilarityCheck("error")
+		slog.Warn("project similarity check failed", slog.String("job_id", payload.JobID), slog.Any("error", err))
+		return nil
+	}
+	if !report.Flagged {
+		adapterobs.RecordSimilarityCheck("clear")
+		return report
+	}
+	adapterobs.RecordSimilarityCheck("flagged")
+	slog.Warn("project report highly similar to earlier submissions",
+		slog.String("job_id", payload.JobID),
+		slog.Int("matches", len(report.Matches)),
+		slog.Float64("top_score", report.Matches[0].Score))
+	return report
+}
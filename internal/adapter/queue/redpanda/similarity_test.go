@@ -0,0 +1,80 @@
+package redpanda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// fakeSubmissionStore answers searches with hits and records upserts.
+type fakeSubmissionStore struct {
+	hits      []map[string]any
+	searchErr error
+	filters   []map[string]any
+	upserted  []map[string]any
+	ids       []any
+}
+
+func (s *fakeSubmissionStore) SearchFiltered(_ context.Context, collection string, _ []float32, _ int, filter map[string]any) ([]map[string]any, error) {
+	if collection != SubmissionsCollection {
+		return nil, errors.New("unexpected collection " + collection)
+	}
+	s.filters = append(s.filters, filter)
+	return s.hits, s.searchErr
+}
+
+func (s *fakeSubmissionStore) UpsertPoints(_ context.Context, _ string, _ [][]float32, payloads []map[string]any, ids []any) error {
+	s.upserted = append(s.upserted, payloads...)
+	s.ids = append(s.ids, ids...)
+	return nil
+}
+
+func TestSimilarityIndex_Check(t *testing.T) {
+	store := &fakeSubmissionStore{hits: []map[string]any{
+		{"score": 0.97, "payload": map[string]any{"job_id": "job-0"}},
+		{"score": 0.95, "payload": map[string]any{"job_id": "job-2"}},
+		{"score": 0.6, "payload": map[string]any{"job_id": "job-3"}},
+	}}
+	idx := NewSimilarityIndex(&stubAIForHandle{}, store, 0.9, 3)
+
+	report, err := idx.Check(context.Background(), "job-1", "acme", "project text")
+	require.NoError(t, err)
+	assert.True(t, report.Flagged)
+	assert.Equal(t, []domain.SimilarMatch{{JobID: "job-0", Score: 0.97}, {JobID: "job-2", Score: 0.95}}, report.Matches)
+
+	// The search stays within the tenant and excludes the job's own point,
+	// which is then indexed under a stable ID.
+	require.Len(t, store.filters, 1)
+	must := store.filters[0]["must"].([]any)
+	assert.Equal(t, map[string]any{"value": "acme"}, must[0].(map[string]any)["match"])
+	mustNot := store.filters[0]["must_not"].([]any)
+	assert.Equal(t, store.ids, mustNot[0].(map[string]any)["has_id"])
+	assert.Equal(t, []map[string]any{{"job_id": "job-1", "tenant_id": "acme"}}, store.upserted)
+
+	_, err = idx.Check(context.Background(), "job-1", "acme", "project text")
+	require.NoError(t, err)
+	assert.Equal(t, store.ids[0], store.ids[1])
+}
+
+func TestCheckSimilarity(t *testing.T) {
+	ctx := context.Background()
+	payload := domain.EvaluateTaskPayload{JobID: "job-1", TenantID: "acme"}
+
+	idx := NewSimilarityIndex(&stubAIForHandle{}, &fakeSubmissionStore{}, 0.9, 3)
+	report := checkSimilarity(ctx, idx, payload, "project text")
+	require.NotNil(t, report)
+	assert.False(t, report.Flagged)
+	assert.Empty(t, report.Matches)
+
+	// Failures and CV-only jobs leave the result without a report.
+	failing := NewSimilarityIndex(&stubAIForHandle{}, &fakeSubmissionStore{searchErr: errors.New("down")}, 0.9, 3)
+	assert.Nil(t, checkSimilarity(ctx, failing, payload, "project text"))
+	assert.Nil(t, checkSimilarity(ctx, idx, domain.EvaluateTaskPayload{JobID: "job-1", CVOnly: true}, ""))
+	assert.Nil(t, checkSimilarity(ctx, nil, payload, "project text"))
+	assert.Nil(t, NewSimilarityIndex(&stubAIForHandle{}, nil, 0.9, 3))
+}
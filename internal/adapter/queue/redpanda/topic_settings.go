@@ -0,0 +1,214 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// TopicSettings are the settings a topic is expected to have. Zero values
+// leave the corresponding setting as it is.
+type TopicSettings struct {
+	// Partitions is the minimum partition count; partitions are never removed.
+	Partitions      int32
+	Retention       time.Duration
+	MaxMessageBytes int64
+	CleanupPolicy   string
+}
+
+// configs returns the topic configs managed by s.
+func (s TopicSettings) configs() map[string]string {
+	out := map[string]string{}
+	if s.Retention > 0 {
+		out["retention.ms"] = strconv.FormatInt(s.Retention.Milliseconds(), 10)
+	}
+	if s.MaxMessageBytes > 0 {
+		out["max.message.bytes"] = strconv.FormatInt(s.MaxMessageBytes, 10)
+	}
+	if s.CleanupPolicy != "" {
+		out["cleanup.policy"] = s.CleanupPolicy
+	}
+	return out
+}
+
+// ReconcileTopics creates the evaluation topic if needed and brings its
+// partitions and configs in line with want. Settings that already match are
+// left untouched.
+func ReconcileTopics(ctx context.Context, brokers []string, want TopicSettings) error {
+	client, err := kgo.NewClient(kgo.SeedBrokers(brokers...))
+	if err != nil {
+		return fmt.Errorf("op=topics.reconcile_client: %w", err)
+	}
+	defer client.Close()
+	partitions := want.Partitions
+	if partitions <= 0 {
+		partitions = 8
+	}
+	if err := createTopicIfNotExists(ctx, client, TopicEvaluate, partitions, 1); err != nil {
+		return fmt.Errorf("op=topics.reconcile_create: %w", err)
+	}
+	return reconcileTopic(ctx, client, TopicEvaluate, want)
+}
+
+// reconcileTopic adds the partitions topic lacks and sets the configs that
+// differ from want.
+func reconcileTopic(ctx context.Context, client kmsg.Requestor, topic string, want TopicSettings) error {
+	if want.Partitions > 0 {
+		have, err := topicPartitions(ctx, client, topic)
+		if err != nil {
+			return err
+		}
+		switch {
+		case have < want.Partitions:
+			if err := addPartitions(ctx, client, topic, want.Partitions); err != nil {
+				return err
+			}
+			slog.Info("topic partitions increased", slog.String("topic", topic), slog.Int("from", int(have)), slog.Int("to", int(want.Partitions)))
+		case have > want.Partitions:
+			slog.Warn("topic has more partitions than configured; partitions cannot be removed",
+				slog.String("topic", topic), slog.Int("partitions", int(have)), slog.Int("configured", int(want.Partitions)))
+		}
+	}
+	desired := want.configs()
+	if len(desired) == 0 {
+		return nil
+	}
+	current, err := topicConfigs(ctx, client, topic)
+	if err != nil {
+		return err
+	}
+	changes := configChanges(current, desired)
+	if len(changes) == 0 {
+		slog.Info("topic settings up to date", slog.String("topic", topic))
+		return nil
+	}
+	if err := alterTopicConfigs(ctx, client, topic, changes); err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(changes) {
+		slog.Info("topic config updated", slog.String("topic", topic), slog.String("config", name),
+			slog.String("from", current[name]), slog.String("to", changes[name]))
+	}
+	return nil
+}
+
+// configChanges returns the desired configs whose current value differs.
+func configChanges(current, desired map[string]string) map[string]string {
+	out := map[string]string{}
+	for name, v := range desired {
+		if cur, ok := current[name]; !ok || cur != v {
+			out[name] = v
+		}
+	}
+	return out
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// topicPartitions returns the partition count of topic.
+func topicPartitions(ctx context.Context, client kmsg.Requestor, topic string) (int32, error) {
+	req := kmsg.NewPtrMetadataRequest()
+	t := kmsg.NewMetadataRequestTopic()
+	t.Topic = kmsg.StringPtr(topic)
+	req.Topics = append(req.Topics, t)
+	resp, err := req.RequestWith(ctx, client)
+	if err != nil {
+		return 0, fmt.Errorf("op=topics.metadata: %w", err)
+	}
+	for _, rt := range resp.Topics {
+		if rt.Topic == nil || *rt.Topic != topic {
+			continue
+		}
+		if err := kerr.ErrorForCode(rt.ErrorCode); err != nil {
+			return 0, fmt.Errorf("op=topics.metadata: %w", err)
+		}
+		return int32(len(rt.Partitions)), nil
+	}
+	return 0, fmt.Errorf("op=topics.metadata: topic %s not found", topic)
+}
+
+// addPartitions raises the partition count of topic to count.
+func addPartitions(ctx context.Context, client kmsg.Requestor, topic string, count int32) error {
+	req := kmsg.NewPtrCreatePartitionsRequest()
+	req.TimeoutMillis = 30000
+	t := kmsg.NewCreatePartitionsRequestTopic()
+	t.Topic = topic
+	t.Count = count
+	req.Topics = append(req.Topics, t)
+	resp, err := req.RequestWith(ctx, client)
+	if err != nil {
+		return fmt.Errorf("op=topics.create_partitions: %w", err)
+	}
+	for _, rt := range resp.Topics {
+		if err := kerr.ErrorForCode(rt.ErrorCode); err != nil {
+			return fmt.Errorf("op=topics.create_partitions: %w", err)
+		}
+	}
+	return nil
+}
+
+// topicConfigs returns the current configs of topic.
+func topicConfigs(ctx context.Context, client kmsg.Requestor, topic string) (map[string]string, error) {
+	req := kmsg.NewPtrDescribeConfigsRequest()
+	r := kmsg.NewDescribeConfigsRequestResource()
+	r.ResourceType = kmsg.ConfigResourceTypeTopic
+	r.ResourceName = topic
+	req.Resources = append(req.Resources, r)
+	resp, err := req.RequestWith(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("op=topics.describe_configs: %w", err)
+	}
+	out := map[string]string{}
+	for _, res := range resp.Resources {
+		if err := kerr.ErrorForCode(res.ErrorCode); err != nil {
+			return nil, fmt.Errorf("op=topics.describe_configs: %w", err)
+		}
+		for _, c := range res.Configs {
+			if c.Value != nil {
+				out[c.Name] = *c.Value
+			}
+		}
+	}
+	return out, nil
+}
+
+// alterTopicConfigs sets configs on topic, leaving the others untouched.
+func alterTopicConfigs(ctx context.Context, client kmsg.Requestor, topic string, configs map[string]string) error {
+	req := kmsg.NewPtrIncrementalAlterConfigsRequest()
+	r := kmsg.NewIncrementalAlterConfigsRequestResource()
+	r.ResourceType = kmsg.ConfigResourceTypeTopic
+	r.ResourceName = topic
+	for _, name := range sortedKeys(configs) {
+		c := kmsg.NewIncrementalAlterConfigsRequestResourceConfig()
+		c.Name = name
+		c.Op = kmsg.IncrementalAlterConfigOpSet
+		c.Value = kmsg.StringPtr(configs[name])
+		r.Configs = append(r.Configs, c)
+	}
+	req.Resources = append(req.Resources, r)
+	resp, err := req.RequestWith(ctx, client)
+	if err != nil {
+		return fmt.Errorf("op=topics.alter_configs: %w", err)
+	}
+	for _, res := range resp.Resources {
+		if err := kerr.ErrorForCode(res.ErrorCode); err != nil {
+			return fmt.Errorf("op=topics.alter_configs: %w", err)
+		}
+	}
+	return nil
+}
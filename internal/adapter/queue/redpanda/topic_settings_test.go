@@ -0,0 +1,80 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// fakeTopicAdmin serves the admin requests of reconcileTopic from an
+// in-memory topic and records the changes made to it.
+type fakeTopicAdmin struct {
+	partitions int32
+	configs    map[string]string
+	added      int32
+	altered    map[string]string
+}
+
+func (f *fakeTopicAdmin) Request(_ context.Context, req kmsg.Request) (kmsg.Response, error) {
+	switch r := req.(type) {
+	case *kmsg.MetadataRequest:
+		resp := kmsg.NewPtrMetadataResponse()
+		t := kmsg.NewMetadataResponseTopic()
+		t.Topic = r.Topics[0].Topic
+		t.Partitions = make([]kmsg.MetadataResponseTopicPartition, f.partitions)
+		resp.Topics = append(resp.Topics, t)
+		return resp, nil
+	case *kmsg.CreatePartitionsRequest:
+		f.added = r.Topics[0].Count
+		resp := kmsg.NewPtrCreatePartitionsResponse()
+		resp.Topics = append(resp.Topics, kmsg.NewCreatePartitionsResponseTopic())
+		return resp, nil
+	case *kmsg.DescribeConfigsRequest:
+		resp := kmsg.NewPtrDescribeConfigsResponse()
+		res := kmsg.NewDescribeConfigsResponseResource()
+		for name, v := range f.configs {
+			c := kmsg.NewDescribeConfigsResponseResourceConfig()
+			c.Name, c.Value = name, kmsg.StringPtr(v)
+			res.Configs = append(res.Configs, c)
+		}
+		resp.Resources = append(resp.Resources, res)
+		return resp, nil
+	case *kmsg.IncrementalAlterConfigsRequest:
+		f.altered = map[string]string{}
+		for _, c := range r.Resources[0].Configs {
+			f.altered[c.Name] = *c.Value
+		}
+		resp := kmsg.NewPtrIncrementalAlterConfigsResponse()
+		resp.Resources = append(resp.Resources, kmsg.NewIncrementalAlterConfigsResponseResource())
+		return resp, nil
+	}
+	return nil, fmt.Errorf("unexpected request %T", req)
+}
+
+func TestReconcileTopic(t *testing.T) {
+	want := TopicSettings{Partitions: 8, Retention: 7 * 24 * time.Hour, MaxMessageBytes: 1000012, CleanupPolicy: "delete"}
+
+	// Drifted settings are corrected.
+	admin := &fakeTopicAdmin{partitions: 4, configs: map[string]string{
+		"retention.ms": "86400000", "max.message.bytes": "1000012", "cleanup.policy": "delete", "segment.ms": "3600000",
+	}}
+	require.NoError(t, reconcileTopic(context.Background(), admin, TopicEvaluate, want))
+	assert.Equal(t, int32(8), admin.added)
+	assert.Equal(t, map[string]string{"retention.ms": "604800000"}, admin.altered)
+
+	// Matching settings are a no-op.
+	admin = &fakeTopicAdmin{partitions: 12, configs: map[string]string{
+		"retention.ms": "604800000", "max.message.bytes": "1000012", "cleanup.policy": "delete",
+	}}
+	require.NoError(t, reconcileTopic(context.Background(), admin, TopicEvaluate, want))
+	assert.Zero(t, admin.added, "partitions are never removed")
+	assert.Nil(t, admin.altered)
+
+	// Unmanaged settings are not even read.
+	require.NoError(t, reconcileTopic(context.Background(), &fakeTopicAdmin{}, TopicEvaluate, TopicSettings{}))
+}
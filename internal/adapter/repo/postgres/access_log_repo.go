@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// AccessLogRepo stores reads of results in access_log.
+type AccessLogRepo struct{ Pool PgxPool }
+
+// NewAccessLogRepo constructs an AccessLogRepo with the given pool.
+func NewAccessLogRepo(p PgxPool) *AccessLogRepo { return &AccessLogRepo{Pool: p} }
+
+// Record inserts entries in one statement, so a batch read is logged as a
+// whole or not at all.
+func (r *AccessLogRepo) Record(ctx domain.Context, entries []domain.ResultAccess) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	tracer := otel.Tracer("repo.access_log")
+	ctx, span := tracer.Start(ctx, "access_log.Record")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "INSERT"),
+		attribute.String("db.sql.table", "access_log"),
+		attribute.Int("access_log.entries", len(entries)),
+	)
+	jobIDs := make([]string, len(entries))
+	types := make([]string, len(entries))
+	accessors := make([]string, len(entries))
+	ips := make([]string, len(entries))
+	endpoints := make([]string, len(entries))
+	times := make([]time.Time, len(entries))
+	for i, e := range entries {
+		jobIDs[i], types[i], accessors[i], ips[i], endpoints[i], times[i] = e.JobID, e.AccessorType, e.Accessor, e.IP, e.Endpoint, e.AccessedAt
+	}
+	q := `INSERT INTO access_log (job_id, accessor_type, accessor, ip, endpoint, accessed_at)
+		SELECT * FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::text[], $6::timestamptz[])`
+	if _, err := r.Pool.Exec(ctx, q, jobIDs, types, accessors, ips, endpoints, times); err != nil {
+		return fmt.Errorf("op=access_log.record: %w", err)
+	}
+	return nil
+}
+
+// List returns the reads matching f, newest first, at most f.Limit of them.
+func (r *AccessLogRepo) List(ctx domain.Context, f domain.AccessLogFilter) ([]domain.ResultAccess, error) {
+	tracer := otel.Tracer("repo.access_log")
+	ctx, span := tracer.Start(ctx, "access_log.List")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "access_log"),
+	)
+	var since, until *time.Time
+	if !f.Since.IsZero() {
+		since = &f.Since
+	}
+	if !f.Until.IsZero() {
+		until = &f.Until
+	}
+	q := `SELECT job_id, accessor_type, accessor, ip, endpoint, accessed_at FROM access_log
+		WHERE ($1 = '' OR job_id = $1) AND ($2 = '' OR accessor = $2)
+		AND ($3::timestamptz IS NULL OR accessed_at >= $3) AND ($4::timestamptz IS NULL OR accessed_at < $4)
+		ORDER BY accessed_at DESC, id DESC LIMIT $5`
+	rows, err := r.Pool.Query(ctx, q, f.JobID, f.Accessor, since, until, f.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("op=access_log.list: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.ResultAccess
+	for rows.Next() {
+		var e domain.ResultAccess
+		if err := rows.Scan(&e.JobID, &e.AccessorType, &e.Accessor, &e.IP, &e.Endpoint, &e.AccessedAt); err != nil {
+			return nil, fmt.Errorf("op=access_log.list_scan: %w", err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=access_log.list_rows: %w", err)
+	}
+	return out, nil
+}
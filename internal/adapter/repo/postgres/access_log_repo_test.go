@@ -0,0 +1,76 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestAccessLogRepo_Record(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewAccessLogRepo(pool)
+	at := time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.Record(context.Background(), nil))
+
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "INSERT INTO access_log") && strings.Contains(sql, "unnest(")
+	}), []any{
+		[]string{"j1", "j2"}, []string{"api_key", "api_key"}, []string{"h", "h"},
+		[]string{"10.0.0.1", "10.0.0.1"}, []string{"batch", "batch"}, []time.Time{at, at},
+	}).Return(pgconn.NewCommandTag("INSERT 0 2"), nil).Once()
+	entries := []domain.ResultAccess{
+		{JobID: "j1", AccessorType: domain.AccessorAPIKey, Accessor: "h", IP: "10.0.0.1", Endpoint: "batch", AccessedAt: at},
+		{JobID: "j2", AccessorType: domain.AccessorAPIKey, Accessor: "h", IP: "10.0.0.1", Endpoint: "batch", AccessedAt: at},
+	}
+	require.NoError(t, repo.Record(context.Background(), entries))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.Record(context.Background(), entries[:1]), "op=access_log.record")
+}
+
+func TestAccessLogRepo_List(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewAccessLogRepo(pool)
+	at := time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC)
+	since := at.Add(-time.Hour)
+
+	rows := mocks.NewMockRows(t)
+	calls := 0
+	rows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	rows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "j1"
+		*(dest[1].(*string)) = "admin"
+		*(dest[2].(*string)) = "alice"
+		*(dest[3].(*string)) = "10.0.0.2"
+		*(dest[4].(*string)) = "admin_job_details"
+		*(dest[5].(*time.Time)) = at
+	}).Return(nil).Once()
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "FROM access_log") && strings.Contains(sql, "ORDER BY accessed_at DESC")
+	}), []any{"j1", "", &since, (*time.Time)(nil), 50}).Return(rows, nil).Once()
+
+	got, err := repo.List(context.Background(), domain.AccessLogFilter{JobID: "j1", Since: since, Limit: 50})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ResultAccess{{JobID: "j1", AccessorType: "admin", Accessor: "alice", IP: "10.0.0.2", Endpoint: "admin_job_details", AccessedAt: at}}, got)
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+	_, err = repo.List(context.Background(), domain.AccessLogFilter{Limit: 10})
+	assert.ErrorContains(t, err, "op=access_log.list")
+}
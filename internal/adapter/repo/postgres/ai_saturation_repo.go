@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// AISaturationRepo shares the workers' AI capacity reports through
+// ai_saturation.
+type AISaturationRepo struct{ Pool PgxPool }
+
+// NewAISaturationRepo constructs an AISaturationRepo with the given pool.
+func NewAISaturationRepo(p PgxPool) *AISaturationRepo { return &AISaturationRepo{Pool: p} }
+
+func startAISaturationSpan(ctx domain.Context, name, op string) (domain.Context, func()) {
+	tracer := otel.Tracer("repo.ai_saturation")
+	ctx, span := tracer.Start(ctx, "ai_saturation."+name)
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", op),
+		attribute.String("db.sql.table", "ai_saturation"),
+	)
+	return ctx, func() { span.End() }
+}
+
+// Report stores s, replacing the instance's earlier report.
+func (r *AISaturationRepo) Report(ctx domain.Context, s domain.AISaturation) error {
+	ctx, end := startAISaturationSpan(ctx, "Report", "UPSERT")
+	defer end()
+	q := `INSERT INTO ai_saturation (instance, blocked_until, reported_at) VALUES ($1,$2,$3)
+		ON CONFLICT (instance) DO UPDATE SET blocked_until=EXCLUDED.blocked_until, reported_at=EXCLUDED.reported_at`
+	if _, err := r.Pool.Exec(ctx, q, s.Instance, s.BlockedUntil, s.ReportedAt); err != nil {
+		return fmt.Errorf("op=ai_saturation.report: %w", err)
+	}
+	return nil
+}
+
+// List returns the reports made since since.
+func (r *AISaturationRepo) List(ctx domain.Context, since time.Time) ([]domain.AISaturation, error) {
+	ctx, end := startAISaturationSpan(ctx, "List", "SELECT")
+	defer end()
+	rows, err := r.Pool.Query(ctx, `SELECT instance, blocked_until, reported_at FROM ai_saturation WHERE reported_at >= $1`, since)
+	if err != nil {
+		return nil, fmt.Errorf("op=ai_saturation.list: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.AISaturation
+	for rows.Next() {
+		var s domain.AISaturation
+		if err := rows.Scan(&s.Instance, &s.BlockedUntil, &s.ReportedAt); err != nil {
+			return nil, fmt.Errorf("op=ai_saturation.list_scan: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=ai_saturation.list_rows: %w", err)
+	}
+	return out, nil
+}
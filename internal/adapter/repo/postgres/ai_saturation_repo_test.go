@@ -0,0 +1,54 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestAISaturationRepo_ReportAndList(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewAISaturationRepo(pool)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 7, 9, 0, 0, 0, time.UTC)
+	until := at.Add(2 * time.Minute)
+
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.HasPrefix(q, "INSERT INTO ai_saturation") && strings.Contains(q, "ON CONFLICT (instance)")
+	}), mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Equal(t, []any{"worker-1", &until, at}, args)
+	}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Report(ctx, domain.AISaturation{Instance: "worker-1", BlockedUntil: &until, ReportedAt: at}))
+
+	rows := mocks.NewMockRows(t)
+	calls := 0
+	rows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	rows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "worker-1"
+		*(dest[1].(**time.Time)) = &until
+		*(dest[2].(*time.Time)) = at
+	}).Return(nil).Once()
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.Contains(q, "FROM ai_saturation WHERE reported_at >= $1") }),
+		[]any{at.Add(-time.Minute)}).Return(rows, nil).Once()
+	got, err := repo.List(ctx, at.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "worker-1", got[0].Instance)
+	assert.Equal(t, until, *got[0].BlockedUntil)
+}
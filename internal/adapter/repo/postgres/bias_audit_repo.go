@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// biasExcerptChars bounds how much CV text is loaded per sample for language detection.
+const biasExcerptChars = 2000
+
+// BiasAuditRepo reads evaluation samples for the bias audit and stores its reports.
+type BiasAuditRepo struct{ Pool PgxPool }
+
+// NewBiasAuditRepo constructs a BiasAuditRepo with the given pool.
+func NewBiasAuditRepo(p PgxPool) *BiasAuditRepo { return &BiasAuditRepo{Pool: p} }
+
+// ListSamples returns the completed evaluations whose result was stored in
+// [from, to). CV-only evaluations have no project upload or score and
+// project-only evaluations no CV upload or match rate. Whether an evaluation
+// was anonymized is read from the task kept on the job.
+func (r *BiasAuditRepo) ListSamples(ctx domain.Context, from, to time.Time) ([]domain.EvaluationSample, error) {
+	tracer := otel.Tracer("repo.bias_audit")
+	ctx, span := tracer.Start(ctx, "bias_audit.ListSamples")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "results"),
+	)
+	q := `SELECT j.id, COALESCE(LEFT(cv.text, $3), ''), COALESCE(length(cv.text), 0), COALESCE(length(pr.text), 0),
+			COALESCE(r.cv_match_rate, 0), COALESCE(r.project_score, 0), r.cv_match_rate IS NULL, r.project_score IS NULL,
+			CASE WHEN j.task IS NULL THEN NULL ELSE COALESCE((j.task->'Overrides'->>'anonymize')::boolean, false) END, r.created_at
+		FROM results r
+		JOIN jobs j ON j.id = r.job_id
+		LEFT JOIN uploads cv ON cv.id = j.cv_id
+		LEFT JOIN uploads pr ON pr.id = j.project_id
+		WHERE j.status = 'completed' AND r.created_at >= $1 AND r.created_at < $2`
+	rows, err := r.Pool.Query(ctx, q, from, to, biasExcerptChars)
+	if err != nil {
+		return nil, fmt.Errorf("op=bias_audit.list_samples: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.EvaluationSample
+	for rows.Next() {
+		var s domain.EvaluationSample
+		if err := rows.Scan(&s.JobID, &s.CVExcerpt, &s.CVLength, &s.ProjectLength, &s.CVMatchRate, &s.ProjectScore,
+			&s.ProjectOnly, &s.CVOnly, &s.Anonymized, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("op=bias_audit.list_samples_scan: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=bias_audit.list_samples_rows: %w", err)
+	}
+	return out, nil
+}
+
+// SaveReport stores a report, assigning an ID when it has none.
+func (r *BiasAuditRepo) SaveReport(ctx domain.Context, rep domain.BiasReport) error {
+	tracer := otel.Tracer("repo.bias_audit")
+	ctx, span := tracer.Start(ctx, "bias_audit.SaveReport")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "INSERT"),
+		attribute.String("db.sql.table", "bias_reports"),
+	)
+	if rep.ID == "" {
+		rep.ID = uuid.New().String()
+	}
+	body, err := json.Marshal(rep)
+	if err != nil {
+		return fmt.Errorf("op=bias_audit.save_report_marshal: %w", err)
+	}
+	q := `INSERT INTO bias_reports (id, generated_at, window_start, window_end, report) VALUES ($1,$2,$3,$4,$5)`
+	if _, err := r.Pool.Exec(ctx, q, rep.ID, rep.GeneratedAt, rep.WindowStart, rep.WindowEnd, body); err != nil {
+		return fmt.Errorf("op=bias_audit.save_report: %w", err)
+	}
+	return nil
+}
+
+// LatestReport returns the most recently generated report.
+func (r *BiasAuditRepo) LatestReport(ctx domain.Context) (domain.BiasReport, error) {
+	tracer := otel.Tracer("repo.bias_audit")
+	ctx, span := tracer.Start(ctx, "bias_audit.LatestReport")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "bias_reports"),
+	)
+	var body []byte
+	row := r.Pool.QueryRow(ctx, `SELECT report FROM bias_reports ORDER BY generated_at DESC LIMIT 1`)
+	if err := row.Scan(&body); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.BiasReport{}, fmt.Errorf("op=bias_audit.latest_report: %w", domain.ErrNotFound)
+		}
+		return domain.BiasReport{}, fmt.Errorf("op=bias_audit.latest_report: %w", err)
+	}
+	var rep domain.BiasReport
+	if err := json.Unmarshal(body, &rep); err != nil {
+		return domain.BiasReport{}, fmt.Errorf("op=bias_audit.latest_report_unmarshal: %w", err)
+	}
+	return rep, nil
+}
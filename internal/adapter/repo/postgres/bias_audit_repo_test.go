@@ -0,0 +1,103 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestBiasAuditRepo_ListSamples(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewBiasAuditRepo(pool)
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 3
+	}).Times(4)
+	anonymized := true
+	sample := func(id, excerpt string, cvLen, prLen int, cv, proj float64, projectOnly, cvOnly bool, anon *bool) {
+		mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+			dest := args[0].([]any)
+			*(dest[0].(*string)) = id
+			*(dest[1].(*string)) = excerpt
+			*(dest[2].(*int)) = cvLen
+			*(dest[3].(*int)) = prLen
+			*(dest[4].(*float64)) = cv
+			*(dest[5].(*float64)) = proj
+			*(dest[6].(*bool)) = projectOnly
+			*(dest[7].(*bool)) = cvOnly
+			*(dest[8].(**bool)) = anon
+			*(dest[9].(*time.Time)) = from
+		}).Return(nil).Once()
+	}
+	sample("job-1", "Go engineer", 3200, 9000, 0.7, 8, false, false, &anonymized)
+	// CV-only and project-only evaluations lack one of the uploads and scores.
+	sample("job-2", "Go engineer", 3200, 0, 0.6, 0, false, true, nil)
+	sample("job-3", "", 0, 4000, 0, 7, true, false, nil)
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "LEFT JOIN uploads cv") && strings.Contains(q, "LEFT JOIN uploads pr") &&
+			strings.Contains(q, "j.task->'Overrides'->>'anonymize'")
+	}), []any{from, to, 2000}).Return(mockRows, nil).Once()
+
+	samples, err := repo.ListSamples(context.Background(), from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.EvaluationSample{
+		{JobID: "job-1", CVExcerpt: "Go engineer", CVLength: 3200, ProjectLength: 9000, CVMatchRate: 0.7, ProjectScore: 8, Anonymized: &anonymized, CreatedAt: from},
+		{JobID: "job-2", CVExcerpt: "Go engineer", CVLength: 3200, CVMatchRate: 0.6, CVOnly: true, CreatedAt: from},
+		{JobID: "job-3", ProjectLength: 4000, ProjectScore: 7, ProjectOnly: true, CreatedAt: from},
+	}, samples)
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+	_, err = repo.ListSamples(context.Background(), from, to)
+	assert.ErrorContains(t, err, "op=bias_audit.list_samples")
+}
+
+func TestBiasAuditRepo_SaveAndLatestReport(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewBiasAuditRepo(pool)
+	at := time.Date(2025, 12, 7, 9, 0, 0, 0, time.UTC)
+	rep := domain.BiasReport{ID: "r1", GeneratedAt: at, WindowStart: at.Add(-time.Hour), WindowEnd: at, SampleCount: 3,
+		Segments: []domain.BiasSegment{{Dimension: "language", Value: "en", Count: 3}}}
+
+	var stored []byte
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		require.Len(t, args, 5)
+		assert.Equal(t, "r1", args[0])
+		stored = args[4].([]byte)
+	}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.SaveReport(context.Background(), rep))
+	require.True(t, json.Valid(stored))
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*[]byte)) = stored
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything).Return(row).Once()
+	got, err := repo.LatestReport(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, rep, got)
+
+	empty := mocks.NewMockRow(t)
+	empty.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything).Return(empty).Once()
+	_, err = repo.LatestReport(context.Background())
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
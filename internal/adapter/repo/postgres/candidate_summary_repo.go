@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// CandidateSummaryRepo caches generated candidate summaries per job.
+type CandidateSummaryRepo struct{ Pool PgxPool }
+
+// NewCandidateSummaryRepo constructs a CandidateSummaryRepo with the given pool.
+func NewCandidateSummaryRepo(p PgxPool) *CandidateSummaryRepo {
+	return &CandidateSummaryRepo{Pool: p}
+}
+
+// Get returns the summary stored for a job.
+func (r *CandidateSummaryRepo) Get(ctx domain.Context, jobID string) (domain.CandidateSummary, error) {
+	tracer := otel.Tracer("repo.candidate_summaries")
+	ctx, span := tracer.Start(ctx, "candidate_summaries.Get")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "candidate_summaries"),
+		attribute.String("job.id", jobID),
+	)
+	var s domain.CandidateSummary
+	err := r.Pool.QueryRow(ctx, `SELECT job_id, result_version, summary, strengths, risks, created_at FROM candidate_summaries WHERE job_id=$1`, jobID).
+		Scan(&s.JobID, &s.ResultVersion, &s.Summary, &s.Strengths, &s.Risks, &s.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.CandidateSummary{}, fmt.Errorf("op=candidate_summary.get: %w", domain.ErrNotFound)
+		}
+		return domain.CandidateSummary{}, fmt.Errorf("op=candidate_summary.get: %w", err)
+	}
+	return s, nil
+}
+
+// Save inserts or replaces the summary of s.JobID.
+func (r *CandidateSummaryRepo) Save(ctx domain.Context, s domain.CandidateSummary) error {
+	tracer := otel.Tracer("repo.candidate_summaries")
+	ctx, span := tracer.Start(ctx, "candidate_summaries.Save")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "candidate_summaries"),
+		attribute.String("job.id", s.JobID),
+	)
+	strengths, risks := s.Strengths, s.Risks
+	if strengths == nil {
+		strengths = []string{}
+	}
+	if risks == nil {
+		risks = []string{}
+	}
+	q := `INSERT INTO candidate_summaries (job_id, result_version, summary, strengths, risks, created_at) VALUES ($1,$2,$3,$4,$5,$6)
+		ON CONFLICT (job_id) DO UPDATE SET
+			result_version = EXCLUDED.result_version,
+			summary = EXCLUDED.summary,
+			strengths = EXCLUDED.strengths,
+			risks = EXCLUDED.risks,
+			created_at = EXCLUDED.created_at`
+	if _, err := r.Pool.Exec(ctx, q, s.JobID, s.ResultVersion, s.Summary, strengths, risks, s.CreatedAt); err != nil {
+		return fmt.Errorf("op=candidate_summary.save: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestCandidateSummaryRepo_Get(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewCandidateSummaryRepo(pool)
+	at := time.Date(2025, 12, 14, 9, 0, 0, 0, time.UTC)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "job-1"
+		*(dest[1].(*string)) = "v1"
+		*(dest[2].(*string)) = "Solid backend engineer."
+		*(dest[3].(*[]string)) = []string{"Go"}
+		*(dest[4].(*[]string)) = []string{"No cloud experience"}
+		*(dest[5].(*time.Time)) = at
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"job-1"}).Return(row).Once()
+	s, err := repo.Get(context.Background(), "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CandidateSummary{
+		JobID:         "job-1",
+		ResultVersion: "v1",
+		Summary:       "Solid backend engineer.",
+		Strengths:     []string{"Go"},
+		Risks:         []string{"No cloud experience"},
+		CreatedAt:     at,
+	}, s)
+
+	empty := mocks.NewMockRow(t)
+	empty.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"job-2"}).Return(empty).Once()
+	_, err = repo.Get(context.Background(), "job-2")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestCandidateSummaryRepo_Save(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewCandidateSummaryRepo(pool)
+	at := time.Date(2025, 12, 14, 9, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"job-1", "v1", "summary", []string{}, []string{}, at}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Save(context.Background(), domain.CandidateSummary{JobID: "job-1", ResultVersion: "v1", Summary: "summary", CreatedAt: at}))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.Save(context.Background(), domain.CandidateSummary{JobID: "job-1"}), "op=candidate_summary.save")
+}
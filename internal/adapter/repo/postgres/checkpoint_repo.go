@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// CheckpointRepo stores the completed steps of evaluation chains.
+type CheckpointRepo struct{ Pool PgxPool }
+
+// NewCheckpointRepo constructs a CheckpointRepo with the given pool.
+func NewCheckpointRepo(p PgxPool) *CheckpointRepo {
+	return &CheckpointRepo{Pool: p}
+}
+
+// Get returns the checkpoint of step for a job.
+func (r *CheckpointRepo) Get(ctx domain.Context, jobID, step string) (domain.EvaluationCheckpoint, error) {
+	tracer := otel.Tracer("repo.evaluation_checkpoints")
+	ctx, span := tracer.Start(ctx, "evaluation_checkpoints.Get")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "evaluation_checkpoints"),
+		attribute.String("job.id", jobID),
+		attribute.String("evaluation.step", step),
+	)
+	var (
+		c          domain.EvaluationCheckpoint
+		provenance []byte
+	)
+	err := r.Pool.QueryRow(ctx, `SELECT job_id, step, output, provenance, created_at FROM evaluation_checkpoints WHERE job_id=$1 AND step=$2`, jobID, step).
+		Scan(&c.JobID, &c.Step, &c.Output, &provenance, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.EvaluationCheckpoint{}, fmt.Errorf("op=checkpoint.get: %w", domain.ErrNotFound)
+		}
+		return domain.EvaluationCheckpoint{}, fmt.Errorf("op=checkpoint.get: %w", err)
+	}
+	if len(provenance) > 0 {
+		if err := json.Unmarshal(provenance, &c.Provenance); err != nil {
+			return domain.EvaluationCheckpoint{}, fmt.Errorf("op=checkpoint.get_provenance: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// Save inserts or replaces the checkpoint of c.JobID and c.Step.
+func (r *CheckpointRepo) Save(ctx domain.Context, c domain.EvaluationCheckpoint) error {
+	tracer := otel.Tracer("repo.evaluation_checkpoints")
+	ctx, span := tracer.Start(ctx, "evaluation_checkpoints.Save")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "evaluation_checkpoints"),
+		attribute.String("job.id", c.JobID),
+		attribute.String("evaluation.step", c.Step),
+	)
+	steps := c.Provenance
+	if steps == nil {
+		steps = []domain.StepProvenance{}
+	}
+	provenance, err := json.Marshal(steps)
+	if err != nil {
+		return fmt.Errorf("op=checkpoint.save_provenance: %w", err)
+	}
+	q := `INSERT INTO evaluation_checkpoints (job_id, step, output, provenance, created_at) VALUES ($1,$2,$3,$4,$5)
+		ON CONFLICT (job_id, step) DO UPDATE SET
+			output = EXCLUDED.output,
+			provenance = EXCLUDED.provenance,
+			created_at = EXCLUDED.created_at`
+	if _, err := r.Pool.Exec(ctx, q, c.JobID, c.Step, c.Output, provenance, c.CreatedAt); err != nil {
+		return fmt.Errorf("op=checkpoint.save: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the checkpoints of a job.
+func (r *CheckpointRepo) Delete(ctx domain.Context, jobID string) error {
+	tracer := otel.Tracer("repo.evaluation_checkpoints")
+	ctx, span := tracer.Start(ctx, "evaluation_checkpoints.Delete")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "DELETE"),
+		attribute.String("db.sql.table", "evaluation_checkpoints"),
+		attribute.String("job.id", jobID),
+	)
+	if _, err := r.Pool.Exec(ctx, `DELETE FROM evaluation_checkpoints WHERE job_id=$1`, jobID); err != nil {
+		return fmt.Errorf("op=checkpoint.delete: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestCheckpointRepo_Get(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewCheckpointRepo(pool)
+	at := time.Date(2025, 12, 20, 9, 0, 0, 0, time.UTC)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "job-1"
+		*(dest[1].(*string)) = "cv_match"
+		*(dest[2].(*string)) = `{"technical_skills":4}`
+		*(dest[3].(*[]byte)) = []byte(`[{"step":"cv_match","provider":"groq","model":"groq/m1","prompt_version":"1"}]`)
+		*(dest[4].(*time.Time)) = at
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"job-1", "cv_match"}).Return(row).Once()
+	c, err := repo.Get(context.Background(), "job-1", "cv_match")
+	require.NoError(t, err)
+	assert.Equal(t, domain.EvaluationCheckpoint{
+		JobID:      "job-1",
+		Step:       "cv_match",
+		Output:     `{"technical_skills":4}`,
+		Provenance: []domain.StepProvenance{{Step: "cv_match", Provider: "groq", Model: "groq/m1", PromptVersion: "1"}},
+		CreatedAt:  at,
+	}, c)
+
+	empty := mocks.NewMockRow(t)
+	empty.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"job-2", "cv_match"}).Return(empty).Once()
+	_, err = repo.Get(context.Background(), "job-2", "cv_match")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestCheckpointRepo_SaveAndDelete(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewCheckpointRepo(pool)
+	at := time.Date(2025, 12, 20, 9, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"job-1", "project_evaluation", "text", []byte("[]"), at}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Save(context.Background(), domain.EvaluationCheckpoint{JobID: "job-1", Step: "project_evaluation", Output: "text", CreatedAt: at}))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.Save(context.Background(), domain.EvaluationCheckpoint{JobID: "job-1"}), "op=checkpoint.save")
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"job-1"}).Return(pgconn.NewCommandTag("DELETE 2"), nil).Once()
+	require.NoError(t, repo.Delete(context.Background(), "job-1"))
+}
@@ -14,6 +14,13 @@ import (
 type CleanupService struct {
 	Pool          Beginner
 	RetentionDays int
+	// Partitions, when set, keeps upcoming jobs/results partitions created on
+	// every run. If it also has an archive sink, expired partitions are
+	// archived and dropped instead of deleting jobs and results row by row.
+	Partitions *PartitionManager
+	// AccessLogRetentionDays, when positive, deletes access log entries older
+	// than that many days; otherwise they are kept.
+	AccessLogRetentionDays int
 }
 
 // Beginner is a minimal interface for starting a transaction.
@@ -38,7 +45,23 @@ func NewCleanupService(pool Beginner, retentionDays int) *CleanupService {
 
 // CleanupOldData removes data older than retention period
 func (s *CleanupService) CleanupOldData(ctx context.Context) error {
-	cutoff := time.Now().AddDate(0, 0, -s.RetentionDays)
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -s.RetentionDays)
+
+	archiving := false
+	if s.Partitions != nil {
+		if err := s.Partitions.EnsurePartitions(ctx, now); err != nil {
+			return fmt.Errorf("cleanup ensure partitions: %w", err)
+		}
+		if s.Partitions.ArchiveEnabled() {
+			archiving = true
+			archived, err := s.Partitions.ArchiveExpired(ctx, cutoff)
+			if err != nil {
+				return fmt.Errorf("cleanup archive partitions: %w", err)
+			}
+			slog.Info("partition archival completed", slog.Int("archived_partitions", archived), slog.Time("cutoff", cutoff))
+		}
+	}
 
 	// Start transaction for consistency
 	tx, err := s.Pool.Begin(ctx)
@@ -47,53 +70,206 @@ func (s *CleanupService) CleanupOldData(ctx context.Context) error {
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
-	// Delete old results (cascade from jobs)
-	var deletedResults int64
+	// Jobs past their own expiry are marked expired: queued ones are no longer
+	// processed and completed ones no longer serve their result.
+	var expiredJobs int64
+	err = tx.QueryRow(ctx, `
+		WITH upd AS (
+			UPDATE jobs SET status = 'expired', updated_at = $1
+			WHERE expires_at <= $1 AND status IN ('queued', 'completed')
+			RETURNING 1
+		)
+		SELECT count(*) FROM upd
+	`, now.UTC()).Scan(&expiredJobs)
+	if err != nil {
+		slog.Debug("no jobs to expire", slog.Any("error", err))
+	}
+
+	// Jobs and results are removed a whole partition at a time when archiving;
+	// row-level deletes would discard data that has not been exported yet.
+	var deletedResults, deletedJobs int64
+	if !archiving {
+		deletedResults, deletedJobs = deleteExpiredJobs(ctx, tx, cutoff)
+	}
+
+	// Delete orphaned uploads (not referenced by any job) unless held
+	var deletedUploads int64
 	err = tx.QueryRow(ctx, `
 		WITH del AS (
-			DELETE FROM results 
-			WHERE job_id IN (
-				SELECT id FROM jobs WHERE created_at < $1
+			DELETE FROM uploads 
+			WHERE created_at < $1 
+			AND NOT legal_hold
+			AND id NOT IN (
+				SELECT cv_id FROM jobs WHERE cv_id IS NOT NULL
+				UNION 
+				SELECT project_id FROM jobs WHERE project_id IS NOT NULL
 			)
 			RETURNING 1
 		)
 		SELECT count(*) FROM del
-	`, cutoff).Scan(&deletedResults)
+	`, cutoff).Scan(&deletedUploads)
 	if err != nil {
-		slog.Debug("no results to delete", slog.Any("error", err))
+		slog.Debug("no uploads to delete", slog.Any("error", err))
 	}
 
-	// Delete old jobs
-	var deletedJobs int64
+	// Summaries are generated after their job, so one older than the cutoff
+	// belongs to an expired job.
+	var deletedSummaries int64
 	err = tx.QueryRow(ctx, `
 		WITH del AS (
-			DELETE FROM jobs 
-			WHERE created_at < $1
+			DELETE FROM candidate_summaries WHERE created_at < $1 AND job_id NOT IN (`+heldJobsSQL+`)
 			RETURNING 1
 		)
 		SELECT count(*) FROM del
-	`, cutoff).Scan(&deletedJobs)
+	`, cutoff).Scan(&deletedSummaries)
 	if err != nil {
-		slog.Debug("no jobs to delete", slog.Any("error", err))
+		slog.Debug("no candidate summaries to delete", slog.Any("error", err))
 	}
 
-	// Delete orphaned uploads (not referenced by any job)
-	var deletedUploads int64
+	// Checkpoints of completed jobs are deleted right away; the rest belong to
+	// jobs that failed for good or were abandoned.
+	var deletedCheckpoints int64
 	err = tx.QueryRow(ctx, `
 		WITH del AS (
-			DELETE FROM uploads 
-			WHERE created_at < $1 
-			AND id NOT IN (
-				SELECT cv_id FROM jobs 
-				UNION 
-				SELECT project_id FROM jobs
-			)
+			DELETE FROM evaluation_checkpoints WHERE created_at < $1 AND job_id NOT IN (`+heldJobsSQL+`)
 			RETURNING 1
 		)
 		SELECT count(*) FROM del
-	`, cutoff).Scan(&deletedUploads)
+	`, cutoff).Scan(&deletedCheckpoints)
 	if err != nil {
-		slog.Debug("no uploads to delete", slog.Any("error", err))
+		slog.Debug("no evaluation checkpoints to delete", slog.Any("error", err))
+	}
+
+	// Result versions are stored with their job's evaluations, so one older
+	// than the cutoff belongs to an expired job.
+	var deletedVersions int64
+	err = tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM result_versions WHERE created_at < $1 AND job_id NOT IN (`+heldJobsSQL+`)
+			RETURNING 1
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedVersions)
+	if err != nil {
+		slog.Debug("no result versions to delete", slog.Any("error", err))
+	}
+
+	// Leases of consumers that died before releasing them.
+	var deletedLocks int64
+	err = tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM job_locks WHERE expires_at < $1
+			RETURNING 1
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedLocks)
+	if err != nil {
+		slog.Debug("no job locks to delete", slog.Any("error", err))
+	}
+
+	var deletedQuotaUsage int64
+	err = tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM tenant_quota_usage WHERE day < $1::date
+			RETURNING 1
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedQuotaUsage)
+	if err != nil {
+		slog.Debug("no tenant quota usage to delete", slog.Any("error", err))
+	}
+
+	// Experiment assignments are only reported together with their jobs.
+	var deletedVariants int64
+	err = tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM job_prompt_variants WHERE created_at < $1 AND job_id NOT IN (`+heldJobsSQL+`)
+			RETURNING 1
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedVariants)
+	if err != nil {
+		slog.Debug("no job prompt variants to delete", slog.Any("error", err))
+	}
+
+	// Bumps only order queued jobs, so old ones belong to finished jobs.
+	var deletedBumps int64
+	err = tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM job_bumps WHERE bumped_at < $1
+			RETURNING 1
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedBumps)
+	if err != nil {
+		slog.Debug("no job bumps to delete", slog.Any("error", err))
+	}
+
+	var deletedProviderErrors int64
+	err = tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM provider_errors WHERE at < $1 AND job_id NOT IN (`+heldJobsSQL+`)
+			RETURNING 1
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedProviderErrors)
+	if err != nil {
+		slog.Debug("no provider errors to delete", slog.Any("error", err))
+	}
+
+	var deletedJobUsage int64
+	err = tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM job_usage WHERE completed_at < $1 AND job_id NOT IN (`+heldJobsSQL+`)
+			RETURNING 1
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedJobUsage)
+	if err != nil {
+		slog.Debug("no job usage records to delete", slog.Any("error", err))
+	}
+
+	// Reports of workers that stopped long ago.
+	var deletedSaturation int64
+	err = tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM ai_saturation WHERE reported_at < $1
+			RETURNING 1
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedSaturation)
+	if err != nil {
+		slog.Debug("no ai saturation reports to delete", slog.Any("error", err))
+	}
+
+	// Observation markers only keep retried jobs from being counted twice in
+	// model_score_stats; jobs past retention are not retried.
+	var deletedScoreObservations int64
+	err = tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM model_score_observations WHERE observed_at < $1
+			RETURNING 1
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedScoreObservations)
+	if err != nil {
+		slog.Debug("no score observations to delete", slog.Any("error", err))
+	}
+
+	// The access log has its own retention: it must outlive the data it
+	// accounts for.
+	var deletedAccessLog int64
+	if s.AccessLogRetentionDays > 0 {
+		err = tx.QueryRow(ctx, `
+			WITH del AS (
+				DELETE FROM access_log WHERE accessed_at < $1 AND job_id NOT IN (`+heldJobsSQL+`)
+				RETURNING 1
+			)
+			SELECT count(*) FROM del
+		`, now.AddDate(0, 0, -s.AccessLogRetentionDays)).Scan(&deletedAccessLog)
+		if err != nil {
+			slog.Debug("no access log entries to delete", slog.Any("error", err))
+		}
 	}
 
 	if err := tx.Commit(ctx); err != nil {
@@ -102,14 +278,65 @@ func (s *CleanupService) CleanupOldData(ctx context.Context) error {
 
 	slog.Info("data cleanup completed",
 		slog.Int64("deleted_jobs", deletedJobs),
+		slog.Int64("expired_jobs", expiredJobs),
 		slog.Int64("deleted_results", deletedResults),
 		slog.Int64("deleted_uploads", deletedUploads),
+		slog.Int64("deleted_candidate_summaries", deletedSummaries),
+		slog.Int64("deleted_evaluation_checkpoints", deletedCheckpoints),
+		slog.Int64("deleted_result_versions", deletedVersions),
+		slog.Int64("deleted_job_locks", deletedLocks),
+		slog.Int64("deleted_tenant_quota_usage", deletedQuotaUsage),
+		slog.Int64("deleted_job_prompt_variants", deletedVariants),
+		slog.Int64("deleted_job_bumps", deletedBumps),
+		slog.Int64("deleted_provider_errors", deletedProviderErrors),
+		slog.Int64("deleted_job_usage", deletedJobUsage),
+		slog.Int64("deleted_ai_saturation", deletedSaturation),
+		slog.Int64("deleted_model_score_observations", deletedScoreObservations),
+		slog.Int64("deleted_access_log", deletedAccessLog),
 		slog.Time("cutoff", cutoff),
 	)
 
 	return nil
 }
 
+// deleteExpiredJobs removes results and jobs created before cutoff, except
+// those under a legal hold, and returns how many of each were deleted.
+func deleteExpiredJobs(ctx context.Context, tx Tx, cutoff time.Time) (deletedResults, deletedJobs int64) {
+	// Delete old results first, together with their result_job_keys entries;
+	// results carry no foreign key to jobs
+	err := tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM results 
+			WHERE job_id IN (
+				SELECT id FROM jobs WHERE created_at < $1
+			)
+			AND job_id NOT IN (`+heldJobsSQL+`)
+			RETURNING job_id
+		), keys AS (
+			DELETE FROM result_job_keys WHERE job_id IN (SELECT job_id FROM del)
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedResults)
+	if err != nil {
+		slog.Debug("no results to delete", slog.Any("error", err))
+	}
+
+	// Delete old jobs
+	err = tx.QueryRow(ctx, `
+		WITH del AS (
+			DELETE FROM jobs 
+			WHERE created_at < $1
+			AND id NOT IN (`+heldJobsSQL+`)
+			RETURNING 1
+		)
+		SELECT count(*) FROM del
+	`, cutoff).Scan(&deletedJobs)
+	if err != nil {
+		slog.Debug("no jobs to delete", slog.Any("error", err))
+	}
+	return deletedResults, deletedJobs
+}
+
 // RunPeriodic starts a periodic cleanup job
 func (s *CleanupService) RunPeriodic(ctx context.Context, interval time.Duration) {
 	if interval <= 0 {
@@ -3,9 +3,11 @@ package postgres_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
@@ -130,3 +132,76 @@ func TestCleanupService_RunPeriodic_WithError(t *testing.T) {
 	// Run with a short interval and timeout
 	svc.RunPeriodic(ctx, 50*time.Millisecond)
 }
+
+func TestCleanupService_SkipsLegalHolds(t *testing.T) {
+	tx := mocks.NewMockTx(t)
+	tx.EXPECT().Rollback(mock.Anything).Return(nil)
+	tx.EXPECT().Commit(mock.Anything).Return(nil)
+	var stmts []string
+	tx.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, sql string, _ ...any) pgx.Row {
+		stmts = append(stmts, sql)
+		row := mocks.NewMockRow(t)
+		row.EXPECT().Scan(mock.Anything).Return(nil).Once()
+		return row
+	})
+	svc := postgres.NewCleanupService(createMockBeginner(t, nil, tx), 30)
+	svc.AccessLogRetentionDays = 365
+	if err := svc.CleanupOldData(context.Background()); err != nil {
+		t.Fatalf("cleanup: %v", err)
+	}
+	for _, table := range []string{"results", "jobs", "candidate_summaries", "evaluation_checkpoints", "result_versions", "job_prompt_variants",
+		"provider_errors", "job_usage", "access_log"} {
+		found := false
+		for _, sql := range stmts {
+			if strings.Contains(sql, "DELETE FROM "+table+" ") {
+				found = true
+				if !strings.Contains(sql, "NOT IN (SELECT id FROM jobs WHERE legal_hold") {
+					t.Errorf("%s delete ignores legal holds:\n%s", table, sql)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("no delete from %s", table)
+		}
+	}
+	for _, sql := range stmts {
+		if strings.Contains(sql, "DELETE FROM uploads") && !strings.Contains(sql, "NOT legal_hold") {
+			t.Errorf("uploads delete ignores legal holds:\n%s", sql)
+		}
+	}
+}
+
+func TestCleanupService_AccessLogRetention(t *testing.T) {
+	run := func(days int) []string {
+		tx := mocks.NewMockTx(t)
+		tx.EXPECT().Rollback(mock.Anything).Return(nil)
+		tx.EXPECT().Commit(mock.Anything).Return(nil)
+		var stmts []string
+		tx.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, sql string, _ ...any) pgx.Row {
+			stmts = append(stmts, sql)
+			row := mocks.NewMockRow(t)
+			row.EXPECT().Scan(mock.Anything).Return(nil).Once()
+			return row
+		})
+		svc := postgres.NewCleanupService(createMockBeginner(t, nil, tx), 30)
+		svc.AccessLogRetentionDays = days
+		if err := svc.CleanupOldData(context.Background()); err != nil {
+			t.Fatalf("cleanup: %v", err)
+		}
+		return stmts
+	}
+	deletesAccessLog := func(stmts []string) bool {
+		for _, sql := range stmts {
+			if strings.Contains(sql, "DELETE FROM access_log") {
+				return true
+			}
+		}
+		return false
+	}
+	if deletesAccessLog(run(0)) {
+		t.Error("access log deleted without a retention")
+	}
+	if !deletesAccessLog(run(365)) {
+		t.Error("access log not deleted with a retention")
+	}
+}
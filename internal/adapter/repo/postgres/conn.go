@@ -7,23 +7,133 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/exaring/otelpgx"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
 )
 
+// hotStatements are prepared eagerly on every new connection so the most
+// frequent queries (status polling, status transitions, result writes) skip
+// the parse/plan round trip from the first call. Statements are registered
+// under their own SQL text, which lets pgx reuse them transparently for
+// regular Exec/QueryRow calls with the same SQL.
+var hotStatements = []string{
+	getJobSQL,
+	updateJobStatusSQL,
+	upsertResultSQL,
+	getResultByJobIDSQL,
+}
+
+// DefaultPoolConfig returns the pool settings used when no explicit
+// configuration is supplied.
+func DefaultPoolConfig() config.DBPoolConfig {
+	return config.DBPoolConfig{
+		MaxConns:               10,
+		MinConns:               0,
+		MaxConnLifetime:        time.Hour,
+		MaxConnIdleTime:        5 * time.Minute,
+		HealthCheckPeriod:      time.Minute,
+		StatementCacheCapacity: 512,
+		QueryExecMode:          "cache_statement",
+	}
+}
+
 // NewPool creates a pgx connection pool from the provided DSN and returns it.
 // The pool is configured with sane defaults for this application and includes
 // OpenTelemetry tracing for distributed tracing visibility in Jaeger.
 func NewPool(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
+	return NewPoolWithConfig(ctx, dsn, DefaultPoolConfig())
+}
+
+// NewPoolWithConfig creates a pgx connection pool using the given tuning knobs.
+func NewPoolWithConfig(ctx context.Context, dsn string, pc config.DBPoolConfig) (*pgxpool.Pool, error) {
+	cfg, err := buildPoolConfig(dsn, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record connection pool stats for metrics
+	if err := otelpgx.RecordStats(pool); err != nil {
+		slog.Warn("failed to record pgx stats", slog.Any("error", err))
+	}
+
+	return pool, nil
+}
+
+// buildPoolConfig parses the DSN and applies pool tuning on top of it.
+// Zero values in pc fall back to DefaultPoolConfig. SSLMode and SSLRootCert
+// override the DSN; IAMAuth installs a BeforeConnect hook that supplies a
+// fresh token as the password.
+func buildPoolConfig(dsn string, pc config.DBPoolConfig) (*pgxpool.Config, error) {
+	dsn, err := withSSLParams(dsn, pc.SSLMode, pc.SSLRootCert)
+	if err != nil {
+		return nil, err
+	}
 	cfg, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, err
 	}
-	cfg.MaxConns = 10
-	cfg.MaxConnIdleTime = 5 * time.Minute
+	src, err := newIAMTokenSource(cfg.ConnConfig, pc)
+	if err != nil {
+		return nil, err
+	}
+	if src != nil {
+		cfg.BeforeConnect = (&cachedToken{src: src, now: time.Now}).beforeConnect
+	}
+	def := DefaultPoolConfig()
+	if pc.MaxConns <= 0 {
+		pc.MaxConns = def.MaxConns
+	}
+	if pc.MinConns < 0 {
+		pc.MinConns = 0
+	}
+	if pc.MinConns > pc.MaxConns {
+		pc.MinConns = pc.MaxConns
+	}
+	if pc.MaxConnLifetime <= 0 {
+		pc.MaxConnLifetime = def.MaxConnLifetime
+	}
+	if pc.MaxConnIdleTime <= 0 {
+		pc.MaxConnIdleTime = def.MaxConnIdleTime
+	}
+	if pc.HealthCheckPeriod <= 0 {
+		pc.HealthCheckPeriod = def.HealthCheckPeriod
+	}
+	if pc.StatementCacheCapacity <= 0 {
+		pc.StatementCacheCapacity = def.StatementCacheCapacity
+	}
+	mode, err := parseQueryExecMode(pc.QueryExecMode)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.MaxConns = pc.MaxConns
+	cfg.MinConns = pc.MinConns
+	cfg.MaxConnLifetime = pc.MaxConnLifetime
+	cfg.MaxConnIdleTime = pc.MaxConnIdleTime
+	cfg.HealthCheckPeriod = pc.HealthCheckPeriod
+	cfg.ConnConfig.DefaultQueryExecMode = mode
+	cfg.ConnConfig.StatementCacheCapacity = pc.StatementCacheCapacity
+
+	// Server-side prepared statements are only safe when talking to Postgres
+	// directly (or via a session-mode pooler); other modes are typically
+	// selected for PgBouncer transaction pooling, so skip the eager prepare.
+	if mode == pgx.QueryExecModeCacheStatement {
+		cfg.AfterConnect = prepareHotStatements
+	}
 
 	// Add OpenTelemetry tracing to PostgreSQL connections.
 	// Note: otelpgx.NewTracer() captures the global tracer provider at creation time,
@@ -31,16 +141,76 @@ func NewPool(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
 	cfg.ConnConfig.Tracer = otelpgx.NewTracer(
 		otelpgx.WithTrimSQLInSpanName(),
 	)
+	return cfg, nil
+}
 
-	pool, err := pgxpool.NewWithConfig(ctx, cfg)
-	if err != nil {
-		return nil, err
+// prepareHotStatements prepares hotStatements on a freshly opened connection.
+func prepareHotStatements(ctx context.Context, conn *pgx.Conn) error {
+	for _, sql := range hotStatements {
+		if _, err := conn.Prepare(ctx, sql, sql); err != nil {
+			return fmt.Errorf("op=pool.prepare: %w", err)
+		}
 	}
+	return nil
+}
 
-	// Record connection pool stats for metrics
-	if err := otelpgx.RecordStats(pool); err != nil {
-		slog.Warn("failed to record pgx stats", slog.Any("error", err))
+// parseQueryExecMode maps a configuration string to a pgx.QueryExecMode.
+func parseQueryExecMode(s string) (pgx.QueryExecMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "cache_statement":
+		return pgx.QueryExecModeCacheStatement, nil
+	case "cache_describe":
+		return pgx.QueryExecModeCacheDescribe, nil
+	case "describe_exec":
+		return pgx.QueryExecModeDescribeExec, nil
+	case "exec":
+		return pgx.QueryExecModeExec, nil
+	case "simple_protocol":
+		return pgx.QueryExecModeSimpleProtocol, nil
+	default:
+		return 0, fmt.Errorf("op=pool.config: unknown query exec mode %q", s)
 	}
+}
 
-	return pool, nil
+// sslModes are the libpq sslmode values pgx understands.
+var sslModes = map[string]bool{
+	"disable": true, "allow": true, "prefer": true,
+	"require": true, "verify-ca": true, "verify-full": true,
+}
+
+// withSSLParams sets sslmode and sslrootcert on dsn, in URL or keyword/value
+// form, leaving the DSN untouched for empty values. pgx then builds the TLS
+// config with libpq semantics, including verify-full hostname checks.
+func withSSLParams(dsn, mode, rootCert string) (string, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	rootCert = strings.TrimSpace(rootCert)
+	if mode != "" && !sslModes[mode] {
+		return "", fmt.Errorf("op=pool.config: unknown sslmode %q", mode)
+	}
+	if mode == "" && rootCert == "" {
+		return dsn, nil
+	}
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return "", fmt.Errorf("op=pool.config: %w", err)
+		}
+		q := u.Query()
+		if mode != "" {
+			q.Set("sslmode", mode)
+		}
+		if rootCert != "" {
+			q.Set("sslrootcert", rootCert)
+		}
+		u.RawQuery = q.Encode()
+		return u.String(), nil
+	}
+	// Later keywords win in keyword/value DSNs.
+	if mode != "" {
+		dsn += " sslmode=" + mode
+	}
+	if rootCert != "" {
+		dsn += " sslrootcert='" + strings.ReplaceAll(rootCert, "'", `\'`) + "'"
+	}
+	return strings.TrimSpace(dsn), nil
 }
@@ -2,7 +2,22 @@ package postgres
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
 )
 
 func TestNewPool_InvalidDSN(t *testing.T) {
@@ -46,3 +61,157 @@ func TestNewPool_InvalidPort(t *testing.T) {
 		t.Log("No error for invalid port (unexpected but not failing test)")
 	}
 }
+
+func TestBuildPoolConfig_AppliesTuning(t *testing.T) {
+	pc := config.DBPoolConfig{
+		MaxConns:               20,
+		MinConns:               2,
+		MaxConnLifetime:        30 * time.Minute,
+		MaxConnIdleTime:        time.Minute,
+		HealthCheckPeriod:      15 * time.Second,
+		StatementCacheCapacity: 128,
+		QueryExecMode:          "cache_statement",
+	}
+	cfg, err := buildPoolConfig("postgres://u:p@localhost:5432/db", pc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxConns != 20 || cfg.MinConns != 2 {
+		t.Fatalf("conns = (%d,%d), want (20,2)", cfg.MaxConns, cfg.MinConns)
+	}
+	if cfg.MaxConnLifetime != 30*time.Minute || cfg.MaxConnIdleTime != time.Minute || cfg.HealthCheckPeriod != 15*time.Second {
+		t.Fatalf("unexpected durations: %v %v %v", cfg.MaxConnLifetime, cfg.MaxConnIdleTime, cfg.HealthCheckPeriod)
+	}
+	if cfg.ConnConfig.StatementCacheCapacity != 128 {
+		t.Fatalf("StatementCacheCapacity = %d, want 128", cfg.ConnConfig.StatementCacheCapacity)
+	}
+	if cfg.ConnConfig.DefaultQueryExecMode != pgx.QueryExecModeCacheStatement {
+		t.Fatalf("unexpected exec mode %v", cfg.ConnConfig.DefaultQueryExecMode)
+	}
+	if cfg.AfterConnect == nil {
+		t.Fatalf("expected hot statements to be prepared on connect")
+	}
+}
+
+func TestBuildPoolConfig_DefaultsAndClamping(t *testing.T) {
+	cfg, err := buildPoolConfig("postgres://u:p@localhost:5432/db", config.DBPoolConfig{MinConns: 50})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxConns != 10 || cfg.MinConns != 10 {
+		t.Fatalf("conns = (%d,%d), want (10,10)", cfg.MaxConns, cfg.MinConns)
+	}
+	if cfg.MaxConnIdleTime != 5*time.Minute {
+		t.Fatalf("MaxConnIdleTime = %v, want 5m", cfg.MaxConnIdleTime)
+	}
+}
+
+func TestBuildPoolConfig_ExecModes(t *testing.T) {
+	cfg, err := buildPoolConfig("postgres://u:p@localhost:5432/db", config.DBPoolConfig{QueryExecMode: "simple_protocol"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ConnConfig.DefaultQueryExecMode != pgx.QueryExecModeSimpleProtocol {
+		t.Fatalf("unexpected exec mode %v", cfg.ConnConfig.DefaultQueryExecMode)
+	}
+	if cfg.AfterConnect != nil {
+		t.Fatalf("hot statements must not be prepared outside cache_statement mode")
+	}
+	if _, err := buildPoolConfig("postgres://u:p@localhost:5432/db", config.DBPoolConfig{QueryExecMode: "bogus"}); err == nil {
+		t.Fatalf("expected error for unknown exec mode")
+	}
+}
+
+func TestWithSSLParams(t *testing.T) {
+	got, err := withSSLParams("postgres://u:p@db:5432/app?sslmode=disable", "verify-full", "/etc/ssl/rds.pem")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "postgres://u:p@db:5432/app?sslmode=verify-full&sslrootcert=%2Fetc%2Fssl%2Frds.pem" {
+		t.Fatalf("url dsn = %q", got)
+	}
+	got, err = withSSLParams("host=db user=u sslmode=disable", "require", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "host=db user=u sslmode=disable sslmode=require" {
+		t.Fatalf("keyword dsn = %q", got)
+	}
+	if got, _ := withSSLParams("postgres://db/app", "", ""); got != "postgres://db/app" {
+		t.Fatalf("dsn changed without overrides: %q", got)
+	}
+	if _, err := withSSLParams("postgres://db/app", "strict", ""); err == nil {
+		t.Fatalf("expected error for unknown sslmode")
+	}
+}
+
+func TestBuildPoolConfig_VerifyFull(t *testing.T) {
+	ca := writeTestCA(t)
+	cfg, err := buildPoolConfig("postgres://u:p@db.example.com:5432/app?sslmode=disable", config.DBPoolConfig{SSLMode: "verify-full", SSLRootCert: ca})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tc := cfg.ConnConfig.TLSConfig
+	if tc == nil || tc.ServerName != "db.example.com" || tc.RootCAs == nil || tc.InsecureSkipVerify {
+		t.Fatalf("unexpected TLS config: %+v", tc)
+	}
+	if len(cfg.ConnConfig.Fallbacks) != 0 {
+		t.Fatalf("verify-full must not fall back to plaintext")
+	}
+	if _, err := buildPoolConfig("postgres://u:p@db:5432/app", config.DBPoolConfig{SSLMode: "verify-full", SSLRootCert: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+		t.Fatalf("expected error for missing CA bundle")
+	}
+}
+
+func TestBuildPoolConfig_IAMAuth(t *testing.T) {
+	cfg, err := buildPoolConfig("postgres://app@db:5432/app", config.DBPoolConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.BeforeConnect != nil {
+		t.Fatalf("BeforeConnect must be unset without IAM auth")
+	}
+	cfg, err = buildPoolConfig("postgres://app@db:5432/app", config.DBPoolConfig{
+		IAMAuth: "aws", AWSRegion: "eu-west-1", AWSAccessKeyID: "AKID", AWSSecretAccessKey: "secret",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cc := cfg.ConnConfig.Copy()
+	if err := cfg.BeforeConnect(context.Background(), cc); err != nil {
+		t.Fatalf("BeforeConnect: %v", err)
+	}
+	if !strings.HasPrefix(cc.Password, "db:5432/?Action=connect&DBUser=app&") {
+		t.Fatalf("password is not an RDS token: %q", cc.Password)
+	}
+	if _, err := buildPoolConfig("postgres://app@db:5432/app", config.DBPoolConfig{IAMAuth: "azure"}); err == nil {
+		t.Fatalf("expected error for unknown IAM auth")
+	}
+}
+
+// writeTestCA writes a self-signed CA certificate and returns its path.
+func writeTestCA(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ConsumerPauseRepo persists the paused queue topics in consumer_pauses.
+type ConsumerPauseRepo struct{ Pool PgxPool }
+
+// NewConsumerPauseRepo constructs a ConsumerPauseRepo with the given pool.
+func NewConsumerPauseRepo(p PgxPool) *ConsumerPauseRepo { return &ConsumerPauseRepo{Pool: p} }
+
+// List returns the paused topics ordered by topic.
+func (r *ConsumerPauseRepo) List(ctx domain.Context) ([]domain.ConsumerPause, error) {
+	tracer := otel.Tracer("repo.consumer_pauses")
+	ctx, span := tracer.Start(ctx, "consumer_pauses.List")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "consumer_pauses"),
+	)
+	rows, err := r.Pool.Query(ctx, `SELECT topic, reason, paused_by, paused_at FROM consumer_pauses ORDER BY topic`)
+	if err != nil {
+		return nil, fmt.Errorf("op=consumer_pause.list: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.ConsumerPause
+	for rows.Next() {
+		var p domain.ConsumerPause
+		if err := rows.Scan(&p.Topic, &p.Reason, &p.PausedBy, &p.PausedAt); err != nil {
+			return nil, fmt.Errorf("op=consumer_pause.list_scan: %w", err)
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=consumer_pause.list_rows: %w", err)
+	}
+	return out, nil
+}
+
+// Pause stores p, replacing an existing pause of the topic.
+func (r *ConsumerPauseRepo) Pause(ctx domain.Context, p domain.ConsumerPause) error {
+	tracer := otel.Tracer("repo.consumer_pauses")
+	ctx, span := tracer.Start(ctx, "consumer_pauses.Pause")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "consumer_pauses"),
+		attribute.String("messaging.destination", p.Topic),
+	)
+	q := `INSERT INTO consumer_pauses (topic, reason, paused_by, paused_at) VALUES ($1,$2,$3,$4)
+		ON CONFLICT (topic) DO UPDATE SET
+			reason = EXCLUDED.reason,
+			paused_by = EXCLUDED.paused_by,
+			paused_at = EXCLUDED.paused_at`
+	if _, err := r.Pool.Exec(ctx, q, p.Topic, p.Reason, p.PausedBy, p.PausedAt); err != nil {
+		return fmt.Errorf("op=consumer_pause.pause: %w", err)
+	}
+	return nil
+}
+
+// Resume removes the pause of topic and reports whether it was paused.
+func (r *ConsumerPauseRepo) Resume(ctx domain.Context, topic string) (bool, error) {
+	tracer := otel.Tracer("repo.consumer_pauses")
+	ctx, span := tracer.Start(ctx, "consumer_pauses.Resume")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "DELETE"),
+		attribute.String("db.sql.table", "consumer_pauses"),
+		attribute.String("messaging.destination", topic),
+	)
+	tag, err := r.Pool.Exec(ctx, `DELETE FROM consumer_pauses WHERE topic=$1`, topic)
+	if err != nil {
+		return false, fmt.Errorf("op=consumer_pause.resume: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
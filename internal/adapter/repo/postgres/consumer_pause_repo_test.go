@@ -0,0 +1,71 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestConsumerPauseRepo_PauseAndResume(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewConsumerPauseRepo(pool)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "INSERT INTO consumer_pauses") && strings.Contains(sql, "ON CONFLICT (topic)")
+	}), []any{"evaluate-jobs", "provider outage", "admin", at}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Pause(ctx, domain.ConsumerPause{Topic: "evaluate-jobs", Reason: "provider outage", PausedBy: "admin", PausedAt: at}))
+
+	pool.EXPECT().Exec(mock.Anything, "DELETE FROM consumer_pauses WHERE topic=$1", []any{"evaluate-jobs"}).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
+	resumed, err := repo.Resume(ctx, "evaluate-jobs")
+	require.NoError(t, err)
+	assert.True(t, resumed)
+
+	pool.EXPECT().Exec(mock.Anything, "DELETE FROM consumer_pauses WHERE topic=$1", []any{"dlq-jobs"}).Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+	resumed, err = repo.Resume(ctx, "dlq-jobs")
+	require.NoError(t, err)
+	assert.False(t, resumed)
+}
+
+func TestConsumerPauseRepo_List(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewConsumerPauseRepo(pool)
+	at := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+
+	rows := mocks.NewMockRows(t)
+	calls := 0
+	rows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	rows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "evaluate-jobs"
+		*(dest[1].(*string)) = "provider outage"
+		*(dest[2].(*string)) = "admin"
+		*(dest[3].(*time.Time)) = at
+	}).Return(nil).Once()
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "FROM consumer_pauses")
+	})).Return(rows, nil).Once()
+	got, err := repo.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ConsumerPause{{Topic: "evaluate-jobs", Reason: "provider outage", PausedBy: "admin", PausedAt: at}}, got)
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+	_, err = repo.List(context.Background())
+	assert.ErrorContains(t, err, "op=consumer_pause.list")
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRDSTokenSource_Token(t *testing.T) {
+	now := time.Date(2025, 12, 19, 8, 30, 0, 0, time.UTC)
+	src := &rdsTokenSource{
+		endpoint:     "mydb.abc.eu-west-1.rds.amazonaws.com:5432",
+		user:         "app",
+		region:       "eu-west-1",
+		accessKeyID:  "AKIDEXAMPLE",
+		secretKey:    "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		sessionToken: "tok/en+=",
+		now:          func() time.Time { return now },
+	}
+	tok, exp, err := src.token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !exp.Equal(now.Add(15 * time.Minute)) {
+		t.Fatalf("expires = %v", exp)
+	}
+	host, rawQuery, ok := strings.Cut(tok, "/?")
+	if !ok || host != "mydb.abc.eu-west-1.rds.amazonaws.com:5432" {
+		t.Fatalf("token has no endpoint prefix: %q", tok)
+	}
+	q, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		t.Fatalf("token query: %v", err)
+	}
+	want := map[string]string{
+		"Action":               "connect",
+		"DBUser":               "app",
+		"X-Amz-Algorithm":      "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":     "AKIDEXAMPLE/20251219/eu-west-1/rds-db/aws4_request",
+		"X-Amz-Date":           "20251219T083000Z",
+		"X-Amz-Expires":        "900",
+		"X-Amz-SignedHeaders":  "host",
+		"X-Amz-Security-Token": "tok/en+=",
+	}
+	for k, v := range want {
+		if q.Get(k) != v {
+			t.Fatalf("%s = %q, want %q", k, q.Get(k), v)
+		}
+	}
+	if len(q.Get("X-Amz-Signature")) != 64 {
+		t.Fatalf("unexpected signature %q", q.Get("X-Amz-Signature"))
+	}
+	// Signing is deterministic for a fixed clock and changes with the user.
+	again, _, _ := src.token(context.Background())
+	src.user = "other"
+	other, _, _ := src.token(context.Background())
+	if again != tok || other == tok {
+		t.Fatalf("signature is not bound to the request")
+	}
+}
+
+func TestGCPTokenSource_Token(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" || r.URL.Path != "/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"ya29.token","expires_in":3599,"token_type":"Bearer"}`))
+	}))
+	defer srv.Close()
+	now := time.Date(2025, 12, 19, 8, 30, 0, 0, time.UTC)
+	src := &gcpTokenSource{client: srv.Client(), url: srv.URL, now: func() time.Time { return now }}
+	tok, exp, err := src.token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tok != "ya29.token" || !exp.Equal(now.Add(3599*time.Second)) {
+		t.Fatalf("token = %q expires %v", tok, exp)
+	}
+
+	src.url = srv.URL + "/missing"
+	if _, _, err := src.token(context.Background()); err == nil {
+		t.Fatalf("expected error for non-200 response")
+	}
+}
+
+type fakeTokenSource struct {
+	calls int
+	ttl   time.Duration
+	now   *time.Time
+	err   error
+}
+
+func (f *fakeTokenSource) token(context.Context) (string, time.Time, error) {
+	if f.err != nil {
+		return "", time.Time{}, f.err
+	}
+	f.calls++
+	return "tok-" + string(rune('0'+f.calls)), f.now.Add(f.ttl), nil
+}
+
+func TestCachedToken_RefreshesBeforeExpiry(t *testing.T) {
+	now := time.Date(2025, 12, 19, 8, 30, 0, 0, time.UTC)
+	src := &fakeTokenSource{ttl: 15 * time.Minute, now: &now}
+	c := &cachedToken{src: src, now: func() time.Time { return now }}
+	ctx := context.Background()
+
+	for range 3 {
+		if tok, err := c.get(ctx); err != nil || tok != "tok-1" {
+			t.Fatalf("get = %q, %v", tok, err)
+		}
+	}
+	now = now.Add(12 * time.Minute)
+	if tok, _ := c.get(ctx); tok != "tok-1" {
+		t.Fatalf("token renewed too early: %q", tok)
+	}
+	now = now.Add(2 * time.Minute)
+	if tok, _ := c.get(ctx); tok != "tok-2" {
+		t.Fatalf("token not renewed near expiry: %q", tok)
+	}
+
+	src.err = errors.New("metadata down")
+	now = now.Add(time.Hour)
+	if _, err := c.get(ctx); err == nil {
+		t.Fatalf("expected refresh error")
+	}
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+const jobBulkOperationColumns = `id, action, filter_status, filter_older_than_seconds, filter_failure_reason, reason, started_by,
+	status, matched, truncated, processed, succeeded, skipped, failed, created_at, updated_at, finished_at`
+
+// JobBulkRepo selects and fails the jobs of bulk operations and persists the
+// operations in job_bulk_operations.
+type JobBulkRepo struct{ Pool PgxPool }
+
+// NewJobBulkRepo constructs a JobBulkRepo with the given pool.
+func NewJobBulkRepo(p PgxPool) *JobBulkRepo { return &JobBulkRepo{Pool: p} }
+
+func startJobBulkSpan(ctx domain.Context, name, op, table string) (domain.Context, func()) {
+	tracer := otel.Tracer("repo.job_bulk")
+	ctx, span := tracer.Start(ctx, "job_bulk."+name)
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", op),
+		attribute.String("db.sql.table", table),
+	)
+	return ctx, func() { span.End() }
+}
+
+// Candidates returns up to limit jobs in status last updated before before,
+// least recently updated first; 0 is unlimited.
+func (r *JobBulkRepo) Candidates(ctx domain.Context, status domain.JobStatus, before time.Time, limit int) ([]domain.Job, error) {
+	ctx, end := startJobBulkSpan(ctx, "Candidates", "SELECT", "jobs")
+	defer end()
+	q := `SELECT id, status, error, created_at, updated_at FROM jobs WHERE status=$1 AND updated_at < $2 ORDER BY updated_at, id`
+	args := []any{string(status), before}
+	if limit > 0 {
+		q += ` LIMIT $3`
+		args = append(args, limit)
+	}
+	rows, err := r.Pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("op=job_bulk.candidates: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.Job
+	for rows.Next() {
+		var j domain.Job
+		var st string
+		if err := rows.Scan(&j.ID, &st, &j.Error, &j.CreatedAt, &j.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("op=job_bulk.candidates_scan: %w", err)
+		}
+		j.Status = domain.JobStatus(st)
+		out = append(out, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=job_bulk.candidates_rows: %w", err)
+	}
+	return out, nil
+}
+
+// Fail moves job id from status from to failed with errMsg unless it changed
+// since before; it reports whether the job was moved.
+func (r *JobBulkRepo) Fail(ctx domain.Context, id string, from domain.JobStatus, before time.Time, errMsg string) (bool, error) {
+	ctx, end := startJobBulkSpan(ctx, "Fail", "UPDATE", "jobs")
+	defer end()
+	q := `UPDATE jobs SET status='failed', error=$4, updated_at=now() WHERE id=$1 AND status=$2 AND updated_at < $3`
+	tag, err := r.Pool.Exec(ctx, q, id, string(from), before, errMsg)
+	if err != nil {
+		return false, fmt.Errorf("op=job_bulk.fail: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// CreateOperation stores op with a new ID and returns it.
+func (r *JobBulkRepo) CreateOperation(ctx domain.Context, op domain.JobBulkOperation) (domain.JobBulkOperation, error) {
+	ctx, end := startJobBulkSpan(ctx, "CreateOperation", "INSERT", "job_bulk_operations")
+	defer end()
+	op.ID = uuid.New().String()
+	q := `INSERT INTO job_bulk_operations (` + jobBulkOperationColumns + `)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17)`
+	_, err := r.Pool.Exec(ctx, q, op.ID, string(op.Action), string(op.Filter.Status), int64(op.Filter.OlderThan/time.Second),
+		op.Filter.FailureReason, op.Reason, op.StartedBy, op.Status, op.Matched, op.Truncated,
+		op.Processed, op.Succeeded, op.Skipped, op.Failed, op.CreatedAt, op.UpdatedAt, op.FinishedAt)
+	if err != nil {
+		return domain.JobBulkOperation{}, fmt.Errorf("op=job_bulk.create_operation: %w", err)
+	}
+	return op, nil
+}
+
+// UpdateOperation stores the progress and status of op.
+func (r *JobBulkRepo) UpdateOperation(ctx domain.Context, op domain.JobBulkOperation) error {
+	ctx, end := startJobBulkSpan(ctx, "UpdateOperation", "UPDATE", "job_bulk_operations")
+	defer end()
+	q := `UPDATE job_bulk_operations SET status=$2, processed=$3, succeeded=$4, skipped=$5, failed=$6, updated_at=$7, finished_at=$8 WHERE id=$1`
+	tag, err := r.Pool.Exec(ctx, q, op.ID, op.Status, op.Processed, op.Succeeded, op.Skipped, op.Failed, op.UpdatedAt, op.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("op=job_bulk.update_operation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("op=job_bulk.update_operation: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// GetOperation returns an operation by ID.
+func (r *JobBulkRepo) GetOperation(ctx domain.Context, id string) (domain.JobBulkOperation, error) {
+	ctx, end := startJobBulkSpan(ctx, "GetOperation", "SELECT", "job_bulk_operations")
+	defer end()
+	op, err := scanJobBulkOperation(r.Pool.QueryRow(ctx, `SELECT `+jobBulkOperationColumns+` FROM job_bulk_operations WHERE id=$1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.JobBulkOperation{}, fmt.Errorf("op=job_bulk.get_operation: %w", domain.ErrNotFound)
+		}
+		return domain.JobBulkOperation{}, fmt.Errorf("op=job_bulk.get_operation: %w", err)
+	}
+	return op, nil
+}
+
+// ListOperations returns the latest limit operations, newest first.
+func (r *JobBulkRepo) ListOperations(ctx domain.Context, limit int) ([]domain.JobBulkOperation, error) {
+	ctx, end := startJobBulkSpan(ctx, "ListOperations", "SELECT", "job_bulk_operations")
+	defer end()
+	rows, err := r.Pool.Query(ctx, `SELECT `+jobBulkOperationColumns+` FROM job_bulk_operations ORDER BY created_at DESC, id LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("op=job_bulk.list_operations: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.JobBulkOperation
+	for rows.Next() {
+		op, err := scanJobBulkOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("op=job_bulk.list_operations_scan: %w", err)
+		}
+		out = append(out, op)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=job_bulk.list_operations_rows: %w", err)
+	}
+	return out, nil
+}
+
+func scanJobBulkOperation(row pgx.Row) (domain.JobBulkOperation, error) {
+	var op domain.JobBulkOperation
+	var action, status string
+	var olderThan int64
+	err := row.Scan(&op.ID, &action, &status, &olderThan, &op.Filter.FailureReason, &op.Reason, &op.StartedBy,
+		&op.Status, &op.Matched, &op.Truncated, &op.Processed, &op.Succeeded, &op.Skipped, &op.Failed,
+		&op.CreatedAt, &op.UpdatedAt, &op.FinishedAt)
+	op.Action, op.Filter.Status = domain.JobBulkAction(action), domain.JobStatus(status)
+	op.Filter.OlderThan = time.Duration(olderThan) * time.Second
+	return op, err
+}
@@ -0,0 +1,110 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestJobBulkRepo_CandidatesAndFail(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobBulkRepo(pool)
+	ctx := context.Background()
+	before := time.Date(2026, 1, 3, 9, 0, 0, 0, time.UTC)
+
+	rows := mocks.NewMockRows(t)
+	calls := 0
+	rows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	rows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "job-1"
+		*(dest[1].(*string)) = "processing"
+		*(dest[3].(*time.Time)) = before.Add(-time.Hour)
+		*(dest[4].(*time.Time)) = before.Add(-time.Hour)
+	}).Return(nil).Once()
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "FROM jobs WHERE status=$1 AND updated_at < $2") && strings.HasSuffix(q, "LIMIT $3")
+	}), []any{"processing", before, 10}).Return(rows, nil).Once()
+	jobs, err := repo.Candidates(ctx, domain.JobProcessing, before, 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, domain.JobProcessing, jobs[0].Status)
+
+	// Failing is conditional on the job not having moved on.
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "UPDATE jobs SET status='failed'") }),
+		[]any{"job-1", "processing", before, "cancelled by admin"}).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	moved, err := repo.Fail(ctx, "job-1", domain.JobProcessing, before, "cancelled by admin")
+	require.NoError(t, err)
+	assert.True(t, moved)
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+	moved, err = repo.Fail(ctx, "job-2", domain.JobProcessing, before, "cancelled by admin")
+	require.NoError(t, err)
+	assert.False(t, moved)
+}
+
+func TestJobBulkRepo_Operations(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobBulkRepo(pool)
+	ctx := context.Background()
+	at := time.Date(2026, 1, 3, 9, 0, 0, 0, time.UTC)
+	op := domain.JobBulkOperation{
+		Action:    domain.JobBulkRequeue,
+		Filter:    domain.JobBulkFilter{Status: domain.JobFailed, OlderThan: 30 * time.Minute, FailureReason: "rate_limit"},
+		StartedBy: "admin",
+		Status:    domain.JobBulkRunning,
+		Matched:   3,
+		CreatedAt: at,
+		UpdatedAt: at,
+	}
+
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "INSERT INTO job_bulk_operations") }), mock.Anything).
+		Run(func(_ context.Context, _ string, args ...any) {
+			assert.Equal(t, "requeue", args[1])
+			assert.Equal(t, int64(1800), args[3])
+			assert.Equal(t, "rate_limit", args[4])
+		}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	created, err := repo.CreateOperation(ctx, op)
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ID)
+
+	created.Processed, created.Succeeded = 3, 3
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "UPDATE job_bulk_operations") }),
+		[]any{created.ID, domain.JobBulkRunning, 3, 3, 0, 0, at, (*time.Time)(nil)}).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	require.NoError(t, repo.UpdateOperation(ctx, created))
+
+	row := mocks.NewMockRow(t)
+	row.EXPECT().Scan(mock.Anything).Run(func(dest ...any) {
+		*(dest[0].(*string)) = created.ID
+		*(dest[1].(*string)) = "requeue"
+		*(dest[2].(*string)) = "failed"
+		*(dest[3].(*int64)) = 1800
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.Contains(q, "FROM job_bulk_operations WHERE id=$1") }), []any{created.ID}).
+		Return(row).Once()
+	got, err := repo.GetOperation(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobBulkRequeue, got.Action)
+	assert.Equal(t, domain.JobBulkFilter{Status: domain.JobFailed, OlderThan: 30 * time.Minute}, got.Filter)
+
+	missing := mocks.NewMockRow(t)
+	missing.EXPECT().Scan(mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"nope"}).Return(missing).Once()
+	_, err = repo.GetOperation(ctx, "nope")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// JobLockRepo leases jobs to consumers with fencing tokens.
+type JobLockRepo struct{ Pool PgxPool }
+
+// NewJobLockRepo constructs a JobLockRepo with the given pool.
+func NewJobLockRepo(p PgxPool) *JobLockRepo { return &JobLockRepo{Pool: p} }
+
+// Acquire leases jobID to owner for ttl. An expired lease is taken over with
+// the next token; a live one yields ErrConflict.
+func (r *JobLockRepo) Acquire(ctx domain.Context, jobID, owner string, ttl time.Duration) (int64, error) {
+	tracer := otel.Tracer("repo.job_locks")
+	ctx, span := tracer.Start(ctx, "job_locks.Acquire")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "job_locks"),
+		attribute.String("job.id", jobID),
+	)
+	q := `INSERT INTO job_locks (job_id, owner, token, acquired_at, expires_at)
+		VALUES ($1, $2, 1, now(), now() + make_interval(secs => $3))
+		ON CONFLICT (job_id) DO UPDATE SET
+			owner = EXCLUDED.owner,
+			token = job_locks.token + 1,
+			acquired_at = EXCLUDED.acquired_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE job_locks.expires_at < now()
+		RETURNING token`
+	var token int64
+	if err := r.Pool.QueryRow(ctx, q, jobID, owner, ttl.Seconds()).Scan(&token); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("op=job_lock.acquire: %w", domain.ErrConflict)
+		}
+		return 0, fmt.Errorf("op=job_lock.acquire: %w", err)
+	}
+	return token, nil
+}
+
+// Renew extends the lease held with token by ttl. A lease that expired but
+// was not taken over is still renewed.
+func (r *JobLockRepo) Renew(ctx domain.Context, jobID string, token int64, ttl time.Duration) error {
+	tracer := otel.Tracer("repo.job_locks")
+	ctx, span := tracer.Start(ctx, "job_locks.Renew")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPDATE"),
+		attribute.String("db.sql.table", "job_locks"),
+		attribute.String("job.id", jobID),
+	)
+	tag, err := r.Pool.Exec(ctx, `UPDATE job_locks SET expires_at = now() + make_interval(secs => $3) WHERE job_id=$1 AND token=$2`, jobID, token, ttl.Seconds())
+	if err != nil {
+		return fmt.Errorf("op=job_lock.renew: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("op=job_lock.renew: %w", domain.ErrConflict)
+	}
+	return nil
+}
+
+// Release deletes the lease held with token.
+func (r *JobLockRepo) Release(ctx domain.Context, jobID string, token int64) error {
+	tracer := otel.Tracer("repo.job_locks")
+	ctx, span := tracer.Start(ctx, "job_locks.Release")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "DELETE"),
+		attribute.String("db.sql.table", "job_locks"),
+		attribute.String("job.id", jobID),
+	)
+	if _, err := r.Pool.Exec(ctx, `DELETE FROM job_locks WHERE job_id=$1 AND token=$2`, jobID, token); err != nil {
+		return fmt.Errorf("op=job_lock.release: %w", err)
+	}
+	return nil
+}
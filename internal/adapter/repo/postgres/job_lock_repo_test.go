@@ -0,0 +1,55 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestJobLockRepo_Acquire(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobLockRepo(pool)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*int64)) = 3
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"job-1", "worker-a", 600.0}).Return(row).Once()
+	token, err := repo.Acquire(context.Background(), "job-1", "worker-a", 10*time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), token)
+
+	// A live lease leaves the conditional upsert without a row.
+	held := mocks.NewMockRow(t)
+	held.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(held).Once()
+	_, err = repo.Acquire(context.Background(), "job-1", "worker-b", time.Minute)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
+
+func TestJobLockRepo_RenewAndRelease(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobLockRepo(pool)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"job-1", int64(3), 60.0}).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	require.NoError(t, repo.Renew(context.Background(), "job-1", 3, time.Minute))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"job-1", int64(2), 60.0}).Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+	assert.ErrorIs(t, repo.Renew(context.Background(), "job-1", 2, time.Minute), domain.ErrConflict)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"job-1", int64(3)}).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
+	require.NoError(t, repo.Release(context.Background(), "job-1", 3))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.Release(context.Background(), "job-1", 3), "op=job_lock.release")
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// JobQueueRepo orders queued jobs, with the bumps of job_bumps ahead of the
+// rest, and records bumps together with their outbox entries.
+type JobQueueRepo struct{ Pool PgxPool }
+
+// NewJobQueueRepo constructs a JobQueueRepo with the given pool.
+func NewJobQueueRepo(p PgxPool) *JobQueueRepo { return &JobQueueRepo{Pool: p} }
+
+// Bump records b for the queued job b.JobID and an outbox entry for p in one
+// transaction.
+func (r *JobQueueRepo) Bump(ctx domain.Context, b domain.JobBump, p domain.EvaluateTaskPayload) error {
+	tracer := otel.Tracer("repo.job_queue")
+	ctx, span := tracer.Start(ctx, "job_queue.Bump")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "INSERT"),
+		attribute.String("db.sql.table", "job_bumps,evaluate_outbox,jobs"),
+		attribute.String("job.id", b.JobID),
+	)
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("op=job_queue.bump_marshal: %w", err)
+	}
+	tx, err := r.Pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return fmt.Errorf("op=job_queue.bump.begin_tx: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			if err := tx.Rollback(ctx); err != nil {
+				slog.Error("failed to rollback job bump transaction", slog.String("job_id", b.JobID), slog.Any("error", err))
+			}
+		}
+	}()
+	q := `INSERT INTO job_bumps (job_id, bumped_by, reason, bumped_at)
+		SELECT $1,$2,$3,$4 WHERE EXISTS (SELECT 1 FROM jobs WHERE id=$1 AND status='queued')
+		ON CONFLICT (job_id) DO NOTHING`
+	tag, err := tx.Exec(ctx, q, b.JobID, b.BumpedBy, b.Reason, b.BumpedAt)
+	if err != nil {
+		return fmt.Errorf("op=job_queue.bump.insert_bump: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("op=job_queue.bump: %w: job is not queued or already bumped", domain.ErrConflict)
+	}
+	if _, err := tx.Exec(ctx, insertOutboxTaskSQL, b.JobID, body, b.BumpedAt); err != nil {
+		return fmt.Errorf("op=job_queue.bump.insert_outbox: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("op=job_queue.bump.commit: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// positionSQL counts the queued jobs ahead of $1: earlier bumps when it is
+// bumped, otherwise every bump and the jobs created before it.
+const positionSQL = `WITH target AS (
+		SELECT j.created_at, b.bumped_at FROM jobs j LEFT JOIN job_bumps b ON b.job_id = j.id WHERE j.id = $1
+	)
+	SELECT count(*) FROM jobs j LEFT JOIN job_bumps b ON b.job_id = j.id, target t
+	WHERE j.status = 'queued' AND j.id <> $1 AND CASE
+		WHEN t.bumped_at IS NOT NULL THEN b.bumped_at < t.bumped_at
+		ELSE b.bumped_at IS NOT NULL OR j.created_at < t.created_at
+	END`
+
+// Position returns how many queued jobs are ahead of jobID; 0 for unknown
+// jobs.
+func (r *JobQueueRepo) Position(ctx domain.Context, jobID string) (int64, error) {
+	tracer := otel.Tracer("repo.job_queue")
+	ctx, span := tracer.Start(ctx, "job_queue.Position")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "jobs,job_bumps"),
+		attribute.String("job.id", jobID),
+	)
+	var n int64
+	if err := r.Pool.QueryRow(ctx, positionSQL, jobID).Scan(&n); err != nil {
+		return 0, fmt.Errorf("op=job_queue.position: %w", err)
+	}
+	return n, nil
+}
+
+// FinishedSince returns how many jobs reached completed or failed since
+// since.
+func (r *JobQueueRepo) FinishedSince(ctx domain.Context, since time.Time) (int64, error) {
+	tracer := otel.Tracer("repo.job_queue")
+	ctx, span := tracer.Start(ctx, "job_queue.FinishedSince")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "jobs"),
+	)
+	var n int64
+	q := `SELECT count(*) FROM jobs WHERE status IN ('completed','failed') AND updated_at >= $1`
+	if err := r.Pool.QueryRow(ctx, q, since).Scan(&n); err != nil {
+		return 0, fmt.Errorf("op=job_queue.finished_since: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,74 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestJobQueueRepo_Bump(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobQueueRepo(pool)
+	tx := mocks.NewMockTx(t)
+	at := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	b := domain.JobBump{JobID: "job-1", BumpedBy: "admin", Reason: "urgent", BumpedAt: at}
+	p := domain.EvaluateTaskPayload{JobID: "job-1", CVID: "cv-1", Bumped: true}
+
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "INSERT INTO job_bumps") }), []any{"job-1", "admin", "urgent", at}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	var stored []byte
+	// The bumped task also replaces the one kept on the job.
+	tx.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "UPDATE jobs SET task=$2") && strings.Contains(q, "INSERT INTO evaluate_outbox")
+	}), mock.Anything).
+		Run(func(_ context.Context, _ string, args ...any) { stored = args[1].([]byte) }).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+	require.NoError(t, repo.Bump(context.Background(), b, p))
+	var got domain.EvaluateTaskPayload
+	require.NoError(t, json.Unmarshal(stored, &got))
+	assert.Equal(t, p, got)
+
+	// A job that is not queued, or already bumped, is left alone.
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 0"), nil).Once()
+	tx.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+	assert.ErrorIs(t, repo.Bump(context.Background(), b, p), domain.ErrConflict)
+}
+
+func TestJobQueueRepo_PositionAndFinishedSince(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobQueueRepo(pool)
+	ctx := context.Background()
+
+	row := mocks.NewMockRow(t)
+	row.EXPECT().Scan(mock.Anything).Run(func(dest ...any) { *(dest[0].(*int64)) = 4 }).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "LEFT JOIN job_bumps") && strings.Contains(q, "j.status = 'queued'")
+	}), []any{"job-1"}).Return(row).Once()
+	n, err := repo.Position(ctx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), n)
+
+	since := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	row = mocks.NewMockRow(t)
+	row.EXPECT().Scan(mock.Anything).Run(func(dest ...any) { *(dest[0].(*int64)) = 12 }).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "status IN ('completed','failed')")
+	}), []any{since}).Return(row).Once()
+	n, err = repo.FinishedSince(ctx, since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), n)
+}
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// jobUsagePhases orders the phases of a usage report.
+var jobUsagePhases = []string{
+	domain.UsagePhaseQueueWait,
+	domain.UsagePhaseExtraction,
+	domain.UsagePhaseRAG,
+	domain.UsagePhaseAI,
+	domain.UsagePhasePostProcessing,
+	domain.UsagePhaseLocal,
+	domain.UsagePhaseTotal,
+}
+
+// JobUsageRepo persists the per-phase timings of completed jobs in
+// job_usage.
+type JobUsageRepo struct{ Pool PgxPool }
+
+// NewJobUsageRepo constructs a JobUsageRepo with the given pool.
+func NewJobUsageRepo(p PgxPool) *JobUsageRepo { return &JobUsageRepo{Pool: p} }
+
+func startJobUsageSpan(ctx domain.Context, name, op string) (domain.Context, func()) {
+	tracer := otel.Tracer("repo.job_usage")
+	ctx, span := tracer.Start(ctx, "job_usage."+name)
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", op),
+		attribute.String("db.sql.table", "job_usage"),
+	)
+	return ctx, func() { span.End() }
+}
+
+// Record stores u, replacing an earlier record of the same job.
+func (r *JobUsageRepo) Record(ctx domain.Context, u domain.JobUsage) error {
+	ctx, end := startJobUsageSpan(ctx, "Record", "UPSERT")
+	defer end()
+	q := `INSERT INTO job_usage (job_id, queue_wait_ms, extraction_ms, rag_ms, ai_ms, ai_calls, post_processing_ms, total_ms, completed_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+		ON CONFLICT (job_id) DO UPDATE SET queue_wait_ms=EXCLUDED.queue_wait_ms, extraction_ms=EXCLUDED.extraction_ms,
+			rag_ms=EXCLUDED.rag_ms, ai_ms=EXCLUDED.ai_ms, ai_calls=EXCLUDED.ai_calls,
+			post_processing_ms=EXCLUDED.post_processing_ms, total_ms=EXCLUDED.total_ms, completed_at=EXCLUDED.completed_at`
+	_, err := r.Pool.Exec(ctx, q, u.JobID, u.QueueWait.Milliseconds(), u.Extraction.Milliseconds(), u.RAG.Milliseconds(),
+		u.AI.Milliseconds(), u.AICalls, u.PostProcessing.Milliseconds(), u.Total.Milliseconds(), u.CompletedAt)
+	if err != nil {
+		return fmt.Errorf("op=job_usage.record: %w", err)
+	}
+	return nil
+}
+
+// Percentiles summarises the jobs completed since since. The local phase is
+// the total less the queue wait, RAG and AI time.
+func (r *JobUsageRepo) Percentiles(ctx domain.Context, since time.Time) (domain.JobUsageReport, error) {
+	ctx, end := startJobUsageSpan(ctx, "Percentiles", "SELECT")
+	defer end()
+	q := `SELECT p.phase, count(*), avg(p.ms)::float8,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY p.ms),
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY p.ms),
+			percentile_cont(0.99) WITHIN GROUP (ORDER BY p.ms)
+		FROM job_usage u
+		CROSS JOIN LATERAL (VALUES
+			('queue_wait', u.queue_wait_ms),
+			('extraction', u.extraction_ms),
+			('rag', u.rag_ms),
+			('ai', u.ai_ms),
+			('post_processing', u.post_processing_ms),
+			('local', GREATEST(u.total_ms - u.queue_wait_ms - u.rag_ms - u.ai_ms, 0)),
+			('total', u.total_ms)
+		) AS p(phase, ms)
+		WHERE u.completed_at >= $1
+		GROUP BY p.phase`
+	rows, err := r.Pool.Query(ctx, q, since)
+	if err != nil {
+		return domain.JobUsageReport{}, fmt.Errorf("op=job_usage.percentiles: %w", err)
+	}
+	defer rows.Close()
+	rep := domain.JobUsageReport{Since: since}
+	byPhase := map[string]domain.JobUsagePercentiles{}
+	for rows.Next() {
+		var p domain.JobUsagePercentiles
+		if err := rows.Scan(&p.Phase, &rep.Jobs, &p.AvgMS, &p.P50MS, &p.P90MS, &p.P99MS); err != nil {
+			return domain.JobUsageReport{}, fmt.Errorf("op=job_usage.percentiles_scan: %w", err)
+		}
+		byPhase[p.Phase] = p
+	}
+	if err := rows.Err(); err != nil {
+		return domain.JobUsageReport{}, fmt.Errorf("op=job_usage.percentiles_rows: %w", err)
+	}
+	for _, phase := range jobUsagePhases {
+		if p, ok := byPhase[phase]; ok {
+			rep.Phases = append(rep.Phases, p)
+		}
+	}
+	return rep, nil
+}
@@ -0,0 +1,75 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestJobUsageRepo_Record(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobUsageRepo(pool)
+	at := time.Date(2026, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.HasPrefix(q, "INSERT INTO job_usage") && strings.Contains(q, "ON CONFLICT (job_id)")
+	}), mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Equal(t, []any{"job-1", int64(1500), int64(300), int64(120), int64(9000), 4, int64(40), int64(11000), at}, args)
+	}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Record(context.Background(), domain.JobUsage{
+		JobID: "job-1", QueueWait: 1500 * time.Millisecond, Extraction: 300 * time.Millisecond,
+		RAG: 120 * time.Millisecond, AI: 9 * time.Second, AICalls: 4,
+		PostProcessing: 40 * time.Millisecond, Total: 11 * time.Second, CompletedAt: at,
+	}))
+}
+
+func TestJobUsageRepo_Percentiles(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobUsageRepo(pool)
+	since := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	// The query returns phases in no particular order; the report follows
+	// the pipeline's.
+	phases := []string{domain.UsagePhaseTotal, domain.UsagePhaseAI}
+	rows := mocks.NewMockRows(t)
+	calls := 0
+	rows.On("Next").Return(func() bool {
+		calls++
+		return calls <= len(phases)
+	}).Times(len(phases) + 1)
+	scanned := 0
+	rows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = phases[scanned]
+		*(dest[1].(*int64)) = 12
+		*(dest[2].(*float64)) = 800
+		*(dest[3].(*float64)) = 700
+		*(dest[4].(*float64)) = 1200
+		*(dest[5].(*float64)) = 2500
+		scanned++
+	}).Return(nil).Times(len(phases))
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "percentile_cont(0.99)") && strings.Contains(q, "GROUP BY p.phase")
+	}), []any{since}).Return(rows, nil).Once()
+
+	rep, err := repo.Percentiles(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, since, rep.Since)
+	assert.Equal(t, int64(12), rep.Jobs)
+	require.Len(t, rep.Phases, 2)
+	assert.Equal(t, domain.UsagePhaseAI, rep.Phases[0].Phase)
+	assert.Equal(t, domain.UsagePhaseTotal, rep.Phases[1].Phase)
+	assert.Equal(t, 2500.0, rep.Phases[1].P99MS)
+}
@@ -6,8 +6,10 @@
 package postgres
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +20,42 @@ import (
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
 )
 
+// Hot job queries are kept as constants so the pool can prepare them eagerly
+// (see hotStatements in conn.go).
+const (
+	getJobSQL          = `SELECT id, status, COALESCE(error,''), created_at, updated_at, COALESCE(cv_id,''), COALESCE(project_id,''), idempotency_key, security_notes, request_id, expires_at, metadata, tags FROM jobs WHERE id=$1`
+	updateJobStatusSQL = `UPDATE jobs SET status=$2, error=$3, updated_at=$4 WHERE id=$1`
+)
+
+// insertJobSQL is shared with OutboxRepo.CreateJob.
+const insertJobSQL = `INSERT INTO jobs (id, status, error, created_at, updated_at, cv_id, project_id, idempotency_key, request_id, expires_at, metadata, tags) VALUES ($1,$2,$3,$4,$5,NULLIF($6,''),NULLIF($7,''),$8,$9,$10,$11,$12)`
+
+// jobLabelArgs returns the metadata and tags columns of j: metadata as JSON,
+// NULL when empty, and tags as a non-nil array.
+func jobLabelArgs(j domain.Job) ([]byte, []string, error) {
+	var metadata []byte
+	if len(j.Metadata) > 0 {
+		b, err := json.Marshal(j.Metadata)
+		if err != nil {
+			return nil, nil, err
+		}
+		metadata = b
+	}
+	tags := j.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return metadata, tags, nil
+}
+
+// decodeJobMetadata fills j.Metadata from its JSONB column.
+func decodeJobMetadata(raw []byte, j *domain.Job) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw, &j.Metadata)
+}
+
 // JobRepo persists and loads jobs from PostgreSQL using a minimal pgx pool.
 type JobRepo struct{ Pool PgxPool }
 
@@ -38,8 +76,11 @@ func (r *JobRepo) Create(ctx domain.Context, j domain.Job) (string, error) {
 	if id == "" {
 		id = uuid.New().String()
 	}
-	q := `INSERT INTO jobs (id, status, error, created_at, updated_at, cv_id, project_id, idempotency_key) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
-	_, err := r.Pool.Exec(ctx, q, id, j.Status, j.Error, time.Now().UTC(), time.Now().UTC(), j.CVID, j.ProjectID, j.IdemKey)
+	metadata, tags, err := jobLabelArgs(j)
+	if err != nil {
+		return "", fmt.Errorf("op=job.create_labels: %w", err)
+	}
+	_, err = r.Pool.Exec(ctx, insertJobSQL, id, j.Status, j.Error, time.Now().UTC(), time.Now().UTC(), j.CVID, j.ProjectID, j.IdemKey, j.RequestID, j.ExpiresAt, metadata, tags)
 	if err != nil {
 		return "", fmt.Errorf("op=job.create: %w", err)
 	}
@@ -93,7 +134,7 @@ func (r *JobRepo) UpdateStatus(ctx domain.Context, id string, status domain.JobS
 	}()
 
 	// Execute the update within the transaction
-	q := `UPDATE jobs SET status=$2, error=$3, updated_at=$4 WHERE id=$1`
+	q := updateJobStatusSQL
 	updateStart := time.Now()
 	result, err := tx.Exec(ctx, q, id, status, errVal, time.Now().UTC())
 	updateDuration := time.Since(updateStart)
@@ -155,6 +196,27 @@ func (r *JobRepo) UpdateStatus(ctx domain.Context, id string, status domain.JobS
 	return nil
 }
 
+// AddSecurityNote appends note to the job's security notes.
+func (r *JobRepo) AddSecurityNote(ctx domain.Context, id string, note string) error {
+	tracer := otel.Tracer("repo.jobs")
+	ctx, span := tracer.Start(ctx, "jobs.AddSecurityNote")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPDATE"),
+		attribute.String("db.sql.table", "jobs"),
+	)
+	q := `UPDATE jobs SET security_notes = array_append(security_notes, $2) WHERE id=$1`
+	tag, err := r.Pool.Exec(ctx, q, id, note)
+	if err != nil {
+		return fmt.Errorf("op=job.add_security_note: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("op=job.add_security_note: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
 // Get loads a job by id.
 func (r *JobRepo) Get(ctx domain.Context, id string) (domain.Job, error) {
 	tracer := otel.Tracer("repo.jobs")
@@ -165,20 +227,135 @@ func (r *JobRepo) Get(ctx domain.Context, id string) (domain.Job, error) {
 		attribute.String("db.operation", "SELECT"),
 		attribute.String("db.sql.table", "jobs"),
 	)
-	q := `SELECT id, status, COALESCE(error,''), created_at, updated_at, cv_id, project_id, idempotency_key FROM jobs WHERE id=$1`
-	row := r.Pool.QueryRow(ctx, q, id)
+	row := r.Pool.QueryRow(ctx, getJobSQL, id)
 	var j domain.Job
 	var idem *string
-	if err := row.Scan(&j.ID, &j.Status, &j.Error, &j.CreatedAt, &j.UpdatedAt, &j.CVID, &j.ProjectID, &idem); err != nil {
+	var metadata []byte
+	if err := row.Scan(&j.ID, &j.Status, &j.Error, &j.CreatedAt, &j.UpdatedAt, &j.CVID, &j.ProjectID, &idem, &j.SecurityNotes, &j.RequestID, &j.ExpiresAt, &metadata, &j.Tags); err != nil {
 		if err == pgx.ErrNoRows {
 			return domain.Job{}, fmt.Errorf("op=job.get: %w", domain.ErrNotFound)
 		}
 		return domain.Job{}, fmt.Errorf("op=job.get: %w", err)
 	}
+	if err := decodeJobMetadata(metadata, &j); err != nil {
+		return domain.Job{}, fmt.Errorf("op=job.get_metadata: %w", err)
+	}
 	j.IdemKey = idem
 	return j, nil
 }
 
+// Task returns the evaluation task the job was last queued with. Jobs created
+// without the outbox have none (ErrNotFound).
+func (r *JobRepo) Task(ctx domain.Context, id string) (domain.EvaluateTaskPayload, error) {
+	tracer := otel.Tracer("repo.jobs")
+	ctx, span := tracer.Start(ctx, "jobs.Task")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "jobs"),
+	)
+	var body []byte
+	if err := r.Pool.QueryRow(ctx, `SELECT task FROM jobs WHERE id=$1`, id).Scan(&body); err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.EvaluateTaskPayload{}, fmt.Errorf("op=job.task: %w", domain.ErrNotFound)
+		}
+		return domain.EvaluateTaskPayload{}, fmt.Errorf("op=job.task: %w", err)
+	}
+	if body == nil {
+		return domain.EvaluateTaskPayload{}, fmt.Errorf("op=job.task: %w: no task recorded for job", domain.ErrNotFound)
+	}
+	var p domain.EvaluateTaskPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return domain.EvaluateTaskPayload{}, fmt.Errorf("op=job.task_unmarshal: %w", err)
+	}
+	return p, nil
+}
+
+// ScanTasks returns up to limit recorded tasks of jobs created at or after
+// since, ordered by (created_at, id). Pass the cursor of the last returned
+// task as after to read the next page; jobs without a task are skipped.
+func (r *JobRepo) ScanTasks(ctx domain.Context, after *domain.JobCursor, since time.Time, limit int) ([]domain.JobTask, error) {
+	tracer := otel.Tracer("repo.jobs")
+	ctx, span := tracer.Start(ctx, "jobs.ScanTasks")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "jobs"),
+	)
+	cur := domain.JobCursor{CreatedAt: since}
+	if after != nil {
+		cur = *after
+	}
+	q := `SELECT id, created_at, task FROM jobs
+		WHERE task IS NOT NULL AND created_at >= $1 AND (created_at, id) > ($2, $3)
+		ORDER BY created_at, id LIMIT $4`
+	rows, err := r.Pool.Query(ctx, q, since, cur.CreatedAt, cur.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("op=job.scan_tasks: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.JobTask
+	for rows.Next() {
+		var t domain.JobTask
+		var body []byte
+		if err := rows.Scan(&t.JobID, &t.CreatedAt, &body); err != nil {
+			return nil, fmt.Errorf("op=job.scan_tasks_scan: %w", err)
+		}
+		if err := json.Unmarshal(body, &t.Payload); err != nil {
+			return nil, fmt.Errorf("op=job.scan_tasks_unmarshal: %w", err)
+		}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=job.scan_tasks_rows: %w", err)
+	}
+	return out, nil
+}
+
+// GetMany loads all jobs whose id is in ids with a single query. Unknown ids
+// are skipped, so the result may be shorter than ids.
+func (r *JobRepo) GetMany(ctx domain.Context, ids []string) ([]domain.Job, error) {
+	tracer := otel.Tracer("repo.jobs")
+	ctx, span := tracer.Start(ctx, "jobs.GetMany")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "jobs"),
+		attribute.Int("jobs.count", len(ids)),
+	)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	q := `SELECT id, status, COALESCE(error,''), created_at, updated_at, COALESCE(cv_id,''), COALESCE(project_id,''), idempotency_key, security_notes, request_id, expires_at, metadata, tags FROM jobs WHERE id = ANY($1)`
+	rows, err := r.Pool.Query(ctx, q, ids)
+	if err != nil {
+		return nil, fmt.Errorf("op=job.get_many: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := make([]domain.Job, 0, len(ids))
+	for rows.Next() {
+		var j domain.Job
+		var idem *string
+		var metadata []byte
+		if err := rows.Scan(&j.ID, &j.Status, &j.Error, &j.CreatedAt, &j.UpdatedAt, &j.CVID, &j.ProjectID, &idem, &j.SecurityNotes, &j.RequestID, &j.ExpiresAt, &metadata, &j.Tags); err != nil {
+			return nil, fmt.Errorf("op=job.get_many_scan: %w", err)
+		}
+		if err := decodeJobMetadata(metadata, &j); err != nil {
+			return nil, fmt.Errorf("op=job.get_many_metadata: %w", err)
+		}
+		j.IdemKey = idem
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=job.get_many_rows: %w", err)
+	}
+	return jobs, nil
+}
+
 // FindByIdempotencyKey loads a job by idempotency key.
 func (r *JobRepo) FindByIdempotencyKey(ctx domain.Context, key string) (domain.Job, error) {
 	tracer := otel.Tracer("repo.jobs")
@@ -189,7 +366,7 @@ func (r *JobRepo) FindByIdempotencyKey(ctx domain.Context, key string) (domain.J
 		attribute.String("db.operation", "SELECT"),
 		attribute.String("db.sql.table", "jobs"),
 	)
-	q := `SELECT id, status, COALESCE(error,''), created_at, updated_at, cv_id, project_id, idempotency_key FROM jobs WHERE idempotency_key=$1 LIMIT 1`
+	q := `SELECT id, status, COALESCE(error,''), created_at, updated_at, COALESCE(cv_id,''), COALESCE(project_id,''), idempotency_key FROM jobs WHERE idempotency_key=$1 LIMIT 1`
 	row := r.Pool.QueryRow(ctx, q, key)
 	var j domain.Job
 	var idem *string
@@ -251,7 +428,7 @@ func (r *JobRepo) List(ctx domain.Context, offset, limit int) ([]domain.Job, err
 		attribute.String("db.operation", "SELECT"),
 		attribute.String("db.sql.table", "jobs"),
 	)
-	q := `SELECT id, status, COALESCE(error,''), created_at, updated_at, cv_id, project_id, idempotency_key FROM jobs ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+	q := `SELECT id, status, COALESCE(error,''), created_at, updated_at, COALESCE(cv_id,''), COALESCE(project_id,''), idempotency_key FROM jobs ORDER BY created_at DESC LIMIT $1 OFFSET $2`
 	rows, err := r.Pool.Query(ctx, q, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("op=job.list: %w", err)
@@ -286,7 +463,7 @@ func (r *JobRepo) ListWithFilters(ctx domain.Context, offset, limit int, search,
 	)
 
 	// Build dynamic query based on filters
-	baseQuery := `SELECT id, status, COALESCE(error,''), created_at, updated_at, cv_id, project_id, idempotency_key FROM jobs`
+	baseQuery := `SELECT id, status, COALESCE(error,''), created_at, updated_at, COALESCE(cv_id,''), COALESCE(project_id,''), idempotency_key FROM jobs`
 	whereClause := ""
 	args := []interface{}{}
 	argIndex := 1
@@ -382,6 +559,125 @@ func (r *JobRepo) CountWithFilters(ctx domain.Context, search, status string) (i
 	return count, nil
 }
 
+// jobFilterWhere builds the WHERE clause shared by ListByCursor and
+// CountByFilter. The cursor predicate is only added when withCursor is set.
+func jobFilterWhere(f domain.JobListFilter, withCursor bool) (string, []interface{}) {
+	var conds []string
+	args := []interface{}{}
+	next := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + fmt.Sprintf("%d", len(args))
+	}
+
+	if f.Status != "" {
+		conds = append(conds, "status = "+next(f.Status))
+	}
+	if f.Search != "" {
+		searchPattern := "%" + f.Search + "%"
+		conds = append(conds, "(id ILIKE "+next(searchPattern)+" OR cv_id ILIKE "+next(searchPattern)+" OR project_id ILIKE "+next(searchPattern)+")")
+	}
+	if f.RequestID != "" {
+		conds = append(conds, "request_id = "+next(f.RequestID))
+	}
+	if len(f.Tags) > 0 {
+		conds = append(conds, "tags @> "+next(f.Tags))
+	}
+	if len(f.Metadata) > 0 {
+		// A map of strings always marshals.
+		b, _ := json.Marshal(f.Metadata)
+		conds = append(conds, "metadata @> "+next(b))
+	}
+	if !f.CreatedFrom.IsZero() {
+		conds = append(conds, "created_at >= "+next(f.CreatedFrom))
+	}
+	if !f.CreatedTo.IsZero() {
+		conds = append(conds, "created_at < "+next(f.CreatedTo))
+	}
+	if withCursor && f.After != nil {
+		// Row-value comparison lets Postgres seek directly on the
+		// (created_at, id) index instead of scanning skipped rows.
+		op := "<"
+		if f.Sort == domain.JobSortCreatedAsc {
+			op = ">"
+		}
+		conds = append(conds, "(created_at, id) "+op+" ("+next(f.After.CreatedAt)+", "+next(f.After.ID)+")")
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// ListByCursor returns a keyset-paginated list of jobs matching the filter.
+// Ordering is always (created_at, id) so the cursor is stable across pages.
+func (r *JobRepo) ListByCursor(ctx domain.Context, f domain.JobListFilter) ([]domain.Job, error) {
+	tracer := otel.Tracer("repo.jobs")
+	ctx, span := tracer.Start(ctx, "jobs.ListByCursor")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "jobs"),
+		attribute.String("jobs.sort", string(f.Sort)),
+		attribute.Bool("jobs.has_cursor", f.After != nil),
+	)
+
+	whereClause, args := jobFilterWhere(f, true)
+	order := " ORDER BY created_at DESC, id DESC"
+	if f.Sort == domain.JobSortCreatedAsc {
+		order = " ORDER BY created_at ASC, id ASC"
+	}
+	args = append(args, f.Limit)
+	query := `SELECT id, status, COALESCE(error,''), created_at, updated_at, COALESCE(cv_id,''), COALESCE(project_id,''), idempotency_key, request_id, metadata, tags FROM jobs` +
+		whereClause + order + " LIMIT $" + fmt.Sprintf("%d", len(args))
+
+	rows, err := r.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("op=job.list_by_cursor: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []domain.Job
+	for rows.Next() {
+		var j domain.Job
+		var idem *string
+		var metadata []byte
+		if err := rows.Scan(&j.ID, &j.Status, &j.Error, &j.CreatedAt, &j.UpdatedAt, &j.CVID, &j.ProjectID, &idem, &j.RequestID, &metadata, &j.Tags); err != nil {
+			return nil, fmt.Errorf("op=job.list_by_cursor_scan: %w", err)
+		}
+		if err := decodeJobMetadata(metadata, &j); err != nil {
+			return nil, fmt.Errorf("op=job.list_by_cursor_metadata: %w", err)
+		}
+		j.IdemKey = idem
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=job.list_by_cursor_rows: %w", err)
+	}
+	return jobs, nil
+}
+
+// CountByFilter returns the number of jobs matching the filter, ignoring its cursor and limit.
+func (r *JobRepo) CountByFilter(ctx domain.Context, f domain.JobListFilter) (int64, error) {
+	tracer := otel.Tracer("repo.jobs")
+	ctx, span := tracer.Start(ctx, "jobs.CountByFilter")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "COUNT"),
+		attribute.String("db.sql.table", "jobs"),
+	)
+
+	whereClause, args := jobFilterWhere(f, false)
+	row := r.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM jobs`+whereClause, args...)
+	var count int64
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("op=job.count_by_filter: %w", err)
+	}
+	return count, nil
+}
+
 // GetAverageProcessingTime returns the average processing time for completed jobs.
 func (r *JobRepo) GetAverageProcessingTime(ctx domain.Context) (float64, error) {
 	tracer := otel.Tracer("repo.jobs")
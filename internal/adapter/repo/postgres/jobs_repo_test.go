@@ -2,9 +2,11 @@ package postgres_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -30,11 +32,23 @@ func TestJobRepo_Create(t *testing.T) {
 	}
 
 	// Test successful creation
-	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Nil(t, args[10], "no metadata is stored as NULL")
+		assert.Equal(t, []string{}, args[11])
+	}).Return(pgconn.CommandTag{}, nil).Once()
 	id, err := repo.Create(ctx, job)
 	require.NoError(t, err)
 	assert.Equal(t, "job-1", id)
 
+	labeled := job
+	labeled.Metadata, labeled.Tags = map[string]string{"team": "platform"}, []string{"urgent"}
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Equal(t, []byte(`{"team":"platform"}`), args[10])
+		assert.Equal(t, []string{"urgent"}, args[11])
+	}).Return(pgconn.CommandTag{}, nil).Once()
+	_, err = repo.Create(ctx, labeled)
+	require.NoError(t, err)
+
 	// Test database error
 	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
 	_, err = repo.Create(ctx, job)
@@ -80,6 +94,7 @@ func TestJobRepo_Get(t *testing.T) {
 	ctx := context.Background()
 
 	// Test successful get
+	expiresAt := time.Now().Add(time.Hour).UTC()
 	mockRow := mocks.NewMockRow(t)
 	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
 		dest := args[0].([]any)
@@ -91,6 +106,11 @@ func TestJobRepo_Get(t *testing.T) {
 		*(dest[5].(*string)) = "cv-1"
 		*(dest[6].(*string)) = "proj-1"
 		*(dest[7].(**string)) = nil
+		*(dest[8].(*[]string)) = []string{"prompt_injection: cv: role_hijack"}
+		*(dest[9].(*string)) = "req-42"
+		*(dest[10].(**time.Time)) = &expiresAt
+		*(dest[11].(*[]byte)) = []byte(`{"team":"platform"}`)
+		*(dest[12].(*[]string)) = []string{"campaign-q4"}
 	}).Return(nil).Once()
 
 	pool.EXPECT().QueryRow(mock.MatchedBy(func(interface{}) bool { return true }), mock.Anything, mock.Anything).Return(mockRow).Once()
@@ -99,6 +119,12 @@ func TestJobRepo_Get(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "job-1", job.ID)
 	assert.Equal(t, domain.JobCompleted, job.Status)
+	assert.Equal(t, []string{"prompt_injection: cv: role_hijack"}, job.SecurityNotes)
+	assert.Equal(t, "req-42", job.RequestID)
+	require.NotNil(t, job.ExpiresAt)
+	assert.Equal(t, expiresAt, *job.ExpiresAt)
+	assert.Equal(t, map[string]string{"team": "platform"}, job.Metadata)
+	assert.Equal(t, []string{"campaign-q4"}, job.Tags)
 
 	// Test database error
 	mockRowErr := mocks.NewMockRow(t)
@@ -109,6 +135,88 @@ func TestJobRepo_Get(t *testing.T) {
 	assert.Contains(t, err.Error(), "op=job.get")
 }
 
+func TestJobRepo_Task(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+	ctx := context.Background()
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*[]byte)) = []byte(`{"JobID":"job-1","CVID":"cv-1","JobDescription":"jd"}`)
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, `SELECT task FROM jobs WHERE id=$1`, []any{"job-1"}).Return(row).Once()
+	p, err := repo.Task(ctx, "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.EvaluateTaskPayload{JobID: "job-1", CVID: "cv-1", JobDescription: "jd"}, p)
+
+	// Jobs created without the outbox have no task.
+	empty := mocks.NewMockRow(t)
+	empty.On("Scan", mock.Anything).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"job-2"}).Return(empty).Once()
+	_, err = repo.Task(ctx, "job-2")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	missing := mocks.NewMockRow(t)
+	missing.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"job-3"}).Return(missing).Once()
+	_, err = repo.Task(ctx, "job-3")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestJobRepo_ScanTasks(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+	since := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "j2"
+		*(dest[1].(*time.Time)) = since.Add(time.Hour)
+		*(dest[2].(*[]byte)) = []byte(`{"JobID":"j2","JobDescription":"Go engineer"}`)
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+	// The first page starts at since; later pages after the cursor.
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{since, since, "", 100}).Return(mockRows, nil).Once()
+
+	got, err := repo.ScanTasks(context.Background(), nil, since, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.JobTask{
+		{JobID: "j2", CreatedAt: since.Add(time.Hour), Payload: domain.EvaluateTaskPayload{JobID: "j2", JobDescription: "Go engineer"}},
+	}, got)
+
+	after := &domain.JobCursor{CreatedAt: since.Add(time.Hour), ID: "j2"}
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{since, after.CreatedAt, "j2", 100}).Return(nil, assert.AnError).Once()
+	_, err = repo.ScanTasks(context.Background(), after, since, 100)
+	assert.ErrorContains(t, err, "op=job.scan_tasks")
+}
+
+func TestJobRepo_AddSecurityNote(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+	ctx := context.Background()
+
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "array_append(security_notes, $2)")
+	}), []any{"job-1", "note"}).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	require.NoError(t, repo.AddSecurityNote(ctx, "job-1", "note"))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+	err := repo.AddSecurityNote(ctx, "missing", "note")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	err = repo.AddSecurityNote(ctx, "job-1", "note")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=job.add_security_note")
+}
+
 func TestJobRepo_FindByIdempotencyKey(t *testing.T) {
 	pool := postgres.NewMockPgxPool(t)
 	repo := postgres.NewJobRepo(pool)
@@ -593,3 +701,174 @@ func TestJobRepo_ListWithFilters_BothFilters(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, jobs)
 }
+
+func TestJobRepo_GetMany(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+	ctx := context.Background()
+
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 2
+	}).Times(3)
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "job-" + string(rune('0'+calls))
+		*(dest[1].(*domain.JobStatus)) = domain.JobQueued
+		*(dest[2].(*string)) = ""
+		*(dest[3].(*time.Time)) = time.Now().UTC()
+		*(dest[4].(*time.Time)) = time.Now().UTC()
+		*(dest[5].(*string)) = "cv"
+		*(dest[6].(*string)) = "proj"
+		*(dest[7].(**string)) = nil
+	}).Return(nil).Times(2)
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{[]string{"job-1", "job-2", "job-3"}}).Return(mockRows, nil).Once()
+
+	jobs, err := repo.GetMany(ctx, []string{"job-1", "job-2", "job-3"})
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "job-1", jobs[0].ID)
+	assert.Equal(t, "job-2", jobs[1].ID)
+}
+
+func TestJobRepo_GetMany_EmptyIDsSkipsQuery(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+
+	jobs, err := repo.GetMany(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestJobRepo_GetMany_QueryError(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+	jobs, err := repo.GetMany(context.Background(), []string{"job-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=job.get_many")
+	assert.Nil(t, jobs)
+}
+
+func TestJobRepo_ListByCursor_BuildsKeysetQuery(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+
+	after := &domain.JobCursor{CreatedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), ID: "job-9"}
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockRows := mocks.NewMockRows(t)
+	mockRows.On("Next").Return(false).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "status = $1") &&
+			strings.Contains(q, "created_at >= $2") &&
+			strings.Contains(q, "(created_at, id) > ($3, $4)") &&
+			strings.Contains(q, "ORDER BY created_at ASC, id ASC LIMIT $5")
+	}), []any{"queued", from, after.CreatedAt, "job-9", 5}).Return(mockRows, nil).Once()
+
+	jobs, err := repo.ListByCursor(context.Background(), domain.JobListFilter{
+		Status:      "queued",
+		CreatedFrom: from,
+		Sort:        domain.JobSortCreatedAsc,
+		After:       after,
+		Limit:       5,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestJobRepo_ListByCursor_FiltersByRequestID(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+
+	mockRows := mocks.NewMockRows(t)
+	mockRows.On("Next").Return(true).Once()
+	mockRows.On("Next").Return(false).Once()
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "job-1"
+		*(dest[8].(*string)) = "req-42"
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "request_id = $1") && strings.Contains(q, "LIMIT $2")
+	}), []any{"req-42", 10}).Return(mockRows, nil).Once()
+
+	jobs, err := repo.ListByCursor(context.Background(), domain.JobListFilter{RequestID: "req-42", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "req-42", jobs[0].RequestID)
+}
+
+func TestJobRepo_ListByCursor_FiltersByLabels(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+
+	mockRows := mocks.NewMockRows(t)
+	mockRows.On("Next").Return(true).Once()
+	mockRows.On("Next").Return(false).Once()
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "job-1"
+		*(dest[9].(*[]byte)) = []byte(`{"team":"platform","req":"R-1"}`)
+		*(dest[10].(*[]string)) = []string{"campaign-q4", "urgent"}
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "tags @> $1 AND metadata @> $2") && strings.Contains(q, "LIMIT $3")
+	}), []any{[]string{"campaign-q4"}, []byte(`{"team":"platform"}`), 10}).Return(mockRows, nil).Once()
+
+	jobs, err := repo.ListByCursor(context.Background(), domain.JobListFilter{
+		Tags:     []string{"campaign-q4"},
+		Metadata: map[string]string{"team": "platform"},
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, map[string]string{"team": "platform", "req": "R-1"}, jobs[0].Metadata)
+	assert.Equal(t, []string{"campaign-q4", "urgent"}, jobs[0].Tags)
+}
+
+func TestJobRepo_ListByCursor_QueryError(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "ORDER BY created_at DESC, id DESC LIMIT $1")
+	}), mock.Anything).Return(nil, assert.AnError).Once()
+	jobs, err := repo.ListByCursor(context.Background(), domain.JobListFilter{Limit: 10})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=job.list_by_cursor")
+	assert.Nil(t, jobs)
+}
+
+func TestJobRepo_CountByFilter(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewJobRepo(pool)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*int64)) = 7
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, "SELECT COUNT(*) FROM jobs WHERE status = $1", []any{"failed"}).Return(row).Once()
+
+	n, err := repo.CountByFilter(context.Background(), domain.JobListFilter{
+		Status: "failed",
+		After:  &domain.JobCursor{ID: "ignored"},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// KeyUsageRepo persists daily usage counters of AI provider keys.
+type KeyUsageRepo struct{ Pool PgxPool }
+
+// NewKeyUsageRepo constructs a KeyUsageRepo with the given pool.
+func NewKeyUsageRepo(p PgxPool) *KeyUsageRepo { return &KeyUsageRepo{Pool: p} }
+
+// Add atomically increments the counters of keyID on day and returns the new totals.
+func (r *KeyUsageRepo) Add(ctx domain.Context, keyID string, day time.Time, requests, tokens int64) (domain.KeyUsage, error) {
+	tracer := otel.Tracer("repo.key_usage")
+	ctx, span := tracer.Start(ctx, "key_usage.Add")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "ai_key_usage"),
+	)
+	q := `INSERT INTO ai_key_usage (key_id, day, requests, tokens, updated_at) VALUES ($1,$2,$3,$4,now())
+		ON CONFLICT (key_id, day) DO UPDATE SET
+			requests = ai_key_usage.requests + EXCLUDED.requests,
+			tokens = ai_key_usage.tokens + EXCLUDED.tokens,
+			updated_at = now()
+		RETURNING requests, tokens`
+	u := domain.KeyUsage{KeyID: keyID, Day: day}
+	if err := r.Pool.QueryRow(ctx, q, keyID, day, requests, tokens).Scan(&u.Requests, &u.Tokens); err != nil {
+		return domain.KeyUsage{}, fmt.Errorf("op=key_usage.add: %w", err)
+	}
+	return u, nil
+}
+
+// ListDay returns the usage of every key on day.
+func (r *KeyUsageRepo) ListDay(ctx domain.Context, day time.Time) ([]domain.KeyUsage, error) {
+	tracer := otel.Tracer("repo.key_usage")
+	ctx, span := tracer.Start(ctx, "key_usage.ListDay")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "ai_key_usage"),
+	)
+	rows, err := r.Pool.Query(ctx, `SELECT key_id, requests, tokens FROM ai_key_usage WHERE day = $1`, day)
+	if err != nil {
+		return nil, fmt.Errorf("op=key_usage.list_day: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.KeyUsage
+	for rows.Next() {
+		u := domain.KeyUsage{Day: day}
+		if err := rows.Scan(&u.KeyID, &u.Requests, &u.Tokens); err != nil {
+			return nil, fmt.Errorf("op=key_usage.list_day_scan: %w", err)
+		}
+		out = append(out, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=key_usage.list_day_rows: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,74 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+)
+
+func TestKeyUsageRepo_Add(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewKeyUsageRepo(pool)
+	day := time.Date(2025, 12, 4, 0, 0, 0, 0, time.UTC)
+
+	mockRow := mocks.NewMockRow(t)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*int64)) = 7
+		*(dest[1].(*int64)) = 4200
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"k_abc", day, int64(1), int64(600)}).Return(mockRow).Once()
+
+	u, err := repo.Add(context.Background(), "k_abc", day, 1, 600)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), u.Requests)
+	assert.Equal(t, int64(4200), u.Tokens)
+	assert.Equal(t, "k_abc", u.KeyID)
+
+	mockRowErr := mocks.NewMockRow(t)
+	mockRowErr.On("Scan", mock.Anything).Return(assert.AnError).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(mockRowErr).Once()
+	_, err = repo.Add(context.Background(), "k_abc", day, 1, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=key_usage.add")
+}
+
+func TestKeyUsageRepo_ListDay(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewKeyUsageRepo(pool)
+	day := time.Date(2025, 12, 4, 0, 0, 0, 0, time.UTC)
+
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "k_abc"
+		*(dest[1].(*int64)) = 3
+		*(dest[2].(*int64)) = 900
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{day}).Return(mockRows, nil).Once()
+
+	usage, err := repo.ListDay(context.Background(), day)
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	assert.Equal(t, "k_abc", usage[0].KeyID)
+	assert.Equal(t, int64(900), usage[0].Tokens)
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+	_, err = repo.ListDay(context.Background(), day)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=key_usage.list_day")
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// LegalHoldRepo sets the legal_hold flags of uploads and jobs and records
+// every change in legal_hold_events.
+type LegalHoldRepo struct{ Pool PgxPool }
+
+// NewLegalHoldRepo constructs a LegalHoldRepo with the given pool.
+func NewLegalHoldRepo(p PgxPool) *LegalHoldRepo { return &LegalHoldRepo{Pool: p} }
+
+// legalHoldTables maps hold targets to their tables.
+var legalHoldTables = map[string]string{
+	domain.LegalHoldUpload: "uploads",
+	domain.LegalHoldJob:    "jobs",
+}
+
+// heldJobsSQL selects the IDs of jobs kept by a legal hold: held jobs and
+// jobs of held uploads.
+const heldJobsSQL = `SELECT id FROM jobs WHERE legal_hold
+	OR cv_id IN (SELECT id FROM uploads WHERE legal_hold)
+	OR project_id IN (SELECT id FROM uploads WHERE legal_hold)`
+
+const legalHoldColumns = `target_type, target_id, held, actor, reason, created_at`
+
+// Set updates the flag and records the change in one statement, so the audit
+// trail never disagrees with the flags.
+func (r *LegalHoldRepo) Set(ctx domain.Context, h domain.LegalHold) error {
+	tracer := otel.Tracer("repo.legal_holds")
+	ctx, span := tracer.Start(ctx, "legal_holds.Set")
+	defer span.End()
+	table, ok := legalHoldTables[h.TargetType]
+	if !ok {
+		return fmt.Errorf("op=legal_hold.set: %w: unknown target type %q", domain.ErrInvalidArgument, h.TargetType)
+	}
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPDATE"),
+		attribute.String("db.sql.table", table),
+		attribute.Bool("legal_hold.held", h.Held),
+	)
+	q := `WITH upd AS (
+		UPDATE ` + pgx.Identifier{table}.Sanitize() + ` SET legal_hold=$3 WHERE id=$2 RETURNING id
+	)
+	INSERT INTO legal_hold_events (` + legalHoldColumns + `)
+	SELECT $1, id, $3, $4, $5, $6 FROM upd`
+	tag, err := r.Pool.Exec(ctx, q, h.TargetType, h.TargetID, h.Held, h.Actor, h.Reason, h.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("op=legal_hold.set: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("op=legal_hold.set: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// History returns the recorded changes of a target, newest first.
+func (r *LegalHoldRepo) History(ctx domain.Context, targetType, targetID string) ([]domain.LegalHold, error) {
+	tracer := otel.Tracer("repo.legal_holds")
+	ctx, span := tracer.Start(ctx, "legal_holds.History")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "legal_hold_events"),
+	)
+	rows, err := r.Pool.Query(ctx, `SELECT `+legalHoldColumns+` FROM legal_hold_events
+		WHERE target_type=$1 AND target_id=$2 ORDER BY created_at DESC, id DESC`, targetType, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("op=legal_hold.history: %w", err)
+	}
+	return scanLegalHolds(rows, "op=legal_hold.history")
+}
+
+// ListHeld returns the latest change of each target whose hold is in place.
+func (r *LegalHoldRepo) ListHeld(ctx domain.Context) ([]domain.LegalHold, error) {
+	tracer := otel.Tracer("repo.legal_holds")
+	ctx, span := tracer.Start(ctx, "legal_holds.ListHeld")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "legal_hold_events"),
+	)
+	rows, err := r.Pool.Query(ctx, `SELECT `+legalHoldColumns+` FROM (
+		SELECT DISTINCT ON (target_type, target_id) `+legalHoldColumns+` FROM legal_hold_events
+		ORDER BY target_type, target_id, created_at DESC, id DESC
+	) latest WHERE held ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("op=legal_hold.list: %w", err)
+	}
+	return scanLegalHolds(rows, "op=legal_hold.list")
+}
+
+func scanLegalHolds(rows pgx.Rows, op string) ([]domain.LegalHold, error) {
+	defer rows.Close()
+	var out []domain.LegalHold
+	for rows.Next() {
+		var h domain.LegalHold
+		if err := rows.Scan(&h.TargetType, &h.TargetID, &h.Held, &h.Actor, &h.Reason, &h.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s_scan: %w", op, err)
+		}
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s_rows: %w", op, err)
+	}
+	return out, nil
+}
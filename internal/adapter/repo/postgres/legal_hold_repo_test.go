@@ -0,0 +1,73 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestLegalHoldRepo_Set(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewLegalHoldRepo(pool)
+	ctx := context.Background()
+	at := time.Date(2025, 12, 30, 9, 0, 0, 0, time.UTC)
+	h := domain.LegalHold{TargetType: domain.LegalHoldUpload, TargetID: "u1", Held: true, Actor: "admin", Reason: "litigation 42", CreatedAt: at}
+
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, `UPDATE "uploads" SET legal_hold=$3`) && strings.Contains(sql, "INSERT INTO legal_hold_events")
+	}), []any{"upload", "u1", true, "admin", "litigation 42", at}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Set(ctx, h))
+
+	h.TargetType, h.TargetID = domain.LegalHoldJob, "missing"
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, `UPDATE "jobs" SET legal_hold=$3`)
+	}), mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 0"), nil).Once()
+	require.ErrorIs(t, repo.Set(ctx, h), domain.ErrNotFound)
+
+	h.TargetType = "results"
+	require.ErrorIs(t, repo.Set(ctx, h), domain.ErrInvalidArgument)
+}
+
+func TestLegalHoldRepo_HistoryAndListHeld(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewLegalHoldRepo(pool)
+	at := time.Date(2025, 12, 30, 9, 0, 0, 0, time.UTC)
+
+	rows := mocks.NewMockRows(t)
+	calls := 0
+	rows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	rows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "job"
+		*(dest[1].(*string)) = "j1"
+		*(dest[2].(*bool)) = true
+		*(dest[3].(*string)) = "admin"
+		*(dest[4].(*string)) = "audit"
+		*(dest[5].(*time.Time)) = at
+	}).Return(nil).Once()
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{"job", "j1"}).Return(rows, nil).Once()
+	got, err := repo.History(context.Background(), "job", "j1")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.LegalHold{{TargetType: "job", TargetID: "j1", Held: true, Actor: "admin", Reason: "audit", CreatedAt: at}}, got)
+
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DISTINCT ON (target_type, target_id)") && strings.Contains(sql, "WHERE held")
+	})).Return(nil, assert.AnError).Once()
+	_, err = repo.ListHeld(context.Background())
+	assert.ErrorContains(t, err, "op=legal_hold.list")
+}
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// MaintenanceRepo persists the maintenance state and deferred evaluations.
+type MaintenanceRepo struct{ Pool PgxPool }
+
+// NewMaintenanceRepo constructs a MaintenanceRepo with the given pool.
+func NewMaintenanceRepo(p PgxPool) *MaintenanceRepo { return &MaintenanceRepo{Pool: p} }
+
+// Get returns the stored maintenance state, or MaintenanceOff when none is stored.
+func (r *MaintenanceRepo) Get(ctx domain.Context) (domain.MaintenanceState, error) {
+	tracer := otel.Tracer("repo.maintenance")
+	ctx, span := tracer.Start(ctx, "maintenance.Get")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "maintenance_state"),
+	)
+	var s domain.MaintenanceState
+	var mode string
+	var retryAfter int
+	row := r.Pool.QueryRow(ctx, `SELECT mode, retry_after_seconds, message, updated_at FROM maintenance_state WHERE id`)
+	if err := row.Scan(&mode, &retryAfter, &s.Message, &s.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.MaintenanceState{Mode: domain.MaintenanceOff}, nil
+		}
+		return domain.MaintenanceState{}, fmt.Errorf("op=maintenance.get: %w", err)
+	}
+	s.Mode = domain.MaintenanceMode(mode)
+	s.RetryAfter = time.Duration(retryAfter) * time.Second
+	return s, nil
+}
+
+// Set stores the maintenance state.
+func (r *MaintenanceRepo) Set(ctx domain.Context, s domain.MaintenanceState) error {
+	tracer := otel.Tracer("repo.maintenance")
+	ctx, span := tracer.Start(ctx, "maintenance.Set")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "maintenance_state"),
+	)
+	q := `INSERT INTO maintenance_state (id, mode, retry_after_seconds, message, updated_at) VALUES (TRUE,$1,$2,$3,$4)
+		ON CONFLICT (id) DO UPDATE SET
+			mode = EXCLUDED.mode,
+			retry_after_seconds = EXCLUDED.retry_after_seconds,
+			message = EXCLUDED.message,
+			updated_at = EXCLUDED.updated_at`
+	if _, err := r.Pool.Exec(ctx, q, string(s.Mode), int(s.RetryAfter/time.Second), s.Message, s.UpdatedAt); err != nil {
+		return fmt.Errorf("op=maintenance.set: %w", err)
+	}
+	return nil
+}
+
+// insertDeferredTaskSQL holds the task $2 of job $1 back from the queue and
+// keeps the task on the job row, like insertOutboxTaskSQL.
+const insertDeferredTaskSQL = `WITH task AS (UPDATE jobs SET task=$2 WHERE id=$1)
+	INSERT INTO deferred_evaluations (job_id, payload, created_at) VALUES ($1,$2,$3)`
+
+// releaseDeferredSQL moves up to $1 deferred tasks, oldest first, to the
+// evaluate outbox in one statement. Rows locked by a concurrent release are
+// skipped. Released jobs count as enqueued now and keep the released task,
+// and jobs that were finished while deferred are not published.
+const releaseDeferredSQL = `WITH released AS (
+		DELETE FROM deferred_evaluations WHERE job_id IN (
+			SELECT job_id FROM deferred_evaluations ORDER BY created_at LIMIT $1 FOR UPDATE SKIP LOCKED
+		) RETURNING job_id, payload, created_at
+	), requeued AS (
+		UPDATE jobs SET task = released.payload, updated_at = now() FROM released
+		WHERE jobs.id = released.job_id AND jobs.status = 'queued'
+		RETURNING released.job_id, released.payload, released.created_at
+	), sent AS (
+		INSERT INTO evaluate_outbox (job_id, payload, created_at)
+		SELECT job_id, payload, created_at FROM requeued
+	)
+	SELECT COUNT(*) FROM released`
+
+// CreateDeferred inserts j and the deferred task p atomically, records p on
+// the job, and returns the job id.
+func (r *MaintenanceRepo) CreateDeferred(ctx domain.Context, j domain.Job, p domain.EvaluateTaskPayload) (string, error) {
+	tracer := otel.Tracer("repo.maintenance")
+	ctx, span := tracer.Start(ctx, "maintenance.CreateDeferred")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "INSERT"),
+		attribute.String("db.sql.table", "jobs,deferred_evaluations"),
+	)
+	id := j.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	p.JobID = id
+	body, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("op=maintenance.create_deferred_marshal: %w", err)
+	}
+	metadata, tags, err := jobLabelArgs(j)
+	if err != nil {
+		return "", fmt.Errorf("op=maintenance.create_deferred_labels: %w", err)
+	}
+	tx, err := r.Pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return "", fmt.Errorf("op=maintenance.create_deferred.begin_tx: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			if err := tx.Rollback(ctx); err != nil {
+				slog.Error("failed to rollback deferred job transaction", slog.String("job_id", id), slog.Any("error", err))
+			}
+		}
+	}()
+	now := time.Now().UTC()
+	if _, err := tx.Exec(ctx, insertJobSQL, id, j.Status, j.Error, now, now, j.CVID, j.ProjectID, j.IdemKey, j.RequestID, j.ExpiresAt, metadata, tags); err != nil {
+		return "", fmt.Errorf("op=maintenance.create_deferred.insert_job: %w", err)
+	}
+	if _, err := tx.Exec(ctx, insertDeferredTaskSQL, id, body, now); err != nil {
+		return "", fmt.Errorf("op=maintenance.create_deferred.insert_deferred: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("op=maintenance.create_deferred.commit: %w", err)
+	}
+	committed = true
+	return id, nil
+}
+
+// ReleaseDeferred moves up to limit deferred tasks, oldest first, to the
+// evaluate outbox and returns how many left the deferred set.
+func (r *MaintenanceRepo) ReleaseDeferred(ctx domain.Context, limit int) (int, error) {
+	tracer := otel.Tracer("repo.maintenance")
+	ctx, span := tracer.Start(ctx, "maintenance.ReleaseDeferred")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "DELETE"),
+		attribute.String("db.sql.table", "deferred_evaluations,evaluate_outbox"),
+	)
+	var n int
+	if err := r.Pool.QueryRow(ctx, releaseDeferredSQL, limit).Scan(&n); err != nil {
+		return 0, fmt.Errorf("op=maintenance.release_deferred: %w", err)
+	}
+	return n, nil
+}
+
+// Deferred reports whether the task of job jobID is held back.
+func (r *MaintenanceRepo) Deferred(ctx domain.Context, jobID string) (bool, error) {
+	tracer := otel.Tracer("repo.maintenance")
+	ctx, span := tracer.Start(ctx, "maintenance.Deferred")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "deferred_evaluations"),
+	)
+	var ok bool
+	if err := r.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM deferred_evaluations WHERE job_id=$1)`, jobID).Scan(&ok); err != nil {
+		return false, fmt.Errorf("op=maintenance.deferred: %w", err)
+	}
+	return ok, nil
+}
+
+// CountDeferred returns the number of deferred payloads.
+func (r *MaintenanceRepo) CountDeferred(ctx domain.Context) (int64, error) {
+	tracer := otel.Tracer("repo.maintenance")
+	ctx, span := tracer.Start(ctx, "maintenance.CountDeferred")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "deferred_evaluations"),
+	)
+	var n int64
+	if err := r.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM deferred_evaluations`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("op=maintenance.count_deferred: %w", err)
+	}
+	return n, nil
+}
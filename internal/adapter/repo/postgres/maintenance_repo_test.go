@@ -0,0 +1,148 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestMaintenanceRepo_GetSet(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewMaintenanceRepo(pool)
+	at := time.Date(2025, 12, 9, 9, 0, 0, 0, time.UTC)
+
+	empty := mocks.NewMockRow(t)
+	empty.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything).Return(empty).Once()
+	st, err := repo.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, domain.MaintenanceState{Mode: domain.MaintenanceOff}, st)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "reject"
+		*(dest[1].(*int)) = 90
+		*(dest[2].(*string)) = "upgrading workers"
+		*(dest[3].(*time.Time)) = at
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything).Return(row).Once()
+	st, err = repo.Get(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, domain.MaintenanceState{Mode: domain.MaintenanceReject, RetryAfter: 90 * time.Second, Message: "upgrading workers", UpdatedAt: at}, st)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"defer", 60, "", at}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Set(context.Background(), domain.MaintenanceState{Mode: domain.MaintenanceDefer, RetryAfter: time.Minute, UpdatedAt: at}))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.Set(context.Background(), domain.MaintenanceState{Mode: domain.MaintenanceOff}), "op=maintenance.set")
+}
+
+func TestMaintenanceRepo_CreateDeferred(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewMaintenanceRepo(pool)
+	tx := mocks.NewMockTx(t)
+
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Equal(t, "job-1", args[0])
+	}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	var stored []byte
+	tx.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "UPDATE jobs SET task=$2") && strings.Contains(q, "INSERT INTO deferred_evaluations")
+	}), mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Equal(t, "job-1", args[0])
+		stored = args[1].([]byte)
+	}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+
+	id, err := repo.CreateDeferred(context.Background(), domain.Job{ID: "job-1", Status: domain.JobQueued, CVID: "cv-1"}, domain.EvaluateTaskPayload{CVID: "cv-1", AllowPaidFallback: true})
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", id)
+	var p domain.EvaluateTaskPayload
+	require.NoError(t, json.Unmarshal(stored, &p))
+	assert.Equal(t, domain.EvaluateTaskPayload{JobID: "job-1", CVID: "cv-1", AllowPaidFallback: true}, p)
+
+	// A failed deferral rolls back the job as well.
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	tx.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+	_, err = repo.CreateDeferred(context.Background(), domain.Job{Status: domain.JobQueued}, domain.EvaluateTaskPayload{})
+	assert.ErrorContains(t, err, "op=maintenance.create_deferred.insert_deferred")
+}
+
+func TestMaintenanceRepo_ReleaseDeferred(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewMaintenanceRepo(pool)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*int)) = 2
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "DELETE FROM deferred_evaluations") && strings.Contains(q, "SET task = released.payload") &&
+			strings.Contains(q, "INSERT INTO evaluate_outbox")
+	}), []any{10}).Return(row).Once()
+	n, err := repo.ReleaseDeferred(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	failing := mocks.NewMockRow(t)
+	failing.On("Scan", mock.Anything).Return(assert.AnError).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(failing).Once()
+	_, err = repo.ReleaseDeferred(context.Background(), 10)
+	assert.ErrorContains(t, err, "op=maintenance.release_deferred")
+}
+
+func TestMaintenanceRepo_Deferred(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewMaintenanceRepo(pool)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*bool)) = true
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"job-1"}).Return(row).Once()
+	ok, err := repo.Deferred(context.Background(), "job-1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	failing := mocks.NewMockRow(t)
+	failing.On("Scan", mock.Anything).Return(assert.AnError).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(failing).Once()
+	_, err = repo.Deferred(context.Background(), "job-1")
+	assert.ErrorContains(t, err, "op=maintenance.deferred")
+}
+
+func TestMaintenanceRepo_CountDeferred(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewMaintenanceRepo(pool)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*int64)) = 7
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything).Return(row).Once()
+	n, err := repo.CountDeferred(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+
+	failing := mocks.NewMockRow(t)
+	failing.On("Scan", mock.Anything).Return(assert.AnError).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything).Return(failing).Once()
+	_, err = repo.CountDeferred(context.Background())
+	assert.ErrorContains(t, err, "op=maintenance.count_deferred")
+}
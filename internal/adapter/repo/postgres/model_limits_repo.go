@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ModelLimitRepo persists discovered rate limits of AI provider models.
+type ModelLimitRepo struct{ Pool PgxPool }
+
+// NewModelLimitRepo constructs a ModelLimitRepo with the given pool.
+func NewModelLimitRepo(p PgxPool) *ModelLimitRepo { return &ModelLimitRepo{Pool: p} }
+
+// List returns the stored limits of every model of provider.
+func (r *ModelLimitRepo) List(ctx domain.Context, provider string) ([]domain.ModelLimit, error) {
+	tracer := otel.Tracer("repo.model_limits")
+	ctx, span := tracer.Start(ctx, "model_limits.List")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "ai_model_limits"),
+	)
+	rows, err := r.Pool.Query(ctx, `SELECT model, requests_per_day, tokens_per_minute, updated_at FROM ai_model_limits WHERE provider = $1 ORDER BY model`, provider)
+	if err != nil {
+		return nil, fmt.Errorf("op=model_limits.list: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.ModelLimit
+	for rows.Next() {
+		l := domain.ModelLimit{Provider: provider}
+		if err := rows.Scan(&l.Model, &l.RequestsPerDay, &l.TokensPerMinute, &l.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("op=model_limits.list_scan: %w", err)
+		}
+		out = append(out, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=model_limits.list_rows: %w", err)
+	}
+	return out, nil
+}
+
+// Upsert inserts or replaces the limit of a model.
+func (r *ModelLimitRepo) Upsert(ctx domain.Context, l domain.ModelLimit) error {
+	tracer := otel.Tracer("repo.model_limits")
+	ctx, span := tracer.Start(ctx, "model_limits.Upsert")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "ai_model_limits"),
+	)
+	q := `INSERT INTO ai_model_limits (provider, model, requests_per_day, tokens_per_minute, updated_at) VALUES ($1,$2,$3,$4,$5)
+		ON CONFLICT (provider, model) DO UPDATE SET
+			requests_per_day = EXCLUDED.requests_per_day,
+			tokens_per_minute = EXCLUDED.tokens_per_minute,
+			updated_at = EXCLUDED.updated_at`
+	if _, err := r.Pool.Exec(ctx, q, l.Provider, l.Model, l.RequestsPerDay, l.TokensPerMinute, l.UpdatedAt); err != nil {
+		return fmt.Errorf("op=model_limits.upsert: %w", err)
+	}
+	return nil
+}
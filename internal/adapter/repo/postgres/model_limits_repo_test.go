@@ -0,0 +1,65 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestModelLimitRepo_List(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewModelLimitRepo(pool)
+	seen := time.Date(2025, 12, 5, 9, 0, 0, 0, time.UTC)
+
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "llama-3.1-8b-instant"
+		*(dest[1].(*int64)) = 14400
+		*(dest[2].(*int64)) = 6000
+		*(dest[3].(*time.Time)) = seen
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{"groq"}).Return(mockRows, nil).Once()
+
+	limits, err := repo.List(context.Background(), "groq")
+	require.NoError(t, err)
+	require.Len(t, limits, 1)
+	assert.Equal(t, domain.ModelLimit{Provider: "groq", Model: "llama-3.1-8b-instant", RequestsPerDay: 14400, TokensPerMinute: 6000, UpdatedAt: seen}, limits[0])
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+	_, err = repo.List(context.Background(), "groq")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=model_limits.list")
+}
+
+func TestModelLimitRepo_Upsert(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewModelLimitRepo(pool)
+	seen := time.Date(2025, 12, 5, 9, 0, 0, 0, time.UTC)
+	l := domain.ModelLimit{Provider: "groq", Model: "qwen/qwen3-32b", RequestsPerDay: 1000, TokensPerMinute: 6000, UpdatedAt: seen}
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"groq", "qwen/qwen3-32b", int64(1000), int64(6000), seen}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), l))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	err := repo.Upsert(context.Background(), l)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=model_limits.upsert")
+}
@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// insertOutboxTaskSQL records an outbox entry for the task $2 of job $1 at
+// $3 and keeps the task on the job row, which outlives the purge of the
+// entry. The job counts as enqueued at $3.
+const insertOutboxTaskSQL = `WITH task AS (UPDATE jobs SET task=$2, updated_at=$3 WHERE id=$1)
+	INSERT INTO evaluate_outbox (job_id, payload, created_at) VALUES ($1,$2,$3)`
+
+// OutboxRepo writes jobs and their evaluation tasks in one transaction and
+// serves the pending tasks to the outbox relay.
+type OutboxRepo struct{ Pool PgxPool }
+
+// NewOutboxRepo constructs an OutboxRepo with the given pool.
+func NewOutboxRepo(p PgxPool) *OutboxRepo { return &OutboxRepo{Pool: p} }
+
+// CreateJob inserts j and the outbox entry for p atomically and returns the
+// job id.
+func (r *OutboxRepo) CreateJob(ctx domain.Context, j domain.Job, p domain.EvaluateTaskPayload) (string, error) {
+	tracer := otel.Tracer("repo.outbox")
+	ctx, span := tracer.Start(ctx, "outbox.CreateJob")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "INSERT"),
+		attribute.String("db.sql.table", "jobs,evaluate_outbox"),
+	)
+	id := j.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	p.JobID = id
+	body, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("op=outbox.create_job_marshal: %w", err)
+	}
+	metadata, tags, err := jobLabelArgs(j)
+	if err != nil {
+		return "", fmt.Errorf("op=outbox.create_job_labels: %w", err)
+	}
+	tx, err := r.Pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return "", fmt.Errorf("op=outbox.create_job.begin_tx: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			if err := tx.Rollback(ctx); err != nil {
+				slog.Error("failed to rollback outbox transaction", slog.String("job_id", id), slog.Any("error", err))
+			}
+		}
+	}()
+	now := time.Now().UTC()
+	if _, err := tx.Exec(ctx, insertJobSQL, id, j.Status, j.Error, now, now, j.CVID, j.ProjectID, j.IdemKey, j.RequestID, j.ExpiresAt, metadata, tags); err != nil {
+		return "", fmt.Errorf("op=outbox.create_job.insert_job: %w", err)
+	}
+	if _, err := tx.Exec(ctx, insertOutboxTaskSQL, id, body, now); err != nil {
+		return "", fmt.Errorf("op=outbox.create_job.insert_outbox: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("op=outbox.create_job.commit: %w", err)
+	}
+	committed = true
+	return id, nil
+}
+
+// ClaimPending leases up to limit unsent entries, oldest first. Rows locked
+// by a concurrent claim are skipped.
+func (r *OutboxRepo) ClaimPending(ctx domain.Context, limit int, lease time.Duration) ([]domain.OutboxEntry, error) {
+	tracer := otel.Tracer("repo.outbox")
+	ctx, span := tracer.Start(ctx, "outbox.ClaimPending")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPDATE"),
+		attribute.String("db.sql.table", "evaluate_outbox"),
+	)
+	q := `UPDATE evaluate_outbox SET locked_until = now() + make_interval(secs => $2), attempts = attempts + 1
+		WHERE id IN (
+			SELECT id FROM evaluate_outbox WHERE sent_at IS NULL AND parked_at IS NULL AND locked_until <= now()
+			ORDER BY created_at LIMIT $1 FOR UPDATE SKIP LOCKED
+		) RETURNING id, payload, attempts, created_at`
+	rows, err := r.Pool.Query(ctx, q, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("op=outbox.claim_pending: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.OutboxEntry
+	for rows.Next() {
+		var e domain.OutboxEntry
+		var body []byte
+		if err := rows.Scan(&e.ID, &body, &e.Attempts, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("op=outbox.claim_pending_scan: %w", err)
+		}
+		if err := json.Unmarshal(body, &e.Payload); err != nil {
+			return nil, fmt.Errorf("op=outbox.claim_pending_unmarshal: %w", err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=outbox.claim_pending_rows: %w", err)
+	}
+	// UPDATE ... RETURNING does not preserve the subquery order.
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+// MarkSent records that the entry was published.
+func (r *OutboxRepo) MarkSent(ctx domain.Context, id int64) error {
+	tracer := otel.Tracer("repo.outbox")
+	ctx, span := tracer.Start(ctx, "outbox.MarkSent")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPDATE"),
+		attribute.String("db.sql.table", "evaluate_outbox"),
+	)
+	if _, err := r.Pool.Exec(ctx, `UPDATE evaluate_outbox SET sent_at = now() WHERE id=$1`, id); err != nil {
+		return fmt.Errorf("op=outbox.mark_sent: %w", err)
+	}
+	return nil
+}
+
+// Park stops relaying the entry and fails its job, if still queued, with
+// reason.
+func (r *OutboxRepo) Park(ctx domain.Context, id int64, reason string) error {
+	tracer := otel.Tracer("repo.outbox")
+	ctx, span := tracer.Start(ctx, "outbox.Park")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPDATE"),
+		attribute.String("db.sql.table", "evaluate_outbox,jobs"),
+	)
+	q := `WITH parked AS (
+			UPDATE evaluate_outbox SET parked_at = now(), last_error = $2 WHERE id=$1 RETURNING job_id
+		)
+		UPDATE jobs SET status='failed', error=$2, updated_at=now() WHERE id IN (SELECT job_id FROM parked) AND status='queued'`
+	if _, err := r.Pool.Exec(ctx, q, id, reason); err != nil {
+		return fmt.Errorf("op=outbox.park: %w", err)
+	}
+	return nil
+}
+
+// PurgeSent deletes entries sent before before.
+func (r *OutboxRepo) PurgeSent(ctx domain.Context, before time.Time) (int64, error) {
+	tracer := otel.Tracer("repo.outbox")
+	ctx, span := tracer.Start(ctx, "outbox.PurgeSent")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "DELETE"),
+		attribute.String("db.sql.table", "evaluate_outbox"),
+	)
+	tag, err := r.Pool.Exec(ctx, `DELETE FROM evaluate_outbox WHERE sent_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("op=outbox.purge_sent: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Requeue resets the failed job p.JobID to queued, deletes its checkpoints so
+// the retry runs every step again, and records an outbox entry for p, which
+// becomes the job's task.
+func (r *OutboxRepo) Requeue(ctx domain.Context, p domain.EvaluateTaskPayload) error {
+	tracer := otel.Tracer("repo.outbox")
+	ctx, span := tracer.Start(ctx, "outbox.Requeue")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPDATE"),
+		attribute.String("db.sql.table", "jobs,evaluation_checkpoints,evaluate_outbox"),
+		attribute.String("job.id", p.JobID),
+	)
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("op=outbox.requeue_marshal: %w", err)
+	}
+	tx, err := r.Pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return fmt.Errorf("op=outbox.requeue.begin_tx: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			if err := tx.Rollback(ctx); err != nil {
+				slog.Error("failed to rollback outbox transaction", slog.String("job_id", p.JobID), slog.Any("error", err))
+			}
+		}
+	}()
+	now := time.Now().UTC()
+	tag, err := tx.Exec(ctx, `UPDATE jobs SET status='queued', error='', updated_at=$2 WHERE id=$1 AND status='failed'`, p.JobID, now)
+	if err != nil {
+		return fmt.Errorf("op=outbox.requeue.update_job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("op=outbox.requeue: %w: job is not failed", domain.ErrConflict)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM evaluation_checkpoints WHERE job_id=$1`, p.JobID); err != nil {
+		return fmt.Errorf("op=outbox.requeue.delete_checkpoints: %w", err)
+	}
+	if _, err := tx.Exec(ctx, insertOutboxTaskSQL, p.JobID, body, now); err != nil {
+		return fmt.Errorf("op=outbox.requeue.insert_outbox: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("op=outbox.requeue.commit: %w", err)
+	}
+	committed = true
+	return nil
+}
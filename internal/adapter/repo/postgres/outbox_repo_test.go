@@ -0,0 +1,159 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestOutboxRepo_CreateJob(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewOutboxRepo(pool)
+	tx := mocks.NewMockTx(t)
+
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Equal(t, "job-1", args[0])
+	}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	var stored []byte
+	tx.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "UPDATE jobs SET task=$2") && strings.Contains(q, "INSERT INTO evaluate_outbox")
+	}), mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Equal(t, "job-1", args[0])
+		stored = args[1].([]byte)
+	}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+
+	id, err := repo.CreateJob(context.Background(), domain.Job{ID: "job-1", Status: domain.JobQueued, CVID: "cv-1"}, domain.EvaluateTaskPayload{CVID: "cv-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", id)
+	var p domain.EvaluateTaskPayload
+	require.NoError(t, json.Unmarshal(stored, &p))
+	assert.Equal(t, domain.EvaluateTaskPayload{JobID: "job-1", CVID: "cv-1"}, p)
+
+	// A failed outbox insert rolls back the job as well.
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	tx.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+	_, err = repo.CreateJob(context.Background(), domain.Job{Status: domain.JobQueued}, domain.EvaluateTaskPayload{})
+	assert.ErrorContains(t, err, "op=outbox.create_job.insert_outbox")
+}
+
+func TestOutboxRepo_ClaimPending(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewOutboxRepo(pool)
+	t0 := time.Date(2025, 12, 17, 9, 0, 0, 0, time.UTC)
+	older, err := json.Marshal(domain.EvaluateTaskPayload{JobID: "j1"})
+	require.NoError(t, err)
+	newer, err := json.Marshal(domain.EvaluateTaskPayload{JobID: "j2"})
+	require.NoError(t, err)
+
+	// RETURNING yields rows in arbitrary order; the newer one comes first here.
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 2
+	}).Times(3)
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*int64)) = 2
+		*(dest[1].(*[]byte)) = newer
+		*(dest[2].(*int)) = 1
+		*(dest[3].(*time.Time)) = t0.Add(time.Second)
+	}).Return(nil).Once()
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*int64)) = 1
+		*(dest[1].(*[]byte)) = older
+		*(dest[2].(*int)) = 3
+		*(dest[3].(*time.Time)) = t0
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{50, 30.0}).Return(mockRows, nil).Once()
+
+	got, err := repo.ClaimPending(context.Background(), 50, 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.OutboxEntry{
+		{ID: 1, Payload: domain.EvaluateTaskPayload{JobID: "j1"}, Attempts: 3, CreatedAt: t0},
+		{ID: 2, Payload: domain.EvaluateTaskPayload{JobID: "j2"}, Attempts: 1, CreatedAt: t0.Add(time.Second)},
+	}, got)
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+	_, err = repo.ClaimPending(context.Background(), 50, time.Second)
+	assert.ErrorContains(t, err, "op=outbox.claim_pending")
+}
+
+func TestOutboxRepo_MarkSentAndPurge(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewOutboxRepo(pool)
+	before := time.Date(2025, 12, 16, 9, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{int64(4)}).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	require.NoError(t, repo.MarkSent(context.Background(), 4))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{before}).Return(pgconn.NewCommandTag("DELETE 12"), nil).Once()
+	n, err := repo.PurgeSent(context.Background(), before)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), n)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.MarkSent(context.Background(), 4), "op=outbox.mark_sent")
+}
+
+func TestOutboxRepo_Park(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewOutboxRepo(pool)
+
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "SET parked_at = now(), last_error = $2") && strings.Contains(q, "UPDATE jobs SET status='failed', error=$2")
+	}), []any{int64(4), "enqueue failed"}).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	require.NoError(t, repo.Park(context.Background(), 4, "enqueue failed"))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.Park(context.Background(), 4, "enqueue failed"), "op=outbox.park")
+}
+
+func TestOutboxRepo_Requeue(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewOutboxRepo(pool)
+	tx := mocks.NewMockTx(t)
+	p := domain.EvaluateTaskPayload{JobID: "job-1", Overrides: domain.EvaluationOverrides{Provider: "groq", Model: "llama-3.1-8b-instant"}}
+
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "UPDATE jobs") }), mock.Anything).
+		Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "DELETE FROM evaluation_checkpoints") }), mock.Anything).
+		Return(pgconn.NewCommandTag("DELETE 2"), nil).Once()
+	var stored []byte
+	// The requeued task also replaces the one kept on the job.
+	tx.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "UPDATE jobs SET task=$2") && strings.Contains(q, "INSERT INTO evaluate_outbox")
+	}), mock.Anything).
+		Run(func(_ context.Context, _ string, args ...any) { stored = args[1].([]byte) }).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+	require.NoError(t, repo.Requeue(context.Background(), p))
+	var got domain.EvaluateTaskPayload
+	require.NoError(t, json.Unmarshal(stored, &got))
+	assert.Equal(t, p, got)
+
+	// A job that is not failed is left alone.
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+	tx.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+	assert.ErrorIs(t, repo.Requeue(context.Background(), p), domain.ErrConflict)
+}
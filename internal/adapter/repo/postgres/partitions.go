@@ -0,0 +1,330 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// partitionedTables lists the monthly-partitioned tables. results comes first
+// so a job's result is never left behind once its job partition is dropped.
+var partitionedTables = []string{"results", "jobs"}
+
+// ArchiveSink persists exported partition data in cold storage.
+type ArchiveSink interface {
+	Store(ctx context.Context, key string, r io.Reader) error
+}
+
+// Copier streams the output of a COPY ... TO STDOUT statement into w.
+type Copier interface {
+	CopyTo(ctx context.Context, w io.Writer, sql string) error
+}
+
+// PoolCopier adapts *pgxpool.Pool to Copier by acquiring a connection per COPY.
+type PoolCopier struct{ Pool *pgxpool.Pool }
+
+// CopyTo runs sql (a COPY ... TO STDOUT statement) and writes its output to w.
+func (c PoolCopier) CopyTo(ctx context.Context, w io.Writer, sql string) error {
+	conn, err := c.Pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	_, err = conn.Conn().PgConn().CopyTo(ctx, w, sql)
+	return err
+}
+
+// PartitionManager keeps monthly partitions of jobs and results created ahead
+// of time and, when a Sink is configured, archives partitions that fall
+// entirely before the retention cutoff before dropping them.
+type PartitionManager struct {
+	Pool          PgxPool
+	Copier        Copier
+	Sink          ArchiveSink
+	PremakeMonths int
+}
+
+// NewPartitionManager constructs a PartitionManager. copier and sink may be
+// nil when archival is disabled; partitions are then only created.
+func NewPartitionManager(pool PgxPool, copier Copier, sink ArchiveSink, premakeMonths int) *PartitionManager {
+	if premakeMonths < 1 {
+		premakeMonths = 3
+	}
+	return &PartitionManager{Pool: pool, Copier: copier, Sink: sink, PremakeMonths: premakeMonths}
+}
+
+// ArchiveEnabled reports whether expired partitions are archived and dropped.
+func (m *PartitionManager) ArchiveEnabled() bool { return m.Sink != nil && m.Copier != nil }
+
+// EnsurePartitions creates partitions from the month of now through
+// PremakeMonths months ahead. Existing partitions are left untouched.
+func (m *PartitionManager) EnsurePartitions(ctx context.Context, now time.Time) error {
+	start := monthStart(now)
+	for _, table := range partitionedTables {
+		for i := 0; i <= m.PremakeMonths; i++ {
+			from := start.AddDate(0, i, 0)
+			if err := m.createPartition(ctx, table, from); err != nil {
+				return fmt.Errorf("op=partition.create %s: %w", partitionName(table, from), err)
+			}
+		}
+	}
+	return nil
+}
+
+// createPartition creates the partition of table for the month starting at
+// from. Postgres refuses to attach a range the DEFAULT partition already holds
+// rows for, so those rows are moved into the new table before it is attached.
+func (m *PartitionManager) createPartition(ctx context.Context, table string, from time.Time) error {
+	name := partitionName(table, from)
+	tx, err := m.Pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return err
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+	// Instances creating the same partition at once take turns.
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, name); err != nil {
+		return err
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM pg_class WHERE relname = $1)`, name).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		to := from.AddDate(0, 1, 0)
+		parent, ident := pgx.Identifier{table}.Sanitize(), pgx.Identifier{name}.Sanitize()
+		if _, err := tx.Exec(ctx, "CREATE TABLE "+ident+" (LIKE "+parent+" INCLUDING DEFAULTS INCLUDING CONSTRAINTS)"); err != nil {
+			return err
+		}
+		move := "WITH moved AS (DELETE FROM " + pgx.Identifier{table + "_default"}.Sanitize() + " WHERE created_at >= $1 AND created_at < $2 RETURNING *) INSERT INTO " + ident + " SELECT * FROM moved"
+		if _, err := tx.Exec(ctx, move, from, to); err != nil {
+			return err
+		}
+		attach := fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s FOR VALUES FROM ('%s') TO ('%s')",
+			parent, ident, from.Format(time.RFC3339), to.Format(time.RFC3339))
+		if _, err := tx.Exec(ctx, attach); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// RunPeriodic keeps upcoming partitions created by calling EnsurePartitions
+// every interval until ctx is cancelled, so writes keep landing in monthly
+// partitions even when retention cleanup is disabled.
+func (m *PartitionManager) RunPeriodic(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := m.EnsurePartitions(ctx, now); err != nil {
+				slog.Error("partition maintenance failed", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+// ArchiveExpired exports every partition whose range ends at or before cutoff
+// to the sink as CSV, then detaches and drops it. It returns the number of
+// partitions archived. A failed export leaves the partition in place so the
+// next run retries it. A partition holding jobs under a legal hold is kept,
+// but its other rows are exported and deleted row by row.
+func (m *PartitionManager) ArchiveExpired(ctx context.Context, cutoff time.Time) (int, error) {
+	if !m.ArchiveEnabled() {
+		return 0, nil
+	}
+	archived := 0
+	for _, table := range partitionedTables {
+		names, err := m.listPartitions(ctx, table)
+		if err != nil {
+			return archived, err
+		}
+		for _, name := range names {
+			month, ok := parsePartitionMonth(table, name)
+			if !ok || month.AddDate(0, 1, 0).After(cutoff) {
+				continue
+			}
+			held, err := m.holdsJobs(ctx, table, name)
+			if err != nil {
+				return archived, err
+			}
+			if held {
+				n, err := m.archiveUnheld(ctx, table, name, cutoff)
+				if err != nil {
+					return archived, err
+				}
+				slog.Info("partition kept for legal hold", slog.String("table", table), slog.String("partition", name),
+					slog.Int64("archived_rows", n))
+				continue
+			}
+			if err := m.archivePartition(ctx, table, name); err != nil {
+				return archived, err
+			}
+			archived++
+			slog.Info("partition archived", slog.String("table", table), slog.String("partition", name))
+		}
+	}
+	return archived, nil
+}
+
+// jobColumn returns the column of table that holds the job ID.
+func jobColumn(table string) string {
+	if table == "jobs" {
+		return "id"
+	}
+	return "job_id"
+}
+
+// holdsJobs reports whether a partition has rows of jobs under a legal hold.
+func (m *PartitionManager) holdsJobs(ctx context.Context, table, name string) (bool, error) {
+	var held bool
+	q := "SELECT EXISTS (SELECT 1 FROM " + pgx.Identifier{name}.Sanitize() + " WHERE " + jobColumn(table) + " IN (" + heldJobsSQL + "))"
+	if err := m.Pool.QueryRow(ctx, q).Scan(&held); err != nil {
+		return false, fmt.Errorf("op=partition.legal_hold %s: %w", name, err)
+	}
+	return held, nil
+}
+
+// archiveUnheld exports the rows of a held partition that belong to jobs
+// without a legal hold and deletes them, leaving only the held rows behind.
+// Each run writes its own object, keyed by cutoff, so earlier exports are
+// never overwritten. It returns the number of rows deleted.
+func (m *PartitionManager) archiveUnheld(ctx context.Context, table, name string, cutoff time.Time) (int64, error) {
+	ident := pgx.Identifier{name}.Sanitize()
+	unheld := jobColumn(table) + " NOT IN (" + heldJobsSQL + ")"
+	var found bool
+	if err := m.Pool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+ident+" WHERE "+unheld+")").Scan(&found); err != nil {
+		return 0, fmt.Errorf("op=partition.unheld %s: %w", name, err)
+	}
+	if !found {
+		return 0, nil
+	}
+	key := table + "/" + name + "_" + cutoff.UTC().Format("20060102T150405Z") + ".csv"
+	if err := m.export(ctx, name, key, "SELECT * FROM "+ident+" WHERE "+unheld); err != nil {
+		return 0, err
+	}
+	del := "DELETE FROM " + ident + " WHERE " + unheld
+	if table == "results" {
+		// Forget the deleted rows' keys, as when a whole partition is dropped.
+		del = "WITH del AS (" + del + " RETURNING job_id) DELETE FROM result_job_keys WHERE job_id IN (SELECT job_id FROM del)"
+	}
+	tag, err := m.Pool.Exec(ctx, del)
+	if err != nil {
+		return 0, fmt.Errorf("op=partition.delete_unheld %s: %w", name, err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// listPartitions returns the names of the partitions attached to table.
+func (m *PartitionManager) listPartitions(ctx context.Context, table string) ([]string, error) {
+	rows, err := m.Pool.Query(ctx, `SELECT c.relname FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = $1 ORDER BY c.relname`, table)
+	if err != nil {
+		return nil, fmt.Errorf("op=partition.list: %w", err)
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("op=partition.list_scan: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=partition.list_rows: %w", err)
+	}
+	return names, nil
+}
+
+// archivePartition streams a partition to the sink and drops it once the
+// sink has accepted the complete export.
+func (m *PartitionManager) archivePartition(ctx context.Context, table, name string) error {
+	ident := pgx.Identifier{name}.Sanitize()
+	if err := m.export(ctx, name, table+"/"+name+".csv", "SELECT * FROM "+ident); err != nil {
+		return err
+	}
+	if _, err := m.Pool.Exec(ctx, "ALTER TABLE "+pgx.Identifier{table}.Sanitize()+" DETACH PARTITION "+ident); err != nil {
+		return fmt.Errorf("op=partition.detach %s: %w", name, err)
+	}
+	if _, err := m.Pool.Exec(ctx, "DROP TABLE "+ident); err != nil {
+		return fmt.Errorf("op=partition.drop %s: %w", name, err)
+	}
+	if table == "results" {
+		// Forget the dropped rows' keys so a later upsert of the same job
+		// starts a new row instead of routing to the missing partition.
+		month, _ := parsePartitionMonth(table, name)
+		if _, err := m.Pool.Exec(ctx, `DELETE FROM result_job_keys WHERE created_at >= $1 AND created_at < $2`, month, month.AddDate(0, 1, 0)); err != nil {
+			return fmt.Errorf("op=partition.drop_keys %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// export streams the rows selected by query from partition name to the sink
+// under key as CSV.
+func (m *PartitionManager) export(ctx context.Context, name, key, query string) error {
+	pr, pw := io.Pipe()
+	copyErr := make(chan error, 1)
+	go func() {
+		err := m.Copier.CopyTo(ctx, pw, "COPY ("+query+") TO STDOUT WITH (FORMAT csv, HEADER true)")
+		_ = pw.CloseWithError(err)
+		copyErr <- err
+	}()
+
+	storeErr := m.Sink.Store(ctx, key, pr)
+	_ = pr.CloseWithError(storeErr)
+	if err := <-copyErr; err != nil {
+		return fmt.Errorf("op=partition.export %s: %w", name, err)
+	}
+	if storeErr != nil {
+		return fmt.Errorf("op=partition.store %s: %w", name, storeErr)
+	}
+	return nil
+}
+
+// partitionName returns the monthly partition name, e.g. jobs_p202501.
+func partitionName(table string, month time.Time) string {
+	return table + "_p" + month.UTC().Format("200601")
+}
+
+// parsePartitionMonth extracts the month from a partition name produced by
+// partitionName; other partitions (such as the DEFAULT one) are rejected.
+func parsePartitionMonth(table, name string) (time.Time, bool) {
+	suffix, ok := strings.CutPrefix(name, table+"_p")
+	if !ok || len(suffix) != 6 {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("200601", suffix)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
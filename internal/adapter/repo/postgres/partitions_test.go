@@ -0,0 +1,284 @@
+package postgres_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+)
+
+type fakeCopier struct {
+	data string
+	err  error
+	sql  []string
+}
+
+func (c *fakeCopier) CopyTo(_ context.Context, w io.Writer, sql string) error {
+	c.sql = append(c.sql, sql)
+	if c.err != nil {
+		return c.err
+	}
+	_, err := io.WriteString(w, c.data)
+	return err
+}
+
+type memorySink struct {
+	stored map[string]string
+	err    error
+}
+
+func (s *memorySink) Store(_ context.Context, key string, r io.Reader) error {
+	if s.err != nil {
+		return s.err
+	}
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		return err
+	}
+	if s.stored == nil {
+		s.stored = map[string]string{}
+	}
+	s.stored[key] = buf.String()
+	return nil
+}
+
+func partitionRows(t *testing.T, names ...string) *mocks.MockRows {
+	rows := mocks.NewMockRows(t)
+	i := 0
+	rows.On("Next").Return(func() bool {
+		i++
+		return i <= len(names)
+	})
+	rows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*string)) = names[i-1]
+	}).Return(nil).Maybe()
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	return rows
+}
+
+// boolRow answers a single boolean query, such as the legal hold check of a
+// partition.
+func boolRow(t *testing.T, v bool) *mocks.MockRow {
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*bool)) = v
+	}).Return(nil).Once()
+	return row
+}
+
+// partitionTx answers one createPartition transaction: the partition exists
+// already or, when it does not, the statements creating it are recorded.
+func partitionTx(t *testing.T, exists bool, stmts *[]string) *mocks.MockTx {
+	tx := mocks.NewMockTx(t)
+	tx.EXPECT().Exec(mock.Anything, `SELECT pg_advisory_xact_lock(hashtext($1))`, mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(boolRow(t, exists)).Once()
+	if !exists {
+		tx.EXPECT().Exec(mock.Anything, mock.Anything).Run(func(_ context.Context, sql string, _ ...any) {
+			*stmts = append(*stmts, sql)
+		}).Return(pgconn.CommandTag{}, nil).Twice()
+		tx.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, sql string, _ ...any) {
+			*stmts = append(*stmts, sql)
+		}).Return(pgconn.CommandTag{}, nil).Once()
+	}
+	tx.EXPECT().Commit(mock.Anything).Return(nil).Once()
+	return tx
+}
+
+func TestPartitionManager_EnsurePartitions(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	var stmts []string
+	// Only results_p202601 is missing.
+	created := 0
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).RunAndReturn(func(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+		created++
+		return partitionTx(t, created != 3, &stmts), nil
+	}).Times(6)
+
+	m := postgres.NewPartitionManager(pool, nil, nil, 2)
+	require.NoError(t, m.EnsurePartitions(context.Background(), time.Date(2025, 11, 15, 12, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{
+		`CREATE TABLE "results_p202601" (LIKE "results" INCLUDING DEFAULTS INCLUDING CONSTRAINTS)`,
+		`WITH moved AS (DELETE FROM "results_default" WHERE created_at >= $1 AND created_at < $2 RETURNING *) INSERT INTO "results_p202601" SELECT * FROM moved`,
+		`ALTER TABLE "results" ATTACH PARTITION "results_p202601" FOR VALUES FROM ('2026-01-01T00:00:00Z') TO ('2026-02-01T00:00:00Z')`,
+	}, stmts)
+	assert.False(t, m.ArchiveEnabled())
+}
+
+func TestPartitionManager_EnsurePartitions_Error(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	tx := mocks.NewMockTx(t)
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).Return(tx, nil).Once()
+	tx.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("boom")).Once()
+	tx.EXPECT().Rollback(mock.Anything).Return(nil).Once()
+
+	m := postgres.NewPartitionManager(pool, nil, nil, 2)
+	err := m.EnsurePartitions(context.Background(), time.Date(2025, 11, 15, 12, 0, 0, 0, time.UTC))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=partition.create results_p202511")
+}
+
+func TestPartitionManager_ArchiveExpired(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{"results"}).
+		Return(partitionRows(t, "results_default", "results_p202501", "results_p202502"), nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{"jobs"}).
+		Return(partitionRows(t, "jobs_p202501", "jobs_p202502"), nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything).Return(boolRow(t, false)).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything).Return(boolRow(t, false)).Once()
+	var stmts []string
+	pool.EXPECT().Exec(mock.Anything, mock.Anything).Run(func(_ context.Context, sql string, _ ...any) {
+		stmts = append(stmts, sql)
+	}).Return(pgconn.CommandTag{}, nil)
+	jan, feb := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	pool.EXPECT().Exec(mock.Anything, `DELETE FROM result_job_keys WHERE created_at >= $1 AND created_at < $2`, []any{jan, feb}).
+		Return(pgconn.CommandTag{}, nil).Once()
+
+	copier := &fakeCopier{data: "id\n1\n"}
+	sink := &memorySink{}
+	m := postgres.NewPartitionManager(pool, copier, sink, 3)
+
+	// January ends exactly at the cutoff and is archived; February is still live.
+	n, err := m.ArchiveExpired(context.Background(), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, map[string]string{
+		"results/results_p202501.csv": "id\n1\n",
+		"jobs/jobs_p202501.csv":       "id\n1\n",
+	}, sink.stored)
+	assert.Equal(t, []string{
+		`ALTER TABLE "results" DETACH PARTITION "results_p202501"`,
+		`DROP TABLE "results_p202501"`,
+		`ALTER TABLE "jobs" DETACH PARTITION "jobs_p202501"`,
+		`DROP TABLE "jobs_p202501"`,
+	}, stmts)
+	assert.True(t, strings.HasPrefix(copier.sql[0], `COPY (SELECT * FROM "results_p202501") TO STDOUT`))
+}
+
+func TestPartitionManager_ArchiveExpired_SinkErrorKeepsPartition(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{"results"}).
+		Return(partitionRows(t, "results_p202401"), nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything).Return(boolRow(t, false)).Once()
+
+	m := postgres.NewPartitionManager(pool, &fakeCopier{data: strings.Repeat("x", 1<<20)}, &memorySink{err: errors.New("bucket gone")}, 3)
+	n, err := m.ArchiveExpired(context.Background(), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "results_p202401")
+	assert.Equal(t, 0, n)
+}
+
+func TestPartitionManager_ArchiveExpired_KeepsHeldPartitions(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{"results"}).
+		Return(partitionRows(t, "results_p202501"), nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{"jobs"}).
+		Return(partitionRows(t, "jobs_p202501"), nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, `FROM "results_p202501" WHERE job_id IN (SELECT id FROM jobs WHERE legal_hold`)
+	})).Return(boolRow(t, true)).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, `FROM "jobs_p202501" WHERE id IN (SELECT id FROM jobs WHERE legal_hold`)
+	})).Return(boolRow(t, true)).Once()
+	// The results partition also has rows of jobs without a hold; every job
+	// in the jobs partition is held.
+	pool.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, `FROM "results_p202501" WHERE job_id NOT IN (SELECT id FROM jobs WHERE legal_hold`)
+	})).Return(boolRow(t, true)).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, `FROM "jobs_p202501" WHERE id NOT IN (SELECT id FROM jobs WHERE legal_hold`)
+	})).Return(boolRow(t, false)).Once()
+	var stmts []string
+	pool.EXPECT().Exec(mock.Anything, mock.Anything).Run(func(_ context.Context, sql string, _ ...any) {
+		stmts = append(stmts, sql)
+	}).Return(pgconn.NewCommandTag("DELETE 2"), nil).Once()
+
+	copier := &fakeCopier{data: "job_id\nj-1\nj-2\n"}
+	sink := &memorySink{}
+	m := postgres.NewPartitionManager(pool, copier, sink, 3)
+	n, err := m.ArchiveExpired(context.Background(), time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Zero(t, n, "held partitions are never dropped")
+	assert.Equal(t, map[string]string{"results/results_p202501_20250201T000000Z.csv": "job_id\nj-1\nj-2\n"}, sink.stored)
+	require.Len(t, copier.sql, 1)
+	assert.True(t, strings.HasPrefix(copier.sql[0], `COPY (SELECT * FROM "results_p202501" WHERE job_id NOT IN (SELECT id FROM jobs WHERE legal_hold`))
+	require.Len(t, stmts, 1)
+	assert.True(t, strings.HasPrefix(stmts[0], `WITH del AS (DELETE FROM "results_p202501" WHERE job_id NOT IN (SELECT id FROM jobs WHERE legal_hold`))
+	assert.Contains(t, stmts[0], "DELETE FROM result_job_keys WHERE job_id IN (SELECT job_id FROM del)")
+}
+
+func TestCleanupService_WithPartitionArchival_SkipsRowDeletes(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	pool.EXPECT().BeginTx(mock.Anything, mock.Anything).RunAndReturn(func(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+		return partitionTx(t, true, nil), nil
+	})
+	pool.EXPECT().Query(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(context.Context, string, ...any) (pgx.Rows, error) { return partitionRows(t), nil })
+
+	tx := mocks.NewMockTx(t)
+	tx.EXPECT().Rollback(mock.Anything).Return(nil)
+	tx.EXPECT().Commit(mock.Anything).Return(nil)
+	row := mocks.NewMockRow(t)
+	row.EXPECT().Scan(mock.Anything).Return(nil).Times(13)
+	// Only the job expiry, orphaned uploads, derived summaries, checkpoints,
+	// result versions, expired job locks, quota usage, prompt variant, job
+	// bump, provider error, job usage, AI saturation and score observation
+	// statements run while archiving.
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM uploads")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM candidate_summaries")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM evaluation_checkpoints")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM result_versions")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "UPDATE jobs SET status = 'expired'")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM job_locks")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM tenant_quota_usage")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM job_prompt_variants")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM job_bumps")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM provider_errors")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM job_usage")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM ai_saturation")
+	}), mock.Anything).Return(row).Once()
+	tx.EXPECT().QueryRow(mock.Anything, mock.MatchedBy(func(sql string) bool {
+		return strings.Contains(sql, "DELETE FROM model_score_observations")
+	}), mock.Anything).Return(row).Once()
+	b := mocks.NewMockBeginner(t)
+	b.EXPECT().Begin(mock.Anything).Return(tx, nil)
+
+	svc := postgres.NewCleanupService(b, 30)
+	svc.Partitions = postgres.NewPartitionManager(pool, &fakeCopier{}, &memorySink{}, 1)
+	require.NoError(t, svc.CleanupOldData(context.Background()))
+}
@@ -0,0 +1,84 @@
+// Package postgres provides PostgreSQL database adapters.
+package postgres
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStatter is the subset of *pgxpool.Pool needed to export pool metrics.
+type PoolStatter interface {
+	Stat() *pgxpool.Stat
+}
+
+// PoolCollector exports pgxpool statistics as Prometheus metrics. Values are
+// read from the pool on every scrape so no background goroutine is needed.
+type PoolCollector struct {
+	pool PoolStatter
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquireCount      *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	emptyAcquireCount *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+	newConnsCount     *prometheus.Desc
+	lifetimeDestroyed *prometheus.Desc
+	idleDestroyed     *prometheus.Desc
+}
+
+// NewPoolCollector builds a collector for the given pool. The process label
+// distinguishes server and worker pools when both are scraped.
+func NewPoolCollector(pool PoolStatter, process string) *PoolCollector {
+	labels := prometheus.Labels{"process": process}
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("db_pool_"+name, help, nil, labels)
+	}
+	return &PoolCollector{
+		pool:              pool,
+		acquiredConns:     desc("acquired_connections", "Number of currently acquired connections"),
+		idleConns:         desc("idle_connections", "Number of currently idle connections"),
+		totalConns:        desc("total_connections", "Total number of connections currently open"),
+		maxConns:          desc("max_connections", "Maximum size of the pool"),
+		acquireCount:      desc("acquire_total", "Cumulative count of successful acquires"),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total time spent waiting for successful acquires"),
+		emptyAcquireCount: desc("empty_acquire_total", "Cumulative count of acquires that waited for a connection"),
+		canceledAcquires:  desc("canceled_acquire_total", "Cumulative count of acquires canceled by context"),
+		newConnsCount:     desc("new_connections_total", "Cumulative count of new connections opened"),
+		lifetimeDestroyed: desc("max_lifetime_destroy_total", "Cumulative count of connections closed due to MaxConnLifetime"),
+		idleDestroyed:     desc("max_idle_destroy_total", "Cumulative count of connections closed due to MaxConnIdleTime"),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquires
+	ch <- c.newConnsCount
+	ch <- c.lifetimeDestroyed
+	ch <- c.idleDestroyed
+}
+
+// Collect implements prometheus.Collector.
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.newConnsCount, prometheus.CounterValue, float64(s.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(c.lifetimeDestroyed, prometheus.CounterValue, float64(s.MaxLifetimeDestroyCount()))
+	ch <- prometheus.MustNewConstMetric(c.idleDestroyed, prometheus.CounterValue, float64(s.MaxIdleDestroyCount()))
+}
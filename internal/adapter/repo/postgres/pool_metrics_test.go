@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolCollector_CollectsAllMetrics(t *testing.T) {
+	// pgxpool connects lazily, so an unreachable DSN still yields usable stats.
+	pool, err := pgxpool.New(context.Background(), "postgres://u:p@127.0.0.1:1/db")
+	require.NoError(t, err)
+	defer pool.Close()
+
+	c := NewPoolCollector(pool, "server")
+	assert.Equal(t, 11, testutil.CollectAndCount(c))
+
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(c))
+	mfs, err := reg.Gather()
+	require.NoError(t, err)
+	names := map[string]bool{}
+	for _, mf := range mfs {
+		names[mf.GetName()] = true
+	}
+	assert.True(t, names["db_pool_acquired_connections"])
+	assert.True(t, names["db_pool_max_connections"])
+	assert.True(t, names["db_pool_acquire_total"])
+}
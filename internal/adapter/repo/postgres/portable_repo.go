@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PortableTables are the tables copied between environments by cmd/export
+// and cmd/import, in foreign key order.
+var PortableTables = []string{"uploads", "jobs", "results", "result_versions"}
+
+// portableKeys are the columns identifying a row of each portable table.
+// Partitioned tables have no unique index on them alone, so imports check
+// them explicitly instead of relying on ON CONFLICT.
+var portableKeys = map[string][]string{
+	"uploads":         {"id"},
+	"jobs":            {"id"},
+	"results":         {"job_id"},
+	"result_versions": {"job_id", "version"},
+}
+
+// PortableRepo reads and writes whole rows as JSON objects keyed by column
+// name, so archives survive columns added by later migrations: missing keys
+// take the column default on import and unknown keys are ignored.
+type PortableRepo struct{ Pool PgxPool }
+
+// NewPortableRepo constructs a PortableRepo with the given pool.
+func NewPortableRepo(p PgxPool) *PortableRepo { return &PortableRepo{Pool: p} }
+
+// ExportRows calls fn with every row of table.
+func (r *PortableRepo) ExportRows(ctx context.Context, table string, fn func(row json.RawMessage) error) error {
+	ident, err := portableTable(table)
+	if err != nil {
+		return err
+	}
+	tracer := otel.Tracer("repo.portable")
+	ctx, span := tracer.Start(ctx, "portable.ExportRows")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", table),
+	)
+	rows, err := r.Pool.Query(ctx, "SELECT row_to_json(t)::text FROM "+ident+" t")
+	if err != nil {
+		return fmt.Errorf("op=portable.export %s: %w", table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return fmt.Errorf("op=portable.export_scan %s: %w", table, err)
+		}
+		if err := fn(json.RawMessage(row)); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("op=portable.export_rows %s: %w", table, err)
+	}
+	return nil
+}
+
+// ImportRow inserts row into table and reports whether it was inserted; rows
+// whose key already exists are left untouched, so imports can be rerun.
+// Results are checked against and recorded in result_job_keys, which later
+// upserts of the job rely on.
+func (r *PortableRepo) ImportRow(ctx context.Context, table string, row json.RawMessage) (bool, error) {
+	ident, err := portableTable(table)
+	if err != nil {
+		return false, err
+	}
+	tracer := otel.Tracer("repo.portable")
+	ctx, span := tracer.Start(ctx, "portable.ImportRow")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "INSERT"),
+		attribute.String("db.sql.table", table),
+	)
+	lookup := ident
+	if table == "results" {
+		lookup = "result_job_keys"
+	}
+	var match []string
+	for _, c := range portableKeys[table] {
+		col := pgx.Identifier{c}.Sanitize()
+		match = append(match, "x."+col+" = r."+col)
+	}
+	q := "INSERT INTO " + ident + " SELECT r.* FROM json_populate_record(NULL::" + ident + ", $1::json) r" +
+		" WHERE NOT EXISTS (SELECT 1 FROM " + lookup + " x WHERE " + strings.Join(match, " AND ") + ") ON CONFLICT DO NOTHING"
+	if table == "results" {
+		q = "WITH ins AS (" + q + " RETURNING job_id, created_at) " +
+			"INSERT INTO result_job_keys (job_id, created_at) SELECT job_id, created_at FROM ins ON CONFLICT (job_id) DO NOTHING"
+	}
+	tag, err := r.Pool.Exec(ctx, q, string(row))
+	if err != nil {
+		return false, fmt.Errorf("op=portable.import %s: %w", table, err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func portableTable(table string) (string, error) {
+	if !slices.Contains(PortableTables, table) {
+		return "", fmt.Errorf("op=portable.table: %q is not a portable table", table)
+	}
+	return pgx.Identifier{table}.Sanitize(), nil
+}
@@ -0,0 +1,76 @@
+package postgres_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+)
+
+func TestPortableRepo_ExportRows(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewPortableRepo(pool)
+
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*string)) = `{"id":"u1"}`
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, `SELECT row_to_json(t)::text FROM "uploads" t`).Return(mockRows, nil).Once()
+
+	var got []string
+	err := repo.ExportRows(context.Background(), "uploads", func(row json.RawMessage) error {
+		got = append(got, string(row))
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"id":"u1"}`}, got)
+
+	err = repo.ExportRows(context.Background(), "users; DROP TABLE jobs", func(json.RawMessage) error { return nil })
+	assert.ErrorContains(t, err, "not a portable table")
+}
+
+func TestPortableRepo_ImportRow(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewPortableRepo(pool)
+	ctx := context.Background()
+
+	pool.EXPECT().Exec(mock.Anything, `INSERT INTO "jobs" SELECT r.* FROM json_populate_record(NULL::"jobs", $1::json) r`+
+		` WHERE NOT EXISTS (SELECT 1 FROM "jobs" x WHERE x."id" = r."id") ON CONFLICT DO NOTHING`, []any{`{"id":"j1"}`}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	inserted, err := repo.ImportRow(ctx, "jobs", json.RawMessage(`{"id":"j1"}`))
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 0"), nil).Once()
+	inserted, err = repo.ImportRow(ctx, "jobs", json.RawMessage(`{"id":"j1"}`))
+	require.NoError(t, err)
+	assert.False(t, inserted)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	_, err = repo.ImportRow(ctx, "jobs", json.RawMessage(`{}`))
+	assert.ErrorContains(t, err, "op=portable.import jobs")
+
+	// Results are keyed by job in result_job_keys, which the import fills.
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, `NOT EXISTS (SELECT 1 FROM result_job_keys x WHERE x."job_id" = r."job_id")`) &&
+			strings.Contains(q, "INSERT INTO result_job_keys")
+	}), []any{`{"job_id":"j1"}`}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	inserted, err = repo.ImportRow(ctx, "results", json.RawMessage(`{"job_id":"j1"}`))
+	require.NoError(t, err)
+	assert.True(t, inserted)
+}
@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+const promptExperimentColumns = `id, name, step, version_a, version_b, traffic_b, created_at, ended_at`
+
+// PromptExperimentRepo persists prompt experiments and job variant assignments.
+type PromptExperimentRepo struct{ Pool PgxPool }
+
+// NewPromptExperimentRepo constructs a PromptExperimentRepo with the given pool.
+func NewPromptExperimentRepo(p PgxPool) *PromptExperimentRepo {
+	return &PromptExperimentRepo{Pool: p}
+}
+
+func startPromptExperimentSpan(ctx domain.Context, name, op, table string) (domain.Context, func()) {
+	tracer := otel.Tracer("repo.prompt_experiments")
+	ctx, span := tracer.Start(ctx, "prompt_experiments."+name)
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", op),
+		attribute.String("db.sql.table", table),
+	)
+	return ctx, func() { span.End() }
+}
+
+// Create stores e with a new ID unless an experiment on e.Step is active.
+func (r *PromptExperimentRepo) Create(ctx domain.Context, e domain.PromptExperiment) (domain.PromptExperiment, error) {
+	ctx, end := startPromptExperimentSpan(ctx, "Create", "INSERT", "prompt_experiments")
+	defer end()
+	e.ID = uuid.New().String()
+	e.EndedAt = nil
+	q := `INSERT INTO prompt_experiments (id, name, step, version_a, version_b, traffic_b, created_at)
+		SELECT $1,$2,$3,$4,$5,$6,$7
+		WHERE NOT EXISTS (SELECT 1 FROM prompt_experiments WHERE step=$3 AND ended_at IS NULL)`
+	tag, err := r.Pool.Exec(ctx, q, e.ID, e.Name, e.Step, e.VersionA, e.VersionB, e.TrafficB, e.CreatedAt)
+	if err != nil {
+		return domain.PromptExperiment{}, fmt.Errorf("op=prompt_experiment.create: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.PromptExperiment{}, fmt.Errorf("op=prompt_experiment.create: %w: an experiment on step %s is active", domain.ErrConflict, e.Step)
+	}
+	return e, nil
+}
+
+// Get returns an experiment by ID.
+func (r *PromptExperimentRepo) Get(ctx domain.Context, id string) (domain.PromptExperiment, error) {
+	ctx, end := startPromptExperimentSpan(ctx, "Get", "SELECT", "prompt_experiments")
+	defer end()
+	var e domain.PromptExperiment
+	err := r.Pool.QueryRow(ctx, `SELECT `+promptExperimentColumns+` FROM prompt_experiments WHERE id=$1`, id).
+		Scan(&e.ID, &e.Name, &e.Step, &e.VersionA, &e.VersionB, &e.TrafficB, &e.CreatedAt, &e.EndedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.PromptExperiment{}, fmt.Errorf("op=prompt_experiment.get: %w", domain.ErrNotFound)
+		}
+		return domain.PromptExperiment{}, fmt.Errorf("op=prompt_experiment.get: %w", err)
+	}
+	return e, nil
+}
+
+// List returns all experiments, newest first.
+func (r *PromptExperimentRepo) List(ctx domain.Context) ([]domain.PromptExperiment, error) {
+	ctx, end := startPromptExperimentSpan(ctx, "List", "SELECT", "prompt_experiments")
+	defer end()
+	return r.query(ctx, "list", `SELECT `+promptExperimentColumns+` FROM prompt_experiments ORDER BY created_at DESC, id`)
+}
+
+// Active returns the experiments that are not ended.
+func (r *PromptExperimentRepo) Active(ctx domain.Context) ([]domain.PromptExperiment, error) {
+	ctx, end := startPromptExperimentSpan(ctx, "Active", "SELECT", "prompt_experiments")
+	defer end()
+	return r.query(ctx, "active", `SELECT `+promptExperimentColumns+` FROM prompt_experiments WHERE ended_at IS NULL ORDER BY step`)
+}
+
+func (r *PromptExperimentRepo) query(ctx domain.Context, op, q string) ([]domain.PromptExperiment, error) {
+	rows, err := r.Pool.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("op=prompt_experiment.%s: %w", op, err)
+	}
+	defer rows.Close()
+	var out []domain.PromptExperiment
+	for rows.Next() {
+		var e domain.PromptExperiment
+		if err := rows.Scan(&e.ID, &e.Name, &e.Step, &e.VersionA, &e.VersionB, &e.TrafficB, &e.CreatedAt, &e.EndedAt); err != nil {
+			return nil, fmt.Errorf("op=prompt_experiment.%s_scan: %w", op, err)
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=prompt_experiment.%s_rows: %w", op, err)
+	}
+	return out, nil
+}
+
+// End stops an active experiment at the given time.
+func (r *PromptExperimentRepo) End(ctx domain.Context, id string, at time.Time) error {
+	ctx, end := startPromptExperimentSpan(ctx, "End", "UPDATE", "prompt_experiments")
+	defer end()
+	tag, err := r.Pool.Exec(ctx, `UPDATE prompt_experiments SET ended_at=$2 WHERE id=$1 AND ended_at IS NULL`, id, at)
+	if err != nil {
+		return fmt.Errorf("op=prompt_experiment.end: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("op=prompt_experiment.end: %w", domain.ErrNotFound)
+	}
+	return nil
+}
+
+// Assign records the variant a job uses per experiment ID. Existing
+// assignments are kept, so a redelivered job reports under its first variant.
+func (r *PromptExperimentRepo) Assign(ctx domain.Context, jobID string, variants map[string]string) error {
+	ctx, end := startPromptExperimentSpan(ctx, "Assign", "INSERT", "job_prompt_variants")
+	defer end()
+	if len(variants) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(variants))
+	names := make([]string, 0, len(variants))
+	for id, v := range variants {
+		ids = append(ids, id)
+		names = append(names, v)
+	}
+	q := `INSERT INTO job_prompt_variants (job_id, experiment_id, variant)
+		SELECT $1, e, v FROM unnest($2::text[], $3::text[]) AS t(e, v)
+		ON CONFLICT (job_id, experiment_id) DO NOTHING`
+	if _, err := r.Pool.Exec(ctx, q, jobID, ids, names); err != nil {
+		return fmt.Errorf("op=prompt_experiment.assign: %w", err)
+	}
+	return nil
+}
+
+// Finish records the parse failures and latency of a job's evaluation.
+func (r *PromptExperimentRepo) Finish(ctx domain.Context, jobID string, parseFailures int, latency time.Duration) error {
+	ctx, end := startPromptExperimentSpan(ctx, "Finish", "UPDATE", "job_prompt_variants")
+	defer end()
+	q := `UPDATE job_prompt_variants SET parse_failures=$2, latency_ms=$3 WHERE job_id=$1`
+	if _, err := r.Pool.Exec(ctx, q, jobID, parseFailures, latency.Milliseconds()); err != nil {
+		return fmt.Errorf("op=prompt_experiment.finish: %w", err)
+	}
+	return nil
+}
+
+// Stats aggregates the jobs of each variant of an experiment that has any.
+// Versions are not filled in.
+func (r *PromptExperimentRepo) Stats(ctx domain.Context, id string) ([]domain.PromptVariantStats, error) {
+	ctx, end := startPromptExperimentSpan(ctx, "Stats", "SELECT", "job_prompt_variants")
+	defer end()
+	q := `SELECT v.variant,
+			count(*),
+			count(*) FILTER (WHERE j.status = 'completed'),
+			count(*) FILTER (WHERE j.status = 'failed'),
+			count(v.parse_failures),
+			count(*) FILTER (WHERE v.parse_failures > 0),
+			COALESCE(sum(v.parse_failures), 0),
+			avg(r.cv_match_rate)::float8, stddev_samp(r.cv_match_rate)::float8,
+			avg(r.project_score)::float8, stddev_samp(r.project_score)::float8,
+			avg(v.latency_ms)::float8,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY v.latency_ms),
+			percentile_cont(0.95) WITHIN GROUP (ORDER BY v.latency_ms)
+		FROM job_prompt_variants v
+		LEFT JOIN jobs j ON j.id = v.job_id
+		LEFT JOIN results r ON r.job_id = v.job_id
+		WHERE v.experiment_id = $1
+		GROUP BY v.variant
+		ORDER BY v.variant`
+	rows, err := r.Pool.Query(ctx, q, id)
+	if err != nil {
+		return nil, fmt.Errorf("op=prompt_experiment.stats: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.PromptVariantStats
+	for rows.Next() {
+		var (
+			s                      domain.PromptVariantStats
+			finished, withFailures int
+		)
+		if err := rows.Scan(&s.Variant, &s.Jobs, &s.Completed, &s.Failed, &finished, &withFailures, &s.ParseFailures,
+			&s.AvgCVMatchRate, &s.StdCVMatchRate, &s.AvgProjectScore, &s.StdProjectScore,
+			&s.AvgLatencyMS, &s.P50LatencyMS, &s.P95LatencyMS); err != nil {
+			return nil, fmt.Errorf("op=prompt_experiment.stats_scan: %w", err)
+		}
+		if finished > 0 {
+			s.ParseFailureRate = float64(withFailures) / float64(finished)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=prompt_experiment.stats_rows: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,135 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestPromptExperimentRepo_CreateAndEnd(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewPromptExperimentRepo(pool)
+	at := time.Date(2025, 12, 25, 9, 0, 0, 0, time.UTC)
+	in := domain.PromptExperiment{Name: "evidence", Step: "cv_match", VersionA: "1", VersionB: "2", TrafficB: 20, CreatedAt: at}
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		require.Len(t, args, 7)
+		assert.Equal(t, []any{"evidence", "cv_match", "1", "2", 20, at}, args[1:])
+	}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	e, err := repo.Create(context.Background(), in)
+	require.NoError(t, err)
+	assert.NotEmpty(t, e.ID)
+
+	// An active experiment on the step leaves nothing inserted.
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 0"), nil).Once()
+	_, err = repo.Create(context.Background(), in)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{e.ID, at}).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	require.NoError(t, repo.End(context.Background(), e.ID, at))
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{e.ID, at}).Return(pgconn.NewCommandTag("UPDATE 0"), nil).Once()
+	assert.ErrorIs(t, repo.End(context.Background(), e.ID, at), domain.ErrNotFound)
+}
+
+func TestPromptExperimentRepo_GetAndActive(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewPromptExperimentRepo(pool)
+	at := time.Date(2025, 12, 25, 9, 0, 0, 0, time.UTC)
+	fill := func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "e1"
+		*(dest[1].(*string)) = "evidence"
+		*(dest[2].(*string)) = "cv_match"
+		*(dest[3].(*string)) = "1"
+		*(dest[4].(*string)) = "2"
+		*(dest[5].(*int)) = 20
+		*(dest[6].(*time.Time)) = at
+	}
+	want := domain.PromptExperiment{ID: "e1", Name: "evidence", Step: "cv_match", VersionA: "1", VersionB: "2", TrafficB: 20, CreatedAt: at}
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(fill).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"e1"}).Return(row).Once()
+	got, err := repo.Get(context.Background(), "e1")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	missing := mocks.NewMockRow(t)
+	missing.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"e2"}).Return(missing).Once()
+	_, err = repo.Get(context.Background(), "e2")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	rows := mocks.NewMockRows(t)
+	calls := 0
+	rows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	rows.On("Scan", mock.Anything).Run(fill).Return(nil).Once()
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything).Return(rows, nil).Once()
+	active, err := repo.Active(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PromptExperiment{want}, active)
+}
+
+func TestPromptExperimentRepo_AssignAndFinish(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewPromptExperimentRepo(pool)
+
+	require.NoError(t, repo.Assign(context.Background(), "job-1", nil))
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"job-1", []string{"e1"}, []string{"b"}}).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Assign(context.Background(), "job-1", map[string]string{"e1": "b"}))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"job-1", 1, int64(1500)}).
+		Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	require.NoError(t, repo.Finish(context.Background(), "job-1", 1, 1500*time.Millisecond))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.Finish(context.Background(), "job-1", 0, time.Second), "op=prompt_experiment.finish")
+}
+
+func TestPromptExperimentRepo_Stats(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewPromptExperimentRepo(pool)
+	avg := 0.75
+
+	rows := mocks.NewMockRows(t)
+	calls := 0
+	rows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	rows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "b"
+		*(dest[1].(*int)) = 5
+		*(dest[2].(*int)) = 4
+		*(dest[3].(*int)) = 1
+		*(dest[4].(*int)) = 4
+		*(dest[5].(*int)) = 1
+		*(dest[6].(*int)) = 2
+		*(dest[7].(**float64)) = &avg
+	}).Return(nil).Once()
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{"e1"}).Return(rows, nil).Once()
+
+	stats, err := repo.Stats(context.Background(), "e1")
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, domain.PromptVariantStats{Variant: "b", Jobs: 5, Completed: 4, Failed: 1, ParseFailures: 2, ParseFailureRate: 0.25, AvgCVMatchRate: &avg}, stats[0])
+}
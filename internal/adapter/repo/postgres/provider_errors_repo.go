@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ProviderErrorRepo persists fingerprinted failed AI provider calls in
+// provider_errors.
+type ProviderErrorRepo struct{ Pool PgxPool }
+
+// NewProviderErrorRepo constructs a ProviderErrorRepo with the given pool.
+func NewProviderErrorRepo(p PgxPool) *ProviderErrorRepo { return &ProviderErrorRepo{Pool: p} }
+
+func startProviderErrorSpan(ctx domain.Context, name, op string) (domain.Context, func()) {
+	tracer := otel.Tracer("repo.provider_errors")
+	ctx, span := tracer.Start(ctx, "provider_errors."+name)
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", op),
+		attribute.String("db.sql.table", "provider_errors"),
+	)
+	return ctx, func() { span.End() }
+}
+
+// Record stores a failed call.
+func (r *ProviderErrorRepo) Record(ctx domain.Context, e domain.ProviderError) error {
+	ctx, end := startProviderErrorSpan(ctx, "Record", "INSERT")
+	defer end()
+	q := `INSERT INTO provider_errors (id, provider, op, model, status, fingerprint, message, job_id, step, at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`
+	_, err := r.Pool.Exec(ctx, q, uuid.New().String(), e.Provider, e.Op, e.Model, e.Status, string(e.Fingerprint),
+		e.Message, e.JobID, e.Step, e.At)
+	if err != nil {
+		return fmt.Errorf("op=provider_errors.record: %w", err)
+	}
+	return nil
+}
+
+// Top returns the limit fingerprint and provider pairs that occurred most
+// often since since, most frequent first, with the model and message of
+// their latest occurrence.
+func (r *ProviderErrorRepo) Top(ctx domain.Context, since time.Time, limit int) ([]domain.ProviderErrorCount, error) {
+	ctx, end := startProviderErrorSpan(ctx, "Top", "SELECT")
+	defer end()
+	q := `SELECT fingerprint, provider, count(*), min(at), max(at),
+			(array_agg(model ORDER BY at DESC))[1], (array_agg(message ORDER BY at DESC))[1]
+		FROM provider_errors WHERE at >= $1
+		GROUP BY fingerprint, provider
+		ORDER BY count(*) DESC, max(at) DESC
+		LIMIT $2`
+	rows, err := r.Pool.Query(ctx, q, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("op=provider_errors.top: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.ProviderErrorCount
+	for rows.Next() {
+		var c domain.ProviderErrorCount
+		var fp string
+		if err := rows.Scan(&fp, &c.Provider, &c.Count, &c.FirstSeen, &c.LastSeen, &c.Model, &c.Message); err != nil {
+			return nil, fmt.Errorf("op=provider_errors.top_scan: %w", err)
+		}
+		c.Fingerprint = domain.ProviderErrorFingerprint(fp)
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=provider_errors.top_rows: %w", err)
+	}
+	return out, nil
+}
+
+// Buckets returns the occurrences since since counted in buckets of length
+// bucket aligned to the Unix epoch, oldest first.
+func (r *ProviderErrorRepo) Buckets(ctx domain.Context, since time.Time, bucket time.Duration) ([]domain.ProviderErrorBucket, error) {
+	ctx, end := startProviderErrorSpan(ctx, "Buckets", "SELECT")
+	defer end()
+	q := `SELECT date_bin(make_interval(secs => $2), at, TIMESTAMPTZ 'epoch') AS bucket, fingerprint, provider, count(*)
+		FROM provider_errors WHERE at >= $1
+		GROUP BY bucket, fingerprint, provider
+		ORDER BY bucket, fingerprint, provider`
+	rows, err := r.Pool.Query(ctx, q, since, bucket.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("op=provider_errors.buckets: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.ProviderErrorBucket
+	for rows.Next() {
+		var b domain.ProviderErrorBucket
+		var fp string
+		if err := rows.Scan(&b.Start, &fp, &b.Provider, &b.Count); err != nil {
+			return nil, fmt.Errorf("op=provider_errors.buckets_scan: %w", err)
+		}
+		b.Fingerprint = domain.ProviderErrorFingerprint(fp)
+		out = append(out, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=provider_errors.buckets_rows: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,74 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestProviderErrorRepo_Record(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewProviderErrorRepo(pool)
+	at := time.Date(2026, 1, 4, 9, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.HasPrefix(q, "INSERT INTO provider_errors") }), mock.Anything).
+		Run(func(_ context.Context, _ string, args ...any) {
+			assert.NotEmpty(t, args[0])
+			assert.Equal(t, []any{"groq", "chat", "llama-3.1-8b-instant", 404, "model_not_found", "model not found", "job-1", "cv_match", at}, args[1:])
+		}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Record(context.Background(), domain.ProviderError{
+		Provider: "groq", Op: "chat", Model: "llama-3.1-8b-instant", Status: 404,
+		Fingerprint: domain.ProviderErrorModelNotFound, Message: "model not found",
+		JobID: "job-1", Step: "cv_match", At: at,
+	}))
+}
+
+func TestProviderErrorRepo_TopAndBuckets(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewProviderErrorRepo(pool)
+	ctx := context.Background()
+	since := time.Date(2026, 1, 3, 9, 0, 0, 0, time.UTC)
+
+	rows := mocks.NewMockRows(t)
+	calls := 0
+	rows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	rows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "quota_exceeded"
+		*(dest[1].(*string)) = "openrouter"
+		*(dest[2].(*int64)) = 42
+	}).Return(nil).Once()
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(q string) bool {
+		return strings.Contains(q, "GROUP BY fingerprint, provider") && strings.Contains(q, "LIMIT $2")
+	}), []any{since, 10}).Return(rows, nil).Once()
+	top, err := repo.Top(ctx, since, 10)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	assert.Equal(t, domain.ProviderErrorQuota, top[0].Fingerprint)
+	assert.Equal(t, int64(42), top[0].Count)
+
+	empty := mocks.NewMockRows(t)
+	empty.On("Next").Return(false).Once()
+	empty.On("Close").Return().Once()
+	empty.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.Contains(q, "date_bin(make_interval(secs => $2)") }),
+		[]any{since, 3600.0}).Return(empty, nil).Once()
+	buckets, err := repo.Buckets(ctx, since, time.Hour)
+	require.NoError(t, err)
+	assert.Empty(t, buckets)
+}
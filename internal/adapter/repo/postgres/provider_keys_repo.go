@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/secretbox"
+)
+
+// ProviderKeyRepo persists runtime-managed AI provider keys. Secrets are
+// stored sealed by Secrets, bound to the key ID, and never in plaintext.
+type ProviderKeyRepo struct {
+	Pool    PgxPool
+	Secrets *secretbox.Box
+}
+
+// NewProviderKeyRepo constructs a ProviderKeyRepo with the given pool and
+// secret box. Without a box only overrides of configured keys, which carry
+// no secret, can be stored.
+func NewProviderKeyRepo(p PgxPool, secrets *secretbox.Box) *ProviderKeyRepo {
+	return &ProviderKeyRepo{Pool: p, Secrets: secrets}
+}
+
+// List returns all stored provider keys ordered by provider and creation time.
+func (r *ProviderKeyRepo) List(ctx domain.Context) ([]domain.ProviderKey, error) {
+	tracer := otel.Tracer("repo.provider_keys")
+	ctx, span := tracer.Start(ctx, "provider_keys.List")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "ai_provider_keys"),
+	)
+	q := `SELECT id, provider, secret, weight, state, created_at, updated_at FROM ai_provider_keys ORDER BY provider, created_at, id`
+	rows, err := r.Pool.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("op=provider_key.list: %w", err)
+	}
+	defer rows.Close()
+	var keys []domain.ProviderKey
+	for rows.Next() {
+		var k domain.ProviderKey
+		var state string
+		if err := rows.Scan(&k.ID, &k.Provider, &k.Secret, &k.Weight, &state, &k.CreatedAt, &k.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("op=provider_key.list_scan: %w", err)
+		}
+		k.State = domain.ProviderKeyState(state)
+		if secretbox.Sealed(k.Secret) {
+			secret, err := r.Secrets.Open(k.Secret, k.ID)
+			if err != nil {
+				return nil, fmt.Errorf("op=provider_key.list_open %s: %w", k.ID, err)
+			}
+			k.Secret = secret
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=provider_key.list_rows: %w", err)
+	}
+	return keys, nil
+}
+
+// Upsert inserts or updates a key by ID. An empty Secret keeps the stored
+// secret, so state/weight overrides never need the secret itself. A secret
+// is sealed before it is written; without a secret box it is rejected.
+func (r *ProviderKeyRepo) Upsert(ctx domain.Context, k domain.ProviderKey) error {
+	tracer := otel.Tracer("repo.provider_keys")
+	ctx, span := tracer.Start(ctx, "provider_keys.Upsert")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "ai_provider_keys"),
+	)
+	secret := k.Secret
+	if secret != "" {
+		if r.Secrets == nil {
+			return fmt.Errorf("%w: provider key encryption is not configured (AI_PROVIDER_KEY_ENCRYPTION_KEYS)", domain.ErrInvalidArgument)
+		}
+		sealed, err := r.Secrets.Seal(secret, k.ID)
+		if err != nil {
+			return fmt.Errorf("op=provider_key.upsert_seal: %w", err)
+		}
+		secret = sealed
+	}
+	q := `INSERT INTO ai_provider_keys (id, provider, secret, weight, state, created_at, updated_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$6)
+		ON CONFLICT (id) DO UPDATE SET
+			weight = EXCLUDED.weight,
+			state = EXCLUDED.state,
+			secret = CASE WHEN EXCLUDED.secret <> '' THEN EXCLUDED.secret ELSE ai_provider_keys.secret END,
+			updated_at = EXCLUDED.updated_at`
+	if _, err := r.Pool.Exec(ctx, q, k.ID, k.Provider, secret, k.Weight, string(k.State), time.Now().UTC()); err != nil {
+		return fmt.Errorf("op=provider_key.upsert: %w", err)
+	}
+	return nil
+}
+
+// Reseal seals the stored secrets that are in plaintext, or sealed with a key
+// other than the active one, with the active key. It returns the number of
+// rows rewritten. Secrets sealed with a key the box does not have fail the
+// run.
+func (r *ProviderKeyRepo) Reseal(ctx domain.Context) (int, error) {
+	tracer := otel.Tracer("repo.provider_keys")
+	ctx, span := tracer.Start(ctx, "provider_keys.Reseal")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPDATE"),
+		attribute.String("db.sql.table", "ai_provider_keys"),
+	)
+	if r.Secrets == nil {
+		return 0, nil
+	}
+	rows, err := r.Pool.Query(ctx, `SELECT id, secret FROM ai_provider_keys WHERE secret <> ''`)
+	if err != nil {
+		return 0, fmt.Errorf("op=provider_key.reseal: %w", err)
+	}
+	type stored struct{ id, secret string }
+	var stale []stored
+	for rows.Next() {
+		var s stored
+		if err := rows.Scan(&s.id, &s.secret); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("op=provider_key.reseal_scan: %w", err)
+		}
+		if !r.Secrets.SealedWithActive(s.secret) {
+			stale = append(stale, s)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("op=provider_key.reseal_rows: %w", err)
+	}
+	n := 0
+	for _, s := range stale {
+		secret := s.secret
+		if secretbox.Sealed(secret) {
+			if secret, err = r.Secrets.Open(secret, s.id); err != nil {
+				return n, fmt.Errorf("op=provider_key.reseal_open %s: %w", s.id, err)
+			}
+		}
+		sealed, err := r.Secrets.Seal(secret, s.id)
+		if err != nil {
+			return n, fmt.Errorf("op=provider_key.reseal_seal %s: %w", s.id, err)
+		}
+		// Only rewrite the value read, so a concurrent change wins.
+		if _, err := r.Pool.Exec(ctx, `UPDATE ai_provider_keys SET secret = $2 WHERE id = $1 AND secret = $3`, s.id, sealed, s.secret); err != nil {
+			return n, fmt.Errorf("op=provider_key.reseal_update %s: %w", s.id, err)
+		}
+		n++
+	}
+	return n, nil
+}
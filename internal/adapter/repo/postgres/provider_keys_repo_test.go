@@ -0,0 +1,173 @@
+package postgres_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/secretbox"
+)
+
+func testSecretBox(t *testing.T, active string) *secretbox.Box {
+	t.Helper()
+	box, err := secretbox.New(active, map[string][]byte{"pk1": bytes.Repeat([]byte{1}, 32), "pk2": bytes.Repeat([]byte{2}, 32)})
+	require.NoError(t, err)
+	return box
+}
+
+func TestProviderKeyRepo_List_Success(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewProviderKeyRepo(pool, nil)
+
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "k_abc"
+		*(dest[1].(*string)) = "groq"
+		*(dest[2].(*string)) = "gsk_1"
+		*(dest[3].(*int)) = 2
+		*(dest[4].(*string)) = "draining"
+		*(dest[5].(*time.Time)) = time.Now().UTC()
+		*(dest[6].(*time.Time)) = time.Now().UTC()
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything).Return(mockRows, nil).Once()
+
+	keys, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "k_abc", keys[0].ID)
+	assert.Equal(t, domain.ProviderKeyDraining, keys[0].State)
+	assert.Equal(t, 2, keys[0].Weight)
+}
+
+func TestProviderKeyRepo_List_QueryError(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewProviderKeyRepo(pool, nil)
+	pool.EXPECT().Query(mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+
+	keys, err := repo.List(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=provider_key.list")
+	assert.Nil(t, keys)
+}
+
+func TestProviderKeyRepo_Upsert(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewProviderKeyRepo(pool, nil)
+	k := domain.ProviderKey{ID: "k_abc", Provider: "openrouter", Weight: 3, State: domain.ProviderKeyDisabled}
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.MatchedBy(func(args []any) bool {
+		return len(args) == 6 && args[0] == "k_abc" && args[2] == "" && args[3] == 3 && args[4] == "disabled"
+	})).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), k))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).
+		Return(pgconn.CommandTag{}, assert.AnError).Once()
+	err := repo.Upsert(context.Background(), k)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=provider_key.upsert")
+}
+
+func TestProviderKeyRepo_SealsSecrets(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	box := testSecretBox(t, "pk1")
+	repo := postgres.NewProviderKeyRepo(pool, box)
+
+	var stored string
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		stored = args[2].(string)
+	}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), domain.ProviderKey{ID: "k_abc", Provider: "groq", Secret: "gsk_1", Weight: 1, State: domain.ProviderKeyActive}))
+	assert.True(t, strings.HasPrefix(stored, "enc:pk1:"))
+	assert.NotContains(t, stored, "gsk_1")
+
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "k_abc"
+		*(dest[1].(*string)) = "groq"
+		*(dest[2].(*string)) = stored
+		*(dest[3].(*int)) = 1
+		*(dest[4].(*string)) = "active"
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything).Return(mockRows, nil).Once()
+	keys, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "gsk_1", keys[0].Secret)
+
+	// Without encryption configured a secret is never written.
+	err = postgres.NewProviderKeyRepo(pool, nil).Upsert(context.Background(), domain.ProviderKey{ID: "k_abc", Provider: "groq", Secret: "gsk_1"})
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestProviderKeyRepo_Reseal(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	old, err := testSecretBox(t, "pk1").Seal("gsk_old", "k_old")
+	require.NoError(t, err)
+	box := testSecretBox(t, "pk2")
+	current, err := box.Seal("gsk_cur", "k_cur")
+	require.NoError(t, err)
+	repo := postgres.NewProviderKeyRepo(pool, box)
+
+	stored := [][2]string{{"k_plain", "gsk_plain"}, {"k_old", old}, {"k_cur", current}}
+	mockRows := mocks.NewMockRows(t)
+	i := 0
+	mockRows.On("Next").Return(func() bool {
+		i++
+		return i <= len(stored)
+	})
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = stored[i-1][0]
+		*(dest[1].(*string)) = stored[i-1][1]
+	}).Return(nil).Times(3)
+	mockRows.On("Close").Return()
+	mockRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything).Return(mockRows, nil).Once()
+	resealed := map[string]string{}
+	pool.EXPECT().Exec(mock.Anything, `UPDATE ai_provider_keys SET secret = $2 WHERE id = $1 AND secret = $3`, mock.Anything).
+		Run(func(_ context.Context, _ string, args ...any) {
+			resealed[args[0].(string)] = args[1].(string)
+		}).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Twice()
+
+	n, err := repo.Reseal(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	for id, want := range map[string]string{"k_plain": "gsk_plain", "k_old": "gsk_old"} {
+		require.True(t, box.SealedWithActive(resealed[id]), id)
+		got, err := box.Open(resealed[id], id)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+
+	// Nothing to do without a box.
+	n, err = postgres.NewProviderKeyRepo(pool, nil).Reseal(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
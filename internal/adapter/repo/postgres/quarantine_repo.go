@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// QuarantineRepo stores queue records that could not be decoded.
+type QuarantineRepo struct{ Pool PgxPool }
+
+// NewQuarantineRepo constructs a QuarantineRepo with the given pool.
+func NewQuarantineRepo(p PgxPool) *QuarantineRepo { return &QuarantineRepo{Pool: p} }
+
+// Record upserts m by topic, partition and offset and returns its attempts.
+func (r *QuarantineRepo) Record(ctx domain.Context, m domain.QuarantinedMessage) (int, error) {
+	tracer := otel.Tracer("repo.quarantine")
+	ctx, span := tracer.Start(ctx, "quarantine.Record")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "queue_quarantine"),
+	)
+	headers := m.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	hdr, err := json.Marshal(headers)
+	if err != nil {
+		return 0, fmt.Errorf("op=quarantine.record_marshal: %w", err)
+	}
+	now := time.Now().UTC()
+	q := `INSERT INTO queue_quarantine (topic, record_partition, record_offset, record_key, payload, headers, error, attempts, first_seen_at, last_seen_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,1,$8,$8)
+		ON CONFLICT (topic, record_partition, record_offset) DO UPDATE SET
+			error = EXCLUDED.error,
+			attempts = queue_quarantine.attempts + 1,
+			last_seen_at = EXCLUDED.last_seen_at
+		RETURNING attempts`
+	var attempts int
+	if err := r.Pool.QueryRow(ctx, q, m.Topic, m.Partition, m.Offset, m.Key, m.Value, hdr, m.Error, now).Scan(&attempts); err != nil {
+		return 0, fmt.Errorf("op=quarantine.record: %w", err)
+	}
+	return attempts, nil
+}
@@ -0,0 +1,37 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestQuarantineRepo_Record(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewQuarantineRepo(pool)
+	m := domain.QuarantinedMessage{Topic: "evaluate-jobs", Partition: 3, Offset: 42, Key: []byte("job-1"), Value: []byte("{not json"), Headers: map[string]string{"job_id": "job-1"}, Error: "invalid character"}
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		*(args[0].([]any)[0].(*int)) = 2
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.MatchedBy(func(args []any) bool {
+		return assert.ObjectsAreEqual([]any{"evaluate-jobs", int32(3), int64(42), []byte("job-1"), []byte("{not json"), []byte(`{"job_id":"job-1"}`), "invalid character"}, args[:7])
+	})).Return(row).Once()
+	attempts, err := repo.Record(context.Background(), m)
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	failing := mocks.NewMockRow(t)
+	failing.On("Scan", mock.Anything).Return(assert.AnError).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(failing).Once()
+	_, err = repo.Record(context.Background(), domain.QuarantinedMessage{Topic: "evaluate-jobs"})
+	assert.ErrorContains(t, err, "op=quarantine.record")
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ReportRepo aggregates activity for scheduled reports and records their deliveries.
+type ReportRepo struct{ Pool PgxPool }
+
+// NewReportRepo constructs a ReportRepo with the given pool.
+func NewReportRepo(p PgxPool) *ReportRepo { return &ReportRepo{Pool: p} }
+
+// Summary aggregates jobs updated and results stored in [from, to). Key usage
+// is tracked per UTC day, so it covers the whole days from the day of from up
+// to, but excluding, the day of to.
+func (r *ReportRepo) Summary(ctx domain.Context, from, to time.Time) (domain.ActivitySummary, error) {
+	tracer := otel.Tracer("repo.report")
+	ctx, span := tracer.Start(ctx, "report.Summary")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "jobs"),
+	)
+	s := domain.ActivitySummary{WindowStart: from, WindowEnd: to, JobsByStatus: map[domain.JobStatus]int64{}}
+
+	rows, err := r.Pool.Query(ctx, `SELECT status, count(*) FROM jobs WHERE updated_at >= $1 AND updated_at < $2 GROUP BY status`, from, to)
+	if err != nil {
+		return domain.ActivitySummary{}, fmt.Errorf("op=report.summary_jobs: %w", err)
+	}
+	for rows.Next() {
+		var status string
+		var n int64
+		if err := rows.Scan(&status, &n); err != nil {
+			rows.Close()
+			return domain.ActivitySummary{}, fmt.Errorf("op=report.summary_jobs_scan: %w", err)
+		}
+		s.JobsByStatus[domain.JobStatus(status)] = n
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return domain.ActivitySummary{}, fmt.Errorf("op=report.summary_jobs_rows: %w", err)
+	}
+
+	row := r.Pool.QueryRow(ctx, `SELECT count(*), COALESCE(avg(cv_match_rate), 0), COALESCE(avg(project_score), 0)
+		FROM results WHERE created_at >= $1 AND created_at < $2`, from, to)
+	if err := row.Scan(&s.ResultCount, &s.AvgCVMatchRate, &s.AvgProjectScore); err != nil {
+		return domain.ActivitySummary{}, fmt.Errorf("op=report.summary_scores: %w", err)
+	}
+
+	dayFrom := from.UTC().Truncate(24 * time.Hour)
+	dayTo := to.UTC().Truncate(24 * time.Hour)
+	rows, err = r.Pool.Query(ctx, `SELECT key_id, sum(requests), sum(tokens) FROM ai_key_usage
+		WHERE day >= $1 AND day < $2 GROUP BY key_id ORDER BY key_id`, dayFrom, dayTo)
+	if err != nil {
+		return domain.ActivitySummary{}, fmt.Errorf("op=report.summary_usage: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var u domain.KeyUsage
+		if err := rows.Scan(&u.KeyID, &u.Requests, &u.Tokens); err != nil {
+			return domain.ActivitySummary{}, fmt.Errorf("op=report.summary_usage_scan: %w", err)
+		}
+		s.KeyUsage = append(s.KeyUsage, u)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.ActivitySummary{}, fmt.Errorf("op=report.summary_usage_rows: %w", err)
+	}
+	return s, nil
+}
+
+// ClaimDelivery inserts the delivery of recipient at scheduledFor and reports
+// whether this call created it.
+func (r *ReportRepo) ClaimDelivery(ctx domain.Context, recipient string, scheduledFor time.Time) (bool, error) {
+	tracer := otel.Tracer("repo.report")
+	ctx, span := tracer.Start(ctx, "report.ClaimDelivery")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "INSERT"),
+		attribute.String("db.sql.table", "report_deliveries"),
+	)
+	tag, err := r.Pool.Exec(ctx, `INSERT INTO report_deliveries (recipient, scheduled_for) VALUES ($1,$2)
+		ON CONFLICT (recipient, scheduled_for) DO NOTHING`, recipient, scheduledFor)
+	if err != nil {
+		return false, fmt.Errorf("op=report.claim_delivery: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// ReleaseDelivery deletes the claim of recipient's report scheduled at
+// scheduledFor.
+func (r *ReportRepo) ReleaseDelivery(ctx domain.Context, recipient string, scheduledFor time.Time) error {
+	tracer := otel.Tracer("repo.report")
+	ctx, span := tracer.Start(ctx, "report.ReleaseDelivery")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "DELETE"),
+		attribute.String("db.sql.table", "report_deliveries"),
+	)
+	if _, err := r.Pool.Exec(ctx, `DELETE FROM report_deliveries WHERE recipient=$1 AND scheduled_for=$2`, recipient, scheduledFor); err != nil {
+		return fmt.Errorf("op=report.release_delivery: %w", err)
+	}
+	return nil
+}
+
+// UsageStats aggregates the jobs created and results stored in [from, to).
+// Derived fields such as JobsPerDay are left to the caller.
+func (r *ReportRepo) UsageStats(ctx domain.Context, from, to time.Time) (domain.UsageStats, error) {
+	tracer := otel.Tracer("repo.report")
+	ctx, span := tracer.Start(ctx, "report.UsageStats")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "jobs"),
+	)
+	s := domain.UsageStats{WindowStart: from, WindowEnd: to, ModelMix: map[string]int64{}}
+	row := r.Pool.QueryRow(ctx, `SELECT count(*),
+		count(*) FILTER (WHERE status = 'completed'),
+		count(*) FILTER (WHERE status = 'failed'),
+		COALESCE(avg(EXTRACT(EPOCH FROM updated_at - created_at)) FILTER (WHERE status = 'completed'), 0)
+		FROM jobs WHERE created_at >= $1 AND created_at < $2`, from, to)
+	if err := row.Scan(&s.Jobs, &s.Completed, &s.Failed, &s.MeanLatencySeconds); err != nil {
+		return domain.UsageStats{}, fmt.Errorf("op=report.usage_jobs: %w", err)
+	}
+
+	rows, err := r.Pool.Query(ctx, `SELECT p->>'model', count(*) FROM results r, jsonb_array_elements(r.provenance) p
+		WHERE r.created_at >= $1 AND r.created_at < $2 GROUP BY 1`, from, to)
+	if err != nil {
+		return domain.UsageStats{}, fmt.Errorf("op=report.usage_models: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var model string
+		var n int64
+		if err := rows.Scan(&model, &n); err != nil {
+			return domain.UsageStats{}, fmt.Errorf("op=report.usage_models_scan: %w", err)
+		}
+		s.ModelMix[model] = n
+	}
+	if err := rows.Err(); err != nil {
+		return domain.UsageStats{}, fmt.Errorf("op=report.usage_models_rows: %w", err)
+	}
+	return s, nil
+}
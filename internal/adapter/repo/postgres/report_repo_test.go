@@ -0,0 +1,145 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestReportRepo_Summary(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewReportRepo(pool)
+	from := time.Date(2025, 12, 6, 8, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	jobRows := mocks.NewMockRows(t)
+	statuses := []string{"completed", "failed"}
+	i := 0
+	jobRows.On("Next").Return(func() bool { i++; return i <= len(statuses) }).Times(3)
+	jobRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = statuses[i-1]
+		*(dest[1].(*int64)) = int64(10 * i)
+	}).Return(nil).Twice()
+	jobRows.On("Close").Return().Once()
+	jobRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{from, to}).Return(jobRows, nil).Once()
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*int64)) = 10
+		*(dest[1].(*float64)) = 0.75
+		*(dest[2].(*float64)) = 8.5
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{from, to}).Return(row).Once()
+
+	usageRows := mocks.NewMockRows(t)
+	n := 0
+	usageRows.On("Next").Return(func() bool { n++; return n <= 1 }).Times(2)
+	usageRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "k_1"
+		*(dest[1].(*int64)) = 40
+		*(dest[2].(*int64)) = 12000
+	}).Return(nil).Once()
+	usageRows.On("Close").Return().Once()
+	usageRows.On("Err").Return(nil).Once()
+	dayFrom := time.Date(2025, 12, 6, 0, 0, 0, 0, time.UTC)
+	dayTo := time.Date(2025, 12, 7, 0, 0, 0, 0, time.UTC)
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{dayFrom, dayTo}).Return(usageRows, nil).Once()
+
+	s, err := repo.Summary(context.Background(), from, to)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ActivitySummary{
+		WindowStart:     from,
+		WindowEnd:       to,
+		JobsByStatus:    map[domain.JobStatus]int64{domain.JobCompleted: 10, domain.JobFailed: 20},
+		ResultCount:     10,
+		AvgCVMatchRate:  0.75,
+		AvgProjectScore: 8.5,
+		KeyUsage:        []domain.KeyUsage{{KeyID: "k_1", Requests: 40, Tokens: 12000}},
+	}, s)
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+	_, err = repo.Summary(context.Background(), from, to)
+	assert.ErrorContains(t, err, "op=report.summary_jobs")
+}
+
+func TestReportRepo_ClaimDelivery(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewReportRepo(pool)
+	at := time.Date(2025, 12, 7, 8, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"ops@example.com", at}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	ok, err := repo.ClaimDelivery(context.Background(), "ops@example.com", at)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"ops@example.com", at}).Return(pgconn.NewCommandTag("INSERT 0 0"), nil).Once()
+	ok, err = repo.ClaimDelivery(context.Background(), "ops@example.com", at)
+	require.NoError(t, err)
+	assert.False(t, ok, "already claimed by another worker")
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	_, err = repo.ClaimDelivery(context.Background(), "ops@example.com", at)
+	assert.ErrorContains(t, err, "op=report.claim_delivery")
+}
+
+func TestReportRepo_ReleaseDelivery(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewReportRepo(pool)
+	at := time.Date(2025, 12, 7, 8, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, `DELETE FROM report_deliveries WHERE recipient=$1 AND scheduled_for=$2`, []any{"ops@example.com", at}).
+		Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
+	require.NoError(t, repo.ReleaseDelivery(context.Background(), "ops@example.com", at))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.ReleaseDelivery(context.Background(), "ops@example.com", at), "op=report.release_delivery")
+}
+
+func TestReportRepo_UsageStats(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewReportRepo(pool)
+	from := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*int64)) = 30
+		*(dest[1].(*int64)) = 25
+		*(dest[2].(*int64)) = 5
+		*(dest[3].(*float64)) = 42.5
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{from, to}).Return(row).Once()
+
+	modelRows := mocks.NewMockRows(t)
+	i := 0
+	modelRows.On("Next").Return(func() bool { i++; return i <= 1 }).Times(2)
+	modelRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "groq/llama-3.1-8b-instant"
+		*(dest[1].(*int64)) = 50
+	}).Return(nil).Once()
+	modelRows.On("Close").Return().Once()
+	modelRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{from, to}).Return(modelRows, nil).Once()
+
+	st, err := repo.UsageStats(context.Background(), from, to)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UsageStats{
+		WindowStart: from, WindowEnd: to, Jobs: 30, Completed: 25, Failed: 5, MeanLatencySeconds: 42.5,
+		ModelMix: map[string]int64{"groq/llama-3.1-8b-instant": 50},
+	}, st)
+}
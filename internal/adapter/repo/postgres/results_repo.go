@@ -6,14 +6,50 @@
 package postgres
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 )
 
+// Hot result queries, prepared eagerly by the pool (see hotStatements in conn.go).
+const (
+	// results is partitioned by created_at, which rules out a unique index on
+	// job_id alone. result_job_keys pins the created_at of a job's result row
+	// (locking it against concurrent upserts of the same job) so the insert
+	// can conflict on (job_id, created_at). Every write is also kept as the
+	// job's next result version; a concurrent upsert of the same job reads
+	// the same next version and fails on the result_versions primary key,
+	// so Upsert retries it.
+	// A non-NULL $15 is a lease fencing token: the key is only written, and
+	// so the result only stored, while it is the job's token in job_locks.
+	// FOR SHARE holds off a takeover until the write commits.
+	upsertResultSQL = `WITH key AS (
+		INSERT INTO result_job_keys (job_id, created_at)
+		SELECT $1, $7::timestamptz
+		WHERE $15::bigint IS NULL OR EXISTS (SELECT 1 FROM job_locks WHERE job_id=$1 AND token=$15 FOR SHARE)
+		ON CONFLICT (job_id) DO UPDATE SET job_id=EXCLUDED.job_id
+		RETURNING created_at
+	), ver AS (
+		INSERT INTO result_versions (job_id, version, result, created_at)
+		SELECT $1, COALESCE((SELECT MAX(version) FROM result_versions WHERE job_id=$1), 0) + 1, $12, $7::timestamptz FROM key
+	)
+	INSERT INTO results (job_id, cv_match_rate, cv_feedback, project_score, project_feedback, overall_summary, created_at, scoring_weights, score_normalization, provenance, similarity, encryption, language)
+	SELECT $1,$2,$3,$4,$5,$6,key.created_at,$8,$9,$10,$11,$13,$14 FROM key
+	ON CONFLICT (job_id, created_at) DO UPDATE SET cv_match_rate=EXCLUDED.cv_match_rate, cv_feedback=EXCLUDED.cv_feedback, project_score=EXCLUDED.project_score, project_feedback=EXCLUDED.project_feedback, overall_summary=EXCLUDED.overall_summary, scoring_weights=EXCLUDED.scoring_weights, score_normalization=EXCLUDED.score_normalization, provenance=EXCLUDED.provenance, similarity=EXCLUDED.similarity, encryption=EXCLUDED.encryption, language=EXCLUDED.language`
+	getResultByJobIDSQL = `SELECT job_id, COALESCE(cv_match_rate, 0), cv_feedback, COALESCE(project_score, 0), project_feedback, overall_summary, created_at, project_score IS NULL, cv_match_rate IS NULL, scoring_weights, score_normalization, provenance, similarity, encryption, language FROM results WHERE job_id=$1`
+)
+
+// upsertResultAttempts bounds how often Upsert retries a result version
+// taken by a concurrent upsert of the same job.
+const upsertResultAttempts = 3
+
 // ResultRepo persists and loads evaluation results from PostgreSQL.
 type ResultRepo struct{ Pool PgxPool }
 
@@ -30,15 +66,110 @@ func (r *ResultRepo) Upsert(ctx domain.Context, res domain.Result) error {
 		attribute.String("db.operation", "UPSERT"),
 		attribute.String("db.sql.table", "results"),
 	)
-	q := `INSERT INTO results (job_id, cv_match_rate, cv_feedback, project_score, project_feedback, overall_summary, created_at)
-	VALUES ($1,$2,$3,$4,$5,$6,$7)
-	ON CONFLICT (job_id)
-	DO UPDATE SET cv_match_rate=EXCLUDED.cv_match_rate, cv_feedback=EXCLUDED.cv_feedback, project_score=EXCLUDED.project_score, project_feedback=EXCLUDED.project_feedback, overall_summary=EXCLUDED.overall_summary`
-	_, err := r.Pool.Exec(ctx, q, res.JobID, res.CVMatchRate, res.CVFeedback, res.ProjectScore, res.ProjectFeedback, res.OverallSummary, time.Now().UTC())
+	return r.upsert(ctx, res, nil)
+}
+
+// UpsertFenced inserts or updates a result by job_id while token is the
+// fencing token of the job's lease.
+func (r *ResultRepo) UpsertFenced(ctx domain.Context, res domain.Result, token int64) error {
+	tracer := otel.Tracer("repo.results")
+	ctx, span := tracer.Start(ctx, "results.UpsertFenced")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "results"),
+	)
+	return r.upsert(ctx, res, &token)
+}
+
+// upsert stores res, fenced by token when it is not nil.
+func (r *ResultRepo) upsert(ctx domain.Context, res domain.Result, token *int64) error {
+	// CV-only results store no project score, project-only results no CV
+	// match rate.
+	cvMatchRate, projectScore := &res.CVMatchRate, &res.ProjectScore
+	if res.CVOnly {
+		projectScore = nil
+	}
+	if res.ProjectOnly {
+		cvMatchRate = nil
+	}
+	var weights, normalization, provenance, similarity, encryption, language []byte
+	if res.ScoringWeights != nil {
+		b, err := json.Marshal(res.ScoringWeights)
+		if err != nil {
+			return fmt.Errorf("op=result.upsert_weights: %w", err)
+		}
+		weights = b
+	}
+	if res.Normalization != nil {
+		b, err := json.Marshal(res.Normalization)
+		if err != nil {
+			return fmt.Errorf("op=result.upsert_normalization: %w", err)
+		}
+		normalization = b
+	}
+	if len(res.Provenance) > 0 {
+		b, err := json.Marshal(res.Provenance)
+		if err != nil {
+			return fmt.Errorf("op=result.upsert_provenance: %w", err)
+		}
+		provenance = b
+	}
+	if res.Similarity != nil {
+		b, err := json.Marshal(res.Similarity)
+		if err != nil {
+			return fmt.Errorf("op=result.upsert_similarity: %w", err)
+		}
+		similarity = b
+	}
+	if res.Encryption != nil {
+		b, err := json.Marshal(res.Encryption)
+		if err != nil {
+			return fmt.Errorf("op=result.upsert_encryption: %w", err)
+		}
+		encryption = b
+	}
+	if res.Language != nil {
+		b, err := json.Marshal(res.Language)
+		if err != nil {
+			return fmt.Errorf("op=result.upsert_language: %w", err)
+		}
+		language = b
+	}
+	version, err := json.Marshal(resultVersionJSON{
+		CVMatchRate:     cvMatchRate,
+		CVFeedback:      res.CVFeedback,
+		ProjectScore:    projectScore,
+		ProjectFeedback: res.ProjectFeedback,
+		OverallSummary:  res.OverallSummary,
+		Provenance:      res.Provenance,
+		Encryption:      res.Encryption,
+	})
 	if err != nil {
-		return fmt.Errorf("op=result.upsert: %w", err)
+		return fmt.Errorf("op=result.upsert_version: %w", err)
+	}
+	for attempt := 1; ; attempt++ {
+		tag, err := r.Pool.Exec(ctx, upsertResultSQL, res.JobID, cvMatchRate, res.CVFeedback, projectScore, res.ProjectFeedback, res.OverallSummary, time.Now().UTC(), weights, normalization, provenance, similarity, version, encryption, language, token)
+		// Each attempt runs on a fresh snapshot, which sees the version
+		// the concurrent upsert committed.
+		if attempt < upsertResultAttempts && isUniqueViolation(err, "result_versions_pkey") {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("op=result.upsert: %w", err)
+		}
+		if token != nil && tag.RowsAffected() == 0 {
+			return fmt.Errorf("op=result.upsert: %w: job lease was taken over", domain.ErrConflict)
+		}
+		return nil
 	}
-	return nil
+}
+
+// isUniqueViolation reports whether err is a unique violation of constraint.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == constraint
 }
 
 // GetByJobID loads a result by its job_id.
@@ -51,11 +182,159 @@ func (r *ResultRepo) GetByJobID(ctx domain.Context, jobID string) (domain.Result
 		attribute.String("db.operation", "SELECT"),
 		attribute.String("db.sql.table", "results"),
 	)
-	q := `SELECT job_id, cv_match_rate, cv_feedback, project_score, project_feedback, overall_summary, created_at FROM results WHERE job_id=$1`
-	row := r.Pool.QueryRow(ctx, q, jobID)
+	row := r.Pool.QueryRow(ctx, getResultByJobIDSQL, jobID)
 	var res domain.Result
-	if err := row.Scan(&res.JobID, &res.CVMatchRate, &res.CVFeedback, &res.ProjectScore, &res.ProjectFeedback, &res.OverallSummary, &res.CreatedAt); err != nil {
+	var weights, normalization, provenance, similarity, encryption, language []byte
+	if err := row.Scan(&res.JobID, &res.CVMatchRate, &res.CVFeedback, &res.ProjectScore, &res.ProjectFeedback, &res.OverallSummary, &res.CreatedAt, &res.CVOnly, &res.ProjectOnly, &weights, &normalization, &provenance, &similarity, &encryption, &language); err != nil {
 		return domain.Result{}, fmt.Errorf("op=result.get: %w", err)
 	}
+	if err := decodeResultJSON(weights, normalization, provenance, similarity, encryption, language, &res); err != nil {
+		return domain.Result{}, fmt.Errorf("op=result.get_json: %w", err)
+	}
+	return res, nil
+}
+
+// GetByJobIDs loads all results for the given job ids with a single query.
+// Jobs without a stored result are skipped.
+func (r *ResultRepo) GetByJobIDs(ctx domain.Context, jobIDs []string) ([]domain.Result, error) {
+	tracer := otel.Tracer("repo.results")
+	ctx, span := tracer.Start(ctx, "results.GetByJobIDs")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "results"),
+		attribute.Int("results.count", len(jobIDs)),
+	)
+	if len(jobIDs) == 0 {
+		return nil, nil
+	}
+	q := `SELECT job_id, COALESCE(cv_match_rate, 0), cv_feedback, COALESCE(project_score, 0), project_feedback, overall_summary, created_at, project_score IS NULL, cv_match_rate IS NULL, scoring_weights, score_normalization, provenance, similarity, encryption, language FROM results WHERE job_id = ANY($1)`
+	rows, err := r.Pool.Query(ctx, q, jobIDs)
+	if err != nil {
+		return nil, fmt.Errorf("op=result.get_many: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]domain.Result, 0, len(jobIDs))
+	for rows.Next() {
+		var res domain.Result
+		var weights, normalization, provenance, similarity, encryption, language []byte
+		if err := rows.Scan(&res.JobID, &res.CVMatchRate, &res.CVFeedback, &res.ProjectScore, &res.ProjectFeedback, &res.OverallSummary, &res.CreatedAt, &res.CVOnly, &res.ProjectOnly, &weights, &normalization, &provenance, &similarity, &encryption, &language); err != nil {
+			return nil, fmt.Errorf("op=result.get_many_scan: %w", err)
+		}
+		if err := decodeResultJSON(weights, normalization, provenance, similarity, encryption, language, &res); err != nil {
+			return nil, fmt.Errorf("op=result.get_many_json: %w", err)
+		}
+		results = append(results, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=result.get_many_rows: %w", err)
+	}
+	return results, nil
+}
+
+// decodeResultJSON fills res.ScoringWeights, res.Normalization,
+// res.Provenance, res.Similarity, res.Encryption and res.Language from their
+// JSONB columns; NULL leaves them nil, meaning the default weights applied,
+// the raw scores were kept, no provenance was recorded, similarity detection
+// did not run, the feedback is plain text and no language mismatch was found.
+func decodeResultJSON(weights, normalization, provenance, similarity, encryption, language []byte, res *domain.Result) error {
+	if len(weights) > 0 {
+		if err := json.Unmarshal(weights, &res.ScoringWeights); err != nil {
+			return err
+		}
+	}
+	if len(normalization) > 0 {
+		if err := json.Unmarshal(normalization, &res.Normalization); err != nil {
+			return err
+		}
+	}
+	if len(provenance) > 0 {
+		if err := json.Unmarshal(provenance, &res.Provenance); err != nil {
+			return err
+		}
+	}
+	if len(similarity) > 0 {
+		if err := json.Unmarshal(similarity, &res.Similarity); err != nil {
+			return err
+		}
+	}
+	if len(encryption) > 0 {
+		if err := json.Unmarshal(encryption, &res.Encryption); err != nil {
+			return err
+		}
+	}
+	if len(language) > 0 {
+		return json.Unmarshal(language, &res.Language)
+	}
+	return nil
+}
+
+// resultVersionJSON is the stored form of a result version. Scores that were
+// not assessed are null.
+type resultVersionJSON struct {
+	CVMatchRate     *float64                 `json:"cv_match_rate"`
+	CVFeedback      string                   `json:"cv_feedback"`
+	ProjectScore    *float64                 `json:"project_score"`
+	ProjectFeedback string                   `json:"project_feedback"`
+	OverallSummary  string                   `json:"overall_summary"`
+	Provenance      []domain.StepProvenance  `json:"provenance,omitempty"`
+	Encryption      *domain.ResultEncryption `json:"encryption,omitempty"`
+}
+
+// LatestVersion returns the number of the newest stored version of a job's
+// result.
+func (r *ResultRepo) LatestVersion(ctx domain.Context, jobID string) (int, error) {
+	tracer := otel.Tracer("repo.results")
+	ctx, span := tracer.Start(ctx, "results.LatestVersion")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "result_versions"),
+	)
+	var version *int
+	if err := r.Pool.QueryRow(ctx, `SELECT MAX(version) FROM result_versions WHERE job_id=$1`, jobID).Scan(&version); err != nil {
+		return 0, fmt.Errorf("op=result.latest_version: %w", err)
+	}
+	if version == nil {
+		return 0, fmt.Errorf("op=result.latest_version: %w", domain.ErrNotFound)
+	}
+	return *version, nil
+}
+
+// GetVersion loads one stored version of a job's result.
+func (r *ResultRepo) GetVersion(ctx domain.Context, jobID string, version int) (domain.Result, error) {
+	tracer := otel.Tracer("repo.results")
+	ctx, span := tracer.Start(ctx, "results.GetVersion")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "result_versions"),
+		attribute.Int("result.version", version),
+	)
+	var raw []byte
+	res := domain.Result{JobID: jobID}
+	err := r.Pool.QueryRow(ctx, `SELECT result, created_at FROM result_versions WHERE job_id=$1 AND version=$2`, jobID, version).Scan(&raw, &res.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.Result{}, fmt.Errorf("op=result.get_version: %w", domain.ErrNotFound)
+		}
+		return domain.Result{}, fmt.Errorf("op=result.get_version: %w", err)
+	}
+	var v resultVersionJSON
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return domain.Result{}, fmt.Errorf("op=result.get_version_json: %w", err)
+	}
+	res.CVFeedback, res.ProjectFeedback, res.OverallSummary, res.Provenance, res.Encryption = v.CVFeedback, v.ProjectFeedback, v.OverallSummary, v.Provenance, v.Encryption
+	res.CVOnly, res.ProjectOnly = v.ProjectScore == nil, v.CVMatchRate == nil
+	if v.CVMatchRate != nil {
+		res.CVMatchRate = *v.CVMatchRate
+	}
+	if v.ProjectScore != nil {
+		res.ProjectScore = *v.ProjectScore
+	}
 	return res, nil
 }
@@ -2,9 +2,11 @@ package postgres_test
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -26,6 +28,150 @@ func TestResultRepo_Upsert_Success(t *testing.T) {
 	require.NoError(t, repo.Upsert(ctx, res))
 }
 
+func TestResultRepo_Upsert_CVOnlyStoresNullProjectScore(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Nil(t, args[3].(*float64))
+	}).Return(pgconn.CommandTag{}, nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), domain.Result{JobID: "j1", CVMatchRate: 0.8, CVOnly: true}))
+}
+
+func TestResultRepo_Upsert_ProjectOnlyStoresNullCVMatchRate(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Nil(t, args[1].(*float64))
+		assert.Equal(t, 7.5, *args[3].(*float64))
+	}).Return(pgconn.CommandTag{}, nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), domain.Result{JobID: "j1", ProjectScore: 7.5, ProjectOnly: true}))
+}
+
+func TestResultRepo_ScoringWeightsRoundTrip(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	weights := domain.ScoringWeights{"technical_skills_match": 70, "experience_level": 30}
+	var stored []byte
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		stored = args[7].([]byte)
+	}).Return(pgconn.CommandTag{}, nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), domain.Result{JobID: "j1", ScoringWeights: weights}))
+	require.JSONEq(t, `{"technical_skills_match":70,"experience_level":30}`, string(stored))
+
+	mockRow := mocks.NewMockRow(t)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "j1"
+		*(dest[9].(*[]byte)) = stored
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(mockRow).Once()
+	got, err := repo.GetByJobID(context.Background(), "j1")
+	require.NoError(t, err)
+	assert.Equal(t, weights, got.ScoringWeights)
+}
+
+func TestResultRepo_ProvenanceRoundTrip(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	provenance := []domain.StepProvenance{
+		{Step: "cv_match", Provider: "groq", Model: "groq/llama-3.1-8b-instant", PromptVersion: "1"},
+		{Step: "refine", Provider: "openrouter", Model: "qwen/qwen3:free", PromptVersion: "1"},
+	}
+	var stored []byte
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Nil(t, args[8].([]byte))
+		stored = args[9].([]byte)
+	}).Return(pgconn.CommandTag{}, nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), domain.Result{JobID: "j1", Provenance: provenance}))
+	require.JSONEq(t, `[{"step":"cv_match","provider":"groq","model":"groq/llama-3.1-8b-instant","prompt_version":"1"},
+		{"step":"refine","provider":"openrouter","model":"qwen/qwen3:free","prompt_version":"1"}]`, string(stored))
+
+	mockRow := mocks.NewMockRow(t)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "j1"
+		*(dest[11].(*[]byte)) = stored
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(mockRow).Once()
+	got, err := repo.GetByJobID(context.Background(), "j1")
+	require.NoError(t, err)
+	assert.Equal(t, provenance, got.Provenance)
+	assert.Nil(t, got.Normalization)
+}
+
+func TestResultRepo_SimilarityRoundTrip(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	similarity := &domain.SimilarityReport{Flagged: true, Matches: []domain.SimilarMatch{{JobID: "j0", Score: 0.97}}}
+	var stored []byte
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Nil(t, args[9].([]byte))
+		stored = args[10].([]byte)
+	}).Return(pgconn.CommandTag{}, nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), domain.Result{JobID: "j1", Similarity: similarity}))
+	require.JSONEq(t, `{"flagged":true,"matches":[{"job_id":"j0","score":0.97}]}`, string(stored))
+
+	mockRow := mocks.NewMockRow(t)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "j1"
+		*(dest[12].(*[]byte)) = stored
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(mockRow).Once()
+	got, err := repo.GetByJobID(context.Background(), "j1")
+	require.NoError(t, err)
+	assert.Equal(t, similarity, got.Similarity)
+}
+
+func TestResultRepo_EncryptionRoundTrip(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	enc := &domain.ResultEncryption{KeyID: "0123456789abcdef", Algorithm: "RSA-OAEP-256+A256GCM", WrappedKey: "d2s="}
+	var stored, version []byte
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		version = args[11].([]byte)
+		stored = args[12].([]byte)
+	}).Return(pgconn.CommandTag{}, nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), domain.Result{JobID: "j1", CVFeedback: "Y2lwaGVy", Encryption: enc}))
+	require.JSONEq(t, `{"key_id":"0123456789abcdef","algorithm":"RSA-OAEP-256+A256GCM","wrapped_key":"d2s="}`, string(stored))
+	require.Contains(t, string(version), `"encryption":{"key_id":"0123456789abcdef"`)
+
+	mockRow := mocks.NewMockRow(t)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "j1"
+		*(dest[13].(*[]byte)) = stored
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(mockRow).Once()
+	got, err := repo.GetByJobID(context.Background(), "j1")
+	require.NoError(t, err)
+	assert.Equal(t, enc, got.Encryption)
+}
+
+func TestResultRepo_LanguageRoundTrip(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	language := &domain.LanguageMismatch{JobDescription: "en", CV: "id", Translated: true}
+	var stored []byte
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Nil(t, args[12].([]byte))
+		stored = args[13].([]byte)
+	}).Return(pgconn.CommandTag{}, nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), domain.Result{JobID: "j1", Language: language}))
+	require.JSONEq(t, `{"job_description":"en","cv":"id","translated":true}`, string(stored))
+
+	mockRow := mocks.NewMockRow(t)
+	mockRow.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "j1"
+		*(dest[14].(*[]byte)) = stored
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(mockRow).Once()
+	got, err := repo.GetByJobID(context.Background(), "j1")
+	require.NoError(t, err)
+	assert.Equal(t, language, got.Language)
+}
+
 func TestResultRepo_Get_Success(t *testing.T) {
 	pool := postgres.NewMockPgxPool(t)
 	repo := postgres.NewResultRepo(pool)
@@ -76,3 +222,137 @@ func TestResultRepo_Upsert_DBError(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "op=result.upsert")
 }
+
+func TestResultRepo_Upsert_RetriesTakenVersion(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	ctx := context.Background()
+	taken := &pgconn.PgError{Code: "23505", ConstraintName: "result_versions_pkey"}
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, taken).Once()
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Upsert(ctx, domain.Result{JobID: "j1"}))
+
+	// Other violations and a version that stays taken are returned.
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, taken).Times(3)
+	require.ErrorAs(t, repo.Upsert(ctx, domain.Result{JobID: "j1"}), &taken)
+	other := &pgconn.PgError{Code: "23505", ConstraintName: "result_job_keys_pkey"}
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, other).Once()
+	require.ErrorContains(t, repo.Upsert(ctx, domain.Result{JobID: "j1"}), "op=result.upsert")
+}
+
+func TestResultRepo_UpsertFenced(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	ctx := context.Background()
+
+	// The token is checked against job_locks by the write itself.
+	var token any
+	pool.EXPECT().Exec(mock.Anything, mock.MatchedBy(func(q string) bool { return strings.Contains(q, "FROM job_locks WHERE job_id=$1 AND token=$15") }), mock.Anything).
+		Run(func(_ context.Context, _ string, args ...any) { token = args[14] }).
+		Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.UpsertFenced(ctx, domain.Result{JobID: "j1"}, 5))
+	assert.Equal(t, int64(5), *token.(*int64))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 0"), nil).Once()
+	assert.ErrorIs(t, repo.UpsertFenced(ctx, domain.Result{JobID: "j1"}, 5), domain.ErrConflict)
+}
+
+func TestResultRepo_GetByJobIDs_Success(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "job-1"
+		*(dest[1].(*float64)) = 0.8
+		*(dest[2].(*string)) = "good"
+		*(dest[3].(*float64)) = 8
+		*(dest[4].(*string)) = "solid"
+		*(dest[5].(*string)) = "ok"
+		*(dest[6].(*time.Time)) = time.Now().UTC()
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{[]string{"job-1", "job-2"}}).Return(mockRows, nil).Once()
+
+	results, err := repo.GetByJobIDs(context.Background(), []string{"job-1", "job-2"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "job-1", results[0].JobID)
+	assert.InDelta(t, 0.8, results[0].CVMatchRate, 1e-9)
+}
+
+func TestResultRepo_GetByJobIDs_QueryError(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+	results, err := repo.GetByJobIDs(context.Background(), []string{"job-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "op=result.get_many")
+	assert.Nil(t, results)
+}
+
+func TestResultRepo_Upsert_StoresVersion(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	var version []byte
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		version = args[11].([]byte)
+	}).Return(pgconn.CommandTag{}, nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), domain.Result{JobID: "j1", CVMatchRate: 0.8, CVFeedback: "a.", OverallSummary: "c.", CVOnly: true}))
+	require.JSONEq(t, `{"cv_match_rate":0.8,"cv_feedback":"a.","project_score":null,"project_feedback":"","overall_summary":"c."}`, string(version))
+}
+
+func TestResultRepo_GetVersion(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+	at := time.Date(2025, 12, 26, 9, 0, 0, 0, time.UTC)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*[]byte)) = []byte(`{"cv_match_rate":null,"cv_feedback":"","project_score":7.5,"project_feedback":"Clean.","overall_summary":"ok"}`)
+		*(dest[1].(*time.Time)) = at
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"j1", 2}).Return(row).Once()
+	res, err := repo.GetVersion(context.Background(), "j1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Result{JobID: "j1", ProjectScore: 7.5, ProjectFeedback: "Clean.", OverallSummary: "ok", ProjectOnly: true, CreatedAt: at}, res)
+
+	missing := mocks.NewMockRow(t)
+	missing.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"j1", 3}).Return(missing).Once()
+	_, err = repo.GetVersion(context.Background(), "j1", 3)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestResultRepo_LatestVersion(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewResultRepo(pool)
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		v := 3
+		*(args[0].([]any)[0].(**int)) = &v
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"j1"}).Return(row).Once()
+	v, err := repo.LatestVersion(context.Background(), "j1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	// MAX over no rows is NULL.
+	none := mocks.NewMockRow(t)
+	none.On("Scan", mock.Anything).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"j2"}).Return(none).Once()
+	_, err = repo.LatestVersion(context.Background(), "j2")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ScoreStatsRepo tracks per-model score distributions in model_score_stats.
+type ScoreStatsRepo struct{ Pool PgxPool }
+
+// NewScoreStatsRepo constructs a ScoreStatsRepo with the given pool.
+func NewScoreStatsRepo(p PgxPool) *ScoreStatsRepo { return &ScoreStatsRepo{Pool: p} }
+
+// Observe adds a score to the model's distribution in a single statement, so
+// concurrent workers never lose updates. The job is recorded in
+// model_score_observations in the same statement; a job whose metric was
+// already observed, e.g. on an earlier attempt, leaves the distribution as is.
+func (r *ScoreStatsRepo) Observe(ctx domain.Context, jobID, model, metric string, value float64, bucket, buckets int) error {
+	tracer := otel.Tracer("repo.model_score_stats")
+	ctx, span := tracer.Start(ctx, "model_score_stats.Observe")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "model_score_stats"),
+		attribute.String("ai.model", model),
+	)
+	if bucket < 0 || bucket >= buckets {
+		return fmt.Errorf("op=score_stats.observe: %w: bucket %d out of range", domain.ErrInvalidArgument, bucket)
+	}
+	hist := make([]int64, buckets)
+	hist[bucket] = 1
+	// Postgres arrays are 1-based.
+	q := `WITH seen AS (
+			INSERT INTO model_score_observations (job_id, metric, model, observed_at)
+			VALUES ($6, $2, $1, now())
+			ON CONFLICT (job_id, metric) DO NOTHING
+			RETURNING 1
+		)
+		INSERT INTO model_score_stats (model, metric, n, sum, sum_sq, buckets, updated_at)
+		SELECT $1, $2, 1, $3, $3 * $3, $4, now() FROM seen
+		ON CONFLICT (model, metric) DO UPDATE SET
+			n = model_score_stats.n + 1,
+			sum = model_score_stats.sum + $3,
+			sum_sq = model_score_stats.sum_sq + $3 * $3,
+			buckets[$5] = COALESCE(model_score_stats.buckets[$5], 0) + 1,
+			updated_at = now()`
+	if _, err := r.Pool.Exec(ctx, q, model, metric, value, hist, bucket+1, jobID); err != nil {
+		return fmt.Errorf("op=score_stats.observe: %w", err)
+	}
+	return nil
+}
+
+// List returns the distributions of metric for all models.
+func (r *ScoreStatsRepo) List(ctx domain.Context, metric string) ([]domain.ScoreDistribution, error) {
+	tracer := otel.Tracer("repo.model_score_stats")
+	ctx, span := tracer.Start(ctx, "model_score_stats.List")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "model_score_stats"),
+	)
+	rows, err := r.Pool.Query(ctx, `SELECT model, metric, n, sum, sum_sq, buckets FROM model_score_stats WHERE metric=$1 ORDER BY model`, metric)
+	if err != nil {
+		return nil, fmt.Errorf("op=score_stats.list: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.ScoreDistribution
+	for rows.Next() {
+		var d domain.ScoreDistribution
+		if err := rows.Scan(&d.Model, &d.Metric, &d.Count, &d.Sum, &d.SumSquares, &d.Buckets); err != nil {
+			return nil, fmt.Errorf("op=score_stats.list_scan: %w", err)
+		}
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=score_stats.list_rows: %w", err)
+	}
+	return out, nil
+}
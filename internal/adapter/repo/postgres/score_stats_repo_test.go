@@ -0,0 +1,60 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestScoreStatsRepo_Observe(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewScoreStatsRepo(pool)
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Run(func(_ context.Context, _ string, args ...any) {
+		assert.Equal(t, "m1", args[0])
+		assert.Equal(t, domain.ScoreMetricProjectScore, args[1])
+		assert.Equal(t, []int64{0, 0, 1, 0}, args[3])
+		assert.Equal(t, 3, args[4], "1-based array index")
+		assert.Equal(t, "job-1", args[5])
+	}).Return(pgconn.CommandTag{}, nil).Once()
+	require.NoError(t, repo.Observe(context.Background(), "job-1", "m1", domain.ScoreMetricProjectScore, 6, 2, 4))
+
+	err := repo.Observe(context.Background(), "job-1", "m1", domain.ScoreMetricProjectScore, 6, 4, 4)
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestScoreStatsRepo_List(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewScoreStatsRepo(pool)
+
+	rows := mocks.NewMockRows(t)
+	calls := 0
+	rows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	rows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "m1"
+		*(dest[1].(*string)) = domain.ScoreMetricCVMatchRate
+		*(dest[2].(*int64)) = 2
+		*(dest[3].(*float64)) = 1.2
+		*(dest[4].(*float64)) = 0.72
+		*(dest[5].(*[]int64)) = []int64{0, 2}
+	}).Return(nil).Once()
+	rows.On("Close").Return().Once()
+	rows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything, []any{domain.ScoreMetricCVMatchRate}).Return(rows, nil).Once()
+
+	got, err := repo.List(context.Background(), domain.ScoreMetricCVMatchRate)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, domain.ScoreDistribution{Model: "m1", Metric: domain.ScoreMetricCVMatchRate, Count: 2, Sum: 1.2, SumSquares: 0.72, Buckets: []int64{0, 2}}, got[0])
+}
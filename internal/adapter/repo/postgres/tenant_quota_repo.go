@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// TenantQuotaRepo counts tenants' daily quota usage in tenant_quota_usage.
+type TenantQuotaRepo struct{ Pool PgxPool }
+
+// NewTenantQuotaRepo constructs a TenantQuotaRepo with the given pool.
+func NewTenantQuotaRepo(p PgxPool) *TenantQuotaRepo { return &TenantQuotaRepo{Pool: p} }
+
+// Consume counts one evaluation unless the day's usage already reached a
+// limit; the conditional upsert keeps concurrent submissions within it.
+func (r *TenantQuotaRepo) Consume(ctx domain.Context, tenantID string, day time.Time, evaluationLimit int, tokenLimit int64) (domain.TenantQuotaUsage, error) {
+	tracer := otel.Tracer("repo.tenant_quota_usage")
+	ctx, span := tracer.Start(ctx, "tenant_quota_usage.Consume")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "tenant_quota_usage"),
+		attribute.String("tenant.id", tenantID),
+	)
+	q := `INSERT INTO tenant_quota_usage (tenant_id, day, evaluations, tokens) VALUES ($1, $2::date, 1, 0)
+		ON CONFLICT (tenant_id, day) DO UPDATE SET evaluations = tenant_quota_usage.evaluations + 1
+		WHERE ($3 = 0 OR tenant_quota_usage.evaluations < $3)
+			AND ($4 = 0 OR tenant_quota_usage.tokens < $4)
+		RETURNING evaluations, tokens`
+	var u domain.TenantQuotaUsage
+	if err := r.Pool.QueryRow(ctx, q, tenantID, day.UTC().Format(time.DateOnly), evaluationLimit, tokenLimit).Scan(&u.Evaluations, &u.Tokens); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.TenantQuotaUsage{}, fmt.Errorf("op=tenant_quota.consume: %w: daily quota exhausted", domain.ErrRateLimited)
+		}
+		return domain.TenantQuotaUsage{}, fmt.Errorf("op=tenant_quota.consume: %w", err)
+	}
+	return u, nil
+}
+
+// AddTokens adds tokens to the day's usage of tenantID.
+func (r *TenantQuotaRepo) AddTokens(ctx domain.Context, tenantID string, day time.Time, tokens int64) error {
+	tracer := otel.Tracer("repo.tenant_quota_usage")
+	ctx, span := tracer.Start(ctx, "tenant_quota_usage.AddTokens")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "tenant_quota_usage"),
+		attribute.String("tenant.id", tenantID),
+	)
+	q := `INSERT INTO tenant_quota_usage (tenant_id, day, evaluations, tokens) VALUES ($1, $2::date, 0, $3)
+		ON CONFLICT (tenant_id, day) DO UPDATE SET tokens = tenant_quota_usage.tokens + EXCLUDED.tokens`
+	if _, err := r.Pool.Exec(ctx, q, tenantID, day.UTC().Format(time.DateOnly), tokens); err != nil {
+		return fmt.Errorf("op=tenant_quota.add_tokens: %w", err)
+	}
+	return nil
+}
+
+// Refund takes back one evaluation from the day's usage of tenantID.
+func (r *TenantQuotaRepo) Refund(ctx domain.Context, tenantID string, day time.Time) error {
+	tracer := otel.Tracer("repo.tenant_quota_usage")
+	ctx, span := tracer.Start(ctx, "tenant_quota_usage.Refund")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPDATE"),
+		attribute.String("db.sql.table", "tenant_quota_usage"),
+		attribute.String("tenant.id", tenantID),
+	)
+	q := `UPDATE tenant_quota_usage SET evaluations = evaluations - 1
+		WHERE tenant_id = $1 AND day = $2::date AND evaluations > 0`
+	if _, err := r.Pool.Exec(ctx, q, tenantID, day.UTC().Format(time.DateOnly)); err != nil {
+		return fmt.Errorf("op=tenant_quota.refund: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestTenantQuotaRepo_Consume(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewTenantQuotaRepo(pool)
+	// Days are UTC even for a local timestamp on the previous evening.
+	day := time.Date(2025, 12, 23, 1, 0, 0, 0, time.FixedZone("UTC+7", 7*3600))
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*int)) = 8
+		*(dest[1].(*int64)) = 1200
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"acme", "2025-12-22", 10, int64(0)}).Return(row).Once()
+	u, err := repo.Consume(context.Background(), "acme", day, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, domain.TenantQuotaUsage{Evaluations: 8, Tokens: 1200}, u)
+
+	// A day at its limit leaves the conditional upsert without a row.
+	full := mocks.NewMockRow(t)
+	full.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, mock.Anything).Return(full).Once()
+	_, err = repo.Consume(context.Background(), "acme", day, 10, 0)
+	assert.ErrorIs(t, err, domain.ErrRateLimited)
+}
+
+func TestTenantQuotaRepo_AddTokens(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewTenantQuotaRepo(pool)
+	day := time.Date(2025, 12, 23, 9, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"acme", "2025-12-23", int64(900)}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.AddTokens(context.Background(), "acme", day, 900))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.AddTokens(context.Background(), "acme", day, 900), "op=tenant_quota.add_tokens")
+}
+
+func TestTenantQuotaRepo_Refund(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewTenantQuotaRepo(pool)
+	day := time.Date(2025, 12, 23, 9, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"acme", "2025-12-23"}).Return(pgconn.NewCommandTag("UPDATE 1"), nil).Once()
+	require.NoError(t, repo.Refund(context.Background(), "acme", day))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.Refund(context.Background(), "acme", day), "op=tenant_quota.refund")
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// TenantSettingsRepo persists admin-managed per-tenant evaluation settings.
+type TenantSettingsRepo struct{ Pool PgxPool }
+
+// NewTenantSettingsRepo constructs a TenantSettingsRepo with the given pool.
+func NewTenantSettingsRepo(p PgxPool) *TenantSettingsRepo { return &TenantSettingsRepo{Pool: p} }
+
+const tenantSettingsColumns = `tenant_id, api_key_hash, preferred_models, rubric_template, max_tokens, anonymize, updated_at, daily_evaluations, daily_tokens, temperature, top_p, frequency_penalty, result_public_key`
+
+func scanTenantSettings(row pgx.Row) (domain.TenantSettings, error) {
+	var s domain.TenantSettings
+	var p domain.SamplingParams
+	err := row.Scan(&s.TenantID, &s.APIKeyHash, &s.Overrides.PreferredModels, &s.RubricTemplate, &s.Overrides.MaxTokens, &s.Overrides.Anonymize, &s.UpdatedAt, &s.DailyEvaluations, &s.DailyTokens, &p.Temperature, &p.TopP, &p.FrequencyPenalty, &s.ResultPublicKey)
+	if !p.IsZero() {
+		s.Overrides.Sampling = &p
+	}
+	return s, err
+}
+
+// GetByAPIKeyHash returns the settings of the tenant owning the key hash.
+func (r *TenantSettingsRepo) GetByAPIKeyHash(ctx domain.Context, hash string) (domain.TenantSettings, error) {
+	tracer := otel.Tracer("repo.tenant_settings")
+	ctx, span := tracer.Start(ctx, "tenant_settings.GetByAPIKeyHash")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "tenant_settings"),
+	)
+	s, err := scanTenantSettings(r.Pool.QueryRow(ctx, `SELECT `+tenantSettingsColumns+` FROM tenant_settings WHERE api_key_hash=$1`, hash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.TenantSettings{}, fmt.Errorf("op=tenant_settings.get_by_key: %w", domain.ErrNotFound)
+		}
+		return domain.TenantSettings{}, fmt.Errorf("op=tenant_settings.get_by_key: %w", err)
+	}
+	return s, nil
+}
+
+// Get returns the settings of a tenant.
+func (r *TenantSettingsRepo) Get(ctx domain.Context, tenantID string) (domain.TenantSettings, error) {
+	tracer := otel.Tracer("repo.tenant_settings")
+	ctx, span := tracer.Start(ctx, "tenant_settings.Get")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "tenant_settings"),
+		attribute.String("tenant.id", tenantID),
+	)
+	s, err := scanTenantSettings(r.Pool.QueryRow(ctx, `SELECT `+tenantSettingsColumns+` FROM tenant_settings WHERE tenant_id=$1`, tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.TenantSettings{}, fmt.Errorf("op=tenant_settings.get: %w", domain.ErrNotFound)
+		}
+		return domain.TenantSettings{}, fmt.Errorf("op=tenant_settings.get: %w", err)
+	}
+	return s, nil
+}
+
+// List returns all tenants ordered by ID.
+func (r *TenantSettingsRepo) List(ctx domain.Context) ([]domain.TenantSettings, error) {
+	tracer := otel.Tracer("repo.tenant_settings")
+	ctx, span := tracer.Start(ctx, "tenant_settings.List")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "SELECT"),
+		attribute.String("db.sql.table", "tenant_settings"),
+	)
+	rows, err := r.Pool.Query(ctx, `SELECT `+tenantSettingsColumns+` FROM tenant_settings ORDER BY tenant_id`)
+	if err != nil {
+		return nil, fmt.Errorf("op=tenant_settings.list: %w", err)
+	}
+	defer rows.Close()
+	var out []domain.TenantSettings
+	for rows.Next() {
+		s, err := scanTenantSettings(rows)
+		if err != nil {
+			return nil, fmt.Errorf("op=tenant_settings.list_scan: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("op=tenant_settings.list_rows: %w", err)
+	}
+	return out, nil
+}
+
+// Upsert inserts or replaces the settings of s.TenantID. An empty APIKeyHash
+// keeps the stored hash, so settings can change without re-issuing the key.
+func (r *TenantSettingsRepo) Upsert(ctx domain.Context, s domain.TenantSettings) error {
+	tracer := otel.Tracer("repo.tenant_settings")
+	ctx, span := tracer.Start(ctx, "tenant_settings.Upsert")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "UPSERT"),
+		attribute.String("db.sql.table", "tenant_settings"),
+		attribute.String("tenant.id", s.TenantID),
+	)
+	models := s.Overrides.PreferredModels
+	if models == nil {
+		models = []string{}
+	}
+	q := `INSERT INTO tenant_settings (` + tenantSettingsColumns + `) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			api_key_hash = CASE WHEN EXCLUDED.api_key_hash <> '' THEN EXCLUDED.api_key_hash ELSE tenant_settings.api_key_hash END,
+			preferred_models = EXCLUDED.preferred_models,
+			rubric_template = EXCLUDED.rubric_template,
+			max_tokens = EXCLUDED.max_tokens,
+			anonymize = EXCLUDED.anonymize,
+			updated_at = EXCLUDED.updated_at,
+			daily_evaluations = EXCLUDED.daily_evaluations,
+			daily_tokens = EXCLUDED.daily_tokens,
+			temperature = EXCLUDED.temperature,
+			top_p = EXCLUDED.top_p,
+			frequency_penalty = EXCLUDED.frequency_penalty,
+			result_public_key = EXCLUDED.result_public_key`
+	var p domain.SamplingParams
+	if s.Overrides.Sampling != nil {
+		p = *s.Overrides.Sampling
+	}
+	if _, err := r.Pool.Exec(ctx, q, s.TenantID, s.APIKeyHash, models, s.RubricTemplate, s.Overrides.MaxTokens, s.Overrides.Anonymize, s.UpdatedAt, s.DailyEvaluations, s.DailyTokens, p.Temperature, p.TopP, p.FrequencyPenalty, s.ResultPublicKey); err != nil {
+		return fmt.Errorf("op=tenant_settings.upsert: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a tenant.
+func (r *TenantSettingsRepo) Delete(ctx domain.Context, tenantID string) error {
+	tracer := otel.Tracer("repo.tenant_settings")
+	ctx, span := tracer.Start(ctx, "tenant_settings.Delete")
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", "DELETE"),
+		attribute.String("db.sql.table", "tenant_settings"),
+		attribute.String("tenant.id", tenantID),
+	)
+	tag, err := r.Pool.Exec(ctx, `DELETE FROM tenant_settings WHERE tenant_id=$1`, tenantID)
+	if err != nil {
+		return fmt.Errorf("op=tenant_settings.delete: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("op=tenant_settings.delete: %w", domain.ErrNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+func TestTenantSettingsRepo_GetByAPIKeyHash(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewTenantSettingsRepo(pool)
+	at := time.Date(2025, 12, 11, 9, 0, 0, 0, time.UTC)
+	temperature := 0.5
+
+	row := mocks.NewMockRow(t)
+	row.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "acme"
+		*(dest[1].(*string)) = "h1"
+		*(dest[2].(*[]string)) = []string{"llama-3.3-70b-versatile"}
+		*(dest[3].(*string)) = "acme rubric"
+		*(dest[4].(*int)) = 800
+		*(dest[5].(*bool)) = true
+		*(dest[6].(*time.Time)) = at
+		*(dest[7].(*int)) = 100
+		*(dest[8].(*int64)) = 500000
+		*(dest[9].(**float64)) = &temperature
+	}).Return(nil).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"h1"}).Return(row).Once()
+	s, err := repo.GetByAPIKeyHash(context.Background(), "h1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.TenantSettings{
+		TenantID:         "acme",
+		APIKeyHash:       "h1",
+		Overrides:        domain.EvaluationOverrides{PreferredModels: []string{"llama-3.3-70b-versatile"}, MaxTokens: 800, Anonymize: true, Sampling: &domain.SamplingParams{Temperature: &temperature}},
+		RubricTemplate:   "acme rubric",
+		DailyEvaluations: 100,
+		DailyTokens:      500000,
+		UpdatedAt:        at,
+	}, s)
+
+	empty := mocks.NewMockRow(t)
+	empty.On("Scan", mock.Anything).Return(pgx.ErrNoRows).Once()
+	pool.EXPECT().QueryRow(mock.Anything, mock.Anything, []any{"h2"}).Return(empty).Once()
+	_, err = repo.GetByAPIKeyHash(context.Background(), "h2")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestTenantSettingsRepo_UpsertDelete(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewTenantSettingsRepo(pool)
+	at := time.Date(2025, 12, 11, 9, 0, 0, 0, time.UTC)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"acme", "", []string{}, "", 0, false, at, 0, int64(0), (*float64)(nil), (*float64)(nil), (*float64)(nil), ""}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Once()
+	require.NoError(t, repo.Upsert(context.Background(), domain.TenantSettings{TenantID: "acme", UpdatedAt: at}))
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, assert.AnError).Once()
+	assert.ErrorContains(t, repo.Upsert(context.Background(), domain.TenantSettings{TenantID: "acme"}), "op=tenant_settings.upsert")
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"acme"}).Return(pgconn.NewCommandTag("DELETE 1"), nil).Once()
+	require.NoError(t, repo.Delete(context.Background(), "acme"))
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, []any{"gone"}).Return(pgconn.NewCommandTag("DELETE 0"), nil).Once()
+	assert.ErrorIs(t, repo.Delete(context.Background(), "gone"), domain.ErrNotFound)
+}
+
+func TestTenantSettingsRepo_List(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewTenantSettingsRepo(pool)
+
+	mockRows := mocks.NewMockRows(t)
+	calls := 0
+	mockRows.On("Next").Return(func() bool {
+		calls++
+		return calls <= 1
+	}).Times(2)
+	mockRows.On("Scan", mock.Anything).Run(func(args mock.Arguments) {
+		dest := args[0].([]any)
+		*(dest[0].(*string)) = "acme"
+		*(dest[4].(*int)) = 500
+	}).Return(nil).Once()
+	mockRows.On("Close").Return().Once()
+	mockRows.On("Err").Return(nil).Once()
+	pool.EXPECT().Query(mock.Anything, mock.Anything).Return(mockRows, nil).Once()
+
+	got, err := repo.List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "acme", got[0].TenantID)
+	assert.Equal(t, 500, got[0].Overrides.MaxTokens)
+
+	pool.EXPECT().Query(mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+	_, err = repo.List(context.Background())
+	assert.ErrorContains(t, err, "op=tenant_settings.list")
+}
@@ -50,8 +50,12 @@ func (r *UploadRepo) Create(ctx domain.Context, u domain.Upload) (string, error)
 	if id == "" {
 		id = uuid.New().String()
 	}
-	q := `INSERT INTO uploads (id, type, text, filename, mime, size, created_at) VALUES ($1,$2,$3,$4,$5,$6,$7)`
-	_, err := r.Pool.Exec(ctx, q, id, u.Type, u.Text, u.Filename, u.MIME, u.Size, time.Now().UTC())
+	source := u.Source
+	if source == "" {
+		source = domain.UploadSourceFile
+	}
+	q := `INSERT INTO uploads (id, type, text, filename, mime, size, source, created_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+	_, err := r.Pool.Exec(ctx, q, id, u.Type, u.Text, u.Filename, u.MIME, u.Size, source, time.Now().UTC())
 	if err != nil {
 		return "", fmt.Errorf("op=upload.create: %w", err)
 	}
@@ -68,10 +72,10 @@ func (r *UploadRepo) Get(ctx domain.Context, id string) (domain.Upload, error) {
 		attribute.String("db.operation", "SELECT"),
 		attribute.String("db.sql.table", "uploads"),
 	)
-	q := `SELECT id, type, text, filename, mime, size, created_at FROM uploads WHERE id=$1`
+	q := `SELECT id, type, text, filename, mime, size, created_at, source FROM uploads WHERE id=$1`
 	row := r.Pool.QueryRow(ctx, q, id)
 	var u domain.Upload
-	if err := row.Scan(&u.ID, &u.Type, &u.Text, &u.Filename, &u.MIME, &u.Size, &u.CreatedAt); err != nil {
+	if err := row.Scan(&u.ID, &u.Type, &u.Text, &u.Filename, &u.MIME, &u.Size, &u.CreatedAt, &u.Source); err != nil {
 		return domain.Upload{}, fmt.Errorf("op=upload.get: %w", err)
 	}
 	return u, nil
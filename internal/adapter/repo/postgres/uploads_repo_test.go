@@ -37,6 +37,22 @@ func TestUploadRepo_Create_Success(t *testing.T) {
 	assert.Equal(t, "upload-1", id)
 }
 
+func TestUploadRepo_Create_Source(t *testing.T) {
+	pool := postgres.NewMockPgxPool(t)
+	repo := postgres.NewUploadRepo(pool)
+	source := func(want string) any {
+		return mock.MatchedBy(func(args []any) bool { return len(args) == 8 && args[6] == want })
+	}
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, source(domain.UploadSourceFile)).Return(pgconn.CommandTag{}, nil).Once()
+	_, err := repo.Create(context.Background(), domain.Upload{Type: domain.UploadTypeCV, Text: "cv"})
+	require.NoError(t, err)
+
+	pool.EXPECT().Exec(mock.Anything, mock.Anything, source(domain.UploadSourceText)).Return(pgconn.CommandTag{}, nil).Once()
+	_, err = repo.Create(context.Background(), domain.Upload{Type: domain.UploadTypeCV, Text: "cv", Source: domain.UploadSourceText})
+	require.NoError(t, err)
+}
+
 func TestUploadRepo_Create_Error(t *testing.T) {
 	pool := postgres.NewMockPgxPool(t)
 	repo := postgres.NewUploadRepo(pool)
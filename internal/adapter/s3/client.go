@@ -0,0 +1,156 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/sigv4"
+)
+
+// Config configures access to one bucket.
+type Config struct {
+	// Endpoint overrides the AWS endpoint, e.g. http://minio:9000. When set,
+	// path-style addressing is used.
+	Endpoint string
+	// Region is the bucket region used for request signing.
+	Region string
+	// Bucket is the bucket name.
+	Bucket string
+	Credentials
+}
+
+// Object describes a stored object as returned by List.
+type Object struct {
+	Key          string
+	ETag         string
+	Size         int64
+	LastModified time.Time
+}
+
+// Client reads and deletes the objects of one bucket.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// New constructs a Client.
+func New(cfg Config) *Client {
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	return &Client{cfg: cfg, httpClient: &http.Client{Timeout: 2 * time.Minute}, now: time.Now}
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns every object whose key starts with prefix, following
+// ListObjectsV2 pagination.
+func (c *Client) List(ctx context.Context, prefix string) ([]Object, error) {
+	var out []Object
+	token := ""
+	for {
+		q := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+		resp, err := c.do(ctx, http.MethodGet, c.bucketURL(), sigv4.CanonicalQuery(q))
+		if err != nil {
+			return nil, fmt.Errorf("op=s3.list: %w", err)
+		}
+		var page listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("op=s3.list_decode: %w", err)
+		}
+		for _, o := range page.Contents {
+			out = append(out, Object{Key: o.Key, ETag: strings.Trim(o.ETag, `"`), Size: o.Size, LastModified: o.LastModified})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return out, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// Get downloads the object at key. Objects larger than maxBytes are rejected.
+func (c *Client) Get(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	resp, err := c.do(ctx, http.MethodGet, c.ObjectURL(key), "")
+	if err != nil {
+		return nil, fmt.Errorf("op=s3.get: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("op=s3.get: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("op=s3.get: %s exceeds %d bytes", key, maxBytes)
+	}
+	return data, nil
+}
+
+// Delete removes the object at key.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, c.ObjectURL(key), "")
+	if err != nil {
+		return fmt.Errorf("op=s3.delete: %w", err)
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// Bucket returns the bucket name.
+func (c *Client) Bucket() string { return c.cfg.Bucket }
+
+// ObjectURL returns the path-style (custom endpoint) or virtual-hosted-style
+// (AWS) URL for key.
+func (c *Client) ObjectURL(key string) string {
+	return c.bucketURL() + EscapePath(strings.TrimPrefix(key, "/"))
+}
+
+func (c *Client) bucketURL() string {
+	if c.cfg.Endpoint != "" {
+		return strings.TrimRight(c.cfg.Endpoint, "/") + "/" + c.cfg.Bucket + "/"
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/", c.cfg.Bucket, c.cfg.Region)
+}
+
+// do sends a signed bodiless request and fails on non-2xx answers.
+func (c *Client) do(ctx context.Context, method, rawURL, rawQuery string) (*http.Response, error) {
+	if c.cfg.Bucket == "" {
+		return nil, fmt.Errorf("empty bucket")
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = rawQuery
+	Sign(req, c.cfg.Credentials, c.cfg.Region, EmptyPayloadHash, c.now())
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return resp, nil
+}
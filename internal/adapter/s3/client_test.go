@@ -0,0 +1,79 @@
+package s3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListFollowsPagination(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/inbox/", r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/20250102/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date,"))
+		queries = append(queries, r.URL.RawQuery)
+		if r.URL.Query().Get("continuation-token") == "" {
+			_, _ = w.Write([]byte(`<ListBucketResult><Contents><Key>incoming/jane doe/cv.pdf</Key><ETag>"abc"</ETag><Size>3</Size><LastModified>2025-01-02T03:04:05.000Z</LastModified></Contents><IsTruncated>true</IsTruncated><NextContinuationToken>next 1</NextContinuationToken></ListBucketResult>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<ListBucketResult><Contents><Key>incoming/jane doe/project.txt</Key><ETag>"def"</ETag><Size>5</Size><LastModified>2025-01-02T03:04:06.000Z</LastModified></Contents><IsTruncated>false</IsTruncated></ListBucketResult>`))
+	}))
+	defer srv.Close()
+
+	c := New(Config{Endpoint: srv.URL, Bucket: "inbox", Credentials: Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret"}})
+	c.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+	objects, err := c.List(context.Background(), "incoming/")
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.Equal(t, Object{Key: "incoming/jane doe/cv.pdf", ETag: "abc", Size: 3, LastModified: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}, objects[0])
+	assert.Equal(t, "def", objects[1].ETag)
+	assert.Equal(t, []string{
+		"list-type=2&prefix=incoming%2F",
+		"continuation-token=next%201&list-type=2&prefix=incoming%2F",
+	}, queries)
+}
+
+func TestClient_GetAndDelete(t *testing.T) {
+	var deleted string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Path == "/inbox/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte("<Error>NoSuchKey</Error>"))
+				return
+			}
+			_, _ = w.Write([]byte("hello"))
+		case http.MethodDelete:
+			deleted = r.URL.EscapedPath()
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+
+	c := New(Config{Endpoint: srv.URL, Bucket: "inbox"})
+	data, err := c.Get(context.Background(), "a/cv.txt", 5)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	_, err = c.Get(context.Background(), "a/cv.txt", 4)
+	assert.ErrorContains(t, err, "exceeds 4 bytes")
+	_, err = c.Get(context.Background(), "missing", 5)
+	assert.ErrorContains(t, err, "status 404")
+
+	require.NoError(t, c.Delete(context.Background(), "jane doe/cv.txt"))
+	assert.Equal(t, "/inbox/jane%20doe/cv.txt", deleted)
+
+	assert.Error(t, New(Config{}).Delete(context.Background(), "a"))
+}
+
+func TestClient_ObjectURL_VirtualHosted(t *testing.T) {
+	c := New(Config{Region: "us-west-2", Bucket: "inbox"})
+	assert.Equal(t, "https://inbox.s3.us-west-2.amazonaws.com/p/a%20b.pdf", c.ObjectURL("/p/a b.pdf"))
+}
@@ -0,0 +1,35 @@
+// Package s3 talks to S3 and S3-compatible stores such as MinIO over their
+// REST API with AWS Signature Version 4, without the AWS SDK.
+package s3
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/sigv4"
+)
+
+// EmptyPayloadHash is the SHA-256 of an empty request body.
+const EmptyPayloadHash = sigv4.EmptyPayloadHash
+
+// Credentials are S3 credentials.
+type Credentials = sigv4.Credentials
+
+// Sign adds AWS Signature Version 4 headers for the S3 service to req. The
+// host, the Content-Type and every X-Amz-* header are signed; payloadHash is
+// the hex SHA-256 of the body. The query of req must already be in canonical
+// form (sorted, %20 for spaces).
+func Sign(req *http.Request, creds Credentials, region, payloadHash string, now time.Time) {
+	sigv4.Sign(req, creds, region, "s3", payloadHash, now)
+}
+
+// EscapePath percent-encodes each segment of an object key as SigV4 expects.
+func EscapePath(p string) string {
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		segs[i] = strings.ReplaceAll(url.PathEscape(seg), "+", "%2B")
+	}
+	return strings.Join(segs, "/")
+}
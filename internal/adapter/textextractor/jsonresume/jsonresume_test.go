@@ -0,0 +1,101 @@
+package jsonresume_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/textextractor/jsonresume"
+)
+
+func TestText_JSONResume(t *testing.T) {
+	doc := `{
+		"basics": {
+			"name": "Dewi Lestari", "label": "Backend Engineer", "email": "dewi@example.com",
+			"summary": "Builds payment systems.",
+			"location": {"city": "Jakarta", "countryCode": "ID"},
+			"profiles": [{"network": "GitHub", "url": "https://github.com/dewi"}]
+		},
+		"work": [{
+			"name": "Bluefin Payments", "position": "Senior Engineer", "startDate": "2021-03",
+			"summary": "Owns the ledger service.", "highlights": ["Cut p95 latency by 40%."]
+		}],
+		"education": [{"institution": "Universitas Nusantara", "studyType": "Bachelor", "area": "Computer Science", "endDate": "2017"}],
+		"skills": [{"name": "Backend", "level": "Advanced", "keywords": ["Go", "PostgreSQL"]}],
+		"languages": [{"language": "English", "fluency": "Professional"}],
+		"unknownSection": {"ignored": true}
+	}`
+	text, err := jsonresume.Text([]byte(doc))
+	require.NoError(t, err)
+	assert.Equal(t, `Candidate:
+Name: Dewi Lestari
+Headline: Backend Engineer
+Location: Jakarta, ID
+Email: dewi@example.com
+GitHub: https://github.com/dewi
+
+Summary:
+Builds payment systems.
+
+Work Experience:
+- Senior Engineer at Bluefin Payments (2021-03 - present)
+  - Owns the ledger service.
+  - Cut p95 latency by 40%.
+
+Education:
+- Bachelor in Computer Science at Universitas Nusantara (? - 2017)
+
+Skills:
+- Backend (Advanced): Go, PostgreSQL
+
+Languages:
+- English (Professional)`, text)
+}
+
+func TestText_LinkedInExport(t *testing.T) {
+	doc := `{
+		"profile": {"firstName": "Budi", "lastName": "Santoso", "headline": "Data Engineer", "locationName": "Bandung"},
+		"positions": [{
+			"title": "Data Engineer", "companyName": "Harbor Analytics", "description": "Runs Airflow pipelines.",
+			"timePeriod": {"startDate": {"year": 2020, "month": 7}}
+		}],
+		"educations": [{"schoolName": "Institut Teknologi Merapi", "degreeName": "S.Kom.", "timePeriod": {"startDate": {"year": 2014}, "endDate": {"year": 2018}}}],
+		"skills": ["Python", {"name": "Spark"}],
+		"certifications": [{"name": "GCP Data Engineer", "authority": "Google", "timePeriod": {"startDate": {"year": 2022, "month": 1}}}]
+	}`
+	text, err := jsonresume.Text([]byte(doc))
+	require.NoError(t, err)
+	assert.Equal(t, `Candidate:
+Name: Budi Santoso
+Headline: Data Engineer
+Location: Bandung
+
+Work Experience:
+- Data Engineer at Harbor Analytics (2020-07 - present)
+  - Runs Airflow pipelines.
+
+Education:
+- S.Kom. at Institut Teknologi Merapi (2014 - 2018)
+
+Skills:
+- Python, Spark
+
+Certificates:
+- GCP Data Engineer, Google, 2022-01`, text)
+}
+
+func TestText_Rejects(t *testing.T) {
+	for name, doc := range map[string]string{
+		"not json":       `name: Dewi`,
+		"array":          `[{"basics": {}}]`,
+		"other document": `{"title": "Project report"}`,
+		"empty resume":   `{"basics": {}, "work": []}`,
+		"bad field type": `{"basics": {"name": 42}}`,
+	} {
+		_, err := jsonresume.Text([]byte(doc))
+		assert.Error(t, err, name)
+	}
+	_, err := jsonresume.Parse([]byte(`{"title": "x"}`))
+	assert.ErrorIs(t, err, jsonresume.ErrUnrecognized)
+}
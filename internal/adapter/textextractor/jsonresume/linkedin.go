@@ -0,0 +1,154 @@
+package jsonresume
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// linkedInProfile is a LinkedIn profile export as produced by LinkedIn's
+// profile API and the browser tools built on it. The basic fields are either
+// top-level or nested under "profile".
+type linkedInProfile struct {
+	linkedInBasics
+	Profile        *linkedInBasics `json:"profile"`
+	Positions      []linkedInPosition
+	Educations     []linkedInEducation
+	Skills         []json.RawMessage
+	Languages      []struct{ Name, Proficiency string }
+	Certifications []struct {
+		Name       string
+		Authority  string
+		TimePeriod linkedInPeriod
+	}
+	Projects []struct {
+		Title       string
+		Description string
+		URL         string `json:"url"`
+		TimePeriod  linkedInPeriod
+	}
+	Honors []struct {
+		Title     string
+		Issuer    string
+		IssueDate *linkedInDate
+	}
+}
+
+type linkedInBasics struct {
+	FirstName       string
+	LastName        string
+	Headline        string
+	Summary         string
+	LocationName    string
+	GeoLocationName string
+	EmailAddress    string
+}
+
+type linkedInPosition struct {
+	Title        string
+	CompanyName  string
+	LocationName string
+	Description  string
+	TimePeriod   linkedInPeriod
+}
+
+type linkedInEducation struct {
+	SchoolName   string
+	DegreeName   string
+	FieldOfStudy string
+	Grade        string
+	TimePeriod   linkedInPeriod
+}
+
+type linkedInPeriod struct {
+	StartDate *linkedInDate
+	EndDate   *linkedInDate
+}
+
+type linkedInDate struct{ Year, Month int }
+
+// String formats d like JSON Resume dates: "2021-03" or "2021".
+func (d *linkedInDate) String() string {
+	switch {
+	case d == nil || d.Year == 0:
+		return ""
+	case d.Month == 0:
+		return fmt.Sprintf("%d", d.Year)
+	default:
+		return fmt.Sprintf("%d-%02d", d.Year, d.Month)
+	}
+}
+
+// parseLinkedIn maps a LinkedIn profile export onto the JSON Resume fields.
+func parseLinkedIn(data []byte) (Resume, error) {
+	var p linkedInProfile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Resume{}, err
+	}
+	b := p.linkedInBasics
+	if p.Profile != nil {
+		b = *p.Profile
+	}
+	var r Resume
+	r.Basics.Name = join(" ", b.FirstName, b.LastName)
+	r.Basics.Label = b.Headline
+	r.Basics.Summary = b.Summary
+	r.Basics.Email = b.EmailAddress
+	r.Basics.Location.City = first(b.LocationName, b.GeoLocationName)
+	for _, pos := range p.Positions {
+		r.Work = append(r.Work, Work{
+			Name:      pos.CompanyName,
+			Position:  pos.Title,
+			Location:  pos.LocationName,
+			StartDate: pos.TimePeriod.StartDate.String(),
+			EndDate:   pos.TimePeriod.EndDate.String(),
+			Summary:   pos.Description,
+		})
+	}
+	for _, e := range p.Educations {
+		r.Education = append(r.Education, Education{
+			Institution: e.SchoolName,
+			StudyType:   e.DegreeName,
+			Area:        e.FieldOfStudy,
+			Score:       e.Grade,
+			StartDate:   e.TimePeriod.StartDate.String(),
+			EndDate:     e.TimePeriod.EndDate.String(),
+		})
+	}
+	// Skills are exported either as names or as {"name": ...} objects.
+	var names []string
+	for _, raw := range p.Skills {
+		var name string
+		if json.Unmarshal(raw, &name) != nil {
+			var s struct{ Name string }
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return Resume{}, err
+			}
+			name = s.Name
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		r.Skills = []Skill{{Keywords: names}}
+	}
+	for _, l := range p.Languages {
+		r.Languages = append(r.Languages, Language{Language: l.Name, Fluency: l.Proficiency})
+	}
+	for _, c := range p.Certifications {
+		r.Certificates = append(r.Certificates, Certificate{Name: c.Name, Issuer: c.Authority, Date: c.TimePeriod.StartDate.String()})
+	}
+	for _, pr := range p.Projects {
+		r.Projects = append(r.Projects, Project{
+			Name:        pr.Title,
+			Description: pr.Description,
+			URL:         pr.URL,
+			StartDate:   pr.TimePeriod.StartDate.String(),
+			EndDate:     pr.TimePeriod.EndDate.String(),
+		})
+	}
+	for _, h := range p.Honors {
+		r.Awards = append(r.Awards, Award{Title: h.Title, Awarder: h.Issuer, Date: h.IssueDate.String()})
+	}
+	return r, nil
+}
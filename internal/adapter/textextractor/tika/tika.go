@@ -147,8 +147,10 @@ func (c *Client) ExtractPath(ctx context.Context, fileName, path string) (string
 		if err != nil {
 			return err
 		}
-		// Sanitize control characters and then collapse all whitespace to single spaces
-		sanitized := textx.SanitizeText(string(b))
+		// Sanitize control characters, strip page numbers and running
+		// headers while lines are still intact, then collapse all whitespace
+		// to single spaces
+		sanitized := textx.Normalize(textx.SanitizeText(string(b)))
 		fields := strings.Fields(sanitized)
 		result = strings.Join(fields, " ")
 		return nil
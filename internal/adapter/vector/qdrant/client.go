@@ -9,10 +9,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
+
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
@@ -23,8 +27,41 @@ type Client struct {
 	apiKey     string
 	httpClient *http.Client
 	obs        *observability.IntegratedObservableClient
+	upsert     UpsertOptions
+	vectorName string
+	// prefix is prepended to every collection and alias name.
+	prefix string
+}
+
+// UpsertOptions controls how UpsertPoints splits and retries requests.
+type UpsertOptions struct {
+	// BatchSize is the maximum number of points sent per request.
+	BatchSize int
+	// MaxRetries is how many times a batch is retried on 5xx or transport errors.
+	MaxRetries int
+	// InitialBackoff and MaxBackoff bound the exponential delay between retries.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
 }
 
+// DefaultUpsertOptions returns the batching and retry defaults.
+func DefaultUpsertOptions() UpsertOptions {
+	return UpsertOptions{BatchSize: 64, MaxRetries: 3, InitialBackoff: 200 * time.Millisecond, MaxBackoff: 5 * time.Second}
+}
+
+// UpsertProgress is called after each batch with the number of points
+// written so far and the total being upserted.
+type UpsertProgress func(done, total int)
+
+// statusError is returned for non-2xx responses so callers can tell
+// retryable server errors from client errors.
+type statusError struct {
+	op     string
+	status int
+}
+
+func (e *statusError) Error() string { return fmt.Sprintf("qdrant %s status %d", e.op, e.status) }
+
 // New constructs a Qdrant client with baseURL and optional apiKey.
 func New(baseURL, apiKey string) *Client {
 	// Use otelhttp transport for distributed tracing
@@ -46,14 +83,36 @@ func New(baseURL, apiKey string) *Client {
 			2*time.Second,
 			30*time.Second,
 		),
+		upsert: DefaultUpsertOptions(),
+	}
+}
+
+// WithUpsertOptions overrides batching and retry behaviour of UpsertPoints.
+// Non-positive fields keep their defaults, except MaxRetries where 0
+// disables retries.
+func (c *Client) WithUpsertOptions(opts UpsertOptions) *Client {
+	def := DefaultUpsertOptions()
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = def.BatchSize
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
 	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = def.InitialBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = def.MaxBackoff
+	}
+	c.upsert = opts
+	return c
 }
 
 // EnsureCollection creates the collection if it does not exist.
 func (c *Client) EnsureCollection(ctx context.Context, name string, vectorSize int, distance string) error {
 	return c.obs.ExecuteWithMetrics(ctx, "ensure_collection", func(callCtx context.Context) error {
 		// GET /collections/{name}
-		req, err := http.NewRequestWithContext(callCtx, http.MethodGet, fmt.Sprintf("%s/collections/%s", c.baseURL, name), nil)
+		req, err := http.NewRequestWithContext(callCtx, http.MethodGet, c.collectionURL(name), nil)
 		if err != nil {
 			return err
 		}
@@ -71,7 +130,7 @@ func (c *Client) EnsureCollection(ctx context.Context, name string, vectorSize i
 			"vectors": map[string]any{"size": vectorSize, "distance": distance},
 		}
 		b, _ := json.Marshal(payload)
-		req, err = http.NewRequestWithContext(callCtx, http.MethodPut, fmt.Sprintf("%s/collections/%s", c.baseURL, name), bytes.NewReader(b))
+		req, err = http.NewRequestWithContext(callCtx, http.MethodPut, c.collectionURL(name), bytes.NewReader(b))
 		if err != nil {
 			return err
 		}
@@ -92,13 +151,20 @@ func (c *Client) EnsureCollection(ctx context.Context, name string, vectorSize i
 // UpsertPoints inserts or updates points in a collection.
 // vectors: list of float32 slices; payloads: matching metadata per point; ids: optional custom ids (len must match if provided)
 func (c *Client) UpsertPoints(ctx context.Context, collection string, vectors [][]float32, payloads []map[string]any, ids []any) error {
+	return c.UpsertPointsWithProgress(ctx, collection, vectors, payloads, ids, nil)
+}
+
+// UpsertPointsWithProgress upserts points in batches of the configured size,
+// retrying each batch with exponential backoff on 5xx and 429 responses and
+// transport errors. progress, when non-nil, is invoked after every successful batch.
+func (c *Client) UpsertPointsWithProgress(ctx context.Context, collection string, vectors [][]float32, payloads []map[string]any, ids []any, progress UpsertProgress) error {
 	if len(vectors) != len(payloads) {
 		return fmt.Errorf("vectors and payloads length mismatch")
 	}
 	points := make([]map[string]any, 0, len(vectors))
 	for i := range vectors {
 		pt := map[string]any{
-			"vector":  vectors[i],
+			"vector":  c.vectorValue(vectors[i]),
 			"payload": payloads[i],
 		}
 		if ids != nil && len(ids) == len(vectors) {
@@ -106,34 +172,85 @@ func (c *Client) UpsertPoints(ctx context.Context, collection string, vectors []
 		}
 		points = append(points, pt)
 	}
-	body := map[string]any{"points": points}
-	return c.obs.ExecuteWithMetrics(ctx, "upsert_points", func(callCtx context.Context) error {
-		b, _ := json.Marshal(body)
-		req, err := http.NewRequestWithContext(callCtx, http.MethodPut, fmt.Sprintf("%s/collections/%s/points", c.baseURL, collection), bytes.NewReader(b))
-		if err != nil {
-			return err
+	size := c.upsert.BatchSize
+	if size <= 0 {
+		size = DefaultUpsertOptions().BatchSize
+	}
+	for start := 0; start < len(points); start += size {
+		end := start + size
+		if end > len(points) {
+			end = len(points)
 		}
-		c.setHeaders(req)
-		req.Header.Set("Content-Type", "application/json")
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return err
+		if err := c.upsertBatch(ctx, collection, points[start:end]); err != nil {
+			return fmt.Errorf("op=qdrant.upsert batch=%d-%d: %w", start, end, err)
 		}
-		defer func() { _ = resp.Body.Close() }()
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return fmt.Errorf("qdrant upsert status %d", resp.StatusCode)
+		if progress != nil {
+			progress(end, len(points))
 		}
-		return nil
-	})
+	}
+	return nil
+}
+
+// upsertBatch sends one batch, retrying retryable failures.
+func (c *Client) upsertBatch(ctx context.Context, collection string, points []map[string]any) error {
+	b, err := json.Marshal(map[string]any{"points": points})
+	if err != nil {
+		return err
+	}
+	expo := backoff.NewExponentialBackOff()
+	expo.InitialInterval = c.upsert.InitialBackoff
+	expo.MaxInterval = c.upsert.MaxBackoff
+	expo.MaxElapsedTime = 0
+	bo := backoff.WithContext(backoff.WithMaxRetries(expo, uint64(c.upsert.MaxRetries)), ctx)
+
+	return backoff.Retry(func() error {
+		err := c.obs.ExecuteWithMetrics(ctx, "upsert_points", func(callCtx context.Context) error {
+			req, err := http.NewRequestWithContext(callCtx, http.MethodPut, c.collectionURL(collection)+"/points", bytes.NewReader(b))
+			if err != nil {
+				return err
+			}
+			c.setHeaders(req)
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := c.httpClient.Do(req)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				return &statusError{op: "upsert", status: resp.StatusCode}
+			}
+			return nil
+		})
+		var se *statusError
+		if errors.As(err, &se) && se.status < 500 && se.status != http.StatusTooManyRequests {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, bo)
 }
 
 // Search returns top-k nearest points for a given vector.
 func (c *Client) Search(ctx context.Context, collection string, vector []float32, topK int) ([]map[string]any, error) {
-	body := map[string]any{"vector": vector, "limit": topK, "with_payload": true}
+	return c.SearchFiltered(ctx, collection, vector, topK, nil)
+}
+
+// SearchFiltered returns the top-k nearest points whose payload matches
+// filter, a Qdrant filter object such as
+// {"must": [{"key": "tenant_id", "match": {"value": "t1"}}]}. A nil filter
+// searches all points.
+func (c *Client) SearchFiltered(ctx context.Context, collection string, vector []float32, topK int, filter map[string]any) ([]map[string]any, error) {
+	var query any = vector
+	if c.vectorName != "" {
+		query = map[string]any{"name": c.vectorName, "vector": vector}
+	}
+	body := map[string]any{"vector": query, "limit": topK, "with_payload": true}
+	if filter != nil {
+		body["filter"] = filter
+	}
 	var result []map[string]any
 	if err := c.obs.ExecuteWithMetrics(ctx, "search", func(callCtx context.Context) error {
 		b, _ := json.Marshal(body)
-		req, err := http.NewRequestWithContext(callCtx, http.MethodPost, fmt.Sprintf("%s/collections/%s/points/search", c.baseURL, collection), bytes.NewReader(b))
+		req, err := http.NewRequestWithContext(callCtx, http.MethodPost, c.collectionURL(collection)+"/points/search", bytes.NewReader(b))
 		if err != nil {
 			return err
 		}
@@ -184,6 +301,37 @@ func (c *Client) Ping(ctx context.Context) error {
 	})
 }
 
+// vectorValue wraps v for the configured named vector, if any.
+func (c *Client) vectorValue(v []float32) any {
+	if c.vectorName == "" {
+		return v
+	}
+	return map[string][]float32{c.vectorName: v}
+}
+
+// WithNamespace scopes the client to namespace: every collection and alias
+// name it sends is prefixed with "<namespace>_", so environments or tenants
+// sharing one Qdrant instance do not collide. Callers keep using the logical
+// names. An empty namespace disables the prefix.
+func (c *Client) WithNamespace(namespace string) *Client {
+	c.prefix = ""
+	if namespace != "" {
+		c.prefix = namespace + "_"
+	}
+	return c
+}
+
+// physical returns the Qdrant name of the logical collection or alias name.
+func (c *Client) physical(name string) string { return c.prefix + name }
+
+// logical strips the namespace prefix from a Qdrant name.
+func (c *Client) logical(name string) string { return strings.TrimPrefix(name, c.prefix) }
+
+// collectionURL returns the URL of the logical collection name.
+func (c *Client) collectionURL(name string) string {
+	return c.baseURL + "/collections/" + c.physical(name)
+}
+
 func (c *Client) setHeaders(req *http.Request) {
 	if c.apiKey != "" {
 		req.Header.Set("api-key", c.apiKey)
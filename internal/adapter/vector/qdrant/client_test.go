@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -282,6 +283,23 @@ func TestClient_Search(t *testing.T) {
 	}
 }
 
+func TestClient_SearchFiltered(t *testing.T) {
+	t.Parallel()
+
+	filter := map[string]any{"must": []any{map[string]any{"key": "tenant_id", "match": map[string]any{"value": "t1"}}}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, filter, payload["filter"])
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]any{"result": []map[string]any{{"id": "p1", "score": 0.9}}}))
+	}))
+	defer server.Close()
+
+	results, err := qdrant.New(server.URL, "").SearchFiltered(context.Background(), "c", []float32{0.1}, 3, filter)
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+}
+
 func TestClient_Ping(t *testing.T) {
 	t.Parallel()
 
@@ -332,3 +350,76 @@ func TestClient_Ping(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_UpsertPoints_BatchesAndReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Points []map[string]any `json:"points"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		sizes = append(sizes, len(payload.Points))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := qdrant.New(server.URL, "").WithUpsertOptions(qdrant.UpsertOptions{BatchSize: 2})
+	vectors := [][]float32{{1}, {2}, {3}, {4}, {5}}
+	payloads := []map[string]any{{}, {}, {}, {}, {}}
+	var progress [][2]int
+	err := client.UpsertPointsWithProgress(context.Background(), "c", vectors, payloads, nil, func(done, total int) {
+		progress = append(progress, [2]int{done, total})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 2, 1}, sizes)
+	assert.Equal(t, [][2]int{{2, 5}, {4, 5}, {5, 5}}, progress)
+}
+
+func TestClient_UpsertPoints_Retry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "recovers after 5xx", statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError, http.StatusOK}, wantCalls: 3},
+		{name: "retries 429", statuses: []int{http.StatusTooManyRequests, http.StatusOK}, wantCalls: 2},
+		{name: "no retry on 4xx", statuses: []int{http.StatusBadRequest}, wantCalls: 1, wantErr: true},
+		{name: "gives up after max retries", statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, wantCalls: 3, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				status := tt.statuses[len(tt.statuses)-1]
+				if calls < len(tt.statuses) {
+					status = tt.statuses[calls]
+				}
+				calls++
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			client := qdrant.New(server.URL, "").WithUpsertOptions(qdrant.UpsertOptions{
+				MaxRetries:     2,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     2 * time.Millisecond,
+			})
+			err := client.UpsertPoints(context.Background(), "c", [][]float32{{1}}, []map[string]any{{}}, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
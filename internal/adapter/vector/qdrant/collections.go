@@ -0,0 +1,250 @@
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+)
+
+// ErrVectorConfigMismatch is returned by EnsureCollectionSpec when an existing
+// collection's vector configuration differs from the requested spec and
+// recreation is not allowed.
+var ErrVectorConfigMismatch = errors.New("qdrant vector config mismatch")
+
+// VectorParams describes one vector space of a collection.
+type VectorParams struct {
+	Size     int    `json:"size"`
+	Distance string `json:"distance"`
+}
+
+// CollectionSpec describes the desired shape of a collection. When Named is
+// non-empty the collection uses named vectors, allowing several embedding
+// models to live side by side; otherwise Vector defines the single unnamed
+// vector. PayloadIndexes maps payload fields to Qdrant field schemas such as
+// "keyword", "integer" or "float".
+type CollectionSpec struct {
+	Name           string
+	Vector         VectorParams
+	Named          map[string]VectorParams
+	PayloadIndexes map[string]string
+}
+
+// collectionVectors is the decoded vectors section of a collection config.
+type collectionVectors struct {
+	Single *VectorParams
+	Named  map[string]VectorParams
+}
+
+// WithVectorName makes upserts and searches target the named vector name.
+// An empty name uses the collection's unnamed vector.
+func (c *Client) WithVectorName(name string) *Client {
+	c.vectorName = name
+	return c
+}
+
+// EnsureCollectionSpec creates the collection described by spec if missing,
+// verifies vector dimensions of an existing collection and creates the
+// requested payload indexes. On a vector mismatch the collection is dropped
+// and recreated when recreate is true; otherwise ErrVectorConfigMismatch is
+// returned and the collection is left untouched.
+//
+// When spec.Name is an alias (e.g. after cmd/reembed switched it), the
+// collection it points to is checked instead.
+func (c *Client) EnsureCollectionSpec(ctx context.Context, spec CollectionSpec, recreate bool) error {
+	if target, ok, err := c.ResolveAlias(ctx, spec.Name); err == nil && ok {
+		spec.Name = target
+	}
+	existing, found, err := c.getCollectionVectors(ctx, spec.Name)
+	if err != nil {
+		return fmt.Errorf("op=qdrant.ensure_collection %s: %w", spec.Name, err)
+	}
+	if found {
+		if mismatch := vectorMismatch(spec, existing); mismatch != "" {
+			if !recreate {
+				return fmt.Errorf("op=qdrant.ensure_collection %s: %w: %s", spec.Name, ErrVectorConfigMismatch, mismatch)
+			}
+			if err := c.DeleteCollection(ctx, spec.Name); err != nil {
+				return fmt.Errorf("op=qdrant.ensure_collection %s: %w", spec.Name, err)
+			}
+			found = false
+		}
+	}
+	if !found {
+		if err := c.createCollection(ctx, spec); err != nil {
+			return fmt.Errorf("op=qdrant.ensure_collection %s: %w", spec.Name, err)
+		}
+	}
+	fields := make([]string, 0, len(spec.PayloadIndexes))
+	for f := range spec.PayloadIndexes {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	for _, f := range fields {
+		if err := c.CreatePayloadIndex(ctx, spec.Name, f, spec.PayloadIndexes[f]); err != nil {
+			return fmt.Errorf("op=qdrant.ensure_collection %s: %w", spec.Name, err)
+		}
+	}
+	return nil
+}
+
+// CreatePayloadIndex creates (idempotently) an index on a payload field.
+func (c *Client) CreatePayloadIndex(ctx context.Context, collection, field, schema string) error {
+	body := map[string]any{"field_name": field, "field_schema": schema}
+	return c.obs.ExecuteWithMetrics(ctx, "create_payload_index", func(callCtx context.Context) error {
+		b, _ := json.Marshal(body)
+		req, err := http.NewRequestWithContext(callCtx, http.MethodPut, c.collectionURL(collection)+"/index?wait=true", bytes.NewReader(b))
+		if err != nil {
+			return err
+		}
+		c.setHeaders(req)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return &statusError{op: "create index " + field, status: resp.StatusCode}
+		}
+		return nil
+	})
+}
+
+// DeleteCollection drops a collection and all of its points.
+func (c *Client) DeleteCollection(ctx context.Context, name string) error {
+	return c.obs.ExecuteWithMetrics(ctx, "delete_collection", func(callCtx context.Context) error {
+		req, err := http.NewRequestWithContext(callCtx, http.MethodDelete, c.collectionURL(name), nil)
+		if err != nil {
+			return err
+		}
+		c.setHeaders(req)
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return &statusError{op: "delete collection", status: resp.StatusCode}
+		}
+		return nil
+	})
+}
+
+func (c *Client) getCollectionVectors(ctx context.Context, name string) (collectionVectors, bool, error) {
+	var out collectionVectors
+	found := false
+	err := c.obs.ExecuteWithMetrics(ctx, "get_collection", func(callCtx context.Context) error {
+		req, err := http.NewRequestWithContext(callCtx, http.MethodGet, c.collectionURL(name), nil)
+		if err != nil {
+			return err
+		}
+		c.setHeaders(req)
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return &statusError{op: "get collection", status: resp.StatusCode}
+		}
+		var body struct {
+			Result struct {
+				Config struct {
+					Params struct {
+						Vectors json.RawMessage `json:"vectors"`
+					} `json:"params"`
+				} `json:"config"`
+			} `json:"result"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		found = true
+		out = decodeCollectionVectors(body.Result.Config.Params.Vectors)
+		return nil
+	})
+	return out, found, err
+}
+
+// decodeCollectionVectors accepts both the unnamed form {"size":..} and the
+// named form {"name":{"size":..}}.
+func decodeCollectionVectors(raw json.RawMessage) collectionVectors {
+	var single VectorParams
+	if err := json.Unmarshal(raw, &single); err == nil && single.Size > 0 {
+		return collectionVectors{Single: &single}
+	}
+	var named map[string]VectorParams
+	if err := json.Unmarshal(raw, &named); err == nil && len(named) > 0 {
+		return collectionVectors{Named: named}
+	}
+	return collectionVectors{}
+}
+
+// vectorMismatch describes how existing differs from spec, or returns "".
+// Collections whose vector config could not be decoded are not reported.
+func vectorMismatch(spec CollectionSpec, existing collectionVectors) string {
+	if existing.Single == nil && existing.Named == nil {
+		return ""
+	}
+	if len(spec.Named) == 0 {
+		if existing.Single == nil {
+			return "collection uses named vectors, unnamed vector requested"
+		}
+		if existing.Single.Size != spec.Vector.Size {
+			return fmt.Sprintf("dimension %d, want %d", existing.Single.Size, spec.Vector.Size)
+		}
+		return ""
+	}
+	if existing.Named == nil {
+		return "collection uses an unnamed vector, named vectors requested"
+	}
+	names := make([]string, 0, len(spec.Named))
+	for n := range spec.Named {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		got, ok := existing.Named[n]
+		if !ok {
+			return fmt.Sprintf("missing named vector %q", n)
+		}
+		if got.Size != spec.Named[n].Size {
+			return fmt.Sprintf("named vector %q dimension %d, want %d", n, got.Size, spec.Named[n].Size)
+		}
+	}
+	return ""
+}
+
+func (c *Client) createCollection(ctx context.Context, spec CollectionSpec) error {
+	var vectors any = spec.Vector
+	if len(spec.Named) > 0 {
+		vectors = spec.Named
+	}
+	payload := map[string]any{"vectors": vectors}
+	return c.obs.ExecuteWithMetrics(ctx, "create_collection", func(callCtx context.Context) error {
+		b, _ := json.Marshal(payload)
+		req, err := http.NewRequestWithContext(callCtx, http.MethodPut, c.collectionURL(spec.Name), bytes.NewReader(b))
+		if err != nil {
+			return err
+		}
+		c.setHeaders(req)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return &statusError{op: "create collection", status: resp.StatusCode}
+		}
+		return nil
+	})
+}
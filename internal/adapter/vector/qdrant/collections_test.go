@@ -0,0 +1,139 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+)
+
+// fakeCollection serves GET/PUT/DELETE for one collection and records calls.
+type fakeCollection struct {
+	mu      sync.Mutex
+	vectors any // nil means the collection does not exist
+	calls   []string
+	indexes []string
+	created map[string]any
+}
+
+func (f *fakeCollection) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.calls = append(f.calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/collections/docs/index":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			f.indexes = append(f.indexes, body["field_name"].(string)+":"+body["field_schema"].(string))
+		case r.Method == http.MethodGet:
+			if f.vectors == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"result": map[string]any{"config": map[string]any{"params": map[string]any{"vectors": f.vectors}}}})
+		case r.Method == http.MethodPut:
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			f.created = body
+			f.vectors = body["vectors"]
+		case r.Method == http.MethodDelete:
+			f.vectors = nil
+		}
+	}
+}
+
+func TestClient_EnsureCollectionSpec(t *testing.T) {
+	t.Parallel()
+
+	named := qdrant.CollectionSpec{
+		Name: "docs",
+		Named: map[string]qdrant.VectorParams{
+			"small": {Size: 1536, Distance: "Cosine"},
+			"nomic": {Size: 768, Distance: "Cosine"},
+		},
+		PayloadIndexes: map[string]string{"posting_id": "keyword", "doc_type": "keyword"},
+	}
+
+	t.Run("creates named collection and indexes", func(t *testing.T) {
+		t.Parallel()
+		f := &fakeCollection{}
+		srv := httptest.NewServer(f.handler(t))
+		defer srv.Close()
+
+		require.NoError(t, qdrant.New(srv.URL, "").EnsureCollectionSpec(context.Background(), named, false))
+		vectors := f.created["vectors"].(map[string]any)
+		assert.Len(t, vectors, 2)
+		assert.Equal(t, float64(768), vectors["nomic"].(map[string]any)["size"])
+		assert.Equal(t, []string{"doc_type:keyword", "posting_id:keyword"}, f.indexes)
+	})
+
+	t.Run("matching collection is kept", func(t *testing.T) {
+		t.Parallel()
+		f := &fakeCollection{vectors: map[string]any{
+			"small": map[string]any{"size": 1536, "distance": "Cosine"},
+			"nomic": map[string]any{"size": 768, "distance": "Cosine"},
+			"old":   map[string]any{"size": 384, "distance": "Cosine"},
+		}}
+		srv := httptest.NewServer(f.handler(t))
+		defer srv.Close()
+
+		require.NoError(t, qdrant.New(srv.URL, "").EnsureCollectionSpec(context.Background(), named, false))
+		assert.Nil(t, f.created)
+		assert.NotContains(t, f.calls, "DELETE /collections/docs")
+	})
+
+	t.Run("dimension mismatch without recreate", func(t *testing.T) {
+		t.Parallel()
+		f := &fakeCollection{vectors: map[string]any{"size": 768, "distance": "Cosine"}}
+		srv := httptest.NewServer(f.handler(t))
+		defer srv.Close()
+
+		spec := qdrant.CollectionSpec{Name: "docs", Vector: qdrant.VectorParams{Size: 1536, Distance: "Cosine"}}
+		err := qdrant.New(srv.URL, "").EnsureCollectionSpec(context.Background(), spec, false)
+		require.ErrorIs(t, err, qdrant.ErrVectorConfigMismatch)
+		assert.NotContains(t, f.calls, "DELETE /collections/docs")
+	})
+
+	t.Run("unnamed to named with recreate", func(t *testing.T) {
+		t.Parallel()
+		f := &fakeCollection{vectors: map[string]any{"size": 1536, "distance": "Cosine"}}
+		srv := httptest.NewServer(f.handler(t))
+		defer srv.Close()
+
+		require.NoError(t, qdrant.New(srv.URL, "").EnsureCollectionSpec(context.Background(), named, true))
+		assert.Contains(t, f.calls, "DELETE /collections/docs")
+		assert.Len(t, f.created["vectors"].(map[string]any), 2)
+	})
+}
+
+func TestClient_WithVectorName(t *testing.T) {
+	t.Parallel()
+
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": []any{}})
+	}))
+	defer srv.Close()
+
+	c := qdrant.New(srv.URL, "").WithVectorName("nomic")
+	require.NoError(t, c.UpsertPoints(context.Background(), "docs", [][]float32{{1, 2}}, []map[string]any{{}}, nil))
+	_, err := c.Search(context.Background(), "docs", []float32{1, 2}, 3)
+	require.NoError(t, err)
+
+	require.Len(t, bodies, 2)
+	point := bodies[0]["points"].([]any)[0].(map[string]any)
+	assert.Equal(t, []any{float64(1), float64(2)}, point["vector"].(map[string]any)["nomic"])
+	query := bodies[1]["vector"].(map[string]any)
+	assert.Equal(t, "nomic", query["name"])
+}
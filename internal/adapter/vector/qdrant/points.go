@@ -0,0 +1,174 @@
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Point is a stored point without its vector.
+type Point struct {
+	ID      any            `json:"id"`
+	Payload map[string]any `json:"payload"`
+}
+
+// ScrollPoints returns up to limit points of collection starting at offset
+// (nil for the first page) together with the offset of the next page, which
+// is nil once the collection is exhausted. Vectors are not returned.
+func (c *Client) ScrollPoints(ctx context.Context, collection string, limit int, offset any) ([]Point, any, error) {
+	body := map[string]any{"limit": limit, "with_payload": true, "with_vector": false}
+	if offset != nil {
+		body["offset"] = offset
+	}
+	var out struct {
+		Result struct {
+			Points         []Point `json:"points"`
+			NextPageOffset any     `json:"next_page_offset"`
+		} `json:"result"`
+	}
+	if err := c.postJSON(ctx, "scroll_points", c.collectionURL(collection)+"/points/scroll", body, &out); err != nil {
+		return nil, nil, err
+	}
+	return out.Result.Points, out.Result.NextPageOffset, nil
+}
+
+// VectorPoint is a stored point with its vector.
+type VectorPoint struct {
+	ID      any            `json:"id"`
+	Vector  []float32      `json:"vector"`
+	Payload map[string]any `json:"payload"`
+}
+
+// ScrollVectors pages through collection like ScrollPoints but also returns
+// each point's vector: the configured named vector, or the unnamed one.
+func (c *Client) ScrollVectors(ctx context.Context, collection string, limit int, offset any) ([]VectorPoint, any, error) {
+	body := map[string]any{"limit": limit, "with_payload": true, "with_vector": true}
+	if c.vectorName != "" {
+		body["with_vector"] = []string{c.vectorName}
+	}
+	if offset != nil {
+		body["offset"] = offset
+	}
+	var out struct {
+		Result struct {
+			Points []struct {
+				ID      any             `json:"id"`
+				Vector  json.RawMessage `json:"vector"`
+				Payload map[string]any  `json:"payload"`
+			} `json:"points"`
+			NextPageOffset any `json:"next_page_offset"`
+		} `json:"result"`
+	}
+	if err := c.postJSON(ctx, "scroll_vectors", c.collectionURL(collection)+"/points/scroll", body, &out); err != nil {
+		return nil, nil, err
+	}
+	points := make([]VectorPoint, 0, len(out.Result.Points))
+	for _, p := range out.Result.Points {
+		vp := VectorPoint{ID: p.ID, Payload: p.Payload}
+		if c.vectorName != "" {
+			var named map[string][]float32
+			if err := json.Unmarshal(p.Vector, &named); err != nil {
+				return nil, nil, fmt.Errorf("qdrant scroll_vectors: point %v: %w", p.ID, err)
+			}
+			vp.Vector = named[c.vectorName]
+		} else if err := json.Unmarshal(p.Vector, &vp.Vector); err != nil {
+			return nil, nil, fmt.Errorf("qdrant scroll_vectors: point %v: %w", p.ID, err)
+		}
+		points = append(points, vp)
+	}
+	return points, out.Result.NextPageOffset, nil
+}
+
+// CountPoints returns the exact number of points in collection.
+func (c *Client) CountPoints(ctx context.Context, collection string) (int, error) {
+	var out struct {
+		Result struct {
+			Count int `json:"count"`
+		} `json:"result"`
+	}
+	if err := c.postJSON(ctx, "count_points", c.collectionURL(collection)+"/points/count", map[string]any{"exact": true}, &out); err != nil {
+		return 0, err
+	}
+	return out.Result.Count, nil
+}
+
+// ResolveAlias returns the collection an alias points to; ok is false when
+// name is not an alias. Both names are logical (without the namespace).
+func (c *Client) ResolveAlias(ctx context.Context, name string) (collection string, ok bool, err error) {
+	var out struct {
+		Result struct {
+			Aliases []struct {
+				AliasName      string `json:"alias_name"`
+				CollectionName string `json:"collection_name"`
+			} `json:"aliases"`
+		} `json:"result"`
+	}
+	if err := c.doJSON(ctx, "list_aliases", http.MethodGet, c.baseURL+"/aliases", nil, &out); err != nil {
+		return "", false, err
+	}
+	for _, a := range out.Result.Aliases {
+		if a.AliasName == c.physical(name) {
+			return c.logical(a.CollectionName), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// SwitchAlias atomically points alias at collection, replacing any previous
+// target in the same request.
+func (c *Client) SwitchAlias(ctx context.Context, alias, collection string) error {
+	body := map[string]any{"actions": []any{
+		map[string]any{"delete_alias": map[string]any{"alias_name": c.physical(alias)}},
+		map[string]any{"create_alias": map[string]any{"alias_name": c.physical(alias), "collection_name": c.physical(collection)}},
+	}}
+	if _, ok, err := c.ResolveAlias(ctx, alias); err != nil {
+		return err
+	} else if !ok {
+		// Deleting a missing alias fails the whole request, so only create it.
+		body["actions"] = body["actions"].([]any)[1:]
+	}
+	return c.postJSON(ctx, "switch_alias", c.baseURL+"/collections/aliases", body, nil)
+}
+
+func (c *Client) postJSON(ctx context.Context, op, url string, body, out any) error {
+	return c.doJSON(ctx, op, http.MethodPost, url, body, out)
+}
+
+// doJSON sends body (when non-nil) as JSON and decodes the response into out
+// (when non-nil).
+func (c *Client) doJSON(ctx context.Context, op, method, url string, body, out any) error {
+	return c.obs.ExecuteWithMetrics(ctx, op, func(callCtx context.Context) error {
+		var rd *bytes.Reader
+		if body != nil {
+			b, err := json.Marshal(body)
+			if err != nil {
+				return err
+			}
+			rd = bytes.NewReader(b)
+		} else {
+			rd = bytes.NewReader(nil)
+		}
+		req, err := http.NewRequestWithContext(callCtx, method, url, rd)
+		if err != nil {
+			return err
+		}
+		c.setHeaders(req)
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return &statusError{op: op, status: resp.StatusCode}
+		}
+		if out == nil {
+			return nil
+		}
+		return json.NewDecoder(resp.Body).Decode(out)
+	})
+}
@@ -0,0 +1,181 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+)
+
+func TestClient_ScrollAndCount(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.Path {
+		case "/collections/docs/points/scroll":
+			assert.Equal(t, false, body["with_vector"])
+			if body["offset"] == nil {
+				_, _ = w.Write([]byte(`{"result":{"points":[{"id":"a","payload":{"text":"x"}}],"next_page_offset":"b"}}`))
+				return
+			}
+			assert.Equal(t, "b", body["offset"])
+			_, _ = w.Write([]byte(`{"result":{"points":[{"id":"b","payload":{}}],"next_page_offset":null}}`))
+		case "/collections/docs/points/count":
+			assert.Equal(t, true, body["exact"])
+			_, _ = w.Write([]byte(`{"result":{"count":2}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := qdrant.New(srv.URL, "")
+	ctx := context.Background()
+	pts, next, err := c.ScrollPoints(ctx, "docs", 1, nil)
+	require.NoError(t, err)
+	require.Len(t, pts, 1)
+	assert.Equal(t, "x", pts[0].Payload["text"])
+	pts, next, err = c.ScrollPoints(ctx, "docs", 1, next)
+	require.NoError(t, err)
+	assert.Equal(t, "b", pts[0].ID)
+	assert.Nil(t, next)
+
+	n, err := c.CountPoints(ctx, "docs")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	_, err = c.CountPoints(ctx, "missing")
+	require.Error(t, err)
+}
+
+func TestClient_ScrollVectors(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body["with_vector"] == true {
+			_, _ = w.Write([]byte(`{"result":{"points":[{"id":1,"vector":[0.5,1],"payload":{"text":"x"}}],"next_page_offset":null}}`))
+			return
+		}
+		assert.Equal(t, []any{"dense"}, body["with_vector"])
+		_, _ = w.Write([]byte(`{"result":{"points":[{"id":1,"vector":{"dense":[0.25]},"payload":{}}],"next_page_offset":null}}`))
+	}))
+	defer srv.Close()
+
+	pts, next, err := qdrant.New(srv.URL, "").ScrollVectors(context.Background(), "docs", 10, nil)
+	require.NoError(t, err)
+	assert.Nil(t, next)
+	require.Len(t, pts, 1)
+	assert.Equal(t, []float32{0.5, 1}, pts[0].Vector)
+	assert.Equal(t, "x", pts[0].Payload["text"])
+
+	pts, _, err = qdrant.New(srv.URL, "").WithVectorName("dense").ScrollVectors(context.Background(), "docs", 10, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []float32{0.25}, pts[0].Vector)
+}
+
+func TestClient_SwitchAlias(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		aliases     string
+		wantActions []string
+	}{
+		{name: "replaces existing alias", aliases: `[{"alias_name":"jd","collection_name":"jd__v1"}]`, wantActions: []string{"delete_alias", "create_alias"}},
+		{name: "creates new alias", aliases: `[]`, wantActions: []string{"create_alias"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var actions []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet && r.URL.Path == "/aliases" {
+					_, _ = w.Write([]byte(`{"result":{"aliases":` + tt.aliases + `}}`))
+					return
+				}
+				require.Equal(t, "/collections/aliases", r.URL.Path)
+				var body struct {
+					Actions []map[string]any `json:"actions"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				for _, a := range body.Actions {
+					for k := range a {
+						actions = append(actions, k)
+					}
+				}
+				_, _ = w.Write([]byte(`{"result":true}`))
+			}))
+			defer srv.Close()
+
+			c := qdrant.New(srv.URL, "")
+			target, ok, err := c.ResolveAlias(context.Background(), "jd")
+			require.NoError(t, err)
+			assert.Equal(t, tt.aliases != "[]", ok)
+			if ok {
+				assert.Equal(t, "jd__v1", target)
+			}
+			require.NoError(t, c.SwitchAlias(context.Background(), "jd", "jd__v2"))
+			assert.Equal(t, tt.wantActions, actions)
+		})
+	}
+}
+
+func TestClient_WithNamespace(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	var aliasActions []any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/aliases":
+			_, _ = w.Write([]byte(`{"result":{"aliases":[{"alias_name":"jd","collection_name":"jd__v1"},{"alias_name":"prod_jd","collection_name":"prod_jd__v2"}]}}`))
+		case r.URL.Path == "/collections/aliases":
+			var body struct {
+				Actions []any `json:"actions"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			aliasActions = body.Actions
+			_, _ = w.Write([]byte(`{"result":true}`))
+		case strings.HasSuffix(r.URL.Path, "/search"):
+			_, _ = w.Write([]byte(`{"result":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"result":{"count":0}}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := qdrant.New(srv.URL, "").WithNamespace("prod")
+	ctx := context.Background()
+	_, err := c.Search(ctx, "scoring_rubric", []float32{1}, 3)
+	require.NoError(t, err)
+	_, err = c.CountPoints(ctx, "scoring_rubric")
+	require.NoError(t, err)
+	require.NoError(t, c.UpsertPoints(ctx, "scoring_rubric", [][]float32{{1}}, []map[string]any{{}}, nil))
+	assert.Equal(t, []string{
+		"POST /collections/prod_scoring_rubric/points/search",
+		"POST /collections/prod_scoring_rubric/points/count",
+		"PUT /collections/prod_scoring_rubric/points",
+	}, paths)
+
+	// Aliases are namespaced too and resolve to logical names.
+	target, ok, err := c.ResolveAlias(ctx, "jd")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "jd__v2", target)
+	require.NoError(t, c.SwitchAlias(ctx, "jd", "jd__v3"))
+	require.Len(t, aliasActions, 2)
+	assert.Equal(t, map[string]any{"create_alias": map[string]any{"alias_name": "prod_jd", "collection_name": "prod_jd__v3"}}, aliasActions[1])
+}
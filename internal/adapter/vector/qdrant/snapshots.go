@@ -0,0 +1,151 @@
+package qdrant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Snapshot is a point-in-time copy of a collection stored by Qdrant.
+type Snapshot struct {
+	Name         string    `json:"name"`
+	CreationTime time.Time `json:"creation_time"`
+	Size         int64     `json:"size"`
+}
+
+// qdrantTime parses Qdrant's creation_time, which carries no zone.
+type qdrantTime struct{ time.Time }
+
+func (t *qdrantTime) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil || s == "" {
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+		if v, err := time.Parse(layout, s); err == nil {
+			t.Time = v.UTC()
+			return nil
+		}
+	}
+	return nil
+}
+
+type snapshotJSON struct {
+	Name         string     `json:"name"`
+	CreationTime qdrantTime `json:"creation_time"`
+	Size         int64      `json:"size"`
+}
+
+func (s snapshotJSON) snapshot() Snapshot {
+	return Snapshot{Name: s.Name, CreationTime: s.CreationTime.Time, Size: s.Size}
+}
+
+// CreateSnapshot snapshots collection and waits until the snapshot is stored.
+func (c *Client) CreateSnapshot(ctx context.Context, collection string) (Snapshot, error) {
+	var out struct {
+		Result snapshotJSON `json:"result"`
+	}
+	if err := c.snapshotRequest(ctx, "create snapshot", http.MethodPost, c.collectionURL(collection)+"/snapshots?wait=true", nil, "", &out); err != nil {
+		return Snapshot{}, err
+	}
+	return out.Result.snapshot(), nil
+}
+
+// ListSnapshots returns the snapshots Qdrant holds for collection.
+func (c *Client) ListSnapshots(ctx context.Context, collection string) ([]Snapshot, error) {
+	var out struct {
+		Result []snapshotJSON `json:"result"`
+	}
+	if err := c.snapshotRequest(ctx, "list snapshots", http.MethodGet, c.collectionURL(collection)+"/snapshots", nil, "", &out); err != nil {
+		return nil, err
+	}
+	snaps := make([]Snapshot, 0, len(out.Result))
+	for _, s := range out.Result {
+		snaps = append(snaps, s.snapshot())
+	}
+	return snaps, nil
+}
+
+// DeleteSnapshot removes a snapshot of collection from Qdrant's storage.
+func (c *Client) DeleteSnapshot(ctx context.Context, collection, name string) error {
+	return c.snapshotRequest(ctx, "delete snapshot", http.MethodDelete, c.snapshotURL(collection, name)+"?wait=true", nil, "", nil)
+}
+
+// DownloadSnapshot streams a snapshot of collection into w.
+func (c *Client) DownloadSnapshot(ctx context.Context, collection, name string, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.snapshotURL(collection, name), nil)
+	if err != nil {
+		return err
+	}
+	c.setHeaders(req)
+	resp, err := c.transferClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("qdrant download snapshot: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &statusError{op: "download snapshot", status: resp.StatusCode}
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// RestoreSnapshot replaces collection with the snapshot read from r,
+// creating the collection when it does not exist. The snapshot's data takes
+// priority over any points the collection currently holds.
+func (c *Client) RestoreSnapshot(ctx context.Context, collection string, r io.Reader) error {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreateFormFile("snapshot", "snapshot.snapshot")
+		if err == nil {
+			_, err = io.Copy(part, r)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+	defer func() { _ = pr.Close() }()
+	return c.snapshotRequest(ctx, "restore snapshot", http.MethodPost,
+		c.collectionURL(collection)+"/snapshots/upload?wait=true&priority=snapshot", pr, mw.FormDataContentType(), nil)
+}
+
+func (c *Client) snapshotURL(collection, name string) string {
+	return c.collectionURL(collection) + "/snapshots/" + url.PathEscape(name)
+}
+
+// transferClient is the HTTP client for snapshot requests, which move whole
+// collections and are bounded by the caller's context instead of the
+// client's request timeout.
+func (c *Client) transferClient() *http.Client {
+	return &http.Client{Transport: c.httpClient.Transport}
+}
+
+func (c *Client) snapshotRequest(ctx context.Context, op, method, url string, body io.Reader, contentType string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	c.setHeaders(req)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.transferClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("qdrant %s: %w", op, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &statusError{op: op, status: resp.StatusCode}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
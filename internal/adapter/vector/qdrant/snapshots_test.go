@@ -0,0 +1,68 @@
+package qdrant_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+)
+
+func TestClient_Snapshots(t *testing.T) {
+	t.Parallel()
+
+	var restored string
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.RequestURI())
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/collections/prod_docs/snapshots":
+			_, _ = w.Write([]byte(`{"result":{"name":"docs-1.snapshot","creation_time":"2025-12-01T10:00:00","size":42}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/collections/prod_docs/snapshots":
+			_, _ = w.Write([]byte(`{"result":[{"name":"docs-1.snapshot","creation_time":"2025-12-01T10:00:00","size":42}]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/collections/prod_docs/snapshots/docs-1.snapshot":
+			_, _ = w.Write([]byte("snapshot-bytes"))
+		case r.Method == http.MethodDelete:
+			_, _ = w.Write([]byte(`{"result":true}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/collections/prod_docs/snapshots/upload":
+			f, _, err := r.FormFile("snapshot")
+			require.NoError(t, err)
+			b, _ := io.ReadAll(f)
+			restored = string(b)
+			_, _ = w.Write([]byte(`{"result":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := qdrant.New(srv.URL, "").WithNamespace("prod")
+	ctx := context.Background()
+	snap, err := c.CreateSnapshot(ctx, "docs")
+	require.NoError(t, err)
+	assert.Equal(t, qdrant.Snapshot{Name: "docs-1.snapshot", CreationTime: time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC), Size: 42}, snap)
+
+	snaps, err := c.ListSnapshots(ctx, "docs")
+	require.NoError(t, err)
+	assert.Equal(t, []qdrant.Snapshot{snap}, snaps)
+
+	var buf bytes.Buffer
+	require.NoError(t, c.DownloadSnapshot(ctx, "docs", snap.Name, &buf))
+	assert.Equal(t, "snapshot-bytes", buf.String())
+
+	require.NoError(t, c.RestoreSnapshot(ctx, "docs", strings.NewReader("snapshot-bytes")))
+	assert.Equal(t, "snapshot-bytes", restored)
+	require.NoError(t, c.DeleteSnapshot(ctx, "docs", snap.Name))
+	assert.Contains(t, calls, "POST /collections/prod_docs/snapshots/upload?wait=true&priority=snapshot")
+
+	err = c.DownloadSnapshot(ctx, "missing", "x", &buf)
+	assert.ErrorContains(t, err, "status 404")
+}
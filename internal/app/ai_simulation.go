@@ -0,0 +1,24 @@
+package app
+
+import (
+	"log/slog"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// BuildAISimulation wraps base with the configured record/replay layer.
+// Unknown modes are logged and base is used directly.
+func BuildAISimulation(cfg config.Config, base domain.AIClient) domain.AIClient {
+	switch cfg.AISimulationMode {
+	case "", ai.SimulationOff:
+		return base
+	case ai.SimulationRecord, ai.SimulationReplay:
+		slog.Warn("AI simulation enabled", slog.String("mode", cfg.AISimulationMode), slog.String("dir", cfg.AISimulationDir))
+		return ai.NewSimulationClient(base, cfg.AISimulationMode, ai.NewFileRecordingStore(cfg.AISimulationDir))
+	default:
+		slog.Warn("unknown AI_SIMULATION_MODE; calling providers directly", slog.String("mode", cfg.AISimulationMode))
+		return base
+	}
+}
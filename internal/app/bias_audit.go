@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// BiasReportGenerator produces and stores a bias audit report.
+type BiasReportGenerator interface {
+	Generate(ctx domain.Context) (domain.BiasReport, error)
+}
+
+// BiasAuditScheduler periodically generates bias audit reports.
+type BiasAuditScheduler struct {
+	gen      BiasReportGenerator
+	interval time.Duration
+}
+
+// NewBiasAuditScheduler creates a scheduler. It returns nil when gen is nil
+// or interval is not positive, which disables periodic reports.
+func NewBiasAuditScheduler(gen BiasReportGenerator, interval time.Duration) *BiasAuditScheduler {
+	if gen == nil || interval <= 0 {
+		return nil
+	}
+	return &BiasAuditScheduler{gen: gen, interval: interval}
+}
+
+// Run generates a report every interval until ctx is done. The first report
+// is produced after one interval so that restarts do not add reports.
+func (s *BiasAuditScheduler) Run(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("bias audit scheduler stopping")
+			return
+		case <-ticker.C:
+			if _, err := s.gen.Generate(ctx); err != nil {
+				slog.Error("bias audit report failed", slog.Any("error", err))
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+type countingGenerator struct{ calls atomic.Int32 }
+
+func (g *countingGenerator) Generate(domain.Context) (domain.BiasReport, error) {
+	if g.calls.Add(1) == 1 {
+		return domain.BiasReport{}, errors.New("db down")
+	}
+	return domain.BiasReport{}, nil
+}
+
+func TestBiasAuditScheduler_Run(t *testing.T) {
+	assert.Nil(t, NewBiasAuditScheduler(nil, time.Hour))
+	assert.Nil(t, NewBiasAuditScheduler(&countingGenerator{}, 0))
+
+	gen := &countingGenerator{}
+	s := NewBiasAuditScheduler(gen, 5*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return gen.calls.Load() >= 2 }, time.Second, 5*time.Millisecond, "keeps running after a failed report")
+	cancel()
+	<-done
+}
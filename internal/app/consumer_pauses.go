@@ -0,0 +1,23 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/queue/redpanda"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// BuildConsumerPauses loads the paused queue topics and keeps them in sync
+// every CONSUMER_PAUSE_SYNC_PERIOD until ctx is done. A failed initial load
+// leaves every topic consumed until the next sync.
+func BuildConsumerPauses(ctx context.Context, cfg config.Config, pool postgres.PgxPool) *usecase.ConsumerPauseService {
+	pauses := usecase.NewConsumerPauseService(postgres.NewConsumerPauseRepo(pool), redpanda.TopicEvaluate, redpanda.TopicDLQ)
+	if err := pauses.Sync(ctx); err != nil {
+		slog.Warn("consumer pauses load failed; assuming none", slog.Any("error", err))
+	}
+	go pauses.Run(ctx, cfg.ConsumerPauseSyncPeriod)
+	return pauses
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// BuildEmbedCache wraps base with the configured embedding cache. When Redis
+// persistence is enabled and reachable, cached embeddings are also stored in
+// Redis; otherwise the cache runs in memory only. The returned func closes
+// the Redis client and is always safe to call.
+func BuildEmbedCache(ctx context.Context, cfg config.Config, base domain.AIClient) (domain.AIClient, func()) {
+	ec := cfg.GetEmbedCacheConfig()
+	opts := ai.EmbedCacheOptions{Capacity: ec.Capacity, MaxBytes: ec.MaxBytes, TTL: ec.TTL}
+	closeFn := func() {}
+	if ec.RedisEnabled && ec.Capacity > 0 {
+		rdb := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr, Password: cfg.RedisPassword, DB: cfg.RedisDB})
+		pingCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		err := rdb.Ping(pingCtx).Err()
+		cancel()
+		if err != nil {
+			slog.Warn("embed cache redis unavailable; using memory only", slog.String("addr", cfg.RedisAddr), slog.Any("error", err))
+			_ = rdb.Close()
+		} else {
+			opts.Store = ai.NewRedisEmbedStore(rdb, ec.RedisPrefix)
+			closeFn = func() { _ = rdb.Close() }
+			slog.Info("embed cache redis persistence enabled", slog.String("addr", cfg.RedisAddr), slog.String("prefix", ec.RedisPrefix))
+		}
+	}
+	return ai.NewEmbedCacheWithOptions(base, opts), closeFn
+}
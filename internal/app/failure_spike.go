@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// FailureSpikeMonitor periodically counts the jobs that failed within a
+// sliding window and raises a job_failure_spike notification when the count
+// reaches a threshold. Counts come from the database, so failures of every
+// worker are included.
+type FailureSpikeMonitor struct {
+	jobs      domain.JobRepository
+	notifier  domain.Notifier
+	threshold int
+	window    time.Duration
+	interval  time.Duration
+	now       func() time.Time
+}
+
+// NewFailureSpikeMonitor creates a monitor checking every minute, or every
+// window when it is shorter. It returns nil when jobs or notifier is nil or
+// threshold or window is not positive, which disables the check.
+func NewFailureSpikeMonitor(jobs domain.JobRepository, notifier domain.Notifier, threshold int, window time.Duration) *FailureSpikeMonitor {
+	if jobs == nil || notifier == nil || threshold <= 0 || window <= 0 {
+		return nil
+	}
+	return &FailureSpikeMonitor{
+		jobs:      jobs,
+		notifier:  notifier,
+		threshold: threshold,
+		window:    window,
+		interval:  min(time.Minute, window),
+		now:       time.Now,
+	}
+}
+
+// Run checks for failure spikes until ctx is done.
+func (m *FailureSpikeMonitor) Run(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("failure spike monitor stopping")
+			return
+		case <-ticker.C:
+			m.check(ctx)
+		}
+	}
+}
+
+// check counts the jobs created within the window by terminal status; jobs
+// finish within minutes, so this approximates the failures of the window.
+func (m *FailureSpikeMonitor) check(ctx context.Context) {
+	from := m.now().Add(-m.window)
+	failed, err := m.jobs.CountByFilter(ctx, domain.JobListFilter{Status: string(domain.JobFailed), CreatedFrom: from})
+	if err != nil {
+		slog.Warn("failure spike check failed", slog.Any("error", err))
+		return
+	}
+	if failed < int64(m.threshold) {
+		return
+	}
+	completed, err := m.jobs.CountByFilter(ctx, domain.JobListFilter{Status: string(domain.JobCompleted), CreatedFrom: from})
+	if err != nil {
+		slog.Warn("failure spike check failed", slog.Any("error", err))
+		return
+	}
+	slog.Warn("job failure spike detected", slog.Int64("failed", failed), slog.Int64("completed", completed), slog.Duration("window", m.window))
+	m.notifier.Notify(ctx, domain.Notification{
+		Event: domain.NotifyJobFailureSpike,
+		Data:  map[string]any{"Count": failed, "Completed": completed, "Window": m.window.String()},
+	})
+}
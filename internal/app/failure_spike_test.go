@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+type countingJobRepo struct {
+	fakeJobRepo
+	counts  map[string]int64
+	filters []domain.JobListFilter
+	err     error
+}
+
+func (r *countingJobRepo) CountByFilter(_ context.Context, f domain.JobListFilter) (int64, error) {
+	r.filters = append(r.filters, f)
+	return r.counts[f.Status], r.err
+}
+
+func TestNewFailureSpikeMonitor_Disabled(t *testing.T) {
+	repo, n := &countingJobRepo{}, &recordingNotifier{}
+	if NewFailureSpikeMonitor(nil, n, 1, time.Minute) != nil ||
+		NewFailureSpikeMonitor(repo, nil, 1, time.Minute) != nil ||
+		NewFailureSpikeMonitor(repo, n, 0, time.Minute) != nil ||
+		NewFailureSpikeMonitor(repo, n, 1, 0) != nil {
+		t.Fatalf("expected nil monitor for incomplete configuration")
+	}
+	if m := NewFailureSpikeMonitor(repo, n, 1, 30*time.Second); m.interval != 30*time.Second {
+		t.Fatalf("interval = %v, want window", m.interval)
+	}
+}
+
+func TestFailureSpikeMonitor_Check(t *testing.T) {
+	now := time.Date(2025, 12, 8, 10, 0, 0, 0, time.UTC)
+	repo := &countingJobRepo{counts: map[string]int64{"failed": 4, "completed": 20}}
+	n := &recordingNotifier{}
+	m := NewFailureSpikeMonitor(repo, n, 5, 10*time.Minute)
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	m.check(ctx)
+	if len(n.got) != 0 {
+		t.Fatalf("below threshold must not notify: %+v", n.got)
+	}
+	if got := repo.filters[0]; got.Status != "failed" || !got.CreatedFrom.Equal(now.Add(-10*time.Minute)) {
+		t.Fatalf("unexpected filter %+v", got)
+	}
+
+	repo.counts["failed"] = 5
+	m.check(ctx)
+	if len(n.got) != 1 {
+		t.Fatalf("expected one notification, got %+v", n.got)
+	}
+	ev := n.got[0]
+	if ev.Event != domain.NotifyJobFailureSpike || ev.Data["Count"] != int64(5) || ev.Data["Completed"] != int64(20) || ev.Data["Window"] != "10m0s" {
+		t.Fatalf("unexpected notification %+v", ev)
+	}
+
+	repo.err = errors.New("db down")
+	m.check(ctx)
+	if len(n.got) != 1 {
+		t.Fatalf("errors must not notify")
+	}
+}
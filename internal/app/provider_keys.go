@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/secretbox"
+)
+
+// BuildProviderKeySecrets returns the box sealing the provider key secrets
+// stored in ai_provider_keys, configured by AI_PROVIDER_KEY_ENCRYPTION_KEYS
+// and AI_PROVIDER_KEY_ENCRYPTION_KEY_ID, or nil when no keys are configured
+// and keys cannot be added at runtime.
+func BuildProviderKeySecrets(cfg config.Config) (*secretbox.Box, error) {
+	enc, err := cfg.GetProviderKeyEncryption()
+	if err != nil {
+		return nil, err
+	}
+	box, err := secretbox.New(enc.KeyID, enc.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if box != nil {
+		slog.Info("provider key encryption enabled",
+			slog.String("active_key_id", enc.KeyID),
+			slog.Int("keys", len(enc.Keys)))
+	}
+	return box, nil
+}
+
+// BuildKeyRing creates the provider key ring from configuration, merges the
+// keys stored in repo and keeps them and today's usage in sync until ctx is
+// done so that keys added or disabled through the admin API and daily
+// budgets apply to every process. A failed initial sync is logged and the
+// configured keys are used.
+func BuildKeyRing(ctx context.Context, cfg config.Config, repo domain.ProviderKeyRepository, usage domain.KeyUsageRepository) *ai.KeyRing {
+	ring := ai.NewKeyRingFromConfig(cfg).WithUsage(usage, nil)
+	if repo == nil && usage == nil {
+		return ring
+	}
+	if repo != nil {
+		ring.WithStore(repo)
+	}
+	if err := ring.Sync(ctx); err != nil {
+		slog.Warn("provider key sync failed; using configured keys", slog.Any("error", err))
+	}
+	go ring.RunSync(ctx, cfg.ProviderKeySyncPeriod)
+	return ring
+}
+
+// BuildModelLimits creates the Groq model limits registry from configuration
+// and loads the limits previously discovered by any process from repo. A
+// failed load is logged; limits are then rediscovered from responses.
+func BuildModelLimits(ctx context.Context, cfg config.Config, repo domain.ModelLimitRepository) *ai.ModelLimits {
+	limits := ai.NewGroqModelLimitsFromConfig(cfg)
+	if repo == nil {
+		return limits
+	}
+	limits.WithStore(repo)
+	if err := limits.Load(ctx); err != nil {
+		slog.Warn("model limits load failed; relying on discovery", slog.Any("error", err))
+	}
+	return limits
+}
@@ -5,23 +5,61 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/queue/redpanda"
 	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/ragseed"
 )
 
+// defaultCollections are the RAG collections seeded at startup.
+var defaultCollections = []string{"job_description", "scoring_rubric"}
+
 // EnsureDefaultCollections ensures collections exist and seeds them using ragseed.
+// It uses a single unnamed 1536-dim cosine vector and no payload indexes.
 func EnsureDefaultCollections(ctx context.Context, qcli *qdrantcli.Client, aicl domain.AIClient) {
+	EnsureCollections(ctx, qcli, aicl, config.QdrantCollectionConfig{VectorSize: 1536})
+}
+
+// EnsureCollections ensures the default collections match qc (vector layout
+// and payload indexes) and seeds them using ragseed. Collections whose
+// vectors mismatch are only recreated when qc.RecreateOnMismatch is set.
+func EnsureCollections(ctx context.Context, qcli *qdrantcli.Client, aicl domain.AIClient, qc config.QdrantCollectionConfig) {
 	if qcli == nil {
 		return
 	}
-	if err := qcli.EnsureCollection(ctx, "job_description", 1536, "Cosine"); err != nil {
-		slog.Warn("qdrant ensure job_description failed", slog.Any("error", err))
-	}
-	if err := qcli.EnsureCollection(ctx, "scoring_rubric", 1536, "Cosine"); err != nil {
-		slog.Warn("qdrant ensure scoring_rubric failed", slog.Any("error", err))
+	for _, name := range defaultCollections {
+		if err := qcli.EnsureCollectionSpec(ctx, collectionSpec(name, qc), qc.RecreateOnMismatch); err != nil {
+			slog.Warn("qdrant ensure collection failed", slog.String("collection", name), slog.Any("error", err))
+		}
 	}
 	if aicl != nil {
 		_ = ragseed.SeedDefault(ctx, qcli, aicl)
 	}
 }
+
+// ensureSubmissionsCollection ensures the collection used for project
+// similarity detection, indexed by tenant so searches stay within a tenant.
+func ensureSubmissionsCollection(ctx context.Context, qcli *qdrantcli.Client, qc config.QdrantCollectionConfig) {
+	spec := collectionSpec(redpanda.SubmissionsCollection, qc)
+	spec.PayloadIndexes = map[string]string{"tenant_id": "keyword", "job_id": "keyword"}
+	if err := qcli.EnsureCollectionSpec(ctx, spec, qc.RecreateOnMismatch); err != nil {
+		slog.Warn("qdrant ensure collection failed", slog.String("collection", spec.Name), slog.Any("error", err))
+	}
+}
+
+// collectionSpec builds the Qdrant spec for a collection from qc.
+func collectionSpec(name string, qc config.QdrantCollectionConfig) qdrantcli.CollectionSpec {
+	spec := qdrantcli.CollectionSpec{
+		Name:           name,
+		Vector:         qdrantcli.VectorParams{Size: qc.VectorSize, Distance: "Cosine"},
+		PayloadIndexes: qc.PayloadIndexes,
+	}
+	if len(qc.NamedVectors) > 0 {
+		spec.Named = make(map[string]qdrantcli.VectorParams, len(qc.NamedVectors))
+		for n, size := range qc.NamedVectors {
+			spec.Named[n] = qdrantcli.VectorParams{Size: size, Distance: "Cosine"}
+		}
+	}
+	return spec
+}
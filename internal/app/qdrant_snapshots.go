@@ -0,0 +1,218 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// SnapshotStore is the Qdrant snapshot API. It is implemented by
+// qdrant.Client.
+type SnapshotStore interface {
+	CreateSnapshot(ctx context.Context, collection string) (qdrantcli.Snapshot, error)
+	ListSnapshots(ctx context.Context, collection string) ([]qdrantcli.Snapshot, error)
+	DeleteSnapshot(ctx context.Context, collection, name string) error
+	DownloadSnapshot(ctx context.Context, collection, name string, w io.Writer) error
+	RestoreSnapshot(ctx context.Context, collection string, r io.Reader) error
+}
+
+// SnapshotSink keeps snapshot copies in object storage. It is implemented by
+// archive.FileSink and archive.S3Sink.
+type SnapshotSink interface {
+	Store(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// QdrantSnapshotService snapshots the RAG collections and restores them, so
+// losing the corpus does not require re-seeding and re-embedding it.
+type QdrantSnapshotService struct {
+	store SnapshotStore
+	sink  SnapshotSink
+	cfg   config.QdrantSnapshotConfig
+}
+
+// NewQdrantSnapshotService creates the service. sink may be nil, in which
+// case snapshots only live in Qdrant. It returns nil when store is nil or no
+// collection is configured.
+func NewQdrantSnapshotService(store SnapshotStore, sink SnapshotSink, cfg config.QdrantSnapshotConfig) *QdrantSnapshotService {
+	if store == nil || len(cfg.Collections) == 0 {
+		return nil
+	}
+	if !cfg.Upload {
+		sink = nil
+	}
+	return &QdrantSnapshotService{store: store, sink: sink, cfg: cfg}
+}
+
+// snapshotKey is the object storage key of a snapshot.
+func snapshotKey(collection, name string) string {
+	return path.Join("qdrant", collection, name)
+}
+
+func (s *QdrantSnapshotService) collections(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return s.cfg.Collections, nil
+	}
+	for _, c := range requested {
+		if !slices.Contains(s.cfg.Collections, c) {
+			return nil, fmt.Errorf("%w: collection %q is not snapshotted (one of %s)", domain.ErrInvalidArgument, c, strings.Join(s.cfg.Collections, ", "))
+		}
+	}
+	return requested, nil
+}
+
+// Create snapshots collections (all configured ones when empty), copies the
+// snapshots to object storage when configured and prunes the oldest
+// snapshots kept in Qdrant. It stops at the first failure.
+func (s *QdrantSnapshotService) Create(ctx context.Context, collections []string) ([]domain.VectorSnapshot, error) {
+	names, err := s.collections(collections)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.VectorSnapshot, 0, len(names))
+	for _, c := range names {
+		snap, err := s.store.CreateSnapshot(ctx, c)
+		observability.RecordQdrantSnapshot(c, "create", err)
+		if err != nil {
+			return out, fmt.Errorf("op=qdrant_snapshot.create %s: %w", c, err)
+		}
+		v := domain.VectorSnapshot{Collection: c, Name: snap.Name, CreatedAt: snap.CreationTime, Size: snap.Size}
+		if s.sink != nil {
+			err := s.upload(ctx, c, snap.Name)
+			observability.RecordQdrantSnapshot(c, "upload", err)
+			if err != nil {
+				return out, fmt.Errorf("op=qdrant_snapshot.upload %s: %w", c, err)
+			}
+			v.StorageKey = snapshotKey(c, snap.Name)
+		}
+		out = append(out, v)
+		slog.Info("qdrant snapshot created", slog.String("collection", c), slog.String("snapshot", snap.Name), slog.String("storage_key", v.StorageKey))
+		s.prune(ctx, c)
+	}
+	return out, nil
+}
+
+// upload streams a snapshot from Qdrant to the sink.
+func (s *QdrantSnapshotService) upload(ctx context.Context, collection, name string) error {
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(s.store.DownloadSnapshot(ctx, collection, name, pw))
+	}()
+	err := s.sink.Store(ctx, snapshotKey(collection, name), pr)
+	_ = pr.CloseWithError(err)
+	return err
+}
+
+// prune deletes all but the newest Keep snapshots of collection from Qdrant.
+// Copies in object storage are left to the bucket's lifecycle rules.
+func (s *QdrantSnapshotService) prune(ctx context.Context, collection string) {
+	snaps, err := s.store.ListSnapshots(ctx, collection)
+	if err != nil {
+		slog.Warn("qdrant snapshot prune failed", slog.String("collection", collection), slog.Any("error", err))
+		return
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].CreationTime.After(snaps[j].CreationTime) })
+	for _, snap := range snaps[min(s.cfg.Keep, len(snaps)):] {
+		if err := s.store.DeleteSnapshot(ctx, collection, snap.Name); err != nil {
+			slog.Warn("qdrant snapshot prune failed", slog.String("collection", collection), slog.String("snapshot", snap.Name), slog.Any("error", err))
+		}
+	}
+}
+
+// List returns the snapshots Qdrant holds for collections (all configured
+// ones when empty), newest first.
+func (s *QdrantSnapshotService) List(ctx context.Context, collections []string) ([]domain.VectorSnapshot, error) {
+	names, err := s.collections(collections)
+	if err != nil {
+		return nil, err
+	}
+	var out []domain.VectorSnapshot
+	for _, c := range names {
+		snaps, err := s.store.ListSnapshots(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("op=qdrant_snapshot.list %s: %w", c, err)
+		}
+		for _, snap := range snaps {
+			out = append(out, domain.VectorSnapshot{Collection: c, Name: snap.Name, CreatedAt: snap.CreationTime, Size: snap.Size})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+// Restore replaces collection with snapshot name, read from object storage
+// when fromStorage is set and from Qdrant otherwise.
+func (s *QdrantSnapshotService) Restore(ctx context.Context, collection, name string, fromStorage bool) error {
+	if _, err := s.collections([]string{collection}); err != nil {
+		return err
+	}
+	if name == "" || strings.ContainsAny(name, "/\\") || strings.Contains(name, "..") {
+		return fmt.Errorf("%w: invalid snapshot name %q", domain.ErrInvalidArgument, name)
+	}
+	var r io.Reader
+	if fromStorage {
+		if s.sink == nil {
+			return fmt.Errorf("%w: snapshot upload is not configured", domain.ErrInvalidArgument)
+		}
+		rc, err := s.sink.Open(ctx, snapshotKey(collection, name))
+		if err != nil {
+			return fmt.Errorf("op=qdrant_snapshot.open %s: %w", collection, err)
+		}
+		defer func() { _ = rc.Close() }()
+		r = rc
+	} else {
+		snaps, err := s.store.ListSnapshots(ctx, collection)
+		if err != nil {
+			return fmt.Errorf("op=qdrant_snapshot.list %s: %w", collection, err)
+		}
+		if !slices.ContainsFunc(snaps, func(snap qdrantcli.Snapshot) bool { return snap.Name == name }) {
+			return fmt.Errorf("%w: snapshot %s of %s", domain.ErrNotFound, name, collection)
+		}
+		pr, pw := io.Pipe()
+		go func() {
+			_ = pw.CloseWithError(s.store.DownloadSnapshot(ctx, collection, name, pw))
+		}()
+		defer func() { _ = pr.Close() }()
+		r = pr
+	}
+	err := s.store.RestoreSnapshot(ctx, collection, r)
+	observability.RecordQdrantSnapshot(collection, "restore", err)
+	if err != nil {
+		return fmt.Errorf("op=qdrant_snapshot.restore %s: %w", collection, err)
+	}
+	slog.Info("qdrant snapshot restored", slog.String("collection", collection), slog.String("snapshot", name), slog.Bool("from_storage", fromStorage))
+	return nil
+}
+
+// Run snapshots the collections every interval until ctx is done. It does
+// nothing when the schedule is disabled.
+func (s *QdrantSnapshotService) Run(ctx context.Context) {
+	if s == nil || s.cfg.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("qdrant snapshot scheduler stopping")
+			return
+		case <-ticker.C:
+			if _, err := s.Create(ctx, nil); err != nil && !errors.Is(err, context.Canceled) {
+				slog.Error("scheduled qdrant snapshot failed", slog.Any("error", err))
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+type memSnapshotStore struct {
+	snaps    map[string][]qdrantcli.Snapshot
+	restored map[string]string
+	now      time.Time
+}
+
+func (m *memSnapshotStore) CreateSnapshot(_ context.Context, collection string) (qdrantcli.Snapshot, error) {
+	m.now = m.now.Add(time.Minute)
+	s := qdrantcli.Snapshot{Name: fmt.Sprintf("%s-%d.snapshot", collection, len(m.snaps[collection])+1), CreationTime: m.now, Size: 8}
+	m.snaps[collection] = append(m.snaps[collection], s)
+	return s, nil
+}
+
+func (m *memSnapshotStore) ListSnapshots(_ context.Context, collection string) ([]qdrantcli.Snapshot, error) {
+	return append([]qdrantcli.Snapshot(nil), m.snaps[collection]...), nil
+}
+
+func (m *memSnapshotStore) DeleteSnapshot(_ context.Context, collection, name string) error {
+	kept := m.snaps[collection][:0]
+	for _, s := range m.snaps[collection] {
+		if s.Name != name {
+			kept = append(kept, s)
+		}
+	}
+	m.snaps[collection] = kept
+	return nil
+}
+
+func (m *memSnapshotStore) DownloadSnapshot(_ context.Context, collection, name string, w io.Writer) error {
+	_, err := io.WriteString(w, "qdrant:"+collection+"/"+name)
+	return err
+}
+
+func (m *memSnapshotStore) RestoreSnapshot(_ context.Context, collection string, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	m.restored[collection] = string(b)
+	return err
+}
+
+type memSnapshotSink struct{ objects map[string]string }
+
+func (m *memSnapshotSink) Store(_ context.Context, key string, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	m.objects[key] = string(b)
+	return err
+}
+
+func (m *memSnapshotSink) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	v, ok := m.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", key)
+	}
+	return io.NopCloser(bytes.NewBufferString(v)), nil
+}
+
+func TestQdrantSnapshotService_CreateUploadsAndPrunes(t *testing.T) {
+	store := &memSnapshotStore{snaps: map[string][]qdrantcli.Snapshot{}, restored: map[string]string{}}
+	sink := &memSnapshotSink{objects: map[string]string{}}
+	svc := NewQdrantSnapshotService(store, sink, config.QdrantSnapshotConfig{Collections: []string{"jd", "rubric"}, Keep: 2, Upload: true})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		snaps, err := svc.Create(ctx, nil)
+		require.NoError(t, err)
+		require.Len(t, snaps, 2)
+	}
+	assert.Equal(t, "qdrant:jd/jd-3.snapshot", sink.objects["qdrant/jd/jd-3.snapshot"])
+	assert.Len(t, sink.objects, 6)
+
+	listed, err := svc.List(ctx, []string{"jd"})
+	require.NoError(t, err)
+	require.Len(t, listed, 2, "older snapshots are pruned from Qdrant")
+	assert.Equal(t, "jd-3.snapshot", listed[0].Name)
+
+	_, err = svc.Create(ctx, []string{"users"})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestQdrantSnapshotService_Restore(t *testing.T) {
+	store := &memSnapshotStore{snaps: map[string][]qdrantcli.Snapshot{}, restored: map[string]string{}}
+	sink := &memSnapshotSink{objects: map[string]string{"qdrant/jd/jd-0.snapshot": "stored"}}
+	svc := NewQdrantSnapshotService(store, sink, config.QdrantSnapshotConfig{Collections: []string{"jd"}, Keep: 3, Upload: true})
+	ctx := context.Background()
+
+	require.NoError(t, svc.Restore(ctx, "jd", "jd-0.snapshot", true))
+	assert.Equal(t, "stored", store.restored["jd"])
+
+	snaps, err := svc.Create(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, svc.Restore(ctx, "jd", snaps[0].Name, false))
+	assert.Equal(t, "qdrant:jd/jd-1.snapshot", store.restored["jd"])
+
+	require.ErrorIs(t, svc.Restore(ctx, "jd", "gone.snapshot", false), domain.ErrNotFound)
+	require.ErrorIs(t, svc.Restore(ctx, "jd", "../etc/passwd", true), domain.ErrInvalidArgument)
+	require.ErrorIs(t, svc.Restore(ctx, "rubric", "x", false), domain.ErrInvalidArgument)
+
+	local := NewQdrantSnapshotService(store, sink, config.QdrantSnapshotConfig{Collections: []string{"jd"}, Keep: 1})
+	require.ErrorIs(t, local.Restore(ctx, "jd", "jd-0.snapshot", true), domain.ErrInvalidArgument)
+	assert.Nil(t, NewQdrantSnapshotService(nil, nil, config.QdrantSnapshotConfig{Collections: []string{"jd"}}))
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"log/slog"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/queue/redpanda"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+)
+
+// BuildPayloadCipher returns the cipher of queue payloads configured by
+// QUEUE_ENCRYPTION_KEYS and QUEUE_ENCRYPTION_KEY_ID, or nil when no keys are
+// configured and payloads stay in plaintext.
+func BuildPayloadCipher(cfg config.Config) (*redpanda.PayloadCipher, error) {
+	enc, err := cfg.GetQueueEncryption()
+	if err != nil {
+		return nil, err
+	}
+	pc, err := redpanda.NewPayloadCipher(enc.KeyID, enc.Keys)
+	if err != nil {
+		return nil, err
+	}
+	if pc != nil {
+		slog.Info("queue payload encryption enabled",
+			slog.String("active_key_id", enc.KeyID),
+			slog.Int("keys", len(enc.Keys)))
+	}
+	return pc, nil
+}
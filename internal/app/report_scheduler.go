@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/cron"
+)
+
+// ReportDeliverer emails one scheduled activity report.
+type ReportDeliverer interface {
+	Deliver(ctx domain.Context, recipient, period string, scheduledFor time.Time) (bool, error)
+}
+
+// reportCatchUp bounds how many missed minutes a delayed tick replays.
+const reportCatchUp = 5 * time.Minute
+
+type reportSchedule struct {
+	recipient string
+	period    string
+	cron      cron.Schedule
+}
+
+// ReportScheduler emails activity reports on per-recipient cron schedules
+// evaluated in UTC.
+type ReportScheduler struct {
+	d         ReportDeliverer
+	schedules []reportSchedule
+	tick      time.Duration
+	now       func() time.Time
+	last      time.Time
+}
+
+// NewReportScheduler creates a scheduler for specs. Specs with an invalid
+// cron expression are logged and skipped. It returns nil when d is nil or no
+// valid schedule remains, which disables scheduled reports.
+func NewReportScheduler(d ReportDeliverer, specs []config.ReportScheduleSpec) *ReportScheduler {
+	if d == nil {
+		return nil
+	}
+	s := &ReportScheduler{d: d, tick: 15 * time.Second, now: time.Now}
+	for _, spec := range specs {
+		c, err := cron.Parse(spec.Cron)
+		if err != nil {
+			slog.Warn("skipping report schedule", slog.String("recipient", spec.Recipient), slog.Any("error", err))
+			continue
+		}
+		s.schedules = append(s.schedules, reportSchedule{recipient: spec.Recipient, period: spec.Period, cron: c})
+		slog.Info("report schedule registered",
+			slog.String("recipient", spec.Recipient),
+			slog.String("period", spec.Period),
+			slog.Time("next", c.Next(s.now().UTC())))
+	}
+	if len(s.schedules) == 0 {
+		return nil
+	}
+	return s
+}
+
+// Run checks the schedules several times a minute until ctx is done.
+func (s *ReportScheduler) Run(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("report scheduler stopping")
+			return
+		case <-ticker.C:
+			s.runDue(ctx)
+		}
+	}
+}
+
+// runDue delivers the reports due in every minute since the previous check,
+// replaying at most reportCatchUp of missed minutes. A minute with a failed
+// delivery is checked again on the next tick; reports already sent for it
+// are skipped by their delivery claim.
+func (s *ReportScheduler) runDue(ctx context.Context) {
+	cur := s.now().UTC().Truncate(time.Minute)
+	from := s.last.Add(time.Minute)
+	if s.last.IsZero() || cur.Sub(from) > reportCatchUp {
+		from = cur
+	}
+	var failed time.Time
+	for m := from; !m.After(cur); m = m.Add(time.Minute) {
+		for _, sc := range s.schedules {
+			if !sc.cron.Matches(m) {
+				continue
+			}
+			sent, err := s.d.Deliver(ctx, sc.recipient, sc.period, m)
+			switch {
+			case err != nil:
+				if failed.IsZero() {
+					failed = m
+				}
+				observability.RecordReportDelivery(sc.period, "failed")
+				slog.Error("activity report delivery failed",
+					slog.String("recipient", sc.recipient),
+					slog.String("period", sc.period),
+					slog.Any("error", err))
+			case sent:
+				observability.RecordReportDelivery(sc.period, "sent")
+			default:
+				observability.RecordReportDelivery(sc.period, "skipped")
+			}
+		}
+	}
+	s.last = cur
+	if !failed.IsZero() {
+		s.last = failed.Add(-time.Minute)
+	}
+}
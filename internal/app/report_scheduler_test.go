@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+type delivery struct {
+	recipient, period string
+	at                time.Time
+}
+
+type recordingDeliverer struct{ got []delivery }
+
+func (d *recordingDeliverer) Deliver(_ domain.Context, recipient, period string, at time.Time) (bool, error) {
+	d.got = append(d.got, delivery{recipient, period, at})
+	if recipient == "broken@example.com" {
+		return false, errors.New("smtp down")
+	}
+	return true, nil
+}
+
+func TestNewReportScheduler(t *testing.T) {
+	assert.Nil(t, NewReportScheduler(nil, []config.ReportScheduleSpec{{Recipient: "a", Period: "daily", Cron: "@daily"}}))
+	assert.Nil(t, NewReportScheduler(&recordingDeliverer{}, []config.ReportScheduleSpec{{Recipient: "a", Period: "daily", Cron: "bad"}}))
+	s := NewReportScheduler(&recordingDeliverer{}, []config.ReportScheduleSpec{
+		{Recipient: "a", Period: "daily", Cron: "bad"},
+		{Recipient: "b", Period: "daily", Cron: "@daily"},
+	})
+	require.NotNil(t, s)
+	assert.Len(t, s.schedules, 1)
+}
+
+func TestReportScheduler_RunDue(t *testing.T) {
+	d := &recordingDeliverer{}
+	s := NewReportScheduler(d, []config.ReportScheduleSpec{
+		{Recipient: "ops@example.com", Period: "daily", Cron: "0 8 * * *"},
+		{Recipient: "lead@example.com", Period: "weekly", Cron: "0 8 * * 1"},
+		{Recipient: "broken@example.com", Period: "daily", Cron: "1 8 * * *"},
+	})
+	require.NotNil(t, s)
+	now := time.Date(2025, 12, 8, 7, 59, 40, 0, time.UTC) // Monday
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	s.runDue(ctx)
+	assert.Empty(t, d.got)
+
+	// A delayed tick replays the missed minutes, including 08:00.
+	now = now.Add(90 * time.Second) // 08:01:10
+	s.runDue(ctx)
+	at := time.Date(2025, 12, 8, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, []delivery{
+		{"ops@example.com", "daily", at},
+		{"lead@example.com", "weekly", at},
+		{"broken@example.com", "daily", at.Add(time.Minute)},
+	}, d.got)
+
+	// Ticks within the same minute do not deliver again, except the failed
+	// delivery, which is retried.
+	now = now.Add(15 * time.Second)
+	s.runDue(ctx)
+	require.Len(t, d.got, 4)
+	assert.Equal(t, delivery{"broken@example.com", "daily", at.Add(time.Minute)}, d.got[3])
+
+	// After a long pause only the current minute is checked.
+	now = time.Date(2025, 12, 9, 8, 0, 5, 0, time.UTC)
+	s.runDue(ctx)
+	assert.Len(t, d.got, 5)
+}
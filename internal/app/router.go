@@ -54,6 +54,12 @@ func BuildRouter(cfg config.Config, srv *httpserver.Server) http.Handler {
 	r.Use(httpserver.TraceMiddleware)
 	r.Use(httpserver.AccessLog())
 	r.Use(observability.HTTPMetricsMiddleware)
+	if cfg.HTTPCompressionEnabled {
+		r.Use(httpserver.Compress(httpserver.CompressionOptions{MinBytes: cfg.HTTPCompressionMinBytes, Routes: cfg.HTTPCompressionRoutes}))
+	}
+	if srv.Drainer != nil {
+		r.Use(srv.Drainer.Middleware)
+	}
 
 	// CORS - Updated for frontend separation
 	r.Use(cors.Handler(cors.Options{
@@ -76,9 +82,26 @@ func BuildRouter(cfg config.Config, srv *httpserver.Server) http.Handler {
 		}
 		wr.Post("/v1/upload", srv.UploadHandler())
 		wr.Post("/v1/evaluate", srv.EvaluateHandler())
+		if srv.Summaries != nil {
+			// Generating a summary calls the AI provider, so it is rate
+			// limited and guarded like the other mutating endpoints.
+			wr.Post("/v1/results/{id}/summary", srv.CandidateSummaryHandler())
+		}
 	})
+	// Email-in webhook; the secret in the path authenticates the provider.
+	if cfg.InboundEmailEnabled && cfg.InboundEmailSecret != "" {
+		r.With(httprate.LimitByIP(cfg.RateLimitPerMin, 1*time.Minute)).Post("/v1/inbound/email/{secret}", srv.InboundEmailHandler())
+	}
 	// Read-only endpoints
 	r.Get("/v1/result/{id}", srv.ResultHandler())
+	if srv.Diffs != nil {
+		r.Get("/v1/result/{id}/diff", srv.ResultDiffHandler())
+	}
+	if cfg.StatusBadgeEnabled {
+		r.Get("/v1/evaluate/{id}/badge.svg", srv.StatusBadgeHandler())
+	}
+	r.Get("/v1/results", srv.BatchResultsHandler())
+	r.Post("/v1/results", srv.BatchResultsHandler())
 
 	// Enhanced health and metrics endpoints
 	r.Get("/healthz", srv.HealthzHandler()) // Enhanced health check with service status
@@ -102,6 +125,102 @@ func BuildRouter(cfg config.Config, srv *httpserver.Server) http.Handler {
 			r.Get("/admin/api/jobs", admin.AdminJobsHandler())
 			r.Get("/admin/api/jobs/{id}", admin.AdminJobDetailsHandler())
 
+			// CSV bulk submission of evaluations (JWT required)
+			r.Post("/admin/api/v1/evaluations/bulk", admin.AdminBearerRequired(admin.AdminBulkEvaluateHandler()))
+
+			// Failed job retries pinned to one provider and model (JWT required)
+			if srv.Evaluate.Outbox != nil {
+				r.Post("/admin/api/v1/jobs/{id}/retry", admin.AdminBearerRequired(admin.AdminRetryJobHandler()))
+			}
+
+			// Past jobs re-run through the current pipeline without storing
+			// anything (JWT required)
+			if srv.Replays != nil {
+				r.Post("/admin/api/v1/jobs/{id}/replay", admin.AdminBearerRequired(admin.AdminReplayJobHandler()))
+			}
+
+			// Queued jobs bumped ahead of the backlog (JWT required)
+			if srv.JobQueue != nil && srv.Evaluate.Outbox != nil {
+				r.Post("/admin/api/v1/jobs/{id}/bump", admin.AdminBearerRequired(admin.AdminBumpJobHandler()))
+			}
+
+			// Requeue, cancel or fail every job matching a filter (JWT required)
+			if srv.JobBulk != nil {
+				r.Post("/admin/api/v1/jobs/bulk", admin.AdminBearerRequired(admin.AdminStartJobBulkHandler()))
+				r.Get("/admin/api/v1/jobs/bulk", admin.AdminBearerRequired(admin.AdminJobBulkOperationsHandler()))
+				r.Get("/admin/api/v1/jobs/bulk/{id}", admin.AdminBearerRequired(admin.AdminJobBulkOperationHandler()))
+			}
+
+			// Anonymous usage stats, opt-in via STATS_ENABLED (JWT required)
+			if srv.UsageStats != nil {
+				r.Get("/admin/api/v1/stats", admin.AdminBearerRequired(admin.AdminUsageStatsHandler()))
+			}
+
+			// Runtime management of AI provider keys (JWT required)
+			if srv.ProviderKeys != nil {
+				r.Get("/admin/api/ai/keys", admin.AdminBearerRequired(admin.AdminProviderKeysHandler()))
+				r.Post("/admin/api/ai/keys", admin.AdminBearerRequired(admin.AdminAddProviderKeyHandler()))
+				r.Patch("/admin/api/ai/keys/{id}", admin.AdminBearerRequired(admin.AdminUpdateProviderKeyHandler()))
+			}
+
+			// Most frequent AI provider error fingerprints (JWT required)
+			if srv.ProviderErrors != nil {
+				r.Get("/admin/api/ai/errors", admin.AdminBearerRequired(admin.AdminProviderErrorsHandler()))
+			}
+
+			// Bias/drift audit of evaluation scores (JWT required)
+			if srv.BiasAudit != nil {
+				r.Get("/admin/api/analytics/bias", admin.AdminBearerRequired(admin.AdminBiasReportHandler()))
+				r.Post("/admin/api/analytics/bias", admin.AdminBearerRequired(admin.AdminGenerateBiasReportHandler()))
+			}
+
+			// Per-phase job timing percentiles for capacity planning (JWT required)
+			if srv.JobUsage != nil {
+				r.Get("/admin/api/analytics/job-usage", admin.AdminBearerRequired(admin.AdminJobUsageHandler()))
+			}
+
+			// Maintenance mode toggle (JWT required)
+			if srv.Maintenance != nil {
+				r.Get("/admin/api/maintenance", admin.AdminBearerRequired(admin.AdminMaintenanceHandler()))
+				r.Post("/admin/api/maintenance", admin.AdminBearerRequired(admin.AdminSetMaintenanceHandler()))
+			}
+
+			// Pause and resume of the workers' queue consumption (JWT required)
+			if srv.ConsumerPauses != nil {
+				r.Get("/admin/api/consumers", admin.AdminBearerRequired(admin.AdminConsumersHandler()))
+				r.Post("/admin/api/consumers/{topic}/pause", admin.AdminBearerRequired(admin.AdminPauseConsumerHandler()))
+				r.Post("/admin/api/consumers/{topic}/resume", admin.AdminBearerRequired(admin.AdminResumeConsumerHandler()))
+			}
+
+			// Per-tenant evaluation settings (JWT required)
+			if srv.Experiments != nil {
+				r.Get("/admin/api/experiments", admin.AdminBearerRequired(admin.AdminPromptExperimentsHandler()))
+				r.Post("/admin/api/experiments", admin.AdminBearerRequired(admin.AdminCreatePromptExperimentHandler()))
+				r.Post("/admin/api/experiments/{id}/end", admin.AdminBearerRequired(admin.AdminEndPromptExperimentHandler()))
+				r.Get("/admin/api/experiments/{id}/report", admin.AdminBearerRequired(admin.AdminPromptExperimentReportHandler()))
+			}
+			// Qdrant snapshots of the RAG collections (JWT required)
+			if srv.Snapshots != nil {
+				r.Get("/admin/api/qdrant/snapshots", admin.AdminBearerRequired(admin.AdminSnapshotsHandler()))
+				r.Post("/admin/api/qdrant/snapshots", admin.AdminBearerRequired(admin.AdminCreateSnapshotsHandler()))
+				r.Post("/admin/api/qdrant/snapshots/restore", admin.AdminBearerRequired(admin.AdminRestoreSnapshotHandler()))
+			}
+			// Legal holds on uploads and jobs (JWT required)
+			if srv.LegalHolds != nil {
+				r.Get("/admin/api/legal-holds", admin.AdminBearerRequired(admin.AdminLegalHoldsHandler()))
+				r.Get("/admin/api/legal-holds/{type}/{id}", admin.AdminBearerRequired(admin.AdminLegalHoldHistoryHandler()))
+				r.Put("/admin/api/legal-holds/{type}/{id}", admin.AdminBearerRequired(admin.AdminSetLegalHoldHandler()))
+			}
+			// Audit log of result reads (JWT required)
+			if srv.AccessLog != nil {
+				r.Get("/admin/api/access-log", admin.AdminBearerRequired(admin.AdminAccessLogHandler()))
+			}
+			if srv.Tenants != nil {
+				r.Get("/admin/api/tenants", admin.AdminBearerRequired(admin.AdminTenantsHandler()))
+				r.Put("/admin/api/tenants/{id}", admin.AdminBearerRequired(admin.AdminSetTenantHandler()))
+				r.Delete("/admin/api/tenants/{id}", admin.AdminBearerRequired(admin.AdminDeleteTenantHandler()))
+			}
+
 			// Admin-only observability endpoints (JWT required)
 			r.Get("/admin/metrics", admin.AdminBearerRequired(srv.MetricsHandler()))                                                                   // Custom observability metrics (admin only)
 			r.Get("/admin/prometheus", admin.AdminBearerRequired(func(w http.ResponseWriter, r *http.Request) { promhttp.Handler().ServeHTTP(w, r) })) // Prometheus metrics (admin only)
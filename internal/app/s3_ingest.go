@@ -0,0 +1,247 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/s3"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ObjectStore lists, reads and deletes the objects of a bucket. It is
+// implemented by s3.Client.
+type ObjectStore interface {
+	List(ctx context.Context, prefix string) ([]s3.Object, error)
+	Get(ctx context.Context, key string, maxBytes int64) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// DocumentIngester stores a document as an upload. It is implemented by
+// httpserver.Server.
+type DocumentIngester interface {
+	IngestDocument(ctx context.Context, uploadType, name string, data []byte) (string, error)
+}
+
+// EvaluationEnqueuer queues an evaluation. It is implemented by
+// usecase.EvaluateService.
+type EvaluationEnqueuer interface {
+	Enqueue(ctx domain.Context, cvID, projectID, jobDesc, studyCase, scoringRubric, idemKey string) (string, error)
+}
+
+// IdempotentJobs finds jobs by idempotency key.
+type IdempotentJobs interface {
+	FindByIdempotencyKey(ctx domain.Context, key string) (domain.Job, error)
+}
+
+// S3IngestOptions tunes the S3IngestScheduler.
+type S3IngestOptions struct {
+	// Prefix is the key prefix watched for candidate folders.
+	Prefix string
+	// Interval is the time between bucket scans.
+	Interval time.Duration
+	// PairWait is how long a lone document waits for its counterpart before
+	// it is evaluated by itself.
+	PairWait time.Duration
+	// DeleteProcessed removes the documents once their job is queued.
+	DeleteProcessed bool
+	// MaxBytes caps the size of a document.
+	MaxBytes int64
+}
+
+// S3IngestScheduler turns documents dropped into a bucket into evaluations.
+// Documents follow the naming convention <prefix><candidate>/cv.<ext> and
+// <prefix><candidate>/project.<ext>; each candidate folder becomes one job.
+type S3IngestScheduler struct {
+	store ObjectStore
+	docs  DocumentIngester
+	evals EvaluationEnqueuer
+	jobs  IdempotentJobs
+	opts  S3IngestOptions
+	now   func() time.Time
+	// settled holds the keys of pairs that need no further work: queued
+	// pairs that are kept in the bucket and pairs that failed validation.
+	// Replacing a document changes the pair's key.
+	settled map[string]struct{}
+}
+
+// NewS3IngestScheduler creates a scheduler. It returns nil when a dependency
+// is nil or the interval is not positive, which disables ingestion.
+func NewS3IngestScheduler(store ObjectStore, docs DocumentIngester, evals EvaluationEnqueuer, jobs IdempotentJobs, opts S3IngestOptions) *S3IngestScheduler {
+	if store == nil || docs == nil || evals == nil || jobs == nil || opts.Interval <= 0 {
+		return nil
+	}
+	return &S3IngestScheduler{store: store, docs: docs, evals: evals, jobs: jobs, opts: opts, now: time.Now, settled: map[string]struct{}{}}
+}
+
+// Run scans the bucket on start and then every interval until ctx is done.
+func (s *S3IngestScheduler) Run(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	s.scan(ctx)
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("s3 ingest scheduler stopping")
+			return
+		case <-ticker.C:
+			s.scan(ctx)
+		}
+	}
+}
+
+// s3Pair holds the documents of one candidate folder.
+type s3Pair struct {
+	candidate   string
+	cv, project *s3.Object
+}
+
+// key identifies the pair's current documents; it is used as the job's
+// idempotency key.
+func (p s3Pair) key() string {
+	var parts []string
+	for _, o := range []*s3.Object{p.cv, p.project} {
+		if o == nil {
+			parts = append(parts, "", "")
+			continue
+		}
+		parts = append(parts, o.Key, o.ETag)
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return "s3-" + hex.EncodeToString(sum[:16])
+}
+
+func (s *S3IngestScheduler) scan(ctx context.Context) {
+	objects, err := s.store.List(ctx, s.opts.Prefix)
+	if err != nil {
+		slog.Error("s3 ingest list failed", slog.Any("error", err))
+		return
+	}
+	pairs := groupS3Pairs(s.opts.Prefix, objects)
+	for _, p := range pairs {
+		if ctx.Err() != nil {
+			return
+		}
+		if p.cv == nil || p.project == nil {
+			lone := p.cv
+			if lone == nil {
+				lone = p.project
+			}
+			if s.now().Sub(lone.LastModified) < s.opts.PairWait {
+				continue
+			}
+		}
+		if _, ok := s.settled[p.key()]; ok {
+			continue
+		}
+		observability.RecordS3Ingestion(s.ingest(ctx, p))
+	}
+}
+
+// groupS3Pairs groups the cv and project documents under prefix by candidate
+// folder. Other keys are ignored; of two documents of the same kind the newer
+// one wins.
+func groupS3Pairs(prefix string, objects []s3.Object) []s3Pair {
+	byCandidate := map[string]*s3Pair{}
+	for i := range objects {
+		o := &objects[i]
+		candidate, file, ok := strings.Cut(strings.TrimPrefix(o.Key, prefix), "/")
+		if !ok || candidate == "" || strings.Contains(file, "/") {
+			continue
+		}
+		p := byCandidate[candidate]
+		if p == nil {
+			p = &s3Pair{candidate: candidate}
+			byCandidate[candidate] = p
+		}
+		switch strings.ToLower(strings.TrimSuffix(file, path.Ext(file))) {
+		case domain.UploadTypeCV:
+			if p.cv == nil || o.LastModified.After(p.cv.LastModified) {
+				p.cv = o
+			}
+		case domain.UploadTypeProject:
+			if p.project == nil || o.LastModified.After(p.project.LastModified) {
+				p.project = o
+			}
+		}
+	}
+	out := make([]s3Pair, 0, len(byCandidate))
+	for _, p := range byCandidate {
+		if p.cv != nil || p.project != nil {
+			out = append(out, *p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].candidate < out[j].candidate })
+	return out
+}
+
+// ingest queues the evaluation of p and returns the outcome: queued,
+// duplicate (queued by an earlier scan), rejected (invalid documents, not
+// retried) or failed (retried on the next scan).
+func (s *S3IngestScheduler) ingest(ctx context.Context, p s3Pair) string {
+	idemKey := p.key()
+	lg := slog.With(slog.String("candidate", p.candidate), slog.String("idempotency_key", idemKey))
+	if j, err := s.jobs.FindByIdempotencyKey(ctx, idemKey); err == nil && j.ID != "" {
+		s.settle(ctx, p, idemKey)
+		return "duplicate"
+	}
+	ids := map[string]string{}
+	for uploadType, o := range map[string]*s3.Object{domain.UploadTypeCV: p.cv, domain.UploadTypeProject: p.project} {
+		if o == nil {
+			continue
+		}
+		data, err := s.store.Get(ctx, o.Key, s.opts.MaxBytes)
+		if err == nil {
+			ids[uploadType], err = s.docs.IngestDocument(ctx, uploadType, path.Base(o.Key), data)
+		}
+		if err != nil {
+			return s.fail(lg, idemKey, o.Key, err)
+		}
+	}
+	jobID, err := s.evals.Enqueue(ctx, ids[domain.UploadTypeCV], ids[domain.UploadTypeProject],
+		config.GetDefaultJobDescription(), config.GetDefaultStudyCaseBrief(), "", idemKey)
+	if err != nil {
+		return s.fail(lg, idemKey, "", err)
+	}
+	lg.Info("s3 documents queued for evaluation", slog.String("job_id", jobID))
+	s.settle(ctx, p, idemKey)
+	return "queued"
+}
+
+func (s *S3IngestScheduler) fail(lg *slog.Logger, idemKey, objectKey string, err error) string {
+	if errors.Is(err, domain.ErrInvalidArgument) {
+		s.settled[idemKey] = struct{}{}
+		lg.Warn("s3 documents rejected", slog.String("key", objectKey), slog.Any("error", err))
+		return "rejected"
+	}
+	lg.Error("s3 ingest failed; retrying on the next scan", slog.String("key", objectKey), slog.Any("error", err))
+	return "failed"
+}
+
+// settle deletes the documents of a queued pair when configured to and
+// remembers the pair otherwise.
+func (s *S3IngestScheduler) settle(ctx context.Context, p s3Pair, idemKey string) {
+	if !s.opts.DeleteProcessed {
+		s.settled[idemKey] = struct{}{}
+		return
+	}
+	for _, o := range []*s3.Object{p.cv, p.project} {
+		if o == nil {
+			continue
+		}
+		if err := s.store.Delete(ctx, o.Key); err != nil {
+			slog.Warn("s3 ingest delete failed", slog.String("key", o.Key), slog.Any("error", err))
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/s3"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+type memObjectStore struct {
+	objects []s3.Object
+	data    map[string]string
+	deleted []string
+}
+
+func (m *memObjectStore) List(context.Context, string) ([]s3.Object, error) { return m.objects, nil }
+
+func (m *memObjectStore) Get(_ context.Context, key string, _ int64) ([]byte, error) {
+	return []byte(m.data[key]), nil
+}
+
+func (m *memObjectStore) Delete(_ context.Context, key string) error {
+	m.deleted = append(m.deleted, key)
+	return nil
+}
+
+type recordingIngester struct{ names []string }
+
+func (r *recordingIngester) IngestDocument(_ context.Context, uploadType, name string, data []byte) (string, error) {
+	if string(data) == "" {
+		return "", fmt.Errorf("%w: empty extracted text", domain.ErrInvalidArgument)
+	}
+	r.names = append(r.names, uploadType+":"+name)
+	return uploadType + "-" + string(data), nil
+}
+
+type recordingEnqueuer struct {
+	calls [][2]string
+	keys  map[string]string
+	err   error
+}
+
+func (e *recordingEnqueuer) Enqueue(_ domain.Context, cvID, projectID, _, _, _, idemKey string) (string, error) {
+	if e.err != nil {
+		return "", e.err
+	}
+	e.calls = append(e.calls, [2]string{cvID, projectID})
+	id := fmt.Sprintf("job-%d", len(e.calls))
+	e.keys[idemKey] = id
+	return id, nil
+}
+
+func (e *recordingEnqueuer) FindByIdempotencyKey(_ domain.Context, key string) (domain.Job, error) {
+	if id, ok := e.keys[key]; ok {
+		return domain.Job{ID: id}, nil
+	}
+	return domain.Job{}, domain.ErrNotFound
+}
+
+func TestS3IngestScheduler_Scan(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	fresh, old := now.Add(-time.Minute), now.Add(-time.Hour)
+	store := &memObjectStore{
+		objects: []s3.Object{
+			{Key: "incoming/jane/cv.pdf", ETag: "1", LastModified: fresh},
+			{Key: "incoming/jane/Project.txt", ETag: "2", LastModified: fresh},
+			{Key: "incoming/john/cv.txt", ETag: "3", LastModified: old},
+			{Key: "incoming/mary/cv.txt", ETag: "4", LastModified: fresh},
+			{Key: "incoming/empty/cv.txt", ETag: "5", LastModified: old},
+			{Key: "incoming/readme.txt", ETag: "6", LastModified: old},
+			{Key: "incoming/jane/notes.txt", ETag: "7", LastModified: old},
+		},
+		data: map[string]string{
+			"incoming/jane/cv.pdf":      "jane",
+			"incoming/jane/Project.txt": "jane",
+			"incoming/john/cv.txt":      "john",
+		},
+	}
+	docs := &recordingIngester{}
+	evals := &recordingEnqueuer{keys: map[string]string{}}
+	s := NewS3IngestScheduler(store, docs, evals, evals, S3IngestOptions{Prefix: "incoming/", Interval: time.Minute, PairWait: 10 * time.Minute, DeleteProcessed: true, MaxBytes: 1 << 20})
+	s.now = func() time.Time { return now }
+
+	s.scan(context.Background())
+	// jane is a complete pair, john's lone CV waited long enough, mary's did
+	// not and empty's CV is rejected.
+	assert.Equal(t, [][2]string{{"cv-jane", "project-jane"}, {"cv-john", ""}}, evals.calls)
+	assert.Contains(t, docs.names, "project:Project.txt")
+	assert.ElementsMatch(t, []string{"incoming/jane/cv.pdf", "incoming/jane/Project.txt", "incoming/john/cv.txt"}, store.deleted)
+
+	// Rejected pairs are not fetched again and queued ones are not queued twice.
+	store.deleted = nil
+	s.scan(context.Background())
+	assert.Len(t, evals.calls, 2)
+	assert.Len(t, store.deleted, 3)
+}
+
+func TestS3IngestScheduler_KeepsProcessedDocuments(t *testing.T) {
+	store := &memObjectStore{
+		objects: []s3.Object{{Key: "in/jane/cv.txt", ETag: "1"}},
+		data:    map[string]string{"in/jane/cv.txt": "jane"},
+	}
+	evals := &recordingEnqueuer{keys: map[string]string{}, err: errors.New("broker down")}
+	s := NewS3IngestScheduler(store, &recordingIngester{}, evals, evals, S3IngestOptions{Prefix: "in/", Interval: time.Minute})
+
+	// A failed enqueue is retried on the next scan.
+	s.scan(context.Background())
+	assert.Empty(t, evals.calls)
+	evals.err = nil
+	s.scan(context.Background())
+	s.scan(context.Background())
+	require.Len(t, evals.calls, 1)
+	assert.Empty(t, store.deleted)
+}
+
+func TestNewS3IngestScheduler_Disabled(t *testing.T) {
+	evals := &recordingEnqueuer{}
+	assert.Nil(t, NewS3IngestScheduler(nil, &recordingIngester{}, evals, evals, S3IngestOptions{Interval: time.Minute}))
+	assert.Nil(t, NewS3IngestScheduler(&memObjectStore{}, &recordingIngester{}, evals, evals, S3IngestOptions{}))
+}
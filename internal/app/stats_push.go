@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// UsageStatsCompiler compiles the anonymous usage stats of the deployment.
+type UsageStatsCompiler interface {
+	Compile(ctx domain.Context) (domain.UsageStats, error)
+}
+
+// UsageStatsPusher delivers usage stats to a collector.
+type UsageStatsPusher interface {
+	Push(ctx context.Context, s domain.UsageStats) error
+}
+
+// StatsPushScheduler periodically pushes usage stats.
+type StatsPushScheduler struct {
+	stats    UsageStatsCompiler
+	pusher   UsageStatsPusher
+	interval time.Duration
+}
+
+// NewStatsPushScheduler creates a scheduler. It returns nil when stats or
+// pusher is nil or interval is not positive, which disables pushing.
+func NewStatsPushScheduler(stats UsageStatsCompiler, pusher UsageStatsPusher, interval time.Duration) *StatsPushScheduler {
+	if stats == nil || pusher == nil || interval <= 0 {
+		return nil
+	}
+	return &StatsPushScheduler{stats: stats, pusher: pusher, interval: interval}
+}
+
+// Run pushes the stats every interval until ctx is done. The first push
+// happens after one interval so that restarts do not add pushes.
+func (s *StatsPushScheduler) Run(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("stats push scheduler stopping")
+			return
+		case <-ticker.C:
+			s.push(ctx)
+		}
+	}
+}
+
+func (s *StatsPushScheduler) push(ctx context.Context) {
+	st, err := s.stats.Compile(ctx)
+	if err != nil {
+		slog.Error("usage stats compile failed", slog.Any("error", err))
+		return
+	}
+	if err := s.pusher.Push(ctx, st); err != nil {
+		slog.Error("usage stats push failed", slog.Any("error", err))
+		return
+	}
+	slog.Info("usage stats pushed", slog.Int64("jobs", st.Jobs))
+}
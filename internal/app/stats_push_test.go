@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+type countingStats struct{ calls atomic.Int32 }
+
+func (s *countingStats) Compile(domain.Context) (domain.UsageStats, error) {
+	if s.calls.Add(1) == 1 {
+		return domain.UsageStats{}, errors.New("db down")
+	}
+	return domain.UsageStats{Jobs: 3}, nil
+}
+
+type countingPusher struct{ pushed atomic.Int32 }
+
+func (p *countingPusher) Push(_ context.Context, s domain.UsageStats) error {
+	p.pushed.Add(int32(s.Jobs))
+	return nil
+}
+
+func TestStatsPushScheduler_Run(t *testing.T) {
+	assert.Nil(t, NewStatsPushScheduler(nil, &countingPusher{}, time.Hour))
+	assert.Nil(t, NewStatsPushScheduler(&countingStats{}, nil, time.Hour))
+	assert.Nil(t, NewStatsPushScheduler(&countingStats{}, &countingPusher{}, 0))
+
+	pusher := &countingPusher{}
+	s := NewStatsPushScheduler(&countingStats{}, pusher, 5*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool { return pusher.pushed.Load() >= 3 }, time.Second, 5*time.Millisecond, "keeps running after a failed compile")
+	cancel()
+	<-done
+}
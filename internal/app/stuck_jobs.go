@@ -16,6 +16,7 @@ type StuckJobSweeper struct {
 	jobs             domain.JobRepository
 	maxProcessingAge time.Duration
 	interval         time.Duration
+	notifier         domain.Notifier
 }
 
 // NewStuckJobSweeper creates a new sweeper.
@@ -36,6 +37,14 @@ func NewStuckJobSweeper(jobs domain.JobRepository, maxProcessingAge, interval ti
 	}
 }
 
+// WithNotifier reports each sweep that fails jobs to n.
+func (s *StuckJobSweeper) WithNotifier(n domain.Notifier) *StuckJobSweeper {
+	if s != nil {
+		s.notifier = n
+	}
+	return s
+}
+
 // Run starts the sweeper loop.
 func (s *StuckJobSweeper) Run(ctx context.Context) {
 	if s == nil || s.jobs == nil {
@@ -119,4 +128,10 @@ func (s *StuckJobSweeper) sweepOnce(ctx context.Context) {
 		attribute.Int("jobs.total_checked", totalChecked),
 		attribute.Int("jobs.total_marked_failed", totalMarkedFailed),
 	)
+	if totalMarkedFailed > 0 && s.notifier != nil {
+		s.notifier.Notify(ctx, domain.Notification{
+			Event: domain.NotifySweeperAction,
+			Data:  map[string]any{"Count": totalMarkedFailed, "MaxAge": s.maxProcessingAge.String()},
+		})
+	}
 }
@@ -31,7 +31,12 @@ func (r *fakeJobRepo) UpdateStatus(_ context.Context, id string, status domain.J
 	}{id: id, status: status, msg: msg})
 	return nil
 }
-func (r *fakeJobRepo) Get(context.Context, string) (domain.Job, error) { return domain.Job{}, nil }
+func (r *fakeJobRepo) AddSecurityNote(context.Context, string, string) error   { return nil }
+func (r *fakeJobRepo) Get(context.Context, string) (domain.Job, error)         { return domain.Job{}, nil }
+func (r *fakeJobRepo) GetMany(context.Context, []string) ([]domain.Job, error) { return nil, nil }
+func (r *fakeJobRepo) Task(context.Context, string) (domain.EvaluateTaskPayload, error) {
+	return domain.EvaluateTaskPayload{}, domain.ErrNotFound
+}
 func (r *fakeJobRepo) FindByIdempotencyKey(context.Context, string) (domain.Job, error) {
 	return domain.Job{}, nil
 }
@@ -49,6 +54,12 @@ func (r *fakeJobRepo) ListWithFilters(context.Context, int, int, string, string)
 func (r *fakeJobRepo) CountWithFilters(context.Context, string, string) (int64, error) {
 	return int64(len(r.jobs)), nil
 }
+func (r *fakeJobRepo) ListByCursor(context.Context, domain.JobListFilter) ([]domain.Job, error) {
+	return r.jobs, nil
+}
+func (r *fakeJobRepo) CountByFilter(context.Context, domain.JobListFilter) (int64, error) {
+	return int64(len(r.jobs)), nil
+}
 func (r *fakeJobRepo) GetAverageProcessingTime(context.Context) (float64, error) {
 	return 0, nil
 }
@@ -104,6 +115,30 @@ func TestStuckJobSweeperSweepOnceMarksOldJobsFailed(t *testing.T) {
 	}
 }
 
+type recordingNotifier struct{ got []domain.Notification }
+
+func (n *recordingNotifier) Notify(_ domain.Context, ev domain.Notification) {
+	n.got = append(n.got, ev)
+}
+
+func TestStuckJobSweeperSweepOnceNotifies(t *testing.T) {
+	now := time.Now()
+	repo := &fakeJobRepo{jobs: []domain.Job{{ID: "old", Status: domain.JobProcessing, UpdatedAt: now.Add(-10 * time.Minute)}}}
+	n := &recordingNotifier{}
+	s := NewStuckJobSweeper(repo, 5*time.Minute, time.Minute).WithNotifier(n)
+
+	s.sweepOnce(context.Background())
+	if len(n.got) != 1 || n.got[0].Event != domain.NotifySweeperAction || n.got[0].Data["Count"] != 1 {
+		t.Fatalf("unexpected notifications: %+v", n.got)
+	}
+
+	repo.jobs = nil
+	s.sweepOnce(context.Background())
+	if len(n.got) != 1 {
+		t.Fatalf("sweeps that fail no jobs must not notify, got %+v", n.got)
+	}
+}
+
 func TestStuckJobSweeperRunStopsOnContextDone(t *testing.T) {
 	repo := &fakeJobRepo{}
 	s := NewStuckJobSweeper(repo, time.Minute, 10*time.Millisecond)
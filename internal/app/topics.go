@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/queue/redpanda"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+)
+
+// ReconcileTopics brings the evaluation topic in line with the configured
+// Kafka topic settings. Failures are logged; the topic keeps its settings.
+func ReconcileTopics(ctx context.Context, cfg config.Config) {
+	if !cfg.KafkaTopicReconcile {
+		return
+	}
+	want := redpanda.TopicSettings{
+		Partitions:      cfg.KafkaTopicPartitions,
+		Retention:       cfg.KafkaTopicRetention,
+		MaxMessageBytes: cfg.KafkaTopicMaxMessageBytes,
+		CleanupPolicy:   cfg.KafkaTopicCleanupPolicy,
+	}
+	if err := redpanda.ReconcileTopics(ctx, cfg.KafkaBrokers, want); err != nil {
+		slog.Warn("kafka topic reconciliation failed", slog.Any("error", err))
+	}
+}
@@ -0,0 +1,319 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/promptguard"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/ai/safety"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/mail"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/notify"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/queue/redpanda"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/repo/postgres"
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	intobs "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// WorkerDeps are the dependencies the background worker shares with the
+// rest of the process.
+type WorkerDeps struct {
+	Pool    postgres.PgxPool
+	AI      domain.AIClient
+	Qdrant  *qdrantcli.Client
+	KeyRing *ai.KeyRing
+	// Saturation reports when every chat model of AI is rate limited
+	// (optional).
+	Saturation usecase.AISaturationProbe
+}
+
+// NewMailer builds the outgoing mailer selected by MAIL_PROVIDER; it returns
+// nil when mail is disabled.
+func NewMailer(c config.MailConfig) (domain.Mailer, error) {
+	switch c.Provider {
+	case "":
+		return nil, nil
+	case config.MailProviderSMTP:
+		if c.SMTPHost == "" || c.From == "" {
+			return nil, fmt.Errorf("SMTP_HOST and MAIL_FROM are required for the smtp mail provider")
+		}
+		return mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     c.SMTPHost,
+			Port:     c.SMTPPort,
+			Username: c.SMTPUsername,
+			Password: c.SMTPPassword,
+			From:     c.From,
+		}), nil
+	case config.MailProviderSES:
+		if c.From == "" {
+			return nil, fmt.Errorf("MAIL_FROM is required for the ses mail provider")
+		}
+		return mail.NewSESMailer(mail.SESConfig{
+			Endpoint:        c.SESEndpoint,
+			Region:          c.SESRegion,
+			AccessKeyID:     c.SESAccessKeyID,
+			SecretAccessKey: c.SESSecretAccessKey,
+			From:            c.From,
+		}), nil
+	case config.MailProviderLog:
+		return mail.NewLogMailer(func(subject string, to []string, body string) {
+			slog.Info("email (log mail provider)", slog.String("subject", subject), slog.Any("to", to), slog.String("body", body))
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown MAIL_PROVIDER %q", c.Provider)
+	}
+}
+
+// StartWorker starts the evaluation consumer, the DLQ consumer and the
+// periodic worker jobs (stuck-job sweeper, failure spike monitor, bias audit
+// and scheduled reports). The returned function stops them all; it must be
+// called once.
+func StartWorker(ctx context.Context, cfg config.Config, deps WorkerDeps) (func(), error) {
+	ctx, cancel := context.WithCancel(ctx)
+	var closers []func()
+	stop := func() {
+		cancel()
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+
+	// Embedding, chat and CoT-cleaning calls have separate slots, so that a
+	// burst of one kind does not starve the others.
+	deps.AI = ai.NewConcurrencyLimitedClient(deps.AI, ai.ConcurrencyLimits{
+		Embed:    cfg.AIEmbedConcurrency,
+		Chat:     cfg.AIChatConcurrency,
+		CoTClean: cfg.AICoTCleanConcurrency,
+	})
+
+	// Repositories
+	jobRepo := postgres.NewJobRepo(deps.Pool)
+	upRepo := postgres.NewUploadRepo(deps.Pool)
+	resRepo := postgres.NewResultRepo(deps.Pool)
+
+	// Queue producer used for retry and DLQ flows within the worker. Use a
+	// transactional ID distinct from the HTTP server's producer to avoid
+	// transactional conflicts across processes.
+	// Payloads carry CV text, so they are encrypted on the broker when keys
+	// are configured.
+	payloadCipher, err := BuildPayloadCipher(cfg)
+	if err != nil {
+		stop()
+		return nil, fmt.Errorf("op=worker.payload_cipher: %w", err)
+	}
+	queueProducer, err := redpanda.NewProducerWithTransactionalID(cfg.KafkaBrokers, "ai-cv-evaluator-worker-producer")
+	if err != nil {
+		stop()
+		return nil, fmt.Errorf("op=worker.producer: %w", err)
+	}
+	queueProducer.WithPayloadCipher(payloadCipher)
+	closers = append(closers, func() {
+		if err := queueProducer.Close(); err != nil {
+			slog.Error("failed to close queue producer", slog.Any("error", err))
+		}
+	})
+
+	// Build retry configuration for the worker from env-configured values while
+	// reusing the domain-level retryable/non-retryable error taxonomy.
+	baseRetryCfg := domain.DefaultRetryConfig()
+	cfgRetry := cfg.GetRetryConfig()
+	retryCfg := domain.RetryConfig{
+		MaxRetries:         cfgRetry.MaxRetries,
+		InitialDelay:       cfgRetry.InitialDelay,
+		MaxDelay:           cfgRetry.MaxDelay,
+		Multiplier:         cfgRetry.Multiplier,
+		Jitter:             cfgRetry.Jitter,
+		RetryableErrors:    baseRetryCfg.RetryableErrors,
+		NonRetryableErrors: baseRetryCfg.NonRetryableErrors,
+	}
+
+	// Operational notifications to Slack/Teams webhooks. The port stays nil
+	// when notifications are disabled so consumers can skip them.
+	notifyCfg := cfg.GetNotifyConfig()
+	notifier, err := notify.New(notifyCfg)
+	if err != nil {
+		slog.Error("notification configuration invalid; notifications disabled", slog.Any("error", err))
+	}
+	var notifierPort domain.Notifier
+	if notifier != nil {
+		notifierPort = notifier
+		closers = append(closers, notifier.Wait)
+		intobs.SetCircuitOpenHook(func(connType intobs.ConnectionType, endpoint string) {
+			notifier.Notify(context.Background(), domain.Notification{
+				Event:   domain.NotifyProviderCircuitOpen,
+				Subject: string(connType) + "/" + endpoint,
+				Data:    map[string]any{"Endpoint": endpoint, "Connection": string(connType)},
+			})
+		})
+	}
+
+	retryManager := redpanda.NewRetryManager(queueProducer, queueProducer, jobRepo, retryCfg).
+		WithDLQAlert(notifierPort, notifyCfg.DLQThreshold, notifyCfg.DLQWindow)
+
+	// Worker (Redpanda consumer) with dynamic worker pool
+	// Use CONSUMER_MAX_CONCURRENCY as max workers, with higher min workers for better throughput
+	minWorkers := cfg.ConsumerMaxConcurrency / 2 // Start with half the max workers
+	if cfg.ConsumerMaxConcurrency <= 1 {
+		// Strict single-worker mode for free-tier safety
+		minWorkers = 1
+	} else if minWorkers < 4 {
+		minWorkers = 4 // Minimum 4 workers for reasonable throughput
+	}
+	maxWorkers := cfg.ConsumerMaxConcurrency
+	if maxWorkers < minWorkers {
+		maxWorkers = minWorkers
+	}
+
+	slog.Info("worker scaling configuration",
+		slog.Int("min_workers", minWorkers),
+		slog.Int("max_workers", maxWorkers),
+		slog.Duration("scaling_interval", cfg.WorkerScalingInterval),
+		slog.Duration("idle_timeout", cfg.WorkerIdleTimeout))
+
+	worker, err := redpanda.NewConsumerWithConfig(
+		cfg.KafkaBrokers,
+		"ai-cv-evaluator-workers",  // Consumer group ID
+		"ai-cv-evaluator-consumer", // Transactional ID
+		jobRepo,
+		upRepo,
+		resRepo,
+		deps.AI,
+		deps.Qdrant,
+		minWorkers,
+		maxWorkers,
+	)
+	if err != nil {
+		stop()
+		return nil, fmt.Errorf("op=worker.consumer: %w", err)
+	}
+	// Attach retry manager so that upstream rate-limit and timeout failures are
+	// routed through the retry/DLQ flow instead of leaving jobs permanently
+	// failed.
+	worker.WithRetryManager(retryManager)
+	worker.WithPromptGuard(promptguard.New(cfg.PromptInjectionMode))
+	worker.WithSafetyFilter(safety.New(cfg.OutputSafetyFilter))
+	worker.WithJDLanguage(cfg.JDLanguageMode)
+	worker.WithQuarantine(postgres.NewQuarantineRepo(deps.Pool), cfg.QueuePoisonMaxAttempts)
+	worker.WithPayloadCipher(payloadCipher)
+	if n := usecase.NewScoreNormalizer(postgres.NewScoreStatsRepo(deps.Pool), cfg.ScoreNormalization, cfg.ScoreNormalizationMinSamples); n != nil {
+		worker.WithScoreNormalizer(n)
+	}
+	if cfg.EvaluationCheckpoints {
+		worker.WithCheckpoints(postgres.NewCheckpointRepo(deps.Pool))
+	}
+	worker.WithJobLocks(postgres.NewJobLockRepo(deps.Pool), cfg.JobLockTTL)
+	worker.WithTenantQuota(postgres.NewTenantQuotaRepo(deps.Pool))
+	worker.WithJobUsage(postgres.NewJobUsageRepo(deps.Pool))
+	worker.WithPromptExperiments(postgres.NewPromptExperimentRepo(deps.Pool))
+	worker.WithEvaluationSLA(cfg.EvaluationSLA)
+	worker.WithRAGCache(redpanda.NewRAGCache(cfg.RAGCacheTTL))
+	worker.WithFastPath(redpanda.FastPathPolicy{
+		QueueLag:        cfg.FastPathQueueLag,
+		ProviderLatency: cfg.FastPathProviderLatency,
+		Latency:         redpanda.NewLatencyTracker(),
+	})
+	// Operators pause topics through the admin API, e.g. during provider
+	// incidents; paused consumers stop polling but keep their pools.
+	pauses := BuildConsumerPauses(ctx, cfg, deps.Pool)
+	worker.WithPauses(pauses)
+	worker.WithModelChallenger(redpanda.ModelChallenger{
+		Provider: cfg.ModelChallengerProvider,
+		Model:    cfg.ModelChallenger,
+		Traffic:  cfg.ModelChallengerTraffic,
+	})
+	if cfg.SimilarityDetection && deps.Qdrant != nil {
+		ensureSubmissionsCollection(ctx, deps.Qdrant, cfg.GetQdrantCollectionConfig())
+		worker.WithSimilarity(redpanda.NewSimilarityIndex(deps.AI, deps.Qdrant, cfg.SimilarityThreshold, cfg.SimilarityTopK))
+	}
+	closers = append(closers, func() {
+		if err := worker.Close(); err != nil {
+			slog.Error("failed to close worker", slog.Any("error", err))
+		}
+	})
+
+	sweeperMaxProcessingAge := 10 * time.Minute
+	if v := os.Getenv("E2E_AI_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			sweeperMaxProcessingAge = d + time.Minute
+		}
+	}
+
+	// DLQ consumer to process failed jobs and apply cooling behavior before
+	// requeueing. This runs alongside the main worker.
+	dlqConsumer, err := redpanda.NewDLQConsumer(cfg.KafkaBrokers, "ai-cv-evaluator-dlq-workers", retryManager, jobRepo)
+	if err != nil {
+		stop()
+		return nil, fmt.Errorf("op=worker.dlq_consumer: %w", err)
+	}
+	dlqConsumer.WithPauses(pauses)
+	dlqConsumer.WithPayloadCipher(payloadCipher)
+	closers = append(closers, dlqConsumer.Stop)
+	if err := dlqConsumer.Start(ctx); err != nil {
+		slog.Error("DLQ consumer start error", slog.Any("error", err))
+	}
+
+	// Start stuck-job sweeper to ensure long-running processing jobs eventually
+	// transition to a failed terminal state even if the original worker handling
+	// them crashes or is interrupted.
+	if sweeper := NewStuckJobSweeper(jobRepo, sweeperMaxProcessingAge, 0); sweeper != nil {
+		go sweeper.WithNotifier(notifierPort).Run(ctx)
+	}
+
+	// Alert when failed jobs across all workers spike within the window.
+	if monitor := NewFailureSpikeMonitor(jobRepo, notifierPort, notifyCfg.FailureSpikeThreshold, notifyCfg.FailureSpikeWindow); monitor != nil {
+		go monitor.Run(ctx)
+	}
+
+	// The API fails evaluations fast while every worker reports all of its
+	// AI models rate limited.
+	if cfg.AISaturationFailFast && deps.Saturation != nil {
+		host, _ := os.Hostname()
+		saturation := usecase.NewAISaturationService(postgres.NewAISaturationRepo(deps.Pool))
+		go saturation.Run(ctx, fmt.Sprintf("%s/%d", host, os.Getpid()), deps.Saturation)
+	}
+
+	// Periodic bias/drift audit of evaluation scores, served by the admin API.
+	biasAudit := usecase.NewBiasAuditService(postgres.NewBiasAuditRepo(deps.Pool), cfg.BiasAuditWindow, cfg.BiasAuditMinSegment)
+	if scheduler := NewBiasAuditScheduler(biasAudit, cfg.BiasAuditInterval); scheduler != nil {
+		go scheduler.Run(ctx)
+	}
+
+	// Anonymous usage stats pushed to a fleet-wide collector (opt-in).
+	if cfg.StatsEnabled && cfg.StatsPushURL != "" {
+		stats := usecase.NewUsageStatsService(postgres.NewReportRepo(deps.Pool), cfg.StatsWindow, cfg.StatsDeploymentID)
+		if scheduler := NewStatsPushScheduler(stats, notify.NewStatsPusher(cfg.StatsPushURL), cfg.StatsPushInterval); scheduler != nil {
+			go scheduler.Run(ctx)
+		}
+	}
+
+	// Scheduled activity reports emailed per recipient.
+	if specs := cfg.GetReportSchedules(); len(specs) > 0 {
+		mailer, err := NewMailer(cfg.GetMailConfig())
+		switch {
+		case err != nil:
+			slog.Error("mailer configuration invalid; scheduled reports disabled", slog.Any("error", err))
+		case mailer == nil:
+			slog.Warn("REPORT_SCHEDULES set but MAIL_PROVIDER is empty; scheduled reports disabled")
+		default:
+			reports := usecase.NewReportService(postgres.NewReportRepo(deps.Pool), mailer, deps.KeyRing.Provider, cfg.GetReportProviderCosts())
+			if scheduler := NewReportScheduler(reports, specs); scheduler != nil {
+				go scheduler.Run(ctx)
+			}
+		}
+	}
+
+	// Start worker in background
+	slog.Info("starting redpanda consumer")
+	go func() {
+		if err := worker.Start(ctx); err != nil {
+			slog.Error("worker error", slog.Any("error", err))
+		}
+	}()
+	return stop, nil
+}
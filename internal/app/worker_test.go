@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/mail"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+)
+
+func TestNewMailer(t *testing.T) {
+	if m, err := NewMailer(config.MailConfig{}); m != nil || err != nil {
+		t.Fatalf("empty provider must disable mail: %v %v", m, err)
+	}
+	if _, err := NewMailer(config.MailConfig{Provider: config.MailProviderSMTP, From: "ops@example.com"}); err == nil {
+		t.Fatalf("smtp without host must fail")
+	}
+	if _, err := NewMailer(config.MailConfig{Provider: config.MailProviderSES}); err == nil {
+		t.Fatalf("ses without sender must fail")
+	}
+	if _, err := NewMailer(config.MailConfig{Provider: "pigeon"}); err == nil {
+		t.Fatalf("unknown provider must fail")
+	}
+	m, err := NewMailer(config.MailConfig{Provider: config.MailProviderLog})
+	if err != nil {
+		t.Fatalf("log mailer: %v", err)
+	}
+	if _, ok := m.(*mail.LogMailer); !ok {
+		t.Fatalf("unexpected mailer %T", m)
+	}
+}
+
+func TestStartWorker_FailsWithoutBrokers(t *testing.T) {
+	stop, err := StartWorker(context.Background(), config.Config{}, WorkerDeps{})
+	if err == nil || stop != nil {
+		t.Fatalf("expected producer error without brokers, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Package config defines partition archival configuration.
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// Archive sink kinds accepted by ARCHIVE_SINK.
+const (
+	ArchiveSinkFile = "file"
+	ArchiveSinkS3   = "s3"
+)
+
+// ArchiveConfig holds partition maintenance and cold-storage archival settings.
+type ArchiveConfig struct {
+	// PremakeMonths is how many future monthly partitions are kept created
+	PremakeMonths int
+	// MaintenanceInterval is how often upcoming partitions are (re)created
+	MaintenanceInterval time.Duration
+	// Enabled switches retention from row deletes to partition archival
+	Enabled bool
+	// Sink selects the archive destination (file or s3)
+	Sink string
+	// Dir is the destination directory for the file sink
+	Dir string
+	// S3 settings for the s3 sink
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3Prefix          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+}
+
+// GetArchiveConfig returns the partition archival configuration
+func (c Config) GetArchiveConfig() ArchiveConfig {
+	premake := c.PartitionPremakeMonths
+	if premake < 1 {
+		premake = 1
+	}
+	interval := c.PartitionMaintenanceInterval
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+	return ArchiveConfig{
+		PremakeMonths:       premake,
+		MaintenanceInterval: interval,
+		Enabled:             c.ArchiveEnabled,
+		Sink:                strings.ToLower(strings.TrimSpace(c.ArchiveSink)),
+		Dir:                 c.ArchiveDir,
+		S3Endpoint:          c.ArchiveS3Endpoint,
+		S3Region:            c.ArchiveS3Region,
+		S3Bucket:            c.ArchiveS3Bucket,
+		S3Prefix:            c.ArchiveS3Prefix,
+		S3AccessKeyID:       c.ArchiveS3AccessKeyID,
+		S3SecretAccessKey:   c.ArchiveS3SecretAccessKey,
+	}
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_GetArchiveConfig_Defaults(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	ac := cfg.GetArchiveConfig()
+	if ac.Enabled || ac.Sink != ArchiveSinkFile || ac.PremakeMonths != 3 || ac.MaintenanceInterval != 24*time.Hour {
+		t.Fatalf("unexpected defaults: %+v", ac)
+	}
+}
+
+func TestConfig_GetArchiveConfig_FromEnv(t *testing.T) {
+	t.Setenv("ARCHIVE_ENABLED", "true")
+	t.Setenv("ARCHIVE_SINK", " S3 ")
+	t.Setenv("ARCHIVE_S3_BUCKET", "cold")
+	t.Setenv("PARTITION_PREMAKE_MONTHS", "0")
+	t.Setenv("PARTITION_MAINTENANCE_INTERVAL", "6h")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	ac := cfg.GetArchiveConfig()
+	if !ac.Enabled || ac.Sink != ArchiveSinkS3 || ac.S3Bucket != "cold" {
+		t.Fatalf("unexpected config: %+v", ac)
+	}
+	if ac.PremakeMonths != 1 {
+		t.Fatalf("premake months = %d, want clamped to 1", ac.PremakeMonths)
+	}
+	if ac.MaintenanceInterval != 6*time.Hour {
+		t.Fatalf("maintenance interval = %s, want 6h", ac.MaintenanceInterval)
+	}
+}
@@ -24,6 +24,13 @@ type Config struct {
 	// per process. Default is tuned for real-world usage and E2E tests without
 	// requiring manual environment overrides.
 	OpenRouterMinInterval time.Duration `env:"OPENROUTER_MIN_INTERVAL" envDefault:"1s"`
+	// OpenRouter model switching gives each model attempt
+	// AI_MODEL_ATTEMPT_TIMEOUT (0 = 60s, 120s with APP_ENV=dev) before moving
+	// on to the next attempt. A timed-out attempt is canceled unless
+	// AI_LATE_RESULT_GRACE is set: it then keeps running that much longer and
+	// its result is used if it arrives before a later attempt's
+	AIModelAttemptTimeout time.Duration `env:"AI_MODEL_ATTEMPT_TIMEOUT" envDefault:"0"`
+	AILateResultGrace     time.Duration `env:"AI_LATE_RESULT_GRACE" envDefault:"0"`
 	// FreeModelsRefresh: how often to refresh the list of available free models
 	FreeModelsRefresh time.Duration `env:"FREE_MODELS_REFRESH" envDefault:"1h"`
 	OpenAIAPIKey      string        `env:"OPENAI_API_KEY"`
@@ -51,27 +58,63 @@ type Config struct {
 	// Valid values: Strict, Lax, None. Defaults to Strict.
 	AdminSessionSameSite  string        `env:"ADMIN_SESSION_SAMESITE" envDefault:"Strict"`
 	MaxUploadMB           int64         `env:"MAX_UPLOAD_MB" envDefault:"10"`
+	BulkSubmitMaxRows     int           `env:"BULK_SUBMIT_MAX_ROWS" envDefault:"500"` // rows per CSV bulk submission
+	BulkJobMaxJobs        int           `env:"BULK_JOB_MAX_JOBS" envDefault:"5000"`   // jobs per admin bulk requeue/cancel/fail operation
+	UploadQualityGate     bool          `env:"UPLOAD_QUALITY_GATE" envDefault:"true"` // reject scanned, garbled or repetitive extractions
+	UploadMinChars        int           `env:"UPLOAD_MIN_CHARS" envDefault:"100"`     // shortest extracted text accepted by the quality gate
 	CORSAllowOrigins      string        `env:"CORS_ALLOW_ORIGINS" envDefault:"*"`
 	RateLimitPerMin       int           `env:"RATE_LIMIT_PER_MIN" envDefault:"30"`
 	ServerShutdownTimeout time.Duration `env:"SERVER_SHUTDOWN_TIMEOUT" envDefault:"30s"`
+	ServerDrainDelay      time.Duration `env:"SERVER_DRAIN_DELAY" envDefault:"0s"` // serve with failing /readyz before closing listeners
 	HTTPReadTimeout       time.Duration `env:"HTTP_READ_TIMEOUT" envDefault:"15s"`
 	HTTPWriteTimeout      time.Duration `env:"HTTP_WRITE_TIMEOUT" envDefault:"30s"`
 	HTTPIdleTimeout       time.Duration `env:"HTTP_IDLE_TIMEOUT" envDefault:"60s"`
 	DataRetentionDays     int           `env:"DATA_RETENTION_DAYS" envDefault:"90"`
 	CleanupInterval       time.Duration `env:"CLEANUP_INTERVAL" envDefault:"24h"`
+	// HTTP response compression: gzip, deflate or zstd negotiated from
+	// Accept-Encoding for JSON responses of HTTPCompressionRoutes (chi route
+	// patterns; "/prefix/*" and "*" match more than one route).
+	HTTPCompressionEnabled  bool     `env:"HTTP_COMPRESSION_ENABLED" envDefault:"true"`
+	HTTPCompressionMinBytes int      `env:"HTTP_COMPRESSION_MIN_BYTES" envDefault:"1024"`
+	HTTPCompressionRoutes   []string `env:"HTTP_COMPRESSION_ROUTES" envSeparator:"," envDefault:"/v1/result/{id},/v1/result/{id}/diff,/v1/results,/admin/api/*"`
+	// AITemperature is the sampling temperature of evaluation chat calls;
+	// requests and tenants may override it.
+	AITemperature float64 `env:"AI_TEMPERATURE" envDefault:"0.2"`
 	// AIWorkerReplicas approximates the number of worker processes that will be
 	// issuing Groq/OpenRouter requests. Provider-level client throttling scales
 	// its minimal call interval by this factor so that aggregate QPS across all
 	// workers stays within free-tier limits.
 	AIWorkerReplicas int `env:"AI_WORKER_REPLICAS" envDefault:"1"`
+	// Worker AI calls of each kind (embeddings, chat, CoT cleaning) run at
+	// most this many at a time per process, so that a burst of one kind
+	// cannot take the slots of another; 0 = unlimited
+	AIEmbedConcurrency    int `env:"AI_EMBED_CONCURRENCY" envDefault:"0"`
+	AIChatConcurrency     int `env:"AI_CHAT_CONCURRENCY" envDefault:"0"`
+	AICoTCleanConcurrency int `env:"AI_COT_CLEAN_CONCURRENCY" envDefault:"0"`
 	// AI Backoff Configuration (defaults tuned for real-world usage and E2E
 	// tests to avoid excessively long retries while still allowing resilience).
 	AIBackoffMaxElapsedTime  time.Duration `env:"AI_BACKOFF_MAX_ELAPSED_TIME" envDefault:"30s"`
 	AIBackoffInitialInterval time.Duration `env:"AI_BACKOFF_INITIAL_INTERVAL" envDefault:"1s"`
 	AIBackoffMaxInterval     time.Duration `env:"AI_BACKOFF_MAX_INTERVAL" envDefault:"5s"`
 	AIBackoffMultiplier      float64       `env:"AI_BACKOFF_MULTIPLIER" envDefault:"1.5"`
+	// Evaluation topic settings reconciled at startup when
+	// KAFKA_TOPIC_RECONCILE is set; 0 or empty leaves a setting unmanaged and
+	// partitions are only ever added
+	KafkaTopicReconcile       bool          `env:"KAFKA_TOPIC_RECONCILE" envDefault:"true"`
+	KafkaTopicPartitions      int32         `env:"KAFKA_TOPIC_PARTITIONS" envDefault:"8"`
+	KafkaTopicRetention       time.Duration `env:"KAFKA_TOPIC_RETENTION" envDefault:"168h"`
+	KafkaTopicMaxMessageBytes int64         `env:"KAFKA_TOPIC_MAX_MESSAGE_BYTES" envDefault:"1000012"`
+	KafkaTopicCleanupPolicy   string        `env:"KAFKA_TOPIC_CLEANUP_POLICY" envDefault:"delete"`
 	// Queue Consumer Configuration
 	ConsumerMaxConcurrency int `env:"CONSUMER_MAX_CONCURRENCY" envDefault:"1"`
+	// Records that fail to decode are captured in queue_quarantine and
+	// skipped after this many failed deliveries
+	QueuePoisonMaxAttempts int `env:"QUEUE_POISON_MAX_ATTEMPTS" envDefault:"3"`
+	// Queue payloads are encrypted with AES-256-GCM under the key named by
+	// QUEUE_ENCRYPTION_KEY_ID when set; QUEUE_ENCRYPTION_KEYS lists every key
+	// id:base64key that consumers accept (see GetQueueEncryption)
+	QueueEncryptionKeys  string `env:"QUEUE_ENCRYPTION_KEYS" envDefault:""`
+	QueueEncryptionKeyID string `env:"QUEUE_ENCRYPTION_KEY_ID" envDefault:""`
 	// Worker Scaling Configuration
 	WorkerScalingInterval time.Duration `env:"WORKER_SCALING_INTERVAL" envDefault:"2s"`
 	WorkerIdleTimeout     time.Duration `env:"WORKER_IDLE_TIMEOUT" envDefault:"30s"`
@@ -84,8 +127,409 @@ type Config struct {
 	// DLQ Configuration (DLQ always enabled)
 	DLQMaxAge          time.Duration `env:"DLQ_MAX_AGE" envDefault:"168h"`
 	DLQCleanupInterval time.Duration `env:"DLQ_CLEANUP_INTERVAL" envDefault:"24h"`
+	// Database pool tuning. Defaults match the previously hardcoded pool
+	// settings; DBQueryExecMode accepts pgx mode names (cache_statement,
+	// cache_describe, describe_exec, exec, simple_protocol).
+	DBMaxConns               int32         `env:"DB_MAX_CONNS" envDefault:"10"`
+	DBMinConns               int32         `env:"DB_MIN_CONNS" envDefault:"0"`
+	DBMaxConnLifetime        time.Duration `env:"DB_MAX_CONN_LIFETIME" envDefault:"1h"`
+	DBMaxConnIdleTime        time.Duration `env:"DB_MAX_CONN_IDLE_TIME" envDefault:"5m"`
+	DBHealthCheckPeriod      time.Duration `env:"DB_HEALTH_CHECK_PERIOD" envDefault:"1m"`
+	DBStatementCacheCapacity int           `env:"DB_STATEMENT_CACHE_CAPACITY" envDefault:"512"`
+	DBQueryExecMode          string        `env:"DB_QUERY_EXEC_MODE" envDefault:"cache_statement"`
+	// Database TLS and IAM authentication. DBSSLMode and DBSSLRootCert
+	// override sslmode/sslrootcert in DB_URL; use verify-full with the
+	// provider's CA bundle for managed databases. DBIAMAuth (aws or gcp)
+	// replaces the DB_URL password with a short-lived IAM token, refreshed
+	// before it expires. aws signs RDS tokens with the DB_IAM_AWS_*
+	// credentials (falling back to the standard AWS_* variables); gcp uses
+	// the instance service account from the metadata server.
+	DBSSLMode               string `env:"DB_SSL_MODE" envDefault:""`
+	DBSSLRootCert           string `env:"DB_SSL_ROOT_CERT" envDefault:""`
+	DBIAMAuth               string `env:"DB_IAM_AUTH" envDefault:""`
+	DBIAMAWSRegion          string `env:"DB_IAM_AWS_REGION" envDefault:""`
+	DBIAMAWSAccessKeyID     string `env:"DB_IAM_AWS_ACCESS_KEY_ID" envDefault:""`
+	DBIAMAWSSecretAccessKey string `env:"DB_IAM_AWS_SECRET_ACCESS_KEY" envDefault:""`
+	DBIAMAWSSessionToken    string `env:"DB_IAM_AWS_SESSION_TOKEN" envDefault:""`
+	// Partition maintenance and archival. When ArchiveEnabled is set,
+	// retention exports expired monthly partitions of jobs/results to the
+	// configured sink (file or s3) and drops them instead of deleting rows.
+	PartitionPremakeMonths       int           `env:"PARTITION_PREMAKE_MONTHS" envDefault:"3"`
+	PartitionMaintenanceInterval time.Duration `env:"PARTITION_MAINTENANCE_INTERVAL" envDefault:"24h"`
+	ArchiveEnabled               bool          `env:"ARCHIVE_ENABLED" envDefault:"false"`
+	ArchiveSink                  string        `env:"ARCHIVE_SINK" envDefault:"file"`
+	ArchiveDir                   string        `env:"ARCHIVE_DIR" envDefault:"/var/lib/ai-cv-evaluator/archive"`
+	ArchiveS3Endpoint            string        `env:"ARCHIVE_S3_ENDPOINT" envDefault:""`
+	ArchiveS3Region              string        `env:"ARCHIVE_S3_REGION" envDefault:"us-east-1"`
+	ArchiveS3Bucket              string        `env:"ARCHIVE_S3_BUCKET" envDefault:""`
+	ArchiveS3Prefix              string        `env:"ARCHIVE_S3_PREFIX" envDefault:"ai-cv-evaluator"`
+	ArchiveS3AccessKeyID         string        `env:"ARCHIVE_S3_ACCESS_KEY_ID" envDefault:""`
+	ArchiveS3SecretAccessKey     string        `env:"ARCHIVE_S3_SECRET_ACCESS_KEY" envDefault:""`
+
+	// Embedding cache persistence and eviction
+	EmbedCacheTTL          time.Duration `env:"EMBED_CACHE_TTL" envDefault:"168h"`
+	EmbedCacheMaxBytes     int64         `env:"EMBED_CACHE_MAX_BYTES" envDefault:"67108864"`
+	EmbedCacheRedisEnabled bool          `env:"EMBED_CACHE_REDIS_ENABLED" envDefault:"false"`
+	EmbedCacheRedisPrefix  string        `env:"EMBED_CACHE_REDIS_PREFIX" envDefault:"embedcache"`
+
+	// Qdrant upsert batching and retry
+	QdrantUpsertBatchSize  int `env:"QDRANT_UPSERT_BATCH_SIZE" envDefault:"64"`
+	QdrantUpsertMaxRetries int `env:"QDRANT_UPSERT_MAX_RETRIES" envDefault:"3"`
+
+	// Qdrant collection layout: vector size, optional named vectors for
+	// hosting several embedding models, payload indexes, and recreation guard
+	QdrantVectorSize         int    `env:"QDRANT_VECTOR_SIZE" envDefault:"1536"`
+	QdrantVectorName         string `env:"QDRANT_VECTOR_NAME" envDefault:""`
+	QdrantNamedVectors       string `env:"QDRANT_NAMED_VECTORS" envDefault:""`
+	QdrantPayloadIndexes     string `env:"QDRANT_PAYLOAD_INDEXES" envDefault:"source:keyword,type:keyword,section:keyword,doc_type:keyword,posting_id:keyword"`
+	QdrantRecreateOnMismatch bool   `env:"QDRANT_RECREATE_ON_MISMATCH" envDefault:"false"`
+	// QdrantNamespace prefixes every collection and alias name so several
+	// environments or tenants can share one Qdrant instance; "{env}" is
+	// replaced with APP_ENV (e.g. "{env}" or "acme-{env}"). Empty keeps the
+	// unprefixed names.
+	QdrantNamespace string `env:"QDRANT_NAMESPACE" envDefault:""`
+	// Qdrant snapshots of the RAG collections. The server snapshots
+	// QDRANT_SNAPSHOT_COLLECTIONS every QDRANT_SNAPSHOT_INTERVAL (0 disables
+	// the schedule; admins can still snapshot on demand) and keeps the newest
+	// QDRANT_SNAPSHOT_KEEP in Qdrant. With QDRANT_SNAPSHOT_UPLOAD each snapshot
+	// is also copied to the ARCHIVE_SINK object storage.
+	QdrantSnapshotCollections string        `env:"QDRANT_SNAPSHOT_COLLECTIONS" envDefault:"job_description,scoring_rubric"`
+	QdrantSnapshotInterval    time.Duration `env:"QDRANT_SNAPSHOT_INTERVAL" envDefault:"0s"`
+	QdrantSnapshotKeep        int           `env:"QDRANT_SNAPSHOT_KEEP" envDefault:"3"`
+	QdrantSnapshotUpload      bool          `env:"QDRANT_SNAPSHOT_UPLOAD" envDefault:"false"`
+	// Workers cache the RAG context retrieved for a posting (job description,
+	// study case and rubric) for RAG_CACHE_TTL, so later evaluations against
+	// it skip the embedding call and the Qdrant searches. The cached context
+	// is retrieved without the candidate's text. 0 disables the cache.
+	RAGCacheTTL time.Duration `env:"RAG_CACHE_TTL" envDefault:"0s"`
+
+	// Provider API key pools: comma-separated secret[:weight[:state]] entries,
+	// merged with the legacy *_API_KEY/*_API_KEY_2 variables
+	OpenRouterAPIKeys     string        `env:"OPENROUTER_API_KEYS" envDefault:""`
+	GroqAPIKeys           string        `env:"GROQ_API_KEYS" envDefault:""`
+	ProviderKeySyncPeriod time.Duration `env:"PROVIDER_KEY_SYNC_PERIOD" envDefault:"30s"`
+	// Secrets of keys added through the admin API are stored in
+	// ai_provider_keys encrypted with AES-256-GCM under the key named by
+	// AI_PROVIDER_KEY_ENCRYPTION_KEY_ID; AI_PROVIDER_KEY_ENCRYPTION_KEYS lists
+	// every id:base64key that opens stored secrets (see
+	// GetProviderKeyEncryption). Without them keys cannot be added at runtime
+	AIProviderKeyEncryptionKeys  string `env:"AI_PROVIDER_KEY_ENCRYPTION_KEYS" envDefault:""`
+	AIProviderKeyEncryptionKeyID string `env:"AI_PROVIDER_KEY_ENCRYPTION_KEY_ID" envDefault:""`
+
+	// Daily budget per provider key (UTC day); 0 means unlimited. A key that
+	// reaches its budget is skipped until the next day
+	OpenRouterKeyDailyRequests int64 `env:"OPENROUTER_KEY_DAILY_REQUESTS" envDefault:"0"`
+	OpenRouterKeyDailyTokens   int64 `env:"OPENROUTER_KEY_DAILY_TOKENS" envDefault:"0"`
+	GroqKeyDailyRequests       int64 `env:"GROQ_KEY_DAILY_REQUESTS" envDefault:"0"`
+	GroqKeyDailyTokens         int64 `env:"GROQ_KEY_DAILY_TOKENS" envDefault:"0"`
+	// Weight keys and providers by the share of their daily budget left
+	// instead of trying Groq before OpenRouter
+	AIProviderBalancing bool `env:"AI_PROVIDER_BALANCING" envDefault:"true"`
+
+	// Paid OpenRouter models used when every free model is rate limited.
+	// Price cap is USD per 1K prompt+1K completion tokens (0 = no cap); the
+	// model list is a comma-separated whitelist (empty = any paid model)
+	PaidFallbackEnabled       bool    `env:"PAID_FALLBACK_ENABLED" envDefault:"true"`
+	PaidFallbackMaxPricePer1K float64 `env:"PAID_FALLBACK_MAX_PRICE_PER_1K" envDefault:"0"`
+	PaidFallbackModels        string  `env:"PAID_FALLBACK_MODELS" envDefault:""`
+	PaidFallbackRequireOptIn  bool    `env:"PAID_FALLBACK_REQUIRE_OPT_IN" envDefault:"false"`
+
+	// What to do when OpenRouter serves a call with a model outside the
+	// requested model's family: "warn" records the substitution, "reject"
+	// also fails the call when the substitute is not a free model
+	AIModelSubstitutionPolicy string `env:"AI_MODEL_SUBSTITUTION_POLICY" envDefault:"warn"`
+
+	// OpenRouter provider routing sent with every chat call: upstream
+	// providers to try first and to never use (comma-separated), whether
+	// other providers may serve a call, "deny" to exclude providers that
+	// store prompts, and whether providers must support every parameter
+	OpenRouterProviderOrder             string `env:"OPENROUTER_PROVIDER_ORDER" envDefault:""`
+	OpenRouterProviderIgnore            string `env:"OPENROUTER_PROVIDER_IGNORE" envDefault:""`
+	OpenRouterProviderAllowFallbacks    bool   `env:"OPENROUTER_PROVIDER_ALLOW_FALLBACKS" envDefault:"true"`
+	OpenRouterProviderDataCollection    string `env:"OPENROUTER_PROVIDER_DATA_COLLECTION" envDefault:"allow"`
+	OpenRouterProviderRequireParameters bool   `env:"OPENROUTER_PROVIDER_REQUIRE_PARAMETERS" envDefault:"false"`
+
+	// HTTP transport of the AI providers: idle connections kept per
+	// provider, dial and TLS handshake timeouts, HTTP/2 and a proxy URL
+	// (empty uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY). OPENROUTER_HTTP,
+	// GROQ_HTTP and OPENAI_HTTP override them per provider with
+	// comma-separated key=value entries, e.g. "max_idle_conns=200,http2=false"
+	AIHTTPMaxIdleConns        int           `env:"AI_HTTP_MAX_IDLE_CONNS" envDefault:"100"`
+	AIHTTPDialTimeout         time.Duration `env:"AI_HTTP_DIAL_TIMEOUT" envDefault:"30s"`
+	AIHTTPTLSHandshakeTimeout time.Duration `env:"AI_HTTP_TLS_HANDSHAKE_TIMEOUT" envDefault:"10s"`
+	AIHTTP2                   bool          `env:"AI_HTTP2" envDefault:"true"`
+	AIHTTPProxy               string        `env:"AI_HTTP_PROXY" envDefault:""`
+	// AI_HTTP_EVICT_FAILURES failed requests to a provider host within
+	// AI_HTTP_EVICT_WINDOW close its idle keep-alive connections (0 disables)
+	AIHTTPEvictFailures int           `env:"AI_HTTP_EVICT_FAILURES" envDefault:"3"`
+	AIHTTPEvictWindow   time.Duration `env:"AI_HTTP_EVICT_WINDOW" envDefault:"30s"`
+	OpenRouterHTTP      string        `env:"OPENROUTER_HTTP" envDefault:""`
+	GroqHTTP            string        `env:"GROQ_HTTP" envDefault:""`
+	OpenAIHTTP          string        `env:"OPENAI_HTTP" envDefault:""`
+
+	// File holding the last-known-good free models catalog so restarts can
+	// serve evaluations during OpenRouter catalog outages; empty keeps it in memory only
+	FreeModelsCatalogPath string `env:"FREE_MODELS_CATALOG_PATH" envDefault:""`
+
+	// AI simulation: "record" stores every provider response in
+	// AI_SIMULATION_DIR keyed by request hash, "replay" serves them without
+	// network calls (tests, demos); "off" calls the providers directly
+	AISimulationMode string `env:"AI_SIMULATION_MODE" envDefault:"off"`
+	AISimulationDir  string `env:"AI_SIMULATION_DIR" envDefault:"testdata/ai-recordings"`
+
+	// Groq model limits are discovered from x-ratelimit-* response headers and
+	// persisted; entries here ("model=requests_per_day:tokens_per_minute",
+	// comma-separated, 0 = keep discovered) take precedence
+	GroqModelLimits string `env:"GROQ_MODEL_LIMITS" envDefault:""`
+
+	// Streaming chat responses: abort after SSE_IDLE_TIMEOUT without any line
+	// or SSE_TOKEN_TIMEOUT without a content or reasoning token (0 = off);
+	// with salvage on, partial JSON is closed and used instead of discarding
+	// the generation
+	SSEIdleTimeout    time.Duration `env:"SSE_IDLE_TIMEOUT" envDefault:"20s"`
+	SSETokenTimeout   time.Duration `env:"SSE_TOKEN_TIMEOUT" envDefault:"0"`
+	SSESalvagePartial bool          `env:"SSE_SALVAGE_PARTIAL" envDefault:"false"`
+
+	// Reasoning models' reasoning is always kept out of the answer; with
+	// AI_REASONING_LOG it is written to the log, up to AI_REASONING_LOG_MAX_CHARS
+	AIReasoningLog         bool `env:"AI_REASONING_LOG" envDefault:"false"`
+	AIReasoningLogMaxChars int  `env:"AI_REASONING_LOG_MAX_CHARS" envDefault:"4000"`
+
+	// Debug logs of AI request bodies: "redact" replaces message contents
+	// (CV and project text) with their digest and length, "full" logs them
+	// as sent. Empty redacts in prod and logs full bodies elsewhere.
+	LogPromptBodies string `env:"LOG_PROMPT_BODIES" envDefault:""`
+
+	// Adaptive max_tokens: once a step/model pair has
+	// ADAPTIVE_MAX_TOKENS_MIN_SAMPLES completions, its calls request the P95
+	// completion length plus ADAPTIVE_MAX_TOKENS_MARGIN (a fraction), capped
+	// at ADAPTIVE_MAX_TOKENS_CEILING
+	AdaptiveMaxTokens           bool    `env:"ADAPTIVE_MAX_TOKENS" envDefault:"true"`
+	AdaptiveMaxTokensMargin     float64 `env:"ADAPTIVE_MAX_TOKENS_MARGIN" envDefault:"0.25"`
+	AdaptiveMaxTokensMinSamples int     `env:"ADAPTIVE_MAX_TOKENS_MIN_SAMPLES" envDefault:"20"`
+	AdaptiveMaxTokensCeiling    int     `env:"ADAPTIVE_MAX_TOKENS_CEILING" envDefault:"4096"`
+
+	// Prompt-injection screening of uploaded documents: "sanitize" replaces
+	// detected instructions, "flag" only records them, "off" disables it.
+	PromptInjectionMode string `env:"PROMPT_INJECTION_MODE" envDefault:"sanitize"`
+
+	// Remove sentences referencing protected attributes (age, gender,
+	// nationality, ...) from generated feedback before results are stored.
+	OutputSafetyFilter bool `env:"OUTPUT_SAFETY_FILTER" envDefault:"true"`
+
+	// When the job description and the CV are detected in different
+	// languages: "warn" records the mismatch with the result, "translate"
+	// also translates the job description into the CV's language for
+	// prompting, "off" disables the check.
+	JDLanguageMode string `env:"JD_LANGUAGE_MODE" envDefault:"warn"`
+
+	// Bias audit: every BIAS_AUDIT_INTERVAL (0 = off) aggregate the scores of
+	// the last BIAS_AUDIT_WINDOW by document language and length; segments
+	// smaller than BIAS_AUDIT_MIN_SEGMENT are reported but never flagged
+	BiasAuditInterval   time.Duration `env:"BIAS_AUDIT_INTERVAL" envDefault:"24h"`
+	BiasAuditWindow     time.Duration `env:"BIAS_AUDIT_WINDOW" envDefault:"168h"`
+	BiasAuditMinSegment int           `env:"BIAS_AUDIT_MIN_SEGMENT" envDefault:"20"`
+
+	// Anonymous usage stats (opt-in): jobs per day, mean latency, failure ratio
+	// and model mix over STATS_WINDOW, served at /admin/api/v1/stats and, when
+	// STATS_PUSH_URL is set, posted there every STATS_PUSH_INTERVAL.
+	// STATS_DEPLOYMENT_ID labels this deployment in fleet-wide monitoring.
+	StatsEnabled      bool          `env:"STATS_ENABLED" envDefault:"false"`
+	StatsWindow       time.Duration `env:"STATS_WINDOW" envDefault:"168h"`
+	StatsPushURL      string        `env:"STATS_PUSH_URL" envDefault:""`
+	StatsPushInterval time.Duration `env:"STATS_PUSH_INTERVAL" envDefault:"24h"`
+	StatsDeploymentID string        `env:"STATS_DEPLOYMENT_ID" envDefault:""`
+
+	// S3 ingestion: when INGEST_S3_ENABLED is set, the server polls the bucket
+	// every INGEST_S3_POLL_INTERVAL for <prefix><candidate>/cv.<ext> and
+	// <prefix><candidate>/project.<ext> documents and evaluates each pair. A
+	// lone document is evaluated by itself once older than INGEST_S3_PAIR_WAIT.
+	IngestS3Enabled         bool          `env:"INGEST_S3_ENABLED" envDefault:"false"`
+	IngestS3Endpoint        string        `env:"INGEST_S3_ENDPOINT" envDefault:""`
+	IngestS3Region          string        `env:"INGEST_S3_REGION" envDefault:"us-east-1"`
+	IngestS3Bucket          string        `env:"INGEST_S3_BUCKET" envDefault:""`
+	IngestS3Prefix          string        `env:"INGEST_S3_PREFIX" envDefault:"incoming/"`
+	IngestS3AccessKeyID     string        `env:"INGEST_S3_ACCESS_KEY_ID" envDefault:""`
+	IngestS3SecretAccessKey string        `env:"INGEST_S3_SECRET_ACCESS_KEY" envDefault:""`
+	IngestS3PollInterval    time.Duration `env:"INGEST_S3_POLL_INTERVAL" envDefault:"1m"`
+	IngestS3PairWait        time.Duration `env:"INGEST_S3_PAIR_WAIT" envDefault:"10m"`
+	IngestS3DeleteProcessed bool          `env:"INGEST_S3_DELETE_PROCESSED" envDefault:"true"`
+
+	// Email-in: POST /v1/inbound/email/{INBOUND_EMAIL_SECRET} accepts the
+	// inbound parse webhooks of SendGrid or Mailgun, evaluates the CV and
+	// project attached to mail sent to INBOUND_EMAIL_ADDRESS (empty = any) and
+	// replies to the sender with the result link, rooted at PUBLIC_BASE_URL.
+	// INBOUND_EMAIL_ALLOWED_SENDERS lists the addresses and @domains allowed
+	// to submit (empty = anyone).
+	InboundEmailEnabled        bool   `env:"INBOUND_EMAIL_ENABLED" envDefault:"false"`
+	InboundEmailSecret         string `env:"INBOUND_EMAIL_SECRET" envDefault:""`
+	InboundEmailAddress        string `env:"INBOUND_EMAIL_ADDRESS" envDefault:""`
+	InboundEmailAllowedSenders string `env:"INBOUND_EMAIL_ALLOWED_SENDERS" envDefault:""`
+	PublicBaseURL              string `env:"PUBLIC_BASE_URL" envDefault:""`
+
+	// GET /v1/evaluate/{id}/badge.svg serves a job's status and scores as an
+	// SVG badge; like /v1/result it needs no credentials beyond the job id.
+	StatusBadgeEnabled bool `env:"STATUS_BADGE_ENABLED" envDefault:"true"`
+
+	// Record every read of a result (who, when, from where) in access_log,
+	// queryable at /admin/api/access-log; entries older than
+	// ACCESS_LOG_RETENTION_DAYS are deleted by the cleanup (0 = kept). The
+	// client IP is the first X-Forwarded-For entry when
+	// ACCESS_LOG_TRUST_FORWARDED_FOR is set, for deployments behind a proxy
+	// that sets it; otherwise the peer address.
+	AccessLogEnabled           bool `env:"ACCESS_LOG_ENABLED" envDefault:"false"`
+	AccessLogRetentionDays     int  `env:"ACCESS_LOG_RETENTION_DAYS" envDefault:"365"`
+	AccessLogTrustForwardedFor bool `env:"ACCESS_LOG_TRUST_FORWARDED_FOR" envDefault:"false"`
+
+	// Score normalization across models: SCORE_NORMALIZATION is off, zscore or
+	// quantile; a model's scores are normalized once it has
+	// SCORE_NORMALIZATION_MIN_SAMPLES results.
+	ScoreNormalization           string `env:"SCORE_NORMALIZATION" envDefault:"off"`
+	ScoreNormalizationMinSamples int    `env:"SCORE_NORMALIZATION_MIN_SAMPLES" envDefault:"30"`
+
+	// Save the output of completed evaluation chain steps so a retried job
+	// resumes after the last completed step instead of repeating its AI calls.
+	EvaluationCheckpoints bool `env:"EVALUATION_CHECKPOINTS" envDefault:"true"`
+
+	// Lease each job for JOB_LOCK_TTL before evaluating it so consumers in
+	// different groups do not evaluate the same job twice; 0 disables it.
+	JobLockTTL time.Duration `env:"JOB_LOCK_TTL" envDefault:"10m"`
+
+	// Jobs expire JOB_TTL after submission unless the request sets
+	// ttl_seconds: queued jobs are no longer processed and completed results
+	// are no longer served. 0 never expires jobs.
+	JobTTL time.Duration `env:"JOB_TTL" envDefault:"0s"`
+
+	// Completed results may be reused by clients for RESULT_CACHE_MAX_AGE
+	// before they revalidate them with If-None-Match or If-Modified-Since;
+	// 0 makes them revalidate every poll
+	ResultCacheMaxAge time.Duration `env:"RESULT_CACHE_MAX_AGE" envDefault:"60s"`
+
+	// Every job carries EVALUATION_SLA as its deadline once a worker starts
+	// it: steps and AI calls stop at the deadline and the job fails. Jobs still
+	// processing past it are reported as failed.
+	EvaluationSLA time.Duration `env:"EVALUATION_SLA" envDefault:"5m"`
+
+	// Workers take the single-prompt fast path instead of the multi-step
+	// chain for jobs that waited in the queue longer than FAST_PATH_QUEUE_LAG
+	// and while the average AI call takes longer than
+	// FAST_PATH_PROVIDER_LATENCY. 0 disables the trigger; requests with
+	// priority=interactive always take the fast path.
+	FastPathQueueLag        time.Duration `env:"FAST_PATH_QUEUE_LAG" envDefault:"0s"`
+	FastPathProviderLatency time.Duration `env:"FAST_PATH_PROVIDER_LATENCY" envDefault:"0s"`
+
+	// Responses to /v1/evaluate carry quota warning headers once a tenant used
+	// more than TENANT_QUOTA_WARN_RATIO of a daily quota.
+	TenantQuotaWarnRatio float64 `env:"TENANT_QUOTA_WARN_RATIO" envDefault:"0.8"`
+
+	// Similarity detection: project reports are embedded into a dedicated
+	// Qdrant collection and compared with the tenant's earlier submissions.
+	// Results whose closest matches score at least SIMILARITY_THRESHOLD
+	// (cosine) are flagged with up to SIMILARITY_TOP_K match references.
+	SimilarityDetection bool    `env:"SIMILARITY_DETECTION" envDefault:"false"`
+	SimilarityThreshold float64 `env:"SIMILARITY_THRESHOLD" envDefault:"0.92"`
+	SimilarityTopK      int     `env:"SIMILARITY_TOP_K" envDefault:"3"`
+
+	// Model A/B routing: MODEL_CHALLENGER_TRAFFIC percent of jobs try
+	// MODEL_CHALLENGER (a model of MODEL_CHALLENGER_PROVIDER, groq or
+	// openrouter) first and fall back to the default pool when it fails.
+	// Routing is off while the model is empty or the traffic is 0.
+	ModelChallengerProvider string `env:"MODEL_CHALLENGER_PROVIDER" envDefault:"groq"`
+	ModelChallenger         string `env:"MODEL_CHALLENGER" envDefault:""`
+	ModelChallengerTraffic  int    `env:"MODEL_CHALLENGER_TRAFFIC" envDefault:"0"`
+
+	// Scheduled activity reports. REPORT_SCHEDULES lists "recipient|period|cron"
+	// entries separated by ';' (period is daily or weekly, cron is evaluated in
+	// UTC). REPORT_PROVIDER_COSTS prices tokens per provider in USD per million,
+	// e.g. "openrouter=0.5,groq=0".
+	ReportSchedules     string `env:"REPORT_SCHEDULES" envDefault:""`
+	ReportProviderCosts string `env:"REPORT_PROVIDER_COSTS" envDefault:""`
+	// Outgoing mail: MAIL_PROVIDER is smtp, ses or log; empty disables email
+	MailProvider       string `env:"MAIL_PROVIDER" envDefault:""`
+	MailFrom           string `env:"MAIL_FROM" envDefault:""`
+	SMTPHost           string `env:"SMTP_HOST" envDefault:""`
+	SMTPPort           int    `env:"SMTP_PORT" envDefault:"587"`
+	SMTPUsername       string `env:"SMTP_USERNAME" envDefault:""`
+	SMTPPassword       string `env:"SMTP_PASSWORD" envDefault:""`
+	SESEndpoint        string `env:"SES_ENDPOINT" envDefault:""`
+	SESRegion          string `env:"SES_REGION" envDefault:"us-east-1"`
+	SESAccessKeyID     string `env:"SES_ACCESS_KEY_ID" envDefault:""`
+	SESSecretAccessKey string `env:"SES_SECRET_ACCESS_KEY" envDefault:""`
+
+	// Operational notifications posted to Slack and/or Teams incoming webhooks.
+	// NOTIFY_EVENTS lists the enabled events; each event+subject is posted at
+	// most once per NOTIFY_MIN_INTERVAL. NOTIFY_TEMPLATE_* override the
+	// text/template of an event's message.
+	NotifySlackWebhookURL         string        `env:"NOTIFY_SLACK_WEBHOOK_URL" envDefault:""`
+	NotifyTeamsWebhookURL         string        `env:"NOTIFY_TEAMS_WEBHOOK_URL" envDefault:""`
+	NotifyEvents                  string        `env:"NOTIFY_EVENTS" envDefault:"job_failure_spike,dlq_growth,provider_circuit_open,sweeper_action"`
+	NotifyMinInterval             time.Duration `env:"NOTIFY_MIN_INTERVAL" envDefault:"15m"`
+	NotifyFailureSpikeThreshold   int           `env:"NOTIFY_FAILURE_SPIKE_THRESHOLD" envDefault:"10"`
+	NotifyFailureSpikeWindow      time.Duration `env:"NOTIFY_FAILURE_SPIKE_WINDOW" envDefault:"10m"`
+	NotifyDLQThreshold            int           `env:"NOTIFY_DLQ_THRESHOLD" envDefault:"20"`
+	NotifyDLQWindow               time.Duration `env:"NOTIFY_DLQ_WINDOW" envDefault:"10m"`
+	NotifyTemplateJobFailureSpike string        `env:"NOTIFY_TEMPLATE_JOB_FAILURE_SPIKE" envDefault:""`
+	NotifyTemplateDLQGrowth       string        `env:"NOTIFY_TEMPLATE_DLQ_GROWTH" envDefault:""`
+	NotifyTemplateCircuitOpen     string        `env:"NOTIFY_TEMPLATE_PROVIDER_CIRCUIT_OPEN" envDefault:""`
+	NotifyTemplateSweeperAction   string        `env:"NOTIFY_TEMPLATE_SWEEPER_ACTION" envDefault:""`
+
+	// Maintenance mode is toggled through the admin API and shared through
+	// the database; servers reload it every MAINTENANCE_SYNC_PERIOD (0 = only
+	// at startup). MAINTENANCE_RETRY_AFTER is advertised when a toggle omits it
+	MaintenanceSyncPeriod time.Duration `env:"MAINTENANCE_SYNC_PERIOD" envDefault:"10s"`
+	MaintenanceRetryAfter time.Duration `env:"MAINTENANCE_RETRY_AFTER" envDefault:"120s"`
+
+	// Queue topics paused through the admin API are shared through the
+	// database; workers and servers reload them every CONSUMER_PAUSE_SYNC_PERIOD
+	// (0 = only at startup)
+	ConsumerPauseSyncPeriod time.Duration `env:"CONSUMER_PAUSE_SYNC_PERIOD" envDefault:"5s"`
+
+	// Queued jobs report their queue position and an ETA from the jobs
+	// finished within QUEUE_THROUGHPUT_WINDOW (0 = 15m)
+	QueueThroughputWindow time.Duration `env:"QUEUE_THROUGHPUT_WINDOW" envDefault:"15m"`
+
+	// Backpressure: once BACKPRESSURE_MAX_PENDING (0 = off) jobs are queued or
+	// processing, new evaluations are rejected with 429 and
+	// BACKPRESSURE_RETRY_AFTER ("reject") or deferred until the backlog
+	// drains ("defer"); deferred evaluations are released every
+	// MAINTENANCE_SYNC_PERIOD
+	BackpressureMaxPending int64         `env:"BACKPRESSURE_MAX_PENDING" envDefault:"0"`
+	BackpressureMode       string        `env:"BACKPRESSURE_MODE" envDefault:"reject"`
+	BackpressureRetryAfter time.Duration `env:"BACKPRESSURE_RETRY_AFTER" envDefault:"30s"`
+
+	// AI saturation fail-fast: workers report when every chat model they can
+	// use is blocked after rate limits, and while all of them are new
+	// evaluations are rejected with 503 and a Retry-After of the shortest
+	// block
+	AISaturationFailFast bool `env:"AI_SATURATION_FAIL_FAST" envDefault:"false"`
+
+	// Enqueue outbox: new jobs and their evaluation tasks are written in one
+	// transaction and a relay in every server publishes pending tasks right
+	// away and every OUTBOX_POLL_INTERVAL. Sent entries are purged after
+	// OUTBOX_RETENTION (0 = kept). An entry that failed to publish
+	// OUTBOX_MAX_ATTEMPTS times is parked and its job failed (0 = retried
+	// forever). The relay also publishes released deferred evaluations, so
+	// it runs even when OUTBOX_ENABLED is false
+	OutboxEnabled      bool          `env:"OUTBOX_ENABLED" envDefault:"true"`
+	OutboxPollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" envDefault:"1s"`
+	OutboxBatchSize    int           `env:"OUTBOX_BATCH_SIZE" envDefault:"100"`
+	OutboxRetention    time.Duration `env:"OUTBOX_RETENTION" envDefault:"24h"`
+	OutboxMaxAttempts  int           `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"10"`
+
+	// RUN_MODE=all runs the evaluation worker inside the server process,
+	// sharing its DB pool and AI client; "server" leaves it to cmd/worker
+	RunMode string `env:"RUN_MODE" envDefault:"server"`
 }
 
+// Process run modes accepted in RUN_MODE.
+const (
+	RunModeServer = "server"
+	RunModeAll    = "all"
+)
+
+// RunsWorker reports whether the server process also runs the worker.
+func (c Config) RunsWorker() bool { return strings.EqualFold(strings.TrimSpace(c.RunMode), RunModeAll) }
+
 // AdminEnabled returns true if admin features should be enabled
 func (c Config) AdminEnabled() bool {
 	// Admin enabled if credentials and secret present.
@@ -110,6 +554,18 @@ func (c Config) IsProd() bool { return strings.ToLower(c.AppEnv) == "prod" }
 // IsTest reports whether the app is running in test mode.
 func (c Config) IsTest() bool { return strings.ToLower(c.AppEnv) == "test" }
 
+// RedactPromptLogs reports whether AI request bodies are redacted in logs.
+func (c Config) RedactPromptLogs() bool {
+	switch strings.ToLower(strings.TrimSpace(c.LogPromptBodies)) {
+	case "redact":
+		return true
+	case "full":
+		return false
+	default:
+		return c.IsProd()
+	}
+}
+
 // GetAIBackoffConfig returns backoff configuration appropriate for the current environment.
 // In test environments, uses much shorter timeouts for faster test execution.
 func (c Config) GetAIBackoffConfig() (maxElapsedTime, initialInterval, maxInterval time.Duration, multiplier float64) {
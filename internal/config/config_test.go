@@ -147,3 +147,43 @@ func Test_GetAIBackoffConfig_DevelopmentEnvironment(t *testing.T) {
 		t.Errorf("expected multiplier 1.5, got %v", multiplier)
 	}
 }
+
+func Test_RunsWorker(t *testing.T) {
+	tests := []struct {
+		runMode  string
+		expected bool
+	}{
+		{"", false},
+		{"server", false},
+		{"all", true},
+		{" ALL ", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.runMode, func(t *testing.T) {
+			t.Setenv("RUN_MODE", tt.runMode)
+			cfg, err := Load()
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, cfg.RunsWorker())
+		})
+	}
+}
+
+func Test_EnvVars(t *testing.T) {
+	vars := EnvVars()
+	byName := map[string]EnvVar{}
+	for _, v := range vars {
+		if _, dup := byName[v.Name]; dup {
+			t.Fatalf("duplicate env var %s", v.Name)
+		}
+		byName[v.Name] = v
+	}
+	require.Equal(t, "APP_ENV", vars[0].Name)
+	require.Equal(t, EnvVar{Name: "PORT", Default: "8080"}, byName["PORT"])
+	require.Equal(t, "localhost:19092", byName["KAFKA_BROKERS"].Default)
+	for _, name := range []string{"DB_URL", "OPENROUTER_API_KEY", "OPENROUTER_API_KEYS", "ADMIN_PASSWORD", "ADMIN_SESSION_SECRET", "SES_ACCESS_KEY_ID", "NOTIFY_SLACK_WEBHOOK_URL"} {
+		require.True(t, byName[name].Secret, name)
+	}
+	for _, name := range []string{"ADMIN_USERNAME", "QDRANT_URL", "RUN_MODE"} {
+		require.False(t, byName[name].Secret, name)
+	}
+}
@@ -0,0 +1,56 @@
+// Package config defines database pool configuration.
+package config
+
+import (
+	"time"
+)
+
+// DBPoolConfig holds PostgreSQL connection pool tuning knobs.
+type DBPoolConfig struct {
+	// MaxConns is the maximum number of connections kept by the pool
+	MaxConns int32
+	// MinConns is the number of connections the pool keeps warm
+	MinConns int32
+	// MaxConnLifetime is the duration after which a connection is recycled
+	MaxConnLifetime time.Duration
+	// MaxConnIdleTime is the duration after which an idle connection is closed
+	MaxConnIdleTime time.Duration
+	// HealthCheckPeriod is the interval between idle connection health checks
+	HealthCheckPeriod time.Duration
+	// StatementCacheCapacity is the per-connection prepared statement cache size
+	StatementCacheCapacity int
+	// QueryExecMode selects how pgx executes queries (e.g. cache_statement)
+	QueryExecMode string
+	// SSLMode overrides the DSN sslmode (e.g. verify-full)
+	SSLMode string
+	// SSLRootCert is the path of the CA bundle used to verify the server
+	SSLRootCert string
+	// IAMAuth selects IAM token authentication: "", aws or gcp
+	IAMAuth string
+	// AWSRegion is the RDS region tokens are signed for
+	AWSRegion string
+	// AWSAccessKeyID, AWSSecretAccessKey and AWSSessionToken sign RDS tokens
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string
+}
+
+// GetDBPoolConfig returns the database pool configuration
+func (c Config) GetDBPoolConfig() DBPoolConfig {
+	return DBPoolConfig{
+		MaxConns:               c.DBMaxConns,
+		MinConns:               c.DBMinConns,
+		MaxConnLifetime:        c.DBMaxConnLifetime,
+		MaxConnIdleTime:        c.DBMaxConnIdleTime,
+		HealthCheckPeriod:      c.DBHealthCheckPeriod,
+		StatementCacheCapacity: c.DBStatementCacheCapacity,
+		QueryExecMode:          c.DBQueryExecMode,
+		SSLMode:                c.DBSSLMode,
+		SSLRootCert:            c.DBSSLRootCert,
+		IAMAuth:                c.DBIAMAuth,
+		AWSRegion:              c.DBIAMAWSRegion,
+		AWSAccessKeyID:         c.DBIAMAWSAccessKeyID,
+		AWSSecretAccessKey:     c.DBIAMAWSSecretAccessKey,
+		AWSSessionToken:        c.DBIAMAWSSessionToken,
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_GetDBPoolConfig_Defaults(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	pc := cfg.GetDBPoolConfig()
+	if pc.MaxConns != 10 || pc.MinConns != 0 {
+		t.Fatalf("conns = (%d,%d), want (10,0)", pc.MaxConns, pc.MinConns)
+	}
+	if pc.MaxConnIdleTime != 5*time.Minute || pc.HealthCheckPeriod != time.Minute || pc.MaxConnLifetime != time.Hour {
+		t.Fatalf("unexpected durations: %+v", pc)
+	}
+	if pc.StatementCacheCapacity != 512 || pc.QueryExecMode != "cache_statement" {
+		t.Fatalf("unexpected statement cache settings: %+v", pc)
+	}
+}
+
+func TestConfig_GetDBPoolConfig_FromEnv(t *testing.T) {
+	t.Setenv("DB_MAX_CONNS", "25")
+	t.Setenv("DB_MIN_CONNS", "5")
+	t.Setenv("DB_HEALTH_CHECK_PERIOD", "10s")
+	t.Setenv("DB_QUERY_EXEC_MODE", "exec")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	pc := cfg.GetDBPoolConfig()
+	if pc.MaxConns != 25 || pc.MinConns != 5 || pc.HealthCheckPeriod != 10*time.Second || pc.QueryExecMode != "exec" {
+		t.Fatalf("unexpected pool config: %+v", pc)
+	}
+}
@@ -0,0 +1,46 @@
+// Package config defines embedding cache configuration.
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// EmbedCacheConfig holds in-memory and persistent embedding cache settings.
+type EmbedCacheConfig struct {
+	// Capacity is the maximum number of in-memory entries (0 disables the cache)
+	Capacity int
+	// MaxBytes bounds the estimated in-memory size (0 means unbounded)
+	MaxBytes int64
+	// TTL expires cached embeddings (0 means no expiry)
+	TTL time.Duration
+	// RedisEnabled persists embeddings in Redis so they survive restarts
+	RedisEnabled bool
+	// RedisPrefix namespaces persisted keys; the embeddings model is appended
+	RedisPrefix string
+}
+
+// GetEmbedCacheConfig returns the embedding cache configuration. Persisted
+// keys include the embeddings model so switching models never serves
+// vectors from a different embedding space.
+func (c Config) GetEmbedCacheConfig() EmbedCacheConfig {
+	maxBytes := c.EmbedCacheMaxBytes
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+	ttl := c.EmbedCacheTTL
+	if ttl < 0 {
+		ttl = 0
+	}
+	prefix := strings.TrimSuffix(strings.TrimSpace(c.EmbedCacheRedisPrefix), ":")
+	if prefix == "" {
+		prefix = "embedcache"
+	}
+	return EmbedCacheConfig{
+		Capacity:     c.EmbedCacheSize,
+		MaxBytes:     maxBytes,
+		TTL:          ttl,
+		RedisEnabled: c.EmbedCacheRedisEnabled && c.RedisAddr != "",
+		RedisPrefix:  prefix + ":" + c.EmbeddingsModel + ":",
+	}
+}
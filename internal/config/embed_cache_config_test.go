@@ -0,0 +1,41 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_GetEmbedCacheConfig_Defaults(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	ec := cfg.GetEmbedCacheConfig()
+	if ec.Capacity != 2048 || ec.MaxBytes != 64<<20 || ec.TTL != 168*time.Hour || ec.RedisEnabled {
+		t.Fatalf("unexpected defaults: %+v", ec)
+	}
+	if ec.RedisPrefix != "embedcache:"+cfg.EmbeddingsModel+":" {
+		t.Fatalf("prefix = %q", ec.RedisPrefix)
+	}
+}
+
+func TestConfig_GetEmbedCacheConfig_FromEnv(t *testing.T) {
+	t.Setenv("EMBED_CACHE_REDIS_ENABLED", "true")
+	t.Setenv("EMBED_CACHE_REDIS_PREFIX", " emb: ")
+	t.Setenv("EMBED_CACHE_TTL", "-1s")
+	t.Setenv("EMBED_CACHE_MAX_BYTES", "-5")
+	t.Setenv("EMBEDDINGS_MODEL", "m1")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	ec := cfg.GetEmbedCacheConfig()
+	if !ec.RedisEnabled || ec.RedisPrefix != "emb:m1:" || ec.TTL != 0 || ec.MaxBytes != 0 {
+		t.Fatalf("unexpected config: %+v", ec)
+	}
+
+	cfg.RedisAddr = ""
+	if cfg.GetEmbedCacheConfig().RedisEnabled {
+		t.Fatalf("redis should be disabled without REDIS_ADDR")
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// EnvVar describes one environment variable read by Load.
+type EnvVar struct {
+	Name    string
+	Default string
+	// Secret marks credentials that belong in a secret store rather than in
+	// plain configuration.
+	Secret bool
+}
+
+// secretMarkers are the name fragments of variables holding credentials.
+var secretMarkers = []string{"PASSWORD", "SECRET", "API_KEY", "ACCESS_KEY_ID", "SESSION_TOKEN", "WEBHOOK_URL", "ENCRYPTION_KEYS"}
+
+// EnvVars lists the environment variables read by Load in declaration order.
+// It is derived from the Config struct tags so that generated deployment
+// assets cannot drift from the parser.
+func EnvVars() []EnvVar {
+	t := reflect.TypeOf(Config{})
+	vars := make([]EnvVar, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag
+		name := tag.Get("env")
+		if name == "" {
+			continue
+		}
+		vars = append(vars, EnvVar{Name: name, Default: tag.Get("envDefault"), Secret: isSecretEnv(name)})
+	}
+	return vars
+}
+
+func isSecretEnv(name string) bool {
+	// DB_URL embeds the database password.
+	if name == "DB_URL" {
+		return true
+	}
+	for _, m := range secretMarkers {
+		if strings.Contains(name, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+// Package config defines the HTTP transport settings of the AI providers.
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPTransportConfig tunes the HTTP transport of an AI provider.
+type HTTPTransportConfig struct {
+	// MaxIdleConns is the number of idle connections kept to the provider.
+	MaxIdleConns        int
+	DialTimeout         time.Duration
+	TLSHandshakeTimeout time.Duration
+	HTTP2               bool
+	// Proxy is the proxy URL; empty uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+	Proxy string
+	// EvictFailures failed requests to a host within EvictWindow close the
+	// transport's idle connections; 0 disables eviction.
+	EvictFailures int
+	EvictWindow   time.Duration
+}
+
+// GetHTTPTransportConfig returns the transport settings of provider
+// ("openrouter", "groq" or "openai"): the AI_HTTP_* defaults with the
+// provider's key=value overrides applied. Malformed entries are skipped.
+func (c Config) GetHTTPTransportConfig(provider string) HTTPTransportConfig {
+	t := HTTPTransportConfig{
+		MaxIdleConns:        c.AIHTTPMaxIdleConns,
+		DialTimeout:         c.AIHTTPDialTimeout,
+		TLSHandshakeTimeout: c.AIHTTPTLSHandshakeTimeout,
+		HTTP2:               c.AIHTTP2,
+		Proxy:               strings.TrimSpace(c.AIHTTPProxy),
+		EvictFailures:       c.AIHTTPEvictFailures,
+		EvictWindow:         c.AIHTTPEvictWindow,
+	}
+	var overrides string
+	switch provider {
+	case "openrouter":
+		overrides = c.OpenRouterHTTP
+	case "groq":
+		overrides = c.GroqHTTP
+	case "openai":
+		overrides = c.OpenAIHTTP
+	}
+	for _, entry := range strings.Split(overrides, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "max_idle_conns":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				t.MaxIdleConns = n
+			}
+		case "dial_timeout":
+			if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+				t.DialTimeout = d
+			}
+		case "tls_handshake_timeout":
+			if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+				t.TLSHandshakeTimeout = d
+			}
+		case "http2":
+			if b, err := strconv.ParseBool(value); err == nil {
+				t.HTTP2 = b
+			}
+		case "proxy":
+			t.Proxy = value
+		case "evict_failures":
+			if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+				t.EvictFailures = n
+			}
+		case "evict_window":
+			if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+				t.EvictWindow = d
+			}
+		}
+	}
+	return t
+}
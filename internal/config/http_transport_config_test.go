@@ -0,0 +1,23 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfig_GetHTTPTransportConfig(t *testing.T) {
+	t.Setenv("AI_HTTP_PROXY", "http://proxy.internal:3128")
+	t.Setenv("GROQ_HTTP", "max_idle_conns=200, http2=false,dial_timeout=5s,bogus,proxy=")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	want := HTTPTransportConfig{MaxIdleConns: 100, DialTimeout: 30 * time.Second, TLSHandshakeTimeout: 10 * time.Second, HTTP2: true, Proxy: "http://proxy.internal:3128", EvictFailures: 3, EvictWindow: 30 * time.Second}
+	if got := cfg.GetHTTPTransportConfig("openrouter"); got != want {
+		t.Fatalf("unexpected openrouter transport: %+v", got)
+	}
+	want = HTTPTransportConfig{MaxIdleConns: 200, DialTimeout: 5 * time.Second, TLSHandshakeTimeout: 10 * time.Second, EvictFailures: 3, EvictWindow: 30 * time.Second}
+	if got := cfg.GetHTTPTransportConfig("groq"); got != want {
+		t.Fatalf("unexpected groq transport: %+v", got)
+	}
+}
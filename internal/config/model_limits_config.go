@@ -0,0 +1,40 @@
+// Package config defines provider model limit overrides.
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ModelLimitOverride pins the rate limit of a model; zero fields keep the
+// value discovered from provider headers.
+type ModelLimitOverride struct {
+	RequestsPerDay  int64
+	TokensPerMinute int64
+}
+
+// GetGroqModelLimitOverrides parses GROQ_MODEL_LIMITS entries of the form
+// "model=requests_per_day:tokens_per_minute". Malformed entries are skipped.
+func (c Config) GetGroqModelLimitOverrides() map[string]ModelLimitOverride {
+	out := map[string]ModelLimitOverride{}
+	for _, entry := range strings.Split(c.GroqModelLimits, ",") {
+		model, limits, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		model = strings.TrimSpace(model)
+		if !ok || model == "" {
+			continue
+		}
+		rpd, tpm, ok := strings.Cut(limits, ":")
+		if !ok {
+			continue
+		}
+		var o ModelLimitOverride
+		var err1, err2 error
+		o.RequestsPerDay, err1 = strconv.ParseInt(strings.TrimSpace(rpd), 10, 64)
+		o.TokensPerMinute, err2 = strconv.ParseInt(strings.TrimSpace(tpm), 10, 64)
+		if err1 != nil || err2 != nil || o.RequestsPerDay < 0 || o.TokensPerMinute < 0 {
+			continue
+		}
+		out[model] = o
+	}
+	return out
+}
@@ -0,0 +1,28 @@
+package config
+
+import "testing"
+
+func TestConfig_GetGroqModelLimitOverrides(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	if o := cfg.GetGroqModelLimitOverrides(); len(o) != 0 {
+		t.Fatalf("expected no overrides by default, got %v", o)
+	}
+	t.Setenv("GROQ_MODEL_LIMITS", "llama-3.1-8b-instant=14400:6000, qwen/qwen3-32b = 0:9000,bad,x=1,y=a:2,z=-1:5")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	o := cfg.GetGroqModelLimitOverrides()
+	if len(o) != 2 {
+		t.Fatalf("unexpected overrides: %v", o)
+	}
+	if o["llama-3.1-8b-instant"] != (ModelLimitOverride{RequestsPerDay: 14400, TokensPerMinute: 6000}) {
+		t.Fatalf("unexpected llama override: %+v", o["llama-3.1-8b-instant"])
+	}
+	if o["qwen/qwen3-32b"] != (ModelLimitOverride{TokensPerMinute: 9000}) {
+		t.Fatalf("unexpected qwen override: %+v", o["qwen/qwen3-32b"])
+	}
+}
@@ -0,0 +1,63 @@
+// Package config defines operational notification configuration.
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// NotifyConfig holds Slack/Teams notification settings.
+type NotifyConfig struct {
+	// SlackWebhookURL and TeamsWebhookURL are incoming webhook URLs; empty disables a channel
+	SlackWebhookURL string
+	TeamsWebhookURL string
+	// Events is the set of enabled event names
+	Events map[string]bool
+	// MinInterval is the minimum time between two posts of one event and subject
+	MinInterval time.Duration
+	// Templates overrides the message template of an event by name
+	Templates map[string]string
+	// FailureSpikeThreshold failed jobs within FailureSpikeWindow raise job_failure_spike
+	FailureSpikeThreshold int
+	FailureSpikeWindow    time.Duration
+	// DLQThreshold jobs moved to the DLQ within DLQWindow raise dlq_growth
+	DLQThreshold int
+	DLQWindow    time.Duration
+}
+
+// Enabled reports whether any notification channel is configured.
+func (c NotifyConfig) Enabled() bool {
+	return c.SlackWebhookURL != "" || c.TeamsWebhookURL != ""
+}
+
+// GetNotifyConfig returns the operational notification configuration.
+func (c Config) GetNotifyConfig() NotifyConfig {
+	events := map[string]bool{}
+	for _, e := range strings.Split(c.NotifyEvents, ",") {
+		if e = strings.ToLower(strings.TrimSpace(e)); e != "" {
+			events[e] = true
+		}
+	}
+	templates := map[string]string{}
+	for name, tmpl := range map[string]string{
+		"job_failure_spike":     c.NotifyTemplateJobFailureSpike,
+		"dlq_growth":            c.NotifyTemplateDLQGrowth,
+		"provider_circuit_open": c.NotifyTemplateCircuitOpen,
+		"sweeper_action":        c.NotifyTemplateSweeperAction,
+	} {
+		if strings.TrimSpace(tmpl) != "" {
+			templates[name] = tmpl
+		}
+	}
+	return NotifyConfig{
+		SlackWebhookURL:       strings.TrimSpace(c.NotifySlackWebhookURL),
+		TeamsWebhookURL:       strings.TrimSpace(c.NotifyTeamsWebhookURL),
+		Events:                events,
+		MinInterval:           c.NotifyMinInterval,
+		Templates:             templates,
+		FailureSpikeThreshold: c.NotifyFailureSpikeThreshold,
+		FailureSpikeWindow:    c.NotifyFailureSpikeWindow,
+		DLQThreshold:          c.NotifyDLQThreshold,
+		DLQWindow:             c.NotifyDLQWindow,
+	}
+}
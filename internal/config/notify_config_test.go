@@ -0,0 +1,45 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfig_GetNotifyConfig_Defaults(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	nc := cfg.GetNotifyConfig()
+	if nc.Enabled() {
+		t.Fatalf("notifications should be disabled without webhooks")
+	}
+	wantEvents := map[string]bool{"job_failure_spike": true, "dlq_growth": true, "provider_circuit_open": true, "sweeper_action": true}
+	if !reflect.DeepEqual(nc.Events, wantEvents) {
+		t.Fatalf("events = %v, want %v", nc.Events, wantEvents)
+	}
+	if nc.MinInterval != 15*time.Minute || nc.FailureSpikeThreshold != 10 || nc.DLQThreshold != 20 || len(nc.Templates) != 0 {
+		t.Fatalf("unexpected defaults: %+v", nc)
+	}
+}
+
+func TestConfig_GetNotifyConfig_Overrides(t *testing.T) {
+	t.Setenv("NOTIFY_SLACK_WEBHOOK_URL", " https://hooks.slack.com/services/x ")
+	t.Setenv("NOTIFY_EVENTS", "DLQ_Growth, ,sweeper_action")
+	t.Setenv("NOTIFY_TEMPLATE_DLQ_GROWTH", "DLQ: {{.Count}}")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	nc := cfg.GetNotifyConfig()
+	if !nc.Enabled() || nc.SlackWebhookURL != "https://hooks.slack.com/services/x" {
+		t.Fatalf("slack webhook not applied: %+v", nc)
+	}
+	if !reflect.DeepEqual(nc.Events, map[string]bool{"dlq_growth": true, "sweeper_action": true}) {
+		t.Fatalf("events = %v", nc.Events)
+	}
+	if !reflect.DeepEqual(nc.Templates, map[string]string{"dlq_growth": "DLQ: {{.Count}}"}) {
+		t.Fatalf("templates = %v", nc.Templates)
+	}
+}
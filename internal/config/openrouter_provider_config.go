@@ -0,0 +1,42 @@
+// Package config defines the OpenRouter provider routing options.
+package config
+
+import "strings"
+
+// OpenRouterProviderRouting are the deployment's OpenRouter provider routing
+// options.
+type OpenRouterProviderRouting struct {
+	// Order lists the upstream providers to try first, in order.
+	Order []string
+	// Ignore lists upstream providers that never serve a call.
+	Ignore []string
+	// AllowFallbacks lets providers outside Order serve a call.
+	AllowFallbacks bool
+	// DenyDataCollection excludes providers that store or train on prompts.
+	DenyDataCollection bool
+	// RequireParameters limits calls to providers supporting every parameter.
+	RequireParameters bool
+}
+
+// GetOpenRouterProviderRouting returns the OpenRouter provider routing
+// options. Any data collection value other than "deny" allows it.
+func (c Config) GetOpenRouterProviderRouting() OpenRouterProviderRouting {
+	return OpenRouterProviderRouting{
+		Order:              splitList(c.OpenRouterProviderOrder),
+		Ignore:             splitList(c.OpenRouterProviderIgnore),
+		AllowFallbacks:     c.OpenRouterProviderAllowFallbacks,
+		DenyDataCollection: strings.EqualFold(strings.TrimSpace(c.OpenRouterProviderDataCollection), "deny"),
+		RequireParameters:  c.OpenRouterProviderRequireParameters,
+	}
+}
+
+// splitList returns the non-empty trimmed entries of a comma-separated list.
+func splitList(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_GetOpenRouterProviderRouting(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	if r := cfg.GetOpenRouterProviderRouting(); !reflect.DeepEqual(r, OpenRouterProviderRouting{AllowFallbacks: true}) {
+		t.Fatalf("unexpected default routing: %+v", r)
+	}
+	t.Setenv("OPENROUTER_PROVIDER_ORDER", "Groq, ,Together")
+	t.Setenv("OPENROUTER_PROVIDER_IGNORE", "DeepInfra")
+	t.Setenv("OPENROUTER_PROVIDER_ALLOW_FALLBACKS", "false")
+	t.Setenv("OPENROUTER_PROVIDER_DATA_COLLECTION", "Deny")
+	t.Setenv("OPENROUTER_PROVIDER_REQUIRE_PARAMETERS", "true")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	want := OpenRouterProviderRouting{
+		Order:              []string{"Groq", "Together"},
+		Ignore:             []string{"DeepInfra"},
+		DenyDataCollection: true,
+		RequireParameters:  true,
+	}
+	if r := cfg.GetOpenRouterProviderRouting(); !reflect.DeepEqual(r, want) {
+		t.Fatalf("unexpected routing: %+v", r)
+	}
+}
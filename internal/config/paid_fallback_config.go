@@ -0,0 +1,33 @@
+// Package config defines the paid-model fallback policy.
+package config
+
+import "strings"
+
+// PaidFallbackPolicy controls whether and how paid OpenRouter models are used
+// once all free models are rate limited.
+type PaidFallbackPolicy struct {
+	// Enabled turns the paid fallback on.
+	Enabled bool
+	// MaxPricePer1K caps the USD price per 1K prompt and 1K completion tokens; 0 means no cap.
+	MaxPricePer1K float64
+	// AllowedModels restricts the fallback to these model IDs; empty allows any paid model.
+	AllowedModels []string
+	// RequireOptIn limits the fallback to requests that explicitly allow it.
+	RequireOptIn bool
+}
+
+// GetPaidFallbackPolicy returns the paid-model fallback policy. Negative
+// price caps are treated as no cap.
+func (c Config) GetPaidFallbackPolicy() PaidFallbackPolicy {
+	p := PaidFallbackPolicy{
+		Enabled:       c.PaidFallbackEnabled,
+		MaxPricePer1K: max(c.PaidFallbackMaxPricePer1K, 0),
+		RequireOptIn:  c.PaidFallbackRequireOptIn,
+	}
+	for _, id := range strings.Split(c.PaidFallbackModels, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			p.AllowedModels = append(p.AllowedModels, id)
+		}
+	}
+	return p
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_GetPaidFallbackPolicy(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	if p := cfg.GetPaidFallbackPolicy(); !p.Enabled || p.RequireOptIn || p.MaxPricePer1K != 0 || p.AllowedModels != nil {
+		t.Fatalf("unexpected default policy: %+v", p)
+	}
+	t.Setenv("PAID_FALLBACK_ENABLED", "false")
+	t.Setenv("PAID_FALLBACK_MAX_PRICE_PER_1K", "0.002")
+	t.Setenv("PAID_FALLBACK_MODELS", " openai/gpt-4o-mini, ,meta-llama/llama-3.1-8b-instruct")
+	t.Setenv("PAID_FALLBACK_REQUIRE_OPT_IN", "true")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	want := PaidFallbackPolicy{
+		MaxPricePer1K: 0.002,
+		AllowedModels: []string{"openai/gpt-4o-mini", "meta-llama/llama-3.1-8b-instruct"},
+		RequireOptIn:  true,
+	}
+	if p := cfg.GetPaidFallbackPolicy(); !reflect.DeepEqual(p, want) {
+		t.Fatalf("unexpected policy: %+v", p)
+	}
+}
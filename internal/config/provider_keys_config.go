@@ -0,0 +1,148 @@
+// Package config defines AI provider key pool configuration.
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Provider key states accepted in OPENROUTER_API_KEYS / GROQ_API_KEYS.
+const (
+	providerKeyActive   = "active"
+	providerKeyDraining = "draining"
+	providerKeyDisabled = "disabled"
+)
+
+// ProviderKeySpec describes one configured provider API key.
+type ProviderKeySpec struct {
+	// Provider is "openrouter" or "groq"
+	Provider string
+	// Secret is the API key itself
+	Secret string
+	// Weight is the relative share of traffic among active keys (>= 1)
+	Weight int
+	// State is active, draining or disabled
+	State string
+}
+
+// GetProviderKeys returns the configured API keys of all providers. Legacy
+// OPENROUTER_API_KEY(_2) and GROQ_API_KEY(_2) come first as weight-1 active
+// keys, followed by the OPENROUTER_API_KEYS and GROQ_API_KEYS entries. A
+// secret listed twice keeps its first position and its last weight/state.
+func (c Config) GetProviderKeys() []ProviderKeySpec {
+	var out []ProviderKeySpec
+	index := map[string]int{}
+	add := func(k ProviderKeySpec) {
+		id := k.Provider + "\x00" + k.Secret
+		if i, ok := index[id]; ok {
+			out[i] = k
+			return
+		}
+		index[id] = len(out)
+		out = append(out, k)
+	}
+	for _, p := range []struct {
+		provider string
+		legacy   []string
+		list     string
+	}{
+		{"openrouter", []string{c.OpenRouterAPIKey, c.OpenRouterAPIKey2}, c.OpenRouterAPIKeys},
+		{"groq", []string{c.GroqAPIKey, c.GroqAPIKey2}, c.GroqAPIKeys},
+	} {
+		for _, secret := range p.legacy {
+			if secret = strings.TrimSpace(secret); secret != "" {
+				add(ProviderKeySpec{Provider: p.provider, Secret: secret, Weight: 1, State: providerKeyActive})
+			}
+		}
+		for _, entry := range strings.Split(p.list, ",") {
+			if k, ok := parseProviderKey(p.provider, entry); ok {
+				add(k)
+			}
+		}
+	}
+	return out
+}
+
+// parseProviderKey parses "secret[:weight[:state]]". Fields are taken from
+// the right so secrets containing ':' remain usable when weight is given.
+func parseProviderKey(provider, entry string) (ProviderKeySpec, bool) {
+	k := ProviderKeySpec{Provider: provider, Weight: 1, State: providerKeyActive}
+	rest := strings.TrimSpace(entry)
+	if i := strings.LastIndex(rest, ":"); i >= 0 {
+		switch s := strings.ToLower(strings.TrimSpace(rest[i+1:])); s {
+		case providerKeyActive, providerKeyDraining, providerKeyDisabled:
+			k.State = s
+			rest = rest[:i]
+		}
+	}
+	if i := strings.LastIndex(rest, ":"); i >= 0 {
+		if w, err := strconv.Atoi(strings.TrimSpace(rest[i+1:])); err == nil && w > 0 {
+			k.Weight = w
+			rest = rest[:i]
+		}
+	}
+	k.Secret = strings.TrimSpace(rest)
+	return k, k.Secret != ""
+}
+
+// PrimaryProviderKey returns the first non-disabled configured key of
+// provider, used for auxiliary calls such as model discovery, or "".
+func (c Config) PrimaryProviderKey(provider string) string {
+	for _, k := range c.GetProviderKeys() {
+		if k.Provider == provider && k.State != providerKeyDisabled {
+			return k.Secret
+		}
+	}
+	return ""
+}
+
+// KeyBudget is the daily allowance of a single provider key; zero fields are unlimited.
+type KeyBudget struct {
+	Requests int64
+	Tokens   int64
+}
+
+// Unlimited reports whether the budget imposes no limit.
+func (b KeyBudget) Unlimited() bool { return b.Requests <= 0 && b.Tokens <= 0 }
+
+// GetProviderKeyBudgets returns the per-key daily budget of each provider.
+// Providers without any limit are omitted.
+func (c Config) GetProviderKeyBudgets() map[string]KeyBudget {
+	out := map[string]KeyBudget{}
+	for provider, b := range map[string]KeyBudget{
+		"openrouter": {Requests: c.OpenRouterKeyDailyRequests, Tokens: c.OpenRouterKeyDailyTokens},
+		"groq":       {Requests: c.GroqKeyDailyRequests, Tokens: c.GroqKeyDailyTokens},
+	} {
+		if !b.Unlimited() {
+			out[provider] = b
+		}
+	}
+	return out
+}
+
+// ProviderKeyEncryption configures the encryption of the provider key
+// secrets stored in ai_provider_keys.
+type ProviderKeyEncryption struct {
+	// KeyID names the key new secrets are encrypted with; empty means
+	// secrets cannot be stored.
+	KeyID string
+	// Keys holds the AES-256 keys by key id. Retired keys stay listed until
+	// the stored secrets are re-encrypted with the active key.
+	Keys map[string][]byte
+}
+
+// GetProviderKeyEncryption parses AI_PROVIDER_KEY_ENCRYPTION_KEYS,
+// comma-separated "id:base64key" entries of 32-byte keys, and checks that
+// AI_PROVIDER_KEY_ENCRYPTION_KEY_ID names one of them.
+func (c Config) GetProviderKeyEncryption() (ProviderKeyEncryption, error) {
+	keyID, keys, err := parseEncryptionKeys("provider_key_encryption", "AI_PROVIDER_KEY_ENCRYPTION_KEYS", "AI_PROVIDER_KEY_ENCRYPTION_KEY_ID",
+		c.AIProviderKeyEncryptionKeys, c.AIProviderKeyEncryptionKeyID)
+	if err != nil {
+		return ProviderKeyEncryption{}, err
+	}
+	if keyID == "" && len(keys) > 0 {
+		return ProviderKeyEncryption{}, fmt.Errorf("op=config.provider_key_encryption: AI_PROVIDER_KEY_ENCRYPTION_KEY_ID is required with AI_PROVIDER_KEY_ENCRYPTION_KEYS")
+	}
+	return ProviderKeyEncryption{KeyID: keyID, Keys: keys}, nil
+}
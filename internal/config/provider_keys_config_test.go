@@ -0,0 +1,104 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestConfig_GetProviderKeys_LegacyOnly(t *testing.T) {
+	t.Setenv("OPENROUTER_API_KEY", "or1")
+	t.Setenv("OPENROUTER_API_KEY_2", "or2")
+	t.Setenv("GROQ_API_KEY", "g1")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	keys := cfg.GetProviderKeys()
+	want := []ProviderKeySpec{
+		{Provider: "openrouter", Secret: "or1", Weight: 1, State: "active"},
+		{Provider: "openrouter", Secret: "or2", Weight: 1, State: "active"},
+		{Provider: "groq", Secret: "g1", Weight: 1, State: "active"},
+	}
+	if len(keys) != len(want) {
+		t.Fatalf("keys = %+v, want %+v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("keys[%d] = %+v, want %+v", i, keys[i], want[i])
+		}
+	}
+}
+
+func TestConfig_GetProviderKeys_List(t *testing.T) {
+	t.Setenv("GROQ_API_KEY", "g1")
+	t.Setenv("GROQ_API_KEYS", "g1:3:draining, g2:2 ,g3:disabled,,g4:x:y:5")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	cfg.OpenRouterAPIKey, cfg.OpenRouterAPIKey2 = "", ""
+	keys := cfg.GetProviderKeys()
+	want := []ProviderKeySpec{
+		{Provider: "groq", Secret: "g1", Weight: 3, State: "draining"},
+		{Provider: "groq", Secret: "g2", Weight: 2, State: "active"},
+		{Provider: "groq", Secret: "g3", Weight: 1, State: "disabled"},
+		{Provider: "groq", Secret: "g4:x:y", Weight: 5, State: "active"},
+	}
+	if len(keys) != len(want) {
+		t.Fatalf("keys = %+v, want %+v", keys, want)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("keys[%d] = %+v, want %+v", i, keys[i], want[i])
+		}
+	}
+}
+
+func TestConfig_GetProviderKeyBudgets(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	if b := cfg.GetProviderKeyBudgets(); len(b) != 0 {
+		t.Fatalf("expected no budgets by default, got %v", b)
+	}
+	t.Setenv("GROQ_KEY_DAILY_REQUESTS", "1000")
+	t.Setenv("OPENROUTER_KEY_DAILY_TOKENS", "500000")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	b := cfg.GetProviderKeyBudgets()
+	if b["groq"] != (KeyBudget{Requests: 1000}) || b["openrouter"] != (KeyBudget{Tokens: 500000}) {
+		t.Fatalf("unexpected budgets: %v", b)
+	}
+}
+
+func TestConfig_GetProviderKeyEncryption(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	t.Setenv("AI_PROVIDER_KEY_ENCRYPTION_KEYS", "pk1:"+base64.StdEncoding.EncodeToString(key))
+	t.Setenv("AI_PROVIDER_KEY_ENCRYPTION_KEY_ID", "pk1")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	enc, err := cfg.GetProviderKeyEncryption()
+	if err != nil {
+		t.Fatalf("GetProviderKeyEncryption err: %v", err)
+	}
+	if enc.KeyID != "pk1" || !bytes.Equal(enc.Keys["pk1"], key) {
+		t.Fatalf("enc = %+v", enc)
+	}
+
+	// Stored secrets are always sealed, so keys need an active one.
+	cfg.AIProviderKeyEncryptionKeyID = ""
+	if _, err := cfg.GetProviderKeyEncryption(); err == nil || !strings.Contains(err.Error(), "AI_PROVIDER_KEY_ENCRYPTION_KEY_ID is required") {
+		t.Fatalf("err = %v", err)
+	}
+	cfg.AIProviderKeyEncryptionKeyID = "pk2"
+	if _, err := cfg.GetProviderKeyEncryption(); err == nil || !strings.Contains(err.Error(), "not in AI_PROVIDER_KEY_ENCRYPTION_KEYS") {
+		t.Fatalf("err = %v", err)
+	}
+}
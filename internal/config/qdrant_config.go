@@ -0,0 +1,116 @@
+// Package config defines Qdrant collection configuration.
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QdrantCollectionConfig holds the vector layout shared by all RAG collections.
+type QdrantCollectionConfig struct {
+	// VectorSize is the dimension of the unnamed vector (and the default for VectorName)
+	VectorSize int
+	// VectorName selects the named vector used for reads and writes ("" = unnamed)
+	VectorName string
+	// NamedVectors maps vector names to dimensions; empty means a single unnamed vector
+	NamedVectors map[string]int
+	// PayloadIndexes maps payload fields to Qdrant field schemas
+	PayloadIndexes map[string]string
+	// RecreateOnMismatch drops and recreates collections whose vectors differ
+	RecreateOnMismatch bool
+	// Namespace prefixes collection and alias names ("" = no prefix)
+	Namespace string
+}
+
+// GetQdrantCollectionConfig parses the Qdrant collection layout settings.
+// QDRANT_NAMED_VECTORS and QDRANT_PAYLOAD_INDEXES are comma-separated
+// name:value lists; malformed entries are skipped. A QDRANT_VECTOR_NAME
+// missing from QDRANT_NAMED_VECTORS is added with QDRANT_VECTOR_SIZE.
+// QDRANT_NAMESPACE is expanded and sanitized (see qdrantNamespace).
+func (c Config) GetQdrantCollectionConfig() QdrantCollectionConfig {
+	size := c.QdrantVectorSize
+	if size <= 0 {
+		size = 1536
+	}
+	qc := QdrantCollectionConfig{
+		VectorSize:         size,
+		VectorName:         strings.TrimSpace(c.QdrantVectorName),
+		NamedVectors:       map[string]int{},
+		PayloadIndexes:     map[string]string{},
+		RecreateOnMismatch: c.QdrantRecreateOnMismatch,
+		Namespace:          qdrantNamespace(c.QdrantNamespace, c.AppEnv),
+	}
+	for name, v := range parsePairs(c.QdrantNamedVectors) {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			qc.NamedVectors[name] = n
+		}
+	}
+	if qc.VectorName != "" {
+		if _, ok := qc.NamedVectors[qc.VectorName]; !ok {
+			qc.NamedVectors[qc.VectorName] = size
+		}
+	}
+	for field, schema := range parsePairs(c.QdrantPayloadIndexes) {
+		qc.PayloadIndexes[field] = strings.ToLower(schema)
+	}
+	return qc
+}
+
+// QdrantSnapshotConfig holds the Qdrant snapshot schedule.
+type QdrantSnapshotConfig struct {
+	// Collections are the logical collections snapshotted and restorable
+	Collections []string
+	// Interval between scheduled snapshots (0 = on demand only)
+	Interval time.Duration
+	// Keep is how many snapshots per collection are kept in Qdrant
+	Keep int
+	// Upload copies snapshots to the archive sink
+	Upload bool
+}
+
+// GetQdrantSnapshotConfig parses the Qdrant snapshot settings. Keep is at
+// least 1.
+func (c Config) GetQdrantSnapshotConfig() QdrantSnapshotConfig {
+	sc := QdrantSnapshotConfig{Interval: c.QdrantSnapshotInterval, Keep: c.QdrantSnapshotKeep, Upload: c.QdrantSnapshotUpload}
+	if sc.Keep < 1 {
+		sc.Keep = 1
+	}
+	for _, name := range strings.Split(c.QdrantSnapshotCollections, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			sc.Collections = append(sc.Collections, name)
+		}
+	}
+	return sc
+}
+
+// qdrantNamespace expands "{env}" in ns with appEnv and lowercases the
+// result, replacing characters other than letters, digits, '-' and '_' with
+// '_' so it is safe inside a collection name.
+func qdrantNamespace(ns, appEnv string) string {
+	ns = strings.ReplaceAll(strings.TrimSpace(ns), "{env}", strings.TrimSpace(appEnv))
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '_'
+		}
+	}, ns)
+}
+
+// parsePairs parses "a:1,b:2" into a map, skipping malformed entries.
+func parsePairs(s string) map[string]string {
+	out := map[string]string{}
+	for _, part := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(part), ":")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" || v == "" {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package config
+
+import "testing"
+
+func TestConfig_GetQdrantCollectionConfig_Defaults(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	qc := cfg.GetQdrantCollectionConfig()
+	if qc.VectorSize != 1536 || qc.VectorName != "" || len(qc.NamedVectors) != 0 || qc.RecreateOnMismatch {
+		t.Fatalf("unexpected defaults: %+v", qc)
+	}
+	if qc.PayloadIndexes["doc_type"] != "keyword" || qc.PayloadIndexes["posting_id"] != "keyword" {
+		t.Fatalf("unexpected payload indexes: %v", qc.PayloadIndexes)
+	}
+}
+
+func TestConfig_GetQdrantCollectionConfig_NamedVectors(t *testing.T) {
+	t.Setenv("QDRANT_NAMED_VECTORS", "small:1536, nomic:768,bad,zero:0")
+	t.Setenv("QDRANT_VECTOR_NAME", "large")
+	t.Setenv("QDRANT_VECTOR_SIZE", "3072")
+	t.Setenv("QDRANT_PAYLOAD_INDEXES", "weight:FLOAT")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	qc := cfg.GetQdrantCollectionConfig()
+	want := map[string]int{"small": 1536, "nomic": 768, "large": 3072}
+	if len(qc.NamedVectors) != len(want) {
+		t.Fatalf("named vectors = %v, want %v", qc.NamedVectors, want)
+	}
+	for k, v := range want {
+		if qc.NamedVectors[k] != v {
+			t.Fatalf("named vectors = %v, want %v", qc.NamedVectors, want)
+		}
+	}
+	if len(qc.PayloadIndexes) != 1 || qc.PayloadIndexes["weight"] != "float" {
+		t.Fatalf("unexpected payload indexes: %v", qc.PayloadIndexes)
+	}
+}
+
+func TestConfig_GetQdrantCollectionConfig_Namespace(t *testing.T) {
+	t.Setenv("APP_ENV", "Staging")
+	t.Setenv("QDRANT_NAMESPACE", "acme corp-{env}")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	if ns := cfg.GetQdrantCollectionConfig().Namespace; ns != "acme_corp-staging" {
+		t.Fatalf("namespace = %q, want acme_corp-staging", ns)
+	}
+	t.Setenv("QDRANT_NAMESPACE", "")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	if ns := cfg.GetQdrantCollectionConfig().Namespace; ns != "" {
+		t.Fatalf("namespace = %q, want empty", ns)
+	}
+}
+
+func TestConfig_GetQdrantSnapshotConfig(t *testing.T) {
+	t.Setenv("QDRANT_SNAPSHOT_COLLECTIONS", " job_description, ,project_submissions")
+	t.Setenv("QDRANT_SNAPSHOT_KEEP", "0")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	sc := cfg.GetQdrantSnapshotConfig()
+	if len(sc.Collections) != 2 || sc.Collections[0] != "job_description" || sc.Collections[1] != "project_submissions" {
+		t.Fatalf("collections = %v", sc.Collections)
+	}
+	if sc.Keep != 1 || sc.Interval != 0 || sc.Upload {
+		t.Fatalf("unexpected snapshot config: %+v", sc)
+	}
+}
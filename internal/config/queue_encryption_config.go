@@ -0,0 +1,67 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// queueKeySize is the size of the AES-256 keys of QUEUE_ENCRYPTION_KEYS and
+// AI_PROVIDER_KEY_ENCRYPTION_KEYS.
+const queueKeySize = 32
+
+// QueueEncryption configures the encryption of queue payloads.
+type QueueEncryption struct {
+	// KeyID names the key new records are encrypted with; empty produces
+	// plaintext records.
+	KeyID string
+	// Keys holds the AES-256 keys by key id. Records encrypted with any of
+	// them can be read, so retired keys stay listed until their records are
+	// consumed.
+	Keys map[string][]byte
+}
+
+// GetQueueEncryption parses QUEUE_ENCRYPTION_KEYS, comma-separated
+// "id:base64key" entries of 32-byte keys, and checks that
+// QUEUE_ENCRYPTION_KEY_ID names one of them.
+func (c Config) GetQueueEncryption() (QueueEncryption, error) {
+	keyID, keys, err := parseEncryptionKeys("queue_encryption", "QUEUE_ENCRYPTION_KEYS", "QUEUE_ENCRYPTION_KEY_ID", c.QueueEncryptionKeys, c.QueueEncryptionKeyID)
+	if err != nil {
+		return QueueEncryption{}, err
+	}
+	return QueueEncryption{KeyID: keyID, Keys: keys}, nil
+}
+
+// parseEncryptionKeys parses comma-separated "id:base64key" entries of 32-byte
+// AES keys from the variable keysVar and checks that keyID, from idVar, is
+// empty or names one of them.
+func parseEncryptionKeys(op, keysVar, idVar, raw, keyID string) (string, map[string][]byte, error) {
+	keyID = strings.TrimSpace(keyID)
+	var keys map[string][]byte
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, ":")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			return "", nil, fmt.Errorf("op=config.%s: entry %d: want id:base64key", op, len(keys)+1)
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil || len(key) != queueKeySize {
+			return "", nil, fmt.Errorf("op=config.%s: key %q must be %d base64-encoded bytes", op, id, queueKeySize)
+		}
+		if _, dup := keys[id]; dup {
+			return "", nil, fmt.Errorf("op=config.%s: key %q listed twice", op, id)
+		}
+		if keys == nil {
+			keys = map[string][]byte{}
+		}
+		keys[id] = key
+	}
+	if _, ok := keys[keyID]; keyID != "" && !ok {
+		return "", nil, fmt.Errorf("op=config.%s: %s %q is not in %s", op, idVar, keyID, keysVar)
+	}
+	return keyID, keys, nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestConfig_GetQueueEncryption_Unset(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	enc, err := cfg.GetQueueEncryption()
+	if err != nil {
+		t.Fatalf("GetQueueEncryption err: %v", err)
+	}
+	if enc.KeyID != "" || len(enc.Keys) != 0 {
+		t.Fatalf("enc = %+v, want plaintext", enc)
+	}
+}
+
+func TestConfig_GetQueueEncryption_Keys(t *testing.T) {
+	k1 := bytes.Repeat([]byte{1}, 32)
+	k2 := bytes.Repeat([]byte{2}, 32)
+	t.Setenv("QUEUE_ENCRYPTION_KEYS", "k1:"+base64.StdEncoding.EncodeToString(k1)+", k2:"+base64.StdEncoding.EncodeToString(k2)+",")
+	t.Setenv("QUEUE_ENCRYPTION_KEY_ID", "k2")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	enc, err := cfg.GetQueueEncryption()
+	if err != nil {
+		t.Fatalf("GetQueueEncryption err: %v", err)
+	}
+	if enc.KeyID != "k2" || len(enc.Keys) != 2 || !bytes.Equal(enc.Keys["k1"], k1) || !bytes.Equal(enc.Keys["k2"], k2) {
+		t.Fatalf("enc = %+v", enc)
+	}
+}
+
+func TestConfig_GetQueueEncryption_Invalid(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	cases := map[string]struct{ keys, keyID, want string }{
+		"missing id":    {keys: key, want: "want id:base64key"},
+		"short key":     {keys: "k1:" + base64.StdEncoding.EncodeToString([]byte("short")), want: "32 base64-encoded bytes"},
+		"not base64":    {keys: "k1:!!", want: "32 base64-encoded bytes"},
+		"duplicate id":  {keys: "k1:" + key + ",k1:" + key, want: "listed twice"},
+		"unknown keyid": {keys: "k1:" + key, keyID: "k2", want: "not in QUEUE_ENCRYPTION_KEYS"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := Config{QueueEncryptionKeys: tc.keys, QueueEncryptionKeyID: tc.keyID}
+			_, err := cfg.GetQueueEncryption()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("err = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
@@ -19,7 +19,20 @@ type RAGConfig struct {
 
 // RAGYAML represents the structure of RAG config YAML files.
 type RAGYAML struct {
-	Texts []string `yaml:"texts"`
+	Texts []string      `yaml:"texts"`
+	Data  []RAGYAMLItem `yaml:"data"`
+}
+
+// RAGYAMLItem is a data entry of a RAG config YAML file. Entries of type
+// "rubric" are the weighted parameters of a scoring rubric.
+type RAGYAMLItem struct {
+	Text    string `yaml:"text"`
+	Type    string `yaml:"type"`
+	Section string `yaml:"section"`
+	// Group is "cv" or "project" for rubric entries.
+	Group string `yaml:"group"`
+	// Weight is the entry's share of its group as a fraction of 1.
+	Weight float64 `yaml:"weight"`
 }
 
 // LoadRAGConfig loads RAG configuration from YAML files.
@@ -52,42 +65,83 @@ func LoadRAGConfig() (*RAGConfig, error) {
 
 // loadTextFromYAML loads the first text entry from a RAG YAML file.
 func loadTextFromYAML(filePath string) (string, error) {
+	ragYAML, err := readRAGYAML(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	// Extract the first text entry and clean it up
+	if len(ragYAML.Texts) == 0 {
+		return "", fmt.Errorf("no texts found in config file: %s", filePath)
+	}
+
+	// Join all texts with newlines and clean up
+	text := strings.Join(ragYAML.Texts, "\n")
+	text = strings.TrimSpace(text)
+
+	// If the text is too long, we might want to truncate it for the default
+	// For now, we'll use the full text
+	return text, nil
+}
+
+// readRAGYAML reads and parses a RAG YAML file.
+func readRAGYAML(filePath string) (RAGYAML, error) {
 	// Get absolute path to ensure we're reading from the correct location
 	absPath, err := filepath.Abs(filePath)
 	if err != nil {
-		return "", fmt.Errorf("failed to get absolute path: %w", err)
+		return RAGYAML{}, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
 	// Check if file exists
 	if _, err := os.Stat(absPath); os.IsNotExist(err) {
-		return "", fmt.Errorf("config file not found: %s", absPath)
+		return RAGYAML{}, fmt.Errorf("config file not found: %s", absPath)
 	}
 
 	// Read file content
 	// #nosec G304 -- Configuration files are expected to be safe
 	content, err := os.ReadFile(absPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to read config file: %w", err)
+		return RAGYAML{}, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	// Parse YAML
 	var ragYAML RAGYAML
 	if err := yaml.Unmarshal(content, &ragYAML); err != nil {
-		return "", fmt.Errorf("failed to parse YAML: %w", err)
+		return RAGYAML{}, fmt.Errorf("failed to parse YAML: %w", err)
 	}
+	return ragYAML, nil
+}
 
-	// Extract the first text entry and clean it up
-	if len(ragYAML.Texts) == 0 {
-		return "", fmt.Errorf("no texts found in config file: %s", filePath)
+// rubricItems returns the rubric entries of doc.
+func rubricItems(doc RAGYAML) []RAGYAMLItem {
+	var out []RAGYAMLItem
+	for _, it := range doc.Data {
+		if it.Type == "rubric" && strings.TrimSpace(it.Section) != "" {
+			out = append(out, it)
+		}
 	}
+	return out
+}
 
-	// Join all texts with newlines and clean up
-	text := strings.Join(ragYAML.Texts, "\n")
-	text = strings.TrimSpace(text)
+// ParseRubricItems returns the rubric entries of a scoring rubric written in
+// the shape of configs/rag/scoring_rubric.yaml. It returns nil for plain-text
+// rubrics and documents without rubric entries.
+func ParseRubricItems(rubric string) []RAGYAMLItem {
+	var doc RAGYAML
+	if err := yaml.Unmarshal([]byte(rubric), &doc); err != nil {
+		return nil
+	}
+	return rubricItems(doc)
+}
 
-	// If the text is too long, we might want to truncate it for the default
-	// For now, we'll use the full text
-	return text, nil
+// GetDefaultRubricItems returns the rubric entries of
+// configs/rag/scoring_rubric.yaml, or nil when it cannot be loaded.
+func GetDefaultRubricItems() []RAGYAMLItem {
+	doc, err := readRAGYAML("configs/rag/scoring_rubric.yaml")
+	if err != nil {
+		return nil
+	}
+	return rubricItems(doc)
 }
 
 // GetDefaultJobDescription returns the default job description from config.
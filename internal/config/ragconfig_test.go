@@ -316,3 +316,37 @@ func TestGetDefaultScoringRubric_ReturnsValue(t *testing.T) {
 	result := GetDefaultScoringRubric()
 	assert.NotEmpty(t, result)
 }
+
+func TestParseRubricItems(t *testing.T) {
+	items := ParseRubricItems(`
+data:
+  - text: "Leadership (50%): Leads projects."
+    type: rubric
+    section: leadership
+    group: cv
+    weight: 0.5
+  - text: "Backend developer"
+    type: job
+`)
+	require.Len(t, items, 1)
+	assert.Equal(t, RAGYAMLItem{Text: "Leadership (50%): Leads projects.", Type: "rubric", Section: "leadership", Group: "cv", Weight: 0.5}, items[0])
+
+	assert.Nil(t, ParseRubricItems("CV Match Evaluation (1–5 scale per parameter)"))
+	assert.Nil(t, ParseRubricItems("texts: [\"a\"]"))
+}
+
+func TestGetDefaultRubricItems_SeedFile(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(originalDir) }()
+	require.NoError(t, os.Chdir(filepath.Join("..", "..")))
+
+	items := GetDefaultRubricItems()
+	require.Len(t, items, 9)
+	sums := map[string]float64{}
+	for _, it := range items {
+		sums[it.Group] += it.Weight
+	}
+	assert.InDelta(t, 1, sums["cv"], 1e-9)
+	assert.InDelta(t, 1, sums["project"], 1e-9)
+}
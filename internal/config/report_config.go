@@ -0,0 +1,111 @@
+// Package config defines scheduled report and outgoing mail configuration.
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Report periods accepted in REPORT_SCHEDULES.
+const (
+	ReportPeriodDaily  = "daily"
+	ReportPeriodWeekly = "weekly"
+)
+
+// Mail providers accepted by MAIL_PROVIDER.
+const (
+	MailProviderSMTP = "smtp"
+	MailProviderSES  = "ses"
+	MailProviderLog  = "log"
+)
+
+// ReportScheduleSpec describes when one recipient receives an activity report.
+type ReportScheduleSpec struct {
+	// Recipient is the email address receiving the report
+	Recipient string
+	// Period is daily or weekly and sets the window the report covers
+	Period string
+	// Cron is the five-field UTC cron expression of the deliveries
+	Cron string
+}
+
+// MailConfig holds outgoing mail settings.
+type MailConfig struct {
+	// Provider is smtp, ses or log; empty disables mail
+	Provider string
+	// From is the sender address
+	From string
+	// SMTP settings for the smtp provider
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	// SES settings for the ses provider; SESEndpoint overrides the AWS endpoint
+	SESEndpoint        string
+	SESRegion          string
+	SESAccessKeyID     string
+	SESSecretAccessKey string
+}
+
+// GetReportSchedules parses REPORT_SCHEDULES. Entries are
+// "recipient|period|cron" separated by ';'; the period may be omitted
+// ("recipient|cron") and defaults to daily. Entries without a recipient or
+// cron expression, or with an unknown period, are skipped.
+func (c Config) GetReportSchedules() []ReportScheduleSpec {
+	var out []ReportScheduleSpec
+	for _, entry := range strings.Split(c.ReportSchedules, ";") {
+		parts := strings.Split(entry, "|")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		spec := ReportScheduleSpec{Period: ReportPeriodDaily}
+		switch len(parts) {
+		case 2:
+			spec.Recipient, spec.Cron = parts[0], parts[1]
+		case 3:
+			spec.Recipient, spec.Period, spec.Cron = parts[0], strings.ToLower(parts[1]), parts[2]
+		default:
+			continue
+		}
+		if spec.Recipient == "" || spec.Cron == "" || (spec.Period != ReportPeriodDaily && spec.Period != ReportPeriodWeekly) {
+			continue
+		}
+		out = append(out, spec)
+	}
+	return out
+}
+
+// GetReportProviderCosts parses REPORT_PROVIDER_COSTS ("provider=usd,...")
+// into USD per million tokens by provider. Malformed or negative entries are skipped.
+func (c Config) GetReportProviderCosts() map[string]float64 {
+	out := map[string]float64{}
+	for _, entry := range strings.Split(c.ReportProviderCosts, ",") {
+		name, val, ok := strings.Cut(entry, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			continue
+		}
+		usd, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if err != nil || usd < 0 {
+			continue
+		}
+		out[name] = usd
+	}
+	return out
+}
+
+// GetMailConfig returns the outgoing mail configuration.
+func (c Config) GetMailConfig() MailConfig {
+	return MailConfig{
+		Provider:           strings.ToLower(strings.TrimSpace(c.MailProvider)),
+		From:               strings.TrimSpace(c.MailFrom),
+		SMTPHost:           c.SMTPHost,
+		SMTPPort:           c.SMTPPort,
+		SMTPUsername:       c.SMTPUsername,
+		SMTPPassword:       c.SMTPPassword,
+		SESEndpoint:        c.SESEndpoint,
+		SESRegion:          c.SESRegion,
+		SESAccessKeyID:     c.SESAccessKeyID,
+		SESSecretAccessKey: c.SESSecretAccessKey,
+	}
+}
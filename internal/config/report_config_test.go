@@ -0,0 +1,47 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_GetReportSchedules(t *testing.T) {
+	t.Setenv("REPORT_SCHEDULES", "ops@example.com|daily|0 8 * * *; lead@example.com | WEEKLY | 0 8 * * 1 ;cto@example.com|@daily;bad|monthly|0 0 1 * *;|0 8 * * *;junk")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	want := []ReportScheduleSpec{
+		{Recipient: "ops@example.com", Period: "daily", Cron: "0 8 * * *"},
+		{Recipient: "lead@example.com", Period: "weekly", Cron: "0 8 * * 1"},
+		{Recipient: "cto@example.com", Period: "daily", Cron: "@daily"},
+	}
+	if got := cfg.GetReportSchedules(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("schedules = %+v, want %+v", got, want)
+	}
+}
+
+func TestConfig_GetReportProviderCosts(t *testing.T) {
+	t.Setenv("REPORT_PROVIDER_COSTS", "OpenRouter=0.5, groq=0,acme=-1,bad,x=y")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	want := map[string]float64{"openrouter": 0.5, "groq": 0}
+	if got := cfg.GetReportProviderCosts(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("costs = %v, want %v", got, want)
+	}
+}
+
+func TestConfig_GetMailConfig(t *testing.T) {
+	t.Setenv("MAIL_PROVIDER", " SES ")
+	t.Setenv("MAIL_FROM", "reports@example.com")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load err: %v", err)
+	}
+	mc := cfg.GetMailConfig()
+	if mc.Provider != MailProviderSES || mc.From != "reports@example.com" || mc.SMTPPort != 587 || mc.SESRegion != "us-east-1" {
+		t.Fatalf("unexpected mail config: %+v", mc)
+	}
+}
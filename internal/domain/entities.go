@@ -4,6 +4,7 @@ package domain
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 )
 
@@ -17,8 +18,32 @@ var (
 	ErrUpstreamRateLimit = errors.New("upstream rate limit")
 	ErrSchemaInvalid     = errors.New("schema invalid")
 	ErrInternal          = errors.New("internal error")
+	ErrQuotaExceeded     = errors.New("quota exceeded")
+	ErrMaintenance       = errors.New("under maintenance")
+	ErrBacklogFull       = errors.New("evaluation backlog full")
+	ErrAISaturated       = errors.New("ai capacity saturated")
 )
 
+// RetryAfterError wraps an error whose request may succeed when retried
+// after After.
+type RetryAfterError struct {
+	Err   error
+	After time.Duration
+}
+
+func (e *RetryAfterError) Error() string { return e.Err.Error() }
+
+func (e *RetryAfterError) Unwrap() error { return e.Err }
+
+// RetryAfter returns the delay of the first RetryAfterError in err's chain.
+func RetryAfter(err error) (time.Duration, bool) {
+	var ra *RetryAfterError
+	if errors.As(err, &ra) {
+		return ra.After, true
+	}
+	return 0, false
+}
+
 // UploadType enumerates upload types
 // UploadType is a string constant that represents the type of upload.
 const (
@@ -28,6 +53,14 @@ const (
 	UploadTypeProject = "project"
 )
 
+// Upload sources: how an upload's text reached the service.
+const (
+	// UploadSourceFile is the source of text extracted from uploaded files.
+	UploadSourceFile = "file"
+	// UploadSourceText is the source of text submitted as is.
+	UploadSourceText = "text"
+)
+
 // Upload represents stored text and metadata for CV or Project
 // Invariants: Type in {cv, project}; Size <= Max; Text sanitized and non-empty
 //
@@ -45,6 +78,8 @@ type Upload struct {
 	MIME string
 	// Size is the size of the upload in bytes.
 	Size int64
+	// Source is how the text was submitted (file or text); empty means file.
+	Source string
 	// CreatedAt is the timestamp when the upload was created.
 	CreatedAt time.Time
 }
@@ -63,6 +98,9 @@ const (
 	JobCompleted JobStatus = "completed"
 	// JobFailed is the status when a job fails.
 	JobFailed JobStatus = "failed"
+	// JobExpired is the status when a job passed its expiry before it was
+	// processed, or its result is no longer served.
+	JobExpired JobStatus = "expired"
 )
 
 // Job is the domain model for an evaluation job.
@@ -83,6 +121,80 @@ type Job struct {
 	ProjectID string
 	// IdemKey is the idempotency key for the job.
 	IdemKey *string
+	// SecurityNotes records security findings about the job's inputs, such as
+	// detected prompt-injection attempts.
+	SecurityNotes []string
+	// RequestID is the X-Request-Id of the HTTP request that created the job.
+	RequestID string
+	// ExpiresAt is when the job expires: a queued job is no longer processed
+	// and a completed job's result is no longer served. Nil never expires.
+	ExpiresAt *time.Time
+	// Metadata holds client-supplied key/value pairs, such as a team or an
+	// external requisition id, echoed with the job's result.
+	Metadata map[string]string
+	// Tags are client-supplied labels, such as a campaign, echoed with the
+	// job's result.
+	Tags []string
+}
+
+// Expired reports whether the job's expiry passed at now.
+func (j Job) Expired(now time.Time) bool {
+	return j.ExpiresAt != nil && !now.Before(*j.ExpiresAt)
+}
+
+// JobSortOrder selects the ordering used by keyset job listings.
+type JobSortOrder string
+
+// Job listing sort orders.
+const (
+	// JobSortCreatedDesc lists newest jobs first (default).
+	JobSortCreatedDesc JobSortOrder = "created_at_desc"
+	// JobSortCreatedAsc lists oldest jobs first.
+	JobSortCreatedAsc JobSortOrder = "created_at_asc"
+)
+
+// JobCursor marks the last row of a keyset page; the next page starts strictly
+// after (CreatedAt, ID) in the requested sort order.
+type JobCursor struct {
+	// CreatedAt is the creation timestamp of the last returned job.
+	CreatedAt time.Time
+	// ID is the ID of the last returned job, used as a tiebreaker.
+	ID string
+}
+
+// JobTask is the evaluation task recorded on a job.
+type JobTask struct {
+	// JobID is the ID of the job.
+	JobID string
+	// CreatedAt is the creation timestamp of the job.
+	CreatedAt time.Time
+	// Payload is the task the job was last enqueued with.
+	Payload EvaluateTaskPayload
+}
+
+// JobListFilter describes a keyset-paginated job listing query.
+type JobListFilter struct {
+	// Status restricts results to a single status when non-empty.
+	Status string
+	// Search matches job, CV, or project IDs by substring when non-empty.
+	Search string
+	// RequestID restricts results to jobs created by this request when non-empty.
+	RequestID string
+	// CreatedFrom restricts results to jobs created at or after this time when non-zero.
+	CreatedFrom time.Time
+	// CreatedTo restricts results to jobs created before this time when non-zero.
+	CreatedTo time.Time
+	// Tags restricts results to jobs carrying all of these tags when non-empty.
+	Tags []string
+	// Metadata restricts results to jobs whose metadata contains all of these
+	// pairs when non-empty.
+	Metadata map[string]string
+	// Sort is the ordering; empty means JobSortCreatedDesc.
+	Sort JobSortOrder
+	// After is the cursor of the previous page; nil starts from the beginning.
+	After *JobCursor
+	// Limit is the maximum number of jobs returned.
+	Limit int
 }
 
 // Result stores the evaluation output for a job.
@@ -99,10 +211,421 @@ type Result struct {
 	ProjectFeedback string
 	// OverallSummary is the overall summary of the evaluation.
 	OverallSummary string
+	// CVOnly marks the result of a CV-only evaluation, which leaves
+	// ProjectScore and ProjectFeedback unset.
+	CVOnly bool
+	// ProjectOnly marks the result of a project-only evaluation, which leaves
+	// CVMatchRate and CVFeedback unset.
+	ProjectOnly bool
+	// ScoringWeights are the custom rubric weights the scores were computed
+	// with; nil when the default weights applied.
+	ScoringWeights ScoringWeights
+	// Normalization records the model-specific adjustment applied to the
+	// scores; nil when the raw model scores were kept.
+	Normalization *ScoreNormalization
+	// Provenance lists, in order, the provider, model and prompt template
+	// that produced each step of the evaluation; nil for results stored
+	// before provenance was recorded.
+	Provenance []StepProvenance
+	// Similarity compares the project report with the tenant's earlier
+	// submissions; nil when similarity detection did not run.
+	Similarity *SimilarityReport
+	// Encryption is set when the feedback fields hold ciphertext for the
+	// tenant's public key instead of plain text.
+	Encryption *ResultEncryption
+	// Language reports that the job description and the CV were detected in
+	// different languages; nil when they match or either is unknown.
+	Language *LanguageMismatch
 	// CreatedAt is the timestamp when the result was created.
 	CreatedAt time.Time
 }
 
+// ResultEncryption describes how a result's feedback fields were encrypted.
+// Each field is the base64 of a GCM nonce followed by the ciphertext, sealed
+// with a per-result data key and "<job_id>:<field>" as additional data.
+type ResultEncryption struct {
+	// KeyID identifies the tenant public key that wrapped the data key.
+	KeyID string `json:"key_id"`
+	// Algorithm names the key wrapping and content encryption scheme.
+	Algorithm string `json:"algorithm"`
+	// WrappedKey is the base64 of the data key encrypted with the public key.
+	WrappedKey string `json:"wrapped_key"`
+}
+
+// ScoreNormalization describes how a result's scores were normalized against
+// the score distribution of the model that produced them.
+type ScoreNormalization struct {
+	// Model is the AI model that produced the raw scores.
+	Model string `json:"model"`
+	// Method is the normalization method, e.g. "zscore" or "quantile".
+	Method string `json:"method"`
+	// RawCVMatchRate and RawProjectScore are the scores before
+	// normalization; nil when the metric was not adjusted.
+	RawCVMatchRate  *float64 `json:"raw_cv_match_rate,omitempty"`
+	RawProjectScore *float64 `json:"raw_project_score,omitempty"`
+}
+
+// StepProvenance records what produced one evaluation step: the AI provider,
+// the model that served the call and the version of the step's prompt
+// template.
+type StepProvenance struct {
+	Step          string `json:"step"`
+	Provider      string `json:"provider"`
+	Model         string `json:"model"`
+	PromptVersion string `json:"prompt_version,omitempty"`
+	// ModelArm is the model A/B arm of the job, ModelArmControl or
+	// ModelArmChallenger; empty when model routing is off.
+	ModelArm string `json:"model_arm,omitempty"`
+	// FastPath is why the single-prompt fast path ran instead of the
+	// multi-step chain, one of the FastPath* reasons; empty for chain steps.
+	FastPath string `json:"fast_path,omitempty"`
+	// RequestedModel is the model the step's call asked for when the
+	// provider substituted Model for it; empty otherwise.
+	RequestedModel string `json:"requested_model,omitempty"`
+}
+
+// Reasons the single-prompt fast path ran.
+const (
+	// FastPathFallback: a step of the multi-step chain failed.
+	FastPathFallback = "fallback"
+	// FastPathInteractive: the request set priority interactive.
+	FastPathInteractive = "interactive"
+	// FastPathQueueLag: the job waited in the queue past the lag budget.
+	FastPathQueueLag = "queue_lag"
+	// FastPathProviderLatency: recent AI calls were slower than the latency
+	// budget.
+	FastPathProviderLatency = "provider_latency"
+)
+
+// Job priorities a request may set.
+const (
+	// PriorityBatch evaluates with the multi-step chain unless the worker is
+	// behind (default).
+	PriorityBatch = "batch"
+	// PriorityInteractive evaluates with the single-prompt fast path.
+	PriorityInteractive = "interactive"
+)
+
+// Feedback formats a request may set.
+const (
+	// FeedbackFormatText is plain prose (default).
+	FeedbackFormatText = "text"
+	// FeedbackFormatMarkdown structures the feedback with Markdown headings
+	// and bullet lists.
+	FeedbackFormatMarkdown = "markdown"
+)
+
+// FeedbackLanguages maps the ISO 639-1 codes a request may set as its
+// feedback language to the language name given to the model. English is the
+// default and is not carried in jobs.
+var FeedbackLanguages = map[string]string{
+	"en": "English",
+	"id": "Indonesian",
+	"es": "Spanish",
+	"pt": "Portuguese",
+	"fr": "French",
+	"de": "German",
+	"it": "Italian",
+	"nl": "Dutch",
+	"ja": "Japanese",
+	"zh": "Chinese (Simplified)",
+}
+
+// Model A/B arms.
+const (
+	ModelArmControl    = "control"
+	ModelArmChallenger = "challenger"
+)
+
+// SimilarityReport lists the earlier project submissions that reached the
+// similarity threshold against a result's project report, most similar
+// first. Flagged marks the submission for reviewer attention.
+type SimilarityReport struct {
+	Flagged bool           `json:"flagged"`
+	Matches []SimilarMatch `json:"matches"`
+}
+
+// LanguageMismatch records the languages detected in a job's job
+// description and CV when they differ. Translated reports that the job
+// description was translated into the CV's language for prompting.
+type LanguageMismatch struct {
+	JobDescription string `json:"job_description"`
+	CV             string `json:"cv"`
+	Translated     bool   `json:"translated"`
+}
+
+// SimilarMatch references an earlier submission and its cosine similarity to
+// the compared project report.
+type SimilarMatch struct {
+	JobID string  `json:"job_id"`
+	Score float64 `json:"score"`
+}
+
+// Score metrics tracked per model for normalization.
+const (
+	ScoreMetricCVMatchRate  = "cv_match_rate"
+	ScoreMetricProjectScore = "project_score"
+)
+
+// ScoreDistribution summarises the scores one model produced for one metric.
+type ScoreDistribution struct {
+	Model  string
+	Metric string
+	// Count, Sum and SumSquares give the mean and standard deviation.
+	Count      int64
+	Sum        float64
+	SumSquares float64
+	// Buckets is a histogram over the metric's range in equal-width buckets.
+	Buckets []int64
+}
+
+// ProviderKeyState is the rotation state of an AI provider API key.
+type ProviderKeyState string
+
+const (
+	// ProviderKeyActive keys are rotated across for new requests.
+	ProviderKeyActive ProviderKeyState = "active"
+	// ProviderKeyDraining keys are only used when no active key is usable,
+	// giving an old key an overlap window before it is disabled.
+	ProviderKeyDraining ProviderKeyState = "draining"
+	// ProviderKeyDisabled keys are never used.
+	ProviderKeyDisabled ProviderKeyState = "disabled"
+)
+
+// Valid reports whether s is a known provider key state.
+func (s ProviderKeyState) Valid() bool {
+	return s == ProviderKeyActive || s == ProviderKeyDraining || s == ProviderKeyDisabled
+}
+
+// ProviderKey is an AI provider API key participating in key rotation.
+type ProviderKey struct {
+	// ID is a stable, non-secret identifier derived from the secret.
+	ID string
+	// Provider is the AI provider the key belongs to (e.g. openrouter, groq).
+	Provider string
+	// Secret is the API key itself.
+	Secret string
+	// Weight is the relative share of requests among active keys.
+	Weight int
+	// State is the rotation state of the key.
+	State ProviderKeyState
+	// CreatedAt is the timestamp when the key was added.
+	CreatedAt time.Time
+	// UpdatedAt is the timestamp of the last state or weight change.
+	UpdatedAt time.Time
+}
+
+// KeyUsage is the number of requests and tokens a provider key consumed on
+// one UTC day.
+type KeyUsage struct {
+	// KeyID identifies the provider key (see ProviderKey.ID).
+	KeyID string
+	// Day is the UTC day the usage belongs to, truncated to midnight.
+	Day time.Time
+	// Requests is the number of completed provider calls.
+	Requests int64
+	// Tokens is the number of prompt and completion tokens.
+	Tokens int64
+}
+
+// ModelLimit is the rate limit of a provider model, discovered from the
+// provider's x-ratelimit-* response headers.
+type ModelLimit struct {
+	// Provider is the AI provider, e.g. "groq".
+	Provider string
+	// Model is the provider's model ID.
+	Model string
+	// RequestsPerDay is the advertised daily request limit; 0 if unknown.
+	RequestsPerDay int64
+	// TokensPerMinute is the advertised per-minute token limit; 0 if unknown.
+	TokensPerMinute int64
+	// UpdatedAt is when the limit was last observed.
+	UpdatedAt time.Time
+}
+
+// EvaluationSample is a completed evaluation with the document features the
+// bias audit segments by. It never carries protected attributes.
+type EvaluationSample struct {
+	// JobID is the evaluated job.
+	JobID string
+	// CVExcerpt is the beginning of the CV text, used for language detection.
+	CVExcerpt string
+	// CVLength is the length of the CV text in characters.
+	CVLength int
+	// ProjectLength is the length of the project report text in characters.
+	ProjectLength int
+	// CVMatchRate is the stored CV match rate.
+	CVMatchRate float64
+	// ProjectScore is the stored project score.
+	ProjectScore float64
+	// CVOnly marks an evaluation without a project; ProjectLength and
+	// ProjectScore are zero and not counted.
+	CVOnly bool
+	// ProjectOnly marks an evaluation without a CV; CVExcerpt, CVLength and
+	// CVMatchRate are zero and not counted.
+	ProjectOnly bool
+	// Anonymized reports whether contact details were redacted before
+	// scoring; nil when the job kept no task to tell.
+	Anonymized *bool
+	// CreatedAt is when the result was stored.
+	CreatedAt time.Time
+}
+
+// BiasSegment aggregates the scores of one segment of a bias audit, e.g. all
+// evaluations whose CV is written in Indonesian.
+type BiasSegment struct {
+	// Dimension is what the segment is cut by: language, cv_length,
+	// project_length or anonymization.
+	Dimension string
+	// Value is the segment within the dimension, e.g. "id" or "2k-5k".
+	Value string
+	// Count is the number of evaluations in the segment.
+	Count int
+	// AvgCVMatchRate and AvgProjectScore are the segment's mean scores.
+	AvgCVMatchRate  float64
+	AvgProjectScore float64
+	// CVMatchRateDelta and ProjectScoreDelta are the differences from the overall means.
+	CVMatchRateDelta  float64
+	ProjectScoreDelta float64
+	// CVMatchRateDrift and ProjectScoreDrift are the changes of the segment
+	// means since the previous report; nil when the segment is new.
+	CVMatchRateDrift  *float64
+	ProjectScoreDrift *float64
+	// Flagged marks segments large enough to judge whose delta exceeds the threshold.
+	Flagged bool
+}
+
+// BiasReport is a periodic audit of how scores differ between segments of
+// evaluations.
+type BiasReport struct {
+	// ID is the unique identifier of the report.
+	ID string
+	// GeneratedAt is when the report was produced.
+	GeneratedAt time.Time
+	// WindowStart and WindowEnd bound the evaluations included.
+	WindowStart time.Time
+	WindowEnd   time.Time
+	// SampleCount is the number of evaluations included.
+	SampleCount int
+	// AvgCVMatchRate and AvgProjectScore are the overall mean scores.
+	AvgCVMatchRate  float64
+	AvgProjectScore float64
+	// Segments holds the per-segment aggregates.
+	Segments []BiasSegment
+}
+
+// ActivitySummary aggregates job, score and provider usage figures over a
+// reporting window.
+type ActivitySummary struct {
+	// WindowStart and WindowEnd bound the summarized activity.
+	WindowStart time.Time
+	WindowEnd   time.Time
+	// JobsByStatus counts the jobs last updated in the window by status.
+	JobsByStatus map[JobStatus]int64
+	// ResultCount is the number of results stored in the window.
+	ResultCount int64
+	// AvgCVMatchRate and AvgProjectScore are the mean scores of those results.
+	AvgCVMatchRate  float64
+	AvgProjectScore float64
+	// KeyUsage holds the summed usage of each provider key; Day is unset.
+	KeyUsage []KeyUsage
+}
+
+// UsageStats are anonymous operational stats of a deployment over a window.
+// They carry no tenant, job or candidate data.
+type UsageStats struct {
+	// DeploymentID is the operator-chosen label of the deployment.
+	DeploymentID string    `json:"deployment_id,omitempty"`
+	WindowStart  time.Time `json:"window_start"`
+	WindowEnd    time.Time `json:"window_end"`
+	// Jobs is the number of jobs created in the window.
+	Jobs       int64   `json:"jobs"`
+	JobsPerDay float64 `json:"jobs_per_day"`
+	Completed  int64   `json:"completed"`
+	Failed     int64   `json:"failed"`
+	// FailureRatio is the share of completed or failed jobs that failed.
+	FailureRatio float64 `json:"failure_ratio"`
+	// MeanLatencySeconds is the mean time from submission to completion.
+	MeanLatencySeconds float64 `json:"mean_latency_seconds"`
+	// ModelMix counts the evaluation steps served by each model.
+	ModelMix map[string]int64 `json:"model_mix"`
+}
+
+// Email is a plain-text message sent by a Mailer.
+type Email struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Operational events posted by a Notifier.
+const (
+	// NotifyJobFailureSpike reports many jobs failing within a short window.
+	NotifyJobFailureSpike = "job_failure_spike"
+	// NotifyDLQGrowth reports many jobs moved to the DLQ within a short window.
+	NotifyDLQGrowth = "dlq_growth"
+	// NotifyProviderCircuitOpen reports an external provider's circuit opening.
+	NotifyProviderCircuitOpen = "provider_circuit_open"
+	// NotifySweeperAction reports stuck jobs failed by the sweeper.
+	NotifySweeperAction = "sweeper_action"
+)
+
+// Notification is an operational event posted to chat channels.
+type Notification struct {
+	// Event is one of the Notify* event names.
+	Event string
+	// Subject tells notifications of one event apart for rate limiting,
+	// e.g. the provider endpoint; it may be empty.
+	Subject string
+	// Data is passed to the event's message template.
+	Data map[string]any
+}
+
+// MaintenanceMode selects how new evaluation requests are handled.
+type MaintenanceMode string
+
+const (
+	// MaintenanceOff accepts and queues evaluations normally.
+	MaintenanceOff MaintenanceMode = "off"
+	// MaintenanceReject refuses evaluations; clients retry after RetryAfter.
+	MaintenanceReject MaintenanceMode = "reject"
+	// MaintenanceDefer accepts evaluations but holds them back from the
+	// queue until maintenance ends.
+	MaintenanceDefer MaintenanceMode = "defer"
+)
+
+// Valid reports whether m is a known maintenance mode.
+func (m MaintenanceMode) Valid() bool {
+	return m == MaintenanceOff || m == MaintenanceReject || m == MaintenanceDefer
+}
+
+// VectorSnapshot is a point-in-time copy of a vector collection.
+type VectorSnapshot struct {
+	Collection string
+	Name       string
+	CreatedAt  time.Time
+	Size       int64
+	// StorageKey is the object storage key the snapshot was copied to, if any.
+	StorageKey string
+}
+
+// MaintenanceState is the maintenance mode shared by all server processes.
+type MaintenanceState struct {
+	// Mode is the current maintenance mode.
+	Mode MaintenanceMode
+	// RetryAfter is advertised to clients whose evaluations are rejected.
+	RetryAfter time.Duration
+	// Message explains the maintenance to clients; it may be empty.
+	Message string
+	// UpdatedAt is when the state was last changed.
+	UpdatedAt time.Time
+}
+
+// Active reports whether maintenance is on.
+func (s MaintenanceState) Active() bool {
+	return s.Mode == MaintenanceReject || s.Mode == MaintenanceDefer
+}
+
 // Repositories (ports)
 
 // UploadRepository is responsible for managing uploads.
@@ -125,6 +648,13 @@ type JobRepository interface {
 	UpdateStatus(ctx Context, id string, status JobStatus, errMsg *string) error
 	// Get retrieves a job by ID.
 	Get(ctx Context, id string) (Job, error)
+	// AddSecurityNote appends a security note to a job.
+	AddSecurityNote(ctx Context, id string, note string) error
+	// Task returns the evaluation task the job was last queued with, or
+	// ErrNotFound when none was recorded.
+	Task(ctx Context, id string) (EvaluateTaskPayload, error)
+	// GetMany retrieves all jobs matching the given IDs; unknown IDs are skipped.
+	GetMany(ctx Context, ids []string) ([]Job, error)
 	// FindByIdempotencyKey finds a job by idempotency key.
 	FindByIdempotencyKey(ctx Context, key string) (Job, error)
 	// Count returns the total number of jobs.
@@ -137,6 +667,10 @@ type JobRepository interface {
 	ListWithFilters(ctx Context, offset, limit int, search, status string) ([]Job, error)
 	// CountWithFilters returns the total count of jobs with search and status filtering.
 	CountWithFilters(ctx Context, search, status string) (int64, error)
+	// ListByCursor returns a keyset-paginated list of jobs matching the filter.
+	ListByCursor(ctx Context, f JobListFilter) ([]Job, error)
+	// CountByFilter returns the number of jobs matching the filter, ignoring its cursor and limit.
+	CountByFilter(ctx Context, f JobListFilter) (int64, error)
 	// GetAverageProcessingTime returns the average processing time for completed jobs.
 	GetAverageProcessingTime(ctx Context) (float64, error)
 }
@@ -145,8 +679,682 @@ type JobRepository interface {
 type ResultRepository interface {
 	// Upsert upserts a result.
 	Upsert(ctx Context, r Result) error
+	// UpsertFenced upserts a result only while token is the fencing token of
+	// the job's lease (see JobLockRepository), checked in the same statement
+	// as the write. It returns ErrConflict once the lease was taken over.
+	UpsertFenced(ctx Context, r Result, token int64) error
 	// GetByJobID retrieves a result by job ID.
 	GetByJobID(ctx Context, jobID string) (Result, error)
+	// GetByJobIDs retrieves results for the given job IDs; jobs without results are skipped.
+	GetByJobIDs(ctx Context, jobIDs []string) ([]Result, error)
+}
+
+// ResultVersionRepository reads the earlier versions of job results. Every
+// stored result of a job, including re-evaluations, is kept as a version
+// numbered from 1.
+type ResultVersionRepository interface {
+	// LatestVersion returns the number of the newest version of a job's
+	// result, or ErrNotFound when none was stored.
+	LatestVersion(ctx Context, jobID string) (int, error)
+	// GetVersion returns one version of a job's result, or ErrNotFound.
+	GetVersion(ctx Context, jobID string, version int) (Result, error)
+}
+
+// BiasAuditRepository supplies evaluation samples to the bias audit and stores its reports.
+type BiasAuditRepository interface {
+	// ListSamples returns completed evaluations stored in [from, to).
+	ListSamples(ctx Context, from, to time.Time) ([]EvaluationSample, error)
+	// SaveReport stores a report.
+	SaveReport(ctx Context, r BiasReport) error
+	// LatestReport returns the most recent report, or ErrNotFound.
+	LatestReport(ctx Context) (BiasReport, error)
+}
+
+// ScoreStatsRepository tracks per-model score distributions.
+type ScoreStatsRepository interface {
+	// Observe adds value to the model's distribution of metric, counting it
+	// in histogram bucket (0-based) of buckets. Each job's metric is counted
+	// once: a retried or redelivered job observing it again is a no-op.
+	Observe(ctx Context, jobID, model, metric string, value float64, bucket, buckets int) error
+	// List returns the distributions of metric for all models.
+	List(ctx Context, metric string) ([]ScoreDistribution, error)
+}
+
+// ReportRepository supplies the figures of scheduled activity reports.
+type ReportRepository interface {
+	// Summary aggregates the activity in [from, to).
+	Summary(ctx Context, from, to time.Time) (ActivitySummary, error)
+	// ClaimDelivery records that the report of recipient scheduled at
+	// scheduledFor is being sent. It returns false when another process
+	// already claimed it.
+	ClaimDelivery(ctx Context, recipient string, scheduledFor time.Time) (bool, error)
+	// ReleaseDelivery drops the claim of a delivery that could not be sent,
+	// so that a later run sends it.
+	ReleaseDelivery(ctx Context, recipient string, scheduledFor time.Time) error
+}
+
+// UsageStatsRepository aggregates anonymous usage stats.
+type UsageStatsRepository interface {
+	// UsageStats aggregates the jobs created and results stored in [from, to).
+	UsageStats(ctx Context, from, to time.Time) (UsageStats, error)
+}
+
+// MaintenanceRepository persists the maintenance state and the evaluations
+// deferred while it is on.
+type MaintenanceRepository interface {
+	// Get returns the stored state, or MaintenanceOff when none is stored.
+	Get(ctx Context) (MaintenanceState, error)
+	// Set stores s.
+	Set(ctx Context, s MaintenanceState) error
+	// CreateDeferred inserts j and holds its task p back from the queue in
+	// one transaction and returns the job id. p is recorded on the job like
+	// a task queued through the outbox.
+	CreateDeferred(ctx Context, j Job, p EvaluateTaskPayload) (string, error)
+	// ReleaseDeferred moves up to limit deferred tasks, oldest first, to the
+	// evaluate outbox and returns how many it moved. Each task leaves the
+	// deferred set and enters the outbox atomically; concurrent callers move
+	// disjoint tasks.
+	ReleaseDeferred(ctx Context, limit int) (int, error)
+	// Deferred reports whether the task of job jobID is held back.
+	Deferred(ctx Context, jobID string) (bool, error)
+	// CountDeferred returns the number of deferred payloads.
+	CountDeferred(ctx Context) (int64, error)
+}
+
+// ConsumerPause stops every worker from polling a queue topic until the
+// topic is resumed. Jobs already fetched are finished.
+type ConsumerPause struct {
+	Topic    string
+	Reason   string
+	PausedBy string
+	PausedAt time.Time
+}
+
+// ConsumerPauseRepository persists the paused queue topics shared by all
+// workers.
+type ConsumerPauseRepository interface {
+	// List returns the paused topics.
+	List(ctx Context) ([]ConsumerPause, error)
+	// Pause stores p, replacing an existing pause of the topic.
+	Pause(ctx Context, p ConsumerPause) error
+	// Resume removes the pause of topic and reports whether it was paused.
+	Resume(ctx Context, topic string) (bool, error)
+}
+
+// JobBump records that an admin moved a queued job ahead of the backlog.
+type JobBump struct {
+	JobID    string
+	BumpedBy string
+	Reason   string
+	BumpedAt time.Time
+}
+
+// JobQueueRepository reports the order of queued jobs and reprioritizes them.
+// Bumped jobs are ahead of the others, in the order they were bumped; the
+// others are in the order they were created.
+type JobQueueRepository interface {
+	// Bump records b and an outbox entry for the bumped task p in one
+	// transaction. It returns ErrConflict when the job is not queued or was
+	// already bumped.
+	Bump(ctx Context, b JobBump, p EvaluateTaskPayload) error
+	// Position returns how many queued jobs are ahead of jobID.
+	Position(ctx Context, jobID string) (int64, error)
+	// FinishedSince returns how many jobs completed or failed since since.
+	FinishedSince(ctx Context, since time.Time) (int64, error)
+}
+
+// JobBulkAction is what a bulk operation does to each matching job.
+type JobBulkAction string
+
+// Bulk operation actions.
+const (
+	// JobBulkRequeue requeues processing and failed jobs from their outbox task.
+	JobBulkRequeue JobBulkAction = "requeue"
+	// JobBulkCancel fails queued and processing jobs as cancelled.
+	JobBulkCancel JobBulkAction = "cancel"
+	// JobBulkFail fails queued and processing jobs.
+	JobBulkFail JobBulkAction = "fail"
+)
+
+// JobBulkFilter selects the jobs of a bulk operation.
+type JobBulkFilter struct {
+	// Status is the status of the jobs; required.
+	Status JobStatus
+	// OlderThan restricts the operation to jobs last updated at least that
+	// long before it started when non-zero.
+	OlderThan time.Duration
+	// FailureReason restricts failed jobs to those whose error code matches,
+	// e.g. rate_limit for UPSTREAM_RATE_LIMIT, when non-empty.
+	FailureReason string
+}
+
+// Bulk operation states.
+const (
+	JobBulkRunning   = "running"
+	JobBulkCompleted = "completed"
+)
+
+// JobBulkOperation is an admin action applied to every job matching a
+// filter in the background, with its progress.
+type JobBulkOperation struct {
+	ID        string
+	Action    JobBulkAction
+	Filter    JobBulkFilter
+	Reason    string
+	StartedBy string
+	// Status is JobBulkRunning until every matched job was handled, then
+	// JobBulkCompleted.
+	Status string
+	// Matched is the number of jobs the filter selected when the operation
+	// started; Truncated reports that more matched than one operation takes.
+	Matched   int
+	Truncated bool
+	// Processed counts the matched jobs handled so far: Succeeded were
+	// changed, Skipped no longer matched when their turn came and Failed
+	// could not be changed.
+	Processed int
+	Succeeded int
+	Skipped   int
+	Failed    int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// FinishedAt is nil while the operation runs.
+	FinishedAt *time.Time
+}
+
+// JobBulkRepository selects and transitions the jobs of bulk operations and
+// stores the operations.
+type JobBulkRepository interface {
+	// Candidates returns up to limit jobs in status last updated before
+	// before, least recently updated first; 0 is unlimited.
+	Candidates(ctx Context, status JobStatus, before time.Time, limit int) ([]Job, error)
+	// Fail moves job id from status from to failed with errMsg unless it
+	// changed since before; it reports whether the job was moved.
+	Fail(ctx Context, id string, from JobStatus, before time.Time, errMsg string) (bool, error)
+	// CreateOperation stores op with a new ID and returns it.
+	CreateOperation(ctx Context, op JobBulkOperation) (JobBulkOperation, error)
+	// UpdateOperation stores the progress and status of op.
+	UpdateOperation(ctx Context, op JobBulkOperation) error
+	// GetOperation returns an operation by ID, or ErrNotFound.
+	GetOperation(ctx Context, id string) (JobBulkOperation, error)
+	// ListOperations returns the latest limit operations, newest first.
+	ListOperations(ctx Context, limit int) ([]JobBulkOperation, error)
+}
+
+// Legal hold targets.
+const (
+	LegalHoldUpload = "upload"
+	LegalHoldJob    = "job"
+)
+
+// LegalHold is a change of the legal hold on an upload or a job. Held records
+// are kept by data retention until the hold is released; a held upload also
+// keeps the jobs that evaluated it.
+type LegalHold struct {
+	// TargetType is LegalHoldUpload or LegalHoldJob.
+	TargetType string
+	TargetID   string
+	// Held is true when the hold was placed and false when it was released.
+	Held bool
+	// Actor is the admin who made the change and Reason why.
+	Actor     string
+	Reason    string
+	CreatedAt time.Time
+}
+
+// LegalHoldRepository sets legal holds and keeps an audit trail of them.
+type LegalHoldRepository interface {
+	// Set places or releases the hold on h's target and records h. It returns
+	// ErrNotFound when the target does not exist.
+	Set(ctx Context, h LegalHold) error
+	// History returns the recorded changes of a target, newest first.
+	History(ctx Context, targetType, targetID string) ([]LegalHold, error)
+	// ListHeld returns the change that placed each current hold, newest
+	// first.
+	ListHeld(ctx Context) ([]LegalHold, error)
+}
+
+// Accessor types of a ResultAccess.
+const (
+	AccessorAPIKey    = "api_key"
+	AccessorAdmin     = "admin"
+	AccessorAnonymous = "anonymous"
+)
+
+// ResultAccess records one read of a job's result, for tenants that must
+// account for every access to candidate data.
+type ResultAccess struct {
+	JobID string
+	// AccessorType is AccessorAPIKey, AccessorAdmin or AccessorAnonymous.
+	AccessorType string
+	// Accessor is the SHA-256 of the API key, as stored in tenant settings,
+	// or the admin's SSO user or JWT subject; empty for anonymous reads.
+	Accessor   string
+	IP         string
+	Endpoint   string
+	AccessedAt time.Time
+}
+
+// AccessLogFilter narrows an access log query. Empty fields match anything.
+type AccessLogFilter struct {
+	JobID    string
+	Accessor string
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+}
+
+// AccessLogRepository stores reads of results.
+type AccessLogRepository interface {
+	Record(ctx Context, entries []ResultAccess) error
+	// List returns the matching reads, newest first.
+	List(ctx Context, f AccessLogFilter) ([]ResultAccess, error)
+}
+
+// QuarantinedMessage is a queue record whose payload could not be decoded.
+type QuarantinedMessage struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Error     string
+	// Attempts counts the deliveries that failed to decode.
+	Attempts    int
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
+
+// QuarantineRepository captures undecodable queue records for inspection.
+type QuarantineRepository interface {
+	// Record stores m, identified by topic, partition and offset. A record
+	// seen before gets its error refreshed and its attempts incremented. It
+	// returns the number of attempts so far.
+	Record(ctx Context, m QuarantinedMessage) (int, error)
+}
+
+// JobLockRepository grants expiring, exclusive leases on jobs so that a job
+// is never evaluated by two consumers at once. Each acquisition gets a new,
+// larger fencing token; a holder whose token is no longer current has lost
+// the lease.
+type JobLockRepository interface {
+	// Acquire leases jobID to owner for ttl and returns the fencing token. It
+	// returns ErrConflict while another holder's lease has not expired.
+	Acquire(ctx Context, jobID, owner string, ttl time.Duration) (int64, error)
+	// Renew extends the lease held with token by ttl. It returns ErrConflict
+	// when the lease was taken over.
+	Renew(ctx Context, jobID string, token int64, ttl time.Duration) error
+	// Release ends the lease held with token; a lease taken over is left alone.
+	Release(ctx Context, jobID string, token int64) error
+}
+
+// OutboxEntry is an evaluation task recorded in the enqueue outbox.
+type OutboxEntry struct {
+	ID        int64
+	Payload   EvaluateTaskPayload
+	Attempts  int
+	CreatedAt time.Time
+}
+
+// OutboxRepository stores new jobs together with their evaluation task so
+// that a task is published if and only if its job exists.
+type OutboxRepository interface {
+	// CreateJob inserts j and an outbox entry for p in one transaction and
+	// returns the job id; p.JobID is set to it.
+	CreateJob(ctx Context, j Job, p EvaluateTaskPayload) (string, error)
+	// ClaimPending leases up to limit unsent entries, oldest first, for
+	// lease. Concurrent callers receive disjoint entries; entries whose lease
+	// expires without MarkSent are claimed again.
+	ClaimPending(ctx Context, limit int, lease time.Duration) ([]OutboxEntry, error)
+	// MarkSent records that the entry was published.
+	MarkSent(ctx Context, id int64) error
+	// Park stops relaying the entry and marks its job failed with reason if
+	// the job is still queued.
+	Park(ctx Context, id int64, reason string) error
+	// PurgeSent deletes entries sent before before and returns how many.
+	PurgeSent(ctx Context, before time.Time) (int64, error)
+	// Requeue moves the failed job p.JobID back to queued, drops its
+	// checkpoints and records an outbox entry for p in one transaction. It
+	// returns ErrConflict when the job is not failed.
+	Requeue(ctx Context, p EvaluateTaskPayload) error
+}
+
+// TenantQuotaRepository counts tenants' daily quota usage.
+type TenantQuotaRepository interface {
+	// Consume counts one evaluation of tenantID on day and returns the usage
+	// including it. It returns ErrRateLimited without counting when the day
+	// already reached evaluationLimit evaluations or tokenLimit tokens; a
+	// limit of 0 is unlimited.
+	Consume(ctx Context, tenantID string, day time.Time, evaluationLimit int, tokenLimit int64) (TenantQuotaUsage, error)
+	// AddTokens adds tokens used by a job of tenantID to day.
+	AddTokens(ctx Context, tenantID string, day time.Time, tokens int64) error
+	// Refund takes back one evaluation of tenantID counted on day by Consume.
+	Refund(ctx Context, tenantID string, day time.Time) error
+}
+
+// TenantSettingsRepository persists per-tenant evaluation settings.
+type TenantSettingsRepository interface {
+	// GetByAPIKeyHash returns the settings of the tenant owning the key hash,
+	// or ErrNotFound.
+	GetByAPIKeyHash(ctx Context, hash string) (TenantSettings, error)
+	// Get returns the settings of a tenant, or ErrNotFound.
+	Get(ctx Context, tenantID string) (TenantSettings, error)
+	// List returns all tenants ordered by ID.
+	List(ctx Context) ([]TenantSettings, error)
+	// Upsert inserts or replaces the settings of s.TenantID.
+	Upsert(ctx Context, s TenantSettings) error
+	// Delete removes a tenant; it returns ErrNotFound when none exists.
+	Delete(ctx Context, tenantID string) error
+}
+
+// CandidateSummaryRepository caches generated candidate summaries per job.
+type CandidateSummaryRepository interface {
+	// Get returns the summary stored for a job, or ErrNotFound.
+	Get(ctx Context, jobID string) (CandidateSummary, error)
+	// Save inserts or replaces the summary of s.JobID.
+	Save(ctx Context, s CandidateSummary) error
+}
+
+// CheckpointRepository stores the completed steps of evaluation chains.
+type CheckpointRepository interface {
+	// Get returns the checkpoint of step for a job, or ErrNotFound.
+	Get(ctx Context, jobID, step string) (EvaluationCheckpoint, error)
+	// Save inserts or replaces the checkpoint of c.JobID and c.Step.
+	Save(ctx Context, c EvaluationCheckpoint) error
+	// Delete removes the checkpoints of a job.
+	Delete(ctx Context, jobID string) error
+}
+
+// Prompt experiment variants.
+const (
+	PromptVariantA = "a"
+	PromptVariantB = "b"
+)
+
+// PromptExperiment splits evaluation traffic between two template versions
+// of one step's prompt. TrafficB percent of jobs use VersionB, the rest
+// VersionA. At most one experiment per step is active; EndedAt is set once
+// it is ended.
+type PromptExperiment struct {
+	ID        string
+	Name      string
+	Step      string
+	VersionA  string
+	VersionB  string
+	TrafficB  int
+	CreatedAt time.Time
+	EndedAt   *time.Time
+}
+
+// Active reports whether the experiment still assigns variants.
+func (e PromptExperiment) Active() bool { return e.EndedAt == nil }
+
+// Version returns the prompt version of variant.
+func (e PromptExperiment) Version(variant string) string {
+	if variant == PromptVariantB {
+		return e.VersionB
+	}
+	return e.VersionA
+}
+
+// PromptVariantStats compares the jobs of one variant of an experiment.
+// Score and latency fields are nil while no job of the variant completed.
+type PromptVariantStats struct {
+	Variant string
+	Version string
+	// Jobs counts assigned jobs; Completed and Failed those that finished.
+	Jobs      int
+	Completed int
+	Failed    int
+	// ParseFailureRate is the share of finished jobs whose model output
+	// failed to parse at least once; ParseFailures counts all failed parses.
+	ParseFailureRate float64
+	ParseFailures    int
+	AvgCVMatchRate   *float64
+	StdCVMatchRate   *float64
+	AvgProjectScore  *float64
+	StdProjectScore  *float64
+	// Latency of finished jobs' evaluations in milliseconds.
+	AvgLatencyMS *float64
+	P50LatencyMS *float64
+	P95LatencyMS *float64
+}
+
+// PromptExperimentReport compares the variants of an experiment. Variants
+// always holds variant A then variant B, with zero counts before any job.
+type PromptExperimentReport struct {
+	Experiment PromptExperiment
+	Variants   []PromptVariantStats
+}
+
+// PromptExperimentRepository persists prompt experiments and the variants
+// jobs were assigned.
+type PromptExperimentRepository interface {
+	// Create stores e, assigning its ID, or returns ErrConflict when an
+	// experiment on e.Step is already active.
+	Create(ctx Context, e PromptExperiment) (PromptExperiment, error)
+	// Get returns an experiment, or ErrNotFound.
+	Get(ctx Context, id string) (PromptExperiment, error)
+	// List returns all experiments, newest first.
+	List(ctx Context) ([]PromptExperiment, error)
+	// Active returns the active experiments.
+	Active(ctx Context) ([]PromptExperiment, error)
+	// End stops an active experiment, or returns ErrNotFound.
+	End(ctx Context, id string, at time.Time) error
+	// Assign records the variant of each experiment a job uses; a job keeps
+	// the variants it was first assigned.
+	Assign(ctx Context, jobID string, variants map[string]string) error
+	// Finish records the outcome of a job's evaluation for its variants.
+	Finish(ctx Context, jobID string, parseFailures int, latency time.Duration) error
+	// Stats compares the variants of an experiment.
+	Stats(ctx Context, id string) ([]PromptVariantStats, error)
+}
+
+// Notifier posts operational events, e.g. to Slack or Teams. Implementations
+// must not block the caller on delivery and may drop disabled or rate-limited
+// events.
+type Notifier interface {
+	Notify(ctx Context, n Notification)
+}
+
+// Mailer delivers email.
+type Mailer interface {
+	// Send delivers e to all of its recipients.
+	Send(ctx Context, e Email) error
+}
+
+// ProviderKeyRepository persists runtime changes to AI provider keys so that
+// every process picks them up without a restart.
+type ProviderKeyRepository interface {
+	// List returns all stored provider keys.
+	List(ctx Context) ([]ProviderKey, error)
+	// Upsert inserts or updates a key by ID; an empty Secret keeps the stored one.
+	Upsert(ctx Context, k ProviderKey) error
+}
+
+// KeyUsageRepository persists daily provider key usage shared by all processes.
+type KeyUsageRepository interface {
+	// Add increments the usage of keyID on day and returns the new totals.
+	Add(ctx Context, keyID string, day time.Time, requests, tokens int64) (KeyUsage, error)
+	// ListDay returns the usage of all keys on day.
+	ListDay(ctx Context, day time.Time) ([]KeyUsage, error)
+}
+
+// ModelLimitRepository persists discovered provider model limits.
+type ModelLimitRepository interface {
+	// List returns the stored limits of all models of provider.
+	List(ctx Context, provider string) ([]ModelLimit, error)
+	// Upsert inserts or replaces the limit of a model.
+	Upsert(ctx Context, l ModelLimit) error
+}
+
+// ProviderErrorFingerprint classifies the error body of a failed AI provider
+// call, so that recurring failures are counted alike across providers.
+type ProviderErrorFingerprint string
+
+// Provider error fingerprints.
+const (
+	ProviderErrorModelNotFound  ProviderErrorFingerprint = "model_not_found"
+	ProviderErrorContentFilter  ProviderErrorFingerprint = "content_filter"
+	ProviderErrorContextLength  ProviderErrorFingerprint = "context_length_exceeded"
+	ProviderErrorQuota          ProviderErrorFingerprint = "quota_exceeded"
+	ProviderErrorRateLimited    ProviderErrorFingerprint = "rate_limited"
+	ProviderErrorAuth           ProviderErrorFingerprint = "auth"
+	ProviderErrorInvalidRequest ProviderErrorFingerprint = "invalid_request"
+	ProviderErrorUnavailable    ProviderErrorFingerprint = "provider_unavailable"
+	ProviderErrorUnknown        ProviderErrorFingerprint = "unknown"
+)
+
+// ProviderError is a failed AI provider call.
+type ProviderError struct {
+	Provider string
+	// Op is the client operation, e.g. chat or embed.
+	Op    string
+	Model string
+	// Status is the HTTP status of the response.
+	Status      int
+	Fingerprint ProviderErrorFingerprint
+	// Message is the provider's error message, truncated.
+	Message string
+	// JobID and Step identify the evaluation that made the call, if any.
+	JobID string
+	Step  string
+	At    time.Time
+}
+
+// ProviderErrorCount is how often a fingerprint occurred with a provider.
+type ProviderErrorCount struct {
+	Fingerprint ProviderErrorFingerprint
+	Provider    string
+	Count       int64
+	FirstSeen   time.Time
+	LastSeen    time.Time
+	// Model and Message are those of the latest occurrence.
+	Model   string
+	Message string
+}
+
+// ProviderErrorBucket counts the occurrences of a fingerprint with a provider
+// in the time bucket starting at Start.
+type ProviderErrorBucket struct {
+	Start       time.Time
+	Fingerprint ProviderErrorFingerprint
+	Provider    string
+	Count       int64
+}
+
+// ProviderErrorTrend is a fingerprint's occurrences with a provider over a
+// report window.
+type ProviderErrorTrend struct {
+	ProviderErrorCount
+	// Series counts the occurrences in each bucket of the window, oldest
+	// first.
+	Series []int64
+}
+
+// ProviderErrorReport lists the most frequent provider error fingerprints of
+// the window [Since, Until) split into buckets of length Bucket.
+type ProviderErrorReport struct {
+	Since  time.Time
+	Until  time.Time
+	Bucket time.Duration
+	Top    []ProviderErrorTrend
+}
+
+// ProviderErrorRepository persists failed AI provider calls shared by all
+// processes.
+type ProviderErrorRepository interface {
+	// Record stores a failed call.
+	Record(ctx Context, e ProviderError) error
+	// Top returns the limit fingerprint and provider pairs that occurred
+	// most often since since, most frequent first.
+	Top(ctx Context, since time.Time, limit int) ([]ProviderErrorCount, error)
+	// Buckets returns the occurrences since since counted in buckets of
+	// length bucket, oldest first.
+	Buckets(ctx Context, since time.Time, bucket time.Duration) ([]ProviderErrorBucket, error)
+}
+
+// Job usage phases. Local is derived: the time of a job outside its queue
+// wait, AI calls and RAG lookups, i.e. the cost of processing it locally.
+const (
+	UsagePhaseQueueWait      = "queue_wait"
+	UsagePhaseExtraction     = "extraction"
+	UsagePhaseRAG            = "rag"
+	UsagePhaseAI             = "ai"
+	UsagePhasePostProcessing = "post_processing"
+	UsagePhaseLocal          = "local"
+	UsagePhaseTotal          = "total"
+)
+
+// JobUsage is the wall-clock time a completed job spent in each phase of its
+// processing. CPU time is not attributed per job, since the jobs of a worker
+// share its threads.
+type JobUsage struct {
+	JobID string
+	// QueueWait runs from the job's creation until a worker started it.
+	QueueWait time.Duration
+	// Extraction loads and screens the job's documents.
+	Extraction time.Duration
+	// RAG retrieves context from the vector store, including the embedding
+	// of the search query.
+	RAG time.Duration
+	// AI is the time spent in chat completions, including provider retries
+	// and waits for a call slot.
+	AI time.Duration
+	// AICalls counts the chat completions.
+	AICalls int
+	// PostProcessing runs from the end of the evaluation until the job is
+	// marked completed.
+	PostProcessing time.Duration
+	// Total runs from the job's creation until it completed.
+	Total       time.Duration
+	CompletedAt time.Time
+}
+
+// JobUsagePercentiles summarises the time jobs spent in one phase, in
+// milliseconds.
+type JobUsagePercentiles struct {
+	Phase string
+	AvgMS float64
+	P50MS float64
+	P90MS float64
+	P99MS float64
+}
+
+// JobUsageReport summarises the usage of the jobs completed in [Since, Until).
+type JobUsageReport struct {
+	Since time.Time
+	Until time.Time
+	Jobs  int64
+	// Phases lists the phases in the order of the UsagePhase constants.
+	Phases []JobUsagePercentiles
+}
+
+// JobUsageRepository persists the usage of completed jobs.
+type JobUsageRepository interface {
+	// Record stores u, replacing an earlier record of the same job.
+	Record(ctx Context, u JobUsage) error
+	// Percentiles summarises the jobs completed since since. Phases is
+	// empty when there are none.
+	Percentiles(ctx Context, since time.Time) (JobUsageReport, error)
+}
+
+// AISaturation is a worker's latest report of its AI capacity. BlockedUntil
+// is set while every chat model the worker can use is blocked after rate
+// limits, to when the first of them is usable again.
+type AISaturation struct {
+	Instance     string
+	BlockedUntil *time.Time
+	ReportedAt   time.Time
+}
+
+// AISaturationRepository shares the workers' AI capacity reports.
+type AISaturationRepository interface {
+	// Report stores s, replacing the instance's earlier report.
+	Report(ctx Context, s AISaturation) error
+	// List returns the reports made since since.
+	List(ctx Context, since time.Time) ([]AISaturation, error)
 }
 
 // Queue (port)
@@ -190,6 +1398,723 @@ type EvaluateTaskPayload struct {
 	// RequestID carries the originating HTTP request identifier so that
 	// background workers can correlate their logs with the frontend request.
 	RequestID string
+	// AllowPaidFallback records the request's opt-in to paid models when all
+	// free models are rate limited.
+	AllowPaidFallback bool
+	// TenantID is the tenant whose settings were resolved at submission; empty
+	// for requests without a known API key.
+	TenantID string
+	// Overrides are the tenant's evaluation overrides applied by the worker.
+	Overrides EvaluationOverrides
+	// CVOnly evaluates the CV against the job description only; ProjectID and
+	// StudyCaseBrief are empty.
+	CVOnly bool
+	// ProjectOnly grades the project against the study case only; CVID and
+	// JobDescription are empty.
+	ProjectOnly bool
+	// ScoringWeights are the request's rubric weights, complete for both
+	// groups; nil applies the default weights.
+	ScoringWeights ScoringWeights
+	// Rubric lists the sections of the rubric ScoringWeights were validated
+	// against; set with ScoringWeights, nil means DefaultRubric.
+	Rubric []RubricSection
+	// SLA bounds the evaluation, every step and AI call included, from when a
+	// worker starts it; zero applies the worker's default.
+	SLA time.Duration
+	// Priority is the request's priority; PriorityInteractive selects the
+	// fast path and empty means PriorityBatch.
+	Priority string
+	// ResultPublicKey is the tenant's PEM public key; when set the worker
+	// encrypts the result feedback before storing it.
+	ResultPublicKey string
+	// FeedbackFormat is FeedbackFormatMarkdown or, when empty,
+	// FeedbackFormatText.
+	FeedbackFormat string
+	// FeedbackLanguage is a FeedbackLanguages code other than "en" to write
+	// the feedback and summary in; empty keeps English.
+	FeedbackLanguage string
+	// Bumped is set when an admin moved the queued job ahead of the backlog;
+	// the task is published to the priority topic.
+	Bumped bool
+}
+
+// EvaluationOverrides are per-tenant adjustments of how an evaluation runs.
+// The zero value keeps the deployment defaults.
+type EvaluationOverrides struct {
+	// PreferredModels are tried before the other models of a provider, in order.
+	PreferredModels []string `json:"preferred_models,omitempty"`
+	// MaxTokens caps the completion tokens of each AI call; 0 means no cap.
+	MaxTokens int `json:"max_tokens,omitempty"`
+	// Anonymize redacts contact details from the CV before it reaches a prompt.
+	Anonymize bool `json:"anonymize,omitempty"`
+	// Provider and Model pin every AI call to one model of one provider,
+	// without round-robin or fallbacks. They are set by admin retries only.
+	Provider string `json:"provider,omitempty"`
+	Model    string `json:"model,omitempty"`
+	// Challenger makes Provider and Model a challenger under test instead
+	// of a pin: calls try it first and fall back to the default pool.
+	Challenger bool `json:"challenger,omitempty"`
+	// OpenRouterProvider adjusts how OpenRouter routes calls between its
+	// upstream providers; set fields take precedence over the deployment's.
+	OpenRouterProvider *OpenRouterProviderPrefs `json:"openrouter_provider,omitempty"`
+	// Sampling adjusts the sampling of chat calls; set fields take precedence
+	// over the deployment's temperature.
+	Sampling *SamplingParams `json:"sampling,omitempty"`
+}
+
+// Pinned reports whether the AI calls are pinned to one provider and model.
+func (ov EvaluationOverrides) Pinned() bool {
+	return ov.Provider != "" && ov.Model != ""
+}
+
+// OpenRouterProviderPrefs are OpenRouter's provider routing options, sent as
+// the "provider" object of chat requests. Unset fields keep OpenRouter's
+// defaults.
+type OpenRouterProviderPrefs struct {
+	// Order lists the upstream providers to try first, in order.
+	Order []string `json:"order,omitempty"`
+	// Ignore lists upstream providers that must never serve a call.
+	Ignore []string `json:"ignore,omitempty"`
+	// AllowFallbacks false limits calls to the providers in Order.
+	AllowFallbacks *bool `json:"allow_fallbacks,omitempty"`
+	// DataCollection "deny" excludes providers that store or train on prompts.
+	DataCollection string `json:"data_collection,omitempty"`
+	// RequireParameters limits calls to providers supporting every request
+	// parameter, such as max_tokens.
+	RequireParameters *bool `json:"require_parameters,omitempty"`
+}
+
+// SamplingParams trade the variety of generated feedback against the
+// stability of scores. Unset fields keep the deployment defaults.
+type SamplingParams struct {
+	Temperature      *float64 `json:"temperature,omitempty"`
+	TopP             *float64 `json:"top_p,omitempty"`
+	FrequencyPenalty *float64 `json:"frequency_penalty,omitempty"`
+}
+
+// IsZero reports whether p sets no parameter.
+func (p SamplingParams) IsZero() bool {
+	return p.Temperature == nil && p.TopP == nil && p.FrequencyPenalty == nil
+}
+
+// Merge returns p with the parameters set in over replacing its own.
+func (p SamplingParams) Merge(over SamplingParams) SamplingParams {
+	if over.Temperature != nil {
+		p.Temperature = over.Temperature
+	}
+	if over.TopP != nil {
+		p.TopP = over.TopP
+	}
+	if over.FrequencyPenalty != nil {
+		p.FrequencyPenalty = over.FrequencyPenalty
+	}
+	return p
+}
+
+// IsZero reports whether p sets no option.
+func (p OpenRouterProviderPrefs) IsZero() bool {
+	return len(p.Order) == 0 && len(p.Ignore) == 0 && p.AllowFallbacks == nil && p.DataCollection == "" && p.RequireParameters == nil
+}
+
+// Merge returns p with the options set in over replacing its own.
+func (p OpenRouterProviderPrefs) Merge(over OpenRouterProviderPrefs) OpenRouterProviderPrefs {
+	if len(over.Order) > 0 {
+		p.Order = over.Order
+	}
+	if len(over.Ignore) > 0 {
+		p.Ignore = over.Ignore
+	}
+	if over.AllowFallbacks != nil {
+		p.AllowFallbacks = over.AllowFallbacks
+	}
+	if over.DataCollection != "" {
+		p.DataCollection = over.DataCollection
+	}
+	if over.RequireParameters != nil {
+		p.RequireParameters = over.RequireParameters
+	}
+	return p
+}
+
+// TenantSettings are the admin-managed evaluation settings of a tenant,
+// identified by the API key its requests carry.
+type TenantSettings struct {
+	// TenantID is the stable identifier chosen by the admin.
+	TenantID string
+	// APIKeyHash is the hex SHA-256 of the tenant's API key; the key itself is
+	// never stored.
+	APIKeyHash string
+	// Overrides adjust model selection, token budget and anonymization.
+	Overrides EvaluationOverrides
+	// RubricTemplate replaces the default scoring rubric when a request does
+	// not provide one; empty keeps the default.
+	RubricTemplate string
+	// DailyEvaluations and DailyTokens cap the evaluations submitted and the
+	// AI tokens used per UTC day; 0 means unlimited.
+	DailyEvaluations int
+	DailyTokens      int64
+	// ResultPublicKey is a PEM RSA public key; when set, result feedback is
+	// stored encrypted so only the tenant can read it.
+	ResultPublicKey string
+	// UpdatedAt is the timestamp of the last change.
+	UpdatedAt time.Time
+}
+
+// TenantQuotaUsage is what a tenant used of its daily quotas on one UTC day.
+type TenantQuotaUsage struct {
+	// Evaluations is the number of evaluations submitted.
+	Evaluations int
+	// Tokens is the number of AI tokens the tenant's jobs used.
+	Tokens int64
+}
+
+// CandidateSummary is a recruiter-facing summary generated from a stored
+// evaluation result.
+type CandidateSummary struct {
+	// JobID is the evaluation job the summary belongs to.
+	JobID string
+	// ResultVersion identifies the result content the summary was generated
+	// from; a changed result invalidates the summary.
+	ResultVersion string
+	// Summary is a one-paragraph overview of the candidate.
+	Summary string
+	// Strengths and Risks are short bullet points.
+	Strengths []string
+	Risks     []string
+	// CreatedAt is when the summary was generated.
+	CreatedAt time.Time
+}
+
+// Kinds of SentenceChange.
+const (
+	SentenceUnchanged = "unchanged"
+	SentenceAdded     = "added"
+	SentenceRemoved   = "removed"
+	SentenceChanged   = "changed"
+)
+
+// SentenceChange is one sentence of a feedback text diff. Changed sentences
+// carry the earlier wording in Previous.
+type SentenceChange struct {
+	Kind     string
+	Text     string
+	Previous string
+}
+
+// ScoreChange compares one score of two result versions; nil scores were not
+// assessed in that version.
+type ScoreChange struct {
+	From  *float64
+	To    *float64
+	Delta float64
+}
+
+// ResultDiff compares two versions of a job's result.
+type ResultDiff struct {
+	JobID string
+	// From and To are the compared version numbers.
+	From, To int
+	// FromCreatedAt and ToCreatedAt are when the versions were stored.
+	FromCreatedAt, ToCreatedAt time.Time
+	CVMatchRate                ScoreChange
+	ProjectScore               ScoreChange
+	// The feedback texts are diffed sentence by sentence.
+	CVFeedback      []SentenceChange
+	ProjectFeedback []SentenceChange
+	OverallSummary  []SentenceChange
+	// FeedbackEncrypted reports that either version holds encrypted feedback,
+	// which cannot be diffed; the feedback changes are then empty.
+	FeedbackEncrypted bool
+	// FromProvenance and ToProvenance list the models behind each version.
+	FromProvenance []StepProvenance
+	ToProvenance   []StepProvenance
+}
+
+// JobReplay is the result the current evaluation pipeline produces for the
+// stored inputs of a past job, next to the result stored for the job.
+// Replays are never stored.
+type JobReplay struct {
+	JobID string
+	// Result is the replayed result.
+	Result Result
+	// Stored is the job's stored result; nil unless the job completed.
+	Stored *Result
+	// Diff compares Stored (from) with Result (to); nil without a stored
+	// result.
+	Diff *ResultDiff
+	// Duration is how long the replay took.
+	Duration time.Duration
+}
+
+// EvaluationCheckpoint is the saved output of one completed step of a job's
+// evaluation chain, so that a retried job resumes after it instead of
+// repeating its AI calls.
+type EvaluationCheckpoint struct {
+	JobID string
+	// Step is the evaluation step, e.g. cv_match.
+	Step string
+	// Output is the text the step produced.
+	Output string
+	// Provenance lists the models that produced Output.
+	Provenance []StepProvenance
+	CreatedAt  time.Time
+}
+
+type evaluationOverridesKey struct{}
+
+// WithEvaluationOverrides attaches a tenant's evaluation overrides to ctx.
+func WithEvaluationOverrides(ctx context.Context, ov EvaluationOverrides) context.Context {
+	return context.WithValue(ctx, evaluationOverridesKey{}, ov)
+}
+
+// EvaluationOverridesFrom returns the overrides carried by ctx, or the zero
+// value.
+func EvaluationOverridesFrom(ctx context.Context) EvaluationOverrides {
+	ov, _ := ctx.Value(evaluationOverridesKey{}).(EvaluationOverrides)
+	return ov
+}
+
+type tenantAPIKeyKey struct{}
+
+// WithTenantAPIKey attaches the API key presented by the client to ctx.
+func WithTenantAPIKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, tenantAPIKeyKey{}, key)
+}
+
+// TenantAPIKey returns the API key carried by ctx, or "".
+func TenantAPIKey(ctx context.Context) string {
+	key, _ := ctx.Value(tenantAPIKeyKey{}).(string)
+	return key
+}
+
+// Rubric groups: CV parameters make up the CV match rate and project
+// parameters the project score.
+const (
+	RubricGroupCV      = "cv"
+	RubricGroupProject = "project"
+)
+
+// RubricSection is a weighted parameter of the scoring rubric.
+type RubricSection struct {
+	// Name is the section key of configs/rag/scoring_rubric.yaml.
+	Name string
+	// Group is RubricGroupCV or RubricGroupProject.
+	Group string
+	// Label is the human-readable parameter name used in prompts.
+	Label string
+	// Weight is the share of the group's score in percent; the weights of a
+	// group sum to 100.
+	Weight float64
+}
+
+// DefaultRubric lists the rubric parameters with their default weights, in
+// the order of configs/rag/scoring_rubric.yaml.
+var DefaultRubric = []RubricSection{
+	{Name: "technical_skills_match", Group: RubricGroupCV, Label: "Technical Skills Match", Weight: 40},
+	{Name: "experience_level", Group: RubricGroupCV, Label: "Experience Level", Weight: 25},
+	{Name: "relevant_achievements", Group: RubricGroupCV, Label: "Relevant Achievements", Weight: 20},
+	{Name: "cultural_collaboration_fit", Group: RubricGroupCV, Label: "Cultural/Collaboration Fit", Weight: 15},
+	{Name: "correctness", Group: RubricGroupProject, Label: "Correctness", Weight: 30},
+	{Name: "code_quality_structure", Group: RubricGroupProject, Label: "Code Quality & Structure", Weight: 25},
+	{Name: "resilience_error_handling", Group: RubricGroupProject, Label: "Resilience & Error Handling", Weight: 20},
+	{Name: "documentation_explanation", Group: RubricGroupProject, Label: "Documentation & Explanation", Weight: 15},
+	{Name: "creativity_bonus", Group: RubricGroupProject, Label: "Creativity/Bonus", Weight: 10},
+}
+
+// ScoringWeights maps rubric section names to weights in percent.
+type ScoringWeights map[string]float64
+
+// Rubric returns DefaultRubric with the weights of w applied.
+func (w ScoringWeights) Rubric() []RubricSection { return w.Apply(DefaultRubric) }
+
+// Apply returns sections with the weights of w applied.
+func (w ScoringWeights) Apply(sections []RubricSection) []RubricSection {
+	out := make([]RubricSection, len(sections))
+	for i, s := range sections {
+		if v, ok := w[s.Name]; ok {
+			s.Weight = v
+		}
+		out[i] = s
+	}
+	return out
+}
+
+type scoringWeightsKey struct{}
+
+// WithScoringWeights attaches a request's rubric weight overrides to ctx.
+func WithScoringWeights(ctx context.Context, w ScoringWeights) context.Context {
+	return context.WithValue(ctx, scoringWeightsKey{}, w)
+}
+
+// ScoringWeightsFrom returns the weight overrides carried by ctx, or nil.
+func ScoringWeightsFrom(ctx context.Context) ScoringWeights {
+	w, _ := ctx.Value(scoringWeightsKey{}).(ScoringWeights)
+	return w
+}
+
+type openRouterProviderKey struct{}
+
+// WithOpenRouterProviderPrefs attaches a request's OpenRouter provider
+// routing options to ctx.
+func WithOpenRouterProviderPrefs(ctx context.Context, p OpenRouterProviderPrefs) context.Context {
+	return context.WithValue(ctx, openRouterProviderKey{}, p)
+}
+
+// OpenRouterProviderPrefsFrom returns the OpenRouter provider routing options
+// carried by ctx, or the zero value.
+func OpenRouterProviderPrefsFrom(ctx context.Context) OpenRouterProviderPrefs {
+	p, _ := ctx.Value(openRouterProviderKey{}).(OpenRouterProviderPrefs)
+	return p
+}
+
+type samplingParamsKey struct{}
+
+// WithSamplingParams attaches a request's sampling parameters to ctx.
+func WithSamplingParams(ctx context.Context, p SamplingParams) context.Context {
+	return context.WithValue(ctx, samplingParamsKey{}, p)
+}
+
+// SamplingParamsFrom returns the sampling parameters carried by ctx, or the
+// zero value.
+func SamplingParamsFrom(ctx context.Context) SamplingParams {
+	p, _ := ctx.Value(samplingParamsKey{}).(SamplingParams)
+	return p
+}
+
+type jobTTLKey struct{}
+
+// WithJobTTL attaches a request's job expiry override to ctx.
+func WithJobTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return context.WithValue(ctx, jobTTLKey{}, ttl)
+}
+
+// JobTTLFrom returns the job expiry override carried by ctx, or 0.
+func JobTTLFrom(ctx context.Context) time.Duration {
+	ttl, _ := ctx.Value(jobTTLKey{}).(time.Duration)
+	return ttl
+}
+
+type jobLabelsKey struct{}
+
+// JobLabels are the metadata and tags a request attaches to its job.
+type JobLabels struct {
+	Metadata map[string]string
+	Tags     []string
+}
+
+// WithJobLabels attaches a request's job metadata and tags to ctx.
+func WithJobLabels(ctx context.Context, l JobLabels) context.Context {
+	return context.WithValue(ctx, jobLabelsKey{}, l)
+}
+
+// JobLabelsFrom returns the job metadata and tags carried by ctx, or the
+// zero value.
+func JobLabelsFrom(ctx context.Context) JobLabels {
+	l, _ := ctx.Value(jobLabelsKey{}).(JobLabels)
+	return l
+}
+
+// ModelTrace records the AI models that served the calls made with a
+// context, the provenance of the calls made within an evaluation step and
+// the tokens the calls used. It is safe for concurrent use.
+type ModelTrace struct {
+	mu     sync.Mutex
+	models []string
+	steps  []StepProvenance
+	// promptTokens and completionTokens count every call, including the
+	// calls forgotten by Reset.
+	promptTokens, completionTokens int
+}
+
+// Last returns the model that served the most recent call, or "".
+func (t *ModelTrace) Last() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.models) == 0 {
+		return ""
+	}
+	return t.models[len(t.models)-1]
+}
+
+// Provenance returns the step provenance recorded so far, oldest first.
+// Consecutive calls of one step served by the same model appear once.
+func (t *ModelTrace) Provenance() []StepProvenance {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.steps) == 0 {
+		return nil
+	}
+	return append([]StepProvenance(nil), t.steps...)
+}
+
+// Tokens returns the prompt and completion tokens of all calls recorded.
+func (t *ModelTrace) Tokens() (prompt, completion int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.promptTokens, t.completionTokens
+}
+
+// Reset forgets the models and provenance recorded so far. Token counts are
+// kept, since the discarded calls still used them.
+func (t *ModelTrace) Reset() {
+	t.mu.Lock()
+	t.models, t.steps = nil, nil
+	t.mu.Unlock()
+}
+
+type modelTraceKey struct{}
+
+// WithModelTrace attaches a new ModelTrace to ctx.
+func WithModelTrace(ctx context.Context) (context.Context, *ModelTrace) {
+	t := &ModelTrace{}
+	return context.WithValue(ctx, modelTraceKey{}, t), t
+}
+
+// ResetModelTrace forgets the calls recorded in ctx's ModelTrace, for when
+// their output is discarded. It is a no-op when ctx carries no ModelTrace.
+func ResetModelTrace(ctx context.Context) {
+	if t, _ := ctx.Value(modelTraceKey{}).(*ModelTrace); t != nil {
+		t.Reset()
+	}
+}
+
+// LastServedModel returns the model that served the most recent call made
+// with ctx, or "" when ctx carries no ModelTrace or no call was recorded.
+func LastServedModel(ctx context.Context) string {
+	if t, _ := ctx.Value(modelTraceKey{}).(*ModelTrace); t != nil {
+		return t.Last()
+	}
+	return ""
+}
+
+// JobTimer accumulates the time a job spends in AI chat completions and RAG
+// lookups. It is safe for concurrent use.
+type JobTimer struct {
+	mu      sync.Mutex
+	ai, rag time.Duration
+	aiCalls int
+}
+
+// AI returns the time spent in chat completions and their number.
+func (t *JobTimer) AI() (time.Duration, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.ai, t.aiCalls
+}
+
+// RAG returns the time spent retrieving RAG context.
+func (t *JobTimer) RAG() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rag
+}
+
+type jobTimerKey struct{}
+
+// WithJobTimer attaches a new JobTimer to ctx.
+func WithJobTimer(ctx context.Context) (context.Context, *JobTimer) {
+	t := &JobTimer{}
+	return context.WithValue(ctx, jobTimerKey{}, t), t
+}
+
+// AddAITime adds a chat completion that took d to ctx's JobTimer. It is a
+// no-op when ctx carries no JobTimer.
+func AddAITime(ctx context.Context, d time.Duration) {
+	if t, _ := ctx.Value(jobTimerKey{}).(*JobTimer); t != nil {
+		t.mu.Lock()
+		t.ai += d
+		t.aiCalls++
+		t.mu.Unlock()
+	}
+}
+
+// AddRAGTime adds a RAG lookup that took d to ctx's JobTimer. It is a no-op
+// when ctx carries no JobTimer.
+func AddRAGTime(ctx context.Context, d time.Duration) {
+	if t, _ := ctx.Value(jobTimerKey{}).(*JobTimer); t != nil {
+		t.mu.Lock()
+		t.rag += d
+		t.mu.Unlock()
+	}
+}
+
+type evaluationJobKey struct{}
+
+// WithEvaluationJob labels the AI calls made with ctx as made for the job
+// jobID.
+func WithEvaluationJob(ctx context.Context, jobID string) context.Context {
+	return context.WithValue(ctx, evaluationJobKey{}, jobID)
+}
+
+// EvaluationJobFrom returns the job ctx is labeled with, or "".
+func EvaluationJobFrom(ctx context.Context) string {
+	id, _ := ctx.Value(evaluationJobKey{}).(string)
+	return id
+}
+
+type evaluationStepKey struct{}
+
+// WithEvaluationStep labels the AI calls made with ctx as part of step, run
+// with version promptVersion of the step's prompt template.
+func WithEvaluationStep(ctx context.Context, step, promptVersion string) context.Context {
+	return context.WithValue(ctx, evaluationStepKey{}, StepProvenance{Step: step, PromptVersion: promptVersion})
+}
+
+// EvaluationStepFrom returns the evaluation step ctx is labeled with, or "".
+func EvaluationStepFrom(ctx context.Context) string {
+	step, _ := ctx.Value(evaluationStepKey{}).(StepProvenance)
+	return step.Step
+}
+
+// RecordTokenUsage adds the tokens of an AI call made with ctx to its
+// ModelTrace. It is a no-op when ctx carries no ModelTrace.
+func RecordTokenUsage(ctx context.Context, prompt, completion int) {
+	t, _ := ctx.Value(modelTraceKey{}).(*ModelTrace)
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.promptTokens += prompt
+	t.completionTokens += completion
+	t.mu.Unlock()
+}
+
+// ProvenanceFrom returns the step provenance recorded in ctx's ModelTrace,
+// or nil when ctx carries none.
+func ProvenanceFrom(ctx context.Context) []StepProvenance {
+	if t, _ := ctx.Value(modelTraceKey{}).(*ModelTrace); t != nil {
+		return t.Provenance()
+	}
+	return nil
+}
+
+// RestoreProvenance appends step provenance recorded by an earlier attempt,
+// e.g. for a step resumed from a checkpoint, to ctx's ModelTrace. It is a
+// no-op when ctx carries no ModelTrace.
+func RestoreProvenance(ctx context.Context, steps []StepProvenance) {
+	t, _ := ctx.Value(modelTraceKey{}).(*ModelTrace)
+	if t == nil || len(steps) == 0 {
+		return
+	}
+	t.mu.Lock()
+	t.steps = append(t.steps, steps...)
+	t.mu.Unlock()
+}
+
+// RecordServedModel records that model of provider served an AI call made
+// with ctx, and the step provenance when ctx carries an evaluation step. It
+// is a no-op when ctx carries no ModelTrace.
+func RecordServedModel(ctx context.Context, provider, model string) {
+	recordServedModel(ctx, provider, model, "")
+}
+
+// RecordModelSubstitution is RecordServedModel for a call that asked for
+// the model requested but was served by model, a different one; the step
+// provenance notes the requested model.
+func RecordModelSubstitution(ctx context.Context, provider, requested, model string) {
+	recordServedModel(ctx, provider, model, requested)
+}
+
+func recordServedModel(ctx context.Context, provider, model, requested string) {
+	t, _ := ctx.Value(modelTraceKey{}).(*ModelTrace)
+	if t == nil || model == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.models = append(t.models, model)
+	step, ok := ctx.Value(evaluationStepKey{}).(StepProvenance)
+	if !ok {
+		return
+	}
+	step.Provider, step.Model, step.RequestedModel = provider, model, requested
+	step.ModelArm = ModelArmFrom(ctx)
+	step.FastPath = FastPathReasonFrom(ctx)
+	if n := len(t.steps); n > 0 && t.steps[n-1] == step {
+		return
+	}
+	t.steps = append(t.steps, step)
+}
+
+type modelArmKey struct{}
+
+// WithModelArm attaches the model A/B arm a job was assigned to ctx.
+func WithModelArm(ctx context.Context, arm string) context.Context {
+	return context.WithValue(ctx, modelArmKey{}, arm)
+}
+
+// ModelArmFrom returns the model A/B arm carried by ctx, or "".
+func ModelArmFrom(ctx context.Context) string {
+	arm, _ := ctx.Value(modelArmKey{}).(string)
+	return arm
+}
+
+type fastPathReasonKey struct{}
+
+// WithFastPathReason marks the evaluation run with ctx as taking the fast
+// path for reason.
+func WithFastPathReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, fastPathReasonKey{}, reason)
+}
+
+// FastPathReasonFrom returns why the evaluation run with ctx takes the fast
+// path, or "" for the multi-step chain.
+func FastPathReasonFrom(ctx context.Context) string {
+	reason, _ := ctx.Value(fastPathReasonKey{}).(string)
+	return reason
+}
+
+type feedbackFormatKey struct{}
+
+// WithFeedbackFormat attaches a request's feedback format to ctx.
+func WithFeedbackFormat(ctx context.Context, format string) context.Context {
+	return context.WithValue(ctx, feedbackFormatKey{}, format)
+}
+
+// FeedbackFormatFrom returns the feedback format carried by ctx, or "".
+func FeedbackFormatFrom(ctx context.Context) string {
+	f, _ := ctx.Value(feedbackFormatKey{}).(string)
+	return f
+}
+
+type feedbackLanguageKey struct{}
+
+// WithFeedbackLanguage attaches a request's feedback language to ctx.
+func WithFeedbackLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, feedbackLanguageKey{}, lang)
+}
+
+// FeedbackLanguageFrom returns the feedback language carried by ctx, or "".
+func FeedbackLanguageFrom(ctx context.Context) string {
+	l, _ := ctx.Value(feedbackLanguageKey{}).(string)
+	return l
+}
+
+type jobPriorityKey struct{}
+
+// WithJobPriority attaches a request's job priority to ctx.
+func WithJobPriority(ctx context.Context, priority string) context.Context {
+	return context.WithValue(ctx, jobPriorityKey{}, priority)
+}
+
+// JobPriorityFrom returns the job priority carried by ctx, or "".
+func JobPriorityFrom(ctx context.Context) string {
+	p, _ := ctx.Value(jobPriorityKey{}).(string)
+	return p
+}
+
+type paidFallbackOptInKey struct{}
+
+// WithPaidFallbackOptIn marks ctx as allowed (or not) to fall back to paid
+// AI models when free models are exhausted.
+func WithPaidFallbackOptIn(ctx context.Context, allow bool) context.Context {
+	return context.WithValue(ctx, paidFallbackOptInKey{}, allow)
+}
+
+// PaidFallbackOptedIn reports whether ctx carries a paid-fallback opt-in.
+func PaidFallbackOptedIn(ctx context.Context) bool {
+	allow, _ := ctx.Value(paidFallbackOptInKey{}).(bool)
+	return allow
 }
 
 // Context is an alias to allow decoupling from std context in domain
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"context"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -34,6 +36,7 @@ func TestJobStatusConstants(t *testing.T) {
 		{"JobProcessing", JobProcessing, "processing"},
 		{"JobCompleted", JobCompleted, "completed"},
 		{"JobFailed", JobFailed, "failed"},
+		{"JobExpired", JobExpired, "expired"},
 	}
 
 	for _, tt := range tests {
@@ -204,3 +207,105 @@ func TestEvaluateTaskPayload(t *testing.T) {
 		t.Errorf("Expected StudyCaseBrief to be 'Build a web app', got %q", payload.StudyCaseBrief)
 	}
 }
+
+func TestModelTrace(t *testing.T) {
+	RecordServedModel(context.Background(), "openrouter", "ignored")
+
+	ctx, trace := WithModelTrace(context.Background())
+	if got := trace.Last(); got != "" {
+		t.Errorf("Expected empty trace, got %q", got)
+	}
+	RecordServedModel(ctx, "groq", "groq/llama-3.1-8b-instant")
+	RecordServedModel(ctx, "openrouter", "meta-llama/llama-3.3-70b-instruct:free")
+	if got := trace.Last(); got != "meta-llama/llama-3.3-70b-instruct:free" {
+		t.Errorf("Expected last served model, got %q", got)
+	}
+	if got := trace.Provenance(); got != nil {
+		t.Errorf("Expected no provenance for unlabeled calls, got %v", got)
+	}
+}
+
+func TestModelTrace_ModelArm(t *testing.T) {
+	ctx, trace := WithModelTrace(WithModelArm(context.Background(), ModelArmChallenger))
+	RecordServedModel(WithEvaluationStep(ctx, "cv_match", "1"), "groq", "challenger-model")
+	want := []StepProvenance{{Step: "cv_match", Provider: "groq", Model: "challenger-model", PromptVersion: "1", ModelArm: ModelArmChallenger}}
+	if got := trace.Provenance(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Provenance() = %v, want %v", got, want)
+	}
+}
+
+func TestModelTrace_ModelSubstitution(t *testing.T) {
+	ctx, trace := WithModelTrace(context.Background())
+	RecordModelSubstitution(WithEvaluationStep(ctx, "cv_match", "1"), "openrouter", "free/a:free", "paid/b")
+	if got := trace.Last(); got != "paid/b" {
+		t.Errorf("Expected the substitute as last served model, got %q", got)
+	}
+	want := []StepProvenance{{Step: "cv_match", Provider: "openrouter", Model: "paid/b", PromptVersion: "1", RequestedModel: "free/a:free"}}
+	if got := trace.Provenance(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Provenance() = %v, want %v", got, want)
+	}
+}
+
+func TestModelTrace_Provenance(t *testing.T) {
+	ctx, trace := WithModelTrace(context.Background())
+	cvCtx := WithEvaluationStep(ctx, "cv_match", "1")
+	RecordServedModel(cvCtx, "groq", "groq/llama-3.1-8b-instant")
+	RecordServedModel(cvCtx, "groq", "groq/llama-3.1-8b-instant")
+	RecordServedModel(WithEvaluationStep(ctx, "refine", "2"), "openrouter", "qwen/qwen3:free")
+	want := []StepProvenance{
+		{Step: "cv_match", Provider: "groq", Model: "groq/llama-3.1-8b-instant", PromptVersion: "1"},
+		{Step: "refine", Provider: "openrouter", Model: "qwen/qwen3:free", PromptVersion: "2"},
+	}
+	if got := trace.Provenance(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Provenance() = %v, want %v", got, want)
+	}
+	if got := ProvenanceFrom(ctx); !reflect.DeepEqual(got, want) {
+		t.Errorf("ProvenanceFrom() = %v, want %v", got, want)
+	}
+
+	if got := EvaluationStepFrom(cvCtx); got != "cv_match" {
+		t.Errorf("EvaluationStepFrom() = %q, want cv_match", got)
+	}
+	RecordTokenUsage(cvCtx, 900, 100)
+	RecordTokenUsage(ctx, 100, 50)
+
+	ResetModelTrace(ctx)
+	if trace.Last() != "" || trace.Provenance() != nil {
+		t.Errorf("Expected empty trace after reset")
+	}
+	// Tokens spent on discarded calls still count.
+	if prompt, completion := trace.Tokens(); prompt != 1000 || completion != 150 {
+		t.Errorf("Tokens() = (%d, %d), want (1000, 150)", prompt, completion)
+	}
+
+	// Provenance of checkpointed steps is carried over to a new attempt.
+	RestoreProvenance(ctx, want[:1])
+	if got := trace.Provenance(); !reflect.DeepEqual(got, want[:1]) {
+		t.Errorf("Provenance() after restore = %v, want %v", got, want[:1])
+	}
+	RestoreProvenance(context.Background(), want)
+	if got := ProvenanceFrom(context.Background()); got != nil {
+		t.Errorf("ProvenanceFrom() without trace = %v, want nil", got)
+	}
+}
+
+func TestJobExpired(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+	if (Job{}).Expired(now) {
+		t.Errorf("Expected a job without expiry to never expire")
+	}
+	if !(Job{ExpiresAt: &past}).Expired(now) {
+		t.Errorf("Expected a job past its expiry to be expired")
+	}
+	if (Job{ExpiresAt: &future}).Expired(now) {
+		t.Errorf("Expected a job before its expiry not to be expired")
+	}
+
+	if got := JobTTLFrom(context.Background()); got != 0 {
+		t.Errorf("JobTTLFrom() without override = %v, want 0", got)
+	}
+	if got := JobTTLFrom(WithJobTTL(context.Background(), time.Hour)); got != time.Hour {
+		t.Errorf("JobTTLFrom() = %v, want 1h", got)
+	}
+}
@@ -2,7 +2,9 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestErrorConstants(t *testing.T) {
@@ -19,6 +21,7 @@ func TestErrorConstants(t *testing.T) {
 		{"ErrUpstreamRateLimit", ErrUpstreamRateLimit, "upstream rate limit"},
 		{"ErrSchemaInvalid", ErrSchemaInvalid, "schema invalid"},
 		{"ErrInternal", ErrInternal, "internal error"},
+		{"ErrAISaturated", ErrAISaturated, "ai capacity saturated"},
 	}
 
 	for _, tt := range tests {
@@ -59,3 +62,16 @@ func TestErrorIs(t *testing.T) {
 }
 
 // Note: errors.As tests removed due to Go version compatibility issues
+
+func TestRetryAfter(t *testing.T) {
+	err := fmt.Errorf("enqueue: %w", &RetryAfterError{Err: ErrAISaturated, After: 90 * time.Second})
+	if !errors.Is(err, ErrAISaturated) {
+		t.Fatalf("expected %v to wrap ErrAISaturated", err)
+	}
+	if d, ok := RetryAfter(err); !ok || d != 90*time.Second {
+		t.Errorf("RetryAfter = %v, %v; want 1m30s, true", d, ok)
+	}
+	if _, ok := RetryAfter(ErrAISaturated); ok {
+		t.Error("RetryAfter of a plain error should report false")
+	}
+}
@@ -36,6 +36,69 @@ func (_m *MockJobRepository) EXPECT() *MockJobRepository_Expecter {
 	return &MockJobRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddSecurityNote provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) AddSecurityNote(ctx domain.Context, id string, note string) error {
+	ret := _mock.Called(ctx, id, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSecurityNote")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, id, note)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockJobRepository_AddSecurityNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSecurityNote'
+type MockJobRepository_AddSecurityNote_Call struct {
+	*mock.Call
+}
+
+// AddSecurityNote is a helper method to define mock.On call
+//   - ctx domain.Context
+//   - id string
+//   - note string
+func (_e *MockJobRepository_Expecter) AddSecurityNote(ctx interface{}, id interface{}, note interface{}) *MockJobRepository_AddSecurityNote_Call {
+	return &MockJobRepository_AddSecurityNote_Call{Call: _e.mock.On("AddSecurityNote", ctx, id, note)}
+}
+
+func (_c *MockJobRepository_AddSecurityNote_Call) Run(run func(ctx domain.Context, id string, note string)) *MockJobRepository_AddSecurityNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 domain.Context
+		if args[0] != nil {
+			arg0 = args[0].(domain.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_AddSecurityNote_Call) Return(err error) *MockJobRepository_AddSecurityNote_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockJobRepository_AddSecurityNote_Call) RunAndReturn(run func(ctx domain.Context, id string, note string) error) *MockJobRepository_AddSecurityNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Count provides a mock function for the type MockJobRepository
 func (_mock *MockJobRepository) Count(ctx domain.Context) (int64, error) {
 	ret := _mock.Called(ctx)
@@ -96,6 +159,72 @@ func (_c *MockJobRepository_Count_Call) RunAndReturn(run func(ctx domain.Context
 	return _c
 }
 
+// CountByFilter provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) CountByFilter(ctx domain.Context, f domain.JobListFilter) (int64, error) {
+	ret := _mock.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByFilter")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, domain.JobListFilter) (int64, error)); ok {
+		return returnFunc(ctx, f)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, domain.JobListFilter) int64); ok {
+		r0 = returnFunc(ctx, f)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Context, domain.JobListFilter) error); ok {
+		r1 = returnFunc(ctx, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_CountByFilter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByFilter'
+type MockJobRepository_CountByFilter_Call struct {
+	*mock.Call
+}
+
+// CountByFilter is a helper method to define mock.On call
+//   - ctx domain.Context
+//   - f domain.JobListFilter
+func (_e *MockJobRepository_Expecter) CountByFilter(ctx interface{}, f interface{}) *MockJobRepository_CountByFilter_Call {
+	return &MockJobRepository_CountByFilter_Call{Call: _e.mock.On("CountByFilter", ctx, f)}
+}
+
+func (_c *MockJobRepository_CountByFilter_Call) Run(run func(ctx domain.Context, f domain.JobListFilter)) *MockJobRepository_CountByFilter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 domain.Context
+		if args[0] != nil {
+			arg0 = args[0].(domain.Context)
+		}
+		var arg1 domain.JobListFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.JobListFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_CountByFilter_Call) Return(n int64, err error) *MockJobRepository_CountByFilter_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockJobRepository_CountByFilter_Call) RunAndReturn(run func(ctx domain.Context, f domain.JobListFilter) (int64, error)) *MockJobRepository_CountByFilter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CountByStatus provides a mock function for the type MockJobRepository
 func (_mock *MockJobRepository) CountByStatus(ctx domain.Context, status domain.JobStatus) (int64, error) {
 	ret := _mock.Called(ctx, status)
@@ -492,6 +621,74 @@ func (_c *MockJobRepository_GetAverageProcessingTime_Call) RunAndReturn(run func
 	return _c
 }
 
+// GetMany provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) GetMany(ctx domain.Context, ids []string) ([]domain.Job, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 []domain.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, []string) ([]domain.Job, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, []string) []domain.Job); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Context, []string) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_GetMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMany'
+type MockJobRepository_GetMany_Call struct {
+	*mock.Call
+}
+
+// GetMany is a helper method to define mock.On call
+//   - ctx domain.Context
+//   - ids []string
+func (_e *MockJobRepository_Expecter) GetMany(ctx interface{}, ids interface{}) *MockJobRepository_GetMany_Call {
+	return &MockJobRepository_GetMany_Call{Call: _e.mock.On("GetMany", ctx, ids)}
+}
+
+func (_c *MockJobRepository_GetMany_Call) Run(run func(ctx domain.Context, ids []string)) *MockJobRepository_GetMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 domain.Context
+		if args[0] != nil {
+			arg0 = args[0].(domain.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_GetMany_Call) Return(jobs []domain.Job, err error) *MockJobRepository_GetMany_Call {
+	_c.Call.Return(jobs, err)
+	return _c
+}
+
+func (_c *MockJobRepository_GetMany_Call) RunAndReturn(run func(ctx domain.Context, ids []string) ([]domain.Job, error)) *MockJobRepository_GetMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // List provides a mock function for the type MockJobRepository
 func (_mock *MockJobRepository) List(ctx domain.Context, offset int, limit int) ([]domain.Job, error) {
 	ret := _mock.Called(ctx, offset, limit)
@@ -566,6 +763,74 @@ func (_c *MockJobRepository_List_Call) RunAndReturn(run func(ctx domain.Context,
 	return _c
 }
 
+// ListByCursor provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) ListByCursor(ctx domain.Context, f domain.JobListFilter) ([]domain.Job, error) {
+	ret := _mock.Called(ctx, f)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByCursor")
+	}
+
+	var r0 []domain.Job
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, domain.JobListFilter) ([]domain.Job, error)); ok {
+		return returnFunc(ctx, f)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, domain.JobListFilter) []domain.Job); ok {
+		r0 = returnFunc(ctx, f)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Job)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Context, domain.JobListFilter) error); ok {
+		r1 = returnFunc(ctx, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_ListByCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByCursor'
+type MockJobRepository_ListByCursor_Call struct {
+	*mock.Call
+}
+
+// ListByCursor is a helper method to define mock.On call
+//   - ctx domain.Context
+//   - f domain.JobListFilter
+func (_e *MockJobRepository_Expecter) ListByCursor(ctx interface{}, f interface{}) *MockJobRepository_ListByCursor_Call {
+	return &MockJobRepository_ListByCursor_Call{Call: _e.mock.On("ListByCursor", ctx, f)}
+}
+
+func (_c *MockJobRepository_ListByCursor_Call) Run(run func(ctx domain.Context, f domain.JobListFilter)) *MockJobRepository_ListByCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 domain.Context
+		if args[0] != nil {
+			arg0 = args[0].(domain.Context)
+		}
+		var arg1 domain.JobListFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.JobListFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_ListByCursor_Call) Return(jobs []domain.Job, err error) *MockJobRepository_ListByCursor_Call {
+	_c.Call.Return(jobs, err)
+	return _c
+}
+
+func (_c *MockJobRepository_ListByCursor_Call) RunAndReturn(run func(ctx domain.Context, f domain.JobListFilter) ([]domain.Job, error)) *MockJobRepository_ListByCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListWithFilters provides a mock function for the type MockJobRepository
 func (_mock *MockJobRepository) ListWithFilters(ctx domain.Context, offset int, limit int, search string, status string) ([]domain.Job, error) {
 	ret := _mock.Called(ctx, offset, limit, search, status)
@@ -652,6 +917,72 @@ func (_c *MockJobRepository_ListWithFilters_Call) RunAndReturn(run func(ctx doma
 	return _c
 }
 
+// Task provides a mock function for the type MockJobRepository
+func (_mock *MockJobRepository) Task(ctx domain.Context, id string) (domain.EvaluateTaskPayload, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Task")
+	}
+
+	var r0 domain.EvaluateTaskPayload
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, string) (domain.EvaluateTaskPayload, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, string) domain.EvaluateTaskPayload); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.EvaluateTaskPayload)
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockJobRepository_Task_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Task'
+type MockJobRepository_Task_Call struct {
+	*mock.Call
+}
+
+// Task is a helper method to define mock.On call
+//   - ctx domain.Context
+//   - id string
+func (_e *MockJobRepository_Expecter) Task(ctx interface{}, id interface{}) *MockJobRepository_Task_Call {
+	return &MockJobRepository_Task_Call{Call: _e.mock.On("Task", ctx, id)}
+}
+
+func (_c *MockJobRepository_Task_Call) Run(run func(ctx domain.Context, id string)) *MockJobRepository_Task_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 domain.Context
+		if args[0] != nil {
+			arg0 = args[0].(domain.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockJobRepository_Task_Call) Return(evaluateTaskPayload domain.EvaluateTaskPayload, err error) *MockJobRepository_Task_Call {
+	_c.Call.Return(evaluateTaskPayload, err)
+	return _c
+}
+
+func (_c *MockJobRepository_Task_Call) RunAndReturn(run func(ctx domain.Context, id string) (domain.EvaluateTaskPayload, error)) *MockJobRepository_Task_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateStatus provides a mock function for the type MockJobRepository
 func (_mock *MockJobRepository) UpdateStatus(ctx domain.Context, id string, status domain.JobStatus, errMsg *string) error {
 	ret := _mock.Called(ctx, id, status, errMsg)
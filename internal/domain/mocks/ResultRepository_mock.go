@@ -102,6 +102,74 @@ func (_c *MockResultRepository_GetByJobID_Call) RunAndReturn(run func(ctx domain
 	return _c
 }
 
+// GetByJobIDs provides a mock function for the type MockResultRepository
+func (_mock *MockResultRepository) GetByJobIDs(ctx domain.Context, jobIDs []string) ([]domain.Result, error) {
+	ret := _mock.Called(ctx, jobIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByJobIDs")
+	}
+
+	var r0 []domain.Result
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, []string) ([]domain.Result, error)); ok {
+		return returnFunc(ctx, jobIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, []string) []domain.Result); ok {
+		r0 = returnFunc(ctx, jobIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Result)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(domain.Context, []string) error); ok {
+		r1 = returnFunc(ctx, jobIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockResultRepository_GetByJobIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByJobIDs'
+type MockResultRepository_GetByJobIDs_Call struct {
+	*mock.Call
+}
+
+// GetByJobIDs is a helper method to define mock.On call
+//   - ctx domain.Context
+//   - jobIDs []string
+func (_e *MockResultRepository_Expecter) GetByJobIDs(ctx interface{}, jobIDs interface{}) *MockResultRepository_GetByJobIDs_Call {
+	return &MockResultRepository_GetByJobIDs_Call{Call: _e.mock.On("GetByJobIDs", ctx, jobIDs)}
+}
+
+func (_c *MockResultRepository_GetByJobIDs_Call) Run(run func(ctx domain.Context, jobIDs []string)) *MockResultRepository_GetByJobIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 domain.Context
+		if args[0] != nil {
+			arg0 = args[0].(domain.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResultRepository_GetByJobIDs_Call) Return(results []domain.Result, err error) *MockResultRepository_GetByJobIDs_Call {
+	_c.Call.Return(results, err)
+	return _c
+}
+
+func (_c *MockResultRepository_GetByJobIDs_Call) RunAndReturn(run func(ctx domain.Context, jobIDs []string) ([]domain.Result, error)) *MockResultRepository_GetByJobIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Upsert provides a mock function for the type MockResultRepository
 func (_mock *MockResultRepository) Upsert(ctx domain.Context, r domain.Result) error {
 	ret := _mock.Called(ctx, r)
@@ -158,3 +226,66 @@ func (_c *MockResultRepository_Upsert_Call) RunAndReturn(run func(ctx domain.Con
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpsertFenced provides a mock function for the type MockResultRepository
+func (_mock *MockResultRepository) UpsertFenced(ctx domain.Context, r domain.Result, token int64) error {
+	ret := _mock.Called(ctx, r, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertFenced")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(domain.Context, domain.Result, int64) error); ok {
+		r0 = returnFunc(ctx, r, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockResultRepository_UpsertFenced_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertFenced'
+type MockResultRepository_UpsertFenced_Call struct {
+	*mock.Call
+}
+
+// UpsertFenced is a helper method to define mock.On call
+//   - ctx domain.Context
+//   - r domain.Result
+//   - token int64
+func (_e *MockResultRepository_Expecter) UpsertFenced(ctx interface{}, r interface{}, token interface{}) *MockResultRepository_UpsertFenced_Call {
+	return &MockResultRepository_UpsertFenced_Call{Call: _e.mock.On("UpsertFenced", ctx, r, token)}
+}
+
+func (_c *MockResultRepository_UpsertFenced_Call) Run(run func(ctx domain.Context, r domain.Result, token int64)) *MockResultRepository_UpsertFenced_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 domain.Context
+		if args[0] != nil {
+			arg0 = args[0].(domain.Context)
+		}
+		var arg1 domain.Result
+		if args[1] != nil {
+			arg1 = args[1].(domain.Result)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockResultRepository_UpsertFenced_Call) Return(err error) *MockResultRepository_UpsertFenced_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockResultRepository_UpsertFenced_Call) RunAndReturn(run func(ctx domain.Context, r domain.Result, token int64) error) *MockResultRepository_UpsertFenced_Call {
+	_c.Call.Return(run)
+	return _c
+}
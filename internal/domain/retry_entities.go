@@ -61,6 +61,7 @@ func DefaultRetryConfig() RetryConfig {
 			"schema invalid",
 			"authentication failed",
 			"authorization failed",
+			"quota exceeded",
 		},
 	}
 }
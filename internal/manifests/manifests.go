@@ -0,0 +1,344 @@
+// Package manifests renders the Kubernetes deployment assets of the service
+// from the configuration struct so that every variable read by config.Load is
+// present in the generated ConfigMap or Secret.
+package manifests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+)
+
+// shutdownFlush is the time cmd/server spends flushing the producer and
+// traces after the HTTP drain.
+const shutdownFlush = 10 * time.Second
+
+// Options parameterise the generated manifests.
+type Options struct {
+	Name      string
+	Namespace string
+	// Registry and Tag form the images <Registry>/<Name>-{server,worker}:<Tag>.
+	Registry string
+	Tag      string
+
+	ServerReplicas    int
+	ServerMaxReplicas int
+	WorkerReplicas    int
+	WorkerMaxReplicas int
+	// TargetCPU is the average CPU utilisation, in percent, the autoscalers
+	// aim for.
+	TargetCPU int
+
+	// Env overrides ConfigMap defaults by variable name.
+	Env map[string]string
+}
+
+// DefaultOptions returns the options used for the assets checked into
+// deploy/k8s.
+func DefaultOptions() Options {
+	return Options{
+		Name:              "ai-cv-evaluator",
+		Namespace:         "ai-cv-evaluator",
+		Registry:          "ghcr.io/fairyhunter13",
+		Tag:               "latest",
+		ServerReplicas:    2,
+		ServerMaxReplicas: 6,
+		WorkerReplicas:    1,
+		WorkerMaxReplicas: 4,
+		TargetCPU:         70,
+		Env: map[string]string{
+			"APP_ENV":       "prod",
+			"PORT":          "8080",
+			"RUN_MODE":      config.RunModeServer,
+			"QDRANT_URL":    "http://qdrant:6333",
+			"TIKA_URL":      "http://tika:9998",
+			"REDIS_ADDR":    "redis:6379",
+			"KAFKA_BROKERS": "redpanda:9092",
+		},
+	}
+}
+
+// File is one rendered manifest.
+type File struct {
+	Name string
+	Body []byte
+}
+
+type entry struct {
+	Name, Value string
+}
+
+type data struct {
+	Options
+	Config  []entry
+	Secrets []entry
+	Port    string
+	// GracePeriod is the pod termination grace period in seconds.
+	GracePeriod int
+}
+
+type autoscaler struct {
+	Component string
+	Min, Max  int
+}
+
+// Autoscalers lists the Deployments scaled on CPU.
+func (d data) Autoscalers() []autoscaler {
+	return []autoscaler{
+		{Component: "server", Min: d.ServerReplicas, Max: d.ServerMaxReplicas},
+		{Component: "worker", Min: d.WorkerReplicas, Max: d.WorkerMaxReplicas},
+	}
+}
+
+// Render renders the ConfigMap, an example Secret, the server and worker
+// Deployments, the server Service and the autoscalers.
+func Render(opts Options) ([]File, error) {
+	d := data{Options: opts}
+	env := config.EnvVars()
+	known := make(map[string]config.EnvVar, len(env))
+	for _, v := range env {
+		known[v.Name] = v
+	}
+	for name := range opts.Env {
+		v, ok := known[name]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("op=manifests.render: unknown environment variable %s", name)
+		case v.Secret:
+			return nil, fmt.Errorf("op=manifests.render: %s is a secret and cannot be set in the ConfigMap", name)
+		}
+	}
+	values := map[string]string{}
+	for _, v := range env {
+		if v.Secret {
+			d.Secrets = append(d.Secrets, entry{Name: v.Name})
+			continue
+		}
+		value := v.Default
+		if o, ok := opts.Env[v.Name]; ok {
+			value = o
+		}
+		values[v.Name] = value
+		d.Config = append(d.Config, entry{Name: v.Name, Value: value})
+	}
+	d.Port = values["PORT"]
+
+	grace := shutdownFlush
+	for _, name := range []string{"SERVER_SHUTDOWN_TIMEOUT", "SERVER_DRAIN_DELAY"} {
+		dur, err := time.ParseDuration(values[name])
+		if err != nil {
+			return nil, fmt.Errorf("op=manifests.render: %s: %w", name, err)
+		}
+		grace += dur
+	}
+	d.GracePeriod = int(grace.Round(time.Second) / time.Second)
+
+	files := make([]File, 0, len(templates))
+	for _, t := range templates {
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, d); err != nil {
+			return nil, fmt.Errorf("op=manifests.render: %s: %w", t.Name(), err)
+		}
+		files = append(files, File{Name: t.Name(), Body: buf.Bytes()})
+	}
+	return files, nil
+}
+
+// quote renders s as a YAML double-quoted scalar.
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+var funcs = template.FuncMap{"quote": quote}
+
+var templates = []*template.Template{
+	template.Must(template.New("configmap.yaml").Funcs(funcs).Parse(configMapTmpl)),
+	template.Must(template.New("secret.example.yaml").Funcs(funcs).Parse(secretTmpl)),
+	template.Must(template.New("server.yaml").Funcs(funcs).Parse(serverTmpl)),
+	template.Must(template.New("worker.yaml").Funcs(funcs).Parse(workerTmpl)),
+	template.Must(template.New("hpa.yaml").Funcs(funcs).Parse(hpaTmpl)),
+}
+
+const header = `# Code generated by cmd/genmanifests from internal/config; DO NOT EDIT.
+`
+
+const configMapTmpl = header + `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{.Name}}-config
+  namespace: {{.Namespace}}
+  labels:
+    app.kubernetes.io/name: {{.Name}}
+data:
+{{- range .Config}}
+  {{.Name}}: {{quote .Value}}
+{{- end}}
+`
+
+const secretTmpl = header + `# Fill in the values (or provision the Secret from your secret store) and
+# apply it before the Deployments. Empty variables keep their defaults.
+apiVersion: v1
+kind: Secret
+metadata:
+  name: {{.Name}}-secrets
+  namespace: {{.Namespace}}
+  labels:
+    app.kubernetes.io/name: {{.Name}}
+type: Opaque
+stringData:
+{{- range .Secrets}}
+  {{.Name}}: ""
+{{- end}}
+`
+
+const envFrom = `          envFrom:
+            - configMapRef:
+                name: {{.Name}}-config
+            - secretRef:
+                name: {{.Name}}-secrets
+`
+
+const resources = `          resources:
+            requests:
+              cpu: 250m
+              memory: 32Mi
+            limits:
+              cpu: "1"
+              memory: 128Mi
+`
+
+const serverTmpl = header + `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.Name}}-server
+  namespace: {{.Namespace}}
+  labels:
+    app.kubernetes.io/name: {{.Name}}
+    app.kubernetes.io/component: server
+spec:
+  replicas: {{.ServerReplicas}}
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: {{.Name}}
+      app.kubernetes.io/component: server
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: {{.Name}}
+        app.kubernetes.io/component: server
+      annotations:
+        prometheus.io/scrape: "true"
+        prometheus.io/port: {{quote .Port}}
+        prometheus.io/path: /metrics
+    spec:
+      # SERVER_SHUTDOWN_TIMEOUT + SERVER_DRAIN_DELAY + producer/trace flush.
+      terminationGracePeriodSeconds: {{.GracePeriod}}
+      containers:
+        - name: server
+          image: {{.Registry}}/{{.Name}}-server:{{.Tag}}
+          ports:
+            - name: http
+              containerPort: {{.Port}}
+` + envFrom + `          readinessProbe:
+            httpGet:
+              path: /readyz
+              port: http
+            periodSeconds: 5
+            failureThreshold: 1
+          # /healthz reports dependency status; liveness only checks that the
+          # process still accepts connections.
+          livenessProbe:
+            tcpSocket:
+              port: http
+            periodSeconds: 10
+            failureThreshold: 3
+` + resources + `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{.Name}}-server
+  namespace: {{.Namespace}}
+  labels:
+    app.kubernetes.io/name: {{.Name}}
+    app.kubernetes.io/component: server
+spec:
+  selector:
+    app.kubernetes.io/name: {{.Name}}
+    app.kubernetes.io/component: server
+  ports:
+    - name: http
+      port: 80
+      targetPort: http
+`
+
+const workerTmpl = header + `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{.Name}}-worker
+  namespace: {{.Namespace}}
+  labels:
+    app.kubernetes.io/name: {{.Name}}
+    app.kubernetes.io/component: worker
+spec:
+  replicas: {{.WorkerReplicas}}
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: {{.Name}}
+      app.kubernetes.io/component: worker
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: {{.Name}}
+        app.kubernetes.io/component: worker
+      annotations:
+        prometheus.io/scrape: "true"
+        prometheus.io/port: "9090"
+        prometheus.io/path: /metrics
+    spec:
+      containers:
+        - name: worker
+          image: {{.Registry}}/{{.Name}}-worker:{{.Tag}}
+          ports:
+            - name: metrics
+              containerPort: 9090
+` + envFrom + `          livenessProbe:
+            httpGet:
+              path: /metrics
+              port: metrics
+            periodSeconds: 15
+            failureThreshold: 3
+` + resources
+
+const hpaTmpl = header + `{{- range $i, $a := .Autoscalers}}
+{{- if $i}}
+---
+{{- end}}
+apiVersion: autoscaling/v2
+kind: HorizontalPodAutoscaler
+metadata:
+  name: {{$.Name}}-{{$a.Component}}
+  namespace: {{$.Namespace}}
+  labels:
+    app.kubernetes.io/name: {{$.Name}}
+    app.kubernetes.io/component: {{$a.Component}}
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: {{$.Name}}-{{$a.Component}}
+  minReplicas: {{$a.Min}}
+  maxReplicas: {{$a.Max}}
+  metrics:
+    - type: Resource
+      resource:
+        name: cpu
+        target:
+          type: Utilization
+          averageUtilization: {{$.TargetCPU}}
+{{- end}}
+`
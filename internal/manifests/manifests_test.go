@@ -0,0 +1,83 @@
+package manifests_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/manifests"
+)
+
+func render(t *testing.T, opts manifests.Options) map[string]string {
+	t.Helper()
+	files, err := manifests.Render(opts)
+	require.NoError(t, err)
+	out := map[string]string{}
+	for _, f := range files {
+		out[f.Name] = string(f.Body)
+	}
+	return out
+}
+
+func TestRender_CoversEveryEnvVar(t *testing.T) {
+	files := render(t, manifests.DefaultOptions())
+	for _, v := range config.EnvVars() {
+		key := "\n  " + v.Name + ":"
+		if v.Secret {
+			assert.Contains(t, files["secret.example.yaml"], key)
+			assert.NotContains(t, files["configmap.yaml"], key, "secret leaked into the ConfigMap")
+		} else {
+			assert.Contains(t, files["configmap.yaml"], key)
+		}
+	}
+	assert.Contains(t, files["configmap.yaml"], `APP_ENV: "prod"`)
+	assert.Contains(t, files["server.yaml"], "terminationGracePeriodSeconds: 40")
+	assert.Contains(t, files["hpa.yaml"], "name: ai-cv-evaluator-worker")
+}
+
+func TestRender_Overrides(t *testing.T) {
+	opts := manifests.DefaultOptions()
+	opts.Env["SERVER_DRAIN_DELAY"] = "5s"
+	opts.Env["PORT"] = "9000"
+	files := render(t, opts)
+	assert.Contains(t, files["server.yaml"], "terminationGracePeriodSeconds: 45")
+	assert.Contains(t, files["server.yaml"], "containerPort: 9000")
+
+	opts.Env["NO_SUCH_VAR"] = "x"
+	_, err := manifests.Render(opts)
+	assert.ErrorContains(t, err, "unknown environment variable NO_SUCH_VAR")
+
+	opts = manifests.DefaultOptions()
+	opts.Env["ADMIN_PASSWORD"] = "hunter2"
+	_, err = manifests.Render(opts)
+	assert.ErrorContains(t, err, "ADMIN_PASSWORD is a secret")
+
+	opts = manifests.DefaultOptions()
+	opts.Env["SERVER_SHUTDOWN_TIMEOUT"] = "soon"
+	_, err = manifests.Render(opts)
+	assert.ErrorContains(t, err, "SERVER_SHUTDOWN_TIMEOUT")
+}
+
+// TestCheckedInManifestsUpToDate fails when a config change was not followed
+// by `make k8s-manifests`.
+func TestCheckedInManifestsUpToDate(t *testing.T) {
+	for name, body := range render(t, manifests.DefaultOptions()) {
+		got, err := os.ReadFile(filepath.Join("..", "..", "deploy", "k8s", name))
+		require.NoError(t, err)
+		if string(got) != body {
+			t.Errorf("deploy/k8s/%s is stale; run make k8s-manifests", name)
+		}
+	}
+	entries, err := os.ReadDir(filepath.Join("..", "..", "deploy", "k8s"))
+	require.NoError(t, err)
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".yaml") {
+			assert.Contains(t, render(t, manifests.DefaultOptions()), e.Name(), "unexpected file in deploy/k8s")
+		}
+	}
+}
@@ -103,6 +103,27 @@ func TestConnectionMetrics_RecordFailureAndTimeout(t *testing.T) {
 	}
 }
 
+func TestConnectionMetrics_CircuitOpenHook(t *testing.T) {
+	var opened []string
+	SetCircuitOpenHook(func(connType ConnectionType, endpoint string) {
+		opened = append(opened, string(connType)+"/"+endpoint)
+	})
+	defer SetCircuitOpenHook(nil)
+
+	cm := NewConnectionMetrics(ConnectionTypeAI, OperationTypeChat, "groq")
+	for i := 0; i < 4; i++ {
+		cm.RecordFailure(errors.New("boom"), time.Millisecond)
+	}
+	if len(opened) != 0 {
+		t.Fatalf("hook called before the circuit opened: %v", opened)
+	}
+	cm.RecordTimeout(time.Millisecond)
+	cm.RecordFailure(errors.New("boom"), time.Millisecond)
+	if len(opened) != 1 || opened[0] != "ai/groq" {
+		t.Fatalf("opened = %v, want one ai/groq transition", opened)
+	}
+}
+
 func TestConnectionMetrics_GetStatsAndIsHealthy(t *testing.T) {
 	cm := NewConnectionMetrics(ConnectionTypeQueue, OperationTypeConsume, "queue")
 
@@ -58,3 +58,41 @@ func RequestIDFromContext(ctx context.Context) string {
 	}
 	return ""
 }
+
+// requestIDHandler adds the context's request_id to records logged with a
+// context, unless the logger already carries one.
+type requestIDHandler struct {
+	slog.Handler
+	hasRequestID bool
+}
+
+// WithRequestIDs returns lg with a handler that tags every record logged
+// through the *Context methods with the request_id stored in the context, so
+// layers that only receive a context still correlate their logs.
+func WithRequestIDs(lg *slog.Logger) *slog.Logger {
+	return slog.New(requestIDHandler{Handler: lg.Handler()})
+}
+
+func (h requestIDHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.hasRequestID {
+		if rid := RequestIDFromContext(ctx); rid != "" {
+			r = r.Clone()
+			r.AddAttrs(slog.String("request_id", rid))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h requestIDHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	has := h.hasRequestID
+	for _, a := range attrs {
+		if a.Key == "request_id" {
+			has = true
+		}
+	}
+	return requestIDHandler{Handler: h.Handler.WithAttrs(attrs), hasRequestID: has}
+}
+
+func (h requestIDHandler) WithGroup(name string) slog.Handler {
+	return requestIDHandler{Handler: h.Handler.WithGroup(name), hasRequestID: h.hasRequestID}
+}
@@ -1,8 +1,10 @@
 package observability
 
 import (
+	"bytes"
 	"context"
 	"log/slog"
+	"strings"
 	"testing"
 )
 
@@ -72,3 +74,27 @@ func TestContextWithRequestID_ValidRequestID(t *testing.T) {
 		t.Fatalf("expected req-456, got %q", got)
 	}
 }
+
+func TestWithRequestIDs(t *testing.T) {
+	var buf bytes.Buffer
+	lg := WithRequestIDs(slog.New(slog.NewJSONHandler(&buf, nil)))
+	ctx := ContextWithRequestID(context.Background(), "req-42")
+
+	lg.InfoContext(ctx, "with context")
+	lg.Info("without context")
+	lg.With(slog.String("request_id", "req-42")).InfoContext(ctx, "already tagged")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %q", buf.String())
+	}
+	if !strings.Contains(lines[0], `"request_id":"req-42"`) {
+		t.Fatalf("missing request_id: %s", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Fatalf("unexpected request_id: %s", lines[1])
+	}
+	if strings.Count(lines[2], "request_id") != 1 {
+		t.Fatalf("duplicated request_id: %s", lines[2])
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -37,6 +38,26 @@ const (
 	OperationTypeRequest OperationType = "request"
 )
 
+// circuitOpenHook is called whenever a connection's circuit opens.
+var circuitOpenHook atomic.Pointer[func(ConnectionType, string)]
+
+// SetCircuitOpenHook registers fn to be called whenever the circuit of any
+// connection transitions to open. fn runs outside the metrics lock on the
+// failing call's goroutine and must not block. A nil fn removes the hook.
+func SetCircuitOpenHook(fn func(connType ConnectionType, endpoint string)) {
+	if fn == nil {
+		circuitOpenHook.Store(nil)
+		return
+	}
+	circuitOpenHook.Store(&fn)
+}
+
+func (cm *ConnectionMetrics) notifyCircuitOpen() {
+	if fn := circuitOpenHook.Load(); fn != nil {
+		(*fn)(cm.ConnectionType, cm.Endpoint)
+	}
+}
+
 // ConnectionMetrics tracks metrics for external connections
 type ConnectionMetrics struct {
 	mu sync.RWMutex
@@ -128,6 +149,12 @@ func (cm *ConnectionMetrics) RecordSuccess(duration time.Duration) {
 
 // RecordFailure records a failed operation
 func (cm *ConnectionMetrics) RecordFailure(err error, _ time.Duration) {
+	opened := false
+	defer func() {
+		if opened {
+			cm.notifyCircuitOpen()
+		}
+	}()
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
@@ -145,6 +172,7 @@ func (cm *ConnectionMetrics) RecordFailure(err error, _ time.Duration) {
 	cm.CircuitFailures++
 	if cm.CircuitState == "closed" && cm.CircuitFailures >= 5 {
 		cm.CircuitState = "open"
+		opened = true
 	} else if cm.CircuitState == "open" && time.Since(cm.LastFailure) > 30*time.Second {
 		cm.CircuitState = "half-open"
 		cm.CircuitFailures = 0
@@ -154,6 +182,12 @@ func (cm *ConnectionMetrics) RecordFailure(err error, _ time.Duration) {
 
 // RecordTimeout records a timeout
 func (cm *ConnectionMetrics) RecordTimeout(_ time.Duration) {
+	opened := false
+	defer func() {
+		if opened {
+			cm.notifyCircuitOpen()
+		}
+	}()
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
@@ -167,6 +201,7 @@ func (cm *ConnectionMetrics) RecordTimeout(_ time.Duration) {
 	cm.CircuitFailures++
 	if cm.CircuitState == "closed" && cm.CircuitFailures >= 5 {
 		cm.CircuitState = "open"
+		opened = true
 	}
 }
 
@@ -0,0 +1,369 @@
+// Package portable dumps the evaluation data of an environment to a portable
+// archive and restores it into another one, for staging refreshes and
+// disaster-recovery drills.
+//
+// An archive is a gzip-compressed tar file holding manifest.json followed by
+// one newline-delimited JSON file per table (db/<table>.ndjson) and per
+// Qdrant collection (vectors/<collection>.ndjson). Table rows are JSON
+// objects keyed by column name; points carry their id, vector and payload, so
+// the RAG corpus is restored without calling the embeddings provider.
+package portable
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+)
+
+// FormatVersion is the archive layout version written to the manifest.
+const FormatVersion = 1
+
+const manifestName = "manifest.json"
+
+// ErrUnsupportedArchive is returned for archives that are not portable
+// archives or were written by a newer, incompatible version.
+var ErrUnsupportedArchive = errors.New("unsupported archive")
+
+// TableStore reads and writes the rows of the portable tables. It is
+// implemented by postgres.PortableRepo.
+type TableStore interface {
+	ExportRows(ctx context.Context, table string, fn func(row json.RawMessage) error) error
+	ImportRow(ctx context.Context, table string, row json.RawMessage) (bool, error)
+}
+
+// VectorStore is the subset of the Qdrant client used to copy collections.
+type VectorStore interface {
+	ScrollVectors(ctx context.Context, collection string, limit int, offset any) ([]qdrantcli.VectorPoint, any, error)
+	EnsureCollectionSpec(ctx context.Context, spec qdrantcli.CollectionSpec, recreate bool) error
+	UpsertPoints(ctx context.Context, collection string, vectors [][]float32, payloads []map[string]any, ids []any) error
+}
+
+// Manifest describes the contents of an archive.
+type Manifest struct {
+	Version     int                   `json:"version"`
+	CreatedAt   time.Time             `json:"created_at"`
+	Tables      map[string]int        `json:"tables"`
+	Collections map[string]Collection `json:"collections"`
+}
+
+// Collection describes an exported Qdrant collection.
+type Collection struct {
+	Points     int `json:"points"`
+	VectorSize int `json:"vector_size"`
+}
+
+// Result counts what an import wrote; rows and points already present in the
+// target environment are counted as skipped.
+type Result struct {
+	Rows        map[string]int
+	SkippedRows map[string]int
+	Points      map[string]int
+}
+
+// Exporter writes archives.
+type Exporter struct {
+	Tables      TableStore
+	Vectors     VectorStore
+	TableNames  []string
+	Collections []string
+	// BatchSize is the number of points read per scroll.
+	BatchSize int
+	Now       func() time.Time
+}
+
+// Export writes an archive of every table and collection to w. The files are
+// staged in a temporary directory so the manifest, which needs the final
+// counts, can lead the archive.
+func (e *Exporter) Export(ctx context.Context, w io.Writer) (Manifest, error) {
+	now := time.Now
+	if e.Now != nil {
+		now = e.Now
+	}
+	m := Manifest{Version: FormatVersion, CreatedAt: now().UTC(), Tables: map[string]int{}, Collections: map[string]Collection{}}
+	dir, err := os.MkdirTemp("", "portable-export-")
+	if err != nil {
+		return m, fmt.Errorf("op=portable.export: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	var files []string
+	for _, table := range e.TableNames {
+		name := "db/" + table + ".ndjson"
+		n, err := stage(dir, name, func(enc *json.Encoder) (int, error) {
+			n := 0
+			err := e.Tables.ExportRows(ctx, table, func(row json.RawMessage) error {
+				n++
+				return enc.Encode(row)
+			})
+			return n, err
+		})
+		if err != nil {
+			return m, fmt.Errorf("op=portable.export_table %s: %w", table, err)
+		}
+		m.Tables[table] = n
+		files = append(files, name)
+		slog.Info("portable table exported", slog.String("table", table), slog.Int("rows", n))
+	}
+	for _, collection := range e.Collections {
+		name := "vectors/" + collection + ".ndjson"
+		var info Collection
+		_, err := stage(dir, name, func(enc *json.Encoder) (int, error) {
+			var offset any
+			for {
+				points, next, err := e.Vectors.ScrollVectors(ctx, collection, batchSize(e.BatchSize), offset)
+				if err != nil {
+					return info.Points, err
+				}
+				for _, p := range points {
+					if info.VectorSize == 0 {
+						info.VectorSize = len(p.Vector)
+					}
+					if err := enc.Encode(p); err != nil {
+						return info.Points, err
+					}
+					info.Points++
+				}
+				if next == nil {
+					return info.Points, nil
+				}
+				offset = next
+			}
+		})
+		if err != nil {
+			return m, fmt.Errorf("op=portable.export_collection %s: %w", collection, err)
+		}
+		m.Collections[collection] = info
+		files = append(files, name)
+		slog.Info("portable collection exported", slog.String("collection", collection), slog.Int("points", info.Points))
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return m, fmt.Errorf("op=portable.export_manifest: %w", err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0o644, Size: int64(len(manifest)), ModTime: m.CreatedAt}); err != nil {
+		return m, fmt.Errorf("op=portable.export_manifest: %w", err)
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return m, fmt.Errorf("op=portable.export_manifest: %w", err)
+	}
+	for _, name := range files {
+		if err := appendFile(tw, dir, name, m.CreatedAt); err != nil {
+			return m, fmt.Errorf("op=portable.export_write %s: %w", name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return m, fmt.Errorf("op=portable.export_write: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return m, fmt.Errorf("op=portable.export_write: %w", err)
+	}
+	return m, nil
+}
+
+// stage writes the records produced by fill to dir/name as NDJSON.
+func stage(dir, name string, fill func(enc *json.Encoder) (int, error)) (int, error) {
+	p := path.Join(dir, name)
+	if err := os.MkdirAll(path.Dir(p), 0o700); err != nil {
+		return 0, err
+	}
+	f, err := os.Create(p)
+	if err != nil {
+		return 0, err
+	}
+	bw := bufio.NewWriter(f)
+	n, err := fill(json.NewEncoder(bw))
+	if err == nil {
+		err = bw.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+func appendFile(tw *tar.Writer, dir, name string, modTime time.Time) error {
+	f, err := os.Open(path.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: st.Size(), ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// Importer restores archives.
+type Importer struct {
+	Tables  TableStore
+	Vectors VectorStore
+	// VectorName, when set, stores vectors under that named vector.
+	VectorName string
+	// PayloadIndexes are created on restored collections.
+	PayloadIndexes map[string]string
+	// BatchSize is the number of points written per upsert.
+	BatchSize int
+	// SkipVectors restores the tables only.
+	SkipVectors bool
+}
+
+// Import restores the archive read from r. Rows whose key already exists in
+// the target are skipped and points are upserted by id, so an interrupted
+// import can simply be rerun.
+func (im *Importer) Import(ctx context.Context, r io.Reader) (Manifest, Result, error) {
+	res := Result{Rows: map[string]int{}, SkippedRows: map[string]int{}, Points: map[string]int{}}
+	var m Manifest
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return m, res, fmt.Errorf("op=portable.import: %w: %v", ErrUnsupportedArchive, err)
+	}
+	defer func() { _ = gz.Close() }()
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != manifestName {
+		return m, res, fmt.Errorf("op=portable.import: %w: %s must come first", ErrUnsupportedArchive, manifestName)
+	}
+	if err := json.NewDecoder(tr).Decode(&m); err != nil {
+		return m, res, fmt.Errorf("op=portable.import_manifest: %w: %v", ErrUnsupportedArchive, err)
+	}
+	if m.Version < 1 || m.Version > FormatVersion {
+		return m, res, fmt.Errorf("op=portable.import_manifest: %w: version %d", ErrUnsupportedArchive, m.Version)
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return m, res, fmt.Errorf("op=portable.import_read: %w", err)
+		}
+		dir, file := path.Split(hdr.Name)
+		name := strings.TrimSuffix(file, ".ndjson")
+		switch dir {
+		case "db/":
+			if err := im.importTable(ctx, tr, name, &res); err != nil {
+				return m, res, err
+			}
+			slog.Info("portable table imported", slog.String("table", name),
+				slog.Int("rows", res.Rows[name]), slog.Int("skipped", res.SkippedRows[name]))
+		case "vectors/":
+			if im.SkipVectors {
+				continue
+			}
+			if err := im.importCollection(ctx, tr, name, m.Collections[name], &res); err != nil {
+				return m, res, err
+			}
+			slog.Info("portable collection imported", slog.String("collection", name), slog.Int("points", res.Points[name]))
+		}
+	}
+	return m, res, nil
+}
+
+func (im *Importer) importTable(ctx context.Context, r io.Reader, table string, res *Result) error {
+	dec := json.NewDecoder(r)
+	for {
+		var row json.RawMessage
+		if err := dec.Decode(&row); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("op=portable.import_table %s: %w", table, err)
+		}
+		inserted, err := im.Tables.ImportRow(ctx, table, row)
+		if err != nil {
+			return err
+		}
+		if inserted {
+			res.Rows[table]++
+		} else {
+			res.SkippedRows[table]++
+		}
+	}
+}
+
+func (im *Importer) importCollection(ctx context.Context, r io.Reader, collection string, info Collection, res *Result) error {
+	if info.VectorSize > 0 {
+		spec := qdrantcli.CollectionSpec{Name: collection, PayloadIndexes: im.PayloadIndexes}
+		params := qdrantcli.VectorParams{Size: info.VectorSize, Distance: "Cosine"}
+		if im.VectorName != "" {
+			spec.Named = map[string]qdrantcli.VectorParams{im.VectorName: params}
+		} else {
+			spec.Vector = params
+		}
+		if err := im.Vectors.EnsureCollectionSpec(ctx, spec, false); err != nil {
+			return fmt.Errorf("op=portable.import_collection %s: %w", collection, err)
+		}
+	}
+	var (
+		vectors  [][]float32
+		payloads []map[string]any
+		ids      []any
+	)
+	flush := func() error {
+		if len(ids) == 0 {
+			return nil
+		}
+		if err := im.Vectors.UpsertPoints(ctx, collection, vectors, payloads, ids); err != nil {
+			return fmt.Errorf("op=portable.import_points %s: %w", collection, err)
+		}
+		res.Points[collection] += len(ids)
+		vectors, payloads, ids = vectors[:0], payloads[:0], ids[:0]
+		return nil
+	}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	for {
+		var p qdrantcli.VectorPoint
+		if err := dec.Decode(&p); errors.Is(err, io.EOF) {
+			return flush()
+		} else if err != nil {
+			return fmt.Errorf("op=portable.import_collection %s: %w", collection, err)
+		}
+		vectors, payloads, ids = append(vectors, p.Vector), append(payloads, p.Payload), append(ids, pointID(p.ID))
+		if len(ids) >= batchSize(im.BatchSize) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// pointID restores integer point ids, which UseNumber decodes as json.Number;
+// other ids are UUID strings.
+func pointID(id any) any {
+	if n, ok := id.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return i
+		}
+		return n.String()
+	}
+	return id
+}
+
+func batchSize(n int) int {
+	if n <= 0 {
+		return 256
+	}
+	return n
+}
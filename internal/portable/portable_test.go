@@ -0,0 +1,175 @@
+package portable_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/portable"
+)
+
+type fakeTables struct {
+	rows map[string][]json.RawMessage
+}
+
+func (f *fakeTables) ExportRows(_ context.Context, table string, fn func(json.RawMessage) error) error {
+	for _, r := range f.rows[table] {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeKeys are the key columns of the tables, as in postgres.PortableRepo.
+var fakeKeys = map[string][]string{"uploads": {"id"}, "jobs": {"id"}, "results": {"job_id"}}
+
+func rowKey(table string, row json.RawMessage) string {
+	var m map[string]any
+	_ = json.Unmarshal(row, &m)
+	key := ""
+	for _, c := range fakeKeys[table] {
+		key += fmt.Sprint(m[c]) + "/"
+	}
+	return key
+}
+
+func (f *fakeTables) ImportRow(_ context.Context, table string, row json.RawMessage) (bool, error) {
+	for _, r := range f.rows[table] {
+		if rowKey(table, r) == rowKey(table, row) {
+			return false, nil
+		}
+	}
+	f.rows[table] = append(f.rows[table], row)
+	return true, nil
+}
+
+type fakeVectors struct {
+	points map[string][]qdrantcli.VectorPoint
+	specs  map[string]qdrantcli.CollectionSpec
+}
+
+func (f *fakeVectors) ScrollVectors(_ context.Context, collection string, limit int, offset any) ([]qdrantcli.VectorPoint, any, error) {
+	pts := f.points[collection]
+	start := 0
+	if offset != nil {
+		start = offset.(int)
+	}
+	if start+limit >= len(pts) {
+		return pts[start:], nil, nil
+	}
+	return pts[start : start+limit], start + limit, nil
+}
+
+func (f *fakeVectors) EnsureCollectionSpec(_ context.Context, spec qdrantcli.CollectionSpec, _ bool) error {
+	f.specs[spec.Name] = spec
+	return nil
+}
+
+func (f *fakeVectors) UpsertPoints(_ context.Context, collection string, vectors [][]float32, payloads []map[string]any, ids []any) error {
+	for i := range ids {
+		f.points[collection] = append(f.points[collection], qdrantcli.VectorPoint{ID: ids[i], Vector: vectors[i], Payload: payloads[i]})
+	}
+	return nil
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	src := &fakeTables{rows: map[string][]json.RawMessage{
+		"uploads": {json.RawMessage(`{"id":"u1","type":"cv"}`)},
+		"jobs":    {json.RawMessage(`{"id":"j1","cv_id":"u1"}`), json.RawMessage(`{"id":"j2","cv_id":"u1"}`)},
+	}}
+	srcVectors := &fakeVectors{points: map[string][]qdrantcli.VectorPoint{
+		"job_description": {
+			{ID: 7.0, Vector: []float32{0.1, 0.2}, Payload: map[string]any{"text": "Go"}},
+			{ID: "b0c5", Vector: []float32{0.3, 0.4}, Payload: map[string]any{"text": "SQL"}},
+		},
+	}}
+	exp := &portable.Exporter{
+		Tables: src, Vectors: srcVectors,
+		TableNames: []string{"uploads", "jobs"}, Collections: []string{"job_description"},
+		BatchSize: 1, Now: func() time.Time { return time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC) },
+	}
+	var buf bytes.Buffer
+	m, err := exp.Export(ctx, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"uploads": 1, "jobs": 2}, m.Tables)
+	assert.Equal(t, portable.Collection{Points: 2, VectorSize: 2}, m.Collections["job_description"])
+
+	dst := &fakeTables{rows: map[string][]json.RawMessage{"uploads": {json.RawMessage(`{"id":"u1","type":"cv"}`)}}}
+	dstVectors := &fakeVectors{points: map[string][]qdrantcli.VectorPoint{}, specs: map[string]qdrantcli.CollectionSpec{}}
+	imp := &portable.Importer{Tables: dst, Vectors: dstVectors, VectorName: "dense"}
+	got, res, err := imp.Import(ctx, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, m.CreatedAt, got.CreatedAt)
+	assert.Equal(t, map[string]int{"jobs": 2}, res.Rows)
+	assert.Equal(t, map[string]int{"uploads": 1}, res.SkippedRows)
+	assert.Equal(t, 2, res.Points["job_description"])
+	assert.Len(t, dst.rows["jobs"], 2)
+	assert.Equal(t, map[string]qdrantcli.VectorParams{"dense": {Size: 2, Distance: "Cosine"}}, dstVectors.specs["job_description"].Named)
+	pts := dstVectors.points["job_description"]
+	require.Len(t, pts, 2)
+	assert.Equal(t, int64(7), pts[0].ID)
+	assert.Equal(t, "b0c5", pts[1].ID)
+	assert.Equal(t, []float32{0.3, 0.4}, pts[1].Vector)
+	assert.Equal(t, "SQL", pts[1].Payload["text"])
+}
+
+func TestImport_RerunKeepsRowCounts(t *testing.T) {
+	ctx := context.Background()
+	src := &fakeTables{rows: map[string][]json.RawMessage{
+		"uploads": {json.RawMessage(`{"id":"u1","type":"cv"}`)},
+		"jobs":    {json.RawMessage(`{"id":"j1","cv_id":"u1"}`), json.RawMessage(`{"id":"j2","cv_id":"u1"}`)},
+		"results": {json.RawMessage(`{"job_id":"j1","project_score":7}`)},
+	}}
+	exp := &portable.Exporter{Tables: src, TableNames: []string{"uploads", "jobs", "results"}, BatchSize: 1, Now: time.Now}
+	var buf bytes.Buffer
+	_, err := exp.Export(ctx, &buf)
+	require.NoError(t, err)
+
+	// The target already holds a newer result of j1, which is kept.
+	dst := &fakeTables{rows: map[string][]json.RawMessage{"results": {json.RawMessage(`{"job_id":"j1","project_score":8}`)}}}
+	imp := &portable.Importer{Tables: dst}
+	_, res, err := imp.Import(ctx, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"uploads": 1, "jobs": 2}, res.Rows)
+	assert.Equal(t, map[string]int{"results": 1}, res.SkippedRows)
+
+	_, res, err = imp.Import(ctx, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Empty(t, res.Rows)
+	assert.Equal(t, map[string]int{"uploads": 1, "jobs": 2, "results": 1}, res.SkippedRows)
+	assert.Len(t, dst.rows["uploads"], 1)
+	assert.Len(t, dst.rows["jobs"], 2)
+	require.Len(t, dst.rows["results"], 1)
+	assert.JSONEq(t, `{"job_id":"j1","project_score":8}`, string(dst.rows["results"][0]))
+}
+
+func TestImport_RejectsUnsupportedArchives(t *testing.T) {
+	imp := &portable.Importer{Tables: &fakeTables{rows: map[string][]json.RawMessage{}}}
+
+	_, _, err := imp.Import(context.Background(), bytes.NewReader([]byte("not gzip")))
+	require.ErrorIs(t, err, portable.ErrUnsupportedArchive)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	manifest := []byte(`{"version":99}`)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0o644, Size: int64(len(manifest))}))
+	_, err = tw.Write(manifest)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	_, _, err = imp.Import(context.Background(), &buf)
+	require.ErrorIs(t, err, portable.ErrUnsupportedArchive)
+	assert.ErrorContains(t, err, "version 99")
+}
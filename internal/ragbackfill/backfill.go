@@ -0,0 +1,203 @@
+// Package ragbackfill populates the RAG collections from the job
+// descriptions and study case briefs of past evaluation tasks.
+//
+// Deployments that started before the RAG collections were seeded have no
+// retrieval context for the texts their users actually submit. The
+// backfiller pages through the tasks recorded on past jobs, deduplicates their texts and
+// upserts one point per distinct text. Point ids follow the ragseed scheme,
+// so reruns and texts that are also seeded from configs/rag overwrite the
+// existing points instead of adding copies.
+package ragbackfill
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Point types recorded in the payload of backfilled points.
+const (
+	TypeJobDescription = "job_description"
+	TypeStudyCase      = "study_case"
+)
+
+// TaskSource pages through the tasks recorded on jobs in (created_at, id)
+// order.
+type TaskSource interface {
+	ScanTasks(ctx domain.Context, after *domain.JobCursor, since time.Time, limit int) ([]domain.JobTask, error)
+}
+
+// VectorStore is the subset of the Qdrant client used by the backfiller.
+type VectorStore interface {
+	UpsertPoints(ctx context.Context, collection string, vectors [][]float32, payloads []map[string]any, ids []any) error
+}
+
+// Backfiller embeds the texts of past tasks into the RAG collections.
+type Backfiller struct {
+	Source TaskSource
+	Store  VectorStore
+	AI     domain.AIClient
+	// JobDescriptionCollection receives job descriptions; defaults to
+	// job_description.
+	JobDescriptionCollection string
+	// StudyCaseCollection receives study case briefs; defaults to
+	// scoring_rubric, the collection queried with the study case.
+	StudyCaseCollection string
+	// Since skips tasks recorded before it.
+	Since time.Time
+	// TenantID, when set, only reads the tasks of that tenant.
+	TenantID string
+	// MinChars skips texts shorter than it after trimming.
+	MinChars int
+	// BatchSize is the number of tasks read and texts embedded per step.
+	BatchSize int
+	// DryRun only counts the texts that would be upserted.
+	DryRun bool
+}
+
+// Result summarises a backfill.
+type Result struct {
+	Tasks      int
+	Duplicates int
+	Skipped    int
+	// Upserted counts the points written per collection.
+	Upserted map[string]int
+}
+
+// text is a distinct text waiting to be embedded.
+type text struct {
+	collection string
+	kind       string
+	text       string
+	tenantID   string
+}
+
+// Run reads every matching task and upserts its distinct texts.
+func (b *Backfiller) Run(ctx context.Context) (Result, error) {
+	res := Result{Upserted: map[string]int{}}
+	batch := b.BatchSize
+	if batch <= 0 {
+		batch = 100
+	}
+	jdCollection := b.JobDescriptionCollection
+	if jdCollection == "" {
+		jdCollection = "job_description"
+	}
+	studyCollection := b.StudyCaseCollection
+	if studyCollection == "" {
+		studyCollection = "scoring_rubric"
+	}
+
+	seen := map[string]bool{}
+	var pending []text
+	var after *domain.JobCursor
+	for {
+		entries, err := b.Source.ScanTasks(ctx, after, b.Since, batch)
+		if err != nil {
+			return res, fmt.Errorf("op=ragbackfill.scan: %w", err)
+		}
+		for _, e := range entries {
+			after = &domain.JobCursor{CreatedAt: e.CreatedAt, ID: e.JobID}
+			if b.TenantID != "" && e.Payload.TenantID != b.TenantID {
+				continue
+			}
+			res.Tasks++
+			for _, t := range []text{
+				{collection: jdCollection, kind: TypeJobDescription, text: e.Payload.JobDescription, tenantID: e.Payload.TenantID},
+				{collection: studyCollection, kind: TypeStudyCase, text: e.Payload.StudyCaseBrief, tenantID: e.Payload.TenantID},
+			} {
+				t.text = strings.TrimSpace(t.text)
+				if t.text == "" {
+					continue
+				}
+				if len([]rune(t.text)) < b.MinChars {
+					res.Skipped++
+					continue
+				}
+				key := t.collection + ":" + strings.Join(strings.Fields(t.text), " ")
+				if seen[key] {
+					res.Duplicates++
+					continue
+				}
+				seen[key] = true
+				pending = append(pending, t)
+			}
+		}
+		for len(pending) >= batch {
+			if err := b.upsert(ctx, pending[:batch], &res); err != nil {
+				return res, err
+			}
+			pending = pending[batch:]
+		}
+		if len(entries) < batch {
+			break
+		}
+	}
+	if err := b.upsert(ctx, pending, &res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// upsert embeds texts and writes them to their collections.
+func (b *Backfiller) upsert(ctx context.Context, texts []text, res *Result) error {
+	if len(texts) == 0 {
+		return nil
+	}
+	if b.DryRun {
+		for _, t := range texts {
+			res.Upserted[t.collection]++
+		}
+		return nil
+	}
+	inputs := make([]string, len(texts))
+	for i, t := range texts {
+		inputs[i] = t.text
+	}
+	vecs, err := b.AI.Embed(ctx, inputs)
+	if err != nil {
+		return fmt.Errorf("op=ragbackfill.embed: %w", err)
+	}
+	if len(vecs) != len(texts) {
+		return fmt.Errorf("op=ragbackfill.embed: got %d vectors for %d texts", len(vecs), len(texts))
+	}
+
+	type group struct {
+		vectors  [][]float32
+		payloads []map[string]any
+		ids      []any
+	}
+	groups := map[string]*group{}
+	var order []string
+	for i, t := range texts {
+		g, ok := groups[t.collection]
+		if !ok {
+			g = &group{}
+			groups[t.collection] = g
+			order = append(order, t.collection)
+		}
+		p := map[string]any{"text": t.text, "source": "backfill", "type": t.kind}
+		if t.tenantID != "" {
+			p["tenant_id"] = t.tenantID
+		}
+		// Same id as ragseed so that reruns overwrite their points.
+		sum := sha256.Sum256([]byte(t.collection + ":" + t.text))
+		g.vectors = append(g.vectors, vecs[i])
+		g.payloads = append(g.payloads, p)
+		g.ids = append(g.ids, fmt.Sprintf("%x", sum[:]))
+	}
+	for _, c := range order {
+		g := groups[c]
+		if err := b.Store.UpsertPoints(ctx, c, g.vectors, g.payloads, g.ids); err != nil {
+			return fmt.Errorf("op=ragbackfill.upsert %s: %w", c, err)
+		}
+		res.Upserted[c] += len(g.ids)
+		slog.Info("rag backfill upserted", slog.String("collection", c), slog.Int("points", len(g.ids)))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package ragbackfill_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/ragbackfill"
+)
+
+type fakeSource struct{ entries []domain.JobTask }
+
+func (f *fakeSource) ScanTasks(_ domain.Context, after *domain.JobCursor, _ time.Time, limit int) ([]domain.JobTask, error) {
+	var out []domain.JobTask
+	for _, e := range f.entries {
+		if (after == nil || e.JobID > after.ID) && len(out) < limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+type fakeStore struct {
+	payloads map[string][]map[string]any
+	ids      map[string][]any
+}
+
+func (f *fakeStore) UpsertPoints(_ context.Context, collection string, _ [][]float32, payloads []map[string]any, ids []any) error {
+	f.payloads[collection] = append(f.payloads[collection], payloads...)
+	f.ids[collection] = append(f.ids[collection], ids...)
+	return nil
+}
+
+type fakeAI struct{ embedded int }
+
+func (a *fakeAI) Embed(_ domain.Context, texts []string) ([][]float32, error) {
+	a.embedded += len(texts)
+	out := make([][]float32, len(texts))
+	for i := range out {
+		out[i] = []float32{1}
+	}
+	return out, nil
+}
+
+func (a *fakeAI) ChatJSON(domain.Context, string, string, int) (string, error) { return "", nil }
+
+func (a *fakeAI) ChatJSONWithRetry(domain.Context, string, string, int) (string, error) {
+	return "", nil
+}
+
+func (a *fakeAI) CleanCoTResponse(_ domain.Context, s string) (string, error) { return s, nil }
+
+func TestBackfiller_Run(t *testing.T) {
+	src := &fakeSource{entries: []domain.JobTask{
+		{JobID: "j1", Payload: domain.EvaluateTaskPayload{JobDescription: "Backend engineer with Go", StudyCaseBrief: "Build a CV evaluator", TenantID: "acme"}},
+		{JobID: "j2", Payload: domain.EvaluateTaskPayload{JobDescription: "  Backend   engineer with Go\n", StudyCaseBrief: "short"}},
+		{JobID: "j3", Payload: domain.EvaluateTaskPayload{JobDescription: "Data engineer with Python", CVOnly: true}},
+		{JobID: "j4", Payload: domain.EvaluateTaskPayload{JobDescription: "Frontend engineer with React", TenantID: "other"}},
+	}}
+	store := &fakeStore{payloads: map[string][]map[string]any{}, ids: map[string][]any{}}
+	ai := &fakeAI{}
+	b := &ragbackfill.Backfiller{Source: src, Store: store, AI: ai, MinChars: 10, BatchSize: 2}
+
+	res, err := b.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4, res.Tasks)
+	assert.Equal(t, 1, res.Duplicates)
+	assert.Equal(t, 1, res.Skipped)
+	assert.Equal(t, map[string]int{"job_description": 3, "scoring_rubric": 1}, res.Upserted)
+	assert.Equal(t, 4, ai.embedded)
+	assert.Equal(t, map[string]any{"text": "Build a CV evaluator", "source": "backfill", "type": ragbackfill.TypeStudyCase, "tenant_id": "acme"}, store.payloads["scoring_rubric"][0])
+
+	// A rerun upserts the same ids again instead of adding points.
+	first := store.ids["job_description"]
+	_, err = b.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, first, store.ids["job_description"][len(first):])
+
+	// Tenant filtering and dry runs.
+	ai.embedded = 0
+	b.TenantID, b.DryRun = "other", true
+	res, err = b.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Tasks)
+	assert.Equal(t, map[string]int{"job_description": 1}, res.Upserted)
+	assert.Zero(t, ai.embedded)
+}
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -128,44 +129,53 @@ func SeedDefault(ctx domain.Context, q *qdrantcli.Client, ai domain.AIClient) er
 	return nil
 }
 
-// upsertAll embeds and upserts texts with optional metadata mapping
+// upsertAll embeds texts in provider-sized chunks and upserts all points
+// through the client's batched, retrying upsert with optional metadata mapping
 func upsertAll(ctx domain.Context, q *qdrantcli.Client, ai domain.AIClient, collection string, texts []string, meta map[string]ragYAMLItem) error {
-	const batch = 16
-	for i := 0; i < len(texts); i += batch {
-		end := i + batch
+	const embedBatch = 16
+	vecs := make([][]float32, 0, len(texts))
+	for i := 0; i < len(texts); i += embedBatch {
+		end := i + embedBatch
 		if end > len(texts) {
 			end = len(texts)
 		}
-		chunk := texts[i:end]
-		vecs, err := ai.Embed(ctx, chunk)
+		chunkVecs, err := ai.Embed(ctx, texts[i:end])
 		if err != nil {
 			return fmt.Errorf("embed: %w", err)
 		}
-		payloads := make([]map[string]any, len(chunk))
-		ids := make([]any, len(chunk))
-		for j := range chunk {
-			p := map[string]any{"text": chunk[j], "source": collection}
-			if meta != nil {
-				if it, ok := meta[strings.TrimSpace(chunk[j])]; ok {
-					if it.Type != "" {
-						p["type"] = it.Type
-					}
-					if it.Section != "" {
-						p["section"] = it.Section
-					}
-					if it.Weight > 0 {
-						p["weight"] = it.Weight
-					}
+		if len(chunkVecs) != end-i {
+			return fmt.Errorf("embed: got %d vectors for %d texts", len(chunkVecs), end-i)
+		}
+		vecs = append(vecs, chunkVecs...)
+	}
+	payloads := make([]map[string]any, len(texts))
+	ids := make([]any, len(texts))
+	for j, text := range texts {
+		p := map[string]any{"text": text, "source": collection}
+		if meta != nil {
+			if it, ok := meta[strings.TrimSpace(text)]; ok {
+				if it.Type != "" {
+					p["type"] = it.Type
+				}
+				if it.Section != "" {
+					p["section"] = it.Section
+				}
+				if it.Weight > 0 {
+					p["weight"] = it.Weight
 				}
 			}
-			payloads[j] = p
-			// Deterministic ID to avoid duplicate points on re-ingestion
-			sum := sha256.Sum256([]byte(collection + ":" + strings.TrimSpace(chunk[j])))
-			ids[j] = fmt.Sprintf("%x", sum[:])
-		}
-		if err := q.UpsertPoints(ctx, collection, vecs, payloads, ids); err != nil {
-			return fmt.Errorf("qdrant upsert: %w", err)
 		}
+		payloads[j] = p
+		// Deterministic ID to avoid duplicate points on re-ingestion
+		sum := sha256.Sum256([]byte(collection + ":" + strings.TrimSpace(text)))
+		ids[j] = fmt.Sprintf("%x", sum[:])
+	}
+	progress := func(done, total int) {
+		slog.Debug("rag seed upsert progress", slog.String("collection", collection), slog.Int("done", done), slog.Int("total", total))
+	}
+	if err := q.UpsertPointsWithProgress(ctx, collection, vecs, payloads, ids, progress); err != nil {
+		return fmt.Errorf("qdrant upsert: %w", err)
 	}
+	slog.Info("rag seed upserted", slog.String("collection", collection), slog.Int("points", len(texts)))
 	return nil
 }
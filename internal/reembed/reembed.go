@@ -0,0 +1,212 @@
+// Package reembed migrates Qdrant collections to a new embeddings model.
+//
+// Each logical collection (e.g. job_description) is copied point by point
+// into a parallel collection whose vectors are produced by the new model.
+// Once the copy is complete and verified, the logical name is switched to
+// the new collection through a Qdrant alias so readers never observe a
+// half-migrated collection or vectors from two different embedding spaces.
+package reembed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ErrSourceNotAlias is returned when the logical collection is a concrete
+// collection and DropSource is not set, so the alias cannot be created.
+var ErrSourceNotAlias = errors.New("source is a concrete collection; rerun with drop-source to replace it with an alias")
+
+// VectorStore is the subset of the Qdrant client used by the migrator.
+type VectorStore interface {
+	ResolveAlias(ctx context.Context, name string) (string, bool, error)
+	ScrollPoints(ctx context.Context, collection string, limit int, offset any) ([]qdrantcli.Point, any, error)
+	CountPoints(ctx context.Context, collection string) (int, error)
+	EnsureCollectionSpec(ctx context.Context, spec qdrantcli.CollectionSpec, recreate bool) error
+	UpsertPoints(ctx context.Context, collection string, vectors [][]float32, payloads []map[string]any, ids []any) error
+	DeleteCollection(ctx context.Context, name string) error
+	SwitchAlias(ctx context.Context, alias, collection string) error
+}
+
+// Migrator re-embeds collections with AI's embeddings model.
+type Migrator struct {
+	Store VectorStore
+	AI    domain.AIClient
+	// Suffix names the parallel collection as <logical>__<Suffix>.
+	Suffix string
+	// VectorName, when set, stores vectors under that named vector.
+	VectorName string
+	// PayloadIndexes are recreated on the new collection.
+	PayloadIndexes map[string]string
+	// BatchSize is the number of points read, embedded and written per step.
+	BatchSize int
+	// DropSource allows replacing a concrete source collection with an alias.
+	// The source is deleted right before the alias is created.
+	DropSource bool
+	// DeleteOld removes the previous alias target after a successful switch.
+	DeleteOld bool
+	// DryRun only reports what would be migrated.
+	DryRun bool
+}
+
+// Result summarises the migration of one logical collection.
+type Result struct {
+	Collection string
+	Source     string
+	Target     string
+	Migrated   int
+	Skipped    int
+	Switched   bool
+}
+
+var suffixSanitizer = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Suffix builds a collection suffix from a model name and time, e.g.
+// "text_embedding_3_small_20250101120000".
+func Suffix(model string, now time.Time) string {
+	s := strings.Trim(suffixSanitizer.ReplaceAllString(strings.ToLower(model), "_"), "_")
+	if s == "" {
+		s = "model"
+	}
+	return s + "_" + now.UTC().Format("20060102150405")
+}
+
+// Migrate copies logical into a new collection embedded with the new model
+// and switches the logical name to it.
+func (m *Migrator) Migrate(ctx context.Context, logical string) (Result, error) {
+	res := Result{Collection: logical, Source: logical, Target: logical + "__" + m.Suffix}
+	src, isAlias, err := m.Store.ResolveAlias(ctx, logical)
+	if err != nil {
+		return res, fmt.Errorf("op=reembed.resolve %s: %w", logical, err)
+	}
+	if isAlias {
+		res.Source = src
+	}
+	if res.Source == res.Target {
+		return res, fmt.Errorf("op=reembed.migrate %s: target %s is already live", logical, res.Target)
+	}
+	if !isAlias && !m.DropSource && !m.DryRun {
+		return res, fmt.Errorf("op=reembed.migrate %s: %w", logical, ErrSourceNotAlias)
+	}
+
+	total, err := m.Store.CountPoints(ctx, res.Source)
+	if err != nil {
+		return res, fmt.Errorf("op=reembed.count %s: %w", res.Source, err)
+	}
+	if m.DryRun {
+		slog.Info("reembed dry run", slog.String("collection", logical), slog.String("source", res.Source),
+			slog.String("target", res.Target), slog.Int("points", total), slog.Bool("alias", isAlias))
+		return res, nil
+	}
+
+	size, err := m.probeDimension(ctx)
+	if err != nil {
+		return res, err
+	}
+	spec := qdrantcli.CollectionSpec{Name: res.Target, PayloadIndexes: m.PayloadIndexes}
+	params := qdrantcli.VectorParams{Size: size, Distance: "Cosine"}
+	if m.VectorName != "" {
+		spec.Named = map[string]qdrantcli.VectorParams{m.VectorName: params}
+	} else {
+		spec.Vector = params
+	}
+	if err := m.Store.EnsureCollectionSpec(ctx, spec, true); err != nil {
+		return res, fmt.Errorf("op=reembed.create %s: %w", res.Target, err)
+	}
+
+	if err := m.copyPoints(ctx, &res, total); err != nil {
+		return res, err
+	}
+	if n, err := m.Store.CountPoints(ctx, res.Target); err != nil {
+		return res, fmt.Errorf("op=reembed.count %s: %w", res.Target, err)
+	} else if n != res.Migrated {
+		return res, fmt.Errorf("op=reembed.verify %s: target has %d points, migrated %d", res.Target, n, res.Migrated)
+	}
+
+	if !isAlias {
+		// Qdrant cannot create an alias that shadows an existing collection,
+		// so the concrete source has to go first. Searches fail until the
+		// alias below is created.
+		slog.Warn("reembed dropping concrete source collection", slog.String("collection", logical))
+		if err := m.Store.DeleteCollection(ctx, logical); err != nil {
+			return res, fmt.Errorf("op=reembed.drop_source %s: %w", logical, err)
+		}
+	}
+	if err := m.Store.SwitchAlias(ctx, logical, res.Target); err != nil {
+		return res, fmt.Errorf("op=reembed.switch %s: %w", logical, err)
+	}
+	res.Switched = true
+	if isAlias && m.DeleteOld {
+		if err := m.Store.DeleteCollection(ctx, res.Source); err != nil {
+			slog.Warn("reembed delete old collection failed", slog.String("collection", res.Source), slog.Any("error", err))
+		}
+	}
+	return res, nil
+}
+
+// copyPoints streams the source collection into the target, re-embedding
+// each point's "text" payload. Points without text are skipped.
+func (m *Migrator) copyPoints(ctx context.Context, res *Result, total int) error {
+	batch := m.BatchSize
+	if batch <= 0 {
+		batch = 64
+	}
+	var offset any
+	for {
+		points, next, err := m.Store.ScrollPoints(ctx, res.Source, batch, offset)
+		if err != nil {
+			return fmt.Errorf("op=reembed.scroll %s: %w", res.Source, err)
+		}
+		texts := make([]string, 0, len(points))
+		payloads := make([]map[string]any, 0, len(points))
+		ids := make([]any, 0, len(points))
+		for _, p := range points {
+			text, _ := p.Payload["text"].(string)
+			if strings.TrimSpace(text) == "" {
+				res.Skipped++
+				continue
+			}
+			texts = append(texts, text)
+			payloads = append(payloads, p.Payload)
+			ids = append(ids, p.ID)
+		}
+		if len(texts) > 0 {
+			vecs, err := m.AI.Embed(ctx, texts)
+			if err != nil {
+				return fmt.Errorf("op=reembed.embed %s: %w", res.Source, err)
+			}
+			if len(vecs) != len(texts) {
+				return fmt.Errorf("op=reembed.embed %s: got %d vectors for %d texts", res.Source, len(vecs), len(texts))
+			}
+			if err := m.Store.UpsertPoints(ctx, res.Target, vecs, payloads, ids); err != nil {
+				return fmt.Errorf("op=reembed.upsert %s: %w", res.Target, err)
+			}
+			res.Migrated += len(texts)
+		}
+		slog.Info("reembed progress", slog.String("collection", res.Collection),
+			slog.Int("migrated", res.Migrated), slog.Int("skipped", res.Skipped), slog.Int("total", total))
+		if next == nil || len(points) == 0 {
+			return nil
+		}
+		offset = next
+	}
+}
+
+// probeDimension embeds a short text to learn the new model's vector size.
+func (m *Migrator) probeDimension(ctx context.Context) (int, error) {
+	vecs, err := m.AI.Embed(ctx, []string{"dimension probe"})
+	if err != nil {
+		return 0, fmt.Errorf("op=reembed.probe: %w", err)
+	}
+	if len(vecs) != 1 || len(vecs[0]) == 0 {
+		return 0, fmt.Errorf("op=reembed.probe: empty embedding")
+	}
+	return len(vecs[0]), nil
+}
@@ -0,0 +1,175 @@
+package reembed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	qdrantcli "github.com/fairyhunter13/ai-cv-evaluator/internal/adapter/vector/qdrant"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/reembed"
+)
+
+type fakeStore struct {
+	aliases     map[string]string
+	collections map[string][]qdrantcli.Point
+	specs       map[string]qdrantcli.CollectionSpec
+	vectors     map[string][][]float32
+	deleted     []string
+	upsertErr   error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		aliases:     map[string]string{},
+		collections: map[string][]qdrantcli.Point{},
+		specs:       map[string]qdrantcli.CollectionSpec{},
+		vectors:     map[string][][]float32{},
+	}
+}
+
+func (f *fakeStore) ResolveAlias(_ context.Context, name string) (string, bool, error) {
+	c, ok := f.aliases[name]
+	return c, ok, nil
+}
+
+func (f *fakeStore) ScrollPoints(_ context.Context, collection string, limit int, offset any) ([]qdrantcli.Point, any, error) {
+	pts := f.collections[collection]
+	start := 0
+	if offset != nil {
+		start = offset.(int)
+	}
+	end := start + limit
+	if end >= len(pts) {
+		return pts[start:], nil, nil
+	}
+	return pts[start:end], end, nil
+}
+
+func (f *fakeStore) CountPoints(_ context.Context, collection string) (int, error) {
+	return len(f.collections[collection]), nil
+}
+
+func (f *fakeStore) EnsureCollectionSpec(_ context.Context, spec qdrantcli.CollectionSpec, _ bool) error {
+	f.specs[spec.Name] = spec
+	f.collections[spec.Name] = nil
+	return nil
+}
+
+func (f *fakeStore) UpsertPoints(_ context.Context, collection string, vectors [][]float32, payloads []map[string]any, ids []any) error {
+	if f.upsertErr != nil {
+		return f.upsertErr
+	}
+	for i := range vectors {
+		f.collections[collection] = append(f.collections[collection], qdrantcli.Point{ID: ids[i], Payload: payloads[i]})
+	}
+	f.vectors[collection] = append(f.vectors[collection], vectors...)
+	return nil
+}
+
+func (f *fakeStore) DeleteCollection(_ context.Context, name string) error {
+	f.deleted = append(f.deleted, name)
+	delete(f.collections, name)
+	return nil
+}
+
+func (f *fakeStore) SwitchAlias(_ context.Context, alias, collection string) error {
+	f.aliases[alias] = collection
+	return nil
+}
+
+// dimAI returns vectors of a fixed dimension.
+type dimAI struct {
+	dim   int
+	calls int
+}
+
+func (a *dimAI) Embed(_ domain.Context, texts []string) ([][]float32, error) {
+	a.calls++
+	out := make([][]float32, len(texts))
+	for i := range texts {
+		out[i] = make([]float32, a.dim)
+	}
+	return out, nil
+}
+func (a *dimAI) ChatJSON(_ domain.Context, _, _ string, _ int) (string, error) { return "{}", nil }
+func (a *dimAI) ChatJSONWithRetry(_ domain.Context, _, _ string, _ int) (string, error) {
+	return "{}", nil
+}
+func (a *dimAI) CleanCoTResponse(_ domain.Context, r string) (string, error) { return r, nil }
+
+func seedPoints(n int) []qdrantcli.Point {
+	pts := make([]qdrantcli.Point, n)
+	for i := range pts {
+		pts[i] = qdrantcli.Point{ID: i, Payload: map[string]any{"text": "t", "source": "jd"}}
+	}
+	return pts
+}
+
+func TestMigrate_AliasSwitch(t *testing.T) {
+	store := newFakeStore()
+	store.aliases["jd"] = "jd__old"
+	store.collections["jd__old"] = append(seedPoints(5), qdrantcli.Point{ID: 99, Payload: map[string]any{"source": "jd"}})
+	m := &reembed.Migrator{Store: store, AI: &dimAI{dim: 3072}, Suffix: "large", BatchSize: 2, DeleteOld: true,
+		PayloadIndexes: map[string]string{"doc_type": "keyword"}}
+
+	res, err := m.Migrate(context.Background(), "jd")
+	require.NoError(t, err)
+	assert.Equal(t, reembed.Result{Collection: "jd", Source: "jd__old", Target: "jd__large", Migrated: 5, Skipped: 1, Switched: true}, res)
+	assert.Equal(t, "jd__large", store.aliases["jd"])
+	assert.Equal(t, 3072, store.specs["jd__large"].Vector.Size)
+	assert.Equal(t, "keyword", store.specs["jd__large"].PayloadIndexes["doc_type"])
+	assert.Len(t, store.vectors["jd__large"][0], 3072)
+	assert.Equal(t, []string{"jd__old"}, store.deleted)
+}
+
+func TestMigrate_ConcreteSource(t *testing.T) {
+	store := newFakeStore()
+	store.collections["jd"] = seedPoints(3)
+	m := &reembed.Migrator{Store: store, AI: &dimAI{dim: 8}, Suffix: "s", VectorName: "small"}
+
+	_, err := m.Migrate(context.Background(), "jd")
+	require.ErrorIs(t, err, reembed.ErrSourceNotAlias)
+	assert.Empty(t, store.specs)
+
+	m.DropSource = true
+	res, err := m.Migrate(context.Background(), "jd")
+	require.NoError(t, err)
+	assert.True(t, res.Switched)
+	assert.Equal(t, []string{"jd"}, store.deleted)
+	assert.Equal(t, "jd__s", store.aliases["jd"])
+	assert.Equal(t, 8, store.specs["jd__s"].Named["small"].Size)
+}
+
+func TestMigrate_DryRunAndFailures(t *testing.T) {
+	store := newFakeStore()
+	store.collections["jd"] = seedPoints(3)
+	ai := &dimAI{dim: 4}
+	m := &reembed.Migrator{Store: store, AI: ai, Suffix: "s", DryRun: true}
+	res, err := m.Migrate(context.Background(), "jd")
+	require.NoError(t, err)
+	assert.False(t, res.Switched)
+	assert.Zero(t, ai.calls)
+
+	store.aliases["rubric"] = "rubric__s"
+	_, err = m.Migrate(context.Background(), "rubric")
+	require.Error(t, err, "target already live")
+
+	store.upsertErr = errors.New("boom")
+	m = &reembed.Migrator{Store: store, AI: ai, Suffix: "s", DropSource: true}
+	_, err = m.Migrate(context.Background(), "jd")
+	require.Error(t, err)
+	assert.Empty(t, store.deleted, "source must survive a failed copy")
+	_, aliased := store.aliases["jd"]
+	assert.False(t, aliased)
+}
+
+func TestSuffix(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, "openai_text_embedding_3_large_20250102030405", reembed.Suffix("openai/Text-Embedding-3-Large", now))
+	assert.Equal(t, "model_20250102030405", reembed.Suffix("//", now))
+}
@@ -0,0 +1,70 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Matches(t *testing.T) {
+	at := func(s string) time.Time {
+		v, err := time.Parse("2006-01-02 15:04", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	cases := []struct {
+		expr string
+		t    string
+		want bool
+	}{
+		{"0 8 * * *", "2025-12-08 08:00", true},
+		{"0 8 * * *", "2025-12-08 08:01", false},
+		{"@daily", "2025-12-08 00:00", true},
+		{"0 8 * * 1", "2025-12-08 08:00", true}, // Monday
+		{"0 8 * * 1", "2025-12-09 08:00", false},
+		{"0 8 * * 7", "2025-12-07 08:00", true}, // Sunday as 7
+		{"*/15 9-17 * * 1-5", "2025-12-08 13:45", true},
+		{"*/15 9-17 * * 1-5", "2025-12-08 13:50", false},
+		{"30 6 1,15 * *", "2025-12-15 06:30", true},
+		{"0 0 1 * 1", "2025-12-08 00:00", true},    // restricted day fields match either
+		{"0 0 1 * 1", "2025-12-01 00:00", true},    // first of month (also a Monday)
+		{"0 0 1 * 1", "2025-12-02 00:00", false},   // neither
+		{"5/20 * * * *", "2025-12-02 10:45", true}, // 5, 25, 45
+	}
+	for _, c := range cases {
+		s, err := Parse(c.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", c.expr, err)
+		}
+		if got := s.Matches(at(c.t)); got != c.want {
+			t.Errorf("%q at %s = %v, want %v", c.expr, c.t, got, c.want)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "a * * * *", "5-1 * * * *", "@never"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	s, err := Parse("0 8 * * 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2025, 12, 8, 8, 0, 30, 0, time.UTC)
+	if got, want := s.Next(from), time.Date(2025, 12, 15, 8, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("Next = %v, want %v", got, want)
+	}
+	never, err := Parse("0 0 31 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := never.Next(from); !got.IsZero() {
+		t.Fatalf("Next = %v, want zero", got)
+	}
+}
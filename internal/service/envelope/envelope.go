@@ -0,0 +1,175 @@
+// Package envelope encrypts the feedback of evaluation results for tenants
+// that supply a public key. Each result gets a fresh AES-256-GCM data key,
+// which is stored wrapped with the tenant's RSA key, so only the holder of
+// the matching private key can read the feedback.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Algorithm names the scheme: RSA-OAEP with SHA-256 wraps an AES-256-GCM
+// data key.
+const Algorithm = "RSA-OAEP-256+A256GCM"
+
+// minKeyBits is the smallest accepted RSA modulus.
+const minKeyBits = 2048
+
+// ParsePublicKey parses a PEM encoded RSA public key, in PKIX ("PUBLIC KEY")
+// or PKCS #1 ("RSA PUBLIC KEY") form, and returns it with its key id: the
+// first 16 hex characters of the SHA-256 of its PKIX encoding.
+func ParsePublicKey(pemKey string) (*rsa.PublicKey, string, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, "", fmt.Errorf("%w: result_public_key is not PEM encoded", domain.ErrInvalidArgument)
+	}
+	var pub *rsa.PublicKey
+	switch block.Type {
+	case "PUBLIC KEY":
+		k, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: result_public_key: %v", domain.ErrInvalidArgument, err)
+		}
+		rk, ok := k.(*rsa.PublicKey)
+		if !ok {
+			return nil, "", fmt.Errorf("%w: result_public_key must be an RSA key", domain.ErrInvalidArgument)
+		}
+		pub = rk
+	case "RSA PUBLIC KEY":
+		k, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: result_public_key: %v", domain.ErrInvalidArgument, err)
+		}
+		pub = k
+	default:
+		return nil, "", fmt.Errorf("%w: result_public_key has PEM type %q, want PUBLIC KEY", domain.ErrInvalidArgument, block.Type)
+	}
+	if pub.N.BitLen() < minKeyBits {
+		return nil, "", fmt.Errorf("%w: result_public_key must have at least %d bits", domain.ErrInvalidArgument, minKeyBits)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, "", fmt.Errorf("op=envelope.key_id: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return pub, hex.EncodeToString(sum[:])[:16], nil
+}
+
+// feedbackFields returns the encrypted fields of res by name.
+func feedbackFields(res *domain.Result) []struct {
+	name string
+	val  *string
+} {
+	return []struct {
+		name string
+		val  *string
+	}{
+		{"cv_feedback", &res.CVFeedback},
+		{"project_feedback", &res.ProjectFeedback},
+		{"overall_summary", &res.OverallSummary},
+	}
+}
+
+// additionalData binds a ciphertext to its job and field, so ciphertexts
+// cannot be swapped between fields or results.
+func additionalData(jobID, field string) []byte {
+	return []byte(jobID + ":" + field)
+}
+
+// Encrypt replaces the non-empty feedback fields of res with the base64 of
+// nonce||ciphertext under a fresh data key and records the data key,
+// wrapped with pemKey, in res.Encryption. Scores are left readable.
+func Encrypt(res domain.Result, pemKey string) (domain.Result, error) {
+	pub, keyID, err := ParsePublicKey(pemKey)
+	if err != nil {
+		return domain.Result{}, err
+	}
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return domain.Result{}, fmt.Errorf("op=envelope.data_key: %w", err)
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("op=envelope.cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("op=envelope.cipher: %w", err)
+	}
+	for _, f := range feedbackFields(&res) {
+		if *f.val == "" {
+			continue
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return domain.Result{}, fmt.Errorf("op=envelope.nonce: %w", err)
+		}
+		sealed := gcm.Seal(nonce, nonce, []byte(*f.val), additionalData(res.JobID, f.name))
+		*f.val = base64.StdEncoding.EncodeToString(sealed)
+	}
+	wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, dataKey, nil)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("op=envelope.wrap: %w", err)
+	}
+	res.Encryption = &domain.ResultEncryption{
+		KeyID:      keyID,
+		Algorithm:  Algorithm,
+		WrappedKey: base64.StdEncoding.EncodeToString(wrapped),
+	}
+	return res, nil
+}
+
+// Decrypt reverses Encrypt with the tenant's private key. It is the
+// reference for tenants implementing decryption; the service itself never
+// holds private keys.
+func Decrypt(res domain.Result, priv *rsa.PrivateKey) (domain.Result, error) {
+	if res.Encryption == nil {
+		return res, nil
+	}
+	if res.Encryption.Algorithm != Algorithm {
+		return domain.Result{}, fmt.Errorf("%w: unsupported algorithm %q", domain.ErrInvalidArgument, res.Encryption.Algorithm)
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(res.Encryption.WrappedKey)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("op=envelope.unwrap: %w", err)
+	}
+	dataKey, err := rsa.DecryptOAEP(sha256.New(), nil, priv, wrapped, nil)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("op=envelope.unwrap: %w", err)
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("op=envelope.cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return domain.Result{}, fmt.Errorf("op=envelope.cipher: %w", err)
+	}
+	for _, f := range feedbackFields(&res) {
+		if *f.val == "" {
+			continue
+		}
+		sealed, err := base64.StdEncoding.DecodeString(*f.val)
+		if err != nil || len(sealed) < gcm.NonceSize() {
+			return domain.Result{}, fmt.Errorf("op=envelope.decrypt %s: malformed ciphertext", f.name)
+		}
+		plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], additionalData(res.JobID, f.name))
+		if err != nil {
+			return domain.Result{}, fmt.Errorf("op=envelope.decrypt %s: %w", f.name, err)
+		}
+		*f.val = string(plain)
+	}
+	res.Encryption = nil
+	return res, nil
+}
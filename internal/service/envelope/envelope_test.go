@@ -0,0 +1,74 @@
+package envelope_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/envelope"
+)
+
+func publicKeyPEM(t *testing.T, pub any) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestEncryptDecrypt_RoundTrip(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	pemKey := publicKeyPEM(t, &priv.PublicKey)
+	_, keyID, err := envelope.ParsePublicKey(pemKey)
+	require.NoError(t, err)
+	assert.Len(t, keyID, 16)
+
+	res := domain.Result{JobID: "job-1", CVMatchRate: 0.8, CVFeedback: "Strong Go skills.", ProjectScore: 8, OverallSummary: "Hire.", CVOnly: true}
+	enc, err := envelope.Encrypt(res, pemKey)
+	require.NoError(t, err)
+	require.NotNil(t, enc.Encryption)
+	assert.Equal(t, keyID, enc.Encryption.KeyID)
+	assert.Equal(t, envelope.Algorithm, enc.Encryption.Algorithm)
+	assert.NotContains(t, enc.CVFeedback, "Go")
+	assert.Empty(t, enc.ProjectFeedback, "empty fields stay empty")
+	assert.Equal(t, 0.8, enc.CVMatchRate, "scores stay readable")
+
+	dec, err := envelope.Decrypt(enc, priv)
+	require.NoError(t, err)
+	assert.Equal(t, res, dec)
+
+	// Ciphertexts are bound to their field and job.
+	swapped := enc
+	swapped.CVFeedback, swapped.OverallSummary = enc.OverallSummary, enc.CVFeedback
+	_, err = envelope.Decrypt(swapped, priv)
+	require.Error(t, err)
+	moved := enc
+	moved.JobID = "job-2"
+	_, err = envelope.Decrypt(moved, priv)
+	require.Error(t, err)
+}
+
+func TestParsePublicKey_Rejects(t *testing.T) {
+	small, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	ec, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	for name, key := range map[string]string{
+		"not pem":   "ssh-rsa AAAA",
+		"small key": publicKeyPEM(t, &small.PublicKey),
+		"not rsa":   publicKeyPEM(t, &ec.PublicKey),
+		"private":   string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(small)})),
+	} {
+		_, _, err := envelope.ParsePublicKey(key)
+		require.ErrorIs(t, err, domain.ErrInvalidArgument, name)
+	}
+}
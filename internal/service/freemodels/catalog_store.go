@@ -0,0 +1,83 @@
+package freemodels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Catalog is a snapshot of the free models catalog.
+type Catalog struct {
+	Models    []Model   `json:"models"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// CatalogStore persists the last-known-good catalog.
+type CatalogStore interface {
+	// Load returns the stored catalog, or an empty Catalog when none exists.
+	Load(ctx context.Context) (Catalog, error)
+	// Save replaces the stored catalog.
+	Save(ctx context.Context, c Catalog) error
+}
+
+// FileCatalogStore keeps the catalog in a JSON file. Writes go through a
+// temporary file and a rename so readers never observe a partial catalog.
+type FileCatalogStore struct {
+	Path string
+}
+
+// NewFileCatalogStore returns a store writing to path, or nil when path is empty.
+func NewFileCatalogStore(path string) *FileCatalogStore {
+	if path == "" {
+		return nil
+	}
+	return &FileCatalogStore{Path: path}
+}
+
+// Load reads the catalog file; a missing file yields an empty Catalog.
+func (f *FileCatalogStore) Load(_ context.Context) (Catalog, error) {
+	b, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Catalog{}, nil
+	}
+	if err != nil {
+		return Catalog{}, fmt.Errorf("op=catalog.load: %w", err)
+	}
+	var c Catalog
+	if err := json.Unmarshal(b, &c); err != nil {
+		return Catalog{}, fmt.Errorf("op=catalog.decode: %w", err)
+	}
+	return c, nil
+}
+
+// Save atomically replaces the catalog file, creating its directory if needed.
+func (f *FileCatalogStore) Save(_ context.Context, c Catalog) error {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("op=catalog.encode: %w", err)
+	}
+	dir := filepath.Dir(f.Path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("op=catalog.mkdir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(f.Path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("op=catalog.save: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("op=catalog.save: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("op=catalog.save: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.Path); err != nil {
+		return fmt.Errorf("op=catalog.save: %w", err)
+	}
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -48,14 +50,24 @@ type OpenRouterResponse struct {
 	Data []Model `json:"data"`
 }
 
-// Service handles fetching and managing free models from OpenRouter
+// Service handles fetching and managing free models from OpenRouter.
+// The catalog is served stale-while-revalidate: once it is older than the
+// refresh interval the cached models are still returned while a single
+// background fetch replaces them. A failed or empty fetch never discards the
+// last-known-good catalog, which is also persisted in the optional store.
 type Service struct {
 	apiKey     string
 	baseURL    string
 	httpClient *http.Client
-	models     []Model
-	lastFetch  time.Time
 	refreshDur time.Duration
+	store      CatalogStore
+	observeAge func(time.Duration)
+
+	mu           sync.RWMutex
+	models       []Model
+	lastFetch    time.Time
+	loadOnce     sync.Once
+	revalidating atomic.Bool
 }
 
 // NewService creates a new free models service with OpenTelemetry tracing
@@ -74,62 +86,165 @@ func NewService(apiKey, baseURL string, refreshDur time.Duration) *Service {
 	}
 }
 
-// GetFreeModels returns the list of free models, fetching from API if needed
+// WithTransport sends the catalog requests through base, e.g. a transport
+// tuned or proxied for OpenRouter.
+func (s *Service) WithTransport(base http.RoundTripper) *Service {
+	s.httpClient.Transport = otelhttp.NewTransport(base,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return fmt.Sprintf("FreeModels %s %s", r.Method, r.URL.Host)
+		}),
+	)
+	return s
+}
+
+// WithStore persists every successfully fetched catalog in store and seeds
+// the service from it on first use, so a restarted process can keep serving
+// evaluations while the OpenRouter catalog is unreachable.
+func (s *Service) WithStore(store CatalogStore) *Service {
+	s.store = store
+	return s
+}
+
+// WithAgeObserver registers fn to receive the catalog age whenever models
+// are served, e.g. to export it as a metric.
+func (s *Service) WithAgeObserver(fn func(time.Duration)) *Service {
+	s.observeAge = fn
+	return s
+}
+
+// CatalogAge returns how old the served catalog is, or 0 when none is loaded.
+func (s *Service) CatalogAge() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.models == nil || s.lastFetch.IsZero() {
+		return 0
+	}
+	return time.Since(s.lastFetch)
+}
+
+// GetFreeModels returns the list of free models. Without a cached catalog it
+// fetches synchronously; a stale catalog is returned immediately and
+// refreshed in the background.
 func (s *Service) GetFreeModels(ctx context.Context) ([]Model, error) {
+	s.loadOnce.Do(func() { s.loadStored(ctx) })
+
+	s.mu.RLock()
+	models, lastFetch := s.models, s.lastFetch
+	s.mu.RUnlock()
+
 	slog.Debug("GetFreeModels called",
-		slog.Bool("has_cached_models", s.models != nil),
-		slog.Time("last_fetch", s.lastFetch),
-		slog.Duration("time_since_fetch", time.Since(s.lastFetch)),
+		slog.Bool("has_cached_models", models != nil),
+		slog.Time("last_fetch", lastFetch),
+		slog.Duration("time_since_fetch", time.Since(lastFetch)),
 		slog.Duration("refresh_duration", s.refreshDur))
 
-	// Check if we need to refresh the models list
-	if s.models == nil || time.Since(s.lastFetch) > s.refreshDur {
-		slog.Info("fetching free models from OpenRouter API",
-			slog.String("base_url", s.baseURL),
-			slog.Duration("refresh_interval", s.refreshDur),
-			slog.Bool("has_cached_models", s.models != nil),
-			slog.Int("cached_count", len(s.models)))
+	defer s.reportAge()
+	if models == nil {
+		return s.revalidate(ctx)
+	}
+	if time.Since(lastFetch) > s.refreshDur && s.revalidating.CompareAndSwap(false, true) {
+		slog.Info("free models catalog is stale, revalidating in background",
+			slog.Duration("age", time.Since(lastFetch)),
+			slog.Int("cached_count", len(models)))
+		go func() {
+			defer s.revalidating.Store(false)
+			_, _ = s.revalidate(context.WithoutCancel(ctx))
+		}()
+	}
+	return models, nil
+}
 
-		models, err := s.fetchModelsFromAPI(ctx)
-		if err != nil {
-			slog.Error("failed to fetch models from OpenRouter API",
+func (s *Service) reportAge() {
+	if s.observeAge != nil {
+		s.observeAge(s.CatalogAge())
+	}
+}
+
+// revalidate fetches the catalog and installs it. On failure, or when the
+// fetch yields no free models, the last-known-good catalog is kept and
+// returned; an error is returned only when there is nothing to fall back to.
+func (s *Service) revalidate(ctx context.Context) ([]Model, error) {
+	slog.Info("fetching free models from OpenRouter API",
+		slog.String("base_url", s.baseURL),
+		slog.Duration("refresh_interval", s.refreshDur))
+
+	models, err := s.fetchModelsFromAPI(ctx)
+
+	s.mu.Lock()
+	cached := s.models
+	switch {
+	case err != nil:
+		s.mu.Unlock()
+		slog.Error("failed to fetch models from OpenRouter API",
+			slog.Any("error", err),
+			slog.String("base_url", s.baseURL),
+			slog.Bool("api_key_present", s.apiKey != ""))
+		if cached != nil {
+			slog.Warn("using last-known-good free models due to API failure",
 				slog.Any("error", err),
-				slog.String("base_url", s.baseURL),
-				slog.Bool("api_key_present", s.apiKey != ""))
-
-			// If API fetch fails and we have cached models, use them
-			if s.models != nil {
-				slog.Warn("using cached models due to API failure",
-					slog.Any("error", err),
-					slog.Int("cached_count", len(s.models)))
-				return s.models, nil
-			}
-			return nil, fmt.Errorf("failed to fetch models from API: %w", err)
+				slog.Int("cached_count", len(cached)))
+			return cached, nil
 		}
+		return nil, fmt.Errorf("failed to fetch models from API: %w", err)
+	case len(models) == 0 && len(cached) > 0:
+		s.mu.Unlock()
+		slog.Warn("OpenRouter returned no free models, keeping last-known-good catalog",
+			slog.Int("cached_count", len(cached)))
+		return cached, nil
+	}
+	if models == nil {
+		models = []Model{}
+	}
+	s.models = models
+	s.lastFetch = time.Now()
+	catalog := Catalog{Models: models, FetchedAt: s.lastFetch}
+	s.mu.Unlock()
+
+	slog.Info("successfully fetched free models from OpenRouter API",
+		slog.Int("count", len(models)),
+		slog.Time("last_fetch", catalog.FetchedAt),
+		slog.String("base_url", s.baseURL))
+
+	// Log details about the fetched models
+	for i, model := range models {
+		slog.Debug("free model details",
+			slog.Int("index", i),
+			slog.String("id", model.ID),
+			slog.String("name", model.Name),
+			slog.String("pricing_prompt", model.Pricing.Prompt),
+			slog.String("pricing_completion", model.Pricing.Completion))
+	}
 
-		s.models = models
-		s.lastFetch = time.Now()
-		slog.Info("successfully fetched free models from OpenRouter API",
-			slog.Int("count", len(models)),
-			slog.Time("last_fetch", s.lastFetch),
-			slog.String("base_url", s.baseURL))
-
-		// Log details about the fetched models
-		for i, model := range models {
-			slog.Debug("free model details",
-				slog.Int("index", i),
-				slog.String("id", model.ID),
-				slog.String("name", model.Name),
-				slog.String("pricing_prompt", model.Pricing.Prompt),
-				slog.String("pricing_completion", model.Pricing.Completion))
+	if s.store != nil && len(models) > 0 {
+		if err := s.store.Save(ctx, catalog); err != nil {
+			slog.Warn("failed to persist free models catalog", slog.Any("error", err))
 		}
-	} else {
-		slog.Debug("using cached free models",
-			slog.Int("count", len(s.models)),
-			slog.Time("last_fetch", s.lastFetch))
 	}
+	return models, nil
+}
 
-	return s.models, nil
+// loadStored seeds an empty cache from the catalog store.
+func (s *Service) loadStored(ctx context.Context) {
+	if s.store == nil {
+		return
+	}
+	catalog, err := s.store.Load(ctx)
+	if err != nil {
+		slog.Warn("failed to load persisted free models catalog", slog.Any("error", err))
+		return
+	}
+	if len(catalog.Models) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.models == nil {
+		s.models = catalog.Models
+		s.lastFetch = catalog.FetchedAt
+		slog.Info("loaded persisted free models catalog",
+			slog.Int("count", len(catalog.Models)),
+			slog.Time("fetched_at", catalog.FetchedAt))
+	}
 }
 
 // fetchModelsFromAPI fetches all models from OpenRouter API and filters free ones
@@ -361,11 +476,10 @@ func (s *Service) GetModelIDs(ctx context.Context) ([]string, error) {
 	return ids, nil
 }
 
-// Refresh forces a refresh of the models list
+// Refresh synchronously fetches the models list. It fails only when the
+// fetch fails and no last-known-good catalog is available.
 func (s *Service) Refresh(ctx context.Context) error {
-	s.models = nil
-	s.lastFetch = time.Time{}
-	_, err := s.GetFreeModels(ctx)
+	_, err := s.revalidate(ctx)
 	return err
 }
 
@@ -397,9 +511,24 @@ func (s *Service) fetchAllModelsFromAPI(ctx context.Context) ([]Model, error) {
 	return apiResp.Data, nil
 }
 
+// PaidModelFilter restricts which paid models GetCheapestPaidModelsFiltered
+// may return. The zero value accepts every paid model.
+type PaidModelFilter struct {
+	// MaxPricePer1K excludes models whose PricePer1K exceeds it (USD); 0 disables the cap.
+	MaxPricePer1K float64
+	// Allowed, when non-empty, is a case-insensitive whitelist of model IDs.
+	Allowed []string
+}
+
 // GetCheapestPaidModels returns up to `limit` cheapest non-free models by estimated per-request cost.
 // Cost heuristic: prefer Pricing.Request when present; otherwise use Prompt+Completion. Empty values are treated as high cost.
 func (s *Service) GetCheapestPaidModels(ctx context.Context, limit int) ([]Model, error) {
+	return s.GetCheapestPaidModelsFiltered(ctx, limit, PaidModelFilter{})
+}
+
+// GetCheapestPaidModelsFiltered is GetCheapestPaidModels restricted to the
+// models accepted by filter. Models with unknown pricing never pass a price cap.
+func (s *Service) GetCheapestPaidModelsFiltered(ctx context.Context, limit int, filter PaidModelFilter) ([]Model, error) {
 	if limit <= 0 {
 		return nil, nil
 	}
@@ -407,6 +536,10 @@ func (s *Service) GetCheapestPaidModels(ctx context.Context, limit int) ([]Model
 	if err != nil {
 		return nil, err
 	}
+	allowed := make(map[string]struct{}, len(filter.Allowed))
+	for _, id := range filter.Allowed {
+		allowed[strings.ToLower(strings.TrimSpace(id))] = struct{}{}
+	}
 	// Filter to paid models and exclude problematic ids
 	candidates := make([]Model, 0)
 	for _, m := range all {
@@ -417,6 +550,17 @@ func (s *Service) GetCheapestPaidModels(ctx context.Context, limit int) ([]Model
 		if mid == "openrouter/auto" {
 			continue
 		}
+		if len(allowed) > 0 {
+			if _, ok := allowed[mid]; !ok {
+				continue
+			}
+		}
+		if filter.MaxPricePer1K > 0 {
+			price, ok := PricePer1K(m)
+			if !ok || price > filter.MaxPricePer1K {
+				continue
+			}
+		}
 		candidates = append(candidates, m)
 	}
 	type priced struct {
@@ -446,6 +590,14 @@ func (s *Service) GetCheapestPaidModels(ctx context.Context, limit int) ([]Model
 	return out, nil
 }
 
+// PricePer1K estimates the USD cost of a call with 1K prompt and 1K
+// completion tokens: OpenRouter quotes token prices per token and any flat
+// per-request price is added once. ok is false when the model has no pricing.
+func PricePer1K(m Model) (price float64, ok bool) {
+	price = (parsePrice(m.Pricing.Prompt)+parsePrice(m.Pricing.Completion))*1000 + parsePrice(m.Pricing.Request)
+	return price, price > 0
+}
+
 func parsePrice(v string) float64 {
 	if v == "" {
 		return 0
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -314,9 +316,9 @@ func TestService_GetFreeModels_CacheBehavior(t *testing.T) {
 func TestService_GetFreeModels_RefreshAfterDuration(t *testing.T) {
 	t.Parallel()
 
-	callCount := 0
+	var callCount atomic.Int32
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-		callCount++
+		callCount.Add(1)
 		w.Header().Set("Content-Type", "application/json")
 		_ = json.NewEncoder(w).Encode(map[string]any{
 			"data": []map[string]any{
@@ -342,16 +344,88 @@ func TestService_GetFreeModels_RefreshAfterDuration(t *testing.T) {
 	models1, err1 := service.GetFreeModels(context.Background())
 	require.NoError(t, err1)
 	require.Len(t, models1, 1)
-	require.Equal(t, 1, callCount)
+	require.Equal(t, int32(1), callCount.Load())
 
 	// Wait for refresh duration to pass
 	time.Sleep(10 * time.Millisecond)
 
-	// Second call should trigger refresh
+	// Second call serves the stale catalog and revalidates in the background
 	models2, err2 := service.GetFreeModels(context.Background())
 	require.NoError(t, err2)
 	require.Len(t, models2, 1)
-	require.Equal(t, 2, callCount) // Should have made another API call
+	require.Eventually(t, func() bool { return callCount.Load() == 2 }, time.Second, 5*time.Millisecond)
+}
+
+func TestService_StaleWhileRevalidate_KeepsLastKnownGood(t *testing.T) {
+	t.Parallel()
+
+	var mode atomic.Int32 // 0 = ok, 1 = empty catalog, 2 = error
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		switch mode.Load() {
+		case 1:
+			_ = json.NewEncoder(w).Encode(OpenRouterResponse{})
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_ = json.NewEncoder(w).Encode(OpenRouterResponse{Data: []Model{{ID: "good:free"}}})
+		}
+	}))
+	defer server.Close()
+
+	var ages atomic.Int32
+	svc := NewService("k", server.URL, time.Hour).WithAgeObserver(func(time.Duration) { ages.Add(1) })
+	ctx := context.Background()
+	models, err := svc.GetFreeModels(ctx)
+	require.NoError(t, err)
+	require.Len(t, models, 1)
+	assert.Equal(t, int32(1), ages.Load())
+
+	mode.Store(1)
+	require.NoError(t, svc.Refresh(ctx))
+	mode.Store(2)
+	require.NoError(t, svc.Refresh(ctx), "refresh failure falls back to the last-known-good catalog")
+
+	models, err = svc.GetFreeModels(ctx)
+	require.NoError(t, err)
+	require.Len(t, models, 1)
+	assert.Equal(t, "good:free", models[0].ID)
+	assert.Less(t, svc.CatalogAge(), time.Minute)
+}
+
+func TestService_CatalogStore(t *testing.T) {
+	t.Parallel()
+
+	ok := atomic.Bool{}
+	ok.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !ok.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(OpenRouterResponse{Data: []Model{{ID: "persisted:free"}}})
+	}))
+	defer server.Close()
+
+	store := NewFileCatalogStore(filepath.Join(t.TempDir(), "catalog", "free-models.json"))
+	ctx := context.Background()
+
+	empty, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, empty.Models)
+
+	_, err = NewService("k", server.URL, time.Hour).WithStore(store).GetFreeModels(ctx)
+	require.NoError(t, err)
+
+	// A fresh process starts while the catalog API is down.
+	ok.Store(false)
+	models, err := NewService("k", server.URL, time.Hour).WithStore(store).GetFreeModels(ctx)
+	require.NoError(t, err)
+	require.Len(t, models, 1)
+	assert.Equal(t, "persisted:free", models[0].ID)
+
+	_, err = NewService("k", server.URL, time.Hour).GetFreeModels(ctx)
+	require.Error(t, err, "without a store there is nothing to fall back to")
+	assert.Nil(t, NewFileCatalogStore(""))
 }
 
 func TestService_FetchAllModelsFromAPI(t *testing.T) {
@@ -495,6 +569,56 @@ func TestService_GetCheapestPaidModels(t *testing.T) {
 	})
 }
 
+func TestService_GetCheapestPaidModelsFiltered(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(OpenRouterResponse{
+			Data: []Model{
+				{ID: "cheap/a", Pricing: Pricing{Prompt: "0.0000005", Completion: "0.0000005"}},
+				{ID: "mid/b", Pricing: Pricing{Prompt: "0.000002", Completion: "0.000002"}},
+				{ID: "flat/c", Pricing: Pricing{Request: "0.01"}},
+				{ID: "unpriced/d", Pricing: Pricing{Prompt: "n/a"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	service := NewService("test-key", server.URL, 1*time.Hour)
+	ctx := context.Background()
+	ids := func(ms []Model) []string {
+		out := make([]string, len(ms))
+		for i, m := range ms {
+			out[i] = m.ID
+		}
+		return out
+	}
+
+	capped, err := service.GetCheapestPaidModelsFiltered(ctx, 10, PaidModelFilter{MaxPricePer1K: 0.005})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cheap/a", "mid/b"}, ids(capped), "flat and unpriced models exceed or cannot prove the cap")
+
+	listed, err := service.GetCheapestPaidModelsFiltered(ctx, 10, PaidModelFilter{Allowed: []string{" MID/B ", "flat/c"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mid/b", "flat/c"}, ids(listed))
+
+	none, err := service.GetCheapestPaidModelsFiltered(ctx, 10, PaidModelFilter{MaxPricePer1K: 0.002, Allowed: []string{"mid/b"}})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestPricePer1K(t *testing.T) {
+	t.Parallel()
+
+	p, ok := PricePer1K(Model{Pricing: Pricing{Prompt: "0.000001", Completion: "0.000002", Request: "0.001"}})
+	assert.True(t, ok)
+	assert.InDelta(t, 0.004, p, 1e-12)
+
+	_, ok = PricePer1K(Model{})
+	assert.False(t, ok)
+}
+
 func TestParsePrice_EmptyAndInvalid(t *testing.T) {
 	t.Parallel()
 
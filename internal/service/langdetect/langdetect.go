@@ -0,0 +1,101 @@
+// Package langdetect guesses the language of submitted documents. It is a
+// cheap heuristic over frequent function words and scripts, good enough to
+// tell the languages submissions are expected in apart, not a general
+// purpose detector.
+package langdetect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Unknown is returned when a text's language cannot be decided.
+const Unknown = "unknown"
+
+// stopwords holds frequent function words of the Latin-script languages,
+// keyed by ISO 639-1 code. Words listed for several languages are ignored,
+// since they do not tell those languages apart.
+var stopwords = distinctWords(map[string]string{
+	"en": "the and of to in for with on is are was as by at from that this have has be an or",
+	"id": "dan yang di ke dari untuk dengan pada adalah ini itu dalam sebagai oleh atau juga tidak saya telah",
+	"es": "el los las del que y por con para es son como pero sus al lo se una mi muy",
+	"pt": "os do da dos das que e em um uma com para é são como mas não seu sua ao na no",
+	"fr": "le les des du et pour avec est sont dans qui pas sur au aux ce une je nous vous",
+	"de": "der die das und ist nicht mit von zu den dem ein eine für auf im sich auch als werden ich",
+	"it": "il gli della che è per non sono nel alla come anche una di con ho",
+	"nl": "het een van dat op te voor niet zijn aan ook bij er ik wij naar",
+})
+
+// distinctWords splits each language's word list and drops the words that
+// appear in more than one list.
+func distinctWords(lists map[string]string) map[string]map[string]struct{} {
+	count := map[string]int{}
+	for _, s := range lists {
+		for _, w := range strings.Fields(s) {
+			count[w]++
+		}
+	}
+	out := make(map[string]map[string]struct{}, len(lists))
+	for lang, s := range lists {
+		set := map[string]struct{}{}
+		for _, w := range strings.Fields(s) {
+			if count[w] == 1 {
+				set[w] = struct{}{}
+			}
+		}
+		out[lang] = set
+	}
+	return out
+}
+
+// Detect guesses the language of text and returns its ISO 639-1 code, or
+// Unknown when too few clues are present to decide. Texts mostly written in
+// Han characters are "ja" when they contain kana and "zh" otherwise.
+func Detect(text string) string {
+	if lang := detectScript(text); lang != "" {
+		return lang
+	}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) })
+	best, bestHits, total := Unknown, 0, 0
+	for lang, set := range stopwords {
+		hits := 0
+		for _, w := range words {
+			if _, ok := set[w]; ok {
+				hits++
+			}
+		}
+		total += hits
+		if hits > bestHits {
+			best, bestHits = lang, hits
+		}
+	}
+	if bestHits < 3 || float64(bestHits) < 0.6*float64(total) {
+		return Unknown
+	}
+	return best
+}
+
+// detectScript returns "ja" or "zh" when at least a third of text's letters
+// are Han or kana, and "" otherwise.
+func detectScript(text string) string {
+	var letters, han, kana int
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+			letters++
+		case unicode.Is(unicode.Han, r):
+			han++
+			letters++
+		case unicode.IsLetter(r):
+			letters++
+		}
+	}
+	if letters == 0 || 3*(han+kana) < letters {
+		return ""
+	}
+	if kana > 0 {
+		return "ja"
+	}
+	return "zh"
+}
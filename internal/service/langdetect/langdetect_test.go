@@ -0,0 +1,26 @@
+package langdetect_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/langdetect"
+)
+
+func TestDetect(t *testing.T) {
+	cases := map[string]string{
+		"We are looking for a backend engineer with experience in Go and the design of distributed systems.":         "en",
+		"Saya adalah pengembang backend dengan pengalaman lima tahun dalam membangun layanan yang andal.":            "id",
+		"Buscamos un ingeniero backend con experiencia en Go y en el diseño de los sistemas distribuidos.":           "es",
+		"Wir suchen einen Backend-Entwickler mit Erfahrung in Go, der sich auch für verteilte Systeme interessiert.": "de",
+		"Nous recherchons un ingénieur backend avec une expérience dans les systèmes distribués pour notre équipe.":  "fr",
+		"我们正在寻找一名具有分布式系统经验的后端工程师。":                                                                                   "zh",
+		"分散システムの経験を持つバックエンドエンジニアを募集しています。":                                                                           "ja",
+		"Go, Kubernetes, PostgreSQL, Redis": langdetect.Unknown,
+		"":                                  langdetect.Unknown,
+	}
+	for text, want := range cases {
+		assert.Equal(t, want, langdetect.Detect(text), text)
+	}
+}
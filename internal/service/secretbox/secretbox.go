@@ -0,0 +1,114 @@
+// Package secretbox encrypts small secrets, such as AI provider API keys,
+// before they are stored. Values are sealed with AES-256-GCM under the active
+// key and carry the key id, so keys can be rotated: any listed key opens the
+// values it sealed.
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// prefix marks sealed values: "enc:<key id>:<base64 of nonce and ciphertext>".
+const prefix = "enc:"
+
+// ErrUnknownKey fails values sealed with a key the box does not have, and
+// sealing with a box that has no active key.
+var ErrUnknownKey = errors.New("unknown secret encryption key")
+
+// Box seals and opens secrets. A nil Box has no keys.
+type Box struct {
+	active string
+	keys   map[string]cipher.AEAD
+}
+
+// New returns a Box that seals with the key named activeKeyID and opens with
+// all keys. It returns nil when keys is empty.
+func New(activeKeyID string, keys map[string][]byte) (*Box, error) {
+	if len(keys) == 0 {
+		if activeKeyID != "" {
+			return nil, fmt.Errorf("op=secretbox.new: active key %q: %w", activeKeyID, ErrUnknownKey)
+		}
+		return nil, nil
+	}
+	b := &Box{active: activeKeyID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for id, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("op=secretbox.new: key %q: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("op=secretbox.new: key %q: %w", id, err)
+		}
+		b.keys[id] = aead
+	}
+	if _, ok := b.keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("op=secretbox.new: active key %q: %w", activeKeyID, ErrUnknownKey)
+	}
+	return b, nil
+}
+
+// Seal encrypts secret with the active key, bound to aad (e.g. the row the
+// value is stored in).
+func (b *Box) Seal(secret, aad string) (string, error) {
+	if b == nil {
+		return "", fmt.Errorf("op=secretbox.seal: %w", ErrUnknownKey)
+	}
+	aead := b.keys[b.active]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(secret)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("op=secretbox.seal: nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(secret), []byte(aad))
+	return prefix + b.active + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a value produced by Seal with the same aad.
+func (b *Box) Open(value, aad string) (string, error) {
+	rest, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return "", fmt.Errorf("op=secretbox.open: value is not sealed")
+	}
+	id, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", fmt.Errorf("op=secretbox.open: malformed value")
+	}
+	var aead cipher.AEAD
+	if b != nil {
+		aead = b.keys[id]
+	}
+	if aead == nil {
+		return "", fmt.Errorf("op=secretbox.open: key %q: %w", id, ErrUnknownKey)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("op=secretbox.open: malformed value")
+	}
+	plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(aad))
+	if err != nil {
+		return "", fmt.Errorf("op=secretbox.open: %w", err)
+	}
+	return string(plain), nil
+}
+
+// Sealed reports whether value was produced by Seal.
+func Sealed(value string) bool { return strings.HasPrefix(value, prefix) }
+
+// ActiveKeyID returns the id of the key new values are sealed with.
+func (b *Box) ActiveKeyID() string {
+	if b == nil {
+		return ""
+	}
+	return b.active
+}
+
+// SealedWithActive reports whether value was sealed with the active key.
+func (b *Box) SealedWithActive(value string) bool {
+	return b != nil && strings.HasPrefix(value, prefix+b.active+":")
+}
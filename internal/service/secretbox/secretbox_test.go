@@ -0,0 +1,56 @@
+package secretbox_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/secretbox"
+)
+
+func TestBox_SealOpen(t *testing.T) {
+	old, err := secretbox.New("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	require.NoError(t, err)
+	sealedOld, err := old.Seal("gsk_secret", "k_abc")
+	require.NoError(t, err)
+	assert.True(t, secretbox.Sealed(sealedOld))
+	assert.True(t, strings.HasPrefix(sealedOld, "enc:k1:"))
+	assert.NotContains(t, sealedOld, "gsk_secret")
+
+	// After rotation new values use k2 and k1 values still open.
+	box, err := secretbox.New("k2", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32), "k2": bytes.Repeat([]byte{2}, 32)})
+	require.NoError(t, err)
+	assert.Equal(t, "k2", box.ActiveKeyID())
+	assert.False(t, box.SealedWithActive(sealedOld))
+	secret, err := box.Open(sealedOld, "k_abc")
+	require.NoError(t, err)
+	assert.Equal(t, "gsk_secret", secret)
+	sealed, err := box.Seal("gsk_secret", "k_abc")
+	require.NoError(t, err)
+	assert.True(t, box.SealedWithActive(sealed))
+	assert.NotEqual(t, sealedOld, sealed)
+
+	// A value moved to another row, or sealed with a missing key, does not open.
+	_, err = box.Open(sealed, "k_other")
+	assert.Error(t, err)
+	_, err = old.Open(sealed, "k_abc")
+	assert.ErrorIs(t, err, secretbox.ErrUnknownKey)
+	_, err = box.Open("gsk_plain", "k_abc")
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	box, err := secretbox.New("", nil)
+	require.NoError(t, err)
+	assert.Nil(t, box)
+	_, err = box.Seal("x", "y")
+	assert.ErrorIs(t, err, secretbox.ErrUnknownKey)
+
+	_, err = secretbox.New("k2", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	assert.ErrorIs(t, err, secretbox.ErrUnknownKey)
+	_, err = secretbox.New("k1", map[string][]byte{"k1": []byte("short")})
+	assert.Error(t, err)
+}
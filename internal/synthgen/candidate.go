@@ -0,0 +1,236 @@
+package synthgen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// stack is a family of technologies a candidate works with.
+type stack struct {
+	name      string
+	role      string
+	languages []string
+	tools     []string
+	infra     []string
+}
+
+var stacks = []stack{
+	{"backend-go", "Backend Engineer", []string{"Go", "SQL"}, []string{"gRPC", "PostgreSQL", "Redis", "Kafka", "chi", "pgx"}, []string{"Docker", "Kubernetes", "Prometheus", "OpenTelemetry"}},
+	{"backend-java", "Backend Engineer", []string{"Java", "Kotlin", "SQL"}, []string{"Spring Boot", "MySQL", "RabbitMQ", "Hibernate", "Elasticsearch"}, []string{"Docker", "Jenkins", "AWS", "Grafana"}},
+	{"frontend-ts", "Frontend Engineer", []string{"TypeScript", "JavaScript"}, []string{"React", "Next.js", "GraphQL", "Redux", "Tailwind CSS", "Jest"}, []string{"Vercel", "Cypress", "Storybook", "GitHub Actions"}},
+	{"data-python", "Data Engineer", []string{"Python", "SQL"}, []string{"Pandas", "Airflow", "Spark", "dbt", "FastAPI"}, []string{"BigQuery", "GCP", "Docker", "Great Expectations"}},
+	{"mobile-kotlin", "Android Engineer", []string{"Kotlin", "Java"}, []string{"Jetpack Compose", "Coroutines", "Room", "Retrofit", "Firebase"}, []string{"Gradle", "Fastlane", "Crashlytics", "GitHub Actions"}},
+	{"devops", "Platform Engineer", []string{"Go", "Bash", "Python"}, []string{"Terraform", "Helm", "ArgoCD", "Vault"}, []string{"AWS", "Kubernetes", "Prometheus", "Grafana"}},
+}
+
+func stackByName(name string) stack {
+	for _, s := range stacks {
+		if s.name == name {
+			return s
+		}
+	}
+	return stacks[0]
+}
+
+var (
+	firstNames = []string{"Andi", "Budi", "Citra", "Dewi", "Eko", "Fitri", "Gilang", "Hana", "Alex", "Jordan", "Maya", "Priya", "Sam", "Taylor", "Rina", "Yusuf"}
+	lastNames  = []string{"Santoso", "Pratama", "Wijaya", "Lestari", "Hartono", "Nguyen", "Rivera", "Kim", "Patel", "Okafor", "Larsen", "Haryanto"}
+	companies  = []string{"Bluefin Payments", "Nusantara Logistics", "Kopi Cloud", "Harbor Analytics", "Lumen Health", "Sagara Commerce", "Tiga Ride", "Orbit Media", "Pinewood Labs", "Atlas Insurance"}
+	cities     = []string{"Jakarta", "Bandung", "Surabaya", "Yogyakarta", "Singapore", "Remote"}
+	schools    = []string{"Universitas Nusantara", "Northfield Institute of Technology", "Institut Teknologi Merapi", "Lakeside State University"}
+)
+
+// yearsBySeniority is the range of years of experience of each seniority.
+var yearsBySeniority = map[string][2]int{
+	"junior": {0, 2},
+	"mid":    {3, 5},
+	"senior": {6, 9},
+	"lead":   {10, 14},
+}
+
+// candidate is a drawn profile together with the random stream its
+// documents are generated from.
+type candidate struct {
+	r         *rand.Rand
+	id        string
+	name      string
+	email     string
+	phone     string
+	city      string
+	seniority string
+	stack     stack
+	lang      string
+	length    string
+	years     int
+}
+
+func newCandidate(r *rand.Rand, n int, seniority string, st stack, lang, length string) *candidate {
+	first, last := pick(r, firstNames), pick(r, lastNames)
+	span := yearsBySeniority[seniority]
+	return &candidate{
+		r:         r,
+		id:        fmt.Sprintf("%04d", n),
+		name:      first + " " + last,
+		email:     strings.ToLower(first+"."+last) + fmt.Sprintf("%d@example.com", n),
+		phone:     fmt.Sprintf("+1 555 01%02d", r.IntN(100)),
+		city:      pick(r, cities),
+		seniority: seniority,
+		stack:     st,
+		lang:      lang,
+		length:    length,
+		years:     span[0] + r.IntN(span[1]-span[0]+1),
+	}
+}
+
+// t returns the phrase key in the candidate's language.
+func (c *candidate) t(key string) string {
+	if s, ok := phrases[c.lang][key]; ok {
+		return s
+	}
+	return phrases["en"][key]
+}
+
+// fill replaces the placeholders of a phrase with values drawn for c.
+func (c *candidate) fill(s string) string {
+	r := c.r
+	return strings.NewReplacer(
+		"{tool}", pick(r, c.stack.tools),
+		"{infra}", pick(r, c.stack.infra),
+		"{lang}", c.stack.languages[0],
+		"{role}", c.stack.role,
+		"{years}", strconv.Itoa(max(c.years, 1)),
+		"{n}", strconv.Itoa(200+r.IntN(40)*50),
+		"{pct}", strconv.Itoa(15+r.IntN(50)),
+		"{small}", strconv.Itoa(2+r.IntN(5)),
+	).Replace(s)
+}
+
+// detail is the number of bullets per entry for the document length.
+func (c *candidate) detail() int {
+	switch c.length {
+	case "short":
+		return 1
+	case "long":
+		return 5
+	default:
+		return 3
+	}
+}
+
+// senior reports whether c is expected to lead and mentor.
+func (c *candidate) senior() bool { return c.seniority == "senior" || c.seniority == "lead" }
+
+func (c *candidate) cv() document {
+	d := document{
+		title:    c.name,
+		subtitle: fmt.Sprintf("%s | %s | %s | %s", c.title(), c.city, c.email, c.phone),
+	}
+	d.sections = append(d.sections, section{heading: c.t("summary"), paragraphs: []string{c.fill(c.t("summary_text"))}})
+	skills := section{heading: c.t("skills"), bullets: []string{
+		c.t("skills_languages") + ": " + strings.Join(c.stack.languages, ", "),
+		c.t("skills_tools") + ": " + strings.Join(pickN(c.r, c.stack.tools, 2+c.detail()), ", "),
+		c.t("skills_infra") + ": " + strings.Join(pickN(c.r, c.stack.infra, 1+c.detail()), ", "),
+	}}
+	d.sections = append(d.sections, skills)
+
+	d.sections = append(d.sections, section{heading: c.t("experience")})
+	jobs := map[string]int{"junior": 1, "mid": 2, "senior": 3, "lead": 4}[c.seniority]
+	if c.length == "short" {
+		jobs = min(jobs, 2)
+	}
+	end := 2025
+	remaining := max(c.years, 1)
+	for i, company := range pickN(c.r, companies, jobs) {
+		span := max(remaining/(jobs-i), 1)
+		remaining -= span
+		title := c.stack.role
+		if i == 0 {
+			title = c.title()
+		}
+		var bullets []string
+		for _, p := range pickN(c.r, c.achievements(), c.detail()) {
+			bullets = append(bullets, c.fill(p))
+		}
+		d.sections = append(d.sections, section{
+			heading: fmt.Sprintf("%s, %s (%d - %s)", title, company, end-span, c.until(i, end)),
+			sub:     true,
+			bullets: bullets,
+		})
+		end -= span
+	}
+
+	d.sections = append(d.sections, section{heading: c.t("education"), bullets: []string{
+		fmt.Sprintf("%s, %s (%d)", c.t("degree"), pick(c.r, schools), end-c.r.IntN(2)),
+	}})
+	if c.length == "long" {
+		var bullets []string
+		for _, p := range pickN(c.r, c.t2("side_projects"), 2) {
+			bullets = append(bullets, c.fill(p))
+		}
+		d.sections = append(d.sections, section{heading: c.t("projects"), bullets: bullets})
+	}
+	d.sections = append(d.sections, section{heading: c.t("spoken"), bullets: []string{c.t("spoken_text")}})
+	return d
+}
+
+// until formats the end of the i-th most recent job.
+func (c *candidate) until(i, end int) string {
+	if i == 0 {
+		return c.t("present")
+	}
+	return strconv.Itoa(end)
+}
+
+// achievements are the experience bullets fitting c's seniority.
+func (c *candidate) achievements() []string {
+	if c.senior() {
+		return slices.Concat(c.t2("achievements"), c.t2("leadership"))
+	}
+	return c.t2("achievements")
+}
+
+// project renders c's report on the study case: an AI-assisted CV evaluation
+// backend. Less senior candidates cover fewer concerns, which gives scoring
+// fixtures a realistic spread.
+func (c *candidate) project() document {
+	d := document{
+		title:    c.t("project_title"),
+		subtitle: fmt.Sprintf("%s - %s", c.name, c.t("project_subtitle")),
+	}
+	add := func(heading string, key string, n int) {
+		var bullets []string
+		for _, p := range pickN(c.r, c.t2(key), n) {
+			bullets = append(bullets, c.fill(p))
+		}
+		d.sections = append(d.sections, section{heading: c.t(heading), bullets: bullets})
+	}
+	d.sections = append(d.sections, section{heading: c.t("overview"), paragraphs: []string{c.fill(c.t("overview_text"))}})
+	add("architecture", "architecture_points", c.detail()+1)
+	if c.seniority != "junior" {
+		add("resilience", "resilience_points", c.detail())
+	}
+	add("testing", "testing_points", map[string]int{"junior": 1, "mid": 2, "senior": 3, "lead": 3}[c.seniority])
+	if c.senior() {
+		add("tradeoffs", "tradeoff_points", c.detail())
+	}
+	if c.length == "long" {
+		add("future", "future_points", 3)
+	}
+	return d
+}
+
+// t2 returns the phrase list key in the candidate's language.
+func (c *candidate) t2(key string) []string {
+	if s, ok := phraseLists[c.lang][key]; ok {
+		return s
+	}
+	return phraseLists["en"][key]
+}
+
+var titlePrefix = map[string]string{"junior": "Junior ", "mid": "", "senior": "Senior ", "lead": "Lead "}
+
+// title is c's current job title.
+func (c *candidate) title() string { return titlePrefix[c.seniority] + c.stack.role }
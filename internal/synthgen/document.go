@@ -0,0 +1,75 @@
+package synthgen
+
+import (
+	"strings"
+)
+
+// document is a rendering-independent document: a title, an optional
+// subtitle line and sections of paragraphs and bullet points.
+type document struct {
+	title    string
+	subtitle string
+	sections []section
+}
+
+type section struct {
+	heading string
+	// sub renders the heading one level below the document's sections.
+	sub        bool
+	paragraphs []string
+	bullets    []string
+}
+
+// render renders d in format, which must be valid.
+func (d document) render(format string) []byte {
+	switch format {
+	case FormatPDF:
+		return renderPDF(d.lines(false))
+	case FormatText:
+		return []byte(strings.Join(d.lines(false), "\n") + "\n")
+	default:
+		return []byte(strings.Join(d.lines(true), "\n") + "\n")
+	}
+}
+
+// lines returns the document as lines of Markdown or of plain text.
+func (d document) lines(markdown bool) []string {
+	var out []string
+	if markdown {
+		out = append(out, "# "+d.title)
+	} else {
+		out = append(out, strings.ToUpper(d.title))
+	}
+	if d.subtitle != "" {
+		out = append(out, "", d.subtitle)
+	}
+	for _, s := range d.sections {
+		out = append(out, "")
+		switch {
+		case markdown && s.sub:
+			out = append(out, "### "+s.heading)
+		case markdown:
+			out = append(out, "## "+s.heading)
+		case s.sub:
+			out = append(out, s.heading)
+		default:
+			out = append(out, s.heading, strings.Repeat("-", len(s.heading)))
+		}
+		if markdown && len(s.paragraphs)+len(s.bullets) > 0 {
+			out = append(out, "")
+		}
+		for i, p := range s.paragraphs {
+			if i > 0 {
+				out = append(out, "")
+			}
+			out = append(out, p)
+		}
+		if len(s.paragraphs) > 0 && len(s.bullets) > 0 {
+			out = append(out, "")
+		}
+		for _, b := range s.bullets {
+			out = append(out, "- "+b)
+		}
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package synthgen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PDF page layout: A4 in points, Helvetica 10pt.
+const (
+	pdfPageWidth    = 595
+	pdfPageHeight   = 842
+	pdfMargin       = 56
+	pdfFontSize     = 10
+	pdfLineHeight   = 14
+	pdfLinesPerPage = (pdfPageHeight - 2*pdfMargin) / pdfLineHeight
+	pdfWrapColumn   = 90
+)
+
+// renderPDF lays lines out as a text-only PDF with the standard Helvetica
+// font, so that text extractors read back the same text. Long lines are
+// wrapped and the text is split into pages.
+func renderPDF(lines []string) []byte {
+	var wrapped []string
+	for _, l := range lines {
+		wrapped = append(wrapped, wrap(l, pdfWrapColumn)...)
+	}
+	var pages [][]string
+	for len(wrapped) > 0 {
+		n := min(pdfLinesPerPage, len(wrapped))
+		pages = append(pages, wrapped[:n])
+		wrapped = wrapped[n:]
+	}
+	if len(pages) == 0 {
+		pages = [][]string{nil}
+	}
+
+	// Objects: 1 catalog, 2 page tree, 3 font, then a page and its content
+	// stream for every page.
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+	)
+	for i, page := range pages {
+		var content strings.Builder
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLineHeight, pdfMargin, pdfPageHeight-pdfMargin)
+		for _, l := range page {
+			fmt.Fprintf(&content, "(%s) '\n", pdfEscape(l))
+		}
+		content.WriteString("ET")
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+				pdfPageWidth, pdfPageHeight, 5+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, o := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+// pdfEscape escapes a string literal; characters outside printable ASCII are
+// replaced since the generated text is ASCII.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// wrap splits s into lines of at most width characters at spaces. Bullet
+// continuation lines are indented.
+func wrap(s string, width int) []string {
+	if len(s) <= width {
+		return []string{s}
+	}
+	indent := ""
+	if strings.HasPrefix(s, "- ") {
+		indent = "  "
+	}
+	var out []string
+	line := ""
+	for _, w := range strings.Fields(s) {
+		switch {
+		case line == "":
+			line = w
+		case len(line)+1+len(w) > width:
+			out = append(out, line)
+			line = indent + w
+		default:
+			line += " " + w
+		}
+	}
+	return append(out, line)
+}
@@ -0,0 +1,168 @@
+package synthgen
+
+// phrases are the document texts by language. Placeholders in braces are
+// filled per candidate; see candidate.fill.
+var phrases = map[string]map[string]string{
+	"en": {
+		"summary":          "Summary",
+		"summary_text":     "{role} with {years} years of experience building production systems in {lang}. Comfortable owning features end to end, from design and implementation to monitoring in production with {infra}.",
+		"skills":           "Skills",
+		"skills_languages": "Languages",
+		"skills_tools":     "Frameworks and tools",
+		"skills_infra":     "Infrastructure",
+		"experience":       "Experience",
+		"present":          "present",
+		"education":        "Education",
+		"degree":           "B.Sc. Computer Science",
+		"projects":         "Side Projects",
+		"spoken":           "Languages Spoken",
+		"spoken_text":      "English (professional), Indonesian (native)",
+		"project_title":    "CV Evaluation Service - Project Report",
+		"project_subtitle": "Study case submission",
+		"overview":         "Overview",
+		"overview_text":    "This report describes a backend that accepts a CV and a project report, evaluates them against a job description and a scoring rubric with an LLM, and returns the result asynchronously. It is written in {lang} and packaged with Docker.",
+		"architecture":     "Architecture",
+		"resilience":       "Resilience",
+		"testing":          "Testing",
+		"tradeoffs":        "Trade-offs",
+		"future":           "Future Work",
+	},
+	"id": {
+		"summary":          "Ringkasan",
+		"summary_text":     "{role} dengan pengalaman {years} tahun membangun sistem produksi menggunakan {lang}. Terbiasa menangani fitur dari awal hingga akhir, mulai dari desain dan implementasi hingga pemantauan di produksi dengan {infra}.",
+		"skills":           "Keahlian",
+		"skills_languages": "Bahasa pemrograman",
+		"skills_tools":     "Framework dan tools",
+		"skills_infra":     "Infrastruktur",
+		"experience":       "Pengalaman Kerja",
+		"present":          "sekarang",
+		"education":        "Pendidikan",
+		"degree":           "S.Kom. Teknik Informatika",
+		"projects":         "Proyek Pribadi",
+		"spoken":           "Bahasa",
+		"spoken_text":      "Bahasa Indonesia (native), Inggris (profesional)",
+		"project_title":    "Layanan Evaluasi CV - Laporan Proyek",
+		"project_subtitle": "Pengumpulan studi kasus",
+		"overview":         "Gambaran Umum",
+		"overview_text":    "Laporan ini menjelaskan backend yang menerima CV dan laporan proyek, mengevaluasinya terhadap deskripsi pekerjaan dan rubrik penilaian dengan LLM, lalu mengembalikan hasilnya secara asinkron. Backend ditulis dengan {lang} dan dikemas dengan Docker.",
+		"architecture":     "Arsitektur",
+		"resilience":       "Ketahanan",
+		"testing":          "Pengujian",
+		"tradeoffs":        "Pertimbangan Desain",
+		"future":           "Pengembangan Selanjutnya",
+	},
+}
+
+// phraseLists are the interchangeable bullet points by language.
+var phraseLists = map[string]map[string][]string{
+	"en": {
+		"achievements": {
+			"Designed and shipped {tool}-based services handling {n} requests per second.",
+			"Cut p95 latency by {pct}% by profiling hot paths and adding caching.",
+			"Introduced automated tests and CI pipelines, raising coverage to {pct}%.",
+			"Migrated a legacy module to {tool}, reducing infrastructure cost by {pct}%.",
+			"Built dashboards and alerts with {infra}, reducing incident resolution time by {pct}%.",
+			"Worked with product and design to deliver {small} major features per quarter.",
+			"Implemented retries, timeouts and idempotency keys for third-party integrations.",
+			"Automated deployments with {infra}, shortening release cycles from weeks to days.",
+		},
+		"leadership": {
+			"Mentored {small} engineers and ran the team's code review process.",
+			"Led the design review for a re-architecture of the core platform on {tool}.",
+			"Defined on-call practices and postmortem templates adopted by {small} teams.",
+		},
+		"side_projects": {
+			"Open-source {tool} helper library with {n} GitHub stars.",
+			"Personal finance tracker built with {lang} and {tool}.",
+			"Conference talk on running {tool} in production.",
+		},
+		"architecture_points": {
+			"HTTP API with /upload, /evaluate and /result endpoints; uploads are stored and their text extracted once.",
+			"Evaluations run asynchronously: the API enqueues a job and a worker processes it, so requests return immediately.",
+			"Job descriptions and rubrics are embedded and stored in a vector database for retrieval during evaluation.",
+			"The LLM chain evaluates the CV, then the project, then refines both into a final summary.",
+			"Persistence uses a relational database with migrations; job status moves from queued to processing to completed or failed.",
+			"Configuration is read from environment variables so the same image runs in every environment.",
+		},
+		"resilience_points": {
+			"LLM calls use exponential backoff with jitter and a timeout of {small}0 seconds.",
+			"Malformed model output is validated against a JSON schema and retried with a corrective prompt.",
+			"Idempotency keys prevent duplicate jobs when clients retry a submission.",
+			"Rate limits of the LLM provider are respected with a token bucket shared by all workers.",
+			"Failed jobs are retried a limited number of times and then moved to a dead-letter queue.",
+		},
+		"testing_points": {
+			"Unit tests cover the scoring and parsing logic with table-driven cases.",
+			"Integration tests run the API and worker against their real dependencies in containers.",
+			"A golden set of CVs checks that scores stay stable when prompts change.",
+			"Load tests submit {n} evaluations to measure queue latency.",
+		},
+		"tradeoff_points": {
+			"An asynchronous queue adds operational complexity but keeps the API responsive during slow LLM calls.",
+			"Low temperature makes scores more repeatable at the cost of less varied feedback.",
+			"Storing extracted text instead of files simplifies processing but loses formatting.",
+			"A single worker pool is simpler than per-step pools but limits tuning of expensive steps.",
+		},
+		"future_points": {
+			"Stream partial results to clients while the evaluation runs.",
+			"Calibrate scores across models with a shared golden set.",
+			"Add per-tenant quotas and usage reports.",
+			"Support more document formats such as DOCX and HTML.",
+		},
+	},
+	"id": {
+		"achievements": {
+			"Merancang dan merilis layanan berbasis {tool} yang melayani {n} request per detik.",
+			"Menurunkan latensi p95 sebesar {pct}% dengan profiling dan menambahkan caching.",
+			"Menerapkan pengujian otomatis dan pipeline CI hingga coverage mencapai {pct}%.",
+			"Memigrasikan modul lama ke {tool} sehingga biaya infrastruktur turun {pct}%.",
+			"Membangun dashboard dan alert dengan {infra} sehingga waktu penanganan insiden turun {pct}%.",
+			"Bekerja sama dengan tim produk dan desain untuk merilis {small} fitur utama setiap kuartal.",
+			"Menerapkan retry, timeout dan idempotency key untuk integrasi dengan pihak ketiga.",
+			"Mengotomatiskan deployment dengan {infra} sehingga siklus rilis dari mingguan menjadi harian.",
+		},
+		"leadership": {
+			"Membimbing {small} engineer dan memimpin proses code review tim.",
+			"Memimpin design review untuk arsitektur ulang platform inti dengan {tool}.",
+			"Menyusun praktik on-call dan template postmortem yang dipakai {small} tim.",
+		},
+		"side_projects": {
+			"Library open-source untuk {tool} dengan {n} bintang di GitHub.",
+			"Aplikasi pencatat keuangan pribadi dengan {lang} dan {tool}.",
+			"Pembicara konferensi tentang menjalankan {tool} di produksi.",
+		},
+		"architecture_points": {
+			"API HTTP dengan endpoint /upload, /evaluate dan /result; teks dokumen diekstrak sekali saat upload.",
+			"Evaluasi berjalan asinkron: API memasukkan job ke antrean dan worker memprosesnya, sehingga request langsung selesai.",
+			"Deskripsi pekerjaan dan rubrik disimpan sebagai embedding di vector database untuk retrieval saat evaluasi.",
+			"Rantai LLM mengevaluasi CV, lalu proyek, lalu menyempurnakan keduanya menjadi ringkasan akhir.",
+			"Penyimpanan memakai database relasional dengan migrasi; status job berubah dari queued, processing, hingga completed atau failed.",
+			"Konfigurasi dibaca dari environment variable sehingga image yang sama berjalan di semua environment.",
+		},
+		"resilience_points": {
+			"Pemanggilan LLM memakai exponential backoff dengan jitter dan timeout {small}0 detik.",
+			"Output model yang tidak valid dicek dengan JSON schema dan diulang dengan prompt perbaikan.",
+			"Idempotency key mencegah job ganda saat klien mengulang pengiriman.",
+			"Batas rate provider LLM dijaga dengan token bucket yang dipakai bersama semua worker.",
+			"Job yang gagal diulang beberapa kali lalu dipindahkan ke dead-letter queue.",
+		},
+		"testing_points": {
+			"Unit test mencakup logika penilaian dan parsing dengan table-driven test.",
+			"Integration test menjalankan API dan worker dengan dependensi asli di dalam container.",
+			"Golden set berisi CV memastikan skor tetap stabil saat prompt berubah.",
+			"Load test mengirim {n} evaluasi untuk mengukur latensi antrean.",
+		},
+		"tradeoff_points": {
+			"Antrean asinkron menambah kompleksitas operasional tetapi menjaga API tetap responsif saat LLM lambat.",
+			"Temperature rendah membuat skor lebih konsisten dengan konsekuensi feedback yang kurang bervariasi.",
+			"Menyimpan teks hasil ekstraksi menyederhanakan pemrosesan tetapi kehilangan format dokumen.",
+			"Satu pool worker lebih sederhana dibanding pool per langkah tetapi membatasi tuning langkah yang mahal.",
+		},
+		"future_points": {
+			"Mengirim hasil parsial ke klien selama evaluasi berjalan.",
+			"Kalibrasi skor antar model dengan golden set bersama.",
+			"Menambahkan kuota per tenant dan laporan pemakaian.",
+			"Mendukung format dokumen lain seperti DOCX dan HTML.",
+		},
+	},
+}
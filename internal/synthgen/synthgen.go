@@ -0,0 +1,157 @@
+// Package synthgen generates synthetic CVs and project reports for load
+// tests, golden sets and end-to-end fixtures, so that test environments never
+// need real candidate data.
+//
+// Candidates vary in seniority, tech stack, language and document length.
+// Generation is deterministic: the same seed always yields the same
+// documents. Every person, company, email address and phone number is made
+// up; emails use example.com and phone numbers the fictional 555-01xx range.
+package synthgen
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+)
+
+// Document formats.
+const (
+	FormatMarkdown = "md"
+	FormatText     = "txt"
+	FormatPDF      = "pdf"
+)
+
+// Seniorities, stacks, languages and lengths candidates are drawn from.
+var (
+	Seniorities = []string{"junior", "mid", "senior", "lead"}
+	Languages   = []string{"en", "id"}
+	Lengths     = []string{"short", "medium", "long"}
+)
+
+// Stacks returns the names of the tech stacks candidates are drawn from.
+func Stacks() []string {
+	names := make([]string, 0, len(stacks))
+	for _, s := range stacks {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// Options select how many candidates are generated and how.
+type Options struct {
+	// Count is the number of candidates; each has a CV and a project report.
+	Count int
+	// Seed makes the output reproducible.
+	Seed uint64
+	// Formats are the document formats to render; empty renders Markdown.
+	Formats []string
+	// Seniorities, Stacks, Languages and Lengths restrict the profiles drawn;
+	// empty draws from all of them.
+	Seniorities []string
+	Stacks      []string
+	Languages   []string
+	Lengths     []string
+}
+
+// Profile describes a generated candidate.
+type Profile struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Seniority string `json:"seniority"`
+	Stack     string `json:"stack"`
+	Language  string `json:"language"`
+	Length    string `json:"length"`
+	Years     int    `json:"years"`
+	// Files are the names of the candidate's rendered documents.
+	Files []string `json:"files"`
+}
+
+// File is a rendered document.
+type File struct {
+	Name string
+	Body []byte
+}
+
+// Generate draws opts.Count candidate profiles and renders a CV and a project
+// report for each in every requested format.
+func Generate(opts Options) ([]Profile, []File, error) {
+	if opts.Count <= 0 {
+		return nil, nil, fmt.Errorf("count must be positive, got %d", opts.Count)
+	}
+	formats := opts.Formats
+	if len(formats) == 0 {
+		formats = []string{FormatMarkdown}
+	}
+	for _, f := range formats {
+		if f != FormatMarkdown && f != FormatText && f != FormatPDF {
+			return nil, nil, fmt.Errorf("unknown format %q (want md, txt or pdf)", f)
+		}
+	}
+	seniorities, err := restrict("seniority", Seniorities, opts.Seniorities)
+	if err != nil {
+		return nil, nil, err
+	}
+	stackNames, err := restrict("stack", Stacks(), opts.Stacks)
+	if err != nil {
+		return nil, nil, err
+	}
+	languages, err := restrict("language", Languages, opts.Languages)
+	if err != nil {
+		return nil, nil, err
+	}
+	lengths, err := restrict("length", Lengths, opts.Lengths)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	profiles := make([]Profile, 0, opts.Count)
+	files := make([]File, 0, opts.Count*2*len(formats))
+	for i := range opts.Count {
+		// Each candidate has its own stream so that changing Count does not
+		// change the candidates generated before it.
+		r := rand.New(rand.NewPCG(opts.Seed, uint64(i)))
+		c := newCandidate(r, i+1, pick(r, seniorities), stackByName(pick(r, stackNames)), pick(r, languages), pick(r, lengths))
+		p := Profile{
+			ID: c.id, Name: c.name, Seniority: c.seniority, Stack: c.stack.name,
+			Language: c.lang, Length: c.length, Years: c.years,
+		}
+		for _, doc := range []struct {
+			kind string
+			d    document
+		}{{"cv", c.cv()}, {"project", c.project()}} {
+			for _, f := range formats {
+				name := fmt.Sprintf("%s_%s_%s_%s_%s.%s", doc.kind, c.id, c.seniority, c.stack.name, c.lang, f)
+				files = append(files, File{Name: name, Body: doc.d.render(f)})
+				p.Files = append(p.Files, name)
+			}
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, files, nil
+}
+
+// restrict returns the values of all selected by want, or all without want.
+func restrict(what string, all, want []string) ([]string, error) {
+	if len(want) == 0 {
+		return all, nil
+	}
+	for _, w := range want {
+		if !slices.Contains(all, w) {
+			return nil, fmt.Errorf("unknown %s %q (want one of %s)", what, w, strings.Join(all, ", "))
+		}
+	}
+	return want, nil
+}
+
+func pick[T any](r *rand.Rand, xs []T) T { return xs[r.IntN(len(xs))] }
+
+// pickN returns n distinct elements of xs in random order.
+func pickN[T any](r *rand.Rand, xs []T, n int) []T {
+	idx := r.Perm(len(xs))
+	out := make([]T, 0, min(n, len(xs)))
+	for _, i := range idx[:min(n, len(xs))] {
+		out = append(out, xs[i])
+	}
+	return out
+}
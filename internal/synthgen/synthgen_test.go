@@ -0,0 +1,110 @@
+package synthgen
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_Deterministic(t *testing.T) {
+	opts := Options{Count: 5, Seed: 42, Formats: []string{FormatMarkdown, FormatText, FormatPDF}}
+	p1, f1, err := Generate(opts)
+	require.NoError(t, err)
+	p2, f2, err := Generate(opts)
+	require.NoError(t, err)
+	assert.Equal(t, p1, p2)
+	assert.Equal(t, f1, f2)
+	require.Len(t, f1, 5*2*3)
+
+	// Candidates do not depend on how many are generated after them.
+	p3, _, err := Generate(Options{Count: 2, Seed: 42})
+	require.NoError(t, err)
+	assert.Equal(t, p1[1].Name, p3[1].Name)
+	assert.Equal(t, p1[1].Stack, p3[1].Stack)
+
+	p4, _, err := Generate(Options{Count: 5, Seed: 43})
+	require.NoError(t, err)
+	assert.NotEqual(t, p1, p4)
+}
+
+func TestGenerate_Profiles(t *testing.T) {
+	profiles, files, err := Generate(Options{Count: 20, Seed: 7, Seniorities: []string{"junior", "lead"}, Languages: []string{"id"}})
+	require.NoError(t, err)
+	names := map[string]bool{}
+	for _, f := range files {
+		names[f.Name] = true
+		assert.NotContains(t, string(f.Body), "{", f.Name)
+	}
+	for _, p := range profiles {
+		assert.Contains(t, []string{"junior", "lead"}, p.Seniority)
+		assert.Equal(t, "id", p.Language)
+		span := yearsBySeniority[p.Seniority]
+		assert.True(t, p.Years >= span[0] && p.Years <= span[1], p.Years)
+		require.Len(t, p.Files, 2)
+		for _, f := range p.Files {
+			assert.True(t, names[f], f)
+			assert.Contains(t, f, p.ID)
+		}
+	}
+}
+
+func TestGenerate_SeniorityShapesProject(t *testing.T) {
+	_, junior, err := Generate(Options{Count: 1, Seniorities: []string{"junior"}, Languages: []string{"en"}})
+	require.NoError(t, err)
+	_, lead, err := Generate(Options{Count: 1, Seniorities: []string{"lead"}, Languages: []string{"en"}})
+	require.NoError(t, err)
+	assert.NotContains(t, string(junior[1].Body), "## Resilience")
+	assert.Contains(t, string(lead[1].Body), "## Resilience")
+	assert.Contains(t, string(lead[1].Body), "## Trade-offs")
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	for _, opts := range []Options{
+		{Count: 0},
+		{Count: 1, Formats: []string{"docx"}},
+		{Count: 1, Stacks: []string{"cobol"}},
+		{Count: 1, Seniorities: []string{"principal"}},
+		{Count: 1, Languages: []string{"fr"}},
+		{Count: 1, Lengths: []string{"huge"}},
+	} {
+		_, _, err := Generate(opts)
+		assert.Error(t, err, opts)
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	lines := make([]string, 0, 120)
+	for i := range 120 {
+		lines = append(lines, "line "+strconv.Itoa(i)+" (with parentheses) and a \\ backslash")
+	}
+	lines = append(lines, strings.Repeat("word ", 40))
+	pdf := renderPDF(lines)
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "/Count 3")
+	assert.Contains(t, string(pdf), `(line 0 \(with parentheses\) and a \\ backslash) '`)
+
+	// Every xref entry points at its object and startxref at the table.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf[xref:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	require.Len(t, entries, 3+2*3)
+	for i, e := range entries {
+		off, err := strconv.Atoi(string(e[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(pdf[off:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, []string{"short"}, wrap("short", 10))
+	assert.Equal(t, []string{"- aaaa bbbb", "  cccc"}, wrap("- aaaa bbbb cccc", 11))
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Access log query limits.
+const (
+	defaultAccessLogLimit = 100
+	maxAccessLogLimit     = 1000
+)
+
+// AccessLogService records reads of results and answers audit queries about
+// them.
+type AccessLogService struct {
+	Repo domain.AccessLogRepository
+
+	now func() time.Time
+}
+
+// NewAccessLogService constructs an AccessLogService.
+func NewAccessLogService(repo domain.AccessLogRepository) *AccessLogService {
+	return &AccessLogService{Repo: repo, now: time.Now}
+}
+
+// Record stores entries, stamping those without an access time with the
+// current time.
+func (s *AccessLogService) Record(ctx domain.Context, entries []domain.ResultAccess) error {
+	now := s.now().UTC()
+	for i := range entries {
+		if entries[i].AccessedAt.IsZero() {
+			entries[i].AccessedAt = now
+		}
+	}
+	if err := s.Repo.Record(ctx, entries); err != nil {
+		return fmt.Errorf("op=access_log.record: %w", err)
+	}
+	return nil
+}
+
+// List returns the reads matching f, newest first. A zero limit returns up
+// to 100 entries; larger limits are capped at 1000.
+func (s *AccessLogService) List(ctx domain.Context, f domain.AccessLogFilter) ([]domain.ResultAccess, error) {
+	if f.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", domain.ErrInvalidArgument)
+	}
+	if !f.Since.IsZero() && !f.Until.IsZero() && !f.Since.Before(f.Until) {
+		return nil, fmt.Errorf("%w: since must be before until", domain.ErrInvalidArgument)
+	}
+	if f.Limit == 0 {
+		f.Limit = defaultAccessLogLimit
+	}
+	f.Limit = min(f.Limit, maxAccessLogLimit)
+	return s.Repo.List(ctx, f)
+}
@@ -0,0 +1,54 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestAccessLogService_Record(t *testing.T) {
+	repo := mocks.NewMockAccessLogRepository(t)
+	svc := usecase.NewAccessLogService(repo)
+	at := time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().Record(mock.Anything, mock.MatchedBy(func(entries []domain.ResultAccess) bool {
+		return len(entries) == 2 && !entries[0].AccessedAt.IsZero() && entries[1].AccessedAt.Equal(at)
+	})).Return(nil).Once()
+	require.NoError(t, svc.Record(context.Background(), []domain.ResultAccess{
+		{JobID: "j1", AccessorType: domain.AccessorAnonymous, Endpoint: "result"},
+		{JobID: "j2", AccessorType: domain.AccessorAnonymous, Endpoint: "result", AccessedAt: at},
+	}))
+
+	repo.EXPECT().Record(mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	assert.ErrorIs(t, svc.Record(context.Background(), []domain.ResultAccess{{JobID: "j1"}}), assert.AnError)
+}
+
+func TestAccessLogService_List(t *testing.T) {
+	repo := mocks.NewMockAccessLogRepository(t)
+	svc := usecase.NewAccessLogService(repo)
+	ctx := context.Background()
+	at := time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC)
+
+	_, err := svc.List(ctx, domain.AccessLogFilter{Limit: -1})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+	_, err = svc.List(ctx, domain.AccessLogFilter{Since: at, Until: at})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	want := []domain.ResultAccess{{JobID: "j1", AccessorType: domain.AccessorAdmin, Accessor: "alice"}}
+	repo.EXPECT().List(mock.Anything, domain.AccessLogFilter{JobID: "j1", Limit: 100}).Return(want, nil).Once()
+	got, err := svc.List(ctx, domain.AccessLogFilter{JobID: "j1"})
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	repo.EXPECT().List(mock.Anything, domain.AccessLogFilter{Limit: 1000}).Return(nil, nil).Once()
+	_, err = svc.List(ctx, domain.AccessLogFilter{Limit: 5000})
+	require.NoError(t, err)
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// AISaturationProbe reports whether every chat model of an AI client is
+// blocked after rate limits and how long until the first is usable again.
+type AISaturationProbe interface {
+	Saturation(ctx context.Context) (time.Duration, bool)
+}
+
+// aiSaturationCacheTTL bounds how often submissions reload the reports.
+const aiSaturationCacheTTL = 2 * time.Second
+
+// AISaturationService shares the AI capacity of the workers: each worker
+// reports whether all of its chat models are blocked after rate limits, and
+// new evaluations fail fast while every worker that reported recently is
+// saturated. A nil *AISaturationService never reports saturation.
+type AISaturationService struct {
+	Repo domain.AISaturationRepository
+	// Interval is how often workers report. Reports older than three
+	// intervals are ignored, so a stopped worker does not hold submissions
+	// back.
+	Interval time.Duration
+
+	now       func() time.Time
+	mu        sync.Mutex
+	until     time.Time
+	checkedAt time.Time
+}
+
+// NewAISaturationService constructs an AISaturationService over repo whose
+// workers report every 10s.
+func NewAISaturationService(repo domain.AISaturationRepository) *AISaturationService {
+	return &AISaturationService{Repo: repo, Interval: 10 * time.Second, now: time.Now}
+}
+
+// Saturated reports whether the AI models of every recently reporting worker
+// are blocked and, if so, how long until the first of them is usable again.
+// The reports are reloaded at most every few seconds; load failures let the
+// submission through.
+func (s *AISaturationService) Saturated(ctx domain.Context) (time.Duration, bool) {
+	if s == nil {
+		return 0, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if now.Sub(s.checkedAt) >= aiSaturationCacheTTL {
+		until, err := s.blockedUntil(ctx, now)
+		if err != nil {
+			slog.Warn("ai saturation load failed; skipping fail-fast", slog.Any("error", err))
+			return 0, false
+		}
+		s.until, s.checkedAt = until, now
+	}
+	if !s.until.After(now) {
+		return 0, false
+	}
+	return s.until.Sub(now), true
+}
+
+// blockedUntil returns the earliest end of the workers' blocks, or the zero
+// time when a worker is not saturated or none reported.
+func (s *AISaturationService) blockedUntil(ctx domain.Context, now time.Time) (time.Time, error) {
+	reports, err := s.Repo.List(ctx, now.Add(-3*s.Interval))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("op=ai_saturation.list: %w", err)
+	}
+	var earliest time.Time
+	for _, r := range reports {
+		if r.BlockedUntil == nil || !r.BlockedUntil.After(now) {
+			return time.Time{}, nil
+		}
+		if earliest.IsZero() || r.BlockedUntil.Before(earliest) {
+			earliest = *r.BlockedUntil
+		}
+	}
+	return earliest, nil
+}
+
+// Report stores the saturation probe currently sees as instance's report.
+func (s *AISaturationService) Report(ctx domain.Context, instance string, probe AISaturationProbe) error {
+	now := s.now().UTC()
+	report := domain.AISaturation{Instance: instance, ReportedAt: now}
+	if wait, ok := probe.Saturation(ctx); ok {
+		until := now.Add(wait)
+		report.BlockedUntil = &until
+	}
+	if err := s.Repo.Report(ctx, report); err != nil {
+		return fmt.Errorf("op=ai_saturation.report: %w", err)
+	}
+	return nil
+}
+
+// Run reports instance's saturation every Interval until ctx is done.
+func (s *AISaturationService) Run(ctx context.Context, instance string, probe AISaturationProbe) {
+	if s == nil || probe == nil || s.Interval <= 0 {
+		return
+	}
+	t := time.NewTicker(s.Interval)
+	defer t.Stop()
+	for {
+		if err := s.Report(ctx, instance, probe); err != nil {
+			slog.Warn("ai saturation report failed", slog.Any("error", err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type memAISaturation struct {
+	reports []domain.AISaturation
+	err     error
+	lists   int
+}
+
+func (m *memAISaturation) Report(_ domain.Context, s domain.AISaturation) error {
+	m.reports = append(m.reports, s)
+	return m.err
+}
+
+func (m *memAISaturation) List(domain.Context, time.Time) ([]domain.AISaturation, error) {
+	m.lists++
+	return m.reports, m.err
+}
+
+type fixedProbe struct {
+	wait      time.Duration
+	saturated bool
+}
+
+func (p fixedProbe) Saturation(context.Context) (time.Duration, bool) { return p.wait, p.saturated }
+
+func TestAISaturationService_SaturatedWhenEveryWorkerIs(t *testing.T) {
+	ctx := context.Background()
+	repo := &memAISaturation{}
+	reporter := usecase.NewAISaturationService(repo)
+	require.NoError(t, reporter.Report(ctx, "worker-1", fixedProbe{wait: 3 * time.Minute, saturated: true}))
+	require.NoError(t, reporter.Report(ctx, "worker-2", fixedProbe{wait: time.Minute, saturated: true}))
+
+	wait, saturated := usecase.NewAISaturationService(repo).Saturated(ctx)
+	assert.True(t, saturated)
+	assert.InDelta(t, time.Minute.Seconds(), wait.Seconds(), 1)
+
+	// One worker with a usable model is enough to accept jobs.
+	require.NoError(t, reporter.Report(ctx, "worker-3", fixedProbe{}))
+	svc := usecase.NewAISaturationService(repo)
+	_, saturated = svc.Saturated(ctx)
+	assert.False(t, saturated)
+	_, _ = svc.Saturated(ctx)
+	assert.Equal(t, 2, repo.lists, "reloads are throttled")
+
+	// Without reports, or when they cannot be loaded, submissions go through.
+	_, saturated = usecase.NewAISaturationService(&memAISaturation{}).Saturated(ctx)
+	assert.False(t, saturated)
+	_, saturated = usecase.NewAISaturationService(&memAISaturation{err: errors.New("db down")}).Saturated(ctx)
+	assert.False(t, saturated)
+	var none *usecase.AISaturationService
+	_, saturated = none.Saturated(ctx)
+	assert.False(t, saturated)
+}
+
+func TestEvaluate_Enqueue_AISaturatedRejectsWithRetryAfter(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	until := time.Now().Add(2 * time.Minute)
+	svc.Saturation = usecase.NewAISaturationService(&memAISaturation{reports: []domain.AISaturation{
+		{Instance: "worker-1", BlockedUntil: &until, ReportedAt: time.Now()},
+	}})
+
+	_, err := svc.Enqueue(context.Background(), "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.ErrorIs(t, err, domain.ErrAISaturated)
+	wait, ok := domain.RetryAfter(err)
+	require.True(t, ok)
+	assert.InDelta(t, (2 * time.Minute).Seconds(), wait.Seconds(), 1)
+	jobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Backpressure modes applied to new evaluations while the backlog is full.
+const (
+	BackpressureReject = "reject"
+	BackpressureDefer  = "defer"
+)
+
+// backpressureCacheTTL bounds how often submissions recount the backlog.
+const backpressureCacheTTL = 2 * time.Second
+
+// BackpressureService protects the free-tier AI capacity from unbounded
+// backlog growth. The backlog is the number of queued and processing jobs,
+// not counting evaluations deferred out of the queue. Once it reaches
+// MaxPending, new evaluations are rejected or deferred until it drains. A nil
+// *BackpressureService never applies backpressure.
+type BackpressureService struct {
+	Jobs domain.JobRepository
+	// Deferred counts the evaluations held back from the queue (optional).
+	Deferred domain.MaintenanceRepository
+	// MaxPending is the backlog at which backpressure starts.
+	MaxPending int64
+	// Mode is BackpressureReject or BackpressureDefer.
+	Mode string
+	// RetryAfter is advertised to rejected clients.
+	RetryAfter time.Duration
+
+	now       func() time.Time
+	mu        sync.Mutex
+	backlog   int64
+	checkedAt time.Time
+}
+
+// NewBackpressureService constructs a BackpressureService. It returns nil
+// when maxPending is not positive. Unknown modes fall back to reject and a
+// non-positive retryAfter defaults to 30s.
+func NewBackpressureService(jobs domain.JobRepository, deferred domain.MaintenanceRepository, maxPending int64, mode string, retryAfter time.Duration) *BackpressureService {
+	if maxPending <= 0 {
+		return nil
+	}
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	if mode != BackpressureDefer {
+		mode = BackpressureReject
+	}
+	if retryAfter <= 0 {
+		retryAfter = 30 * time.Second
+	}
+	return &BackpressureService{Jobs: jobs, Deferred: deferred, MaxPending: maxPending, Mode: mode, RetryAfter: retryAfter, now: time.Now}
+}
+
+// Backlog returns the number of queued and processing jobs that are not
+// deferred.
+func (b *BackpressureService) Backlog(ctx domain.Context) (int64, error) {
+	var total int64
+	for _, st := range []domain.JobStatus{domain.JobQueued, domain.JobProcessing} {
+		n, err := b.Jobs.CountByStatus(ctx, st)
+		if err != nil {
+			return 0, fmt.Errorf("op=backpressure.count_%s: %w", st, err)
+		}
+		total += n
+	}
+	if b.Deferred != nil {
+		n, err := b.Deferred.CountDeferred(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("op=backpressure.count_deferred: %w", err)
+		}
+		total -= n
+	}
+	return max(total, 0), nil
+}
+
+// Full reports whether the backlog has reached MaxPending. The backlog is
+// recounted at most every few seconds; counting failures let the submission
+// through.
+func (b *BackpressureService) Full(ctx domain.Context) bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now := b.now(); now.Sub(b.checkedAt) >= backpressureCacheTTL {
+		n, err := b.Backlog(ctx)
+		if err != nil {
+			slog.Warn("backlog count failed; skipping backpressure", slog.Any("error", err))
+			return false
+		}
+		b.backlog, b.checkedAt = n, now
+	}
+	return b.backlog >= b.MaxPending
+}
+
+// Headroom returns how many evaluations can be enqueued before the backlog
+// reaches MaxPending, and false when no limit applies.
+func (b *BackpressureService) Headroom(ctx domain.Context) (int, bool) {
+	if b == nil {
+		return 0, false
+	}
+	n, err := b.Backlog(ctx)
+	if err != nil {
+		slog.Warn("backlog count failed; holding deferred evaluations", slog.Any("error", err))
+		return 0, true
+	}
+	return int(max(b.MaxPending-n, 0)), true
+}
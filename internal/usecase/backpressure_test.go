@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// expectBacklog makes the job counts report queued+processing-deferred jobs.
+func expectBacklog(jobRepo *mocks.MockJobRepository, repo *mocks.MockMaintenanceRepository, queued, processing, deferred int64) {
+	jobRepo.On("CountByStatus", mock.Anything, domain.JobQueued).Return(queued, nil).Once()
+	jobRepo.On("CountByStatus", mock.Anything, domain.JobProcessing).Return(processing, nil).Once()
+	repo.EXPECT().CountDeferred(mock.Anything).Return(deferred, nil).Once()
+}
+
+func TestNewBackpressureService(t *testing.T) {
+	assert.Nil(t, usecase.NewBackpressureService(nil, nil, 0, "defer", 0))
+	bp := usecase.NewBackpressureService(nil, nil, 10, "bogus", 0)
+	require.NotNil(t, bp)
+	assert.Equal(t, usecase.BackpressureReject, bp.Mode)
+	assert.Equal(t, 30*time.Second, bp.RetryAfter)
+
+	var disabled *usecase.BackpressureService
+	assert.False(t, disabled.Full(context.Background()))
+	_, limited := disabled.Headroom(context.Background())
+	assert.False(t, limited)
+}
+
+func TestBackpressureService_FullIsCached(t *testing.T) {
+	jobRepo, _, _ := setupMocks()
+	repo := mocks.NewMockMaintenanceRepository(t)
+	bp := usecase.NewBackpressureService(jobRepo, repo, 5, usecase.BackpressureReject, 0)
+
+	// Deferred evaluations are out of the queue and do not count.
+	expectBacklog(jobRepo, repo, 6, 1, 3)
+	assert.False(t, bp.Full(context.Background()))
+	assert.False(t, bp.Full(context.Background()), "recount is throttled")
+	jobRepo.AssertExpectations(t)
+}
+
+func TestBackpressureService_CountFailureLetsSubmissionsThrough(t *testing.T) {
+	jobRepo, _, _ := setupMocks()
+	jobRepo.On("CountByStatus", mock.Anything, domain.JobQueued).Return(int64(0), assert.AnError).Once()
+	bp := usecase.NewBackpressureService(jobRepo, nil, 1, usecase.BackpressureReject, 0)
+	assert.False(t, bp.Full(context.Background()))
+}
+
+func TestEvaluate_Enqueue_BacklogFullRejects(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	jobRepo.On("CountByStatus", mock.Anything, domain.JobQueued).Return(int64(8), nil).Once()
+	jobRepo.On("CountByStatus", mock.Anything, domain.JobProcessing).Return(int64(2), nil).Once()
+	svc.Backpressure = usecase.NewBackpressureService(jobRepo, nil, 10, usecase.BackpressureReject, time.Minute)
+
+	_, err := svc.Enqueue(context.Background(), "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.ErrorIs(t, err, domain.ErrBacklogFull)
+	jobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	queue.AssertNotCalled(t, "EnqueueEvaluate", mock.Anything, mock.Anything)
+}
+
+func TestEvaluate_Enqueue_BacklogFullDefers(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	repo := mocks.NewMockMaintenanceRepository(t)
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	svc.Maintenance = usecase.NewMaintenanceService(repo, nil, time.Minute)
+	svc.Backpressure = usecase.NewBackpressureService(jobRepo, repo, 10, usecase.BackpressureDefer, 0)
+	expectBacklog(jobRepo, repo, 12, 2, 4)
+	repo.EXPECT().CreateDeferred(mock.Anything, mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool { return p.CVID == "cv-1" })).Return("job-1", nil).Once()
+
+	id, err := svc.Enqueue(context.Background(), "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", id)
+	queue.AssertNotCalled(t, "EnqueueEvaluate", mock.Anything, mock.Anything)
+}
+
+func TestMaintenanceService_ReleaseBoundedByBacklog(t *testing.T) {
+	jobRepo, _, _ := setupMocks()
+	repo := mocks.NewMockMaintenanceRepository(t)
+	svc := usecase.NewMaintenanceService(repo, nil, time.Minute)
+	svc.Backpressure = usecase.NewBackpressureService(jobRepo, repo, 10, usecase.BackpressureDefer, 0)
+	ctx := context.Background()
+
+	// 8 in the queue leaves room for 2 of the deferred evaluations.
+	expectBacklog(jobRepo, repo, 15, 3, 10)
+	repo.EXPECT().ReleaseDeferred(mock.Anything, 2).Return(2, nil).Once()
+	released, err := svc.Release(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, released)
+
+	// A full backlog releases nothing.
+	expectBacklog(jobRepo, repo, 12, 0, 2)
+	released, err = svc.Release(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, released)
+}
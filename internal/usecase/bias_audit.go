@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/langdetect"
+	"go.opentelemetry.io/otel"
+)
+
+// Bias audit segment dimensions. Segments are derived from document features
+// only; protected attributes are never inferred or used.
+const (
+	BiasDimensionLanguage      = "language"
+	BiasDimensionCVLength      = "cv_length"
+	BiasDimensionProjectLength = "project_length"
+	BiasDimensionAnonymization = "anonymization"
+)
+
+// Values of the anonymization dimension. Jobs that kept no task cannot tell
+// which mode they ran in and are reported as unknown.
+const (
+	BiasAnonymized        = "anonymized"
+	BiasNotAnonymized     = "standard"
+	BiasAnonymizedUnknown = "unknown"
+)
+
+// Thresholds above which a segment's deviation from the overall mean is flagged.
+const (
+	biasCVMatchRateThreshold  = 0.1
+	biasProjectScoreThreshold = 1.0
+)
+
+// BiasAuditService aggregates evaluation scores by segment and compares each
+// segment with the overall mean and with the previous report.
+type BiasAuditService struct {
+	Repo domain.BiasAuditRepository
+	// Window is how far back each report looks.
+	Window time.Duration
+	// MinSegmentSize is the number of evaluations a segment needs before it can be flagged.
+	MinSegmentSize int
+	now            func() time.Time
+}
+
+// NewBiasAuditService constructs a BiasAuditService. Non-positive window and
+// minimum segment size default to 7 days and 20.
+func NewBiasAuditService(repo domain.BiasAuditRepository, window time.Duration, minSegmentSize int) BiasAuditService {
+	if window <= 0 {
+		window = 7 * 24 * time.Hour
+	}
+	if minSegmentSize <= 0 {
+		minSegmentSize = 20
+	}
+	return BiasAuditService{Repo: repo, Window: window, MinSegmentSize: minSegmentSize, now: time.Now}
+}
+
+// Latest returns the most recent stored report.
+func (s BiasAuditService) Latest(ctx domain.Context) (domain.BiasReport, error) {
+	return s.Repo.LatestReport(ctx)
+}
+
+// Generate builds a report over the last Window of evaluations and stores it.
+func (s BiasAuditService) Generate(ctx domain.Context) (domain.BiasReport, error) {
+	tr := otel.Tracer("usecase.bias_audit")
+	ctx, span := tr.Start(ctx, "BiasAuditService.Generate")
+	defer span.End()
+
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	end := now().UTC()
+	start := end.Add(-s.Window)
+	samples, err := s.Repo.ListSamples(ctx, start, end)
+	if err != nil {
+		return domain.BiasReport{}, fmt.Errorf("op=bias_audit.generate: %w", err)
+	}
+	prev, err := s.Repo.LatestReport(ctx)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		slog.Warn("bias audit could not load previous report; drift omitted", slog.Any("error", err))
+	}
+
+	rep := buildBiasReport(samples, prev, s.MinSegmentSize)
+	rep.GeneratedAt, rep.WindowStart, rep.WindowEnd = end, start, end
+	if err := s.Repo.SaveReport(ctx, rep); err != nil {
+		return domain.BiasReport{}, fmt.Errorf("op=bias_audit.generate: %w", err)
+	}
+	flagged := 0
+	for _, seg := range rep.Segments {
+		if seg.Flagged {
+			flagged++
+		}
+	}
+	slog.Info("bias audit report generated",
+		slog.Int("samples", rep.SampleCount),
+		slog.Int("segments", len(rep.Segments)),
+		slog.Int("flagged_segments", flagged))
+	return rep, nil
+}
+
+func buildBiasReport(samples []domain.EvaluationSample, prev domain.BiasReport, minSegmentSize int) domain.BiasReport {
+	rep := domain.BiasReport{SampleCount: len(samples)}
+	if len(samples) == 0 {
+		return rep
+	}
+	type acc struct {
+		n, nCV, nProj int
+		cv, proj      float64
+		dim, value    string
+	}
+	groups := map[string]*acc{}
+	add := func(dim, value string, s domain.EvaluationSample) {
+		k := dim + "\x00" + value
+		g, ok := groups[k]
+		if !ok {
+			g = &acc{dim: dim, value: value}
+			groups[k] = g
+		}
+		g.n++
+		if !s.ProjectOnly {
+			g.nCV++
+			g.cv += s.CVMatchRate
+		}
+		if !s.CVOnly {
+			g.nProj++
+			g.proj += s.ProjectScore
+		}
+	}
+	// Each score is averaged only over the evaluations that have it, so
+	// CV-only and project-only evaluations do not pull the other averages
+	// towards zero.
+	var totalCV, totalProj float64
+	var nCV, nProj int
+	for _, s := range samples {
+		if !s.ProjectOnly {
+			nCV++
+			totalCV += s.CVMatchRate
+			add(BiasDimensionLanguage, langdetect.Detect(s.CVExcerpt), s)
+			add(BiasDimensionCVLength, lengthBucket(s.CVLength), s)
+		}
+		if !s.CVOnly {
+			nProj++
+			totalProj += s.ProjectScore
+			add(BiasDimensionProjectLength, lengthBucket(s.ProjectLength), s)
+		}
+		add(BiasDimensionAnonymization, anonymizationMode(s.Anonymized), s)
+	}
+	rep.AvgCVMatchRate = mean(totalCV, nCV)
+	rep.AvgProjectScore = mean(totalProj, nProj)
+
+	previous := make(map[string]domain.BiasSegment, len(prev.Segments))
+	for _, seg := range prev.Segments {
+		previous[seg.Dimension+"\x00"+seg.Value] = seg
+	}
+	for k, g := range groups {
+		seg := domain.BiasSegment{
+			Dimension:       g.dim,
+			Value:           g.value,
+			Count:           g.n,
+			AvgCVMatchRate:  mean(g.cv, g.nCV),
+			AvgProjectScore: mean(g.proj, g.nProj),
+		}
+		p, hasPrev := previous[k]
+		if g.nCV > 0 {
+			seg.CVMatchRateDelta = round4(seg.AvgCVMatchRate - rep.AvgCVMatchRate)
+			if hasPrev {
+				cv := round4(seg.AvgCVMatchRate - p.AvgCVMatchRate)
+				seg.CVMatchRateDrift = &cv
+			}
+		}
+		if g.nProj > 0 {
+			seg.ProjectScoreDelta = round4(seg.AvgProjectScore - rep.AvgProjectScore)
+			if hasPrev {
+				proj := round4(seg.AvgProjectScore - p.AvgProjectScore)
+				seg.ProjectScoreDrift = &proj
+			}
+		}
+		seg.Flagged = seg.Count >= minSegmentSize &&
+			(math.Abs(seg.CVMatchRateDelta) >= biasCVMatchRateThreshold || math.Abs(seg.ProjectScoreDelta) >= biasProjectScoreThreshold)
+		rep.Segments = append(rep.Segments, seg)
+	}
+	sort.Slice(rep.Segments, func(i, j int) bool {
+		a, b := rep.Segments[i], rep.Segments[j]
+		if a.Dimension != b.Dimension {
+			return a.Dimension < b.Dimension
+		}
+		return a.Value < b.Value
+	})
+	return rep
+}
+
+// anonymizationMode names the mode an evaluation ran in.
+func anonymizationMode(anonymized *bool) string {
+	switch {
+	case anonymized == nil:
+		return BiasAnonymizedUnknown
+	case *anonymized:
+		return BiasAnonymized
+	default:
+		return BiasNotAnonymized
+	}
+}
+
+// lengthBucket groups document lengths (in characters) into coarse ranges.
+func lengthBucket(n int) string {
+	switch {
+	case n < 2000:
+		return "<2k"
+	case n < 5000:
+		return "2k-5k"
+	case n < 10000:
+		return "5k-10k"
+	default:
+		return ">=10k"
+	}
+}
+
+// mean returns total/n rounded to four decimals, or zero when n is zero.
+func mean(total float64, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return round4(total / float64(n))
+}
+
+func round4(f float64) float64 { return math.Round(f*1e4) / 1e4 }
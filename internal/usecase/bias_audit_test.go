@@ -0,0 +1,198 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+const (
+	englishCV    = "Backend engineer with five years of experience in Go and the design of APIs for payments."
+	indonesianCV = "Saya adalah pengembang backend dengan pengalaman di bidang pembayaran dan ini untuk sistem yang besar."
+)
+
+func findSegment(t *testing.T, rep domain.BiasReport, dim, value string) domain.BiasSegment {
+	t.Helper()
+	for _, s := range rep.Segments {
+		if s.Dimension == dim && s.Value == value {
+			return s
+		}
+	}
+	t.Fatalf("segment %s=%s not found", dim, value)
+	return domain.BiasSegment{}
+}
+
+func TestBiasAuditService_Generate(t *testing.T) {
+	repo := mocks.NewMockBiasAuditRepository(t)
+	svc := usecase.NewBiasAuditService(repo, 24*time.Hour, 2)
+
+	samples := []domain.EvaluationSample{
+		{JobID: "1", CVExcerpt: englishCV, CVLength: 1500, ProjectLength: 6000, CVMatchRate: 0.8, ProjectScore: 8},
+		{JobID: "2", CVExcerpt: englishCV, CVLength: 3000, ProjectLength: 6000, CVMatchRate: 0.8, ProjectScore: 8},
+		{JobID: "3", CVExcerpt: indonesianCV, CVLength: 3000, ProjectLength: 12000, CVMatchRate: 0.5, ProjectScore: 6},
+		{JobID: "4", CVExcerpt: indonesianCV, CVLength: 3000, ProjectLength: 12000, CVMatchRate: 0.5, ProjectScore: 6},
+		{JobID: "5", CVExcerpt: "Go, Kafka, PostgreSQL", CVLength: 100, ProjectLength: 500, CVMatchRate: 0.65, ProjectScore: 7},
+	}
+	prevDrift := 0.6
+	repo.EXPECT().ListSamples(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ domain.Context, from, to time.Time) ([]domain.EvaluationSample, error) {
+			assert.Equal(t, 24*time.Hour, to.Sub(from))
+			return samples, nil
+		}).Once()
+	repo.EXPECT().LatestReport(mock.Anything).Return(domain.BiasReport{Segments: []domain.BiasSegment{
+		{Dimension: usecase.BiasDimensionLanguage, Value: "id", AvgCVMatchRate: prevDrift, AvgProjectScore: 6},
+	}}, nil).Once()
+	var saved domain.BiasReport
+	repo.EXPECT().SaveReport(mock.Anything, mock.Anything).Run(func(_ domain.Context, r domain.BiasReport) { saved = r }).Return(nil).Once()
+
+	rep, err := svc.Generate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, saved, rep)
+	assert.Equal(t, 5, rep.SampleCount)
+	assert.InDelta(t, 0.65, rep.AvgCVMatchRate, 1e-9)
+	assert.InDelta(t, 7.0, rep.AvgProjectScore, 1e-9)
+
+	en := findSegment(t, rep, usecase.BiasDimensionLanguage, "en")
+	assert.Equal(t, 2, en.Count)
+	assert.InDelta(t, 0.15, en.CVMatchRateDelta, 1e-9)
+	assert.True(t, en.Flagged)
+	assert.Nil(t, en.CVMatchRateDrift)
+
+	id := findSegment(t, rep, usecase.BiasDimensionLanguage, "id")
+	assert.InDelta(t, -0.15, id.CVMatchRateDelta, 1e-9)
+	require.NotNil(t, id.CVMatchRateDrift)
+	assert.InDelta(t, -0.1, *id.CVMatchRateDrift, 1e-9)
+
+	unknown := findSegment(t, rep, usecase.BiasDimensionLanguage, "unknown")
+	assert.Equal(t, 1, unknown.Count)
+	assert.False(t, unknown.Flagged, "segments below the minimum size are never flagged")
+
+	assert.Equal(t, 3, findSegment(t, rep, usecase.BiasDimensionCVLength, "2k-5k").Count)
+	assert.Equal(t, 2, findSegment(t, rep, usecase.BiasDimensionProjectLength, ">=10k").Count)
+}
+
+func TestBiasAuditService_GenerateCVOnlyEvaluations(t *testing.T) {
+	repo := mocks.NewMockBiasAuditRepository(t)
+	svc := usecase.NewBiasAuditService(repo, 24*time.Hour, 1)
+
+	repo.EXPECT().ListSamples(mock.Anything, mock.Anything, mock.Anything).Return([]domain.EvaluationSample{
+		{JobID: "1", CVExcerpt: englishCV, CVLength: 3000, ProjectLength: 6000, CVMatchRate: 0.8, ProjectScore: 8},
+		{JobID: "2", CVExcerpt: englishCV, CVLength: 3000, CVMatchRate: 0.4, CVOnly: true},
+	}, nil).Once()
+	repo.EXPECT().LatestReport(mock.Anything).Return(domain.BiasReport{}, domain.ErrNotFound).Once()
+	repo.EXPECT().SaveReport(mock.Anything, mock.Anything).Return(nil).Once()
+
+	rep, err := svc.Generate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, rep.SampleCount)
+	assert.InDelta(t, 0.6, rep.AvgCVMatchRate, 1e-9)
+	assert.InDelta(t, 8.0, rep.AvgProjectScore, 1e-9, "CV-only evaluations have no project score to average")
+
+	en := findSegment(t, rep, usecase.BiasDimensionLanguage, "en")
+	assert.Equal(t, 2, en.Count)
+	assert.InDelta(t, 8.0, en.AvgProjectScore, 1e-9)
+	assert.Zero(t, en.ProjectScoreDelta)
+
+	// The CV-only evaluation has no project length to bucket.
+	assert.Equal(t, 1, findSegment(t, rep, usecase.BiasDimensionProjectLength, "5k-10k").Count)
+	for _, seg := range rep.Segments {
+		assert.NotEqual(t, "<2k", seg.Value, "segment %s", seg.Dimension)
+	}
+}
+
+func TestBiasAuditService_GenerateProjectOnlyEvaluations(t *testing.T) {
+	repo := mocks.NewMockBiasAuditRepository(t)
+	svc := usecase.NewBiasAuditService(repo, 24*time.Hour, 1)
+
+	repo.EXPECT().ListSamples(mock.Anything, mock.Anything, mock.Anything).Return([]domain.EvaluationSample{
+		{JobID: "1", CVExcerpt: englishCV, CVLength: 3000, ProjectLength: 6000, CVMatchRate: 0.8, ProjectScore: 8},
+		{JobID: "2", ProjectLength: 6000, ProjectScore: 6, ProjectOnly: true},
+	}, nil).Once()
+	repo.EXPECT().LatestReport(mock.Anything).Return(domain.BiasReport{}, domain.ErrNotFound).Once()
+	repo.EXPECT().SaveReport(mock.Anything, mock.Anything).Return(nil).Once()
+
+	rep, err := svc.Generate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, rep.SampleCount)
+	assert.InDelta(t, 0.8, rep.AvgCVMatchRate, 1e-9, "project-only evaluations have no CV match rate to average")
+	assert.InDelta(t, 7.0, rep.AvgProjectScore, 1e-9)
+
+	pl := findSegment(t, rep, usecase.BiasDimensionProjectLength, "5k-10k")
+	assert.Equal(t, 2, pl.Count)
+	assert.InDelta(t, 0.8, pl.AvgCVMatchRate, 1e-9)
+	assert.Zero(t, pl.CVMatchRateDelta)
+
+	// The project-only evaluation has no CV to detect a language or length in.
+	assert.Equal(t, 1, findSegment(t, rep, usecase.BiasDimensionLanguage, "en").Count)
+	assert.Equal(t, 1, findSegment(t, rep, usecase.BiasDimensionCVLength, "2k-5k").Count)
+	for _, seg := range rep.Segments {
+		if seg.Dimension == usecase.BiasDimensionLanguage || seg.Dimension == usecase.BiasDimensionCVLength {
+			assert.NotEqual(t, "unknown", seg.Value, "segment %s", seg.Dimension)
+			assert.NotEqual(t, "<2k", seg.Value, "segment %s", seg.Dimension)
+		}
+	}
+}
+
+func TestBiasAuditService_GenerateAnonymizationSegments(t *testing.T) {
+	repo := mocks.NewMockBiasAuditRepository(t)
+	svc := usecase.NewBiasAuditService(repo, 24*time.Hour, 2)
+
+	yes, no := true, false
+	repo.EXPECT().ListSamples(mock.Anything, mock.Anything, mock.Anything).Return([]domain.EvaluationSample{
+		{JobID: "1", CVExcerpt: englishCV, CVLength: 3000, ProjectLength: 6000, CVMatchRate: 0.5, ProjectScore: 6, Anonymized: &yes},
+		{JobID: "2", CVExcerpt: englishCV, CVLength: 3000, ProjectLength: 6000, CVMatchRate: 0.5, ProjectScore: 6, Anonymized: &yes},
+		{JobID: "3", CVExcerpt: englishCV, CVLength: 3000, ProjectLength: 6000, CVMatchRate: 0.8, ProjectScore: 8, Anonymized: &no},
+		{JobID: "4", CVExcerpt: englishCV, CVLength: 3000, ProjectLength: 6000, CVMatchRate: 0.8, ProjectScore: 8, Anonymized: &no},
+		{JobID: "5", CVExcerpt: englishCV, CVLength: 3000, ProjectLength: 6000, CVMatchRate: 0.65, ProjectScore: 7},
+	}, nil).Once()
+	repo.EXPECT().LatestReport(mock.Anything).Return(domain.BiasReport{}, domain.ErrNotFound).Once()
+	repo.EXPECT().SaveReport(mock.Anything, mock.Anything).Return(nil).Once()
+
+	rep, err := svc.Generate(context.Background())
+	require.NoError(t, err)
+
+	anon := findSegment(t, rep, usecase.BiasDimensionAnonymization, usecase.BiasAnonymized)
+	assert.Equal(t, 2, anon.Count)
+	assert.InDelta(t, -0.15, anon.CVMatchRateDelta, 1e-9)
+	assert.InDelta(t, -1.0, anon.ProjectScoreDelta, 1e-9)
+	assert.True(t, anon.Flagged)
+
+	std := findSegment(t, rep, usecase.BiasDimensionAnonymization, usecase.BiasNotAnonymized)
+	assert.Equal(t, 2, std.Count)
+	assert.InDelta(t, 0.15, std.CVMatchRateDelta, 1e-9)
+	assert.True(t, std.Flagged)
+
+	// Jobs that kept no task cannot tell which mode they ran in.
+	unknown := findSegment(t, rep, usecase.BiasDimensionAnonymization, usecase.BiasAnonymizedUnknown)
+	assert.Equal(t, 1, unknown.Count)
+	assert.False(t, unknown.Flagged)
+}
+
+func TestBiasAuditService_GenerateErrors(t *testing.T) {
+	repo := mocks.NewMockBiasAuditRepository(t)
+	svc := usecase.NewBiasAuditService(repo, 0, 0)
+	assert.Equal(t, 7*24*time.Hour, svc.Window)
+	assert.Equal(t, 20, svc.MinSegmentSize)
+
+	repo.EXPECT().ListSamples(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+	_, err := svc.Generate(context.Background())
+	assert.ErrorContains(t, err, "op=bias_audit.generate")
+
+	// No previous report and no samples still produce an (empty) report.
+	repo.EXPECT().ListSamples(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	repo.EXPECT().LatestReport(mock.Anything).Return(domain.BiasReport{}, domain.ErrNotFound).Once()
+	repo.EXPECT().SaveReport(mock.Anything, mock.Anything).Return(nil).Once()
+	rep, err := svc.Generate(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, rep.SampleCount)
+	assert.Empty(t, rep.Segments)
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+	"go.opentelemetry.io/otel"
+)
+
+// Limits of a generated candidate summary.
+const (
+	candidateSummaryMaxTokens  = 600
+	candidateSummaryMaxBullets = 5
+)
+
+const candidateSummarySystemPrompt = `You write recruiter-facing candidate summaries from evaluation results. Use only the facts in the evaluation; do not invent experience, employers or scores. Return only a JSON object, no prose or code fences.`
+
+// CandidateSummaryService generates recruiter-facing summaries from stored
+// evaluation results and caches them per result version.
+type CandidateSummaryService struct {
+	Jobs      domain.JobRepository
+	Results   domain.ResultRepository
+	Summaries domain.CandidateSummaryRepository
+	AI        domain.AIClient
+
+	now func() time.Time
+}
+
+// NewCandidateSummaryService constructs a CandidateSummaryService.
+func NewCandidateSummaryService(j domain.JobRepository, r domain.ResultRepository, s domain.CandidateSummaryRepository, ai domain.AIClient) CandidateSummaryService {
+	return CandidateSummaryService{Jobs: j, Results: r, Summaries: s, AI: ai, now: time.Now}
+}
+
+// Summarize returns the summary of the result of job id. A cached summary is
+// returned while the result is unchanged; otherwise a new one is generated and
+// cached. The boolean reports whether the summary came from the cache.
+func (s CandidateSummaryService) Summarize(ctx domain.Context, id string) (domain.CandidateSummary, bool, error) {
+	tr := otel.Tracer("usecase.candidate_summary")
+	ctx, span := tr.Start(ctx, "CandidateSummaryService.Summarize")
+	defer span.End()
+
+	lg := obsctx.LoggerFromContext(ctx)
+	job, err := s.Jobs.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.CandidateSummary{}, false, fmt.Errorf("%w: job not found", domain.ErrNotFound)
+		}
+		return domain.CandidateSummary{}, false, fmt.Errorf("op=candidate_summary.summarize: %w", err)
+	}
+	if job.Status != domain.JobCompleted {
+		return domain.CandidateSummary{}, false, fmt.Errorf("%w: job is %s, summaries need a completed evaluation", domain.ErrConflict, job.Status)
+	}
+	res, err := s.Results.GetByJobID(ctx, id)
+	if err != nil {
+		return domain.CandidateSummary{}, false, fmt.Errorf("op=candidate_summary.summarize: %w", err)
+	}
+	if res.Encryption != nil {
+		return domain.CandidateSummary{}, false, fmt.Errorf("%w: the feedback is encrypted for the tenant and cannot be summarized", domain.ErrConflict)
+	}
+	evaluation := completedEnvelope(id, res)["result"]
+	version := makeETag(evaluation)
+
+	cached, err := s.Summaries.Get(ctx, id)
+	switch {
+	case err == nil && cached.ResultVersion == version:
+		lg.Info("candidate summary cache hit", slog.String("job_id", id))
+		return cached, true, nil
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		lg.Warn("candidate summary cache read failed; regenerating", slog.String("job_id", id), slog.Any("error", err))
+	}
+
+	b, _ := json.Marshal(evaluation)
+	prompt := fmt.Sprintf(`Evaluation of the candidate:
+%s
+
+Write a JSON object with:
+{
+  "summary": "One paragraph (3-5 sentences) for a recruiter: fit for the role, key evidence and a recommendation",
+  "strengths": ["Short bullet", "..."],
+  "risks": ["Short bullet about a gap or concern", "..."]
+}
+Give at most %d strengths and %d risks. Fields that are absent from the evaluation were not assessed; do not comment on them.`, b, candidateSummaryMaxBullets, candidateSummaryMaxBullets)
+	raw, err := s.AI.ChatJSONWithRetry(ctx, candidateSummarySystemPrompt, prompt, candidateSummaryMaxTokens)
+	if err != nil {
+		lg.Error("candidate summary generation failed", slog.String("job_id", id), slog.Any("error", err))
+		return domain.CandidateSummary{}, false, fmt.Errorf("op=candidate_summary.generate: %w", err)
+	}
+	if cleaned, err := s.AI.CleanCoTResponse(ctx, raw); err == nil {
+		raw = cleaned
+	}
+	sum, err := parseCandidateSummary(raw)
+	if err != nil {
+		lg.Error("candidate summary response invalid", slog.String("job_id", id), slog.Any("error", err))
+		return domain.CandidateSummary{}, false, err
+	}
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	sum.JobID, sum.ResultVersion, sum.CreatedAt = id, version, now().UTC()
+	if err := s.Summaries.Save(ctx, sum); err != nil {
+		lg.Warn("candidate summary cache write failed", slog.String("job_id", id), slog.Any("error", err))
+	}
+	lg.Info("candidate summary generated", slog.String("job_id", id),
+		slog.Int("strengths", len(sum.Strengths)), slog.Int("risks", len(sum.Risks)))
+	return sum, false, nil
+}
+
+// parseCandidateSummary extracts the summary JSON object from a model response.
+func parseCandidateSummary(raw string) (domain.CandidateSummary, error) {
+	start, end := strings.Index(raw, "{"), strings.LastIndex(raw, "}")
+	if start < 0 || end < start {
+		return domain.CandidateSummary{}, fmt.Errorf("%w: candidate summary is not a JSON object", domain.ErrSchemaInvalid)
+	}
+	var data struct {
+		Summary   string   `json:"summary"`
+		Strengths []string `json:"strengths"`
+		Risks     []string `json:"risks"`
+	}
+	if err := json.Unmarshal([]byte(raw[start:end+1]), &data); err != nil {
+		return domain.CandidateSummary{}, fmt.Errorf("%w: candidate summary: %v", domain.ErrSchemaInvalid, err)
+	}
+	sum := domain.CandidateSummary{
+		Summary:   strings.TrimSpace(data.Summary),
+		Strengths: summaryBullets(data.Strengths),
+		Risks:     summaryBullets(data.Risks),
+	}
+	if sum.Summary == "" {
+		return domain.CandidateSummary{}, fmt.Errorf("%w: candidate summary is empty", domain.ErrSchemaInvalid)
+	}
+	return sum, nil
+}
+
+// summaryBullets trims bullets, drops empty ones and keeps at most
+// candidateSummaryMaxBullets.
+func summaryBullets(in []string) []string {
+	out := make([]string, 0, len(in))
+	for _, b := range in {
+		if b = strings.TrimSpace(b); b != "" && len(out) < candidateSummaryMaxBullets {
+			out = append(out, b)
+		}
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestCandidateSummaryService_CachesPerResultVersion(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	results := mocks.NewMockResultRepository(t)
+	summaries := mocks.NewMockCandidateSummaryRepository(t)
+	ai := mocks.NewMockAIClient(t)
+	svc := usecase.NewCandidateSummaryService(jobs, results, summaries, ai)
+	ctx := context.Background()
+
+	jobs.EXPECT().Get(mock.Anything, "job-1").Return(domain.Job{ID: "job-1", Status: domain.JobCompleted}, nil)
+	res := domain.Result{JobID: "job-1", CVMatchRate: 0.8, CVFeedback: "strong Go", ProjectScore: 7, ProjectFeedback: "clean", OverallSummary: "hire"}
+	results.EXPECT().GetByJobID(mock.Anything, "job-1").Return(res, nil).Twice()
+
+	// Cache miss: the summary is generated and stored.
+	summaries.EXPECT().Get(mock.Anything, "job-1").Return(domain.CandidateSummary{}, domain.ErrNotFound).Once()
+	ai.EXPECT().ChatJSONWithRetry(mock.Anything, mock.Anything, mock.MatchedBy(func(p string) bool {
+		return assert.Contains(t, p, `"cv_feedback":"strong Go"`)
+	}), 600).Return("```json\n{\"summary\":\" Strong fit. \",\"strengths\":[\"Go\",\" \",\"a\",\"b\",\"c\",\"d\",\"e\"],\"risks\":[\"cloud\"]}\n```", nil).Once()
+	ai.EXPECT().CleanCoTResponse(mock.Anything, mock.Anything).RunAndReturn(func(_ domain.Context, s string) (string, error) { return s, nil }).Once()
+	var saved domain.CandidateSummary
+	summaries.EXPECT().Save(mock.Anything, mock.Anything).Run(func(_ domain.Context, s domain.CandidateSummary) { saved = s }).Return(nil).Once()
+
+	sum, cached, err := svc.Summarize(ctx, "job-1")
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, "Strong fit.", sum.Summary)
+	assert.Equal(t, []string{"Go", "a", "b", "c", "d"}, sum.Strengths)
+	assert.Equal(t, []string{"cloud"}, sum.Risks)
+	assert.NotEmpty(t, sum.ResultVersion)
+	assert.Equal(t, sum, saved)
+
+	// Same result version: served from the cache without calling the AI.
+	summaries.EXPECT().Get(mock.Anything, "job-1").Return(saved, nil).Once()
+	sum, cached, err = svc.Summarize(ctx, "job-1")
+	require.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, saved, sum)
+}
+
+func TestCandidateSummaryService_RegeneratesWhenResultChanges(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	results := mocks.NewMockResultRepository(t)
+	summaries := mocks.NewMockCandidateSummaryRepository(t)
+	ai := mocks.NewMockAIClient(t)
+	svc := usecase.NewCandidateSummaryService(jobs, results, summaries, ai)
+
+	jobs.EXPECT().Get(mock.Anything, "job-1").Return(domain.Job{ID: "job-1", Status: domain.JobCompleted}, nil).Once()
+	results.EXPECT().GetByJobID(mock.Anything, "job-1").Return(domain.Result{JobID: "job-1", CVMatchRate: 0.5}, nil).Once()
+	summaries.EXPECT().Get(mock.Anything, "job-1").Return(domain.CandidateSummary{JobID: "job-1", ResultVersion: "stale", Summary: "old"}, nil).Once()
+	ai.EXPECT().ChatJSONWithRetry(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(`{"summary":"new"}`, nil).Once()
+	ai.EXPECT().CleanCoTResponse(mock.Anything, mock.Anything).Return(`{"summary":"new"}`, nil).Once()
+	summaries.EXPECT().Save(mock.Anything, mock.Anything).Return(assert.AnError).Once()
+
+	sum, cached, err := svc.Summarize(context.Background(), "job-1")
+	require.NoError(t, err, "cache write failures are not fatal")
+	assert.False(t, cached)
+	assert.Equal(t, "new", sum.Summary)
+	assert.NotEqual(t, "stale", sum.ResultVersion)
+}
+
+func TestCandidateSummaryService_Errors(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	results := mocks.NewMockResultRepository(t)
+	summaries := mocks.NewMockCandidateSummaryRepository(t)
+	ai := mocks.NewMockAIClient(t)
+	svc := usecase.NewCandidateSummaryService(jobs, results, summaries, ai)
+	ctx := context.Background()
+
+	jobs.EXPECT().Get(mock.Anything, "missing").Return(domain.Job{}, domain.ErrNotFound).Once()
+	_, _, err := svc.Summarize(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	jobs.EXPECT().Get(mock.Anything, "queued").Return(domain.Job{ID: "queued", Status: domain.JobQueued}, nil).Once()
+	_, _, err = svc.Summarize(ctx, "queued")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	jobs.EXPECT().Get(mock.Anything, "job-1").Return(domain.Job{ID: "job-1", Status: domain.JobCompleted}, nil).Once()
+	results.EXPECT().GetByJobID(mock.Anything, "job-1").Return(domain.Result{JobID: "job-1"}, nil).Once()
+	summaries.EXPECT().Get(mock.Anything, "job-1").Return(domain.CandidateSummary{}, domain.ErrNotFound).Once()
+	ai.EXPECT().ChatJSONWithRetry(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(`{"strengths":["x"]}`, nil).Once()
+	ai.EXPECT().CleanCoTResponse(mock.Anything, mock.Anything).Return(`{"strengths":["x"]}`, nil).Once()
+	_, _, err = svc.Summarize(ctx, "job-1")
+	assert.ErrorIs(t, err, domain.ErrSchemaInvalid)
+}
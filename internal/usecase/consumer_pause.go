@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// ConsumerTopicState is the pause state of one queue topic. Pause is nil
+// while the topic is consumed.
+type ConsumerTopicState struct {
+	Topic string
+	Pause *domain.ConsumerPause
+}
+
+// ConsumerPauseService pauses and resumes the consumption of queue topics
+// and keeps this process's view of the paused topics in sync with the shared
+// repository. A nil *ConsumerPauseService reports every topic as consumed.
+type ConsumerPauseService struct {
+	Repo domain.ConsumerPauseRepository
+	// Topics are the topics that may be paused.
+	Topics []string
+
+	now    func() time.Time
+	mu     sync.RWMutex
+	paused map[string]domain.ConsumerPause
+}
+
+// NewConsumerPauseService constructs a ConsumerPauseService for topics; no
+// topic is paused until Sync or Pause.
+func NewConsumerPauseService(repo domain.ConsumerPauseRepository, topics ...string) *ConsumerPauseService {
+	return &ConsumerPauseService{Repo: repo, Topics: topics, now: time.Now, paused: map[string]domain.ConsumerPause{}}
+}
+
+// Paused reports whether topic was paused at the last sync.
+func (s *ConsumerPauseService) Paused(topic string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.paused[topic]
+	return ok
+}
+
+// States returns the pause state of every topic.
+func (s *ConsumerPauseService) States() []ConsumerTopicState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]ConsumerTopicState, 0, len(s.Topics))
+	for _, t := range s.Topics {
+		st := ConsumerTopicState{Topic: t}
+		if p, ok := s.paused[t]; ok {
+			st.Pause = &p
+		}
+		out = append(out, st)
+	}
+	return out
+}
+
+// Sync reloads the paused topics from the repository.
+func (s *ConsumerPauseService) Sync(ctx domain.Context) error {
+	pauses, err := s.Repo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("op=consumer_pause.sync: %w", err)
+	}
+	paused := make(map[string]domain.ConsumerPause, len(pauses))
+	for _, p := range pauses {
+		paused[p.Topic] = p
+	}
+	s.mu.Lock()
+	s.paused = paused
+	s.mu.Unlock()
+	return nil
+}
+
+// Pause stops every worker from polling topic. Workers finish the jobs they
+// already fetched and pick the pause up within their sync interval.
+func (s *ConsumerPauseService) Pause(ctx domain.Context, topic, actor, reason string) (domain.ConsumerPause, error) {
+	if err := s.checkTopic(topic); err != nil {
+		return domain.ConsumerPause{}, err
+	}
+	p := domain.ConsumerPause{Topic: topic, Reason: strings.TrimSpace(reason), PausedBy: actor, PausedAt: s.now().UTC()}
+	if err := s.Repo.Pause(ctx, p); err != nil {
+		return domain.ConsumerPause{}, fmt.Errorf("op=consumer_pause.pause: %w", err)
+	}
+	s.mu.Lock()
+	s.paused[topic] = p
+	s.mu.Unlock()
+	slog.Warn("queue consumption paused", slog.String("topic", topic), slog.String("actor", actor), slog.String("reason", p.Reason))
+	return p, nil
+}
+
+// Resume lets the workers poll topic again and reports whether it was
+// paused.
+func (s *ConsumerPauseService) Resume(ctx domain.Context, topic, actor string) (bool, error) {
+	if err := s.checkTopic(topic); err != nil {
+		return false, err
+	}
+	resumed, err := s.Repo.Resume(ctx, topic)
+	if err != nil {
+		return false, fmt.Errorf("op=consumer_pause.resume: %w", err)
+	}
+	s.mu.Lock()
+	delete(s.paused, topic)
+	s.mu.Unlock()
+	if resumed {
+		slog.Info("queue consumption resumed", slog.String("topic", topic), slog.String("actor", actor))
+	}
+	return resumed, nil
+}
+
+func (s *ConsumerPauseService) checkTopic(topic string) error {
+	if !slices.Contains(s.Topics, topic) {
+		return fmt.Errorf("%w: unknown topic %q (one of %s)", domain.ErrInvalidArgument, topic, strings.Join(s.Topics, ", "))
+	}
+	return nil
+}
+
+// Run syncs the paused topics every interval until ctx is done.
+func (s *ConsumerPauseService) Run(ctx context.Context, interval time.Duration) {
+	if s == nil || interval <= 0 {
+		return
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := s.Sync(ctx); err != nil {
+				slog.Warn("consumer pause sync failed", slog.Any("error", err))
+			}
+		}
+	}
+}
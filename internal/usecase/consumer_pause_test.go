@@ -0,0 +1,73 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// memConsumerPauses is an in-memory domain.ConsumerPauseRepository.
+type memConsumerPauses struct{ pauses map[string]domain.ConsumerPause }
+
+func (m *memConsumerPauses) List(domain.Context) ([]domain.ConsumerPause, error) {
+	out := make([]domain.ConsumerPause, 0, len(m.pauses))
+	for _, p := range m.pauses {
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (m *memConsumerPauses) Pause(_ domain.Context, p domain.ConsumerPause) error {
+	m.pauses[p.Topic] = p
+	return nil
+}
+
+func (m *memConsumerPauses) Resume(_ domain.Context, topic string) (bool, error) {
+	_, ok := m.pauses[topic]
+	delete(m.pauses, topic)
+	return ok, nil
+}
+
+func TestConsumerPauseService_PauseResumeAndSync(t *testing.T) {
+	ctx := context.Background()
+	repo := &memConsumerPauses{pauses: map[string]domain.ConsumerPause{}}
+	server := usecase.NewConsumerPauseService(repo, "evaluate-jobs", "dlq-jobs")
+	worker := usecase.NewConsumerPauseService(repo, "evaluate-jobs", "dlq-jobs")
+
+	_, err := server.Pause(ctx, "unknown", "admin", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	p, err := server.Pause(ctx, "evaluate-jobs", "admin", " provider outage ")
+	require.NoError(t, err)
+	assert.Equal(t, "provider outage", p.Reason)
+	assert.True(t, server.Paused("evaluate-jobs"))
+	assert.False(t, server.Paused("dlq-jobs"))
+
+	// Other processes see the pause after their next sync.
+	assert.False(t, worker.Paused("evaluate-jobs"))
+	require.NoError(t, worker.Sync(ctx))
+	assert.True(t, worker.Paused("evaluate-jobs"))
+
+	states := server.States()
+	require.Len(t, states, 2)
+	require.NotNil(t, states[0].Pause)
+	assert.Equal(t, "admin", states[0].Pause.PausedBy)
+	assert.Nil(t, states[1].Pause)
+
+	resumed, err := server.Resume(ctx, "evaluate-jobs", "admin")
+	require.NoError(t, err)
+	assert.True(t, resumed)
+	resumed, err = server.Resume(ctx, "evaluate-jobs", "admin")
+	require.NoError(t, err)
+	assert.False(t, resumed)
+	require.NoError(t, worker.Sync(ctx))
+	assert.False(t, worker.Paused("evaluate-jobs"))
+
+	var none *usecase.ConsumerPauseService
+	assert.False(t, none.Paused("evaluate-jobs"))
+}
@@ -2,12 +2,14 @@
 package usecase
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"log/slog"
 	"time"
 
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
 	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
 	"go.opentelemetry.io/otel"
@@ -20,6 +22,26 @@ type EvaluateService struct {
 	Uploads domain.UploadRepository
 	AI      domain.AIClient
 	Vector  VectorDBHealthChecker
+	// Maintenance rejects or defers new evaluations during maintenance (optional).
+	Maintenance *MaintenanceService
+	// Tenants resolves per-tenant overrides from the request's API key (optional).
+	Tenants *TenantService
+	// Backpressure rejects or defers new evaluations while the backlog is full (optional).
+	Backpressure *BackpressureService
+	// Saturation rejects new evaluations while every AI model is rate
+	// limited (optional).
+	Saturation *AISaturationService
+	// Outbox creates jobs and their tasks atomically and publishes the tasks
+	// asynchronously (optional; without it the task is published directly).
+	Outbox *OutboxService
+	// Quota enforces the tenants' daily quotas (optional).
+	Quota *QuotaService
+	// JobTTL is how long after submission a job expires unless the request
+	// overrides it; 0 never expires jobs.
+	JobTTL time.Duration
+	// SLA is carried by every job as the time its evaluation may take; 0
+	// leaves it to the worker's default.
+	SLA time.Duration
 }
 
 // VectorDBHealthChecker interface for checking vector database health
@@ -46,6 +68,17 @@ func NewEvaluateServiceWithHealthChecks(j domain.JobRepository, q domain.Queue,
 }
 
 // Enqueue validates inputs, creates a job, and enqueues the evaluation task.
+// An empty projectID requests a CV-only evaluation and an empty cvID a
+// project-only one; at least one of them is required.
+// The settings of the tenant owning the API key in ctx are resolved here: an
+// empty scoringRubric falls back to the tenant's rubric template, then to the
+// default rubric, and the tenant's overrides travel with the task. Scoring
+// weight overrides in ctx are validated against the rubric schema. While the
+// backlog is full, evaluations are rejected with ErrBacklogFull or deferred;
+// while every AI model is rate limited, they are rejected with
+// ErrAISaturated wrapped in a RetryAfterError.
+// With an Outbox, the job and its task are stored in one transaction and the
+// task is published by the outbox relay.
 func (s EvaluateService) Enqueue(ctx domain.Context, cvID, projectID, jobDesc, studyCase, scoringRubric, idemKey string) (string, error) {
 	tr := otel.Tracer("usecase.evaluate")
 	ctx, span := tr.Start(ctx, "EvaluateService.Enqueue")
@@ -58,9 +91,45 @@ func (s EvaluateService) Enqueue(ctx domain.Context, cvID, projectID, jobDesc, s
 		slog.String("idempotency_key", idemKey),
 		slog.String("request_id", obsctx.RequestIDFromContext(ctx)))
 
-	if cvID == "" || projectID == "" {
-		lg.Error("enqueue evaluate missing ids", slog.String("cv_id", cvID), slog.String("project_id", projectID))
-		return "", fmt.Errorf("%w: ids required", domain.ErrInvalidArgument)
+	if cvID == "" && projectID == "" {
+		lg.Error("enqueue evaluate missing ids")
+		return "", fmt.Errorf("%w: cv_id or project_id required", domain.ErrInvalidArgument)
+	}
+	// Without a project the CV is evaluated against the job description only;
+	// without a CV the project is graded against the study case only.
+	cvOnly, projectOnly := projectID == "", cvID == ""
+	if cvOnly {
+		studyCase = ""
+	}
+	if projectOnly {
+		jobDesc = ""
+	}
+	if err := ValidateSamplingParams(domain.SamplingParamsFrom(ctx)); err != nil {
+		lg.Error("enqueue evaluate invalid sampling parameters", slog.Any("error", err))
+		return "", err
+	}
+	labels, err := ValidateJobLabels(domain.JobLabelsFrom(ctx))
+	if err != nil {
+		lg.Error("enqueue evaluate invalid job labels", slog.Any("error", err))
+		return "", err
+	}
+	priority := domain.JobPriorityFrom(ctx)
+	if priority != "" && priority != domain.PriorityBatch && priority != domain.PriorityInteractive {
+		return "", fmt.Errorf("%w: priority must be %s or %s", domain.ErrInvalidArgument, domain.PriorityBatch, domain.PriorityInteractive)
+	}
+	format := domain.FeedbackFormatFrom(ctx)
+	if format != "" && format != domain.FeedbackFormatText && format != domain.FeedbackFormatMarkdown {
+		return "", fmt.Errorf("%w: format must be %s or %s", domain.ErrInvalidArgument, domain.FeedbackFormatText, domain.FeedbackFormatMarkdown)
+	}
+	if format == domain.FeedbackFormatText {
+		format = ""
+	}
+	lang := domain.FeedbackLanguageFrom(ctx)
+	if _, ok := domain.FeedbackLanguages[lang]; lang != "" && !ok {
+		return "", fmt.Errorf("%w: unsupported feedback_language %q", domain.ErrInvalidArgument, lang)
+	}
+	if lang == "en" {
+		lang = ""
 	}
 	// Idempotency: if provided, try to find an existing job
 	if idemKey != "" {
@@ -69,23 +138,125 @@ func (s EvaluateService) Enqueue(ctx domain.Context, cvID, projectID, jobDesc, s
 			return j.ID, nil
 		}
 	}
+	mode := s.Maintenance.State()
+	if mode.Mode == domain.MaintenanceReject {
+		lg.Info("enqueue evaluate rejected during maintenance", slog.String("cv_id", cvID), slog.String("project_id", projectID))
+		if mode.Message != "" {
+			return "", fmt.Errorf("%w: %s", domain.ErrMaintenance, mode.Message)
+		}
+		return "", domain.ErrMaintenance
+	}
+	// A full backlog defers through the maintenance store when one is
+	// available and rejects otherwise.
+	backlogFull := s.Backpressure.Full(ctx)
+	if backlogFull && (s.Backpressure.Mode == BackpressureReject || s.Maintenance == nil) {
+		lg.Info("enqueue evaluate rejected: backlog full", slog.String("cv_id", cvID), slog.String("project_id", projectID))
+		return "", fmt.Errorf("%w: retry later", domain.ErrBacklogFull)
+	}
+	// Jobs submitted while every model is blocked would only wait in the
+	// queue; clients are told when to retry instead.
+	if wait, saturated := s.Saturation.Saturated(ctx); saturated {
+		lg.Info("enqueue evaluate rejected: ai models saturated", slog.String("cv_id", cvID), slog.String("project_id", projectID), slog.Duration("retry_after", wait))
+		return "", &domain.RetryAfterError{Err: fmt.Errorf("%w: all AI models are rate limited", domain.ErrAISaturated), After: wait}
+	}
+	tenant, _, err := s.Tenants.Resolve(ctx, domain.TenantAPIKey(ctx))
+	if err != nil {
+		lg.Error("enqueue evaluate failed to resolve tenant", slog.Any("error", err))
+		return "", err
+	}
+	if scoringRubric == "" {
+		scoringRubric = tenant.RubricTemplate
+	}
+	if scoringRubric == "" {
+		scoringRubric = config.GetDefaultScoringRubric()
+	}
+	// Weights are checked against the rubric the job is evaluated with.
+	var rubric []domain.RubricSection
+	weights := domain.ScoringWeightsFrom(ctx)
+	if len(weights) > 0 {
+		rubric = ActiveRubric(scoringRubric)
+		if weights, err = ValidateScoringWeights(weights, rubric); err != nil {
+			lg.Error("enqueue evaluate invalid scoring weights", slog.Any("error", err))
+			return "", err
+		}
+	}
+	quota, err := s.Quota.Consume(ctx, tenant)
+	reportQuotaStatus(ctx, quota)
+	if err != nil {
+		lg.Info("enqueue evaluate rejected by tenant quota", slog.String("tenant_id", tenant.TenantID), slog.Any("error", err))
+		return "", err
+	}
+	// A submission that ends up creating no job gives its evaluation back,
+	// even when the client is gone.
+	refundQuota := func() {
+		st, err := s.Quota.Refund(context.WithoutCancel(ctx), tenant, quota)
+		if err != nil {
+			lg.Warn("enqueue evaluate failed to refund tenant quota", slog.String("tenant_id", tenant.TenantID), slog.Any("error", err))
+			return
+		}
+		reportQuotaStatus(ctx, st)
+	}
 	// Create job
-	j := domain.Job{Status: domain.JobQueued, CVID: cvID, ProjectID: projectID, CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}
+	requestID := obsctx.RequestIDFromContext(ctx)
+	j := domain.Job{Status: domain.JobQueued, CVID: cvID, ProjectID: projectID, RequestID: requestID, Metadata: labels.Metadata, Tags: labels.Tags, CreatedAt: time.Now().UTC(), UpdatedAt: time.Now().UTC()}
 	if idemKey != "" {
 		j.IdemKey = &idemKey
 	}
+	ttl := domain.JobTTLFrom(ctx)
+	if ttl <= 0 {
+		ttl = s.JobTTL
+	}
+	if ttl > 0 {
+		expiresAt := j.CreatedAt.Add(ttl)
+		j.ExpiresAt = &expiresAt
+	}
+	// The task propagates request_id to the background worker
+	payload := domain.EvaluateTaskPayload{CVID: cvID, ProjectID: projectID, JobDescription: jobDesc, StudyCaseBrief: studyCase, ScoringRubric: scoringRubric, RequestID: requestID, AllowPaidFallback: domain.PaidFallbackOptedIn(ctx), TenantID: tenant.TenantID, Overrides: tenant.Overrides, CVOnly: cvOnly, ProjectOnly: projectOnly, ScoringWeights: weights, Rubric: rubric, SLA: s.SLA, Priority: priority, ResultPublicKey: tenant.ResultPublicKey, FeedbackFormat: format, FeedbackLanguage: lang}
+	if p := domain.OpenRouterProviderPrefsFrom(ctx); !p.IsZero() {
+		payload.Overrides.OpenRouterProvider = &p
+	}
+	// Request sampling parameters take precedence over the tenant's.
+	if p := domain.SamplingParamsFrom(ctx); !p.IsZero() {
+		merged := p
+		if tenant.Overrides.Sampling != nil {
+			merged = tenant.Overrides.Sampling.Merge(p)
+		}
+		payload.Overrides.Sampling = &merged
+	}
+	if mode.Mode == domain.MaintenanceDefer || backlogFull {
+		// The job stays queued; it is released to the outbox once
+		// maintenance ends and the backlog has room.
+		jobID, err := s.Maintenance.Defer(ctx, j, payload)
+		if err != nil {
+			lg.Error("enqueue evaluate failed to defer", slog.Any("error", err), slog.String("cv_id", cvID), slog.String("project_id", projectID))
+			refundQuota()
+			return "", err
+		}
+		lg.Info("enqueue evaluate deferred", slog.String("job_id", jobID), slog.Bool("backlog_full", backlogFull))
+		return jobID, nil
+	}
+	if s.Outbox != nil {
+		jobID, err := s.Outbox.CreateJob(ctx, j, payload)
+		if err != nil {
+			lg.Error("enqueue evaluate failed to create job", slog.Any("error", err), slog.String("cv_id", cvID), slog.String("project_id", projectID))
+			refundQuota()
+			return "", err
+		}
+		lg.Info("enqueue evaluate job created in outbox", slog.String("job_id", jobID), slog.String("cv_id", cvID), slog.String("project_id", projectID))
+		return jobID, nil
+	}
 	jobID, err := s.Jobs.Create(ctx, j)
 	if err != nil {
 		lg.Error("enqueue evaluate failed to create job", slog.Any("error", err), slog.String("cv_id", cvID), slog.String("project_id", projectID))
+		refundQuota()
 		return "", err
 	}
 	lg.Info("enqueue evaluate job created", slog.String("job_id", jobID), slog.String("cv_id", cvID), slog.String("project_id", projectID))
-	// Enqueue, propagating request_id to the background worker via payload
-	requestID := obsctx.RequestIDFromContext(ctx)
-	payload := domain.EvaluateTaskPayload{JobID: jobID, CVID: cvID, ProjectID: projectID, JobDescription: jobDesc, StudyCaseBrief: studyCase, ScoringRubric: scoringRubric, RequestID: requestID}
+	payload.JobID = jobID
 	if _, err := s.Queue.EnqueueEvaluate(ctx, payload); err != nil {
 		_ = s.Jobs.UpdateStatus(ctx, jobID, domain.JobFailed, ptr("enqueue failed"))
 		lg.Error("enqueue evaluate failed to enqueue", slog.String("job_id", jobID), slog.Any("error", err))
+		refundQuota()
 		return "", err
 	}
 	lg.Info("enqueue evaluate enqueued", slog.String("job_id", jobID))
@@ -12,6 +12,7 @@ import (
 
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
 	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
 )
 
@@ -55,12 +56,89 @@ func TestEvaluate_Enqueue_Success(t *testing.T) {
 	uploadRepo.AssertExpectations(t)
 }
 
+func TestEvaluate_Enqueue_PropagatesPaidFallbackOptIn(t *testing.T) {
+	t.Parallel()
+	jobRepo, queue, uploadRepo := setupMocks()
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-abc", nil)
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.AllowPaidFallback
+	})).Return("t-1", nil)
+
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	ctx := domain.WithPaidFallbackOptIn(context.Background(), true)
+	_, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	queue.AssertExpectations(t)
+}
+
+func TestEvaluate_Enqueue_StoresRequestID(t *testing.T) {
+	t.Parallel()
+	jobRepo, queue, uploadRepo := setupMocks()
+	jobRepo.On("Create", mock.Anything, mock.MatchedBy(func(j domain.Job) bool {
+		return j.RequestID == "req-42"
+	})).Return("job-abc", nil)
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.RequestID == "req-42"
+	})).Return("t-1", nil)
+
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	ctx := obsctx.ContextWithRequestID(context.Background(), "req-42")
+	_, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	jobRepo.AssertExpectations(t)
+	queue.AssertExpectations(t)
+}
+
+func TestEvaluate_Enqueue_SetsJobExpiry(t *testing.T) {
+	t.Parallel()
+	ttlOf := func(want time.Duration) any {
+		return mock.MatchedBy(func(j domain.Job) bool {
+			if want == 0 {
+				return j.ExpiresAt == nil
+			}
+			return j.ExpiresAt != nil && j.ExpiresAt.Sub(j.CreatedAt) == want
+		})
+	}
+	jobRepo, queue, uploadRepo := setupMocks()
+	jobRepo.On("Create", mock.Anything, ttlOf(0)).Return("job-1", nil).Once()
+	jobRepo.On("Create", mock.Anything, ttlOf(time.Hour)).Return("job-2", nil).Once()
+	jobRepo.On("Create", mock.Anything, ttlOf(10*time.Minute)).Return("job-3", nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.Anything).Return("t-1", nil)
+
+	// Without a default TTL jobs never expire.
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	_, err := svc.Enqueue(context.Background(), "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+
+	// The configured TTL applies unless the request overrides it.
+	svc.JobTTL = time.Hour
+	_, err = svc.Enqueue(context.Background(), "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	ctx := domain.WithJobTTL(context.Background(), 10*time.Minute)
+	_, err = svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	jobRepo.AssertExpectations(t)
+}
+
+func TestEvaluate_Enqueue_CarriesSLA(t *testing.T) {
+	t.Parallel()
+	jobRepo, queue, uploadRepo := setupMocks()
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-1", nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.SLA == 3*time.Minute
+	})).Return("t-1", nil).Once()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	svc.SLA = 3 * time.Minute
+	_, err := svc.Enqueue(context.Background(), "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+}
+
 func TestEvaluate_Enqueue_InvalidArgs(t *testing.T) {
 	t.Parallel()
 	jobRepo, queue, uploadRepo := setupMocks()
 	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
-	_, err := svc.Enqueue(context.Background(), "", "pr-1", "jd", "sc", "sr", "")
-	require.Error(t, err)
+	_, err := svc.Enqueue(context.Background(), "", "", "jd", "sc", "sr", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
 }
 
 func TestEvaluate_Enqueue_QueueFail_UpdatesJobFailed(t *testing.T) {
@@ -87,3 +165,91 @@ func TestEvaluate_Enqueue_QueueFail_UpdatesJobFailed(t *testing.T) {
 	queue.AssertExpectations(t)
 	uploadRepo.AssertExpectations(t)
 }
+
+func TestEvaluate_Enqueue_CVOnly(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+
+	jobRepo.On("Create", mock.Anything, mock.MatchedBy(func(j domain.Job) bool { return j.ProjectID == "" })).Return("job-1", nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.CVOnly && p.ProjectID == "" && p.StudyCaseBrief == "" && p.JobDescription == "jd"
+	})).Return("t1", nil).Once()
+	id, err := svc.Enqueue(context.Background(), "cv-1", "", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", id)
+	queue.AssertExpectations(t)
+}
+
+func TestEvaluate_Enqueue_ProjectOnly(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+
+	jobRepo.On("Create", mock.Anything, mock.MatchedBy(func(j domain.Job) bool { return j.CVID == "" && j.ProjectID == "pr-1" })).Return("job-2", nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.ProjectOnly && !p.CVOnly && p.CVID == "" && p.JobDescription == "" && p.StudyCaseBrief == "sc"
+	})).Return("t2", nil).Once()
+	id, err := svc.Enqueue(context.Background(), "", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	assert.Equal(t, "job-2", id)
+	queue.AssertExpectations(t)
+}
+
+func TestEvaluate_Enqueue_Priority(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-3", nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.Priority == domain.PriorityInteractive
+	})).Return("t3", nil).Once()
+	_, err := svc.Enqueue(domain.WithJobPriority(context.Background(), domain.PriorityInteractive), "cv-1", "pr-1", "jd", "sc", "", "")
+	require.NoError(t, err)
+	queue.AssertExpectations(t)
+
+	_, err = svc.Enqueue(domain.WithJobPriority(context.Background(), "urgent"), "cv-1", "pr-1", "jd", "sc", "", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestEvaluate_Enqueue_FeedbackFormat(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-4", nil).Twice()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.FeedbackFormat == domain.FeedbackFormatMarkdown
+	})).Return("t4", nil).Once()
+	_, err := svc.Enqueue(domain.WithFeedbackFormat(context.Background(), domain.FeedbackFormatMarkdown), "cv-1", "pr-1", "jd", "sc", "", "")
+	require.NoError(t, err)
+	// Plain text is the default and is not carried in the payload.
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.FeedbackFormat == ""
+	})).Return("t5", nil).Once()
+	_, err = svc.Enqueue(domain.WithFeedbackFormat(context.Background(), domain.FeedbackFormatText), "cv-1", "pr-1", "jd", "sc", "", "")
+	require.NoError(t, err)
+	queue.AssertExpectations(t)
+
+	_, err = svc.Enqueue(domain.WithFeedbackFormat(context.Background(), "html"), "cv-1", "pr-1", "jd", "sc", "", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestEvaluate_Enqueue_FeedbackLanguage(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-5", nil).Twice()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.FeedbackLanguage == "id"
+	})).Return("t6", nil).Once()
+	_, err := svc.Enqueue(domain.WithFeedbackLanguage(context.Background(), "id"), "cv-1", "pr-1", "jd", "sc", "", "")
+	require.NoError(t, err)
+	// English is the default and is not carried in the payload.
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.FeedbackLanguage == ""
+	})).Return("t7", nil).Once()
+	_, err = svc.Enqueue(domain.WithFeedbackLanguage(context.Background(), "en"), "cv-1", "pr-1", "jd", "sc", "", "")
+	require.NoError(t, err)
+	queue.AssertExpectations(t)
+
+	_, err = svc.Enqueue(domain.WithFeedbackLanguage(context.Background(), "klingon"), "cv-1", "pr-1", "jd", "sc", "", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+)
+
+// jobBulkProgressEvery is how many jobs a bulk operation handles between
+// progress updates.
+const jobBulkProgressEvery = 25
+
+// JobBulkService applies an admin action to every job matching a filter in
+// the background, replacing manual SQL during incidents such as a provider
+// rate limit storm or workers stuck on processing jobs.
+type JobBulkService struct {
+	Repo domain.JobBulkRepository
+	// Jobs supplies the tasks of requeued jobs.
+	Jobs domain.JobRepository
+	// Outbox publishes the tasks of requeued jobs; requeues are rejected
+	// without it.
+	Outbox *OutboxService
+	// MaxJobs bounds the jobs one operation takes, least recently updated
+	// first; 0 means 5000.
+	MaxJobs int
+
+	now func() time.Time
+}
+
+// NewJobBulkService constructs a JobBulkService taking at most maxJobs jobs
+// per operation.
+func NewJobBulkService(repo domain.JobBulkRepository, jobs domain.JobRepository, outbox *OutboxService, maxJobs int) *JobBulkService {
+	return &JobBulkService{Repo: repo, Jobs: jobs, Outbox: outbox, MaxJobs: maxJobs, now: time.Now}
+}
+
+// validateJobBulk checks that action applies to jobs in f.Status.
+func (s *JobBulkService) validateJobBulk(action domain.JobBulkAction, f domain.JobBulkFilter) error {
+	switch action {
+	case domain.JobBulkRequeue:
+		if f.Status != domain.JobProcessing && f.Status != domain.JobFailed {
+			return fmt.Errorf("%w: requeue applies to processing or failed jobs", domain.ErrInvalidArgument)
+		}
+		if s.Outbox == nil {
+			return fmt.Errorf("%w: bulk requeues require OUTBOX_ENABLED", domain.ErrInvalidArgument)
+		}
+	case domain.JobBulkCancel, domain.JobBulkFail:
+		if f.Status != domain.JobQueued && f.Status != domain.JobProcessing {
+			return fmt.Errorf("%w: %s applies to queued or processing jobs", domain.ErrInvalidArgument, action)
+		}
+	default:
+		return fmt.Errorf("%w: action must be one of requeue, cancel, fail", domain.ErrInvalidArgument)
+	}
+	if f.OlderThan < 0 {
+		return fmt.Errorf("%w: older_than must not be negative", domain.ErrInvalidArgument)
+	}
+	if f.FailureReason != "" && f.Status != domain.JobFailed {
+		return fmt.Errorf("%w: failure_reason applies to failed jobs", domain.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// Start selects the jobs matching f and applies action to them in the
+// background, returning the operation to poll with Get. With dryRun it only
+// reports how many jobs match.
+func (s *JobBulkService) Start(ctx domain.Context, action domain.JobBulkAction, f domain.JobBulkFilter, actor, reason string, dryRun bool) (domain.JobBulkOperation, error) {
+	f.FailureReason = strings.TrimSpace(f.FailureReason)
+	if err := s.validateJobBulk(action, f); err != nil {
+		return domain.JobBulkOperation{}, err
+	}
+	now := s.now().UTC()
+	before := now.Add(-f.OlderThan)
+	maxJobs := s.MaxJobs
+	if maxJobs <= 0 {
+		maxJobs = 5000
+	}
+	// Jobs filtered by failure reason are fetched without a limit so that
+	// other failures do not crowd them out.
+	limit := maxJobs + 1
+	if f.FailureReason != "" {
+		limit = 0
+	}
+	candidates, err := s.Repo.Candidates(ctx, f.Status, before, limit)
+	if err != nil {
+		return domain.JobBulkOperation{}, fmt.Errorf("op=job_bulk.candidates: %w", err)
+	}
+	jobs := make([]domain.Job, 0, len(candidates))
+	for _, j := range candidates {
+		if f.FailureReason == "" || matchesFailureReason(j.Error, f.FailureReason) {
+			jobs = append(jobs, j)
+		}
+	}
+	op := domain.JobBulkOperation{
+		Action:    action,
+		Filter:    f,
+		Reason:    strings.TrimSpace(reason),
+		StartedBy: actor,
+		Status:    domain.JobBulkRunning,
+		Matched:   min(len(jobs), maxJobs),
+		Truncated: len(jobs) > maxJobs,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if dryRun {
+		return op, nil
+	}
+	jobs = jobs[:op.Matched]
+	op, err = s.Repo.CreateOperation(ctx, op)
+	if err != nil {
+		return domain.JobBulkOperation{}, fmt.Errorf("op=job_bulk.create: %w", err)
+	}
+	obsctx.LoggerFromContext(ctx).Info("bulk job operation started",
+		slog.String("operation_id", op.ID), slog.String("action", string(action)), slog.String("status", string(f.Status)),
+		slog.Duration("older_than", f.OlderThan), slog.String("failure_reason", f.FailureReason),
+		slog.Int("matched", op.Matched), slog.String("actor", actor))
+	// The operation outlives the request that started it.
+	go s.run(context.WithoutCancel(ctx), op, jobs, before)
+	return op, nil
+}
+
+// run applies op to jobs, storing its progress every jobBulkProgressEvery
+// jobs and when it finishes.
+func (s *JobBulkService) run(ctx context.Context, op domain.JobBulkOperation, jobs []domain.Job, before time.Time) {
+	lg := obsctx.LoggerFromContext(ctx).With(slog.String("operation_id", op.ID))
+	for i, j := range jobs {
+		changed, err := s.apply(ctx, op, j, before)
+		op.Processed++
+		switch {
+		case err != nil:
+			op.Failed++
+			lg.Warn("bulk job action failed", slog.String("job_id", j.ID), slog.Any("error", err))
+		case changed:
+			op.Succeeded++
+		default:
+			op.Skipped++
+		}
+		if (i+1)%jobBulkProgressEvery == 0 && i+1 < len(jobs) {
+			op.UpdatedAt = s.now().UTC()
+			if err := s.Repo.UpdateOperation(ctx, op); err != nil {
+				lg.Warn("failed to store bulk operation progress", slog.Any("error", err))
+			}
+		}
+	}
+	if op.Action == domain.JobBulkRequeue && op.Succeeded > 0 {
+		s.Outbox.Notify()
+	}
+	finished := s.now().UTC()
+	op.Status, op.UpdatedAt, op.FinishedAt = domain.JobBulkCompleted, finished, &finished
+	if err := s.Repo.UpdateOperation(ctx, op); err != nil {
+		lg.Error("failed to store bulk operation result", slog.Any("error", err))
+	}
+	lg.Info("bulk job operation finished", slog.Int("succeeded", op.Succeeded), slog.Int("skipped", op.Skipped), slog.Int("failed", op.Failed))
+}
+
+// apply applies op to job j and reports whether it changed the job. Jobs
+// that moved on since the operation started are left alone.
+func (s *JobBulkService) apply(ctx domain.Context, op domain.JobBulkOperation, j domain.Job, before time.Time) (bool, error) {
+	note := ""
+	if op.Reason != "" {
+		note = ": " + op.Reason
+	}
+	switch op.Action {
+	case domain.JobBulkCancel:
+		return s.Repo.Fail(ctx, j.ID, op.Filter.Status, before, "cancelled by admin"+note)
+	case domain.JobBulkFail:
+		return s.Repo.Fail(ctx, j.ID, op.Filter.Status, before, "failed by admin"+note)
+	}
+	p, err := s.Jobs.Task(ctx, j.ID)
+	if err != nil {
+		return false, fmt.Errorf("op=job_bulk.requeue.task: %w", err)
+	}
+	if op.Filter.Status == domain.JobProcessing {
+		// Outbox requeues take failed jobs, so stuck jobs are failed first.
+		moved, err := s.Repo.Fail(ctx, j.ID, domain.JobProcessing, before, "requeued by admin"+note)
+		if err != nil || !moved {
+			return false, err
+		}
+	}
+	p.JobID = j.ID
+	if err := s.Outbox.Repo.Requeue(ctx, p); err != nil {
+		if errWrapped(err, domain.ErrConflict) {
+			return false, nil
+		}
+		return false, fmt.Errorf("op=job_bulk.requeue: %w", err)
+	}
+	return true, nil
+}
+
+// matchesFailureReason reports whether the job error errMsg has the error
+// code reason, given with or without its UPSTREAM_ prefix in any case.
+func matchesFailureReason(errMsg, reason string) bool {
+	code := errorCodeFromJobError(errMsg)
+	reason = strings.ToUpper(reason)
+	return code == reason || code == "UPSTREAM_"+reason
+}
+
+// Get returns the operation id with its progress.
+func (s *JobBulkService) Get(ctx domain.Context, id string) (domain.JobBulkOperation, error) {
+	op, err := s.Repo.GetOperation(ctx, id)
+	if err != nil {
+		return domain.JobBulkOperation{}, fmt.Errorf("op=job_bulk.get: %w", err)
+	}
+	return op, nil
+}
+
+// List returns the latest limit operations, newest first. limit defaults to
+// 20 and is capped at 100.
+func (s *JobBulkService) List(ctx domain.Context, limit int) ([]domain.JobBulkOperation, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	limit = min(limit, 100)
+	ops, err := s.Repo.ListOperations(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("op=job_bulk.list: %w", err)
+	}
+	return ops, nil
+}
@@ -0,0 +1,166 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// stubJobBulk is a domain.JobBulkRepository over fixed candidates that fails
+// every job except those in stale.
+type stubJobBulk struct {
+	mu         sync.Mutex
+	candidates []domain.Job
+	stale      map[string]bool
+	failed     map[string]string
+	op         domain.JobBulkOperation
+	creates    int
+}
+
+func (s *stubJobBulk) Candidates(_ domain.Context, status domain.JobStatus, _ time.Time, limit int) ([]domain.Job, error) {
+	var out []domain.Job
+	for _, j := range s.candidates {
+		if j.Status == status && (limit == 0 || len(out) < limit) {
+			out = append(out, j)
+		}
+	}
+	return out, nil
+}
+
+func (s *stubJobBulk) Fail(_ domain.Context, id string, _ domain.JobStatus, _ time.Time, errMsg string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stale[id] {
+		return false, nil
+	}
+	if s.failed == nil {
+		s.failed = map[string]string{}
+	}
+	s.failed[id] = errMsg
+	return true, nil
+}
+
+func (s *stubJobBulk) CreateOperation(_ domain.Context, op domain.JobBulkOperation) (domain.JobBulkOperation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.creates++
+	op.ID = "op-1"
+	s.op = op
+	return op, nil
+}
+
+func (s *stubJobBulk) UpdateOperation(_ domain.Context, op domain.JobBulkOperation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.op = op
+	return nil
+}
+
+func (s *stubJobBulk) GetOperation(_ domain.Context, id string) (domain.JobBulkOperation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id != s.op.ID {
+		return domain.JobBulkOperation{}, domain.ErrNotFound
+	}
+	return s.op, nil
+}
+
+func (s *stubJobBulk) ListOperations(domain.Context, int) ([]domain.JobBulkOperation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return []domain.JobBulkOperation{s.op}, nil
+}
+
+func waitJobBulk(t *testing.T, svc *usecase.JobBulkService) domain.JobBulkOperation {
+	t.Helper()
+	var op domain.JobBulkOperation
+	require.Eventually(t, func() bool {
+		var err error
+		op, err = svc.Get(context.Background(), "op-1")
+		return err == nil && op.Status == domain.JobBulkCompleted
+	}, time.Second, 5*time.Millisecond)
+	return op
+}
+
+func TestJobBulkService_Validation(t *testing.T) {
+	svc := usecase.NewJobBulkService(&stubJobBulk{}, nil, nil, 0)
+	ctx := context.Background()
+	for name, tc := range map[string]struct {
+		action domain.JobBulkAction
+		filter domain.JobBulkFilter
+	}{
+		"unknown action":         {"delete", domain.JobBulkFilter{Status: domain.JobQueued}},
+		"cancel completed jobs":  {domain.JobBulkCancel, domain.JobBulkFilter{Status: domain.JobCompleted}},
+		"requeue queued jobs":    {domain.JobBulkRequeue, domain.JobBulkFilter{Status: domain.JobQueued}},
+		"requeue without outbox": {domain.JobBulkRequeue, domain.JobBulkFilter{Status: domain.JobFailed}},
+		"reason of live jobs":    {domain.JobBulkFail, domain.JobBulkFilter{Status: domain.JobProcessing, FailureReason: "timeout"}},
+		"negative age":           {domain.JobBulkFail, domain.JobBulkFilter{Status: domain.JobProcessing, OlderThan: -time.Minute}},
+	} {
+		_, err := svc.Start(ctx, tc.action, tc.filter, "admin", "", false)
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument, name)
+	}
+}
+
+func TestJobBulkService_CancelStuckJobs(t *testing.T) {
+	repo := &stubJobBulk{
+		candidates: []domain.Job{
+			{ID: "job-1", Status: domain.JobProcessing},
+			{ID: "job-2", Status: domain.JobProcessing},
+			{ID: "job-3", Status: domain.JobProcessing},
+		},
+		stale: map[string]bool{"job-2": true},
+	}
+	svc := usecase.NewJobBulkService(repo, nil, nil, 2)
+	ctx := context.Background()
+	filter := domain.JobBulkFilter{Status: domain.JobProcessing, OlderThan: 30 * time.Minute}
+
+	// A dry run only counts the jobs.
+	op, err := svc.Start(ctx, domain.JobBulkCancel, filter, "admin", "stuck workers", true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, op.Matched)
+	assert.True(t, op.Truncated)
+	assert.Zero(t, repo.creates)
+
+	op, err = svc.Start(ctx, domain.JobBulkCancel, filter, "admin", "stuck workers", false)
+	require.NoError(t, err)
+	assert.Equal(t, "op-1", op.ID)
+	op = waitJobBulk(t, svc)
+	assert.Equal(t, 2, op.Processed)
+	assert.Equal(t, 1, op.Succeeded)
+	assert.Equal(t, 1, op.Skipped)
+	require.NotNil(t, op.FinishedAt)
+	assert.Equal(t, map[string]string{"job-1": "cancelled by admin: stuck workers"}, repo.failed)
+}
+
+func TestJobBulkService_RequeueByFailureReason(t *testing.T) {
+	repo := &stubJobBulk{candidates: []domain.Job{
+		{ID: "job-1", Status: domain.JobFailed, Error: "upstream rate limit exceeded"},
+		{ID: "job-2", Status: domain.JobFailed, Error: "schema invalid: cv_match_rate"},
+		{ID: "job-3", Status: domain.JobFailed, Error: "rate limit: 429"},
+	}}
+	jobs := mocks.NewMockJobRepository(t)
+	jobs.EXPECT().Task(mock.Anything, "job-1").Return(domain.EvaluateTaskPayload{JobID: "job-1", CVID: "cv-1"}, nil).Once()
+	jobs.EXPECT().Task(mock.Anything, "job-3").Return(domain.EvaluateTaskPayload{JobID: "job-3", CVID: "cv-3"}, nil).Once()
+	outbox := mocks.NewMockOutboxRepository(t)
+	outbox.EXPECT().Requeue(mock.Anything, domain.EvaluateTaskPayload{JobID: "job-1", CVID: "cv-1"}).Return(nil).Once()
+	// A job retried meanwhile is no longer failed.
+	outbox.EXPECT().Requeue(mock.Anything, domain.EvaluateTaskPayload{JobID: "job-3", CVID: "cv-3"}).Return(domain.ErrConflict).Once()
+	svc := usecase.NewJobBulkService(repo, jobs, usecase.NewOutboxService(outbox, nil, 0, 0), 0)
+
+	op, err := svc.Start(context.Background(), domain.JobBulkRequeue, domain.JobBulkFilter{Status: domain.JobFailed, FailureReason: "rate_limit"}, "admin", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, op.Matched)
+	op = waitJobBulk(t, svc)
+	assert.Equal(t, 1, op.Succeeded)
+	assert.Equal(t, 1, op.Skipped)
+	assert.Zero(t, op.Failed)
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Limits of the metadata and tags a job may carry.
+const (
+	maxJobMetadataPairs    = 20
+	maxJobMetadataValueLen = 256
+	maxJobTags             = 20
+)
+
+// jobLabelPattern matches metadata keys and tags.
+var jobLabelPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+// ValidateJobLabels checks the metadata and tags of a job and returns them
+// normalized: tags are trimmed and deduplicated, keeping their order, and
+// empty metadata and tags become nil. Keys and tags are at most 64 letters,
+// digits or ._:- characters; values are at most 256 printable characters.
+func ValidateJobLabels(l domain.JobLabels) (domain.JobLabels, error) {
+	var out domain.JobLabels
+	if len(l.Metadata) > maxJobMetadataPairs {
+		return out, fmt.Errorf("%w: at most %d metadata pairs", domain.ErrInvalidArgument, maxJobMetadataPairs)
+	}
+	for k, v := range l.Metadata {
+		if !jobLabelPattern.MatchString(k) {
+			return out, fmt.Errorf("%w: metadata key %q must be 1-64 letters, digits or ._:- characters", domain.ErrInvalidArgument, k)
+		}
+		if len(v) > maxJobMetadataValueLen || strings.IndexFunc(v, unicode.IsControl) >= 0 {
+			return out, fmt.Errorf("%w: metadata %s must be at most %d printable characters", domain.ErrInvalidArgument, k, maxJobMetadataValueLen)
+		}
+		if out.Metadata == nil {
+			out.Metadata = make(map[string]string, len(l.Metadata))
+		}
+		out.Metadata[k] = v
+	}
+	seen := map[string]bool{}
+	for _, t := range l.Tags {
+		t = strings.TrimSpace(t)
+		if !jobLabelPattern.MatchString(t) {
+			return out, fmt.Errorf("%w: tag %q must be 1-64 letters, digits or ._:- characters", domain.ErrInvalidArgument, t)
+		}
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		out.Tags = append(out.Tags, t)
+	}
+	if len(out.Tags) > maxJobTags {
+		return domain.JobLabels{}, fmt.Errorf("%w: at most %d tags", domain.ErrInvalidArgument, maxJobTags)
+	}
+	return out, nil
+}
@@ -0,0 +1,62 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestValidateJobLabels(t *testing.T) {
+	l, err := usecase.ValidateJobLabels(domain.JobLabels{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobLabels{}, l)
+
+	l, err = usecase.ValidateJobLabels(domain.JobLabels{
+		Metadata: map[string]string{"team": "platform", "req-id": "R-1"},
+		Tags:     []string{" campaign:q4 ", "urgent", "campaign:q4"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "platform", "req-id": "R-1"}, l.Metadata)
+	assert.Equal(t, []string{"campaign:q4", "urgent"}, l.Tags)
+
+	tooMany := map[string]string{}
+	for i := 0; i < 21; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+	for name, in := range map[string]domain.JobLabels{
+		"bad key":          {Metadata: map[string]string{"team name": "x"}},
+		"long value":       {Metadata: map[string]string{"team": strings.Repeat("x", 257)}},
+		"control in value": {Metadata: map[string]string{"team": "a\nb"}},
+		"too many pairs":   {Metadata: tooMany},
+		"empty tag":        {Tags: []string{" "}},
+		"bad tag":          {Tags: []string{"a/b"}},
+	} {
+		_, err := usecase.ValidateJobLabels(in)
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument, name)
+	}
+}
+
+func TestEvaluate_Enqueue_JobLabels(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+
+	jobRepo.On("Create", mock.Anything, mock.MatchedBy(func(j domain.Job) bool {
+		return j.Metadata["team"] == "platform" && assert.ObjectsAreEqual([]string{"urgent"}, j.Tags)
+	})).Return("job-1", nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.Anything).Return("t1", nil).Once()
+	ctx := domain.WithJobLabels(context.Background(), domain.JobLabels{Metadata: map[string]string{"team": "platform"}, Tags: []string{"urgent", "urgent"}})
+	_, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	jobRepo.AssertExpectations(t)
+
+	ctx = domain.WithJobLabels(context.Background(), domain.JobLabels{Tags: []string{"not a tag"}})
+	_, err = svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+)
+
+// throughputTTL is how long a measured throughput is reused; estimates are
+// requested on every poll of a queued job.
+const throughputTTL = 30 * time.Second
+
+// QueueEstimate is where a queued job stands in the queue.
+type QueueEstimate struct {
+	// Position is 1 for the next job to be evaluated.
+	Position int64
+	// ETA is the estimated time until the job's result is ready; zero when
+	// no job finished within the throughput window.
+	ETA time.Duration
+}
+
+// JobQueueService estimates the queue position of queued jobs from the
+// backlog ahead of them and the recent throughput, and lets admins bump
+// urgent jobs ahead of the backlog.
+type JobQueueService struct {
+	Repo domain.JobQueueRepository
+	Jobs domain.JobRepository
+	// Outbox republishes bumped tasks; bumps are rejected without it.
+	Outbox *OutboxService
+	// Window is the period the throughput is measured over; 0 means 15
+	// minutes.
+	Window time.Duration
+
+	now    func() time.Time
+	mu     sync.Mutex
+	rate   float64
+	rateAt time.Time
+}
+
+// NewJobQueueService constructs a JobQueueService measuring throughput over
+// window.
+func NewJobQueueService(repo domain.JobQueueRepository, jobs domain.JobRepository, outbox *OutboxService, window time.Duration) *JobQueueService {
+	return &JobQueueService{Repo: repo, Jobs: jobs, Outbox: outbox, Window: window, now: time.Now}
+}
+
+// Estimate returns the queue position and ETA of the queued job jobID.
+func (s *JobQueueService) Estimate(ctx domain.Context, jobID string) (QueueEstimate, error) {
+	ahead, err := s.Repo.Position(ctx, jobID)
+	if err != nil {
+		return QueueEstimate{}, fmt.Errorf("op=job_queue.estimate.position: %w", err)
+	}
+	rate, err := s.throughput(ctx)
+	if err != nil {
+		return QueueEstimate{}, fmt.Errorf("op=job_queue.estimate.throughput: %w", err)
+	}
+	e := QueueEstimate{Position: ahead + 1}
+	if rate > 0 {
+		e.ETA = time.Duration(float64(e.Position) / rate * float64(time.Second)).Round(time.Second)
+	}
+	return e, nil
+}
+
+// throughput returns the jobs finished per second over Window, measured at
+// most once per throughputTTL.
+func (s *JobQueueService) throughput(ctx domain.Context) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if !s.rateAt.IsZero() && now.Sub(s.rateAt) < throughputTTL {
+		return s.rate, nil
+	}
+	window := s.Window
+	if window <= 0 {
+		window = 15 * time.Minute
+	}
+	n, err := s.Repo.FinishedSince(ctx, now.Add(-window))
+	if err != nil {
+		return 0, err
+	}
+	s.rate, s.rateAt = float64(n)/window.Seconds(), now
+	return s.rate, nil
+}
+
+// Bump moves the queued job jobID ahead of every job that was not bumped
+// earlier: its task is republished to the priority topic, and the worker
+// that receives the original task later finds the job already evaluated.
+// The task is the one kept on the job (ErrNotFound when none was recorded);
+// jobs that are not queued or already bumped are rejected with ErrConflict.
+func (s *JobQueueService) Bump(ctx domain.Context, jobID, actor, reason string) (domain.JobBump, error) {
+	if s.Outbox == nil {
+		return domain.JobBump{}, fmt.Errorf("%w: job bumps require OUTBOX_ENABLED", domain.ErrInvalidArgument)
+	}
+	job, err := s.Jobs.Get(ctx, jobID)
+	if err != nil {
+		return domain.JobBump{}, fmt.Errorf("op=job_queue.bump.get_job: %w", err)
+	}
+	if job.Status != domain.JobQueued {
+		return domain.JobBump{}, fmt.Errorf("%w: only queued jobs can be bumped, job is %s", domain.ErrConflict, job.Status)
+	}
+	p, err := s.Jobs.Task(ctx, jobID)
+	if err != nil {
+		return domain.JobBump{}, fmt.Errorf("op=job_queue.bump.task: %w", err)
+	}
+	if p.Bumped {
+		return domain.JobBump{}, fmt.Errorf("%w: job is already bumped", domain.ErrConflict)
+	}
+	p.JobID = jobID
+	p.Bumped = true
+	b := domain.JobBump{JobID: jobID, BumpedBy: actor, Reason: strings.TrimSpace(reason), BumpedAt: s.now().UTC()}
+	if err := s.Repo.Bump(ctx, b, p); err != nil {
+		return domain.JobBump{}, fmt.Errorf("op=job_queue.bump: %w", err)
+	}
+	s.Outbox.Notify()
+	obsctx.LoggerFromContext(ctx).Info("queued job bumped",
+		slog.String("job_id", jobID), slog.String("actor", actor), slog.String("reason", b.Reason))
+	return b, nil
+}
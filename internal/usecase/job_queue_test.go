@@ -0,0 +1,101 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// stubJobQueue is a domain.JobQueueRepository with fixed counts that records
+// bumps.
+type stubJobQueue struct {
+	ahead, finished int64
+	finishedCalls   int
+	bumps           []domain.JobBump
+	payloads        []domain.EvaluateTaskPayload
+}
+
+func (s *stubJobQueue) Bump(_ domain.Context, b domain.JobBump, p domain.EvaluateTaskPayload) error {
+	s.bumps = append(s.bumps, b)
+	s.payloads = append(s.payloads, p)
+	return nil
+}
+
+func (s *stubJobQueue) Position(domain.Context, string) (int64, error) { return s.ahead, nil }
+
+func (s *stubJobQueue) FinishedSince(domain.Context, time.Time) (int64, error) {
+	s.finishedCalls++
+	return s.finished, nil
+}
+
+func TestJobQueueService_Estimate(t *testing.T) {
+	repo := &stubJobQueue{ahead: 3, finished: 60}
+	svc := usecase.NewJobQueueService(repo, nil, nil, 10*time.Minute)
+
+	e, err := svc.Estimate(context.Background(), "job-1")
+	require.NoError(t, err)
+	// 60 jobs in 10 minutes is one every 10s; the job is fourth in line.
+	assert.Equal(t, usecase.QueueEstimate{Position: 4, ETA: 40 * time.Second}, e)
+
+	// The throughput is measured once per interval.
+	repo.ahead = 0
+	e, err = svc.Estimate(context.Background(), "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, usecase.QueueEstimate{Position: 1, ETA: 10 * time.Second}, e)
+	assert.Equal(t, 1, repo.finishedCalls)
+
+	// Without recent throughput there is no ETA.
+	idle := usecase.NewJobQueueService(&stubJobQueue{ahead: 2}, nil, nil, time.Minute)
+	e, err = idle.Estimate(context.Background(), "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, usecase.QueueEstimate{Position: 3}, e)
+}
+
+func TestJobQueueService_Bump(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	outbox := mocks.NewMockOutboxRepository(t)
+	repo := &stubJobQueue{}
+	svc := usecase.NewJobQueueService(repo, jobs, usecase.NewOutboxService(outbox, nil, 0, 0), 0)
+
+	jobs.EXPECT().Get(mock.Anything, "job-1").Return(domain.Job{ID: "job-1", Status: domain.JobQueued}, nil).Once()
+	jobs.EXPECT().Task(mock.Anything, "job-1").Return(domain.EvaluateTaskPayload{JobID: "job-1", CVID: "cv-1"}, nil).Once()
+	b, err := svc.Bump(context.Background(), "job-1", "admin", " customer escalation ")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", b.BumpedBy)
+	assert.Equal(t, "customer escalation", b.Reason)
+	require.Len(t, repo.payloads, 1)
+	assert.Equal(t, domain.EvaluateTaskPayload{JobID: "job-1", CVID: "cv-1", Bumped: true}, repo.payloads[0])
+
+	// Only queued jobs that were not bumped yet can be bumped.
+	jobs.EXPECT().Get(mock.Anything, "job-2").Return(domain.Job{ID: "job-2", Status: domain.JobProcessing}, nil).Once()
+	_, err = svc.Bump(context.Background(), "job-2", "admin", "")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	jobs.EXPECT().Get(mock.Anything, "job-3").Return(domain.Job{ID: "job-3", Status: domain.JobQueued}, nil).Once()
+	jobs.EXPECT().Task(mock.Anything, "job-3").Return(domain.EvaluateTaskPayload{JobID: "job-3", Bumped: true}, nil).Once()
+	_, err = svc.Bump(context.Background(), "job-3", "admin", "")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	// Bumps republish through the outbox.
+	_, err = usecase.NewJobQueueService(repo, jobs, nil, 0).Bump(context.Background(), "job-1", "admin", "")
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestResult_QueuedJobIncludesQueueEstimate(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	jobRepo.EXPECT().Get(mock.Anything, "job-1").Return(domain.Job{ID: "job-1", Status: domain.JobQueued, CreatedAt: time.Now(), UpdatedAt: time.Now()}, nil)
+	svc := usecase.NewResultService(jobRepo, mocks.NewMockResultRepository(t))
+	svc.Queue = usecase.NewJobQueueService(&stubJobQueue{ahead: 1, finished: 30}, jobRepo, nil, 5*time.Minute)
+
+	st, body, _, err := svc.Fetch(context.Background(), "job-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, 200, st)
+	assert.Equal(t, map[string]any{"position": int64(2), "eta_seconds": int64(20)}, body["queue"])
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+)
+
+// JobReplaySandbox evaluates a job's task on the given document texts without
+// storing anything or changing the job. It is implemented by
+// redpanda.Sandbox.
+type JobReplaySandbox interface {
+	Evaluate(ctx domain.Context, p domain.EvaluateTaskPayload, cvText, projectText string) (domain.Result, error)
+}
+
+// JobReplayService re-runs past jobs through the current evaluation pipeline
+// in a sandbox, so that fixes can be validated on the inputs of jobs that were
+// evaluated badly. Replayed results are returned, never stored.
+type JobReplayService struct {
+	Jobs    domain.JobRepository
+	Uploads domain.UploadRepository
+	Results domain.ResultRepository
+	Sandbox JobReplaySandbox
+
+	now func() time.Time
+}
+
+// NewJobReplayService constructs a JobReplayService. Jobs are replayed with
+// the task kept on the job.
+func NewJobReplayService(jobs domain.JobRepository, uploads domain.UploadRepository, results domain.ResultRepository, sandbox JobReplaySandbox) *JobReplayService {
+	return &JobReplayService{Jobs: jobs, Uploads: uploads, Results: results, Sandbox: sandbox, now: time.Now}
+}
+
+// Replay evaluates the stored uploads, job description and options of job
+// jobID again and compares the outcome with the job's stored result. Queued
+// and processing jobs are rejected with ErrConflict; jobs whose task or
+// uploads have been purged with ErrNotFound.
+func (s *JobReplayService) Replay(ctx domain.Context, jobID string) (domain.JobReplay, error) {
+	tr := otel.Tracer("usecase.job_replay")
+	ctx, span := tr.Start(ctx, "JobReplayService.Replay")
+	defer span.End()
+
+	job, err := s.Jobs.Get(ctx, jobID)
+	if err != nil {
+		return domain.JobReplay{}, fmt.Errorf("op=job_replay.get_job: %w", err)
+	}
+	if job.Status == domain.JobQueued || job.Status == domain.JobProcessing {
+		return domain.JobReplay{}, fmt.Errorf("%w: job is still %s", domain.ErrConflict, job.Status)
+	}
+	p, err := s.Jobs.Task(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.JobReplay{}, fmt.Errorf("%w: no task recorded for the job", domain.ErrNotFound)
+		}
+		return domain.JobReplay{}, fmt.Errorf("op=job_replay.task: %w", err)
+	}
+	p.JobID = jobID
+	var cvText, projectText string
+	if !p.ProjectOnly {
+		if cvText, err = s.uploadText(ctx, p.CVID); err != nil {
+			return domain.JobReplay{}, err
+		}
+	}
+	if !p.CVOnly {
+		if projectText, err = s.uploadText(ctx, p.ProjectID); err != nil {
+			return domain.JobReplay{}, err
+		}
+	}
+
+	start := s.now()
+	res, err := s.Sandbox.Evaluate(ctx, p, cvText, projectText)
+	if err != nil {
+		return domain.JobReplay{}, fmt.Errorf("op=job_replay.evaluate: %w", err)
+	}
+	res.JobID = jobID
+	res.CreatedAt = s.now()
+	replay := domain.JobReplay{JobID: jobID, Result: res, Duration: s.now().Sub(start)}
+
+	// Only completed jobs have a stored result.
+	if job.Status == domain.JobCompleted {
+		stored, err := s.Results.GetByJobID(ctx, jobID)
+		if err != nil {
+			return domain.JobReplay{}, fmt.Errorf("op=job_replay.get_result: %w", err)
+		}
+		diff := diffResults(stored, res)
+		diff.JobID = jobID
+		replay.Stored, replay.Diff = &stored, &diff
+	}
+	obsctx.LoggerFromContext(ctx).Info("job replayed in sandbox",
+		slog.String("job_id", jobID),
+		slog.String("status", string(job.Status)),
+		slog.Duration("duration", replay.Duration))
+	return replay, nil
+}
+
+func (s *JobReplayService) uploadText(ctx domain.Context, id string) (string, error) {
+	u, err := s.Uploads.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", fmt.Errorf("%w: upload %s of the job is no longer kept", domain.ErrNotFound, id)
+		}
+		return "", fmt.Errorf("op=job_replay.get_upload: %w", err)
+	}
+	return u.Text, nil
+}
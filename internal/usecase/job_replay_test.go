@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+type stubSandbox struct {
+	payload             domain.EvaluateTaskPayload
+	cvText, projectText string
+	res                 domain.Result
+}
+
+func (s *stubSandbox) Evaluate(_ domain.Context, p domain.EvaluateTaskPayload, cvText, projectText string) (domain.Result, error) {
+	s.payload, s.cvText, s.projectText = p, cvText, projectText
+	return s.res, nil
+}
+
+func TestJobReplay_Replay(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	uploads := mocks.NewMockUploadRepository(t)
+	results := mocks.NewMockResultRepository(t)
+	sandbox := &stubSandbox{res: domain.Result{CVMatchRate: 0.8, CVFeedback: "Strong Go skills.", ProjectScore: 8, OverallSummary: "Hire."}}
+	svc := usecase.NewJobReplayService(jobs, uploads, results, sandbox)
+
+	jobs.EXPECT().Get(mock.Anything, "job-1").Return(domain.Job{ID: "job-1", Status: domain.JobCompleted}, nil).Once()
+	jobs.EXPECT().Task(mock.Anything, "job-1").Return(domain.EvaluateTaskPayload{CVID: "cv-1", ProjectID: "pr-1", JobDescription: "Go engineer"}, nil).Once()
+	uploads.EXPECT().Get(mock.Anything, "cv-1").Return(domain.Upload{ID: "cv-1", Text: "cv text"}, nil).Once()
+	uploads.EXPECT().Get(mock.Anything, "pr-1").Return(domain.Upload{ID: "pr-1", Text: "project text"}, nil).Once()
+	results.EXPECT().GetByJobID(mock.Anything, "job-1").Return(domain.Result{JobID: "job-1", CVMatchRate: 0.5, CVFeedback: "Weak Go skills.", ProjectScore: 8, OverallSummary: "Hire."}, nil).Once()
+
+	rep, err := svc.Replay(context.Background(), "job-1")
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", sandbox.payload.JobID)
+	assert.Equal(t, "Go engineer", sandbox.payload.JobDescription)
+	assert.Equal(t, "cv text", sandbox.cvText)
+	assert.Equal(t, "project text", sandbox.projectText)
+	assert.Equal(t, "job-1", rep.Result.JobID)
+	require.NotNil(t, rep.Stored)
+	require.NotNil(t, rep.Diff)
+	assert.InDelta(t, 0.3, rep.Diff.CVMatchRate.Delta, 1e-9)
+	assert.Zero(t, rep.Diff.ProjectScore.Delta)
+	require.Len(t, rep.Diff.CVFeedback, 1)
+	assert.Equal(t, domain.SentenceChanged, rep.Diff.CVFeedback[0].Kind)
+	assert.Equal(t, []domain.SentenceChange{{Kind: domain.SentenceUnchanged, Text: "Hire."}}, rep.Diff.OverallSummary)
+
+	// Failed jobs have no stored result to compare with; CV-only jobs have
+	// no project upload.
+	jobs.EXPECT().Get(mock.Anything, "job-2").Return(domain.Job{ID: "job-2", Status: domain.JobFailed}, nil).Once()
+	jobs.EXPECT().Task(mock.Anything, "job-2").Return(domain.EvaluateTaskPayload{CVID: "cv-1", CVOnly: true}, nil).Once()
+	uploads.EXPECT().Get(mock.Anything, "cv-1").Return(domain.Upload{ID: "cv-1", Text: "cv text"}, nil).Once()
+	rep, err = svc.Replay(context.Background(), "job-2")
+	require.NoError(t, err)
+	assert.Nil(t, rep.Stored)
+	assert.Nil(t, rep.Diff)
+	assert.Empty(t, sandbox.projectText)
+}
+
+func TestJobReplay_Replay_Rejected(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	uploads := mocks.NewMockUploadRepository(t)
+	svc := usecase.NewJobReplayService(jobs, uploads, mocks.NewMockResultRepository(t), &stubSandbox{})
+
+	jobs.EXPECT().Get(mock.Anything, "job-1").Return(domain.Job{ID: "job-1", Status: domain.JobProcessing}, nil).Once()
+	_, err := svc.Replay(context.Background(), "job-1")
+	assert.ErrorIs(t, err, domain.ErrConflict)
+
+	jobs.EXPECT().Get(mock.Anything, "job-2").Return(domain.Job{ID: "job-2", Status: domain.JobCompleted}, nil).Once()
+	jobs.EXPECT().Task(mock.Anything, "job-2").Return(domain.EvaluateTaskPayload{}, fmt.Errorf("op=job.task: %w", domain.ErrNotFound)).Once()
+	_, err = svc.Replay(context.Background(), "job-2")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	jobs.EXPECT().Get(mock.Anything, "job-3").Return(domain.Job{ID: "job-3", Status: domain.JobCompleted}, nil).Once()
+	jobs.EXPECT().Task(mock.Anything, "job-3").Return(domain.EvaluateTaskPayload{CVID: "cv-3"}, nil).Once()
+	uploads.EXPECT().Get(mock.Anything, "cv-3").Return(domain.Upload{}, fmt.Errorf("op=upload.get: %w", domain.ErrNotFound)).Once()
+	_, err = svc.Replay(context.Background(), "job-3")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
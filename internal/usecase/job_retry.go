@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	obsctx "github.com/fairyhunter13/ai-cv-evaluator/internal/observability"
+)
+
+// pinnableProviders are the AI providers a retry can be pinned to.
+var pinnableProviders = []string{"openrouter", "groq"}
+
+// RetryPinned requeues the failed job jobID with every AI call pinned to
+// model of provider, bypassing the round-robin and fallbacks, so that
+// model-specific failures can be reproduced on the job's real inputs. The
+// job's task is the one recorded on the job when it was queued through the
+// outbox or deferred (ErrNotFound without one); jobs that are not failed are
+// rejected with ErrConflict.
+func (s EvaluateService) RetryPinned(ctx domain.Context, jobID, provider, model string) error {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	model = strings.TrimSpace(model)
+	if !slices.Contains(pinnableProviders, provider) {
+		return fmt.Errorf("%w: provider must be one of %s", domain.ErrInvalidArgument, strings.Join(pinnableProviders, ", "))
+	}
+	if model == "" {
+		return fmt.Errorf("%w: model is required", domain.ErrInvalidArgument)
+	}
+	if s.Outbox == nil {
+		return fmt.Errorf("%w: job retries require OUTBOX_ENABLED", domain.ErrInvalidArgument)
+	}
+	job, err := s.Jobs.Get(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("op=evaluate.retry_pinned.get_job: %w", err)
+	}
+	if job.Status != domain.JobFailed {
+		return fmt.Errorf("%w: only failed jobs can be retried, job is %s", domain.ErrConflict, job.Status)
+	}
+	p, err := s.Jobs.Task(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("op=evaluate.retry_pinned.task: %w", err)
+	}
+	p.JobID = jobID
+	p.Overrides.Provider, p.Overrides.Model = provider, model
+	if err := s.Outbox.Repo.Requeue(ctx, p); err != nil {
+		return fmt.Errorf("op=evaluate.retry_pinned.requeue: %w", err)
+	}
+	s.Outbox.Notify()
+	obsctx.LoggerFromContext(ctx).Info("failed job requeued with a pinned model",
+		slog.String("job_id", jobID), slog.String("provider", provider), slog.String("model", model))
+	return nil
+}
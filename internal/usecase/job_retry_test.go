@@ -0,0 +1,81 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestEvaluate_RetryPinned(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	repo := mocks.NewMockOutboxRepository(t)
+	svc := usecase.NewEvaluateService(jobs, nil, nil)
+	svc.Outbox = usecase.NewOutboxService(repo, nil, 0, 0)
+
+	jobs.EXPECT().Get(mock.Anything, "job-1").Return(domain.Job{ID: "job-1", Status: domain.JobFailed}, nil).Once()
+	jobs.EXPECT().Task(mock.Anything, "job-1").Return(domain.EvaluateTaskPayload{JobID: "job-1", CVID: "cv-1", Overrides: domain.EvaluationOverrides{MaxTokens: 800}}, nil).Once()
+	repo.EXPECT().Requeue(mock.Anything, domain.EvaluateTaskPayload{
+		JobID: "job-1", CVID: "cv-1",
+		Overrides: domain.EvaluationOverrides{MaxTokens: 800, Provider: "groq", Model: "llama-3.3-70b-versatile"},
+	}).Return(nil).Once()
+	require.NoError(t, svc.RetryPinned(context.Background(), "job-1", " Groq ", "llama-3.3-70b-versatile"))
+
+	// Only failed jobs with a recorded task can be retried.
+	jobs.EXPECT().Get(mock.Anything, "job-2").Return(domain.Job{ID: "job-2", Status: domain.JobCompleted}, nil).Once()
+	assert.ErrorIs(t, svc.RetryPinned(context.Background(), "job-2", "groq", "m"), domain.ErrConflict)
+	jobs.EXPECT().Get(mock.Anything, "job-3").Return(domain.Job{ID: "job-3", Status: domain.JobFailed}, nil).Once()
+	jobs.EXPECT().Task(mock.Anything, "job-3").Return(domain.EvaluateTaskPayload{}, fmt.Errorf("op=job.task: %w", domain.ErrNotFound)).Once()
+	assert.ErrorIs(t, svc.RetryPinned(context.Background(), "job-3", "groq", "m"), domain.ErrNotFound)
+}
+
+func TestEvaluate_RetryPinned_Invalid(t *testing.T) {
+	svc := usecase.NewEvaluateService(mocks.NewMockJobRepository(t), nil, nil)
+	assert.ErrorIs(t, svc.RetryPinned(context.Background(), "job-1", "groq", "m"), domain.ErrInvalidArgument)
+	svc.Outbox = usecase.NewOutboxService(mocks.NewMockOutboxRepository(t), nil, 0, 0)
+	for _, tc := range []struct{ provider, model string }{
+		{"anthropic", "m"},
+		{"", "m"},
+		{"openrouter", " "},
+	} {
+		assert.ErrorIs(t, svc.RetryPinned(context.Background(), "job-1", tc.provider, tc.model), domain.ErrInvalidArgument, tc)
+	}
+}
+
+func TestEvaluate_RetryPinned_DeferredJob(t *testing.T) {
+	ctx := context.Background()
+	jobs, queue, uploads := setupMocks()
+	maintenance := mocks.NewMockMaintenanceRepository(t)
+	outbox := mocks.NewMockOutboxRepository(t)
+	svc := usecase.NewEvaluateService(jobs, queue, uploads)
+	svc.Outbox = usecase.NewOutboxService(outbox, queue, 0, 0)
+	svc.Maintenance = usecase.NewMaintenanceService(maintenance, svc.Outbox, time.Minute)
+
+	// The task a job is deferred with is the one recorded on it.
+	maintenance.EXPECT().Get(mock.Anything).Return(domain.MaintenanceState{Mode: domain.MaintenanceDefer}, nil).Once()
+	require.NoError(t, svc.Maintenance.Sync(ctx))
+	var recorded domain.EvaluateTaskPayload
+	maintenance.EXPECT().CreateDeferred(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ domain.Context, _ domain.Job, p domain.EvaluateTaskPayload) (string, error) {
+			p.JobID = "job-1"
+			recorded = p
+			return "job-1", nil
+		}).Once()
+	id, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+
+	jobs.On("Get", mock.Anything, id).Return(domain.Job{ID: id, Status: domain.JobFailed}, nil).Once()
+	jobs.On("Task", mock.Anything, id).Return(recorded, nil).Once()
+	outbox.EXPECT().Requeue(mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.JobID == id && p.CVID == "cv-1" && p.ProjectID == "pr-1" && p.JobDescription == "jd" && p.Overrides.Model == "m"
+	})).Return(nil).Once()
+	require.NoError(t, svc.RetryPinned(ctx, id, "groq", "m"))
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// JobUsageService reports how long completed jobs spent in each phase of
+// their processing, separating time spent waiting on AI providers from the
+// cost of local processing for capacity planning.
+type JobUsageService struct {
+	Repo domain.JobUsageRepository
+
+	now func() time.Time
+}
+
+// NewJobUsageService constructs a JobUsageService over repo.
+func NewJobUsageService(repo domain.JobUsageRepository) *JobUsageService {
+	return &JobUsageService{Repo: repo, now: time.Now}
+}
+
+// Report summarises the jobs completed in the last window, which defaults
+// to 24h.
+func (s *JobUsageService) Report(ctx domain.Context, window time.Duration) (domain.JobUsageReport, error) {
+	if window == 0 {
+		window = 24 * time.Hour
+	}
+	if window < 0 {
+		return domain.JobUsageReport{}, fmt.Errorf("%w: window must be positive", domain.ErrInvalidArgument)
+	}
+	now := s.now().UTC()
+	report, err := s.Repo.Percentiles(ctx, now.Add(-window))
+	if err != nil {
+		return domain.JobUsageReport{}, fmt.Errorf("op=job_usage.percentiles: %w", err)
+	}
+	report.Until = now
+	return report, nil
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+type stubJobUsageRepo struct {
+	report domain.JobUsageReport
+	err    error
+	since  time.Time
+}
+
+func (s *stubJobUsageRepo) Record(domain.Context, domain.JobUsage) error { return nil }
+
+func (s *stubJobUsageRepo) Percentiles(_ domain.Context, since time.Time) (domain.JobUsageReport, error) {
+	s.since = since
+	s.report.Since = since
+	return s.report, s.err
+}
+
+func TestJobUsageService_Report(t *testing.T) {
+	now := time.Date(2026, 1, 6, 9, 30, 0, 0, time.UTC)
+	repo := &stubJobUsageRepo{report: domain.JobUsageReport{
+		Jobs:   3,
+		Phases: []domain.JobUsagePercentiles{{Phase: domain.UsagePhaseAI, AvgMS: 900, P50MS: 800, P90MS: 1500, P99MS: 1900}},
+	}}
+	svc := NewJobUsageService(repo)
+	svc.now = func() time.Time { return now }
+
+	rep, err := svc.Report(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), repo.since)
+	assert.Equal(t, now.Add(-24*time.Hour), rep.Since)
+	assert.Equal(t, now, rep.Until)
+	assert.Equal(t, int64(3), rep.Jobs)
+	require.Len(t, rep.Phases, 1)
+
+	_, err = svc.Report(context.Background(), -time.Hour)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	repo.err = errors.New("db down")
+	_, err = svc.Report(context.Background(), time.Hour)
+	assert.Error(t, err)
+}
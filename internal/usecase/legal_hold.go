@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// maxLegalHoldReason bounds the recorded reason of a legal hold change.
+const maxLegalHoldReason = 1000
+
+// LegalHoldService places and releases legal holds on uploads and jobs, which
+// keep them out of data retention, and reports who changed them and why.
+type LegalHoldService struct {
+	Repo domain.LegalHoldRepository
+
+	now func() time.Time
+}
+
+// NewLegalHoldService constructs a LegalHoldService.
+func NewLegalHoldService(repo domain.LegalHoldRepository) *LegalHoldService {
+	return &LegalHoldService{Repo: repo, now: time.Now}
+}
+
+func validLegalHoldTarget(targetType, targetID string) error {
+	if targetType != domain.LegalHoldUpload && targetType != domain.LegalHoldJob {
+		return fmt.Errorf("%w: target type must be %s or %s", domain.ErrInvalidArgument, domain.LegalHoldUpload, domain.LegalHoldJob)
+	}
+	if targetID == "" {
+		return fmt.Errorf("%w: target id is required", domain.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// Set places (held) or releases the hold on a target. actor and reason are
+// required so every change can be accounted for.
+func (s *LegalHoldService) Set(ctx domain.Context, targetType, targetID string, held bool, actor, reason string) (domain.LegalHold, error) {
+	if err := validLegalHoldTarget(targetType, targetID); err != nil {
+		return domain.LegalHold{}, err
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > maxLegalHoldReason {
+		return domain.LegalHold{}, fmt.Errorf("%w: reason must be 1-%d characters", domain.ErrInvalidArgument, maxLegalHoldReason)
+	}
+	if actor == "" {
+		return domain.LegalHold{}, fmt.Errorf("%w: the admin making the change is unknown", domain.ErrInvalidArgument)
+	}
+	h := domain.LegalHold{TargetType: targetType, TargetID: targetID, Held: held, Actor: actor, Reason: reason, CreatedAt: s.now().UTC()}
+	if err := s.Repo.Set(ctx, h); err != nil {
+		return domain.LegalHold{}, fmt.Errorf("op=legal_hold.set: %w", err)
+	}
+	slog.Info("legal hold changed", slog.String("target_type", targetType), slog.String("target_id", targetID),
+		slog.Bool("held", held), slog.String("actor", actor))
+	return h, nil
+}
+
+// History returns the changes of a target's hold, newest first.
+func (s *LegalHoldService) History(ctx domain.Context, targetType, targetID string) ([]domain.LegalHold, error) {
+	if err := validLegalHoldTarget(targetType, targetID); err != nil {
+		return nil, err
+	}
+	return s.Repo.History(ctx, targetType, targetID)
+}
+
+// ListHeld returns the holds in place, most recently placed first.
+func (s *LegalHoldService) ListHeld(ctx domain.Context) ([]domain.LegalHold, error) {
+	return s.Repo.ListHeld(ctx)
+}
@@ -0,0 +1,57 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestLegalHoldService_Set(t *testing.T) {
+	repo := mocks.NewMockLegalHoldRepository(t)
+	svc := usecase.NewLegalHoldService(repo)
+	ctx := context.Background()
+
+	for name, args := range map[string][4]string{
+		"bad type":    {"result", "r1", "admin", "audit"},
+		"no id":       {"job", "", "admin", "audit"},
+		"no actor":    {"job", "j1", "", "audit"},
+		"no reason":   {"job", "j1", "admin", "  "},
+		"long reason": {"job", "j1", "admin", strings.Repeat("x", 1001)},
+	} {
+		_, err := svc.Set(ctx, args[0], args[1], true, args[2], args[3])
+		require.ErrorIs(t, err, domain.ErrInvalidArgument, name)
+	}
+
+	repo.EXPECT().Set(mock.Anything, mock.MatchedBy(func(h domain.LegalHold) bool {
+		return h.TargetType == "upload" && h.TargetID == "u1" && h.Held && h.Actor == "admin" && h.Reason == "litigation 42" && !h.CreatedAt.IsZero()
+	})).Return(nil).Once()
+	h, err := svc.Set(ctx, domain.LegalHoldUpload, "u1", true, "admin", " litigation 42 ")
+	require.NoError(t, err)
+	assert.Equal(t, "litigation 42", h.Reason)
+
+	repo.EXPECT().Set(mock.Anything, mock.Anything).Return(domain.ErrNotFound).Once()
+	_, err = svc.Set(ctx, domain.LegalHoldJob, "gone", false, "admin", "closed")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestLegalHoldService_History(t *testing.T) {
+	repo := mocks.NewMockLegalHoldRepository(t)
+	svc := usecase.NewLegalHoldService(repo)
+
+	_, err := svc.History(context.Background(), "candidate", "c1")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	events := []domain.LegalHold{{TargetType: "job", TargetID: "j1", Held: false}, {TargetType: "job", TargetID: "j1", Held: true}}
+	repo.EXPECT().History(mock.Anything, "job", "j1").Return(events, nil).Once()
+	got, err := svc.History(context.Background(), "job", "j1")
+	require.NoError(t, err)
+	assert.Equal(t, events, got)
+}
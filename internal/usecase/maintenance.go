@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// releaseBatchSize bounds how many deferred evaluations are released at once.
+const releaseBatchSize = 100
+
+// MaintenanceService keeps this process's view of the maintenance mode in
+// sync with the shared repository and releases the evaluations deferred while
+// it was on. A nil *MaintenanceService reports maintenance as off.
+type MaintenanceService struct {
+	Repo domain.MaintenanceRepository
+	// Outbox relays released evaluations to the queue (optional; without it
+	// they wait for the next relay tick).
+	Outbox *OutboxService
+	// DefaultRetryAfter is advertised when a state does not set RetryAfter.
+	DefaultRetryAfter time.Duration
+	// Backpressure bounds releases by the backlog headroom (optional).
+	Backpressure *BackpressureService
+
+	now   func() time.Time
+	mu    sync.RWMutex
+	state domain.MaintenanceState
+}
+
+// NewMaintenanceService constructs a MaintenanceService; maintenance is off
+// until Sync or Set.
+func NewMaintenanceService(repo domain.MaintenanceRepository, outbox *OutboxService, defaultRetryAfter time.Duration) *MaintenanceService {
+	return &MaintenanceService{
+		Repo:              repo,
+		Outbox:            outbox,
+		DefaultRetryAfter: defaultRetryAfter,
+		now:               time.Now,
+		state:             domain.MaintenanceState{Mode: domain.MaintenanceOff},
+	}
+}
+
+// State returns the last known maintenance state.
+func (s *MaintenanceService) State() domain.MaintenanceState {
+	if s == nil {
+		return domain.MaintenanceState{Mode: domain.MaintenanceOff}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.state
+}
+
+func (s *MaintenanceService) setState(st domain.MaintenanceState) {
+	if st.Mode == "" {
+		st.Mode = domain.MaintenanceOff
+	}
+	if st.RetryAfter <= 0 {
+		st.RetryAfter = s.DefaultRetryAfter
+	}
+	s.mu.Lock()
+	s.state = st
+	s.mu.Unlock()
+}
+
+// Sync reloads the maintenance state from the repository.
+func (s *MaintenanceService) Sync(ctx domain.Context) error {
+	st, err := s.Repo.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("op=maintenance.sync: %w", err)
+	}
+	s.setState(st)
+	return nil
+}
+
+// Set stores st for every process and, when it turns maintenance off,
+// releases the deferred evaluations. It returns the stored state and the
+// number of evaluations released; evaluations that fail to release are
+// logged and left to Run.
+func (s *MaintenanceService) Set(ctx domain.Context, st domain.MaintenanceState) (domain.MaintenanceState, int, error) {
+	if !st.Mode.Valid() {
+		return domain.MaintenanceState{}, 0, fmt.Errorf("%w: unknown maintenance mode %q", domain.ErrInvalidArgument, st.Mode)
+	}
+	if st.RetryAfter < 0 {
+		return domain.MaintenanceState{}, 0, fmt.Errorf("%w: retry after must not be negative", domain.ErrInvalidArgument)
+	}
+	st.UpdatedAt = s.now().UTC()
+	if err := s.Repo.Set(ctx, st); err != nil {
+		return domain.MaintenanceState{}, 0, fmt.Errorf("op=maintenance.set: %w", err)
+	}
+	s.setState(st)
+	slog.Info("maintenance mode changed", slog.String("mode", string(st.Mode)))
+	if st.Active() {
+		return s.State(), 0, nil
+	}
+	released, err := s.Release(ctx)
+	if err != nil {
+		slog.Warn("deferred evaluation release failed", slog.Int("released", released), slog.Any("error", err))
+	}
+	return s.State(), released, nil
+}
+
+// Defer creates j with its task p held back from the queue until
+// maintenance ends and the backlog has room, and returns the job id.
+func (s *MaintenanceService) Defer(ctx domain.Context, j domain.Job, p domain.EvaluateTaskPayload) (string, error) {
+	id, err := s.Repo.CreateDeferred(ctx, j, p)
+	if err != nil {
+		return "", fmt.Errorf("op=maintenance.defer: %w", err)
+	}
+	return id, nil
+}
+
+// Deferred reports whether the task of job jobID is held back. A nil
+// *MaintenanceService holds nothing back.
+func (s *MaintenanceService) Deferred(ctx domain.Context, jobID string) (bool, error) {
+	if s == nil {
+		return false, nil
+	}
+	ok, err := s.Repo.Deferred(ctx, jobID)
+	if err != nil {
+		return false, fmt.Errorf("op=maintenance.deferred: %w", err)
+	}
+	return ok, nil
+}
+
+// Release moves the deferred evaluations, oldest first, to the evaluate
+// outbox and returns how many were released. With backpressure, only as many
+// as fit below the backlog limit are released. A task leaves the deferred set
+// only together with its outbox entry, so the outbox relay publishes every
+// released evaluation.
+func (s *MaintenanceService) Release(ctx domain.Context) (int, error) {
+	released := 0
+	defer func() {
+		if released > 0 && s.Outbox != nil {
+			s.Outbox.Notify()
+		}
+	}()
+	headroom, limited := s.Backpressure.Headroom(ctx)
+	for {
+		size := releaseBatchSize
+		if limited {
+			size = min(size, headroom-released)
+		}
+		if size <= 0 {
+			return released, nil
+		}
+		n, err := s.Repo.ReleaseDeferred(ctx, size)
+		if err != nil {
+			return released, fmt.Errorf("op=maintenance.release: %w", err)
+		}
+		released += n
+		if n < size {
+			if released > 0 {
+				slog.Info("deferred evaluations released", slog.Int("count", released))
+			}
+			return released, nil
+		}
+	}
+}
+
+// Run syncs the maintenance state every interval until ctx is done and
+// releases evaluations deferred by any process once maintenance is off,
+// including those deferred by backpressure as the backlog drains.
+func (s *MaintenanceService) Run(ctx context.Context, interval time.Duration) {
+	if s == nil || interval <= 0 {
+		return
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := s.Sync(ctx); err != nil {
+				slog.Warn("maintenance sync failed", slog.Any("error", err))
+				continue
+			}
+			if s.State().Active() {
+				continue
+			}
+			if _, err := s.Release(ctx); err != nil {
+				slog.Warn("deferred evaluation release failed", slog.Any("error", err))
+			}
+		}
+	}
+}
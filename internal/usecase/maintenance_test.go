@@ -0,0 +1,102 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestMaintenanceService_SetAndSync(t *testing.T) {
+	repo := mocks.NewMockMaintenanceRepository(t)
+	svc := usecase.NewMaintenanceService(repo, nil, 2*time.Minute)
+	ctx := context.Background()
+
+	var nilSvc *usecase.MaintenanceService
+	assert.Equal(t, domain.MaintenanceOff, nilSvc.State().Mode)
+	assert.Equal(t, domain.MaintenanceOff, svc.State().Mode)
+
+	_, _, err := svc.Set(ctx, domain.MaintenanceState{Mode: "paused"})
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	repo.EXPECT().Set(mock.Anything, mock.MatchedBy(func(s domain.MaintenanceState) bool {
+		return s.Mode == domain.MaintenanceReject && s.RetryAfter == 0 && !s.UpdatedAt.IsZero()
+	})).Return(nil).Once()
+	st, released, err := svc.Set(ctx, domain.MaintenanceState{Mode: domain.MaintenanceReject, Message: "upgrading workers"})
+	require.NoError(t, err)
+	assert.Zero(t, released)
+	assert.Equal(t, 2*time.Minute, st.RetryAfter, "default retry after applies")
+	assert.Equal(t, "upgrading workers", svc.State().Message)
+
+	repo.EXPECT().Get(mock.Anything).Return(domain.MaintenanceState{Mode: domain.MaintenanceDefer, RetryAfter: time.Minute}, nil).Once()
+	require.NoError(t, svc.Sync(ctx))
+	assert.Equal(t, domain.MaintenanceDefer, svc.State().Mode)
+	assert.Equal(t, time.Minute, svc.State().RetryAfter)
+
+	repo.EXPECT().Get(mock.Anything).Return(domain.MaintenanceState{}, assert.AnError).Once()
+	require.ErrorContains(t, svc.Sync(ctx), "op=maintenance.sync")
+	assert.Equal(t, domain.MaintenanceDefer, svc.State().Mode, "failed sync keeps the last state")
+}
+
+func TestMaintenanceService_SetOffReleasesDeferred(t *testing.T) {
+	repo := mocks.NewMockMaintenanceRepository(t)
+	outbox := usecase.NewOutboxService(mocks.NewMockOutboxRepository(t), &mocks.MockQueue{}, 0, 0)
+	svc := usecase.NewMaintenanceService(repo, outbox, time.Minute)
+	ctx := context.Background()
+
+	// Full batches are followed by another release until one comes up short.
+	repo.EXPECT().Set(mock.Anything, mock.Anything).Return(nil).Once()
+	repo.EXPECT().ReleaseDeferred(mock.Anything, 100).Return(100, nil).Once()
+	repo.EXPECT().ReleaseDeferred(mock.Anything, 100).Return(3, nil).Once()
+	st, released, err := svc.Set(ctx, domain.MaintenanceState{Mode: domain.MaintenanceOff})
+	require.NoError(t, err)
+	assert.Equal(t, domain.MaintenanceOff, st.Mode)
+	assert.Equal(t, 103, released)
+
+	// The deferred tasks stay put when the release fails; Run retries it.
+	repo.EXPECT().Set(mock.Anything, mock.Anything).Return(nil).Once()
+	repo.EXPECT().ReleaseDeferred(mock.Anything, 100).Return(0, errors.New("db down")).Once()
+	_, released, err = svc.Set(ctx, domain.MaintenanceState{Mode: domain.MaintenanceOff})
+	require.NoError(t, err, "a failed release is left to the periodic sync")
+	assert.Zero(t, released)
+}
+
+func TestEvaluate_Enqueue_Maintenance(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewMockMaintenanceRepository(t)
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	svc.Maintenance = usecase.NewMaintenanceService(repo, nil, time.Minute)
+
+	repo.EXPECT().Get(mock.Anything).Return(domain.MaintenanceState{Mode: domain.MaintenanceReject, Message: "upgrading workers"}, nil).Once()
+	require.NoError(t, svc.Maintenance.Sync(ctx))
+	_, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.ErrorIs(t, err, domain.ErrMaintenance)
+	assert.Contains(t, err.Error(), "upgrading workers")
+
+	repo.EXPECT().Get(mock.Anything).Return(domain.MaintenanceState{Mode: domain.MaintenanceDefer}, nil).Once()
+	require.NoError(t, svc.Maintenance.Sync(ctx))
+	repo.EXPECT().CreateDeferred(mock.Anything, mock.MatchedBy(func(j domain.Job) bool {
+		return j.Status == domain.JobQueued && j.CVID == "cv-1"
+	}), mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.CVID == "cv-1" && p.ProjectID == "pr-1"
+	})).Return("job-1", nil).Once()
+	jobID, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", jobID)
+	jobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	queue.AssertNotCalled(t, "EnqueueEvaluate", mock.Anything, mock.Anything)
+
+	// A failed deferral creates no job.
+	repo.EXPECT().CreateDeferred(mock.Anything, mock.Anything, mock.Anything).Return("", assert.AnError).Once()
+	_, err = svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.ErrorContains(t, err, "op=maintenance.defer")
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// outboxLease is how long a claimed outbox entry is hidden from other relays
+// before it is published again.
+const outboxLease = 30 * time.Second
+
+// outboxPurgeInterval bounds how often sent outbox entries are purged.
+const outboxPurgeInterval = time.Hour
+
+// outboxMaxAttempts is the default number of publish attempts before an entry
+// is parked.
+const outboxMaxAttempts = 10
+
+// OutboxService creates jobs together with their evaluation task in the
+// outbox and relays pending tasks to the queue. A task is published at least
+// once for every created job and never for a job that was not created;
+// redeliveries of finished jobs are skipped by the worker.
+type OutboxService struct {
+	Repo  domain.OutboxRepository
+	Queue domain.Queue
+	// BatchSize bounds how many entries are claimed at once.
+	BatchSize int
+	// Lease is how long a claimed entry waits before it is retried.
+	Lease time.Duration
+	// Retention is how long sent entries are kept (0 keeps them).
+	Retention time.Duration
+	// MaxAttempts is how many publish attempts an entry gets before it is
+	// parked and its job failed (0 retries forever).
+	MaxAttempts int
+
+	now       func() time.Time
+	wake      chan struct{}
+	lastPurge time.Time
+}
+
+// NewOutboxService constructs an OutboxService. A non-positive batchSize
+// defaults to 100.
+func NewOutboxService(repo domain.OutboxRepository, queue domain.Queue, batchSize int, retention time.Duration) *OutboxService {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	return &OutboxService{
+		Repo:        repo,
+		Queue:       queue,
+		BatchSize:   batchSize,
+		Lease:       outboxLease,
+		Retention:   retention,
+		MaxAttempts: outboxMaxAttempts,
+		now:         time.Now,
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// CreateJob stores j and its task p atomically and wakes the relay. It
+// returns the job id.
+func (o *OutboxService) CreateJob(ctx domain.Context, j domain.Job, p domain.EvaluateTaskPayload) (string, error) {
+	id, err := o.Repo.CreateJob(ctx, j, p)
+	if err != nil {
+		return "", fmt.Errorf("op=outbox.create_job: %w", err)
+	}
+	o.Notify()
+	return id, nil
+}
+
+// Notify makes Run relay without waiting for the next tick.
+func (o *OutboxService) Notify() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Relay publishes the pending tasks, oldest first, and returns how many were
+// published. An entry that fails to publish is logged and retried once its
+// lease expires, while the pass goes on with the next one; after MaxAttempts
+// it is parked and its job failed. A repository failure stops the pass.
+func (o *OutboxService) Relay(ctx domain.Context) (int, error) {
+	sent := 0
+	for {
+		batch, err := o.Repo.ClaimPending(ctx, o.BatchSize, o.Lease)
+		if err != nil {
+			return sent, fmt.Errorf("op=outbox.relay: %w", err)
+		}
+		for _, e := range batch {
+			if e.Attempts > 1 {
+				slog.Warn("republishing outbox entry", slog.String("job_id", e.Payload.JobID), slog.Int("attempts", e.Attempts))
+			}
+			if _, err := o.Queue.EnqueueEvaluate(ctx, e.Payload); err != nil {
+				if o.MaxAttempts > 0 && e.Attempts >= o.MaxAttempts {
+					reason := fmt.Sprintf("enqueue failed after %d attempts: %v", e.Attempts, err)
+					if err := o.Repo.Park(ctx, e.ID, reason); err != nil {
+						return sent, fmt.Errorf("op=outbox.relay_park: %w", err)
+					}
+					slog.Error("outbox entry parked", slog.String("job_id", e.Payload.JobID), slog.Int("attempts", e.Attempts), slog.Any("error", err))
+					continue
+				}
+				slog.Warn("outbox publish failed", slog.String("job_id", e.Payload.JobID), slog.Int("attempts", e.Attempts), slog.Any("error", err))
+				continue
+			}
+			if err := o.Repo.MarkSent(ctx, e.ID); err != nil {
+				return sent, fmt.Errorf("op=outbox.relay_mark_sent: %w", err)
+			}
+			sent++
+		}
+		if len(batch) < o.BatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// purge deletes sent entries older than Retention, at most once per
+// outboxPurgeInterval.
+func (o *OutboxService) purge(ctx domain.Context) {
+	now := o.now()
+	if o.Retention <= 0 || now.Sub(o.lastPurge) < outboxPurgeInterval {
+		return
+	}
+	o.lastPurge = now
+	n, err := o.Repo.PurgeSent(ctx, now.Add(-o.Retention))
+	if err != nil {
+		slog.Warn("outbox purge failed", slog.Any("error", err))
+		return
+	}
+	if n > 0 {
+		slog.Info("outbox entries purged", slog.Int64("count", n))
+	}
+}
+
+// Run relays pending tasks every interval, and as soon as CreateJob stores
+// one, until ctx is done. Tasks left by a crashed process are picked up by
+// any running one.
+func (o *OutboxService) Run(ctx context.Context, interval time.Duration) {
+	if o == nil || interval <= 0 {
+		return
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		case <-o.wake:
+		}
+		if _, err := o.Relay(ctx); err != nil {
+			slog.Warn("outbox relay failed", slog.Any("error", err))
+		}
+		o.purge(ctx)
+	}
+}
@@ -0,0 +1,126 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestEvaluate_Enqueue_Outbox(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	repo := mocks.NewMockOutboxRepository(t)
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	svc.Outbox = usecase.NewOutboxService(repo, queue, 0, 0)
+	repo.EXPECT().CreateJob(mock.Anything, mock.MatchedBy(func(j domain.Job) bool { return j.Status == domain.JobQueued && j.CVID == "cv-1" }),
+		mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+			return p.CVID == "cv-1" && p.ProjectID == "pr-1" && p.JobID == ""
+		})).
+		Return("job-1", nil).Once()
+
+	id, err := svc.Enqueue(context.Background(), "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	assert.Equal(t, "job-1", id)
+	// The relay publishes the task, not the request.
+	jobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	queue.AssertNotCalled(t, "EnqueueEvaluate", mock.Anything, mock.Anything)
+
+	repo.EXPECT().CreateJob(mock.Anything, mock.Anything, mock.Anything).Return("", assert.AnError).Once()
+	_, err = svc.Enqueue(context.Background(), "cv-1", "pr-1", "jd", "sc", "sr", "")
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestOutboxService_Relay(t *testing.T) {
+	_, queue, _ := setupMocks()
+	repo := mocks.NewMockOutboxRepository(t)
+	o := usecase.NewOutboxService(repo, queue, 2, 0)
+	ctx := context.Background()
+
+	repo.EXPECT().ClaimPending(mock.Anything, 2, 30*time.Second).Return([]domain.OutboxEntry{
+		{ID: 1, Payload: domain.EvaluateTaskPayload{JobID: "j1"}, Attempts: 1},
+		{ID: 2, Payload: domain.EvaluateTaskPayload{JobID: "j2"}, Attempts: 2},
+	}, nil).Once()
+	repo.EXPECT().ClaimPending(mock.Anything, 2, 30*time.Second).Return([]domain.OutboxEntry{
+		{ID: 3, Payload: domain.EvaluateTaskPayload{JobID: "j3"}, Attempts: 1},
+	}, nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.Anything).Return("t", nil).Times(3)
+	for _, id := range []int64{1, 2, 3} {
+		repo.EXPECT().MarkSent(mock.Anything, id).Return(nil).Once()
+	}
+	sent, err := o.Relay(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, sent)
+
+	// A publish failure leaves the entry unsent for a later claim and the
+	// pass goes on with the next entry.
+	repo.EXPECT().ClaimPending(mock.Anything, 2, 30*time.Second).Return([]domain.OutboxEntry{
+		{ID: 4, Payload: domain.EvaluateTaskPayload{JobID: "j4"}, Attempts: 1},
+		{ID: 5, Payload: domain.EvaluateTaskPayload{JobID: "j5"}, Attempts: 1},
+	}, nil).Once()
+	repo.EXPECT().ClaimPending(mock.Anything, 2, 30*time.Second).Return(nil, nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, domain.EvaluateTaskPayload{JobID: "j4"}).Return("", assert.AnError).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, domain.EvaluateTaskPayload{JobID: "j5"}).Return("t", nil).Once()
+	repo.EXPECT().MarkSent(mock.Anything, int64(5)).Return(nil).Once()
+	sent, err = o.Relay(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	repo.AssertNotCalled(t, "MarkSent", mock.Anything, int64(4))
+}
+
+func TestOutboxService_Relay_ParksAfterMaxAttempts(t *testing.T) {
+	_, queue, _ := setupMocks()
+	repo := mocks.NewMockOutboxRepository(t)
+	o := usecase.NewOutboxService(repo, queue, 10, 0)
+	o.MaxAttempts = 3
+
+	repo.EXPECT().ClaimPending(mock.Anything, 10, 30*time.Second).Return([]domain.OutboxEntry{
+		{ID: 1, Payload: domain.EvaluateTaskPayload{JobID: "j1"}, Attempts: 2},
+		{ID: 2, Payload: domain.EvaluateTaskPayload{JobID: "j2"}, Attempts: 3},
+	}, nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.Anything).Return("", assert.AnError).Twice()
+	repo.EXPECT().Park(mock.Anything, int64(2), mock.MatchedBy(func(reason string) bool {
+		return strings.HasPrefix(reason, "enqueue failed after 3 attempts")
+	})).Return(nil).Once()
+	sent, err := o.Relay(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	repo.AssertNotCalled(t, "Park", mock.Anything, int64(1), mock.Anything)
+
+	// Parking is a repository write; its failure stops the pass.
+	repo.EXPECT().ClaimPending(mock.Anything, 10, 30*time.Second).Return([]domain.OutboxEntry{
+		{ID: 3, Payload: domain.EvaluateTaskPayload{JobID: "j3"}, Attempts: 3},
+	}, nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.Anything).Return("", assert.AnError).Once()
+	repo.EXPECT().Park(mock.Anything, int64(3), mock.Anything).Return(assert.AnError).Once()
+	_, err = o.Relay(context.Background())
+	assert.ErrorContains(t, err, "op=outbox.relay_park")
+}
+
+func TestOutboxService_RunRelaysOnNotify(t *testing.T) {
+	_, queue, _ := setupMocks()
+	repo := mocks.NewMockOutboxRepository(t)
+	o := usecase.NewOutboxService(repo, queue, 10, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	relayed := make(chan struct{})
+	repo.EXPECT().ClaimPending(mock.Anything, 10, 30*time.Second).Return([]domain.OutboxEntry{{ID: 1, Payload: domain.EvaluateTaskPayload{JobID: "j1"}}}, nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.Anything).Return("t", nil).Once()
+	repo.EXPECT().MarkSent(mock.Anything, int64(1)).Run(func(domain.Context, int64) { close(relayed) }).Return(nil).Once()
+
+	go o.Run(ctx, time.Hour)
+	o.Notify()
+	select {
+	case <-relayed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("outbox was not relayed after Notify")
+	}
+}
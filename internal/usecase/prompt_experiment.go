@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+const maxPromptExperimentName = 200
+
+// PromptExperimentService manages prompt A/B experiments and reports how
+// their variants compare.
+type PromptExperimentService struct {
+	Repo domain.PromptExperimentRepository
+
+	// hasVersion reports whether a step has a prompt template at a version.
+	hasVersion func(step, version string) bool
+	now        func() time.Time
+}
+
+// NewPromptExperimentService constructs a PromptExperimentService. hasVersion
+// checks the prompt versions experiments may use, e.g.
+// redpanda.HasPromptVersion.
+func NewPromptExperimentService(repo domain.PromptExperimentRepository, hasVersion func(step, version string) bool) *PromptExperimentService {
+	return &PromptExperimentService{Repo: repo, hasVersion: hasVersion, now: time.Now}
+}
+
+// Create validates and starts an experiment. It returns ErrConflict when the
+// step already has an active experiment.
+func (s *PromptExperimentService) Create(ctx domain.Context, e domain.PromptExperiment) (domain.PromptExperiment, error) {
+	e.Name = strings.TrimSpace(e.Name)
+	if e.Name == "" || len(e.Name) > maxPromptExperimentName {
+		return domain.PromptExperiment{}, fmt.Errorf("%w: name must be 1-%d characters", domain.ErrInvalidArgument, maxPromptExperimentName)
+	}
+	if e.VersionA == e.VersionB {
+		return domain.PromptExperiment{}, fmt.Errorf("%w: version_a and version_b must differ", domain.ErrInvalidArgument)
+	}
+	for _, v := range []string{e.VersionA, e.VersionB} {
+		if !s.hasVersion(e.Step, v) {
+			return domain.PromptExperiment{}, fmt.Errorf("%w: step %q has no prompt version %q", domain.ErrInvalidArgument, e.Step, v)
+		}
+	}
+	if e.TrafficB < 0 || e.TrafficB > 100 {
+		return domain.PromptExperiment{}, fmt.Errorf("%w: traffic_b must be between 0 and 100", domain.ErrInvalidArgument)
+	}
+	e.CreatedAt = s.now().UTC()
+	return s.Repo.Create(ctx, e)
+}
+
+// List returns all experiments, newest first.
+func (s *PromptExperimentService) List(ctx domain.Context) ([]domain.PromptExperiment, error) {
+	return s.Repo.List(ctx)
+}
+
+// End stops an active experiment; its jobs stay in the report.
+func (s *PromptExperimentService) End(ctx domain.Context, id string) error {
+	return s.Repo.End(ctx, id, s.now().UTC())
+}
+
+// Report compares the score distributions, parse-failure rates and latency
+// of an experiment's variants.
+func (s *PromptExperimentService) Report(ctx domain.Context, id string) (domain.PromptExperimentReport, error) {
+	e, err := s.Repo.Get(ctx, id)
+	if err != nil {
+		return domain.PromptExperimentReport{}, err
+	}
+	stats, err := s.Repo.Stats(ctx, id)
+	if err != nil {
+		return domain.PromptExperimentReport{}, fmt.Errorf("op=prompt_experiment.report: %w", err)
+	}
+	rep := domain.PromptExperimentReport{Experiment: e}
+	for _, variant := range []string{domain.PromptVariantA, domain.PromptVariantB} {
+		vs := domain.PromptVariantStats{Variant: variant}
+		for _, st := range stats {
+			if st.Variant == variant {
+				vs = st
+			}
+		}
+		vs.Version = e.Version(variant)
+		rep.Variants = append(rep.Variants, vs)
+	}
+	return rep, nil
+}
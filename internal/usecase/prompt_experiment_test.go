@@ -0,0 +1,64 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func cvMatchVersions(step, version string) bool {
+	return step == "cv_match" && (version == "1" || version == "2")
+}
+
+func TestPromptExperimentService_Create(t *testing.T) {
+	repo := mocks.NewMockPromptExperimentRepository(t)
+	svc := usecase.NewPromptExperimentService(repo, cvMatchVersions)
+	ctx := context.Background()
+
+	for _, e := range []domain.PromptExperiment{
+		{Name: " ", Step: "cv_match", VersionA: "1", VersionB: "2"},
+		{Name: "same", Step: "cv_match", VersionA: "1", VersionB: "1"},
+		{Name: "unknown version", Step: "cv_match", VersionA: "1", VersionB: "3"},
+		{Name: "unknown step", Step: "refine", VersionA: "1", VersionB: "2"},
+		{Name: "traffic", Step: "cv_match", VersionA: "1", VersionB: "2", TrafficB: 101},
+	} {
+		_, err := svc.Create(ctx, e)
+		require.ErrorIs(t, err, domain.ErrInvalidArgument, e.Name)
+	}
+
+	repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(e domain.PromptExperiment) bool {
+		return e.Name == "evidence" && !e.CreatedAt.IsZero()
+	})).Return(domain.PromptExperiment{ID: "e1"}, nil).Once()
+	got, err := svc.Create(ctx, domain.PromptExperiment{Name: " evidence ", Step: "cv_match", VersionA: "1", VersionB: "2", TrafficB: 20})
+	require.NoError(t, err)
+	assert.Equal(t, "e1", got.ID)
+}
+
+func TestPromptExperimentService_Report(t *testing.T) {
+	repo := mocks.NewMockPromptExperimentRepository(t)
+	svc := usecase.NewPromptExperimentService(repo, cvMatchVersions)
+	ctx := context.Background()
+	e := domain.PromptExperiment{ID: "e1", Step: "cv_match", VersionA: "1", VersionB: "2", TrafficB: 20}
+
+	repo.EXPECT().Get(mock.Anything, "e1").Return(e, nil).Once()
+	repo.EXPECT().Stats(mock.Anything, "e1").Return([]domain.PromptVariantStats{{Variant: "b", Jobs: 3, Completed: 3}}, nil).Once()
+	rep, err := svc.Report(ctx, "e1")
+	require.NoError(t, err)
+	assert.Equal(t, e, rep.Experiment)
+	// Variant A is listed even before any of its jobs ran.
+	assert.Equal(t, []domain.PromptVariantStats{
+		{Variant: "a", Version: "1"},
+		{Variant: "b", Version: "2", Jobs: 3, Completed: 3},
+	}, rep.Variants)
+
+	repo.EXPECT().Get(mock.Anything, "e2").Return(domain.PromptExperiment{}, domain.ErrNotFound).Once()
+	_, err = svc.Report(ctx, "e2")
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
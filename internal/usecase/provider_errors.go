@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// providerErrorMaxBuckets bounds the series of a provider error report.
+const providerErrorMaxBuckets = 720
+
+// ProviderErrorService reports the most frequent fingerprints of failed AI
+// provider calls over time, so that recurring failures such as a retired
+// model or an exhausted quota stand out from one-off errors.
+type ProviderErrorService struct {
+	Repo domain.ProviderErrorRepository
+
+	now func() time.Time
+}
+
+// NewProviderErrorService constructs a ProviderErrorService over repo.
+func NewProviderErrorService(repo domain.ProviderErrorRepository) *ProviderErrorService {
+	return &ProviderErrorService{Repo: repo, now: time.Now}
+}
+
+// Report returns the limit fingerprint and provider pairs that occurred most
+// often in the last window, each with its occurrences per bucket. window
+// defaults to 24h, bucket to 1h and limit to 10, capped at 50. The window
+// starts at a bucket boundary and ends with the current bucket.
+func (s *ProviderErrorService) Report(ctx domain.Context, window, bucket time.Duration, limit int) (domain.ProviderErrorReport, error) {
+	if window == 0 {
+		window = 24 * time.Hour
+	}
+	if bucket == 0 {
+		bucket = time.Hour
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	limit = min(limit, 50)
+	switch {
+	case window < 0:
+		return domain.ProviderErrorReport{}, fmt.Errorf("%w: window must be positive", domain.ErrInvalidArgument)
+	case bucket < time.Minute:
+		return domain.ProviderErrorReport{}, fmt.Errorf("%w: bucket must be at least 1m", domain.ErrInvalidArgument)
+	case window/bucket > providerErrorMaxBuckets:
+		return domain.ProviderErrorReport{}, fmt.Errorf("%w: window spans more than %d buckets", domain.ErrInvalidArgument, providerErrorMaxBuckets)
+	}
+	now := s.now().UTC()
+	since := alignToBucket(now.Add(-window), bucket)
+	until := alignToBucket(now, bucket).Add(bucket)
+	n := int(until.Sub(since) / bucket)
+
+	top, err := s.Repo.Top(ctx, since, limit)
+	if err != nil {
+		return domain.ProviderErrorReport{}, fmt.Errorf("op=provider_errors.top: %w", err)
+	}
+	buckets, err := s.Repo.Buckets(ctx, since, bucket)
+	if err != nil {
+		return domain.ProviderErrorReport{}, fmt.Errorf("op=provider_errors.buckets: %w", err)
+	}
+	type key struct {
+		fp       domain.ProviderErrorFingerprint
+		provider string
+	}
+	report := domain.ProviderErrorReport{Since: since, Until: until, Bucket: bucket, Top: make([]domain.ProviderErrorTrend, len(top))}
+	series := make(map[key][]int64, len(top))
+	for i, c := range top {
+		report.Top[i] = domain.ProviderErrorTrend{ProviderErrorCount: c, Series: make([]int64, n)}
+		series[key{c.Fingerprint, c.Provider}] = report.Top[i].Series
+	}
+	for _, b := range buckets {
+		points, ok := series[key{b.Fingerprint, b.Provider}]
+		if i := int(b.Start.Sub(since) / bucket); ok && i >= 0 && i < n {
+			points[i] += b.Count
+		}
+	}
+	return report, nil
+}
+
+// alignToBucket returns the start of the bucket of length bucket, counted
+// from the Unix epoch, that t falls in.
+func alignToBucket(t time.Time, bucket time.Duration) time.Time {
+	ns := t.UnixNano()
+	return time.Unix(0, ns-ns%int64(bucket)).UTC()
+}
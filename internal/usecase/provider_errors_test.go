@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+type stubProviderErrorRepo struct {
+	top     []domain.ProviderErrorCount
+	buckets []domain.ProviderErrorBucket
+	since   time.Time
+	limit   int
+}
+
+func (s *stubProviderErrorRepo) Record(domain.Context, domain.ProviderError) error { return nil }
+
+func (s *stubProviderErrorRepo) Top(_ domain.Context, since time.Time, limit int) ([]domain.ProviderErrorCount, error) {
+	s.since, s.limit = since, limit
+	return s.top, nil
+}
+
+func (s *stubProviderErrorRepo) Buckets(domain.Context, time.Time, time.Duration) ([]domain.ProviderErrorBucket, error) {
+	return s.buckets, nil
+}
+
+func TestProviderErrorService_Report(t *testing.T) {
+	hour := func(h int) time.Time { return time.Date(2026, 1, 4, h, 0, 0, 0, time.UTC) }
+	repo := &stubProviderErrorRepo{
+		top: []domain.ProviderErrorCount{
+			{Fingerprint: domain.ProviderErrorModelNotFound, Provider: "groq", Count: 5},
+			{Fingerprint: domain.ProviderErrorQuota, Provider: "openrouter", Count: 2},
+		},
+		buckets: []domain.ProviderErrorBucket{
+			{Start: hour(6), Fingerprint: domain.ProviderErrorModelNotFound, Provider: "groq", Count: 1},
+			{Start: hour(6), Fingerprint: domain.ProviderErrorQuota, Provider: "openrouter", Count: 2},
+			{Start: hour(9), Fingerprint: domain.ProviderErrorModelNotFound, Provider: "groq", Count: 4},
+			// Fingerprints outside the top are left out.
+			{Start: hour(9), Fingerprint: domain.ProviderErrorAuth, Provider: "groq", Count: 1},
+		},
+	}
+	svc := NewProviderErrorService(repo)
+	svc.now = func() time.Time { return hour(9).Add(25 * time.Minute) }
+
+	rep, err := svc.Report(context.Background(), 3*time.Hour, time.Hour, 0)
+	require.NoError(t, err)
+	assert.Equal(t, hour(6), rep.Since)
+	assert.Equal(t, hour(10), rep.Until)
+	assert.Equal(t, hour(6), repo.since)
+	assert.Equal(t, 10, repo.limit)
+	require.Len(t, rep.Top, 2)
+	assert.Equal(t, []int64{1, 0, 0, 4}, rep.Top[0].Series)
+	assert.Equal(t, []int64{2, 0, 0, 0}, rep.Top[1].Series)
+
+	for _, bad := range [][2]time.Duration{{-time.Hour, time.Hour}, {time.Hour, time.Second}, {60 * 24 * time.Hour, time.Hour}} {
+		_, err := svc.Report(context.Background(), bad[0], bad[1], 0)
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument, bad)
+	}
+}
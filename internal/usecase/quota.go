@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// QuotaService enforces tenants' daily evaluation and token quotas and tells
+// clients when they come close to them. Days are UTC. A nil *QuotaService
+// enforces no quotas.
+type QuotaService struct {
+	Repo domain.TenantQuotaRepository
+	// WarnRatio is the share of a quota above which clients are warned.
+	WarnRatio float64
+
+	now func() time.Time
+}
+
+// NewQuotaService constructs a QuotaService. A warnRatio outside (0, 1]
+// defaults to 0.8.
+func NewQuotaService(repo domain.TenantQuotaRepository, warnRatio float64) *QuotaService {
+	if warnRatio <= 0 || warnRatio > 1 {
+		warnRatio = 0.8
+	}
+	return &QuotaService{Repo: repo, WarnRatio: warnRatio, now: time.Now}
+}
+
+// QuotaStatus is how much of its daily quotas a tenant has left.
+type QuotaStatus struct {
+	// Remaining is the number of evaluations left today; -1 without an
+	// evaluation quota.
+	Remaining int
+	// RemainingTokens is the number of tokens left today; -1 without a token
+	// budget.
+	RemainingTokens int64
+	// Reset is when the quotas reset (the next UTC midnight).
+	Reset time.Time
+	// Warn is set once the usage of a quota passed WarnRatio.
+	Warn bool
+}
+
+// Consume counts one evaluation for tenant and returns its status. It returns
+// an ErrRateLimited error, along with the exhausted status, when a quota is
+// used up. Tenants without quotas are not counted and get a zero status.
+func (s *QuotaService) Consume(ctx domain.Context, tenant domain.TenantSettings) (QuotaStatus, error) {
+	if s == nil || tenant.TenantID == "" || (tenant.DailyEvaluations <= 0 && tenant.DailyTokens <= 0) {
+		return QuotaStatus{}, nil
+	}
+	now := s.now().UTC()
+	st := QuotaStatus{Remaining: -1, RemainingTokens: -1, Reset: now.Truncate(24 * time.Hour).Add(24 * time.Hour)}
+	used, err := s.Repo.Consume(ctx, tenant.TenantID, now, tenant.DailyEvaluations, tenant.DailyTokens)
+	if errors.Is(err, domain.ErrRateLimited) {
+		if tenant.DailyEvaluations > 0 {
+			st.Remaining = 0
+		}
+		if tenant.DailyTokens > 0 {
+			st.RemainingTokens = 0
+		}
+		st.Warn = true
+		return st, err
+	}
+	if err != nil {
+		return QuotaStatus{}, fmt.Errorf("op=quota.consume: %w", err)
+	}
+	if limit := tenant.DailyEvaluations; limit > 0 {
+		st.Remaining = max(limit-used.Evaluations, 0)
+		st.Warn = float64(used.Evaluations) > s.WarnRatio*float64(limit)
+	}
+	if limit := tenant.DailyTokens; limit > 0 {
+		st.RemainingTokens = max(limit-used.Tokens, 0)
+		st.Warn = st.Warn || float64(used.Tokens) > s.WarnRatio*float64(limit)
+	}
+	return st, nil
+}
+
+// Refund takes back the evaluation counted by the Consume that returned st,
+// for a submission that created no job after all. A zero st counted none.
+func (s *QuotaService) Refund(ctx domain.Context, tenant domain.TenantSettings, st QuotaStatus) (QuotaStatus, error) {
+	if s == nil || st.Reset.IsZero() {
+		return st, nil
+	}
+	if err := s.Repo.Refund(ctx, tenant.TenantID, st.Reset.Add(-24*time.Hour)); err != nil {
+		return st, fmt.Errorf("op=quota.refund: %w", err)
+	}
+	if st.Remaining >= 0 {
+		st.Remaining++
+	}
+	return st, nil
+}
+
+type quotaStatusKey struct{}
+
+// WithQuotaStatus returns a context in which Enqueue reports the quota status
+// of the submitting tenant into the returned QuotaStatus.
+func WithQuotaStatus(ctx context.Context) (context.Context, *QuotaStatus) {
+	st := &QuotaStatus{}
+	return context.WithValue(ctx, quotaStatusKey{}, st), st
+}
+
+// reportQuotaStatus stores st in the QuotaStatus attached to ctx, if any.
+func reportQuotaStatus(ctx context.Context, st QuotaStatus) {
+	if dst, ok := ctx.Value(quotaStatusKey{}).(*QuotaStatus); ok {
+		*dst = st
+	}
+}
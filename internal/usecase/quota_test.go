@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestQuotaService_Consume(t *testing.T) {
+	repo := mocks.NewMockTenantQuotaRepository(t)
+	svc := usecase.NewQuotaService(repo, 0)
+	assert.Equal(t, 0.8, svc.WarnRatio)
+	ctx := context.Background()
+	acme := domain.TenantSettings{TenantID: "acme", DailyEvaluations: 10, DailyTokens: 100000}
+
+	// Tenants without quotas and anonymous requests are not counted.
+	st, err := svc.Consume(ctx, domain.TenantSettings{TenantID: "free"})
+	require.NoError(t, err)
+	assert.Equal(t, usecase.QuotaStatus{}, st)
+	var none *usecase.QuotaService
+	_, err = none.Consume(ctx, acme)
+	require.NoError(t, err)
+
+	// At 80% of both quotas the tenant is not warned yet.
+	repo.EXPECT().Consume(mock.Anything, "acme", mock.Anything, 10, int64(100000)).Return(domain.TenantQuotaUsage{Evaluations: 8, Tokens: 80000}, nil).Once()
+	st, err = svc.Consume(ctx, acme)
+	require.NoError(t, err)
+	assert.False(t, st.Warn)
+	assert.Equal(t, 2, st.Remaining)
+	assert.Equal(t, int64(20000), st.RemainingTokens)
+	assert.True(t, st.Reset.After(time.Now()) && time.Until(st.Reset) <= 24*time.Hour)
+
+	// Past it on either quota the tenant is warned.
+	repo.EXPECT().Consume(mock.Anything, "acme", mock.Anything, 10, int64(100000)).Return(domain.TenantQuotaUsage{Evaluations: 3, Tokens: 95000}, nil).Once()
+	st, err = svc.Consume(ctx, acme)
+	require.NoError(t, err)
+	assert.True(t, st.Warn)
+	assert.Equal(t, int64(5000), st.RemainingTokens)
+
+	// An exhausted quota rejects the evaluation.
+	repo.EXPECT().Consume(mock.Anything, "acme", mock.Anything, 0, int64(100000)).
+		Return(domain.TenantQuotaUsage{}, fmt.Errorf("op=tenant_quota.consume: %w", domain.ErrRateLimited)).Once()
+	st, err = svc.Consume(ctx, domain.TenantSettings{TenantID: "acme", DailyTokens: 100000})
+	require.ErrorIs(t, err, domain.ErrRateLimited)
+	assert.Equal(t, -1, st.Remaining)
+	assert.Equal(t, int64(0), st.RemainingTokens)
+	assert.True(t, st.Warn)
+
+	repo.EXPECT().Consume(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domain.TenantQuotaUsage{}, assert.AnError).Once()
+	_, err = svc.Consume(ctx, acme)
+	require.ErrorIs(t, err, assert.AnError)
+	assert.NotErrorIs(t, err, domain.ErrRateLimited)
+}
+
+func TestEvaluate_Enqueue_TenantQuota(t *testing.T) {
+	tenants := mocks.NewMockTenantSettingsRepository(t)
+	quota := mocks.NewMockTenantQuotaRepository(t)
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	svc.Tenants = usecase.NewTenantService(tenants)
+	svc.Quota = usecase.NewQuotaService(quota, 0.8)
+	tenants.EXPECT().GetByAPIKeyHash(mock.Anything, mock.Anything).Return(domain.TenantSettings{TenantID: "acme", DailyEvaluations: 10}, nil)
+	ctx, st := usecase.WithQuotaStatus(domain.WithTenantAPIKey(context.Background(), "k1"))
+
+	quota.EXPECT().Consume(mock.Anything, "acme", mock.Anything, 10, int64(0)).Return(domain.TenantQuotaUsage{Evaluations: 9}, nil).Once()
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-1", nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.Anything).Return("t1", nil).Once()
+	_, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	assert.True(t, st.Warn)
+	assert.Equal(t, 1, st.Remaining)
+
+	// An exhausted quota creates no job.
+	quota.EXPECT().Consume(mock.Anything, "acme", mock.Anything, 10, int64(0)).
+		Return(domain.TenantQuotaUsage{}, fmt.Errorf("op=tenant_quota.consume: %w", domain.ErrRateLimited)).Once()
+	_, err = svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.ErrorIs(t, err, domain.ErrRateLimited)
+	assert.Equal(t, 0, st.Remaining)
+	jobRepo.AssertNumberOfCalls(t, "Create", 1)
+
+	// A submission whose job is not created, or not enqueued, is refunded.
+	quota.EXPECT().Consume(mock.Anything, "acme", mock.Anything, 10, int64(0)).Return(domain.TenantQuotaUsage{Evaluations: 10}, nil).Twice()
+	quota.EXPECT().Refund(mock.Anything, "acme", mock.Anything).Return(nil).Twice()
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("", assert.AnError).Once()
+	_, err = svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 1, st.Remaining)
+
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-2", nil).Once()
+	jobRepo.On("UpdateStatus", mock.Anything, "job-2", domain.JobFailed, mock.Anything).Return(nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.Anything).Return("", assert.AnError).Once()
+	_, err = svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 1, st.Remaining)
+}
+
+func TestQuotaService_Refund(t *testing.T) {
+	repo := mocks.NewMockTenantQuotaRepository(t)
+	svc := usecase.NewQuotaService(repo, 0)
+	ctx := context.Background()
+	acme := domain.TenantSettings{TenantID: "acme", DailyEvaluations: 10}
+	reset := time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC)
+
+	// Nothing was counted for a zero status.
+	st, err := svc.Refund(ctx, acme, usecase.QuotaStatus{})
+	require.NoError(t, err)
+	assert.Equal(t, usecase.QuotaStatus{}, st)
+
+	repo.EXPECT().Refund(mock.Anything, "acme", reset.Add(-24*time.Hour)).Return(nil).Once()
+	st, err = svc.Refund(ctx, acme, usecase.QuotaStatus{Remaining: 2, RemainingTokens: -1, Reset: reset})
+	require.NoError(t, err)
+	assert.Equal(t, 3, st.Remaining)
+
+	repo.EXPECT().Refund(mock.Anything, "acme", mock.Anything).Return(assert.AnError).Once()
+	st, err = svc.Refund(ctx, acme, usecase.QuotaStatus{Remaining: 2, Reset: reset})
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 2, st.Remaining)
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"go.opentelemetry.io/otel"
+)
+
+// ProviderUsage is the AI usage of one provider over a report window.
+type ProviderUsage struct {
+	Provider string
+	Requests int64
+	Tokens   int64
+	// CostUSD is the estimated cost; Priced is false when no price is configured.
+	CostUSD float64
+	Priced  bool
+}
+
+// ActivityReport is a compiled activity summary ready to be rendered.
+type ActivityReport struct {
+	domain.ActivitySummary
+	// Period is daily or weekly.
+	Period string
+	// Processed is the number of jobs that reached a terminal state.
+	Processed int64
+	// FailureRate is the share of processed jobs that failed.
+	FailureRate float64
+	// Providers holds usage by provider, ordered by name.
+	Providers []ProviderUsage
+	// TotalCostUSD sums the priced provider costs.
+	TotalCostUSD float64
+}
+
+// ReportService compiles daily and weekly activity reports and emails them.
+type ReportService struct {
+	Repo   domain.ReportRepository
+	Mailer domain.Mailer
+	// ProviderOf maps a provider key ID to its provider; unknown keys are
+	// reported as "unknown".
+	ProviderOf func(keyID string) string
+	// Costs is the price in USD per million tokens by provider.
+	Costs map[string]float64
+}
+
+// NewReportService constructs a ReportService.
+func NewReportService(repo domain.ReportRepository, mailer domain.Mailer, providerOf func(string) string, costs map[string]float64) ReportService {
+	return ReportService{Repo: repo, Mailer: mailer, ProviderOf: providerOf, Costs: costs}
+}
+
+// Compile builds the report of period ending at end.
+func (s ReportService) Compile(ctx domain.Context, period string, end time.Time) (ActivityReport, error) {
+	// A daily report covers 24 hours and a weekly one 7 days.
+	window := 24 * time.Hour
+	switch period {
+	case config.ReportPeriodDaily:
+	case config.ReportPeriodWeekly:
+		window = 7 * 24 * time.Hour
+	default:
+		return ActivityReport{}, fmt.Errorf("op=report.compile: unknown period %q: %w", period, domain.ErrInvalidArgument)
+	}
+	end = end.UTC()
+	sum, err := s.Repo.Summary(ctx, end.Add(-window), end)
+	if err != nil {
+		return ActivityReport{}, fmt.Errorf("op=report.compile: %w", err)
+	}
+	rep := ActivityReport{ActivitySummary: sum, Period: period}
+	rep.WindowStart, rep.WindowEnd = end.Add(-window), end
+	completed, failed := sum.JobsByStatus[domain.JobCompleted], sum.JobsByStatus[domain.JobFailed]
+	rep.Processed = completed + failed
+	if rep.Processed > 0 {
+		rep.FailureRate = float64(failed) / float64(rep.Processed)
+	}
+
+	byProvider := map[string]*ProviderUsage{}
+	for _, u := range sum.KeyUsage {
+		name := ""
+		if s.ProviderOf != nil {
+			name = s.ProviderOf(u.KeyID)
+		}
+		if name == "" {
+			name = "unknown"
+		}
+		p, ok := byProvider[name]
+		if !ok {
+			p = &ProviderUsage{Provider: name}
+			byProvider[name] = p
+		}
+		p.Requests += u.Requests
+		p.Tokens += u.Tokens
+	}
+	for _, p := range byProvider {
+		if price, ok := s.Costs[p.Provider]; ok {
+			p.CostUSD, p.Priced = float64(p.Tokens)/1e6*price, true
+			rep.TotalCostUSD += p.CostUSD
+		}
+		rep.Providers = append(rep.Providers, *p)
+	}
+	sort.Slice(rep.Providers, func(i, j int) bool { return rep.Providers[i].Provider < rep.Providers[j].Provider })
+	return rep, nil
+}
+
+// Deliver emails the report of period scheduled at scheduledFor to
+// recipient. The delivery is claimed first so that only one worker sends it;
+// sent is false when another worker already did. A delivery that fails is
+// released again, so that it can be retried.
+func (s ReportService) Deliver(ctx domain.Context, recipient, period string, scheduledFor time.Time) (sent bool, err error) {
+	tr := otel.Tracer("usecase.report")
+	ctx, span := tr.Start(ctx, "ReportService.Deliver")
+	defer span.End()
+
+	claimed, err := s.Repo.ClaimDelivery(ctx, recipient, scheduledFor)
+	if err != nil {
+		return false, fmt.Errorf("op=report.deliver: %w", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+	rep, err := s.Compile(ctx, period, scheduledFor)
+	if err == nil {
+		err = s.Mailer.Send(ctx, RenderActivityReport(rep, recipient))
+	}
+	if err != nil {
+		if errRelease := s.Repo.ReleaseDelivery(ctx, recipient, scheduledFor); errRelease != nil {
+			slog.Error("failed to release activity report delivery",
+				slog.String("recipient", recipient), slog.Any("error", errRelease))
+		}
+		return false, fmt.Errorf("op=report.deliver: %w", err)
+	}
+	slog.Info("activity report sent",
+		slog.String("recipient", recipient),
+		slog.String("period", period),
+		slog.Int64("processed", rep.Processed))
+	return true, nil
+}
+
+// RenderActivityReport renders rep as a plain-text email to recipient.
+func RenderActivityReport(rep ActivityReport, recipient string) domain.Email {
+	const layout = "2006-01-02 15:04 UTC"
+	var b strings.Builder
+	title := strings.ToUpper(rep.Period[:1]) + rep.Period[1:]
+	fmt.Fprintf(&b, "%s activity report\n", title)
+	fmt.Fprintf(&b, "Window: %s - %s\n\n", rep.WindowStart.Format(layout), rep.WindowEnd.Format(layout))
+
+	b.WriteString("Jobs\n")
+	fmt.Fprintf(&b, "  processed:    %d\n", rep.Processed)
+	for _, st := range []domain.JobStatus{domain.JobCompleted, domain.JobFailed, domain.JobQueued, domain.JobProcessing} {
+		fmt.Fprintf(&b, "  %-13s %d\n", string(st)+":", rep.JobsByStatus[st])
+	}
+	fmt.Fprintf(&b, "  failure rate: %.1f%%\n\n", rep.FailureRate*100)
+
+	b.WriteString("Scores\n")
+	fmt.Fprintf(&b, "  results:             %d\n", rep.ResultCount)
+	fmt.Fprintf(&b, "  avg cv_match_rate:   %.2f\n", rep.AvgCVMatchRate)
+	fmt.Fprintf(&b, "  avg project_score:   %.2f\n\n", rep.AvgProjectScore)
+
+	b.WriteString("Provider usage (whole UTC days)\n")
+	if len(rep.Providers) == 0 {
+		b.WriteString("  none recorded\n")
+	}
+	for _, p := range rep.Providers {
+		cost := "n/a"
+		if p.Priced {
+			cost = fmt.Sprintf("$%.2f", p.CostUSD)
+		}
+		fmt.Fprintf(&b, "  %-12s %d requests, %d tokens, est. cost %s\n", p.Provider+":", p.Requests, p.Tokens, cost)
+	}
+	fmt.Fprintf(&b, "  total est. cost: $%.2f\n", rep.TotalCostUSD)
+
+	return domain.Email{
+		To:      []string{recipient},
+		Subject: fmt.Sprintf("AI CV Evaluator %s report - %s", rep.Period, rep.WindowEnd.Format("2006-01-02")),
+		Body:    b.String(),
+	}
+}
@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestReportService_Compile(t *testing.T) {
+	repo := mocks.NewMockReportRepository(t)
+	providers := map[string]string{"k_or": "openrouter", "k_g1": "groq", "k_g2": "groq"}
+	svc := usecase.NewReportService(repo, nil, func(id string) string { return providers[id] }, map[string]float64{"openrouter": 0.5})
+	end := time.Date(2025, 12, 8, 8, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().Summary(mock.Anything, end.Add(-7*24*time.Hour), end).Return(domain.ActivitySummary{
+		WindowStart:  end.Add(-7 * 24 * time.Hour),
+		WindowEnd:    end,
+		JobsByStatus: map[domain.JobStatus]int64{domain.JobCompleted: 30, domain.JobFailed: 10, domain.JobQueued: 2},
+		KeyUsage: []domain.KeyUsage{
+			{KeyID: "k_or", Requests: 10, Tokens: 2_000_000},
+			{KeyID: "k_g1", Requests: 5, Tokens: 100},
+			{KeyID: "k_g2", Requests: 5, Tokens: 200},
+			{KeyID: "k_gone", Requests: 1, Tokens: 1},
+		},
+	}, nil).Once()
+
+	rep, err := svc.Compile(context.Background(), config.ReportPeriodWeekly, end)
+	require.NoError(t, err)
+	assert.Equal(t, int64(40), rep.Processed)
+	assert.InDelta(t, 0.25, rep.FailureRate, 1e-9)
+	assert.Equal(t, []usecase.ProviderUsage{
+		{Provider: "groq", Requests: 10, Tokens: 300},
+		{Provider: "openrouter", Requests: 10, Tokens: 2_000_000, CostUSD: 1, Priced: true},
+		{Provider: "unknown", Requests: 1, Tokens: 1},
+	}, rep.Providers)
+	assert.InDelta(t, 1.0, rep.TotalCostUSD, 1e-9)
+
+	_, err = svc.Compile(context.Background(), "monthly", end)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestReportService_Deliver(t *testing.T) {
+	repo := mocks.NewMockReportRepository(t)
+	mailer := mocks.NewMockMailer(t)
+	svc := usecase.NewReportService(repo, mailer, nil, nil)
+	at := time.Date(2025, 12, 8, 8, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().ClaimDelivery(mock.Anything, "ops@example.com", at).Return(true, nil).Once()
+	repo.EXPECT().Summary(mock.Anything, at.Add(-24*time.Hour), at).Return(domain.ActivitySummary{
+		WindowStart: at.Add(-24 * time.Hour), WindowEnd: at,
+		JobsByStatus: map[domain.JobStatus]int64{domain.JobCompleted: 4},
+		ResultCount:  4, AvgCVMatchRate: 0.72, AvgProjectScore: 7.5,
+	}, nil).Once()
+	var email domain.Email
+	mailer.EXPECT().Send(mock.Anything, mock.Anything).Run(func(_ domain.Context, e domain.Email) { email = e }).Return(nil).Once()
+	sent, err := svc.Deliver(context.Background(), "ops@example.com", config.ReportPeriodDaily, at)
+	require.NoError(t, err)
+	assert.True(t, sent)
+	assert.Equal(t, []string{"ops@example.com"}, email.To)
+	assert.Equal(t, "AI CV Evaluator daily report - 2025-12-08", email.Subject)
+	assert.Contains(t, email.Body, "Window: 2025-12-07 08:00 UTC - 2025-12-08 08:00 UTC")
+	assert.Contains(t, email.Body, "avg cv_match_rate:   0.72")
+	assert.Contains(t, email.Body, "none recorded")
+
+	repo.EXPECT().ClaimDelivery(mock.Anything, "ops@example.com", at).Return(false, nil).Once()
+	sent, err = svc.Deliver(context.Background(), "ops@example.com", config.ReportPeriodDaily, at)
+	require.NoError(t, err)
+	assert.False(t, sent, "claimed by another worker")
+
+	repo.EXPECT().ClaimDelivery(mock.Anything, "ops@example.com", at).Return(true, nil).Once()
+	repo.EXPECT().Summary(mock.Anything, mock.Anything, mock.Anything).Return(domain.ActivitySummary{}, nil).Once()
+	mailer.EXPECT().Send(mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	// A failed send gives the claim back for the next run.
+	repo.EXPECT().ReleaseDelivery(mock.Anything, "ops@example.com", at).Return(nil).Once()
+	_, err = svc.Deliver(context.Background(), "ops@example.com", config.ReportPeriodDaily, at)
+	assert.ErrorIs(t, err, assert.AnError)
+
+	repo.EXPECT().ClaimDelivery(mock.Anything, "ops@example.com", at).Return(true, nil).Once()
+	repo.EXPECT().ReleaseDelivery(mock.Anything, "ops@example.com", at).Return(nil).Once()
+	_, err = svc.Deliver(context.Background(), "ops@example.com", "monthly", at)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
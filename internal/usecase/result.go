@@ -25,6 +25,24 @@ import (
 type ResultService struct {
 	Jobs    domain.JobRepository
 	Results domain.ResultRepository
+	// StaleAfter is how long a job may stay queued or processing before it
+	// is reported as failed; 0 means 5 minutes. It matches the evaluation SLA.
+	StaleAfter time.Duration
+	// Queue, when set, adds the queue position and ETA to queued jobs
+	// fetched one at a time.
+	Queue *JobQueueService
+	// Versions, when set, tags completed results by their latest version.
+	Versions domain.ResultVersionRepository
+	// CacheMaxAge is how long clients may reuse a completed result before
+	// revalidating it; 0 makes them revalidate every time.
+	CacheMaxAge time.Duration
+	// Maintenance, when set, keeps queued jobs deferred by maintenance or
+	// backpressure from going stale.
+	Maintenance *MaintenanceService
+	// Pauses, when set, keeps queued jobs from going stale while QueueTopic
+	// is paused.
+	Pauses     *ConsumerPauseService
+	QueueTopic string
 }
 
 // NewResultService constructs a ResultService with the given repositories.
@@ -32,10 +50,41 @@ func NewResultService(j domain.JobRepository, r domain.ResultRepository) ResultS
 	return ResultService{Jobs: j, Results: r}
 }
 
+// ResultConditions are the conditional headers of a result request.
+type ResultConditions struct {
+	IfNoneMatch string
+	// IfModifiedSince is ignored when IfNoneMatch is set; zero when absent.
+	IfModifiedSince time.Time
+}
+
+// ResultResponse is a result envelope with its caching metadata.
+type ResultResponse struct {
+	// Status is 200, or 304 with a nil Body when the client's copy is current.
+	Status int
+	Body   map[string]any
+	// ETag is a strong, quoted entity tag.
+	ETag string
+	// LastModified is when the job last changed; zero for jobs that are not
+	// completed, whose envelopes change without the job changing.
+	LastModified time.Time
+	// MaxAge is how long clients may reuse a completed result without
+	// revalidating; zero means they must revalidate every time.
+	MaxAge time.Duration
+}
+
 // Fetch returns the HTTP status code, response body, and ETag for the given job id.
 // It implements conditional responses (304 Not Modified) based on If-None-Match ETag
 // and returns proper shapes for queued/processing/failed states per API rules.
 func (s ResultService) Fetch(ctx domain.Context, id, ifNoneMatch string) (int, map[string]any, string, error) {
+	resp, err := s.FetchConditional(ctx, id, ResultConditions{IfNoneMatch: ifNoneMatch})
+	return resp.Status, resp.Body, resp.ETag, err
+}
+
+// FetchConditional returns the envelope of job id unless the client's copy
+// described by cond is current. The ETag of a completed job is derived from
+// its latest result version, so a client revalidating a finished job is
+// answered without loading the result.
+func (s ResultService) FetchConditional(ctx domain.Context, id string, cond ResultConditions) (ResultResponse, error) {
 	tr := otel.Tracer("usecase.result")
 	ctx, span := tr.Start(ctx, "ResultService.Fetch")
 	defer span.End()
@@ -46,78 +95,320 @@ func (s ResultService) Fetch(ctx domain.Context, id, ifNoneMatch string) (int, m
 	if err != nil {
 		lg.Error("failed to get job", slog.String("job_id", id), slog.Any("error", err))
 		if errWrapped(err, domain.ErrNotFound) {
-			return http.StatusNotFound, nil, "", fmt.Errorf("%w: job not found", domain.ErrNotFound)
+			return ResultResponse{Status: http.StatusNotFound}, fmt.Errorf("%w: job not found", domain.ErrNotFound)
 		}
-		return http.StatusInternalServerError, nil, "", err
+		return ResultResponse{Status: http.StatusInternalServerError}, err
 	}
 	lg.Info("job retrieved", slog.String("job_id", id), slog.String("status", string(job.Status)), slog.Time("created_at", job.CreatedAt), slog.Time("updated_at", job.UpdatedAt))
+	job = expireJob(job)
 	if job.Status != domain.JobCompleted {
 		lg.Info("job not completed", slog.String("job_id", id), slog.String("status", string(job.Status)))
-
-		// Stale timeout policy: queued/processing older than 5 minutes are considered stale.
-		// This protects clients from jobs that never progress while still reflecting the
-		// real upstream behavior (no synthetic results are created here).
-		now := time.Now().UTC()
-		stale := false
-		if job.Status == domain.JobQueued && now.Sub(job.CreatedAt) > 5*time.Minute {
-			stale = true
+		job = s.expireStale(ctx, id, job)
+	}
+	// After potential stale handling, if the job is still not completed, return a
+	// non-completed status payload (queued/processing/failed/expired) as before.
+	if job.Status != domain.JobCompleted {
+		m := pendingEnvelope(id, job)
+		if job.Status == domain.JobQueued && s.Queue != nil {
+			if e, err := s.Queue.Estimate(ctx, id); err != nil {
+				lg.Warn("queue estimate failed", slog.String("job_id", id), slog.Any("error", err))
+			} else {
+				q := map[string]any{"position": e.Position}
+				if e.ETA > 0 {
+					q["eta_seconds"] = int64(e.ETA / time.Second)
+				}
+				m["queue"] = q
+			}
 		}
-		if job.Status == domain.JobProcessing && now.Sub(job.UpdatedAt) > 5*time.Minute {
-			stale = true
+		lg.Info("returning non-completed status", slog.String("job_id", id), slog.String("status", string(job.Status)), slog.Any("response", m))
+		resp := ResultResponse{Status: http.StatusOK, Body: m, ETag: makeETag(m)}
+		if cond.notModified(resp.ETag, time.Time{}) {
+			resp.Status, resp.Body = http.StatusNotModified, nil
 		}
-		if stale {
-			lg.Warn("job marked as stale", slog.String("job_id", id), slog.String("status", string(job.Status)), slog.Duration("age", now.Sub(job.CreatedAt)))
-			msg := "timeout: job exceeded 5 minutes"
-			_ = s.Jobs.UpdateStatus(ctx, id, domain.JobFailed, &msg)
-			job.Status = domain.JobFailed
-			job.Error = msg
+		return resp, nil
+	}
+	resp := ResultResponse{Status: http.StatusOK, LastModified: job.UpdatedAt.UTC().Truncate(time.Second), MaxAge: s.cacheMaxAge(job)}
+	if s.Versions != nil {
+		version, err := s.Versions.LatestVersion(ctx, id)
+		switch {
+		case err == nil:
+			resp.ETag = versionETag(id, version)
+			if cond.notModified(resp.ETag, resp.LastModified) {
+				resp.Status = http.StatusNotModified
+				return resp, nil
+			}
+		case !errWrapped(err, domain.ErrNotFound):
+			lg.Warn("failed to get latest result version", slog.String("job_id", id), slog.Any("error", err))
+		}
+	}
+	res, err := s.Results.GetByJobID(ctx, id)
+	if err != nil {
+		return ResultResponse{Status: http.StatusInternalServerError}, err
+	}
+	m := completedEnvelope(id, res)
+	addSecurityNotes(m, job.SecurityNotes)
+	addJobLabels(m, job)
+	// Results stored before versioning are tagged by their content.
+	if resp.ETag == "" {
+		resp.ETag = makeETag(m)
+	}
+	if cond.notModified(resp.ETag, resp.LastModified) {
+		resp.Status = http.StatusNotModified
+		return resp, nil
+	}
+	resp.Body = m
+	return resp, nil
+}
+
+// cacheMaxAge returns how long the completed job's result may be reused:
+// CacheMaxAge, cut short by the job's expiry.
+func (s ResultService) cacheMaxAge(job domain.Job) time.Duration {
+	maxAge := s.CacheMaxAge
+	if job.ExpiresAt != nil {
+		maxAge = min(maxAge, time.Until(*job.ExpiresAt).Truncate(time.Second))
+	}
+	return max(maxAge, 0)
+}
+
+// notModified reports whether the client's copy tagged etag and last changed
+// at lastModified is current. If-None-Match takes precedence over
+// If-Modified-Since, which is only checked when lastModified is known.
+func (c ResultConditions) notModified(etag string, lastModified time.Time) bool {
+	if c.IfNoneMatch != "" {
+		return etagMatches(c.IfNoneMatch, etag)
+	}
+	return !lastModified.IsZero() && !c.IfModifiedSince.IsZero() && !lastModified.After(c.IfModifiedSince)
+}
+
+// etagMatches reports whether the If-None-Match header value lists etag,
+// using the weak comparison RFC 9110 prescribes for it.
+func etagMatches(header, etag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == etag {
+			return true
 		}
+	}
+	return false
+}
+
+// FetchMany returns the response envelopes for many jobs at once, keyed by job
+// id, using one query for jobs and one for results. Ids that do not exist are
+// reported in the second return value instead of failing the whole batch.
+func (s ResultService) FetchMany(ctx domain.Context, ids []string) (map[string]map[string]any, []string, error) {
+	tr := otel.Tracer("usecase.result")
+	ctx, span := tr.Start(ctx, "ResultService.FetchMany")
+	defer span.End()
+
+	lg := obsctx.LoggerFromContext(ctx)
+	lg.Info("fetching results batch", slog.Int("count", len(ids)))
 
-		// After potential stale handling, if the job is still not completed, return a
-		// non-completed status payload (queued/processing/failed) as before.
+	jobs, err := s.Jobs.GetMany(ctx, ids)
+	if err != nil {
+		lg.Error("failed to get jobs batch", slog.Int("count", len(ids)), slog.Any("error", err))
+		return nil, nil, err
+	}
+
+	out := make(map[string]map[string]any, len(jobs))
+	var completed []string
+	completedJobs := make(map[string]domain.Job)
+	for _, job := range jobs {
+		job = expireJob(job)
 		if job.Status != domain.JobCompleted {
-			// Include error object when failed, per rules (03-api-contracts-and-validation.md)
-			m := map[string]any{"id": id, "status": string(job.Status)}
-			if job.Status == domain.JobFailed {
-				code := errorCodeFromJobError(job.Error)
-				m["error"] = map[string]any{
-					"code":    code,
-					"message": job.Error,
-				}
-			}
-			lg.Info("returning non-completed status", slog.String("job_id", id), slog.String("status", string(job.Status)), slog.Any("response", m))
-			etag := makeETag(m)
-			if etag == ifNoneMatch {
-				return http.StatusNotModified, nil, etag, nil
+			job = s.expireStale(ctx, job.ID, job)
+		}
+		if job.Status == domain.JobCompleted {
+			completed = append(completed, job.ID)
+			completedJobs[job.ID] = job
+			continue
+		}
+		out[job.ID] = pendingEnvelope(job.ID, job)
+	}
+
+	if len(completed) > 0 {
+		results, err := s.Results.GetByJobIDs(ctx, completed)
+		if err != nil {
+			lg.Error("failed to get results batch", slog.Int("count", len(completed)), slog.Any("error", err))
+			return nil, nil, err
+		}
+		for _, res := range results {
+			out[res.JobID] = completedEnvelope(res.JobID, res)
+			addSecurityNotes(out[res.JobID], completedJobs[res.JobID].SecurityNotes)
+			addJobLabels(out[res.JobID], completedJobs[res.JobID])
+		}
+		// A completed job without a stored result is an inconsistency; report
+		// the bare status instead of failing the whole batch or dropping the id.
+		for _, id := range completed {
+			if _, ok := out[id]; !ok {
+				out[id] = map[string]any{"id": id, "status": string(domain.JobCompleted)}
 			}
-			return http.StatusOK, m, etag, nil
 		}
 	}
-	res, err := s.Results.GetByJobID(ctx, id)
+
+	var missing []string
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if _, dup := seen[id]; dup {
+			continue
+		}
+		seen[id] = struct{}{}
+		if _, ok := out[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return out, missing, nil
+}
+
+// expireJob reports a queued or completed job past its expiry as expired,
+// ahead of the cleanup service marking it so.
+func expireJob(job domain.Job) domain.Job {
+	if (job.Status == domain.JobQueued || job.Status == domain.JobCompleted) && job.Expired(time.Now()) {
+		job.Status = domain.JobExpired
+	}
+	return job
+}
+
+// expireStale applies the stale timeout policy: jobs queued or processing
+// for longer than StaleAfter are considered stale and marked failed. This
+// protects clients from jobs that never progress while still reflecting the
+// real upstream behavior (no synthetic results are created here).
+//
+// A queued job ages from its last enqueue (creation, release from deferral,
+// requeue or bump) and never goes stale while it waits on purpose: deferred,
+// behind a paused topic, or with its own TTL, after which it expires instead.
+func (s ResultService) expireStale(ctx domain.Context, id string, job domain.Job) domain.Job {
+	now := time.Now().UTC()
+	staleAfter := s.StaleAfter
+	if staleAfter <= 0 {
+		staleAfter = 5 * time.Minute
+	}
+	age := now.Sub(job.UpdatedAt)
+	stale := false
+	switch job.Status {
+	case domain.JobQueued:
+		stale = job.ExpiresAt == nil && age > staleAfter && !s.heldBack(ctx, id)
+	case domain.JobProcessing:
+		stale = age > staleAfter
+	}
+	if stale {
+		obsctx.LoggerFromContext(ctx).Warn("job marked as stale", slog.String("job_id", id), slog.String("status", string(job.Status)), slog.Duration("age", age))
+		msg := fmt.Sprintf("timeout: job exceeded %v", staleAfter)
+		_ = s.Jobs.UpdateStatus(ctx, id, domain.JobFailed, &msg)
+		job.Status = domain.JobFailed
+		job.Error = msg
+	}
+	return job
+}
+
+// heldBack reports whether the queued job id waits on purpose. A failed
+// lookup counts as held back, so a job is never failed on a guess.
+func (s ResultService) heldBack(ctx domain.Context, id string) bool {
+	if s.Pauses.Paused(s.QueueTopic) {
+		return true
+	}
+	deferred, err := s.Maintenance.Deferred(ctx, id)
 	if err != nil {
-		return http.StatusInternalServerError, nil, "", err
+		obsctx.LoggerFromContext(ctx).Warn("deferred lookup failed; job not marked stale", slog.String("job_id", id), slog.Any("error", err))
+		return true
+	}
+	return deferred
+}
+
+// pendingEnvelope builds the response for a queued/processing/failed/expired
+// job; expired jobs never include their result.
+// Failed jobs include an error object, per rules (03-api-contracts-and-validation.md).
+func pendingEnvelope(id string, job domain.Job) map[string]any {
+	m := map[string]any{"id": id, "status": string(job.Status)}
+	if job.Status == domain.JobExpired && job.ExpiresAt != nil {
+		m["expired_at"] = job.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if job.Status == domain.JobFailed {
+		m["error"] = map[string]any{
+			"code":    errorCodeFromJobError(job.Error),
+			"message": job.Error,
+		}
+	}
+	addSecurityNotes(m, job.SecurityNotes)
+	addJobLabels(m, job)
+	return m
+}
+
+// completedEnvelope builds the response for a completed job and its result.
+// CV-only results carry no project fields and project-only results no CV
+// fields. Custom scoring weights and score normalization are echoed so scores
+// can be interpreted, similarity findings are attached for reviewers, and the
+// provenance of each step and any job description/CV language mismatch are
+// returned under meta. Encrypted feedback is
+// returned as ciphertext with the key id and wrapped data key the tenant
+// needs to decrypt it.
+func completedEnvelope(id string, res domain.Result) map[string]any {
+	result := map[string]any{"overall_summary": res.OverallSummary}
+	if !res.ProjectOnly {
+		result["cv_match_rate"] = res.CVMatchRate
+		result["cv_feedback"] = res.CVFeedback
+	}
+	if !res.CVOnly {
+		result["project_score"] = res.ProjectScore
+		result["project_feedback"] = res.ProjectFeedback
+	}
+	if res.ScoringWeights != nil {
+		result["scoring_weights"] = res.ScoringWeights
+	}
+	if res.Normalization != nil {
+		result["normalization"] = res.Normalization
+	}
+	if res.Similarity != nil {
+		result["similarity_flag"] = res.Similarity.Flagged
+		result["similar_submissions"] = res.Similarity.Matches
 	}
-	m := map[string]any{
-		"id": id, "status": string(domain.JobCompleted),
-		"result": map[string]any{
-			"cv_match_rate":    res.CVMatchRate,
-			"cv_feedback":      res.CVFeedback,
-			"project_score":    res.ProjectScore,
-			"project_feedback": res.ProjectFeedback,
-			"overall_summary":  res.OverallSummary,
-		},
+	if res.Encryption != nil {
+		result["encryption"] = res.Encryption
 	}
-	etag := makeETag(m)
-	if etag == ifNoneMatch {
-		return http.StatusNotModified, nil, etag, nil
+	m := map[string]any{"id": id, "status": string(domain.JobCompleted), "result": result}
+	meta := map[string]any{}
+	if len(res.Provenance) > 0 {
+		meta["provenance"] = res.Provenance
+	}
+	if res.Language != nil {
+		meta["language_mismatch"] = res.Language
+	}
+	if len(meta) > 0 {
+		m["meta"] = meta
+	}
+	return m
+}
+
+// addSecurityNotes adds the job's security notes to an envelope, if any.
+func addSecurityNotes(m map[string]any, notes []string) {
+	if len(notes) > 0 {
+		m["security_notes"] = notes
 	}
-	return http.StatusOK, m, etag, nil
 }
 
+// addJobLabels echoes the job's metadata and tags in an envelope, if any, so
+// clients can correlate results with their own records.
+func addJobLabels(m map[string]any, job domain.Job) {
+	if len(job.Metadata) > 0 {
+		m["metadata"] = job.Metadata
+	}
+	if len(job.Tags) > 0 {
+		m["tags"] = job.Tags
+	}
+}
+
+// makeETag returns a strong, quoted entity tag of v's JSON encoding.
 func makeETag(v any) string {
 	b, _ := json.Marshal(v)
 	s := sha256.Sum256(b)
-	return hex.EncodeToString(s[:])
+	return `"` + hex.EncodeToString(s[:]) + `"`
+}
+
+// versionETag returns a strong, quoted entity tag of a job's result version.
+// The envelope of a completed job only changes when a re-evaluation stores a
+// new version.
+func versionETag(jobID string, version int) string {
+	s := sha256.Sum256([]byte(fmt.Sprintf("%s/v%d", jobID, version)))
+	return `"` + hex.EncodeToString(s[:16]) + `"`
 }
 
 func errWrapped(err error, target error) bool {
@@ -143,6 +434,8 @@ func errorIs(err, target error) bool {
 func errorCodeFromJobError(msg string) string {
 	s := strings.ToLower(strings.TrimSpace(msg))
 	switch {
+	case strings.HasPrefix(s, "cancelled by admin"):
+		return "CANCELLED"
 	case strings.Contains(s, "schema invalid"), strings.Contains(s, "invalid json"), strings.Contains(s, "out of range"), strings.Contains(s, "empty"):
 		return "SCHEMA_INVALID"
 	case strings.Contains(s, "rate limit"):
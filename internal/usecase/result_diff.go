@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"go.opentelemetry.io/otel"
+)
+
+// minSentenceSimilarity is the word overlap above which a removed and an
+// added sentence are reported as one changed sentence.
+const minSentenceSimilarity = 0.5
+
+// ResultDiffService compares stored versions of a job's result, so reviewers
+// can see the effect of re-runs after rubric or model changes.
+type ResultDiffService struct {
+	Jobs     domain.JobRepository
+	Versions domain.ResultVersionRepository
+}
+
+// NewResultDiffService constructs a ResultDiffService.
+func NewResultDiffService(j domain.JobRepository, v domain.ResultVersionRepository) ResultDiffService {
+	return ResultDiffService{Jobs: j, Versions: v}
+}
+
+// Diff compares versions from and to of the result of job id. A zero to
+// selects the newest version and a zero from the version before to. Like
+// ResultService, it only serves results of completed jobs that have not
+// expired: expired jobs are reported with ErrNotFound and other jobs with
+// ErrConflict.
+func (s ResultDiffService) Diff(ctx domain.Context, id string, from, to int) (domain.ResultDiff, error) {
+	tr := otel.Tracer("usecase.result_diff")
+	ctx, span := tr.Start(ctx, "ResultDiffService.Diff")
+	defer span.End()
+
+	if from < 0 || to < 0 {
+		return domain.ResultDiff{}, fmt.Errorf("%w: versions start at 1", domain.ErrInvalidArgument)
+	}
+	job, err := s.Jobs.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ResultDiff{}, fmt.Errorf("%w: job not found", domain.ErrNotFound)
+		}
+		return domain.ResultDiff{}, fmt.Errorf("op=result_diff.get_job: %w", err)
+	}
+	switch job = expireJob(job); job.Status {
+	case domain.JobCompleted:
+	case domain.JobExpired:
+		return domain.ResultDiff{}, fmt.Errorf("%w: result expired", domain.ErrNotFound)
+	default:
+		return domain.ResultDiff{}, fmt.Errorf("%w: job is %s", domain.ErrConflict, job.Status)
+	}
+	if to == 0 {
+		latest, err := s.Versions.LatestVersion(ctx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ResultDiff{}, fmt.Errorf("%w: no result stored for job", domain.ErrNotFound)
+			}
+			return domain.ResultDiff{}, fmt.Errorf("op=result_diff.latest: %w", err)
+		}
+		to = latest
+	}
+	if from == 0 {
+		from = to - 1
+		if from < 1 {
+			return domain.ResultDiff{}, fmt.Errorf("%w: no result version before %d", domain.ErrNotFound, to)
+		}
+	}
+	if from == to {
+		return domain.ResultDiff{}, fmt.Errorf("%w: from and to are the same version", domain.ErrInvalidArgument)
+	}
+	a, err := s.version(ctx, id, from)
+	if err != nil {
+		return domain.ResultDiff{}, err
+	}
+	b, err := s.version(ctx, id, to)
+	if err != nil {
+		return domain.ResultDiff{}, err
+	}
+	d := diffResults(a, b)
+	d.JobID, d.From, d.To = id, from, to
+	return d, nil
+}
+
+// diffResults compares the scores and feedback of results a and b.
+func diffResults(a, b domain.Result) domain.ResultDiff {
+	d := domain.ResultDiff{
+		FromCreatedAt:  a.CreatedAt,
+		ToCreatedAt:    b.CreatedAt,
+		CVMatchRate:    scoreChange(cvMatchRate(a), cvMatchRate(b)),
+		ProjectScore:   scoreChange(projectScore(a), projectScore(b)),
+		FromProvenance: a.Provenance,
+		ToProvenance:   b.Provenance,
+	}
+	// Ciphertext changes completely between versions; only the tenant can
+	// compare the plain text.
+	if a.Encryption != nil || b.Encryption != nil {
+		d.FeedbackEncrypted = true
+		return d
+	}
+	d.CVFeedback = diffSentences(a.CVFeedback, b.CVFeedback)
+	d.ProjectFeedback = diffSentences(a.ProjectFeedback, b.ProjectFeedback)
+	d.OverallSummary = diffSentences(a.OverallSummary, b.OverallSummary)
+	return d
+}
+
+func (s ResultDiffService) version(ctx domain.Context, id string, version int) (domain.Result, error) {
+	res, err := s.Versions.GetVersion(ctx, id, version)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.Result{}, fmt.Errorf("%w: result version %d not found", domain.ErrNotFound, version)
+		}
+		return domain.Result{}, fmt.Errorf("op=result_diff.get_version: %w", err)
+	}
+	return res, nil
+}
+
+func cvMatchRate(r domain.Result) *float64 {
+	if r.ProjectOnly {
+		return nil
+	}
+	return &r.CVMatchRate
+}
+
+func projectScore(r domain.Result) *float64 {
+	if r.CVOnly {
+		return nil
+	}
+	return &r.ProjectScore
+}
+
+func scoreChange(from, to *float64) domain.ScoreChange {
+	c := domain.ScoreChange{From: from, To: to}
+	if from != nil && to != nil {
+		// Rounded so that 0.7 - 0.6 reads 0.1.
+		c.Delta = math.Round((*to-*from)*1e4) / 1e4
+	}
+	return c
+}
+
+// feedbackSentenceEnd matches the end of a sentence in feedback text.
+var feedbackSentenceEnd = regexp.MustCompile(`[.!?]+\s+|\n+`)
+
+// feedbackSentences splits text into trimmed, non-empty sentences.
+func feedbackSentences(text string) []string {
+	var out []string
+	last := 0
+	for _, loc := range feedbackSentenceEnd.FindAllStringIndex(text, -1) {
+		if s := strings.TrimSpace(text[last:loc[1]]); s != "" {
+			out = append(out, s)
+		}
+		last = loc[1]
+	}
+	if s := strings.TrimSpace(text[last:]); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
+
+// normalizeSentence drops case, spacing and closing punctuation, which do not
+// change what a sentence says.
+func normalizeSentence(s string) string {
+	return strings.TrimRight(strings.ToLower(strings.Join(strings.Fields(s), " ")), ".!?")
+}
+
+// sentenceSimilarity is the Jaccard overlap of the words of a and b.
+func sentenceSimilarity(a, b string) float64 {
+	words := func(s string) map[string]struct{} {
+		m := map[string]struct{}{}
+		for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+			m[w] = struct{}{}
+		}
+		return m
+	}
+	wa, wb := words(a), words(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range wa {
+		if _, ok := wb[w]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wa)+len(wb)-shared)
+}
+
+// diffSentences diffs two feedback texts sentence by sentence using their
+// longest common subsequence. Within each run of edits, a removed sentence
+// that resembles the added one at the same position is reported as changed.
+func diffSentences(from, to string) []domain.SentenceChange {
+	a, b := feedbackSentences(from), feedbackSentences(to)
+	na, nb := make([]string, len(a)), make([]string, len(b))
+	for i, s := range a {
+		na[i] = normalizeSentence(s)
+	}
+	for i, s := range b {
+		nb[i] = normalizeSentence(s)
+	}
+	// lcs[i][j] is the length of the longest common subsequence of na[i:]
+	// and nb[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if na[i] == nb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	out := []domain.SentenceChange{}
+	var removed, added []string
+	flush := func() {
+		n := min(len(removed), len(added))
+		for k := 0; k < n; k++ {
+			if sentenceSimilarity(removed[k], added[k]) >= minSentenceSimilarity {
+				out = append(out, domain.SentenceChange{Kind: domain.SentenceChanged, Text: added[k], Previous: removed[k]})
+				continue
+			}
+			out = append(out,
+				domain.SentenceChange{Kind: domain.SentenceRemoved, Text: removed[k]},
+				domain.SentenceChange{Kind: domain.SentenceAdded, Text: added[k]})
+		}
+		for _, s := range removed[n:] {
+			out = append(out, domain.SentenceChange{Kind: domain.SentenceRemoved, Text: s})
+		}
+		for _, s := range added[n:] {
+			out = append(out, domain.SentenceChange{Kind: domain.SentenceAdded, Text: s})
+		}
+		removed, added = nil, nil
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && na[i] == nb[j]:
+			flush()
+			out = append(out, domain.SentenceChange{Kind: domain.SentenceUnchanged, Text: b[j]})
+			i, j = i+1, j+1
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, a[i])
+			i++
+		default:
+			added = append(added, b[j])
+			j++
+		}
+	}
+	flush()
+	return out
+}
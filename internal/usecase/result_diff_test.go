@@ -0,0 +1,129 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// completedJobs reports every job as completed.
+func completedJobs(t *testing.T) *mocks.MockJobRepository {
+	jobs := mocks.NewMockJobRepository(t)
+	jobs.EXPECT().Get(mock.Anything, mock.Anything).RunAndReturn(func(_ domain.Context, id string) (domain.Job, error) {
+		return domain.Job{ID: id, Status: domain.JobCompleted}, nil
+	})
+	return jobs
+}
+
+func TestResultDiffService_ComparesNewestWithPrevious(t *testing.T) {
+	versions := mocks.NewMockResultVersionRepository(t)
+	svc := usecase.NewResultDiffService(completedJobs(t), versions)
+
+	versions.EXPECT().LatestVersion(mock.Anything, "job-1").Return(3, nil).Once()
+	versions.EXPECT().GetVersion(mock.Anything, "job-1", 2).Return(domain.Result{
+		CVMatchRate: 0.6, ProjectScore: 7,
+		CVFeedback:     "Strong Go skills. Limited cloud experience. Good communication.",
+		OverallSummary: "Hire.",
+	}, nil).Once()
+	versions.EXPECT().GetVersion(mock.Anything, "job-1", 3).Return(domain.Result{
+		CVMatchRate: 0.7, ProjectScore: 8,
+		CVFeedback:     "strong go skills!  Limited AWS cloud experience. Writes clear tests.",
+		OverallSummary: "Hire.",
+	}, nil).Once()
+
+	d, err := svc.Diff(context.Background(), "job-1", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.From)
+	assert.Equal(t, 3, d.To)
+	assert.Equal(t, 0.1, d.CVMatchRate.Delta)
+	assert.Equal(t, 1.0, d.ProjectScore.Delta)
+	// Case and punctuation are not changes; a reworded sentence is.
+	assert.Equal(t, []domain.SentenceChange{
+		{Kind: domain.SentenceUnchanged, Text: "strong go skills!"},
+		{Kind: domain.SentenceChanged, Text: "Limited AWS cloud experience.", Previous: "Limited cloud experience."},
+		{Kind: domain.SentenceRemoved, Text: "Good communication."},
+		{Kind: domain.SentenceAdded, Text: "Writes clear tests."},
+	}, d.CVFeedback)
+	assert.Equal(t, []domain.SentenceChange{{Kind: domain.SentenceUnchanged, Text: "Hire."}}, d.OverallSummary)
+	assert.Empty(t, d.ProjectFeedback)
+}
+
+func TestResultDiffService_ScoresNotAssessed(t *testing.T) {
+	versions := mocks.NewMockResultVersionRepository(t)
+	svc := usecase.NewResultDiffService(completedJobs(t), versions)
+
+	versions.EXPECT().GetVersion(mock.Anything, "job-1", 1).Return(domain.Result{CVMatchRate: 0.5, CVOnly: true}, nil).Once()
+	versions.EXPECT().GetVersion(mock.Anything, "job-1", 2).Return(domain.Result{CVMatchRate: 0.5, ProjectScore: 6}, nil).Once()
+
+	d, err := svc.Diff(context.Background(), "job-1", 1, 2)
+	require.NoError(t, err)
+	assert.Nil(t, d.ProjectScore.From)
+	assert.Equal(t, 6.0, *d.ProjectScore.To)
+	assert.Zero(t, d.ProjectScore.Delta)
+}
+
+func TestResultDiffService_EncryptedFeedbackIsNotDiffed(t *testing.T) {
+	versions := mocks.NewMockResultVersionRepository(t)
+	svc := usecase.NewResultDiffService(completedJobs(t), versions)
+	enc := &domain.ResultEncryption{KeyID: "k1", Algorithm: "RSA-OAEP-256+A256GCM", WrappedKey: "d2s="}
+
+	versions.EXPECT().GetVersion(mock.Anything, "job-1", 1).Return(domain.Result{CVMatchRate: 0.5, CVFeedback: "Y2lwaGVy", Encryption: enc}, nil).Once()
+	versions.EXPECT().GetVersion(mock.Anything, "job-1", 2).Return(domain.Result{CVMatchRate: 0.7, CVFeedback: "b3RoZXI=", Encryption: enc}, nil).Once()
+
+	d, err := svc.Diff(context.Background(), "job-1", 1, 2)
+	require.NoError(t, err)
+	assert.True(t, d.FeedbackEncrypted)
+	assert.Empty(t, d.CVFeedback)
+	assert.InDelta(t, 0.2, d.CVMatchRate.Delta, 1e-9)
+}
+
+func TestResultDiffService_Errors(t *testing.T) {
+	versions := mocks.NewMockResultVersionRepository(t)
+	svc := usecase.NewResultDiffService(completedJobs(t), versions)
+	ctx := context.Background()
+
+	versions.EXPECT().LatestVersion(mock.Anything, "none").Return(0, domain.ErrNotFound).Once()
+	_, err := svc.Diff(ctx, "none", 0, 0)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// A job evaluated once has nothing to compare with.
+	versions.EXPECT().LatestVersion(mock.Anything, "once").Return(1, nil).Once()
+	_, err = svc.Diff(ctx, "once", 0, 0)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = svc.Diff(ctx, "job-1", 2, 2)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument)
+
+	versions.EXPECT().GetVersion(mock.Anything, "job-1", 5).Return(domain.Result{}, domain.ErrNotFound).Once()
+	_, err = svc.Diff(ctx, "job-1", 5, 6)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestResultDiffService_OnlyServesCompletedJobs(t *testing.T) {
+	jobs := mocks.NewMockJobRepository(t)
+	svc := usecase.NewResultDiffService(jobs, mocks.NewMockResultVersionRepository(t))
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+
+	jobs.EXPECT().Get(mock.Anything, "gone").Return(domain.Job{}, domain.ErrNotFound).Once()
+	_, err := svc.Diff(ctx, "gone", 0, 0)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// Expired results are no longer served, even before cleanup marks them.
+	jobs.EXPECT().Get(mock.Anything, "expired").Return(domain.Job{ID: "expired", Status: domain.JobCompleted, ExpiresAt: &past}, nil).Once()
+	_, err = svc.Diff(ctx, "expired", 1, 2)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	// A re-run in progress has no current result to compare.
+	jobs.EXPECT().Get(mock.Anything, "running").Return(domain.Job{ID: "running", Status: domain.JobProcessing}, nil).Once()
+	_, err = svc.Diff(ctx, "running", 1, 2)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+}
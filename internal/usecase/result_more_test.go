@@ -112,3 +112,118 @@ func TestResult_ErrorCode_Mapping_UnknownError(t *testing.T) {
 	errObj := body["error"].(map[string]any)
 	assert.Equal(t, "INTERNAL", errObj["code"]) //nolint:forcetypeassert
 }
+
+func TestResult_StaleAfterSLA(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+	started := time.Now().Add(-7 * time.Minute)
+	jobRepo.On("Get", mock.Anything, "j1").Return(domain.Job{ID: "j1", Status: domain.JobProcessing, CreatedAt: started, UpdatedAt: started}, nil)
+
+	// Within a 10 minute SLA a job processing for 7 minutes is not stale.
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	svc.StaleAfter = 10 * time.Minute
+	_, body, _, err := svc.Fetch(context.Background(), "j1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "processing", body["status"])
+
+	jobRepo.On("UpdateStatus", mock.Anything, "j1", domain.JobFailed, mock.MatchedBy(func(msg *string) bool {
+		return msg != nil && *msg == "timeout: job exceeded 5m0s"
+	})).Return(nil).Once()
+	svc.StaleAfter = 0
+	_, body, _, err = svc.Fetch(context.Background(), "j1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "failed", body["status"])
+}
+
+func TestResult_StaleQueuedJobsHeldBackOnPurpose(t *testing.T) {
+	ctx := context.Background()
+	jobRepo := mocks.NewMockJobRepository(t)
+	repo := mocks.NewMockMaintenanceRepository(t)
+	svc := usecase.NewResultService(jobRepo, mocks.NewMockResultRepository(t))
+	svc.StaleAfter = 5 * time.Minute
+	svc.Maintenance = usecase.NewMaintenanceService(repo, nil, time.Minute)
+	accepted := time.Now().Add(-10 * time.Minute)
+
+	// A job deferred past StaleAfter keeps waiting instead of failing.
+	deferred := domain.Job{ID: "j1", Status: domain.JobQueued, CreatedAt: accepted, UpdatedAt: accepted}
+	jobRepo.EXPECT().Get(mock.Anything, "j1").Return(deferred, nil).Once()
+	repo.EXPECT().Deferred(mock.Anything, "j1").Return(true, nil).Once()
+	_, body, _, err := svc.Fetch(ctx, "j1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "queued", body["status"])
+
+	// Released, it ages from the release and is still queued for the worker.
+	repo.EXPECT().ReleaseDeferred(mock.Anything, 100).Return(1, nil).Once()
+	released, err := svc.Maintenance.Release(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+	deferred.UpdatedAt = time.Now()
+	jobRepo.EXPECT().Get(mock.Anything, "j1").Return(deferred, nil).Once()
+	_, body, _, err = svc.Fetch(ctx, "j1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "queued", body["status"])
+
+	// A failed lookup does not fail the job either.
+	jobRepo.EXPECT().Get(mock.Anything, "j2").Return(domain.Job{ID: "j2", Status: domain.JobQueued, CreatedAt: accepted, UpdatedAt: accepted}, nil).Once()
+	repo.EXPECT().Deferred(mock.Anything, "j2").Return(false, assert.AnError).Once()
+	_, body, _, err = svc.Fetch(ctx, "j2", "")
+	require.NoError(t, err)
+	assert.Equal(t, "queued", body["status"])
+
+	// Jobs with a TTL expire rather than time out.
+	expires := time.Now().Add(time.Hour)
+	jobRepo.EXPECT().Get(mock.Anything, "j3").Return(domain.Job{ID: "j3", Status: domain.JobQueued, CreatedAt: accepted, UpdatedAt: accepted, ExpiresAt: &expires}, nil).Once()
+	_, body, _, err = svc.Fetch(ctx, "j3", "")
+	require.NoError(t, err)
+	assert.Equal(t, "queued", body["status"])
+
+	// Nothing waits behind a paused topic...
+	svc.Pauses = usecase.NewConsumerPauseService(&memConsumerPauses{pauses: map[string]domain.ConsumerPause{}}, "evaluate-jobs")
+	svc.QueueTopic = "evaluate-jobs"
+	_, err = svc.Pauses.Pause(ctx, "evaluate-jobs", "admin", "provider outage")
+	require.NoError(t, err)
+	jobRepo.EXPECT().Get(mock.Anything, "j4").Return(domain.Job{ID: "j4", Status: domain.JobQueued, CreatedAt: accepted, UpdatedAt: accepted}, nil).Once()
+	_, body, _, err = svc.Fetch(ctx, "j4", "")
+	require.NoError(t, err)
+	assert.Equal(t, "queued", body["status"])
+
+	// ...but a queued job that is not held back still times out.
+	svc.Pauses = nil
+	jobRepo.EXPECT().Get(mock.Anything, "j4").Return(domain.Job{ID: "j4", Status: domain.JobQueued, CreatedAt: accepted, UpdatedAt: accepted}, nil).Once()
+	repo.EXPECT().Deferred(mock.Anything, "j4").Return(false, nil).Once()
+	jobRepo.EXPECT().UpdateStatus(mock.Anything, "j4", domain.JobFailed, mock.Anything).Return(nil).Once()
+	_, body, _, err = svc.Fetch(ctx, "j4", "")
+	require.NoError(t, err)
+	assert.Equal(t, "failed", body["status"])
+}
+
+func TestResult_Completed_VersionETagAndCaching(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+	versions := mocks.NewMockResultVersionRepository(t)
+	updated := time.Date(2026, 1, 2, 9, 0, 0, 500, time.UTC)
+	expires := time.Now().Add(30 * time.Second)
+	jobRepo.EXPECT().Get(mock.Anything, "j3").Return(domain.Job{ID: "j3", Status: domain.JobCompleted, UpdatedAt: updated, ExpiresAt: &expires}, nil)
+	versions.EXPECT().LatestVersion(mock.Anything, "j3").Return(2, nil)
+	resultRepo.EXPECT().GetByJobID(mock.Anything, "j3").Return(domain.Result{JobID: "j3", CVMatchRate: 0.9, CVFeedback: "good", ProjectScore: 8, ProjectFeedback: "ok", OverallSummary: "sum"}, nil).Once()
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	svc.Versions = versions
+	svc.CacheMaxAge = time.Minute
+	resp, err := svc.FetchConditional(context.Background(), "j3", usecase.ResultConditions{})
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.Status)
+	assert.Equal(t, updated.Truncate(time.Second), resp.LastModified)
+	// The max-age does not outlive the job.
+	assert.LessOrEqual(t, resp.MaxAge, 30*time.Second)
+	assert.Positive(t, resp.MaxAge)
+
+	// Revalidating does not load the result again.
+	resp2, err := svc.FetchConditional(context.Background(), "j3", usecase.ResultConditions{IfNoneMatch: `"other", ` + resp.ETag})
+	require.NoError(t, err)
+	assert.Equal(t, 304, resp2.Status)
+	assert.Equal(t, resp.ETag, resp2.ETag)
+	resp3, err := svc.FetchConditional(context.Background(), "j3", usecase.ResultConditions{IfModifiedSince: updated.Add(time.Second)})
+	require.NoError(t, err)
+	assert.Equal(t, 304, resp3.Status)
+}
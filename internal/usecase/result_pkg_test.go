@@ -38,4 +38,8 @@ func Test_errorCodeFromJobError(t *testing.T) {
 			t.Fatalf("%q => %q (got %q)", in, want, got)
 		}
 	}
+	// Cancellations are told apart from the reason the admin gave.
+	if got := errorCodeFromJobError("cancelled by admin: rate limit storm"); got != "CANCELLED" {
+		t.Fatalf("cancellation => %q", got)
+	}
 }
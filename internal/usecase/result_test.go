@@ -2,7 +2,9 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -35,3 +37,190 @@ func TestResult_FailedShape_ReturnsErrorObject(t *testing.T) {
 	assert.Equal(t, "SCHEMA_INVALID", code)
 	require.Contains(t, msg, "schema invalid")
 }
+
+func TestResult_FetchMany_MixedStatusesAndMissing(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+
+	now := time.Now().UTC()
+	jobRepo.On("GetMany", mock.Anything, []string{"job1", "job2", "job3"}).Return([]domain.Job{
+		{ID: "job1", Status: domain.JobCompleted, CreatedAt: now, UpdatedAt: now, SecurityNotes: []string{"note"}, Metadata: map[string]string{"team": "platform"}},
+		{ID: "job2", Status: domain.JobProcessing, CreatedAt: now, UpdatedAt: now, Tags: []string{"urgent"}},
+	}, nil)
+	resultRepo.On("GetByJobIDs", mock.Anything, []string{"job1"}).Return([]domain.Result{
+		{JobID: "job1", CVMatchRate: 0.8, CVFeedback: "good", ProjectScore: 8, ProjectFeedback: "solid", OverallSummary: "ok"},
+	}, nil)
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	out, missing, err := svc.FetchMany(context.Background(), []string{"job1", "job2", "job3"})
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+	assert.Equal(t, "completed", out["job1"]["status"])
+	assert.NotNil(t, out["job1"]["result"])
+	assert.Equal(t, []string{"note"}, out["job1"]["security_notes"])
+	assert.Equal(t, map[string]string{"team": "platform"}, out["job1"]["metadata"])
+	assert.NotContains(t, out["job1"], "tags")
+	assert.Equal(t, "processing", out["job2"]["status"])
+	assert.NotContains(t, out["job2"], "security_notes")
+	assert.Equal(t, []string{"urgent"}, out["job2"]["tags"])
+	assert.Equal(t, []string{"job3"}, missing)
+}
+
+func TestResult_FetchMany_SkipsResultQueryWhenNothingCompleted(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+
+	now := time.Now().UTC()
+	jobRepo.On("GetMany", mock.Anything, []string{"job1"}).Return([]domain.Job{
+		{ID: "job1", Status: domain.JobQueued, CreatedAt: now, UpdatedAt: now},
+	}, nil)
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	out, missing, err := svc.FetchMany(context.Background(), []string{"job1"})
+	require.NoError(t, err)
+	assert.Equal(t, "queued", out["job1"]["status"])
+	assert.Empty(t, missing)
+}
+
+func TestResult_ExpiredJobsDoNotServeResults(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+
+	now := time.Now().UTC()
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+	jobRepo.On("Get", mock.Anything, "job1").Return(domain.Job{ID: "job1", Status: domain.JobCompleted, CreatedAt: now, UpdatedAt: now, ExpiresAt: &past}, nil)
+	jobRepo.On("GetMany", mock.Anything, []string{"job1", "job2", "job3"}).Return([]domain.Job{
+		{ID: "job1", Status: domain.JobCompleted, CreatedAt: now, UpdatedAt: now, ExpiresAt: &past},
+		{ID: "job2", Status: domain.JobExpired, CreatedAt: now, UpdatedAt: now, ExpiresAt: &past},
+		{ID: "job3", Status: domain.JobQueued, CreatedAt: now, UpdatedAt: now, ExpiresAt: &future},
+	}, nil)
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	st, body, _, err := svc.Fetch(context.Background(), "job1", "")
+	require.NoError(t, err)
+	assert.Equal(t, 200, st)
+	assert.Equal(t, "expired", body["status"])
+	assert.Equal(t, past.Format(time.RFC3339), body["expired_at"])
+	assert.NotContains(t, body, "result")
+
+	// No result is loaded for jobs that expired, marked or not.
+	out, _, err := svc.FetchMany(context.Background(), []string{"job1", "job2", "job3"})
+	require.NoError(t, err)
+	assert.Equal(t, "expired", out["job1"]["status"])
+	assert.Equal(t, "expired", out["job2"]["status"])
+	assert.Equal(t, "queued", out["job3"]["status"])
+	resultRepo.AssertNotCalled(t, "GetByJobID", mock.Anything, mock.Anything)
+	resultRepo.AssertNotCalled(t, "GetByJobIDs", mock.Anything, mock.Anything)
+}
+
+func TestResult_FetchMany_PropagatesRepoError(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+	jobRepo.On("GetMany", mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	_, _, err := svc.FetchMany(context.Background(), []string{"job1"})
+	require.Error(t, err)
+}
+
+func TestResult_CVOnlyShape_OmitsProjectFields(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+	jobRepo.On("Get", mock.Anything, "job1").Return(domain.Job{ID: "job1", Status: domain.JobCompleted}, nil)
+	resultRepo.On("GetByJobID", mock.Anything, "job1").Return(domain.Result{JobID: "job1", CVMatchRate: 0.7, CVFeedback: "good", OverallSummary: "ok", CVOnly: true}, nil)
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	_, body, _, err := svc.Fetch(context.Background(), "job1", "")
+	require.NoError(t, err)
+	res, ok := body["result"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, 0.7, res["cv_match_rate"])
+	assert.NotContains(t, res, "project_score")
+	assert.NotContains(t, res, "project_feedback")
+}
+
+func TestResult_ProjectOnlyShape_OmitsCVFields(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+	jobRepo.On("Get", mock.Anything, "job1").Return(domain.Job{ID: "job1", Status: domain.JobCompleted}, nil)
+	resultRepo.On("GetByJobID", mock.Anything, "job1").Return(domain.Result{JobID: "job1", ProjectScore: 8, ProjectFeedback: "solid", OverallSummary: "ok", ProjectOnly: true}, nil)
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	_, body, _, err := svc.Fetch(context.Background(), "job1", "")
+	require.NoError(t, err)
+	res, ok := body["result"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, 8.0, res["project_score"])
+	assert.NotContains(t, res, "cv_match_rate")
+	assert.NotContains(t, res, "cv_feedback")
+}
+
+func TestResult_EchoesCustomScoringWeights(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+	weights := domain.ScoringWeights{"technical_skills_match": 70}
+	jobRepo.On("Get", mock.Anything, "job1").Return(domain.Job{ID: "job1", Status: domain.JobCompleted}, nil)
+	resultRepo.On("GetByJobID", mock.Anything, "job1").Return(domain.Result{JobID: "job1", CVMatchRate: 0.8, ScoringWeights: weights}, nil)
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	_, body, _, err := svc.Fetch(context.Background(), "job1", "")
+	require.NoError(t, err)
+	res, ok := body["result"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, weights, res["scoring_weights"])
+}
+
+func TestResult_ReturnsProvenanceUnderMeta(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+	provenance := []domain.StepProvenance{{Step: "cv_match", Provider: "groq", Model: "groq/llama-3.1-8b-instant", PromptVersion: "1"}}
+	jobRepo.On("Get", mock.Anything, "job1").Return(domain.Job{ID: "job1", Status: domain.JobCompleted}, nil)
+	jobRepo.On("Get", mock.Anything, "job2").Return(domain.Job{ID: "job2", Status: domain.JobCompleted}, nil)
+	resultRepo.On("GetByJobID", mock.Anything, "job1").Return(domain.Result{JobID: "job1", CVMatchRate: 0.8, Provenance: provenance}, nil)
+	resultRepo.On("GetByJobID", mock.Anything, "job2").Return(domain.Result{JobID: "job2", CVMatchRate: 0.8}, nil)
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	_, body, _, err := svc.Fetch(context.Background(), "job1", "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"provenance": provenance}, body["meta"])
+
+	// Results stored before provenance was recorded have no meta.
+	_, body, _, err = svc.Fetch(context.Background(), "job2", "")
+	require.NoError(t, err)
+	assert.NotContains(t, body, "meta")
+}
+
+func TestResult_ReturnsLanguageMismatchUnderMeta(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+	language := &domain.LanguageMismatch{JobDescription: "en", CV: "id"}
+	jobRepo.On("Get", mock.Anything, "job1").Return(domain.Job{ID: "job1", Status: domain.JobCompleted}, nil)
+	resultRepo.On("GetByJobID", mock.Anything, "job1").Return(domain.Result{JobID: "job1", CVMatchRate: 0.6, Language: language}, nil)
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	_, body, _, err := svc.Fetch(context.Background(), "job1", "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"language_mismatch": language}, body["meta"])
+}
+
+func TestResult_AttachesSimilarityFindings(t *testing.T) {
+	jobRepo := mocks.NewMockJobRepository(t)
+	resultRepo := mocks.NewMockResultRepository(t)
+	similarity := &domain.SimilarityReport{Flagged: true, Matches: []domain.SimilarMatch{{JobID: "job0", Score: 0.96}}}
+	jobRepo.On("Get", mock.Anything, "job1").Return(domain.Job{ID: "job1", Status: domain.JobCompleted}, nil)
+	jobRepo.On("Get", mock.Anything, "job2").Return(domain.Job{ID: "job2", Status: domain.JobCompleted}, nil)
+	resultRepo.On("GetByJobID", mock.Anything, "job1").Return(domain.Result{JobID: "job1", ProjectScore: 7, Similarity: similarity}, nil)
+	resultRepo.On("GetByJobID", mock.Anything, "job2").Return(domain.Result{JobID: "job2", ProjectScore: 7}, nil)
+
+	svc := usecase.NewResultService(jobRepo, resultRepo)
+	_, body, _, err := svc.Fetch(context.Background(), "job1", "")
+	require.NoError(t, err)
+	res := body["result"].(map[string]any)
+	assert.Equal(t, true, res["similarity_flag"])
+	assert.Equal(t, similarity.Matches, res["similar_submissions"])
+
+	// Results not checked for similarity carry no findings.
+	_, body, _, err = svc.Fetch(context.Background(), "job2", "")
+	require.NoError(t, err)
+	assert.NotContains(t, body["result"], "similarity_flag")
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Bounds of the sampling parameters accepted from clients and tenants. They
+// are narrower than the providers' so that scores stay comparable.
+const (
+	maxSamplingTemperature      = 1.0
+	maxSamplingFrequencyPenalty = 1.0
+)
+
+// ValidateSamplingParams checks that the set parameters of p are within
+// bounds: temperature in [0, 1], top_p in (0, 1] and frequency_penalty in
+// [0, 1].
+func ValidateSamplingParams(p domain.SamplingParams) error {
+	if t := p.Temperature; t != nil && (math.IsNaN(*t) || *t < 0 || *t > maxSamplingTemperature) {
+		return fmt.Errorf("%w: temperature must be between 0 and %g", domain.ErrInvalidArgument, maxSamplingTemperature)
+	}
+	if t := p.TopP; t != nil && (math.IsNaN(*t) || *t <= 0 || *t > 1) {
+		return fmt.Errorf("%w: top_p must be greater than 0 and at most 1", domain.ErrInvalidArgument)
+	}
+	if f := p.FrequencyPenalty; f != nil && (math.IsNaN(*f) || *f < 0 || *f > maxSamplingFrequencyPenalty) {
+		return fmt.Errorf("%w: frequency_penalty must be between 0 and %g", domain.ErrInvalidArgument, maxSamplingFrequencyPenalty)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package usecase_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func f64(v float64) *float64 { return &v }
+
+func TestValidateSamplingParams(t *testing.T) {
+	for _, p := range []domain.SamplingParams{
+		{},
+		{Temperature: f64(0), TopP: f64(1), FrequencyPenalty: f64(0)},
+		{Temperature: f64(1), TopP: f64(0.1), FrequencyPenalty: f64(1)},
+	} {
+		assert.NoError(t, usecase.ValidateSamplingParams(p), "%+v", p)
+	}
+	for name, p := range map[string]domain.SamplingParams{
+		"temperature high":  {Temperature: f64(1.5)},
+		"temperature low":   {Temperature: f64(-0.1)},
+		"temperature NaN":   {Temperature: f64(math.NaN())},
+		"top_p zero":        {TopP: f64(0)},
+		"top_p high":        {TopP: f64(1.1)},
+		"penalty negative":  {FrequencyPenalty: f64(-1)},
+		"penalty too large": {FrequencyPenalty: f64(2)},
+	} {
+		assert.ErrorIs(t, usecase.ValidateSamplingParams(p), domain.ErrInvalidArgument, name)
+	}
+}
+
+func TestEvaluate_Enqueue_SamplingParams(t *testing.T) {
+	repo := mocks.NewMockTenantSettingsRepository(t)
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	svc.Tenants = usecase.NewTenantService(repo)
+	tenant := domain.TenantSettings{TenantID: "acme", Overrides: domain.EvaluationOverrides{Sampling: &domain.SamplingParams{Temperature: f64(0.1), TopP: f64(0.9)}}}
+	repo.EXPECT().GetByAPIKeyHash(mock.Anything, usecase.HashAPIKey("k1")).Return(tenant, nil).Twice()
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-1", nil).Twice()
+	ctx := domain.WithTenantAPIKey(context.Background(), "k1")
+
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.Overrides.Sampling != nil && *p.Overrides.Sampling.Temperature == 0.1 && *p.Overrides.Sampling.TopP == 0.9
+	})).Return("t1", nil).Once()
+	_, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "", "")
+	require.NoError(t, err, "the tenant's parameters apply by default")
+
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		s := p.Overrides.Sampling
+		return s != nil && *s.Temperature == 0.7 && *s.TopP == 0.9 && *s.FrequencyPenalty == 0.3
+	})).Return("t2", nil).Once()
+	_, err = svc.Enqueue(domain.WithSamplingParams(ctx, domain.SamplingParams{Temperature: f64(0.7), FrequencyPenalty: f64(0.3)}), "cv-1", "pr-1", "jd", "sc", "", "")
+	require.NoError(t, err, "request parameters win over the tenant's")
+	queue.AssertExpectations(t)
+
+	_, err = svc.Enqueue(domain.WithSamplingParams(context.Background(), domain.SamplingParams{Temperature: f64(2)}), "cv-1", "pr-1", "jd", "sc", "", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
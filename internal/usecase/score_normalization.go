@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"log/slog"
+	"math"
+	"strings"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Score normalization methods.
+const (
+	ScoreNormalizationOff      = "off"
+	ScoreNormalizationZScore   = "zscore"
+	ScoreNormalizationQuantile = "quantile"
+)
+
+// scoreHistogramBuckets is the number of equal-width histogram buckets kept
+// per model and metric for quantile normalization.
+const scoreHistogramBuckets = 20
+
+// scoreRanges are the [min, max] ranges of the normalized metrics.
+var scoreRanges = map[string][2]float64{
+	domain.ScoreMetricCVMatchRate:  {0, 1},
+	domain.ScoreMetricProjectScore: {1, 10},
+}
+
+// ScoreNormalizer makes scores comparable across the free models that serve
+// evaluations. It tracks each model's score distribution and maps a model's
+// scores onto the pooled distribution of all models, either by z-score
+// (matching mean and standard deviation) or by quantile (matching
+// percentiles). A nil ScoreNormalizer keeps raw scores.
+type ScoreNormalizer struct {
+	Repo domain.ScoreStatsRepository
+	// Method is ScoreNormalizationZScore or ScoreNormalizationQuantile.
+	Method string
+	// MinSamples is the number of scores a model needs before its scores
+	// are normalized.
+	MinSamples int
+}
+
+// NewScoreNormalizer returns a ScoreNormalizer for method ("off", "zscore"
+// or "quantile"). It returns nil for "off" and unknown methods. A
+// non-positive minSamples defaults to 30.
+func NewScoreNormalizer(repo domain.ScoreStatsRepository, method string, minSamples int) *ScoreNormalizer {
+	method = strings.ToLower(strings.TrimSpace(method))
+	if method != ScoreNormalizationZScore && method != ScoreNormalizationQuantile {
+		if method != ScoreNormalizationOff && method != "" {
+			slog.Warn("unknown score normalization method; normalization disabled", slog.String("method", method))
+		}
+		return nil
+	}
+	if minSamples <= 0 {
+		minSamples = 30
+	}
+	return &ScoreNormalizer{Repo: repo, Method: method, MinSamples: minSamples}
+}
+
+// Normalize records the raw scores of res in the distribution of model and,
+// once model has MinSamples scores and other models have scored too, replaces
+// them with normalized scores, keeping the raw values in res.Normalization.
+// Scores are recorded once per job, so retries and redeliveries do not skew
+// the distribution. Statistics failures are logged and leave the raw scores
+// in place.
+func (n *ScoreNormalizer) Normalize(ctx domain.Context, model string, res domain.Result) domain.Result {
+	if n == nil || n.Repo == nil || model == "" {
+		return res
+	}
+	norm := domain.ScoreNormalization{Model: model, Method: n.Method}
+	if !res.ProjectOnly {
+		raw := res.CVMatchRate
+		if v, ok := n.normalize(ctx, model, domain.ScoreMetricCVMatchRate, raw, res.JobID); ok {
+			res.CVMatchRate, norm.RawCVMatchRate = v, &raw
+		}
+	}
+	if !res.CVOnly {
+		raw := res.ProjectScore
+		if v, ok := n.normalize(ctx, model, domain.ScoreMetricProjectScore, raw, res.JobID); ok {
+			res.ProjectScore, norm.RawProjectScore = v, &raw
+		}
+	}
+	if norm.RawCVMatchRate != nil || norm.RawProjectScore != nil {
+		res.Normalization = &norm
+	}
+	return res
+}
+
+// normalize observes x for model and returns its normalized value, or false
+// when the statistics are unavailable or too thin.
+func (n *ScoreNormalizer) normalize(ctx domain.Context, model, metric string, x float64, jobID string) (float64, bool) {
+	lo, hi := scoreRanges[metric][0], scoreRanges[metric][1]
+	if err := n.Repo.Observe(ctx, jobID, model, metric, x, scoreBucket(x, lo, hi), scoreHistogramBuckets); err != nil {
+		slog.Warn("score stats update failed; keeping raw score", slog.String("job_id", jobID), slog.String("metric", metric), slog.Any("error", err))
+		return 0, false
+	}
+	dists, err := n.Repo.List(ctx, metric)
+	if err != nil {
+		slog.Warn("score stats lookup failed; keeping raw score", slog.String("job_id", jobID), slog.String("metric", metric), slog.Any("error", err))
+		return 0, false
+	}
+	var own *domain.ScoreDistribution
+	pooled := domain.ScoreDistribution{Buckets: make([]int64, scoreHistogramBuckets)}
+	for i := range dists {
+		d := &dists[i]
+		if d.Model == model {
+			own = d
+		}
+		pooled.Count += d.Count
+		pooled.Sum += d.Sum
+		pooled.SumSquares += d.SumSquares
+		for j, c := range d.Buckets {
+			if j < len(pooled.Buckets) {
+				pooled.Buckets[j] += c
+			}
+		}
+	}
+	// Nothing to compare against until other models have scored as well.
+	if own == nil || own.Count < int64(n.MinSamples) || own.Count == pooled.Count {
+		return 0, false
+	}
+	var y float64
+	var ok bool
+	if n.Method == ScoreNormalizationQuantile {
+		y, ok = quantileMap(x, own.Buckets, pooled.Buckets, lo, hi)
+	} else {
+		y, ok = zScoreMap(x, *own, pooled)
+	}
+	if !ok {
+		return 0, false
+	}
+	return min(max(y, lo), hi), true
+}
+
+// meanStd returns the mean and population standard deviation of d.
+func meanStd(d domain.ScoreDistribution) (float64, float64) {
+	if d.Count == 0 {
+		return 0, 0
+	}
+	mean := d.Sum / float64(d.Count)
+	variance := d.SumSquares/float64(d.Count) - mean*mean
+	return mean, math.Sqrt(max(variance, 0))
+}
+
+// zScoreMap maps x from own's distribution onto pooled by matching z-scores.
+func zScoreMap(x float64, own, pooled domain.ScoreDistribution) (float64, bool) {
+	mean, std := meanStd(own)
+	pooledMean, pooledStd := meanStd(pooled)
+	if std == 0 {
+		return 0, false
+	}
+	return pooledMean + (x-mean)/std*pooledStd, true
+}
+
+// quantileMap maps x from the own histogram onto the pooled histogram by
+// matching percentiles, interpolating linearly within buckets.
+func quantileMap(x float64, own, pooled []int64, lo, hi float64) (float64, bool) {
+	width := (hi - lo) / scoreHistogramBuckets
+	b := scoreBucket(x, lo, hi)
+	var below, total int64
+	for i, c := range own {
+		if i < b {
+			below += c
+		}
+		total += c
+	}
+	if total == 0 || b >= len(own) {
+		return 0, false
+	}
+	frac := min(max((x-(lo+float64(b)*width))/width, 0), 1)
+	p := (float64(below) + frac*float64(own[b])) / float64(total)
+
+	var pooledTotal int64
+	for _, c := range pooled {
+		pooledTotal += c
+	}
+	target := p * float64(pooledTotal)
+	var cum float64
+	for i, c := range pooled {
+		if c > 0 && cum+float64(c) >= target {
+			return lo + (float64(i)+(target-cum)/float64(c))*width, true
+		}
+		cum += float64(c)
+	}
+	return hi, true
+}
+
+// scoreBucket returns the histogram bucket of x within [lo, hi].
+func scoreBucket(x, lo, hi float64) int {
+	b := int((x - lo) / (hi - lo) * scoreHistogramBuckets)
+	return min(max(b, 0), scoreHistogramBuckets-1)
+}
@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+// scoreDist builds a distribution of the given project scores.
+func scoreDist(model string, scores ...float64) domain.ScoreDistribution {
+	d := domain.ScoreDistribution{Model: model, Metric: domain.ScoreMetricProjectScore, Buckets: make([]int64, 20)}
+	for _, s := range scores {
+		d.Count++
+		d.Sum += s
+		d.SumSquares += s * s
+		d.Buckets[min(int((s-1)/9*20), 19)]++
+	}
+	return d
+}
+
+func TestNewScoreNormalizer(t *testing.T) {
+	assert.Nil(t, usecase.NewScoreNormalizer(nil, "off", 0))
+	assert.Nil(t, usecase.NewScoreNormalizer(nil, "bogus", 0))
+	n := usecase.NewScoreNormalizer(nil, " ZScore ", 0)
+	require.NotNil(t, n)
+	assert.Equal(t, usecase.ScoreNormalizationZScore, n.Method)
+	assert.Equal(t, 30, n.MinSamples)
+
+	var disabled *usecase.ScoreNormalizer
+	res := domain.Result{ProjectScore: 7}
+	assert.Equal(t, res, disabled.Normalize(context.Background(), "m", res))
+}
+
+func TestScoreNormalizer_ZScore(t *testing.T) {
+	repo := mocks.NewMockScoreStatsRepository(t)
+	n := usecase.NewScoreNormalizer(repo, usecase.ScoreNormalizationZScore, 4)
+	// The lenient model scores 8±1, the pooled distribution 6±√5.
+	lenient := scoreDist("lenient", 7, 9, 7, 9)
+	strict := scoreDist("strict", 3, 5, 3, 5)
+	repo.EXPECT().Observe(mock.Anything, "j1", "lenient", domain.ScoreMetricProjectScore, 9.0, 17, 20).Return(nil).Once()
+	repo.EXPECT().List(mock.Anything, domain.ScoreMetricProjectScore).Return([]domain.ScoreDistribution{lenient, strict}, nil).Once()
+
+	res := n.Normalize(context.Background(), "lenient", domain.Result{JobID: "j1", ProjectScore: 9, ProjectOnly: true})
+	// One standard deviation above the model mean maps to one above the pooled mean.
+	assert.InDelta(t, 6+2.2360680, res.ProjectScore, 1e-6)
+	require.NotNil(t, res.Normalization)
+	assert.Equal(t, "lenient", res.Normalization.Model)
+	assert.Equal(t, 9.0, *res.Normalization.RawProjectScore)
+	assert.Nil(t, res.Normalization.RawCVMatchRate)
+}
+
+func TestScoreNormalizer_Quantile(t *testing.T) {
+	repo := mocks.NewMockScoreStatsRepository(t)
+	n := usecase.NewScoreNormalizer(repo, usecase.ScoreNormalizationQuantile, 2)
+	own := scoreDist("m1", 8, 8.5)
+	other := scoreDist("m2", 2, 2.5)
+	repo.EXPECT().Observe(mock.Anything, mock.Anything, "m1", domain.ScoreMetricProjectScore, mock.Anything, mock.Anything, 20).Return(nil).Once()
+	repo.EXPECT().List(mock.Anything, domain.ScoreMetricProjectScore).Return([]domain.ScoreDistribution{own, other}, nil).Once()
+
+	// The model's median maps to the pooled median, between the two clusters.
+	res := n.Normalize(context.Background(), "m1", domain.Result{ProjectScore: 8.3, ProjectOnly: true})
+	assert.Less(t, res.ProjectScore, 8.3)
+	assert.GreaterOrEqual(t, res.ProjectScore, 2.0)
+	require.NotNil(t, res.Normalization)
+}
+
+func TestScoreNormalizer_KeepsRawScores(t *testing.T) {
+	repo := mocks.NewMockScoreStatsRepository(t)
+	n := usecase.NewScoreNormalizer(repo, usecase.ScoreNormalizationZScore, 4)
+	ctx := context.Background()
+
+	// Too few samples for the model.
+	repo.EXPECT().Observe(mock.Anything, mock.Anything, "m1", domain.ScoreMetricProjectScore, 7.0, mock.Anything, 20).Return(nil).Once()
+	repo.EXPECT().List(mock.Anything, domain.ScoreMetricProjectScore).Return([]domain.ScoreDistribution{scoreDist("m1", 7), scoreDist("m2", 3, 4, 5, 6)}, nil).Once()
+	res := n.Normalize(ctx, "m1", domain.Result{ProjectScore: 7, ProjectOnly: true})
+	assert.Equal(t, 7.0, res.ProjectScore)
+	assert.Nil(t, res.Normalization)
+
+	// Stats unavailable.
+	repo.EXPECT().Observe(mock.Anything, mock.Anything, "m1", domain.ScoreMetricCVMatchRate, 0.5, 10, 20).Return(assert.AnError).Once()
+	res = n.Normalize(ctx, "m1", domain.Result{CVMatchRate: 0.5, CVOnly: true})
+	assert.Equal(t, 0.5, res.CVMatchRate)
+	assert.Nil(t, res.Normalization)
+
+	// Unknown model.
+	res = n.Normalize(ctx, "", domain.Result{CVMatchRate: 0.5, CVOnly: true})
+	assert.Equal(t, 0.5, res.CVMatchRate)
+}
@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/config"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// scoringWeightsTolerance is how far a group's weights may sum from 100.
+const scoringWeightsTolerance = 0.01
+
+// ActiveRubric returns the rubric sections of a job evaluated with
+// scoringRubric: the rubric entries of scoringRubric itself when it is
+// written in the shape of configs/rag/scoring_rubric.yaml, otherwise those of
+// configs/rag/scoring_rubric.yaml, and domain.DefaultRubric when neither
+// defines any.
+func ActiveRubric(scoringRubric string) []domain.RubricSection {
+	if sections := rubricSections(config.ParseRubricItems(scoringRubric)); len(sections) > 0 {
+		return sections
+	}
+	if sections := rubricSections(config.GetDefaultRubricItems()); len(sections) > 0 {
+		return sections
+	}
+	return domain.DefaultRubric
+}
+
+// rubricSections converts rubric entries to sections, skipping entries
+// without a valid group. Sections of the default rubric keep its labels and
+// may omit the group.
+func rubricSections(items []config.RAGYAMLItem) []domain.RubricSection {
+	defaults := make(map[string]domain.RubricSection, len(domain.DefaultRubric))
+	for _, s := range domain.DefaultRubric {
+		defaults[s.Name] = s
+	}
+	var out []domain.RubricSection
+	for _, it := range items {
+		name := strings.TrimSpace(it.Section)
+		s := domain.RubricSection{Name: name, Group: it.Group, Label: rubricLabel(it.Text, name), Weight: math.Round(it.Weight*100*1e6) / 1e6}
+		if d, ok := defaults[name]; ok {
+			s.Label = d.Label
+			if s.Group == "" {
+				s.Group = d.Group
+			}
+		}
+		if s.Group != domain.RubricGroupCV && s.Group != domain.RubricGroupProject {
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// rubricLabel returns the parameter name a rubric entry's text starts with,
+// e.g. "Correctness" for "Correctness (30%): ...", or name.
+func rubricLabel(text, name string) string {
+	label, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	if i := strings.IndexAny(label, "(:"); i >= 0 {
+		label = label[:i]
+	}
+	if label = strings.TrimSpace(label); label == "" {
+		return name
+	}
+	return label
+}
+
+// ValidateScoringWeights checks request weight overrides against the active
+// rubric and returns the complete weights to apply, or nil when w is empty.
+// Every key must name a section of rubric and lie in [0, 100]. A group is
+// overridden as a whole: once one of its sections is given, all must be and
+// they must sum to 100. Groups without overrides keep their rubric weights.
+func ValidateScoringWeights(w domain.ScoringWeights, rubric []domain.RubricSection) (domain.ScoringWeights, error) {
+	if len(w) == 0 {
+		return nil, nil
+	}
+	groups := map[string]string{}
+	for _, s := range rubric {
+		groups[s.Name] = s.Group
+	}
+	keys := make([]string, 0, len(w))
+	for k := range w {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	overridden := map[string]bool{}
+	for _, k := range keys {
+		g, ok := groups[k]
+		if !ok {
+			return nil, fmt.Errorf("%w: scoring_weights: unknown rubric section %q", domain.ErrInvalidArgument, k)
+		}
+		if v := w[k]; math.IsNaN(v) || v < 0 || v > 100 {
+			return nil, fmt.Errorf("%w: scoring_weights: %s must be between 0 and 100", domain.ErrInvalidArgument, k)
+		}
+		overridden[g] = true
+	}
+	sums := map[string]float64{}
+	out := make(domain.ScoringWeights, len(rubric))
+	for _, s := range rubric {
+		v, ok := w[s.Name]
+		if overridden[s.Group] && !ok {
+			return nil, fmt.Errorf("%w: scoring_weights: %s weights must include %s", domain.ErrInvalidArgument, s.Group, s.Name)
+		}
+		if !ok {
+			v = s.Weight
+		}
+		out[s.Name] = v
+		sums[s.Group] += v
+	}
+	for _, g := range []string{domain.RubricGroupCV, domain.RubricGroupProject} {
+		if overridden[g] && math.Abs(sums[g]-100) > scoringWeightsTolerance {
+			return nil, fmt.Errorf("%w: scoring_weights: %s weights sum to %g, want 100", domain.ErrInvalidArgument, g, sums[g])
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,110 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestValidateScoringWeights(t *testing.T) {
+	w, err := usecase.ValidateScoringWeights(nil, domain.DefaultRubric)
+	require.NoError(t, err)
+	assert.Nil(t, w)
+
+	w, err = usecase.ValidateScoringWeights(domain.ScoringWeights{
+		"technical_skills_match": 50, "experience_level": 30, "relevant_achievements": 10, "cultural_collaboration_fit": 10,
+	}, domain.DefaultRubric)
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, w["technical_skills_match"])
+	assert.Equal(t, 30.0, w["correctness"], "project group keeps its defaults")
+	assert.Len(t, w, len(domain.DefaultRubric))
+
+	for name, in := range map[string]domain.ScoringWeights{
+		"unknown section": {"leadership": 100},
+		"out of range":    {"technical_skills_match": 120, "experience_level": -20, "relevant_achievements": 0, "cultural_collaboration_fit": 0},
+		"incomplete":      {"technical_skills_match": 50, "experience_level": 50},
+		"bad sum":         {"technical_skills_match": 50, "experience_level": 30, "relevant_achievements": 10, "cultural_collaboration_fit": 5},
+	} {
+		_, err := usecase.ValidateScoringWeights(in, domain.DefaultRubric)
+		assert.ErrorIs(t, err, domain.ErrInvalidArgument, name)
+	}
+}
+
+func TestEvaluate_Enqueue_ScoringWeights(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	project := domain.ScoringWeights{"correctness": 40, "code_quality_structure": 40, "resilience_error_handling": 10, "documentation_explanation": 10, "creativity_bonus": 0}
+
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-1", nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.ScoringWeights["correctness"] == 40 && p.ScoringWeights["technical_skills_match"] == 40 && len(p.Rubric) == len(domain.DefaultRubric)
+	})).Return("t1", nil).Once()
+	ctx := domain.WithScoringWeights(context.Background(), project)
+	_, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.NoError(t, err)
+	queue.AssertExpectations(t)
+
+	ctx = domain.WithScoringWeights(context.Background(), domain.ScoringWeights{"correctness": 100})
+	_, err = svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+// customRubric is a rubric in the shape of configs/rag/scoring_rubric.yaml
+// that replaces the default CV parameters.
+const customRubric = `data:
+  - text: "Leadership (60%): Leads teams and projects."
+    type: rubric
+    section: leadership
+    group: cv
+    weight: 0.6
+  - text: "Domain Knowledge (40%): Knows the industry."
+    type: rubric
+    section: domain_knowledge
+    group: cv
+    weight: 0.4
+  - text: "Correctness (100%): Works as specified."
+    type: rubric
+    section: correctness
+    weight: 1
+`
+
+func TestActiveRubric(t *testing.T) {
+	assert.Equal(t, domain.DefaultRubric, usecase.ActiveRubric("CV Match Evaluation (1–5 scale per parameter)"))
+
+	rubric := usecase.ActiveRubric(customRubric)
+	assert.Equal(t, []domain.RubricSection{
+		{Name: "leadership", Group: domain.RubricGroupCV, Label: "Leadership", Weight: 60},
+		{Name: "domain_knowledge", Group: domain.RubricGroupCV, Label: "Domain Knowledge", Weight: 40},
+		{Name: "correctness", Group: domain.RubricGroupProject, Label: "Correctness", Weight: 100},
+	}, rubric)
+
+	w, err := usecase.ValidateScoringWeights(domain.ScoringWeights{"leadership": 30, "domain_knowledge": 70}, rubric)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ScoringWeights{"leadership": 30, "domain_knowledge": 70, "correctness": 100}, w)
+	_, err = usecase.ValidateScoringWeights(domain.ScoringWeights{"technical_skills_match": 100}, rubric)
+	assert.ErrorIs(t, err, domain.ErrInvalidArgument, "sections of the default rubric are not in the active one")
+}
+
+func TestEvaluate_Enqueue_ScoringWeightsUseTheJobRubric(t *testing.T) {
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-1", nil).Once()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.ScoringWeights["leadership"] == 50 && len(p.Rubric) == 3 && p.Rubric[0].Name == "leadership"
+	})).Return("t1", nil).Once()
+	ctx := domain.WithScoringWeights(context.Background(), domain.ScoringWeights{"leadership": 50, "domain_knowledge": 50})
+	_, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", customRubric, "")
+	require.NoError(t, err)
+	queue.AssertExpectations(t)
+
+	ctx = domain.WithScoringWeights(context.Background(), domain.ScoringWeights{"leadership": 50, "domain_knowledge": 50})
+	_, err = svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "sr", "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument, "the default rubric has no leadership section")
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/service/envelope"
+)
+
+// Limits of admin-supplied tenant settings.
+const (
+	maxTenantPreferredModels = 10
+	maxTenantMaxTokens       = 32768
+	maxTenantRubricTemplate  = 10000
+)
+
+var tenantIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// TenantService manages per-tenant evaluation settings and resolves them
+// from the API key of a request. A nil *TenantService resolves every request
+// to the deployment defaults.
+type TenantService struct {
+	Repo domain.TenantSettingsRepository
+
+	now func() time.Time
+}
+
+// NewTenantService constructs a TenantService.
+func NewTenantService(repo domain.TenantSettingsRepository) *TenantService {
+	return &TenantService{Repo: repo, now: time.Now}
+}
+
+// HashAPIKey returns the hex SHA-256 under which an API key is stored.
+func HashAPIKey(key string) string { return hash(key) }
+
+// Resolve returns the settings of the tenant owning apiKey. It returns false
+// when apiKey is empty or unknown, in which case the defaults apply.
+func (s *TenantService) Resolve(ctx domain.Context, apiKey string) (domain.TenantSettings, bool, error) {
+	if s == nil || apiKey == "" {
+		return domain.TenantSettings{}, false, nil
+	}
+	ts, err := s.Repo.GetByAPIKeyHash(ctx, HashAPIKey(apiKey))
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.TenantSettings{}, false, nil
+	}
+	if err != nil {
+		return domain.TenantSettings{}, false, fmt.Errorf("op=tenant.resolve: %w", err)
+	}
+	return ts, true, nil
+}
+
+// List returns all tenants.
+func (s *TenantService) List(ctx domain.Context) ([]domain.TenantSettings, error) {
+	return s.Repo.List(ctx)
+}
+
+// Set validates and stores the settings of ts.TenantID. apiKey assigns the
+// tenant's API key; it is required for a new tenant and may be empty to keep
+// the key of an existing one.
+func (s *TenantService) Set(ctx domain.Context, ts domain.TenantSettings, apiKey string) (domain.TenantSettings, error) {
+	if !tenantIDPattern.MatchString(ts.TenantID) {
+		return domain.TenantSettings{}, fmt.Errorf("%w: tenant_id must be 1-64 characters of letters, digits, '.', '_' or '-'", domain.ErrInvalidArgument)
+	}
+	ov := &ts.Overrides
+	if len(ov.PreferredModels) > maxTenantPreferredModels {
+		return domain.TenantSettings{}, fmt.Errorf("%w: at most %d preferred_models", domain.ErrInvalidArgument, maxTenantPreferredModels)
+	}
+	models := make([]string, 0, len(ov.PreferredModels))
+	for _, m := range ov.PreferredModels {
+		if m = strings.TrimSpace(m); m == "" {
+			return domain.TenantSettings{}, fmt.Errorf("%w: preferred_models must not contain empty entries", domain.ErrInvalidArgument)
+		}
+		models = append(models, m)
+	}
+	ov.PreferredModels = models
+	if ov.MaxTokens < 0 || ov.MaxTokens > maxTenantMaxTokens {
+		return domain.TenantSettings{}, fmt.Errorf("%w: max_tokens must be between 0 and %d", domain.ErrInvalidArgument, maxTenantMaxTokens)
+	}
+	if ov.Sampling != nil {
+		if err := ValidateSamplingParams(*ov.Sampling); err != nil {
+			return domain.TenantSettings{}, err
+		}
+		if ov.Sampling.IsZero() {
+			ov.Sampling = nil
+		}
+	}
+	if len(ts.RubricTemplate) > maxTenantRubricTemplate {
+		return domain.TenantSettings{}, fmt.Errorf("%w: rubric_template exceeds %d bytes", domain.ErrInvalidArgument, maxTenantRubricTemplate)
+	}
+	if ts.DailyEvaluations < 0 || ts.DailyTokens < 0 {
+		return domain.TenantSettings{}, fmt.Errorf("%w: daily_evaluations and daily_tokens must not be negative", domain.ErrInvalidArgument)
+	}
+	if ts.ResultPublicKey = strings.TrimSpace(ts.ResultPublicKey); ts.ResultPublicKey != "" {
+		if _, _, err := envelope.ParsePublicKey(ts.ResultPublicKey); err != nil {
+			return domain.TenantSettings{}, err
+		}
+	}
+
+	ts.APIKeyHash = ""
+	if apiKey != "" {
+		ts.APIKeyHash = HashAPIKey(apiKey)
+		owner, err := s.Repo.GetByAPIKeyHash(ctx, ts.APIKeyHash)
+		switch {
+		case err == nil && owner.TenantID != ts.TenantID:
+			return domain.TenantSettings{}, fmt.Errorf("%w: api_key belongs to another tenant", domain.ErrConflict)
+		case err != nil && !errors.Is(err, domain.ErrNotFound):
+			return domain.TenantSettings{}, fmt.Errorf("op=tenant.set: %w", err)
+		}
+	} else {
+		existing, err := s.Repo.Get(ctx, ts.TenantID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.TenantSettings{}, fmt.Errorf("%w: api_key is required for a new tenant", domain.ErrInvalidArgument)
+		}
+		if err != nil {
+			return domain.TenantSettings{}, fmt.Errorf("op=tenant.set: %w", err)
+		}
+		ts.APIKeyHash = existing.APIKeyHash
+	}
+	ts.UpdatedAt = s.now().UTC()
+	if err := s.Repo.Upsert(ctx, ts); err != nil {
+		return domain.TenantSettings{}, fmt.Errorf("op=tenant.set: %w", err)
+	}
+	return ts, nil
+}
+
+// Delete removes a tenant; its requests fall back to the defaults.
+func (s *TenantService) Delete(ctx domain.Context, tenantID string) error {
+	return s.Repo.Delete(ctx, tenantID)
+}
@@ -0,0 +1,114 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestTenantService_Resolve(t *testing.T) {
+	repo := mocks.NewMockTenantSettingsRepository(t)
+	svc := usecase.NewTenantService(repo)
+	ctx := context.Background()
+
+	var nilSvc *usecase.TenantService
+	_, ok, err := nilSvc.Resolve(ctx, "key")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	_, ok, err = svc.Resolve(ctx, "")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	acme := domain.TenantSettings{TenantID: "acme", Overrides: domain.EvaluationOverrides{MaxTokens: 800}}
+	repo.EXPECT().GetByAPIKeyHash(mock.Anything, usecase.HashAPIKey("k1")).Return(acme, nil).Once()
+	got, ok, err := svc.Resolve(ctx, "k1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, acme, got)
+
+	repo.EXPECT().GetByAPIKeyHash(mock.Anything, usecase.HashAPIKey("k2")).Return(domain.TenantSettings{}, domain.ErrNotFound).Once()
+	_, ok, err = svc.Resolve(ctx, "k2")
+	require.NoError(t, err, "unknown keys use the defaults")
+	assert.False(t, ok)
+
+	repo.EXPECT().GetByAPIKeyHash(mock.Anything, mock.Anything).Return(domain.TenantSettings{}, assert.AnError).Once()
+	_, _, err = svc.Resolve(ctx, "k3")
+	require.ErrorContains(t, err, "op=tenant.resolve")
+}
+
+func TestTenantService_Set(t *testing.T) {
+	repo := mocks.NewMockTenantSettingsRepository(t)
+	svc := usecase.NewTenantService(repo)
+	ctx := context.Background()
+
+	for _, ts := range []domain.TenantSettings{
+		{TenantID: "bad id"},
+		{TenantID: "acme", Overrides: domain.EvaluationOverrides{MaxTokens: -1}},
+		{TenantID: "acme", Overrides: domain.EvaluationOverrides{PreferredModels: []string{" "}}},
+		{TenantID: "acme", DailyEvaluations: -1},
+		{TenantID: "acme", DailyTokens: -1},
+		{TenantID: "acme", Overrides: domain.EvaluationOverrides{Sampling: &domain.SamplingParams{TopP: new(float64)}}},
+		{TenantID: "acme", ResultPublicKey: "not a key"},
+	} {
+		_, err := svc.Set(ctx, ts, "k1")
+		require.ErrorIs(t, err, domain.ErrInvalidArgument, "%+v", ts)
+	}
+
+	repo.EXPECT().Get(mock.Anything, "acme").Return(domain.TenantSettings{}, domain.ErrNotFound).Once()
+	_, err := svc.Set(ctx, domain.TenantSettings{TenantID: "acme"}, "")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument, "a new tenant needs a key")
+
+	repo.EXPECT().GetByAPIKeyHash(mock.Anything, usecase.HashAPIKey("k1")).Return(domain.TenantSettings{TenantID: "other"}, nil).Once()
+	_, err = svc.Set(ctx, domain.TenantSettings{TenantID: "acme"}, "k1")
+	require.ErrorIs(t, err, domain.ErrConflict)
+
+	repo.EXPECT().GetByAPIKeyHash(mock.Anything, usecase.HashAPIKey("k1")).Return(domain.TenantSettings{}, domain.ErrNotFound).Once()
+	repo.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(s domain.TenantSettings) bool {
+		return s.APIKeyHash == usecase.HashAPIKey("k1") && s.Overrides.PreferredModels[0] == "llama-3.3-70b-versatile" && !s.UpdatedAt.IsZero()
+	})).Return(nil).Once()
+	got, err := svc.Set(ctx, domain.TenantSettings{TenantID: "acme", Overrides: domain.EvaluationOverrides{PreferredModels: []string{" llama-3.3-70b-versatile "}}}, "k1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"llama-3.3-70b-versatile"}, got.Overrides.PreferredModels)
+
+	repo.EXPECT().Get(mock.Anything, "acme").Return(domain.TenantSettings{TenantID: "acme", APIKeyHash: "h"}, nil).Once()
+	repo.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(s domain.TenantSettings) bool {
+		return s.APIKeyHash == "h" && s.Overrides.Anonymize
+	})).Return(nil).Once()
+	_, err = svc.Set(ctx, domain.TenantSettings{TenantID: "acme", Overrides: domain.EvaluationOverrides{Anonymize: true}}, "")
+	require.NoError(t, err, "updates keep the stored key")
+}
+
+func TestEvaluate_Enqueue_TenantOverrides(t *testing.T) {
+	repo := mocks.NewMockTenantSettingsRepository(t)
+	jobRepo, queue, uploadRepo := setupMocks()
+	svc := usecase.NewEvaluateService(jobRepo, queue, uploadRepo)
+	svc.Tenants = usecase.NewTenantService(repo)
+	ov := domain.EvaluationOverrides{PreferredModels: []string{"m1"}, MaxTokens: 800, Anonymize: true}
+	ctx := domain.WithTenantAPIKey(context.Background(), "k1")
+
+	repo.EXPECT().GetByAPIKeyHash(mock.Anything, usecase.HashAPIKey("k1")).Return(domain.TenantSettings{TenantID: "acme", Overrides: ov, RubricTemplate: "acme rubric"}, nil).Twice()
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return("job-1", nil).Twice()
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.ScoringRubric == "acme rubric" && p.TenantID == "acme" && assert.ObjectsAreEqual(ov, p.Overrides)
+	})).Return("t1", nil).Once()
+	_, err := svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "", "")
+	require.NoError(t, err)
+
+	queue.On("EnqueueEvaluate", mock.Anything, mock.MatchedBy(func(p domain.EvaluateTaskPayload) bool {
+		return p.ScoringRubric == "own rubric"
+	})).Return("t2", nil).Once()
+	_, err = svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "own rubric", "")
+	require.NoError(t, err, "a request rubric wins over the template")
+	queue.AssertExpectations(t)
+
+	repo.EXPECT().GetByAPIKeyHash(mock.Anything, mock.Anything).Return(domain.TenantSettings{}, assert.AnError).Once()
+	_, err = svc.Enqueue(ctx, "cv-1", "pr-1", "jd", "sc", "", "")
+	require.Error(t, err)
+}
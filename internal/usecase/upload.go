@@ -17,6 +17,11 @@ import (
 // UploadService ingests sanitized texts and persists them via the repository.
 type UploadService struct {
 	Repo domain.UploadRepository
+	// QualityGate rejects extracted texts that are too short, garbled or
+	// repetitive and reports warnings for table-heavy or fragmented ones.
+	QualityGate bool
+	// MinChars is the shortest accepted text when QualityGate is on.
+	MinChars int
 }
 
 // NewUploadService constructs an UploadService with the given repo.
@@ -29,22 +34,62 @@ func (s UploadService) Ingest(ctx domain.Context, cvText, projText, cvName, proj
 	ctx, span := tr.Start(ctx, "UploadService.Ingest")
 	defer span.End()
 
+	return s.ingest(ctx, domain.UploadSourceFile, cvText, projText, cvName, projName)
+}
+
+// IngestText stores a CV and a project submitted as text rather than files,
+// with the same checks as Ingest, and marks them with source text.
+func (s UploadService) IngestText(ctx domain.Context, cvText, projText string) (string, string, error) {
+	tr := otel.Tracer("usecase.upload")
+	ctx, span := tr.Start(ctx, "UploadService.IngestText")
+	defer span.End()
+
+	return s.ingest(ctx, domain.UploadSourceText, cvText, projText, "", "")
+}
+
+func (s UploadService) ingest(ctx domain.Context, source, cvText, projText, cvName, projName string) (string, string, error) {
 	cvText = sanitize(cvText)
 	projText = sanitize(projText)
 	if cvText == "" || projText == "" {
 		return "", "", fmt.Errorf("%w: empty extracted text", domain.ErrInvalidArgument)
 	}
-	cvID, err := s.Repo.Create(ctx, domain.Upload{Type: domain.UploadTypeCV, Text: cvText, Filename: cvName, MIME: mimeFromName(cvName), Size: int64(len(cvText)), CreatedAt: time.Now().UTC()})
+	if err := s.checkUploadQuality(ctx, domain.UploadTypeCV, cvText); err != nil {
+		return "", "", err
+	}
+	if err := s.checkUploadQuality(ctx, domain.UploadTypeProject, projText); err != nil {
+		return "", "", err
+	}
+	cvID, err := s.Repo.Create(ctx, domain.Upload{Type: domain.UploadTypeCV, Text: cvText, Filename: cvName, MIME: mimeFromName(cvName), Size: int64(len(cvText)), Source: source, CreatedAt: time.Now().UTC()})
 	if err != nil {
 		return "", "", err
 	}
-	prjID, err := s.Repo.Create(ctx, domain.Upload{Type: domain.UploadTypeProject, Text: projText, Filename: projName, MIME: mimeFromName(projName), Size: int64(len(projText)), CreatedAt: time.Now().UTC()})
+	prjID, err := s.Repo.Create(ctx, domain.Upload{Type: domain.UploadTypeProject, Text: projText, Filename: projName, MIME: mimeFromName(projName), Size: int64(len(projText)), Source: source, CreatedAt: time.Now().UTC()})
 	if err != nil {
 		return "", "", err
 	}
 	return cvID, prjID, nil
 }
 
+// IngestOne sanitizes and stores a single document of uploadType (cv or
+// project), returning its id.
+func (s UploadService) IngestOne(ctx domain.Context, uploadType, text, name string) (string, error) {
+	tr := otel.Tracer("usecase.upload")
+	ctx, span := tr.Start(ctx, "UploadService.IngestOne")
+	defer span.End()
+
+	if uploadType != domain.UploadTypeCV && uploadType != domain.UploadTypeProject {
+		return "", fmt.Errorf("%w: unknown upload type %q", domain.ErrInvalidArgument, uploadType)
+	}
+	text = sanitize(text)
+	if text == "" {
+		return "", fmt.Errorf("%w: empty extracted text", domain.ErrInvalidArgument)
+	}
+	if err := s.checkUploadQuality(ctx, uploadType, text); err != nil {
+		return "", err
+	}
+	return s.Repo.Create(ctx, domain.Upload{Type: uploadType, Text: text, Filename: name, MIME: mimeFromName(name), Size: int64(len(text)), Source: domain.UploadSourceFile, CreatedAt: time.Now().UTC()})
+}
+
 func sanitize(s string) string { return strings.TrimSpace(s) }
 
 func mimeFromName(n string) string {
@@ -54,6 +99,8 @@ func mimeFromName(n string) string {
 		return "application/pdf"
 	case strings.HasSuffix(n, ".docx"):
 		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	case strings.HasSuffix(n, ".json"):
+		return "application/json"
 	default:
 		return "text/plain"
 	}
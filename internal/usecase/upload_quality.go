@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+)
+
+// Upload quality issue codes.
+const (
+	QualityTooShort     = "too_short"
+	QualityGarbled      = "garbled_text"
+	QualityRepetitive   = "repetitive_text"
+	QualityMostlyTables = "mostly_tables"
+	QualityFewWords     = "few_words"
+)
+
+// Thresholds of the upload quality gate.
+const (
+	// qualityMaxReplacementRatio is the share of U+FFFD and private-use
+	// characters above which the text is considered undecodable.
+	qualityMaxReplacementRatio = 0.05
+	// qualityMinEntropy is the character entropy in bits below which the
+	// text is mostly the same few characters.
+	qualityMinEntropy = 3.0
+	// qualityEntropySample is the fewest non-space characters the entropy
+	// check needs; shorter texts have too few to judge.
+	qualityEntropySample = 50
+	// qualityMaxTableRatio is the share of digits and punctuation above
+	// which the text is treated as mostly tables or figures.
+	qualityMaxTableRatio = 0.35
+	// qualityMinWordRatio is the share of whitespace-separated tokens that
+	// look like words below which the text is treated as fragments.
+	qualityMinWordRatio = 0.5
+)
+
+// UploadQualityIssue is one reason an extracted document was rejected or
+// flagged, with advice on how to fix the submission.
+type UploadQualityIssue struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Reject is true when the document cannot be evaluated as extracted.
+	Reject bool `json:"-"`
+}
+
+// UploadQuality is the assessment of one extracted document.
+type UploadQuality struct {
+	Chars     int
+	Entropy   float64
+	WordRatio float64
+	// TableRatio is the share of digits and punctuation among non-space characters.
+	TableRatio float64
+	Issues     []UploadQualityIssue
+}
+
+// Rejected reports whether any issue rejects the document.
+func (q UploadQuality) Rejected() bool {
+	for _, i := range q.Issues {
+		if i.Reject {
+			return true
+		}
+	}
+	return false
+}
+
+// AssessUploadQuality scores extracted text by length, character classes,
+// word ratio and character entropy. Texts shorter than minChars are
+// rejected; minChars <= 0 skips the length check.
+func AssessUploadQuality(text string, minChars int) UploadQuality {
+	var q UploadQuality
+	freq := map[rune]int{}
+	var nonSpace, tableLike, undecodable int
+	for _, r := range text {
+		q.Chars++
+		if unicode.IsSpace(r) {
+			continue
+		}
+		nonSpace++
+		freq[r]++
+		switch {
+		case r == unicode.ReplacementChar || unicode.In(r, unicode.Co):
+			undecodable++
+		case unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSymbol(r):
+			tableLike++
+		}
+	}
+	if nonSpace > 0 {
+		for _, n := range freq {
+			p := float64(n) / float64(nonSpace)
+			q.Entropy -= p * math.Log2(p)
+		}
+		q.TableRatio = float64(tableLike) / float64(nonSpace)
+	}
+	tokens := strings.Fields(text)
+	words := 0
+	for _, t := range tokens {
+		if isWordToken(t) {
+			words++
+		}
+	}
+	if len(tokens) > 0 {
+		q.WordRatio = float64(words) / float64(len(tokens))
+	}
+
+	if minChars > 0 && q.Chars < minChars {
+		q.Issues = append(q.Issues, UploadQualityIssue{
+			Code:    QualityTooShort,
+			Message: fmt.Sprintf("only %d characters of text could be extracted (minimum %d); the document appears to be scanned images - upload a text-based PDF or DOCX, or run OCR first", q.Chars, minChars),
+			Reject:  true,
+		})
+		return q
+	}
+	if nonSpace > 0 && float64(undecodable)/float64(nonSpace) > qualityMaxReplacementRatio {
+		q.Issues = append(q.Issues, UploadQualityIssue{
+			Code:    QualityGarbled,
+			Message: "the extracted text is garbled; the PDF may use embedded fonts without a text layer or be protected - re-export it as a standard PDF or upload a DOCX",
+			Reject:  true,
+		})
+	}
+	if nonSpace >= qualityEntropySample && q.Entropy < qualityMinEntropy {
+		q.Issues = append(q.Issues, UploadQualityIssue{
+			Code:    QualityRepetitive,
+			Message: "the extracted text is mostly the same few characters, e.g. separators or placeholder text - check that the right file was uploaded",
+			Reject:  true,
+		})
+	}
+	if q.TableRatio > qualityMaxTableRatio {
+		q.Issues = append(q.Issues, UploadQualityIssue{
+			Code:    QualityMostlyTables,
+			Message: fmt.Sprintf("%.0f%% of the text is digits and punctuation; the document appears to be mostly tables or figures, which evaluate poorly - describe your experience in prose where possible", q.TableRatio*100),
+		})
+	}
+	if len(tokens) > 0 && q.WordRatio < qualityMinWordRatio {
+		q.Issues = append(q.Issues, UploadQualityIssue{
+			Code:    QualityFewWords,
+			Message: fmt.Sprintf("only %.0f%% of the text reads as words; layout columns or text boxes may have been split into fragments - a single-column layout extracts better", q.WordRatio*100),
+		})
+	}
+	return q
+}
+
+// isWordToken reports whether t is mostly letters, allowing surrounding
+// punctuation such as "Go," or "(AWS)".
+func isWordToken(t string) bool {
+	letters, total := 0, 0
+	for _, r := range t {
+		total++
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters >= 2 && letters*2 >= total
+}
+
+// UploadQualityError rejects an upload whose extracted text is unusable.
+// It wraps domain.ErrInvalidArgument.
+type UploadQualityError struct {
+	// Field is the upload type, "cv" or "project".
+	Field  string
+	Issues []UploadQualityIssue
+}
+
+func (e *UploadQualityError) Error() string {
+	msgs := make([]string, 0, len(e.Issues))
+	for _, i := range e.Issues {
+		if i.Reject {
+			msgs = append(msgs, i.Message)
+		}
+	}
+	return fmt.Sprintf("%v: %s: %s", domain.ErrInvalidArgument, e.Field, strings.Join(msgs, "; "))
+}
+
+func (e *UploadQualityError) Unwrap() error { return domain.ErrInvalidArgument }
+
+// UploadWarning is a non-blocking quality issue of a stored upload.
+type UploadWarning struct {
+	Field string `json:"field"`
+	UploadQualityIssue
+}
+
+type uploadWarningsKey struct{}
+
+// WithUploadWarnings returns a context in which Ingest and IngestOne report
+// the quality warnings of accepted documents into the returned slice.
+func WithUploadWarnings(ctx context.Context) (context.Context, *[]UploadWarning) {
+	w := &[]UploadWarning{}
+	return context.WithValue(ctx, uploadWarningsKey{}, w), w
+}
+
+// checkUploadQuality rejects text that fails the gate and reports warnings
+// into ctx. It does nothing when the gate is off.
+func (s UploadService) checkUploadQuality(ctx context.Context, field, text string) error {
+	if !s.QualityGate {
+		return nil
+	}
+	q := AssessUploadQuality(text, s.MinChars)
+	if q.Rejected() {
+		return &UploadQualityError{Field: field, Issues: q.Issues}
+	}
+	if dst, ok := ctx.Value(uploadWarningsKey{}).(*[]UploadWarning); ok {
+		for _, i := range q.Issues {
+			*dst = append(*dst, UploadWarning{Field: field, UploadQualityIssue: i})
+		}
+	}
+	return nil
+}
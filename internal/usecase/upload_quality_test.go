@@ -0,0 +1,75 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+const goodCV = "Jane Doe is a backend engineer with six years of experience building Go services, " +
+	"PostgreSQL schemas and Kafka pipelines. She led the migration of a payments platform to Kubernetes " +
+	"and mentored four engineers. Skills: Go, Python, AWS, Terraform, observability."
+
+func issueCodes(q usecase.UploadQuality) []string {
+	codes := make([]string, 0, len(q.Issues))
+	for _, i := range q.Issues {
+		codes = append(codes, i.Code)
+	}
+	return codes
+}
+
+func TestAssessUploadQuality(t *testing.T) {
+	q := usecase.AssessUploadQuality(goodCV, 100)
+	assert.Empty(t, q.Issues)
+	assert.Greater(t, q.Entropy, 3.5)
+	assert.Greater(t, q.WordRatio, 0.9)
+
+	q = usecase.AssessUploadQuality("Jane Doe", 100)
+	assert.Equal(t, []string{usecase.QualityTooShort}, issueCodes(q))
+	assert.True(t, q.Rejected())
+	assert.Contains(t, q.Issues[0].Message, "scanned images")
+
+	q = usecase.AssessUploadQuality(strings.Repeat("�� a ", 60)+goodCV, 100)
+	assert.Contains(t, issueCodes(q), usecase.QualityGarbled)
+	assert.True(t, q.Rejected())
+
+	q = usecase.AssessUploadQuality(strings.Repeat("-=-=-=-= ", 30), 100)
+	assert.Contains(t, issueCodes(q), usecase.QualityRepetitive)
+
+	table := strings.Repeat("| 2021 | 12.5% | 3,400 | Q1 |\n", 10)
+	q = usecase.AssessUploadQuality(table, 100)
+	assert.Equal(t, []string{usecase.QualityMostlyTables, usecase.QualityFewWords}, issueCodes(q))
+	assert.False(t, q.Rejected(), "tables only warn")
+
+	assert.Empty(t, usecase.AssessUploadQuality("short", 0).Issues, "no minimum")
+}
+
+func TestUpload_Ingest_QualityGate(t *testing.T) {
+	repo := mocks.NewMockUploadRepository(t)
+	svc := usecase.NewUploadService(repo)
+	svc.QualityGate, svc.MinChars = true, 100
+
+	_, _, err := svc.Ingest(context.Background(), goodCV, "tiny", "cv.pdf", "pr.pdf")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+	var qerr *usecase.UploadQualityError
+	require.True(t, errors.As(err, &qerr))
+	assert.Equal(t, domain.UploadTypeProject, qerr.Field)
+	assert.Contains(t, err.Error(), "project: only 4 characters")
+
+	repo.EXPECT().Create(mock.Anything, mock.Anything).Return("id", nil).Twice()
+	ctx, warnings := usecase.WithUploadWarnings(context.Background())
+	_, _, err = svc.Ingest(ctx, goodCV, goodCV+"\n"+strings.Repeat("| 2021 | 12.5% | 3,400 | Q1 |\n", 20), "cv.pdf", "pr.pdf")
+	require.NoError(t, err)
+	require.NotEmpty(t, *warnings)
+	assert.Equal(t, domain.UploadTypeProject, (*warnings)[0].Field)
+	assert.Equal(t, usecase.QualityMostlyTables, (*warnings)[0].Code)
+}
@@ -90,3 +90,41 @@ func TestUpload_Ingest_WithDifferentFileTypes(t *testing.T) {
 	assert.NotEmpty(t, cvID)
 	assert.NotEmpty(t, prID)
 }
+
+func TestUpload_IngestOne(t *testing.T) {
+	t.Parallel()
+	repo := mocks.NewMockUploadRepository(t)
+	svc := usecase.NewUploadService(repo)
+	repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(u domain.Upload) bool {
+		return u.Type == domain.UploadTypeProject && u.Text == "report" && u.MIME == "application/pdf"
+	})).Return("pr-1", nil).Once()
+
+	id, err := svc.IngestOne(context.Background(), domain.UploadTypeProject, "  report\n", "report.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, "pr-1", id)
+
+	_, err = svc.IngestOne(context.Background(), domain.UploadTypeCV, "   ", "cv.txt")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+	_, err = svc.IngestOne(context.Background(), "resume", "text", "cv.txt")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
+
+func TestUpload_IngestText(t *testing.T) {
+	t.Parallel()
+	repo := mocks.NewMockUploadRepository(t)
+	svc := usecase.NewUploadService(repo)
+	repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(u domain.Upload) bool {
+		return u.Type == domain.UploadTypeCV && u.Text == "cv" && u.Source == domain.UploadSourceText && u.MIME == "text/plain"
+	})).Return("cv-1", nil).Once()
+	repo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(u domain.Upload) bool {
+		return u.Type == domain.UploadTypeProject && u.Text == "report" && u.Source == domain.UploadSourceText
+	})).Return("pr-1", nil).Once()
+
+	cvID, prID, err := svc.IngestText(context.Background(), " cv\n", "report")
+	require.NoError(t, err)
+	assert.Equal(t, "cv-1", cvID)
+	assert.Equal(t, "pr-1", prID)
+
+	_, _, err = svc.IngestText(context.Background(), "cv", "  ")
+	require.ErrorIs(t, err, domain.ErrInvalidArgument)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"go.opentelemetry.io/otel"
+)
+
+// UsageStatsService compiles the anonymous usage stats of the deployment.
+type UsageStatsService struct {
+	Repo domain.UsageStatsRepository
+	// Window is how far back the stats look.
+	Window time.Duration
+	// DeploymentID labels the deployment in the stats.
+	DeploymentID string
+	now          func() time.Time
+}
+
+// NewUsageStatsService constructs a UsageStatsService. A non-positive window
+// defaults to 7 days.
+func NewUsageStatsService(repo domain.UsageStatsRepository, window time.Duration, deploymentID string) UsageStatsService {
+	if window <= 0 {
+		window = 7 * 24 * time.Hour
+	}
+	return UsageStatsService{Repo: repo, Window: window, DeploymentID: deploymentID, now: time.Now}
+}
+
+// Compile aggregates the stats of the window ending now.
+func (s UsageStatsService) Compile(ctx domain.Context) (domain.UsageStats, error) {
+	tr := otel.Tracer("usecase.usage_stats")
+	ctx, span := tr.Start(ctx, "UsageStatsService.Compile")
+	defer span.End()
+
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	end := now().UTC()
+	st, err := s.Repo.UsageStats(ctx, end.Add(-s.Window), end)
+	if err != nil {
+		return domain.UsageStats{}, fmt.Errorf("op=usage_stats.compile: %w", err)
+	}
+	st.DeploymentID = s.DeploymentID
+	st.JobsPerDay = round2(float64(st.Jobs) / s.Window.Hours() * 24)
+	if processed := st.Completed + st.Failed; processed > 0 {
+		st.FailureRatio = round2(float64(st.Failed) / float64(processed))
+	}
+	st.MeanLatencySeconds = round2(st.MeanLatencySeconds)
+	if st.ModelMix == nil {
+		st.ModelMix = map[string]int64{}
+	}
+	return st, nil
+}
+
+func round2(v float64) float64 { return math.Round(v*100) / 100 }
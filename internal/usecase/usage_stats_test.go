@@ -0,0 +1,38 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/domain/mocks"
+	"github.com/fairyhunter13/ai-cv-evaluator/internal/usecase"
+)
+
+func TestUsageStatsService_Compile(t *testing.T) {
+	repo := mocks.NewMockUsageStatsRepository(t)
+	svc := usecase.NewUsageStatsService(repo, 48*time.Hour, "eu-1")
+
+	repo.EXPECT().UsageStats(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ domain.Context, from, to time.Time) (domain.UsageStats, error) {
+			assert.Equal(t, 48*time.Hour, to.Sub(from))
+			return domain.UsageStats{WindowStart: from, WindowEnd: to, Jobs: 25, Completed: 20, Failed: 3, MeanLatencySeconds: 41.256}, nil
+		}).Once()
+
+	st, err := svc.Compile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "eu-1", st.DeploymentID)
+	assert.Equal(t, 12.5, st.JobsPerDay)
+	assert.Equal(t, 0.13, st.FailureRatio)
+	assert.Equal(t, 41.26, st.MeanLatencySeconds)
+	assert.Equal(t, map[string]int64{}, st.ModelMix)
+
+	repo.EXPECT().UsageStats(mock.Anything, mock.Anything, mock.Anything).Return(domain.UsageStats{}, assert.AnError).Once()
+	_, err = svc.Compile(context.Background())
+	assert.ErrorContains(t, err, "op=usage_stats.compile")
+}
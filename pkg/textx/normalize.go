@@ -0,0 +1,89 @@
+package textx
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// boilerplateMinRepeats is how often a short line must recur before it is
+// treated as a running header, footer or watermark.
+const boilerplateMinRepeats = 3
+
+// boilerplateMaxWords bounds the lines considered boilerplate; longer
+// repeated lines are more likely real content.
+const boilerplateMaxWords = 12
+
+var (
+	// pageNumberRe matches lines that only carry a page number: "3",
+	// "- 3 -", "Page 3", "Page 3 of 7", "3/7". Years such as "2019" are kept.
+	pageNumberRe = regexp.MustCompile(`(?i)^(?:-\s*\d{1,3}\s*-|(?:page|hal(?:aman)?\.?)?\s*\d{1,3}(?:\s*(?:of|/|dari)\s*\d{1,3})?)$`)
+	digitsRe     = regexp.MustCompile(`\d+`)
+)
+
+// Normalize cleans extracted document text before it is stored and
+// prompted: it collapses runs of spaces and tabs, drops lines that only
+// hold a page number, removes short lines repeated on every page (running
+// headers, footers, watermarks) and squeezes blank lines. Lines that look
+// like list items or headings ending in ':' are kept even when repeated.
+func Normalize(s string) string {
+	s = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\f", "\n", "\u00a0", " ").Replace(s)
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.Join(strings.Fields(l), " ")
+	}
+
+	counts := map[string]int{}
+	for _, l := range lines {
+		if isBoilerplateCandidate(l) {
+			counts[boilerplateKey(l)]++
+		}
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	sep := ""
+	for _, l := range lines {
+		switch {
+		case l == "":
+			if b.Len() > 0 {
+				sep = "\n\n"
+			}
+			continue
+		case pageNumberRe.MatchString(l):
+			continue
+		case isBoilerplateCandidate(l) && counts[boilerplateKey(l)] >= boilerplateMinRepeats:
+			continue
+		}
+		if b.Len() > 0 {
+			if sep == "" {
+				sep = "\n"
+			}
+			b.WriteString(sep)
+		}
+		b.WriteString(l)
+		sep = ""
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// isBoilerplateCandidate reports whether a repeated l may be dropped.
+func isBoilerplateCandidate(l string) bool {
+	words := len(strings.Fields(l))
+	if l == "" || strings.HasSuffix(l, ":") || words > boilerplateMaxWords {
+		return false
+	}
+	// A repeated single word is usually a section name or skill unless it
+	// is shouted like "CONFIDENTIAL" or "DRAFT".
+	if words == 1 && strings.ToUpper(l) != l {
+		return false
+	}
+	r := []rune(l)[0]
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// boilerplateKey groups lines that differ only in case and numbers, such as
+// "Jane Doe - CV - Page 2" and "Jane Doe - CV - Page 3".
+func boilerplateKey(l string) string {
+	return digitsRe.ReplaceAllString(strings.ToLower(l), "#")
+}
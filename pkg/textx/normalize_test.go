@@ -0,0 +1,28 @@
+package textx
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	cases := []struct{ name, in, want string }{
+		{"whitespace", "  Jane\t\tDoe  \r\n\r\n\r\n\nBackend   engineer  ", "Jane Doe\n\nBackend engineer"},
+		{"page numbers", "Intro\n1\nPage 2 of 3\n- 3 -\n4/7\nHalaman 5\nGo 1.22\n2019", "Intro\nGo 1.22\n2019"},
+		{
+			"running header and watermark",
+			"Jane Doe - CV - Page 1\nCONFIDENTIAL\nExperience\f" +
+				"Jane Doe - CV - Page 2\nCONFIDENTIAL\nBuilt APIs\f" +
+				"Jane Doe - CV - Page 3\nCONFIDENTIAL\nEducation",
+			"Experience\nBuilt APIs\nEducation",
+		},
+		{
+			"repeated content kept",
+			"Responsibilities\n- Led team\nResponsibilities\n- Wrote Go\nResponsibilities\nKey results:\nKey results:\nKey results:",
+			"Responsibilities\n- Led team\nResponsibilities\n- Wrote Go\nResponsibilities\nKey results:\nKey results:\nKey results:",
+		},
+		{"twice is not boilerplate", "Jane Doe CV\nA\nJane Doe CV\nB", "Jane Doe CV\nA\nJane Doe CV\nB"},
+	}
+	for _, c := range cases {
+		if got := Normalize(c.in); got != c.want {
+			t.Errorf("%s: Normalize(%q) = %q, want %q", c.name, c.in, got, c.want)
+		}
+	}
+}
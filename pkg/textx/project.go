@@ -0,0 +1,126 @@
+package textx
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ProjectReadmeHeading starts the section of an assembled project that holds
+// its README or report in full.
+const ProjectReadmeHeading = "### Project README/report"
+
+// projectSourceHeading starts the section of summarized source files.
+const projectSourceHeading = "### Source files (summarized)"
+
+// Bounds of a summarized source file.
+const (
+	sourceSummaryLeadLines = 10
+	sourceSummaryMaxLines  = 40
+	sourceSummaryMaxChars  = 2000
+)
+
+var (
+	// readmeNameRe matches file names of project documentation: README.md,
+	// report.pdf, final-report.docx, WRITEUP.txt, documentation.pdf.
+	readmeNameRe = regexp.MustCompile(`(?i)^(?:readme|.*report|write-?up|documentation|docs|solution)$`)
+	// readmeSectionRe matches headings typical of a README.
+	readmeSectionRe = regexp.MustCompile(`(?im)^\W*(?:installation|setup|getting started|usage|how to run|architecture|overview|design decisions|trade-?offs|api|testing|configuration)\W*$`)
+	// declarationRe matches source lines worth keeping in a summary.
+	declarationRe = regexp.MustCompile(`^(?:package|import|from|func|type|class|def|async def|interface|struct|enum|export|public|private|protected|module|fn|impl|const|CREATE|@)\b`)
+)
+
+// ProjectDocument is one extracted file of a project submission.
+type ProjectDocument struct {
+	Name string
+	Text string
+}
+
+// IsProjectReadme reports whether doc is the submission's README or report:
+// by file name, or by content that has at least two README-style headings.
+func IsProjectReadme(doc ProjectDocument) bool {
+	base := path.Base(strings.ReplaceAll(doc.Name, "\\", "/"))
+	base = strings.TrimSuffix(base, path.Ext(base))
+	if readmeNameRe.MatchString(base) {
+		return true
+	}
+	return len(readmeSectionRe.FindAllString(doc.Text, 3)) >= 2
+}
+
+// AssembleProject joins the documents of a multi-file project submission
+// into one text. READMEs and reports are placed first and kept in full,
+// since the rubric scores documentation from them; the other files are
+// reduced to their leading lines and declarations. Without a README every
+// document is kept in full. A single document is returned unchanged.
+func AssembleProject(docs []ProjectDocument) string {
+	if len(docs) == 1 {
+		return docs[0].Text
+	}
+	var readmes, sources []ProjectDocument
+	for _, d := range docs {
+		if IsProjectReadme(d) {
+			readmes = append(readmes, d)
+		} else {
+			sources = append(sources, d)
+		}
+	}
+	var b strings.Builder
+	if len(readmes) == 0 {
+		for i, d := range docs {
+			if i > 0 {
+				b.WriteString("\n\n")
+			}
+			fmt.Fprintf(&b, "#### %s\n\n%s", d.Name, d.Text)
+		}
+		return b.String()
+	}
+	b.WriteString(ProjectReadmeHeading + " (included in full)\n")
+	for _, d := range readmes {
+		fmt.Fprintf(&b, "\n#### %s\n\n%s\n", d.Name, d.Text)
+	}
+	if len(sources) > 0 {
+		b.WriteString("\n" + projectSourceHeading + "\n")
+		for _, d := range sources {
+			summary, kept, total := summarizeSource(d.Text)
+			if kept < total {
+				fmt.Fprintf(&b, "\n#### %s (%d of %d lines)\n\n%s\n", d.Name, kept, total, summary)
+			} else {
+				fmt.Fprintf(&b, "\n#### %s\n\n%s\n", d.Name, summary)
+			}
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// HasProjectReadme reports whether text was assembled with a README section.
+func HasProjectReadme(text string) bool {
+	return strings.HasPrefix(text, ProjectReadmeHeading)
+}
+
+// summarizeSource keeps the leading lines of a source file and its
+// declarations, bounded in lines and characters. It returns the summary and
+// the number of kept and total non-empty lines.
+func summarizeSource(text string) (string, int, int) {
+	var kept []string
+	size, total := 0, 0
+	for _, l := range strings.Split(text, "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+		total++
+		// Extracted PDFs may arrive as one long line; keep its start.
+		if len(kept) == 0 && len(l) > sourceSummaryMaxChars {
+			l = strings.ToValidUTF8(l[:sourceSummaryMaxChars], "") + " ..."
+		}
+		if len(kept) >= sourceSummaryMaxLines || size+len(l) > sourceSummaryMaxChars {
+			continue
+		}
+		if total <= sourceSummaryLeadLines || declarationRe.MatchString(l) {
+			kept = append(kept, l)
+			size += len(l) + 1
+		}
+	}
+	return strings.Join(kept, "\n"), len(kept), total
+}
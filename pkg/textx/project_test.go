@@ -0,0 +1,66 @@
+package textx
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestIsProjectReadme(t *testing.T) {
+	cases := []struct {
+		doc  ProjectDocument
+		want bool
+	}{
+		{ProjectDocument{Name: "README.md"}, true},
+		{ProjectDocument{Name: "docs/Final-Report.pdf"}, true},
+		{ProjectDocument{Name: "writeup.docx"}, true},
+		{ProjectDocument{Name: "main.txt", Text: "package main\nfunc main() {}"}, false},
+		{ProjectDocument{Name: "notes.txt", Text: "My service\n## Installation\nrun make\n## Architecture\nworkers"}, true},
+		{ProjectDocument{Name: "reporter.txt", Text: "type Reporter struct{}"}, false},
+	}
+	for _, c := range cases {
+		if got := IsProjectReadme(c.doc); got != c.want {
+			t.Errorf("IsProjectReadme(%q) = %v, want %v", c.doc.Name, got, c.want)
+		}
+	}
+}
+
+func TestAssembleProject(t *testing.T) {
+	t.Run("single document unchanged", func(t *testing.T) {
+		if got := AssembleProject([]ProjectDocument{{Name: "a.pdf", Text: "report"}}); got != "report" {
+			t.Errorf("got %q", got)
+		}
+	})
+
+	t.Run("readme first and sources summarized", func(t *testing.T) {
+		var src strings.Builder
+		src.WriteString("package worker\n")
+		for i := 0; i < 100; i++ {
+			fmt.Fprintf(&src, "\tx := %d\n", i)
+		}
+		src.WriteString("func Handle() error {\n")
+		got := AssembleProject([]ProjectDocument{
+			{Name: "worker.txt", Text: src.String()},
+			{Name: "README.md", Text: "How to run: make up"},
+		})
+		if !HasProjectReadme(got) {
+			t.Fatalf("missing readme heading: %q", got)
+		}
+		if strings.Index(got, "How to run") > strings.Index(got, "worker.txt") {
+			t.Errorf("readme not placed first: %q", got)
+		}
+		if !strings.Contains(got, "#### worker.txt (11 of 102 lines)") || !strings.Contains(got, "func Handle() error {") {
+			t.Errorf("source not summarized: %q", got)
+		}
+		if strings.Contains(got, "x := 50") {
+			t.Errorf("summary kept body lines: %q", got)
+		}
+	})
+
+	t.Run("without readme everything kept", func(t *testing.T) {
+		got := AssembleProject([]ProjectDocument{{Name: "a.txt", Text: "alpha"}, {Name: "b.txt", Text: "beta"}})
+		if got != "#### a.txt\n\nalpha\n\n#### b.txt\n\nbeta" || HasProjectReadme(got) {
+			t.Errorf("got %q", got)
+		}
+	})
+}